@@ -11,8 +11,10 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"maps"
 	"strconv"
 	"strings"
+	"unicode"
 )
 
 // Flags used by Benchmark.Measured to indicate
@@ -34,6 +36,23 @@ type Benchmark struct {
 	MBPerS            float64 // MB processed per second
 	Measured          int     // which measurements were recorded
 	Ord               int     // ordinal position within a benchmark run
+
+	// Metrics holds the measurements in other units, such as
+	// those reported by testing.B.ReportMetric, in order.
+	Metrics []Metric
+
+	// Config holds the configuration in effect for the benchmark,
+	// from the configuration lines (such as "goos: linux") that
+	// precede it in the output, or nil if there are none.
+	// Benchmarks with the same configuration may share the map.
+	Config map[string]string
+}
+
+// A Metric is a measurement of a benchmark in a unit
+// other than those recorded by the fields of Benchmark.
+type Metric struct {
+	Value float64
+	Unit  string // such as "ns/op", "B/op", or "hits/op"
 }
 
 // ParseLine extracts a Benchmark from a single line of testing.B
@@ -83,6 +102,10 @@ func (b *Benchmark) parseMeasurement(quant string, unit string) {
 			b.AllocsPerOp = i
 			b.Measured |= AllocsPerOp
 		}
+	default:
+		if f, err := strconv.ParseFloat(quant, 64); err == nil {
+			b.Metrics = append(b.Metrics, Metric{f, unit})
+		}
 	}
 }
 
@@ -101,9 +124,60 @@ func (b *Benchmark) String() string {
 	if (b.Measured & AllocsPerOp) != 0 {
 		fmt.Fprintf(buf, " %d allocs/op", b.AllocsPerOp)
 	}
+	for _, m := range b.Metrics {
+		fmt.Fprintf(buf, " %g %s", m.Value, m.Unit)
+	}
 	return buf.String()
 }
 
+// A Name is the structured form of the name of a benchmark, such as
+// "BenchmarkDecode/size=1K/fast-8", which consists of the name of
+// a top-level benchmark, the names of the nested sub-benchmarks run
+// by testing.B.Run, and the value of GOMAXPROCS.
+type Name struct {
+	Base  string   // name of the top-level benchmark ("BenchmarkDecode")
+	Sub   []string // names of the sub-benchmarks (["size=1K", "fast"])
+	Procs int      // value of GOMAXPROCS, or 0 if absent
+}
+
+// ParseName returns the structured form of a benchmark name.
+func ParseName(name string) Name {
+	var n Name
+	if i := strings.LastIndexByte(name, '-'); i >= 0 && !strings.Contains(name[i:], "/") {
+		if procs, err := strconv.Atoi(name[i+1:]); err == nil && procs > 0 {
+			name, n.Procs = name[:i], procs
+		}
+	}
+	parts := strings.Split(name, "/")
+	n.Base = parts[0]
+	if len(parts) > 1 {
+		n.Sub = parts[1:]
+	}
+	return n
+}
+
+// Params returns the parameters of a benchmark expressed by the
+// names of its sub-benchmarks of the form "key=value", such as
+// {"size": "1K"} for "BenchmarkDecode/size=1K/fast-8", or nil if
+// there are none.
+func (n Name) Params() map[string]string {
+	var params map[string]string
+	for _, sub := range n.Sub {
+		if k, v, ok := strings.Cut(sub, "="); ok && k != "" {
+			if params == nil {
+				params = make(map[string]string)
+			}
+			params[k] = v
+		}
+	}
+	return params
+}
+
+// ParsedName returns the structured form of the benchmark's name.
+func (b *Benchmark) ParsedName() Name {
+	return ParseName(b.Name)
+}
+
 // Set is a collection of benchmarks from one
 // testing.B run, keyed by name to facilitate comparison.
 type Set map[string][]*Benchmark
@@ -112,14 +186,71 @@ type Set map[string][]*Benchmark
 // ParseSet preserves the order of benchmarks that have identical
 // names.
 func ParseSet(r io.Reader) (Set, error) {
-	bb := make(Set)
+	report, err := ParseReport(r)
+	if err != nil {
+		return nil, err
+	}
+	return report.Set(), nil
+}
+
+// A Report is the structured form of the output of one or more
+// testing.B runs.
+type Report struct {
+	Benchmarks []*Benchmark // in order of appearance
+
+	// Units holds the metadata of each unit, from lines such as
+	// "Unit MB/s better=higher", keyed by unit and then by key.
+	Units map[string]map[string]string
+}
+
+// ParseReport extracts a Report from testing.B output.
+// In addition to benchmark result lines, it interprets the
+// configuration lines (such as "goos: linux" or "pkg: net/http") and
+// unit metadata lines of the Go benchmark data format described at
+// https://go.dev/design/14313-benchmark-format. Other lines are
+// ignored.
+func ParseReport(r io.Reader) (*Report, error) {
+	report := new(Report)
+	var config map[string]string
 	scan := bufio.NewScanner(r)
-	ord := 0
 	for scan.Scan() {
-		if b, err := ParseLine(scan.Text()); err == nil {
-			b.Ord = ord
-			ord++
-			bb[b.Name] = append(bb[b.Name], b)
+		line := scan.Text()
+		if key, value, ok := parseConfigLine(line); ok {
+			// Copy the configuration, as it may be
+			// shared by earlier benchmarks.
+			config = maps.Clone(config)
+			if value == "" {
+				delete(config, key)
+			} else {
+				if config == nil {
+					config = make(map[string]string)
+				}
+				config[key] = value
+			}
+			if len(config) == 0 {
+				config = nil
+			}
+			continue
+		}
+		if fields := strings.Fields(line); len(fields) >= 2 && fields[0] == "Unit" {
+			unit := fields[1]
+			for _, field := range fields[2:] {
+				if k, v, ok := strings.Cut(field, "="); ok && k != "" {
+					if report.Units == nil {
+						report.Units = make(map[string]map[string]string)
+					}
+					if report.Units[unit] == nil {
+						report.Units[unit] = make(map[string]string)
+					}
+					report.Units[unit][k] = v
+				}
+			}
+			continue
+		}
+		if b, err := ParseLine(line); err == nil {
+			b.Ord = len(report.Benchmarks)
+			b.Config = config
+			report.Benchmarks = append(report.Benchmarks, b)
 		}
 	}
 
@@ -127,5 +258,33 @@ func ParseSet(r io.Reader) (Set, error) {
 		return nil, err
 	}
 
-	return bb, nil
+	return report, nil
+}
+
+// Set returns the benchmarks of the report, keyed by name.
+func (r *Report) Set() Set {
+	bb := make(Set)
+	for _, b := range r.Benchmarks {
+		bb[b.Name] = append(bb[b.Name], b)
+	}
+	return bb
+}
+
+// parseConfigLine parses a configuration line of the form
+// "key: value", in which the key begins with a lower case letter
+// and contains no space or upper case letters.
+func parseConfigLine(line string) (key, value string, ok bool) {
+	key, value, ok = strings.Cut(line, ":")
+	if !ok || key == "" {
+		return "", "", false
+	}
+	for i, r := range key {
+		if i == 0 && !unicode.IsLower(r) || unicode.IsSpace(r) || unicode.IsUpper(r) {
+			return "", "", false
+		}
+	}
+	if value != "" && value[0] != ' ' && value[0] != '\t' {
+		return "", "", false
+	}
+	return key, strings.TrimSpace(value), true
 }
@@ -66,10 +66,11 @@ func TestParseLine(t *testing.T) {
 			err:  true,
 		},
 		{
-			line: "BenchmarkBridge	100000000	        19.6 smoots", // unknown unit
+			line: "BenchmarkBridge	100000000	        19.6 smoots", // custom unit
 			want: &Benchmark{
-				Name: "BenchmarkBridge",
-				N:    100000000,
+				Name:    "BenchmarkBridge",
+				N:       100000000,
+				Metrics: []Metric{{19.6, "smoots"}},
 			},
 		},
 		{
@@ -195,6 +196,16 @@ func TestString(t *testing.T) {
 			},
 			wanted: "BenchmarkTest 100000000 5 allocs/op",
 		},
+		{
+			name: "metricsTest",
+			input: &Benchmark{
+				Name: "BenchmarkTest",
+				N:    100000000, NsPerOp: 19.6,
+				Measured: NsPerOp,
+				Metrics:  []Metric{{0.5, "hits/op"}, {1200, "p99-ns"}},
+			},
+			wanted: "BenchmarkTest 100000000 19.60 ns/op 0.5 hits/op 1200 p99-ns",
+		},
 	}
 
 	for _, tt := range tests {
@@ -206,3 +217,84 @@ func TestString(t *testing.T) {
 		})
 	}
 }
+
+func TestParseReport(t *testing.T) {
+	in := `goos: linux
+goarch: amd64
+pkg: example.com/codec
+Unit MB/s better=higher
+BenchmarkDecode/size=1K-8	1000	1500 ns/op	683.27 MB/s	0.25 misses/op
+    codec_test.go:12: some log output
+pkg: example.com/other
+BenchmarkOther-8	2000	750 ns/op
+goarch:
+BenchmarkOther-8	2000	740 ns/op
+PASS
+ok  	example.com/other	1.234s
+`
+	report, err := ParseReport(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &Report{
+		Benchmarks: []*Benchmark{
+			{
+				Name: "BenchmarkDecode/size=1K-8",
+				N:    1000, NsPerOp: 1500, MBPerS: 683.27,
+				Measured: NsPerOp | MBPerS,
+				Metrics:  []Metric{{0.25, "misses/op"}},
+				Config:   map[string]string{"goos": "linux", "goarch": "amd64", "pkg": "example.com/codec"},
+			},
+			{
+				Name: "BenchmarkOther-8",
+				N:    2000, NsPerOp: 750,
+				Measured: NsPerOp,
+				Ord:      1,
+				Config:   map[string]string{"goos": "linux", "goarch": "amd64", "pkg": "example.com/other"},
+			},
+			{
+				Name: "BenchmarkOther-8",
+				N:    2000, NsPerOp: 740,
+				Measured: NsPerOp,
+				Ord:      2,
+				Config:   map[string]string{"goos": "linux", "pkg": "example.com/other"},
+			},
+		},
+		Units: map[string]map[string]string{"MB/s": {"better": "higher"}},
+	}
+	if !reflect.DeepEqual(report, want) {
+		t.Errorf("ParseReport:\nhave %v %v\nwant %v %v", report.Benchmarks, report.Units, want.Benchmarks, want.Units)
+	}
+	if set := report.Set(); len(set["BenchmarkOther-8"]) != 2 {
+		t.Errorf("Set() = %v, want two BenchmarkOther-8", set)
+	}
+}
+
+func TestParseName(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		want   Name
+		params map[string]string
+	}{
+		{"BenchmarkEncrypt", Name{Base: "BenchmarkEncrypt"}, nil},
+		{"BenchmarkEncrypt-8", Name{Base: "BenchmarkEncrypt", Procs: 8}, nil},
+		{
+			"BenchmarkDecode/size=1K/fast-16",
+			Name{Base: "BenchmarkDecode", Sub: []string{"size=1K", "fast"}, Procs: 16},
+			map[string]string{"size": "1K"},
+		},
+		{
+			"BenchmarkDecode/gzip-9/x",
+			Name{Base: "BenchmarkDecode", Sub: []string{"gzip-9", "x"}},
+			nil,
+		},
+	} {
+		got := ParseName(tt.name)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseName(%q) = %+v, want %+v", tt.name, got, tt.want)
+		}
+		if params := got.Params(); !reflect.DeepEqual(params, tt.params) {
+			t.Errorf("ParseName(%q).Params() = %v, want %v", tt.name, params, tt.params)
+		}
+	}
+}
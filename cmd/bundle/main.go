@@ -16,8 +16,11 @@
 // file compilation, whether with build tags or system-specific file names
 // like code_amd64.go; must not depend on any special comments, which
 // may not be preserved; must not use any assembly sources;
-// must not use renaming imports; and must not use reflection-based APIs
-// that depend on the specific names of types or struct fields.
+// must not use renaming imports; must not use reflection-based APIs
+// that depend on the specific names of types or struct fields;
+// and must not embed files except in string and []byte variables,
+// each of one file, whose //go:embed directives are replaced by the
+// contents of the file.
 //
 // By default, bundle writes the bundled code to standard output.
 // If the -o argument is given, bundle writes to the named file
@@ -81,6 +84,7 @@ import (
 	"go/types"
 	"log"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"unicode"
@@ -264,6 +268,15 @@ func bundle(src, dst, dstpkg, prefix, buildTags string) ([]byte, error) {
 	// - detect shadowing issues, and either return error or resolve them
 	// - preserve comments from the original import declarations.
 
+	// Replace each embedded file by its contents.
+	// The placeholders are replaced in postprocessing.
+	embeds := make(map[string]string)
+	for _, f := range pkg.Syntax {
+		if err := inlineEmbeds(pkg, f, embeds); err != nil {
+			return nil, err
+		}
+	}
+
 	// pkgStd and pkgExt are sets of printed import specs. This is done
 	// to deduplicate instances of the same import name and path.
 	var pkgStd = make(map[string]bool)
@@ -277,6 +290,9 @@ func bundle(src, dst, dstpkg, prefix, buildTags string) ([]byte, error) {
 			if path == dst {
 				continue
 			}
+			if path == "embed" && imp.Name != nil && imp.Name.Name == "_" {
+				continue // the //go:embed directives have been inlined
+			}
 			if newPath, ok := importMap[path]; ok {
 				path = newPath
 			}
@@ -307,6 +323,18 @@ func bundle(src, dst, dstpkg, prefix, buildTags string) ([]byte, error) {
 	}
 	fmt.Fprint(&out, ")\n\n")
 
+	// Record the source range of each declaration before renaming,
+	// which moves the End of a declaration that ends with a renamed
+	// identifier, such as an alias declaration, into the source that
+	// follows it.
+	ranges := make(map[ast.Decl][2]token.Pos)
+	for _, f := range pkg.Syntax {
+		for _, decl := range f.Decls {
+			beg, end := sourceRange(decl)
+			ranges[decl] = [2]token.Pos{beg, end}
+		}
+	}
+
 	// Modify and print each file.
 	for _, f := range pkg.Syntax {
 		// Update renamed identifiers.
@@ -316,7 +344,9 @@ func bundle(src, dst, dstpkg, prefix, buildTags string) ([]byte, error) {
 			}
 		}
 		for id, obj := range pkg.TypesInfo.Uses {
-			if objsToUpdate[obj] {
+			// A field selected from an instance of a generic
+			// type is distinct from the field of its declaration.
+			if obj := origin(obj); objsToUpdate[obj] {
 				id.Name = prefix + obj.Name()
 			}
 		}
@@ -356,15 +386,19 @@ func bundle(src, dst, dstpkg, prefix, buildTags string) ([]byte, error) {
 				continue
 			}
 
-			beg, end := sourceRange(decl)
+			beg, end := ranges[decl][0], ranges[decl][1]
 
 			printComments(&out, f.Comments, last, beg)
 
 			buf.Reset()
-			format.Node(&buf, pkg.Fset, &printer.CommentedNode{Node: decl, Comments: f.Comments})
+			format.Node(&buf, pkg.Fset, &printer.CommentedNode{Node: decl, Comments: commentsIn(f.Comments, beg, end)})
 			// Remove each "@@@." in the output.
 			// TODO(adonovan): not hygienic.
-			out.Write(bytes.Replace(buf.Bytes(), []byte("@@@."), nil, -1))
+			text := bytes.Replace(buf.Bytes(), []byte("@@@."), nil, -1)
+			for placeholder, value := range embeds {
+				text = bytes.Replace(text, []byte(placeholder), []byte(value), 1)
+			}
+			out.Write(text)
 
 			last = printSameLineComment(&out, f.Comments, pkg.Fset, end)
 
@@ -383,6 +417,129 @@ func bundle(src, dst, dstpkg, prefix, buildTags string) ([]byte, error) {
 	return result, nil
 }
 
+// inlineEmbeds replaces the //go:embed directive of each string or
+// []byte variable declared in f by an initializer holding the contents
+// of the embedded file, and the directive by a comment naming the file.
+// The initializer is a placeholder identifier, a key of embeds, whose
+// value is the Go expression that replaces it.
+func inlineEmbeds(pkg *packages.Package, f *ast.File, embeds map[string]string) error {
+	dir := filepath.Dir(pkg.Fset.File(f.Pos()).Name())
+	for _, decl := range f.Decls {
+		decl, ok := decl.(*ast.GenDecl)
+		if !ok || decl.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range decl.Specs {
+			spec := spec.(*ast.ValueSpec)
+			doc := spec.Doc
+			if doc == nil && !decl.Lparen.IsValid() {
+				doc = decl.Doc
+			}
+			if doc == nil {
+				continue
+			}
+			var (
+				directive *ast.Comment
+				patterns  []string
+			)
+			for _, c := range doc.List {
+				if args, ok := strings.CutPrefix(c.Text, "//go:embed"); ok && (args == "" || unicode.IsSpace(rune(args[0]))) {
+					p, err := splitEmbedPatterns(args)
+					if err != nil {
+						return fmt.Errorf("%s: %v", pkg.Fset.Position(c.Pos()), err)
+					}
+					directive = c
+					patterns = append(patterns, p...)
+				}
+			}
+			if directive == nil {
+				continue
+			}
+			posn := pkg.Fset.Position(directive.Pos())
+
+			if len(spec.Names) != 1 {
+				return fmt.Errorf("%s: //go:embed cannot apply to multiple variables", posn)
+			}
+			typ := pkg.TypesInfo.Defs[spec.Names[0]].Type()
+			isBytes := types.Identical(typ, types.NewSlice(types.Typ[types.Byte]))
+			if !isBytes && !types.Identical(typ, types.Typ[types.String]) {
+				return fmt.Errorf("%s: cannot bundle //go:embed variable of type %s; only string and []byte are supported", posn, typ)
+			}
+			if len(patterns) != 1 {
+				return fmt.Errorf("%s: //go:embed of a %s variable must have a single pattern", posn, typ)
+			}
+			files, err := filepath.Glob(filepath.Join(dir, filepath.FromSlash(patterns[0])))
+			if err != nil {
+				return fmt.Errorf("%s: invalid //go:embed pattern %s: %v", posn, patterns[0], err)
+			}
+			if len(files) != 1 {
+				return fmt.Errorf("%s: //go:embed pattern %s matches %d files, want 1", posn, patterns[0], len(files))
+			}
+			data, err := os.ReadFile(files[0])
+			if err != nil {
+				return err
+			}
+
+			// Replace the directive by an initializer:
+			//	// Embedded from file.
+			//	var x = "contents"
+			//	var y = []byte("contents")
+			value := strconv.Quote(string(data))
+			if isBytes {
+				value = "[]byte(" + value + ")"
+			}
+			// A literal in the syntax tree would have a bogus extent,
+			// confusing the placement of comments.
+			placeholder := fmt.Sprintf("@@@embed%d@@@", len(embeds))
+			embeds[placeholder] = value
+			spec.Type = nil
+			spec.Values = []ast.Expr{&ast.Ident{NamePos: spec.Names[0].End(), Name: placeholder}}
+			directive.Text = fmt.Sprintf("// Embedded from %s.", patterns[0])
+		}
+	}
+	return nil
+}
+
+// splitEmbedPatterns splits the arguments of a //go:embed directive
+// into patterns, each of which may be a Go string literal.
+func splitEmbedPatterns(args string) ([]string, error) {
+	var patterns []string
+	for {
+		args = strings.TrimLeftFunc(args, unicode.IsSpace)
+		if args == "" {
+			return patterns, nil
+		}
+		var pattern string
+		if args[0] == '"' || args[0] == '`' {
+			lit, err := strconv.QuotedPrefix(args)
+			if err != nil {
+				return nil, fmt.Errorf("invalid quoted string in //go:embed: %s", args)
+			}
+			pattern, _ = strconv.Unquote(lit)
+			args = args[len(lit):]
+		} else {
+			i := strings.IndexFunc(args, unicode.IsSpace)
+			if i < 0 {
+				i = len(args)
+			}
+			pattern, args = args[:i], args[i:]
+		}
+		patterns = append(patterns, pattern)
+	}
+}
+
+// origin returns the generic object of which obj, a field or method of
+// an instantiated type or an instantiated function, is an instance.
+func origin(obj types.Object) types.Object {
+	switch obj := obj.(type) {
+	case *types.Var:
+		return obj.Origin()
+	case *types.Func:
+		return obj.Origin()
+	}
+	return obj
+}
+
 // sourceRange returns the [beg, end) interval of source code
 // belonging to decl (incl. associated comments).
 func sourceRange(decl ast.Decl) (beg, end token.Pos) {
@@ -416,6 +573,17 @@ func sourceRange(decl ast.Decl) (beg, end token.Pos) {
 	return beg, end
 }
 
+// commentsIn returns the comments within [beg, end).
+func commentsIn(comments []*ast.CommentGroup, beg, end token.Pos) []*ast.CommentGroup {
+	var in []*ast.CommentGroup
+	for _, cg := range comments {
+		if beg <= cg.Pos() && cg.Pos() < end {
+			in = append(in, cg)
+		}
+	}
+	return in
+}
+
 func printComments(out *bytes.Buffer, comments []*ast.CommentGroup, pos, end token.Pos) {
 	for _, cg := range comments {
 		if pos <= cg.Pos() && cg.Pos() < end {
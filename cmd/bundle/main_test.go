@@ -28,9 +28,11 @@ func testBundle(t *testing.T, x packagestest.Exporter) {
 		{
 			Name: "initial",
 			Files: map[string]any{
-				"a.go": load("testdata/src/initial/a.go"),
-				"b.go": load("testdata/src/initial/b.go"),
-				"c.go": load("testdata/src/initial/c.go"),
+				"a.go":      load("testdata/src/initial/a.go"),
+				"b.go":      load("testdata/src/initial/b.go"),
+				"c.go":      load("testdata/src/initial/c.go"),
+				"d.go":      load("testdata/src/initial/d.go"),
+				"hello.txt": load("testdata/src/initial/hello.txt"),
 			},
 		},
 		{
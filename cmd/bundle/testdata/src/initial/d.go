@@ -0,0 +1,36 @@
+package initial
+
+import _ "embed"
+
+// List is generic.
+type List[T any] struct {
+	t
+	items []T
+}
+
+func (l *List[T]) Push(x T) { l.items = append(l.items, x) }
+
+// A is an alias.
+type A = S
+
+// Pair embeds an alias and an instance.
+type Pair[V any] struct {
+	A
+	List[V]
+}
+
+func pairs() int {
+	var p Pair[string]
+	p.Push("x")
+	return int(p.List.t) + p.A.u
+}
+
+// greeting is embedded.
+//
+//go:embed hello.txt
+var greeting string
+
+var (
+	//go:embed hello.txt
+	raw []byte // raw bytes
+)
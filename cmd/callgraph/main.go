@@ -9,13 +9,11 @@ package main // import "golang.org/x/tools/cmd/callgraph"
 // TODO(adonovan):
 //
 // Features:
-// - restrict graph to a single package
 // - output
 //   - functions reachable from root (use digraph tool?)
 //   - unreachable functions (use digraph tool?)
 //   - dynamic (runtime) types
 //   - indexed output (numbered nodes)
-//   - JSON output
 //   - additional template fields:
 //     callee file/line/col
 
@@ -26,7 +24,10 @@ import (
 	"go/token"
 	"io"
 	"os"
+	"regexp"
 	"runtime"
+	"sort"
+	"strings"
 	"text/template"
 
 	"golang.org/x/tools/go/callgraph"
@@ -47,18 +48,32 @@ var (
 	testFlag = flag.Bool("test", false,
 		"Loads test code (*_test.go) for imported packages")
 
-	formatFlag = flag.String("format",
-		"{{.Caller}}\t--{{.Dynamic}}-{{.Line}}:{{.Column}}-->\t{{.Callee}}",
+	formatFlag = flag.String("format", defaultFormat,
 		"A template expression specifying how to format an edge")
 
+	pkgsFlag = flag.String("pkgs", "",
+		"Comma-separated list of package patterns; display only the calls to or from their functions")
+
+	collapseFlag = flag.Bool("collapse", false,
+		"Collapse the functions of each package into a single node")
+
 	tagsFlag = flag.String("tags", "", "comma-separated list of extra build tags (see: go help buildconstraint)")
+
+	diffFlag = flag.Bool("diff", false,
+		"Compares two call graphs saved with -format=json or -format=binary")
+)
+
+const (
+	defaultFormat         = "{{.Caller}}\t--{{.Dynamic}}-{{.Line}}:{{.Column}}-->\t{{.Callee}}"
+	defaultCollapseFormat = "{{.Caller}}\t--{{.Calls}}-->\t{{.Callee}}"
 )
 
 const Usage = `callgraph: display the call graph of a Go program.
 
 Usage:
 
-  callgraph [-algo=static|cha|rta|vta] [-test] [-format=...] package...
+  callgraph [-algo=static|cha|rta|vta] [-test] [-pkgs=...] [-collapse] [-format=...] package...
+  callgraph -diff old new
 
 Flags:
 
@@ -82,6 +97,9 @@ Flags:
             digraph     output suitable for input to
                         golang.org/x/tools/cmd/digraph.
             graphviz    output in AT&T GraphViz (.dot) format.
+            json        the whole graph, in the JSON encoding of
+                        golang.org/x/tools/go/callgraph.EncodedGraph.
+            binary      the whole graph, in a compact binary encoding.
 
            All other values are interpreted using text/template syntax.
            The default value is:
@@ -120,16 +138,49 @@ Flags:
            Consult the documentation for go/token, text/template, and
            golang.org/x/tools/go/ssa for more detail.
 
+-pkgs      Restricts the graph to the calls to or from the functions of
+           the packages matching a comma-separated list of patterns.
+           A pattern is an import path, in which '...' matches any
+           string, as in 'go list'; so example.com/... matches
+           example.com and all the packages beneath it.
+
+-collapse  Collapses the functions of each package into a single node,
+           so that the graph shows the calls between packages. The json
+           and binary formats encode the graph with a node per package
+           and an edge per pair of calling and called packages, and
+           -diff compares such graphs. The structure passed to other
+           templates is:
+
+                   type PackageEdge struct {
+                           Caller string // path of calling package
+                           Callee string // path of called package
+                           Calls  int    // number of calls between their functions
+                   }
+
+           and the default template is:
+
+            {{.Caller}}\t--{{.Calls}}-->\t{{.Callee}}
+
+-diff      Compare two call graphs, each read from a file saved using
+           -format=json or -format=binary, and display the functions and
+           calls that were added (+) or removed (-), grouped by package.
+           Calls are grouped by the package of the caller.
+
 Examples:
 
   Show the call graph of the trivial web server application:
 
     callgraph -format digraph $GOROOT/src/net/http/triv.go
 
-  Same, but show only the packages of each function:
+  Same, but show only the calls between packages, as a graph for
+  display by Graphviz:
+
+    callgraph -collapse -format graphviz $GOROOT/src/net/http/triv.go |
+      dot -Tsvg -o triv.svg
 
-    callgraph -format '{{.Caller.Pkg.Pkg.Path}} -> {{.Callee.Pkg.Pkg.Path}}' \
-      $GOROOT/src/net/http/triv.go | sort | uniq
+  Show only the calls into or out of the packages of a module:
+
+    callgraph -pkgs=example.com/... -format digraph ./cmd/server
 
   Show functions that make dynamic calls into the 'fmt' test package,
   using the Rapid Type Analysis algorithm:
@@ -142,6 +193,13 @@ Examples:
 
     callgraph -format=digraph golang.org/x/tools/cmd/callgraph |
       digraph succs golang.org/x/tools/cmd/callgraph.main
+
+  Show how a dependency upgrade changes the call graph of a program:
+
+    callgraph -format=binary ./cmd/server > old.cg
+    go get example.com/dep@latest
+    callgraph -format=binary ./cmd/server > new.cg
+    callgraph -diff old.cg new.cg
 `
 
 func init() {
@@ -155,6 +213,13 @@ func init() {
 
 func main() {
 	flag.Parse()
+	if *diffFlag {
+		if err := doDiff(flag.Args()); err != nil {
+			fmt.Fprintf(os.Stderr, "callgraph: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
 	if err := doCallgraph("", "", *algoFlag, *formatFlag, *testFlag, flag.Args()); err != nil {
 		fmt.Fprintf(os.Stderr, "callgraph: %s\n", err)
 		os.Exit(1)
@@ -228,12 +293,26 @@ func doCallgraph(dir, gopath, algo, format string, tests bool, args []string) er
 
 	cg.DeleteSyntheticNodes()
 
+	if *pkgsFlag != "" {
+		cg = filterGraph(cg, packagePatterns(strings.Split(*pkgsFlag, ",")))
+	}
+
 	// -- output------------------------------------------------------------
 
+	if *collapseFlag {
+		return printCollapsed(collapse(cg), format)
+	}
+
 	var before, after string
 
 	// Pre-canned formats.
 	switch format {
+	case "json":
+		return callgraph.Save(stdout, cg, callgraph.JSON)
+
+	case "binary":
+		return callgraph.Save(stdout, cg, callgraph.Binary)
+
 	case "digraph":
 		format = `{{printf "%q %q" .Caller .Callee}}`
 
@@ -280,6 +359,169 @@ func doCallgraph(dir, gopath, algo, format string, tests bool, args []string) er
 	return nil
 }
 
+// packagePatterns returns a regular expression that matches the
+// package paths matched by any of the patterns, in which "..." is a
+// wildcard, and a trailing "/..." may also match the empty string.
+func packagePatterns(patterns []string) *regexp.Regexp {
+	var alts []string
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		re := regexp.QuoteMeta(pattern)
+		re = strings.ReplaceAll(re, `\.\.\.`, `.*`)
+		if rest, ok := strings.CutSuffix(re, `/.*`); ok {
+			re = rest + `(/.*)?`
+		}
+		alts = append(alts, re)
+	}
+	return regexp.MustCompile(`^(` + strings.Join(alts, "|") + `)$`)
+}
+
+// filterGraph returns the subgraph of g containing only the edges to or
+// from the functions of the packages matched by re.
+func filterGraph(g *callgraph.Graph, re *regexp.Regexp) *callgraph.Graph {
+	matches := func(n *callgraph.Node) bool {
+		path := pkgPath(n.Func)
+		return path != "" && re.MatchString(path)
+	}
+	sub := callgraph.New(g.Root.Func)
+	for _, n := range g.Nodes {
+		for _, e := range n.Out {
+			if matches(e.Caller) || matches(e.Callee) {
+				callgraph.AddEdge(sub.CreateNode(e.Caller.Func), e.Site, sub.CreateNode(e.Callee.Func))
+			}
+		}
+	}
+	return sub
+}
+
+// pkgPath returns the path of the package of fn, or of the object from
+// which a synthetic function without a package is derived, if any.
+func pkgPath(fn *ssa.Function) string {
+	if fn == nil {
+		return "" // root
+	}
+	if fn.Pkg != nil {
+		return fn.Pkg.Pkg.Path()
+	}
+	if obj := fn.Object(); obj != nil && obj.Pkg() != nil {
+		return obj.Pkg().Path()
+	}
+	return ""
+}
+
+// A PackageEdge is an edge of the call graph collapsed by package.
+type PackageEdge struct {
+	Caller, Callee string // package paths
+	Calls          int    // number of calls between their functions
+}
+
+// collapse returns the edges between distinct packages of the graph g,
+// sorted by caller and callee.
+func collapse(g *callgraph.Graph) []PackageEdge {
+	type key struct{ caller, callee string }
+	calls := make(map[key]int)
+	for _, n := range g.Nodes {
+		for _, e := range n.Out {
+			k := key{pkgPath(e.Caller.Func), pkgPath(e.Callee.Func)}
+			if k.caller != "" && k.callee != "" && k.caller != k.callee {
+				calls[k]++
+			}
+		}
+	}
+	edges := make([]PackageEdge, 0, len(calls))
+	for k, n := range calls {
+		edges = append(edges, PackageEdge{k.caller, k.callee, n})
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		x, y := edges[i], edges[j]
+		if x.Caller != y.Caller {
+			return x.Caller < y.Caller
+		}
+		return x.Callee < y.Callee
+	})
+	return edges
+}
+
+// printCollapsed displays the edges of a collapsed call graph in the
+// specified format.
+func printCollapsed(edges []PackageEdge, format string) error {
+	var before, after string
+	switch format {
+	case "json", "binary":
+		enc := &callgraph.EncodedGraph{Nodes: []callgraph.EncodedNode{}, Edges: []callgraph.EncodedEdge{}}
+		seen := make(map[string]bool)
+		for _, e := range edges {
+			for _, path := range []string{e.Caller, e.Callee} {
+				if !seen[path] {
+					seen[path] = true
+					enc.Nodes = append(enc.Nodes, callgraph.EncodedNode{ID: path, Name: path, Package: path})
+				}
+			}
+			enc.Edges = append(enc.Edges, callgraph.EncodedEdge{Caller: e.Caller, Site: -1, Callee: e.Callee})
+		}
+		sort.Slice(enc.Nodes, func(i, j int) bool { return enc.Nodes[i].ID < enc.Nodes[j].ID })
+		encoding := callgraph.Binary
+		if format == "json" {
+			encoding = callgraph.JSON
+		}
+		return enc.Write(stdout, encoding)
+
+	case "digraph":
+		format = `{{printf "%q %q" .Caller .Callee}}`
+
+	case "graphviz":
+		before = "digraph callgraph {\n"
+		after = "}\n"
+		format = `  {{printf "%q" .Caller}} -> {{printf "%q" .Callee}}`
+
+	case defaultFormat:
+		format = defaultCollapseFormat
+	}
+
+	tmpl, err := template.New("-format").Parse(format)
+	if err != nil {
+		return fmt.Errorf("invalid -format template: %v", err)
+	}
+	var buf bytes.Buffer
+	fmt.Fprint(stdout, before)
+	for _, e := range edges {
+		buf.Reset()
+		if err := tmpl.Execute(&buf, e); err != nil {
+			return err
+		}
+		if n := buf.Len(); n == 0 || buf.Bytes()[n-1] != '\n' {
+			buf.WriteByte('\n')
+		}
+		stdout.Write(buf.Bytes())
+	}
+	fmt.Fprint(stdout, after)
+	return nil
+}
+
+// doDiff displays the differences between the two call graphs saved
+// in the files named by args.
+func doDiff(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("-diff requires two files")
+	}
+	var graphs [2]*callgraph.EncodedGraph
+	for i, name := range args {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		graphs[i], err = callgraph.Read(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+	}
+	return callgraph.Diff(graphs[0], graphs[1]).Write(stdout)
+}
+
 // mainPackages returns the main packages to analyze.
 // Each resulting package is named "main" and has a main function.
 func mainPackages(pkgs []*ssa.Package) ([]*ssa.Package, error) {
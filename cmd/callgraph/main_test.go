@@ -17,6 +17,7 @@ import (
 	"strings"
 	"testing"
 
+	"golang.org/x/tools/go/callgraph"
 	"golang.org/x/tools/internal/testenv"
 )
 
@@ -98,3 +99,55 @@ func TestCallgraph(t *testing.T) {
 		}
 	}
 }
+
+func TestDiff(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name string, format callgraph.Format, edges ...[2]string) string {
+		enc := new(callgraph.EncodedGraph)
+		for _, id := range []string{"p:main", "p:f", "q:g"} {
+			pkg, _, _ := strings.Cut(id, ":")
+			enc.Nodes = append(enc.Nodes, callgraph.EncodedNode{ID: id, Name: strings.Replace(id, ":", ".", 1), Package: pkg})
+		}
+		for _, e := range edges {
+			enc.Edges = append(enc.Edges, callgraph.EncodedEdge{Caller: e[0], Site: 0, Callee: e[1]})
+		}
+		file := filepath.Join(dir, name)
+		var buf bytes.Buffer
+		if err := enc.Write(&buf, format); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, buf.Bytes(), 0666); err != nil {
+			t.Fatal(err)
+		}
+		return file
+	}
+	before := write("old.cg", callgraph.Binary, [2]string{"p:main", "p:f"})
+	after := write("new.json", callgraph.JSON, [2]string{"p:main", "p:f"}, [2]string{"p:f", "q:g"})
+
+	stdout = new(bytes.Buffer)
+	if err := doDiff([]string{before, after}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fmt.Sprint(stdout), "package p\n\t+ p.f --> q.g\n"; got != want {
+		t.Errorf("-diff output = %q, want %q", got, want)
+	}
+}
+
+func TestPackagePatterns(t *testing.T) {
+	re := packagePatterns([]string{"example.com/a/...", " example.com/b", "example.com/c/.../internal"})
+	for path, want := range map[string]bool{
+		"example.com/a":                true,
+		"example.com/a/x/y":            true,
+		"example.com/ab":               false,
+		"example.com/b":                true,
+		"example.com/b/x":              false,
+		"example.com/c/x/internal":     true,
+		"example.com/c/internal/x":     false,
+		"other.org/example.com/a":      false,
+		"example.com/c/x/y/z/internal": true,
+	} {
+		if got := re.MatchString(path); got != want {
+			t.Errorf("match(%q) = %t, want %t", path, got, want)
+		}
+	}
+}
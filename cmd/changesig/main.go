@@ -0,0 +1,274 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// The changesig command changes the parameters of a Go function or
+// method and updates its calls.
+// For the details of the refactoring, see
+// golang.org/x/tools/refactor/changesig.
+package main // import "golang.org/x/tools/cmd/changesig"
+
+import (
+	"flag"
+	"fmt"
+	"go/types"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/internal/diff"
+	"golang.org/x/tools/refactor/changesig"
+)
+
+var (
+	funcFlag   = flag.String("func", "", "the function or method to change, such as example.com/p.F or example.com/p.T.M")
+	paramsFlag = flag.String("params", "", "the new parameter list (see usage)")
+	writeFlag  = flag.Bool("w", false, "rewrite input files in place (by default, diffs are printed to standard output)")
+)
+
+const usage = `changesig: change the parameters of a Go function or method.
+
+Usage: changesig -func <func> -params <params> [-w] <packages>
+
+-func    the function, such as example.com/p.F, or method, such as
+         example.com/p.T.M. Quote an import path containing a dot in
+         its last segment: '"gopkg.in/yaml.v3".Marshal'.
+
+-params  the new parameter list, a comma-separated list of items:
+
+           name            an existing parameter
+           #3              the existing parameter at index 3
+           name type       an existing parameter retyped, or a new one
+           name type = x   a new parameter, whose argument at each
+                           existing call is the expression x
+
+         Existing parameters that are not listed are removed. By
+         default, the argument of a new parameter is the zero value of
+         its type, or its name as a placeholder.
+
+-w       rewrite the files in place.
+
+The packages, which must include every package that refers to the
+function, are loaded with their tests. References that cannot be
+updated are reported, and cause the command to exit with status 1.
+`
+
+func main() {
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+	if err := doMain(flag.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "changesig: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func doMain(args []string) error {
+	if len(args) == 0 || *funcFlag == "" {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	pkgPath, members, err := parseFunc(*funcFlag)
+	if err != nil {
+		return err
+	}
+
+	cfg := &packages.Config{
+		Mode:  packages.NeedTypesInfo | packages.NeedName | packages.NeedTypes | packages.NeedSyntax | packages.NeedImports | packages.NeedDeps | packages.NeedCompiledGoFiles,
+		Tests: true,
+	}
+	pkgs, err := packages.Load(cfg, args...)
+	if err != nil {
+		return err
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		return fmt.Errorf("packages contain errors")
+	}
+
+	// Find the function.
+	var fn *types.Func
+	for _, pkg := range pkgs {
+		if pkg.PkgPath == pkgPath {
+			fn, err = lookup(pkg.Types, members)
+			if err != nil {
+				return err
+			}
+			break
+		}
+	}
+	if fn == nil {
+		return fmt.Errorf("package %s is not among the loaded packages", pkgPath)
+	}
+
+	params, err := parseParams(fn, *paramsFlag)
+	if err != nil {
+		return err
+	}
+	edits, problems, err := changesig.Change(pkgs, fn, params)
+	if err != nil {
+		return err
+	}
+
+	// Apply the edits to each file.
+	fset := pkgs[0].Fset
+	byFile := make(map[string][]diff.Edit)
+	for _, edit := range edits {
+		tokFile := fset.File(edit.Pos)
+		byFile[tokFile.Name()] = append(byFile[tokFile.Name()], diff.Edit{
+			Start: tokFile.Offset(edit.Pos),
+			End:   tokFile.Offset(edit.End),
+			New:   string(edit.NewText),
+		})
+	}
+	var files []string
+	for file := range byFile {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if *writeFlag {
+			out, err := diff.ApplyBytes(content, byFile[file])
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(file, out, 0644); err != nil {
+				return err
+			}
+		} else {
+			unified, err := diff.ToUnified(file, file, string(content), byFile[file], diff.DefaultContextLines)
+			if err != nil {
+				return err
+			}
+			fmt.Print(unified)
+		}
+	}
+
+	for _, p := range problems {
+		fmt.Fprintln(os.Stderr, p)
+	}
+	if len(problems) > 0 {
+		os.Exit(1)
+	}
+	return nil
+}
+
+// parseFunc parses the -func flag into an import path and the names
+// of a package member and, optionally, its method.
+func parseFunc(s string) (string, []string, error) {
+	var path, rest string
+	if strings.HasPrefix(s, `"`) {
+		i := strings.Index(s[1:], `"`)
+		if i < 0 {
+			return "", nil, fmt.Errorf("-func %s: unterminated quoted import path", s)
+		}
+		path, rest = s[1:1+i], strings.TrimPrefix(s[2+i:], ".")
+	} else {
+		slash := strings.LastIndex(s, "/") + 1
+		dot := strings.Index(s[slash:], ".")
+		if dot < 0 {
+			return "", nil, fmt.Errorf("-func %s: want an import path followed by a name", s)
+		}
+		path, rest = s[:slash+dot], s[slash+dot+1:]
+	}
+	members := strings.Split(rest, ".")
+	if len(members) > 2 || rest == "" {
+		return "", nil, fmt.Errorf("-func %s: want a function or method name after the import path", s)
+	}
+	return path, members, nil
+}
+
+// lookup returns the function or method of pkg denoted by members.
+func lookup(pkg *types.Package, members []string) (*types.Func, error) {
+	obj := pkg.Scope().Lookup(members[0])
+	if obj == nil {
+		return nil, fmt.Errorf("%s.%s not found", pkg.Path(), members[0])
+	}
+	if len(members) == 2 {
+		if _, ok := obj.(*types.TypeName); !ok {
+			return nil, fmt.Errorf("%s.%s is not a type", pkg.Path(), members[0])
+		}
+		obj, _, _ = types.LookupFieldOrMethod(obj.Type(), true, pkg, members[1])
+	}
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a function or method", strings.Join(members, "."))
+	}
+	return fn, nil
+}
+
+// parseParams parses the -params flag.
+func parseParams(fn *types.Func, s string) ([]changesig.Param, error) {
+	old := fn.Signature().Params()
+	var params []changesig.Param
+	for _, item := range split(s) {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		var p changesig.Param
+		if decl, value, ok := strings.Cut(item, "="); ok && !strings.ContainsAny(decl, "({[") {
+			item, p.Value = strings.TrimSpace(decl), strings.TrimSpace(value)
+		}
+		name, typ, _ := strings.Cut(item, " ")
+		p.Type = strings.TrimSpace(typ)
+
+		p.Old = -1
+		if index, ok := strings.CutPrefix(name, "#"); ok {
+			i, err := strconv.Atoi(index)
+			if err != nil || i < 0 || i >= old.Len() {
+				return nil, fmt.Errorf("-params: invalid parameter index %s", name)
+			}
+			p.Old = i
+		} else {
+			for i := range old.Len() {
+				if name != "_" && old.At(i).Name() == name {
+					p.Old = i
+				}
+			}
+		}
+		if p.Old < 0 {
+			if p.Type == "" {
+				return nil, fmt.Errorf("-params: %s is not a parameter of %s, and has no type", name, fn.Name())
+			}
+			p.Name = name
+		} else if p.Value != "" {
+			return nil, fmt.Errorf("-params: existing parameter %s cannot have a value", name)
+		}
+		params = append(params, p)
+	}
+	return params, nil
+}
+
+// split splits s at the commas that are not within brackets or
+// quotes.
+func split(s string) []string {
+	var (
+		items []string
+		depth int
+		quote rune
+		start int
+	)
+	for i, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'' || r == '`':
+			quote = r
+		case r == '(' || r == '[' || r == '{':
+			depth++
+		case r == ')' || r == ']' || r == '}':
+			depth--
+		case r == ',' && depth == 0:
+			items = append(items, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(items, s[start:])
+}
@@ -28,6 +28,9 @@
 //	-linkflags 'list'
 //		Pass the space-separated list of flags to the linker.
 //
+//	-compare file
+//		Compare the results with those in file, written by -json.
+//
 //	-count n
 //		Run each benchmark n times (default 1).
 //
@@ -37,6 +40,9 @@
 //	-go path
 //		Path to "go" command (default "go").
 //
+//	-json file
+//		Also write the results to file in JSON form.
+//
 //	-memprofile file
 //		Write a memory profile of the compiler to file.
 //
@@ -66,6 +72,34 @@
 // execution cost. For benchmark comparisons, never use timings
 // obtained with a low -memprofilerate option.
 //
+// The -json flag writes the results as a JSON object, whose Results
+// field holds one element per benchmark run:
+//
+//	{
+//		"GOOS": "linux",
+//		"GOARCH": "amd64",
+//		"Results": [
+//			{
+//				"Name": "BenchmarkTemplate",
+//				"Metrics": [
+//					{"Unit": "ns/op", "Value": 165498034},
+//					{"Unit": "user-ns/op", "Value": 262516000},
+//					...
+//				]
+//			},
+//			...
+//		]
+//	}
+//
+// The -compare flag prints, after the results, a comparison of each
+// metric of each benchmark with those of an earlier run, saved by
+// -json: the median of the old and new values, the change between
+// them, and the p-value of a Mann-Whitney U-test of the hypothesis
+// that the two sets of values come from the same distribution. A
+// change whose p-value is not below 0.05 is shown as "~", meaning no
+// significant difference; at least four runs of each (-count 4) are
+// needed to observe one.
+//
 // # Example
 //
 // Assuming the base version of the compiler has been saved with
@@ -74,6 +108,11 @@
 //	compilebench -count 10 -compile $(toolstash -n compile) >old.txt
 //	compilebench -count 10 >new.txt
 //	benchstat old.txt new.txt
+//
+// Without benchstat, the same comparison is:
+//
+//	compilebench -count 10 -compile $(toolstash -n compile) -json old.json
+//	compilebench -count 10 -compare old.json
 package main
 
 import (
@@ -119,6 +158,8 @@ var (
 	flagPackage        = flag.String("pkg", "", "if set, benchmark the package at path `pkg`")
 	flagShort          = flag.Bool("short", false, "skip long-running benchmarks")
 	flagTrace          = flag.Bool("trace", false, "debug tracing of builds")
+	flagJSON           = flag.String("json", "", "also write results to `file` as JSON")
+	flagCompare        = flag.String("compare", "", "compare results with those in `file`, written by -json")
 )
 
 type test struct {
@@ -209,6 +250,14 @@ func main() {
 		runRE = r
 	}
 
+	var old *benchResults
+	if *flagCompare != "" {
+		var err error
+		if old, err = readResults(*flagCompare); err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	if *flagPackage != "" {
 		tests = []test{
 			{"BenchmarkPkg", compile{*flagPackage}},
@@ -229,6 +278,16 @@ func main() {
 			}
 		}
 	}
+
+	if *flagJSON != "" {
+		if err := writeResults(*flagJSON, &results); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if old != nil {
+		fmt.Println()
+		compare(os.Stdout, old, &results)
+	}
 }
 
 func toolPath(names ...string) (found, path string) {
@@ -272,7 +331,9 @@ func runCmd(name string, cmd *exec.Cmd) error {
 	if err != nil {
 		return fmt.Errorf("%v\n%s", err, out)
 	}
-	fmt.Printf("%s 1 %d ns/op\n", name, time.Since(start).Nanoseconds())
+	r := &benchResult{Name: name}
+	r.add(float64(time.Since(start).Nanoseconds()), "ns/op")
+	report(r)
 	return nil
 }
 
@@ -324,11 +385,24 @@ func (r size) run(name string, count int) error {
 		return fmt.Errorf("not enough output from size: %s", out)
 	}
 	f := strings.Fields(lines[1])
+	var units []string
 	if strings.HasPrefix(lines[0], "__TEXT") && len(f) >= 2 { // OS X
-		fmt.Printf("%s 1 %s text-bytes %s data-bytes %v exe-bytes\n", name, f[0], f[1], info.Size())
+		units = []string{"text-bytes", "data-bytes"}
 	} else if strings.Contains(lines[0], "bss") && len(f) >= 3 {
-		fmt.Printf("%s 1 %s text-bytes %s data-bytes %s bss-bytes %v exe-bytes\n", name, f[0], f[1], f[2], info.Size())
+		units = []string{"text-bytes", "data-bytes", "bss-bytes"}
+	} else {
+		return nil
 	}
+	res := &benchResult{Name: name}
+	for i, unit := range units {
+		v, err := strconv.ParseFloat(f[i], 64)
+		if err != nil {
+			return fmt.Errorf("unexpected output from size: %s", out)
+		}
+		res.add(v, unit)
+	}
+	res.add(float64(info.Size()), "exe-bytes")
+	report(res)
 	return nil
 }
 
@@ -383,7 +457,8 @@ func (c compile) run(name string, count int) error {
 		defer os.Remove(importcfg)
 	}
 	args = append(args, pkg.GoFiles...)
-	if err := runBuildCmd(name, count, pkg.Dir, compiler, args); err != nil {
+	r, err := runBuildCmd(name, count, pkg.Dir, compiler, args)
+	if err != nil {
 		return err
 	}
 
@@ -398,9 +473,10 @@ func (c compile) run(name string, count int) error {
 		i := bytes.Index(data, []byte("\n$$B\n")) + len("\n$$B\n")
 		// Count bytes to end of export data.
 		nexport := bytes.Index(data[i:], []byte("\n$$\n"))
-		fmt.Printf(" %d object-bytes %d export-bytes", len(data), nexport)
+		r.add(float64(len(data)), "object-bytes")
+		r.add(float64(nexport), "export-bytes")
 	}
-	fmt.Println()
+	report(r)
 
 	os.Remove(opath)
 	return nil
@@ -461,22 +537,23 @@ func (r link) run(name string, count int) error {
 	args = append(args, strings.Fields(*flagLinkerFlags)...)
 	args = append(args, strings.Fields(r.flags)...)
 	args = append(args, "_compilebench_.o")
-	if err := runBuildCmd(name, count, pkg.Dir, linker, args); err != nil {
+	res, err := runBuildCmd(name, count, pkg.Dir, linker, args)
+	if err != nil {
 		return err
 	}
-	fmt.Println()
+	report(res)
 	defer os.Remove(pkg.Dir + "/_compilebench_.exe")
 
 	return err
 }
 
-// runBuildCmd runs "tool args..." in dir, measures standard build
-// tool metrics, and prints a benchmark line. The caller may print
-// additional metrics and then must print a newline.
+// runBuildCmd runs "tool args..." in dir, and returns the result of
+// benchmark name holding the standard build tool metrics. The caller
+// may add metrics and then must report the result.
 //
 // This assumes tool accepts standard build tool flags like
 // -memprofilerate, -memprofile, and -cpuprofile.
-func runBuildCmd(name string, count int, dir, tool string, args []string) error {
+func runBuildCmd(name string, count int, dir, tool string, args []string) (*benchResult, error) {
 	var preArgs []string
 	if *flagMemprofilerate >= 0 {
 		preArgs = append(preArgs, "-memprofilerate", fmt.Sprint(*flagMemprofilerate))
@@ -500,7 +577,7 @@ func runBuildCmd(name string, count int, dir, tool string, args []string) error
 	start := time.Now()
 	err := cmd.Run()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	end := time.Now()
 
@@ -565,15 +642,18 @@ func runBuildCmd(name string, count int, dir, tool string, args []string) error
 	wallns := end.Sub(start).Nanoseconds()
 	userns := cmd.ProcessState.UserTime().Nanoseconds()
 
-	fmt.Printf("%s 1 %d ns/op %d user-ns/op", name, wallns, userns)
+	r := &benchResult{Name: name}
+	r.add(float64(wallns), "ns/op")
+	r.add(float64(userns), "user-ns/op")
 	if haveAllocs {
-		fmt.Printf(" %d B/op %d allocs/op", allocbytes, allocs)
+		r.add(float64(allocbytes), "B/op")
+		r.add(float64(allocs), "allocs/op")
 	}
 	if haveRSS {
-		fmt.Printf(" %d maxRSS/op", rssbytes)
+		r.add(float64(rssbytes), "maxRSS/op")
 	}
 
-	return nil
+	return r, nil
 }
 
 func checkCompilingRuntimeFlag(assembler string) error {
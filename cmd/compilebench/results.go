@@ -0,0 +1,242 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+// This file defines the recording of results, their JSON form, and
+// their comparison with an earlier run (-compare).
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// benchResults is the JSON form of the results of a run.
+type benchResults struct {
+	GOOS, GOARCH string
+	Results      []*benchResult
+}
+
+// A benchResult is the result of one run of a benchmark.
+type benchResult struct {
+	Name    string
+	Metrics []metric
+}
+
+// A metric is a measurement, in the units of benchfmt, such as ns/op.
+type metric struct {
+	Unit  string
+	Value float64
+}
+
+func (r *benchResult) add(value float64, unit string) {
+	r.Metrics = append(r.Metrics, metric{unit, value})
+}
+
+// results holds the results of this run.
+var results = benchResults{GOOS: runtime.GOOS, GOARCH: runtime.GOARCH}
+
+// report prints the benchmark line of r, and records it.
+func report(r *benchResult) {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "%s 1", r.Name)
+	for _, m := range r.Metrics {
+		fmt.Fprintf(&buf, " %s %s", strconv.FormatFloat(m.Value, 'f', -1, 64), m.Unit)
+	}
+	fmt.Println(buf.String())
+	results.Results = append(results.Results, r)
+}
+
+func writeResults(file string, results *benchResults) error {
+	data, err := json.MarshalIndent(results, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, append(data, '\n'), 0666)
+}
+
+func readResults(file string) (*benchResults, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var results benchResults
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("reading %s: %v", file, err)
+	}
+	return &results, nil
+}
+
+// alpha is the significance level of a comparison.
+const alpha = 0.05
+
+// compare prints a comparison of each metric of each benchmark
+// in the new results with the same metric in the old.
+func compare(w io.Writer, before, after *benchResults) {
+	type key struct{ name, unit string }
+	samples := func(results *benchResults) (map[key][]float64, []key) {
+		m := make(map[key][]float64)
+		var keys []key
+		for _, r := range results.Results {
+			for _, metric := range r.Metrics {
+				k := key{r.Name, metric.Unit}
+				if _, ok := m[k]; !ok {
+					keys = append(keys, k)
+				}
+				m[k] = append(m[k], metric.Value)
+			}
+		}
+		return m, keys
+	}
+	oldSamples, _ := samples(before)
+	newSamples, keys := samples(after)
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "name\tunit\told\tnew\tdelta\t")
+	for _, k := range keys {
+		x, ok := oldSamples[k]
+		if !ok {
+			continue
+		}
+		y := newSamples[k]
+		oldMedian, newMedian := median(x), median(y)
+		delta := "~"
+		p := mannWhitneyU(x, y)
+		if p < alpha {
+			if oldMedian == 0 {
+				delta = "?"
+			} else {
+				delta = fmt.Sprintf("%+.2f%%", 100*(newMedian-oldMedian)/oldMedian)
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t(p=%.3f n=%d+%d)\n",
+			strings.TrimPrefix(k.name, "Benchmark"), k.unit,
+			strconv.FormatFloat(oldMedian, 'g', 4, 64),
+			strconv.FormatFloat(newMedian, 'g', 4, 64),
+			delta, p, len(x), len(y))
+	}
+	tw.Flush()
+}
+
+func median(x []float64) float64 {
+	x = slices.Sorted(slices.Values(x))
+	n := len(x)
+	if n%2 == 1 {
+		return x[n/2]
+	}
+	return (x[n/2-1] + x[n/2]) / 2
+}
+
+// mannWhitneyU returns the two-sided p-value of the Mann-Whitney
+// U-test of the null hypothesis that the samples x and y come from the
+// same distribution. The p-value is exact if there are no ties between
+// the samples; otherwise it uses the normal approximation with
+// corrections for ties and continuity.
+func mannWhitneyU(x, y []float64) float64 {
+	n1, n2 := len(x), len(y)
+	if n1 == 0 || n2 == 0 {
+		return 1
+	}
+
+	// Rank the combined samples, giving tied values their mean rank.
+	type value struct {
+		v     float64
+		first bool // from x
+	}
+	all := make([]value, 0, n1+n2)
+	for _, v := range x {
+		all = append(all, value{v, true})
+	}
+	for _, v := range y {
+		all = append(all, value{v, false})
+	}
+	slices.SortFunc(all, func(a, b value) int {
+		switch {
+		case a.v < b.v:
+			return -1
+		case a.v > b.v:
+			return +1
+		}
+		return 0
+	})
+	var (
+		rankSum float64 // of x
+		tieSum  float64 // Σ(t³-t) over groups of t tied values
+	)
+	for i := 0; i < len(all); {
+		j := i + 1
+		for j < len(all) && all[j].v == all[i].v {
+			j++
+		}
+		rank := float64(i+j+1) / 2 // mean of ranks i+1..j
+		for _, v := range all[i:j] {
+			if v.first {
+				rankSum += rank
+			}
+		}
+		if t := float64(j - i); t > 1 {
+			tieSum += t*t*t - t
+		}
+		i = j
+	}
+	u := rankSum - float64(n1*(n1+1))/2
+
+	if tieSum == 0 {
+		return exactU(n1, n2, int(u))
+	}
+
+	// Normal approximation.
+	n := float64(n1 + n2)
+	mean := float64(n1*n2) / 2
+	variance := float64(n1*n2) / 12 * ((n + 1) - tieSum/(n*(n-1)))
+	if variance == 0 {
+		return 1
+	}
+	z := (math.Abs(u-mean) - 0.5) / math.Sqrt(variance)
+	if z < 0 {
+		return 1
+	}
+	return math.Min(1, math.Erfc(z/math.Sqrt2))
+}
+
+// exactU returns the exact two-sided p-value of the statistic u of
+// the Mann-Whitney U-test of samples of sizes n1 and n2 without ties.
+func exactU(n1, n2, u int) float64 {
+	// ways[m][k] is the number of orderings of m values of x and n
+	// values of y in which k pairs have the value of x greater, for
+	// the n of the current iteration. If the largest value is of x,
+	// it is greater than all n values of y; otherwise it adds nothing.
+	maxU := n1 * n2
+	ways := make([][]float64, n1+1)
+	for m := range ways {
+		ways[m] = make([]float64, maxU+1)
+		ways[m][0] = 1 // n = 0
+	}
+	for n := 1; n <= n2; n++ {
+		for m := 1; m <= n1; m++ {
+			for k := maxU; k >= n; k-- {
+				ways[m][k] += ways[m-1][k-n]
+			}
+		}
+	}
+	var total, below, above float64
+	for k, w := range ways[n1] {
+		total += w
+		if k <= u {
+			below += w
+		}
+		if k >= u {
+			above += w
+		}
+	}
+	return math.Min(1, 2*math.Min(below, above)/total)
+}
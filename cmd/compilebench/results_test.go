@@ -0,0 +1,98 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestMannWhitneyU(t *testing.T) {
+	for _, test := range []struct {
+		x, y []float64
+		want float64
+	}{
+		// Exact p-values, without ties: 2/C(n1+n2, n1) when the
+		// samples are separated.
+		{[]float64{1, 2, 3}, []float64{4, 5, 6}, 2.0 / 20},
+		{[]float64{4, 5, 6}, []float64{1, 2, 3}, 2.0 / 20},
+		{[]float64{1, 2, 3}, []float64{4, 5, 6, 7}, 2.0 / 35},
+		{[]float64{1, 2, 3, 4}, []float64{5, 6, 7, 8}, 2.0 / 70},
+		{[]float64{1, 2, 3, 4, 5}, []float64{6, 7, 8, 9, 10}, 2.0 / 252},
+		{[]float64{1, 3, 5}, []float64{2, 4, 6, 7}, 0.4},
+		{[]float64{1}, []float64{2}, 1},
+		// Normal approximation, with ties.
+		{[]float64{1, 2, 2, 3}, []float64{2, 4, 5, 6}, 0.10375367752},
+		{[]float64{1, 1, 1}, []float64{1, 1, 1}, 1},
+		// Empty samples.
+		{nil, []float64{1}, 1},
+		{[]float64{1}, nil, 1},
+	} {
+		if got := mannWhitneyU(test.x, test.y); math.Abs(got-test.want) > 1e-9 {
+			t.Errorf("mannWhitneyU(%v, %v) = %v, want %v", test.x, test.y, got, test.want)
+		}
+	}
+}
+
+func TestMedian(t *testing.T) {
+	for _, test := range []struct {
+		x    []float64
+		want float64
+	}{
+		{[]float64{3}, 3},
+		{[]float64{3, 1, 2}, 2},
+		{[]float64{4, 1, 3, 2}, 2.5},
+	} {
+		if got := median(test.x); got != test.want {
+			t.Errorf("median(%v) = %v, want %v", test.x, got, test.want)
+		}
+	}
+}
+
+// TestCompare checks that compare reports a change only if it is
+// significant at level alpha.
+func TestCompare(t *testing.T) {
+	results := func(name string, values ...float64) *benchResults {
+		var r benchResults
+		for _, v := range values {
+			r.Results = append(r.Results, &benchResult{name, []metric{{"ns/op", v}}})
+		}
+		return &r
+	}
+	for _, test := range []struct {
+		before, after *benchResults
+		want          string // delta
+	}{
+		// p = 2/70 < alpha.
+		{results("BenchmarkA", 10, 11, 12, 13), results("BenchmarkA", 20, 21, 22, 23), "+86.96%"},
+		{results("BenchmarkA", 20, 21, 22, 23), results("BenchmarkA", 10, 11, 12, 13), "-46.51%"},
+		// p = 2/35 >= alpha: too few runs to tell.
+		{results("BenchmarkA", 10, 11, 12), results("BenchmarkA", 20, 21, 22, 23), "~"},
+		// p = 1.
+		{results("BenchmarkA", 10, 10, 10), results("BenchmarkA", 10, 10, 10), "~"},
+		// A significant change from zero has no percentage.
+		{results("BenchmarkA", 0, 0, 0, 0), results("BenchmarkA", 1, 2, 3, 4), "?"},
+		// A benchmark absent from the old results is not shown.
+		{results("BenchmarkB", 1, 2, 3, 4), results("BenchmarkA", 1, 2, 3, 4), ""},
+	} {
+		var buf strings.Builder
+		compare(&buf, test.before, test.after)
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if test.want == "" {
+			if len(lines) != 1 {
+				t.Errorf("compare printed %q, want only the header", buf.String())
+			}
+			continue
+		}
+		if len(lines) != 2 {
+			t.Errorf("compare printed %q, want a header and one line", buf.String())
+			continue
+		}
+		if fields := strings.Fields(lines[1]); len(fields) < 5 || fields[0] != "A" || fields[4] != test.want {
+			t.Errorf("compare printed %q, want delta %s", lines[1], test.want)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// The coverprofile command merges and compares coverage profiles
+// produced by "go test -coverprofile".
+//
+// Usage:
+//
+//	coverprofile merge [-o output] profile...
+//	coverprofile diff [-o output.html] base head
+//
+// The merge subcommand merges several profiles of the same code, such
+// as those of its unit and integration tests, into a single profile,
+// which it writes to the -o file or the standard output.
+//
+// The diff subcommand writes an HTML report of the differences in
+// coverage between two profiles, such as those before and after a
+// change, highlighting the lines that lost coverage. It finds the
+// source files named by the head profile using the current build
+// configuration, as does "go tool cover -html". The report is written
+// to the -o file or the standard output.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/build"
+	"io"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+
+	"golang.org/x/tools/cover"
+)
+
+var output = flag.String("o", "", "write output to `file` (default: standard output)")
+
+func usage() {
+	fmt.Fprintf(os.Stderr, `Usage:
+	coverprofile merge [-o output] profile...
+	coverprofile diff [-o output.html] base head
+`)
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("coverprofile: ")
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() < 1 {
+		usage()
+	}
+	cmd, args := flag.Arg(0), flag.Args()[1:]
+	// Permit flags after the subcommand.
+	if err := flag.CommandLine.Parse(args); err != nil {
+		usage()
+	}
+	args = flag.Args()
+
+	var buf bytes.Buffer
+	switch cmd {
+	case "merge":
+		if len(args) == 0 {
+			usage()
+		}
+		if err := merge(&buf, args); err != nil {
+			log.Fatal(err)
+		}
+	case "diff":
+		if len(args) != 2 {
+			usage()
+		}
+		if err := diff(&buf, args[0], args[1]); err != nil {
+			log.Fatal(err)
+		}
+	default:
+		log.Printf("unknown subcommand %q", cmd)
+		usage()
+	}
+
+	if *output == "" {
+		_, err := os.Stdout.Write(buf.Bytes())
+		if err != nil {
+			log.Fatal(err)
+		}
+	} else if err := os.WriteFile(*output, buf.Bytes(), 0666); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// merge writes to w the merge of the named profiles.
+func merge(w io.Writer, filenames []string) error {
+	var sets [][]*cover.Profile
+	for _, filename := range filenames {
+		profiles, err := cover.ParseProfiles(filename)
+		if err != nil {
+			return err
+		}
+		sets = append(sets, profiles)
+	}
+	merged, err := cover.MergeProfiles(sets...)
+	if err != nil {
+		return err
+	}
+	return cover.WriteProfiles(w, merged)
+}
+
+// diff writes to w an HTML report comparing the named profiles.
+func diff(w io.Writer, base, head string) error {
+	baseProfiles, err := cover.ParseProfiles(base)
+	if err != nil {
+		return err
+	}
+	headProfiles, err := cover.ParseProfiles(head)
+	if err != nil {
+		return err
+	}
+	return cover.WriteHTMLDiff(w, baseProfiles, headProfiles, func(name string) ([]byte, error) {
+		filename, err := findFile(name)
+		if err != nil {
+			return nil, err
+		}
+		return os.ReadFile(filename)
+	})
+}
+
+// findFile finds the location of the named file, which is of the form
+// "import/path/file.go", as it appears in a profile.
+func findFile(name string) (string, error) {
+	if filepath.IsAbs(name) {
+		return name, nil
+	}
+	dir, file := path.Split(name)
+	pkg, err := build.Import(dir, ".", build.FindOnly)
+	if err != nil {
+		return "", fmt.Errorf("can't find %q: %v", file, err)
+	}
+	return filepath.Join(pkg.Dir, file), nil
+}
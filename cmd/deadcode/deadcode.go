@@ -48,6 +48,7 @@ var (
 	whyLiveFlag   = flag.String("whylive", "", "show a path from main to the named function")
 	formatFlag    = flag.String("f", "", "format output records using template")
 	jsonFlag      = flag.Bool("json", false, "output JSON records")
+	baselineFlag  = flag.String("baseline", "", "report only dead functions absent from this `file` of -json output of a previous run, and fail if any")
 	cpuProfile    = flag.String("cpuprofile", "", "write CPU profile to this file")
 	memProfile    = flag.String("memprofile", "", "write memory profile to this file")
 )
@@ -113,6 +114,19 @@ func main() {
 		}
 	}
 
+	// Read the baseline early too.
+	var baseline map[string]bool
+	if *baselineFlag != "" {
+		if *whyLiveFlag != "" {
+			log.Fatalf("you cannot specify both -baseline and -whylive")
+		}
+		var err error
+		baseline, err = readBaseline(*baselineFlag)
+		if err != nil {
+			log.Fatalf("-baseline: %v", err)
+		}
+	}
+
 	// Load, parse, and type-check the complete program(s).
 	cfg := &packages.Config{
 		BuildFlags: []string{"-tags=" + *tagsFlag},
@@ -283,11 +297,13 @@ func main() {
 
 	// Group unreachable functions by package path.
 	byPkgPath := make(map[string]map[*ssa.Function]bool)
+	dead := make(map[token.Position]bool)
 	for _, fn := range sourceFuncs {
 		posn := prog.Fset.Position(fn.Pos())
 
 		if !reachablePosn[posn] {
 			reachablePosn[posn] = true // suppress dups with same pos
+			dead[posn] = true
 
 			pkgpath := fn.Pkg.Pkg.Path()
 			m, ok := byPkgPath[pkgpath]
@@ -299,8 +315,16 @@ func main() {
 		}
 	}
 
+	// Explain the deadness of each function, for the structured
+	// output formats. (It is costly, and invisible in the default one.)
+	var callers map[token.Position]*jsonCaller
+	if *jsonFlag || *formatFlag != "" {
+		callers = liveCallers(prog, dead)
+	}
+
 	// Build array of jsonPackage objects.
 	var packages []any
+	var nfuncs int
 	for _, pkgpath := range slices.Sorted(maps.Keys(byPkgPath)) {
 		if !filter.MatchString(pkgpath) {
 			continue
@@ -334,12 +358,20 @@ func main() {
 				continue
 			}
 
+			// With -baseline, skip functions that were already dead.
+			name := prettyName(fn, false)
+			if baseline[pkgpath+"."+name] {
+				continue
+			}
+
 			functions = append(functions, jsonFunction{
-				Name:      prettyName(fn, false),
+				Name:      name,
 				Position:  toJSONPosition(posn),
 				Generated: gen,
+				Caller:    callers[posn],
 			})
 		}
+		nfuncs += len(functions)
 		if len(functions) > 0 {
 			packages = append(packages, jsonPackage{
 				Name:  fns[0].Pkg.Pkg.Name(),
@@ -355,6 +387,131 @@ func main() {
 		format = *formatFlag
 	}
 	printObjects(format, packages)
+
+	// With -baseline, any report is a failure.
+	if baseline != nil && nfuncs > 0 {
+		log.Fatalf("%d newly dead functions", nfuncs)
+	}
+}
+
+// readBaseline reads the named file of -json output, and returns the
+// set of its dead functions, each package-qualified.
+func readBaseline(filename string) (map[string]bool, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var packages []jsonPackage
+	if err := json.Unmarshal(data, &packages); err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	baseline := make(map[string]bool)
+	for _, pkg := range packages {
+		for _, fn := range pkg.Funcs {
+			baseline[pkg.Path+"."+fn.Name] = true
+		}
+	}
+	return baseline, nil
+}
+
+// liveCallers returns, for each dead function, identified by position,
+// the nearest live function that refers to it, either directly or
+// through a chain of dead functions; if there is none, the function has
+// no such entry. A reference from a live function that does not make
+// the referent live is typically the use of a function value, or a
+// bound method, for which there is no dynamic call of the right type.
+func liveCallers(prog *ssa.Program, dead map[token.Position]bool) map[token.Position]*jsonCaller {
+	// Gather the references to dead functions, attributing those
+	// within anonymous functions to their enclosing declaration.
+	// (Wrappers of dead methods have the positions of the methods.)
+	type ref struct {
+		from *ssa.Function // declared function or synthetic init
+		pos  token.Pos     // position of the reference
+	}
+	refs := make(map[token.Position][]ref)
+	for fn := range ssautil.AllFunctions(prog) {
+		from := fn
+		for from.Parent() != nil {
+			from = from.Parent()
+		}
+		for _, b := range fn.Blocks {
+			for _, instr := range b.Instrs {
+				for _, op := range instr.Operands(nil) {
+					if g, ok := (*op).(*ssa.Function); ok {
+						if posn := prog.Fset.Position(g.Pos()); dead[posn] {
+							refs[posn] = append(refs[posn], ref{from, refPos(from, instr)})
+						}
+					}
+				}
+			}
+		}
+	}
+	for _, rs := range refs {
+		sort.Slice(rs, func(i, j int) bool {
+			x, y := prog.Fset.Position(rs[i].pos), prog.Fset.Position(rs[j].pos)
+			if x.Filename != y.Filename {
+				return x.Filename < y.Filename
+			}
+			return x.Offset < y.Offset
+		})
+	}
+
+	// Search breadth-first from each dead function, following
+	// references backwards, for a live one.
+	callers := make(map[token.Position]*jsonCaller)
+	for target := range dead {
+		// seen maps each encountered dead function to the
+		// one it refers to, on the path to the target.
+		type node struct {
+			fn   *ssa.Function
+			next token.Position
+		}
+		seen := map[token.Position]node{target: {}}
+		queue := []token.Position{target}
+	search:
+		for len(queue) > 0 {
+			posn := queue[0]
+			queue = queue[1:]
+			for _, r := range refs[posn] {
+				fromPosn := prog.Fset.Position(r.from.Pos())
+				if dead[fromPosn] {
+					if _, ok := seen[fromPosn]; !ok {
+						seen[fromPosn] = node{r.from, posn}
+						queue = append(queue, fromPosn)
+					}
+					continue
+				}
+				caller := &jsonCaller{
+					Name:     prettyName(r.from, true),
+					Position: toJSONPosition(prog.Fset.Position(r.pos)),
+				}
+				for ; posn != target; posn = seen[posn].next {
+					caller.Via = append(caller.Via, prettyName(seen[posn].fn, true))
+				}
+				callers[target] = caller
+				break search
+			}
+		}
+	}
+	return callers
+}
+
+// refPos returns the best available position of the reference to a
+// function by instr within the function from: its own, that of an
+// instruction using its value (such as an implicit conversion), or
+// failing that, that of from.
+func refPos(from *ssa.Function, instr ssa.Instruction) token.Pos {
+	if pos := instr.Pos(); pos.IsValid() {
+		return pos
+	}
+	if v, ok := instr.(ssa.Value); ok && v.Referrers() != nil {
+		for _, use := range *v.Referrers() {
+			if pos := use.Pos(); pos.IsValid() {
+				return pos
+			}
+		}
+	}
+	return from.Pos()
 }
 
 // prettyName is a fork of Function.String designed to reduce
@@ -546,6 +703,7 @@ type jsonFunction struct {
 	Name      string       // name (sans package qualifier)
 	Position  jsonPosition // file/line/column of declaration
 	Generated bool         // function is declared in a generated .go file
+	Caller    *jsonCaller  `json:",omitempty"` // nearest live function that refers to it, if any
 }
 
 func (f jsonFunction) String() string { return f.Name }
@@ -558,6 +716,15 @@ type jsonPackage struct {
 
 func (p jsonPackage) String() string { return p.Path }
 
+// The Name and Via names are package-qualified.
+type jsonCaller struct {
+	Name     string       // live function
+	Position jsonPosition // file/line/column of its reference
+	Via      []string     `json:",omitempty"` // dead functions through which it refers, nearest first
+}
+
+func (c jsonCaller) String() string { return c.Name }
+
 // The Initial and Callee names are package-qualified.
 type jsonEdge struct {
 	Initial  string `json:",omitempty"` // initial entrypoint (main or init); first edge only
@@ -82,6 +82,29 @@ by package:
 		Parsed.WriteNode
 		wrNode.writeNode
 
+# Why is a function dead?
+
+In the -json and -f=template formats, each dead function records the
+nearest live function, if any, that refers to it, either directly or
+through a chain of other dead functions. A live function may refer to
+a dead one without calling it: for example, it may take the function's
+address, but make no dynamic call that could reach it. A dead function
+without such a Caller is referred to only by dead code, if at all.
+
+# Baselines
+
+The -baseline=file flag causes the command to report only the dead
+functions absent from the named file, which holds the -json output of
+a previous run, and to fail if there are any. This allows a continuous
+integration check to reject newly dead code without first requiring
+the removal of all existing dead code:
+
+	$ deadcode -json -test ./... > deadcode.json
+	$ deadcode -baseline=deadcode.json -test ./...
+
+Functions are identified by package path and name, so a baseline
+remains valid as the code around the dead functions changes.
+
 # Why is a function not dead?
 
 The -whylive=function flag explain why the named function is not dead
@@ -121,6 +144,13 @@ is static or dynamic, and its source line number. For example:
 		Name      string   // name (sans package qualifier)
 		Position  Position // file/line/column of function declaration
 		Generated bool     // function is declared in a generated .go file
+		Caller    *Caller  // nearest live function that refers to it, if any
+	}
+
+	type Caller struct {
+		Name     string    // live function
+		Position Position  // file/line/column of its reference
+		Via      []string  // dead functions through which it refers, nearest first
 	}
 
 	type Edge struct {
@@ -5,8 +5,7 @@ package main // import "golang.org/x/tools/cmd/digraph"
 
 // TODO(adonovan):
 // - support input files other than stdin
-// - support alternative formats (AT&T GraphViz, CSV, etc),
-//   a comment syntax, etc.
+// - support alternative formats (CSV, etc), a comment syntax, etc.
 // - allow queries to nest, like Blaze query language.
 
 import (
@@ -38,6 +37,8 @@ func usage() {
 //go:embed doc.go
 var doc string
 
+var inputFlag = flag.String("input", "text", "the `format` of the input graph: text or dot")
+
 func main() {
 	flag.Usage = usage
 	flag.Parse()
@@ -265,6 +266,123 @@ func (g graph) somepath(from, to string) error {
 	return fmt.Errorf("no path from %q to %q", from, to)
 }
 
+// layers returns the topological layers of the graph: the nodes of
+// each layer are those whose longest path from a node without
+// predecessors has the layer's index as its length. The nodes of each
+// strongly connected component are treated as a single node, and thus
+// belong to the same layer.
+func (g graph) layers() []nodeset {
+	// Map each node to its component.
+	comp := make(map[string]nodeset)
+	for _, scc := range g.sccs() {
+		for node := range scc {
+			comp[node] = scc
+		}
+	}
+	for node := range g {
+		if comp[node] == nil {
+			comp[node] = singleton(node)
+		}
+	}
+
+	// Compute the layer of each component, by depth-first
+	// search of its predecessors.
+	rev := g.transpose()
+	depth := make(map[string]int) // keyed by a node of the component
+	var visit func(scc nodeset) int
+	visit = func(scc nodeset) int {
+		key := scc.sort()[0]
+		if d, ok := depth[key]; ok {
+			return d
+		}
+		d := 0
+		for node := range scc {
+			for pred := range rev[node] {
+				if !scc[pred] {
+					d = max(d, visit(comp[pred])+1)
+				}
+			}
+		}
+		depth[key] = d
+		return d
+	}
+	var layers []nodeset
+	for node := range g {
+		d := visit(comp[node])
+		for len(layers) <= d {
+			layers = append(layers, make(nodeset))
+		}
+		layers[d][node] = true
+	}
+	return layers
+}
+
+// dominators returns the dominator tree of the nodes reachable from
+// root, as a map from each such node other than root to its immediate
+// dominator.
+func (g graph) dominators(root string) map[string]string {
+	// This is the algorithm of Cooper, Harvey, and Kennedy,
+	// "A Simple, Fast Dominance Algorithm" (2001).
+
+	// Number the nodes in postorder.
+	var postorder nodelist
+	index := make(map[string]int)
+	seen := make(nodeset)
+	var visit func(node string)
+	visit = func(node string) {
+		seen[node] = true
+		for _, succ := range g[node].sort() { // (for determinism)
+			if !seen[succ] {
+				visit(succ)
+			}
+		}
+		index[node] = len(postorder)
+		postorder = append(postorder, node)
+	}
+	visit(root)
+
+	idom := make(map[string]string)
+	idom[root] = root
+	intersect := func(x, y string) string {
+		for x != y {
+			for index[x] < index[y] {
+				x = idom[x]
+			}
+			for index[y] < index[x] {
+				y = idom[y]
+			}
+		}
+		return x
+	}
+	rev := g.transpose()
+	for changed := true; changed; {
+		changed = false
+		// Visit in reverse postorder, skipping the root.
+		for i := len(postorder) - 2; i >= 0; i-- {
+			node := postorder[i]
+			var (
+				newIdom string
+				found   bool // newIdom is set
+			)
+			for pred := range rev[node] {
+				if _, ok := idom[pred]; ok {
+					if !found {
+						newIdom, found = pred, true
+					} else {
+						newIdom = intersect(pred, newIdom)
+					}
+				}
+			}
+			if prev, ok := idom[node]; !ok || prev != newIdom {
+				idom[node] = newIdom
+				changed = true
+			}
+		}
+	}
+	delete(idom, root)
+	return idom
+}
+
 func (g graph) toDot(w *bytes.Buffer) {
 	fmt.Fprintln(w, "digraph {")
 	for _, src := range g.nodelist() {
@@ -279,6 +397,28 @@ func (g graph) toDot(w *bytes.Buffer) {
 	fmt.Fprintln(w, "}")
 }
 
+// toText writes the graph in the input format of digraph, one edge per
+// line, followed by the nodes without edges.
+func (g graph) toText(w *bytes.Buffer) {
+	word := func(node string) string {
+		if node == "" || strings.ContainsFunc(node, func(r rune) bool { return unicode.IsSpace(r) || r == '"' }) {
+			return strconv.Quote(node)
+		}
+		return node
+	}
+	rev := g.transpose()
+	for _, src := range g.nodelist() {
+		for _, dst := range g[src].sort() {
+			fmt.Fprintf(w, "%s %s\n", word(src), word(dst))
+		}
+	}
+	for _, node := range g.nodelist() {
+		if len(g[node]) == 0 && len(rev[node]) == 0 {
+			fmt.Fprintln(w, word(node))
+		}
+	}
+}
+
 func parse(rd io.Reader) (graph, error) {
 	g := make(graph)
 
@@ -316,7 +456,16 @@ var stdout io.Writer = os.Stdout
 
 func digraph(cmd string, args []string) error {
 	// Parse the input graph.
-	g, err := parse(stdin)
+	var g graph
+	var err error
+	switch *inputFlag {
+	case "text":
+		g, err = parse(stdin)
+	case "dot":
+		g, err = parseDot(stdin)
+	default:
+		return fmt.Errorf("invalid -input format %q", *inputFlag)
+	}
 	if err != nil {
 		return err
 	}
@@ -452,6 +601,31 @@ func digraph(cmd string, args []string) error {
 			}
 		}
 
+	case "layers":
+		if len(args) != 0 {
+			return fmt.Errorf("usage: digraph layers")
+		}
+		for _, layer := range g.layers() {
+			layer.sort().println(" ")
+		}
+
+	case "dominators":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: digraph dominators <root>")
+		}
+		root := args[0]
+		if g[root] == nil {
+			return fmt.Errorf("no such node %q", root)
+		}
+		var edges []string
+		for node, idom := range g.dominators(root) {
+			edges = append(edges, idom+" "+node)
+		}
+		sort.Strings(edges)
+		for _, e := range edges {
+			fmt.Fprintln(stdout, e)
+		}
+
 	case "focus":
 		if len(args) != 1 {
 			return fmt.Errorf("usage: digraph focus <node>")
@@ -483,11 +657,15 @@ func digraph(cmd string, args []string) error {
 		fmt.Fprintln(stdout, strings.Join(edgesSorted, "\n"))
 
 	case "to":
-		if len(args) != 1 || args[0] != "dot" {
-			return fmt.Errorf("usage: digraph to dot")
+		if len(args) != 1 || args[0] != "dot" && args[0] != "text" {
+			return fmt.Errorf("usage: digraph to (dot | text)")
 		}
 		var b bytes.Buffer
-		g.toDot(&b)
+		if args[0] == "dot" {
+			g.toDot(&b)
+		} else {
+			g.toText(&b)
+		}
 		stdout.Write(b.Bytes())
 
 	default:
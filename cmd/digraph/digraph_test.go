@@ -49,6 +49,11 @@ e e
 		{"succs-long-token", g2 + "x " + strings.Repeat("x", 96*1024), "succs", []string{"x"}, strings.Repeat("x", 96*1024) + "\n"},
 		{"preds", g2, "preds", []string{"c"}, "a\nd\n"},
 		{"preds multiple args", g2, "preds", []string{"c", "d"}, "a\nb\nc\nd\n"},
+		{"layers", g1, "layers", nil, "hat shirt shorts socks\npants sweater tie\nbelt jacket shoes\n"},
+		{"layers with cycles", g2, "layers", nil, "a e\nb\nc d\n"},
+		{"dominators", g1, "dominators", []string{"shorts"}, "pants belt\npants shoes\nshorts pants\n"},
+		{"dominators with cycles", g2, "dominators", []string{"a"}, "a b\na c\na d\n"},
+		{"to text", "a b c\n\"x y\"\n", "to", []string{"text"}, "a b\na c\n\"x y\"\n"},
 	} {
 		t.Run(test.name, func(t *testing.T) {
 			stdin = strings.NewReader(test.input)
@@ -406,3 +411,65 @@ c "d\"\\d"`
 	}
 
 }
+
+func TestParseDot(t *testing.T) {
+	for _, test := range []struct {
+		name, in, want string
+	}{
+		{
+			"edges",
+			`digraph G { a -> b -> c; a -> c [color=red]; d }`,
+			"a b\na c\nb c\nd\n",
+		},
+		{
+			"output of digraph to dot",
+			`digraph {
+	"a" -> "b";
+	"b" -> "d\"\\d";
+}`,
+			"a b\nb \"d\\\"\\\\d\"\n",
+		},
+		{
+			"undirected",
+			`strict graph { a -- b }`,
+			"a b\nb a\n",
+		},
+		{
+			"subgraphs, attributes, ports, and comments",
+			`# preprocessor line
+digraph {
+	graph [rankdir=LR]; node [shape=box]
+	label = "x -> y" // a graph attribute
+	/* a comment */
+	a:p1:n -> { b c } -> subgraph cluster_0 { d; e -> f }
+	<<b>html</b>> -> -1.5
+}`,
+			"<b>html</b> -1.5\na b\na c\nb d\nb e\nb f\nc d\nc e\nc f\ne f\n",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			g, err := parseDot(strings.NewReader(test.in))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var b bytes.Buffer
+			g.toText(&b)
+			if got := b.String(); got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+
+	for _, test := range []struct {
+		in, want string
+	}{
+		{`a -> b`, `at line 1: want graph or digraph, got "a"`},
+		{`digraph { a -> }`, `at line 1: unexpected "}"`},
+		{"digraph {\n a -> \"b }", `at line 2: unterminated string`},
+		{`digraph { a -> b`, `at line 1: unexpected end of input`},
+	} {
+		if _, err := parseDot(strings.NewReader(test.in)); err == nil || err.Error() != test.want {
+			t.Errorf("parseDot(%q) = %v, want %s", test.in, err, test.want)
+		}
+	}
+}
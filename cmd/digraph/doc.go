@@ -9,7 +9,7 @@ typical UNIX command pipelines.
 
 Usage:
 
-	your-application | digraph [command]
+	your-application | digraph [-input=dot] [command]
 
 The supported commands are:
 
@@ -35,10 +35,18 @@ The supported commands are:
 		all strongly connected components (one per line)
 	scc <node>
 		the set of nodes strongly connected to the specified one
+	layers
+		the topological layers of the graph (one per line), each node in the layer
+		after that of its last predecessor, and the nodes of a cycle in the same layer
+	dominators <node>
+		the dominator tree of the nodes reachable from the specified node, as a list
+		of edges from each node's immediate dominator to the node
 	focus <node>
 		the subgraph containing all directed paths that pass through the specified node
 	to dot
-		print the graph in Graphviz dot format (other formats may be supported in the future)
+		print the graph in Graphviz dot format
+	to text
+		print the graph in the input format described below
 
 Input format:
 
@@ -49,6 +57,11 @@ and other characters to be expressed.
 Each word declares a node, and if there are more than one, an edge from the
 first to each subsequent one. The graph is provided on the standard input.
 
+With the -input=dot flag, the graph is instead read in the Graphviz DOT
+language. Only its nodes and edges are significant: attributes, ports,
+and subgraph names are ignored, and each edge of an undirected graph is
+treated as a pair of edges, one in each direction.
+
 For instance, the following (acyclic) graph specifies a partial order among the
 subtasks of getting dressed:
 
@@ -91,5 +104,14 @@ Visualize the package dependency graph of the current package:
 Using a module graph produced by go mod, show all dependencies of the current module:
 
 	$ go mod graph | digraph forward $(go list -m)
+
+Show the modules that every path from the current module to a given one passes through:
+
+	$ go mod graph | digraph dominators $(go list -m) | digraph reverse example.com/m@v1.2.3
+
+Convert a graph in DOT format, such as one produced by another tool, for use
+with other commands:
+
+	$ digraph -input=dot to text < graph.dot
 */
 package main
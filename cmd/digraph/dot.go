@@ -0,0 +1,377 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+// This file defines the parser of graphs in the Graphviz DOT language.
+// See https://graphviz.org/doc/info/lang.html.
+//
+// Only the structure of the graph is retained: attributes, ports, and
+// subgraph identities are parsed and discarded. The edges of an
+// undirected graph are treated as edges in both directions.
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// parseDot parses a graph in DOT format.
+func parseDot(rd io.Reader) (graph, error) {
+	data, err := io.ReadAll(rd)
+	if err != nil {
+		return nil, err
+	}
+	p := &dotParser{src: string(data), line: 1, g: make(graph)}
+	if err := p.parse(); err != nil {
+		return nil, fmt.Errorf("at line %d: %v", p.line, err)
+	}
+	return p.g, nil
+}
+
+// Kinds of DOT tokens, other than punctuation, which is its own kind.
+const (
+	dotEOF = "EOF"
+	dotID  = "ID" // identifier, numeral, or quoted or HTML string
+)
+
+type dotParser struct {
+	src        string
+	line       int    // line of the current token
+	kind, text string // current token
+	quoted     bool   // current ID was quoted (so cannot be a keyword)
+	undirected bool
+	g          graph
+}
+
+// next advances to the next token.
+func (p *dotParser) next() error {
+	p.quoted = false
+	// Skip spaces and comments, including lines
+	// starting with '#' (C preprocessor output).
+	atLineStart := p.line == 1 && p.text == ""
+	for p.src != "" {
+		switch {
+		case p.src[0] == '\n':
+			p.line++
+			p.src = p.src[1:]
+			atLineStart = true
+			continue
+		case p.src[0] == ' ' || p.src[0] == '\t' || p.src[0] == '\r':
+			p.src = p.src[1:]
+			continue
+		case atLineStart && p.src[0] == '#':
+			p.skipTo("\n", 0)
+			continue
+		case strings.HasPrefix(p.src, "//"):
+			p.skipTo("\n", 0)
+			continue
+		case strings.HasPrefix(p.src, "/*"):
+			if !p.skipTo("*/", 2) {
+				return fmt.Errorf("unterminated comment")
+			}
+			continue
+		}
+		break
+	}
+	if p.src == "" {
+		p.kind, p.text = dotEOF, ""
+		return nil
+	}
+
+	switch c := p.src[0]; {
+	case strings.HasPrefix(p.src, "->"), strings.HasPrefix(p.src, "--"):
+		p.kind, p.text, p.src = p.src[:2], p.src[:2], p.src[2:]
+
+	case strings.IndexByte("{}[];,=:", c) >= 0:
+		p.kind, p.text, p.src = p.src[:1], p.src[:1], p.src[1:]
+
+	case c == '"':
+		// Within a quoted string, only \" is an escape,
+		// but we also unescape \\ so that the output of
+		// "digraph to dot" can be read back.
+		var b strings.Builder
+		i := 1
+		for ; i < len(p.src) && p.src[i] != '"'; i++ {
+			if p.src[i] == '\n' {
+				p.line++
+			}
+			if p.src[i] == '\\' && i+1 < len(p.src) {
+				switch p.src[i+1] {
+				case '"', '\\':
+					i++
+				case '\n': // line continuation
+					p.line++
+					i++
+					continue
+				}
+			}
+			b.WriteByte(p.src[i])
+		}
+		if i == len(p.src) {
+			return fmt.Errorf("unterminated string")
+		}
+		p.kind, p.text, p.src, p.quoted = dotID, b.String(), p.src[i+1:], true
+
+	case c == '<':
+		// An HTML string: balanced angle brackets.
+		depth := 0
+		i := 0
+		for ; i < len(p.src); i++ {
+			switch p.src[i] {
+			case '<':
+				depth++
+			case '>':
+				depth--
+			case '\n':
+				p.line++
+			}
+			if depth == 0 {
+				break
+			}
+		}
+		if i == len(p.src) {
+			return fmt.Errorf("unterminated HTML string")
+		}
+		p.kind, p.text, p.src, p.quoted = dotID, p.src[1:i], p.src[i+1:], true
+
+	default:
+		// An identifier or numeral.
+		i := 0
+		for i < len(p.src) {
+			r, size := utf8.DecodeRuneInString(p.src[i:])
+			if !(r == '_' || r == '.' || r >= 0x80 || unicode.IsLetter(r) || unicode.IsDigit(r)) {
+				break
+			}
+			i += size
+		}
+		if c == '-' {
+			// A negative numeral.
+			i = 1
+			for i < len(p.src) && (p.src[i] == '.' || '0' <= p.src[i] && p.src[i] <= '9') {
+				i++
+			}
+		}
+		if i == 0 || p.src[:i] == "-" {
+			return fmt.Errorf("unexpected character %q", c)
+		}
+		p.kind, p.text, p.src = dotID, p.src[:i], p.src[i:]
+	}
+	return nil
+}
+
+// skipTo advances the input past the next occurrence of delim, after
+// skipping the first n bytes, and reports whether there was one.
+func (p *dotParser) skipTo(delim string, n int) bool {
+	i := strings.Index(p.src[n:], delim)
+	if i < 0 {
+		p.line += strings.Count(p.src, "\n")
+		p.src = ""
+		return false
+	}
+	i += n + len(delim)
+	p.line += strings.Count(p.src[:i], "\n")
+	p.src = p.src[i:]
+	return true
+}
+
+// keyword reports whether the current token is the specified keyword,
+// which is case-insensitive.
+func (p *dotParser) keyword(kw string) bool {
+	return p.kind == dotID && !p.quoted && strings.EqualFold(p.text, kw)
+}
+
+// expect consumes a token of the specified kind.
+func (p *dotParser) expect(kind string) error {
+	if p.kind != kind {
+		return p.unexpected()
+	}
+	return p.next()
+}
+
+func (p *dotParser) unexpected() error {
+	if p.kind == dotEOF {
+		return fmt.Errorf("unexpected end of input")
+	}
+	return fmt.Errorf("unexpected %q", p.text)
+}
+
+// parse parses a graph:
+//
+//	[ strict ] (graph | digraph) [ ID ] '{' stmt_list '}'
+func (p *dotParser) parse() error {
+	if err := p.next(); err != nil {
+		return err
+	}
+	if p.keyword("strict") {
+		if err := p.next(); err != nil {
+			return err
+		}
+	}
+	switch {
+	case p.keyword("digraph"):
+	case p.keyword("graph"):
+		p.undirected = true
+	default:
+		return fmt.Errorf("want graph or digraph, got %q", p.text)
+	}
+	if err := p.next(); err != nil {
+		return err
+	}
+	if p.kind == dotID {
+		if err := p.next(); err != nil {
+			return err
+		}
+	}
+	if _, err := p.block(); err != nil {
+		return err
+	}
+	if p.kind != dotEOF {
+		return p.unexpected()
+	}
+	return nil
+}
+
+// block parses a statement list in braces, and returns the nodes
+// mentioned within it.
+func (p *dotParser) block() (nodeset, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	nodes := make(nodeset)
+	for p.kind != "}" {
+		if err := p.stmt(nodes); err != nil {
+			return nil, err
+		}
+		if p.kind == ";" {
+			if err := p.next(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return nodes, p.next()
+}
+
+// stmt parses a statement, adding the nodes it mentions to nodes.
+func (p *dotParser) stmt(nodes nodeset) error {
+	// attr_stmt: (graph | node | edge) attr_list
+	if p.keyword("graph") || p.keyword("node") || p.keyword("edge") {
+		if err := p.next(); err != nil {
+			return err
+		}
+		return p.attrs()
+	}
+
+	// edge_stmt: operand (edgeop operand)+ [ attr_list ]
+	// node_stmt: node_id [ attr_list ]
+	from, id, err := p.operand()
+	if err != nil {
+		return err
+	}
+	if p.kind == "=" && id != "" {
+		// ID '=' ID: a graph attribute.
+		if err := p.next(); err != nil {
+			return err
+		}
+		return p.expect(dotID)
+	}
+	nodes.addAll(from)
+	for p.kind == "->" || p.kind == "--" {
+		if err := p.next(); err != nil {
+			return err
+		}
+		to, _, err := p.operand()
+		if err != nil {
+			return err
+		}
+		for x := range from {
+			for y := range to {
+				p.g.addEdges(x, y)
+				if p.undirected {
+					p.g.addEdges(y, x)
+				}
+			}
+		}
+		nodes.addAll(to)
+		from = to
+	}
+	return p.attrs()
+}
+
+// operand parses a node ID, with an optional port, or a subgraph, and
+// returns the set of its nodes. If it is a node, id is its name.
+func (p *dotParser) operand() (_ nodeset, id string, _ error) {
+	// subgraph: [ subgraph [ ID ] ] '{' stmt_list '}'
+	if p.keyword("subgraph") {
+		if err := p.next(); err != nil {
+			return nil, "", err
+		}
+		if p.kind == dotID {
+			if err := p.next(); err != nil {
+				return nil, "", err
+			}
+		}
+	}
+	if p.kind == "{" {
+		nodes, err := p.block()
+		return nodes, "", err
+	}
+
+	// node_id: ID [ ':' ID [ ':' ID ] ]
+	if p.kind != dotID {
+		return nil, "", p.unexpected()
+	}
+	id = p.text
+	if err := p.next(); err != nil {
+		return nil, "", err
+	}
+	if p.kind != "=" {
+		p.g.addNode(id)
+	}
+	for range 2 {
+		if p.kind != ":" {
+			break
+		}
+		if err := p.next(); err != nil {
+			return nil, "", err
+		}
+		if err := p.expect(dotID); err != nil {
+			return nil, "", err
+		}
+	}
+	return singleton(id), id, nil
+}
+
+// attrs parses an optional attribute list:
+//
+//	'[' [ ID '=' ID [ (';' | ',') ] ]... ']' ...
+func (p *dotParser) attrs() error {
+	for p.kind == "[" {
+		if err := p.next(); err != nil {
+			return err
+		}
+		for p.kind != "]" {
+			if err := p.expect(dotID); err != nil {
+				return err
+			}
+			if err := p.expect("="); err != nil {
+				return err
+			}
+			if err := p.expect(dotID); err != nil {
+				return err
+			}
+			if p.kind == ";" || p.kind == "," {
+				if err := p.next(); err != nil {
+					return err
+				}
+			}
+		}
+		if err := p.next(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -7,40 +7,109 @@
 //
 // Usage:
 //
-//	file2fuzz [-o output] [input...]
+//	file2fuzz [-r] [-o output] [input...]
 //
 // The default behavior is to read input from stdin and write the converted
 // output to stdout. If any position arguments are provided stdin is ignored
-// and the arguments are assumed to be input files to convert.
+// and the arguments are assumed to be input files to convert. An input
+// directory stands for all the files beneath it.
 //
 // The -o flag provides a path to write output files to. If only one positional
 // argument is specified it may be a file path or an existing directory, if there are
 // multiple inputs specified it must be a directory. If a directory is provided
-// the name of the file will be the SHA-256 hash of its contents.
+// the name of the file will be the SHA-256 hash of its contents, so inputs
+// with the same contents, and those already in the directory, are written
+// only once.
+//
+// The -r flag reverses the conversion: it converts Go fuzzing corpus files,
+// such as those in testdata/fuzz/FuzzXxx, back to raw files, for use by
+// other fuzzers. Each corpus file must hold a single []byte or string value.
 package main
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"errors"
 	"flag"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"io"
+	"io/fs"
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
 )
 
 // encVersion1 is version 1 Go fuzzer corpus encoding.
 var encVersion1 = "go test fuzz v1"
 
-func encodeByteSlice(b []byte) []byte {
-	return fmt.Appendf(nil, "%s\n[]byte(%q)", encVersion1, b)
+func encodeByteSlice(b []byte) ([]byte, error) {
+	return fmt.Appendf(nil, "%s\n[]byte(%q)", encVersion1, b), nil
+}
+
+// decodeByteSlice decodes a corpus file holding a single []byte or
+// string value.
+func decodeByteSlice(b []byte) ([]byte, error) {
+	header, rest, _ := bytes.Cut(b, []byte("\n"))
+	if string(bytes.TrimSpace(header)) != encVersion1 {
+		return nil, fmt.Errorf("not a Go fuzzing corpus file (want %q header)", encVersion1)
+	}
+	var value []byte
+	n := 0
+	for line := range bytes.Lines(rest) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		if n++; n > 1 {
+			return nil, fmt.Errorf("corpus file has more than one value")
+		}
+		expr, err := parser.ParseExpr(string(line))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value: %v", err)
+		}
+		// []byte("...") or string("...")
+		call, ok := expr.(*ast.CallExpr)
+		if !ok || len(call.Args) != 1 || !isByteSliceOrString(call.Fun) {
+			return nil, fmt.Errorf("value %s is not a []byte or string", line)
+		}
+		lit, ok := call.Args[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return nil, fmt.Errorf("value %s is not a literal", line)
+		}
+		s, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %s: %v", line, err)
+		}
+		value = []byte(s)
+	}
+	if n == 0 {
+		return nil, fmt.Errorf("corpus file has no value")
+	}
+	return value, nil
+}
+
+// isByteSliceOrString reports whether the type expression is []byte
+// or string.
+func isByteSliceOrString(typ ast.Expr) bool {
+	switch typ := typ.(type) {
+	case *ast.Ident:
+		return typ.Name == "string"
+	case *ast.ArrayType:
+		elem, ok := typ.Elt.(*ast.Ident)
+		return typ.Len == nil && ok && (elem.Name == "byte" || elem.Name == "uint8")
+	}
+	return false
 }
 
 func usage() {
-	fmt.Fprintf(os.Stderr, "usage: file2fuzz [-o output] [input...]\nconverts files to Go fuzzer corpus format\n")
-	fmt.Fprintf(os.Stderr, "\tinput: files to convert\n")
+	fmt.Fprintf(os.Stderr, "usage: file2fuzz [-r] [-o output] [input...]\nconverts files to Go fuzzer corpus format\n")
+	fmt.Fprintf(os.Stderr, "\tinput: files, or directories of files, to convert\n")
 	fmt.Fprintf(os.Stderr, "\t-o: where to write converted file(s)\n")
+	fmt.Fprintf(os.Stderr, "\t-r: convert Go fuzzer corpus files to raw files\n")
 	os.Exit(2)
 }
 func dirWriter(dir string) func([]byte) error {
@@ -50,6 +119,9 @@ func dirWriter(dir string) func([]byte) error {
 		if err := os.MkdirAll(dir, 0777); err != nil {
 			return err
 		}
+		if _, err := os.Stat(name); err == nil {
+			return nil // a duplicate
+		}
 		if err := os.WriteFile(name, b, 0666); err != nil {
 			os.Remove(name)
 			return err
@@ -58,29 +130,38 @@ func dirWriter(dir string) func([]byte) error {
 	}
 }
 
-func convert(inputArgs []string, outputArg string) error {
-	var input []io.Reader
+func convert(inputArgs []string, outputArg string, reverse bool) error {
+	// The input files; "" denotes stdin.
+	var input []string
+	fromDir := false
 	if args := inputArgs; len(args) == 0 {
-		input = []io.Reader{os.Stdin}
+		input = []string{""}
 	} else {
 		for _, a := range args {
-			f, err := os.Open(a)
+			fi, err := os.Stat(a)
 			if err != nil {
 				return fmt.Errorf("unable to open %q: %s", a, err)
 			}
-			defer f.Close()
-			if fi, err := f.Stat(); err != nil {
-				return fmt.Errorf("unable to open %q: %s", a, err)
-			} else if fi.IsDir() {
-				return fmt.Errorf("%q is a directory, not a file", a)
+			if !fi.IsDir() {
+				input = append(input, a)
+				continue
+			}
+			fromDir = true
+			err = filepath.WalkDir(a, func(path string, d fs.DirEntry, err error) error {
+				if err == nil && d.Type().IsRegular() {
+					input = append(input, path)
+				}
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("unable to read directory %q: %s", a, err)
 			}
-			input = append(input, f)
 		}
 	}
 
 	var output func([]byte) error
 	if outputArg == "" {
-		if len(inputArgs) > 1 {
+		if len(input) > 1 {
 			return errors.New("-o required with multiple input files")
 		}
 		output = func(b []byte) error {
@@ -88,7 +169,7 @@ func convert(inputArgs []string, outputArg string) error {
 			return err
 		}
 	} else {
-		if len(inputArgs) > 1 {
+		if len(inputArgs) > 1 || fromDir {
 			output = dirWriter(outputArg)
 		} else {
 			if fi, err := os.Stat(outputArg); err != nil && !os.IsNotExist(err) {
@@ -103,12 +184,31 @@ func convert(inputArgs []string, outputArg string) error {
 		}
 	}
 
-	for _, f := range input {
-		b, err := io.ReadAll(f)
+	conv := encodeByteSlice
+	if reverse {
+		conv = decodeByteSlice
+	}
+	for _, name := range input {
+		var (
+			b   []byte
+			err error
+		)
+		if name == "" {
+			b, err = io.ReadAll(os.Stdin)
+		} else {
+			b, err = os.ReadFile(name)
+		}
 		if err != nil {
 			return fmt.Errorf("unable to read input: %s", err)
 		}
-		if err := output(encodeByteSlice(b)); err != nil {
+		b, err = conv(b)
+		if err != nil {
+			if name == "" {
+				name = "stdin"
+			}
+			return fmt.Errorf("unable to convert %s: %s", name, err)
+		}
+		if err := output(b); err != nil {
 			return fmt.Errorf("unable to write output: %s", err)
 		}
 	}
@@ -121,10 +221,11 @@ func main() {
 	log.SetPrefix("file2fuzz: ")
 
 	output := flag.String("o", "", "where to write converted file(s)")
+	reverse := flag.Bool("r", false, "convert Go fuzzer corpus files to raw files")
 	flag.Usage = usage
 	flag.Parse()
 
-	if err := convert(flag.Args(), *output); err != nil {
+	if err := convert(flag.Args(), *output, *reverse); err != nil {
 		log.Fatal(err)
 	}
 }
@@ -113,6 +113,60 @@ func TestFile2Fuzz(t *testing.T) {
 			inputFiles:    []file{{name: "output", dir: true}, {name: "input", content: "hello"}, {name: "input-2", content: "hello :)"}},
 			expectedError: "file2fuzz: -o required with multiple input files\n",
 		},
+		{
+			name:       "input directory, output directory",
+			args:       []string{"-o", "output", "input"},
+			inputFiles: []file{{name: "input", dir: true}, {name: "input/sub", dir: true}, {name: "input/a", content: "hello"}, {name: "input/sub/b", content: "hello :)"}, {name: "input/c", content: "hello"}},
+			expectedFiles: []file{
+				{name: "output/ffc7b87a0377262d4f77926bd235551d78e6037bbe970d81ec39ac1d95542f7b", content: "go test fuzz v1\n[]byte(\"hello\")"},
+				{name: "output/28059db30ce420ff65b2c29b749804c69c601aeca21b3cbf0644244ff080d7a5", content: "go test fuzz v1\n[]byte(\"hello :)\")"},
+			},
+		},
+		{
+			name:          "input directory, no output",
+			args:          []string{"input"},
+			inputFiles:    []file{{name: "input", dir: true}, {name: "input/a", content: "hello"}, {name: "input/b", content: "hello :)"}},
+			expectedError: "file2fuzz: -o required with multiple input files\n",
+		},
+		{
+			name:           "reverse, stdin, stdout",
+			args:           []string{"-r"},
+			stdin:          "go test fuzz v1\n[]byte(\"hello\\x00\")\n",
+			expectedStdout: "hello\x00",
+		},
+		{
+			name:           "reverse, string value",
+			args:           []string{"-r"},
+			stdin:          "go test fuzz v1\nstring(`hello`)",
+			expectedStdout: "hello",
+		},
+		{
+			name:       "reverse, input directory, output directory",
+			args:       []string{"-r", "-o", "output", "input"},
+			inputFiles: []file{{name: "input", dir: true}, {name: "input/a", content: "go test fuzz v1\n[]byte(\"hello\")"}, {name: "input/b", content: "go test fuzz v1\n[]byte(\"hello :)\")"}},
+			expectedFiles: []file{
+				{name: "output/2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", content: "hello"},
+				{name: "output/f034f9986ae0fa2e3de3b40fcc378bacf6a5a01d269af841121501f610ddc65b", content: "hello :)"},
+			},
+		},
+		{
+			name:          "reverse, not a corpus file",
+			args:          []string{"-r", "input"},
+			inputFiles:    []file{{name: "input", content: "hello"}},
+			expectedError: "file2fuzz: unable to convert input: not a Go fuzzing corpus file (want \"go test fuzz v1\" header)\n",
+		},
+		{
+			name:          "reverse, several values",
+			args:          []string{"-r"},
+			stdin:         "go test fuzz v1\n[]byte(\"a\")\nint(1)\n",
+			expectedError: "file2fuzz: unable to convert stdin: corpus file has more than one value\n",
+		},
+		{
+			name:          "reverse, unsupported type",
+			args:          []string{"-r"},
+			stdin:         "go test fuzz v1\nint(1)\n",
+			expectedError: "file2fuzz: unable to convert stdin: value int(1) is not a []byte or string\n",
+		},
 	}
 
 	for _, tc := range tests {
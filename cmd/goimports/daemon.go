@@ -0,0 +1,334 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+// This file defines the daemon mode, in which a long-lived goimports
+// process serves the formatting requests of goimports clients over a
+// local socket, so that its index of the module cache, which a cold
+// goimports must rebuild by scanning the cache, stays warm.
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/tools/internal/gocommand"
+	"golang.org/x/tools/internal/imports"
+)
+
+var (
+	daemon = flag.Bool("daemon", false, "run as a daemon that serves the formatting requests of goimports -remote over the -socket")
+	remote = flag.Bool("remote", false, "send formatting requests to the daemon listening on the -socket, if any")
+	socket = flag.String("socket", defaultSocket(), "the `path` of the daemon's socket")
+)
+
+// defaultSocket returns the default path of the daemon's socket, in a
+// directory private to the user, or "" if there is none.
+func defaultSocket() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "goimports.sock")
+	}
+	if dir, err := os.UserCacheDir(); err == nil {
+		return filepath.Join(dir, "goimports", "daemon.sock")
+	}
+	return ""
+}
+
+// checkSocket reports an error unless the directory of the socket
+// belongs to the current user and is inaccessible to others, so that
+// no other user can create or replace the socket, and the socket, if
+// it exists, belongs to the current user too.
+func checkSocket(path string) error {
+	dir := filepath.Dir(path)
+	fi, err := os.Lstat(dir)
+	if err != nil {
+		return err
+	}
+	if !fi.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	if err := checkPrivate(dir, fi); err != nil {
+		return err
+	}
+	fi, err = os.Lstat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if fi.Mode().Type() != os.ModeSocket {
+		return fmt.Errorf("%s is not a socket", path)
+	}
+	return checkPrivate(path, fi)
+}
+
+// A request is a formatting request from a client to the daemon.
+type request struct {
+	Filename string   // absolute
+	Src      []byte   // content of the file
+	Dir      string   // working directory of the client
+	Env      []string // Go environment variables of the client
+	Options  requestOptions
+}
+
+// requestOptions holds the imports.Options of a request, except Env.
+type requestOptions struct {
+	LocalPrefix string
+	Groups      []string
+	Fragment    bool
+	AllErrors   bool
+	Comments    bool
+	TabIndent   bool
+	TabWidth    int
+	FormatOnly  bool
+}
+
+// A response is the daemon's response to a request.
+type response struct {
+	Result []byte
+	Error  string
+}
+
+// processRemote processes the file as imports.Process does, but sends
+// the request to the daemon. It reports whether the daemon was reached.
+func processRemote(filename string, src []byte, opt *imports.Options) ([]byte, bool, error) {
+	if *socket == "" {
+		return nil, false, nil
+	}
+	if err := checkSocket(*socket); err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Printf("not using daemon: %v", err)
+		}
+		return nil, false, nil
+	}
+	conn, err := net.Dial("unix", *socket)
+	if err != nil {
+		if verbose {
+			log.Printf("daemon unavailable, processing locally: %v", err)
+		}
+		return nil, false, nil
+	}
+	defer conn.Close()
+
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return nil, true, err
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, true, err
+	}
+	req := request{
+		Filename: abs,
+		Src:      src,
+		Dir:      dir,
+		Env:      goEnviron(os.Environ()),
+		Options: requestOptions{
+			LocalPrefix: opt.LocalPrefix,
+			Groups:      opt.Groups,
+			Fragment:    opt.Fragment,
+			AllErrors:   opt.AllErrors,
+			Comments:    opt.Comments,
+			TabIndent:   opt.TabIndent,
+			TabWidth:    opt.TabWidth,
+			FormatOnly:  opt.FormatOnly,
+		},
+	}
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return nil, true, fmt.Errorf("sending request to daemon: %v", err)
+	}
+	var resp response
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return nil, true, fmt.Errorf("reading response from daemon: %v", err)
+	}
+	if resp.Error != "" {
+		// Report positions relative to the name given by the user.
+		return nil, true, errors.New(strings.ReplaceAll(resp.Error, abs, filename))
+	}
+	return resp.Result, true, nil
+}
+
+// goEnviron returns the variables of environ that affect the go command.
+func goEnviron(environ []string) []string {
+	var env []string
+	for _, kv := range environ {
+		if strings.HasPrefix(kv, "GO") || strings.HasPrefix(kv, "CGO_") {
+			env = append(env, kv)
+		}
+	}
+	sort.Strings(env)
+	return env
+}
+
+// A server serves the requests of clients. It keeps a process
+// environment, with its resolver, for each distinct working directory
+// and Go environment of its clients; they share the index of the
+// module cache.
+type server struct {
+	runner   *gocommand.Runner
+	modCache *imports.DirInfoCache
+
+	mu   sync.Mutex // guards envs
+	envs map[string]*serverEnv
+}
+
+// A serverEnv is the process environment of a group of clients. Its
+// requests are processed one at a time, as a ProcessEnv and its
+// resolver are not safe for concurrent use, but those of different
+// environments are processed concurrently.
+type serverEnv struct {
+	mu      sync.Mutex // guards the fields below and the use of env
+	env     *imports.ProcessEnv
+	gomod   string    // go.mod file of the main module, if any
+	modTime time.Time // its modification time
+}
+
+func newServer() *server {
+	return &server{
+		runner:   &gocommand.Runner{},
+		modCache: imports.NewDirInfoCache(),
+		envs:     make(map[string]*serverEnv),
+	}
+}
+
+// serve runs the daemon, serving requests until the process is
+// terminated.
+func serve() error {
+	if *socket == "" {
+		return fmt.Errorf("no -socket given, and no user cache directory")
+	}
+	// Create the default directory of the socket.
+	if err := os.MkdirAll(filepath.Dir(*socket), 0700); err != nil {
+		return err
+	}
+	l, err := listen(*socket)
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+	if verbose {
+		log.Printf("listening on %s", *socket)
+	}
+	return newServer().serve(l)
+}
+
+// listen listens on the socket at path, unless another daemon is
+// already listening on it, or the socket is not private to the user.
+func listen(path string) (net.Listener, error) {
+	if err := checkSocket(path); err != nil {
+		return nil, fmt.Errorf("unsafe socket: %v", err)
+	}
+	// Remove the socket of a previous daemon that is no longer running.
+	if conn, err := net.Dial("unix", path); err == nil {
+		conn.Close()
+		return nil, fmt.Errorf("a daemon is already listening on %s", path)
+	}
+	os.Remove(path)
+	return net.Listen("unix", path)
+}
+
+// serve serves the requests of the connections accepted by l until it
+// is closed.
+func (s *server) serve(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go s.handle(conn)
+	}
+}
+
+// handle serves a single request on conn.
+func (s *server) handle(conn net.Conn) {
+	defer conn.Close()
+	var req request
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		log.Printf("reading request: %v", err)
+		return
+	}
+	var resp response
+	result, err := s.process(&req)
+	if err != nil {
+		resp.Error = err.Error()
+	} else {
+		resp.Result = result
+	}
+	if err := json.NewEncoder(conn).Encode(resp); err != nil {
+		log.Printf("sending response: %v", err)
+	}
+}
+
+// process processes the file of req.
+func (s *server) process(req *request) ([]byte, error) {
+	key := req.Dir + "\x00" + strings.Join(req.Env, "\x00")
+	s.mu.Lock()
+	se, ok := s.envs[key]
+	if !ok {
+		env := &imports.ProcessEnv{
+			GocmdRunner: s.runner,
+			Env:         make(map[string]string),
+			WorkingDir:  req.Dir,
+			ModCache:    s.modCache,
+		}
+		for _, kv := range req.Env {
+			k, v, _ := strings.Cut(kv, "=")
+			env.Env[k] = v
+		}
+		if verbose {
+			env.Logf = log.Printf
+		}
+		se = &serverEnv{env: env}
+		s.envs[key] = se
+	}
+	s.mu.Unlock()
+
+	se.mu.Lock()
+	defer se.mu.Unlock()
+	if ok {
+		// Forget the scanned contents of the workspace, which may
+		// have changed, but not those of the (immutable) module
+		// cache, and reload the module information if go.mod
+		// has changed.
+		if r, err := se.env.GetResolver(); err == nil {
+			se.env.UpdateResolver(r.ClearForNewScan())
+		}
+		if se.gomod != "" {
+			if fi, err := os.Stat(se.gomod); err != nil || !fi.ModTime().Equal(se.modTime) {
+				se.env.ClearModuleInfo()
+			}
+		}
+	}
+
+	opt := &imports.Options{
+		Env:         se.env,
+		LocalPrefix: req.Options.LocalPrefix,
+		Groups:      req.Options.Groups,
+		Fragment:    req.Options.Fragment,
+		AllErrors:   req.Options.AllErrors,
+		Comments:    req.Options.Comments,
+		TabIndent:   req.Options.TabIndent,
+		TabWidth:    req.Options.TabWidth,
+		FormatOnly:  req.Options.FormatOnly,
+	}
+	result, err := imports.Process(req.Filename, req.Src, opt)
+
+	// Record the state of go.mod, now that the env is initialized.
+	if gomod := se.env.Env["GOMOD"]; gomod != "" && gomod != os.DevNull {
+		if fi, err := os.Stat(gomod); err == nil {
+			se.gomod, se.modTime = gomod, fi.ModTime()
+		}
+	}
+	return result, err
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/internal/testenv"
+)
+
+const daemonInput = `package p
+
+func f() { fmt.Println() }
+`
+
+// TestRemote checks that a request sent by processRemote is processed
+// by a daemon, and that processFile falls back to processing the file
+// itself when no daemon is listening.
+func TestRemote(t *testing.T) {
+	testenv.NeedsTool(t, "go")
+
+	// A socket path may not be long, so don't use t.TempDir.
+	dir, err := os.MkdirTemp("", "goimports")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	oldSocket, oldRemote := *socket, *remote
+	defer func() { *socket, *remote = oldSocket, oldRemote }()
+	*socket = filepath.Join(dir, "sock")
+	*remote = true
+	filename := filepath.Join(dir, "p.go")
+
+	// Without a daemon, the request is not sent,
+	// and processFile processes the file itself.
+	if _, ok, err := processRemote(filename, []byte(daemonInput), options); ok || err != nil {
+		t.Fatalf("processRemote without daemon = ok %t, err %v; want false, nil", ok, err)
+	}
+	var out bytes.Buffer
+	if err := processFile(filename, strings.NewReader(daemonInput), &out, singleArg); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), `import "fmt"`) {
+		t.Errorf("processFile without daemon: got\n%s\nwant import of fmt", out.String())
+	}
+
+	// With a daemon, the request is processed by it.
+	l, err := listen(*socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	s := newServer()
+	go s.serve(l)
+
+	if _, err := listen(*socket); err == nil {
+		t.Errorf("listen succeeded on the socket of a running daemon")
+	}
+
+	res, ok, err := processRemote(filename, []byte(daemonInput), options)
+	if !ok || err != nil {
+		t.Fatalf("processRemote = ok %t, err %v; want true, nil", ok, err)
+	}
+	if !strings.Contains(string(res), `import "fmt"`) {
+		t.Errorf("processRemote: got\n%s\nwant import of fmt", res)
+	}
+	s.mu.Lock()
+	nenvs := len(s.envs)
+	s.mu.Unlock()
+	if nenvs != 1 {
+		t.Errorf("daemon has %d environments after one request, want 1", nenvs)
+	}
+
+	// Errors are reported relative to the client's file name.
+	if _, ok, err := processRemote("p.go", []byte("package p\nfunc"), options); !ok || err == nil || !strings.HasPrefix(err.Error(), "p.go:") {
+		t.Errorf("processRemote of invalid file = ok %t, err %v; want error at p.go", ok, err)
+	}
+}
+
+// TestUnsafeSocket checks that neither the daemon nor its clients use
+// a socket in a directory that other users may modify.
+func TestUnsafeSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file permissions are not checked on Windows")
+	}
+	dir, err := os.MkdirTemp("", "goimports")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	oldSocket := *socket
+	defer func() { *socket = oldSocket }()
+
+	// A socket in a directory writable by others.
+	shared := filepath.Join(dir, "shared")
+	if err := os.Mkdir(shared, 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(shared, 0777); err != nil { // ignore umask
+		t.Fatal(err)
+	}
+	*socket = filepath.Join(shared, "sock")
+	if l, err := listen(*socket); err == nil {
+		l.Close()
+		t.Errorf("listen succeeded in a directory accessible to others")
+	}
+	// A daemon listening there despite the check is not used.
+	l, err := net.Listen("unix", *socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go newServer().serve(l)
+	if _, ok, err := processRemote(filepath.Join(dir, "p.go"), []byte(daemonInput), options); ok || err != nil {
+		t.Errorf("processRemote with shared socket directory = ok %t, err %v; want false, nil", ok, err)
+	}
+
+	// A file that is not a socket.
+	*socket = filepath.Join(dir, "file")
+	if err := os.WriteFile(*socket, nil, 0666); err != nil {
+		t.Fatal(err)
+	}
+	if l, err := listen(*socket); err == nil {
+		l.Close()
+		t.Errorf("listen succeeded in place of a file that is not a socket")
+	}
+	if _, err := os.Stat(*socket); err != nil {
+		t.Errorf("listen removed a file that is not a socket: %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unix
+
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"syscall"
+)
+
+// checkPrivate reports an error unless the file belongs to the current
+// user and, if it is a directory, is inaccessible to other users.
+func checkPrivate(name string, fi fs.FileInfo) error {
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return fmt.Errorf("cannot determine the owner of %s", name)
+	}
+	if int(st.Uid) != os.Getuid() {
+		return fmt.Errorf("%s belongs to another user (uid %d)", name, st.Uid)
+	}
+	if fi.IsDir() && fi.Mode().Perm()&0077 != 0 {
+		return fmt.Errorf("%s is accessible to other users (mode %v)", name, fi.Mode().Perm())
+	}
+	return nil
+}
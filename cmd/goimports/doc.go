@@ -41,6 +41,35 @@ the configuration file to ignore when scanning. No globbing or regex
 patterns are allowed. Use the "-v" verbose flag to verify it's
 working and see what goimports is doing.
 
+By default, goimports separates the imports of the standard library
+from the others, and, with the -local flag, the imports beneath the
+given prefixes from the rest. The -groups flag specifies the order of
+the groups explicitly, as a comma-separated list, each element of
+which is a space-separated list of patterns: "std" for the standard
+library, "external" for the imports that no other pattern matches,
+"module" for the imports of the module enclosing the file, or an
+import path prefix. For example:
+
+	goimports -groups 'std,external,github.com/myorg,module' -w .
+
+With -groups, goimports also replaces the blank lines between the
+imports of a block by exactly one blank line between the groups.
+
+To resolve an unknown identifier, goimports may scan the module cache,
+which is slow for a cold process. Editors that run goimports on every
+save may instead start a long-lived daemon, which keeps its index of
+the module cache warm, and run goimports with the -remote flag, which
+sends the formatting requests to the daemon, or processes them
+locally if no daemon is running:
+
+	goimports -daemon &
+	goimports -remote -w file.go
+
+Both use the socket given by the -socket flag, which by default is in
+$XDG_RUNTIME_DIR, if set, or else in the user cache directory. The
+directory of the socket must belong to the user and be inaccessible to
+other users.
+
 File bugs or feature requests at:
 
 	https://golang.org/issues/new?title=x/tools/cmd/goimports:+
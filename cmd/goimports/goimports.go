@@ -20,6 +20,7 @@ import (
 	"runtime/pprof"
 	"strings"
 
+	"golang.org/x/mod/modfile"
 	"golang.org/x/telemetry/counter"
 	"golang.org/x/tools/internal/gocommand"
 	"golang.org/x/tools/internal/imports"
@@ -31,6 +32,7 @@ var (
 	write  = flag.Bool("w", false, "write result to (source) file instead of stdout")
 	doDiff = flag.Bool("d", false, "display diffs instead of rewriting files")
 	srcdir = flag.String("srcdir", "", "choose imports as if source code is from `dir`. When operating on a single file, dir may instead be the complete file name.")
+	groups = flag.String("groups", "", "comma-separated list of import `groups`, in order, each a space-separated list of patterns: std, external, module (the path of the enclosing module), or an import path prefix. Overrides -local.")
 
 	verbose bool // verbose logging
 
@@ -139,7 +141,20 @@ func processFile(filename string, in io.Reader, out io.Writer, argType argumentT
 		}
 	}
 
-	res, err := imports.Process(target, src, opt)
+	if *groups != "" {
+		nopt := *opt
+		nopt.Groups = importGroups(*groups, target)
+		opt = &nopt
+	}
+
+	var res []byte
+	ok := false
+	if *remote {
+		res, ok, err = processRemote(target, src, opt)
+	}
+	if !ok {
+		res, err = imports.Process(target, src, opt)
+	}
 	if err != nil {
 		return err
 	}
@@ -266,12 +281,25 @@ func gofmtMain() {
 		log.SetFlags(log.LstdFlags | log.Lmicroseconds)
 		options.Env.Logf = log.Printf
 	}
+	if *groups != "" && options.LocalPrefix != "" {
+		fmt.Fprintf(os.Stderr, "-groups and -local are mutually exclusive\n")
+		exitCode = 2
+		return
+	}
 	if options.TabWidth < 0 {
 		fmt.Fprintf(os.Stderr, "negative tabwidth %d\n", options.TabWidth)
 		exitCode = 2
 		return
 	}
 
+	if *daemon {
+		if err := serve(); err != nil {
+			fmt.Fprintf(os.Stderr, "daemon: %v\n", err)
+			exitCode = 2
+		}
+		return
+	}
+
 	if len(paths) == 0 {
 		if err := processFile("<standard input>", os.Stdin, os.Stdout, fromStdin); err != nil {
 			report(err)
@@ -370,6 +398,41 @@ func replaceTempFilename(diff []byte, filename string) ([]byte, error) {
 	return bytes.Join(bs, []byte{'\n'}), nil
 }
 
+// importGroups parses the value of the -groups flag, replacing the
+// pattern "module" by the path of the module enclosing filename.
+func importGroups(flag, filename string) []string {
+	var groups []string
+	for group := range strings.SplitSeq(flag, ",") {
+		patterns := strings.Fields(group)
+		for i, pattern := range patterns {
+			if pattern == "module" {
+				patterns[i] = modulePath(filename)
+			}
+		}
+		groups = append(groups, strings.Join(patterns, " "))
+	}
+	return groups
+}
+
+// modulePath returns the path of the module whose go.mod file is in
+// the directory of filename or the nearest of its parents, or "".
+func modulePath(filename string) string {
+	dir, err := filepath.Abs(filepath.Dir(filename))
+	if err != nil {
+		return ""
+	}
+	for {
+		if data, err := os.ReadFile(filepath.Join(dir, "go.mod")); err == nil {
+			return modfile.ModulePath(data)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
 // isFile reports whether name is a file.
 func isFile(name string) bool {
 	fi, err := os.Stat(name)
@@ -6,13 +6,44 @@
 //
 // Usage:
 //
-//	gonew srcmod[@version] [dstmod [dir]]
+//	gonew [-var name=value]... [-hooks] srcmod[@version] [dstmod [dir]]
 //
 // Gonew makes a copy of the srcmod module, changing its module path to dstmod.
 // It writes that new module to a new directory named by dir.
 // If dir already exists, it must be an empty directory.
 // If dir is omitted, gonew uses ./elem where elem is the final path element of dstmod.
 //
+// # Templates
+//
+// A template module may declare variables in a gonew.json file in its
+// root directory, which is not copied. Each variable has a name and a
+// value that appears in the files of the template, such as a project
+// name, a copyright holder, or an author. The -var name=value flag
+// replaces each occurrence of the variable's value with a new one,
+// across all the text files of the template (after the module path has
+// been changed). With "paths": true, the value is also replaced in the
+// names of files and directories. Since the replacement is textual,
+// values should be distinctive.
+//
+// The manifest may also list hooks, commands to run in the new module's
+// directory once it is initialized, such as "go mod tidy". Since they
+// come from the template, gonew only prints them, unless the -hooks
+// flag is given.
+//
+// For example, this manifest
+//
+//	{
+//		"vars": [
+//			{"name": "project", "value": "hello", "usage": "the name of the command", "paths": true},
+//			{"name": "author", "value": "The Hello Authors", "usage": "the copyright holder"}
+//		],
+//		"hooks": [["go", "mod", "tidy"]]
+//	}
+//
+// allows the template to be copied by
+//
+//	gonew -var project=myprog -var author='Jane Doe' example.com/hello your.domain/myprog
+//
 // This command is highly experimental and subject to change.
 //
 // # Example
@@ -47,14 +78,45 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"golang.org/x/mod/modfile"
 	"golang.org/x/mod/module"
 	"golang.org/x/tools/internal/edit"
 )
 
+var (
+	vars      = make(map[string]string) // values of the -var flags
+	hooksFlag = flag.Bool("hooks", false, "run the hooks of the template's manifest")
+)
+
+func init() {
+	flag.Func("var", "set the template variable `name=value`", func(s string) error {
+		name, value, ok := strings.Cut(s, "=")
+		if !ok || name == "" {
+			return fmt.Errorf("want name=value")
+		}
+		vars[name] = value
+		return nil
+	})
+}
+
+// A manifest describes the variables and hooks of a template module.
+type manifest struct {
+	Vars []struct {
+		Name  string
+		Value string // value in the template
+		Usage string
+		Paths bool // also replace the value in file names
+	}
+	Hooks [][]string // commands to run in the new module
+}
+
+// manifestFile is the name of the manifest in the root of a template.
+const manifestFile = "gonew.json"
+
 func usage() {
-	fmt.Fprintf(os.Stderr, "usage: gonew srcmod[@version] [dstmod [dir]]\n")
+	fmt.Fprintf(os.Stderr, "usage: gonew [-var name=value]... [-hooks] srcmod[@version] [dstmod [dir]]\n")
 	fmt.Fprintf(os.Stderr, "See https://pkg.go.dev/golang.org/x/tools/cmd/gonew.\n")
 	os.Exit(2)
 }
@@ -117,6 +179,39 @@ func main() {
 		log.Fatalf("go mod download -json %s: invalid JSON output: %v\n%s%s", srcMod, err, stderr.Bytes(), stdout.Bytes())
 	}
 
+	// Read the manifest, if any, and check the variables against it.
+	var m manifest
+	if data, err := os.ReadFile(filepath.Join(info.Dir, manifestFile)); err == nil {
+		if err := json.Unmarshal(data, &m); err != nil {
+			log.Fatalf("parsing source module:\n%s: %v", manifestFile, err)
+		}
+	}
+	var textReplacer, pathReplacer *strings.Replacer
+	{
+		var text, paths []string
+		known := make(map[string]bool)
+		for _, v := range m.Vars {
+			known[v.Name] = true
+			if value, ok := vars[v.Name]; ok && v.Value != "" {
+				text = append(text, v.Value, value)
+				if v.Paths {
+					paths = append(paths, v.Value, value)
+				}
+			}
+		}
+		for name := range vars {
+			if !known[name] {
+				var names []string
+				for _, v := range m.Vars {
+					names = append(names, fmt.Sprintf("\n\t%s (%s): %s", v.Name, v.Value, v.Usage))
+				}
+				log.Fatalf("%s has no variable %s; its variables are:%s", srcMod, name, strings.Join(names, ""))
+			}
+		}
+		textReplacer = strings.NewReplacer(text...)
+		pathReplacer = strings.NewReplacer(paths...)
+	}
+
 	if needMkdir {
 		if err := os.MkdirAll(dir, 0777); err != nil {
 			log.Fatal(err)
@@ -132,7 +227,10 @@ func main() {
 		if err != nil {
 			log.Fatal(err)
 		}
-		dst := filepath.Join(dir, rel)
+		if rel == manifestFile {
+			return nil
+		}
+		dst := filepath.Join(dir, pathReplacer.Replace(rel))
 		if d.IsDir() {
 			if err := os.MkdirAll(dst, 0777); err != nil {
 				log.Fatal(err)
@@ -152,6 +250,9 @@ func main() {
 		if rel == "go.mod" {
 			data = fixGoMod(data, srcMod, dstMod)
 		}
+		if utf8.Valid(data) {
+			data = []byte(textReplacer.Replace(string(data)))
+		}
 
 		if err := os.WriteFile(dst, data, 0666); err != nil {
 			log.Fatal(err)
@@ -160,6 +261,25 @@ func main() {
 	})
 
 	log.Printf("initialized %s in %s", dstMod, dir)
+
+	// Run the hooks, or show them.
+	for _, hook := range m.Hooks {
+		if len(hook) == 0 {
+			continue
+		}
+		if !*hooksFlag {
+			log.Printf("the template suggests running: %s", strings.Join(hook, " "))
+			continue
+		}
+		log.Printf("running %s", strings.Join(hook, " "))
+		cmd := exec.Command(hook[0], hook[1:]...)
+		cmd.Dir = dir
+		cmd.Stdout = os.Stderr
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			log.Fatalf("%s: %v", strings.Join(hook, " "), err)
+		}
+	}
 }
 
 // fixGo rewrites the Go source in data to replace srcMod with dstMod.
@@ -39,6 +39,37 @@ Error is equivalent to yyerror in the original yacc.
 Code inside the grammar actions may refer to the variable yylex,
 which holds the yyLexer passed to yyParse.
 
+By default, a syntax error is reported as "syntax error". If the
+variable yyErrorVerbose is set, or the grammar contains the
+declaration %error-verbose, which sets it, the message names the
+unexpected token and up to four expected ones, like that of Bison:
+
+	syntax error: unexpected NUM, expecting ';' or '+'
+
+A %error declaration gives the message for a specific sequence of
+tokens, in place of this one.
+
+If the lexer also conforms to the following interface, the parser
+reports syntax errors by calling ErrorAt instead of Error:
+
+	type yyErrorLexer interface {
+		yyLexer
+		ErrorAt(lval *yySymType, e string)
+	}
+
+The lval argument holds the value that Lex stored for the offending
+token, so a lexer that records the position of each token in its
+yySymType can report the position of the error.
+
+Grammar actions may call yyerrok() and yyclearin(), which are
+equivalent to yyerrok and yyclearin in the original yacc: yyerrok ends
+error recovery, so that the next error is reported even before three
+tokens have been shifted since the last, and yyclearin discards the
+lookahead token. They are typically used in rules that contain the
+error token, such as
+
+	stmt: error ';' { yyerrok() }
+
 Clients that need to understand more about the parser state can
 create the parser separately from invoking it. The function yyNewParser
 returns a yyParser conforming to the following interface:
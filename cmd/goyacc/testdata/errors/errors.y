@@ -0,0 +1,99 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This is an example of a goyacc grammar that recovers from syntax
+// errors and reports them at the position of the offending token.
+
+%{
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+)
+
+%}
+
+%union {
+	num int
+	pos int // offset of the token in the input
+}
+
+%error-verbose
+
+%token	<num>	NUM
+
+%%
+
+list:
+	/* empty */
+|	list stmt
+
+stmt:
+	NUM ';'
+	{
+		fmt.Println($1)
+	}
+|	error ';'
+	{
+		// Report the next error even if it is in the next statement.
+		yyerrok()
+	}
+|	NUM error
+	{
+		// Discard the token that follows the number, and resume
+		// parsing after it.
+		yyerrok()
+		yyclearin()
+	}
+
+%%
+
+// A lexer scans a list of numbers and punctuation.
+type lexer struct {
+	src []byte
+	pos int
+}
+
+func (x *lexer) Lex(yylval *yySymType) int {
+	for x.pos < len(x.src) && x.src[x.pos] == ' ' {
+		x.pos++
+	}
+	yylval.pos = x.pos
+	if x.pos == len(x.src) {
+		return 0
+	}
+	start := x.pos
+	for x.pos < len(x.src) && '0' <= x.src[x.pos] && x.src[x.pos] <= '9' {
+		x.pos++
+	}
+	if x.pos > start {
+		yylval.num, _ = strconv.Atoi(string(x.src[start:x.pos]))
+		return NUM
+	}
+	x.pos++
+	return int(x.src[start])
+}
+
+// Error is not called, as the lexer also has an ErrorAt method.
+func (x *lexer) Error(s string) {
+	log.Fatalf("Error called: %s", s)
+}
+
+// ErrorAt reports a syntax error at the offset of the offending token.
+func (x *lexer) ErrorAt(yylval *yySymType, s string) {
+	fmt.Printf("%d: %s\n", yylval.pos, s)
+}
+
+func main() {
+	src, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		log.Fatal(err)
+	}
+	yyParse(&lexer{src: src})
+}
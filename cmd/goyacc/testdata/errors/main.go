@@ -0,0 +1,16 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file holds the go generate command to run yacc on the grammar in errors.y.
+// To build errors:
+//	% go generate
+//	% go build
+
+//go:generate goyacc -o errors.go errors.y
+
+// Errors reads a list of numbers, each followed by a semicolon, from
+// its standard input and prints them, reporting and recovering from
+// syntax errors. It serves as an example of error handling in Go's
+// yacc implementation.
+package main
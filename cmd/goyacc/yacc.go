@@ -126,6 +126,7 @@ const (
 	TYPENAME
 	UNION
 	ERROR
+	ERRORVERBOSE
 )
 
 const ENDFILE = 0
@@ -323,6 +324,7 @@ var resrv = []Resrv{
 	{"union", UNION},
 	{"struct", UNION},
 	{"error", ERROR},
+	{"error-verbose", ERRORVERBOSE},
 }
 
 type Error struct {
@@ -333,6 +335,8 @@ type Error struct {
 
 var errors []Error
 
+var errorVerbose bool // %error-verbose: report expected tokens by default
+
 type Row struct {
 	actions       []int
 	defaultAction int
@@ -439,6 +443,9 @@ outer:
 			}
 			errors = append(errors, Error{lno, tokens, tokname})
 
+		case ERRORVERBOSE:
+			errorVerbose = true
+
 		case TYPEDEF:
 			t = gettok()
 			if t != TYPENAME {
@@ -930,6 +937,17 @@ func gettok() int {
 		}
 
 		getword(c)
+		// A reserved word may contain hyphens, as in %error-verbose.
+		for {
+			c = getrune(finput)
+			if c != '-' {
+				ungetrune(finput, c)
+				break
+			}
+			word := tokname
+			getword(getrune(finput))
+			tokname = word + "-" + tokname
+		}
 		// find a reserved word
 		for i := range resrv {
 			if tokname == resrv[i].name {
@@ -2884,6 +2902,9 @@ func others() {
 		fmt.Fprintf(ftable, "\n//line yaccpar:1\n")
 	}
 
+	if errorVerbose {
+		yaccpar = strings.Replace(yaccpar, prefix+"ErrorVerbose = false", prefix+"ErrorVerbose = true", 1)
+	}
 	parts := strings.SplitN(yaccpar, prefix+"run()", 2)
 	fmt.Fprintf(ftable, "%v", parts[0])
 	ftable.Write(fcode.Bytes())
@@ -3234,6 +3255,15 @@ type $$Lexer interface {
 	Error(s string)
 }
 
+// A $$ErrorLexer is a $$Lexer that reports syntax errors at the position
+// of the offending token: the parser calls ErrorAt in place of Error,
+// with the value that Lex stored for the lookahead token, which may
+// record its position.
+type $$ErrorLexer interface {
+	$$Lexer
+	ErrorAt(lval *$$SymType, s string)
+}
+
 type $$Parser interface {
 	Parse($$Lexer) int
 	Lookahead() int
@@ -3395,6 +3425,18 @@ func ($$rcvr *$$ParserImpl) Parse($$lex $$Lexer) int {
 		$$token = -1
 	}()
 	$$p := -1
+
+	// For use in grammar actions, like yyerrok and yyclearin in yacc:
+	// $$errok ends error recovery, so that errors are reported before
+	// three tokens have been shifted, and $$clearin discards the
+	// lookahead token.
+	$$errok := func() { Errflag = 0 }
+	$$clearin := func() {
+		$$rcvr.char = -1
+		$$token = -1
+	}
+	_, _ = $$errok, $$clearin
+
 	goto $$stack
 
 ret0:
@@ -3473,7 +3515,11 @@ $$default:
 		/* error ... attempt to resume parsing */
 		switch Errflag {
 		case 0: /* brand new error */
-			$$lex.Error($$ErrorMessage($$state, $$token))
+			if lex, ok := $$lex.($$ErrorLexer); ok {
+				lex.ErrorAt(&$$rcvr.lval, $$ErrorMessage($$state, $$token))
+			} else {
+				$$lex.Error($$ErrorMessage($$state, $$token))
+			}
 			Nerrs++
 			if $$Debug >= 1 {
 				__yyfmt__.Printf("%s", $$Statname($$state))
@@ -0,0 +1,85 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/internal/testenv"
+)
+
+func TestMain(m *testing.M) {
+	if os.Getenv("GO_GOYACC_TEST_IS_GOYACC") != "" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// TestErrors checks the error handling of the parser generated from
+// testdata/errors/errors.y, which uses %error-verbose, ErrorAt, and
+// yyerrok and yyclearin.
+func TestErrors(t *testing.T) {
+	testenv.NeedsExec(t)
+	testenv.NeedsGoBuild(t)
+
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	goyacc := exec.Command(exe, "-o", filepath.Join(dir, "errors.go"), "-v", filepath.Join(dir, "y.output"), "testdata/errors/errors.y")
+	goyacc.Env = append(os.Environ(), "GO_GOYACC_TEST_IS_GOYACC=1")
+	if out, err := goyacc.CombinedOutput(); err != nil {
+		t.Fatalf("goyacc failed: %v\n%s", err, out)
+	}
+	mainFile, err := os.ReadFile("testdata/errors/main.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range map[string]string{
+		"main.go": string(mainFile),
+		"go.mod":  "module example.com/errors\n\ngo 1.24\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	build := exec.Command("go", "build", "-o", "errors.exe", ".")
+	build.Dir = dir
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("go build failed: %v\n%s", err, out)
+	}
+
+	for _, test := range []struct {
+		input, want string
+	}{
+		{"1; 2;", "1\n2\n"},
+		// The error is reported at the offending token, and names
+		// the expected tokens.
+		{"1; x", "1\n3: syntax error: unexpected $unk, expecting NUM\n"},
+		// yyclearin discards the token after 2, so that the ';'
+		// is unexpected, and yyerrok causes that to be reported.
+		{"1; 2 3; 4;", "1\n5: syntax error: unexpected NUM, expecting ';'\n6: syntax error: unexpected ';', expecting NUM\n4\n"},
+		// yyerrok causes errors in consecutive statements to be
+		// reported.
+		{"; ; 5;", "0: syntax error: unexpected ';', expecting NUM\n2: syntax error: unexpected ';', expecting NUM\n5\n"},
+	} {
+		cmd := exec.Command(filepath.Join(dir, "errors.exe"))
+		cmd.Stdin = strings.NewReader(test.input)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Errorf("errors %q failed: %v\n%s", test.input, err, out)
+			continue
+		}
+		if string(out) != test.want {
+			t.Errorf("errors %q printed:\n%s\nwant:\n%s", test.input, out, test.want)
+		}
+	}
+}
@@ -44,6 +44,13 @@ the generated output. The supported formats are:
 	.slide        // HTML5 slide presentation
 	.article      // article format, such as a blog post
 
+The export subcommand renders a file to a self-contained HTML file, or to
+a PDF file using a headless Chrome or Chromium browser, without running the
+server, so that it may be archived or shared:
+
+	present export talk.slide
+	present export -o talk.pdf talk.slide
+
 The present file format is documented by the present package:
 https://pkg.go.dev/golang.org/x/tools/present
 */
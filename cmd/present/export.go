@@ -0,0 +1,197 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+// This file defines the export subcommand, which renders a document
+// to a self-contained HTML or PDF file without running the server.
+
+import (
+	"bytes"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/present"
+)
+
+const exportUsage = `usage: present [flags] export [-o output] [-browser path] file
+
+Export renders the .slide or .article file to a self-contained HTML
+file, whose scripts, style sheets, images, and videos are inlined, or,
+if the output file name ends in .pdf, to a PDF file, which it prints
+using a headless Chrome or Chromium browser. Code snippets cannot be
+run, and presenter notes are not shown.
+
+The default output is the input file name with .html in place of its
+extension.
+`
+
+// export runs the export subcommand, using the static files and
+// templates of fsys.
+func export(fsys fs.FS, args []string) error {
+	fset := flag.NewFlagSet("export", flag.ExitOnError)
+	output := fset.String("o", "", "write output to `file`")
+	browser := fset.String("browser", "", "`path` of the Chrome or Chromium browser that prints PDF files (default: search PATH)")
+	fset.Usage = func() {
+		fmt.Fprint(os.Stderr, exportUsage)
+		fset.PrintDefaults()
+	}
+	fset.Parse(args)
+	if fset.NArg() != 1 || !isDoc(fset.Arg(0)) {
+		fset.Usage()
+		os.Exit(2)
+	}
+	name := fset.Arg(0)
+	if *output == "" {
+		*output = strings.TrimSuffix(name, filepath.Ext(name)) + ".html"
+	}
+
+	// Find the browser before doing any work, so that a PDF file is
+	// not rendered only to be discarded.
+	pdf := strings.EqualFold(filepath.Ext(*output), ".pdf")
+	if pdf {
+		var err error
+		if *browser, err = findBrowser(*browser); err != nil {
+			return err
+		}
+	}
+
+	// Without a server, there is nothing to run code or show notes.
+	present.PlayEnabled = false
+	present.NotesEnabled = false
+
+	var buf bytes.Buffer
+	if err := renderDoc(&buf, name); err != nil {
+		return err
+	}
+	html, err := inline(fsys, filepath.Dir(name), filepath.Ext(name), buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	if !pdf {
+		return os.WriteFile(*output, html, 0666)
+	}
+	return printPDF(*browser, html, *output)
+}
+
+var (
+	scriptRE = regexp.MustCompile(`<script src='/static/([^']*)'></script>`)
+	linkRE   = regexp.MustCompile(`<link type="text/css" rel="stylesheet" href="/static/([^"]*)">`)
+	srcRE    = regexp.MustCompile(`(<(?:img|source)\b[^>]*\bsrc=")([^"]*)(")`)
+)
+
+// inline returns the HTML rendering of a document of the kind denoted
+// by ext, with the static scripts and style sheets to which it refers
+// inlined, and the images and videos, which are relative to dir,
+// inlined as data URLs.
+func inline(fsys fs.FS, dir, ext string, html []byte) ([]byte, error) {
+	var err error
+	static := func(name string) []byte {
+		data, e := fs.ReadFile(fsys, path.Join("static", name))
+		if e != nil && err == nil {
+			err = e
+		}
+		return data
+	}
+
+	html = scriptRE.ReplaceAllFunc(html, func(tag []byte) []byte {
+		name := scriptRE.FindSubmatch(tag)[1]
+		script := bytes.ReplaceAll(static(string(name)), []byte("</script"), []byte(`<\/script`))
+		return fmt.Appendf(nil, "<script>\n%s\n</script>", script)
+	})
+	html = linkRE.ReplaceAllFunc(html, func(tag []byte) []byte {
+		return fmt.Appendf(nil, "<style>\n%s\n</style>", static(string(linkRE.FindSubmatch(tag)[1])))
+	})
+	if ext == ".slide" {
+		// slides.js adds a link to styles.css, which cannot be
+		// loaded; include it instead.
+		style := fmt.Appendf(nil, "<style>\n%s\n</style>\n</head>", static("styles.css"))
+		html = bytes.Replace(html, []byte("</head>"), style, 1)
+	}
+
+	html = srcRE.ReplaceAllFunc(html, func(attr []byte) []byte {
+		m := srcRE.FindSubmatch(attr)
+		u, e := url.Parse(string(m[2]))
+		if e != nil || u.Scheme != "" || u.Host != "" || u.Path == "" {
+			return attr // not a local file
+		}
+		file := filepath.Join(dir, filepath.FromSlash(u.Path))
+		if strings.HasPrefix(u.Path, "/") {
+			file = filepath.Join(*contentPath, filepath.FromSlash(u.Path))
+		}
+		data, e := os.ReadFile(file)
+		if e != nil {
+			if err == nil {
+				err = e
+			}
+			return attr
+		}
+		typ := mime.TypeByExtension(filepath.Ext(file))
+		if typ == "" {
+			typ = "application/octet-stream"
+		}
+		return fmt.Appendf(nil, "%sdata:%s;base64,%s%s", m[1], typ, base64.StdEncoding.EncodeToString(data), m[3])
+	})
+	return html, err
+}
+
+// findBrowser returns the path of the browser that prints PDF files:
+// that of the named one, if any, or else that of the first Chrome or
+// Chromium browser found in PATH.
+func findBrowser(browser string) (string, error) {
+	if browser != "" {
+		p, err := exec.LookPath(browser)
+		if err != nil {
+			return "", fmt.Errorf("cannot find browser to print PDF files: %v", err)
+		}
+		return p, nil
+	}
+	for _, name := range []string{"chromium", "chromium-browser", "google-chrome", "google-chrome-stable", "chrome"} {
+		if p, err := exec.LookPath(name); err == nil {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("cannot find a Chrome or Chromium browser to print PDF files; use -browser")
+}
+
+// printPDF prints the HTML page to a PDF file using the headless
+// browser.
+func printPDF(browser string, html []byte, output string) error {
+	tmp, err := os.CreateTemp("", "present-*.html")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(html); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	abs, err := filepath.Abs(output)
+	if err != nil {
+		return err
+	}
+	page := &url.URL{Scheme: "file", Path: filepath.ToSlash(tmp.Name())}
+	if !strings.HasPrefix(page.Path, "/") {
+		page.Path = "/" + page.Path // a Windows drive letter
+	}
+	cmd := exec.Command(browser, "--headless", "--disable-gpu", "--no-pdf-header-footer", "--print-to-pdf="+abs, page.String())
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("printing PDF with %s: %v\n%s", browser, err, out)
+	}
+	return nil
+}
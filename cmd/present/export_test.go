@@ -0,0 +1,82 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const exportSlide = `Export
+
+* Slide
+
+.image gopher.png
+`
+
+// writeSlide writes a slide file referring to an image to a new
+// directory and returns its name.
+func writeSlide(t *testing.T) string {
+	if err := initTemplates(embedFS); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	name := filepath.Join(dir, "talk.slide")
+	if err := os.WriteFile(name, []byte(exportSlide), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "gopher.png"), []byte("PNG"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	return name
+}
+
+func TestExportHTML(t *testing.T) {
+	name := writeSlide(t)
+	if err := export(embedFS, []string{name}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(strings.TrimSuffix(name, ".slide") + ".html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	html := string(data)
+	for _, ref := range []string{"src='/static/", `href="/static/`} {
+		if strings.Contains(html, ref) {
+			t.Errorf("exported HTML refers to a static file (%s)", ref)
+		}
+	}
+	if want := `src="data:image/png;base64,` + base64.StdEncoding.EncodeToString([]byte("PNG")) + `"`; !strings.Contains(html, want) {
+		t.Errorf("exported HTML lacks the inlined image %s", want)
+	}
+	if !strings.Contains(html, "<style>") || !strings.Contains(html, "<script>") {
+		t.Errorf("exported HTML lacks the inlined style sheets and scripts")
+	}
+}
+
+func TestExportPDFWithoutBrowser(t *testing.T) {
+	name := writeSlide(t)
+	output := strings.TrimSuffix(name, ".slide") + ".pdf"
+	for _, test := range []struct {
+		args    []string
+		path    string // value of PATH
+		wantErr string
+	}{
+		{[]string{"-o", output, "-browser", filepath.Join(t.TempDir(), "chrome"), name}, os.Getenv("PATH"), "cannot find browser"},
+		{[]string{"-o", output, name}, t.TempDir(), "cannot find a Chrome or Chromium browser"},
+	} {
+		t.Setenv("PATH", test.path)
+		err := export(embedFS, test.args)
+		if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+			t.Errorf("export %q = %v, want error containing %q", test.args, err, test.wantErr)
+		}
+		if _, err := os.Stat(output); err == nil {
+			t.Errorf("export %q wrote %s despite the missing browser", test.args, output)
+		}
+	}
+}
@@ -60,6 +60,13 @@ func main() {
 		log.Fatalf("Failed to parse templates: %v", err)
 	}
 
+	if flag.Arg(0) == "export" {
+		if err := export(fsys, flag.Args()[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	ln, err := net.Listen("tcp", *httpAddr)
 	if err != nil {
 		log.Fatal(err)
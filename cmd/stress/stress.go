@@ -14,32 +14,97 @@
 // instruct the utility to not kill hanged processes for gdb attach;
 // or specify the failure output you are looking for (if you want to
 // ignore some other sporadic failures).
+//
+// The -json flag writes a summary of the results, including the log of
+// each failure, to a file when stress exits, whether because -count
+// runs are done or because it was interrupted.
+//
+// The -artifacts flag saves, beside the log of each failure, a tar
+// file holding the standard output and error of the failed run, the
+// goroutine dump that a Go program prints when killed after a timeout,
+// and any core file it left in the current directory (named core or
+// core.PID).
 package main
 
 import (
+	"archive/tar"
 	"bytes"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
 	"regexp"
 	"runtime"
+	"strconv"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"syscall"
 	"time"
 )
 
 var (
-	flagCount   = flag.Int("count", 0, "stop after `N` runs (default never stop)")
-	flagFailure = flag.String("failure", "", "fail only if output matches `regexp`")
-	flagIgnore  = flag.String("ignore", "", "ignore failure if output matches `regexp`")
-	flagKill    = flag.Bool("kill", true, "kill timed out processes if true, otherwise just print pid (to attach with gdb)")
-	flagOutput  = flag.String("o", defaultPrefix(), "output failure logs to `path` plus a unique suffix")
-	flagP       = flag.Int("p", runtime.NumCPU(), "run `N` processes in parallel")
-	flagTimeout = flag.Duration("timeout", 10*time.Minute, "timeout each process after `duration`")
+	flagCount     = flag.Int("count", 0, "stop after `N` runs (default never stop)")
+	flagFailure   = flag.String("failure", "", "fail only if output matches `regexp`")
+	flagIgnore    = flag.String("ignore", "", "ignore failure if output matches `regexp`")
+	flagKill      = flag.Bool("kill", true, "kill timed out processes if true, otherwise just print pid (to attach with gdb)")
+	flagOutput    = flag.String("o", defaultPrefix(), "output failure logs to `path` plus a unique suffix")
+	flagP         = flag.Int("p", runtime.NumCPU(), "run `N` processes in parallel")
+	flagTimeout   = flag.Duration("timeout", 10*time.Minute, "timeout each process after `duration`")
+	flagJSON      = flag.String("json", "", "write a JSON summary of the results to `file` on exit")
+	flagArtifacts = flag.Bool("artifacts", false, "save the output, goroutine dump, and core file of each failure in a tar file beside its log")
 )
 
+// A result is the result of a run.
+type result struct {
+	out      []byte // combined output, or empty if the run did not fail
+	timedOut bool
+	pid      int
+	err      error
+
+	// Set only with -artifacts.
+	stdout, stderr []byte
+	dump           []byte                 // stderr after the timeout signal
+	cores          map[string]os.FileInfo // core files that existed before the run
+}
+
+// A summary is the JSON summary of the results, written by -json.
+type summary struct {
+	Runs     int
+	Failures int
+	Timeouts int     // runs that timed out, whether or not they failed
+	Seconds  float64 // elapsed time
+	Logs     []failure
+}
+
+// A failure describes a failed run.
+type failure struct {
+	Log       string // file holding the combined output of the run
+	Artifacts string `json:",omitempty"` // tar file of its artifacts (-artifacts)
+	Error     string
+	TimedOut  bool
+}
+
+// A teeWriter writes one output stream of a run to the combined
+// output, and also to a buffer of its own, if any. The mutex is shared
+// by the writers of both streams.
+type teeWriter struct {
+	mu            *sync.Mutex
+	combined, own *bytes.Buffer
+}
+
+func (w teeWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.own != nil {
+		w.own.Write(p)
+	}
+	return w.combined.Write(p)
+}
+
 func init() {
 	flag.Usage = func() {
 		os.Stderr.WriteString(`The stress utility is intended for catching sporadic failures.
@@ -79,7 +144,7 @@ func main() {
 			os.Exit(1)
 		}
 	}
-	res := make(chan []byte)
+	res := make(chan result)
 	var started atomic.Int64
 	for i := 0; i < *flagP; i++ {
 		go func() {
@@ -90,11 +155,27 @@ func main() {
 					break
 				}
 				cmd := exec.Command(flag.Args()[0], flag.Args()[1:]...)
-				var buf bytes.Buffer
-				cmd.Stdout = &buf
-				cmd.Stderr = &buf
+				var (
+					mu                  sync.Mutex
+					buf, stdout, stderr bytes.Buffer
+				)
+				if *flagArtifacts {
+					cmd.Stdout = teeWriter{&mu, &buf, &stdout}
+					cmd.Stderr = teeWriter{&mu, &buf, &stderr}
+				} else {
+					cmd.Stdout = &buf
+					cmd.Stderr = &buf
+				}
+				var r result
+				if *flagArtifacts {
+					r.cores = coreFiles()
+				}
 				err := cmd.Start() // make cmd.Process valid for timeout goroutine
 				done := make(chan bool)
+				var (
+					timedOut atomic.Bool
+					dumpAt   atomic.Int64 // offset of the dump in stderr
+				)
 				if err == nil && *flagTimeout > 0 {
 					go func() {
 						select {
@@ -102,10 +183,14 @@ func main() {
 							return
 						case <-time.After(*flagTimeout):
 						}
+						timedOut.Store(true)
 						if !*flagKill {
 							fmt.Printf("process %v timed out\n", cmd.Process.Pid)
 							return
 						}
+						mu.Lock()
+						dumpAt.Store(int64(stderr.Len()))
+						mu.Unlock()
 						cmd.Process.Signal(syscall.SIGABRT)
 						select {
 						case <-done:
@@ -116,20 +201,27 @@ func main() {
 					}()
 				}
 				if err == nil {
+					r.pid = cmd.Process.Pid
 					err = cmd.Wait()
 				}
-				out := buf.Bytes()
 				close(done)
+				out := buf.Bytes()
+				r.timedOut, r.err = timedOut.Load(), err
 				if err != nil && (failureRe == nil || failureRe.Match(out)) && (ignoreRe == nil || !ignoreRe.Match(out)) {
-					out = append(out, fmt.Sprintf("\n\nERROR: %v\n", err)...)
-				} else {
-					out = []byte{}
+					r.out = append(out, fmt.Sprintf("\n\nERROR: %v\n", err)...)
+					if *flagArtifacts {
+						r.stdout, r.stderr = stdout.Bytes(), stderr.Bytes()
+						if r.timedOut && *flagKill {
+							r.dump = r.stderr[dumpAt.Load():]
+						}
+					}
 				}
-				res <- out
+				res <- r
 			}
 		}()
 	}
-	runs, fails := 0, 0
+	runs, fails, timeouts := 0, 0, 0
+	var failures []failure
 	start := time.Now()
 	ticker := time.NewTicker(5 * time.Second).C
 	status := func(context string) {
@@ -152,11 +244,39 @@ func main() {
 		}
 		fmt.Printf("%v: %v runs %s, %v failures%s%s\n", elapsed, runs, context, fails, pct, active)
 	}
+	exit := func() {
+		status("total")
+		if *flagJSON != "" {
+			data, err := json.MarshalIndent(summary{
+				Runs:     runs,
+				Failures: fails,
+				Timeouts: timeouts,
+				Seconds:  time.Since(start).Seconds(),
+				Logs:     failures,
+			}, "", "\t")
+			if err == nil {
+				err = os.WriteFile(*flagJSON, append(data, '\n'), 0666)
+			}
+			if err != nil {
+				fmt.Printf("failed to write summary: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		if fails > 0 {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
 	for {
 		select {
-		case out := <-res:
+		case r := <-res:
 			runs++
-			if len(out) > 0 {
+			if r.timedOut {
+				timeouts++
+			}
+			if out := r.out; len(out) > 0 {
 				fails++
 				dir, path := filepath.Split(*flagOutput)
 				f, err := os.CreateTemp(dir, path)
@@ -166,6 +286,15 @@ func main() {
 				}
 				f.Write(out)
 				f.Close()
+				fail := failure{Log: f.Name(), Error: r.err.Error(), TimedOut: r.timedOut}
+				if *flagArtifacts {
+					fail.Artifacts = f.Name() + ".tar"
+					if err := writeArtifacts(fail.Artifacts, r); err != nil {
+						fmt.Printf("failed to write artifacts: %v\n", err)
+						os.Exit(1)
+					}
+				}
+				failures = append(failures, fail)
 				if len(out) > 2<<10 {
 					out := out[:2<<10]
 					fmt.Printf("\n%s\n%s\n…\n", f.Name(), out)
@@ -174,14 +303,86 @@ func main() {
 				}
 			}
 			if *flagCount > 0 && runs >= *flagCount {
-				status("total")
-				if fails > 0 {
-					os.Exit(1)
-				}
-				os.Exit(0)
+				exit()
 			}
 		case <-ticker:
 			status("so far")
+		case <-interrupt:
+			fmt.Println()
+			exit()
+		}
+	}
+}
+
+// writeArtifacts writes a tar file holding the artifacts of the failed
+// run r: its standard output and error, its goroutine dump, and any
+// core file that it left in the current directory.
+func writeArtifacts(name string, r result) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	tw := tar.NewWriter(f)
+	add := func(name string, data []byte, mtime time.Time) error {
+		hdr := &tar.Header{Name: name, Mode: 0666, Size: int64(len(data)), ModTime: mtime}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+	now := time.Now()
+	err = add("stdout", r.stdout, now)
+	if err == nil {
+		err = add("stderr", r.stderr, now)
+	}
+	if err == nil && r.dump != nil {
+		err = add("goroutines", r.dump, now)
+	}
+	if err == nil && r.pid != 0 {
+		for _, core := range []string{"core", "core." + strconv.Itoa(r.pid)} {
+			fi, statErr := os.Stat(core)
+			if statErr != nil || !fi.Mode().IsRegular() || sameFile(fi, r.cores[core]) {
+				continue // absent, or left by an earlier process
+			}
+			data, readErr := os.ReadFile(core)
+			if readErr != nil {
+				err = readErr
+				break
+			}
+			if err = add(core, data, fi.ModTime()); err != nil {
+				break
+			}
+			os.Remove(core) // don't attribute it to a later failure too
 		}
 	}
+	if err == nil {
+		err = tw.Close()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// coreFiles returns the core files in the current directory.
+func coreFiles() map[string]os.FileInfo {
+	entries, _ := os.ReadDir(".") // ignore error
+	cores := make(map[string]os.FileInfo)
+	for _, e := range entries {
+		if name := e.Name(); name == "core" || strings.HasPrefix(name, "core.") {
+			if fi, err := e.Info(); err == nil {
+				cores[name] = fi
+			}
+		}
+	}
+	return cores
+}
+
+// sameFile reports whether fi describes the same, unmodified file as
+// old, which may be nil. Core files are compared by identity, size,
+// and modification time, as a file's modification time may lag the
+// clock, so it cannot be compared with the start time of a run.
+func sameFile(fi, old os.FileInfo) bool {
+	return old != nil && os.SameFile(fi, old) && fi.Size() == old.Size() && fi.ModTime().Equal(old.ModTime())
 }
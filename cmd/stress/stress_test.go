@@ -0,0 +1,182 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unix
+
+package main
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/tools/internal/testenv"
+)
+
+func TestMain(m *testing.M) {
+	// The test binary is both the stress command and the
+	// command that it runs, named by the "child" argument.
+	if len(os.Args) == 3 && os.Args[1] == "child" {
+		child(os.Args[2])
+	}
+	if os.Getenv("GO_STRESS_TEST_IS_STRESS") != "" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// child runs a command that fails in the given way.
+func child(mode string) {
+	os.Stdout.WriteString("to stdout\n")
+	os.Stderr.WriteString("to stderr\n")
+	switch mode {
+	case "fail":
+		os.WriteFile("core", []byte("core dump"), 0666)
+		os.Exit(1)
+	case "fail-without-core":
+		os.Exit(1)
+	case "hang":
+		time.Sleep(time.Hour)
+	}
+	os.Exit(0)
+}
+
+// stress runs the stress command in dir with the given flags on a
+// child of the given mode, and returns the summary it writes.
+func stress(t *testing.T, dir, mode string, flags ...string) *summary {
+	testenv.NeedsExec(t)
+
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	jsonFile := filepath.Join(dir, "summary.json")
+	args := append([]string{"-json=" + jsonFile, "-o=" + filepath.Join(dir, "log-")}, flags...)
+	cmd := exec.Command(exe, append(args, exe, "child", mode)...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GO_STRESS_TEST_IS_STRESS=1")
+	out, _ := cmd.CombinedOutput() // stress fails if any run does
+	data, err := os.ReadFile(jsonFile)
+	if err != nil {
+		t.Fatalf("reading summary: %v; output:\n%s", err, out)
+	}
+	var s summary
+	if err := json.Unmarshal(data, &s); err != nil {
+		t.Fatal(err)
+	}
+	return &s
+}
+
+// artifacts returns the contents of the tar file of artifacts.
+func artifacts(t *testing.T, name string) map[string]string {
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	files := make(map[string]string)
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[hdr.Name] = string(data)
+	}
+	return files
+}
+
+func TestFailure(t *testing.T) {
+	dir := t.TempDir()
+	s := stress(t, dir, "fail", "-p=1", "-count=2", "-artifacts")
+	if s.Runs != 2 || s.Failures != 2 || s.Timeouts != 0 || len(s.Logs) != 2 {
+		t.Fatalf("summary = %+v, want 2 runs, 2 failures, no timeouts", s)
+	}
+	for _, fail := range s.Logs {
+		if fail.TimedOut || fail.Error != "exit status 1" {
+			t.Errorf("failure %+v, want exit status 1 without timeout", fail)
+		}
+		log, err := os.ReadFile(fail.Log)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(log), "to stdout") || !strings.Contains(string(log), "to stderr") {
+			t.Errorf("log %s lacks the output of the run:\n%s", fail.Log, log)
+		}
+		got := artifacts(t, fail.Artifacts)
+		if got["stdout"] != "to stdout\n" || got["stderr"] != "to stderr\n" || got["core"] != "core dump" {
+			t.Errorf("artifacts of %s = %q, want stdout, stderr, and core", fail.Log, got)
+		}
+		if _, ok := got["goroutines"]; ok {
+			t.Errorf("artifacts of %s include a goroutine dump, but the run did not time out", fail.Log)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "core")); err == nil {
+		t.Errorf("core file was not moved into the artifacts")
+	}
+}
+
+// TestOldCore checks that a core file that existed before a run is not
+// attributed to it.
+func TestOldCore(t *testing.T) {
+	dir := t.TempDir()
+	core := filepath.Join(dir, "core")
+	if err := os.WriteFile(core, []byte("old core dump"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	s := stress(t, dir, "fail-without-core", "-p=1", "-count=1", "-artifacts")
+	if len(s.Logs) != 1 {
+		t.Fatalf("summary = %+v, want 1 failure", s)
+	}
+	if _, ok := artifacts(t, s.Logs[0].Artifacts)["core"]; ok {
+		t.Errorf("artifacts include the core file left before the run")
+	}
+	if _, err := os.Stat(core); err != nil {
+		t.Errorf("old core file was removed: %v", err)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	dir := t.TempDir()
+	s := stress(t, dir, "hang", "-p=1", "-count=1", "-timeout=1s", "-artifacts")
+	if s.Runs != 1 || s.Failures != 1 || s.Timeouts != 1 || len(s.Logs) != 1 {
+		t.Fatalf("summary = %+v, want 1 run, 1 failure, 1 timeout", s)
+	}
+	fail := s.Logs[0]
+	if !fail.TimedOut {
+		t.Errorf("failure %+v, want timeout", fail)
+	}
+	got := artifacts(t, fail.Artifacts)
+	if !strings.HasPrefix(got["stderr"], "to stderr\n") {
+		t.Errorf("stderr artifact = %q, want output of the run", got["stderr"])
+	}
+	if dump := got["goroutines"]; !strings.Contains(dump, "goroutine ") || strings.Contains(dump, "to stderr") {
+		t.Errorf("goroutines artifact = %q, want only the goroutine dump", dump)
+	}
+	if _, ok := got["core"]; ok {
+		t.Errorf("artifacts include a core file, but the run left none")
+	}
+}
+
+func TestIgnore(t *testing.T) {
+	dir := t.TempDir()
+	s := stress(t, dir, "fail", "-p=1", "-count=1", "-ignore=to stdout")
+	if s.Runs != 1 || s.Failures != 0 || len(s.Logs) != 0 {
+		t.Fatalf("summary = %+v, want 1 run without failures", s)
+	}
+}
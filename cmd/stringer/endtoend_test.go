@@ -73,8 +73,9 @@ func TestEndToEnd(t *testing.T) {
 			t.Errorf("%s is not a Go file", name)
 			continue
 		}
-		if strings.HasPrefix(name, "tag_") || strings.HasPrefix(name, "vary_") {
-			// This file is used for tag processing in TestTags or TestConstValueChange, below.
+		if strings.HasPrefix(name, "tag_") || strings.HasPrefix(name, "vary_") || strings.HasPrefix(name, "flags_") {
+			// This file is used for tag processing in TestTags or TestConstValueChange,
+			// or for bit flags in TestBitFlags, below.
 			continue
 		}
 		t.Run(name, func(t *testing.T) {
@@ -93,6 +94,12 @@ func typeName(fname string) string {
 	return fmt.Sprintf("%c%s", base[0]+'A'-'a', base[1:len(base)-len(".go")])
 }
 
+// TestBitFlags verifies that the -bitflags flag works as advertised.
+func TestBitFlags(t *testing.T) {
+	stringer := stringerPath(t)
+	stringerCompileAndRun(t, t.TempDir(), stringer, "Perm", "flags_perm.go", "-bitflags")
+}
+
 // TestTags verifies that the -tags flag works as advertised.
 func TestTags(t *testing.T) {
 	stringer := stringerPath(t)
@@ -327,7 +334,7 @@ func stringerPath(t *testing.T) string {
 
 // stringerCompileAndRun runs stringer for the named file and compiles and
 // runs the target binary in directory dir. That binary will panic if the String method is incorrect.
-func stringerCompileAndRun(t *testing.T, dir, stringer, typeName, fileName string) {
+func stringerCompileAndRun(t *testing.T, dir, stringer, typeName, fileName string, flags ...string) {
 	t.Logf("run: %s %s\n", fileName, typeName)
 	source := filepath.Join(dir, path.Base(fileName))
 	err := copy(source, filepath.Join("testdata", fileName))
@@ -336,7 +343,8 @@ func stringerCompileAndRun(t *testing.T, dir, stringer, typeName, fileName strin
 	}
 	stringSource := filepath.Join(dir, typeName+"_string.go")
 	// Run stringer in temporary directory.
-	err = run(t, stringer, "-type", typeName, "-output", stringSource, source)
+	args := append(flags, "-type", typeName, "-output", stringSource, source)
+	err = run(t, stringer, args...)
 	if err != nil {
 		t.Fatal(err)
 	}
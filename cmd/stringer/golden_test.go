@@ -23,20 +23,27 @@ type Golden struct {
 	name        string
 	trimPrefix  string
 	lineComment bool
+	bitFlags    bool
+	json        bool // generate the marshaling methods
+	parse       bool // generate the parsing function
+	ignoreCase  bool
 	input       string // input; the package clause is provided when running the test.
 	output      string // expected output.
 }
 
 var golden = []Golden{
-	{"day", "", false, day_in, day_out},
-	{"offset", "", false, offset_in, offset_out},
-	{"gap", "", false, gap_in, gap_out},
-	{"num", "", false, num_in, num_out},
-	{"unum", "", false, unum_in, unum_out},
-	{"unumpos", "", false, unumpos_in, unumpos_out},
-	{"prime", "", false, prime_in, prime_out},
-	{"prefix", "Type", false, prefix_in, prefix_out},
-	{"tokens", "", true, tokens_in, tokens_out},
+	{"day", "", false, false, false, false, false, day_in, day_out},
+	{"offset", "", false, false, false, false, false, offset_in, offset_out},
+	{"gap", "", false, false, false, false, false, gap_in, gap_out},
+	{"num", "", false, false, false, false, false, num_in, num_out},
+	{"unum", "", false, false, false, false, false, unum_in, unum_out},
+	{"unumpos", "", false, false, false, false, false, unumpos_in, unumpos_out},
+	{"prime", "", false, false, false, false, false, prime_in, prime_out},
+	{"prefix", "Type", false, false, false, false, false, prefix_in, prefix_out},
+	{"tokens", "", true, false, false, false, false, tokens_in, tokens_out},
+	{"bitflags", "", false, true, false, false, false, bitflags_in, bitflags_out},
+	{"marshal", "", false, false, true, false, false, marshal_in, marshal_out},
+	{"parse", "", false, false, false, true, true, parse_in, parse_out},
 }
 
 // Each example starts with "type XXX [u]int", with a single space separating them.
@@ -448,6 +455,208 @@ func (i Token) String() string {
 }
 `
 
+// Bit flags, with a zero value and a combination.
+const bitflags_in = `type Perm uint8
+const (
+	Read Perm = 1 << iota
+	Write
+	Exec
+	None      Perm = 0
+	ReadWrite Perm = Read | Write
+)
+`
+
+const bitflags_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Read-1]
+	_ = x[Write-2]
+	_ = x[Exec-4]
+	_ = x[None-0]
+	_ = x[ReadWrite-3]
+}
+
+const _Perm_name = "NoneReadWriteReadWriteExec"
+
+var _Perm_index = [...]uint8{0, 4, 8, 13, 22, 26}
+var _Perm_values = [...]Perm{0, 1, 2, 3, 4}
+
+const _Perm_mask = Perm(7)
+
+func (i Perm) String() string {
+	for j, v := range _Perm_values {
+		if i == v {
+			return _Perm_name[_Perm_index[j]:_Perm_index[j+1]]
+		}
+	}
+	if i == 0 {
+		return "Perm(0)"
+	}
+	var b []byte
+	rest := i
+	for j, v := range _Perm_values {
+		if v != 0 && v&(v-1) == 0 && rest&v != 0 { // a single bit
+			if len(b) > 0 {
+				b = append(b, '|')
+			}
+			b = append(b, _Perm_name[_Perm_index[j]:_Perm_index[j+1]]...)
+			rest &^= v
+		}
+	}
+	if rest != 0 {
+		if len(b) > 0 {
+			b = append(b, '|')
+		}
+		b = append(b, "Perm(0x"...)
+		b = strconv.AppendUint(b, uint64(rest), 16)
+		b = append(b, ')')
+	}
+	return string(b)
+}
+
+// IsValid reports whether i has only the bits of the constants of type Perm.
+func (i Perm) IsValid() bool {
+	return i&^_Perm_mask == 0
+}
+`
+
+// Marshaling methods, for multiple runs.
+const marshal_in = `type Size int
+const (
+	Small Size = 1
+	Medium Size = 2
+	Large Size = 10
+)
+`
+
+const marshal_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[Small-1]
+	_ = x[Medium-2]
+	_ = x[Large-10]
+}
+
+const (
+	_Size_name_0 = "SmallMedium"
+	_Size_name_1 = "Large"
+)
+
+var (
+	_Size_index_0 = [...]uint8{0, 5, 11}
+)
+
+func (i Size) String() string {
+	switch {
+	case 1 <= i && i <= 2:
+		i -= 1
+		return _Size_name_0[_Size_index_0[i]:_Size_index_0[i+1]]
+	case i == 10:
+		return _Size_name_1
+	default:
+		return "Size(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+}
+
+var _Size_byName = map[string]Size{
+	_Size_name_0[0:5]:  1,
+	_Size_name_0[5:11]: 2,
+	_Size_name_1:       10,
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (i Size) MarshalText() ([]byte, error) {
+	s := i.String()
+	if _, ok := _Size_byName[s]; !ok {
+		return nil, fmt.Errorf("invalid Size value %d", i)
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (i *Size) UnmarshalText(text []byte) error {
+	v, ok := _Size_byName[string(text)]
+	if !ok {
+		return fmt.Errorf("invalid Size %q", text)
+	}
+	*i = v
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (i Size) MarshalJSON() ([]byte, error) {
+	text, err := i.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (i *Size) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid Size: %v", err)
+	}
+	return i.UnmarshalText([]byte(s))
+}
+`
+
+// Parsing function of an unexported type, ignoring case.
+const parse_in = `type color int
+const (
+	red color = iota
+	green
+	blue
+)
+`
+
+const parse_out = `func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[red-0]
+	_ = x[green-1]
+	_ = x[blue-2]
+}
+
+const _color_name = "redgreenblue"
+
+var _color_index = [...]uint8{0, 3, 8, 12}
+
+func (i color) String() string {
+	if i < 0 || i >= color(len(_color_index)-1) {
+		return "color(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _color_name[_color_index[i]:_color_index[i+1]]
+}
+
+var _color_byName = map[string]color{
+	_color_name[0:3]:  0,
+	_color_name[3:8]:  1,
+	_color_name[8:12]: 2,
+}
+
+// parseColor returns the color whose name is s, ignoring case.
+func parseColor(s string) (color, error) {
+	v, ok := _color_byName[s]
+	if !ok {
+		for name, w := range _color_byName {
+			if strings.EqualFold(name, s) {
+				v, ok = w, true
+				break
+			}
+		}
+	}
+	if !ok {
+		return 0, fmt.Errorf("invalid color %q", s)
+	}
+	return v, nil
+}
+`
+
 func TestGolden(t *testing.T) {
 	testenv.NeedsTool(t, "go")
 
@@ -473,8 +682,13 @@ func TestGolden(t *testing.T) {
 			}
 
 			g := Generator{
-				pkg:  pkgs[0],
-				logf: t.Logf,
+				pkg:         pkgs[0],
+				bitFlags:    test.bitFlags,
+				textMethods: test.json,
+				jsonMethods: test.json,
+				parse:       test.parse,
+				ignoreCase:  test.ignoreCase,
+				logf:        t.Logf,
 			}
 			g.generate(tokens[1], findValues(tokens[1], pkgs[0]))
 			got := string(g.format())
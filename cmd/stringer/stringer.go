@@ -12,8 +12,8 @@
 // It has helpful defaults designed for use with go generate.
 //
 // Stringer works best with constants that are consecutive values such as created using iota,
-// but creates good code regardless. In the future it might also provide custom support for
-// constant sets that are bit patterns.
+// but creates good code regardless. Constant sets that are bit patterns are
+// better served by the -bitflags flag, described below.
 //
 // For example, given this snippet,
 //
@@ -70,6 +70,47 @@
 //	PillAspirin // Aspirin
 //
 // to suppress it in the output.
+//
+// The -bitflags flag tells stringer that the constants are bit flags, such as
+//
+//	type Perm uint8
+//
+//	const (
+//		Read Perm = 1 << iota
+//		Write
+//		Exec
+//	)
+//
+// The String method then renders a value that is not the value of a constant
+// as the OR'ed combination of the constants that are single bits, so that
+// Read|Write prints as "Read|Write", and any remaining bits in hexadecimal,
+// as in "Read|Perm(0x10)". Stringer also generates the method
+//
+//	func (Perm) IsValid() bool
+//
+// which reports whether a value has only the bits of the constants.
+//
+// The -text flag tells stringer to also generate the methods
+//
+//	func (Pill) MarshalText() ([]byte, error)
+//	func (*Pill) UnmarshalText([]byte) error
+//
+// which encode a value as the same name that String returns, and decode
+// such a name, so that the type has a consistent representation in
+// encodings such as JSON keys and values, XML, and YAML. Values without a
+// name are an error in both directions. With -bitflags, a combination is
+// encoded as String renders it, such as "Read|Write". The -json flag
+// generates MarshalJSON and UnmarshalJSON methods as well, implying -text.
+//
+// The -parse flag tells stringer to also generate the function
+//
+//	func ParsePill(s string) (Pill, error)
+//
+// which returns the value whose name is s, as String returns it, for use
+// in parsing command-line flags and configuration files. With -bitflags,
+// it also accepts combinations such as "Read|Write". With -ignorecase,
+// the names are matched without regard to case. The function of an
+// unexported type, such as pill, is named parsePill.
 package main // import "golang.org/x/tools/cmd/stringer"
 
 import (
@@ -96,6 +137,11 @@ var (
 	trimprefix  = flag.String("trimprefix", "", "trim the `prefix` from the generated constant names")
 	linecomment = flag.Bool("linecomment", false, "use line comment text as printed text when present")
 	buildTags   = flag.String("tags", "", "comma-separated list of build tags to apply")
+	bitFlags    = flag.Bool("bitflags", false, "treat the constants as bit flags, rendering combinations such as \"Read|Write\", and generate an IsValid method")
+	textMethods = flag.Bool("text", false, "also generate MarshalText and UnmarshalText methods")
+	jsonMethods = flag.Bool("json", false, "also generate MarshalJSON and UnmarshalJSON methods; implies -text")
+	parse       = flag.Bool("parse", false, "also generate a ParseT function returning the value of type T with a given name")
+	ignoreCase  = flag.Bool("ignorecase", false, "match names without regard to case in the ParseT function; implies -parse")
 )
 
 // Usage is a replacement usage function for the flags package.
@@ -167,7 +213,12 @@ func main() {
 	})
 	for _, pkg := range pkgs {
 		g := Generator{
-			pkg: pkg,
+			pkg:         pkg,
+			bitFlags:    *bitFlags,
+			textMethods: *textMethods || *jsonMethods,
+			jsonMethods: *jsonMethods,
+			parse:       *parse || *ignoreCase,
+			ignoreCase:  *ignoreCase,
 		}
 
 		// Print the header and package clause.
@@ -175,7 +226,18 @@ func main() {
 		g.Printf("\n")
 		g.Printf("package %s", g.pkg.name)
 		g.Printf("\n")
-		g.Printf("import \"strconv\"\n") // Used by all methods.
+		g.Printf("import (\n")
+		g.Printf("\t\"strconv\"\n") // Used by all methods.
+		if g.textMethods || g.parse {
+			g.Printf("\t\"fmt\"\n")
+			if g.bitFlags || g.ignoreCase {
+				g.Printf("\t\"strings\"\n")
+			}
+		}
+		if g.jsonMethods {
+			g.Printf("\t\"encoding/json\"\n")
+		}
+		g.Printf(")\n")
 
 		// Run generate for types that can be found. Keep the rest for the remainingTypes iteration.
 		var foundTypes, remainingTypes []string
@@ -241,8 +303,14 @@ func isDirectory(name string) bool {
 // Generator holds the state of the analysis. Primarily used to buffer
 // the output for format.Source.
 type Generator struct {
-	buf bytes.Buffer // Accumulated output.
-	pkg *Package     // Package we are scanning.
+	buf      bytes.Buffer // Accumulated output.
+	pkg      *Package     // Package we are scanning.
+	bitFlags bool         // Whether the constants are bit flags.
+
+	textMethods bool // Whether to generate MarshalText and UnmarshalText.
+	jsonMethods bool // Whether to generate MarshalJSON and UnmarshalJSON.
+	parse       bool // Whether to generate the ParseT function.
+	ignoreCase  bool // Whether ParseT ignores case.
 
 	logf func(format string, args ...any) // test logging hook; nil when not testing
 }
@@ -343,7 +411,8 @@ func findValues(typeName string, pkg *Package) []Value {
 	return values
 }
 
-// generate produces the String method for the named type.
+// generate produces the String method, and any marshaling methods and
+// parsing function, for the named type.
 func (g *Generator) generate(typeName string, values []Value) {
 	// Generate code that will fail if the constants change value.
 	g.Printf("func _() {\n")
@@ -355,6 +424,25 @@ func (g *Generator) generate(typeName string, values []Value) {
 	}
 	g.Printf("}\n")
 	runs := splitIntoRuns(values)
+	g.buildString(runs, typeName)
+	if g.textMethods || g.parse {
+		g.declareByName(runs, typeName)
+	}
+	if g.textMethods {
+		g.buildMarshal(typeName)
+	}
+	if g.parse {
+		g.buildParse(runs, typeName)
+	}
+}
+
+// buildString generates the variables and String method for the runs
+// of values of the named type.
+func (g *Generator) buildString(runs [][]Value, typeName string) {
+	if g.bitFlags {
+		g.buildBitFlags(runs, typeName)
+		return
+	}
 	// The decision of which pattern to use depends on the number of
 	// runs in the numbers. If there's only one, it's easy. For more than
 	// one, there's a tradeoff between complexity and size of the data
@@ -377,6 +465,218 @@ func (g *Generator) generate(typeName string, values []Value) {
 	}
 }
 
+// nameExprs returns, for each value of the runs, an expression for its
+// name in terms of the name constants declared by buildString.
+func (g *Generator) nameExprs(runs [][]Value, typeName string) []string {
+	var exprs []string
+	if !g.bitFlags && 1 < len(runs) && len(runs) <= 10 {
+		// buildMultipleRuns declares a name constant per run.
+		for i, run := range runs {
+			if len(run) == 1 {
+				exprs = append(exprs, fmt.Sprintf("_%s_name_%d", typeName, i))
+				continue
+			}
+			n := 0
+			for _, v := range run {
+				exprs = append(exprs, fmt.Sprintf("_%s_name_%d[%d:%d]", typeName, i, n, n+len(v.name)))
+				n += len(v.name)
+			}
+		}
+		return exprs
+	}
+	n := 0
+	for _, run := range runs {
+		for _, v := range run {
+			exprs = append(exprs, fmt.Sprintf("_%s_name[%d:%d]", typeName, n, n+len(v.name)))
+			n += len(v.name)
+		}
+	}
+	return exprs
+}
+
+// declareByName declares the map from the names of the values of the
+// runs to the values.
+func (g *Generator) declareByName(runs [][]Value, typeName string) {
+	exprs := g.nameExprs(runs, typeName)
+	g.Printf("\nvar _%s_byName = map[string]%s{\n", typeName, typeName)
+	n := 0
+	for _, values := range runs {
+		for _, value := range values {
+			g.Printf("\t%s: %s,\n", exprs[n], &value)
+			n++
+		}
+	}
+	g.Printf("}\n\n")
+}
+
+// buildMarshal generates the MarshalText and UnmarshalText methods and,
+// if requested, the MarshalJSON and UnmarshalJSON methods.
+func (g *Generator) buildMarshal(typeName string) {
+	if g.bitFlags {
+		g.Printf(marshalTextBitFlags, typeName)
+	} else {
+		g.Printf(marshalText, typeName)
+	}
+	if g.jsonMethods {
+		g.Printf("\n")
+		g.Printf(marshalJSON, typeName)
+	}
+}
+
+// buildParse generates the ParseT function.
+func (g *Generator) buildParse(runs [][]Value, typeName string) {
+	var values []Value
+	for _, run := range runs {
+		values = append(values, run...)
+	}
+	if g.ignoreCase {
+		// The lookup would be ambiguous.
+		for i, v := range values {
+			for _, w := range values[:i] {
+				if strings.EqualFold(v.name, w.name) {
+					log.Fatalf("-ignorecase: names %q and %q of type %s differ only in case", w.name, v.name, typeName)
+				}
+			}
+		}
+	}
+	fnName := "Parse" + typeName
+	if !ast.IsExported(typeName) {
+		fnName = "parse" + strings.ToUpper(typeName[:1]) + typeName[1:]
+	}
+	var lookup, doc string
+	if g.ignoreCase {
+		lookup = fmt.Sprintf(lookupFold, typeName)
+		doc = ", ignoring case"
+	}
+	g.Printf("\n")
+	if g.bitFlags {
+		g.Printf(parseBitFlags, typeName, fnName, lookup, doc)
+	} else {
+		g.Printf(parseName, typeName, fnName, lookup, doc)
+	}
+}
+
+// Argument to format is the type name.
+const lookupFold = `
+	if !ok {
+		for name, w := range _%[1]s_byName {
+			if strings.EqualFold(name, s) {
+				v, ok = w, true
+				break
+			}
+		}
+	}`
+
+// Arguments to format are:
+//
+//	[1]: type name
+//	[2]: function name
+//	[3]: case-insensitive lookup, if any, of s in _T_byName
+//	[4]: qualification of the doc comment
+const parseName = `// %[2]s returns the %[1]s whose name is s%[4]s.
+func %[2]s(s string) (%[1]s, error) {
+	v, ok := _%[1]s_byName[s]%[3]s
+	if !ok {
+		return 0, fmt.Errorf("invalid %[1]s %%q", s)
+	}
+	return v, nil
+}
+`
+
+// Arguments to format are as for parseName.
+const parseBitFlags = `// %[2]s returns the %[1]s whose name is text%[4]s,
+// where text may be a combination of names as returned by String.
+func %[2]s(text string) (%[1]s, error) {
+	var flags %[1]s
+	for _, s := range strings.Split(text, "|") {
+		v, ok := _%[1]s_byName[s]%[3]s
+		if !ok && strings.HasPrefix(s, "%[1]s(") && strings.HasSuffix(s, ")") {
+			// Bits without a name, as in "%[1]s(0x10)".
+			n, err := strconv.ParseUint(s[len("%[1]s("):len(s)-1], 0, 64)
+			v = %[1]s(n)
+			ok = err == nil && uint64(v) == n && v.IsValid()
+		}
+		if !ok {
+			return 0, fmt.Errorf("invalid %[1]s %%q", text)
+		}
+		flags |= v
+	}
+	return flags, nil
+}
+`
+
+// Argument to format is the type name.
+const marshalText = `// MarshalText implements encoding.TextMarshaler.
+func (i %[1]s) MarshalText() ([]byte, error) {
+	s := i.String()
+	if _, ok := _%[1]s_byName[s]; !ok {
+		return nil, fmt.Errorf("invalid %[1]s value %%d", i)
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (i *%[1]s) UnmarshalText(text []byte) error {
+	v, ok := _%[1]s_byName[string(text)]
+	if !ok {
+		return fmt.Errorf("invalid %[1]s %%q", text)
+	}
+	*i = v
+	return nil
+}
+`
+
+// Argument to format is the type name.
+const marshalTextBitFlags = `// MarshalText implements encoding.TextMarshaler.
+func (i %[1]s) MarshalText() ([]byte, error) {
+	if !i.IsValid() {
+		return nil, fmt.Errorf("invalid %[1]s value %%#x", uint64(i))
+	}
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It accepts the combinations of names returned by String.
+func (i *%[1]s) UnmarshalText(text []byte) error {
+	var v %[1]s
+	for _, name := range strings.Split(string(text), "|") {
+		w, ok := _%[1]s_byName[name]
+		if !ok && strings.HasPrefix(name, "%[1]s(") && strings.HasSuffix(name, ")") {
+			// Bits without a name, as in "%[1]s(0x10)".
+			n, err := strconv.ParseUint(name[len("%[1]s("):len(name)-1], 0, 64)
+			w = %[1]s(n)
+			ok = err == nil && uint64(w) == n && w.IsValid()
+		}
+		if !ok {
+			return fmt.Errorf("invalid %[1]s %%q", text)
+		}
+		v |= w
+	}
+	*i = v
+	return nil
+}
+`
+
+// Argument to format is the type name.
+const marshalJSON = `// MarshalJSON implements json.Marshaler.
+func (i %[1]s) MarshalJSON() ([]byte, error) {
+	text, err := i.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (i *%[1]s) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid %[1]s: %%v", err)
+	}
+	return i.UnmarshalText([]byte(s))
+}
+`
+
 // splitIntoRuns breaks the values into runs of contiguous sequences.
 // For example, given 1,2,3,5,6,7 it returns {1,2,3},{5,6,7}.
 // The input slice is known to be non-empty.
@@ -726,6 +1026,73 @@ func (g *Generator) buildMap(runs [][]Value, typeName string) {
 	g.Printf(stringMap, typeName)
 }
 
+// buildBitFlags generates the variables and the String and IsValid
+// methods for constants that are bit flags.
+func (g *Generator) buildBitFlags(runs [][]Value, typeName string) {
+	var values []Value
+	for _, run := range runs {
+		values = append(values, run...)
+	}
+	var mask uint64
+	for _, v := range values {
+		mask |= v.value
+	}
+	maskStr := fmt.Sprint(mask)
+	if values[0].signed {
+		maskStr = fmt.Sprint(int64(mask))
+	}
+	g.Printf("\n")
+	g.declareIndexAndNameVar(values, typeName)
+	g.Printf("var _%s_values = [...]%s{", typeName, typeName)
+	for i := range values {
+		if i > 0 {
+			g.Printf(", ")
+		}
+		g.Printf("%s", &values[i])
+	}
+	g.Printf("}\n\n")
+	g.Printf("const _%s_mask = %s(%s)\n\n", typeName, typeName, maskStr)
+	g.Printf(stringBitFlags, typeName)
+}
+
+// Argument to format is the type name.
+const stringBitFlags = `func (i %[1]s) String() string {
+	for j, v := range _%[1]s_values {
+		if i == v {
+			return _%[1]s_name[_%[1]s_index[j]:_%[1]s_index[j+1]]
+		}
+	}
+	if i == 0 {
+		return "%[1]s(0)"
+	}
+	var b []byte
+	rest := i
+	for j, v := range _%[1]s_values {
+		if v != 0 && v&(v-1) == 0 && rest&v != 0 { // a single bit
+			if len(b) > 0 {
+				b = append(b, '|')
+			}
+			b = append(b, _%[1]s_name[_%[1]s_index[j]:_%[1]s_index[j+1]]...)
+			rest &^= v
+		}
+	}
+	if rest != 0 {
+		if len(b) > 0 {
+			b = append(b, '|')
+		}
+		b = append(b, "%[1]s(0x"...)
+		b = strconv.AppendUint(b, uint64(rest), 16)
+		b = append(b, ')')
+	}
+	return string(b)
+}
+
+// IsValid reports whether i has only the bits of the constants of type %[1]s.
+func (i %[1]s) IsValid() bool {
+	return i&^_%[1]s_mask == 0
+}
+`
+
 // Argument to format is the type name.
 const stringMap = `func (i %[1]s) String() string {
 	if str, ok := _%[1]s_map[i]; ok {
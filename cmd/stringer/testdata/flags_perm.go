@@ -0,0 +1,40 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Bit flags, generated with -bitflags.
+
+package main
+
+import "fmt"
+
+type Perm int64
+
+const (
+	Read Perm = 1 << iota
+	Write
+	Exec
+	None      Perm = 0
+	ReadWrite Perm = Read | Write
+	Sticky    Perm = -1 << 63
+)
+
+func main() {
+	ck(None, "None", true)
+	ck(Read, "Read", true)
+	ck(ReadWrite, "ReadWrite", true)
+	ck(Read|Exec, "Read|Exec", true)
+	ck(Read|Write|Exec, "Read|Write|Exec", true)
+	ck(Sticky|Exec, "Sticky|Exec", true)
+	ck(Write|16, "Write|Perm(0x10)", false)
+	ck(32, "Perm(0x20)", false)
+}
+
+func ck(perm Perm, str string, valid bool) {
+	if fmt.Sprint(perm) != str {
+		panic("flags_perm.go: " + str)
+	}
+	if perm.IsValid() != valid {
+		panic("flags_perm.go: IsValid: " + str)
+	}
+}
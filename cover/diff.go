@@ -0,0 +1,208 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cover
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+)
+
+// WriteHTMLDiff writes to w an HTML report of the differences in
+// coverage between two sets of profiles, base and head, such as
+// those of a program before and after a change. The report summarizes
+// the coverage of each file in both sets, and shows the source of each
+// file whose coverage differs, highlighting the lines that lost
+// coverage and those that gained it.
+//
+// The readFile function returns the source of the named file, as it is
+// described by head. Lines are compared by number, so the report is
+// most precise when both sets of profiles describe the same source,
+// for example when comparing the coverage of two sets of tests.
+func WriteHTMLDiff(w io.Writer, base, head []*Profile, readFile func(fileName string) ([]byte, error)) error {
+	type side struct{ base, head *Profile }
+	files := make(map[string]*side)
+	for _, p := range base {
+		files[p.FileName] = &side{base: p}
+	}
+	for _, p := range head {
+		if s := files[p.FileName]; s != nil {
+			s.head = p
+		} else {
+			files[p.FileName] = &side{head: p}
+		}
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var data diffData
+	for i, name := range names {
+		s := files[name]
+		f := &diffFile{
+			ID:   fmt.Sprintf("file%d", i),
+			Name: name,
+			Base: percentCovered(s.base),
+			Head: percentCovered(s.head),
+		}
+		data.Files = append(data.Files, f)
+		if s.head == nil {
+			continue // file no longer covered: nothing to show
+		}
+		baseLines, headLines := lineCoverage(s.base), lineCoverage(s.head)
+		for line, state := range headLines {
+			switch {
+			case state == uncovered && baseLines[line] == covered:
+				f.Lost++
+			case state == covered && baseLines[line] != covered:
+				f.Gained++
+			}
+		}
+		if f.Lost == 0 && f.Gained == 0 {
+			continue // no change to show
+		}
+
+		src, err := readFile(name)
+		if err != nil {
+			return fmt.Errorf("can't read %q: %v", name, err)
+		}
+		lines := bytes.SplitAfter(src, []byte("\n"))
+		if len(lines[len(lines)-1]) == 0 {
+			lines = lines[:len(lines)-1] // final newline
+		}
+		for i, text := range lines {
+			line := i + 1
+			var class string
+			switch state := headLines[line]; {
+			case state == uncovered && baseLines[line] == covered:
+				class = "lost"
+			case state == covered && baseLines[line] != covered:
+				class = "gained"
+			case state == covered:
+				class = "cov"
+			case state == uncovered:
+				class = "uncov"
+			}
+			f.Lines = append(f.Lines, diffLine{line, string(bytes.TrimRight(text, "\r\n")), class})
+		}
+		data.Shown = append(data.Shown, f)
+	}
+	return diffTemplate.Execute(w, data)
+}
+
+type diffData struct {
+	Files []*diffFile // all files, in name order
+	Shown []*diffFile // files whose coverage differs
+}
+
+type diffFile struct {
+	ID, Name     string
+	Base, Head   float64 // percentage of statements covered, or -1 if absent
+	Lost, Gained int     // number of lines that lost or gained coverage
+	Lines        []diffLine
+}
+
+type diffLine struct {
+	Num   int
+	Text  string
+	Class string // "", "cov", "uncov", "lost", or "gained"
+}
+
+// lineState is the coverage of a line.
+type lineState uint8
+
+const (
+	noStmt    lineState = iota // line has no statements
+	uncovered                  // line has statements, none executed
+	covered                    // line has statements, some executed
+)
+
+// lineCoverage returns the coverage of each line described by p,
+// which may be nil.
+func lineCoverage(p *Profile) map[int]lineState {
+	lines := make(map[int]lineState)
+	if p == nil {
+		return lines
+	}
+	for _, b := range p.Blocks {
+		if b.NumStmt == 0 {
+			continue
+		}
+		for line := b.StartLine; line <= b.EndLine; line++ {
+			if b.Count > 0 {
+				lines[line] = covered
+			} else if lines[line] == noStmt {
+				lines[line] = uncovered
+			}
+		}
+	}
+	return lines
+}
+
+// percentCovered returns the percentage of the statements described by
+// p that were executed, or -1 if p is nil.
+func percentCovered(p *Profile) float64 {
+	if p == nil {
+		return -1
+	}
+	var total, covered int
+	for _, b := range p.Blocks {
+		total += b.NumStmt
+		if b.Count > 0 {
+			covered += b.NumStmt
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(covered) / float64(total)
+}
+
+var diffTemplate = template.Must(template.New("diff").Funcs(template.FuncMap{
+	"percent": func(f float64) string {
+		if f < 0 {
+			return "-"
+		}
+		return fmt.Sprintf("%.1f%%", f)
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Coverage diff</title>
+<style>
+body { font-family: sans-serif; background: white; color: black; }
+table.summary td, table.summary th { padding: 0 1em; text-align: right; }
+table.summary td.name, table.summary th.name { text-align: left; }
+pre { font-family: monospace; margin: 0; }
+.num { color: rgb(128, 128, 128); display: inline-block; width: 5em; text-align: right; padding-right: 1em; user-select: none; }
+.cov { color: rgb(44, 212, 149); }
+.uncov { color: rgb(192, 0, 0); }
+.lost { background: rgb(255, 200, 200); color: rgb(192, 0, 0); font-weight: bold; }
+.gained { background: rgb(200, 255, 200); color: rgb(0, 128, 0); }
+</style>
+</head>
+<body>
+<h1>Coverage diff</h1>
+<table class="summary">
+<tr><th class="name">File</th><th>Base</th><th>Head</th><th>Lines lost</th><th>Lines gained</th></tr>
+{{range .Files}}<tr>
+<td class="name">{{if .Lines}}<a href="#{{.ID}}">{{.Name}}</a>{{else}}{{.Name}}{{end}}</td>
+<td>{{percent .Base}}</td><td>{{percent .Head}}</td>
+<td{{if .Lost}} class="lost"{{end}}>{{.Lost}}</td><td>{{.Gained}}</td>
+</tr>
+{{end}}</table>
+{{range .Shown}}
+<h2 id="{{.ID}}">{{.Name}}</h2>
+<pre>{{range .Lines}}<span class="num">{{.Num}}</span><span{{if .Class}} class="{{.Class}}"{{end}}>{{.Text}}</span>
+{{end}}</pre>
+{{end}}
+</body>
+</html>
+`))
@@ -0,0 +1,107 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cover
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// MergeProfiles merges several sets of profiles, such as those of
+// the unit and integration tests of the same program, into one.
+// The counts of identical blocks of the same file are combined:
+// added, or in "set" mode, combined by logical OR.
+//
+// All the profiles must have the same mode, and identical blocks must
+// have the same number of statements; otherwise MergeProfiles returns
+// an error.
+func MergeProfiles(sets ...[]*Profile) ([]*Profile, error) {
+	files := make(map[string]*Profile)
+	mode := ""
+	for _, profiles := range sets {
+		for _, p := range profiles {
+			if mode == "" {
+				mode = p.Mode
+			} else if p.Mode != mode {
+				return nil, fmt.Errorf("%s: inconsistent mode: %q and %q", p.FileName, mode, p.Mode)
+			}
+			merged := files[p.FileName]
+			if merged == nil {
+				merged = &Profile{FileName: p.FileName, Mode: p.Mode}
+				files[p.FileName] = merged
+			}
+			merged.Blocks = append(merged.Blocks, p.Blocks...)
+		}
+	}
+
+	profiles := make([]*Profile, 0, len(files))
+	for _, p := range files {
+		// Combine the counts of identical blocks,
+		// as does ParseProfilesFromReader.
+		sort.Slice(p.Blocks, func(i, j int) bool {
+			bi, bj := p.Blocks[i], p.Blocks[j]
+			if bi.StartLine != bj.StartLine {
+				return bi.StartLine < bj.StartLine
+			}
+			if bi.StartCol != bj.StartCol {
+				return bi.StartCol < bj.StartCol
+			}
+			if bi.EndLine != bj.EndLine {
+				return bi.EndLine < bj.EndLine
+			}
+			return bi.EndCol < bj.EndCol
+		})
+		var blocks []ProfileBlock
+		for _, b := range p.Blocks {
+			if n := len(blocks); n > 0 {
+				last := &blocks[n-1]
+				if b.StartLine == last.StartLine &&
+					b.StartCol == last.StartCol &&
+					b.EndLine == last.EndLine &&
+					b.EndCol == last.EndCol {
+					if b.NumStmt != last.NumStmt {
+						return nil, fmt.Errorf("%s:%d.%d,%d.%d: inconsistent NumStmt: %d and %d",
+							p.FileName, b.StartLine, b.StartCol, b.EndLine, b.EndCol, last.NumStmt, b.NumStmt)
+					}
+					if mode == "set" {
+						last.Count |= b.Count
+					} else {
+						last.Count += b.Count
+					}
+					continue
+				}
+			}
+			blocks = append(blocks, b)
+		}
+		p.Blocks = blocks
+		profiles = append(profiles, p)
+	}
+	sort.Sort(byFileName(profiles))
+	return profiles, nil
+}
+
+// WriteProfiles writes profiles to w in the format read by
+// ParseProfilesFromReader, that of "go test -coverprofile".
+// All the profiles must have the same mode.
+func WriteProfiles(w io.Writer, profiles []*Profile) error {
+	if len(profiles) == 0 {
+		return fmt.Errorf("no profiles")
+	}
+	mode := profiles[0].Mode
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "mode: %s\n", mode)
+	for _, p := range profiles {
+		if p.Mode != mode {
+			return fmt.Errorf("%s: inconsistent mode: %q and %q", p.FileName, mode, p.Mode)
+		}
+		for _, b := range p.Blocks {
+			fmt.Fprintf(bw, "%s:%d.%d,%d.%d %d %d\n",
+				p.FileName, b.StartLine, b.StartCol, b.EndLine, b.EndCol, b.NumStmt, b.Count)
+		}
+	}
+	return bw.Flush()
+}
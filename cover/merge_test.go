@@ -0,0 +1,123 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cover
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func mustParse(t *testing.T, input string) []*Profile {
+	t.Helper()
+	profiles, err := ParseProfilesFromReader(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return profiles
+}
+
+func TestMergeProfiles(t *testing.T) {
+	unit := mustParse(t, `mode: count
+a.go:1.1,2.2 1 1
+a.go:3.1,4.2 2 0
+b.go:1.1,1.9 1 0
+`)
+	integration := mustParse(t, `mode: count
+a.go:3.1,4.2 2 5
+a.go:1.1,2.2 1 1
+c.go:1.1,1.9 1 3
+`)
+	merged, err := MergeProfiles(unit, integration)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := mustParse(t, `mode: count
+a.go:1.1,2.2 1 2
+a.go:3.1,4.2 2 5
+b.go:1.1,1.9 1 0
+c.go:1.1,1.9 1 3
+`)
+	if !reflect.DeepEqual(merged, want) {
+		t.Errorf("MergeProfiles = %v, want %v", merged, want)
+	}
+
+	// Round trip through WriteProfiles.
+	var buf bytes.Buffer
+	if err := WriteProfiles(&buf, merged); err != nil {
+		t.Fatal(err)
+	}
+	if got := mustParse(t, buf.String()); !reflect.DeepEqual(got, want) {
+		t.Errorf("WriteProfiles wrote %s", buf.String())
+	}
+
+	// set mode combines counts by logical OR.
+	set1 := mustParse(t, "mode: set\na.go:1.1,2.2 1 1\n")
+	set2 := mustParse(t, "mode: set\na.go:1.1,2.2 1 1\n")
+	merged, err = MergeProfiles(set1, set2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count := merged[0].Blocks[0].Count; count != 1 {
+		t.Errorf("merged set mode count = %d, want 1", count)
+	}
+
+	// Inconsistencies are errors.
+	if _, err := MergeProfiles(unit, set1); err == nil {
+		t.Errorf("MergeProfiles of count and set profiles succeeded, want error")
+	}
+	other := mustParse(t, "mode: count\na.go:1.1,2.2 3 1\n")
+	if _, err := MergeProfiles(unit, other); err == nil {
+		t.Errorf("MergeProfiles of inconsistent NumStmt succeeded, want error")
+	}
+}
+
+func TestWriteHTMLDiff(t *testing.T) {
+	const src = `package a
+
+func f() {
+	g()
+	h()
+}
+`
+	base := mustParse(t, `mode: set
+a/a.go:3.10,4.5 1 1
+a/a.go:5.2,5.5 1 1
+`)
+	head := mustParse(t, `mode: set
+a/a.go:3.10,4.5 1 1
+a/a.go:5.2,5.5 1 0
+b/b.go:1.1,1.5 1 1
+`)
+	var buf bytes.Buffer
+	err := WriteHTMLDiff(&buf, base, head, func(name string) ([]byte, error) {
+		switch name {
+		case "a/a.go":
+			return []byte(src), nil
+		case "b/b.go":
+			return []byte("package b\n"), nil
+		}
+		t.Errorf("WriteHTMLDiff read unexpected file %q", name)
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	html := buf.String()
+	for _, want := range []string{
+		`<td class="name"><a href="#file0">a/a.go</a></td>`,
+		`<td>100.0%</td><td>50.0%</td>`,
+		`<td>-</td><td>100.0%</td>`,
+		`<span class="num">1</span><span class="gained">package b</span>`,
+		`<span class="num">4</span><span class="cov">	g()</span>`,
+		`<span class="num">5</span><span class="lost">	h()</span>`,
+		`<span class="num">6</span><span>}</span>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("report does not contain %q:\n%s", want, html)
+		}
+	}
+}
@@ -5,6 +5,7 @@
 package analysis
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"go/ast"
@@ -74,6 +75,18 @@ type Analyzer struct {
 	// FactTypes establishes a "vertical" dependency between
 	// analysis passes (same analyzer, different packages).
 	FactTypes []Fact
+
+	// Version is an optional string identifying the revision of
+	// the analyzer's logic. Drivers that persist facts or results
+	// across runs, such as in a build cache, record it alongside
+	// them, and discard any that were produced by a different
+	// version of the analyzer rather than reusing them.
+	//
+	// An analyzer whose facts change meaning or encoding should
+	// change its Version. Facts recorded by an analyzer whose
+	// Version is empty are reused only by analyzers whose Version
+	// is also empty.
+	Version string
 }
 
 func (a *Analyzer) String() string { return a.Name }
@@ -102,6 +115,13 @@ type Pass struct {
 
 	Module *Module // the package's enclosing module (possibly nil in some drivers)
 
+	// Context, if non-nil, is cancelled when the driver is no
+	// longer interested in the result of the pass, for example
+	// because a timeout expired or the user edited the file.
+	// Long-running analyzers should periodically check [Pass.Err]
+	// and return promptly once it is non-nil.
+	Context context.Context
+
 	// Report reports a Diagnostic, a finding about a specific location
 	// in the analyzed source code such as a potential mistake.
 	// It may be called by the Run function.
@@ -206,6 +226,16 @@ func (pass *Pass) ReportRangef(rng Range, format string, args ...any) {
 	pass.Report(Diagnostic{Pos: rng.Pos(), End: rng.End(), Message: msg})
 }
 
+// Err returns the error, if any, associated with the cancellation of
+// pass.Context. It returns nil if the driver did not provide a
+// Context or if it has not been cancelled.
+func (pass *Pass) Err() error {
+	if pass.Context == nil {
+		return nil
+	}
+	return pass.Context.Err()
+}
+
 func (pass *Pass) String() string {
 	return fmt.Sprintf("%s@%s", pass.Analyzer.Name, pass.Pkg.Path())
 }
@@ -30,6 +30,7 @@ package checker
 
 import (
 	"bytes"
+	"context"
 	"encoding/gob"
 	"fmt"
 	"go/types"
@@ -57,10 +58,39 @@ type Options struct {
 	SanityCheck bool      // check fact encoding is ok and deterministic
 	FactLog     io.Writer // if non-nil, log each exported fact to it
 
-	// TODO(adonovan): expose ReadFile so that an Overlay specified
-	// in the [packages.Config] can be communicated via
-	// Pass.ReadFile to each Analyzer.
-	readFile analysisinternal.ReadFileFunc
+	// ReadFile, if non-nil, is used in place of [os.ReadFile] to
+	// implement Pass.ReadFile for each Analyzer. A client that
+	// loads packages using an Overlay in the [packages.Config]
+	// should set it (see [OverlayReadFile]) so that analyzers
+	// observe the same file contents as the type checker.
+	ReadFile func(filename string) ([]byte, error)
+
+	// Context, if non-nil, is provided to each Analyzer as
+	// Pass.Context. Once it is cancelled, actions that have not
+	// yet started fail without running their analyzer.
+	Context context.Context
+
+	// Timeout, if positive, limits the running time of each
+	// action. The Pass.Context of an action is cancelled once the
+	// limit is reached, and the action fails with an error naming
+	// the analyzer that exceeded it.
+	Timeout time.Duration
+
+	// Timeouts overrides Timeout for particular analyzers, keyed by
+	// analyzer name. A zero duration means no limit.
+	Timeouts map[string]time.Duration
+}
+
+// OverlayReadFile returns a function, suitable for use as
+// [Options.ReadFile], that reads files from the specified overlay
+// (as used by [packages.Config]) and falls back to the file system.
+func OverlayReadFile(overlay map[string][]byte) func(filename string) ([]byte, error) {
+	return func(filename string) ([]byte, error) {
+		if content, ok := overlay[filename]; ok {
+			return bytes.Clone(content), nil // follow ownership of os.ReadFile
+		}
+		return os.ReadFile(filename)
+	}
 }
 
 // Graph holds the results of a round of analysis, including the graph
@@ -270,6 +300,16 @@ func (act *Action) execOnce() {
 	t0 := time.Now()
 	defer func() { act.Duration = time.Since(t0) }()
 
+	// Don't start work that is no longer wanted.
+	ctx := act.opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := ctx.Err(); err != nil {
+		act.Err = err
+		return
+	}
+
 	// Report an error if any dependency failed.
 	var failed []string
 	for _, dep := range act.Deps {
@@ -346,10 +386,23 @@ func (act *Action) execOnce() {
 		AllPackageFacts:   act.AllPackageFacts,
 	}
 	readFile := os.ReadFile
-	if act.opts.readFile != nil {
-		readFile = act.opts.readFile
+	if act.opts.ReadFile != nil {
+		readFile = act.opts.ReadFile
 	}
 	pass.ReadFile = analysisinternal.CheckedReadFile(pass, readFile)
+	timeout := act.opts.Timeout
+	if d, ok := act.opts.Timeouts[act.Analyzer.Name]; ok {
+		timeout = d
+	}
+	var errTimeout error // cause of the context's cancellation by the timeout
+	if timeout > 0 {
+		errTimeout = fmt.Errorf("analyzer %s exceeded timeout of %v on package %s",
+			pass.Analyzer, timeout, pass.Pkg.Path())
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, timeout, errTimeout)
+		defer cancel()
+	}
+	pass.Context = ctx
 	act.pass = pass
 
 	act.Result, act.Err = func() (any, error) {
@@ -358,6 +411,9 @@ func (act *Action) execOnce() {
 		}
 
 		result, err := pass.Analyzer.Run(pass)
+		if errTimeout != nil && context.Cause(ctx) == errTimeout {
+			return nil, errTimeout
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,78 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package checker
+
+import (
+	"fmt"
+	"go/token"
+	"slices"
+	"strings"
+)
+
+// Dedup merges diagnostics reported by different analyzers that
+// refer to the same span of source and have essentially the same
+// message, such as those reported both by a general-purpose analyzer
+// and by a more specialized one.
+//
+// Of each group of such diagnostics, only the first (in the order of
+// g.Roots) is retained, and its message is annotated with the names
+// of the other analyzers that reported it. Messages are compared
+// without regard to case, spacing, or trailing punctuation.
+// Diagnostics reported more than once by the same analyzer, for
+// example in a package and its test variant, are left alone.
+//
+// Dedup modifies the Diagnostics of the root actions of g.
+func (g *Graph) Dedup() {
+	type key struct {
+		posn, end token.Position
+		message   string
+	}
+	type entry struct {
+		act    *Action
+		index  int      // index of diagnostic within act.Diagnostics
+		others []string // names of other analyzers that reported it
+	}
+	first := make(map[key]*entry)
+	var merged []*entry
+	for _, act := range g.Roots {
+		if act.Err != nil {
+			continue
+		}
+		kept := act.Diagnostics[:0:0]
+		for _, diag := range act.Diagnostics {
+			k := key{
+				posn:    act.Package.Fset.Position(diag.Pos),
+				end:     act.Package.Fset.Position(diag.End),
+				message: normalizeMessage(diag.Message),
+			}
+			if e, ok := first[k]; !ok {
+				first[k] = &entry{act: act, index: len(kept)}
+			} else if e.act.Analyzer != act.Analyzer {
+				if name := act.Analyzer.Name; !slices.Contains(e.others, name) {
+					if e.others == nil {
+						merged = append(merged, e)
+					}
+					e.others = append(e.others, name)
+				}
+				continue // duplicate
+			}
+			kept = append(kept, diag)
+		}
+		act.Diagnostics = kept
+	}
+
+	for _, e := range merged {
+		diag := &e.act.Diagnostics[e.index]
+		diag.Message = fmt.Sprintf("%s (also reported by %s)", diag.Message, strings.Join(e.others, ", "))
+	}
+}
+
+// normalizeMessage returns the form of a diagnostic message used to
+// determine whether two messages are essentially the same.
+func normalizeMessage(msg string) string {
+	msg = strings.Join(strings.Fields(msg), " ")
+	msg = strings.TrimRight(msg, ".!;:")
+	return strings.ToLower(msg)
+}
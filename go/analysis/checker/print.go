@@ -9,12 +9,18 @@ package checker
 
 import (
 	"bytes"
+	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"go/token"
 	"io"
+	"maps"
+	"slices"
+	"sort"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/internal/analysisflags"
+	"golang.org/x/tools/internal/analysisinternal"
 )
 
 // PrintText emits diagnostics as plain text to w.
@@ -77,12 +83,320 @@ func (g *Graph) PrintJSON(w io.Writer) error {
 func writeJSONDiagnostics(w io.Writer, roots []*Action) error {
 	tree := make(analysisflags.JSONTree)
 	forEach(roots, func(act *Action) error {
-		var diags []analysis.Diagnostic
+		var (
+			diags    []analysis.Diagnostic
+			readFile analysisinternal.ReadFileFunc
+		)
 		if act.IsRoot {
 			diags = act.Diagnostics
 		}
-		tree.Add(act.Package.Fset, act.Package.ID, act.Analyzer.Name, diags, act.Err)
+		if act.pass != nil {
+			readFile = act.pass.ReadFile // for rendering fixes as diffs
+		}
+		tree.Add(act.Package.Fset, readFile, act.Package.ID, act.Analyzer.Name, diags, act.Err)
 		return nil
 	})
 	return tree.Print(w)
 }
+
+// PrintRDJSON emits the diagnostics of the root nodes to w in the
+// Reviewdog Diagnostic Format (rdjson), which is understood by code
+// review tools such as reviewdog and may readily be converted to
+// GitHub check annotations. Only the first suggested fix of each
+// diagnostic is included. Errors are not included; see [Action.Err].
+//
+// See https://github.com/reviewdog/reviewdog/tree/master/proto/rdf.
+func (g *Graph) PrintRDJSON(w io.Writer) error {
+	return writeRDJSONDiagnostics(w, g.Roots)
+}
+
+// Types for the rdjson schema.
+type (
+	rdjsonResult struct {
+		Source      rdjsonSource       `json:"source"`
+		Diagnostics []rdjsonDiagnostic `json:"diagnostics"`
+	}
+	rdjsonSource struct {
+		Name string `json:"name"`
+		URL  string `json:"url,omitempty"`
+	}
+	rdjsonDiagnostic struct {
+		Message     string             `json:"message"`
+		Location    rdjsonLocation     `json:"location"`
+		Severity    string             `json:"severity"`
+		Source      rdjsonSource       `json:"source"`
+		Code        *rdjsonCode        `json:"code,omitempty"`
+		Suggestions []rdjsonSuggestion `json:"suggestions,omitempty"`
+		Related     []rdjsonRelated    `json:"related_locations,omitempty"`
+	}
+	rdjsonLocation struct {
+		Path  string      `json:"path"`
+		Range rdjsonRange `json:"range"`
+	}
+	rdjsonRange struct {
+		Start rdjsonPosition `json:"start"`
+		End   rdjsonPosition `json:"end"`
+	}
+	rdjsonPosition struct {
+		Line   int `json:"line"`
+		Column int `json:"column"` // 1-based, in UTF-8 bytes
+	}
+	rdjsonCode struct {
+		Value string `json:"value"`
+		URL   string `json:"url,omitempty"`
+	}
+	rdjsonSuggestion struct {
+		Range rdjsonRange `json:"range"`
+		Text  string      `json:"text"`
+	}
+	rdjsonRelated struct {
+		Message  string         `json:"message"`
+		Location rdjsonLocation `json:"location"`
+	}
+)
+
+func writeRDJSONDiagnostics(w io.Writer, roots []*Action) error {
+	result := rdjsonResult{
+		Source:      rdjsonSource{Name: "go/analysis"},
+		Diagnostics: []rdjsonDiagnostic{},
+	}
+
+	// De-duplicate diagnostics as in writeTextDiagnostics.
+	type key struct {
+		pos token.Position
+		end token.Position
+		*analysis.Analyzer
+		message string
+	}
+	seen := make(map[key]bool)
+
+	forEach(roots, func(act *Action) error {
+		if !act.IsRoot || act.Err != nil {
+			return nil
+		}
+		fset := act.Package.Fset
+		location := func(pos, end token.Pos) rdjsonLocation {
+			posn := fset.Position(pos)
+			endPosn := posn
+			if end.IsValid() {
+				endPosn = fset.Position(end)
+			}
+			return rdjsonLocation{
+				Path: posn.Filename,
+				Range: rdjsonRange{
+					Start: rdjsonPosition{posn.Line, posn.Column},
+					End:   rdjsonPosition{endPosn.Line, endPosn.Column},
+				},
+			}
+		}
+		for _, diag := range act.Diagnostics {
+			k := key{fset.Position(diag.Pos), fset.Position(diag.End), act.Analyzer, diag.Message}
+			if seen[k] {
+				continue // duplicate
+			}
+			seen[k] = true
+
+			rd := rdjsonDiagnostic{
+				Message:  diag.Message,
+				Location: location(diag.Pos, diag.End),
+				Severity: "WARNING",
+				Source:   rdjsonSource{Name: act.Analyzer.Name, URL: act.Analyzer.URL},
+			}
+			if diag.Category != "" || diag.URL != "" {
+				rd.Code = &rdjsonCode{Value: diag.Category, URL: diag.URL}
+			}
+			if len(diag.SuggestedFixes) > 0 {
+				for _, edit := range diag.SuggestedFixes[0].TextEdits {
+					rd.Suggestions = append(rd.Suggestions, rdjsonSuggestion{
+						Range: location(edit.Pos, edit.End).Range,
+						Text:  string(edit.NewText),
+					})
+				}
+			}
+			for _, rel := range diag.Related {
+				rd.Related = append(rd.Related, rdjsonRelated{
+					Message:  rel.Message,
+					Location: location(rel.Pos, rel.End),
+				})
+			}
+			result.Diagnostics = append(result.Diagnostics, rd)
+		}
+		return nil
+	})
+
+	data, err := json.MarshalIndent(result, "", "\t")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
+
+// PrintCheckstyle emits the diagnostics of the root nodes to w in
+// the XML format of the Checkstyle tool, which is understood by many
+// continuous integration systems. Errors are not included; see
+// [Action.Err].
+func (g *Graph) PrintCheckstyle(w io.Writer) error {
+	return writeCheckstyleDiagnostics(w, g.Roots)
+}
+
+// Types for the Checkstyle schema.
+type (
+	checkstyleResult struct {
+		XMLName xml.Name         `xml:"checkstyle"`
+		Version string           `xml:"version,attr"`
+		Files   []checkstyleFile `xml:"file"`
+	}
+	checkstyleFile struct {
+		Name   string            `xml:"name,attr"`
+		Errors []checkstyleError `xml:"error"`
+	}
+	checkstyleError struct {
+		Line     int    `xml:"line,attr"`
+		Column   int    `xml:"column,attr"`
+		Severity string `xml:"severity,attr"`
+		Message  string `xml:"message,attr"`
+		Source   string `xml:"source,attr"`
+	}
+)
+
+func writeCheckstyleDiagnostics(w io.Writer, roots []*Action) error {
+	// De-duplicate diagnostics as in writeTextDiagnostics.
+	type key struct {
+		pos token.Position
+		end token.Position
+		*analysis.Analyzer
+		message string
+	}
+	seen := make(map[key]bool)
+
+	files := make(map[string]*checkstyleFile)
+	forEach(roots, func(act *Action) error {
+		if !act.IsRoot || act.Err != nil {
+			return nil
+		}
+		fset := act.Package.Fset
+		for _, diag := range act.Diagnostics {
+			posn := fset.Position(diag.Pos)
+			k := key{posn, fset.Position(diag.End), act.Analyzer, diag.Message}
+			if seen[k] {
+				continue // duplicate
+			}
+			seen[k] = true
+
+			f, ok := files[posn.Filename]
+			if !ok {
+				f = &checkstyleFile{Name: posn.Filename}
+				files[posn.Filename] = f
+			}
+			f.Errors = append(f.Errors, checkstyleError{
+				Line:     posn.Line,
+				Column:   posn.Column,
+				Severity: "warning",
+				Message:  diag.Message,
+				Source:   act.Analyzer.Name,
+			})
+		}
+		return nil
+	})
+
+	result := checkstyleResult{Version: "4.3"}
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		f := files[name]
+		sort.SliceStable(f.Errors, func(i, j int) bool {
+			x, y := f.Errors[i], f.Errors[j]
+			if x.Line != y.Line {
+				return x.Line < y.Line
+			}
+			return x.Column < y.Column
+		})
+		result.Files = append(result.Files, *f)
+	}
+	return writeXML(w, result)
+}
+
+// PrintJUnit emits the results of the root nodes to w in the JUnit
+// XML format, which is understood by many continuous integration
+// systems. Each package is a test suite, and each analyzer applied
+// to it is a test case, which fails if the analyzer reported any
+// diagnostics, and is in error if the analyzer failed.
+func (g *Graph) PrintJUnit(w io.Writer) error {
+	return writeJUnitDiagnostics(w, g.Roots)
+}
+
+// Types for the JUnit schema.
+type (
+	junitResult struct {
+		XMLName xml.Name     `xml:"testsuites"`
+		Suites  []junitSuite `xml:"testsuite"`
+	}
+	junitSuite struct {
+		Name     string      `xml:"name,attr"`
+		Tests    int         `xml:"tests,attr"`
+		Failures int         `xml:"failures,attr"`
+		Errors   int         `xml:"errors,attr"`
+		Cases    []junitCase `xml:"testcase"`
+	}
+	junitCase struct {
+		ClassName string        `xml:"classname,attr"`
+		Name      string        `xml:"name,attr"`
+		Failure   *junitProblem `xml:"failure,omitempty"`
+		Error     *junitProblem `xml:"error,omitempty"`
+	}
+	junitProblem struct {
+		Message string `xml:"message,attr"`
+		Body    string `xml:",chardata"`
+	}
+)
+
+func writeJUnitDiagnostics(w io.Writer, roots []*Action) error {
+	var (
+		suites []*junitSuite
+		byID   = make(map[string]*junitSuite)
+	)
+	for _, act := range roots {
+		id := act.Package.ID
+		suite, ok := byID[id]
+		if !ok {
+			suite = &junitSuite{Name: id}
+			byID[id] = suite
+			suites = append(suites, suite)
+		}
+		c := junitCase{
+			ClassName: id,
+			Name:      act.Analyzer.Name,
+		}
+		if act.Err != nil {
+			c.Error = &junitProblem{Message: act.Err.Error()}
+			suite.Errors++
+		} else if n := len(act.Diagnostics); n > 0 {
+			var buf bytes.Buffer
+			for _, diag := range act.Diagnostics {
+				analysisflags.PrintPlain(&buf, act.Package.Fset, -1, diag)
+			}
+			c.Failure = &junitProblem{
+				Message: fmt.Sprintf("%d diagnostic(s)", n),
+				Body:    buf.String(),
+			}
+			suite.Failures++
+		}
+		suite.Tests++
+		suite.Cases = append(suite.Cases, c)
+	}
+
+	var result junitResult
+	for _, suite := range suites {
+		result.Suites = append(result.Suites, *suite)
+	}
+	return writeXML(w, result)
+}
+
+// writeXML writes an XML document containing v to w.
+func writeXML(w io.Writer, v any) error {
+	data, err := xml.MarshalIndent(v, "", "\t")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s%s\n", xml.Header, data)
+	return err
+}
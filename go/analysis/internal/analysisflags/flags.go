@@ -16,11 +16,15 @@ import (
 	"go/token"
 	"io"
 	"log"
+	"maps"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 
 	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/internal/analysisinternal"
+	"golang.org/x/tools/internal/diff"
 )
 
 // flags common to all {single,multi,unit}checkers.
@@ -175,7 +179,7 @@ func printFlags() {
 		// flags or fix as these have no effect on unitchecker
 		// (as invoked by 'go vet').
 		switch f.Name {
-		case "debug", "cpuprofile", "memprofile", "trace", "fix":
+		case "debug", "cpuprofile", "memprofile", "trace", "fix", "timeout", "dedup", "format", "metrics":
 			return
 		}
 
@@ -368,9 +372,14 @@ type JSONTextEdit struct {
 // A JSONSuggestedFix describes an edit that should be applied as a whole or not
 // at all. It might contain multiple TextEdits/text_edits if the SuggestedFix
 // consists of multiple non-contiguous edits.
+//
+// Diff, if present, is the effect of the edits rendered as a unified
+// diff of each affected file, for clients that wish to display the
+// change without applying the edits themselves.
 type JSONSuggestedFix struct {
 	Message string         `json:"message"`
 	Edits   []JSONTextEdit `json:"edits"`
+	Diff    string         `json:"diff,omitempty"`
 }
 
 // A JSONDiagnostic describes the JSON schema of an analysis.Diagnostic.
@@ -378,7 +387,8 @@ type JSONSuggestedFix struct {
 // TODO(matloob): include End position if present.
 type JSONDiagnostic struct {
 	Category       string                   `json:"category,omitempty"`
-	Posn           string                   `json:"posn"` // e.g. "file.go:line:column"
+	Posn           string                   `json:"posn"`          // e.g. "file.go:line:column"
+	End            string                   `json:"end,omitempty"` // e.g. "file.go:line:column"
 	Message        string                   `json:"message"`
 	SuggestedFixes []JSONSuggestedFix       `json:"suggested_fixes,omitempty"`
 	Related        []JSONRelatedInformation `json:"related,omitempty"`
@@ -386,16 +396,18 @@ type JSONDiagnostic struct {
 
 // A JSONRelated describes a secondary position and message related to
 // a primary diagnostic.
-//
-// TODO(adonovan): include End position if present.
 type JSONRelatedInformation struct {
-	Posn    string `json:"posn"` // e.g. "file.go:line:column"
+	Posn    string `json:"posn"`          // e.g. "file.go:line:column"
+	End     string `json:"end,omitempty"` // e.g. "file.go:line:column"
 	Message string `json:"message"`
 }
 
 // Add adds the result of analysis 'name' on package 'id'.
 // The result is either a list of diagnostics or an error.
-func (tree JSONTree) Add(fset *token.FileSet, id, name string, diags []analysis.Diagnostic, err error) {
+//
+// If readFile is non-nil, it is used to read the files affected by
+// each suggested fix so that the fix can be rendered as a unified diff.
+func (tree JSONTree) Add(fset *token.FileSet, readFile analysisinternal.ReadFileFunc, id, name string, diags []analysis.Diagnostic, err error) {
 	var v any
 	if err != nil {
 		type jsonError struct {
@@ -416,21 +428,28 @@ func (tree JSONTree) Add(fset *token.FileSet, id, name string, diags []analysis.
 						New:      string(edit.NewText),
 					})
 				}
+				var unified string
+				if readFile != nil {
+					unified = fixDiff(readFile, edits)
+				}
 				fixes = append(fixes, JSONSuggestedFix{
 					Message: fix.Message,
 					Edits:   edits,
+					Diff:    unified,
 				})
 			}
 			var related []JSONRelatedInformation
 			for _, r := range f.Related {
 				related = append(related, JSONRelatedInformation{
 					Posn:    fset.Position(r.Pos).String(),
+					End:     jsonEnd(fset, r.End),
 					Message: r.Message,
 				})
 			}
 			jdiag := JSONDiagnostic{
 				Category:       f.Category,
 				Posn:           fset.Position(f.Pos).String(),
+				End:            jsonEnd(fset, f.End),
 				Message:        f.Message,
 				SuggestedFixes: fixes,
 				Related:        related,
@@ -449,6 +468,40 @@ func (tree JSONTree) Add(fset *token.FileSet, id, name string, diags []analysis.
 	}
 }
 
+// jsonEnd returns the JSON form of an optional end position.
+func jsonEnd(fset *token.FileSet, end token.Pos) string {
+	if !end.IsValid() {
+		return ""
+	}
+	return fset.Position(end).String()
+}
+
+// fixDiff returns the unified diff of the changes made by the edits
+// of a single suggested fix, or "" if it could not be computed.
+func fixDiff(readFile analysisinternal.ReadFileFunc, edits []JSONTextEdit) string {
+	byFile := make(map[string][]diff.Edit)
+	for _, edit := range edits {
+		byFile[edit.Filename] = append(byFile[edit.Filename], diff.Edit{
+			Start: edit.Start,
+			End:   edit.End,
+			New:   edit.New,
+		})
+	}
+	var buf strings.Builder
+	for _, file := range slices.Sorted(maps.Keys(byFile)) {
+		content, err := readFile(file)
+		if err != nil {
+			return ""
+		}
+		unified, err := diff.ToUnified(file+" (old)", file+" (new)", string(content), byFile[file], diff.DefaultContextLines)
+		if err != nil {
+			return "" // inconsistent edits
+		}
+		buf.WriteString(unified)
+	}
+	return buf.String()
+}
+
 func (tree JSONTree) Print(out io.Writer) error {
 	data, err := json.MarshalIndent(tree, "", "\t")
 	if err != nil {
@@ -19,10 +19,12 @@ import (
 	"io"
 
 	"log"
+	"maps"
 	"os"
 	"runtime"
 	"runtime/pprof"
 	"runtime/trace"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -50,8 +52,36 @@ var (
 
 	// IncludeTests indicates whether test files should be analyzed too.
 	IncludeTests = true
+
+	// Timeout limits the running time of each analyzer on each package.
+	Timeout time.Duration
+
+	// Timeouts overrides Timeout for particular analyzers, by name.
+	Timeouts map[string]time.Duration
+
+	// Dedup indicates whether to merge near-identical diagnostics
+	// reported by different analyzers at the same position.
+	Dedup = false
+
+	// Metrics is the name of a file to which metrics about the run
+	// are written, in the Prometheus text exposition format. It may
+	// be pushed to a Prometheus push gateway by other means, such as
+	// curl --data-binary @file http://localhost:9091/metrics/job/vet.
+	Metrics = ""
+
+	// Format selects an alternative output format for diagnostics.
+	// The empty string means plain text (or JSON, with -json).
+	Format = ""
 )
 
+// formats is the set of valid values of the -format flag.
+var formats = map[string]bool{
+	"":           true,
+	"rdjson":     true, // Reviewdog Diagnostic Format, for code review tools
+	"checkstyle": true, // Checkstyle XML, for CI systems
+	"junit":      true, // JUnit XML, for CI systems
+}
+
 // RegisterFlags registers command-line flags used by the analysis driver.
 func RegisterFlags() {
 	// When adding flags here, remember to update
@@ -63,6 +93,11 @@ func RegisterFlags() {
 	flag.StringVar(&MemProfile, "memprofile", "", "write memory profile to this file")
 	flag.StringVar(&Trace, "trace", "", "write trace log to this file")
 	flag.BoolVar(&IncludeTests, "test", IncludeTests, "indicates whether test files should be analyzed, too")
+	flag.Var(timeoutFlag{}, "timeout", "cancel any analyzer that runs longer than this on a package (0 means no limit);\n"+
+		"a comma-separated list of `duration`s, each optionally preceded by name= to apply to only that analyzer")
+	flag.BoolVar(&Dedup, "dedup", Dedup, "merge near-identical diagnostics reported by different analyzers at the same position")
+	flag.StringVar(&Metrics, "metrics", Metrics, "write metrics about the run to this `file`")
+	flag.StringVar(&Format, "format", Format, `alternative output format for diagnostics: "rdjson" (for code review tools), "checkstyle" or "junit" (for CI systems)`)
 }
 
 // Run loads the packages specified by args using go/packages,
@@ -144,6 +179,12 @@ func Run(args []string, analyzers []*analysis.Analyzer) (exitcode int) {
 		}()
 	}
 
+	if !formats[Format] {
+		log.Printf("invalid -format=%q", Format)
+		exitAtLeast(1)
+		return
+	}
+
 	// Load the packages.
 	if dbg('v') {
 		log.SetPrefix("")
@@ -154,7 +195,9 @@ func Run(args []string, analyzers []*analysis.Analyzer) (exitcode int) {
 	// Optimization: if the selected analyzers don't produce/consume
 	// facts, we need source only for the initial packages.
 	allSyntax := needFacts(analyzers)
+	t0 := time.Now()
 	initial, err := load(args, allSyntax)
+	loadTime := time.Since(t0)
 	if err != nil {
 		log.Print(err)
 		exitAtLeast(1)
@@ -177,16 +220,25 @@ func Run(args []string, analyzers []*analysis.Analyzer) (exitcode int) {
 		SanityCheck: dbg('s'),
 		Sequential:  dbg('p'),
 		FactLog:     factLog,
+		Timeout:     Timeout,
+		Timeouts:    Timeouts,
 	}
 	if dbg('v') {
 		log.Printf("building graph of analysis passes")
 	}
+	t0 = time.Now()
 	graph, err := checker.Analyze(analyzers, initial, opts)
 	if err != nil {
 		log.Print(err)
 		exitAtLeast(1)
 		return
 	}
+	if Metrics != "" {
+		if err := exportMetrics(Metrics, graph, loadTime, time.Since(t0)); err != nil {
+			log.Printf("writing metrics: %v", err)
+			exitAtLeast(1)
+		}
+	}
 
 	// Don't print the diagnostics,
 	// but apply all fixes from the root actions.
@@ -214,6 +266,10 @@ func Run(args []string, analyzers []*analysis.Analyzer) (exitcode int) {
 		return
 	}
 
+	if Dedup {
+		graph.Dedup()
+	}
+
 	// Print the results. If !RunDespiteErrors and there
 	// are errors in the packages, this will have 0 exit
 	// code. Otherwise, we prefer to return exit code
@@ -235,6 +291,28 @@ func printDiagnostics(graph *checker.Graph) (exitcode int) {
 		if err := graph.PrintJSON(os.Stdout); err != nil {
 			return 1
 		}
+	} else if Format != "" {
+		// Structured formats don't describe errors,
+		// so print them, and let them alone determine
+		// the exit code.
+		for act := range graph.All() {
+			if act.Err != nil {
+				log.Printf("%s: %v", act, act.Err)
+				exitcode = 1
+			}
+		}
+		var err error
+		switch Format {
+		case "rdjson":
+			err = graph.PrintRDJSON(os.Stdout)
+		case "checkstyle":
+			err = graph.PrintCheckstyle(os.Stdout)
+		case "junit":
+			err = graph.PrintJUnit(os.Stdout)
+		}
+		if err != nil {
+			return 1
+		}
 	} else {
 		if err := graph.PrintText(os.Stderr, analysisflags.Context); err != nil {
 			return 1
@@ -336,3 +414,40 @@ func needFacts(analyzers []*analysis.Analyzer) bool {
 }
 
 func dbg(b byte) bool { return strings.IndexByte(Debug, b) >= 0 }
+
+// timeoutFlag is the flag.Value of the -timeout flag, which sets
+// Timeout and Timeouts from a list such as "1m,printf=10s".
+type timeoutFlag struct{}
+
+func (timeoutFlag) String() string {
+	var items []string
+	if Timeout != 0 {
+		items = append(items, Timeout.String())
+	}
+	for _, name := range slices.Sorted(maps.Keys(Timeouts)) {
+		items = append(items, name+"="+Timeouts[name].String())
+	}
+	return strings.Join(items, ",")
+}
+
+func (timeoutFlag) Set(value string) error {
+	for item := range strings.SplitSeq(value, ",") {
+		name, dur, ok := strings.Cut(item, "=")
+		if !ok {
+			name, dur = "", item
+		}
+		d, err := time.ParseDuration(dur)
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			Timeout = d
+		} else {
+			if Timeouts == nil {
+				Timeouts = make(map[string]time.Duration)
+			}
+			Timeouts[name] = d
+		}
+	}
+	return nil
+}
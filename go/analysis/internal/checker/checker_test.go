@@ -5,17 +5,23 @@
 package checker_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/analysistest"
+	publicchecker "golang.org/x/tools/go/analysis/checker"
 	"golang.org/x/tools/go/analysis/internal/analysisflags"
 	"golang.org/x/tools/go/analysis/internal/checker"
 	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/packages"
 	"golang.org/x/tools/internal/testenv"
 	"golang.org/x/tools/internal/testfiles"
 	"golang.org/x/tools/txtar"
@@ -304,3 +310,180 @@ hello from other
 	// TODO(adonovan): test that fixes are applied to the
 	// pass.ReadFile virtual file tree.
 }
+
+// TestPassReadFileOverlay checks that Pass.ReadFile observes the
+// same overlay as was used to load the packages.
+func TestPassReadFileOverlay(t *testing.T) {
+	testenv.NeedsGoPackages(t)
+
+	const src = `
+-- go.mod --
+module example.com
+
+-- p/file.go --
+package p // from disk
+`
+	fs, err := txtar.FS(txtar.Parse([]byte(src)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpdir := testfiles.CopyToTmp(t, fs)
+
+	filename := filepath.Join(tmpdir, "p", "file.go")
+	overlay := map[string][]byte{filename: []byte("package p // from overlay\n")}
+	cfg := &packages.Config{
+		Mode:    packages.LoadAllSyntax,
+		Dir:     tmpdir,
+		Env:     append(os.Environ(), "GOPROXY=off", "GOWORK=off"),
+		Overlay: overlay,
+	}
+	pkgs, err := packages.Load(cfg, "example.com/p")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got string
+	a := &analysis.Analyzer{
+		Name: "a",
+		Doc:  "doc",
+		Run: func(pass *analysis.Pass) (any, error) {
+			name := pass.Fset.File(pass.Files[0].FileStart).Name()
+			content, err := pass.ReadFile(name)
+			if err != nil {
+				return nil, err
+			}
+			got = string(content)
+			return nil, nil
+		},
+	}
+	opts := &publicchecker.Options{ReadFile: publicchecker.OverlayReadFile(overlay)}
+	if _, err := publicchecker.Analyze([]*analysis.Analyzer{a}, pkgs, opts); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(got, "from overlay") {
+		t.Errorf("Pass.ReadFile returned %q, want overlay content", got)
+	}
+}
+
+// TestTimeout checks that an analyzer that exceeds Options.Timeout
+// observes cancellation and that the error names the analyzer.
+func TestTimeout(t *testing.T) {
+	pkgs := testfiles.LoadPackages(t, txtar.Parse([]byte(`
+-- go.mod --
+module example.com
+
+-- p/p.go --
+package p
+`)), "example.com/p")
+
+	slow := &analysis.Analyzer{
+		Name: "slow",
+		Doc:  "doc",
+		Run: func(pass *analysis.Pass) (any, error) {
+			<-pass.Context.Done()
+			return nil, pass.Err()
+		},
+	}
+	opts := &publicchecker.Options{Timeout: 10 * time.Millisecond}
+	graph, err := publicchecker.Analyze([]*analysis.Analyzer{slow}, pkgs, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := fmt.Sprint(graph.Roots[0].Err)
+	if want := "analyzer slow exceeded timeout"; !strings.Contains(got, want) {
+		t.Errorf("action error = %q, want substring %q", got, want)
+	}
+
+	// A per-analyzer timeout overrides the default.
+	fast := &analysis.Analyzer{
+		Name: "fast",
+		Doc:  "doc",
+		Run: func(pass *analysis.Pass) (any, error) {
+			time.Sleep(20 * time.Millisecond)
+			return nil, nil
+		},
+	}
+	graph, err = publicchecker.Analyze([]*analysis.Analyzer{fast, slow}, pkgs, &publicchecker.Options{
+		Timeout:  time.Hour,
+		Timeouts: map[string]time.Duration{"slow": 10 * time.Millisecond},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, act := range graph.Roots {
+		switch act.Analyzer {
+		case fast:
+			if act.Err != nil {
+				t.Errorf("fast analyzer failed: %v", act.Err)
+			}
+		case slow:
+			if got := fmt.Sprint(act.Err); !strings.Contains(got, "exceeded timeout of 10ms") {
+				t.Errorf("slow analyzer error = %q, want per-analyzer timeout", got)
+			}
+		}
+	}
+
+	// The deadline of the client's context is not the analyzer's timeout.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	graph, err = publicchecker.Analyze([]*analysis.Analyzer{slow}, pkgs, &publicchecker.Options{
+		Context: ctx,
+		Timeout: time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := graph.Roots[0].Err; !errors.Is(err, context.DeadlineExceeded) || strings.Contains(err.Error(), "exceeded timeout") {
+		t.Errorf("action error with client deadline = %v, want the context's error", err)
+	}
+}
+
+// TestDedup checks that Graph.Dedup merges near-identical diagnostics
+// reported by different analyzers at the same position.
+func TestDedup(t *testing.T) {
+	pkgs := testfiles.LoadPackages(t, txtar.Parse([]byte(`
+-- go.mod --
+module example.com
+
+-- p/p.go --
+package p
+
+var x int
+`)), "example.com/p")
+
+	reporter := func(name, msg string) *analysis.Analyzer {
+		return &analysis.Analyzer{
+			Name: name,
+			Doc:  "doc",
+			Run: func(pass *analysis.Pass) (any, error) {
+				obj := pass.Pkg.Scope().Lookup("x")
+				pass.Reportf(obj.Pos(), "%s", msg)
+				return nil, nil
+			},
+		}
+	}
+	analyzers := []*analysis.Analyzer{
+		reporter("a", "x is unused"),
+		reporter("b", "X  is unused."),
+		reporter("c", "x is shadowed"),
+	}
+	graph, err := publicchecker.Analyze(analyzers, pkgs, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	graph.Dedup()
+
+	var got []string
+	for act := range graph.All() {
+		for _, diag := range act.Diagnostics {
+			got = append(got, fmt.Sprintf("%s: %s", act.Analyzer.Name, diag.Message))
+		}
+	}
+	want := []string{
+		"a: x is unused (also reported by b)",
+		"c: x is shadowed",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got diagnostics %q, want %q", got, want)
+	}
+}
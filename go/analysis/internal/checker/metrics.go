@@ -0,0 +1,92 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package checker
+
+// This file defines the optional export of metrics about an analysis
+// run, for monitoring of fleets of analysis jobs.
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"time"
+
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+)
+
+// writeMetrics writes metrics about the analysis run to w in the
+// Prometheus text exposition format. The load and analysis
+// durations are measured by the caller.
+func writeMetrics(w io.Writer, graph *checker.Graph, load, analysis time.Duration) error {
+	type stats struct {
+		diagnostics, errors int
+		duration            time.Duration
+	}
+	byAnalyzer := make(map[string]*stats)
+	packages := make(map[*packages.Package]bool)
+	for act := range graph.All() {
+		packages[act.Package] = true
+		s, ok := byAnalyzer[act.Analyzer.Name]
+		if !ok {
+			s = new(stats)
+			byAnalyzer[act.Analyzer.Name] = s
+		}
+		s.duration += act.Duration
+		if act.Err != nil {
+			s.errors++
+		} else if act.IsRoot {
+			s.diagnostics += len(act.Diagnostics)
+		}
+	}
+	names := make([]string, 0, len(byAnalyzer))
+	for name := range byAnalyzer {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var buf bytes.Buffer
+	metric := func(name, typ, help string) {
+		fmt.Fprintf(&buf, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+	}
+
+	metric("go_analysis_packages", "gauge", "Number of packages analyzed, including dependencies.")
+	fmt.Fprintf(&buf, "go_analysis_packages %d\n", len(packages))
+
+	metric("go_analysis_load_seconds", "gauge", "Time spent loading packages.")
+	fmt.Fprintf(&buf, "go_analysis_load_seconds %g\n", load.Seconds())
+
+	metric("go_analysis_run_seconds", "gauge", "Elapsed time spent running analyzers.")
+	fmt.Fprintf(&buf, "go_analysis_run_seconds %g\n", analysis.Seconds())
+
+	metric("go_analysis_diagnostics", "gauge", "Number of diagnostics reported, by analyzer.")
+	for _, name := range names {
+		fmt.Fprintf(&buf, "go_analysis_diagnostics{analyzer=%q} %d\n", name, byAnalyzer[name].diagnostics)
+	}
+
+	metric("go_analysis_errors", "gauge", "Number of failed actions, by analyzer.")
+	for _, name := range names {
+		fmt.Fprintf(&buf, "go_analysis_errors{analyzer=%q} %d\n", name, byAnalyzer[name].errors)
+	}
+
+	metric("go_analysis_analyzer_seconds", "gauge", "Total time spent in each analyzer, summed over packages.")
+	for _, name := range names {
+		fmt.Fprintf(&buf, "go_analysis_analyzer_seconds{analyzer=%q} %g\n", name, byAnalyzer[name].duration.Seconds())
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// exportMetrics writes the metrics to the named file.
+func exportMetrics(file string, graph *checker.Graph, load, analysis time.Duration) error {
+	var buf bytes.Buffer
+	if err := writeMetrics(&buf, graph, load, analysis); err != nil {
+		return err
+	}
+	return os.WriteFile(file, buf.Bytes(), 0666)
+}
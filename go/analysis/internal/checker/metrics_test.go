@@ -0,0 +1,58 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package checker
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/internal/testfiles"
+	"golang.org/x/tools/txtar"
+)
+
+func TestWriteMetrics(t *testing.T) {
+	const src = `
+-- go.mod --
+module example.com
+
+-- p/p.go --
+package p
+
+var x, y int
+`
+	pkgs := testfiles.LoadPackages(t, txtar.Parse([]byte(src)), "example.com/p")
+
+	a := &analysis.Analyzer{
+		Name: "vars",
+		Doc:  "report each package-level variable",
+		Run: func(pass *analysis.Pass) (any, error) {
+			for _, name := range pass.Pkg.Scope().Names() {
+				pass.Reportf(pass.Pkg.Scope().Lookup(name).Pos(), "var %s", name)
+			}
+			return nil, nil
+		},
+	}
+	graph, err := checker.Analyze([]*analysis.Analyzer{a}, pkgs, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf strings.Builder
+	if err := writeMetrics(&buf, graph, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	got := buf.String()
+	for _, want := range []string{
+		"# TYPE go_analysis_packages gauge\ngo_analysis_packages 1\n",
+		"go_analysis_diagnostics{analyzer=\"vars\"} 2\n",
+		"go_analysis_errors{analyzer=\"vars\"} 0\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("metrics do not contain %q:\n%s", want, got)
+		}
+	}
+}
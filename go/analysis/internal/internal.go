@@ -13,3 +13,11 @@ import "golang.org/x/tools/go/analysis"
 // It may return nil, for example if the action was not
 // executed because of a failed dependent.
 var ActionPass func(action any) *analysis.Pass
+
+// This function is set by the unitchecker package to allow the
+// plugin package to make unitchecker read the type information of
+// imported packages from files written by [gcexportdata.Write]
+// instead of from compiler output.
+//
+// [gcexportdata.Write]: https://pkg.go.dev/golang.org/x/tools/go/gcexportdata#Write
+var UnitcheckerUseGCExportData func()
@@ -10,9 +10,9 @@ package assign
 import (
 	_ "embed"
 	"go/ast"
+	"go/constant"
 	"go/token"
 	"go/types"
-	"reflect"
 	"strings"
 
 	"golang.org/x/tools/go/analysis"
@@ -58,23 +58,10 @@ func run(pass *analysis.Pass) (any, error) {
 		)
 		for i, lhs := range stmt.Lhs {
 			rhs := stmt.Rhs[i]
-			isSelfAssign := false
-			var le string
-
-			if !analysisutil.HasSideEffects(pass.TypesInfo, lhs) &&
-				!analysisutil.HasSideEffects(pass.TypesInfo, rhs) &&
-				!isMapIndex(pass.TypesInfo, lhs) &&
-				reflect.TypeOf(lhs) == reflect.TypeOf(rhs) { // short-circuit the heavy-weight gofmt check
-
-				le = analysisinternal.Format(pass.Fset, lhs)
-				re := analysisinternal.Format(pass.Fset, rhs)
-				if le == re {
-					isSelfAssign = true
-				}
-			}
+			isSelfAssign := !isMapIndex(pass.TypesInfo, lhs) && sameVar(pass.TypesInfo, lhs, rhs)
 
 			if isSelfAssign {
-				exprs = append(exprs, le)
+				exprs = append(exprs, analysisinternal.Format(pass.Fset, lhs))
 				if !runStartLHS.IsValid() {
 					// Start of a new run of self-assignments.
 					if i > 0 {
@@ -141,3 +128,64 @@ func isMapIndex(info *types.Info, e ast.Expr) bool {
 	}
 	return false
 }
+
+// sameVar reports whether x and y denote the same variable, such as
+// p.f and (*p).f, or a[1] and a[k] for a constant k equal to 1. The
+// evaluation of each expression must have no side effects: its index
+// operands must be constants or variables.
+func sameVar(info *types.Info, x, y ast.Expr) bool {
+	x, y = ast.Unparen(x), ast.Unparen(y)
+	switch x := x.(type) {
+	case *ast.Ident:
+		y, ok := y.(*ast.Ident)
+		if !ok {
+			return false
+		}
+		v, ok := info.Uses[x].(*types.Var)
+		return ok && info.Uses[y] == v
+
+	case *ast.SelectorExpr:
+		y, ok := y.(*ast.SelectorExpr)
+		if !ok {
+			return false
+		}
+		xsel, ysel := info.Selections[x], info.Selections[y]
+		if xsel == nil || ysel == nil {
+			// qualified identifier, pkg.V
+			v, ok := info.Uses[x.Sel].(*types.Var)
+			return ok && xsel == ysel && info.Uses[y.Sel] == v
+		}
+		return xsel.Kind() == types.FieldVal && ysel.Kind() == types.FieldVal &&
+			xsel.Obj() == ysel.Obj() &&
+			sameVar(info, implicitDeref(x.X), implicitDeref(y.X))
+
+	case *ast.IndexExpr:
+		y, ok := y.(*ast.IndexExpr)
+		if !ok {
+			return false
+		}
+		if !sameVar(info, implicitDeref(x.X), implicitDeref(y.X)) {
+			return false
+		}
+		xv, yv := info.Types[x.Index].Value, info.Types[y.Index].Value
+		if xv != nil && yv != nil {
+			return constant.Compare(xv, token.EQL, yv)
+		}
+		return sameVar(info, x.Index, y.Index)
+
+	case *ast.StarExpr:
+		y, ok := y.(*ast.StarExpr)
+		return ok && sameVar(info, x.X, y.X)
+	}
+	return false
+}
+
+// implicitDeref returns the operand of an explicit pointer
+// dereference *p, which denotes the same variable as p in the operand
+// of a field selection or an index expression.
+func implicitDeref(e ast.Expr) ast.Expr {
+	if star, ok := ast.Unparen(e).(*ast.StarExpr); ok {
+		return star.X
+	}
+	return e
+}
@@ -10,5 +10,9 @@
 //
 // This checker reports assignments of the form x = x or a[i] = a[i].
 // These are almost always useless, and even when they aren't they are
-// usually a mistake.
+// usually a mistake. The two sides need not be written identically:
+// an assignment such as p.f = (*p).f or a[1] = a[k], for a constant k
+// equal to 1, is also reported. Assignments whose index expressions
+// may have side effects, such as a[f()] = a[f()], and assignments to
+// map elements are not reported.
 package assign
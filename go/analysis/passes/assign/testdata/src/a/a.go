@@ -69,3 +69,29 @@ func Index() {
 	}
 	psm.m["key"] = psm.m["key"] // handles dereferences
 }
+
+type Node struct {
+	items []int
+	next  *Node
+	grid  [2][2]int
+}
+
+const one = 1
+
+func Semantic(n *Node, p *int, pa *[2]int, i, j int, ch chan int) {
+	x := 0
+	x = (x)                         // want "self-assignment of x"
+	*p = *p                         // want `self-assignment of \*p`
+	n.items[i] = n.items[i]         // want `self-assignment of n.items\[i\]`
+	n.items[1] = n.items[0+1]       // want `self-assignment of n.items\[1\]`
+	n.items[one] = n.items[1]       // want `self-assignment of n.items\[one\]`
+	n.grid[one][i] = n.grid[1][(i)] // want `self-assignment of n.grid\[one\]\[i\]`
+	(*n).next = n.next              // want `self-assignment of \(\*n\).next`
+	(*pa)[0] = pa[0]                // want `self-assignment of \(\*pa\)\[0\]`
+
+	n.items[i] = n.items[j]         // different indexes
+	n.items[i] = n.items[1]         // different indexes
+	n.items[<-ch] = n.items[<-ch]   // side effects
+	n.items[num()] = n.items[num()] // side effects
+	n.next.items[i] = n.items[i]    // different variables
+}
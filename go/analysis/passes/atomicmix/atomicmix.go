@@ -0,0 +1,127 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package atomicmix defines an Analyzer that reports variables
+// accessed both atomically and non-atomically.
+package atomicmix
+
+import (
+	_ "embed"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/analysis/passes/internal/analysisutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+	"golang.org/x/tools/internal/analysisinternal"
+)
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "atomicmix",
+	Doc:      analysisutil.MustExtractDoc(doc, "atomicmix"),
+	URL:      "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/atomicmix",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	if !analysisinternal.Imports(pass.Pkg, "sync/atomic") {
+		return nil, nil // doesn't directly import sync/atomic
+	}
+
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	// Find the variables whose address is passed to an atomic
+	// function, and the first such call for each.
+	atomics := make(map[*types.Var]*ast.CallExpr)
+	for cur := range inspect.Root().Preorder((*ast.CallExpr)(nil)) {
+		call := cur.Node().(*ast.CallExpr)
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "sync/atomic" ||
+			fn.Signature().Recv() != nil || len(call.Args) == 0 {
+			continue
+		}
+		addr, ok := ast.Unparen(call.Args[0]).(*ast.UnaryExpr)
+		if !ok || addr.Op != token.AND {
+			continue
+		}
+		if v := variable(pass.TypesInfo, addr.X); v != nil && atomics[v] == nil {
+			atomics[v] = call
+		}
+	}
+	if len(atomics) == 0 {
+		return nil, nil
+	}
+
+	// Report the other accesses to those variables.
+	for cur := range inspect.Root().Preorder((*ast.Ident)(nil)) {
+		id := cur.Node().(*ast.Ident)
+		v, ok := pass.TypesInfo.Uses[id].(*types.Var)
+		if !ok || atomics[v] == nil {
+			continue
+		}
+
+		// Find the expression that denotes the variable,
+		// x or x.f, and its parent, ignoring parentheses.
+		access := cur
+		if sel, ok := cur.Parent().Node().(*ast.SelectorExpr); ok && sel.Sel == id {
+			access = cur.Parent()
+		}
+		parent := access.Parent()
+		for {
+			if _, ok := parent.Node().(*ast.ParenExpr); !ok {
+				break
+			}
+			parent = parent.Parent()
+		}
+		switch parent := parent.Node().(type) {
+		case *ast.UnaryExpr:
+			if parent.Op == token.AND {
+				continue // not an access
+			}
+		case *ast.KeyValueExpr:
+			if parent.Key == access.Node() && v.IsField() {
+				continue // field of a composite literal
+			}
+		}
+
+		call := atomics[v]
+		fn := typeutil.Callee(pass.TypesInfo, call)
+		pass.Report(analysis.Diagnostic{
+			Pos: access.Node().Pos(),
+			End: access.Node().End(),
+			Message: fmt.Sprintf("non-atomic access to %s, which is also accessed by atomic.%s",
+				analysisinternal.Format(pass.Fset, access.Node()), fn.Name()),
+			Related: []analysis.RelatedInformation{{
+				Pos:     call.Pos(),
+				End:     call.End(),
+				Message: "atomic access",
+			}},
+		})
+	}
+	return nil, nil
+}
+
+// variable returns the variable or struct field denoted by the
+// expression x, pkg.x, or e.f, or nil.
+func variable(info *types.Info, e ast.Expr) *types.Var {
+	var id *ast.Ident
+	switch e := ast.Unparen(e).(type) {
+	case *ast.Ident:
+		id = e
+	case *ast.SelectorExpr:
+		id = e.Sel
+	default:
+		return nil
+	}
+	v, _ := info.Uses[id].(*types.Var)
+	return v
+}
@@ -0,0 +1,35 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package atomicmix defines an Analyzer that reports variables
+// accessed both atomically and non-atomically.
+//
+// # Analyzer atomicmix
+//
+// atomicmix: check for variables accessed both atomically and non-atomically
+//
+// The atomicmix analyzer reports ordinary reads and writes of a
+// variable or struct field that the same package elsewhere accesses
+// using the functions of the sync/atomic package, such as
+// atomic.AddInt64 or atomic.LoadPointer:
+//
+//	type counter struct{ n int64 }
+//
+//	func (c *counter) inc() { atomic.AddInt64(&c.n, 1) }
+//
+//	func (c *counter) get() int64 { return c.n } // "non-atomic access to c.n, which is also accessed by atomic.AddInt64"
+//
+// Once a variable is accessed atomically by one goroutine, every
+// concurrent access must be atomic too; otherwise the program has a
+// data race. Use the atomic functions for all accesses, or better,
+// declare the variable using one of the types of sync/atomic, such
+// as atomic.Int64, which cannot be accessed non-atomically.
+//
+// A struct field is considered to be accessed atomically if it is so
+// accessed in any value of the struct type. Accesses that are safe
+// because the variable is not yet shared, such as the initialization
+// of a new value, are reported too, except for the fields of a
+// composite literal. Taking the address of the variable is not an
+// access.
+package atomicmix
@@ -0,0 +1,60 @@
+package a
+
+import (
+	"sync/atomic"
+	"unsafe"
+)
+
+type counter struct {
+	n     int64
+	other int64
+}
+
+func (c *counter) inc() { atomic.AddInt64(&c.n, 1) }
+
+func (c *counter) get() int64 { return c.n } // want `non-atomic access to c.n, which is also accessed by atomic.AddInt64`
+
+func (c *counter) reset() {
+	c.n = 0 // want `non-atomic access to c.n, which is also accessed by atomic.AddInt64`
+	c.other = 0
+}
+
+func (c *counter) load() int64 { return atomic.LoadInt64(&(c.n)) }
+
+func newCounter() *counter {
+	return &counter{n: 1} // ok: field of a composite literal
+}
+
+func ptr(c *counter) *int64 { return &c.n } // ok: not an access
+
+var (
+	ready uint32
+	done  uint32
+	p     unsafe.Pointer
+)
+
+func setReady() { atomic.StoreUint32(&ready, 1) }
+
+func isReady() bool { return (ready) == 1 } // want `non-atomic access to ready, which is also accessed by atomic.StoreUint32`
+
+func finish() {
+	if atomic.CompareAndSwapUint32(&done, 0, 1) {
+		atomic.StorePointer(&p, nil)
+	}
+}
+
+func finished() bool { return atomic.LoadUint32(&done) == 1 } // ok: all accesses are atomic
+
+func pointer() unsafe.Pointer { return p } // want `non-atomic access to p, which is also accessed by atomic.StorePointer`
+
+func local() int32 {
+	var n int32
+	go atomic.AddInt32(&n, 1)
+	n++ // want `non-atomic access to n, which is also accessed by atomic.AddInt32`
+	return atomic.LoadInt32(&n)
+}
+
+// Typed atomics cannot be accessed non-atomically.
+var typed atomic.Int64
+
+func incTyped() { typed.Add(1) }
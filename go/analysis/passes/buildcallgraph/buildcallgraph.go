@@ -0,0 +1,96 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package buildcallgraph defines an Analyzer that constructs an
+// approximate call graph of an error-free package from its SSA form.
+// It does not report any diagnostics itself but may be used as an
+// input to other analyzers that need call information, such as checks
+// for unused code, taint analyses, or checks for leaking goroutines.
+//
+// The analysis API analyzes each package independently, so the call
+// graph is that of a partial program: the functions of the current
+// package, and the functions of its direct imports, whose bodies are
+// not available. A dynamic call in the current package may therefore
+// call functions of other packages that do not appear in the graph.
+// The -algo flag selects the algorithm used to construct the graph,
+// one of static, cha (the default), or vta.
+package buildcallgraph
+
+import (
+	"fmt"
+	"reflect"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/callgraph/cha"
+	"golang.org/x/tools/go/callgraph/static"
+	"golang.org/x/tools/go/callgraph/vta"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:       "buildcallgraph",
+	Doc:        "build an approximate call graph for later passes",
+	URL:        "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/buildcallgraph",
+	Requires:   []*analysis.Analyzer{buildssa.Analyzer},
+	Run:        run,
+	ResultType: reflect.TypeOf(new(CallGraph)),
+}
+
+var algo = "cha" // call graph construction algorithm
+
+func init() {
+	Analyzer.Flags.StringVar(&algo, "algo", algo,
+		"call graph construction algorithm (static, cha, vta)")
+}
+
+// CallGraph provides an approximate call graph of the functions in
+// the SSA form of the current package, as provided by
+// [buildssa.Analyzer].
+type CallGraph struct {
+	Graph *callgraph.Graph
+}
+
+// Callees returns the functions that may be called by the call
+// instruction site, in the order of the edges of the graph.
+func (cg *CallGraph) Callees(site ssa.CallInstruction) []*ssa.Function {
+	var callees []*ssa.Function
+	if n := cg.Graph.Nodes[site.Parent()]; n != nil {
+		for _, e := range n.Out {
+			if e.Site == site {
+				callees = append(callees, e.Callee.Func)
+			}
+		}
+	}
+	return callees
+}
+
+// Callers returns the call edges whose callee is fn.
+func (cg *CallGraph) Callers(fn *ssa.Function) []*callgraph.Edge {
+	if n := cg.Graph.Nodes[fn]; n != nil {
+		return n.In
+	}
+	return nil
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	prog := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA).Pkg.Prog
+
+	// The program contains only the current package and its
+	// direct imports (see buildssa).
+	var cg *callgraph.Graph
+	switch algo {
+	case "static":
+		cg = static.CallGraph(prog)
+	case "cha":
+		cg = cha.CallGraph(prog)
+	case "vta":
+		cg = vta.CallGraph(ssautil.AllFunctions(prog), nil)
+	default:
+		return nil, fmt.Errorf("unknown call graph algorithm: %s", algo)
+	}
+	return &CallGraph{Graph: cg}, nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package buildcallgraph_test
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/analysis/passes/buildcallgraph"
+	"golang.org/x/tools/go/callgraph"
+)
+
+func Test(t *testing.T) {
+	testdata := analysistest.TestData()
+	result := analysistest.Run(t, testdata, buildcallgraph.Analyzer, "a")[0].Result
+
+	cg := result.(*buildcallgraph.CallGraph)
+	var edges []string
+	callgraph.GraphVisitEdges(cg.Graph, func(e *callgraph.Edge) error {
+		if pkg := e.Caller.Func.Pkg; pkg != nil && pkg.Pkg.Path() == "a" && e.Site != nil {
+			edges = append(edges, fmt.Sprintf("%s --> %s", e.Caller.Func, e.Callee.Func))
+		}
+		return nil
+	})
+	slices.Sort(edges)
+	got := strings.Join(edges, "\n")
+	want := strings.Join([]string{
+		"a.call --> (*a.T).M",
+		"a.call --> (*a.U).M",
+		"a.call --> (a.T).M",
+		"a.init --> strings.init",
+		"a.main --> a.call",
+		"a.main --> a.main$1",
+		"a.main --> strings.ToUpper",
+		"a.main$1 --> strings.ToLower",
+	}, "\n")
+	if got != want {
+		t.Errorf("got edges:\n%s\nwant:\n%s", got, want)
+	}
+
+	// Callees and Callers agree with the graph.
+	for _, n := range cg.Graph.Nodes {
+		for _, e := range n.Out {
+			if e.Site != nil && !slices.Contains(cg.Callees(e.Site), e.Callee.Func) {
+				t.Errorf("Callees(%v) does not include %v", e.Site, e.Callee.Func)
+			}
+			if !slices.Contains(cg.Callers(e.Callee.Func), e) {
+				t.Errorf("Callers(%v) does not include %v", e.Callee.Func, e)
+			}
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package a
+
+import "strings"
+
+type I interface{ M() }
+
+type T struct{}
+
+func (T) M() {}
+
+type U struct{}
+
+func (*U) M() {}
+
+func call(i I) { i.M() }
+
+func main() {
+	call(T{})
+	f := strings.ToUpper
+	f("a")
+	func() { _ = strings.ToLower("A") }()
+}
@@ -0,0 +1,406 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package closeleak defines an Analyzer that checks that resources
+// such as files, network connections, and HTTP response bodies are
+// closed on all paths.
+package closeleak
+
+import (
+	_ "embed"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/ctrlflow"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/analysis/passes/internal/analysisutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/cfg"
+	"golang.org/x/tools/go/types/typeutil"
+	"golang.org/x/tools/internal/analysisinternal"
+)
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name: "closeleak",
+	Doc:  analysisutil.MustExtractDoc(doc, "closeleak"),
+	URL:  "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/closeleak",
+	Run:  run,
+	Requires: []*analysis.Analyzer{
+		inspect.Analyzer,
+		ctrlflow.Analyzer,
+	},
+}
+
+func init() {
+	Analyzer.Flags.Var(&openFuncs, "funcs",
+		"comma-separated list of additional functions or methods, such as (*example.com/pool.Pool).Acquire, that return a resource to be closed")
+}
+
+// openFuncs is the set of functions (identified by
+// (*types.Func).FullName) whose first result is a resource that
+// must be closed.
+//
+// The -funcs flag adds to this set.
+var openFuncs = stringSetFlag{
+	"os.Create":                   true,
+	"os.CreateTemp":               true,
+	"os.Open":                     true,
+	"os.OpenFile":                 true,
+	"net.Dial":                    true,
+	"net.DialTimeout":             true,
+	"net.Listen":                  true,
+	"net.ListenPacket":            true,
+	"(*net.Dialer).Dial":          true,
+	"(*net.Dialer).DialContext":   true,
+	"net/http.Get":                true,
+	"net/http.Head":               true,
+	"net/http.Post":               true,
+	"net/http.PostForm":           true,
+	"(*net/http.Client).Do":       true,
+	"(*net/http.Client).Get":      true,
+	"(*net/http.Client).Head":     true,
+	"(*net/http.Client).Post":     true,
+	"(*net/http.Client).PostForm": true,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	cfgs := pass.ResultOf[ctrlflow.Analyzer].(*ctrlflow.CFGs)
+
+	for cur := range inspect.Root().Preorder((*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)) {
+		var (
+			body *ast.BlockStmt
+			g    *cfg.CFG
+		)
+		switch n := cur.Node().(type) {
+		case *ast.FuncDecl:
+			body = n.Body
+			if body != nil {
+				g = cfgs.FuncDecl(n)
+			}
+		case *ast.FuncLit:
+			body = n.Body
+			g = cfgs.FuncLit(n)
+		}
+		if g != nil {
+			curBody, _ := cur.FindNode(body)
+			runFunc(pass, curBody, g)
+		}
+	}
+	return nil, nil
+}
+
+// A resource is a value returned by an open function, such as os.Open,
+// and assigned to a local variable.
+type resource struct {
+	assign *ast.AssignStmt
+	call   *ast.CallExpr
+	v      *types.Var // the variable holding the resource
+	err    *types.Var // the variable holding the error result, or nil
+	body   bool       // the resource is v.Body, of a *http.Response
+}
+
+// name returns the expression that denotes the resource to be closed.
+func (r *resource) name() string {
+	if r.body {
+		return r.v.Name() + ".Body"
+	}
+	return r.v.Name()
+}
+
+// runFunc checks the resources opened by a single function.
+func runFunc(pass *analysis.Pass, curBody inspector.Cursor, g *cfg.CFG) {
+	info := pass.TypesInfo
+	body := curBody.Node().(*ast.BlockStmt)
+
+	// Find the resources opened by this function.
+	var resources []*resource
+	analysisinternal.InspectFunc(body, func(n ast.Node) {
+		assign, ok := n.(*ast.AssignStmt)
+		if !ok || len(assign.Rhs) != 1 {
+			return
+		}
+		call, ok := ast.Unparen(assign.Rhs[0]).(*ast.CallExpr)
+		if !ok {
+			return
+		}
+		fn, ok := typeutil.Callee(info, call).(*types.Func)
+		if !ok || !openFuncs[fn.Origin().FullName()] {
+			return
+		}
+		id, ok := ast.Unparen(assign.Lhs[0]).(*ast.Ident)
+		if !ok {
+			return
+		}
+		v, ok := info.ObjectOf(id).(*types.Var)
+		if !ok || v.Pos() < body.Pos() || v.Pos() >= body.End() {
+			return // not a local variable
+		}
+		r := &resource{assign: assign, call: call, v: v}
+		if analysisinternal.IsPointerToNamed(v.Type(), "net/http", "Response") {
+			r.body = true
+		} else if !hasClose(v.Type()) {
+			return
+		}
+		for _, lhs := range assign.Lhs[1:] {
+			if id, ok := ast.Unparen(lhs).(*ast.Ident); ok {
+				if v, ok := info.ObjectOf(id).(*types.Var); ok && analysisinternal.IsErrorType(v.Type()) {
+					r.err = v
+				}
+			}
+		}
+		resources = append(resources, r)
+	})
+
+	for _, r := range resources {
+		isClose := func(n ast.Node) bool { return containsClose(info, n, r) }
+
+		// Is the resource closed by a deferred call, or does it escape?
+		deferred, escapes := false, false
+		for c := range curBody.Preorder((*ast.Ident)(nil), (*ast.DeferStmt)(nil)) {
+			switch n := c.Node().(type) {
+			case *ast.DeferStmt:
+				if lit, ok := n.Call.Fun.(*ast.FuncLit); ok {
+					deferred = deferred || isClose(lit.Body)
+				} else {
+					deferred = deferred || isClose(n.Call)
+				}
+			case *ast.Ident:
+				if info.Uses[n] == r.v {
+					escapes = escapes || escaping(c, body)
+				}
+			}
+		}
+		if deferred || escapes {
+			continue
+		}
+
+		exit := unclosedExit(info, g, body, r, isClose)
+		if exit == nil {
+			continue
+		}
+
+		name := r.name()
+		msg := name + ".Close is not called on all paths after " + analysisinternal.Format(pass.Fset, r.call.Fun)
+		if !isClose(body) {
+			msg = name + ".Close is never called after " + analysisinternal.Format(pass.Fset, r.call.Fun)
+		}
+		pass.ReportRangef(r.assign, "%s", msg)
+
+		switch exit := exit.(type) {
+		case *ast.ReturnStmt:
+			pass.ReportRangef(exit, "this return statement is reached without calling %s.Close", name)
+		case *ast.BlockStmt:
+			pass.Reportf(exit.Rbrace, "the end of the function is reached without calling %s.Close", name)
+		case *ast.AssignStmt:
+			if exit != r.assign { // (a loop, if r.assign)
+				pass.ReportRangef(exit, "this assignment overwrites %s without calling %s.Close", r.v.Name(), name)
+			}
+		}
+	}
+}
+
+// escaping reports whether the use of a resource variable at cursor c
+// may transfer responsibility for closing it elsewhere. Selections,
+// such as method calls, comparisons, and assignments to the variable
+// do not; all other uses do, including references from function
+// literals other than deferred ones.
+func escaping(c inspector.Cursor, body *ast.BlockStmt) bool {
+	for lit := range c.Enclosing((*ast.FuncLit)(nil)) {
+		if lit.Node().Pos() < body.Pos() {
+			break // the function itself
+		}
+		call, ok := lit.Parent().Node().(*ast.CallExpr)
+		if !ok || call.Fun != lit.Node() || !is[*ast.DeferStmt](lit.Parent().Parent().Node()) {
+			return true // referenced by a function literal
+		}
+	}
+
+	id := c.Node().(*ast.Ident)
+	switch parent := c.Parent().Node().(type) {
+	case *ast.SelectorExpr:
+		return parent.X != id
+	case *ast.BinaryExpr:
+		return parent.Op != token.EQL && parent.Op != token.NEQ
+	case *ast.AssignStmt:
+		for _, lhs := range parent.Lhs {
+			if lhs == id {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// unclosedExit returns the first return statement, function body
+// (denoting its end), or assignment to the resource variable that is
+// reachable from the statement that opens the resource along a path
+// that does not close it, or nil if there is none.
+func unclosedExit(info *types.Info, g *cfg.CFG, body *ast.BlockStmt, r *resource, isClose func(ast.Node) bool) ast.Node {
+	// Find the block and index of the assignment.
+	var start *cfg.Block
+	var index int
+	for _, b := range g.Blocks {
+		for i, n := range b.Nodes {
+			if n == r.assign {
+				start, index = b, i
+			}
+		}
+	}
+	if start == nil {
+		return nil // unreachable
+	}
+
+	// Search the paths from the assignment.
+	seen := make(map[*cfg.Block]bool)
+	var search func(b *cfg.Block, nodes []ast.Node) ast.Node
+	search = func(b *cfg.Block, nodes []ast.Node) ast.Node {
+		for _, n := range nodes {
+			if isClose(n) {
+				return nil // this path closes
+			}
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				for _, lhs := range n.Lhs {
+					if id, ok := ast.Unparen(lhs).(*ast.Ident); ok && info.Uses[id] == r.v || n == r.assign {
+						return n // overwritten
+					}
+				}
+			case *ast.ReturnStmt:
+				if n.Return == body.Rbrace {
+					return body // implicit return at end of function
+				}
+				return n
+			}
+		}
+		if len(b.Succs) == 0 {
+			// The path leaves the function: by a call to panic or
+			// another function that does not return, such as
+			// log.Fatal, which we ignore, or by falling off the end.
+			if len(b.Nodes) > 0 {
+				if stmt, ok := b.Nodes[len(b.Nodes)-1].(*ast.ExprStmt); ok && is[*ast.CallExpr](stmt.X) {
+					if len(body.List) == 0 || stmt != body.List[len(body.List)-1] {
+						return nil
+					}
+				}
+			}
+			return body
+		}
+		for i, succ := range b.Succs {
+			if !feasible(info, b, i, r) {
+				continue
+			}
+			if !seen[succ] {
+				seen[succ] = true
+				if exit := search(succ, succ.Nodes); exit != nil {
+					return exit
+				}
+			}
+		}
+		return nil
+	}
+	return search(start, start.Nodes[index+1:])
+}
+
+// feasible reports whether the ith successor of block b may be reached
+// while the resource is open: it is infeasible if b ends with a
+// condition that compares the resource or the error result with nil,
+// such as err != nil, and the successor is the branch in which the
+// resource is nil.
+func feasible(info *types.Info, b *cfg.Block, i int, r *resource) bool {
+	if len(b.Nodes) == 0 || len(b.Succs) != 2 {
+		return true
+	}
+	// The successors of a block that ends with a condition
+	// are its true and false branches, in that order.
+	cond, ok := b.Nodes[len(b.Nodes)-1].(*ast.BinaryExpr)
+	if !ok || cond.Op != token.EQL && cond.Op != token.NEQ {
+		return true
+	}
+	x, y := ast.Unparen(cond.X), ast.Unparen(cond.Y)
+	if info.Types[x].IsNil() {
+		x, y = y, x
+	}
+	id, ok := x.(*ast.Ident)
+	if !ok || !info.Types[y].IsNil() {
+		return true
+	}
+	// Which successor, true (0) or false (1), is the "nil" branch?
+	nilBranch := 0
+	if cond.Op == token.NEQ {
+		nilBranch = 1
+	}
+	switch info.Uses[id] {
+	case r.v:
+		return i != nilBranch
+	case r.err:
+		if r.err != nil {
+			return i == nilBranch // the resource is nil if err is not
+		}
+	}
+	return true
+}
+
+// containsClose reports whether n contains a call that closes the
+// resource, not counting calls in nested functions.
+func containsClose(info *types.Info, n ast.Node, r *resource) bool {
+	found := false
+	analysisinternal.InspectFunc(n, func(n ast.Node) {
+		if call, ok := n.(*ast.CallExpr); ok {
+			if sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr); ok && sel.Sel.Name == "Close" {
+				x := ast.Unparen(sel.X)
+				if r.body {
+					body, ok := x.(*ast.SelectorExpr)
+					if !ok || body.Sel.Name != "Body" {
+						return
+					}
+					x = ast.Unparen(body.X)
+				}
+				if id, ok := x.(*ast.Ident); ok && info.Uses[id] == r.v {
+					found = true
+				}
+			}
+		}
+	})
+	return found
+}
+
+// hasClose reports whether type t has a Close method.
+func hasClose(t types.Type) bool {
+	obj, _, _ := types.LookupFieldOrMethod(t, true, nil, "Close")
+	_, ok := obj.(*types.Func)
+	return ok
+}
+
+func is[T any](x any) bool {
+	_, ok := x.(T)
+	return ok
+}
+
+type stringSetFlag map[string]bool
+
+func (ss *stringSetFlag) String() string {
+	var items []string
+	for item := range *ss {
+		items = append(items, item)
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
+
+func (ss *stringSetFlag) Set(s string) error {
+	for name := range strings.SplitSeq(s, ",") {
+		if name != "" {
+			(*ss)[name] = true
+		}
+	}
+	return nil
+}
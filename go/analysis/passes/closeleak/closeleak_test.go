@@ -0,0 +1,23 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package closeleak_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/analysis/passes/closeleak"
+)
+
+func Test(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), closeleak.Analyzer, "a")
+}
+
+func TestFuncs(t *testing.T) {
+	if err := closeleak.Analyzer.Flags.Set("funcs", "(*b.Pool).Acquire,b.Connect"); err != nil {
+		t.Fatal(err)
+	}
+	analysistest.Run(t, analysistest.TestData(), closeleak.Analyzer, "b")
+}
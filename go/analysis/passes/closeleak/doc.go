@@ -0,0 +1,46 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package closeleak defines an Analyzer that checks that resources
+// such as files, network connections, and HTTP response bodies are
+// closed on all paths.
+//
+// # Analyzer closeleak
+//
+// closeleak: check that opened resources are closed on all paths
+//
+// This analyzer tracks the resources returned by functions such as
+// os.Open, net.Dial, and http.Get, and reports paths on which a
+// resource assigned to a local variable is not closed before the
+// function returns or the variable is overwritten:
+//
+//	f, err := os.Open(name) // "f.Close is not called on all paths after os.Open"
+//	if err != nil {
+//		return err
+//	}
+//	if len(buf) == 0 {
+//		return nil // "this return statement is reached without calling f.Close"
+//	}
+//	...
+//	f.Close()
+//
+// For a *http.Response, the resource is the response body, which must
+// be closed by resp.Body.Close.
+//
+// Paths on which the constructor's error result is non-nil, or the
+// resource itself is nil, are not considered, as there is nothing to
+// close. A resource closed by a deferred call is closed on all paths.
+// A resource that escapes from the function, because it is returned,
+// stored in a variable, field, or composite literal, passed to a
+// function, or referenced by a function literal, is assumed to be
+// closed elsewhere and is not reported.
+//
+// The -funcs flag adds functions and methods to those that return a
+// resource. It is a comma-separated list of names in the form of
+// (*types.Func).FullName, for example:
+//
+//	-funcs='example.com/db.Connect,(*example.com/pool.Pool).Acquire'
+//
+// The first result of such a function must have a Close method.
+package closeleak
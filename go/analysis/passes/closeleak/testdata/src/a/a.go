@@ -0,0 +1,173 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+)
+
+func earlyReturn(name string, buf []byte) error {
+	f, err := os.Open(name) // want "f.Close is not called on all paths after os.Open"
+	if err != nil {
+		return err
+	}
+	if len(buf) == 0 {
+		return errors.New("empty") // want "this return statement is reached without calling f.Close"
+	}
+	_, err = f.Read(buf)
+	f.Close()
+	return err
+}
+
+func neverClosed(name string) {
+	f, err := os.Create(name) // want "f.Close is never called after os.Create"
+	if err != nil {
+		log.Fatal(err)
+	}
+	f.WriteString("hello")
+} // want "the end of the function is reached without calling f.Close"
+
+func response(url string) ([]byte, error) {
+	resp, err := http.Get(url) // want "resp.Body.Close is not called on all paths after http.Get"
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New(resp.Status) // want "this return statement is reached"
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	return data, err
+}
+
+func overwrite(addrs []string) {
+	var conn net.Conn
+	var err error
+	conn, err = net.Dial("tcp", addrs[0]) // want "conn.Close is not called on all paths after net.Dial"
+	if err != nil {
+		return
+	}
+	conn.Write(nil)
+	conn, err = net.Dial("tcp", addrs[1]) // want "this assignment overwrites conn without calling conn.Close"
+	if err != nil {
+		return
+	}
+	conn.Close()
+}
+
+func loop(names []string) {
+	for _, name := range names {
+		f, err := os.Open(name) // want "f.Close is not called on all paths after os.Open"
+		if err != nil {
+			continue
+		}
+		if name == "" {
+			continue
+		}
+		f.Close()
+	}
+}
+
+func deferred(name string) error {
+	f, err := os.Open(name) // ok: deferred
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Stat()
+	return err
+}
+
+func deferredFunc(url string) error {
+	resp, err := http.Get(url) // ok: deferred
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); err == nil {
+			err = cerr
+		}
+	}()
+	return nil
+}
+
+func closedOnAllPaths(name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Stat(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func nilCheck(name string) {
+	f, _ := os.Open(name)
+	if f == nil {
+		return
+	}
+	f.Close()
+}
+
+func returned(name string) (*os.File, error) {
+	f, err := os.Open(name) // ok: returned
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+type holder struct{ f *os.File }
+
+func stored(h *holder, name string) {
+	f, err := os.Open(name) // ok: stored in a field
+	if err != nil {
+		return
+	}
+	h.f = f
+}
+
+func passed(name string) {
+	f, err := os.Open(name) // ok: passed to a function
+	if err != nil {
+		return
+	}
+	consume(f)
+}
+
+func consume(io.ReadCloser) {}
+
+func goroutine(l net.Listener) {
+	conn, err := l.Accept() // ok: not an open function
+	if err != nil {
+		return
+	}
+	_ = conn
+	c, err := net.Dial("tcp", "x") // ok: referenced by a function literal
+	if err != nil {
+		return
+	}
+	go func() {
+		c.Close()
+	}()
+}
+
+func panics(name string) {
+	f, err := os.Open(name)
+	if err != nil {
+		panic(err)
+	}
+	if name == "" {
+		panic("empty") // ok: panics are not considered
+	}
+	f.Close()
+}
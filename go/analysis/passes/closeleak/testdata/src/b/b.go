@@ -0,0 +1,32 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package b
+
+type Conn struct{}
+
+func (*Conn) Close() error { return nil }
+func (*Conn) Ping() error  { return nil }
+
+func Connect(addr string) (*Conn, error) { return &Conn{}, nil }
+
+type Pool struct{}
+
+func (*Pool) Acquire() *Conn { return &Conn{} }
+
+func f(p *Pool) error {
+	c := p.Acquire() // want "c.Close is not called on all paths after p.Acquire"
+	if err := c.Ping(); err != nil {
+		return err // want "this return statement is reached without calling c.Close"
+	}
+	return c.Close()
+}
+
+func g() {
+	c, err := Connect("x") // want "c.Close is never called after Connect"
+	if err != nil {
+		return
+	}
+	c.Ping()
+} // want "the end of the function is reached without calling c.Close"
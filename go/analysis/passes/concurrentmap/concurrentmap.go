@@ -0,0 +1,247 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package concurrentmap defines an Analyzer that reports maps that
+// goroutines may write without synchronization.
+package concurrentmap
+
+import (
+	_ "embed"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/analysis/passes/internal/analysisutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+	"golang.org/x/tools/internal/analysisinternal"
+	"golang.org/x/tools/internal/typesinternal"
+)
+
+// NOTE: Experimental. Not part of the vet suite.
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "concurrentmap",
+	Doc:      analysisutil.MustExtractDoc(doc, "concurrentmap"),
+	URL:      "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/concurrentmap",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	// Index the declarations of the package's functions, which may
+	// be the bodies of goroutines.
+	decls := make(map[*types.Func]*ast.FuncDecl)
+	for cur := range inspect.Root().Preorder((*ast.FuncDecl)(nil)) {
+		decl := cur.Node().(*ast.FuncDecl)
+		if fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func); ok && decl.Body != nil {
+			decls[fn] = decl
+		}
+	}
+
+	// body returns the body of the function denoted by e,
+	// a function literal or a function or method of the package.
+	body := func(e ast.Expr) *ast.BlockStmt {
+		switch e := ast.Unparen(e).(type) {
+		case *ast.FuncLit:
+			return e.Body
+		default:
+			if fn, ok := pass.TypesInfo.Uses[typesinternal.UsedIdent(pass.TypesInfo, e)].(*types.Func); ok {
+				if decl := decls[fn.Origin()]; decl != nil {
+					return decl.Body
+				}
+			}
+		}
+		return nil
+	}
+
+	reported := make(map[token.Pos]bool)
+	protected := make(map[*ast.File]bool)
+	for cur := range inspect.Root().Preorder((*ast.File)(nil)) {
+		file := cur.Node().(*ast.File)
+		protected[file] = refersToSync(pass.TypesInfo, file)
+	}
+
+	// fileOf returns the file of the package that contains pos, or nil.
+	fileOf := func(pos token.Pos) *ast.File {
+		for _, file := range pass.Files {
+			if file.FileStart <= pos && pos <= file.FileEnd {
+				return file
+			}
+		}
+		return nil
+	}
+
+	for cur := range inspect.Root().Preorder((*ast.GoStmt)(nil), (*ast.CallExpr)(nil)) {
+		// Find the goroutine body started by the node.
+		var (
+			goroutine *ast.BlockStmt
+			launch    ast.Node
+			what      string
+		)
+		switch n := cur.Node().(type) {
+		case *ast.GoStmt:
+			if lit, ok := ast.Unparen(n.Call.Fun).(*ast.FuncLit); ok {
+				goroutine = lit.Body
+			} else if fn := typeutil.StaticCallee(pass.TypesInfo, n.Call); fn != nil && decls[fn.Origin()] != nil {
+				goroutine = decls[fn.Origin()].Body
+			}
+			launch, what = n, "goroutine started here"
+		case *ast.CallExpr:
+			if i := callbackIndex(typeutil.Callee(pass.TypesInfo, n)); i >= 0 && i < len(n.Args) {
+				goroutine = body(n.Args[i])
+				launch, what = n, "callback registered here"
+			}
+		}
+		if goroutine == nil {
+			continue
+		}
+
+		// Report each unprotected write to a shared map.
+		ast.Inspect(goroutine, func(n ast.Node) bool {
+			var maps []ast.Expr // operands of map writes
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				for _, lhs := range n.Lhs {
+					if index, ok := ast.Unparen(lhs).(*ast.IndexExpr); ok {
+						maps = append(maps, index.X)
+					}
+				}
+			case *ast.IncDecStmt:
+				if index, ok := ast.Unparen(n.X).(*ast.IndexExpr); ok {
+					maps = append(maps, index.X)
+				}
+			case *ast.CallExpr:
+				if id, ok := ast.Unparen(n.Fun).(*ast.Ident); ok && len(n.Args) > 0 {
+					if b, ok := pass.TypesInfo.Uses[id].(*types.Builtin); ok && (b.Name() == "delete" || b.Name() == "clear") {
+						maps = append(maps, n.Args[0])
+					}
+				}
+			}
+			for _, m := range maps {
+				if _, ok := pass.TypesInfo.TypeOf(m).Underlying().(*types.Map); !ok || reported[m.Pos()] {
+					continue
+				}
+				v, st := sharedMap(pass.TypesInfo, m)
+				if v == nil ||
+					st != nil && hasSyncField(st) ||
+					protected[fileOf(v.Pos())] ||
+					protected[fileOf(m.Pos())] {
+					continue
+				}
+				reported[m.Pos()] = true
+				pass.Report(analysis.Diagnostic{
+					Pos:     m.Pos(),
+					End:     m.End(),
+					Message: fmt.Sprintf("map %s is written by a goroutine without synchronization", analysisinternal.Format(pass.Fset, m)),
+					Related: []analysis.RelatedInformation{{
+						Pos:     launch.Pos(),
+						End:     launch.End(),
+						Message: what,
+					}},
+				})
+			}
+			return true
+		})
+	}
+	return nil, nil
+}
+
+// callbackIndex returns the index of the parameter of fn that is a
+// function that runs concurrently with the caller of fn, or -1.
+func callbackIndex(fn types.Object) int {
+	switch {
+	case analysisinternal.IsFunctionNamed(fn, "time", "AfterFunc"),
+		analysisinternal.IsFunctionNamed(fn, "net/http", "HandleFunc"),
+		analysisinternal.IsMethodNamed(fn, "net/http", "ServeMux", "HandleFunc"):
+		return 1
+	case analysisinternal.IsMethodNamed(fn, "sync", "WaitGroup", "Go"),
+		analysisinternal.IsMethodNamed(fn, "golang.org/x/sync/errgroup", "Group", "Go"):
+		return 0
+	}
+	return -1
+}
+
+// sharedMap returns the package-level variable or the struct field
+// denoted by the map expression e, x, pkg.x, or e.f, and for a field,
+// the struct that declares it.
+func sharedMap(info *types.Info, e ast.Expr) (*types.Var, *types.Struct) {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.Ident:
+		if v, ok := info.Uses[e].(*types.Var); ok && v.Pkg() != nil && v.Parent() == v.Pkg().Scope() {
+			return v, nil
+		}
+	case *ast.SelectorExpr:
+		v, ok := info.Uses[e.Sel].(*types.Var)
+		if !ok {
+			return nil, nil
+		}
+		sel, ok := info.Selections[e]
+		if !ok {
+			if v.Pkg() != nil && v.Parent() == v.Pkg().Scope() {
+				return v, nil // pkg.x
+			}
+			return nil, nil
+		}
+		if sel.Kind() != types.FieldVal {
+			return nil, nil
+		}
+		// Follow the path of embedded fields to the
+		// struct that declares the field.
+		t := sel.Recv()
+		for _, i := range sel.Index()[:len(sel.Index())-1] {
+			st, ok := typesinternal.Unpointer(t).Underlying().(*types.Struct)
+			if !ok {
+				return nil, nil
+			}
+			t = st.Field(i).Type()
+		}
+		st, ok := typesinternal.Unpointer(t).Underlying().(*types.Struct)
+		if !ok {
+			return nil, nil
+		}
+		return v, st
+	}
+	return nil, nil
+}
+
+// hasSyncField reports whether the struct has a field, perhaps
+// embedded, of a synchronization type.
+func hasSyncField(st *types.Struct) bool {
+	for field := range st.Fields() {
+		if isSync(field.Type()) {
+			return true
+		}
+	}
+	return false
+}
+
+// refersToSync reports whether the file refers to a value of a
+// synchronization type.
+func refersToSync(info *types.Info, file *ast.File) bool {
+	found := false
+	ast.Inspect(file, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			if v, ok := info.ObjectOf(id).(*types.Var); ok && isSync(v.Type()) {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// isSync reports whether t is sync.Mutex, sync.RWMutex, or sync.Map,
+// or a pointer to one.
+func isSync(t types.Type) bool {
+	return analysisinternal.IsTypeNamed(typesinternal.Unpointer(t), "sync", "Mutex", "RWMutex", "Map")
+}
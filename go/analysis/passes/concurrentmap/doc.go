@@ -0,0 +1,38 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package concurrentmap defines an Analyzer that reports maps that
+// goroutines may write without synchronization.
+//
+// # Analyzer concurrentmap
+//
+// concurrentmap: report unsynchronized writes to shared maps by goroutines
+//
+// Concurrent writes to a map, or a write concurrent with a read, are a
+// data race, which the runtime may detect and report by crashing the
+// program with "concurrent map writes". The concurrentmap analyzer
+// reports writes to package-level maps and to maps held in struct
+// fields within the body of a goroutine:
+//
+//	var cache = map[string]int{}
+//
+//	func warm(keys []string) {
+//		for _, k := range keys {
+//			go func() {
+//				cache[k] = compute(k) // "map cache is written by a goroutine without synchronization"
+//			}()
+//		}
+//	}
+//
+// The body of a goroutine is a function started by a go statement, or
+// a callback that runs concurrently with its caller, such as a function
+// passed to time.AfterFunc, http.HandleFunc, or (*sync.WaitGroup).Go.
+// A write is an assignment to a map element, or a call to delete or
+// clear.
+//
+// The analyzer is a heuristic: it does not report a write to a map
+// if the struct that holds it, the file that declares it, or the file
+// that contains the write refers to a sync.Mutex, sync.RWMutex, or
+// sync.Map, on the assumption that the map is then protected.
+package concurrentmap
@@ -0,0 +1,54 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a
+
+import (
+	"net/http"
+	"time"
+)
+
+var cache = map[string]int{}
+
+func warm(keys []string) {
+	for _, k := range keys {
+		go func() {
+			cache[k] = len(k) // want `map cache is written by a goroutine without synchronization`
+		}()
+	}
+}
+
+func local(keys []string) {
+	m := map[string]int{}
+	go func() {
+		m["x"] = 1 // OK: local map
+	}()
+}
+
+func write() {
+	delete(cache, "x") // want `map cache is written by a goroutine without synchronization`
+}
+
+func started() {
+	go write()
+}
+
+type Server struct {
+	hits map[string]int
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	s.hits[r.URL.Path]++ // want `map s.hits is written by a goroutine without synchronization`
+}
+
+func (s *Server) register() {
+	http.HandleFunc("/", s.handle)
+	time.AfterFunc(time.Second, func() {
+		clear(s.hits) // want `map s.hits is written by a goroutine without synchronization`
+	})
+}
+
+func notConcurrent(s *Server) {
+	s.hits["x"] = 1 // OK: not in a goroutine
+}
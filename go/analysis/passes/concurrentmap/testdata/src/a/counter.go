@@ -0,0 +1,17 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a
+
+func (c *Counter) inc(key string) {
+	go func() {
+		c.counts[key]++ // OK: the struct has a mutex
+	}()
+}
+
+func writeShared() {
+	go func() {
+		shared["y"] = 2 // OK: the file that declares the map uses a mutex
+	}()
+}
@@ -0,0 +1,25 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a
+
+import "sync"
+
+var (
+	mu     sync.Mutex
+	shared = map[string]int{}
+)
+
+func lockedWrite() {
+	go func() {
+		mu.Lock()
+		shared["x"] = 1 // OK: the file uses a mutex
+		mu.Unlock()
+	}()
+}
+
+type Counter struct {
+	sync.RWMutex
+	counts map[string]int
+}
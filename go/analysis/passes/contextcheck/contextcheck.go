@@ -0,0 +1,156 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package contextcheck defines an Analyzer that reports common misuses
+// of context.Context.
+package contextcheck
+
+import (
+	_ "embed"
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/analysis/passes/internal/analysisutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+	"golang.org/x/tools/internal/analysisinternal"
+)
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "contextcheck",
+	Doc:      analysisutil.MustExtractDoc(doc, "contextcheck"),
+	URL:      "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/contextcheck",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// flags, one per category
+var (
+	checkBackground = true
+	checkField      = true
+	checkParam      = true
+)
+
+func init() {
+	Analyzer.Flags.BoolVar(&checkBackground, "background", checkBackground,
+		"report calls to context.Background or TODO in functions with a context parameter")
+	Analyzer.Flags.BoolVar(&checkField, "field", checkField,
+		"report struct fields of type context.Context")
+	Analyzer.Flags.BoolVar(&checkParam, "param", checkParam,
+		"report context.Context parameters that are not first")
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	if !analysisinternal.Imports(pass.Pkg, "context") {
+		return nil, nil // doesn't directly import context
+	}
+
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	reportf := func(category string, rng analysis.Range, format string, args ...any) {
+		pass.Report(analysis.Diagnostic{
+			Pos:      rng.Pos(),
+			End:      rng.End(),
+			Category: category,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	for cur := range inspect.Root().Preorder((*ast.CallExpr)(nil), (*ast.StructType)(nil), (*ast.FuncDecl)(nil)) {
+		switch n := cur.Node().(type) {
+		case *ast.CallExpr:
+			if !checkBackground {
+				continue
+			}
+			obj := typeutil.Callee(pass.TypesInfo, n)
+			if !analysisinternal.IsFunctionNamed(obj, "context", "Background", "TODO") {
+				continue
+			}
+			// Find the innermost enclosing function with a context parameter.
+			for c := range cur.Enclosing((*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)) {
+				var ftype *ast.FuncType
+				switch fn := c.Node().(type) {
+				case *ast.FuncDecl:
+					ftype = fn.Type
+				case *ast.FuncLit:
+					ftype = fn.Type
+				}
+				if param := contextParam(pass.TypesInfo, ftype); param != nil {
+					reportf("background", n,
+						"context.%s called in function with context parameter %s", obj.Name(), param.Name)
+					break
+				}
+			}
+
+		case *ast.StructType:
+			if !checkField {
+				continue
+			}
+			for _, field := range n.Fields.List {
+				if !isContext(pass.TypesInfo.TypeOf(field.Type)) {
+					continue
+				}
+				if len(field.Names) == 0 {
+					reportf("field", field, "context.Context embedded in struct")
+				}
+				for _, name := range field.Names {
+					reportf("field", name, "context.Context stored in field %s of struct", name.Name)
+				}
+			}
+
+		case *ast.FuncDecl:
+			if !checkParam {
+				continue
+			}
+			first := true // no parameters other than *testing.T-like ones so far
+			for _, field := range n.Type.Params.List {
+				t := pass.TypesInfo.TypeOf(field.Type)
+				switch {
+				case isContext(t):
+					if !first {
+						reportf("param", field, "context.Context should be the first parameter of %s", n.Name.Name)
+					}
+				case isTesting(t):
+					// ok before ctx
+				default:
+					first = false
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// contextParam returns the first named parameter of type
+// context.Context of the function type, or nil if there is none.
+func contextParam(info *types.Info, ftype *ast.FuncType) *ast.Ident {
+	for _, field := range ftype.Params.List {
+		if isContext(info.TypeOf(field.Type)) {
+			for _, name := range field.Names {
+				if name.Name != "_" {
+					return name
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// isContext reports whether t is context.Context.
+func isContext(t types.Type) bool {
+	return analysisinternal.IsTypeNamed(t, "context", "Context")
+}
+
+// isTesting reports whether t is *testing.T, *testing.B, *testing.F,
+// or testing.TB, which conventionally precede a context parameter.
+func isTesting(t types.Type) bool {
+	return analysisinternal.IsPointerToNamed(t, "testing", "T", "B", "F") ||
+		analysisinternal.IsTypeNamed(t, "testing", "TB")
+}
@@ -0,0 +1,24 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package contextcheck_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/analysis/passes/contextcheck"
+)
+
+func Test(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), contextcheck.Analyzer, "a")
+}
+
+func TestDisable(t *testing.T) {
+	for _, category := range []string{"background", "field", "param"} {
+		contextcheck.Analyzer.Flags.Set(category, "false")
+		defer contextcheck.Analyzer.Flags.Set(category, "true")
+	}
+	analysistest.Run(t, analysistest.TestData(), contextcheck.Analyzer, "b")
+}
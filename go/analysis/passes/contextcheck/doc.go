@@ -0,0 +1,49 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package contextcheck defines an Analyzer that reports common misuses
+// of context.Context.
+//
+// # Analyzer contextcheck
+//
+// contextcheck: check for misuses of context.Context
+//
+// The analyzer reports three kinds of problems, each in its own
+// diagnostic category, so that it may be disabled by the flag of the
+// same name.
+//
+// ## background
+//
+// A call to context.Background or context.TODO within a function that
+// has a context.Context parameter usually indicates that the function
+// fails to propagate its context, so the cancellation and deadline of
+// the caller are lost:
+//
+//	func fetch(ctx context.Context, url string) error {
+//		req, err := http.NewRequestWithContext(context.Background(), "GET", url, nil) // "context.Background called in function with context parameter ctx"
+//		...
+//	}
+//
+// To use the values of ctx without its cancellation, for example in
+// cleanup work that must run even after ctx is done, use
+// context.WithoutCancel(ctx).
+//
+// ## field
+//
+// A context.Context should not be stored in a struct field, but passed
+// explicitly as the first parameter of each function that needs it;
+// see https://go.dev/blog/context-and-structs.
+//
+//	type Server struct {
+//		ctx context.Context // "context.Context stored in field ctx of struct"
+//	}
+//
+// ## param
+//
+// By convention, a context.Context parameter is the first parameter of
+// a function, following only the receiver and any *testing.T-like
+// parameter:
+//
+//	func fetch(url string, ctx context.Context) error // "context.Context should be the first parameter of fetch"
+package contextcheck
@@ -0,0 +1,52 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a
+
+import (
+	"context"
+	"testing"
+)
+
+func background(ctx context.Context) {
+	use(context.Background()) // want "context.Background called in function with context parameter ctx"
+	use(context.TODO())       // want "context.TODO called in function with context parameter ctx"
+	use(context.WithoutCancel(ctx))
+
+	func() {
+		use(context.Background()) // want "context.Background called in function with context parameter ctx"
+	}()
+	func(inner context.Context) {
+		use(context.Background()) // want "context.Background called in function with context parameter inner"
+	}(ctx)
+}
+
+func noContext() {
+	use(context.Background())
+	func(_ context.Context) {
+		use(context.Background())
+	}(nil)
+}
+
+func use(context.Context) {}
+
+type Server struct {
+	ctx             context.Context // want "context.Context stored in field ctx of struct"
+	context.Context                 // want "context.Context embedded in struct"
+	cancel          context.CancelFunc
+}
+
+var _ = struct {
+	a, b context.Context // want "context.Context stored in field a of struct" "context.Context stored in field b of struct"
+}{}
+
+func first(ctx context.Context, x int)                   {}
+func second(x int, ctx context.Context)                  {} // want "context.Context should be the first parameter of second"
+func testing1(t *testing.T, ctx context.Context)         {}
+func testing2(tb testing.TB, ctx context.Context, x int) {}
+func testing3(t *testing.T, x int, ctx context.Context)  {} // want "context.Context should be the first parameter of testing3"
+func two(ctx1, ctx2 context.Context)                     {}
+
+func (s *Server) method(ctx context.Context)               {}
+func (s *Server) method2(name string, ctx context.Context) {} // want "context.Context should be the first parameter of method2"
@@ -0,0 +1,21 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file is analyzed with all categories disabled.
+
+package b
+
+import "context"
+
+func background(ctx context.Context) {
+	use(context.Background())
+}
+
+func use(context.Context) {}
+
+type Server struct {
+	ctx context.Context
+}
+
+func second(x int, ctx context.Context) {}
@@ -25,7 +25,14 @@ const Doc = `check for locks erroneously passed by value
 
 Inadvertently copying a value containing a lock, such as sync.Mutex or
 sync.WaitGroup, may cause both copies to malfunction. Generally such
-values should be referred to through a pointer.`
+values should be referred to through a pointer.
+
+In addition to assignments, calls, and range loops, the analyzer
+reports calls to append on slices whose elements contain locks, since
+growing the slice copies its elements, and calls to generic functions
+that copy values of a type argument that contains a lock, either
+directly, as in Clone[T](x T) T, or as slice or map elements, as in
+slices.Clone.`
 
 var Analyzer = &analysis.Analyzer{
 	Name:             "copylocks",
@@ -162,15 +169,111 @@ func checkCopyLocksCallExpr(pass *analysis.Pass, ce *ast.CallExpr) {
 			// for its type (e.g. len(array)), or the operation
 			// does not copy a lock (e.g. len(slice)).
 			return
+
+		case "append":
+			// append copies the existing elements of the
+			// slice when it grows, and any appended elements.
+			if len(ce.Args) > 0 {
+				if s, ok := typeparams.CoreType(pass.TypesInfo.TypeOf(ce.Args[0])).(*types.Slice); ok {
+					if path := lockPath(pass.Pkg, s.Elem(), nil); path != nil {
+						pass.ReportRangef(ce, "call of append copies lock values of slice elements: %v", path)
+						return
+					}
+				}
+			}
 		}
 	}
+	reported := false
 	for _, x := range ce.Args {
 		if path := lockPathRhs(pass, x); path != nil {
 			pass.ReportRangef(x, "call of %s copies lock value: %v", analysisinternal.Format(pass.Fset, ce.Fun), path)
+			reported = true
+		}
+	}
+	if !reported {
+		checkCopyLocksInstance(pass, ce)
+	}
+}
+
+// checkCopyLocksInstance detects lock copy by a call to an
+// instantiation of a generic function, such as Clone[T](x T) T,
+// that passes or returns a value of a type parameter, or that copies
+// the elements of a slice of a type parameter, such as slices.Clone,
+// when the type argument contains a lock.
+func checkCopyLocksInstance(pass *analysis.Pass, ce *ast.CallExpr) {
+	fun := ast.Unparen(ce.Fun)
+	if x, _, _, _ := typeparams.UnpackIndexExpr(fun); x != nil {
+		fun = x // explicit instantiation f[T]
+	}
+	var id *ast.Ident
+	switch fun := fun.(type) {
+	case *ast.Ident:
+		id = fun
+	case *ast.SelectorExpr:
+		id = fun.Sel
+	}
+	inst, ok := pass.TypesInfo.Instances[id]
+	if !ok {
+		return // not an instantiation
+	}
+	fn, ok := pass.TypesInfo.Uses[id].(*types.Func)
+	if !ok {
+		return
+	}
+	sig := fn.Signature()
+	tparams := sig.TypeParams()
+	if tparams == nil || tparams.Len() != inst.TypeArgs.Len() {
+		return // e.g. method of generic type
+	}
+
+	// byValue reports whether the signature passes or
+	// returns a value of type parameter tparam.
+	byValue := func(tparam *types.TypeParam) bool {
+		for _, tuple := range []*types.Tuple{sig.Params(), sig.Results()} {
+			for i := range tuple.Len() {
+				t := tuple.At(i).Type()
+				if sig.Variadic() && tuple == sig.Params() && i == tuple.Len()-1 {
+					t = t.(*types.Slice).Elem() // ...T
+				}
+				if types.Unalias(t) == tparam {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	copiesElems := copiesElements[fn.FullName()]
+
+	for i := range tparams.Len() {
+		tparam := tparams.At(i)
+		if !byValue(tparam) && copiesElems != tparam.Obj().Name() {
+			continue
+		}
+		if path := lockPath(pass.Pkg, inst.TypeArgs.At(i), nil); path != nil {
+			pass.ReportRangef(ce, "call of %s copies lock value: instantiates %s with %v",
+				analysisinternal.Format(pass.Fset, ce.Fun), tparam.Obj().Name(), path)
+			return
 		}
 	}
 }
 
+// copiesElements maps each generic function that copies the elements
+// of its slice or map operands to the name of the type parameter for
+// the element type.
+var copiesElements = map[string]string{
+	"maps.Clone":       "V",
+	"maps.Collect":     "V",
+	"maps.Copy":        "V",
+	"slices.AppendSeq": "E",
+	"slices.Clone":     "E",
+	"slices.Collect":   "E",
+	"slices.Concat":    "E",
+	"slices.Grow":      "E",
+	"slices.Insert":    "E",
+	"slices.Repeat":    "E",
+	"slices.Replace":   "E",
+}
+
 // checkCopyLocksFunc checks whether a function might
 // inadvertently copy a lock, by checking whether
 // its receiver, parameters, or return values
@@ -0,0 +1,68 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains tests for the copylock checker's
+// analysis of generic functions and append.
+
+package a
+
+import (
+	"maps"
+	"slices"
+	"sync"
+)
+
+type Locked struct {
+	mu sync.Mutex
+	n  int
+}
+
+func Clone[T any](x T) T { return x }
+
+func Ident[T any](x *T) *T { return x }
+
+func Each[T any](s []T, f func(*T)) {}
+
+func genericCopies() {
+	var l Locked
+	_ = Clone(&l)         // ok: *Locked
+	_ = Ident(&l)         // ok: not by value
+	Each([]Locked{}, nil) // ok: elements not copied
+	_ = Clone[Locked]     // ok: not a call
+
+	var s []Locked
+	_ = slices.Clone(s)                // want `call of slices.Clone copies lock value: instantiates E with a.Locked contains sync.Mutex`
+	_ = slices.Insert(s, 0)            // want `call of slices.Insert copies lock value: instantiates E with a.Locked contains sync.Mutex`
+	_ = slices.Index([]*Locked{}, nil) // ok
+	_ = slices.Clone([]*Locked{})      // ok: pointers
+
+	var m map[string]Locked
+	_ = maps.Clone(m) // want `call of maps.Clone copies lock value: instantiates V with a.Locked contains sync.Mutex`
+
+	var ms []sync.Mutex
+	_ = slices.Clone(ms) // want `call of slices.Clone copies lock value: instantiates E with sync.Mutex`
+}
+
+func genericCopiesByValue(p *Locked) {
+	_ = Clone(*p)               // want `call of Clone copies lock value: a.Locked contains sync.Mutex`
+	_ = Clone[Locked](Locked{}) // want `call of Clone\[Locked\] copies lock value: instantiates T with a.Locked contains sync.Mutex`
+}
+
+func appendCopies() {
+	var s []Locked
+	s = append(s, Locked{})                   // want `call of append copies lock values of slice elements: a.Locked contains sync.Mutex`
+	s = append(s, s...)                       // want `call of append copies lock values of slice elements`
+	ps := append([]*Locked(nil), new(Locked)) // ok
+	_, _ = s, ps
+}
+
+func rangeGenericElems() {
+	var s []Locked
+	for _, v := range s { // want `range var v copies lock: a.Locked contains sync.Mutex`
+		_ = v.n
+	}
+	for _, v := range slices.All([]*Locked{}) { // ok
+		_ = v
+	}
+}
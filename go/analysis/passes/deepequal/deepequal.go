@@ -0,0 +1,159 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package deepequal defines an Analyzer that reports calls of
+// reflect.DeepEqual on values it does not compare meaningfully.
+package deepequal
+
+import (
+	_ "embed"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/analysis/passes/internal/analysisutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+	"golang.org/x/tools/internal/analysisinternal"
+)
+
+// NOTE: Experimental. Not part of the vet suite.
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "deepequal",
+	Doc:      analysisutil.MustExtractDoc(doc, "deepequal"),
+	URL:      "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/deepequal",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// The problems of reflect.DeepEqual with a kind of type, in order
+// of priority.
+const (
+	none = iota
+	funcs
+	times
+	protos
+)
+
+// advice describes each problem, following the description of the
+// operand type.
+var advice = [...]string{
+	funcs:  "is false unless its funcs are nil; compare the other fields explicitly or use cmp.Diff with an option to ignore the funcs",
+	times:  "compares locations and monotonic clock readings; use Time.Equal or cmp.Diff",
+	protos: "compares the internal state of protocol buffer messages; use proto.Equal or cmp.Diff with protocmp.Transform",
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	if !analysisinternal.Imports(pass.Pkg, "reflect") {
+		return nil, nil // doesn't directly import reflect
+	}
+
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	qual := types.RelativeTo(pass.Pkg)
+
+	for cur := range inspect.Root().Preorder((*ast.CallExpr)(nil)) {
+		call := cur.Node().(*ast.CallExpr)
+		obj := typeutil.Callee(pass.TypesInfo, call)
+		if !analysisinternal.IsFunctionNamed(obj, "reflect", "DeepEqual") || len(call.Args) != 2 {
+			continue
+		}
+		// Report the most serious problem of either operand.
+		var (
+			worst   = none
+			operand types.Type
+			culprit types.Type
+		)
+		for _, arg := range call.Args {
+			t := pass.TypesInfo.TypeOf(arg)
+			if t == nil {
+				continue
+			}
+			if problem, c := find(t); problem > worst {
+				worst, operand, culprit = problem, t, c
+			}
+		}
+		if worst == none {
+			continue
+		}
+		what := types.TypeString(operand, qual)
+		if culprit != operand {
+			what += ", which contains " + types.TypeString(culprit, qual) + ","
+		}
+		pass.ReportRangef(call, "reflect.DeepEqual of %s %s", what, advice[worst])
+	}
+	return nil, nil
+}
+
+// find returns the most serious problem of reflect.DeepEqual with the
+// values of type typ, and the type, typ or one it contains, that has
+// the problem. The contained types are those of struct fields, slice
+// and array elements, map keys and elements, and pointer targets.
+func find(typ types.Type) (int, types.Type) {
+	// Track the types being processed, to avoid infinite recursion.
+	inProgress := make(map[types.Type]bool)
+
+	var check func(t types.Type) (int, types.Type)
+	check = func(t types.Type) (int, types.Type) {
+		if inProgress[t] {
+			return none, nil
+		}
+		inProgress[t] = true
+
+		if isProto(t) {
+			return protos, t
+		}
+		if analysisinternal.IsTypeNamed(t, "time", "Time") {
+			return times, t
+		}
+
+		worst, culprit := none, types.Type(nil)
+		update := func(problem int, c types.Type) {
+			if problem > worst {
+				worst, culprit = problem, c
+			}
+		}
+		switch t := t.(type) {
+		case *types.Signature:
+			return funcs, t
+		case *types.Pointer:
+			update(check(t.Elem()))
+		case *types.Slice:
+			update(check(t.Elem()))
+		case *types.Array:
+			update(check(t.Elem()))
+		case *types.Map:
+			update(check(t.Key()))
+			update(check(t.Elem()))
+		case *types.Struct:
+			for field := range t.Fields() {
+				update(check(field.Type()))
+			}
+		case *types.Named, *types.Alias:
+			problem, c := check(t.Underlying())
+			if c == t.Underlying() {
+				c = t // e.g. a named func type
+			}
+			update(problem, c)
+		}
+		return worst, culprit
+	}
+	return check(typ)
+}
+
+// isProto reports whether t, or a pointer to t, is a protocol buffer
+// message type generated by protoc-gen-go, which has a ProtoReflect
+// method.
+func isProto(t types.Type) bool {
+	if _, ok := types.Unalias(t).(*types.Named); !ok {
+		return false
+	}
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(t), false, nil, "ProtoReflect")
+	fn, ok := obj.(*types.Func)
+	return ok && fn.Signature().Params().Len() == 0 && fn.Signature().Results().Len() == 1
+}
@@ -0,0 +1,35 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package deepequal defines an Analyzer that reports calls of
+// reflect.DeepEqual on values it does not compare meaningfully.
+//
+// # Analyzer deepequal
+//
+// deepequal: check for misuses of reflect.DeepEqual
+//
+// reflect.DeepEqual compares values by their representation, which
+// differs from their meaning for some types. The analyzer reports a
+// call of reflect.DeepEqual whose operand has, or contains in a field,
+// element, or pointer target, a value of one of these types:
+//
+//   - a protocol buffer message, whose internal state, such as its
+//     size cache and unknown fields, may differ between equal messages.
+//     Use proto.Equal, or cmp.Diff with protocmp.Transform.
+//
+//   - time.Time, whose location and monotonic clock reading may differ
+//     between values that represent the same instant. Use Time.Equal,
+//     or cmp.Diff, which uses the Equal method.
+//
+//   - a func, which is deeply equal to another only if both are nil.
+//     Compare the other fields explicitly, or use cmp.Diff with an
+//     option to ignore the funcs.
+//
+// For example:
+//
+//	if !reflect.DeepEqual(got, want) { // "reflect.DeepEqual of Event, which contains time.Time, compares locations and monotonic clock readings; use Time.Equal or cmp.Diff"
+//
+// A type is a protocol buffer message if it has a ProtoReflect method,
+// as generated by protoc-gen-go.
+package deepequal
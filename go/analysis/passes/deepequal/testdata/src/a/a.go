@@ -0,0 +1,66 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a
+
+import (
+	"pb"
+	"reflect"
+	"time"
+)
+
+type Event struct {
+	Name string
+	At   time.Time
+}
+
+type Handler struct {
+	Name string
+	Func func()
+}
+
+type Callback func()
+
+type Request struct {
+	User  *pb.User
+	Event Event
+}
+
+type Plain struct {
+	Name string
+	Tags map[string][]int
+}
+
+type List struct {
+	Next *List
+	F    func()
+}
+
+func _(x, y any) {
+	var t1, t2 time.Time
+	_ = reflect.DeepEqual(t1, t2) // want `reflect.DeepEqual of time.Time compares locations and monotonic clock readings; use Time.Equal or cmp.Diff`
+
+	var e1, e2 Event
+	_ = reflect.DeepEqual(e1, e2) // want `reflect.DeepEqual of Event, which contains time.Time, compares locations`
+
+	var h1, h2 []Handler
+	_ = reflect.DeepEqual(h1, h2) // want `reflect.DeepEqual of \[\]Handler, which contains func\(\), is false unless its funcs are nil`
+
+	var c1, c2 Callback
+	_ = reflect.DeepEqual(c1, c2) // want `reflect.DeepEqual of Callback is false unless its funcs are nil`
+
+	var u1, u2 *pb.User
+	_ = reflect.DeepEqual(u1, u2) // want `reflect.DeepEqual of \*pb.User, which contains pb.User, compares the internal state of protocol buffer messages; use proto.Equal`
+
+	var r1, r2 map[string]Request
+	_ = reflect.DeepEqual(r1, r2) // want `reflect.DeepEqual of map\[string\]Request, which contains pb.User, compares the internal state`
+
+	var l1, l2 *List
+	_ = reflect.DeepEqual(l1, l2) // want `reflect.DeepEqual of \*List, which contains func\(\),`
+
+	var p1, p2 Plain
+	_ = reflect.DeepEqual(p1, p2) // OK
+	_ = reflect.DeepEqual(x, y)   // OK: unknown dynamic types
+	_ = reflect.DeepEqual(p1, t1) // want `reflect.DeepEqual of time.Time`
+}
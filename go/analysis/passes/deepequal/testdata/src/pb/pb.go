@@ -0,0 +1,18 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package pb is a stand-in for code generated by protoc-gen-go.
+package pb
+
+type Message interface{ Interface() any }
+
+type User struct {
+	state         struct{}
+	sizeCache     int32
+	unknownFields []byte
+
+	Name string
+}
+
+func (x *User) ProtoReflect() Message { return nil }
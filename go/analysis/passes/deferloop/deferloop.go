@@ -0,0 +1,217 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package deferloop defines an Analyzer that reports defer statements
+// within loops that release resources.
+package deferloop
+
+import (
+	_ "embed"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/analysis/passes/internal/analysisutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+	"golang.org/x/tools/internal/analysisinternal"
+	"golang.org/x/tools/internal/astutil"
+)
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "deferloop",
+	Doc:      analysisutil.MustExtractDoc(doc, "deferloop"),
+	URL:      "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/deferloop",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	// fixed records the loops for which a fix has been offered,
+	// as the fixes for several defers in one loop would conflict.
+	fixed := make(map[ast.Stmt]bool)
+
+	for cur := range inspect.Root().Preorder((*ast.DeferStmt)(nil)) {
+		def := cur.Node().(*ast.DeferStmt)
+		release := releaseCall(pass.TypesInfo, def.Call)
+		if release == nil {
+			continue
+		}
+
+		// Find the innermost loop enclosing the defer
+		// statement within the same function.
+		var curLoop inspector.Cursor
+		for c := range cur.Enclosing((*ast.ForStmt)(nil), (*ast.RangeStmt)(nil), (*ast.FuncLit)(nil), (*ast.FuncDecl)(nil)) {
+			if _, ok := c.Node().(ast.Stmt); ok {
+				curLoop = c
+			}
+			break
+		}
+		if curLoop.Inspector() == nil {
+			continue // not in a loop
+		}
+		loop := curLoop.Node().(ast.Stmt)
+
+		diag := analysis.Diagnostic{
+			Pos:     def.Pos(),
+			End:     def.End(),
+			Message: "deferred call to " + analysisinternal.Format(pass.Fset, release.Fun) + " in loop does not run until the function returns",
+		}
+		if !fixed[loop] {
+			if edits := wrapBody(pass, curLoop); edits != nil {
+				fixed[loop] = true
+				diag.SuggestedFixes = []analysis.SuggestedFix{{
+					Message:   "Move loop body into a function literal",
+					TextEdits: edits,
+				}}
+			}
+		}
+		pass.Report(diag)
+	}
+	return nil, nil
+}
+
+// releaseCall returns the call that releases a resource, either call
+// itself or, if call is an immediate call of a function literal, a
+// call within it. It returns nil if there is none.
+//
+// A call releases a resource if it calls a Close, Unlock, or RUnlock
+// method, or a context.CancelFunc.
+func releaseCall(info *types.Info, call *ast.CallExpr) *ast.CallExpr {
+	if lit, ok := ast.Unparen(call.Fun).(*ast.FuncLit); ok {
+		var found *ast.CallExpr
+		ast.Inspect(lit.Body, func(n ast.Node) bool {
+			if found != nil {
+				return false
+			}
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				return false // not executed by the deferred call
+			case *ast.CallExpr:
+				found = releaseCall(info, n)
+			}
+			return true
+		})
+		return found
+	}
+
+	if analysisinternal.IsTypeNamed(info.TypeOf(call.Fun), "context", "CancelFunc") {
+		return call
+	}
+	if fn, ok := typeutil.Callee(info, call).(*types.Func); ok && fn.Signature().Recv() != nil {
+		switch fn.Name() {
+		case "Close", "Unlock", "RUnlock":
+			if fn.Signature().Params().Len() == 0 {
+				return call
+			}
+		}
+	}
+	return nil
+}
+
+// wrapBody returns the edits that move the body of the loop into a
+// function literal called on each iteration, or nil if that would
+// change the meaning of the body, because it contains a return
+// statement or a branch statement that leaves the body, other than a
+// continue statement of the loop, which becomes a return statement.
+func wrapBody(pass *analysis.Pass, curLoop inspector.Cursor) []analysis.TextEdit {
+	loop := curLoop.Node().(ast.Stmt)
+	var body *ast.BlockStmt
+	switch loop := loop.(type) {
+	case *ast.ForStmt:
+		body = loop.Body
+	case *ast.RangeStmt:
+		body = loop.Body
+	}
+	curBody, ok := curLoop.FindNode(body)
+	if !ok {
+		return nil
+	}
+
+	var edits []analysis.TextEdit
+	ok = true
+	curBody.Inspect(nil, func(c inspector.Cursor) bool {
+		if !ok {
+			return false
+		}
+		switch n := c.Node().(type) {
+		case *ast.FuncLit:
+			return false // statements belong to another function
+		case *ast.ReturnStmt:
+			ok = false
+		case *ast.BranchStmt:
+			if n.Tok == token.GOTO {
+				// The label of a goto statement need not enclose it.
+				label, _ := pass.TypesInfo.Uses[n.Label].(*types.Label)
+				ok = label != nil && astutil.NodeContains(body, label.Pos())
+				break
+			}
+			if n.Tok == token.FALLTHROUGH {
+				break
+			}
+			target := branchTarget(pass.TypesInfo, c, n)
+			switch {
+			case target == loop && n.Tok == token.CONTINUE:
+				edits = append(edits, analysis.TextEdit{
+					Pos:     n.Pos(),
+					End:     n.End(),
+					NewText: []byte("return"),
+				})
+			case target == nil || !astutil.NodeContains(body, target.Pos()) || target == loop:
+				ok = false // leaves the body
+			}
+		}
+		return ok
+	})
+	if !ok {
+		return nil
+	}
+	return append(edits,
+		analysis.TextEdit{
+			Pos:     body.Lbrace + 1,
+			End:     body.Lbrace + 1,
+			NewText: []byte("\nfunc() {"),
+		},
+		analysis.TextEdit{
+			Pos:     body.Rbrace,
+			End:     body.Rbrace,
+			NewText: []byte("}()\n"),
+		})
+}
+
+// branchTarget returns the statement to which the break or continue
+// statement at cursor c transfers control, or nil if unknown.
+func branchTarget(info *types.Info, c inspector.Cursor, branch *ast.BranchStmt) ast.Stmt {
+	if branch.Label != nil {
+		label, ok := info.Uses[branch.Label].(*types.Label)
+		if !ok {
+			return nil
+		}
+		for c := range c.Enclosing((*ast.LabeledStmt)(nil)) {
+			if labeled := c.Node().(*ast.LabeledStmt); info.Defs[labeled.Label] == label {
+				return labeled.Stmt
+			}
+		}
+		return nil
+	}
+
+	switch branch.Tok {
+	case token.BREAK:
+		for c := range c.Enclosing((*ast.ForStmt)(nil), (*ast.RangeStmt)(nil), (*ast.SwitchStmt)(nil), (*ast.TypeSwitchStmt)(nil), (*ast.SelectStmt)(nil)) {
+			return c.Node().(ast.Stmt)
+		}
+	case token.CONTINUE:
+		for c := range c.Enclosing((*ast.ForStmt)(nil), (*ast.RangeStmt)(nil)) {
+			return c.Node().(ast.Stmt)
+		}
+	}
+	return nil
+}
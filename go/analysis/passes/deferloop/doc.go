@@ -0,0 +1,50 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package deferloop defines an Analyzer that reports defer statements
+// within loops that release resources.
+//
+// # Analyzer deferloop
+//
+// deferloop: report deferred release of resources within loops
+//
+// A deferred call runs when the enclosing function returns, not at the
+// end of the current iteration of a loop. So a defer statement within a
+// loop that releases a resource, such as a file, a response body, or a
+// lock, causes the resources acquired by all iterations to accumulate
+// until the function returns:
+//
+//	for _, name := range names {
+//		f, err := os.Open(name)
+//		if err != nil {
+//			return err
+//		}
+//		defer f.Close() // "deferred call to f.Close in loop does not run until the function returns"
+//		...
+//	}
+//
+// In this example, the program may run out of file descriptors if
+// there are many names; in the case of a mutex, the second iteration
+// would deadlock.
+//
+// The analyzer reports deferred calls of Close, Unlock, and RUnlock
+// methods and of context.CancelFunc values, whether direct or within a
+// deferred function literal. When the loop body contains no return
+// statement, and no break or goto statement that leaves the body, the
+// analyzer offers a fix that moves the body into a function literal
+// that is called on each iteration, so that deferred calls run at the
+// end of each iteration:
+//
+//	for _, name := range names {
+//		func() {
+//			f, err := os.Open(name)
+//			...
+//			defer f.Close()
+//			...
+//		}()
+//	}
+//
+// Continue statements of the loop become return statements of the
+// function literal.
+package deferloop
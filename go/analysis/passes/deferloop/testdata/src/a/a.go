@@ -0,0 +1,115 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"sync"
+)
+
+func files(names []string) {
+	for _, name := range names {
+		f, err := os.Open(name)
+		if err != nil {
+			continue
+		}
+		defer f.Close() // want "deferred call to f.Close in loop does not run until the function returns"
+		_ = f
+	}
+}
+
+func bodies(urls []string) error {
+	for _, url := range urls {
+		resp, err := http.Get(url)
+		if err != nil {
+			return err // no fix: return statement
+		}
+		defer resp.Body.Close() // want "deferred call to resp.Body.Close in loop"
+	}
+	return nil
+}
+
+func locks(mu *sync.RWMutex, n int) {
+	for i := 0; i < n; i++ {
+		mu.Lock()
+		defer mu.Unlock() // want "deferred call to mu.Unlock in loop"
+		mu.RLock()
+		defer mu.RUnlock() // want "deferred call to mu.RUnlock in loop"
+	}
+}
+
+func cancels(ctx context.Context, n int) {
+	for range n {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel() // want "deferred call to cancel in loop"
+		_ = ctx
+	}
+}
+
+func literal(names []string) {
+	for _, name := range names {
+		f, _ := os.Open(name)
+		defer func() { // want "deferred call to f.Close in loop"
+			f.Close()
+		}()
+	}
+}
+
+func breaks(names []string) {
+outer:
+	for _, name := range names {
+		for range 3 {
+			f, _ := os.Open(name)
+			defer f.Close() // want "deferred call to f.Close in loop"
+			switch {
+			case name == "":
+				break // ok: breaks the switch
+			case name == "x":
+				continue outer // no fix: leaves the body
+			}
+		}
+	}
+	for _, name := range names {
+		f, _ := os.Open(name)
+		defer f.Close() // want "deferred call to f.Close in loop"
+		if name == "" {
+			break // no fix: breaks the loop
+		}
+	}
+}
+
+func nested(names []string) {
+	for _, name := range names {
+		f, _ := os.Open(name)
+		defer f.Close() // want "deferred call to f.Close in loop"
+		for i := range 3 {
+			if i == 1 {
+				break // ok: breaks the nested loop
+			}
+		}
+		if name == "" {
+			goto done
+		}
+		func() {
+			defer f.Close() // ok: not in a loop of this function
+		}()
+	done:
+	}
+}
+
+func ok(f *os.File) {
+	defer f.Close() // ok: not in a loop
+	for range 3 {
+		f.Sync()
+	}
+	go func() {
+		for range 3 {
+			f.Sync()
+		}
+		defer f.Close() // ok: not in a loop
+	}()
+}
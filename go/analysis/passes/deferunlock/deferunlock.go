@@ -0,0 +1,334 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package deferunlock defines an Analyzer that reports mutexes
+// unlocked by an explicit call on each of several return paths,
+// instead of by a deferred call.
+package deferunlock
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/ctrlflow"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/analysis/passes/internal/analysisutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/cfg"
+	"golang.org/x/tools/go/types/typeutil"
+	"golang.org/x/tools/internal/analysisinternal"
+	"golang.org/x/tools/internal/astutil"
+)
+
+// NOTE: Experimental. Not part of the vet suite.
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name: "deferunlock",
+	Doc:  analysisutil.MustExtractDoc(doc, "deferunlock"),
+	URL:  "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/deferunlock",
+	Run:  run,
+	Requires: []*analysis.Analyzer{
+		inspect.Analyzer,
+		ctrlflow.Analyzer,
+	},
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	if !analysisinternal.Imports(pass.Pkg, "sync") {
+		return nil, nil // doesn't directly import sync
+	}
+
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	cfgs := pass.ResultOf[ctrlflow.Analyzer].(*ctrlflow.CFGs)
+
+	for cur := range inspect.Root().Preorder((*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)) {
+		var (
+			body *ast.BlockStmt
+			g    *cfg.CFG
+		)
+		switch n := cur.Node().(type) {
+		case *ast.FuncDecl:
+			body = n.Body
+			if body != nil {
+				g = cfgs.FuncDecl(n)
+			}
+		case *ast.FuncLit:
+			body = n.Body
+			g = cfgs.FuncLit(n)
+		}
+		if g != nil {
+			runFunc(pass, body, g)
+		}
+	}
+	return nil, nil
+}
+
+// runFunc checks the lock statements of the body of a single function.
+func runFunc(pass *analysis.Pass, body *ast.BlockStmt, g *cfg.CFG) {
+	for i, stmt := range body.List {
+		stmt, ok := stmt.(*ast.ExprStmt)
+		if !ok {
+			continue
+		}
+		call, ok := stmt.X.(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+		mutex, method := mutexCall(pass.TypesInfo, call)
+		if method != "Lock" && method != "RLock" {
+			continue
+		}
+		unlock := "Unlock"
+		if method == "RLock" {
+			unlock = "RUnlock"
+		}
+		if i+1 < len(body.List) && isDeferUnlock(pass.TypesInfo, body.List[i+1], mutex, unlock) {
+			continue // the usual idiom
+		}
+
+		// Find the unlock statements that precede a return,
+		// and all other uses of the mutex's methods.
+		var (
+			exits    []*ast.ExprStmt // unlock statements followed by a return
+			uses     int             // uses of the mutex's methods other than stmt
+			deferred bool            // the mutex is unlocked by a deferred call
+			calls    bool            // some returned result may involve a call
+			litEnd   token.Pos       // end of the current function literal
+		)
+		ast.Inspect(body, func(n ast.Node) bool {
+			var list []ast.Stmt
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				litEnd = max(litEnd, n.End())
+				return true
+			case *ast.SelectorExpr:
+				if n != call.Fun && isMutexMethod(pass.TypesInfo, n) && sameExpr(pass.TypesInfo, n.X, mutex) {
+					uses++ // a call, or a method value such as f(mu.Unlock)
+				}
+				return true
+			case *ast.DeferStmt:
+				if isDeferUnlock(pass.TypesInfo, n, mutex, unlock) {
+					deferred = true
+				}
+				return true
+			case *ast.BlockStmt:
+				list = n.List
+			case *ast.CaseClause:
+				list = n.Body
+			case *ast.CommClause:
+				list = n.Body
+			default:
+				return true
+			}
+			if n.Pos() < litEnd {
+				return true // statements of a function literal
+			}
+			for j, s := range list {
+				if !isUnlock(pass.TypesInfo, s, mutex, unlock) || s.Pos() < stmt.End() {
+					continue
+				}
+				if j+1 < len(list) {
+					if ret, ok := list[j+1].(*ast.ReturnStmt); ok {
+						for _, res := range ret.Results {
+							calls = calls || analysisutil.HasSideEffects(pass.TypesInfo, res)
+						}
+						exits = append(exits, s.(*ast.ExprStmt))
+					}
+				} else if n == body {
+					exits = append(exits, s.(*ast.ExprStmt)) // end of the function
+				}
+			}
+			return true
+		})
+		if deferred || len(exits) < 2 || uses != len(exits) || !unlockedOnAllPaths(pass.TypesInfo, g, body, stmt, mutex, unlock) {
+			continue
+		}
+
+		name := analysisinternal.Format(pass.Fset, mutex)
+		diag := analysis.Diagnostic{
+			Pos: stmt.Pos(),
+			End: stmt.End(),
+			Message: fmt.Sprintf("%s.%s is followed by %s.%s on each of %d return paths; use defer %s.%s()",
+				name, method, name, unlock, len(exits), name, unlock),
+		}
+		if !calls {
+			if edits := deferEdits(pass, stmt, exits, name+"."+unlock); edits != nil {
+				diag.SuggestedFixes = []analysis.SuggestedFix{{
+					Message:   fmt.Sprintf("Use defer %s.%s()", name, unlock),
+					TextEdits: edits,
+				}}
+			}
+		}
+		pass.Report(diag)
+	}
+}
+
+// unlockedOnAllPaths reports whether every path from the lock
+// statement that returns from the function, other than by a panic,
+// unlocks the mutex.
+func unlockedOnAllPaths(info *types.Info, g *cfg.CFG, body *ast.BlockStmt, stmt ast.Stmt, mutex ast.Expr, unlock string) bool {
+	// Find the block and index of the lock statement.
+	var start *cfg.Block
+	var index int
+	for _, b := range g.Blocks {
+		for i, n := range b.Nodes {
+			if n == stmt {
+				start, index = b, i
+			}
+		}
+	}
+	if start == nil {
+		return false // unreachable
+	}
+
+	seen := make(map[*cfg.Block]bool)
+	var search func(b *cfg.Block, nodes []ast.Node) bool
+	search = func(b *cfg.Block, nodes []ast.Node) bool {
+		for _, n := range nodes {
+			if n == stmt {
+				return false // back to the lock statement: a loop
+			}
+			if s, ok := n.(ast.Stmt); ok && isUnlock(info, s, mutex, unlock) {
+				return true
+			}
+			if _, ok := n.(*ast.ReturnStmt); ok {
+				return false
+			}
+		}
+		if len(b.Succs) == 0 {
+			// The path leaves the function by falling off the
+			// end, or by a call to panic or another function
+			// that does not return, such as os.Exit.
+			if len(b.Nodes) > 0 {
+				last := b.Nodes[len(b.Nodes)-1]
+				if stmt, ok := last.(*ast.ExprStmt); ok {
+					if call, ok := stmt.X.(*ast.CallExpr); ok {
+						if fn, ok := typeutil.Callee(info, call).(*types.Builtin); ok && fn.Name() == "panic" {
+							return true
+						}
+						return last != body.List[len(body.List)-1]
+					}
+				}
+			}
+			return false
+		}
+		for _, succ := range b.Succs {
+			if !seen[succ] {
+				seen[succ] = true
+				if !search(succ, succ.Nodes) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	return search(start, start.Nodes[index+1:])
+}
+
+// deferEdits returns the edits of a fix that inserts a deferred call
+// of unlock on the line after the lock statement, and deletes the
+// unlock statements, or nil if the lock statement is not followed by
+// a line break.
+func deferEdits(pass *analysis.Pass, stmt ast.Stmt, exits []*ast.ExprStmt, unlock string) []analysis.TextEdit {
+	// Insert the defer statement on the line after the lock
+	// statement, preserving any comment at the end of its line.
+	tf := pass.Fset.File(stmt.Pos())
+	line := tf.Line(stmt.End())
+	if line >= tf.LineCount() {
+		return nil
+	}
+	edits := []analysis.TextEdit{{
+		Pos:     tf.LineStart(line + 1),
+		End:     tf.LineStart(line + 1),
+		NewText: []byte("defer " + unlock + "()\n"),
+	}}
+	for _, exit := range exits {
+		// Delete the entire line if the statement is alone on it.
+		start, end := exit.Pos(), exit.End()
+		line := tf.Line(start)
+		if line < tf.LineCount() && aloneOnLine(pass, tf.LineStart(line), tf.LineStart(line+1), exit) {
+			start, end = tf.LineStart(line), tf.LineStart(line+1)
+		}
+		edits = append(edits, analysis.TextEdit{Pos: start, End: end})
+	}
+	return edits
+}
+
+// aloneOnLine reports whether the statement is the only text, other
+// than space, on the line from start to end.
+func aloneOnLine(pass *analysis.Pass, start, end token.Pos, stmt ast.Stmt) bool {
+	tf := pass.Fset.File(stmt.Pos())
+	content, err := pass.ReadFile(tf.Name())
+	if err != nil {
+		return false
+	}
+	before := content[tf.Offset(start):tf.Offset(stmt.Pos())]
+	after := content[tf.Offset(stmt.End()):tf.Offset(end)]
+	return len(bytes.TrimSpace(before)) == 0 && len(bytes.TrimSpace(after)) == 0
+}
+
+// isDeferUnlock reports whether stmt is a deferred call that unlocks
+// the mutex, either directly or in a function literal.
+func isDeferUnlock(info *types.Info, stmt ast.Stmt, mutex ast.Expr, unlock string) bool {
+	d, ok := stmt.(*ast.DeferStmt)
+	if !ok {
+		return false
+	}
+	if lit, ok := d.Call.Fun.(*ast.FuncLit); ok {
+		for _, s := range lit.Body.List {
+			if isUnlock(info, s, mutex, unlock) {
+				return true
+			}
+		}
+		return false
+	}
+	m, method := mutexCall(info, d.Call)
+	return method == unlock && sameExpr(info, m, mutex)
+}
+
+// isUnlock reports whether stmt is a statement that unlocks the mutex.
+func isUnlock(info *types.Info, stmt ast.Stmt, mutex ast.Expr, unlock string) bool {
+	if s, ok := stmt.(*ast.ExprStmt); ok {
+		if call, ok := s.X.(*ast.CallExpr); ok {
+			m, method := mutexCall(info, call)
+			return method == unlock && sameExpr(info, m, mutex)
+		}
+	}
+	return false
+}
+
+// mutexCall returns the mutex operand and method name if the call is
+// to a method of sync.Mutex or sync.RWMutex, perhaps promoted through
+// an embedded field.
+func mutexCall(info *types.Info, call *ast.CallExpr) (ast.Expr, string) {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok || !isMutexMethod(info, sel) {
+		return nil, ""
+	}
+	return sel.X, sel.Sel.Name
+}
+
+// isMutexMethod reports whether sel selects a method of sync.Mutex
+// or sync.RWMutex.
+func isMutexMethod(info *types.Info, sel *ast.SelectorExpr) bool {
+	fn, ok := info.Uses[sel.Sel].(*types.Func)
+	return ok && (analysisinternal.IsMethodNamed(fn, "sync", "Mutex", "Lock", "Unlock", "TryLock") ||
+		analysisinternal.IsMethodNamed(fn, "sync", "RWMutex", "Lock", "Unlock", "RLock", "RUnlock", "TryLock", "TryRLock"))
+}
+
+// sameExpr reports whether x and y are the same expression,
+// referring to the same objects.
+func sameExpr(info *types.Info, x, y ast.Expr) bool {
+	return astutil.Equal(ast.Unparen(x), ast.Unparen(y), func(x, y *ast.Ident) bool {
+		return info.ObjectOf(x) == info.ObjectOf(y)
+	})
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package deferunlock defines an Analyzer that reports mutexes
+// unlocked by an explicit call on each of several return paths,
+// instead of by a deferred call.
+//
+// # Analyzer deferunlock
+//
+// deferunlock: suggest defer for a mutex unlocked on each return path
+//
+// A function that locks a sync.Mutex or sync.RWMutex and returns from
+// several places must unlock it before each return. Repeating the
+// Unlock call is error-prone: a return statement added later may miss
+// it, and a panic leaves the mutex locked. The analyzer reports a Lock
+// or RLock call, in a statement of the function body, that is not
+// followed by a deferred Unlock call, when the mutex is unlocked by
+// at least two calls, each followed by a return statement or at the
+// end of the function, every return path unlocks it, and it is not
+// otherwise locked or unlocked in the function, including in function
+// literals:
+//
+//	mu.Lock() // "mu.Lock is followed by mu.Unlock on each of 2 return paths; use defer mu.Unlock()"
+//	if len(queue) == 0 {
+//		mu.Unlock()
+//		return 0, false
+//	}
+//	x := queue[0]
+//	queue = queue[1:]
+//	mu.Unlock()
+//	return x, true
+//
+// Paths that do not unlock the mutex are reported by the lockrelease
+// analyzer, not by this one.
+//
+// The analyzer offers a fix that inserts a deferred Unlock call after
+// the Lock call and deletes the explicit calls, when that does not
+// change the behavior of the function other than by unlocking the
+// mutex on a panic: the results of the return statements must be
+// evaluated without calls, which would now happen while the mutex is
+// locked.
+package deferunlock
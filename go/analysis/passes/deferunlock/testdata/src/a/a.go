@@ -0,0 +1,167 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	mu    sync.Mutex
+	rw    sync.RWMutex
+	queue []int
+	cache map[string]int
+)
+
+func pop() (int, error) {
+	mu.Lock() // want `mu.Lock is followed by mu.Unlock on each of 2 return paths; use defer mu.Unlock\(\)`
+	if len(queue) == 0 {
+		mu.Unlock()
+		return 0, errors.New("empty")
+	}
+	x := queue[0]
+	queue = queue[1:]
+	mu.Unlock()
+	return x, nil
+}
+
+func lookup(key string) (int, bool) {
+	rw.RLock() // want `rw.RLock is followed by rw.RUnlock on each of 3 return paths`
+	if cache == nil {
+		rw.RUnlock()
+		return 0, false
+	}
+	switch v, ok := cache[key]; {
+	case !ok:
+		rw.RUnlock()
+		return 0, false
+	default:
+		rw.RUnlock()
+		return v, true
+	}
+}
+
+func endOfFunction(b bool) {
+	mu.Lock() // want `mu.Lock is followed by mu.Unlock on each of 2 return paths`
+	if b {
+		mu.Unlock()
+		return
+	}
+	queue = nil
+	mu.Unlock()
+}
+
+func panics(b bool) {
+	mu.Lock() // want `mu.Lock is followed by mu.Unlock on each of 2 return paths`
+	if len(queue) > 10 {
+		panic("too long")
+	}
+	if b {
+		mu.Unlock()
+		return
+	}
+	queue = nil
+	mu.Unlock()
+}
+
+type S struct {
+	mu sync.Mutex
+	n  int
+}
+
+// The result is computed by a call, which would happen while locked.
+func (s *S) call() (int, error) {
+	s.mu.Lock() // want `s.mu.Lock is followed by s.mu.Unlock on each of 2 return paths`
+	if s.n == 0 {
+		s.mu.Unlock()
+		return 0, errors.New("zero")
+	}
+	s.mu.Unlock()
+	return s.n, nil
+}
+
+// The mutex is also unlocked in a function literal.
+func literal(f func(func())) {
+	mu.Lock()
+	if len(queue) == 0 {
+		mu.Unlock()
+		return
+	}
+	f(func() {
+		mu.Unlock()
+		mu.Lock()
+	})
+	mu.Unlock()
+}
+
+func deferred() int {
+	mu.Lock()
+	defer mu.Unlock()
+	if len(queue) == 0 {
+		return 0
+	}
+	return queue[0]
+}
+
+func deferredLater() int {
+	mu.Lock()
+	n := len(queue)
+	defer mu.Unlock()
+	if n == 0 {
+		return 0
+	}
+	return queue[0]
+}
+
+// A single critical section.
+func single() int {
+	mu.Lock()
+	n := len(queue)
+	mu.Unlock()
+	return n
+}
+
+// Not every path unlocks: reported by lockrelease.
+func missing(b bool) int {
+	mu.Lock()
+	if b {
+		return 0
+	}
+	if len(queue) == 0 {
+		mu.Unlock()
+		return 0
+	}
+	mu.Unlock()
+	return 1
+}
+
+// The mutex is unlocked before the end of the function.
+func middle(b bool) int {
+	mu.Lock()
+	if b {
+		mu.Unlock()
+		return 0
+	}
+	n := len(queue)
+	mu.Unlock()
+	n++
+	mu.Lock()
+	queue = queue[n:]
+	mu.Unlock()
+	return n
+}
+
+// The lock is not a statement of the function body.
+func nested(b bool) {
+	if b {
+		mu.Lock()
+		if len(queue) == 0 {
+			mu.Unlock()
+			return
+		}
+		mu.Unlock()
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package defuse defines an Analyzer that provides an [Index] mapping
+// each symbol of a package to the identifiers that declare and use it.
+// Like [golang.org/x/tools/go/analysis/passes/inspect], it is only a
+// building block for other analyzers: the index is built once per
+// package and shared by all the analyzers that require it, instead of
+// each of them inverting the Defs and Uses maps of [types.Info].
+//
+// Example of use in another analysis:
+//
+//	import (
+//		"golang.org/x/tools/go/analysis"
+//		"golang.org/x/tools/go/analysis/passes/defuse"
+//	)
+//
+//	var Analyzer = &analysis.Analyzer{
+//		...
+//		Requires:       []*analysis.Analyzer{defuse.Analyzer},
+//	}
+//
+//	func run(pass *analysis.Pass) (interface{}, error) {
+//		index := pass.ResultOf[defuse.Analyzer].(*defuse.Index)
+//		for cur := range index.Uses(obj) {
+//			...
+//		}
+//		return nil, nil
+//	}
+package defuse
+
+import (
+	"go/types"
+	"iter"
+	"reflect"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/internal/typesinternal/typeindex"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name:             "defuse",
+	Doc:              "index the declarations and uses of symbols for later passes",
+	URL:              "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/defuse",
+	Run:              run,
+	RunDespiteErrors: true,
+	Requires:         []*analysis.Analyzer{inspect.Analyzer},
+	ResultType:       reflect.TypeOf(new(Index)),
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	return &Index{typeindex.New(inspect, pass.Pkg, pass.TypesInfo)}, nil
+}
+
+// An Index maps each symbol of a package to the cursors of the
+// [*ast.Ident]s that declare and use it. In effect, it is the inverse
+// of the Defs and Uses maps of [types.Info].
+//
+// Variables declared implicitly, such as the variable of each clause
+// of a type switch t := x.(type), have no declaring identifier.
+type Index struct {
+	index *typeindex.Index
+}
+
+// Def returns the cursor of the [*ast.Ident] in this package that
+// declares obj, if any.
+func (ix *Index) Def(obj types.Object) (inspector.Cursor, bool) {
+	return ix.index.Def(obj)
+}
+
+// Uses returns the sequence of cursors of the [*ast.Ident]s in this
+// package that refer to obj, in order. If obj is nil, the sequence is
+// empty.
+//
+// Uses, unlike the Uses field of [types.Info], also reports the
+// references to the fields and methods of a generic type through
+// their instantiated objects.
+func (ix *Index) Uses(obj types.Object) iter.Seq[inspector.Cursor] {
+	return ix.index.Uses(obj)
+}
+
+// Used reports whether any of the objects is used in this package.
+// Nil objects are ignored.
+func (ix *Index) Used(objs ...types.Object) bool {
+	return ix.index.Used(objs...)
+}
@@ -0,0 +1,69 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package defuse_test
+
+import (
+	"go/ast"
+	"go/types"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/analysis/passes/defuse"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+// uses reports the number of uses of each variable and function at
+// its declaration, according to the index.
+var uses = &analysis.Analyzer{
+	Name:     "uses",
+	Doc:      "report the number of uses of each declared variable and function",
+	Requires: []*analysis.Analyzer{inspect.Analyzer, defuse.Analyzer},
+	Run: func(pass *analysis.Pass) (any, error) {
+		inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+		index := pass.ResultOf[defuse.Analyzer].(*defuse.Index)
+		for cur := range inspect.Root().Preorder((*ast.Ident)(nil)) {
+			id := cur.Node().(*ast.Ident)
+			obj := pass.TypesInfo.Defs[id]
+			switch obj.(type) {
+			case *types.Var, *types.Func:
+			default:
+				continue
+			}
+			if def, ok := index.Def(obj); !ok || def != cur {
+				pass.ReportRangef(id, "wrong definition of %s", id.Name)
+			}
+			n := 0
+			for use := range index.Uses(obj) {
+				if origin(pass.TypesInfo.Uses[use.Node().(*ast.Ident)]) != obj {
+					pass.ReportRangef(use.Node(), "wrong use of %s", id.Name)
+				}
+				n++
+			}
+			if used := index.Used(obj); used != (n > 0) {
+				pass.ReportRangef(id, "Used(%s) = %t with %d uses", id.Name, used, n)
+			}
+			pass.ReportRangef(id, "%s: %d uses", id.Name, n)
+		}
+		return nil, nil
+	},
+}
+
+// origin returns the generic object of an instantiated field or
+// method, as uses through instances are indexed under it.
+func origin(obj types.Object) types.Object {
+	switch obj := obj.(type) {
+	case *types.Var:
+		return obj.Origin()
+	case *types.Func:
+		return obj.Origin()
+	}
+	return obj
+}
+
+func Test(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), uses, "a")
+}
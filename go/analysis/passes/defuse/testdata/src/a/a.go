@@ -0,0 +1,21 @@
+package a
+
+var global int // want "global: 2 uses"
+
+func f(x int) int { // want "f: 1 uses" "x: 2 uses"
+	global = x
+	return x + global
+}
+
+func g() { // want "g: 0 uses"
+	y := f(0) // want "y: 1 uses"
+	_ = y
+}
+
+type T[E any] struct {
+	field E // want "field: 1 uses"
+}
+
+func h(t T[int]) int { // want "h: 0 uses" "t: 1 uses"
+	return t.field
+}
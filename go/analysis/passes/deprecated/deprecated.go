@@ -32,7 +32,13 @@ var Analyzer = &analysis.Analyzer{
 	Run:              checkDeprecated,
 	FactTypes:        []analysis.Fact{(*deprecationFact)(nil)},
 	RunDespiteErrors: true,
-	URL:              "https://pkg.go.dev/golang.org/x/tools/gopls/internal/analysis/deprecated",
+	URL:              "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/deprecated",
+}
+
+var sameModule bool // -module flag
+
+func init() {
+	Analyzer.Flags.BoolVar(&sameModule, "module", false, "report only uses of objects deprecated in the same module")
 }
 
 // checkDeprecated is a simplified copy of staticcheck.CheckDeprecated.
@@ -58,6 +64,17 @@ func checkDeprecated(pass *analysis.Pass) (any, error) {
 		pass.ReportRangef(node, "%s is deprecated: %s", buf, depr.Msg)
 	}
 
+	// inModule reports whether a deprecation in pkg applies under
+	// the -module flag, that is, whether pkg belongs to the module
+	// of the package being analyzed.
+	inModule := func(pkg *types.Package) bool {
+		if !sameModule || pass.Module == nil || pass.Module.Path == "" {
+			return true
+		}
+		mod := pass.Module.Path
+		return pkg.Path() == mod || strings.HasPrefix(pkg.Path(), mod+"/")
+	}
+
 	nodeFilter := []ast.Node{(*ast.SelectorExpr)(nil)}
 	inspector.Preorder(nodeFilter, func(node ast.Node) {
 		// Caveat: this misses dot-imported objects
@@ -97,7 +114,7 @@ func checkDeprecated(pass *analysis.Pass) (any, error) {
 			return
 		}
 
-		if depr, ok := deprs.objects[obj]; ok {
+		if depr, ok := deprs.objects[obj]; ok && inModule(obj.Pkg()) {
 			reportDeprecation(depr, sel)
 		}
 	})
@@ -134,7 +151,7 @@ func checkDeprecated(pass *analysis.Pass) (any, error) {
 				// foo.test can import foo_test
 				continue
 			}
-			if depr, ok := deprs.packages[imp]; ok {
+			if depr, ok := deprs.packages[imp]; ok && inModule(imp) {
 				reportDeprecation(depr, spec.Path)
 			}
 		}
@@ -156,8 +173,11 @@ type deprecatedNames struct {
 // them both as Facts and the return value. This is a simplified copy
 // of staticcheck's fact_deprecated analyzer.
 func collectDeprecatedNames(pass *analysis.Pass, ins *inspector.Inspector) (deprecatedNames, error) {
+	deprecation := func(docs *ast.CommentGroup) string {
+		return strings.TrimPrefix(internalastutil.Deprecation(docs), "Deprecated: ")
+	}
 	doDocs := func(names []*ast.Ident, docs *ast.CommentGroup) {
-		alt := strings.TrimPrefix(internalastutil.Deprecation(docs), "Deprecated: ")
+		alt := deprecation(docs)
 		if alt == "" {
 			return
 		}
@@ -176,7 +196,7 @@ func collectDeprecatedNames(pass *analysis.Pass, ins *inspector.Inspector) (depr
 	// telling them to use x/sys for that.
 	if pass.Pkg.Path() != "syscall" {
 		for _, f := range pass.Files {
-			if depr := internalastutil.Deprecation(f.Doc); depr != "" {
+			if depr := deprecation(f.Doc); depr != "" {
 				pass.ExportPackageFact(&deprecationFact{depr})
 				break
 			}
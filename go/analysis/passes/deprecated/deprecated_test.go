@@ -0,0 +1,23 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package deprecated_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/analysis/passes/deprecated"
+)
+
+func Test(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), deprecated.Analyzer, "a", "olda", "b", "usedeprecated")
+}
+
+func TestModule(t *testing.T) {
+	deprecated.Analyzer.Flags.Set("module", "true")
+	defer deprecated.Analyzer.Flags.Set("module", "false")
+	analysistest.Run(t, filepath.Join(analysistest.TestData(), "mod"), deprecated.Analyzer, "example.com/main/use")
+}
@@ -0,0 +1,33 @@
+// Copyright 2023 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package deprecated defines an Analyzer that marks deprecated symbols and package imports.
+//
+// # Analyzer deprecated
+//
+// deprecated: check for use of deprecated identifiers
+//
+// The deprecated analyzer looks for deprecated symbols and package
+// imports.
+//
+// See https://go.dev/wiki/Deprecated to learn about Go's convention
+// for documenting and signaling deprecated identifiers.
+//
+// A paragraph of a doc comment that begins with "Deprecated: " marks
+// the documented package, function, method, type, constant, variable,
+// or struct field as deprecated:
+//
+//	// ReadFile reads the named file.
+//	//
+//	// Deprecated: Use os.ReadFile instead.
+//	func ReadFile(name string) ([]byte, error)
+//
+// The analyzer reports uses of deprecated objects, and imports of
+// deprecated packages, in other packages, with the deprecation message.
+// A package may use its own deprecated objects, as may its tests.
+//
+// With the -module flag, the analyzer reports only the uses of objects
+// deprecated in the module of the package that uses them, and not
+// those deprecated in its dependencies.
+package deprecated
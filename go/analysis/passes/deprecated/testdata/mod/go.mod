@@ -0,0 +1,7 @@
+module example.com/main
+
+go 1.24
+
+require example.com/other v0.0.0
+
+replace example.com/other => ./other
@@ -0,0 +1,15 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package use
+
+import (
+	"example.com/main/dep"
+	"example.com/other/old"
+)
+
+func _() {
+	dep.F() // want `dep.F is deprecated: Use G instead.`
+	old.F() // OK: deprecated in another module
+}
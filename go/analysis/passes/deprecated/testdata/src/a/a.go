@@ -0,0 +1,61 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a
+
+// Old is old.
+//
+// Deprecated: Use New instead.
+func Old() {} // want Old:`Deprecated: Use New instead.`
+
+func New() {}
+
+// Deprecated: Use NewT instead.
+type T struct { // want T:`Deprecated: Use NewT instead.`
+	// Deprecated: Use G instead.
+	F int // want F:`Deprecated: Use G instead.`
+	G int
+}
+
+// M is a method.
+//
+// Deprecated: Use N instead.
+func (T) M() {} // want M:`Deprecated: Use N instead.`
+
+func (T) N() {}
+
+type I interface {
+	// Deprecated: Don't.
+	Method() // want Method:`Deprecated: Don't.`
+}
+
+// Deprecated: Use the new constants.
+const (
+	X = 1 // want X:`Deprecated: Use the new constants.`
+	Y = 2 // want Y:`Deprecated: Use the new constants.`
+)
+
+var (
+	// Deprecated: Use W instead.
+	V = 0 // want V:`Deprecated: Use W instead.`
+	W = 0
+)
+
+// Deprecated: unexported objects are recorded too.
+func unexported() {} // want unexported:`Deprecated: unexported objects are recorded too.`
+
+func uses() {
+	Old() // OK: a package may use its own deprecated objects
+	unexported()
+}
+
+// Box is generic.
+//
+// Deprecated: Use boxes.Box instead.
+type Box[T any] struct{ V T } // want Box:`Deprecated: Use boxes.Box instead.`
+
+// Get returns the value.
+//
+// Deprecated: Read V.
+func (b Box[T]) Get() T { return b.V } // want Get:`Deprecated: Read V.`
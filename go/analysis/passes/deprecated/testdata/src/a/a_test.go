@@ -0,0 +1,15 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a_test
+
+import (
+	"testing"
+
+	"a"
+)
+
+func TestOld(t *testing.T) {
+	a.Old() // OK: the tests of a package may use its deprecated objects
+}
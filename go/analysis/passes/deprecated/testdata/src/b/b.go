@@ -0,0 +1,27 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package b
+
+import (
+	"a"
+	"olda" // want `"olda" is deprecated: Use package a instead.`
+)
+
+func _() {
+	a.Old() // want `a.Old is deprecated: Use New instead.`
+	a.New()
+	var t a.T     // want `a.T is deprecated: Use NewT instead.`
+	_ = t.F       // want `t.F is deprecated: Use G instead.`
+	_ = t.G       // OK
+	t.M()         // want `t.M is deprecated: Use N instead.`
+	_ = a.X + a.Y // want `a.X is deprecated: Use the new constants.` `a.Y is deprecated: Use the new constants.`
+	a.V = a.W     // want `a.V is deprecated: Use W instead.`
+	var i a.I = nil
+	i.Method() // want `i.Method is deprecated: Don't.`
+	olda.F()
+	var box a.Box[int] // want `a.Box is deprecated: Use boxes.Box instead.`
+	_ = box.Get()      // want `box.Get is deprecated: Read V.`
+	_ = a.T{F: 1}      // want `a.T is deprecated`
+}
@@ -0,0 +1,8 @@
+// want package:`Deprecated: Use package a instead.`
+
+// Package olda is old.
+//
+// Deprecated: Use package a instead.
+package olda
+
+func F() {}
@@ -0,0 +1,37 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package errorcompare defines an Analyzer that reports comparisons
+// by == of errors that may be wrapped.
+//
+// # Analyzer errorcompare
+//
+// errorcompare: check for comparisons of errors with wrapped sentinels
+//
+// A sentinel error is a package-level variable, such as io.EOF, that
+// callers compare with the errors they receive. Once some function
+// wraps a sentinel error, for example by a call to fmt.Errorf that
+// formats it using the %w verb, or by a call to errors.Join, the
+// errors that callers receive may no longer be equal to the sentinel,
+// so comparing them using == or != is a mistake:
+//
+//	var ErrNotFound = errors.New("not found")
+//
+//	func lookup(name string) error {
+//		...
+//		return fmt.Errorf("lookup %s: %w", name, ErrNotFound)
+//	}
+//
+//	if err := lookup(name); err == ErrNotFound { // "comparing with ErrNotFound by == will not match wrapped errors; use errors.Is"
+//		...
+//	}
+//
+// The analyzer reports such comparisons, including the cases of
+// switch statements on an error, and offers a fix to use errors.Is
+// instead. Comparisons within an Is method, which errors.Is calls to
+// compare errors, are not reported.
+//
+// Only sentinel errors wrapped in the same package as the comparison,
+// or in one of its dependencies, are considered.
+package errorcompare
@@ -0,0 +1,237 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package errorcompare defines an Analyzer that reports comparisons
+// by == of errors that may be wrapped.
+package errorcompare
+
+import (
+	_ "embed"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"maps"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/analysis/passes/internal/analysisutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+	"golang.org/x/tools/internal/analysisinternal"
+	"golang.org/x/tools/internal/fmtstr"
+	"golang.org/x/tools/internal/moreiters"
+)
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name:      "errorcompare",
+	Doc:       analysisutil.MustExtractDoc(doc, "errorcompare"),
+	URL:       "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/errorcompare",
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	FactTypes: []analysis.Fact{new(wrappedFact)},
+	Run:       run,
+}
+
+// A wrappedFact is a package fact that records the sentinel errors,
+// as "pkgpath.Name", wrapped by the package or its dependencies.
+type wrappedFact []string
+
+func (*wrappedFact) AFact()           {}
+func (f *wrappedFact) String() string { return "wrapped(" + strings.Join(*f, ", ") + ")" }
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	// Gather the sentinels wrapped by the dependencies,
+	// and by this package.
+	wrapped := make(map[string]bool)
+	for _, imp := range pass.Pkg.Imports() {
+		var fact wrappedFact
+		if pass.ImportPackageFact(imp, &fact) {
+			for _, name := range fact {
+				wrapped[name] = true
+			}
+		}
+	}
+	for cur := range inspect.Root().Preorder((*ast.CallExpr)(nil)) {
+		for _, arg := range wrappedArgs(pass, cur.Node().(*ast.CallExpr)) {
+			if v := sentinel(pass.TypesInfo, arg); v != nil {
+				wrapped[key(v)] = true
+			}
+		}
+	}
+	if len(wrapped) > 0 {
+		fact := wrappedFact(slices.Sorted(maps.Keys(wrapped)))
+		pass.ExportPackageFact(&fact)
+	}
+
+	// isWrapped reports whether e denotes a wrapped sentinel.
+	isWrapped := func(e ast.Expr) bool {
+		v := sentinel(pass.TypesInfo, e)
+		return v != nil && wrapped[key(v)]
+	}
+
+	for cur := range inspect.Root().Preorder((*ast.BinaryExpr)(nil), (*ast.SwitchStmt)(nil)) {
+		// Comparisons within an Is method are how
+		// errors.Is compares errors.
+		if decl, ok := moreiters.First(cur.Enclosing((*ast.FuncDecl)(nil))); ok {
+			if decl := decl.Node().(*ast.FuncDecl); decl.Recv != nil && decl.Name.Name == "Is" {
+				continue
+			}
+		}
+		curFile, _ := moreiters.First(cur.Enclosing((*ast.File)(nil)))
+		file := curFile.Node().(*ast.File)
+
+		switch n := cur.Node().(type) {
+		case *ast.BinaryExpr:
+			if n.Op != token.EQL && n.Op != token.NEQ {
+				continue
+			}
+			err, target := n.X, n.Y
+			if !isWrapped(target) {
+				err, target = target, err
+			}
+			if !isWrapped(target) || !isError(pass.TypesInfo, err) || sentinel(pass.TypesInfo, err) != nil {
+				continue
+			}
+			_, prefix, edits := analysisinternal.AddImport(pass.TypesInfo, file, "errors", "errors", "Is", n.Pos())
+			not := ""
+			if n.Op == token.NEQ {
+				not = "!"
+			}
+			pass.Report(analysis.Diagnostic{
+				Pos:     n.Pos(),
+				End:     n.End(),
+				Message: fmt.Sprintf("comparing with %s by %s will not match wrapped errors; use errors.Is", format(pass, target), n.Op),
+				SuggestedFixes: []analysis.SuggestedFix{{
+					Message: "Use errors.Is",
+					TextEdits: append(edits, analysis.TextEdit{
+						Pos:     n.Pos(),
+						End:     n.End(),
+						NewText: fmt.Appendf(nil, "%s%sIs(%s, %s)", not, prefix, format(pass, err), format(pass, target)),
+					}),
+				}},
+			})
+
+		case *ast.SwitchStmt:
+			if n.Tag == nil || !isError(pass.TypesInfo, n.Tag) {
+				continue
+			}
+			// Report the first case that compares with a
+			// wrapped sentinel, with a fix for the whole switch.
+			var target ast.Expr
+			for _, clause := range n.Body.List {
+				for _, e := range clause.(*ast.CaseClause).List {
+					if target == nil && isWrapped(e) {
+						target = e
+					}
+				}
+			}
+			if target == nil {
+				continue
+			}
+			diag := analysis.Diagnostic{
+				Pos:     target.Pos(),
+				End:     target.End(),
+				Message: fmt.Sprintf("switch case comparing with %s by == will not match wrapped errors; use errors.Is", format(pass, target)),
+			}
+			// The tag is evaluated once per case in the fix,
+			// so it must be a variable.
+			if tag, ok := ast.Unparen(n.Tag).(*ast.Ident); ok {
+				_, prefix, edits := analysisinternal.AddImport(pass.TypesInfo, file, "errors", "errors", "Is", n.Pos())
+				edits = append(edits, analysis.TextEdit{Pos: n.Tag.Pos(), End: n.Tag.End()})
+				for _, clause := range n.Body.List {
+					for _, e := range clause.(*ast.CaseClause).List {
+						var text string
+						switch {
+						case pass.TypesInfo.Types[e].IsNil():
+							text = tag.Name + " == nil"
+						case sentinel(pass.TypesInfo, e) != nil:
+							text = fmt.Sprintf("%sIs(%s, %s)", prefix, tag.Name, format(pass, e))
+						default:
+							text = tag.Name + " == " + format(pass, e)
+						}
+						edits = append(edits, analysis.TextEdit{Pos: e.Pos(), End: e.End(), NewText: []byte(text)})
+					}
+				}
+				diag.SuggestedFixes = []analysis.SuggestedFix{{
+					Message:   "Use errors.Is",
+					TextEdits: edits,
+				}}
+			}
+			pass.Report(diag)
+		}
+	}
+	return nil, nil
+}
+
+// wrappedArgs returns the arguments of a call to fmt.Errorf that are
+// formatted by %w, or of a call to errors.Join.
+func wrappedArgs(pass *analysis.Pass, call *ast.CallExpr) []ast.Expr {
+	obj := typeutil.Callee(pass.TypesInfo, call)
+	switch {
+	case analysisinternal.IsFunctionNamed(obj, "errors", "Join"):
+		return call.Args
+
+	case analysisinternal.IsFunctionNamed(obj, "fmt", "Errorf"):
+		if len(call.Args) < 2 || call.Ellipsis.IsValid() {
+			return nil
+		}
+		tv := pass.TypesInfo.Types[call.Args[0]]
+		if tv.Value == nil || tv.Value.Kind() != constant.String {
+			return nil
+		}
+		ops, err := fmtstr.Parse(constant.StringVal(tv.Value), 0)
+		if err != nil {
+			return nil
+		}
+		var args []ast.Expr
+		for _, op := range ops {
+			if op.Verb.Verb == 'w' && op.Verb.ArgIndex > 0 && op.Verb.ArgIndex < len(call.Args) {
+				args = append(args, call.Args[op.Verb.ArgIndex])
+			}
+		}
+		return args
+	}
+	return nil
+}
+
+// sentinel returns the package-level error variable denoted by e,
+// or nil.
+func sentinel(info *types.Info, e ast.Expr) *types.Var {
+	var id *ast.Ident
+	switch e := ast.Unparen(e).(type) {
+	case *ast.Ident:
+		id = e
+	case *ast.SelectorExpr:
+		id = e.Sel
+	default:
+		return nil
+	}
+	v, ok := info.Uses[id].(*types.Var)
+	if !ok || v.Pkg() == nil || v.Parent() != v.Pkg().Scope() || !analysisinternal.ImplementsError(v.Type()) {
+		return nil
+	}
+	return v
+}
+
+// key returns the name of a sentinel in a wrappedFact.
+func key(v *types.Var) string { return v.Pkg().Path() + "." + v.Name() }
+
+// isError reports whether e is an expression of interface type that
+// implements error, other than nil.
+func isError(info *types.Info, e ast.Expr) bool {
+	tv := info.Types[e]
+	return tv.Type != nil && !tv.IsNil() && types.IsInterface(tv.Type) && analysisinternal.ImplementsError(tv.Type)
+}
+
+func format(pass *analysis.Pass, e ast.Expr) string {
+	return analysisinternal.Format(pass.Fset, e)
+}
@@ -0,0 +1,44 @@
+package a // want package:`wrapped\(a.errLocal, sentinel.ErrNotFound, sentinel.ErrTimeout\)`
+
+import (
+	"errors"
+	"fmt"
+
+	"sentinel"
+)
+
+var errLocal = errors.New("local")
+
+func local() error { return fmt.Errorf("%s: %[2]w", "local", errLocal) }
+
+func compare() {
+	err := sentinel.Lookup("x")
+	if err == sentinel.ErrNotFound { // want `comparing with sentinel.ErrNotFound by == will not match wrapped errors; use errors.Is`
+	}
+	if sentinel.ErrTimeout != err { // want `comparing with sentinel.ErrTimeout by != will not match wrapped errors; use errors.Is`
+	}
+	if local() == errLocal { // want `comparing with errLocal by == will not match wrapped errors; use errors.Is`
+	}
+	if err == sentinel.ErrPlain { // ok: never wrapped
+	}
+	if err == nil || sentinel.ErrNotFound == sentinel.ErrTimeout { // ok
+	}
+}
+
+func tagSwitch(err error) {
+	switch err {
+	case nil:
+	case sentinel.ErrPlain, sentinel.ErrNotFound: // want `switch case comparing with sentinel.ErrNotFound by == will not match wrapped errors; use errors.Is`
+	case sentinel.ErrTimeout:
+	}
+
+	switch sentinel.Wait() {
+	case sentinel.ErrTimeout: // want `switch case comparing with sentinel.ErrTimeout by == will not match wrapped errors; use errors.Is`
+	}
+}
+
+type myError struct{}
+
+func (myError) Error() string { return "my error" }
+
+func (myError) Is(target error) bool { return target == sentinel.ErrNotFound } // ok: within Is method
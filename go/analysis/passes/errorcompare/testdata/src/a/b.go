@@ -0,0 +1,7 @@
+package a
+
+import "sentinel"
+
+func noImport(err error) bool {
+	return err != sentinel.ErrNotFound // want `comparing with sentinel.ErrNotFound by != will not match wrapped errors; use errors.Is`
+}
@@ -0,0 +1,23 @@
+package sentinel // want package:`wrapped\(sentinel.ErrNotFound, sentinel.ErrTimeout\)`
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNotFound = errors.New("not found")
+	ErrTimeout  = errors.New("timeout")
+	ErrPlain    = errors.New("plain")
+)
+
+func Lookup(name string) error {
+	if name == "" {
+		return ErrPlain
+	}
+	return fmt.Errorf("lookup %s: %w", name, ErrNotFound)
+}
+
+func Wait() error {
+	return errors.Join(ErrTimeout, errors.New("canceled"))
+}
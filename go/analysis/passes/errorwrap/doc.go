@@ -0,0 +1,30 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package errorwrap defines an Analyzer that checks that errors
+// formatted by fmt.Errorf are wrapped, not flattened.
+//
+// # Analyzer errorwrap
+//
+// errorwrap: check that fmt.Errorf wraps its error operands
+//
+// A call to fmt.Errorf that formats an error operand using the %v or
+// %s verb produces an error whose message includes that of the
+// operand, but which does not wrap it, so the original error cannot be
+// recovered by errors.Is, errors.As, or errors.Unwrap:
+//
+//	return fmt.Errorf("open %s: %v", name, err) // "non-wrapping format verb %v for error argument err; use %w"
+//
+// The analyzer reports such calls and offers a fix to use the %w verb
+// instead. (Whether an error should be exposed by wrapping is an API
+// decision, so the fix should not be applied blindly.)
+//
+// The analyzer also reports calls to errors.Is and errors.As whose
+// first operand is an error created by such a call to fmt.Errorf,
+// either directly or by way of a local variable, since they cannot
+// match the flattened error:
+//
+//	err := fmt.Errorf("read: %v", io.EOF)
+//	if errors.Is(err, io.EOF) { // "errors.Is cannot match an error that fmt.Errorf did not wrap"
+package errorwrap
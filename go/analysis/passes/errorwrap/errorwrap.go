@@ -0,0 +1,222 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package errorwrap defines an Analyzer that checks that errors
+// formatted by fmt.Errorf are wrapped, not flattened.
+package errorwrap
+
+import (
+	_ "embed"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/analysis/passes/internal/analysisutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+	"golang.org/x/tools/internal/analysisinternal"
+	"golang.org/x/tools/internal/astutil"
+	"golang.org/x/tools/internal/fmtstr"
+	"golang.org/x/tools/internal/versions"
+)
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "errorwrap",
+	Doc:      analysisutil.MustExtractDoc(doc, "errorwrap"),
+	URL:      "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/errorwrap",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	if !analysisinternal.Imports(pass.Pkg, "fmt") {
+		return nil, nil // doesn't directly import fmt
+	}
+
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	// Record the values assigned to each local error variable,
+	// so that we can tell whether the operand of errors.Is
+	// was created by a non-wrapping call to fmt.Errorf.
+	// A nil value means unknown, e.g. the variable's address was taken.
+	assigned := make(map[*types.Var][]ast.Expr)
+	assign := func(lhs, rhs ast.Expr) {
+		if id, ok := ast.Unparen(lhs).(*ast.Ident); ok {
+			if v, ok := pass.TypesInfo.ObjectOf(id).(*types.Var); ok && !v.IsField() {
+				assigned[v] = append(assigned[v], rhs)
+			}
+		}
+	}
+	for n := range inspect.PreorderSeq((*ast.AssignStmt)(nil), (*ast.ValueSpec)(nil), (*ast.UnaryExpr)(nil)) {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range n.Lhs {
+				var rhs ast.Expr // unknown
+				if len(n.Lhs) == len(n.Rhs) {
+					rhs = n.Rhs[i]
+				}
+				assign(lhs, rhs)
+			}
+		case *ast.ValueSpec:
+			for i, id := range n.Names {
+				var rhs ast.Expr // unknown
+				if len(n.Names) == len(n.Values) {
+					rhs = n.Values[i]
+				}
+				assign(id, rhs)
+			}
+		case *ast.UnaryExpr:
+			if n.Op == token.AND {
+				assign(n.X, nil) // address taken
+			}
+		}
+	}
+
+	// unwrapped reports whether e is (or is a local variable that
+	// only holds) the result of a non-wrapping call to fmt.Errorf.
+	unwrapped := func(e ast.Expr) bool {
+		e = ast.Unparen(e)
+		if id, ok := e.(*ast.Ident); ok {
+			v, ok := pass.TypesInfo.Uses[id].(*types.Var)
+			if !ok || len(assigned[v]) == 0 {
+				return false // e.g. parameter or global
+			}
+			for _, rhs := range assigned[v] {
+				if rhs == nil || !flattens(pass, rhs) {
+					return false
+				}
+			}
+			return true
+		}
+		return flattens(pass, e)
+	}
+
+	for curFile := range inspect.Root().Children() {
+		fileVersion := versions.FileVersion(pass.TypesInfo, curFile.Node().(*ast.File))
+		for cur := range curFile.Preorder((*ast.CallExpr)(nil)) {
+			call := cur.Node().(*ast.CallExpr)
+			obj := typeutil.Callee(pass.TypesInfo, call)
+			switch {
+			case analysisinternal.IsFunctionNamed(obj, "fmt", "Errorf"):
+				checkErrorf(pass, fileVersion, call)
+
+			case analysisinternal.IsFunctionNamed(obj, "errors", "Is", "As"):
+				if len(call.Args) == 2 && unwrapped(call.Args[0]) {
+					pass.ReportRangef(call.Args[0],
+						"errors.%s cannot match an error that fmt.Errorf did not wrap",
+						obj.Name())
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// checkErrorf reports error operands of a call to fmt.Errorf that are
+// formatted with %v or %s, with a fix to use %w.
+func checkErrorf(pass *analysis.Pass, fileVersion string, call *ast.CallExpr) {
+	lit, ops := parseErrorf(pass, call)
+	if ops == nil {
+		return
+	}
+
+	// Before go1.20, fmt.Errorf accepted at most one %w.
+	nwrap := 0
+	for _, op := range ops {
+		if op.Verb.Verb == 'w' {
+			nwrap++
+		}
+	}
+	multi := versions.AtLeast(fileVersion, versions.Go1_20)
+
+	for _, op := range ops {
+		if !isFlattened(pass, call, op) {
+			continue
+		}
+		arg := call.Args[op.Verb.ArgIndex]
+		// The verb's range includes any argument index, as in %[1]v.
+		start, end, err := astutil.RangeInStringLiteral(lit, op.Verb.Range.End-1, op.Verb.Range.End)
+		if err != nil {
+			continue // e.g. verb within escape sequence
+		}
+		diag := analysis.Diagnostic{
+			Pos:     start,
+			End:     end,
+			Message: "non-wrapping format verb %" + string(op.Verb.Verb) + " for error argument " + analysisinternal.Format(pass.Fset, arg) + "; use %w",
+		}
+		if multi || nwrap == 0 {
+			nwrap++
+			diag.SuggestedFixes = []analysis.SuggestedFix{{
+				Message: "Use %w to wrap the error",
+				TextEdits: []analysis.TextEdit{{
+					Pos:     start,
+					End:     end,
+					NewText: []byte("w"),
+				}},
+			}}
+		}
+		pass.Report(diag)
+	}
+}
+
+// parseErrorf parses the format string literal of a call to
+// fmt.Errorf. It returns nil if the format is not a literal or has
+// errors.
+func parseErrorf(pass *analysis.Pass, call *ast.CallExpr) (*ast.BasicLit, []*fmtstr.Operation) {
+	if len(call.Args) < 2 || call.Ellipsis.IsValid() {
+		return nil, nil
+	}
+	lit, ok := ast.Unparen(call.Args[0]).(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return nil, nil
+	}
+	tv := pass.TypesInfo.Types[lit]
+	if tv.Value == nil || tv.Value.Kind() != constant.String {
+		return nil, nil
+	}
+	ops, err := fmtstr.Parse(constant.StringVal(tv.Value), 0)
+	if err != nil {
+		return nil, nil
+	}
+	return lit, ops
+}
+
+// isFlattened reports whether the operation formats an error
+// operand of the call using %v or %s.
+func isFlattened(pass *analysis.Pass, call *ast.CallExpr, op *fmtstr.Operation) bool {
+	if op.Verb.Verb != 'v' && op.Verb.Verb != 's' {
+		return false
+	}
+	if op.Verb.ArgIndex < 1 || op.Verb.ArgIndex >= len(call.Args) {
+		return false
+	}
+	t := pass.TypesInfo.TypeOf(call.Args[op.Verb.ArgIndex])
+	return t != nil && analysisinternal.ImplementsError(t)
+}
+
+// flattens reports whether e is a call to fmt.Errorf that formats an
+// error operand without wrapping it, and wraps no other.
+func flattens(pass *analysis.Pass, e ast.Expr) bool {
+	call, ok := ast.Unparen(e).(*ast.CallExpr)
+	if !ok || !analysisinternal.IsFunctionNamed(typeutil.Callee(pass.TypesInfo, call), "fmt", "Errorf") {
+		return false
+	}
+	_, ops := parseErrorf(pass, call)
+	flattened := false
+	for _, op := range ops {
+		if op.Verb.Verb == 'w' {
+			return false
+		}
+		if isFlattened(pass, call, op) {
+			flattened = true
+		}
+	}
+	return flattened
+}
@@ -0,0 +1,43 @@
+package a
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+func _(err error, name string) {
+	_ = fmt.Errorf("open %s: %v", name, err)  // want `non-wrapping format verb %v for error argument err; use %w`
+	_ = fmt.Errorf("open %s: %s", name, err)  // want `non-wrapping format verb %s for error argument err; use %w`
+	_ = fmt.Errorf("open %s: %w", name, err)  // ok
+	_ = fmt.Errorf("open %v: %w", name, err)  // ok: name is not an error
+	_ = fmt.Errorf("%[2]v: %[1]v", err, name) // want `non-wrapping format verb %v for error argument err; use %w`
+	_ = fmt.Errorf("%q", err)                 // ok: not %v or %s
+
+	var perr *os.PathError
+	_ = fmt.Errorf("%w, %v", err, perr) // want `non-wrapping format verb %v for error argument perr; use %w`
+
+	format := "%v"
+	_ = fmt.Errorf(format, err) // ok: not a literal
+}
+
+func _() {
+	err := fmt.Errorf("read: %v", io.EOF) // want `non-wrapping format verb %v for error argument io.EOF; use %w`
+	if errors.Is(err, io.EOF) {           // want `errors.Is cannot match an error that fmt.Errorf did not wrap`
+	}
+	var perr *os.PathError
+	if errors.As(fmt.Errorf("%s", err), &perr) { // want `errors.As cannot match an error that fmt.Errorf did not wrap` `non-wrapping format verb %s for error argument err; use %w`
+	}
+
+	err2 := fmt.Errorf("read: %w", io.EOF)
+	if errors.Is(err2, io.EOF) { // ok
+	}
+
+	err3 := fmt.Errorf("read: %v", io.EOF) // want `non-wrapping format verb %v`
+	if os.Args == nil {
+		err3 = io.EOF
+	}
+	if errors.Is(err3, io.EOF) { // ok: may be wrapped
+	}
+}
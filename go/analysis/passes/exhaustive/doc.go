@@ -0,0 +1,44 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package exhaustive defines an Analyzer that checks that switch
+// statements over enumerated types have a case for each constant.
+//
+// # Analyzer exhaustive
+//
+// exhaustive: check for missing cases in switches over enumerations
+//
+// A named type with an underlying basic type, together with the
+// package-level constants of that type declared in its package, forms
+// an enumeration. This analyzer reports switch statements on a value
+// of such a type that have no case for some of its constants:
+//
+//	type Color int
+//
+//	const (
+//		Red Color = iota
+//		Green
+//		Blue
+//	)
+//
+//	switch c { // "missing cases in switch of type Color: Blue"
+//	case Red:
+//		...
+//	case Green:
+//		...
+//	}
+//
+// Constants with the same value are interchangeable: a case for one of
+// them covers the others. Constants that are not accessible from the
+// switch statement, because they are unexported members of another
+// package, are not required. Switch statements with a case whose value
+// is not constant are not checked.
+//
+// By default, a switch statement with a default case is considered
+// exhaustive. The -explicit flag causes the analyzer to report missing
+// cases even in switch statements with a default case.
+//
+// The analyzer offers a fix that adds an empty case for each of the
+// missing constants.
+package exhaustive
@@ -0,0 +1,172 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package exhaustive defines an Analyzer that checks that switch
+// statements over enumerated types have a case for each constant.
+package exhaustive
+
+import (
+	_ "embed"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/analysis/passes/internal/analysisutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/internal/analysisinternal"
+	"golang.org/x/tools/internal/moreiters"
+	"golang.org/x/tools/internal/typesinternal"
+)
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "exhaustive",
+	Doc:      analysisutil.MustExtractDoc(doc, "exhaustive"),
+	URL:      "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/exhaustive",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// flags
+var explicit = false
+
+func init() {
+	Analyzer.Flags.BoolVar(&explicit, "explicit", explicit,
+		"report missing cases even in switch statements with a default case")
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	for cur := range inspect.Root().Preorder((*ast.SwitchStmt)(nil)) {
+		stmt := cur.Node().(*ast.SwitchStmt)
+		if stmt.Tag == nil {
+			continue
+		}
+		named, ok := types.Unalias(pass.TypesInfo.TypeOf(stmt.Tag)).(*types.Named)
+		if !ok || named.Obj().Pkg() == nil {
+			continue
+		}
+		if _, ok := named.Underlying().(*types.Basic); !ok {
+			continue
+		}
+
+		values, defaultCase, ok := caseValues(pass.TypesInfo, stmt.Body)
+		if !ok {
+			continue // a case is not constant: can't tell which are missing
+		}
+		if defaultCase != nil && !explicit {
+			continue
+		}
+
+		if missing := missingConsts(pass.Pkg, named, values); len(missing) > 0 {
+			curFile, _ := moreiters.First(cur.Enclosing((*ast.File)(nil)))
+			file := curFile.Node().(*ast.File)
+			var (
+				names []string
+				buf   strings.Builder
+				edits []analysis.TextEdit
+			)
+			for _, c := range missing {
+				name := c.Name()
+				prefix := ""
+				if c.Pkg() != pass.Pkg {
+					name = c.Pkg().Name() + "." + c.Name()
+					var importEdits []analysis.TextEdit
+					_, prefix, importEdits = analysisinternal.AddImport(
+						pass.TypesInfo, file, c.Pkg().Name(), c.Pkg().Path(), c.Name(), stmt.Pos())
+					edits = append(edits, importEdits...)
+				}
+				names = append(names, name)
+				buf.WriteString("case " + prefix + c.Name() + ":\n")
+			}
+
+			// Insert the cases before the default
+			// case, if any, or at the end.
+			pos := stmt.Body.Rbrace
+			if defaultCase != nil {
+				pos = defaultCase.Pos()
+			}
+			edits = append(edits, analysis.TextEdit{
+				Pos:     pos,
+				End:     pos,
+				NewText: []byte(buf.String()),
+			})
+
+			pass.Report(analysis.Diagnostic{
+				Pos: stmt.Pos(),
+				End: stmt.Pos() + token.Pos(len("switch")),
+				Message: "missing cases in switch of type " +
+					types.TypeString(named, typesinternal.NameRelativeTo(pass.Pkg)) + ": " + strings.Join(names, ", "),
+				SuggestedFixes: []analysis.SuggestedFix{{
+					Message:   "Add missing cases",
+					TextEdits: edits,
+				}},
+			})
+		}
+	}
+	return nil, nil
+}
+
+// caseValues returns the values of the cases of a switch statement,
+// and its default case, if any. It returns ok=false if the value of
+// some case is not constant.
+func caseValues(info *types.Info, body *ast.BlockStmt) (values []constant.Value, defaultCase *ast.CaseClause, ok bool) {
+	for _, clause := range body.List {
+		clause := clause.(*ast.CaseClause)
+		if clause.List == nil {
+			defaultCase = clause
+		}
+		for _, e := range clause.List {
+			tv := info.Types[e]
+			if tv.Value == nil {
+				return nil, nil, false
+			}
+			values = append(values, tv.Value)
+		}
+	}
+	return values, defaultCase, true
+}
+
+// missingConsts returns the constants of the enumerated type named,
+// accessible from package pkg, whose values are not among values,
+// in declaration order. Of several constants with the same value,
+// only the first is returned.
+func missingConsts(pkg *types.Package, named *types.Named, values []constant.Value) []*types.Const {
+	// Gather the constants of the type, in declaration order.
+	var consts []*types.Const
+	scope := named.Obj().Pkg().Scope()
+	for _, name := range scope.Names() {
+		if c, ok := scope.Lookup(name).(*types.Const); ok &&
+			(c.Pkg() == pkg || c.Exported()) && // accessible
+			types.Identical(c.Type(), named) {
+			consts = append(consts, c)
+		}
+	}
+	sort.Slice(consts, func(i, j int) bool { return consts[i].Pos() < consts[j].Pos() })
+
+	covered := func(v constant.Value) bool {
+		for _, value := range values {
+			if constant.Compare(value, token.EQL, v) {
+				return true
+			}
+		}
+		return false
+	}
+	var missing []*types.Const
+	for _, c := range consts {
+		if !covered(c.Val()) {
+			missing = append(missing, c)
+			values = append(values, c.Val()) // report only the first of equal constants
+		}
+	}
+	return missing
+}
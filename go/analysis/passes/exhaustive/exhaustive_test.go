@@ -0,0 +1,24 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package exhaustive_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/analysis/passes/exhaustive"
+)
+
+func Test(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), exhaustive.Analyzer, "a")
+}
+
+func TestExplicit(t *testing.T) {
+	if err := exhaustive.Analyzer.Flags.Set("explicit", "true"); err != nil {
+		t.Fatal(err)
+	}
+	defer exhaustive.Analyzer.Flags.Set("explicit", "false")
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), exhaustive.Analyzer, "b")
+}
@@ -0,0 +1,75 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a
+
+import "enum"
+
+type Color int
+
+const (
+	Red Color = iota
+	Green
+	Blue
+)
+
+type Celsius float64 // no constants
+
+func missing(c Color) string {
+	switch c { // want "missing cases in switch of type Color: Blue"
+	case Red:
+		return "red"
+	case Green:
+		return "green"
+	}
+	return ""
+}
+
+func several(c Color) {
+	switch c { // want "missing cases in switch of type Color: Red, Green"
+	case Blue:
+	}
+}
+
+func complete(c Color) {
+	switch c {
+	case Red, Green:
+	case Blue:
+	}
+}
+
+func withDefault(c Color) {
+	switch c { // ok: default case
+	case Red:
+	default:
+	}
+}
+
+func nonConstant(c, d Color) {
+	switch c { // ok: can't tell
+	case d:
+	}
+}
+
+func notEnum(t Celsius, i int) {
+	switch t {
+	case 0:
+	}
+	switch i {
+	case 1:
+	}
+}
+
+func imported(l enum.Level) {
+	switch l { // want "missing cases in switch of type enum.Level: enum.Info, enum.Error"
+	case enum.Debug:
+	case enum.Warning:
+	}
+}
+
+func literal(l enum.Level) {
+	switch l { // want "missing cases in switch of type enum.Level: enum.Debug, enum.Warn, enum.Error"
+	case "info":
+	}
+}
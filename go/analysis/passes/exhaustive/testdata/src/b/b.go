@@ -0,0 +1,14 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package b
+
+import "enum"
+
+func severity(s interface{ Level() enum.Level }) {
+	switch s.Level() { // want "missing cases in switch of type enum.Level: enum.Warn, enum.Error"
+	case enum.Debug, enum.Info:
+	default:
+	}
+}
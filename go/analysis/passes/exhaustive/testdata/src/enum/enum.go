@@ -0,0 +1,20 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package enum
+
+type Level string
+
+const (
+	Debug Level = "debug"
+	Info  Level = "info"
+	Warn  Level = "warn"
+	Error Level = "error"
+
+	Warning = Warn // same value as Warn
+
+	internal Level = "internal" // inaccessible
+)
+
+func (l Level) Severity() int { return 0 }
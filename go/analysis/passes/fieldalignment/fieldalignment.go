@@ -13,7 +13,10 @@ import (
 	"go/format"
 	"go/token"
 	"go/types"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
@@ -42,6 +45,13 @@ has 24 pointer bytes because it has to scan further through the *uint32.
 
 has 8 because it can stop immediately after the string pointer.
 
+The suggested edit keeps the comments of each field, and the blank
+lines that separate groups of fields, with the fields they precede.
+
+The -min-savings flag limits the diagnostics to structs that would
+shrink by at least the given number of bytes, such as -min-savings=8,
+or percentage of their size, such as -min-savings=25%.
+
 Be aware that the most compact order is not always the most efficient.
 In rare cases it may cause two variables each updated by its own goroutine
 to occupy the same CPU cache line, inducing a form of memory contention
@@ -65,6 +75,54 @@ var Analyzer = &analysis.Analyzer{
 	Run:      run,
 }
 
+func init() {
+	Analyzer.Flags.Var(&minSavings, "min-savings",
+		"report only structs whose size (or pointer bytes) would shrink by at least this many bytes, or this percentage if followed by %")
+}
+
+// minSavings is the value of the -min-savings flag.
+var minSavings savingsFlag
+
+// A savingsFlag is a threshold of savings in bytes, such as "8",
+// or as a percentage, such as "10%".
+type savingsFlag struct {
+	bytes   int64
+	percent float64 // if nonzero, bytes is ignored
+}
+
+func (f *savingsFlag) String() string {
+	if f.percent != 0 {
+		return strconv.FormatFloat(f.percent, 'g', -1, 64) + "%"
+	}
+	return strconv.FormatInt(f.bytes, 10)
+}
+
+func (f *savingsFlag) Set(s string) error {
+	if pct, ok := strings.CutSuffix(s, "%"); ok {
+		percent, err := strconv.ParseFloat(pct, 64)
+		if err != nil || percent < 0 || percent > 100 {
+			return fmt.Errorf("invalid percentage %q", s)
+		}
+		*f = savingsFlag{percent: percent}
+		return nil
+	}
+	bytes, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || bytes < 0 {
+		return fmt.Errorf("invalid number of bytes %q", s)
+	}
+	*f = savingsFlag{bytes: bytes}
+	return nil
+}
+
+// met reports whether reducing a quantity from old to new bytes
+// meets the threshold.
+func (f *savingsFlag) met(old, new int64) bool {
+	if f.percent != 0 {
+		return float64(old-new)*100 >= f.percent*float64(old)
+	}
+	return old-new >= f.bytes
+}
+
 func run(pass *analysis.Pass) (any, error) {
 	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 	nodeFilter := []ast.Node{
@@ -95,31 +153,169 @@ func fieldalignment(pass *analysis.Pass, node *ast.StructType, typ *types.Struct
 
 	var message string
 	if sz := s.Sizeof(typ); sz != optsz {
+		if !minSavings.met(sz, optsz) {
+			return
+		}
 		message = fmt.Sprintf("struct of size %d could be %d", sz, optsz)
 	} else if ptrs := s.ptrdata(typ); ptrs != optptrs {
+		if !minSavings.met(ptrs, optptrs) {
+			return
+		}
 		message = fmt.Sprintf("struct with %d pointer bytes could be %d", ptrs, optptrs)
 	} else {
 		// Already optimal order.
 		return
 	}
 
+	newText, err := reorderFields(pass, node, indexes)
+	if err != nil {
+		return
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:     node.Pos(),
+		End:     node.Pos() + token.Pos(len("struct")),
+		Message: message,
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: "Rearrange fields",
+			TextEdits: []analysis.TextEdit{{
+				Pos:     node.Pos(),
+				End:     node.End(),
+				NewText: newText,
+			}},
+		}},
+	})
+}
+
+// reorderFields returns the text of the struct type node with its
+// fields rearranged in the order given by indexes, which are indexes
+// of the fields of the struct type.
+//
+// Each field keeps its doc comment, line comment, and the blank line
+// preceding it, if any. Comments that are not attached to any field
+// are kept at the end.
+func reorderFields(pass *analysis.Pass, node *ast.StructType, indexes []int) ([]byte, error) {
+	tf := pass.Fset.File(node.Pos())
+	content, err := pass.ReadFile(tf.Name())
+	if err != nil {
+		return nil, err
+	}
+	line := tf.Line
+	text := func(start, end token.Pos) []byte { return content[tf.Offset(start):tf.Offset(end)] }
+
+	list := node.Fields.List
+	if len(list) == 0 || line(list[len(list)-1].End()) == line(node.Fields.Closing) {
+		return formatFields(node, indexes) // a field on the closing line
+	}
+
+	// A unit is the text of one or more fields declared together,
+	// with their comments.
+	type unit struct {
+		text  []byte // starting with the indentation of its first line
+		blank bool   // preceded by a blank line
+	}
+	var (
+		units    []unit
+		unitOf   []int // unit of each field, by index
+		prevEnd  = node.Fields.Opening
+		attached = make(map[*ast.CommentGroup]bool)
+	)
+	for _, f := range list {
+		start, end := f.Pos(), f.End()
+		if f.Doc != nil {
+			start = f.Doc.Pos()
+			attached[f.Doc] = true
+		}
+		if f.Comment != nil {
+			end = f.Comment.End()
+			attached[f.Comment] = true
+		}
+		if line(start) == line(prevEnd) {
+			return formatFields(node, indexes) // a field on the opening line, or several on one line
+		}
+		blank := line(start) > line(prevEnd)+1 && prevEnd != node.Fields.Opening
+		prevEnd = end
+
+		// The fields of a declaration such as "x, y int" have the
+		// same type, so they remain adjacent in the optimal order.
+		n := max(len(f.Names), 1)
+		if !adjacent(indexes, len(unitOf), n) {
+			return formatFields(node, indexes)
+		}
+		for range n {
+			unitOf = append(unitOf, len(units))
+		}
+		units = append(units, unit{text(tf.LineStart(line(start)), end), blank})
+	}
+
+	var buf bytes.Buffer
+	// Keep the opening line, which may have a comment.
+	buf.Write(text(node.Pos(), tf.LineStart(line(node.Fields.Opening)+1)))
+	for i, index := range indexes {
+		u := units[unitOf[index]]
+		if i > 0 && unitOf[indexes[i-1]] == unitOf[index] {
+			continue // a later field of the same unit
+		}
+		if u.blank && i > 0 {
+			buf.WriteByte('\n')
+		}
+		buf.Write(u.text)
+		buf.WriteByte('\n')
+	}
+
+	// Add the comments that are not attached to fields.
+	for _, file := range pass.Files {
+		if file.FileStart > node.Pos() || node.End() > file.FileEnd {
+			continue
+		}
+		for _, cg := range file.Comments {
+			if line(cg.Pos()) > line(node.Fields.Opening) && cg.End() < node.Fields.Closing && !attached[cg] {
+				if line(cg.Pos()) > line(prevEnd)+1 {
+					buf.WriteByte('\n')
+				}
+				buf.Write(text(tf.LineStart(line(cg.Pos())), cg.End()))
+				buf.WriteByte('\n')
+				prevEnd = cg.End()
+			}
+		}
+	}
+	buf.Write(text(tf.LineStart(line(node.Fields.Closing)), node.End()))
+	return buf.Bytes(), nil
+}
+
+// adjacent reports whether the n fields starting at index first
+// appear consecutively and in order in indexes.
+func adjacent(indexes []int, first, n int) bool {
+	i := slices.Index(indexes, first)
+	for j := range n {
+		if i+j >= len(indexes) || indexes[i+j] != first+j {
+			return false
+		}
+	}
+	return true
+}
+
+// formatFields returns the formatted struct type node with its fields
+// rearranged in the order given by indexes, without comments. It is
+// used for structs whose fields are not each on lines of their own.
+func formatFields(node *ast.StructType, indexes []int) ([]byte, error) {
 	// Flatten the ast node since it could have multiple field names per list item while
 	// *types.Struct only have one item per field.
-	// TODO: Preserve multi-named fields instead of flattening.
 	var flat []*ast.Field
 	for _, f := range node.Fields.List {
-		// TODO: Preserve comment, for now get rid of them.
-		//       See https://github.com/golang/go/issues/20744
-		f.Comment = nil
-		f.Doc = nil
 		if len(f.Names) <= 1 {
-			flat = append(flat, f)
+			flat = append(flat, &ast.Field{
+				Names: f.Names,
+				Type:  f.Type,
+				Tag:   f.Tag,
+			})
 			continue
 		}
 		for _, name := range f.Names {
 			flat = append(flat, &ast.Field{
 				Names: []*ast.Ident{name},
 				Type:  f.Type,
+				Tag:   f.Tag,
 			})
 		}
 	}
@@ -139,22 +335,9 @@ func fieldalignment(pass *analysis.Pass, node *ast.StructType, typ *types.Struct
 	// Write the newly aligned struct node to get the content for suggested fixes.
 	var buf bytes.Buffer
 	if err := format.Node(&buf, token.NewFileSet(), newStr); err != nil {
-		return
+		return nil, err
 	}
-
-	pass.Report(analysis.Diagnostic{
-		Pos:     node.Pos(),
-		End:     node.Pos() + token.Pos(len("struct")),
-		Message: message,
-		SuggestedFixes: []analysis.SuggestedFix{{
-			Message: "Rearrange fields",
-			TextEdits: []analysis.TextEdit{{
-				Pos:     node.Pos(),
-				End:     node.End(),
-				NewText: buf.Bytes(),
-			}},
-		}},
-	})
+	return buf.Bytes(), nil
 }
 
 func optimalOrder(str *types.Struct, sizes *gcSizes) (*types.Struct, []int) {
@@ -15,3 +15,19 @@ func TestTest(t *testing.T) {
 	testdata := analysistest.TestData()
 	analysistest.RunWithSuggestedFixes(t, testdata, fieldalignment.Analyzer, "a")
 }
+
+func TestComments(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, fieldalignment.Analyzer, "b")
+}
+
+func TestMinSavings(t *testing.T) {
+	for _, value := range []string{"8", "25%"} {
+		if err := fieldalignment.Analyzer.Flags.Set("min-savings", value); err != nil {
+			t.Fatal(err)
+		}
+		testdata := analysistest.TestData()
+		analysistest.Run(t, testdata, fieldalignment.Analyzer, "c")
+	}
+	fieldalignment.Analyzer.Flags.Set("min-savings", "0")
+}
@@ -0,0 +1,29 @@
+package b
+
+// Groups keeps the doc comments and blank lines of its fields.
+type Groups struct { // want "struct of size 12 could be 8"
+	// flags
+	a bool // first flag
+
+	// n counts things.
+	n int32
+
+	b bool
+
+	// trailing comment
+}
+
+// Multi keeps a declaration of several fields.
+type Multi struct { // want "struct of size 16 could be 12"
+	a byte
+	// x and y are coordinates.
+	x, y int32 // in pixels
+	b    byte
+}
+
+// Tags keeps the tags of its fields.
+type Tags struct { // want "struct of size 12 could be 8"
+	A bool  `json:"a"`
+	N int32 `json:"n"` // counter
+	B bool  `json:"b"`
+}
@@ -0,0 +1,18 @@
+package c
+
+// Small wastes 4 of its 32 bytes.
+type Small struct {
+	a   byte
+	n   int32
+	b   byte
+	buf [20]byte
+}
+
+// Large wastes 8 of its 20 bytes.
+type Large struct { // want "struct of size 20 could be 12"
+	a bool
+	n int32
+	b bool
+	m int32
+	c bool
+}
@@ -0,0 +1,41 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package gogenerate defines an Analyzer that checks //go:generate
+// directives.
+//
+// # Analyzer gogenerate
+//
+// gogenerate: check //go:generate directives
+//
+// The gogenerate analyzer checks the //go:generate directives of all
+// Go source files in a package directory, even those excluded by
+// build constraints, and reports:
+//
+//   - directives that do not start at the beginning of a line, which
+//     go generate ignores;
+//   - directives whose quoted arguments are malformed;
+//   - directives in files excluded by build constraints on every
+//     platform, such as by //go:build ignore, which go generate does
+//     not run unless the -tags flag enables the file;
+//   - "go run pkg" commands where pkg has no version and is not
+//     provided by a module required by go.mod, so it cannot be built;
+//   - "go tool name" commands where name is neither a tool of the Go
+//     distribution nor a tool declared in go.mod;
+//   - well-known generators run as plain commands that are not
+//     declared as tools, if the module declares its tools, either by
+//     tool directives in go.mod or by the blank imports of a file
+//     constrained by the "tools" build tag, such as tools.go.
+//
+// It also checks the flags of well-known generators, namely stringer
+// and mockgen, whether they are run as plain commands, by go run, or
+// by go tool. For example:
+//
+//	//go:generate stringer -type=Color,Shape
+//
+// is reported if the package does not declare types Color and Shape.
+//
+// The analyzer reads the go.mod file of the module that encloses the
+// package directory; if there is none, the commands are not checked.
+package gogenerate
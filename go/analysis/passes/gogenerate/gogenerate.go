@@ -0,0 +1,454 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package gogenerate defines an Analyzer that checks //go:generate
+// directives.
+package gogenerate
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/types"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/internal/analysisutil"
+	"golang.org/x/tools/internal/analysisinternal"
+)
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name: "gogenerate",
+	Doc:  analysisutil.MustExtractDoc(doc, "gogenerate"),
+	URL:  "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/gogenerate",
+	Run:  run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	check := &checker{pass: pass, tools: make(map[string]bool)}
+
+	var files []*ast.File
+	excluded := make(map[*ast.File]bool)
+	files = append(files, pass.Files...)
+	for _, name := range pass.IgnoredFiles {
+		if !strings.HasSuffix(name, ".go") {
+			continue
+		}
+		f, err := parser.ParseFile(pass.Fset, name, nil, parser.ParseComments)
+		if err != nil {
+			continue // not our job to diagnose
+		}
+		files = append(files, f)
+		excluded[f] = !matchesAnyPlatform(name)
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	// Gather the tools declared by the module, and by the blank
+	// imports of files with the "tools" build tag.
+	check.mod = findModFile(pass.Fset.File(files[0].FileStart).Name())
+	if check.mod != nil {
+		for _, tool := range check.mod.Tool {
+			check.tools[tool.Path] = true
+		}
+	}
+	for _, f := range files {
+		if !hasToolsTag(f) {
+			continue
+		}
+		for _, spec := range f.Imports {
+			if path, err := strconv.Unquote(spec.Path.Value); err == nil && spec.Name != nil && spec.Name.Name == "_" {
+				check.tools[path] = true
+			}
+		}
+	}
+
+	for _, f := range files {
+		for _, group := range f.Comments {
+			for _, c := range group.List {
+				if args, ok := strings.CutPrefix(c.Text, "//go:generate"); ok && (args == "" || args[0] == ' ' || args[0] == '\t') {
+					check.directive(c, args, excluded[f])
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+type checker struct {
+	pass  *analysis.Pass
+	mod   *modfile.File   // the go.mod file of the module, or nil
+	tools map[string]bool // package paths of the declared tools
+}
+
+// directive checks a //go:generate comment whose arguments are args.
+func (check *checker) directive(c *ast.Comment, args string, excluded bool) {
+	pass := check.pass
+	if pass.Fset.Position(c.Slash).Column != 1 {
+		pass.ReportRangef(c, "//go:generate directive is ignored unless it starts at the beginning of a line")
+		return
+	}
+	if excluded {
+		pass.ReportRangef(c, "//go:generate directive is never run: the file is excluded by build constraints on all platforms")
+	}
+	words, err := splitArgs(args)
+	if err != nil {
+		pass.ReportRangef(c, "malformed //go:generate directive: %v", err)
+		return
+	}
+	if len(words) == 0 {
+		pass.ReportRangef(c, "empty //go:generate directive")
+		return
+	}
+
+	// Find the generator and its arguments.
+	var generator string
+	switch {
+	case words[0] == "go" && len(words) > 2 && words[1] == "run":
+		i := 2
+		for i < len(words) && strings.HasPrefix(words[i], "-") {
+			if name := strings.TrimLeft(words[i], "-"); goRunValueFlags[name] {
+				i++ // -flag value
+			}
+			i++
+		}
+		if i >= len(words) {
+			return
+		}
+		pkg := words[i]
+		words = words[i+1:]
+		if !check.goRunDeclared(pkg) {
+			pass.ReportRangef(c, "go run %s: no module required by go.mod provides the package; require its module or specify a version, as in %[1]s@latest", pkg)
+		}
+		pkg, _, _ = strings.Cut(pkg, "@")
+		generator = commandName(pkg)
+
+	case words[0] == "go" && len(words) > 2 && words[1] == "tool":
+		name := words[2]
+		words = words[3:]
+		if !check.goToolDeclared(name) {
+			pass.ReportRangef(c, "go tool %s: no such tool is declared in go.mod", name)
+		}
+		generator = commandName(name)
+
+	default:
+		generator = strings.TrimSuffix(filepath.Base(words[0]), ".exe")
+		words = words[1:]
+		// If the module declares its tools, it should
+		// declare the well-known generators too.
+		if generators[generator] && len(check.tools) > 0 && !check.declared(generator) {
+			pass.ReportRangef(c, "%s is not declared as a tool in go.mod or tools.go, so it must be installed separately", generator)
+		}
+	}
+
+	switch generator {
+	case "stringer":
+		check.stringer(c, words)
+	case "mockgen":
+		check.mockgen(c, words)
+	}
+}
+
+// goRunDeclared reports whether the package argument of go run is
+// local, standard, pinned to a version, or provided by a module
+// required by go.mod.
+func (check *checker) goRunDeclared(pkg string) bool {
+	if strings.Contains(pkg, "@") ||
+		strings.HasPrefix(pkg, ".") ||
+		filepath.IsAbs(pkg) ||
+		strings.HasSuffix(pkg, ".go") ||
+		strings.Contains(pkg, "$") || // e.g. $GOROOT/...
+		analysisinternal.IsStdPackage(pkg) ||
+		check.mod == nil || check.tools[pkg] {
+		return true
+	}
+	if check.mod.Module != nil && within(pkg, check.mod.Module.Mod.Path) {
+		return true
+	}
+	for _, req := range check.mod.Require {
+		if within(pkg, req.Mod.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// goToolDeclared reports whether the argument of go tool names a tool
+// of the Go distribution or a tool declared by go.mod.
+func (check *checker) goToolDeclared(name string) bool {
+	if distTools[name] || check.mod == nil {
+		return true
+	}
+	for _, tool := range check.mod.Tool {
+		if name == tool.Path || name == commandName(tool.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// declared reports whether a tool with the command name is declared
+// in go.mod or imported by a tools.go file.
+func (check *checker) declared(name string) bool {
+	for path := range check.tools {
+		if commandName(path) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// stringer checks the arguments of golang.org/x/tools/cmd/stringer.
+func (check *checker) stringer(c *ast.Comment, args []string) {
+	flags, rest, err := parseFlags(args, map[string]bool{
+		"type":        false,
+		"output":      false,
+		"trimprefix":  false,
+		"tags":        false,
+		"linecomment": true,
+	})
+	if err != nil {
+		check.pass.ReportRangef(c, "stringer: %v", err)
+		return
+	}
+	typs, ok := flags["type"]
+	if !ok || typs == "" {
+		check.pass.ReportRangef(c, "stringer: missing -type flag")
+		return
+	}
+	if len(rest) > 0 {
+		return // types of another package
+	}
+	for name := range strings.SplitSeq(typs, ",") {
+		if _, ok := check.pass.Pkg.Scope().Lookup(name).(*types.TypeName); !ok {
+			check.pass.ReportRangef(c, "stringer: type %s is not declared in package %s", name, check.pass.Pkg.Name())
+		}
+	}
+}
+
+// mockgen checks the arguments of go.uber.org/mock/mockgen.
+func (check *checker) mockgen(c *ast.Comment, args []string) {
+	flags, rest, err := parseFlags(args, map[string]bool{
+		"source":                   false,
+		"destination":              false,
+		"mock_names":               false,
+		"package":                  false,
+		"self_package":             false,
+		"copyright_file":           false,
+		"imports":                  false,
+		"aux_files":                false,
+		"exclude_interfaces":       false,
+		"build_flags":              false,
+		"build_constraint":         false,
+		"exec_only":                false,
+		"prog_only":                true,
+		"write_package_comment":    true,
+		"write_source_comment":     true,
+		"write_generate_directive": true,
+		"write_command_comment":    true,
+		"typed":                    true,
+		"debug_parser":             true,
+		"version":                  true,
+	})
+	if err != nil {
+		check.pass.ReportRangef(c, "mockgen: %v", err)
+		return
+	}
+	if _, ok := flags["version"]; ok {
+		return
+	}
+	if _, ok := flags["source"]; !ok && len(rest) != 2 {
+		check.pass.ReportRangef(c, "mockgen: without -source, the arguments must be an import path and a comma-separated list of interfaces")
+	}
+}
+
+// parseFlags parses command-line flags in the manner of the flag
+// package. The spec maps each flag name to whether it is boolean.
+// It returns the values of the flags, and the remaining arguments.
+func parseFlags(args []string, spec map[string]bool) (map[string]string, []string, error) {
+	flags := make(map[string]string)
+	for len(args) > 0 {
+		arg := args[0]
+		if arg == "--" {
+			args = args[1:]
+			break
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			break
+		}
+		args = args[1:]
+		name := strings.TrimPrefix(arg[1:], "-")
+		name, value, hasValue := strings.Cut(name, "=")
+		isBool, ok := spec[name]
+		switch {
+		case !ok:
+			return nil, nil, fmt.Errorf("unknown flag -%s", name)
+		case isBool && hasValue:
+			if _, err := strconv.ParseBool(value); err != nil {
+				return nil, nil, fmt.Errorf("invalid boolean value %q for flag -%s", value, name)
+			}
+		case isBool:
+			value = "true"
+		case !hasValue:
+			if len(args) == 0 {
+				return nil, nil, fmt.Errorf("flag -%s needs an argument", name)
+			}
+			value, args = args[0], args[1:]
+		}
+		flags[name] = value
+	}
+	return flags, args, nil
+}
+
+// splitArgs splits the arguments of a //go:generate directive into
+// words, in the manner of the go command: words are separated by
+// spaces, and a double-quoted word is a Go string literal.
+func splitArgs(line string) ([]string, error) {
+	var words []string
+	for {
+		line = strings.TrimLeft(line, " \t")
+		if line == "" {
+			return words, nil
+		}
+		if line[0] == '"' {
+			quoted, err := strconv.QuotedPrefix(line)
+			if err != nil {
+				return nil, fmt.Errorf("invalid quoted string in %s", line)
+			}
+			word, _ := strconv.Unquote(quoted)
+			words = append(words, word)
+			line = line[len(quoted):]
+			continue
+		}
+		i := strings.IndexAny(line, " \t")
+		if i < 0 {
+			i = len(line)
+		}
+		words = append(words, line[:i])
+		line = line[i:]
+	}
+}
+
+// commandName returns the name of the command built from the package
+// path, ignoring a major version suffix, as the go command does.
+func commandName(pkg string) string {
+	elem := path.Base(pkg)
+	if prefix, _, ok := module.SplitPathVersion(pkg); ok && prefix != pkg && strings.HasPrefix(elem, "v") {
+		elem = path.Base(prefix)
+	}
+	return elem
+}
+
+// within reports whether the package path is within the module path.
+func within(pkg, mod string) bool {
+	return pkg == mod || strings.HasPrefix(pkg, mod+"/")
+}
+
+// hasToolsTag reports whether the file has a build constraint that
+// mentions the "tools" tag, by convention a file that declares the
+// tools of a module.
+func hasToolsTag(f *ast.File) bool {
+	for _, group := range f.Comments {
+		if group.Pos() >= f.Package {
+			break
+		}
+		for _, c := range group.List {
+			if strings.HasPrefix(c.Text, "//go:build ") && strings.Contains(c.Text, "tools") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// findModFile returns the parsed go.mod file of the module enclosing
+// the named file, or nil if none is found.
+func findModFile(filename string) *modfile.File {
+	for dir := filepath.Dir(filename); ; {
+		gomod := filepath.Join(dir, "go.mod")
+		if data, err := os.ReadFile(gomod); err == nil {
+			f, err := modfile.Parse(gomod, data, nil)
+			if err != nil {
+				return nil
+			}
+			return f
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil
+		}
+		dir = parent
+	}
+}
+
+// matchesAnyPlatform reports whether the build constraints of the
+// named file, including those implied by its name, are satisfied on
+// some platform without custom build tags.
+func matchesAnyPlatform(filename string) bool {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return true // assume so
+	}
+	ctxt := build.Default // copy
+	ctxt.BuildTags = nil
+	ctxt.OpenFile = func(string) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(content)), nil
+	}
+	dir, name := filepath.Split(filename)
+	for _, goos := range knownOS {
+		for _, goarch := range knownArch {
+			for _, cgo := range []bool{false, true} {
+				ctxt.GOOS, ctxt.GOARCH, ctxt.CgoEnabled = goos, goarch, cgo
+				if ok, err := ctxt.MatchFile(dir, name); err != nil || ok {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+var (
+	knownOS = []string{"aix", "android", "darwin", "dragonfly", "freebsd", "hurd", "illumos", "ios", "js",
+		"linux", "netbsd", "openbsd", "plan9", "solaris", "wasip1", "windows", "zos"}
+	knownArch = []string{"386", "amd64", "arm", "arm64", "loong64", "mips", "mipsle", "mips64", "mips64le",
+		"ppc64", "ppc64le", "riscv64", "s390x", "sparc64", "wasm"}
+)
+
+// goRunValueFlags is the set of build flags of go run that take a
+// separate value.
+var goRunValueFlags = map[string]bool{
+	"C": true, "asmflags": true, "buildmode": true, "buildvcs": true, "compiler": true,
+	"exec": true, "gccgoflags": true, "gcflags": true, "installsuffix": true, "ldflags": true,
+	"mod": true, "modfile": true, "overlay": true, "p": true, "pgo": true, "pkgdir": true,
+	"tags": true, "toolexec": true,
+}
+
+// distTools is the set of tools of the Go distribution run by go tool.
+var distTools = map[string]bool{
+	"addr2line": true, "asm": true, "buildid": true, "cgo": true, "compile": true, "covdata": true,
+	"cover": true, "dist": true, "distpack": true, "doc": true, "fix": true, "link": true, "nm": true,
+	"objdump": true, "pack": true, "pprof": true, "preprofile": true, "test2json": true, "trace": true,
+	"vet": true,
+}
+
+// generators is the set of command names of well-known generators.
+var generators = map[string]bool{
+	"stringer": true,
+	"mockgen":  true,
+}
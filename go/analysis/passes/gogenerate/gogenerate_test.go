@@ -0,0 +1,16 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gogenerate_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/analysis/passes/gogenerate"
+)
+
+func Test(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), gogenerate.Analyzer, "a", "b")
+}
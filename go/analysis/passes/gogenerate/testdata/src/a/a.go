@@ -0,0 +1,53 @@
+package a
+
+type Color int
+
+type Shape int
+
+type Iface interface{ M() }
+
+//go:generate go tool stringer -type=Color
+//go:generate go tool vet
+//go:generate stringer -type Color,Shape -linecomment
+//go:generate mockgen -source=a.go -destination=mock.go -package=mocks
+//go:generate mockgen example.com/a Iface
+//go:generate go run golang.org/x/tools/cmd/stringer -type=Color
+//go:generate go run -tags foo example.com/other/gen@v1.0.0 -x
+//go:generate go run ./internal/gen
+//go:generate go run go.uber.org/mock/mockgen -version
+//go:generate echo "hello, world"
+
+// want +1 `go tool enumer: no such tool is declared in go.mod`
+//go:generate go tool enumer -type=Color
+
+// want +1 `go run example.com/other/gen: no module required by go.mod provides the package`
+//go:generate go run example.com/other/gen
+
+// want +1 `stringer: type Missing is not declared in package a`
+//go:generate stringer -type=Color,Missing
+
+// want +1 `stringer: missing -type flag`
+//go:generate stringer -output=x.go
+
+// want +1 `stringer: unknown flag -bogus`
+//go:generate stringer -type=Color -bogus
+
+// want +1 `stringer: flag -type needs an argument`
+//go:generate go tool stringer -type
+
+// want +1 `mockgen: invalid boolean value "maybe" for flag -typed`
+//go:generate mockgen -typed=maybe -source=a.go
+
+// want +1 `mockgen: without -source, the arguments must be an import path and a comma-separated list of interfaces`
+//go:generate mockgen -destination=mock.go example.com/a
+
+// want +1 `malformed //go:generate directive: invalid quoted string`
+//go:generate echo "unterminated
+
+// want +1 `empty //go:generate directive`
+//go:generate
+
+func f() {
+	// want +1 `//go:generate directive is ignored unless it starts at the beginning of a line`
+	//go:generate echo indented
+}
@@ -0,0 +1,3 @@
+package a
+
+//go:generate stringer -type=Shape
@@ -0,0 +1,10 @@
+module example.com/a
+
+go 1.24
+
+require (
+	go.uber.org/mock v0.5.0
+	golang.org/x/tools v0.30.0
+)
+
+tool golang.org/x/tools/cmd/stringer
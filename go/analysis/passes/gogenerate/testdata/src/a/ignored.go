@@ -0,0 +1,6 @@
+//go:build ignore
+
+package a
+
+// want +1 `//go:generate directive is never run: the file is excluded by build constraints on all platforms`
+//go:generate stringer -type=Shape
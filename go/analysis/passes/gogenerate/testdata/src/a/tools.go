@@ -0,0 +1,5 @@
+//go:build tools
+
+package a
+
+import _ "go.uber.org/mock/mockgen"
@@ -0,0 +1,8 @@
+package b
+
+type T int
+
+// want +1 `stringer is not declared as a tool in go.mod or tools.go, so it must be installed separately`
+//go:generate stringer -type=T
+
+//go:generate goimports -w b.go
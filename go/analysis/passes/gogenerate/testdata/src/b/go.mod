@@ -0,0 +1,5 @@
+module example.com/b
+
+go 1.24
+
+tool golang.org/x/tools/cmd/goimports
@@ -0,0 +1,74 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package goroutineleak defines an Analyzer that reports goroutines
+// that are likely to leak.
+//
+// # Analyzer goroutineleak
+//
+// goroutineleak: report goroutines that may never terminate
+//
+// A goroutine that blocks forever, or runs forever, holds on to its
+// stack and everything reachable from it. This analyzer uses several
+// heuristics to find goroutines that are likely to leak. Each kind of
+// problem is reported in its own diagnostic category, so that it may be
+// disabled by the flag of the same name.
+//
+// ## unmatched
+//
+// A goroutine that sends to (or receives from) an unbuffered channel
+// that is local to the enclosing function blocks forever unless the
+// function receives from (or sends to or closes) that channel. The
+// analyzer reports such goroutines when there is no matching
+// operation at all, or when the only matching operations are within
+// select statements that may choose another case, a common mistake
+// when implementing timeouts:
+//
+//	ch := make(chan result)
+//	go func() {
+//		ch <- compute() // "goroutine may block forever sending to unbuffered channel ch: the receive is in a select statement that may choose another case"
+//	}()
+//	select {
+//	case r := <-ch:
+//		return r, nil
+//	case <-time.After(timeout):
+//		return nil, errTimeout
+//	}
+//
+// Making the channel buffered, with make(chan result, 1), fixes the
+// leak.
+//
+// ## select
+//
+// A select statement within an infinite loop in a function that has a
+// context.Context parameter should usually have a case for the
+// context's Done channel; otherwise the loop cannot be cancelled:
+//
+//	func (w *worker) run(ctx context.Context) {
+//		for {
+//			select { // "select in infinite loop has no case for ctx.Done()"
+//			case job := <-w.jobs:
+//				w.do(ctx, job)
+//			}
+//		}
+//	}
+//
+// The analyzer does not report select statements that have a default
+// case, or loops that check the context's Done or Err methods
+// elsewhere, or selects that receive from a channel whose name
+// suggests that it signals termination, such as done or quit.
+//
+// ## constructor
+//
+// A constructor function, such as NewServer, that starts a goroutine
+// running an infinite loop should provide a way to stop it, either by
+// accepting a context.Context parameter, or by a method of the result
+// type such as Close, Stop, or Shutdown:
+//
+//	func NewCache() *Cache {
+//		c := &Cache{}
+//		go c.evictLoop() // "goroutine started by NewCache runs forever: Cache has no Close, Stop, or Shutdown method"
+//		return c
+//	}
+package goroutineleak
@@ -0,0 +1,566 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package goroutineleak defines an Analyzer that reports goroutines
+// that are likely to leak.
+package goroutineleak
+
+import (
+	_ "embed"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/analysis/passes/internal/analysisutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+	"golang.org/x/tools/internal/analysisinternal"
+)
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "goroutineleak",
+	Doc:      analysisutil.MustExtractDoc(doc, "goroutineleak"),
+	URL:      "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/goroutineleak",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// flags, one per category
+var (
+	checkUnmatched   = true
+	checkSelect      = true
+	checkConstructor = true
+)
+
+func init() {
+	Analyzer.Flags.BoolVar(&checkUnmatched, "unmatched", checkUnmatched,
+		"report goroutines that block on unbuffered channels with no matching operation")
+	Analyzer.Flags.BoolVar(&checkSelect, "select", checkSelect,
+		"report select statements in infinite loops with no case for ctx.Done()")
+	Analyzer.Flags.BoolVar(&checkConstructor, "constructor", checkConstructor,
+		"report goroutines started by constructors that provide no way to stop them")
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	reportf := func(category string, rng analysis.Range, format string, args ...any) {
+		pass.Report(analysis.Diagnostic{
+			Pos:      rng.Pos(),
+			End:      rng.End(),
+			Category: category,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	// Index the function declarations of the package,
+	// for the bodies of functions started as goroutines.
+	decls := make(map[*types.Func]*ast.FuncDecl)
+	for cur := range inspect.Root().Preorder((*ast.FuncDecl)(nil)) {
+		decl := cur.Node().(*ast.FuncDecl)
+		if fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func); ok && decl.Body != nil {
+			decls[fn] = decl
+		}
+	}
+
+	for cur := range inspect.Root().Preorder((*ast.GoStmt)(nil), (*ast.SelectStmt)(nil), (*ast.FuncDecl)(nil)) {
+		switch n := cur.Node().(type) {
+		case *ast.GoStmt:
+			if checkUnmatched {
+				if lit, ok := ast.Unparen(n.Call.Fun).(*ast.FuncLit); ok {
+					if curLit, ok := cur.FindNode(lit); ok {
+						checkGoroutine(pass, reportf, curLit)
+					}
+				}
+			}
+
+		case *ast.SelectStmt:
+			if checkSelect {
+				checkLoopSelect(pass, reportf, cur, n)
+			}
+
+		case *ast.FuncDecl:
+			if checkConstructor {
+				checkConstructorFunc(pass, reportf, decls, n)
+			}
+		}
+	}
+	return nil, nil
+}
+
+type reportFunc = func(category string, rng analysis.Range, format string, args ...any)
+
+// -- unmatched --
+
+// chanOp is an operation on a channel variable.
+type chanOp struct {
+	kind   opKind
+	expr   ast.Node // the operation
+	inLit  bool     // within the goroutine's function literal
+	choice bool     // a case of a select statement with other cases
+}
+
+type opKind int
+
+const (
+	recvOp  opKind = iota // <-ch, or range ch
+	sendOp                // ch <- x
+	closeOp               // close(ch)
+	lenOp                 // len(ch) or cap(ch)
+)
+
+// checkGoroutine reports the first operation of the goroutine, whose
+// function literal is at curLit, on an unbuffered channel local to the
+// enclosing function that has no matching operation outside the
+// goroutine, or only matching operations that are select cases.
+func checkGoroutine(pass *analysis.Pass, reportf reportFunc, curLit inspector.Cursor) {
+	lit := curLit.Node().(*ast.FuncLit)
+
+	// Find the enclosing function.
+	var body *ast.BlockStmt
+	var curBody inspector.Cursor
+	for c := range curLit.Parent().Enclosing((*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)) {
+		switch fn := c.Node().(type) {
+		case *ast.FuncDecl:
+			body = fn.Body
+		case *ast.FuncLit:
+			body = fn.Body
+		}
+		curBody, _ = c.FindNode(body)
+		break
+	}
+	if body == nil {
+		return
+	}
+
+	// Find the channel variables operated on by the goroutine.
+	var chans []*types.Var
+	for c := range curLit.Preorder((*ast.Ident)(nil)) {
+		id := c.Node().(*ast.Ident)
+		v, ok := pass.TypesInfo.Uses[id].(*types.Var)
+		if !ok || !isChan(v.Type()) || lit.Pos() <= v.Pos() && v.Pos() < lit.End() {
+			continue // not a channel var declared outside the goroutine
+		}
+		if !containsVar(chans, v) {
+			chans = append(chans, v)
+		}
+	}
+
+nextChan:
+	for _, v := range chans {
+		if !unbufferedLocal(pass.TypesInfo, body, v) {
+			continue
+		}
+
+		var ops []chanOp
+		for c := range curBody.Preorder((*ast.Ident)(nil)) {
+			id := c.Node().(*ast.Ident)
+			if pass.TypesInfo.Uses[id] != v {
+				continue
+			}
+			op, ok := classify(pass.TypesInfo, c)
+			if !ok {
+				continue nextChan // channel escapes
+			}
+			op.inLit = lit.Pos() <= id.Pos() && id.Pos() < lit.End()
+			ops = append(ops, op)
+		}
+
+		// Find the goroutine's first send or receive,
+		// and the matching operations outside the goroutine.
+		var first *chanOp
+		var matches []chanOp
+		for i, op := range ops {
+			if op.inLit && (op.kind == sendOp || op.kind == recvOp) && first == nil {
+				first = &ops[i]
+			}
+		}
+		if first == nil {
+			continue
+		}
+		for _, op := range ops {
+			if op.inLit {
+				continue
+			}
+			if first.kind == sendOp && op.kind == recvOp ||
+				first.kind == recvOp && (op.kind == sendOp || op.kind == closeOp) {
+				matches = append(matches, op)
+			}
+		}
+
+		verb, match := "sending to", "receive"
+		if first.kind == recvOp {
+			verb, match = "receiving from", "send"
+		}
+		switch {
+		case len(matches) == 0:
+			reportf("unmatched", first.expr,
+				"goroutine blocks forever %s unbuffered channel %s: there is no matching operation", verb, v.Name())
+		case allChoices(matches):
+			reportf("unmatched", first.expr,
+				"goroutine may block forever %s unbuffered channel %s: the %s is in a select statement that may choose another case",
+				verb, v.Name(), match)
+		}
+	}
+}
+
+// classify returns the operation on the channel variable at the
+// identifier cursor, or false if the channel escapes.
+func classify(info *types.Info, cur inspector.Cursor) (chanOp, bool) {
+	id := cur.Node().(*ast.Ident)
+	switch parent := cur.Parent().Node().(type) {
+	case *ast.SendStmt:
+		if parent.Chan == id {
+			return chanOp{kind: sendOp, expr: parent, choice: isChoice(cur.Parent())}, true
+		}
+	case *ast.UnaryExpr:
+		if parent.Op == token.ARROW {
+			return chanOp{kind: recvOp, expr: parent, choice: isChoice(cur.Parent())}, true
+		}
+	case *ast.RangeStmt:
+		if parent.X == id {
+			return chanOp{kind: recvOp, expr: id}, true
+		}
+	case *ast.CallExpr:
+		if fn, ok := typeutil.Callee(info, parent).(*types.Builtin); ok {
+			switch fn.Name() {
+			case "close":
+				return chanOp{kind: closeOp, expr: parent}, true
+			case "len", "cap":
+				return chanOp{kind: lenOp, expr: parent}, true
+			}
+		}
+	}
+	return chanOp{}, false
+}
+
+// isChoice reports whether the send statement or receive expression at
+// cursor cur is the communication of a case of a select statement that
+// has other cases, so that it may not be executed.
+func isChoice(cur inspector.Cursor) bool {
+	// Skip the statement containing a receive expression.
+	if _, ok := cur.Node().(*ast.UnaryExpr); ok {
+		cur = cur.Parent()
+		switch cur.Node().(type) {
+		case *ast.ExprStmt, *ast.AssignStmt:
+		default:
+			return false
+		}
+	}
+	clause, ok := cur.Parent().Node().(*ast.CommClause)
+	if !ok || clause.Comm != cur.Node() {
+		return false
+	}
+	sel := cur.Parent().Parent().Parent().Node().(*ast.SelectStmt)
+	return len(sel.Body.List) > 1
+}
+
+// allChoices reports whether every operation is a select case that may
+// not be chosen.
+func allChoices(ops []chanOp) bool {
+	for _, op := range ops {
+		if !op.choice {
+			return false
+		}
+	}
+	return true
+}
+
+// unbufferedLocal reports whether v is a variable declared in the
+// function body and assigned once, by the declaration, to an
+// unbuffered channel created by make.
+func unbufferedLocal(info *types.Info, body *ast.BlockStmt, v *types.Var) bool {
+	if !(body.Pos() <= v.Pos() && v.Pos() < body.End()) {
+		return false // not local
+	}
+	var init ast.Expr
+	assigns := 0
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range n.Lhs {
+				if id, ok := lhs.(*ast.Ident); ok && info.ObjectOf(id) == v {
+					assigns++
+					if len(n.Lhs) == len(n.Rhs) {
+						init = n.Rhs[i]
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			for i, id := range n.Names {
+				if info.Defs[id] == v {
+					assigns++
+					if len(n.Names) == len(n.Values) {
+						init = n.Values[i]
+					}
+				}
+			}
+		}
+		return true
+	})
+	if assigns != 1 || init == nil {
+		return false
+	}
+	call, ok := ast.Unparen(init).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	if fn, ok := typeutil.Callee(info, call).(*types.Builtin); !ok || fn.Name() != "make" {
+		return false
+	}
+	switch len(call.Args) {
+	case 1:
+		return true
+	case 2:
+		size := info.Types[call.Args[1]].Value
+		return size != nil && constant.Sign(size) == 0
+	}
+	return false
+}
+
+func isChan(t types.Type) bool {
+	_, ok := t.Underlying().(*types.Chan)
+	return ok
+}
+
+func containsVar(vars []*types.Var, v *types.Var) bool {
+	for _, x := range vars {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
+
+// -- select --
+
+// checkLoopSelect reports a select statement, at cursor cur, without a
+// default case within an infinite loop of a function with a context
+// parameter, if neither the select nor the loop consults the context.
+func checkLoopSelect(pass *analysis.Pass, reportf reportFunc, cur inspector.Cursor, sel *ast.SelectStmt) {
+	// Find the innermost enclosing loop, which must be infinite.
+	var loop *ast.ForStmt
+	for c := range cur.Enclosing((*ast.ForStmt)(nil), (*ast.RangeStmt)(nil), (*ast.FuncLit)(nil), (*ast.FuncDecl)(nil)) {
+		loop, _ = c.Node().(*ast.ForStmt)
+		break
+	}
+	if loop == nil || loop.Cond != nil {
+		return
+	}
+
+	// Find the context parameter of an enclosing function.
+	var ctx *ast.Ident
+	for c := range cur.Enclosing((*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)) {
+		switch fn := c.Node().(type) {
+		case *ast.FuncDecl:
+			ctx = contextParam(pass.TypesInfo, fn.Type)
+		case *ast.FuncLit:
+			ctx = contextParam(pass.TypesInfo, fn.Type)
+		}
+		if ctx != nil {
+			break
+		}
+	}
+	if ctx == nil {
+		return
+	}
+
+	for _, clause := range sel.Body.List {
+		clause := clause.(*ast.CommClause)
+		if clause.Comm == nil {
+			return // default case: select does not block
+		}
+		if ch := received(clause.Comm); ch != nil && signalsTermination(ch) {
+			return // e.g. case <-s.quit
+		}
+	}
+
+	// Does the loop consult any context?
+	consults := false
+	ast.Inspect(loop.Body, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok &&
+			(sel.Sel.Name == "Done" || sel.Sel.Name == "Err") &&
+			isContext(pass.TypesInfo.TypeOf(sel.X)) {
+			consults = true
+		}
+		return !consults
+	})
+	if consults {
+		return
+	}
+
+	reportf("select", analysisinternal.Range(sel.Pos(), sel.Body.Lbrace),
+		"select in infinite loop has no case for %s.Done()", ctx.Name)
+}
+
+// received returns the channel operand of the receive
+// communication of a select case, or nil if it is a send.
+func received(comm ast.Stmt) ast.Expr {
+	var x ast.Expr
+	switch comm := comm.(type) {
+	case *ast.ExprStmt:
+		x = comm.X
+	case *ast.AssignStmt:
+		x = comm.Rhs[0]
+	}
+	if recv, ok := ast.Unparen(x).(*ast.UnaryExpr); ok && recv.Op == token.ARROW {
+		return recv.X
+	}
+	return nil
+}
+
+// signalsTermination reports whether the name of the channel
+// expression, or the method that returns it, suggests that it
+// signals termination.
+func signalsTermination(ch ast.Expr) bool {
+	var name string
+	switch ch := ast.Unparen(ch).(type) {
+	case *ast.Ident:
+		name = ch.Name
+	case *ast.SelectorExpr:
+		name = ch.Sel.Name
+	case *ast.CallExpr:
+		return signalsTermination(ch.Fun)
+	}
+	name = strings.ToLower(name)
+	for _, word := range []string{"done", "quit", "stop", "close", "exit", "shutdown", "cancel", "term"} {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// -- constructor --
+
+// shutdownMethods are the names of methods that conventionally stop
+// the goroutines started by a constructor.
+var shutdownMethods = []string{"Close", "Stop", "Shutdown", "Cancel", "Quit", "Terminate"}
+
+// checkConstructorFunc reports goroutines running infinite loops that
+// are started by a constructor function, such as NewServer, with no
+// context parameter, whose result type has no shutdown method.
+func checkConstructorFunc(pass *analysis.Pass, reportf reportFunc, decls map[*types.Func]*ast.FuncDecl, decl *ast.FuncDecl) {
+	if decl.Recv != nil || decl.Body == nil || !isConstructorName(decl.Name.Name) {
+		return
+	}
+	fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+	if !ok {
+		return
+	}
+	sig := fn.Signature()
+	if sig.Results().Len() == 0 || contextParam(pass.TypesInfo, decl.Type) != nil {
+		return
+	}
+
+	// The result type must be a named type of this package
+	// with no shutdown method.
+	t := sig.Results().At(0).Type()
+	if ptr, ok := types.Unalias(t).(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() != pass.Pkg {
+		return
+	}
+	mset := types.NewMethodSet(types.NewPointer(named))
+	for _, name := range shutdownMethods {
+		if mset.Lookup(pass.Pkg, name) != nil {
+			return
+		}
+	}
+	if _, ok := named.Underlying().(*types.Interface); ok {
+		return // methods are provided by the dynamic type
+	}
+
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false // goroutines started by a closure may be stopped elsewhere
+		case *ast.GoStmt:
+			var body *ast.BlockStmt
+			if lit, ok := ast.Unparen(n.Call.Fun).(*ast.FuncLit); ok {
+				body = lit.Body
+			} else if callee, ok := typeutil.Callee(pass.TypesInfo, n.Call).(*types.Func); ok && decls[callee] != nil {
+				body = decls[callee].Body
+			}
+			if body != nil && runsForever(pass.TypesInfo, body) {
+				reportf("constructor", n,
+					"goroutine started by %s runs forever: %s has no Close, Stop, or Shutdown method",
+					decl.Name.Name, named.Obj().Name())
+			}
+			return false
+		}
+		return true
+	})
+}
+
+// isConstructorName reports whether name is conventionally the name of
+// a constructor function, such as New, NewServer, or newServer.
+func isConstructorName(name string) bool {
+	for _, prefix := range []string{"New", "new"} {
+		if rest, ok := strings.CutPrefix(name, prefix); ok {
+			r, _ := utf8.DecodeRuneInString(rest)
+			return rest == "" || unicode.IsUpper(r)
+		}
+	}
+	return false
+}
+
+// runsForever reports whether the function body contains an
+// unconditional for loop, or a loop over a channel, with no return
+// statement, so that it may never terminate.
+func runsForever(info *types.Info, body *ast.BlockStmt) bool {
+	loops, returns := false, false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ForStmt:
+			if n.Cond == nil {
+				loops = true
+			}
+		case *ast.RangeStmt:
+			if isChan(info.TypeOf(n.X)) {
+				loops = true
+			}
+		case *ast.ReturnStmt:
+			returns = true
+		}
+		return true
+	})
+	return loops && !returns
+}
+
+// -- util --
+
+// contextParam returns the first named parameter of type
+// context.Context of the function type, or nil if there is none.
+func contextParam(info *types.Info, ftype *ast.FuncType) *ast.Ident {
+	for _, field := range ftype.Params.List {
+		if isContext(info.TypeOf(field.Type)) {
+			for _, name := range field.Names {
+				if name.Name != "_" {
+					return name
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// isContext reports whether t is context.Context.
+func isContext(t types.Type) bool {
+	return analysisinternal.IsTypeNamed(t, "context", "Context")
+}
@@ -0,0 +1,226 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// -- unmatched --
+
+func compute() int { return 0 }
+
+func timeout() (int, error) {
+	ch := make(chan int)
+	go func() {
+		ch <- compute() // want "goroutine may block forever sending to unbuffered channel ch: the receive is in a select statement that may choose another case"
+	}()
+	select {
+	case r := <-ch:
+		return r, nil
+	case <-time.After(time.Second):
+		return 0, errors.New("timeout")
+	}
+}
+
+func buffered() (int, error) {
+	ch := make(chan int, 1)
+	go func() {
+		ch <- compute() // ok: buffered
+	}()
+	select {
+	case r := <-ch:
+		return r, nil
+	case <-time.After(time.Second):
+		return 0, errors.New("timeout")
+	}
+}
+
+func neverReceived() {
+	ch := make(chan int, 0)
+	go func() {
+		ch <- compute() // want "goroutine blocks forever sending to unbuffered channel ch: there is no matching operation"
+	}()
+}
+
+func neverSent() {
+	ch := make(chan int)
+	go func() {
+		for x := range ch { // want "goroutine blocks forever receiving from unbuffered channel ch: there is no matching operation"
+			print(x)
+		}
+	}()
+}
+
+func closed() {
+	ch := make(chan int)
+	go func() {
+		for x := range ch { // ok: closed
+			print(x)
+		}
+	}()
+	close(ch)
+}
+
+func received() int {
+	ch := make(chan int)
+	go func() {
+		ch <- compute() // ok: received
+	}()
+	return <-ch
+}
+
+func escapes() {
+	ch := make(chan int)
+	go func() {
+		ch <- compute() // ok: ch escapes
+	}()
+	consume(ch)
+}
+
+func consume(chan int) {}
+
+// -- select --
+
+type worker struct {
+	jobs chan int
+	quit chan struct{}
+}
+
+func (w *worker) run(ctx context.Context) {
+	for {
+		select { // want "select in infinite loop has no case for ctx.Done()"
+		case job := <-w.jobs:
+			print(job)
+		}
+	}
+}
+
+func (w *worker) runDone(ctx context.Context) {
+	for {
+		select {
+		case job := <-w.jobs:
+			print(job)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (w *worker) runQuit(ctx context.Context) {
+	for {
+		select {
+		case job := <-w.jobs:
+			print(job)
+		case <-w.quit:
+			return
+		}
+	}
+}
+
+func (w *worker) runErr(ctx context.Context) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+		select {
+		case job := <-w.jobs:
+			print(job)
+		}
+	}
+}
+
+func (w *worker) runDefault(ctx context.Context) {
+	for {
+		select {
+		case job := <-w.jobs:
+			print(job)
+		default:
+		}
+	}
+}
+
+func (w *worker) runNoContext() {
+	for {
+		select {
+		case job := <-w.jobs:
+			print(job)
+		}
+	}
+}
+
+func (w *worker) runBounded(ctx context.Context) {
+	for i := 0; i < 10; i++ {
+		select {
+		case job := <-w.jobs:
+			print(job)
+		}
+	}
+}
+
+// -- constructor --
+
+type Cache struct{ data map[string]string }
+
+func (c *Cache) evictLoop() {
+	for {
+		time.Sleep(time.Minute)
+		c.data = nil
+	}
+}
+
+func NewCache() *Cache {
+	c := &Cache{}
+	go c.evictLoop() // want "goroutine started by NewCache runs forever: Cache has no Close, Stop, or Shutdown method"
+	return c
+}
+
+func newCache() *Cache {
+	c := &Cache{}
+	go func() { // want "goroutine started by newCache runs forever: Cache has no Close, Stop, or Shutdown method"
+		for range time.Tick(time.Minute) {
+			c.data = nil
+		}
+	}()
+	return c
+}
+
+func NewCacheContext(ctx context.Context) *Cache {
+	c := &Cache{}
+	go c.evictLoop() // ok: ctx parameter
+	return c
+}
+
+type Server struct{ quit chan struct{} }
+
+func (s *Server) Close() { close(s.quit) }
+
+func (s *Server) loop() {
+	for {
+		<-s.quit
+	}
+}
+
+func NewServer() *Server {
+	s := &Server{}
+	go s.loop() // ok: Close method
+	return s
+}
+
+func NewOnce() *Cache {
+	c := &Cache{}
+	go func() { // ok: no loop
+		c.data = nil
+	}()
+	return c
+}
+
+func Newsletter() *Cache {
+	c := &Cache{}
+	go c.evictLoop() // ok: not a constructor name
+	return c
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package hardcodedcreds defines an Analyzer that reports credentials
+// written in source code.
+//
+// # Analyzer hardcodedcreds
+//
+// hardcodedcreds: check for hardcoded credentials
+//
+// Credentials in source code are exposed to anyone who can read the
+// code, and cannot be rotated without changing it. This analyzer
+// reports string literals that appear to be credentials:
+//
+//   - a literal assigned to, compared with, or passed as a parameter
+//     whose name suggests a credential, such as password, secret,
+//     token, or apiKey:
+//
+//     const apiKey = "d41d8cd98f00b204e980" // "possible hardcoded credential in apiKey"
+//
+//   - a literal containing an AWS access key ID, such as AKIA followed
+//     by 16 letters or digits;
+//
+//   - a literal containing a PEM-encoded private key.
+//
+// The analysis is a heuristic. To reduce false positives, it does not
+// report a literal assigned to a name that also suggests it is not
+// the credential itself, such as tokenURL, passwordFile, or secretName,
+// nor a literal that is short, contains spaces or format verbs, looks
+// like the name of an environment variable, or names a credential,
+// such as "password" or "X-Api-Token".
+//
+// The -allowlist flag names a file of patterns for files whose
+// literals are not reported, such as known test fixtures. Each line
+// of the file, other than blank lines and comments beginning with #,
+// is a slash-separated pattern in the syntax of path.Match, which is
+// matched against the trailing elements of the file's path:
+//
+//	# Test certificates.
+//	internal/testcerts/*.go
+//	*_test.go
+package hardcodedcreds
@@ -0,0 +1,306 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package hardcodedcreds defines an Analyzer that reports credentials
+// written in source code.
+package hardcodedcreds
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/analysis/passes/internal/analysisutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// NOTE: Experimental. Not part of the vet suite.
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "hardcodedcreds",
+	Doc:      analysisutil.MustExtractDoc(doc, "hardcodedcreds"),
+	URL:      "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/hardcodedcreds",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+var allowlist string // -allowlist flag
+
+func init() {
+	Analyzer.Flags.StringVar(&allowlist, "allowlist", "", "file of path patterns for files whose literals are not reported, such as test fixtures")
+}
+
+var (
+	awsKeyRE     = regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)
+	privateKeyRE = regexp.MustCompile(`-----BEGIN ([A-Z]+ )*PRIVATE KEY-----`)
+	envNameRE    = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+)
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	var patterns []string
+	if allowlist != "" {
+		var err error
+		patterns, err = readAllowlist(allowlist)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for cur := range inspect.Root().Preorder((*ast.File)(nil)) {
+		file := cur.Node().(*ast.File)
+		if allowed(pass.Fset.File(file.FileStart).Name(), patterns) {
+			continue
+		}
+		for cur := range cur.Preorder((*ast.BasicLit)(nil)) {
+			lit := cur.Node().(*ast.BasicLit)
+			if lit.Kind != token.STRING {
+				continue
+			}
+			tv := pass.TypesInfo.Types[lit]
+			if tv.Value == nil || tv.Value.Kind() != constant.String {
+				continue
+			}
+			value := constant.StringVal(tv.Value)
+
+			switch {
+			case privateKeyRE.MatchString(value):
+				pass.ReportRangef(lit, "string literal contains a PEM-encoded private key")
+			case awsKeyRE.MatchString(value):
+				pass.ReportRangef(lit, "string literal contains an AWS access key ID")
+			case isSecretValue(value):
+				if name := credentialName(pass.TypesInfo, cur); name != "" {
+					pass.ReportRangef(lit, "possible hardcoded credential in %s", name)
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// credentialName returns the name associated with the literal at cur,
+// if it suggests a credential: the name of a variable, constant, or
+// field to which it is assigned, or with which it is compared, or of
+// the parameter to which it is passed.
+func credentialName(info *types.Info, cur inspector.Cursor) string {
+	lit := cur.Node().(ast.Expr)
+	for {
+		if _, ok := cur.Parent().Node().(*ast.ParenExpr); !ok {
+			break
+		}
+		cur = cur.Parent()
+	}
+	var names []string
+	switch parent := cur.Parent().Node().(type) {
+	case *ast.ValueSpec:
+		for i, v := range parent.Values {
+			if ast.Unparen(v) == lit && i < len(parent.Names) {
+				names = append(names, parent.Names[i].Name)
+			}
+		}
+	case *ast.AssignStmt:
+		if len(parent.Lhs) == len(parent.Rhs) {
+			for i, v := range parent.Rhs {
+				if ast.Unparen(v) == lit {
+					names = append(names, exprName(info, parent.Lhs[i]))
+				}
+			}
+		}
+	case *ast.KeyValueExpr:
+		if ast.Unparen(parent.Value) == lit {
+			names = append(names, exprName(info, parent.Key))
+		}
+	case *ast.BinaryExpr:
+		if parent.Op == token.EQL || parent.Op == token.NEQ {
+			names = append(names, exprName(info, parent.X), exprName(info, parent.Y))
+		}
+	case *ast.CallExpr:
+		if callee := typeutil.Callee(info, parent); callee != nil {
+			if sig, ok := callee.Type().(*types.Signature); ok {
+				params := sig.Params()
+				for i, arg := range parent.Args {
+					if ast.Unparen(arg) != lit {
+						continue
+					}
+					if sig.Variadic() && i >= params.Len()-1 {
+						i = params.Len() - 1
+					}
+					if i < params.Len() {
+						names = append(names, params.At(i).Name())
+					}
+				}
+			}
+		}
+	}
+	for _, name := range names {
+		if isCredentialName(name) {
+			return name
+		}
+	}
+	return ""
+}
+
+// exprName returns the name denoted by e: an identifier, the selected
+// name of a selector, or the constant string key of an index
+// expression or map literal.
+func exprName(info *types.Info, e ast.Expr) string {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.IndexExpr:
+		return exprName(info, e.Index)
+	case *ast.BasicLit:
+		if tv := info.Types[e]; tv.Value != nil && tv.Value.Kind() == constant.String {
+			return constant.StringVal(tv.Value)
+		}
+	}
+	return ""
+}
+
+// Words of a name that suggest a credential, when joined.
+var credentialWords = []string{"password", "passwd", "pwd", "secret", "token", "apikey", "privatekey", "accesskey", "credential", "credentials"}
+
+// Words of a name that suggest that its value is not the credential
+// itself, but its name, location, or some other property.
+var otherWords = map[string]bool{
+	"count": true, "dir": true, "endpoint": true, "env": true, "field": true,
+	"file": true, "format": true, "header": true, "id": true, "kind": true,
+	"len": true, "length": true, "name": true, "param": true, "path": true,
+	"prefix": true, "suffix": true, "type": true, "uri": true, "url": true,
+	"var": true,
+}
+
+// isCredentialName reports whether a name, such as apiKey or
+// DB_PASSWORD, suggests a credential.
+func isCredentialName(name string) bool {
+	words := splitWords(name)
+	for _, w := range words {
+		if otherWords[w] {
+			return false
+		}
+	}
+	// Match a credential word against one word, or two
+	// adjacent words, such as "api" and "key".
+	for i := range words {
+		for _, cw := range credentialWords {
+			if words[i] == cw || i+1 < len(words) && words[i]+words[i+1] == cw {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// splitWords splits a name such as "apiKey", "APIKey", "api_key", or
+// "X-Api-Key" into its lower-case words.
+func splitWords(name string) []string {
+	var (
+		words []string
+		word  []rune
+	)
+	runes := []rune(name)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(word) > 0 {
+				words = append(words, string(word))
+				word = nil
+			}
+			continue
+		}
+		// A word starts at an upper-case letter that follows a
+		// lower-case letter, as in apiKey, or that precedes one
+		// after an upper-case letter, as in APIKey.
+		if unicode.IsUpper(r) && len(word) > 0 && i > 0 {
+			prev := runes[i-1]
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) ||
+				unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+				words = append(words, string(word))
+				word = nil
+			}
+		}
+		word = append(word, unicode.ToLower(r))
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	return words
+}
+
+// isSecretValue reports whether a string could be a credential, as
+// opposed to an empty or placeholder value, a message, a format, a
+// URL or path, or the name of an environment variable or credential.
+func isSecretValue(s string) bool {
+	if len(s) < 6 ||
+		strings.ContainsFunc(s, unicode.IsSpace) ||
+		strings.ContainsAny(s, "%{") ||
+		strings.Contains(s, "://") ||
+		strings.HasPrefix(s, "/") ||
+		envNameRE.MatchString(s) ||
+		strings.Count(s, s[:1]) == len(s) { // e.g. "xxxxxx"
+		return false
+	}
+	joined := strings.Join(splitWords(s), "")
+	for _, cw := range credentialWords {
+		if strings.Contains(joined, cw) {
+			return false // e.g. "password" or "X-Api-Token"
+		}
+	}
+	return true
+}
+
+// readAllowlist returns the patterns of the allowlist file.
+func readAllowlist(filename string) ([]string, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("reading allowlist: %v", err)
+	}
+	var patterns []string
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; sc.Scan(); line++ {
+		pattern := strings.TrimSpace(sc.Text())
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid pattern %q: %v", filename, line, pattern, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// allowed reports whether a pattern matches the trailing elements of
+// the file name.
+func allowed(filename string, patterns []string) bool {
+	elems := strings.Split(filepath.ToSlash(filename), "/")
+	for _, pattern := range patterns {
+		n := strings.Count(pattern, "/") + 1
+		if n <= len(elems) {
+			if ok, _ := path.Match(pattern, strings.Join(elems[len(elems)-n:], "/")); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hardcodedcreds_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/analysis/passes/hardcodedcreds"
+)
+
+func Test(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), hardcodedcreds.Analyzer, "a")
+}
+
+func TestAllowlist(t *testing.T) {
+	testdata := analysistest.TestData()
+	if err := hardcodedcreds.Analyzer.Flags.Set("allowlist", filepath.Join(testdata, "allowlist")); err != nil {
+		t.Fatal(err)
+	}
+	defer hardcodedcreds.Analyzer.Flags.Set("allowlist", "")
+	analysistest.Run(t, testdata, hardcodedcreds.Analyzer, "b", "b/fixtures")
+}
@@ -9,6 +9,7 @@ package httpresponse
 import (
 	"go/ast"
 	"go/types"
+	"slices"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
@@ -31,7 +32,24 @@ determines whether the response is valid:
 	// (defer statement belongs here)
 
 This checker helps uncover latent nil dereference bugs by reporting a
-diagnostic for such mistakes.`
+diagnostic for such mistakes.
+
+The checker also reports response bodies that are never closed, which
+prevents the underlying connection from being reused and may exhaust
+the connection pool:
+
+	resp, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(resp.Body) // (resp.Body.Close is never called)
+
+A body is considered closed elsewhere if the response is returned,
+stored, or passed to a function, or if the body is passed to a
+function parameter of a type with a Close method, such as io.ReadCloser.
+
+Finally, the checker reports uses of a response body after a statement
+that closes it, such as reading the body after calling resp.Body.Close.`
 
 var Analyzer = &analysis.Analyzer{
 	Name:     "httpresponse",
@@ -100,9 +118,187 @@ func run(pass *analysis.Pass) (any, error) {
 		}
 		return true
 	})
+
+	// Check the bodies of the responses assigned to local variables.
+	for cur := range inspect.Root().Preorder((*ast.AssignStmt)(nil)) {
+		asg := cur.Node().(*ast.AssignStmt)
+		if len(asg.Rhs) != 1 {
+			continue
+		}
+		call, ok := asg.Rhs[0].(*ast.CallExpr)
+		if !ok || !isHTTPFuncOrMethodOnClient(pass.TypesInfo, call) {
+			continue
+		}
+		id, ok := asg.Lhs[0].(*ast.Ident)
+		if !ok {
+			continue
+		}
+		resp, ok := pass.TypesInfo.ObjectOf(id).(*types.Var)
+		if !ok {
+			continue
+		}
+		for curFunc := range cur.Enclosing((*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)) {
+			var body *ast.BlockStmt
+			switch n := curFunc.Node().(type) {
+			case *ast.FuncDecl:
+				body = n.Body
+			case *ast.FuncLit:
+				body = n.Body
+			}
+			if resp.Pos() < body.Pos() || resp.Pos() >= body.End() {
+				break // not a local variable
+			}
+			curBody, _ := curFunc.FindNode(body)
+			checkBody(pass, curBody, asg, resp)
+			break
+		}
+	}
 	return nil, nil
 }
 
+// checkBody reports whether the body of the response held by the local
+// variable resp, assigned by asg, is never closed within the function
+// body at curBody, or is used after a statement that closes it.
+func checkBody(pass *analysis.Pass, curBody inspector.Cursor, asg *ast.AssignStmt, resp *types.Var) {
+	info := pass.TypesInfo
+
+	// Classify the uses of the variable.
+	closed, escapes := false, false
+	for cur := range curBody.Preorder((*ast.Ident)(nil)) {
+		id := cur.Node().(*ast.Ident)
+		if info.Uses[id] != resp {
+			continue
+		}
+		switch parent := cur.Parent().Node().(type) {
+		case *ast.SelectorExpr: // resp.f
+			if parent.Sel.Name != "Body" {
+				continue // another field, such as resp.StatusCode
+			}
+			switch grandparent := cur.Parent().Parent().Node().(type) {
+			case *ast.SelectorExpr: // resp.Body.f
+				if grandparent.Sel.Name == "Close" {
+					closed = true
+				}
+			case *ast.CallExpr: // f(resp.Body)
+				if grandparent.Fun == parent {
+					break
+				}
+				sig, ok := info.TypeOf(grandparent.Fun).(*types.Signature)
+				if !ok || hasClose(paramType(sig, grandparent, parent)) {
+					escapes = true // the callee may close the body
+				}
+			default:
+				escapes = true // e.g. body := resp.Body
+			}
+		case *ast.BinaryExpr: // resp != nil
+		case *ast.AssignStmt:
+			if !slices.Contains(parent.Lhs, ast.Expr(id)) {
+				escapes = true
+			}
+		default:
+			escapes = true // returned, stored, or passed to a function
+		}
+	}
+	if !closed && !escapes {
+		pass.ReportRangef(asg.Lhs[0], "%s.Body is never closed", resp.Name())
+	}
+
+	// Report the first use of the body after a
+	// statement that closes it in the same block.
+	for cur := range curBody.Preorder((*ast.ExprStmt)(nil)) {
+		stmt := cur.Node().(*ast.ExprStmt)
+		call, ok := stmt.X.(*ast.CallExpr)
+		if !ok || !isBodyMethod(info, call.Fun, resp, "Close") {
+			continue
+		}
+		for next, ok := cur.NextSibling(); ok; next, ok = next.NextSibling() {
+			if use := bodyUse(info, next, resp); use != nil {
+				pass.ReportRangef(use, "%s.Body used after being closed", resp.Name())
+				break
+			}
+			if assigns(info, next.Node(), resp) {
+				break
+			}
+		}
+	}
+}
+
+// bodyUse returns the first use of resp.Body within the statement at
+// cur, other than calls to Close, or nil if there is none. It ignores
+// function literals, which may be called later.
+func bodyUse(info *types.Info, cur inspector.Cursor, resp *types.Var) ast.Node {
+	var use ast.Node
+	cur.Inspect([]ast.Node{(*ast.FuncLit)(nil), (*ast.SelectorExpr)(nil)}, func(c inspector.Cursor) bool {
+		if use != nil {
+			return false
+		}
+		switch n := c.Node().(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.SelectorExpr:
+			if isBodyMethod(info, n, resp, "Close") {
+				return false
+			}
+			if id, ok := n.X.(*ast.Ident); ok && info.Uses[id] == resp && n.Sel.Name == "Body" {
+				use = n
+			}
+		}
+		return true
+	})
+	return use
+}
+
+// isBodyMethod reports whether e is resp.Body.name.
+func isBodyMethod(info *types.Info, e ast.Expr, resp *types.Var, name string) bool {
+	sel, ok := e.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != name {
+		return false
+	}
+	body, ok := sel.X.(*ast.SelectorExpr)
+	if !ok || body.Sel.Name != "Body" {
+		return false
+	}
+	id, ok := body.X.(*ast.Ident)
+	return ok && info.Uses[id] == resp
+}
+
+// assigns reports whether the statement n assigns to the variable v.
+func assigns(info *types.Info, n ast.Node, v *types.Var) bool {
+	found := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		if asg, ok := n.(*ast.AssignStmt); ok {
+			for _, lhs := range asg.Lhs {
+				if id, ok := lhs.(*ast.Ident); ok && info.ObjectOf(id) == v {
+					found = true
+				}
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// paramType returns the type of the parameter of the call with
+// signature sig that corresponds to the argument arg.
+func paramType(sig *types.Signature, call *ast.CallExpr, arg ast.Expr) types.Type {
+	i := slices.Index(call.Args, arg)
+	params := sig.Params()
+	if sig.Variadic() && i >= params.Len()-1 {
+		if call.Ellipsis.IsValid() {
+			return params.At(params.Len() - 1).Type()
+		}
+		return params.At(params.Len() - 1).Type().(*types.Slice).Elem()
+	}
+	return params.At(i).Type()
+}
+
+// hasClose reports whether type t has a Close method.
+func hasClose(t types.Type) bool {
+	obj, _, _ := types.LookupFieldOrMethod(t, true, nil, "Close")
+	_, ok := obj.(*types.Func)
+	return ok
+}
+
 // isHTTPFuncOrMethodOnClient checks whether the given call expression is on
 // either a function of the net/http package or a method of http.Client that
 // returns (*http.Response, error).
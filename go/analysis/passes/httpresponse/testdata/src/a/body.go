@@ -0,0 +1,103 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a
+
+import (
+	"io"
+	"net/http"
+)
+
+func neverClosed(url string) ([]byte, error) {
+	resp, err := http.Get(url) // want "resp.Body is never closed"
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func closed(url string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	return data, err
+}
+
+func deferredFunc(c *http.Client, req *http.Request) error {
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+	return nil
+}
+
+func returned(url string) (*http.Response, error) {
+	resp, err := http.Get(url) // ok: returned
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func passedBody(url string) error {
+	resp, err := http.Get(url) // ok: the body is passed to drain, which may close it
+	if err != nil {
+		return err
+	}
+	return drain(resp.Body)
+}
+
+func drain(rc io.ReadCloser) error { return rc.Close() }
+
+func storedBody(url string) io.Reader {
+	resp, err := http.Get(url) // ok: the body escapes
+	if err != nil {
+		return nil
+	}
+	body := resp.Body
+	return body
+}
+
+func useAfterClose(url string) ([]byte, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return io.ReadAll(resp.Body) // want "resp.Body used after being closed"
+}
+
+func closeTwice(url string) {
+	resp, err := http.Head(url)
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+	resp.Body.Close() // ok: not a use
+	_ = resp.StatusCode
+}
+
+func reassigned(c *http.Client, urls []string) {
+	resp, err := c.Get(urls[0])
+	if err != nil {
+		return
+	}
+	resp.Body.Close()
+	resp, err = c.Get(urls[1])
+	if err != nil {
+		return
+	}
+	io.ReadAll(resp.Body) // ok: another response
+	resp.Body.Close()
+}
@@ -0,0 +1,33 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package ineffassign defines an Analyzer that reports assignments
+// to local variables whose values are never read.
+//
+// # Analyzer ineffassign
+//
+// ineffassign: check for ineffectual assignments to local variables
+//
+// This analyzer reports assignments to a local variable or parameter
+// whose value is not read on any path before the variable is assigned
+// again or the function returns. Such an assignment usually indicates
+// a lost write, such as an error that is overwritten before it is
+// checked:
+//
+//	a, err := f()
+//	b, err := g() // the error from f is never checked
+//	if err != nil {
+//		return err
+//	}
+//
+// The shadow analyzer reports the related mistake of a write to a
+// variable that is shadowed by another of the same name.
+//
+// To avoid false positives, the analyzer ignores variables whose
+// address is taken, variables that are referenced by a function
+// literal, named results, and the variables of range and select
+// statements. It also ignores assignments of zero values, such as
+// nil, 0, "", or T{}, which are often used to clear a variable or to
+// declare it in advance of conditional assignments.
+package ineffassign
@@ -0,0 +1,326 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package ineffassign defines an Analyzer that reports assignments
+// to local variables whose values are never read.
+package ineffassign
+
+import (
+	_ "embed"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/ctrlflow"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/analysis/passes/internal/analysisutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/cfg"
+)
+
+// NOTE: Experimental. Not part of the vet suite.
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name: "ineffassign",
+	Doc:  analysisutil.MustExtractDoc(doc, "ineffassign"),
+	URL:  "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/ineffassign",
+	Run:  run,
+	Requires: []*analysis.Analyzer{
+		inspect.Analyzer,
+		ctrlflow.Analyzer,
+	},
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	cfgs := pass.ResultOf[ctrlflow.Analyzer].(*ctrlflow.CFGs)
+
+	for cur := range inspect.Root().Preorder((*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)) {
+		var (
+			ftype *ast.FuncType
+			body  *ast.BlockStmt
+			g     *cfg.CFG
+		)
+		switch n := cur.Node().(type) {
+		case *ast.FuncDecl:
+			ftype, body = n.Type, n.Body
+			if body != nil {
+				g = cfgs.FuncDecl(n)
+			}
+		case *ast.FuncLit:
+			ftype, body = n.Type, n.Body
+			g = cfgs.FuncLit(n)
+		}
+		if g != nil {
+			runFunc(pass, ftype, body, g)
+		}
+	}
+	return nil, nil
+}
+
+// A varSet is a set of local variables.
+type varSet map[*types.Var]bool
+
+// An assignment is an assignment to a variable within a CFG node.
+type assignment struct {
+	id   *ast.Ident
+	v    *types.Var
+	zero bool // the value is a zero value, which is not reported
+	use  bool // the assignment also reads the variable, as in x += 1
+}
+
+// runFunc reports the ineffectual assignments of a single function.
+func runFunc(pass *analysis.Pass, ftype *ast.FuncType, body *ast.BlockStmt, g *cfg.CFG) {
+	info := pass.TypesInfo
+	vars := localVars(info, ftype, body)
+	if len(vars) == 0 {
+		return
+	}
+
+	// effects returns the assignments and the uses of
+	// tracked variables within a CFG node.
+	effects := func(n ast.Node) ([]assignment, []*types.Var) {
+		var (
+			assigns []assignment
+			lhs     = make(map[*ast.Ident]bool)
+			uses    []*types.Var
+		)
+		assign := func(id *ast.Ident, zero, use bool) {
+			if v, ok := info.ObjectOf(id).(*types.Var); ok && vars[v] {
+				lhs[id] = true
+				assigns = append(assigns, assignment{id, v, zero, use})
+			}
+		}
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for i, e := range n.Lhs {
+				if id, ok := ast.Unparen(e).(*ast.Ident); ok {
+					zero := len(n.Lhs) == len(n.Rhs) && isZero(info, n.Rhs[i])
+					assign(id, zero, n.Tok != token.ASSIGN && n.Tok != token.DEFINE)
+				}
+			}
+		case *ast.IncDecStmt:
+			if id, ok := ast.Unparen(n.X).(*ast.Ident); ok {
+				assign(id, false, true)
+			}
+		case *ast.ValueSpec:
+			for i, id := range n.Names {
+				// A declaration without values is an implicit
+				// assignment of the zero value.
+				zero := len(n.Values) == 0 || len(n.Names) == len(n.Values) && isZero(info, n.Values[i])
+				assign(id, zero, false)
+			}
+		}
+		for i := range assigns {
+			if assigns[i].use {
+				uses = append(uses, assigns[i].v)
+			}
+		}
+		ast.Inspect(n, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				return false // references are excluded
+			case *ast.Ident:
+				if v, ok := info.Uses[n].(*types.Var); ok && vars[v] && !lhs[n] {
+					uses = append(uses, v)
+				}
+			}
+			return true
+		})
+		return assigns, uses
+	}
+
+	// transfer updates the set of live variables
+	// backwards across the effects of a node.
+	transfer := func(live varSet, assigns []assignment, uses []*types.Var) {
+		for _, a := range assigns {
+			delete(live, a.v)
+		}
+		for _, v := range uses {
+			live[v] = true
+		}
+	}
+
+	// Compute the live variables on entry to each block,
+	// iterating to a fixed point.
+	liveIn := make(map[*cfg.Block]varSet)
+	liveOut := func(b *cfg.Block) varSet {
+		live := make(varSet)
+		for _, succ := range b.Succs {
+			for v := range liveIn[succ] {
+				live[v] = true
+			}
+		}
+		return live
+	}
+	for changed := true; changed; {
+		changed = false
+		for i := len(g.Blocks) - 1; i >= 0; i-- {
+			b := g.Blocks[i]
+			if !b.Live {
+				continue
+			}
+			live := liveOut(b)
+			for j := len(b.Nodes) - 1; j >= 0; j-- {
+				assigns, uses := effects(b.Nodes[j])
+				transfer(live, assigns, uses)
+			}
+			if len(live) != len(liveIn[b]) {
+				liveIn[b] = live
+				changed = true
+			}
+		}
+	}
+
+	// Report assignments to variables that are not live afterwards.
+	for _, b := range g.Blocks {
+		if !b.Live {
+			continue
+		}
+		live := liveOut(b)
+		for j := len(b.Nodes) - 1; j >= 0; j-- {
+			assigns, uses := effects(b.Nodes[j])
+			for _, a := range assigns {
+				if !live[a.v] && !a.zero {
+					pass.ReportRangef(a.id, "ineffectual assignment to %s: the value is never read", a.id.Name)
+				}
+			}
+			transfer(live, assigns, uses)
+		}
+	}
+}
+
+// localVars returns the variables of the function that are subject
+// to analysis: its parameters and local variables, other than those
+// whose address is taken, that are referenced by a function literal,
+// or that are assigned by a range or select statement.
+func localVars(info *types.Info, ftype *ast.FuncType, body *ast.BlockStmt) varSet {
+	vars := make(varSet)
+	add := func(id *ast.Ident) {
+		if v, ok := info.Defs[id].(*types.Var); ok && !v.IsField() && id.Name != "_" {
+			vars[v] = true
+		}
+	}
+	for _, field := range ftype.Params.List {
+		for _, id := range field.Names {
+			add(id)
+		}
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false // variables of another function
+		case *ast.Ident:
+			add(n)
+		}
+		return true
+	})
+
+	exclude := func(e ast.Expr) {
+		if id := rootIdent(e); id != nil {
+			if v, ok := info.ObjectOf(id).(*types.Var); ok {
+				delete(vars, v)
+			}
+		}
+	}
+	var inspect func(n ast.Node, lit bool)
+	inspect = func(n ast.Node, lit bool) {
+		ast.Inspect(n, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				inspect(n.Body, true)
+				return false
+
+			case *ast.Ident:
+				if lit {
+					exclude(n) // referenced by a function literal
+				}
+
+			case *ast.UnaryExpr:
+				if n.Op == token.AND {
+					exclude(n.X)
+				}
+
+			case *ast.SelectorExpr:
+				// A call to a method with a pointer receiver
+				// implicitly takes the address of its operand.
+				if sel, ok := info.Selections[n]; ok && sel.Kind() != types.FieldVal {
+					if _, ok := sel.Recv().Underlying().(*types.Pointer); !ok {
+						if _, ok := sel.Obj().(*types.Func).Signature().Recv().Type().Underlying().(*types.Pointer); ok {
+							exclude(n.X)
+						}
+					}
+				}
+
+			case *ast.SliceExpr:
+				if _, ok := info.TypeOf(n.X).Underlying().(*types.Array); ok {
+					exclude(n.X)
+				}
+
+			case *ast.RangeStmt:
+				if n.Key != nil {
+					exclude(n.Key)
+				}
+				if n.Value != nil {
+					exclude(n.Value)
+				}
+
+			case *ast.CommClause:
+				if assign, ok := n.Comm.(*ast.AssignStmt); ok {
+					for _, e := range assign.Lhs {
+						exclude(e)
+					}
+				}
+			}
+			return true
+		})
+	}
+	inspect(body, false)
+	return vars
+}
+
+// rootIdent returns the variable identifier at the root of an
+// addressable expression such as x, x.f, or x[i], or nil.
+func rootIdent(e ast.Expr) *ast.Ident {
+	for {
+		switch x := ast.Unparen(e).(type) {
+		case *ast.Ident:
+			return x
+		case *ast.SelectorExpr:
+			e = x.X
+		case *ast.IndexExpr:
+			e = x.X
+		default:
+			return nil
+		}
+	}
+}
+
+// isZero reports whether e is the zero value of its type, such as
+// nil, 0, "", false, or an empty composite literal.
+func isZero(info *types.Info, e ast.Expr) bool {
+	if lit, ok := ast.Unparen(e).(*ast.CompositeLit); ok {
+		return len(lit.Elts) == 0
+	}
+	tv := info.Types[e]
+	if tv.IsNil() {
+		return true
+	}
+	if tv.Value != nil {
+		switch tv.Value.Kind() {
+		case constant.Bool:
+			return !constant.BoolVal(tv.Value)
+		case constant.String:
+			return constant.StringVal(tv.Value) == ""
+		case constant.Int, constant.Float, constant.Complex:
+			return constant.Sign(tv.Value) == 0
+		}
+	}
+	return false
+}
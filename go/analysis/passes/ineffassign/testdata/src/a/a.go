@@ -0,0 +1,202 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a
+
+import (
+	"errors"
+	"fmt"
+)
+
+func f() (int, error) { return 0, nil }
+func g() (int, error) { return 0, errors.New("g") }
+
+func lostError() error {
+	a, err := f() // want "ineffectual assignment to err: the value is never read"
+	b, err := g()
+	if err != nil {
+		return err
+	}
+	fmt.Println(a, b)
+	return nil
+}
+
+func overwritten() int {
+	x := 1 // want "ineffectual assignment to x"
+	x = 2
+	return x
+}
+
+func lastWrite(x int) {
+	fmt.Println(x)
+	x = 3 // want "ineffectual assignment to x"
+}
+
+func param(x int) int {
+	x = 4 // want "ineffectual assignment to x"
+	x = 5
+	return x
+}
+
+func increment() int {
+	n := 0
+	n++
+	fmt.Println(n)
+	n++
+	n += 2 // want "ineffectual assignment to n"
+	return 0
+}
+
+func branches(cond bool) int {
+	x := 1
+	if cond {
+		x = 2
+	}
+	return x
+}
+
+func allBranches(cond bool) int {
+	x := 1 // want "ineffectual assignment to x"
+	if cond {
+		x = 2
+	} else {
+		x = 3
+	}
+	return x
+}
+
+func zero(cond bool) int {
+	x := 0 // ok: zero value
+	var s string
+	var p *int = nil
+	if cond {
+		x = 2
+	} else {
+		x = 3
+	}
+	s = "a"
+	p = new(int)
+	fmt.Println(s, p)
+	return x
+}
+
+func loop(n int) int {
+	sum := 1
+	for i := 0; i < n; i++ {
+		sum = sum * 2
+	}
+	prev := -1
+	for i := 0; i < n; i++ {
+		if prev == i {
+			break
+		}
+		prev = i
+	}
+	return sum
+}
+
+func loopLastValue(xs []int) {
+	last := -1
+	for _, x := range xs {
+		last = x
+	}
+	fmt.Println(last)
+
+	for _, x := range xs {
+		y := x * 2 // want "ineffectual assignment to y"
+		y = x * 3
+		fmt.Println(y)
+	}
+}
+
+func addressTaken() int {
+	x := 1 // ok: address taken
+	p := &x
+	x = 2
+	return *p
+}
+
+func closure() func() int {
+	x := 1 // ok: referenced by a function literal
+	f := func() int { return x }
+	x = 2
+	return f
+}
+
+func funcLit() {
+	_ = func() {
+		x := 1 // want "ineffectual assignment to x"
+		x = 2
+		fmt.Println(x)
+	}
+}
+
+func namedResult() (err error) {
+	err = errors.New("a") // ok: named result
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	err = errors.New("b")
+	return
+}
+
+type T struct{ n int }
+
+func (t *T) Set(n int) { t.n = n }
+
+func pointerMethod() T {
+	var t T
+	t = T{n: 1} // ok: address taken by t.Set
+	t.Set(2)
+	return t
+}
+
+func structField() T {
+	t := T{n: 1}
+	t.n = 2
+	return t
+}
+
+func rangeVar(xs []int) int {
+	for i := range xs {
+		i = 2 // ok: range variable
+		_ = i
+	}
+	return 0
+}
+
+func swap(a, b int) (int, int) {
+	a, b = b, a
+	return a, b
+}
+
+func unreachable() int {
+	x := 1
+	return x
+	x = 2 // ok: unreachable
+	return x
+}
+
+func switchStmt(k int) string {
+	s := "default" // want "ineffectual assignment to s"
+	switch k {
+	case 1:
+		s = "one"
+	default:
+		s = "other"
+	}
+	return s
+}
+
+func gotoLoop() int {
+	i := 0
+loop:
+	if i < 10 {
+		i++
+		goto loop
+	}
+	return i
+}
@@ -0,0 +1,33 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package infinite defines an Analyzer that reports trivially infinite
+// loops and unconditional recursion.
+//
+// # Analyzer infinite
+//
+// infinite: check for infinite loops and unconditional recursion
+//
+// The analyzer reports for loops without a condition from which there
+// is no exit, by a break, return, goto, or panic, and whose body makes
+// no function calls or channel operations, which might block or
+// terminate the program:
+//
+//	for { // "infinite loop"
+//		n++
+//	}
+//
+// Such a loop spins forever, doing nothing useful, and is almost
+// always a mistake, such as a forgotten condition or break statement.
+//
+// The analyzer also reports functions that call themselves on every
+// path through their bodies, and so never return, unless they panic:
+//
+//	func (c *Config) Timeout() time.Duration { // "infinite recursion"
+//		return c.Timeout()
+//	}
+//
+// A method is considered to call itself only if it calls the same
+// method on the same receiver variable.
+package infinite
@@ -0,0 +1,221 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package infinite defines an Analyzer that reports trivially infinite
+// loops and unconditional recursion.
+package infinite
+
+import (
+	_ "embed"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/ctrlflow"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/analysis/passes/internal/analysisutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/cfg"
+	"golang.org/x/tools/go/types/typeutil"
+	"golang.org/x/tools/internal/analysisinternal"
+)
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name: "infinite",
+	Doc:  analysisutil.MustExtractDoc(doc, "infinite"),
+	URL:  "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/infinite",
+	Run:  run,
+	Requires: []*analysis.Analyzer{
+		inspect.Analyzer,
+		ctrlflow.Analyzer,
+	},
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	cfgs := pass.ResultOf[ctrlflow.Analyzer].(*ctrlflow.CFGs)
+
+	for cur := range inspect.Root().Preorder((*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)) {
+		var g *cfg.CFG
+		switch n := cur.Node().(type) {
+		case *ast.FuncDecl:
+			if n.Body == nil {
+				continue
+			}
+			g = cfgs.FuncDecl(n)
+			checkRecursion(pass, n, g)
+		case *ast.FuncLit:
+			g = cfgs.FuncLit(n)
+		}
+		checkLoops(pass, g)
+	}
+	return nil, nil
+}
+
+// checkLoops reports the loops of a function, without a condition,
+// that have no exit and make no calls or channel operations.
+func checkLoops(pass *analysis.Pass, g *cfg.CFG) {
+	for _, b := range g.Blocks {
+		loop, ok := b.Stmt.(*ast.ForStmt)
+		if !ok || b.Kind != cfg.KindForBody || !b.Live || loop.Cond != nil {
+			continue
+		}
+		if !hasExit(b, loop) && !mayBlock(pass.TypesInfo, loop.Body) {
+			pass.ReportRangef(analysisinternal.Range(loop.For, loop.For+token.Pos(len("for"))), "infinite loop: the loop has no exit and makes no calls or channel operations")
+		}
+	}
+}
+
+// hasExit reports whether a path from the body block of the loop
+// leaves the loop or the function.
+func hasExit(body *cfg.Block, loop *ast.ForStmt) bool {
+	seen := make(map[*cfg.Block]bool)
+	var visit func(b *cfg.Block) bool
+	visit = func(b *cfg.Block) bool {
+		if seen[b] {
+			return false
+		}
+		seen[b] = true
+		switch {
+		case len(b.Succs) == 0:
+			return true // return statement, or call that does not return
+		case b.Kind == cfg.KindForDone && b.Stmt == loop:
+			return true // break
+		case b.Stmt == nil || b.Stmt.Pos() < loop.Pos() || b.Stmt.End() > loop.End():
+			return true // e.g. goto, or break of an enclosing statement
+		}
+		for _, succ := range b.Succs {
+			if visit(succ) {
+				return true
+			}
+		}
+		return false
+	}
+	return visit(body)
+}
+
+// mayBlock reports whether the loop body calls a function other than
+// a builtin, or performs a channel operation.
+func mayBlock(info *types.Info, body *ast.BlockStmt) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false // not executed unless called
+		case *ast.CallExpr:
+			tv := info.Types[n.Fun]
+			if !tv.IsType() && !tv.IsBuiltin() {
+				found = true
+			}
+		case *ast.SendStmt, *ast.SelectStmt:
+			found = true
+		case *ast.UnaryExpr:
+			if n.Op == token.ARROW {
+				found = true
+			}
+		case *ast.RangeStmt:
+			switch info.TypeOf(n.X).Underlying().(type) {
+			case *types.Chan, *types.Signature:
+				found = true // receive, or call of an iterator
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// checkRecursion reports a function that calls itself on every path
+// through its body.
+func checkRecursion(pass *analysis.Pass, decl *ast.FuncDecl, g *cfg.CFG) {
+	fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+	if !ok {
+		return
+	}
+	var recv *types.Var // receiver variable of a method
+	if decl.Recv != nil {
+		if len(decl.Recv.List) == 0 || len(decl.Recv.List[0].Names) == 0 {
+			return
+		}
+		recv, _ = pass.TypesInfo.Defs[decl.Recv.List[0].Names[0]].(*types.Var)
+		if recv == nil {
+			return
+		}
+	}
+
+	// isRecursive reports whether the call is a call of the
+	// function itself, on the same receiver for a method.
+	isRecursive := func(call *ast.CallExpr) bool {
+		callee := typeutil.StaticCallee(pass.TypesInfo, call)
+		if callee == nil || callee.Origin() != fn {
+			return false
+		}
+		if recv != nil {
+			sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+			if !ok {
+				return false
+			}
+			id, ok := ast.Unparen(sel.X).(*ast.Ident)
+			return ok && pass.TypesInfo.Uses[id] == recv
+		}
+		return true
+	}
+
+	// calls reports whether evaluating the node always
+	// calls the function.
+	var calls func(n ast.Node) bool
+	calls = func(n ast.Node) bool {
+		found := false
+		ast.Inspect(n, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncLit, *ast.GoStmt, *ast.DeferStmt:
+				return false
+			case *ast.BinaryExpr:
+				if n.Op == token.LAND || n.Op == token.LOR {
+					found = found || calls(n.X) // n.Y is conditional
+					return false
+				}
+			case *ast.CallExpr:
+				if isRecursive(n) {
+					found = true
+				}
+			}
+			return !found
+		})
+		return found
+	}
+
+	// Search for a path from the entry to a return that
+	// does not call the function.
+	seen := make(map[*cfg.Block]bool)
+	recursive := false
+	var returns func(b *cfg.Block) bool
+	returns = func(b *cfg.Block) bool {
+		if seen[b] {
+			return false
+		}
+		seen[b] = true
+		for _, n := range b.Nodes {
+			if calls(n) {
+				recursive = true
+				return false
+			}
+		}
+		if len(b.Succs) == 0 {
+			return true
+		}
+		for _, succ := range b.Succs {
+			if returns(succ) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(g.Blocks) > 0 && !returns(g.Blocks[0]) && recursive {
+		pass.ReportRangef(decl.Name, "infinite recursion: every path through %s calls itself", decl.Name.Name)
+	}
+}
@@ -0,0 +1,168 @@
+package a
+
+import "os"
+
+func loops(ch chan int, f func()) {
+	n := 0
+	for { // want `infinite loop: the loop has no exit and makes no calls or channel operations`
+		n++
+	}
+}
+
+func breaks(n int) {
+	for {
+		if n > 10 {
+			break
+		}
+		n++
+	}
+}
+
+func returns(n int) int {
+	for {
+		if n > 10 {
+			return n
+		}
+		n++
+	}
+}
+
+func calls(f func()) {
+	for {
+		f() // may block or exit
+	}
+}
+
+func receives(ch chan int) {
+	for {
+		<-ch
+	}
+}
+
+func sends(ch chan int) {
+	for {
+		ch <- 1
+	}
+}
+
+func selects() {
+	for {
+		select {}
+	}
+}
+
+func ranges(ch chan int) {
+	for {
+		for range ch {
+		}
+	}
+}
+
+func exit() {
+	for {
+		os.Exit(1)
+	}
+}
+
+func panics(n int) {
+	for {
+		panic(n)
+	}
+}
+
+func breaksOuter(n int) {
+outer:
+	for {
+		for { // ok: breaks the outer loop
+			n++
+			if n > 10 {
+				break outer
+			}
+		}
+	}
+}
+
+func gotos(n int) {
+	for {
+		if n > 10 {
+			goto done
+		}
+	}
+done:
+	for n < 10 { // ok: has a condition
+	}
+}
+
+func noExit(m map[int]int, n int, f func()) {
+	for { // want `infinite loop: the loop has no exit and makes no calls or channel operations`
+		m[n] = len(m)
+		_ = func() { f() }
+		for range 10 {
+			break
+		}
+	}
+}
+
+func literal() {
+	_ = func() {
+		for { // want `infinite loop: the loop has no exit and makes no calls or channel operations`
+		}
+	}
+}
+
+func fact(n int) int { // want `infinite recursion: every path through fact calls itself`
+	return n * fact(n-1)
+}
+
+func base(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	return n * base(n-1)
+}
+
+func both(n int) int { // want `infinite recursion: every path through both calls itself`
+	if n <= 1 {
+		return both(n + 1)
+	}
+	return both(n - 1)
+}
+
+func shortCircuit(b bool) bool {
+	return b && shortCircuit(!b)
+}
+
+func panicsOrRecurses(n int) int {
+	if n < 0 {
+		panic("negative")
+	}
+	return panicsOrRecurses(n - 1)
+}
+
+func spawn() {
+	go spawn()
+	defer spawn()
+	_ = func() { spawn() }
+}
+
+func generic[T any](x T) T { // want `infinite recursion: every path through generic calls itself`
+	return generic(x)
+}
+
+type T struct{ next *T }
+
+func (t *T) Self() int { // want `infinite recursion: every path through Self calls itself`
+	return t.Self()
+}
+
+func (t *T) Next() int {
+	return t.next.Next() // ok: another receiver
+}
+
+type I interface{ M() }
+
+type U struct{ I }
+
+func (u U) M() {
+	u.I.M() // ok: interface method
+}
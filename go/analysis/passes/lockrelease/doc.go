@@ -0,0 +1,35 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package lockrelease defines an Analyzer that checks that locked
+// mutexes are unlocked on all paths.
+//
+// # Analyzer lockrelease
+//
+// lockrelease: check that a locked mutex is unlocked on all paths
+//
+// A function that locks a sync.Mutex or sync.RWMutex and unlocks it
+// on some paths should unlock it on every path that returns or panics;
+// otherwise the next attempt to lock it blocks forever. A typical
+// mistake is an early return:
+//
+//	mu.Lock() // "mu.Lock is not followed by mu.Unlock on all paths"
+//	if len(queue) == 0 {
+//		return nil // "this return statement is reached without calling mu.Unlock"
+//	}
+//	x := queue[0]
+//	queue = queue[1:]
+//	mu.Unlock()
+//	return x
+//
+// A lock released by a deferred call, such as defer mu.Unlock(), is
+// released on all paths. Functions that lock a mutex and never unlock
+// it, such as helper functions whose purpose is to acquire a lock, are
+// not reported.
+//
+// When the Lock call is a statement of the function body itself, and
+// each Unlock call is followed only by a return statement or the end
+// of the function, the analyzer offers a fix that replaces the Unlock
+// calls by a deferred call immediately after the Lock call.
+package lockrelease
@@ -0,0 +1,333 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package lockrelease defines an Analyzer that checks that locked
+// mutexes are unlocked on all paths.
+package lockrelease
+
+import (
+	_ "embed"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/ctrlflow"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/analysis/passes/internal/analysisutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/cfg"
+	"golang.org/x/tools/go/types/typeutil"
+	"golang.org/x/tools/internal/analysisinternal"
+	"golang.org/x/tools/internal/astutil"
+)
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name: "lockrelease",
+	Doc:  analysisutil.MustExtractDoc(doc, "lockrelease"),
+	URL:  "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/lockrelease",
+	Run:  run,
+	Requires: []*analysis.Analyzer{
+		inspect.Analyzer,
+		ctrlflow.Analyzer,
+	},
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	if !analysisinternal.Imports(pass.Pkg, "sync") {
+		return nil, nil // doesn't directly import sync
+	}
+
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	cfgs := pass.ResultOf[ctrlflow.Analyzer].(*ctrlflow.CFGs)
+
+	for cur := range inspect.Root().Preorder((*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)) {
+		var (
+			body *ast.BlockStmt
+			g    *cfg.CFG
+		)
+		switch n := cur.Node().(type) {
+		case *ast.FuncDecl:
+			body = n.Body
+			if body != nil {
+				g = cfgs.FuncDecl(n)
+			}
+		case *ast.FuncLit:
+			body = n.Body
+			g = cfgs.FuncLit(n)
+		}
+		if g != nil {
+			runFunc(pass, body, g)
+		}
+	}
+	return nil, nil
+}
+
+// A lock is a call that locks a mutex, such as mu.Lock().
+type lock struct {
+	stmt   *ast.ExprStmt
+	mutex  ast.Expr // the mutex operand, e.g. mu
+	method string   // "Lock" or "RLock"
+	unlock string   // "Unlock" or "RUnlock"
+}
+
+// runFunc checks the lock statements of a single function.
+func runFunc(pass *analysis.Pass, body *ast.BlockStmt, g *cfg.CFG) {
+	// Find the lock statements of this function.
+	var locks []lock
+	analysisinternal.InspectFunc(body, func(n ast.Node) {
+		if stmt, ok := n.(*ast.ExprStmt); ok {
+			if call, ok := stmt.X.(*ast.CallExpr); ok {
+				if mutex, method := mutexCall(pass.TypesInfo, call); method == "Lock" || method == "RLock" {
+					unlock := "Unlock"
+					if method == "RLock" {
+						unlock = "RUnlock"
+					}
+					locks = append(locks, lock{stmt, mutex, method, unlock})
+				}
+			}
+		}
+	})
+
+	for _, l := range locks {
+		isUnlock := func(n ast.Node) bool { return containsUnlock(pass.TypesInfo, n, l) }
+
+		// Is the mutex unlocked by a deferred call, or never unlocked?
+		deferred := false
+		analysisinternal.InspectFunc(body, func(n ast.Node) {
+			if d, ok := n.(*ast.DeferStmt); ok {
+				if lit, ok := d.Call.Fun.(*ast.FuncLit); ok {
+					deferred = deferred || isUnlock(lit.Body)
+				} else {
+					deferred = deferred || isUnlock(d.Call)
+				}
+			}
+		})
+		if deferred || !isUnlock(body) {
+			continue
+		}
+
+		exit := unlockedExit(pass.TypesInfo, g, body, l.stmt, isUnlock)
+		if exit == nil {
+			continue
+		}
+
+		mutex := analysisinternal.Format(pass.Fset, l.mutex)
+		diag := analysis.Diagnostic{
+			Pos:     l.stmt.Pos(),
+			End:     l.stmt.End(),
+			Message: mutex + "." + l.method + " is not followed by " + mutex + "." + l.unlock + " on all paths",
+		}
+		if edits := deferUnlock(pass, body, l, mutex); edits != nil {
+			diag.SuggestedFixes = []analysis.SuggestedFix{{
+				Message:   "Use defer " + mutex + "." + l.unlock + "()",
+				TextEdits: edits,
+			}}
+		}
+		pass.Report(diag)
+
+		switch exit := exit.(type) {
+		case *ast.ReturnStmt:
+			pass.ReportRangef(exit, "this return statement is reached without calling %s.%s", mutex, l.unlock)
+		case *ast.BlockStmt:
+			pass.Reportf(exit.Rbrace, "the end of the function is reached without calling %s.%s", mutex, l.unlock)
+		default:
+			pass.ReportRangef(exit, "this panic is reached without calling %s.%s", mutex, l.unlock)
+		}
+	}
+}
+
+// unlockedExit returns the first return statement, call to panic, or
+// function body (denoting its end) that is reachable from the lock
+// statement along a path that does not unlock the mutex, or nil if
+// there is none.
+func unlockedExit(info *types.Info, g *cfg.CFG, body *ast.BlockStmt, stmt ast.Stmt, isUnlock func(ast.Node) bool) ast.Node {
+	// Find the block and index of the lock statement.
+	var start *cfg.Block
+	var index int
+	for _, b := range g.Blocks {
+		for i, n := range b.Nodes {
+			if n == stmt {
+				start, index = b, i
+			}
+		}
+	}
+	if start == nil {
+		return nil // unreachable
+	}
+
+	// Search the paths from the lock statement.
+	seen := make(map[*cfg.Block]bool)
+	var search func(b *cfg.Block, nodes []ast.Node) ast.Node
+	search = func(b *cfg.Block, nodes []ast.Node) ast.Node {
+		for _, n := range nodes {
+			if n == stmt {
+				return nil // back to the lock statement: a loop
+			}
+			if isUnlock(n) {
+				return nil // this path unlocks
+			}
+			if ret, ok := n.(*ast.ReturnStmt); ok {
+				if ret.Return == body.Rbrace {
+					return body // implicit return at end of function
+				}
+				return ret
+			}
+		}
+		if len(b.Succs) == 0 {
+			// The path leaves the function: by a call to panic
+			// or another function that does not return, such
+			// as os.Exit, or by falling off the end.
+			if len(b.Nodes) > 0 {
+				last := b.Nodes[len(b.Nodes)-1]
+				if stmt, ok := last.(*ast.ExprStmt); ok {
+					if call, ok := stmt.X.(*ast.CallExpr); ok {
+						if fn, ok := typeutil.Callee(info, call).(*types.Builtin); ok && fn.Name() == "panic" {
+							return call
+						}
+						if last != body.List[len(body.List)-1] {
+							return nil // e.g. os.Exit
+						}
+					}
+				}
+			}
+			return body
+		}
+		for _, succ := range b.Succs {
+			if !seen[succ] {
+				seen[succ] = true
+				if exit := search(succ, succ.Nodes); exit != nil {
+					return exit
+				}
+			}
+		}
+		return nil
+	}
+	return search(start, start.Nodes[index+1:])
+}
+
+// deferUnlock returns the edits of a fix that unlocks the mutex by a
+// deferred call immediately after the lock statement, or nil if that
+// is not feasible: the lock statement must be a statement of the
+// function body, and each call to unlock must be a statement followed
+// only by a return statement, or the last statement of the body.
+func deferUnlock(pass *analysis.Pass, body *ast.BlockStmt, l lock, mutex string) []analysis.TextEdit {
+	top := false
+	for _, stmt := range body.List {
+		if stmt == l.stmt {
+			top = true
+		}
+	}
+	if !top {
+		return nil
+	}
+
+	// Insert the defer statement on the line after the lock
+	// statement, preserving any comment at the end of its line.
+	tf := pass.Fset.File(l.stmt.Pos())
+	line := tf.Line(l.stmt.End())
+	if line >= tf.LineCount() {
+		return nil
+	}
+	edits := []analysis.TextEdit{{
+		Pos:     tf.LineStart(line + 1),
+		End:     tf.LineStart(line + 1),
+		NewText: []byte("defer " + mutex + "." + l.unlock + "()\n"),
+	}}
+	unlocks := 0
+	analysisinternal.InspectFunc(body, func(n ast.Node) {
+		var list []ast.Stmt
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			if sel, method := n.X, n.Sel.Name; method == l.unlock && sameExpr(pass.TypesInfo, sel, l.mutex) {
+				unlocks++ // a call, or a method value such as f(mu.Unlock)
+			}
+			return
+		case *ast.BlockStmt:
+			list = n.List
+		case *ast.CaseClause:
+			list = n.Body
+		case *ast.CommClause:
+			list = n.Body
+		}
+		for i, stmt := range list {
+			stmt, ok := stmt.(*ast.ExprStmt)
+			if !ok {
+				continue
+			}
+			call, ok := stmt.X.(*ast.CallExpr)
+			if !ok {
+				continue
+			}
+			if mutex, method := mutexCall(pass.TypesInfo, call); method != l.unlock || !sameExpr(pass.TypesInfo, mutex, l.mutex) {
+				continue
+			}
+			last := n == body && i == len(list)-1
+			beforeReturn := i+1 < len(list) && is[*ast.ReturnStmt](list[i+1])
+			if last || beforeReturn {
+				// Delete the entire line if the statement is alone on it.
+				start, end := stmt.Pos(), stmt.End()
+				line := tf.Line(start)
+				alone := (i == 0 || tf.Line(list[i-1].End()) < line) &&
+					(i+1 == len(list) && tf.Line(n.End()) > line || i+1 < len(list) && tf.Line(list[i+1].Pos()) > line)
+				if alone && line < tf.LineCount() {
+					start, end = tf.LineStart(line), tf.LineStart(line+1)
+				}
+				edits = append(edits, analysis.TextEdit{Pos: start, End: end})
+			}
+		}
+	})
+	if len(edits)-1 != unlocks {
+		return nil // some unlock is not followed by the end of the function
+	}
+	return edits
+}
+
+// containsUnlock reports whether n contains a call that unlocks the
+// mutex of the lock, not counting calls in nested functions.
+func containsUnlock(info *types.Info, n ast.Node, l lock) bool {
+	found := false
+	analysisinternal.InspectFunc(n, func(n ast.Node) {
+		if call, ok := n.(*ast.CallExpr); ok {
+			if mutex, method := mutexCall(info, call); method == l.unlock && sameExpr(info, mutex, l.mutex) {
+				found = true
+			}
+		}
+	})
+	return found
+}
+
+// mutexCall returns the mutex operand and method name if the call is
+// to a method of sync.Mutex or sync.RWMutex, perhaps promoted through
+// an embedded field.
+func mutexCall(info *types.Info, call *ast.CallExpr) (ast.Expr, string) {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil, ""
+	}
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	if !ok {
+		return nil, ""
+	}
+	if analysisinternal.IsMethodNamed(fn, "sync", "Mutex", "Lock", "Unlock") ||
+		analysisinternal.IsMethodNamed(fn, "sync", "RWMutex", "Lock", "Unlock", "RLock", "RUnlock") {
+		return sel.X, fn.Name()
+	}
+	return nil, ""
+}
+
+// sameExpr reports whether x and y are the same expression,
+// referring to the same objects.
+func sameExpr(info *types.Info, x, y ast.Expr) bool {
+	return astutil.Equal(ast.Unparen(x), ast.Unparen(y), func(x, y *ast.Ident) bool {
+		return info.ObjectOf(x) == info.ObjectOf(y)
+	})
+}
+
+func is[T any](x any) bool {
+	_, ok := x.(T)
+	return ok
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a
+
+import (
+	"errors"
+	"os"
+	"sync"
+)
+
+var (
+	mu    sync.Mutex
+	rw    sync.RWMutex
+	queue []int
+)
+
+func pop() (int, error) {
+	mu.Lock() // want "mu.Lock is not followed by mu.Unlock on all paths"
+	if len(queue) == 0 {
+		return 0, errors.New("empty") // want "this return statement is reached without calling mu.Unlock"
+	}
+	x := queue[0]
+	queue = queue[1:]
+	mu.Unlock()
+	return x, nil
+}
+
+func popFixable() (int, error) {
+	mu.Lock() // want "mu.Lock is not followed by mu.Unlock on all paths"
+	if len(queue) == 0 {
+		mu.Unlock()
+		return 0, errors.New("empty")
+	}
+	if len(queue) == 1 {
+		return 0, errors.New("singleton") // want "this return statement is reached without calling mu.Unlock"
+	}
+	x := queue[0]
+	queue = queue[1:]
+	mu.Unlock()
+	return x, nil
+}
+
+func endOfFunction(b bool) {
+	rw.RLock() // want "rw.RLock is not followed by rw.RUnlock on all paths"
+	if b {
+		rw.RUnlock()
+	}
+} // want "the end of the function is reached without calling rw.RUnlock"
+
+func panics(b bool) {
+	mu.Lock() // want "mu.Lock is not followed by mu.Unlock on all paths"
+	if b {
+		panic("oops") // want "this panic is reached without calling mu.Unlock"
+	}
+	print(len(queue))
+	mu.Unlock()
+}
+
+type S struct {
+	sync.Mutex
+	mu sync.Mutex
+	n  int
+}
+
+func (s *S) fields(b bool) int {
+	s.mu.Lock() // want "s.mu.Lock is not followed by s.mu.Unlock on all paths"
+	if b {
+		s.mu.Unlock()
+		return 0
+	}
+	s.Lock() // want "s.Lock is not followed by s.Unlock on all paths"
+	if s.n > 0 {
+		s.mu.Unlock()
+		return s.n // want "this return statement is reached without calling s.Unlock"
+	}
+	s.Unlock()
+	return 1 // want "this return statement is reached without calling s.mu.Unlock"
+}
+
+// -- ok --
+
+func deferred() int {
+	mu.Lock()
+	defer mu.Unlock()
+	if len(queue) == 0 {
+		return 0
+	}
+	return queue[0]
+}
+
+func deferredLiteral() int {
+	mu.Lock()
+	defer func() {
+		mu.Unlock()
+	}()
+	if len(queue) == 0 {
+		return 0
+	}
+	return queue[0]
+}
+
+func allPaths(b bool) int {
+	mu.Lock()
+	if b {
+		mu.Unlock()
+		return 0
+	}
+	mu.Unlock()
+	return 1
+}
+
+func exits(b bool) {
+	mu.Lock()
+	if b {
+		os.Exit(1)
+	}
+	mu.Unlock()
+}
+
+func (s *S) lock() {
+	s.mu.Lock() // a helper that never unlocks
+}
+
+func loop() {
+	for range 3 {
+		mu.Lock()
+		queue = nil
+		mu.Unlock()
+	}
+}
+
+func closure() {
+	mu.Lock()
+	func() {
+		mu.Unlock() // not this function: not counted
+	}()
+}
@@ -37,7 +37,17 @@
 //	}
 //
 // After Go version 1.22, the previous two for loops are equivalent
-// and both are correct.
+// and both are correct. The analyzer consults the effective Go version
+// of each file, and in files at go1.22 or later it reports only
+// captures of variables declared outside the loop, which are still
+// shared by all iterations:
+//
+//	var v T
+//	for _, v = range list {
+//	    defer func() {
+//	        use(v) // incorrect, even in Go 1.22
+//	    }()
+//	}
 //
 // The next example uses a go statement and has a similar problem [<go1.22].
 // In addition, it has a data race because the loop updates v
@@ -50,7 +60,12 @@
 //	}
 //
 // A fix is the same as before. The checker also reports problems
-// in goroutines started by golang.org/x/sync/errgroup.Group.
+// in goroutines started by golang.org/x/sync/errgroup.Group.Go and
+// sync.WaitGroup.Go, and in functions passed to sync.OnceFunc,
+// OnceValue, and OnceValues, which are called lazily. The
+// -asyncfuncs flag adds other functions or methods, such as those of
+// a semaphore or worker pool, that call their final function
+// argument asynchronously.
 // A hard-to-spot variant of this form is common in parallel tests:
 //
 //	func Test(t *testing.T) {
@@ -7,7 +7,10 @@ package loopclosure
 import (
 	_ "embed"
 	"go/ast"
+	"go/token"
 	"go/types"
+	"sort"
+	"strings"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
@@ -30,9 +33,26 @@ var Analyzer = &analysis.Analyzer{
 	Run:      run,
 }
 
+func init() {
+	Analyzer.Flags.Var(&asyncFuncs, "asyncfuncs",
+		"comma-separated list of additional functions or methods, such as (*example.com/pool.Pool).Submit, that call their function argument asynchronously")
+}
+
+// asyncFuncs is the set of functions (identified by
+// (*types.Func).FullName) that call their final function argument
+// asynchronously, in another goroutine, without awaiting it.
+//
+// The -asyncfuncs flag adds to this set.
+var asyncFuncs = stringSetFlag{
+	"(*golang.org/x/sync/errgroup.Group).Go":    true,
+	"(*golang.org/x/sync/errgroup.Group).TryGo": true,
+	"(*sync.WaitGroup).Go":                      true,
+}
+
 func run(pass *analysis.Pass) (any, error) {
 	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 
+	var goversion string // effective file version ("" => unknown)
 	nodeFilter := []ast.Node{
 		(*ast.File)(nil),
 		(*ast.RangeStmt)(nil),
@@ -43,9 +63,17 @@ func run(pass *analysis.Pass) (any, error) {
 			// inspect.Nodes is slightly suboptimal as we only use push=true.
 			return true
 		}
+		// As of go1.22, each iteration of a loop has its own copy of
+		// the variables declared by the loop (go.dev/issue/60078), so
+		// only variables declared outside the loop are shared.
+		perIteration := versions.AtLeast(goversion, versions.Go1_22)
+
 		// Find the variables updated by the loop statement.
 		var vars []types.Object
-		addVar := func(expr ast.Expr) {
+		addVar := func(expr ast.Expr, declared bool) {
+			if declared && perIteration {
+				return // safe
+			}
 			if id, _ := expr.(*ast.Ident); id != nil {
 				if obj := pass.TypesInfo.ObjectOf(id); obj != nil {
 					vars = append(vars, obj)
@@ -55,24 +83,40 @@ func run(pass *analysis.Pass) (any, error) {
 		var body *ast.BlockStmt
 		switch n := n.(type) {
 		case *ast.File:
-			// Only traverse the file if its goversion is strictly before go1.22.
-			goversion := versions.FileVersion(pass.TypesInfo, n)
-			return versions.Before(goversion, versions.Go1_22)
+			goversion = versions.FileVersion(pass.TypesInfo, n)
+			return true
 		case *ast.RangeStmt:
 			body = n.Body
-			addVar(n.Key)
-			addVar(n.Value)
+			addVar(n.Key, n.Tok == token.DEFINE)
+			addVar(n.Value, n.Tok == token.DEFINE)
 		case *ast.ForStmt:
 			body = n.Body
+
+			// declared reports whether the loop's init
+			// statement declares the variable of expr.
+			declared := func(expr ast.Expr) bool {
+				id, _ := expr.(*ast.Ident)
+				init, _ := n.Init.(*ast.AssignStmt)
+				if id == nil || init == nil || init.Tok != token.DEFINE {
+					return false
+				}
+				obj := pass.TypesInfo.Uses[id]
+				for _, lhs := range init.Lhs {
+					if lhs, ok := lhs.(*ast.Ident); ok && obj != nil && pass.TypesInfo.Defs[lhs] == obj {
+						return true
+					}
+				}
+				return false
+			}
 			switch post := n.Post.(type) {
 			case *ast.AssignStmt:
 				// e.g. for p = head; p != nil; p = p.next
 				for _, lhs := range post.Lhs {
-					addVar(lhs)
+					addVar(lhs, declared(lhs))
 				}
 			case *ast.IncDecStmt:
 				// e.g. for i := 0; i < n; i++
-				addVar(post.X)
+				addVar(post.X, declared(post.X))
 			}
 		}
 		if vars == nil {
@@ -82,7 +126,8 @@ func run(pass *analysis.Pass) (any, error) {
 		// Inspect statements to find function literals that may be run outside of
 		// the current loop iteration.
 		//
-		// For go, defer, and errgroup.Group.Go, we ignore all but the last
+		// For go, defer, and asynchronous calls such as
+		// errgroup.Group.Go, we ignore all but the last
 		// statement, because it's hard to prove go isn't followed by wait, or
 		// defer by return. "Last" is defined recursively.
 		//
@@ -98,7 +143,7 @@ func run(pass *analysis.Pass) (any, error) {
 				stmts = litStmts(s.Call.Fun)
 			case *ast.DeferStmt:
 				stmts = litStmts(s.Call.Fun)
-			case *ast.ExprStmt: // check for errgroup.Group.Go
+			case *ast.ExprStmt: // check for errgroup.Group.Go, etc
 				if call, ok := s.X.(*ast.CallExpr); ok {
 					stmts = litStmts(goInvoke(pass.TypesInfo, call))
 				}
@@ -125,6 +170,19 @@ func run(pass *analysis.Pass) (any, error) {
 				}
 			}
 		}
+
+		// Also check for functions passed to sync.OnceFunc and
+		// friends, which are called lazily, typically after the
+		// iteration in which they were created.
+		ast.Inspect(body, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok && len(call.Args) == 1 &&
+				analysisinternal.IsFunctionNamed(typeutil.Callee(pass.TypesInfo, call), "sync", "OnceFunc", "OnceValue", "OnceValues") {
+				for _, stmt := range litStmts(call.Args[0]) {
+					reportCaptured(pass, vars, stmt)
+				}
+			}
+			return true
+		})
 		return true
 	})
 	return nil, nil
@@ -222,12 +280,13 @@ func litStmts(fun ast.Expr) []ast.Stmt {
 //	var g errgroup.Group
 //	g.Go(func() error { ... })
 //
-// Currently only "golang.org/x/sync/errgroup.Group()" is considered.
+// The functions considered are those of the asyncFuncs set.
 func goInvoke(info *types.Info, call *ast.CallExpr) ast.Expr {
-	if !isMethodCall(info, call, "golang.org/x/sync/errgroup", "Group", "Go") {
+	fn, ok := typeutil.Callee(info, call).(*types.Func)
+	if !ok || !asyncFuncs[fn.FullName()] || len(call.Args) == 0 {
 		return nil
 	}
-	return call.Args[0]
+	return call.Args[len(call.Args)-1]
 }
 
 // parallelSubtest returns statements that can be easily proven to execute
@@ -371,3 +430,23 @@ func isMethodCall(info *types.Info, expr ast.Expr, pkgPath, typeName, method str
 	_, named := typesinternal.ReceiverNamed(recv)
 	return analysisinternal.IsTypeNamed(named, pkgPath, typeName)
 }
+
+type stringSetFlag map[string]bool
+
+func (ss *stringSetFlag) String() string {
+	var items []string
+	for item := range *ss {
+		items = append(items, item)
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
+
+func (ss *stringSetFlag) Set(s string) error {
+	for name := range strings.SplitSeq(s, ",") {
+		if name != "" {
+			(*ss)[name] = true
+		}
+	}
+	return nil
+}
@@ -17,3 +17,9 @@ func TestVersions(t *testing.T) {
 	dir := testfiles.ExtractTxtarFileToTmp(t, filepath.Join(analysistest.TestData(), "src", "versions", "go22.txtar"))
 	analysistest.Run(t, dir, loopclosure.Analyzer, "golang.org/fake/versions")
 }
+
+func TestAsyncFuncs(t *testing.T) {
+	testdata := analysistest.TestData()
+	loopclosure.Analyzer.Flags.Set("asyncfuncs", "(*asyncfuncs.Pool).Submit,asyncfuncs.Acquire")
+	analysistest.Run(t, testdata, loopclosure.Analyzer, "asyncfuncs")
+}
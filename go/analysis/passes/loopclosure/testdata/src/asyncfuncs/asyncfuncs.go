@@ -0,0 +1,31 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.21
+
+// This file contains tests for the -asyncfuncs flag.
+// The go1.21 build tag forces the pre-go1.22 loop semantics.
+
+package asyncfuncs
+
+// A Pool runs submitted tasks in its worker goroutines.
+type Pool struct{}
+
+func (*Pool) Submit(task func()) {}
+
+// Acquire calls f after acquiring n units of the semaphore.
+func Acquire(n int, f func()) {}
+
+func _(p *Pool, l []int) {
+	for _, v := range l {
+		p.Submit(func() {
+			print(v) // want "loop variable v captured by func literal"
+		})
+	}
+	for _, v := range l {
+		Acquire(1, func() {
+			print(v) // want "loop variable v captured by func literal"
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package lossyconv defines an Analyzer that reports conversions
+// between integer types that may lose information.
+//
+// # Analyzer lossyconv
+//
+// lossyconv: check for integer conversions that may truncate or change sign
+//
+// A conversion between integer types silently wraps values that the
+// result type cannot represent, which may cause subtle bugs, such as
+// a negative length that becomes a huge unsigned value, or a size that
+// overflows when narrowed:
+//
+//	func header(n int) []byte {
+//		return binary.BigEndian.AppendUint16(nil, uint16(n)) // "conversion from int to uint16 may truncate the value"
+//	}
+//
+// The analyzer reports two kinds of conversions, each in its own
+// diagnostic category, so that it may be disabled by the flag of the
+// same name.
+//
+// ## truncate
+//
+// A conversion to a smaller type, such as from int64 to int32, may
+// discard the high bits of the value.
+//
+// ## sign
+//
+// A conversion between signed and unsigned types, such as from int to
+// uint, or from uint32 to int32, may change the sign of the value.
+//
+// The analyzer does not report a conversion whose operand is provably
+// in the range of the result type: a constant; an expression whose
+// range follows from its form, such as x&0xff, x%100, x>>56, len(s),
+// or min(x, 100); or a variable whose range is established by an
+// enclosing if or for statement, or by an earlier if statement that
+// returns, as in:
+//
+//	if n < 0 || n > math.MaxUint16 {
+//		return errTooLong
+//	}
+//	buf = binary.BigEndian.AppendUint16(buf, uint16(n)) // ok
+//
+// Nor does it report a conversion to an unsigned type of the result
+// of a bitwise operation, such as byte(x>>8), which is a common and
+// deliberate way to extract bits. The sizes of int, uint, and uintptr
+// are those of the target platform.
+package lossyconv
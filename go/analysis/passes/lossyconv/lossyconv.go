@@ -0,0 +1,506 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package lossyconv defines an Analyzer that reports conversions
+// between integer types that may lose information.
+package lossyconv
+
+import (
+	_ "embed"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"math/big"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/analysis/passes/internal/analysisutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/internal/moreiters"
+)
+
+// NOTE: Experimental. Not part of the vet suite.
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "lossyconv",
+	Doc:      analysisutil.MustExtractDoc(doc, "lossyconv"),
+	URL:      "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/lossyconv",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// flags, one per category
+var (
+	checkTruncate = true
+	checkSign     = true
+)
+
+func init() {
+	Analyzer.Flags.BoolVar(&checkTruncate, "truncate", checkTruncate,
+		"report conversions to smaller integer types")
+	Analyzer.Flags.BoolVar(&checkSign, "sign", checkSign,
+		"report conversions between signed and unsigned integer types")
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	r := &ranger{pass: pass}
+	qual := types.RelativeTo(pass.Pkg)
+	for cur := range inspect.Root().Preorder((*ast.CallExpr)(nil)) {
+		call := cur.Node().(*ast.CallExpr)
+		if len(call.Args) != 1 || !pass.TypesInfo.Types[call.Fun].IsType() {
+			continue
+		}
+		to, from := integer(pass.TypesInfo.TypeOf(call.Fun)), integer(pass.TypesInfo.TypeOf(call.Args[0]))
+		if to == nil || from == nil {
+			continue
+		}
+
+		var category, effect string
+		switch {
+		case pass.TypesSizes.Sizeof(to) < pass.TypesSizes.Sizeof(from):
+			category, effect = "truncate", "truncate the value"
+		case isUnsigned(to) != isUnsigned(from):
+			category, effect = "sign", "change the sign of the value"
+		default:
+			continue // widening, or same size and signedness
+		}
+		if category == "truncate" && !checkTruncate || category == "sign" && !checkSign {
+			continue
+		}
+
+		x := ast.Unparen(call.Args[0])
+		if bin, ok := x.(*ast.BinaryExpr); ok && isUnsigned(to) {
+			switch bin.Op {
+			case token.AND, token.OR, token.XOR, token.AND_NOT, token.SHL, token.SHR:
+				continue // deliberate extraction of bits, e.g. byte(x >> 8)
+			}
+		}
+		if r.exprRange(x, cur).within(r.typeRange(to)) {
+			continue
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:      call.Pos(),
+			End:      call.End(),
+			Category: category,
+			Message: fmt.Sprintf("conversion from %s to %s may %s",
+				types.TypeString(pass.TypesInfo.TypeOf(call.Args[0]), qual),
+				types.TypeString(pass.TypesInfo.TypeOf(call.Fun), qual),
+				effect),
+		})
+	}
+	return nil, nil
+}
+
+// integer returns the basic integer type underlying t, or nil.
+func integer(t types.Type) *types.Basic {
+	if t == nil {
+		return nil
+	}
+	if _, ok := types.Unalias(t).(*types.TypeParam); ok {
+		return nil
+	}
+	b, ok := t.Underlying().(*types.Basic)
+	if !ok || b.Info()&types.IsInteger == 0 || b.Info()&types.IsUntyped != 0 {
+		return nil
+	}
+	return b
+}
+
+func isUnsigned(t *types.Basic) bool { return t.Info()&types.IsUnsigned != 0 }
+
+// An interval is the closed range of integers [lo, hi].
+type interval struct{ lo, hi *big.Int }
+
+func (x interval) within(y interval) bool {
+	return x.lo.Cmp(y.lo) >= 0 && x.hi.Cmp(y.hi) <= 0
+}
+
+// intersect returns the intersection of x and y, assuming it is not
+// empty.
+func (x interval) intersect(y interval) interval {
+	if y.lo.Cmp(x.lo) > 0 {
+		x.lo = y.lo
+	}
+	if y.hi.Cmp(x.hi) < 0 {
+		x.hi = y.hi
+	}
+	return x
+}
+
+// A ranger computes the ranges of integer expressions.
+type ranger struct {
+	pass *analysis.Pass
+}
+
+// typeRange returns the range of values of an integer type.
+func (r *ranger) typeRange(t *types.Basic) interval {
+	bits := uint(8 * r.pass.TypesSizes.Sizeof(t))
+	if isUnsigned(t) {
+		hi := new(big.Int).Lsh(big.NewInt(1), bits)
+		return interval{big.NewInt(0), hi.Sub(hi, big.NewInt(1))}
+	}
+	hi := new(big.Int).Lsh(big.NewInt(1), bits-1)
+	lo := new(big.Int).Neg(hi)
+	return interval{lo, hi.Sub(hi, big.NewInt(1))}
+}
+
+// exprRange returns a range of the values of the integer expression e,
+// which is at or within the cursor cur.
+func (r *ranger) exprRange(e ast.Expr, cur inspector.Cursor) interval {
+	info := r.pass.TypesInfo
+	tv := info.Types[e]
+	if tv.Value != nil {
+		if v := constant.ToInt(tv.Value); v.Kind() == constant.Int {
+			n := exact(v)
+			return interval{n, n}
+		}
+	}
+	t := integer(tv.Type)
+	if t == nil {
+		// e.g. an untyped shift; use the widest range.
+		t = types.Typ[types.Int64]
+		if b, ok := types.Unalias(tv.Type).(*types.Basic); ok && b.Info()&types.IsUnsigned != 0 {
+			t = types.Typ[types.Uint64]
+		}
+	}
+	rng := r.typeRange(t)
+
+	switch e := e.(type) {
+	case *ast.ParenExpr:
+		return r.exprRange(e.X, cur)
+
+	case *ast.CallExpr:
+		if len(e.Args) == 0 {
+			break
+		}
+		if info.Types[e.Fun].IsType() {
+			// A conversion preserves a value in range.
+			if len(e.Args) == 1 && integer(info.TypeOf(e.Args[0])) != nil {
+				if x := r.exprRange(e.Args[0], cur); x.within(rng) {
+					return x
+				}
+			}
+			break
+		}
+		id, ok := ast.Unparen(e.Fun).(*ast.Ident)
+		if !ok {
+			break
+		}
+		if _, ok := info.Uses[id].(*types.Builtin); !ok {
+			break
+		}
+		switch id.Name {
+		case "len", "cap":
+			return interval{big.NewInt(0), rng.hi}
+		case "min", "max":
+			x := r.exprRange(e.Args[0], cur)
+			for _, arg := range e.Args[1:] {
+				y := r.exprRange(arg, cur)
+				if id.Name == "min" {
+					x = interval{minInt(x.lo, y.lo), minInt(x.hi, y.hi)}
+				} else {
+					x = interval{maxInt(x.lo, y.lo), maxInt(x.hi, y.hi)}
+				}
+			}
+			return x
+		}
+
+	case *ast.BinaryExpr:
+		y := info.Types[e.Y].Value
+		if y == nil {
+			break
+		}
+		c := exact(constant.ToInt(y))
+		if c == nil {
+			break
+		}
+		x := r.exprRange(e.X, cur)
+		switch e.Op {
+		case token.AND:
+			if c.Sign() >= 0 {
+				return interval{big.NewInt(0), c} // x & c is in [0, c]
+			}
+		case token.REM:
+			if c.Sign() > 0 {
+				m := new(big.Int).Sub(c, big.NewInt(1))
+				if x.lo.Sign() >= 0 {
+					return interval{big.NewInt(0), minInt(m, x.hi)}
+				}
+				return interval{new(big.Int).Neg(m), m}
+			}
+		case token.SHR:
+			if c.Sign() >= 0 && c.IsUint64() && c.Uint64() < 128 {
+				n := uint(c.Uint64())
+				return interval{new(big.Int).Rsh(x.lo, n), new(big.Int).Rsh(x.hi, n)}
+			}
+		}
+
+	case *ast.Ident:
+		if v, ok := info.Uses[e].(*types.Var); ok && cur.Inspector() != nil && !v.IsField() && v.Parent() != v.Pkg().Scope() {
+			return rng.intersect(r.varRange(v, cur))
+		}
+	}
+	return rng
+}
+
+// varRange returns a range of the values of the local variable v at
+// the cursor, as established by the conditions of the statements that
+// enclose it, or by earlier statements that exit unless a condition
+// holds.
+func (r *ranger) varRange(v *types.Var, cur inspector.Cursor) interval {
+	unbounded := interval{big.NewInt(0).Neg(new(big.Int).Lsh(big.NewInt(1), 128)), new(big.Int).Lsh(big.NewInt(1), 128)}
+	curFunc, ok := moreiters.First(cur.Enclosing((*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)))
+	if !ok || addressTaken(r.pass.TypesInfo, curFunc.Node(), v) {
+		return unbounded
+	}
+	pos := cur.Node().Pos()
+
+	// holds returns the range established by a check of cond,
+	// if the variable is not assigned between the end of the
+	// check, after, and the position of interest.
+	rng := unbounded
+	holds := func(cond ast.Expr, truth bool, after token.Pos) {
+		if cond != nil && !assigned(r.pass.TypesInfo, curFunc.Node(), v, after, pos) {
+			rng = rng.intersect(r.condRange(cond, truth, v, unbounded))
+		}
+	}
+
+	child := cur
+	for parent := cur.Parent(); parent.Node() != curFunc.Node(); child, parent = parent, parent.Parent() {
+		switch n := parent.Node().(type) {
+		case *ast.IfStmt:
+			if child.Node() == n.Body {
+				holds(n.Cond, true, n.Body.Lbrace)
+			} else if child.Node() == n.Else {
+				holds(n.Cond, false, n.Else.Pos())
+			}
+
+		case *ast.ForStmt:
+			if child.Node() == n.Body {
+				holds(n.Cond, true, n.Body.Lbrace)
+				// for i := c; ...; i++ { ... }
+				if assign, ok := n.Init.(*ast.AssignStmt); ok && len(assign.Lhs) == 1 && len(assign.Rhs) == 1 &&
+					isVar(r.pass.TypesInfo, assign.Lhs[0], v) {
+					if inc, ok := n.Post.(*ast.IncDecStmt); ok && inc.Tok == token.INC &&
+						!assigned(r.pass.TypesInfo, n.Body, v, n.Body.Lbrace, n.Body.Rbrace) {
+						if c := r.pass.TypesInfo.Types[assign.Rhs[0]].Value; c != nil {
+							rng.lo = maxInt(rng.lo, exact(constant.ToInt(c)))
+						}
+					}
+				}
+			}
+
+		case *ast.RangeStmt:
+			// for i := range x { ... }
+			if child.Node() == n.Body && isVar(r.pass.TypesInfo, n.Key, v) &&
+				!assigned(r.pass.TypesInfo, n.Body, v, n.Body.Lbrace, n.Body.Rbrace) {
+				switch t := r.pass.TypesInfo.TypeOf(n.X).Underlying().(type) {
+				case *types.Basic:
+					if t.Info()&types.IsInteger != 0 {
+						x := r.exprRange(n.X, parent)
+						rng = rng.intersect(interval{big.NewInt(0), new(big.Int).Sub(x.hi, big.NewInt(1))})
+					} else if t.Info()&types.IsString != 0 {
+						rng.lo = maxInt(rng.lo, big.NewInt(0))
+					}
+				case *types.Slice, *types.Array, *types.Pointer:
+					rng.lo = maxInt(rng.lo, big.NewInt(0))
+				}
+			}
+
+		case *ast.BlockStmt:
+			// if cond { return }
+			for _, stmt := range n.List {
+				if stmt.Pos() >= child.Node().Pos() {
+					break
+				}
+				if ifstmt, ok := stmt.(*ast.IfStmt); ok && ifstmt.Else == nil && exits(ifstmt.Body) {
+					holds(ifstmt.Cond, false, ifstmt.End())
+				}
+			}
+		}
+	}
+	return rng
+}
+
+// condRange returns the range of v established by the condition cond
+// having the value truth, intersected with rng.
+func (r *ranger) condRange(cond ast.Expr, truth bool, v *types.Var, rng interval) interval {
+	switch cond := cond.(type) {
+	case *ast.ParenExpr:
+		return r.condRange(cond.X, truth, v, rng)
+
+	case *ast.UnaryExpr:
+		if cond.Op == token.NOT {
+			return r.condRange(cond.X, !truth, v, rng)
+		}
+
+	case *ast.BinaryExpr:
+		switch cond.Op {
+		case token.LAND, token.LOR:
+			// Both operands have the value truth of x && y
+			// when true, or of x || y when false.
+			if (cond.Op == token.LAND) == truth {
+				rng = r.condRange(cond.X, truth, v, rng)
+				rng = r.condRange(cond.Y, truth, v, rng)
+			}
+			return rng
+		}
+
+		// Put the comparison in the form v op y.
+		op, y := cond.Op, cond.Y
+		if !isVar(r.pass.TypesInfo, cond.X, v) {
+			if !isVar(r.pass.TypesInfo, cond.Y, v) {
+				return rng
+			}
+			y = cond.X
+			switch op {
+			case token.LSS:
+				op = token.GTR
+			case token.LEQ:
+				op = token.GEQ
+			case token.GTR:
+				op = token.LSS
+			case token.GEQ:
+				op = token.LEQ
+			}
+		}
+		if !truth {
+			switch op {
+			case token.LSS:
+				op = token.GEQ
+			case token.LEQ:
+				op = token.GTR
+			case token.GTR:
+				op = token.LEQ
+			case token.GEQ:
+				op = token.LSS
+			case token.EQL:
+				op = token.NEQ
+			case token.NEQ:
+				op = token.EQL
+			}
+		}
+		if integer(r.pass.TypesInfo.TypeOf(y)) == nil && r.pass.TypesInfo.Types[y].Value == nil {
+			return rng
+		}
+		// The range of y depends on no variables, to avoid cycles.
+		yr := r.exprRange(y, inspector.Cursor{})
+		one := big.NewInt(1)
+		switch op {
+		case token.LSS:
+			rng.hi = minInt(rng.hi, new(big.Int).Sub(yr.hi, one))
+		case token.LEQ:
+			rng.hi = minInt(rng.hi, yr.hi)
+		case token.GTR:
+			rng.lo = maxInt(rng.lo, new(big.Int).Add(yr.lo, one))
+		case token.GEQ:
+			rng.lo = maxInt(rng.lo, yr.lo)
+		case token.EQL:
+			rng = rng.intersect(yr)
+		}
+	}
+	return rng
+}
+
+// exits reports whether the block ends with a statement that leaves
+// the enclosing block.
+func exits(body *ast.BlockStmt) bool {
+	if len(body.List) == 0 {
+		return false
+	}
+	switch stmt := body.List[len(body.List)-1].(type) {
+	case *ast.ReturnStmt, *ast.BranchStmt:
+		return true
+	case *ast.ExprStmt:
+		if call, ok := stmt.X.(*ast.CallExpr); ok {
+			if id, ok := call.Fun.(*ast.Ident); ok && id.Name == "panic" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// assigned reports whether the variable v is assigned within the
+// node n, between the positions start and end.
+func assigned(info *types.Info, n ast.Node, v *types.Var, start, end token.Pos) bool {
+	found := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		if n == nil || n.End() < start || n.Pos() > end {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if isVar(info, lhs, v) && lhs.Pos() > start && lhs.Pos() < end {
+					found = true
+				}
+			}
+		case *ast.IncDecStmt:
+			if isVar(info, n.X, v) && n.Pos() > start && n.Pos() < end {
+				found = true
+			}
+		case *ast.RangeStmt:
+			if (isVar(info, n.Key, v) || isVar(info, n.Value, v)) && n.Pos() > start && n.Pos() < end {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// addressTaken reports whether the address of v is taken within n.
+func addressTaken(info *types.Info, n ast.Node, v *types.Var) bool {
+	found := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		if u, ok := n.(*ast.UnaryExpr); ok && u.Op == token.AND && isVar(info, u.X, v) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// isVar reports whether e is a reference to the variable v.
+func isVar(info *types.Info, e ast.Expr, v *types.Var) bool {
+	if e == nil {
+		return false
+	}
+	id, ok := ast.Unparen(e).(*ast.Ident)
+	return ok && info.ObjectOf(id) == v
+}
+
+// exact returns the value of an integer constant.
+func exact(v constant.Value) *big.Int {
+	switch x := constant.Val(v).(type) {
+	case int64:
+		return big.NewInt(x)
+	case *big.Int:
+		return x
+	}
+	return nil
+}
+
+func minInt(x, y *big.Int) *big.Int {
+	if x.Cmp(y) < 0 {
+		return x
+	}
+	return y
+}
+
+func maxInt(x, y *big.Int) *big.Int {
+	if x.Cmp(y) > 0 {
+		return x
+	}
+	return y
+}
@@ -0,0 +1,94 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a
+
+import "math"
+
+func truncate(x int64, n int) {
+	_ = int32(x)                    // want `conversion from int64 to int32 may truncate the value`
+	_ = uint16(n)                   // want `conversion from int to uint16 may truncate the value`
+	_ = int64(int32(x))             // want `conversion from int64 to int32 may truncate the value`
+	_ = int32(x & 0xfff)            // OK: in range
+	_ = int8(x % 100)               // OK: in range
+	_ = int8(x >> 56)               // OK: in range
+	_ = int16(min(max(n, 0), 1000)) // OK: in range
+	_ = int16(max(n, 0))            // want `conversion from int to int16 may truncate the value`
+	_ = int32(len("abc"))
+	_ = int32(1 << 20) // OK: constant
+	_ = byte(x >> 8)   // OK: deliberate extraction of bits
+	_ = byte(n | 1)    // OK: deliberate extraction of bits
+	_ = int8(n >> 8)   // want `conversion from int to int8 may truncate the value`
+	_ = int64(x)       // OK: same type
+	_ = int64(n)       // OK: widening
+}
+
+func sign(n int, u uint, u32 uint32, s []int) {
+	_ = uint(n)          // want `conversion from int to uint may change the sign of the value`
+	_ = int(u)           // want `conversion from uint to int may change the sign of the value`
+	_ = int32(u32)       // want `conversion from uint32 to int32 may change the sign of the value`
+	_ = int64(u32)       // OK: widening
+	_ = uint(len(s))     // OK: a length is not negative
+	_ = uint64(n & 0xff) // OK: deliberate extraction of bits
+}
+
+func checked(n int, x int64) error {
+	if n < 0 || n > math.MaxUint16 {
+		return nil
+	}
+	_ = uint16(n) // OK: checked by the if statement
+	if x >= 0 && x <= math.MaxInt32 {
+		_ = int32(x) // OK: checked by the condition
+		_ = uint32(x)
+	} else {
+		_ = int32(x) // want `conversion from int64 to int32 may truncate the value`
+	}
+	if !(x < 0) {
+		_ = uint64(x) // OK: not negative
+	}
+	return nil
+}
+
+func reassigned(n int) {
+	if n < 0 || n > math.MaxUint8 {
+		return
+	}
+	n *= 2
+	_ = uint8(n) // want `conversion from int to uint8 may truncate the value`
+}
+
+func escapes(n int) {
+	if n < 0 || n > math.MaxUint8 {
+		return
+	}
+	p := &n
+	*p = 1000
+	_ = uint8(n) // want `conversion from int to uint8 may truncate the value`
+}
+
+func loops(s []byte) {
+	for i := 0; i < 10; i++ {
+		_ = int8(i) // OK: i is in [0, 9]
+	}
+	for i := range s {
+		_ = uint(i) // OK: an index is not negative
+	}
+	for i := range 100 {
+		_ = uint8(i) // OK: i is in [0, 99]
+	}
+	for i := 0; i < 10; i++ {
+		i += 100
+		_ = int8(i) // want `conversion from int to int8 may truncate the value`
+	}
+}
+
+type ID uint64
+
+func named(id ID) {
+	_ = int64(id) // want `conversion from ID to int64 may change the sign of the value`
+}
+
+func generic[T ~int64](x T) {
+	_ = int32(x) // OK: type parameters are not checked
+}
@@ -13,4 +13,24 @@
 // WithDeadline and variants such as WithCancelCause must be called,
 // or the new context will remain live until its parent context is cancelled.
 // (The background context is never cancelled.)
+//
+// The analyzer applies the same check to other functions that acquire
+// a resource that must be released by calling a method of the result,
+// such as time.NewTimer (Stop) and (*database/sql.DB).Query (Close).
+// A path from the acquisition to a return statement that neither
+// calls the release method nor otherwise uses the variable, for
+// example by passing it to another function, is reported:
+//
+//	rows, err := db.Query(q) // "rows.Close is not called on all paths (possible resource leak)"
+//	if err != nil {
+//		return err
+//	}
+//	defer rows.Close()
+//
+// Calls to other methods of the resource, such as rows.Next, do not
+// count as uses. Additional pairs may be specified by the -mustcall
+// flag, a comma-separated list of items of the form acquire:Release,
+// where acquire is the full name of a function or method, such as
+// example.com/lock.Acquire or (*example.com/db.Pool).Get, and Release
+// is the name of the method of its result that releases the resource.
 package lostcancel
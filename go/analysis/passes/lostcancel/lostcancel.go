@@ -8,7 +8,10 @@ import (
 	_ "embed"
 	"fmt"
 	"go/ast"
+	"go/token"
 	"go/types"
+	"slices"
+	"strings"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/ctrlflow"
@@ -16,6 +19,7 @@ import (
 	"golang.org/x/tools/go/analysis/passes/internal/analysisutil"
 	"golang.org/x/tools/go/ast/inspector"
 	"golang.org/x/tools/go/cfg"
+	"golang.org/x/tools/go/types/typeutil"
 	"golang.org/x/tools/internal/analysisinternal"
 	"golang.org/x/tools/internal/astutil"
 )
@@ -38,6 +42,72 @@ const debug = false
 
 var contextPackage = "context"
 
+func init() {
+	Analyzer.Flags.Var(mustCall, "mustcall",
+		"comma-separated list of additional acquire:Release pairs, such as example.com/lock.Acquire:Release")
+}
+
+// mustCall maps each function (identified by (*types.Func).FullName)
+// that acquires a resource to the name of the method of its result
+// that must be called to release it.
+//
+// The -mustcall flag adds to this set.
+var mustCall = pairSet{
+	"time.NewTimer":                       "Stop",
+	"time.NewTicker":                      "Stop",
+	"(*database/sql.Conn).QueryContext":   "Close",
+	"(*database/sql.DB).Query":            "Close",
+	"(*database/sql.DB).QueryContext":     "Close",
+	"(*database/sql.Stmt).Query":          "Close",
+	"(*database/sql.Stmt).QueryContext":   "Close",
+	"(*database/sql.Tx).Query":            "Close",
+	"(*database/sql.Tx).QueryContext":     "Close",
+	"(*database/sql.DB).Conn":             "Close",
+	"(*database/sql.DB).Prepare":          "Close",
+	"(*database/sql.DB).PrepareContext":   "Close",
+	"(*database/sql.Tx).Prepare":          "Close",
+	"(*database/sql.Tx).PrepareContext":   "Close",
+	"(*database/sql.Conn).PrepareContext": "Close",
+}
+
+// A pairSet is a flag.Value for a set of acquire:Release pairs.
+type pairSet map[string]string
+
+func (ps pairSet) String() string {
+	var items []string
+	for acquire, release := range ps {
+		items = append(items, acquire+":"+release)
+	}
+	slices.Sort(items)
+	return strings.Join(items, ",")
+}
+
+func (ps pairSet) Set(s string) error {
+	for item := range strings.SplitSeq(s, ",") {
+		acquire, release, ok := strings.Cut(item, ":")
+		if !ok || acquire == "" || !token.IsIdentifier(release) {
+			return fmt.Errorf("invalid pair %q, want acquire:Release", item)
+		}
+		ps[acquire] = release
+	}
+	return nil
+}
+
+// importsMustCall reports whether pkg directly imports the package of
+// any of the functions of the mustCall set.
+func importsMustCall(pkg *types.Package) bool {
+	for _, imp := range pkg.Imports() {
+		for acquire := range mustCall {
+			// Strip the "(*" of a method.
+			name := strings.TrimLeft(acquire, "(*")
+			if strings.HasPrefix(name, imp.Path()+".") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // checkLostCancel reports a failure to the call the cancel function
 // returned by context.WithCancel, either because the variable was
 // assigned to the blank identifier, or because there exists a
@@ -49,8 +119,9 @@ var contextPackage = "context"
 //
 // checkLostCancel analyzes a single named or literal function.
 func run(pass *analysis.Pass) (any, error) {
-	// Fast path: bypass check if file doesn't use context.WithCancel.
-	if !analysisinternal.Imports(pass.Pkg, contextPackage) {
+	// Fast path: bypass check if file doesn't use context.WithCancel
+	// or any of the mustCall functions.
+	if !analysisinternal.Imports(pass.Pkg, contextPackage) && !importsMustCall(pass.Pkg) {
 		return nil, nil
 	}
 
@@ -79,6 +150,10 @@ func runFunc(pass *analysis.Pass, node ast.Node) {
 	// Maps each cancel variable to its defining ValueSpec/AssignStmt.
 	cancelvars := make(map[*types.Var]ast.Node)
 
+	// Maps each resource variable (see mustCall) to
+	// its defining ValueSpec/AssignStmt and release method.
+	resourcevars := make(map[*types.Var]resource)
+
 	// TODO(adonovan): opt: refactor to make a single pass
 	// over the AST using inspect.WithStack and node types
 	// {FuncDecl,FuncLit,CallExpr,SelectorExpr}.
@@ -89,6 +164,20 @@ func runFunc(pass *analysis.Pass, node ast.Node) {
 			return false // don't stray into nested functions
 		}
 
+		// Look for n=CallExpr beneath stack=[{AssignStmt,ValueSpec}]
+		// that acquires a resource:
+		//
+		//   t := time.NewTimer(d)
+		//
+		if call, ok := n.(*ast.CallExpr); ok && len(stack) > 0 {
+			if fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func); ok {
+				if release, ok := mustCall[fn.FullName()]; ok {
+					checkAcquire(pass, funcScope, fn, release, stack[len(stack)-1], resourcevars)
+				}
+			}
+			return true
+		}
+
 		// Look for n=SelectorExpr beneath stack=[{AssignStmt,ValueSpec} CallExpr]:
 		//
 		//   ctx, cancel    := context.WithCancel(...)
@@ -128,7 +217,7 @@ func runFunc(pass *analysis.Pass, node ast.Node) {
 		return true
 	})
 
-	if len(cancelvars) == 0 {
+	if len(cancelvars) == 0 && len(resourcevars) == 0 {
 		return // no need to inspect CFG
 	}
 
@@ -163,7 +252,7 @@ func runFunc(pass *analysis.Pass, node ast.Node) {
 	// (It would be more efficient to analyze all cancelvars in a
 	// single pass over the AST, but seldom is there more than one.)
 	for v, stmt := range cancelvars {
-		if ret := lostCancelPath(pass, g, v, stmt, sig); ret != nil {
+		if ret := lostCancelPath(pass, g, v, "", stmt, sig); ret != nil {
 			lineno := pass.Fset.Position(stmt.Pos()).Line
 			pass.ReportRangef(stmt, "the %s function is not used on all paths (possible context leak)", v.Name())
 
@@ -180,6 +269,75 @@ func runFunc(pass *analysis.Pass, node ast.Node) {
 			})
 		}
 	}
+	for v, res := range resourcevars {
+		if ret := lostCancelPath(pass, g, v, res.release, res.stmt, sig); ret != nil {
+			lineno := pass.Fset.Position(res.stmt.Pos()).Line
+			pass.ReportRangef(res.stmt, "%s.%s is not called on all paths (possible resource leak)", v.Name(), res.release)
+
+			pos, end := ret.Pos(), ret.End()
+			if pass.Fset.File(pos) != pass.Fset.File(end) {
+				end = pos // see golang/go#64547
+			}
+			pass.Report(analysis.Diagnostic{
+				Pos:     pos,
+				End:     end,
+				Message: fmt.Sprintf("this return statement may be reached without calling %s.%s for the var defined on line %d", v.Name(), res.release, lineno),
+			})
+		}
+	}
+}
+
+// A resource records the definition of a variable that holds a
+// resource, and the name of the method that releases it.
+type resource struct {
+	stmt    ast.Node // defining ValueSpec/AssignStmt
+	release string   // name of release method
+}
+
+// checkAcquire records in resourcevars the variable that holds the
+// resource acquired by a call to fn within the statement stmt, or
+// reports a diagnostic if the resource is discarded.
+func checkAcquire(pass *analysis.Pass, funcScope *types.Scope, fn *types.Func, release string, stmt ast.Node, resourcevars map[*types.Var]resource) {
+	// Find the result that has the release method.
+	results := fn.Signature().Results()
+	index := -1
+	for i := range results.Len() {
+		obj, _, _ := types.LookupFieldOrMethod(results.At(i).Type(), true, fn.Pkg(), release)
+		if _, ok := obj.(*types.Func); ok {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return // no such method
+	}
+
+	var id *ast.Ident // id of resource var
+	switch stmt := stmt.(type) {
+	case *ast.ValueSpec:
+		if len(stmt.Values) == 1 && len(stmt.Names) == results.Len() {
+			id = stmt.Names[index]
+		}
+	case *ast.AssignStmt:
+		if len(stmt.Rhs) == 1 && len(stmt.Lhs) == results.Len() {
+			id, _ = stmt.Lhs[index].(*ast.Ident)
+		}
+	}
+	if id != nil {
+		if id.Name == "_" {
+			pass.ReportRangef(id,
+				"the %s returned by %s should be released by calling its %s method, not discarded",
+				results.At(index).Type(), fn.FullName(), release)
+		} else if v, ok := pass.TypesInfo.Uses[id].(*types.Var); ok {
+			// If the variable is defined outside function scope,
+			// do not analyze it.
+			if funcScope.Contains(v.Pos()) {
+				resourcevars[v] = resource{stmt, release}
+			}
+		} else if v, ok := pass.TypesInfo.Defs[id].(*types.Var); ok {
+			resourcevars[v] = resource{stmt, release}
+		}
+	}
 }
 
 func isCall(n ast.Node) bool { _, ok := n.(*ast.CallExpr); return ok }
@@ -213,7 +371,10 @@ func isContextWithCancel(info *types.Info, n ast.Node) bool {
 // the 'cancel' variable v) to a return statement, that doesn't "use" v.
 // If it finds one, it returns the return statement (which may be synthetic).
 // sig is the function's type, if known.
-func lostCancelPath(pass *analysis.Pass, g *cfg.CFG, v *types.Var, stmt ast.Node, sig *types.Signature) *ast.ReturnStmt {
+//
+// If release is nonempty, v holds a resource, and a selection v.f
+// counts as a use only if f is the release method.
+func lostCancelPath(pass *analysis.Pass, g *cfg.CFG, v *types.Var, release string, stmt ast.Node, sig *types.Signature) *ast.ReturnStmt {
 	vIsNamedResult := sig != nil && tupleContains(sig.Results(), v)
 
 	// uses reports whether stmts contain a "use" of variable v.
@@ -222,6 +383,13 @@ func lostCancelPath(pass *analysis.Pass, g *cfg.CFG, v *types.Var, stmt ast.Node
 		for _, stmt := range stmts {
 			ast.Inspect(stmt, func(n ast.Node) bool {
 				switch n := n.(type) {
+				case *ast.SelectorExpr:
+					if id, ok := ast.Unparen(n.X).(*ast.Ident); ok && release != "" && pass.TypesInfo.Uses[id] == v {
+						if n.Sel.Name == release {
+							found = true
+						}
+						return false // e.g. t.Reset(d) is not a use
+					}
 				case *ast.Ident:
 					if pass.TypesInfo.Uses[n] == v {
 						found = true
@@ -15,3 +15,9 @@ func Test(t *testing.T) {
 	testdata := analysistest.TestData()
 	analysistest.Run(t, testdata, lostcancel.Analyzer, "a", "b", "typeparams")
 }
+
+func TestMustCall(t *testing.T) {
+	testdata := analysistest.TestData()
+	lostcancel.Analyzer.Flags.Set("mustcall", "mustcall/lock.Acquire:Release")
+	analysistest.Run(t, testdata, lostcancel.Analyzer, "mustcall")
+}
@@ -0,0 +1,7 @@
+package lock
+
+type Lock struct{}
+
+func Acquire(name string) (*Lock, error) { return new(Lock), nil }
+
+func (*Lock) Release() {}
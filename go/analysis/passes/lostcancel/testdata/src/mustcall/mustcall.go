@@ -0,0 +1,64 @@
+package mustcall
+
+import (
+	"database/sql"
+	"mustcall/lock"
+	"time"
+)
+
+var maybe bool
+
+func timer(d time.Duration) {
+	t := time.NewTimer(d) // want `t.Stop is not called on all paths \(possible resource leak\)`
+	if maybe {
+		return // want `this return statement may be reached without calling t.Stop for the var defined on line 12`
+	}
+	t.Stop()
+}
+
+func timerReset(d time.Duration) {
+	t := time.NewTimer(d) // want `t.Stop is not called on all paths`
+	t.Reset(d)            // not a use
+	<-t.C                 // not a use
+} // want `this return statement may be reached without calling t.Stop`
+
+func timerDefer(d time.Duration) {
+	t := time.NewTimer(d) // ok
+	defer t.Stop()
+	if maybe {
+		return
+	}
+}
+
+func timerEscapes(d time.Duration) *time.Timer {
+	t := time.NewTimer(d) // ok: returned to caller
+	return t
+}
+
+func ticker() {
+	_ = time.NewTicker(time.Second)     // want `the \*time.Ticker returned by time.NewTicker should be released by calling its Stop method, not discarded`
+	var _ = time.NewTicker(time.Second) // want `the \*time.Ticker returned by time.NewTicker should be released`
+}
+
+func rows(db *sql.DB) error {
+	rows, err := db.Query("SELECT 1") // want `rows.Close is not called on all paths`
+	if err != nil {
+		return err // want `this return statement may be reached without calling rows.Close`
+	}
+	for rows.Next() {
+	}
+	return rows.Err()
+}
+
+func rowsDiscarded(db *sql.DB) {
+	_, err := db.Query("SELECT 1") // want `the \*database/sql.Rows returned by \(\*database/sql.DB\).Query should be released by calling its Close method, not discarded`
+	_ = err
+}
+
+func userPair() {
+	l, err := lock.Acquire("x") // want `l.Release is not called on all paths`
+	if err != nil {
+		return // want `this return statement may be reached without calling l.Release`
+	}
+	defer l.Release()
+}
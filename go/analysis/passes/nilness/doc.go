@@ -69,4 +69,17 @@
 //	}
 //
 // ...
+//
+// The checker also summarizes the behavior of each function, and
+// exports the summaries as facts so that they are available when
+// checking other packages. A summary records which results of a
+// function are never nil, so that comparisons of such results
+// against nil are reported as degenerate, and which parameters the
+// function unconditionally dereferences, so that calls passing a nil
+// value for such a parameter are reported:
+//
+//	func (c *Config) Name() string { return c.name }
+//	...
+//	var c *Config
+//	c.Name() // "nil argument for parameter c of Name, which dereferences it"
 package nilness
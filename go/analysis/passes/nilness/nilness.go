@@ -21,22 +21,24 @@ import (
 var doc string
 
 var Analyzer = &analysis.Analyzer{
-	Name:     "nilness",
-	Doc:      analysisutil.MustExtractDoc(doc, "nilness"),
-	URL:      "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/nilness",
-	Run:      run,
-	Requires: []*analysis.Analyzer{buildssa.Analyzer},
+	Name:      "nilness",
+	Doc:       analysisutil.MustExtractDoc(doc, "nilness"),
+	URL:       "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/nilness",
+	Run:       run,
+	Requires:  []*analysis.Analyzer{buildssa.Analyzer},
+	FactTypes: []analysis.Fact{new(summary)},
 }
 
 func run(pass *analysis.Pass) (any, error) {
 	ssainput := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
+	sums := summarize(pass, ssainput.SrcFuncs)
 	for _, fn := range ssainput.SrcFuncs {
-		runFunc(pass, fn)
+		runFunc(pass, sums, fn)
 	}
 	return nil, nil
 }
 
-func runFunc(pass *analysis.Pass, fn *ssa.Function) {
+func runFunc(pass *analysis.Pass, sums *summaries, fn *ssa.Function) {
 	reportf := func(category string, pos token.Pos, format string, args ...any) {
 		// We ignore nil-checking ssa.Instructions
 		// that don't correspond to syntax.
@@ -51,8 +53,8 @@ func runFunc(pass *analysis.Pass, fn *ssa.Function) {
 
 	// notNil reports an error if v is provably nil.
 	notNil := func(stack []fact, instr ssa.Instruction, v ssa.Value, descr string) {
-		if nilnessOf(stack, v) == isnil {
-			reportf("nilderef", instr.Pos(), descr)
+		if nilnessOf(sums, stack, v) == isnil {
+			reportf("nilderef", instr.Pos(), "%s", descr)
 		}
 	}
 
@@ -79,6 +81,16 @@ func runFunc(pass *analysis.Pass, fn *ssa.Function) {
 				if !(cc.IsInvoke() && typeparams.IsTypeParam(cc.Value.Type())) {
 					notNil(stack, instr, cc.Value, "nil dereference in "+cc.Description())
 				}
+				// Report nil arguments for parameters that the
+				// callee unconditionally dereferences.
+				if callee := cc.StaticCallee(); callee != nil {
+					for _, i := range sums.derefParams(callee) {
+						if i < len(cc.Args) && nilnessOf(sums, stack, cc.Args[i]) == isnil {
+							reportf("nilarg", instr.Pos(), "nil argument for parameter %s of %s, which dereferences it",
+								paramName(callee, i), callee.Name())
+						}
+					}
+				}
 			case *ssa.FieldAddr:
 				notNil(stack, instr, instr.X, "nil dereference in field selection")
 			case *ssa.IndexAddr:
@@ -128,11 +140,11 @@ func runFunc(pass *analysis.Pass, fn *ssa.Function) {
 		for _, instr := range b.Instrs {
 			switch instr := instr.(type) {
 			case *ssa.Panic:
-				if nilnessOf(stack, instr.X) == isnil {
+				if nilnessOf(sums, stack, instr.X) == isnil {
 					reportf("nilpanic", instr.Pos(), "panic with nil value")
 				}
 			case *ssa.SliceToArrayPointer:
-				nn := nilnessOf(stack, instr.X)
+				nn := nilnessOf(sums, stack, instr.X)
 				if nn == isnil && slice2ArrayPtrLen(instr) > 0 {
 					reportf("conversionpanic", instr.Pos(), "nil slice being cast to an array of len > 0 will always panic")
 				}
@@ -143,8 +155,8 @@ func runFunc(pass *analysis.Pass, fn *ssa.Function) {
 		// is degenerate, and push a nilness fact on the stack when
 		// visiting its true and false successor blocks.
 		if binop, tsucc, fsucc := eq(b); binop != nil {
-			xnil := nilnessOf(stack, binop.X)
-			ynil := nilnessOf(stack, binop.Y)
+			xnil := nilnessOf(sums, stack, binop.X)
+			ynil := nilnessOf(sums, stack, binop.Y)
 
 			if ynil != unknown && xnil != unknown && (xnil == isnil || ynil == isnil) {
 				// Degenerate condition:
@@ -280,7 +292,10 @@ func (n nilness) String() string { return nilnessStrings[n+1] }
 
 // nilnessOf reports whether v is definitely nil, definitely not nil,
 // or unknown given the dominating stack of facts.
-func nilnessOf(stack []fact, v ssa.Value) nilness {
+//
+// The nilness of the result of a call to a function is
+// determined by the function's summary, if any.
+func nilnessOf(sums *summaries, stack []fact, v ssa.Value) nilness {
 
 	switch v := v.(type) {
 	// unwrap ChangeInterface and Slice values recursively, to detect if underlying
@@ -294,7 +309,7 @@ func nilnessOf(stack []fact, v ssa.Value) nilness {
 	// underlying values, rather than outer values, when the analysis is
 	// transitive in both directions.
 	case *ssa.ChangeInterface:
-		if underlying := nilnessOf(stack, v.X); underlying != unknown {
+		if underlying := nilnessOf(sums, stack, v.X); underlying != unknown {
 			return underlying
 		}
 	case *ssa.MakeInterface:
@@ -316,11 +331,11 @@ func nilnessOf(stack []fact, v ssa.Value) nilness {
 		// we can't determine the nilness.
 
 	case *ssa.Slice:
-		if underlying := nilnessOf(stack, v.X); underlying != unknown {
+		if underlying := nilnessOf(sums, stack, v.X); underlying != unknown {
 			return underlying
 		}
 	case *ssa.SliceToArrayPointer:
-		nn := nilnessOf(stack, v.X)
+		nn := nilnessOf(sums, stack, v.X)
 		if slice2ArrayPtrLen(v) > 0 {
 			if nn == isnil {
 				// We know that *(*[1]byte)(nil) is going to panic because of the
@@ -361,6 +376,16 @@ func nilnessOf(stack []fact, v ssa.Value) nilness {
 		} else {
 			return unknown // non-pointer
 		}
+
+	case *ssa.Call:
+		if sums.nonNilResult(v.Common(), 0) {
+			return isnonnil
+		}
+
+	case *ssa.Extract:
+		if call, ok := v.Tuple.(*ssa.Call); ok && sums.nonNilResult(call.Common(), v.Index) {
+			return isnonnil
+		}
 	}
 
 	// Search dominating control-flow facts.
@@ -0,0 +1,228 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nilness
+
+// This file defines function summaries, which let the analysis
+// reason about the nilness of values across function boundaries.
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/internal/typeparams"
+)
+
+// A summary is a fact that records the nilness behavior of a
+// function: which of its results are never nil, and which of its
+// parameters it unconditionally dereferences, so that it panics if
+// they are nil.
+//
+// Parameter indices are those of [ssa.Function.Params], in which the
+// receiver, if any, comes first.
+type summary struct {
+	NonNilResults []int // indices of results that are never nil
+	DerefParams   []int // indices of parameters that must not be nil
+}
+
+func (*summary) AFact() {}
+
+func (s *summary) String() string {
+	var parts []string
+	if len(s.NonNilResults) > 0 {
+		parts = append(parts, fmt.Sprintf("nonNilResults=%v", s.NonNilResults))
+	}
+	if len(s.DerefParams) > 0 {
+		parts = append(parts, fmt.Sprintf("derefParams=%v", s.DerefParams))
+	}
+	return strings.Join(parts, " ")
+}
+
+// summaries provides the summaries of the functions of the current
+// package and, by way of facts, of the functions of its dependencies.
+type summaries struct {
+	pass  *analysis.Pass
+	local map[*ssa.Function]*summary
+}
+
+// summarize computes the summaries of the package's functions, and
+// exports them as facts.
+func summarize(pass *analysis.Pass, funcs []*ssa.Function) *summaries {
+	sums := &summaries{pass: pass, local: make(map[*ssa.Function]*summary)}
+	for _, fn := range funcs {
+		sums.local[fn] = &summary{DerefParams: derefParams(fn)}
+	}
+
+	// A function's results may depend on those of other functions
+	// of the package, so iterate to a fixed point. Summaries only
+	// ever gain non-nil results, so this terminates.
+	for changed := true; changed; {
+		changed = false
+		for _, fn := range funcs {
+			s := sums.local[fn]
+			if results := nonNilResults(sums, fn); len(results) > len(s.NonNilResults) {
+				s.NonNilResults = results
+				changed = true
+			}
+		}
+	}
+
+	for _, fn := range funcs {
+		s := sums.local[fn]
+		if len(s.NonNilResults) == 0 && len(s.DerefParams) == 0 {
+			continue
+		}
+		// Closures and synthetic functions have no object.
+		if obj, ok := fn.Object().(*types.Func); ok && obj.Pkg() == pass.Pkg && fn.Parent() == nil {
+			pass.ExportObjectFact(obj, s)
+		}
+	}
+	return sums
+}
+
+// lookup returns the summary of fn, or nil if unknown.
+func (sums *summaries) lookup(fn *ssa.Function) *summary {
+	if origin := fn.Origin(); origin != nil {
+		fn = origin // instantiation of generic function
+	}
+	if s, ok := sums.local[fn]; ok {
+		return s
+	}
+	if obj, ok := fn.Object().(*types.Func); ok && obj.Pkg() != nil && obj.Pkg() != sums.pass.Pkg {
+		var s summary
+		if sums.pass.ImportObjectFact(obj, &s) {
+			return &s
+		}
+	}
+	return nil
+}
+
+// nonNilResult reports whether the ith result of the call is never nil.
+func (sums *summaries) nonNilResult(call *ssa.CallCommon, i int) bool {
+	if callee := call.StaticCallee(); callee != nil {
+		if s := sums.lookup(callee); s != nil {
+			return slices.Contains(s.NonNilResults, i)
+		}
+	}
+	return false
+}
+
+// derefParams returns the indices of the parameters of callee that
+// it unconditionally dereferences.
+func (sums *summaries) derefParams(callee *ssa.Function) []int {
+	if s := sums.lookup(callee); s != nil {
+		return s.DerefParams
+	}
+	return nil
+}
+
+// paramName returns the name of the ith parameter (counting the
+// receiver) of fn. Unlike fn.Params, it is available even for
+// functions without a body.
+func paramName(fn *ssa.Function, i int) string {
+	sig := fn.Signature
+	if recv := sig.Recv(); recv != nil {
+		if i == 0 {
+			return recv.Name()
+		}
+		i--
+	}
+	return sig.Params().At(i).Name()
+}
+
+// nonNilResults returns the indices of the results of fn that are
+// non-nil at every return statement.
+func nonNilResults(sums *summaries, fn *ssa.Function) []int {
+	var returns []*ssa.Return
+	for _, b := range fn.Blocks {
+		if ret, ok := b.Instrs[len(b.Instrs)-1].(*ssa.Return); ok {
+			returns = append(returns, ret)
+		}
+	}
+	if len(returns) == 0 {
+		return nil // e.g. no body, or never returns
+	}
+
+	var indices []int
+	results := fn.Signature.Results()
+	for i := range results.Len() {
+		if !isNillable(results.At(i).Type()) {
+			continue
+		}
+		nonnil := true
+		for _, ret := range returns {
+			if resultNilness(sums, ret.Results[i], make(map[*ssa.Phi]bool)) != isnonnil {
+				nonnil = false
+				break
+			}
+		}
+		if nonnil {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// resultNilness is like nilnessOf with no dominating facts, but in
+// addition, a φ-node is non-nil if all its edges are non-nil.
+func resultNilness(sums *summaries, v ssa.Value, seen map[*ssa.Phi]bool) nilness {
+	if phi, ok := v.(*ssa.Phi); ok {
+		if seen[phi] {
+			return isnonnil // a cycle contributes no values of its own
+		}
+		seen[phi] = true
+		for _, edge := range phi.Edges {
+			if resultNilness(sums, edge, seen) != isnonnil {
+				return unknown
+			}
+		}
+		return isnonnil
+	}
+	return nilnessOf(sums, nil, v)
+}
+
+// derefParams returns the indices of the parameters of fn that are
+// dereferenced in its entry block, which executes unconditionally.
+func derefParams(fn *ssa.Function) []int {
+	if len(fn.Blocks) == 0 || fn.Recover != nil {
+		return nil // no body, or a deferred call may recover from the panic
+	}
+	var indices []int
+	deref := func(v ssa.Value) {
+		if param, ok := v.(*ssa.Parameter); ok {
+			if i := slices.Index(fn.Params, param); i >= 0 && !slices.Contains(indices, i) {
+				indices = append(indices, i)
+			}
+		}
+	}
+	for _, instr := range fn.Blocks[0].Instrs {
+		switch instr := instr.(type) {
+		case ssa.CallInstruction:
+			if cc := instr.Common(); cc.IsInvoke() && !typeparams.IsTypeParam(cc.Value.Type()) {
+				deref(cc.Value)
+			}
+		case *ssa.FieldAddr:
+			deref(instr.X)
+		case *ssa.IndexAddr:
+			if is[*types.Pointer](typeparams.CoreType(instr.X.Type())) {
+				deref(instr.X)
+			}
+		case *ssa.MapUpdate:
+			deref(instr.Map)
+		case *ssa.Store:
+			deref(instr.Addr)
+		case *ssa.UnOp:
+			if instr.Op == token.MUL {
+				deref(instr.X)
+			}
+		}
+	}
+	slices.Sort(indices)
+	return indices
+}
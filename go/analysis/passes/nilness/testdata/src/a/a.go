@@ -125,7 +125,7 @@ func f8() {
 	print(v)
 }
 
-func f9(x interface {
+func f9(x interface { // want f9:"derefParams=\\[0\\]"
 	a()
 	b()
 	c()
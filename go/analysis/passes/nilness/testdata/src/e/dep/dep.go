@@ -0,0 +1,26 @@
+package dep
+
+type T struct{ x int }
+
+func New() *T { return new(T) } // want New:"nonNilResults=\\[0\\]"
+
+func Wrap() *T { return New() } // want Wrap:"nonNilResults=\\[0\\]"
+
+func Maybe(b bool) *T {
+	if b {
+		return nil
+	}
+	return new(T)
+}
+
+func Get(t *T) int { return t.x } // want Get:"derefParams=\\[0\\]"
+
+func (t *T) X() int { return t.x } // want X:"derefParams=\\[0\\]"
+
+func Either(b bool) (*T, error) { // want Either:"nonNilResults=\\[0\\]"
+	t := new(T)
+	if b {
+		t = New()
+	}
+	return t, nil
+}
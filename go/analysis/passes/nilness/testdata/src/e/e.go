@@ -0,0 +1,38 @@
+package e
+
+import "e/dep"
+
+func _() {
+	if dep.New() == nil { // want "impossible condition: non-nil == nil"
+		print()
+	}
+	if t := dep.Wrap(); t != nil { // want "tautological condition: non-nil != nil"
+		print()
+	}
+	if t := dep.Maybe(true); t != nil { // ok
+		print()
+	}
+	t, err := dep.Either(true)
+	if t == nil { // want "impossible condition: non-nil == nil"
+		print()
+	}
+	_ = err
+
+	var p *dep.T
+	dep.Get(p) // want "nil argument for parameter t of Get, which dereferences it"
+	p.X()      // want "nil argument for parameter t of X, which dereferences it"
+	if q := dep.Maybe(false); q == nil {
+		dep.Get(q) // want "nil argument for parameter t of Get"
+	}
+	dep.Get(dep.New()) // ok
+}
+
+func local() *int { // want local:"nonNilResults=\\[0\\]"
+	return new(int)
+}
+
+func _() {
+	if local() == nil { // want "impossible condition: non-nil == nil"
+		print()
+	}
+}
@@ -100,4 +100,20 @@
 // function is assumed to be Printf-like, taking a format string before the
 // argument list. Otherwise it is assumed to be Print-like, taking a list
 // of arguments with no format string.
+//
+// # Custom verbs and formatters
+//
+// Some codebases format values using wrappers that accept verbs beyond
+// those of the fmt package, or types whose Format method is not
+// visible to the analyzer. Rather than suppressing the resulting
+// diagnostics at each call, these may be declared by flag.
+//
+// The -verbs flag specifies a comma-separated list of additional
+// verbs, each a single character such as "j", that are accepted with
+// any flags and operands of any type.
+//
+// The -formatters flag specifies a comma-separated list of qualified
+// names of types, such as "example.com/log.Hex", whose values (and
+// pointers to them) are assumed to implement fmt.Formatter, so that
+// any verb and flags are accepted for them.
 package printf
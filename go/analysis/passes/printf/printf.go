@@ -30,6 +30,8 @@ import (
 
 func init() {
 	Analyzer.Flags.Var(isPrint, "funcs", "comma-separated list of print function names to check")
+	Analyzer.Flags.Var(extraVerbs, "verbs", "comma-separated list of additional verbs to accept")
+	Analyzer.Flags.Var(formatterTypes, "formatters", "comma-separated list of types to treat as fmt.Formatters")
 }
 
 //go:embed doc.go
@@ -472,7 +474,20 @@ func printfNameAndKind(pass *analysis.Pass, call *ast.CallExpr) (fn *types.Func,
 
 // isFormatter reports whether t could satisfy fmt.Formatter.
 // The only interface method to look for is "Format(State, rune)".
+//
+// Types named by the -formatters flag, and pointers to them, are
+// assumed to satisfy fmt.Formatter.
 func isFormatter(typ types.Type) bool {
+	if len(formatterTypes) > 0 {
+		if ptr, ok := types.Unalias(typ).(*types.Pointer); ok {
+			typ = ptr.Elem()
+		}
+		if named, ok := types.Unalias(typ).(*types.Named); ok {
+			if obj := named.Obj(); obj.Pkg() != nil && formatterTypes[obj.Pkg().Path()+"."+obj.Name()] {
+				return true
+			}
+		}
+	}
 	// If the type is an interface, the value it holds might satisfy fmt.Formatter.
 	if _, ok := typ.Underlying().(*types.Interface); ok {
 		// Don't assume type parameters could be formatters. With the greater
@@ -681,6 +696,11 @@ func okPrintfArg(pass *analysis.Pass, call *ast.CallExpr, rng analysis.Range, ma
 			break
 		}
 	}
+	if !found && extraVerbs[verb] {
+		// A verb added by the -verbs flag accepts any flags and operand.
+		v = printVerb{verb, allFlags, anyType}
+		found = true
+	}
 
 	// Could verb's arg implement fmt.Formatter?
 	// Skip check for the %w verb, which requires an error.
@@ -1004,6 +1024,64 @@ func (ss stringSet) Set(flag string) error {
 	return nil
 }
 
+// extraVerbs is the set of additional printf verbs, such as those
+// implemented by logging shims, specified by the -verbs flag.
+var extraVerbs = verbSet{}
+
+// verbSet is a flag.Value that holds a set of verbs.
+type verbSet map[rune]bool
+
+func (vs verbSet) String() string {
+	var list []string
+	for verb := range vs {
+		list = append(list, string(verb))
+	}
+	sort.Strings(list)
+	return strings.Join(list, ",")
+}
+
+func (vs verbSet) Set(flag string) error {
+	for verb := range strings.SplitSeq(flag, ",") {
+		runes := []rune(verb)
+		if len(runes) != 1 {
+			return fmt.Errorf("invalid verb %q: must be a single character", verb)
+		}
+		if runes[0] == '%' || runes[0] == '*' || strings.ContainsRune(allFlags, runes[0]) || '1' <= runes[0] && runes[0] <= '9' {
+			return fmt.Errorf("invalid verb %q", verb)
+		}
+		vs[runes[0]] = true
+	}
+	return nil
+}
+
+// formatterTypes is the set of qualified names of types, such as
+// "example.com/log.Hex", that are assumed to satisfy fmt.Formatter,
+// specified by the -formatters flag.
+var formatterTypes = typeNameSet{}
+
+// typeNameSet is a flag.Value that holds a set of qualified type names.
+type typeNameSet map[string]bool
+
+func (ts typeNameSet) String() string {
+	var list []string
+	for name := range ts {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return strings.Join(list, ",")
+}
+
+func (ts typeNameSet) Set(flag string) error {
+	for name := range strings.SplitSeq(flag, ",") {
+		dot := strings.LastIndex(name, ".")
+		if dot <= 0 || dot == len(name)-1 {
+			return fmt.Errorf("invalid type name %q: want pkgpath.Type", name)
+		}
+		ts[name] = true
+	}
+	return nil
+}
+
 // isHex reports whether b is a hex digit.
 func isHex(b byte) bool {
 	return '0' <= b && b <= '9' ||
@@ -35,3 +35,11 @@ func TestNonConstantFmtString_Go124(t *testing.T) {
 	dir := testfiles.ExtractTxtarFileToTmp(t, filepath.Join(analysistest.TestData(), "nonconst_go124.txtar"))
 	analysistest.RunWithSuggestedFixes(t, dir, printf.Analyzer, "example.com/nonconst")
 }
+
+func TestExtensions(t *testing.T) {
+	testdata := analysistest.TestData()
+	printf.Analyzer.Flags.Set("verbs", "j")
+	printf.Analyzer.Flags.Set("formatters", "extensions.Hex")
+
+	analysistest.Run(t, testdata, printf.Analyzer, "extensions")
+}
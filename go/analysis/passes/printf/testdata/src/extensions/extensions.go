@@ -0,0 +1,25 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains tests for the -verbs and -formatters flags of
+// the printf checker.
+
+package extensions
+
+import "fmt"
+
+// Hex is formatted by a logging shim that the analyzer cannot see.
+type Hex []byte
+
+type Plain int
+
+func _() {
+	fmt.Printf("%j", 1)        // ok: -verbs=j
+	fmt.Printf("%#-8j", "x")   // ok: any flags
+	fmt.Printf("%J", 1)        // want "fmt.Printf format %J has unknown verb J"
+	fmt.Printf("%Z", Hex{1})   // ok: -formatters=extensions.Hex
+	fmt.Printf("%d", &Hex{1})  // ok: pointer to formatter
+	fmt.Printf("%Z", Plain(1)) // want "fmt.Printf format %Z has unknown verb Z"
+	fmt.Printf("%j")           // want "fmt.Printf format %j reads arg #1, but call has 0 args"
+}
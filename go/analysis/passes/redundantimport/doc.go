@@ -0,0 +1,49 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package redundantimport defines an Analyzer that reports duplicate
+// and redundant imports.
+//
+// # Analyzer redundantimport
+//
+// redundantimport: check for duplicate and redundant imports
+//
+// This analyzer reports three kinds of unnecessary import declarations,
+// each of which may be disabled by a flag of the same name as its
+// category. For each, it offers a fix that removes the redundancy.
+//
+// ## duplicate
+//
+// The same package imported twice by one file, under different names:
+//
+//	import (
+//		"strings"
+//		str "strings" // "package "strings" is already imported as strings"
+//	)
+//
+// The fix deletes the second import and replaces its uses by the first.
+// A blank import of a package that is also imported by name is
+// reported too.
+//
+// ## blank
+//
+// A blank import of a package whose initialization has no side
+// effects, which therefore does nothing:
+//
+//	import _ "example.com/util" // "blank import of "example.com/util" has no effect"
+//
+// A package is considered to have side effects if it declares an init
+// function. A package outside the standard library is also considered
+// to have side effects if it initializes a package-level variable by
+// a function call, has a blank import of its own, imports "C", or
+// imports another package outside the standard library that has side
+// effects. Blank imports of "embed", needed by //go:embed directives,
+// are not reported.
+//
+// ## alias
+//
+// An import whose name is the same as the package name:
+//
+//	import fmt "fmt" // "redundant import alias fmt"
+package redundantimport
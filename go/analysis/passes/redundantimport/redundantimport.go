@@ -0,0 +1,304 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package redundantimport defines an Analyzer that reports duplicate
+// and redundant imports.
+package redundantimport
+
+import (
+	_ "embed"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/internal/analysisutil"
+	"golang.org/x/tools/internal/analysisinternal"
+)
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name:      "redundantimport",
+	Doc:       analysisutil.MustExtractDoc(doc, "redundantimport"),
+	URL:       "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/redundantimport",
+	FactTypes: []analysis.Fact{new(noInitFact)},
+	Run:       run,
+}
+
+// flags, one per category
+var (
+	checkDuplicate = true
+	checkBlank     = true
+	checkAlias     = true
+)
+
+func init() {
+	Analyzer.Flags.BoolVar(&checkDuplicate, "duplicate", checkDuplicate,
+		"report packages imported twice by the same file")
+	Analyzer.Flags.BoolVar(&checkBlank, "blank", checkBlank,
+		"report blank imports of packages without initialization side effects")
+	Analyzer.Flags.BoolVar(&checkAlias, "alias", checkAlias,
+		"report import names that are the same as the package name")
+}
+
+// noInitFact is a package fact indicating that initializing the
+// package has no side effects.
+type noInitFact struct{}
+
+func (*noInitFact) AFact()         {}
+func (*noInitFact) String() string { return "noInit" }
+
+func run(pass *analysis.Pass) (any, error) {
+	if !hasSideEffects(pass) {
+		pass.ExportPackageFact(new(noInitFact))
+	}
+
+	reportf := func(category string, rng analysis.Range, fix analysis.SuggestedFix, format string, args ...any) {
+		pass.Report(analysis.Diagnostic{
+			Pos:            rng.Pos(),
+			End:            rng.End(),
+			Category:       category,
+			Message:        fmt.Sprintf(format, args...),
+			SuggestedFixes: []analysis.SuggestedFix{fix},
+		})
+	}
+
+	for _, file := range pass.Files {
+		// Group the imports of the file by package.
+		var (
+			specs  = make(map[*types.Package][]*ast.ImportSpec)
+			order  []*types.Package
+			decls  = make(map[*ast.ImportSpec]*ast.GenDecl)
+			report = make(map[*ast.ImportSpec]bool) // specs reported as duplicates
+		)
+		for _, decl := range file.Decls {
+			decl, ok := decl.(*ast.GenDecl)
+			if !ok || decl.Tok != token.IMPORT {
+				continue
+			}
+			for _, spec := range decl.Specs {
+				spec := spec.(*ast.ImportSpec)
+				pkgname := importedName(pass.TypesInfo, spec)
+				if pkgname == nil {
+					continue
+				}
+				pkg := pkgname.Imported()
+				if specs[pkg] == nil {
+					order = append(order, pkg)
+				}
+				specs[pkg] = append(specs[pkg], spec)
+				decls[spec] = decl
+			}
+		}
+
+		for _, pkg := range order {
+			specs := specs[pkg]
+
+			// duplicate: keep the first import by name,
+			// and delete the others.
+			if checkDuplicate && len(specs) > 1 && !anyName(specs, ".") {
+				keep := specs[0]
+				for _, spec := range specs {
+					if !isName(spec, "_") {
+						keep = spec
+						break
+					}
+				}
+				name := importedName(pass.TypesInfo, keep).Name()
+				for _, spec := range specs {
+					if spec == keep {
+						continue
+					}
+					report[spec] = true
+					edits := deleteSpec(pass.Fset, decls[spec], spec)
+					if !isName(spec, "_") {
+						// Replace the uses of the deleted name.
+						pkgname := importedName(pass.TypesInfo, spec)
+						for id, obj := range pass.TypesInfo.Uses {
+							if obj == pkgname {
+								edits = append(edits, analysis.TextEdit{
+									Pos:     id.Pos(),
+									End:     id.End(),
+									NewText: []byte(name),
+								})
+							}
+						}
+					}
+					reportf("duplicate", spec, analysis.SuggestedFix{
+						Message:   "Delete duplicate import",
+						TextEdits: edits,
+					}, "package %q is already imported as %s", pkg.Path(), name)
+				}
+			}
+
+			for _, spec := range specs {
+				if report[spec] {
+					continue
+				}
+				switch {
+				case checkBlank && isName(spec, "_"):
+					// blank: is the package free of side effects?
+					if pkg.Path() != "embed" && pass.ImportPackageFact(pkg, new(noInitFact)) {
+						reportf("blank", spec, analysis.SuggestedFix{
+							Message:   "Delete blank import",
+							TextEdits: deleteSpec(pass.Fset, decls[spec], spec),
+						}, "blank import of %q has no effect", pkg.Path())
+					}
+
+				case checkAlias && spec.Name != nil && spec.Name.Name == pkg.Name():
+					reportf("alias", spec.Name, analysis.SuggestedFix{
+						Message: "Delete import alias",
+						TextEdits: []analysis.TextEdit{{
+							Pos: spec.Name.Pos(),
+							End: spec.Path.Pos(),
+						}},
+					}, "redundant import alias %s", spec.Name.Name)
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// hasSideEffects reports whether initializing the package of the pass
+// may have side effects.
+func hasSideEffects(pass *analysis.Pass) bool {
+	if pass.Pkg.Name() == "main" {
+		return true // can't be imported anyway
+	}
+	std := analysisinternal.IsStdPackage(pass.Pkg.Path())
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				if decl.Recv == nil && decl.Name.Name == "init" {
+					return true
+				}
+			case *ast.GenDecl:
+				// The standard library initializes many variables
+				// by calls, but that is not a side effect a user of
+				// the package would care about.
+				if std {
+					continue
+				}
+				for _, spec := range decl.Specs {
+					switch spec := spec.(type) {
+					case *ast.ImportSpec:
+						if spec.Name != nil && spec.Name.Name == "_" {
+							return true
+						}
+						if path, _ := strconv.Unquote(spec.Path.Value); path == "C" {
+							return true
+						}
+					case *ast.ValueSpec:
+						if decl.Tok == token.VAR && hasCall(pass.TypesInfo, spec) {
+							return true
+						}
+					}
+				}
+			}
+		}
+	}
+	if !std {
+		for _, imp := range pass.Pkg.Imports() {
+			if !analysisinternal.IsStdPackage(imp.Path()) && !pass.ImportPackageFact(imp, new(noInitFact)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasCall reports whether the values of a variable declaration call a
+// function, other than a builtin or type conversion.
+func hasCall(info *types.Info, spec *ast.ValueSpec) bool {
+	found := false
+	for _, value := range spec.Values {
+		ast.Inspect(value, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok {
+				if tv := info.Types[call.Fun]; !tv.IsType() && !tv.IsBuiltin() {
+					found = true
+				}
+			}
+			return !found
+		})
+	}
+	return found
+}
+
+// importedName returns the package name declared by an import spec,
+// or nil if the import is invalid.
+func importedName(info *types.Info, spec *ast.ImportSpec) *types.PkgName {
+	var obj types.Object
+	if spec.Name != nil {
+		obj = info.Defs[spec.Name]
+	} else {
+		obj = info.Implicits[spec]
+	}
+	pkgname, _ := obj.(*types.PkgName)
+	return pkgname
+}
+
+// isName reports whether the import spec has the explicit name.
+func isName(spec *ast.ImportSpec, name string) bool {
+	return spec.Name != nil && spec.Name.Name == name
+}
+
+// anyName reports whether any of the import specs has the explicit name.
+func anyName(specs []*ast.ImportSpec, name string) bool {
+	for _, spec := range specs {
+		if isName(spec, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteSpec returns the edits that delete an import spec of decl,
+// or the entire declaration if it has no other specs.
+func deleteSpec(fset *token.FileSet, decl *ast.GenDecl, spec *ast.ImportSpec) []analysis.TextEdit {
+	tf := fset.File(spec.Pos())
+	var start, end token.Pos
+	if len(decl.Specs) == 1 {
+		start, end = decl.Pos(), decl.End()
+		if decl.Doc != nil {
+			start = decl.Doc.Pos()
+		}
+	} else {
+		start, end = spec.Pos(), spec.End()
+		if spec.Doc != nil {
+			start = spec.Doc.Pos()
+		}
+		if spec.Comment != nil {
+			end = spec.Comment.End()
+		}
+	}
+	// Delete the entire lines if the deleted
+	// text is alone on them.
+	line := tf.Line(end)
+	if line < tf.LineCount() && aloneOnLines(fset, decl, spec, start, end) {
+		start, end = tf.LineStart(tf.Line(start)), tf.LineStart(line+1)
+	}
+	return []analysis.TextEdit{{Pos: start, End: end}}
+}
+
+// aloneOnLines reports whether the text from start to end, which
+// denotes an import spec or its entire declaration, has no other
+// syntax on the same lines.
+func aloneOnLines(fset *token.FileSet, decl *ast.GenDecl, spec *ast.ImportSpec, start, end token.Pos) bool {
+	line := func(pos token.Pos) int { return fset.Position(pos).Line }
+	if len(decl.Specs) == 1 {
+		return true // a declaration starts and ends on its own lines, in practice
+	}
+	for _, other := range decl.Specs {
+		if other != spec && (line(other.End()) == line(start) || line(other.Pos()) == line(end)) {
+			return false
+		}
+	}
+	return line(decl.Lparen) < line(start) && line(end) < line(decl.Rparen)
+}
@@ -0,0 +1,19 @@
+package a
+
+import (
+	"strings"
+	str "strings" // want `package "strings" is already imported as strings`
+
+	_ "example.com/drivers" // want `blank import of "example.com/drivers" has no effect`
+	_ "example.com/hasinit"
+	_ "example.com/noinit" // want `blank import of "example.com/noinit" has no effect`
+	_ "example.com/registry"
+	_ "example.com/transitive"
+
+	fmt "fmt" // want "redundant import alias fmt"
+)
+
+func f() {
+	fmt.Println(strings.ToUpper("a"), str.ToLower("B"))
+	fmt.Println(str.TrimSpace(" c "))
+}
@@ -0,0 +1,16 @@
+package a
+
+import (
+	_ "crypto/sha256"
+	_ "embed"
+	"os"
+	_ "os"      // want `package "os" is already imported as os`
+	_ "strconv" // want `blank import of "strconv" has no effect`
+)
+
+import _ "errors" // want `blank import of "errors" has no effect`
+
+//go:embed b.go
+var self string
+
+var _ = os.Args
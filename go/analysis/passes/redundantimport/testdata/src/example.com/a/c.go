@@ -0,0 +1,8 @@
+package a
+
+import (
+	_ "bytes"     // want `package "bytes" is already imported as bytes`
+	bytes "bytes" // want "redundant import alias bytes"
+)
+
+var _ bytes.Buffer
@@ -0,0 +1,7 @@
+package drivers
+
+import "example.com/noinit"
+
+var Name = noinit.Name
+
+var Zero = int64(0) // a conversion is not a call
@@ -0,0 +1,5 @@
+package hasinit
+
+var registered []string
+
+func init() { registered = append(registered, "hasinit") }
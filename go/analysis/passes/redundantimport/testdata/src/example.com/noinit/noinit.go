@@ -0,0 +1,9 @@
+package noinit
+
+import "fmt"
+
+var greeting = "hello"
+
+func Greet() { fmt.Println(greeting) }
+
+const Name = "noinit"
@@ -0,0 +1,3 @@
+package registry
+
+const Name = "registry"
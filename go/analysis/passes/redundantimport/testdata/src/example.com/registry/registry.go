@@ -0,0 +1,10 @@
+package registry
+
+import "example.com/noinit"
+
+var _ = register("registry")
+
+func register(name string) bool {
+	noinit.Greet()
+	return true
+}
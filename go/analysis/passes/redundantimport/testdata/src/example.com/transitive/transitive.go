@@ -0,0 +1,3 @@
+package transitive
+
+import _ "example.com/hasinit"
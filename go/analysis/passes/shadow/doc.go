@@ -30,4 +30,19 @@
 //		}
 //		return err
 //	}
+//
+// A variable that has the name of an imported package is reported
+// whatever its type, since it makes the package inaccessible.
+//
+// Diagnostics have one of the following categories, so that tools
+// may treat them differently: "named-return", for a variable that
+// shadows a named result of the function; "context", for a variable
+// that shadows a context.Context; and "import", for a variable that
+// shadows an imported package. Other diagnostics have no category.
+//
+// Each diagnostic suggests a fix that renames the shadowing variable.
+// If the variable is declared by a short variable declaration all of
+// whose new variables shadow variables of the same type, the
+// diagnostic also suggests a fix that turns it into an assignment to
+// the shadowed variables, as was probably intended in the example.
 package shadow
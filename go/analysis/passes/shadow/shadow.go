@@ -6,14 +6,18 @@ package shadow
 
 import (
 	_ "embed"
+	"fmt"
 	"go/ast"
 	"go/token"
 	"go/types"
 
 	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/defuse"
 	"golang.org/x/tools/go/analysis/passes/inspect"
 	"golang.org/x/tools/go/analysis/passes/internal/analysisutil"
+	"golang.org/x/tools/go/ast/edge"
 	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/internal/analysisinternal"
 )
 
 // NOTE: Experimental. Not part of the vet suite.
@@ -25,10 +29,19 @@ var Analyzer = &analysis.Analyzer{
 	Name:     "shadow",
 	Doc:      analysisutil.MustExtractDoc(doc, "shadow"),
 	URL:      "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/shadow",
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Requires: []*analysis.Analyzer{inspect.Analyzer, defuse.Analyzer},
 	Run:      run,
 }
 
+// Categories of diagnostics, which clients may use to treat them
+// differently. Diagnostics in none of these categories have no
+// category.
+const (
+	CategoryNamedReturn = "named-return" // shadows a named result of the function
+	CategoryContext     = "context"      // shadows a context.Context
+	CategoryImport      = "import"       // shadows an imported package name
+)
+
 // flags
 var strict = false
 
@@ -37,33 +50,10 @@ func init() {
 }
 
 func run(pass *analysis.Pass) (any, error) {
-	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
-
-	spans := make(map[types.Object]span)
-	for id, obj := range pass.TypesInfo.Defs {
-		// Ignore identifiers that don't denote objects
-		// (package names, symbolic variables such as t
-		// in t := x.(type) of type switch headers).
-		if obj != nil {
-			growSpan(spans, obj, id.Pos(), id.End())
-		}
-	}
-	for id, obj := range pass.TypesInfo.Uses {
-		growSpan(spans, obj, id.Pos(), id.End())
-	}
-	for node, obj := range pass.TypesInfo.Implicits {
-		// A type switch with a short variable declaration
-		// such as t := x.(type) doesn't declare the symbolic
-		// variable (t in the example) at the switch header;
-		// instead a new variable t (with specific type) is
-		// declared implicitly for each case. Such variables
-		// are found in the types.Info.Implicits (not Defs)
-		// map. Add them here, assuming they are declared at
-		// the type cases' colon ":".
-		if cc, ok := node.(*ast.CaseClause); ok {
-			growSpan(spans, obj, cc.Colon, cc.Colon)
-		}
-	}
+	var (
+		inspect = pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+		index   = pass.ResultOf[defuse.Analyzer].(*defuse.Index)
+	)
 
 	nodeFilter := []ast.Node{
 		(*ast.AssignStmt)(nil),
@@ -72,9 +62,9 @@ func run(pass *analysis.Pass) (any, error) {
 	inspect.Preorder(nodeFilter, func(n ast.Node) {
 		switch n := n.(type) {
 		case *ast.AssignStmt:
-			checkShadowAssignment(pass, spans, n)
+			checkShadowAssignment(pass, index, n)
 		case *ast.GenDecl:
-			checkShadowDecl(pass, spans, n)
+			checkShadowDecl(pass, index, n)
 		}
 	})
 	return nil, nil
@@ -109,27 +99,42 @@ func (s span) contains(pos token.Pos) bool {
 	return s.min <= pos && pos < s.max
 }
 
-// growSpan expands the span for the object to contain the source range [pos, end).
-func growSpan(spans map[types.Object]span, obj types.Object, pos, end token.Pos) {
-	if strict {
-		return // No need
-	}
-	s, ok := spans[obj]
-	if ok {
-		if s.min > pos {
-			s.min = pos
-		}
-		if s.max < end {
-			s.max = end
+// spanOf returns the span of the mentions of obj in the package, as
+// recorded by the index, or false if there are none.
+func spanOf(pass *analysis.Pass, index *defuse.Index, obj types.Object) (span, bool) {
+	var (
+		s  span
+		ok bool
+	)
+	grow := func(pos, end token.Pos) {
+		if !ok {
+			s, ok = span{pos, end}, true
 		}
-	} else {
-		s = span{pos, end}
+		s.min = min(s.min, pos)
+		s.max = max(s.max, end)
+	}
+	if cur, found := index.Def(obj); found {
+		grow(cur.Node().Pos(), cur.Node().End())
+	} else if obj.Pkg() == pass.Pkg && obj.Parent() != nil {
+		// A type switch with a short variable declaration
+		// such as t := x.(type) doesn't declare the symbolic
+		// variable (t in the example) at the switch header;
+		// instead a new variable t (with specific type) is
+		// declared implicitly for each case. Such variables
+		// are found in the types.Info.Implicits (not Defs)
+		// map. Assume they are declared at the start of the
+		// case clause whose scope they belong to.
+		pos := obj.Parent().Pos()
+		grow(pos, pos)
 	}
-	spans[obj] = s
+	for cur := range index.Uses(obj) {
+		grow(cur.Node().Pos(), cur.Node().End())
+	}
+	return s, ok
 }
 
 // checkShadowAssignment checks for shadowing in a short variable declaration.
-func checkShadowAssignment(pass *analysis.Pass, spans map[types.Object]span, a *ast.AssignStmt) {
+func checkShadowAssignment(pass *analysis.Pass, index *defuse.Index, a *ast.AssignStmt) {
 	if a.Tok != token.DEFINE {
 		return
 	}
@@ -142,7 +147,7 @@ func checkShadowAssignment(pass *analysis.Pass, spans map[types.Object]span, a *
 			pass.ReportRangef(expr, "invalid AST: short variable declaration of non-identifier")
 			return
 		}
-		checkShadowing(pass, spans, ident)
+		checkShadowing(pass, index, ident, a)
 	}
 }
 
@@ -202,7 +207,7 @@ func idiomaticRedecl(d *ast.ValueSpec) bool {
 }
 
 // checkShadowDecl checks for shadowing in a general variable declaration.
-func checkShadowDecl(pass *analysis.Pass, spans map[types.Object]span, d *ast.GenDecl) {
+func checkShadowDecl(pass *analysis.Pass, index *defuse.Index, d *ast.GenDecl) {
 	if d.Tok != token.VAR {
 		return
 	}
@@ -218,13 +223,14 @@ func checkShadowDecl(pass *analysis.Pass, spans map[types.Object]span, d *ast.Ge
 			return
 		}
 		for _, ident := range valueSpec.Names {
-			checkShadowing(pass, spans, ident)
+			checkShadowing(pass, index, ident, nil)
 		}
 	}
 }
 
 // checkShadowing checks whether the identifier shadows an identifier in an outer scope.
-func checkShadowing(pass *analysis.Pass, spans map[types.Object]span, ident *ast.Ident) {
+// If the identifier is declared by a short variable declaration, assign is that statement.
+func checkShadowing(pass *analysis.Pass, index *defuse.Index, ident *ast.Ident, assign *ast.AssignStmt) {
 	if ident.Name == "_" {
 		// Can't shadow the blank identifier.
 		return
@@ -251,7 +257,7 @@ func checkShadowing(pass *analysis.Pass, spans map[types.Object]span, ident *ast
 	} else {
 		// Don't complain if the span of validity of the shadowed identifier doesn't include
 		// the shadowing identifier.
-		span, ok := spans[shadowed]
+		span, ok := spanOf(pass, index, shadowed)
 		if !ok {
 			pass.ReportRangef(ident, "internal error: no range for %q", ident.Name)
 			return
@@ -260,9 +266,123 @@ func checkShadowing(pass *analysis.Pass, spans map[types.Object]span, ident *ast
 			return
 		}
 	}
+	line := pass.Fset.Position(shadowed.Pos()).Line
+
+	// A variable that shadows an imported package makes
+	// the package inaccessible, whatever its type.
+	if pkgname, ok := shadowed.(*types.PkgName); ok {
+		pass.Report(analysis.Diagnostic{
+			Pos:            ident.Pos(),
+			End:            ident.End(),
+			Category:       CategoryImport,
+			Message:        fmt.Sprintf("declaration of %q shadows import of package %q at line %d", obj.Name(), pkgname.Imported().Path(), line),
+			SuggestedFixes: []analysis.SuggestedFix{renameFix(index, ident, obj)},
+		})
+		return
+	}
+
 	// Don't complain if the types differ: that implies the programmer really wants two different things.
-	if types.Identical(obj.Type(), shadowed.Type()) {
-		line := pass.Fset.Position(shadowed.Pos()).Line
-		pass.ReportRangef(ident, "declaration of %q shadows declaration at line %d", obj.Name(), line)
+	if !types.Identical(obj.Type(), shadowed.Type()) {
+		return
+	}
+	var category string
+	switch {
+	case isNamedResult(index, shadowed):
+		category = CategoryNamedReturn
+	case analysisinternal.IsTypeNamed(shadowed.Type(), "context", "Context"):
+		category = CategoryContext
+	}
+	var fixes []analysis.SuggestedFix
+	if fix, ok := assignFix(pass, assign); ok {
+		fixes = append(fixes, fix)
+	}
+	fixes = append(fixes, renameFix(index, ident, obj))
+	pass.Report(analysis.Diagnostic{
+		Pos:            ident.Pos(),
+		End:            ident.End(),
+		Category:       category,
+		Message:        fmt.Sprintf("declaration of %q shadows declaration at line %d", obj.Name(), line),
+		SuggestedFixes: fixes,
+	})
+}
+
+// isNamedResult reports whether obj is a named result of a function
+// declared in this package.
+func isNamedResult(index *defuse.Index, obj types.Object) bool {
+	if _, ok := obj.(*types.Var); !ok {
+		return false
+	}
+	cur, ok := index.Def(obj)
+	if !ok {
+		return false
+	}
+	for _, want := range []edge.Kind{edge.Field_Names, edge.FieldList_List, edge.FuncType_Results} {
+		if k, _ := cur.ParentEdge(); k != want {
+			return false
+		}
+		cur = cur.Parent()
+	}
+	return true
+}
+
+// assignFix returns a fix that turns the short variable declaration
+// assign, if any, into an assignment to the variables it shadows. It
+// is offered only if every variable that the declaration declares
+// shadows a variable of the same type; otherwise the assignment
+// would leave some undeclared.
+func assignFix(pass *analysis.Pass, assign *ast.AssignStmt) (analysis.SuggestedFix, bool) {
+	if assign == nil {
+		return analysis.SuggestedFix{}, false
+	}
+	for _, lhs := range assign.Lhs {
+		obj := pass.TypesInfo.Defs[lhs.(*ast.Ident)]
+		if obj == nil || obj.Name() == "_" {
+			continue // redeclared or blank
+		}
+		_, shadowed := obj.Parent().Parent().LookupParent(obj.Name(), obj.Pos())
+		if _, ok := shadowed.(*types.Var); !ok || !types.Identical(obj.Type(), shadowed.Type()) {
+			return analysis.SuggestedFix{}, false
+		}
+	}
+	return analysis.SuggestedFix{
+		Message: "Assign to the shadowed variable",
+		TextEdits: []analysis.TextEdit{{
+			Pos:     assign.TokPos,
+			End:     assign.TokPos + token.Pos(len(":=")),
+			NewText: []byte("="),
+		}},
+	}, true
+}
+
+// renameFix returns a fix that renames the shadowing variable obj,
+// declared by ident, to a name that is fresh at its declaration and
+// at each of its uses.
+func renameFix(index *defuse.Index, ident *ast.Ident, obj types.Object) analysis.SuggestedFix {
+	fresh := func(name string) bool {
+		if obj.Parent().Lookup(name) != nil {
+			return false
+		}
+		if _, other := obj.Parent().LookupParent(name, ident.Pos()); other != nil {
+			return false
+		}
+		for cur := range index.Uses(obj) {
+			pos := cur.Node().Pos()
+			if _, other := obj.Parent().Innermost(pos).LookupParent(name, pos); other != nil {
+				return false
+			}
+		}
+		return true
+	}
+	name := obj.Name()
+	for i := 1; !fresh(name); i++ {
+		name = fmt.Sprintf("%s%d", obj.Name(), i)
+	}
+	edits := []analysis.TextEdit{{Pos: ident.Pos(), End: ident.End(), NewText: []byte(name)}}
+	for cur := range index.Uses(obj) {
+		edits = append(edits, analysis.TextEdit{Pos: cur.Node().Pos(), End: cur.Node().End(), NewText: []byte(name)})
+	}
+	return analysis.SuggestedFix{
+		Message:   "Rename the shadowing variable",
+		TextEdits: edits,
 	}
 }
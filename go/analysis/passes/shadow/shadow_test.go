@@ -14,4 +14,5 @@ import (
 func Test(t *testing.T) {
 	testdata := analysistest.TestData()
 	analysistest.Run(t, testdata, shadow.Analyzer, "a")
+	analysistest.RunWithSuggestedFixes(t, testdata, shadow.Analyzer, "fix")
 }
@@ -0,0 +1,35 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a
+
+import (
+	"context"
+	"net/url"
+)
+
+func namedResult(s string) (u *url.URL, err error) {
+	if s != "" {
+		u, err := url.Parse(s) // want "declaration of .u. shadows declaration at line 12" "declaration of .err. shadows declaration at line 12"
+		_, _ = u, err
+	}
+	return u, err
+}
+
+func shadowContext(ctx context.Context) {
+	if ctx != nil {
+		ctx, cancel := context.WithCancel(ctx) // want "declaration of .ctx. shadows declaration at line 20"
+		defer cancel()
+		_ = ctx
+	}
+	_ = ctx
+}
+
+func shadowImport(s string) {
+	if s != "" {
+		url := s // want "declaration of .url. shadows import of package .net/url. at line 9"
+		_ = url
+	}
+	_, _ = url.Parse(s)
+}
@@ -0,0 +1,36 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fix
+
+import "os"
+
+func read(f *os.File, buf []byte) (n int, err error) {
+	for {
+		n, err := f.Read(buf) // want "declaration of .n. shadows declaration at line 9" "declaration of .err. shadows declaration at line 9"
+		if err != nil {
+			break
+		}
+		_ = n
+	}
+	return n, err
+}
+
+func partial(f *os.File, buf []byte) error {
+	var err error
+	if f != nil {
+		m, err := f.Read(buf) // want "declaration of .err. shadows declaration at line 21"
+		_, _ = m, err
+	}
+	return err
+}
+
+func fresh(x int) int {
+	if x > 0 {
+		x1 := 1
+		var x = x1 + 1 // want "declaration of .x. shadows declaration at line 29"
+		return x
+	}
+	return x
+}
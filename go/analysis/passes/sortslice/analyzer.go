@@ -3,14 +3,18 @@
 // license that can be found in the LICENSE file.
 
 // Package sortslice defines an Analyzer that checks for calls
-// to sort.Slice that do not use a slice type as first argument.
+// to sort.Slice that do not use a slice type as first argument,
+// and for comparison functions of sort.Slice and slices.SortFunc
+// that are not strict weak orderings.
 package sortslice
 
 import (
 	"bytes"
 	"fmt"
 	"go/ast"
+	"go/constant"
 	"go/format"
+	"go/token"
 	"go/types"
 
 	"golang.org/x/tools/go/analysis"
@@ -20,10 +24,19 @@ import (
 	"golang.org/x/tools/internal/analysisinternal"
 )
 
-const Doc = `check the argument type of sort.Slice
+const Doc = `check the argument type of sort.Slice and the comparison functions of sorts
 
 sort.Slice requires an argument of a slice type. Check that
-the interface{} value passed to sort.Slice is actually a slice.`
+the interface{} value passed to sort.Slice is actually a slice.
+
+The comparison function of a sort must define a strict weak ordering.
+Check that the less function passed to sort.Slice, sort.SliceStable,
+or sort.SliceIsSorted does not return the result of a <= or >=
+comparison, which reports equal elements as less than each other,
+and that the function passed to slices.SortFunc, slices.SortStableFunc,
+or slices.IsSortedFunc, if it returns only constants, returns 0 for
+equal elements. Sorting with an invalid ordering may produce an
+incorrect order or panic.`
 
 var Analyzer = &analysis.Analyzer{
 	Name:     "sortslice",
@@ -34,8 +47,8 @@ var Analyzer = &analysis.Analyzer{
 }
 
 func run(pass *analysis.Pass) (any, error) {
-	if !analysisinternal.Imports(pass.Pkg, "sort") {
-		return nil, nil // doesn't directly import sort
+	if !analysisinternal.Imports(pass.Pkg, "sort") && !analysisinternal.Imports(pass.Pkg, "slices") {
+		return nil, nil // doesn't directly import sort or slices
 	}
 
 	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
@@ -47,10 +60,19 @@ func run(pass *analysis.Pass) (any, error) {
 	inspect.Preorder(nodeFilter, func(n ast.Node) {
 		call := n.(*ast.CallExpr)
 		obj := typeutil.Callee(pass.TypesInfo, call)
+		if analysisinternal.IsFunctionNamed(obj, "slices", "SortFunc", "SortStableFunc", "IsSortedFunc") {
+			if len(call.Args) == 2 {
+				checkCompare(pass, obj.(*types.Func), call.Args[1])
+			}
+			return
+		}
 		if !analysisinternal.IsFunctionNamed(obj, "sort", "Slice", "SliceStable", "SliceIsSorted") {
 			return
 		}
 		callee := obj.(*types.Func)
+		if len(call.Args) == 2 {
+			checkLess(pass, callee, call.Args[1])
+		}
 
 		arg := call.Args[0]
 		typ := pass.TypesInfo.Types[arg].Type
@@ -133,3 +155,86 @@ func run(pass *analysis.Pass) (any, error) {
 	})
 	return nil, nil
 }
+
+// checkLess reports the return statements of the less function of a
+// call to sort.Slice whose result is a <= or >= comparison, for which
+// equal elements are less than each other.
+func checkLess(pass *analysis.Pass, callee *types.Func, less ast.Expr) {
+	body := funcBody(pass, less)
+	if body == nil {
+		return
+	}
+	analysisinternal.InspectFunc(body, func(n ast.Node) {
+		ret, ok := n.(*ast.ReturnStmt)
+		if !ok || len(ret.Results) != 1 {
+			return
+		}
+		cmp, ok := ast.Unparen(ret.Results[0]).(*ast.BinaryExpr)
+		if !ok || cmp.Op != token.LEQ && cmp.Op != token.GEQ {
+			return
+		}
+		strict := token.LSS
+		if cmp.Op == token.GEQ {
+			strict = token.GTR
+		}
+		pass.Report(analysis.Diagnostic{
+			Pos:     cmp.Pos(),
+			End:     cmp.End(),
+			Message: fmt.Sprintf("less function of %s is not a strict weak ordering: %s reports equal elements as less; use %s", callee.FullName(), cmp.Op, strict),
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message: fmt.Sprintf("Use %s", strict),
+				TextEdits: []analysis.TextEdit{{
+					Pos:     cmp.OpPos,
+					End:     cmp.OpPos + token.Pos(len(cmp.Op.String())),
+					NewText: []byte(strict.String()),
+				}},
+			}},
+		})
+	})
+}
+
+// checkCompare reports the comparison function of a call to
+// slices.SortFunc whose return statements all return nonzero
+// constants, so that equal elements compare as unequal.
+func checkCompare(pass *analysis.Pass, callee *types.Func, cmp ast.Expr) {
+	body := funcBody(pass, cmp)
+	if body == nil {
+		return
+	}
+	returns, nonzero := 0, 0
+	analysisinternal.InspectFunc(body, func(n ast.Node) {
+		ret, ok := n.(*ast.ReturnStmt)
+		if !ok || len(ret.Results) != 1 {
+			return
+		}
+		returns++
+		if v := pass.TypesInfo.Types[ret.Results[0]].Value; v != nil && v.Kind() == constant.Int && constant.Sign(v) != 0 {
+			nonzero++
+		}
+	})
+	if returns > 0 && nonzero == returns {
+		pass.ReportRangef(cmp, "comparison function of %s is not a strict weak ordering: it never returns 0 for equal elements", callee.FullName())
+	}
+}
+
+// funcBody returns the body of the function denoted by e, a function
+// literal or a function declared in this package, or nil.
+func funcBody(pass *analysis.Pass, e ast.Expr) *ast.BlockStmt {
+	switch e := ast.Unparen(e).(type) {
+	case *ast.FuncLit:
+		return e.Body
+	case *ast.Ident:
+		fn, ok := pass.TypesInfo.Uses[e].(*types.Func)
+		if !ok || fn.Pkg() != pass.Pkg {
+			return nil
+		}
+		for _, file := range pass.Files {
+			for _, decl := range file.Decls {
+				if decl, ok := decl.(*ast.FuncDecl); ok && pass.TypesInfo.Defs[decl.Name] == fn {
+					return decl.Body
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -15,3 +15,6 @@ func Test(t *testing.T) {
 	testdata := analysistest.TestData()
 	analysistest.Run(t, testdata, sortslice.Analyzer, "a")
 }
+func TestComparators(t *testing.T) {
+	analysistest.RunWithSuggestedFixes(t, analysistest.TestData(), sortslice.Analyzer, "b")
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package b
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+)
+
+type person struct {
+	name string
+	age  int
+}
+
+func less(people []person) {
+	sort.Slice(people, func(i, j int) bool {
+		return people[i].age <= people[j].age // want `less function of sort.Slice is not a strict weak ordering: <= reports equal elements as less; use <`
+	})
+	sort.SliceStable(people, func(i, j int) bool {
+		if people[i].age != people[j].age {
+			return people[i].age > people[j].age
+		}
+		return (people[i].name >= people[j].name) // want `less function of sort.SliceStable is not a strict weak ordering: >= reports equal elements as less; use >`
+	})
+	sort.Slice(people, func(i, j int) bool {
+		return people[i].age < people[j].age
+	})
+	sort.Slice(people, func(i, j int) bool {
+		ok := func(x, y int) bool { return x <= y }
+		return ok(people[i].age, people[j].age)
+	})
+}
+
+func compare(people []person) {
+	slices.SortFunc(people, func(a, b person) int { // want `comparison function of slices.SortFunc is not a strict weak ordering: it never returns 0 for equal elements`
+		if a.age < b.age {
+			return -1
+		}
+		return 1
+	})
+	slices.SortStableFunc(people, byName) // want `comparison function of slices.SortStableFunc is not a strict weak ordering`
+	slices.SortFunc(people, func(a, b person) int {
+		if a.age < b.age {
+			return -1
+		}
+		if a.age > b.age {
+			return +1
+		}
+		return 0
+	})
+	slices.SortFunc(people, func(a, b person) int {
+		if a.age < b.age {
+			return -1
+		}
+		return cmp.Compare(a.name, b.name)
+	})
+}
+
+func byName(a, b person) int {
+	if a.name < b.name {
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,38 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package sqlinject defines an Analyzer that reports SQL queries built
+// from function parameters by string concatenation or formatting.
+//
+// # Analyzer sqlinject
+//
+// sqlinject: check for SQL queries built from parameters
+//
+// This analyzer reports calls to database/sql methods such as
+// (*sql.DB).Query and (*sql.Tx).Exec whose query argument is built by
+// string concatenation or by fmt.Sprintf from a string parameter of the
+// enclosing function, which may allow SQL injection:
+//
+//	func findUser(db *sql.DB, name string) (*sql.Rows, error) {
+//		return db.Query("SELECT * FROM users WHERE name = '" + name + "'")
+//	}
+//
+// Use a parameterized query instead, passing the values as arguments:
+//
+//	return db.Query("SELECT * FROM users WHERE name = ?", name)
+//
+// The query may also be built in a local variable before the call.
+// Parameters of numeric type are not considered, as they cannot
+// inject SQL syntax. The analysis is a heuristic: it does not report
+// queries built from other sources of untrusted data, and it may
+// report parameters that are trusted, such as table names chosen by
+// the caller.
+//
+// The -funcs flag adds functions and methods to those whose first
+// string parameter is a query, such as those of pgx or sqlx. It is a
+// comma-separated list of names in the form of (*types.Func).FullName,
+// for example:
+//
+//	-funcs='(*github.com/jackc/pgx/v5.Conn).Exec,(*github.com/jmoiron/sqlx.DB).Select'
+package sqlinject
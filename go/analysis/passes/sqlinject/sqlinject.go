@@ -0,0 +1,277 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package sqlinject defines an Analyzer that reports SQL queries built
+// from function parameters by string concatenation or formatting.
+package sqlinject
+
+import (
+	_ "embed"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/analysis/passes/internal/analysisutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+	"golang.org/x/tools/internal/analysisinternal"
+)
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "sqlinject",
+	Doc:      analysisutil.MustExtractDoc(doc, "sqlinject"),
+	URL:      "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/sqlinject",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func init() {
+	Analyzer.Flags.Var(&queryFuncs, "funcs",
+		"comma-separated list of additional functions or methods, such as (*github.com/jackc/pgx/v5.Conn).Exec, whose first string parameter is an SQL query")
+}
+
+// queryFuncs is the set of functions (identified by
+// (*types.Func).FullName) whose first string parameter is an SQL
+// query.
+//
+// The -funcs flag adds to this set.
+var queryFuncs = func() stringSetFlag {
+	funcs := make(stringSetFlag)
+	for _, recv := range []string{"DB", "Tx", "Conn"} {
+		for _, method := range []string{"Exec", "Query", "QueryRow", "Prepare"} {
+			funcs["(*database/sql."+recv+")."+method] = true
+			funcs["(*database/sql."+recv+")."+method+"Context"] = true
+		}
+	}
+	return funcs
+}()
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	// Record the values assigned to each local variable, so that
+	// queries built in a variable before the call can be checked.
+	assigned := make(map[*types.Var]*assignments)
+	record := func(id *ast.Ident, value ast.Expr, concat bool) {
+		if v, ok := pass.TypesInfo.ObjectOf(id).(*types.Var); ok && !v.IsField() {
+			a := assigned[v]
+			if a == nil {
+				a = new(assignments)
+				assigned[v] = a
+			}
+			a.values = append(a.values, value)
+			a.concat = a.concat || concat
+		}
+	}
+	for cur := range inspect.Root().Preorder((*ast.AssignStmt)(nil), (*ast.ValueSpec)(nil)) {
+		switch n := cur.Node().(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) != len(n.Rhs) {
+				continue
+			}
+			for i, lhs := range n.Lhs {
+				if id, ok := ast.Unparen(lhs).(*ast.Ident); ok {
+					record(id, n.Rhs[i], n.Tok == token.ADD_ASSIGN)
+				}
+			}
+		case *ast.ValueSpec:
+			if len(n.Names) != len(n.Values) {
+				continue
+			}
+			for i, id := range n.Names {
+				record(id, n.Values[i], false)
+			}
+		}
+	}
+
+	for cur := range inspect.Root().Preorder((*ast.CallExpr)(nil)) {
+		call := cur.Node().(*ast.CallExpr)
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok || !queryFuncs[fn.Origin().FullName()] {
+			continue
+		}
+		query := queryArg(fn.Signature(), call)
+		if query == nil {
+			continue
+		}
+
+		// Find the string parameters of the enclosing functions.
+		params := make(map[*types.Var]bool)
+		for c := range cur.Enclosing((*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)) {
+			var ftype *ast.FuncType
+			switch n := c.Node().(type) {
+			case *ast.FuncDecl:
+				ftype = n.Type
+			case *ast.FuncLit:
+				ftype = n.Type
+			}
+			for _, field := range ftype.Params.List {
+				for _, id := range field.Names {
+					if v, ok := pass.TypesInfo.Defs[id].(*types.Var); ok && isStringy(v.Type()) {
+						params[v] = true
+					}
+				}
+			}
+		}
+		if len(params) == 0 {
+			continue
+		}
+
+		c := &checker{info: pass.TypesInfo, assigned: assigned, params: params, seen: make(map[*types.Var]bool)}
+		if how := c.built(query); how != "" {
+			if param := c.derived(query); param != nil {
+				pass.ReportRangef(query,
+					"SQL query passed to %s is built by %s from parameter %s; use query parameters instead",
+					fn.Name(), how, param.Name())
+			}
+		}
+	}
+	return nil, nil
+}
+
+// assignments records the values assigned to a local variable.
+type assignments struct {
+	values []ast.Expr
+	concat bool // the variable is appended to by x += y
+}
+
+// A checker determines how a query is built, and from which parameter.
+type checker struct {
+	info     *types.Info
+	assigned map[*types.Var]*assignments
+	params   map[*types.Var]bool
+	seen     map[*types.Var]bool
+}
+
+// built returns a description of how the string expression e is
+// built, "string concatenation" or "fmt.Sprintf", or "" if it is
+// built by neither.
+func (c *checker) built(e ast.Expr) string {
+	if c.info.Types[e].Value != nil {
+		return "" // constant
+	}
+	switch e := ast.Unparen(e).(type) {
+	case *ast.BinaryExpr:
+		if e.Op == token.ADD {
+			return "string concatenation"
+		}
+	case *ast.CallExpr:
+		if analysisinternal.IsFunctionNamed(typeutil.Callee(c.info, e), "fmt", "Sprintf") {
+			return "fmt.Sprintf"
+		}
+	case *ast.Ident:
+		if v, ok := c.info.Uses[e].(*types.Var); ok && !c.params[v] && c.assigned[v] != nil && !c.seen[v] {
+			c.seen[v] = true
+			defer delete(c.seen, v)
+			a := c.assigned[v]
+			if a.concat {
+				return "string concatenation"
+			}
+			for _, value := range a.values {
+				if how := c.built(value); how != "" {
+					return how
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// derived returns the string parameter from which the expression e
+// is derived, directly or through the values of local variables,
+// or nil if there is none.
+func (c *checker) derived(e ast.Expr) (param *types.Var) {
+	ast.Inspect(e, func(n ast.Node) bool {
+		if param != nil {
+			return false
+		}
+		if e, ok := n.(ast.Expr); ok && c.info.Types[e].Value != nil {
+			return false // constant
+		}
+		id, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		v, ok := c.info.Uses[id].(*types.Var)
+		if !ok || v.IsField() || c.seen[v] {
+			return true
+		}
+		if c.params[v] {
+			param = v
+			return false
+		}
+		if a := c.assigned[v]; a != nil && isStringy(v.Type()) {
+			c.seen[v] = true
+			for _, value := range a.values {
+				if param = c.derived(value); param != nil {
+					break
+				}
+			}
+		}
+		return true
+	})
+	return param
+}
+
+// queryArg returns the argument of the call for the first parameter
+// of type string, or nil if there is none.
+func queryArg(sig *types.Signature, call *ast.CallExpr) ast.Expr {
+	for i := range sig.Params().Len() {
+		if sig.Variadic() && i == sig.Params().Len()-1 {
+			break
+		}
+		if isString(sig.Params().At(i).Type()) {
+			if i < len(call.Args) {
+				return call.Args[i]
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// isString reports whether t is a string type.
+func isString(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsString != 0
+}
+
+// isStringy reports whether values of type t may contain SQL syntax:
+// t is a string, byte slice, or slice of strings.
+func isStringy(t types.Type) bool {
+	if slice, ok := t.Underlying().(*types.Slice); ok {
+		if basic, ok := slice.Elem().Underlying().(*types.Basic); ok && basic.Kind() == types.Byte {
+			return true
+		}
+		return isString(slice.Elem())
+	}
+	return isString(t)
+}
+
+type stringSetFlag map[string]bool
+
+func (ss *stringSetFlag) String() string {
+	var items []string
+	for item := range *ss {
+		items = append(items, item)
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
+
+func (ss *stringSetFlag) Set(s string) error {
+	for name := range strings.SplitSeq(s, ",") {
+		if name != "" {
+			(*ss)[name] = true
+		}
+	}
+	return nil
+}
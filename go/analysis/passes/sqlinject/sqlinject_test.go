@@ -0,0 +1,23 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlinject_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/analysis/passes/sqlinject"
+)
+
+func Test(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), sqlinject.Analyzer, "a")
+}
+
+func TestFuncs(t *testing.T) {
+	if err := sqlinject.Analyzer.Flags.Set("funcs", "(*pgx.Conn).Exec,pgx.Select"); err != nil {
+		t.Fatal(err)
+	}
+	analysistest.Run(t, analysistest.TestData(), sqlinject.Analyzer, "b")
+}
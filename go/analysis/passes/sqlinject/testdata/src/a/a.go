@@ -0,0 +1,64 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const table = "users"
+
+func concat(db *sql.DB, name string) {
+	db.Query("SELECT * FROM users WHERE name = '" + name + "'") // want `SQL query passed to Query is built by string concatenation from parameter name; use query parameters instead`
+}
+
+func sprintf(ctx context.Context, tx *sql.Tx, name string) {
+	tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM users WHERE name = '%s'", name)) // want `SQL query passed to ExecContext is built by fmt.Sprintf from parameter name`
+}
+
+func variable(conn *sql.Conn, ctx context.Context, id string) {
+	query := "SELECT * FROM users"
+	query += " WHERE id = " + id
+	conn.QueryRowContext(ctx, query) // want `SQL query passed to QueryRowContext is built by string concatenation from parameter id`
+}
+
+func indirect(db *sql.DB, cols []string) {
+	list := strings.Join(cols, ", ")
+	query := fmt.Sprintf("SELECT %s FROM users", list)
+	db.Prepare(query) // want `built by fmt.Sprintf from parameter cols`
+}
+
+func funcLit(db *sql.DB) func(string) {
+	return func(name string) {
+		db.Exec("DELETE FROM users WHERE name = " + name) // want `from parameter name`
+	}
+}
+
+func placeholders(db *sql.DB, name string) {
+	db.Query("SELECT * FROM users WHERE name = ?", name) // ok: parameterized
+}
+
+func constant(db *sql.DB, name string) {
+	db.Query("SELECT * FROM "+table+" WHERE name = ?", name) // ok: constant
+}
+
+func number(db *sql.DB, id int) {
+	db.Query("SELECT * FROM users WHERE id = " + strconv.Itoa(id))         // ok: numeric parameter
+	db.Query(fmt.Sprintf("SELECT * FROM users WHERE id = %d", id))         // ok: numeric parameter
+	db.Query(fmt.Sprintf("SELECT * FROM users WHERE id = %d LIMIT 1", id)) // ok
+}
+
+func local(db *sql.DB, name string) {
+	column := "name"
+	db.Query("SELECT * FROM users WHERE "+column+" = ?", name) // ok: not derived from a parameter
+}
+
+func notBuilt(db *sql.DB, query string) {
+	db.Query(query) // ok: passed through
+}
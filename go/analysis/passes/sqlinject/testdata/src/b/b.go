@@ -0,0 +1,20 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package b
+
+import (
+	"context"
+	"fmt"
+
+	"pgx"
+)
+
+func f(ctx context.Context, conn *pgx.Conn, name string) {
+	conn.Exec(ctx, "DELETE FROM users WHERE name = "+name)    // want `SQL query passed to Exec is built by string concatenation from parameter name`
+	conn.Exec(ctx, "DELETE FROM users WHERE name = $1", name) // ok
+	conn.Ping(ctx, "hello "+name)                             // ok: not a query function
+	var dest []string
+	pgx.Select(&dest, fmt.Sprintf("SELECT %s FROM users", name)) // want `SQL query passed to Select is built by fmt.Sprintf from parameter name`
+}
@@ -0,0 +1,15 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pgx
+
+import "context"
+
+type Conn struct{}
+
+func (*Conn) Exec(ctx context.Context, sql string, args ...any) error { return nil }
+
+func (*Conn) Ping(ctx context.Context, msg string) error { return nil }
+
+func Select(dest any, query string, args ...any) error { return nil }
@@ -27,4 +27,15 @@
 //	Peek ReadByte ReadFrom ReadRune Scan Seek
 //	UnmarshalJSON UnreadByte UnreadRune WriteByte
 //	WriteTo
+//
+// Additional canonical methods, such as those of interfaces used
+// throughout an organization, may be registered by the -methods flag,
+// as a semicolon-separated list of signatures:
+//
+//	-methods='Validate(=context.Context) error;MarshalLogObject(=zapcore.ObjectEncoder) error'
+//
+// Types are written as in the diagnostics, qualified by package name.
+// As for the standard methods, a parameter whose type has an = prefix
+// must match for the method to be checked at all, so that unrelated
+// methods of the same name are not reported.
 package stdmethods
@@ -6,8 +6,11 @@ package stdmethods
 
 import (
 	_ "embed"
+	"fmt"
 	"go/ast"
+	"go/token"
 	"go/types"
+	"slices"
 	"strings"
 
 	"golang.org/x/tools/go/analysis"
@@ -16,6 +19,10 @@ import (
 	"golang.org/x/tools/go/ast/inspector"
 )
 
+func init() {
+	Analyzer.Flags.Var(extraMethods, "methods", "semicolon-separated list of additional canonical method signatures, such as 'Validate(=context.Context) error'")
+}
+
 //go:embed doc.go
 var doc string
 
@@ -66,6 +73,125 @@ var canonicalMethods = map[string]struct{ args, results []string }{
 	"WriteTo":       {[]string{"=io.Writer"}, []string{"int64", "error"}}, // io.WriterTo
 }
 
+// extraMethods lists the input and output types of additional
+// canonical methods registered by the -methods flag, in the same form
+// as canonicalMethods.
+var extraMethods = make(methodsFlag)
+
+// A methodsFlag is a flag.Value holding method signatures such as
+// "Validate(=context.Context) error", separated by semicolons.
+type methodsFlag map[string]struct{ args, results []string }
+
+func (m methodsFlag) String() string {
+	var list []string
+	for name, sig := range m {
+		s := name + "(" + strings.Join(sig.args, ", ") + ")"
+		if len(sig.results) == 1 {
+			s += " " + sig.results[0]
+		} else if len(sig.results) > 1 {
+			s += " (" + strings.Join(sig.results, ", ") + ")"
+		}
+		list = append(list, s)
+	}
+	slices.Sort(list)
+	return strings.Join(list, ";")
+}
+
+func (m methodsFlag) Set(flag string) error {
+	for spec := range strings.SplitSeq(flag, ";") {
+		spec = strings.TrimSpace(spec)
+		open := strings.IndexByte(spec, '(')
+		if open < 0 {
+			return fmt.Errorf("invalid method signature %q: missing parameters", spec)
+		}
+		name := strings.TrimSpace(spec[:open])
+		if !token.IsIdentifier(name) {
+			return fmt.Errorf("invalid method signature %q: bad method name", spec)
+		}
+		if _, ok := canonicalMethods[name]; ok {
+			return fmt.Errorf("method %s is already checked", name)
+		}
+		end := closingParen(spec, open)
+		if end < 0 {
+			return fmt.Errorf("invalid method signature %q: unbalanced parentheses", spec)
+		}
+		args, ok := splitTypes(spec[open+1 : end])
+		if !ok {
+			return fmt.Errorf("invalid method signature %q: bad parameters", spec)
+		}
+		var results []string
+		switch rest := strings.TrimSpace(spec[end+1:]); {
+		case rest == "":
+			results = []string{}
+		case rest[0] == '(':
+			if closingParen(rest, 0) != len(rest)-1 {
+				return fmt.Errorf("invalid method signature %q: bad results", spec)
+			}
+			results, ok = splitTypes(rest[1 : len(rest)-1])
+			if !ok {
+				return fmt.Errorf("invalid method signature %q: bad results", spec)
+			}
+		default:
+			results = []string{rest}
+		}
+		m[name] = struct{ args, results []string }{args, results}
+	}
+	return nil
+}
+
+// closingParen returns the index of the parenthesis that closes the
+// one at s[open], or -1.
+func closingParen(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitTypes splits a comma-separated list of types, ignoring the
+// commas within brackets, as in func(int, int) or map[K]V.
+func splitTypes(s string) ([]string, bool) {
+	list := []string{}
+	if strings.TrimSpace(s) == "" {
+		return list, true
+	}
+	depth, start := 0, 0
+	for i := 0; i <= len(s); i++ {
+		if i < len(s) {
+			switch s[i] {
+			case '(', '[', '{':
+				depth++
+				continue
+			case ')', ']', '}':
+				depth--
+				continue
+			case ',':
+				if depth > 0 {
+					continue
+				}
+			default:
+				continue
+			}
+		}
+		t := strings.TrimSpace(s[start:i])
+		if t == "" || t == "=" {
+			return nil, false
+		}
+		list = append(list, t)
+		start = i + 1
+	}
+	return list, true
+}
+
 func run(pass *analysis.Pass) (any, error) {
 	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 
@@ -94,7 +220,10 @@ func canonicalMethod(pass *analysis.Pass, id *ast.Ident) {
 	// Expected input/output.
 	expect, ok := canonicalMethods[id.Name]
 	if !ok {
-		return
+		expect, ok = extraMethods[id.Name]
+		if !ok {
+			return
+		}
 	}
 
 	// Actual input/output
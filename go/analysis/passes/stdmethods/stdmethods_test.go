@@ -19,3 +19,26 @@ func Test(t *testing.T) {
 func TestAnalyzeEncodingXML(t *testing.T) {
 	analysistest.Run(t, "", stdmethods.Analyzer, "encoding/xml")
 }
+
+func TestMethods(t *testing.T) {
+	flags := stdmethods.Analyzer.Flags
+	for _, spec := range []string{
+		"Validate",
+		"Validate(context.Context",
+		"Validate(, int) error",
+		"Validate() (error",
+		"3D() error",
+		"MarshalJSON() ([]byte, error)",
+	} {
+		if err := flags.Set("methods", spec); err == nil {
+			t.Errorf("Set(%q) succeeded, want error", spec)
+		}
+	}
+	if err := flags.Set("methods", "Validate(=context.Context) error; MarshalLogObject(=c.Encoder) error"); err != nil {
+		t.Fatal(err)
+	}
+	if err := flags.Set("methods", "Merge(map[string]int, map[string]int) (map[string]int, error);Split(=string) ([]string, func(int, int) bool)"); err != nil {
+		t.Fatal(err)
+	}
+	analysistest.Run(t, analysistest.TestData(), stdmethods.Analyzer, "c")
+}
@@ -0,0 +1,27 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package c
+
+import "context"
+
+type Encoder interface{ AddString(key, value string) }
+
+type T struct{}
+
+func (T) Validate(ctx context.Context) {} // want `method Validate\(ctx context.Context\) should have signature Validate\(context.Context\) error`
+
+func (T) MarshalLogObject(enc Encoder) error { return nil } // ok
+
+type U struct{}
+
+func (U) Validate() bool { return true } // ok: first parameter must be context.Context to trigger check
+
+func (U) MarshalLogObject(enc Encoder) {} // want `method MarshalLogObject\(enc c.Encoder\) should have signature MarshalLogObject\(c.Encoder\) error`
+
+type V struct{}
+
+func (V) Merge(a, b map[string]int) (map[string]int, error) { return nil, nil } // ok
+
+func (V) Split(s string) []string { return nil } // want `method Split\(s string\) \[\]string should have signature Split\(string\) \(\[\]string, func\(int, int\) bool\)`
@@ -0,0 +1,246 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package structtag
+
+// This file defines the checks of the tag keys enabled by the -keys flag,
+// such as those of popular encoding, database, and validation libraries.
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+func init() {
+	Analyzer.Flags.Var(&extraKeys, "keys",
+		"comma-separated list of additional struct tag keys to check: bson, env, gorm, validate, "+
+			"or any other key whose value has the form name,option,...")
+}
+
+// extraKeys is the set of additional tag keys to check, set by the -keys flag.
+var extraKeys keySetFlag
+
+// A tagSyntax describes the syntax of the values of a tag key.
+type tagSyntax struct {
+	// dups reports whether two fields of a struct may not have
+	// the same name, which is the value up to the first comma.
+	dups bool
+
+	// check reports an error if the tag value is malformed.
+	check func(value string) error
+}
+
+// tagSyntaxes defines the syntax of the tag keys known to the analyzer.
+// Other keys enabled by the -keys flag are assumed to have the form
+// "name,option,...", like json.
+var tagSyntaxes = map[string]tagSyntax{
+	// go.mongodb.org/mongo-driver/bson
+	"bson": {
+		dups:  true,
+		check: checkOptions("bson", "omitempty", "omitzero", "minsize", "truncate", "inline"),
+	},
+	// github.com/caarlos0/env
+	"env": {
+		dups:  true,
+		check: checkEnv,
+	},
+	// gorm.io/gorm
+	"gorm": {
+		check: checkGorm,
+	},
+	// github.com/go-playground/validator
+	"validate": {
+		check: checkValidate,
+	},
+}
+
+// syntaxOf returns the syntax of the values of the tag key.
+func syntaxOf(key string) tagSyntax {
+	if syntax, ok := tagSyntaxes[key]; ok {
+		return syntax
+	}
+	return tagSyntax{dups: true, check: checkOptions(key)}
+}
+
+// checkOptions returns a function that checks a value of the form
+// "name,option,...". If any options are given, they are the only
+// ones permitted.
+func checkOptions(key string, options ...string) func(string) error {
+	return func(value string) error {
+		_, opts, _ := strings.Cut(value, ",")
+		if opts == "" {
+			return nil
+		}
+		seen := make(map[string]bool)
+		for opt := range strings.SplitSeq(opts, ",") {
+			switch {
+			case opt == "":
+				return fmt.Errorf("empty option")
+			case seen[opt]:
+				return fmt.Errorf("duplicate option %q", opt)
+			case len(options) > 0 && !slices.Contains(options, opt):
+				return fmt.Errorf("unknown %s option %q", key, opt)
+			}
+			seen[opt] = true
+		}
+		return nil
+	}
+}
+
+// checkEnv checks the value of an env tag, which is an optional
+// environment variable name followed by options.
+func checkEnv(value string) error {
+	name, _, _ := strings.Cut(value, ",")
+	for i, r := range name {
+		if !(r == '_' || 'A' <= r && r <= 'Z' || 'a' <= r && r <= 'z' || i > 0 && '0' <= r && r <= '9') {
+			return fmt.Errorf("invalid environment variable name %q", name)
+		}
+	}
+	return checkOptions("env", "required", "notEmpty", "unset", "file", "expand", "init")(value)
+}
+
+// gormSettings is the set of gorm tag settings, in upper case, as
+// gorm treats them case-insensitively.
+var gormSettings = map[string]bool{
+	"-":                      true,
+	"<-":                     true,
+	"->":                     true,
+	"AUTOCREATETIME":         true,
+	"AUTOINCREMENT":          true,
+	"AUTOINCREMENTINCREMENT": true,
+	"AUTOUPDATETIME":         true,
+	"CHECK":                  true,
+	"COLUMN":                 true,
+	"COMMENT":                true,
+	"CONSTRAINT":             true,
+	"DEFAULT":                true,
+	"EMBEDDED":               true,
+	"EMBEDDEDPREFIX":         true,
+	"FOREIGNKEY":             true,
+	"INDEX":                  true,
+	"JOINFOREIGNKEY":         true,
+	"JOINREFERENCES":         true,
+	"MANY2MANY":              true,
+	"NOT NULL":               true,
+	"NOTNULL":                true,
+	"POLYMORPHIC":            true,
+	"POLYMORPHICTYPE":        true,
+	"POLYMORPHICID":          true,
+	"POLYMORPHICVALUE":       true,
+	"PRECISION":              true,
+	"PRIMARY_KEY":            true,
+	"PRIMARYKEY":             true,
+	"REFERENCES":             true,
+	"SCALE":                  true,
+	"SERIALIZER":             true,
+	"SIZE":                   true,
+	"TYPE":                   true,
+	"UNIQUE":                 true,
+	"UNIQUEINDEX":            true,
+}
+
+// checkGorm checks the value of a gorm tag, which is a
+// semicolon-separated list of settings of the form "name" or
+// "name:value".
+func checkGorm(value string) error {
+	seen := make(map[string]bool)
+	for setting := range strings.SplitSeq(value, ";") {
+		name, _, _ := strings.Cut(setting, ":")
+		name = strings.ToUpper(strings.TrimSpace(name))
+		if name == "" {
+			continue // gorm ignores empty settings, e.g. a trailing semicolon
+		}
+		if !gormSettings[name] {
+			return fmt.Errorf("unknown gorm setting %q", strings.TrimSpace(setting))
+		}
+		// Settings such as INDEX may appear more than once
+		// to add the field to several indexes.
+		if seen[name] && name != "INDEX" && name != "UNIQUEINDEX" {
+			return fmt.Errorf("duplicate gorm setting %q", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// checkValidate checks the value of a validate tag, which is a
+// comma-separated list of rules of the form "name" or "name=param",
+// possibly combined with "|". The dive rule starts a new list of rules
+// for the elements of a slice or map.
+func checkValidate(value string) error {
+	if value == "-" {
+		return nil
+	}
+	seen := make(map[string]bool)
+	keys := false
+	for rule := range strings.SplitSeq(value, ",") {
+		if rule == "" {
+			return fmt.Errorf("empty rule")
+		}
+		for alt := range strings.SplitSeq(rule, "|") {
+			name, _, _ := strings.Cut(alt, "=")
+			if name == "" {
+				return fmt.Errorf("empty rule in %q", rule)
+			}
+			if strings.ContainsAny(name, " \t") {
+				return fmt.Errorf("invalid rule name %q", name)
+			}
+		}
+		switch rule {
+		case "dive":
+			clear(seen) // new rules for the elements
+		case "keys":
+			if keys {
+				return fmt.Errorf("nested keys rule")
+			}
+			keys = true
+			clear(seen)
+		case "endkeys":
+			if !keys {
+				return fmt.Errorf("endkeys rule without keys")
+			}
+			keys = false
+			clear(seen)
+		default:
+			if seen[rule] {
+				return fmt.Errorf("duplicate rule %q", rule)
+			}
+			seen[rule] = true
+		}
+	}
+	if keys {
+		return fmt.Errorf("keys rule without endkeys")
+	}
+	return nil
+}
+
+// keySetFlag is a flag whose value is a comma-separated set of tag keys.
+type keySetFlag map[string]bool
+
+func (ks *keySetFlag) String() string {
+	var keys []string
+	for key := range *ks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
+
+func (ks *keySetFlag) Set(s string) error {
+	m := make(map[string]bool) // clobber previous value
+	for key := range strings.SplitSeq(s, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		if key == "json" || key == "xml" {
+			return fmt.Errorf("%s tags are always checked", key)
+		}
+		m[key] = true
+	}
+	*ks = m
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"go/ast"
 	"go/token"
 	"go/types"
+	"maps"
 	"path/filepath"
 	"reflect"
 	"slices"
@@ -26,7 +27,17 @@ import (
 
 const Doc = `check that struct field tags conform to reflect.StructTag.Get
 
-Also report certain struct tags (json, xml) used with unexported fields.`
+Also report certain struct tags (json, xml) used with unexported fields.
+
+The -keys flag enables checks of additional tag keys. For the keys
+bson, env, gorm, and validate, the analyzer knows the syntax used by
+the popular libraries that define them (go.mongodb.org/mongo-driver,
+github.com/caarlos0/env, gorm.io/gorm, and
+github.com/go-playground/validator), and reports malformed or unknown
+options, duplicate options or rules, and, for bson and env, fields of
+the same struct that use the same name. Any other key is assumed to
+have the form "name,option,...", like json, and is checked for empty
+or duplicate options and duplicate names.`
 
 var Analyzer = &analysis.Analyzer{
 	Name:             "structtag",
@@ -107,6 +118,8 @@ func checkCanonicalFieldTag(pass *analysis.Pass, field *types.Var, tag string, s
 			End:     field.Pos() + token.Pos(len(field.Name())),
 			Message: fmt.Sprintf("struct field tag %#q not compatible with reflect.StructTag.Get: %s", tag, err),
 		})
+	} else {
+		checkExtraKeys(pass, field, tag, seen)
 	}
 
 	// Check for use of json or xml tags with unexported fields.
@@ -138,6 +151,26 @@ func checkCanonicalFieldTag(pass *analysis.Pass, field *types.Var, tag string, s
 	}
 }
 
+// checkExtraKeys checks the values of the tag keys enabled by the
+// -keys flag in a single well-formed struct field tag.
+func checkExtraKeys(pass *analysis.Pass, field *types.Var, tag string, seen *namesSeen) {
+	for _, key := range slices.Sorted(maps.Keys(extraKeys)) {
+		syntax := syntaxOf(key)
+		if syntax.dups {
+			checkTagDuplicates(pass, tag, key, field, field, seen, 1)
+		}
+		if value, ok := reflect.StructTag(tag).Lookup(key); ok {
+			if err := syntax.check(value); err != nil {
+				pass.Report(analysis.Diagnostic{
+					Pos:     field.Pos(),
+					End:     field.Pos() + token.Pos(len(field.Name())),
+					Message: fmt.Sprintf("struct field %s has malformed %s tag %q: %s", field.Name(), key, value, err),
+				})
+			}
+		}
+	}
+}
+
 // checkTagDuplicates checks a single struct field tag to see if any tags are
 // duplicated. nearest is the field that's closest to the field being checked,
 // while still being part of the top-level struct type.
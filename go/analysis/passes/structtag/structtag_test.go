@@ -15,3 +15,10 @@ func Test(t *testing.T) {
 	testdata := analysistest.TestData()
 	analysistest.Run(t, testdata, structtag.Analyzer, "a")
 }
+
+func TestExtraKeys(t *testing.T) {
+	testdata := analysistest.TestData()
+	structtag.Analyzer.Flags.Set("keys", "bson,env,gorm,validate,yaml")
+	defer structtag.Analyzer.Flags.Set("keys", "")
+	analysistest.Run(t, testdata, structtag.Analyzer, "extrakeys")
+}
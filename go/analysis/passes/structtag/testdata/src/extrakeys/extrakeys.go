@@ -0,0 +1,55 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains tests for the -keys flag.
+
+package extrakeys
+
+type BSON struct {
+	A int `bson:"a"`
+	B int `bson:"b,omitempty,inline"`
+	C int `bson:"a"`               // want `struct field C repeats bson tag "a" also at extrakeys.go:10`
+	D int `bson:"d,omitempt"`      // want `struct field D has malformed bson tag "d,omitempt": unknown bson option "omitempt"`
+	E int `bson:"e,,minsize"`      // want `struct field E has malformed bson tag "e,,minsize": empty option`
+	F int `bson:"f,inline,inline"` // want `struct field F has malformed bson tag "f,inline,inline": duplicate option "inline"`
+	G int `bson:"-"`
+	H int `bson:"-"`
+}
+
+type Env struct {
+	Home  string             `env:"HOME,required"`
+	Path  string             `env:"PATH,expand,notEmpty"`
+	Home2 string             `env:"HOME"`         // want `struct field Home2 repeats env tag "HOME" also at extrakeys.go:21`
+	Bad   string             `env:"MY-VAR"`       // want `struct field Bad has malformed env tag "MY-VAR": invalid environment variable name "MY-VAR"`
+	Req   string             `env:"REQ,requried"` // want `unknown env option "requried"`
+	Num   string             `env:"1ST"`          // want `invalid environment variable name "1ST"`
+	Inner struct{ X string } `env:",init"`
+}
+
+type Gorm struct {
+	ID    uint   `gorm:"primaryKey;autoIncrement"`
+	Name  string `gorm:"column:name;type:varchar(100);not null;index:idx_name;index:idx_both"`
+	Email string `gorm:"uniqueIndex;size:255;"`
+	Bad   string `gorm:"column:bad;colunm:bad2"` // want `struct field Bad has malformed gorm tag "column:bad;colunm:bad2": unknown gorm setting "colunm:bad2"`
+	Dup   string `gorm:"size:10;SIZE:20"`        // want `duplicate gorm setting "SIZE"`
+}
+
+type Validate struct {
+	A string         `validate:"required,email"`
+	B string         `validate:"omitempty,min=1,max=10|eq=foo"`
+	C []string       `validate:"required,dive,required,min=1"`
+	D map[string]int `validate:"dive,keys,min=1,endkeys,required"`
+	E string         `validate:"required,,email"`   // want `struct field E has malformed validate tag "required,,email": empty rule`
+	F string         `validate:"required,required"` // want `duplicate rule "required"`
+	G string         `validate:"min=1|"`            // want `empty rule in "min=1|"`
+	H map[string]int `validate:"dive,keys,min=1"`   // want `keys rule without endkeys`
+	I string         `validate:"-"`
+}
+
+type Custom struct {
+	A int `yaml:"a,omitempty"`
+	B int `yaml:"a"`       // want `struct field B repeats yaml tag "a" also at extrakeys.go:51`
+	C int `yaml:"c,,flow"` // want `struct field C has malformed yaml tag "c,,flow": empty option`
+	D int `toml:"d" toml2:"x"`
+}
@@ -13,6 +13,16 @@
 // malformed names, wrong signatures and examples documenting non-existent
 // identifiers.
 //
+// For fuzz functions, it checks the fuzz target passed to f.Fuzz, that
+// the values passed to f.Add match the parameters of the fuzz target,
+// and that f.Fuzz is called only once.
+//
+// For benchmarks, it reports a benchmark that ignores b.N, and so
+// measures a single run of its code regardless of the iteration count,
+// and a benchmark whose loop over b.N follows setup that calls
+// functions without a call to b.ResetTimer, so that the setup is
+// included in the measured time.
+//
 // Please see the documentation for package testing in golang.org/pkg/testing
 // for the conventions that are enforced for Tests, Benchmarks, and Examples.
 package tests
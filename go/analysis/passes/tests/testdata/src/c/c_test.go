@@ -0,0 +1,122 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package c
+
+import (
+	"strings"
+	"testing"
+)
+
+func FuzzTwice(f *testing.F) {
+	f.Fuzz(func(t *testing.T, s string) {})
+	f.Fuzz(func(t *testing.T, s string) {}) // want `\(\*testing.F\).Fuzz must not be called more than once`
+}
+
+func FuzzBranches(f *testing.F) {
+	if testing.Short() {
+		f.Fuzz(func(t *testing.T, s string) {})
+	} else {
+		f.Fuzz(func(t *testing.T, s string) {}) // OK
+	}
+}
+
+func FuzzAdd(f *testing.F) {
+	const n = 2
+	f.Add(1, "a")        // want `mismatched type in call to \(\*testing.F\).Add: int, fuzz target expects int64`
+	f.Add(int64(1), 'a') // want `mismatched type in call to \(\*testing.F\).Add: rune, fuzz target expects string`
+	f.Add(int64(n), "b") // OK
+	f.Add(n, "c")        // want `mismatched type in call to \(\*testing.F\).Add: int, fuzz target expects int64`
+	f.Add(1.5, "d")      // want `mismatched type in call to \(\*testing.F\).Add: float64, fuzz target expects int64`
+	f.Fuzz(func(t *testing.T, i int64, s string) {})
+}
+
+func FuzzAddBytes(f *testing.F) {
+	f.Add("seed") // want `mismatched type in call to \(\*testing.F\).Add: string, fuzz target expects \[\]byte`
+	f.Fuzz(func(t *testing.T, data []byte) {})
+}
+
+func BenchmarkIgnoresN(b *testing.B) { // want `BenchmarkIgnoresN ignores b.N: the benchmarked code must run b.N times`
+	s := strings.Repeat("x", 100)
+	_ = strings.ToUpper(s) // comment
+}
+
+func BenchmarkRawString(bench *testing.B) { // want `BenchmarkRawString ignores bench.N: the benchmarked code must run bench.N times`
+	_ = strings.Fields(`a
+b`)
+
+	_ = strings.Fields("c")
+}
+
+func BenchmarkLoop(b *testing.B) {
+	s := strings.Repeat("x", 100)
+	for b.Loop() {
+		_ = strings.ToUpper(s)
+	}
+}
+
+func BenchmarkHelper(b *testing.B) {
+	helper(b) // OK: helper may use b.N
+}
+
+func helper(b *testing.B) {
+	for range b.N {
+	}
+}
+
+func BenchmarkSub(b *testing.B) {
+	b.Run("sub", func(b *testing.B) {
+		for range b.N {
+		}
+	})
+}
+
+func BenchmarkParallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+		}
+	})
+}
+
+func BenchmarkEmpty(b *testing.B) {}
+
+func BenchmarkNoReset(b *testing.B) {
+	s := strings.Repeat("x", 100)
+	b.ReportAllocs()
+	for range b.N { // want `BenchmarkNoReset does not call b.ResetTimer after its setup: the setup is included in the measured time`
+		_ = strings.ToUpper(s)
+	}
+}
+
+func BenchmarkNoResetClassic(b *testing.B) {
+	s := strings.Repeat("x", 100)
+	for i := 0; i < b.N; i++ { // want `BenchmarkNoResetClassic does not call b.ResetTimer after its setup`
+		_ = strings.ToUpper(s)
+	}
+}
+
+func BenchmarkReset(b *testing.B) {
+	s := strings.Repeat("x", 100)
+	b.ResetTimer()
+	for range b.N {
+		_ = strings.ToUpper(s)
+	}
+}
+
+func BenchmarkCheapSetup(b *testing.B) {
+	s := make([]int, 100)
+	b.ReportAllocs()
+	for range b.N {
+		_ = len(s)
+	}
+}
+
+func BenchmarkTimer(b *testing.B) {
+	for range b.N {
+		b.StopTimer()
+		s := strings.Repeat("x", 100)
+		b.StartTimer()
+		_ = strings.ToUpper(s)
+	}
+}
@@ -0,0 +1,17 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.21
+
+package c
+
+import (
+	"strings"
+	"testing"
+)
+
+func BenchmarkOld(b *testing.B) { // want `BenchmarkOld ignores b.N`
+	i := 1
+	_ = strings.Repeat("x", i)
+}
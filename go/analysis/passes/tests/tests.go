@@ -6,10 +6,12 @@ package tests
 
 import (
 	_ "embed"
+	"fmt"
 	"go/ast"
 	"go/token"
 	"go/types"
 	"regexp"
+	"slices"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -17,6 +19,7 @@ import (
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/internal/analysisutil"
 	"golang.org/x/tools/internal/analysisinternal"
+	"golang.org/x/tools/internal/versions"
 )
 
 //go:embed doc.go
@@ -66,6 +69,7 @@ func run(pass *analysis.Pass) (any, error) {
 				checkTest(pass, fn, "Test")
 			case strings.HasPrefix(fn.Name.Name, "Benchmark"):
 				checkTest(pass, fn, "Benchmark")
+				checkBenchmark(pass, f, fn)
 			case strings.HasPrefix(fn.Name.Name, "Fuzz"):
 				checkTest(pass, fn, "Fuzz")
 				checkFuzz(pass, fn)
@@ -81,6 +85,27 @@ func checkFuzz(pass *analysis.Pass, fn *ast.FuncDecl) {
 	if params != nil {
 		checkAddCalls(pass, fn, params)
 	}
+	checkFuzzCalls(pass, fn)
+}
+
+// checkFuzzCalls reports the calls to (*testing.F).Fuzz, among the
+// statements of the fuzz function, that follow the first one:
+// (*testing.F).Fuzz panics if it is called more than once.
+func checkFuzzCalls(pass *analysis.Pass, fn *ast.FuncDecl) {
+	if fn.Body == nil {
+		return
+	}
+	called := false
+	for _, stmt := range fn.Body.List {
+		if stmt, ok := stmt.(*ast.ExprStmt); ok {
+			if call, ok := stmt.X.(*ast.CallExpr); ok && isFuzzTargetDotFuzz(pass, call) {
+				if called {
+					pass.ReportRangef(call, "(*testing.F).Fuzz must not be called more than once")
+				}
+				called = true
+			}
+		}
+	}
 }
 
 // checkFuzzCall checks the arguments of f.Fuzz() calls:
@@ -184,7 +209,29 @@ func checkAddCalls(pass *analysis.Pass, fn *ast.FuncDecl, params *types.Tuple) {
 				i := mismatched[0]
 				expr := call.Args[i]
 				t := pass.TypesInfo.Types[expr].Type
-				pass.ReportRangef(expr, "mismatched type in call to (*testing.F).Add: %v, fuzz target expects %v", t, params.At(i+1).Type())
+				want := params.At(i + 1).Type()
+				diag := analysis.Diagnostic{
+					Pos:     expr.Pos(),
+					End:     expr.End(),
+					Message: fmt.Sprintf("mismatched type in call to (*testing.F).Add: %v, fuzz target expects %v", t, want),
+				}
+				// A constant, such as 1 for an int64 parameter,
+				// may be converted to the expected type, unless
+				// the conversion would yield the string of a rune.
+				if pass.TypesInfo.Types[expr].Value != nil && !types.Identical(want, types.Typ[types.String]) {
+					conv := fmt.Sprintf("%s(%s)", types.TypeString(want, nil), analysisinternal.Format(pass.Fset, expr))
+					if _, err := types.Eval(pass.Fset, pass.Pkg, expr.Pos(), conv); err == nil {
+						diag.SuggestedFixes = []analysis.SuggestedFix{{
+							Message: fmt.Sprintf("Convert to %s", types.TypeString(want, nil)),
+							TextEdits: []analysis.TextEdit{{
+								Pos:     expr.Pos(),
+								End:     expr.End(),
+								NewText: []byte(conv),
+							}},
+						}}
+					}
+				}
+				pass.Report(diag)
 			} else if len(mismatched) > 1 {
 				var gotArgs, wantArgs []types.Type
 				for i := 0; i < len(call.Args); i++ {
@@ -473,3 +520,218 @@ func checkTest(pass *analysis.Pass, fn *ast.FuncDecl, prefix string) {
 		pass.ReportRangef(fn.Name, "%s has malformed name: first letter after '%s' must not be lowercase", fn.Name.Name, prefix)
 	}
 }
+
+// checkBenchmark checks that a benchmark runs its code b.N times, and
+// that it resets the timer after any setup that precedes its loop.
+func checkBenchmark(pass *analysis.Pass, file *ast.File, fn *ast.FuncDecl) {
+	if !isTestSuffix(fn.Name.Name[len("Benchmark"):]) ||
+		fn.Type.TypeParams != nil ||
+		fn.Type.Results != nil && len(fn.Type.Results.List) > 0 ||
+		len(fn.Type.Params.List) != 1 ||
+		len(fn.Type.Params.List[0].Names) != 1 ||
+		fn.Body == nil || len(fn.Body.List) == 0 {
+		return
+	}
+	b, ok := pass.TypesInfo.Defs[fn.Type.Params.List[0].Names[0]].(*types.Var)
+	if !ok || b.Name() == "_" || !isTestingType(b.Type(), "B") {
+		return
+	}
+
+	// Find the fields and methods of b used by the benchmark.
+	// If b is used otherwise, such as when it is passed to a helper
+	// function, the helper may use b.N.
+	used := make(map[string]bool)
+	uses, selections := 0, 0
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			if isVar(pass.TypesInfo, n.X, b) {
+				used[n.Sel.Name] = true
+				selections++
+			}
+		case *ast.Ident:
+			if pass.TypesInfo.Uses[n] == b {
+				uses++
+			}
+		}
+		return true
+	})
+	if uses > selections {
+		return
+	}
+
+	switch {
+	case used["Loop"], used["Run"], used["RunParallel"]:
+		// b.Loop excludes the setup from the measurement itself;
+		// sub-benchmarks and parallel benchmarks have their own loops.
+	case !used["N"]:
+		diag := analysis.Diagnostic{
+			Pos:     fn.Name.Pos(),
+			End:     fn.Name.End(),
+			Message: fmt.Sprintf("%s ignores %s.N: the benchmarked code must run %s.N times", fn.Name.Name, b.Name(), b.Name()),
+		}
+		if fix, ok := loopFix(pass, file, fn, b); ok {
+			diag.SuggestedFixes = []analysis.SuggestedFix{fix}
+		}
+		pass.Report(diag)
+	case !used["ResetTimer"] && !used["StopTimer"] && !used["StartTimer"]:
+		checkResetTimer(pass, fn, b)
+	}
+}
+
+// loopFix returns a fix that runs the body of the benchmark in a loop
+// of b.N iterations, using b.Loop where the Go version allows it.
+func loopFix(pass *analysis.Pass, file *ast.File, fn *ast.FuncDecl, b *types.Var) (analysis.SuggestedFix, bool) {
+	first, last := fn.Body.List[0], fn.Body.List[len(fn.Body.List)-1]
+	tf := pass.Fset.File(first.Pos())
+	content, err := pass.ReadFile(tf.Name())
+	if err != nil {
+		return analysis.SuggestedFix{}, false
+	}
+	start := tf.LineStart(tf.Line(first.Pos()))
+	indent := string(content[tf.Offset(start):tf.Offset(first.Pos())])
+	if strings.TrimSpace(indent) != "" {
+		return analysis.SuggestedFix{}, false // not at the start of its line
+	}
+	eol := token.Pos(tf.Base() + tf.Size())
+	if line := tf.Line(last.End()); line < tf.LineCount() {
+		eol = tf.LineStart(line+1) - 1
+	}
+	if rest := strings.TrimSpace(string(content[tf.Offset(last.End()):tf.Offset(eol)])); rest != "" && !strings.HasPrefix(rest, "//") {
+		return analysis.SuggestedFix{}, false // not at the end of its line
+	}
+
+	var header string
+	loop, _, _ := types.LookupFieldOrMethod(b.Type(), true, nil, "Loop")
+	switch v := versions.FileVersion(pass.TypesInfo, file); {
+	case versions.AtLeast(v, "go1.24") && loop != nil:
+		header = fmt.Sprintf("for %s.Loop() {", b.Name())
+	case versions.AtLeast(v, versions.Go1_22):
+		header = fmt.Sprintf("for range %s.N {", b.Name())
+	default:
+		i := analysisinternal.FreshName(pass.TypesInfo.Scopes[fn.Type], first.Pos(), "i")
+		header = fmt.Sprintf("for %s := 0; %s < %s.N; %s++ {", i, i, b.Name(), i)
+	}
+
+	// Indent each line of the body, other than blank lines and
+	// lines within a multi-line string literal.
+	var lits []*ast.BasicLit
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		if lit, ok := n.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			lits = append(lits, lit)
+		}
+		return true
+	})
+	edits := []analysis.TextEdit{{
+		Pos:     start,
+		End:     start,
+		NewText: []byte(indent + header + "\n\t"),
+	}}
+	for line := tf.Line(first.Pos()) + 1; line <= tf.Line(last.End()); line++ {
+		pos := tf.LineStart(line)
+		if content[tf.Offset(pos)] == '\n' || slices.ContainsFunc(lits, func(lit *ast.BasicLit) bool {
+			return lit.Pos() < pos && pos < lit.End()
+		}) {
+			continue
+		}
+		edits = append(edits, analysis.TextEdit{Pos: pos, End: pos, NewText: []byte("\t")})
+	}
+	edits = append(edits, analysis.TextEdit{
+		Pos:     eol,
+		End:     eol,
+		NewText: []byte("\n" + indent + "}"),
+	})
+	return analysis.SuggestedFix{
+		Message:   fmt.Sprintf("Run the benchmark body %s.N times", b.Name()),
+		TextEdits: edits,
+	}, true
+}
+
+// checkResetTimer reports a benchmark whose loop over b.N is preceded
+// by setup that calls functions, without a call to b.ResetTimer.
+func checkResetTimer(pass *analysis.Pass, fn *ast.FuncDecl, b *types.Var) {
+	// Find the first loop over b.N among the statements of the body.
+	var (
+		loop  ast.Stmt
+		setup []ast.Stmt
+	)
+	for i, stmt := range fn.Body.List {
+		var header ast.Node
+		switch stmt := stmt.(type) {
+		case *ast.ForStmt:
+			header = stmt.Cond
+		case *ast.RangeStmt:
+			header = stmt.X
+		}
+		if header != nil && usesN(pass.TypesInfo, header, b) {
+			loop, setup = stmt, fn.Body.List[:i]
+			break
+		}
+	}
+	if loop == nil {
+		return
+	}
+
+	// Setup that calls only builtins and the methods of b is
+	// assumed to be cheap.
+	expensive := false
+	for _, stmt := range setup {
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				return false // not executed unless called
+			case *ast.CallExpr:
+				tv := pass.TypesInfo.Types[n.Fun]
+				if sel, ok := ast.Unparen(n.Fun).(*ast.SelectorExpr); ok && isVar(pass.TypesInfo, sel.X, b) {
+					break
+				}
+				if !tv.IsType() && !tv.IsBuiltin() {
+					expensive = true
+				}
+			}
+			return !expensive
+		})
+	}
+	if !expensive {
+		return
+	}
+
+	diag := analysis.Diagnostic{
+		Pos:     loop.Pos(),
+		End:     loop.Pos() + token.Pos(len("for")),
+		Message: fmt.Sprintf("%s does not call %s.ResetTimer after its setup: the setup is included in the measured time", fn.Name.Name, b.Name()),
+	}
+	tf := pass.Fset.File(loop.Pos())
+	if content, err := pass.ReadFile(tf.Name()); err == nil {
+		indent := string(content[tf.Offset(tf.LineStart(tf.Line(loop.Pos()))):tf.Offset(loop.Pos())])
+		if strings.TrimSpace(indent) == "" {
+			diag.SuggestedFixes = []analysis.SuggestedFix{{
+				Message: fmt.Sprintf("Call %s.ResetTimer before the loop", b.Name()),
+				TextEdits: []analysis.TextEdit{{
+					Pos:     loop.Pos(),
+					End:     loop.Pos(),
+					NewText: fmt.Appendf(nil, "%s.ResetTimer()\n%s", b.Name(), indent),
+				}},
+			}}
+		}
+	}
+	pass.Report(diag)
+}
+
+// usesN reports whether n refers to the field N of the variable b.
+func usesN(info *types.Info, n ast.Node, b *types.Var) bool {
+	found := false
+	ast.Inspect(n, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok && sel.Sel.Name == "N" && isVar(info, sel.X, b) {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// isVar reports whether e is a reference to the variable v.
+func isVar(info *types.Info, e ast.Expr, v *types.Var) bool {
+	id, ok := ast.Unparen(e).(*ast.Ident)
+	return ok && info.Uses[id] == v
+}
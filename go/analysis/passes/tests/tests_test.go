@@ -20,3 +20,8 @@ func Test(t *testing.T) {
 		"typeparams",
 	)
 }
+
+func TestFixes(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, tests.Analyzer, "c")
+}
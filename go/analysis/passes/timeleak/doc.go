@@ -0,0 +1,35 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package timeleak defines an Analyzer that reports uses of time.After
+// and time.Tick that waste or leak timers.
+//
+// # Analyzer timeleak
+//
+// timeleak: check for wasteful uses of time.After and time.Tick
+//
+// A call to time.After in a case of a select statement within a loop
+// creates a new timer on each iteration of the loop:
+//
+//	for {
+//		select {
+//		case m := <-msgs:
+//			handle(m)
+//		case <-time.After(time.Minute): // "time.After in select within a loop creates a new timer on each iteration"
+//			return errIdle
+//		}
+//	}
+//
+// Not only is this wasteful, but the timeout is restarted whenever
+// another case is chosen, which may not be what was intended. Before
+// Go 1.23, each timer also remained in memory until it fired. Use a
+// single timer created by time.NewTimer, and call its Reset method as
+// needed, and its Stop method when done.
+//
+// In files whose Go version is before Go 1.23, the analyzer also
+// reports calls to time.Tick outside of the main function, because
+// the underlying ticker can never be stopped or garbage collected, so
+// it leaks if the caller stops receiving from its channel. Use
+// time.NewTicker, and call its Stop method when done.
+package timeleak
@@ -0,0 +1,76 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package a
+
+import (
+	"errors"
+	"time"
+)
+
+func selectLoop(msgs <-chan string) error {
+	for {
+		select {
+		case m := <-msgs:
+			println(m)
+		case <-time.After(time.Minute): // want "time.After in select within a loop creates a new timer on each iteration"
+			return errors.New("idle")
+		}
+	}
+}
+
+func selectRange(items []string, done <-chan struct{}) {
+	for range items {
+		select {
+		case <-done:
+			return
+		case t := <-time.After(time.Second): // want "time.After in select"
+			println(t.String())
+		}
+	}
+}
+
+func selectOnce(msgs <-chan string) {
+	select { // ok: not in a loop
+	case m := <-msgs:
+		println(m)
+	case <-time.After(time.Minute):
+	}
+}
+
+func funcLitInLoop(msgs <-chan string) {
+	for range 3 {
+		go func() {
+			select { // ok: loop is in another function
+			case m := <-msgs:
+				println(m)
+			case <-time.After(time.Minute):
+			}
+		}()
+	}
+}
+
+func notSelect() {
+	for range 3 {
+		<-time.After(time.Second) // ok: not in a select
+	}
+}
+
+func timer(msgs <-chan string) {
+	t := time.NewTimer(time.Minute)
+	defer t.Stop()
+	for {
+		select {
+		case m := <-msgs:
+			println(m)
+		case <-t.C:
+			return
+		}
+	}
+}
+
+func tick() {
+	for range time.Tick(time.Second) { // ok: tickers are collected since go1.23
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.21
+
+package a
+
+import "time"
+
+func poll(done <-chan struct{}) {
+	tick := time.Tick(time.Second) // want "time.Tick leaks its ticker, which cannot be stopped; use time.NewTicker and Stop"
+	for {
+		select {
+		case <-done:
+			return
+		case <-tick:
+		}
+	}
+}
+
+func ticker(done <-chan struct{}) {
+	t := time.NewTicker(time.Second)
+	defer t.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-t.C:
+		}
+	}
+}
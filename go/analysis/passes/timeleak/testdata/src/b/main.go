@@ -0,0 +1,21 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.21
+
+package main
+
+import "time"
+
+func main() {
+	for range time.Tick(time.Second) { // ok: runs for the lifetime of the program
+		work()
+	}
+}
+
+func work() {
+	for range time.Tick(time.Millisecond) { // want "time.Tick leaks its ticker"
+		return
+	}
+}
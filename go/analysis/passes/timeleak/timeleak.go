@@ -0,0 +1,107 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package timeleak defines an Analyzer that reports uses of time.After
+// and time.Tick that waste or leak timers.
+package timeleak
+
+import (
+	_ "embed"
+	"go/ast"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/analysis/passes/internal/analysisutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+	"golang.org/x/tools/internal/analysisinternal"
+	"golang.org/x/tools/internal/versions"
+)
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "timeleak",
+	Doc:      analysisutil.MustExtractDoc(doc, "timeleak"),
+	URL:      "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/timeleak",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	if !analysisinternal.Imports(pass.Pkg, "time") {
+		return nil, nil // doesn't directly import time
+	}
+
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	for curFile := range inspect.Root().Children() {
+		fileVersion := versions.FileVersion(pass.TypesInfo, curFile.Node().(*ast.File))
+		for cur := range curFile.Preorder((*ast.CallExpr)(nil)) {
+			call := cur.Node().(*ast.CallExpr)
+			obj := typeutil.Callee(pass.TypesInfo, call)
+			switch {
+			case analysisinternal.IsFunctionNamed(obj, "time", "After"):
+				if inSelectCase(cur) && inLoop(cur) {
+					pass.ReportRangef(call,
+						"time.After in select within a loop creates a new timer on each iteration; use time.NewTimer and Reset")
+				}
+
+			case analysisinternal.IsFunctionNamed(obj, "time", "Tick"):
+				if versions.Before(fileVersion, "go1.23") && !inMain(pass, cur) {
+					pass.ReportRangef(call,
+						"time.Tick leaks its ticker, which cannot be stopped; use time.NewTicker and Stop")
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// inSelectCase reports whether the call at cur is the channel operand
+// of a receive communication of a select case, as in
+// case <-time.After(d).
+func inSelectCase(cur inspector.Cursor) bool {
+	recv, ok := cur.Parent().Node().(*ast.UnaryExpr)
+	if !ok {
+		return false
+	}
+	comm := cur.Parent().Parent()
+	switch comm.Node().(type) {
+	case *ast.ExprStmt, *ast.AssignStmt:
+	default:
+		return false
+	}
+	clause, ok := comm.Parent().Node().(*ast.CommClause)
+	return ok && clause.Comm == comm.Node() && recv.X == cur.Node()
+}
+
+// inLoop reports whether the node at cur is within the body of a loop
+// of the same function.
+func inLoop(cur inspector.Cursor) bool {
+	for c := range cur.Enclosing((*ast.ForStmt)(nil), (*ast.RangeStmt)(nil), (*ast.FuncLit)(nil), (*ast.FuncDecl)(nil)) {
+		switch loop := c.Node().(type) {
+		case *ast.ForStmt:
+			return loop.Body.Pos() <= cur.Node().Pos() && cur.Node().End() <= loop.Body.End()
+		case *ast.RangeStmt:
+			return loop.Body.Pos() <= cur.Node().Pos() && cur.Node().End() <= loop.Body.End()
+		}
+		return false // function
+	}
+	return false
+}
+
+// inMain reports whether the node at cur is within the main function
+// of a main package, which runs for the lifetime of the program.
+func inMain(pass *analysis.Pass, cur inspector.Cursor) bool {
+	if pass.Pkg.Name() != "main" {
+		return false
+	}
+	for c := range cur.Enclosing((*ast.FuncDecl)(nil)) {
+		decl := c.Node().(*ast.FuncDecl)
+		return decl.Recv == nil && decl.Name.Name == "main"
+	}
+	return false
+}
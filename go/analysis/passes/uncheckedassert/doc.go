@@ -0,0 +1,28 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package uncheckedassert defines an Analyzer that reports type
+// assertions that panic when they fail.
+//
+// # Analyzer uncheckedassert
+//
+// uncheckedassert: report unchecked type assertions
+//
+// A type assertion x.(T) whose result is not checked by the comma-ok
+// form panics if x does not hold a value of type T:
+//
+//	conn := c.(*net.TCPConn) // "type assertion to *net.TCPConn panics if it fails; use the comma-ok form"
+//
+// The analyzer reports such assertions, except in test files, where a
+// panic merely fails the test, and in type switches. For an assertion
+// whose result initializes a new variable, it offers a fix to use the
+// comma-ok form and to handle the failure, by returning an error if
+// the function's last result is an error, or else by a panic whose
+// message describes the failure:
+//
+//	conn, ok := c.(*net.TCPConn)
+//	if !ok {
+//		return fmt.Errorf("c has type %T, want *net.TCPConn", c)
+//	}
+package uncheckedassert
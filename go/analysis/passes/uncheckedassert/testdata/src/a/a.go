@@ -0,0 +1,63 @@
+package a
+
+import (
+	"errors"
+	"io"
+)
+
+type T struct{ r io.Reader }
+
+func errorResult(x any) (int, error) {
+	n := x.(int) // want `type assertion to int panics if it fails; use the comma-ok form`
+	return n, nil
+}
+
+func panics(t T) {
+	w := t.r.(io.Writer) // want `type assertion to io.Writer panics if it fails; use the comma-ok form`
+	w.Write(nil)
+}
+
+func literal() {
+	ok := true
+	_ = func(x any) error {
+		var s = x.(string) // want `type assertion to string panics if it fails; use the comma-ok form`
+		_, _ = s, ok
+		return nil
+	}
+}
+
+func call(f func() any) {
+	s := f().(string) // want `type assertion to string panics if it fails; use the comma-ok form`
+	println(s)
+}
+
+func shadow(x any) {
+	{
+		x := x.(error) // want `type assertion to error panics if it fails; use the comma-ok form`
+		println(x)
+	}
+}
+
+func noFix(x any) string {
+	var s string
+	s = x.(string)                // want `type assertion to string panics if it fails; use the comma-ok form`
+	if e := x.(error); e != nil { // want `type assertion to error panics if it fails; use the comma-ok form`
+		return e.Error()
+	}
+	return s + x.(string) // want `type assertion to string panics if it fails; use the comma-ok form`
+}
+
+func checked(x any) {
+	if s, ok := x.(string); ok {
+		println(s)
+	}
+	var e, isErr = (x.(error))
+	_, _ = e, isErr
+	switch x := x.(type) {
+	case int:
+		println(x)
+	}
+	_ = errors.New
+}
+
+var global = any(1).(int) // want `type assertion to int panics if it fails; use the comma-ok form`
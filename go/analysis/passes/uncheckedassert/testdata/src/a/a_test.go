@@ -0,0 +1,5 @@
+package a
+
+func inTest(x any) int {
+	return x.(int) // ok: test file
+}
@@ -0,0 +1,224 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package uncheckedassert defines an Analyzer that reports type
+// assertions that panic when they fail.
+package uncheckedassert
+
+import (
+	_ "embed"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/analysis/passes/internal/analysisutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/internal/analysisinternal"
+	"golang.org/x/tools/internal/moreiters"
+	"golang.org/x/tools/internal/typesinternal"
+)
+
+// NOTE: Experimental. Not part of the vet suite.
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "uncheckedassert",
+	Doc:      analysisutil.MustExtractDoc(doc, "uncheckedassert"),
+	URL:      "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/uncheckedassert",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	for cur := range inspect.Root().Preorder((*ast.TypeAssertExpr)(nil)) {
+		assert := cur.Node().(*ast.TypeAssertExpr)
+		if assert.Type == nil {
+			continue // x.(type) in a type switch
+		}
+		if strings.HasSuffix(pass.Fset.File(assert.Pos()).Name(), "_test.go") {
+			continue
+		}
+
+		// Find the enclosing expression, ignoring parentheses,
+		// and its parent.
+		expr := cur
+		for {
+			if _, ok := expr.Parent().Node().(*ast.ParenExpr); !ok {
+				break
+			}
+			expr = expr.Parent()
+		}
+		switch parent := expr.Parent().Node().(type) {
+		case *ast.AssignStmt:
+			if len(parent.Lhs) == 2 && len(parent.Rhs) == 1 {
+				continue // v, ok = x.(T)
+			}
+		case *ast.ValueSpec:
+			if len(parent.Names) == 2 && len(parent.Values) == 1 {
+				continue // var v, ok = x.(T)
+			}
+		}
+
+		diag := analysis.Diagnostic{
+			Pos: assert.Pos(),
+			End: assert.End(),
+			Message: fmt.Sprintf("type assertion to %s panics if it fails; use the comma-ok form",
+				analysisinternal.Format(pass.Fset, assert.Type)),
+		}
+		if fix, ok := checkFix(pass, expr, assert); ok {
+			diag.SuggestedFixes = []analysis.SuggestedFix{fix}
+		}
+		pass.Report(diag)
+	}
+	return nil, nil
+}
+
+// checkFix returns a fix that converts the type assertion, whose
+// enclosing expression is curExpr, to the comma-ok form followed by a
+// check of the result. It is possible only if the assertion
+// initializes a single new variable in a statement of a function body.
+func checkFix(pass *analysis.Pass, curExpr inspector.Cursor, assert *ast.TypeAssertExpr) (analysis.SuggestedFix, bool) {
+	// Find the statement and the variable it declares.
+	var (
+		curStmt inspector.Cursor
+		name    *ast.Ident
+	)
+	switch parent := curExpr.Parent().Node().(type) {
+	case *ast.AssignStmt:
+		if parent.Tok != token.DEFINE || len(parent.Lhs) != 1 {
+			return analysis.SuggestedFix{}, false
+		}
+		curStmt, name = curExpr.Parent(), parent.Lhs[0].(*ast.Ident)
+	case *ast.ValueSpec:
+		if len(parent.Names) != 1 || parent.Type != nil {
+			return analysis.SuggestedFix{}, false
+		}
+		curStmt, name = curExpr.Parent().Parent().Parent(), parent.Names[0] // ValueSpec < GenDecl < DeclStmt
+		if _, ok := curStmt.Node().(*ast.DeclStmt); !ok {
+			return analysis.SuggestedFix{}, false // package-level declaration
+		}
+	default:
+		return analysis.SuggestedFix{}, false
+	}
+	switch curStmt.Parent().Node().(type) {
+	case *ast.BlockStmt, *ast.CaseClause, *ast.CommClause:
+	default:
+		return analysis.SuggestedFix{}, false // e.g. if v := x.(T); ...
+	}
+	stmt := curStmt.Node()
+
+	// Find the signature of the enclosing function.
+	curFunc, ok := moreiters.First(curStmt.Enclosing((*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)))
+	if !ok {
+		return analysis.SuggestedFix{}, false
+	}
+	var sig *types.Signature
+	switch n := curFunc.Node().(type) {
+	case *ast.FuncDecl:
+		sig = pass.TypesInfo.Defs[n.Name].(*types.Func).Signature()
+	case *ast.FuncLit:
+		sig = pass.TypesInfo.TypeOf(n).(*types.Signature)
+	}
+	curFile, _ := moreiters.First(curStmt.Enclosing((*ast.File)(nil)))
+	file := curFile.Node().(*ast.File)
+
+	// Describe the failure, including the dynamic type of the
+	// operand if it may be evaluated again without side effects,
+	// and the new variable does not shadow it.
+	x := analysisinternal.Format(pass.Fset, assert.X)
+	typ := analysisinternal.Format(pass.Fset, assert.Type)
+	format := fmt.Sprintf("%s does not have type %s", x, typ)
+	args := ""
+	if isVarRef(assert.X) && !strings.HasPrefix(x+".", name.Name+".") {
+		format = fmt.Sprintf("%s has type %%T, want %s", strings.ReplaceAll(x, "%", "%%"), typ)
+		args = ", " + x
+	}
+
+	// Return an error if the function's last result is an error,
+	// or else panic.
+	var edits []analysis.TextEdit
+	var handle string
+	results := sig.Results()
+	if results.Len() > 0 && analysisinternal.IsErrorType(results.At(results.Len()-1).Type()) {
+		var zeros []string
+		qual := typesinternal.FileQualifier(file, pass.Pkg)
+		for i := range results.Len() - 1 {
+			zero, ok := typesinternal.ZeroString(results.At(i).Type(), qual)
+			if !ok {
+				return analysis.SuggestedFix{}, false
+			}
+			zeros = append(zeros, zero)
+		}
+		_, prefix, importEdits := analysisinternal.AddImport(pass.TypesInfo, file, "fmt", "fmt", "Errorf", stmt.Pos())
+		edits = append(edits, importEdits...)
+		handle = "return " + strings.Join(append(zeros, fmt.Sprintf("%sErrorf(%s%s)", prefix, strconv.Quote(format), args)), ", ")
+	} else if args == "" {
+		handle = fmt.Sprintf("panic(%s)", strconv.Quote(format))
+	} else {
+		_, prefix, importEdits := analysisinternal.AddImport(pass.TypesInfo, file, "fmt", "fmt", "Sprintf", stmt.Pos())
+		edits = append(edits, importEdits...)
+		handle = fmt.Sprintf("panic(%sSprintf(%s%s))", prefix, strconv.Quote(format), args)
+	}
+
+	// Indent the check like the statement.
+	tf := pass.Fset.File(stmt.Pos())
+	content, err := pass.ReadFile(tf.Name())
+	if err != nil {
+		return analysis.SuggestedFix{}, false
+	}
+	indent := string(content[tf.Offset(tf.LineStart(tf.Line(stmt.Pos()))):tf.Offset(stmt.Pos())])
+	if strings.TrimSpace(indent) != "" {
+		return analysis.SuggestedFix{}, false // not at the start of its line
+	}
+
+	// Insert the check after the statement's line comment, if any.
+	eol := token.Pos(tf.Base() + tf.Size())
+	if line := tf.Line(stmt.End()); line < tf.LineCount() {
+		eol = tf.LineStart(line+1) - 1
+	}
+	if rest := strings.TrimSpace(string(content[tf.Offset(stmt.End()):tf.Offset(eol)])); rest != "" && !strings.HasPrefix(rest, "//") {
+		return analysis.SuggestedFix{}, false // not at the end of its line
+	}
+
+	okName := analysisinternal.FreshName(pass.Pkg.Scope().Innermost(stmt.Pos()), stmt.Pos(), "ok")
+	edits = append(edits,
+		analysis.TextEdit{
+			Pos:     name.End(),
+			End:     name.End(),
+			NewText: []byte(", " + okName),
+		},
+		analysis.TextEdit{
+			Pos:     eol,
+			End:     eol,
+			NewText: fmt.Appendf(nil, "\n%sif !%s {\n%s\t%s\n%s}", indent, okName, indent, handle, indent),
+		})
+	return analysis.SuggestedFix{
+		Message:   "Check the type assertion",
+		TextEdits: edits,
+	}, true
+}
+
+// isVarRef reports whether e is a reference to a variable or a field
+// of one, such as x or x.f.g, whose evaluation has no side effects.
+func isVarRef(e ast.Expr) bool {
+	for {
+		switch x := ast.Unparen(e).(type) {
+		case *ast.Ident:
+			return true
+		case *ast.SelectorExpr:
+			e = x.X
+		default:
+			return false
+		}
+	}
+}
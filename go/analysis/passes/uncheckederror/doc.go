@@ -0,0 +1,40 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package uncheckederror defines an Analyzer that reports calls whose
+// error result is ignored.
+//
+// # Analyzer uncheckederror
+//
+// uncheckederror: check for calls whose error result is ignored
+//
+// This analyzer reports calls to functions or methods that return an
+// error, when the call appears as a statement, or in a go or defer
+// statement, so that the error is silently dropped:
+//
+//	os.Remove(name)          // "error returned by os.Remove is not checked"
+//
+// An error that is intentionally discarded by assignment to the blank
+// identifier is not reported:
+//
+//	_ = os.Remove(name)      // ok
+//
+// Some functions return an error only to satisfy an interface and
+// never fail in practice. Calls to the functions named by the -exclude
+// flag, a comma-separated list of names in the form of
+// (*types.Func).FullName such as "fmt.Println" or
+// "(*bytes.Buffer).Write", are not reported. Nor are calls to
+// fmt.Fprint, Fprintf, and Fprintln whose writer is a *bytes.Buffer,
+// a *strings.Builder, or, as with fmt.Print, os.Stdout or os.Stderr,
+// unless -fprintbuffers=false, or calls to Close methods in defer
+// statements, unless -deferclose=false.
+//
+// The analyzer infers which functions never fail, and exports this
+// information as facts so that it is available when checking other
+// packages. A function never fails if each of its return statements
+// returns nil as the error, or returns the error of a call to another
+// function that never fails. Wrappers of an excluded function such as
+// (*bytes.Buffer).Write are thus treated like the function they wrap,
+// while wrappers of any other function are checked.
+package uncheckederror
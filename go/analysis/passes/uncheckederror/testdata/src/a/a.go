@@ -0,0 +1,72 @@
+package a
+
+import (
+	"b"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+)
+
+func Ignored() error { return os.Remove("x") }
+
+func _() {
+	os.Remove("x")     // want `error returned by os.Remove is not checked`
+	(os.Remove("x"))   // want `error returned by os.Remove is not checked`
+	_ = os.Remove("x") // ok: intentionally discarded
+	if err := os.Remove("x"); err != nil {
+		panic(err)
+	}
+
+	go os.Remove("x")    // want `error returned by os.Remove is not checked`
+	defer os.Remove("x") // want `error returned by os.Remove is not checked`
+
+	f, _ := os.Open("x")
+	defer f.Close() // ok: -deferclose
+	f.Close()       // want `error returned by f.Close is not checked`
+
+	fmt.Println("hello")             // ok: excluded
+	fmt.Fprintln(os.Stderr, "hello") // ok: -fprintbuffers
+	fmt.Fprintf(os.Stdout, "hello")  // ok: -fprintbuffers
+	fmt.Fprintln(f, "hello")         // want `error returned by fmt.Fprintln is not checked`
+
+	var buf bytes.Buffer
+	var sb strings.Builder
+	buf.WriteString("x")       // ok: excluded
+	fmt.Fprintf(&buf, "%d", 1) // ok: -fprintbuffers
+	fmt.Fprint(&sb, 1)         // ok: -fprintbuffers
+
+	Ignored() // ok: excluded by flag
+
+	// Facts about functions in other packages.
+	b.Log("x")      // ok: never fails
+	b.Logf("%d", 1) // ok: never fails
+	b.Fail()        // want `error returned by b.Fail is not checked`
+
+	// Facts about functions in this package.
+	wrapper() // ok: never fails
+	nilOnly() // ok: never fails
+	named()   // want `error returned by named is not checked`
+
+	fn := os.Remove
+	fn("x") // want `error returned by fn is not checked`
+
+	func() error { return nil }() // want `error returned by func\(\) error { return nil } is not checked`
+}
+
+func wrapper() error { // want wrapper:"neverFails"
+	return nilOnly()
+}
+
+func nilOnly() error { // want nilOnly:"neverFails"
+	if false {
+		return nil
+	}
+	f := func() error { return os.ErrClosed }
+	_ = f
+	return nil
+}
+
+func named() (err error) {
+	return
+}
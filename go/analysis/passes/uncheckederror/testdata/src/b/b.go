@@ -0,0 +1,22 @@
+package b
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+)
+
+var buf bytes.Buffer
+
+func Log(msg string) error { // want Log:"neverFails"
+	_, err := buf.WriteString(msg)
+	return err
+}
+
+func Logf(format string, args ...any) (int, error) { // want Logf:"neverFails"
+	return fmt.Fprintf(&buf, format, args...)
+}
+
+func Fail() error {
+	return errors.New("fail")
+}
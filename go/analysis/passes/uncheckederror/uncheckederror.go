@@ -0,0 +1,324 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package uncheckederror defines an Analyzer that reports calls whose
+// error result is ignored.
+package uncheckederror
+
+import (
+	_ "embed"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/analysis/passes/internal/analysisutil"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+	"golang.org/x/tools/internal/analysisinternal"
+	"golang.org/x/tools/internal/astutil"
+	"golang.org/x/tools/internal/typesinternal"
+)
+
+//go:embed doc.go
+var doc string
+
+var Analyzer = &analysis.Analyzer{
+	Name:      "uncheckederror",
+	Doc:       analysisutil.MustExtractDoc(doc, "uncheckederror"),
+	URL:       "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/uncheckederror",
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	Run:       run,
+	FactTypes: []analysis.Fact{new(neverFails)},
+}
+
+// flags
+var (
+	exclude       stringSetFlag
+	fprintBuffers = true
+	deferClose    = true
+)
+
+func init() {
+	exclude.Set(strings.Join([]string{
+		"fmt.Print",
+		"fmt.Printf",
+		"fmt.Println",
+		"(*bytes.Buffer).Write",
+		"(*bytes.Buffer).WriteByte",
+		"(*bytes.Buffer).WriteRune",
+		"(*bytes.Buffer).WriteString",
+		"(*strings.Builder).Write",
+		"(*strings.Builder).WriteByte",
+		"(*strings.Builder).WriteRune",
+		"(*strings.Builder).WriteString",
+		"(*hash/maphash.Hash).Write",
+		"(*hash/maphash.Hash).WriteByte",
+		"(*hash/maphash.Hash).WriteString",
+	}, ","))
+	Analyzer.Flags.Var(&exclude, "exclude",
+		"comma-separated list of functions whose errors need not be checked")
+	Analyzer.Flags.BoolVar(&fprintBuffers, "fprintbuffers", fprintBuffers,
+		"don't report calls to fmt.Fprint functions that write to a *bytes.Buffer, *strings.Builder, os.Stdout, or os.Stderr")
+	Analyzer.Flags.BoolVar(&deferClose, "deferclose", deferClose,
+		"don't report calls to Close methods in defer statements")
+}
+
+// A neverFails fact records that a function's error results are
+// always nil.
+type neverFails struct{}
+
+func (*neverFails) AFact()         {}
+func (*neverFails) String() string { return "neverFails" }
+
+func run(pass *analysis.Pass) (any, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	// Infer which functions of this package never fail.
+	// Iterate to a fixed point, as functions may wrap
+	// other functions declared later in the package.
+	local := make(map[*types.Func]bool)
+	infallible := func(fn *types.Func) bool {
+		return local[fn] || exclude[fn.FullName()] || pass.ImportObjectFact(fn, new(neverFails))
+	}
+	var decls []*ast.FuncDecl
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			if decl, ok := decl.(*ast.FuncDecl); ok && decl.Body != nil {
+				if fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func); ok &&
+					len(errorResults(fn.Type().(*types.Signature))) > 0 {
+					decls = append(decls, decl)
+				}
+			}
+		}
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, decl := range decls {
+			fn := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+			if !local[fn] && alwaysNil(pass, decl, infallible) {
+				local[fn] = true
+				changed = true
+			}
+		}
+	}
+	for fn := range local {
+		pass.ExportObjectFact(fn, new(neverFails))
+	}
+
+	// Report calls whose error result is ignored.
+	nodeFilter := []ast.Node{
+		(*ast.ExprStmt)(nil),
+		(*ast.GoStmt)(nil),
+		(*ast.DeferStmt)(nil),
+	}
+	inspect.Preorder(nodeFilter, func(n ast.Node) {
+		var (
+			call    *ast.CallExpr
+			isDefer bool
+		)
+		switch n := n.(type) {
+		case *ast.ExprStmt:
+			call, _ = ast.Unparen(n.X).(*ast.CallExpr)
+		case *ast.GoStmt:
+			call = n.Call
+		case *ast.DeferStmt:
+			call, isDefer = n.Call, true
+		}
+		if call == nil {
+			return // not a call statement
+		}
+		tv, ok := pass.TypesInfo.Types[call.Fun]
+		if !ok || !tv.IsValue() {
+			return // e.g. conversion or builtin
+		}
+		sig, ok := tv.Type.Underlying().(*types.Signature)
+		if !ok || len(errorResults(sig)) == 0 {
+			return // no error result
+		}
+		if fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func); ok {
+			if infallible(fn) || ignorable(pass, fn, call) {
+				return
+			}
+			if isDefer && deferClose && fn.Name() == "Close" && fn.Signature().Recv() != nil {
+				return // e.g. defer f.Close()
+			}
+		}
+		pass.ReportRangef(call, "error returned by %s is not checked",
+			analysisinternal.Format(pass.Fset, call.Fun))
+	})
+	return nil, nil
+}
+
+// alwaysNil reports whether each return statement of the function
+// declaration returns a nil error, or the error of a call to a
+// function that never fails, either directly or by way of a local
+// variable.
+func alwaysNil(pass *analysis.Pass, decl *ast.FuncDecl, infallible func(*types.Func) bool) bool {
+	fn := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+	sig := fn.Type().(*types.Signature)
+	indices := errorResults(sig)
+
+	// callNeverFails reports whether the call to a function
+	// returning an error (possibly among other results)
+	// is known to return a nil error.
+	callNeverFails := func(e ast.Expr) bool {
+		call, ok := ast.Unparen(e).(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		callee, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		return ok && (infallible(callee) || ignorable(pass, callee, call))
+	}
+
+	// Find the local error variables that are only ever
+	// assigned nil or the error of a call that never fails.
+	nilVars := make(map[*types.Var]bool)
+	assign := func(lhs ast.Expr, safe bool) {
+		id, ok := ast.Unparen(lhs).(*ast.Ident)
+		if !ok {
+			return
+		}
+		v, ok := pass.TypesInfo.ObjectOf(id).(*types.Var)
+		if !ok || !analysisinternal.IsErrorType(v.Type()) || !astutil.NodeContains(decl.Body, v.Pos()) {
+			return // not a local error variable, or a parameter or result
+		}
+		prev, seen := nilVars[v]
+		nilVars[v] = (prev || !seen) && safe
+	}
+	safe := func(rhs ast.Expr) bool {
+		return pass.TypesInfo.Types[rhs].IsNil() || callNeverFails(rhs)
+	}
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range n.Lhs {
+				if len(n.Lhs) == len(n.Rhs) {
+					assign(lhs, safe(n.Rhs[i]))
+				} else {
+					assign(lhs, safe(n.Rhs[0]))
+				}
+			}
+		case *ast.ValueSpec:
+			for i, id := range n.Names {
+				switch {
+				case len(n.Values) == 0:
+					assign(id, true) // zero value
+				case len(n.Values) == len(n.Names):
+					assign(id, safe(n.Values[i]))
+				default:
+					assign(id, safe(n.Values[0]))
+				}
+			}
+		case *ast.UnaryExpr:
+			if n.Op == token.AND {
+				assign(n.X, false) // address taken
+			}
+		}
+		return true
+	})
+	isNil := func(e ast.Expr) bool {
+		if pass.TypesInfo.Types[e].IsNil() || callNeverFails(e) {
+			return true
+		}
+		if id, ok := ast.Unparen(e).(*ast.Ident); ok {
+			v, ok := pass.TypesInfo.Uses[id].(*types.Var)
+			return ok && nilVars[v]
+		}
+		return false
+	}
+
+	ok := true
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		if !ok {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false // return statements belong to another function
+		case *ast.ReturnStmt:
+			switch {
+			case len(n.Results) == 0:
+				// A bare return of named results
+				// (or a function with no results).
+				ok = false
+			case len(n.Results) < sig.Results().Len():
+				// return f()
+				ok = callNeverFails(n.Results[0])
+			default:
+				for _, i := range indices {
+					e := n.Results[i]
+					if !isNil(e) {
+						ok = false
+					}
+				}
+			}
+		}
+		return true
+	})
+	return ok
+}
+
+// errorResults returns the indices of the results of sig whose type is error.
+func errorResults(sig *types.Signature) []int {
+	var indices []int
+	for i := range sig.Results().Len() {
+		if analysisinternal.IsErrorType(sig.Results().At(i).Type()) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// ignorable reports whether the call is to one of the fmt.Fprint
+// functions with a writer that never fails, or whose failure is
+// ignored like that of fmt.Print, and -fprintbuffers is set.
+func ignorable(pass *analysis.Pass, fn *types.Func, call *ast.CallExpr) bool {
+	if !fprintBuffers || len(call.Args) == 0 {
+		return false
+	}
+	if !analysisinternal.IsFunctionNamed(fn, "fmt", "Fprint", "Fprintf", "Fprintln") {
+		return false
+	}
+	if id := typesinternal.UsedIdent(pass.TypesInfo, call.Args[0]); id != nil {
+		if v, ok := pass.TypesInfo.Uses[id].(*types.Var); ok && v.Pkg() != nil && v.Pkg().Path() == "os" &&
+			typesinternal.IsPackageLevel(v) && (v.Name() == "Stdout" || v.Name() == "Stderr") {
+			return true
+		}
+	}
+	w := pass.TypesInfo.Types[call.Args[0]].Type
+	if ptr, ok := types.Unalias(w).(*types.Pointer); ok {
+		return analysisinternal.IsTypeNamed(ptr.Elem(), "bytes", "Buffer") ||
+			analysisinternal.IsTypeNamed(ptr.Elem(), "strings", "Builder")
+	}
+	return false
+}
+
+type stringSetFlag map[string]bool
+
+func (ss *stringSetFlag) String() string {
+	var items []string
+	for item := range *ss {
+		items = append(items, item)
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
+
+func (ss *stringSetFlag) Set(s string) error {
+	m := make(map[string]bool) // clobber previous value
+	if s != "" {
+		for name := range strings.SplitSeq(s, ",") {
+			if name == "" {
+				continue
+			}
+			m[name] = true
+		}
+	}
+	*ss = m
+	return nil
+}
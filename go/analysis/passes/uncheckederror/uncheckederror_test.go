@@ -0,0 +1,17 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package uncheckederror_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/analysis/passes/uncheckederror"
+)
+
+func Test(t *testing.T) {
+	uncheckederror.Analyzer.Flags.Set("exclude", "a.Ignored,fmt.Println,(*bytes.Buffer).WriteString")
+	analysistest.Run(t, analysistest.TestData(), uncheckederror.Analyzer, "a", "b")
+}
@@ -11,4 +11,10 @@
 //
 // The unmarshal analysis reports calls to functions such as json.Unmarshal
 // in which the argument type is not a pointer or an interface.
+//
+// The -funcs flag adds to the functions checked. Its value is a
+// comma-separated list of functions or methods, identified by their
+// full names such as gopkg.in/yaml.v3.Unmarshal or
+// (*github.com/BurntSushi/toml.Decoder).Decode, whose final argument
+// is the value to decode into.
 package unmarshal
@@ -0,0 +1,33 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file contains tests for the -funcs flag of the unmarshal checker.
+
+package funcs
+
+type Decoder struct{}
+
+func (*Decoder) Decode(v any) error { return nil }
+
+func Unmarshal(data []byte, v any) error { return nil }
+
+func UnmarshalOptions(data []byte, opts string, v any) error { return nil }
+
+func Other(data []byte, v any) error { return nil }
+
+func _() {
+	type t struct {
+		a int
+	}
+	var v t
+	var d Decoder
+
+	Unmarshal(nil, v) // want "call of Unmarshal passes non-pointer as second argument"
+	Unmarshal(nil, &v)
+	d.Decode(v) // want "call of Decode passes non-pointer"
+	d.Decode(&v)
+	UnmarshalOptions(nil, "", v) // want "call of UnmarshalOptions passes non-pointer as argument 3"
+	UnmarshalOptions(nil, "", &v)
+	Other(nil, v) // not configured
+}
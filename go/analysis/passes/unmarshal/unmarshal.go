@@ -8,13 +8,14 @@ import (
 	_ "embed"
 	"go/ast"
 	"go/types"
+	"sort"
+	"strings"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
 	"golang.org/x/tools/go/analysis/passes/internal/analysisutil"
 	"golang.org/x/tools/go/ast/inspector"
 	"golang.org/x/tools/go/types/typeutil"
-	"golang.org/x/tools/internal/typesinternal"
 )
 
 //go:embed doc.go
@@ -28,6 +29,25 @@ var Analyzer = &analysis.Analyzer{
 	Run:      run,
 }
 
+func init() {
+	Analyzer.Flags.Var(&unmarshalFuncs, "funcs",
+		"comma-separated list of additional functions or methods, such as gopkg.in/yaml.v3.Unmarshal, whose final argument must be a pointer")
+}
+
+// unmarshalFuncs is the set of functions (identified by
+// (*types.Func).FullName) whose final argument is the value to
+// decode into, which must be a pointer or an interface.
+//
+// The -funcs flag adds to this set.
+var unmarshalFuncs = stringSetFlag{
+	"encoding/asn1.Unmarshal":         true,
+	"encoding/json.Unmarshal":         true,
+	"encoding/xml.Unmarshal":          true,
+	"(*encoding/gob.Decoder).Decode":  true,
+	"(*encoding/json.Decoder).Decode": true,
+	"(*encoding/xml.Decoder).Decode":  true,
+}
+
 func run(pass *analysis.Pass) (any, error) {
 	switch pass.Pkg.Path() {
 	case "encoding/gob", "encoding/json", "encoding/xml", "encoding/asn1":
@@ -54,34 +74,12 @@ func run(pass *analysis.Pass) (any, error) {
 			return // not a static call
 		}
 
-		// Classify the callee (without allocating memory).
-		argidx := -1
-
-		recv := fn.Type().(*types.Signature).Recv()
-		if fn.Name() == "Unmarshal" && recv == nil {
-			// "encoding/json".Unmarshal
-			// "encoding/xml".Unmarshal
-			// "encoding/asn1".Unmarshal
-			switch fn.Pkg().Path() {
-			case "encoding/json", "encoding/xml", "encoding/asn1":
-				argidx = 1 // func([]byte, interface{})
-			}
-		} else if fn.Name() == "Decode" && recv != nil {
-			// (*"encoding/json".Decoder).Decode
-			// (* "encoding/gob".Decoder).Decode
-			// (* "encoding/xml".Decoder).Decode
-			_, named := typesinternal.ReceiverNamed(recv)
-			if tname := named.Obj(); tname.Name() == "Decoder" {
-				switch tname.Pkg().Path() {
-				case "encoding/json", "encoding/xml", "encoding/gob":
-					argidx = 0 // func(interface{})
-				}
-			}
-		}
-		if argidx < 0 {
+		// The value to decode into is the final argument.
+		sig := fn.Type().(*types.Signature)
+		if sig.Variadic() || sig.Params().Len() == 0 || !unmarshalFuncs[fn.Origin().FullName()] {
 			return // not a function we are interested in
 		}
-
+		argidx := sig.Params().Len() - 1
 		if len(call.Args) < argidx+1 {
 			return // not enough arguments, e.g. called with return values of another function
 		}
@@ -97,7 +95,29 @@ func run(pass *analysis.Pass) (any, error) {
 			pass.Reportf(call.Lparen, "call of %s passes non-pointer", fn.Name())
 		case 1:
 			pass.Reportf(call.Lparen, "call of %s passes non-pointer as second argument", fn.Name())
+		default:
+			pass.Reportf(call.Lparen, "call of %s passes non-pointer as argument %d", fn.Name(), argidx+1)
 		}
 	})
 	return nil, nil
 }
+
+type stringSetFlag map[string]bool
+
+func (ss *stringSetFlag) String() string {
+	var items []string
+	for item := range *ss {
+		items = append(items, item)
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
+
+func (ss *stringSetFlag) Set(s string) error {
+	for name := range strings.SplitSeq(s, ",") {
+		if name != "" {
+			(*ss)[name] = true
+		}
+	}
+	return nil
+}
@@ -15,3 +15,10 @@ func Test(t *testing.T) {
 	testdata := analysistest.TestData()
 	analysistest.Run(t, testdata, unmarshal.Analyzer, "a", "typeparams")
 }
+
+func TestFuncs(t *testing.T) {
+	if err := unmarshal.Analyzer.Flags.Set("funcs", "funcs.Unmarshal,funcs.UnmarshalOptions,(*funcs.Decoder).Decode"); err != nil {
+		t.Fatal(err)
+	}
+	analysistest.Run(t, analysistest.TestData(), unmarshal.Analyzer, "funcs")
+}
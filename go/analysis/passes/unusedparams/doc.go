@@ -22,6 +22,9 @@
 //     declared in the same package, since the method's signature
 //     may be required to conform to the interface type.
 //   - functions with empty bodies, or containing just a call to panic.
+//   - functions that are also declared in files excluded from the
+//     build, such as those for other platforms, since the variants
+//     must have the same signature.
 //   - parameters that are unnamed, or named "_", the blank identifier.
 //
 // The analyzer suggests a fix of replacing the parameter name by "_",
@@ -2,12 +2,15 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-// The unusedparams command runs the unusedparams analyzer.
+//go:build ignore
+
+// The unusedparams command applies the golang.org/x/tools/go/analysis/passes/unusedparams
+// analysis to the specified packages of Go source code.
 package main
 
 import (
+	"golang.org/x/tools/go/analysis/passes/unusedparams"
 	"golang.org/x/tools/go/analysis/singlechecker"
-	"golang.org/x/tools/gopls/internal/analysis/unusedparams"
 )
 
 func main() { singlechecker.Main(unusedparams.Analyzer) }
@@ -0,0 +1,21 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package variants
+
+// stub has a variant in an excluded file that uses its parameter.
+func stub(x int) int { return 0 }
+
+type t struct{}
+
+// m has a variant in an excluded file that uses its parameter.
+func (*t) m(x int) int { return 0 }
+
+func unused(x int) int { return 0 } // want "unused parameter: x"
+
+func _() {
+	stub(1)
+	new(t).m(1)
+	unused(1)
+}
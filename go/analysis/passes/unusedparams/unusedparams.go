@@ -8,14 +8,18 @@ import (
 	_ "embed"
 	"fmt"
 	"go/ast"
+	"go/parser"
+	"go/token"
 	"go/types"
+	"slices"
+	"strings"
 
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
 	"golang.org/x/tools/go/ast/edge"
 	"golang.org/x/tools/go/ast/inspector"
-	"golang.org/x/tools/gopls/internal/util/moreslices"
 	"golang.org/x/tools/internal/analysisinternal"
+	"golang.org/x/tools/internal/astutil"
 	"golang.org/x/tools/internal/typesinternal"
 )
 
@@ -27,7 +31,7 @@ var Analyzer = &analysis.Analyzer{
 	Doc:      analysisinternal.MustExtractDoc(doc, "unusedparams"),
 	Requires: []*analysis.Analyzer{inspect.Analyzer},
 	Run:      run,
-	URL:      "https://pkg.go.dev/golang.org/x/tools/gopls/internal/analysis/unusedparams",
+	URL:      "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/unusedparams",
 }
 
 const FixCategory = "unusedparams" // recognized by gopls ApplyFix
@@ -123,6 +127,29 @@ func run(pass *analysis.Pass) (any, error) {
 		}
 	}
 
+	// Find the functions declared in files excluded from the build,
+	// such as those for other platforms: a parameter unused by one
+	// variant of a function may be used by another.
+	variants := make(map[string]bool)
+	for _, name := range pass.IgnoredFiles {
+		if !strings.HasSuffix(name, ".go") {
+			continue
+		}
+		content, err := pass.ReadFile(name)
+		if err != nil {
+			continue
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), name, content, parser.SkipObjectResolution)
+		if err != nil {
+			continue // not our job to diagnose
+		}
+		for _, decl := range f.Decls {
+			if decl, ok := decl.(*ast.FuncDecl); ok {
+				variants[funcKey(decl)] = true
+			}
+		}
+	}
+
 	// Check each non-address-taken function's parameters are all used.
 funcloop:
 	for c := range inspect.Root().Preorder((*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)) {
@@ -153,6 +180,13 @@ funcloop:
 				continue
 			}
 
+			// Ignore functions that have variants in files
+			// excluded from the build, whose signatures
+			// must agree.
+			if variants[funcKey(n)] {
+				continue
+			}
+
 			fn = pass.TypesInfo.Defs[n.Name].(*types.Func)
 			ftype, body = n.Type, n.Body
 
@@ -172,14 +206,14 @@ funcloop:
 						// Edge case: f = func() {...}
 						// should not count as a use.
 						if pass.TypesInfo.Uses[id] != nil {
-							usesOutsideCall[fn] = moreslices.Remove(usesOutsideCall[fn], id)
+							usesOutsideCall[fn] = slices.DeleteFunc(usesOutsideCall[fn], func(use *ast.Ident) bool { return use == id })
 						}
 
 						if fn == nil && id.Name == "_" {
 							// Edge case: _ = func() {...}
 							// has no local var. Fake one.
 							v := types.NewVar(id.Pos(), pass.Pkg, id.Name, pass.TypesInfo.TypeOf(n))
-							v.SetKind(types.LocalVar)
+							typesinternal.SetVarKind(v, typesinternal.LocalVar)
 							fn = v
 						}
 					}
@@ -291,3 +325,14 @@ funcloop:
 	}
 	return nil, nil
 }
+
+// funcKey returns a key for a function or method declaration that is
+// the same for each variant of it, such as "f" or "T.m".
+func funcKey(decl *ast.FuncDecl) string {
+	if decl.Recv != nil && len(decl.Recv.List) == 1 {
+		if _, rname, _ := astutil.UnpackRecv(decl.Recv.List[0].Type); rname != nil {
+			return rname.Name + "." + decl.Name.Name
+		}
+	}
+	return decl.Name.Name
+}
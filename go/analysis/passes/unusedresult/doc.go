@@ -15,5 +15,15 @@
 // operation that must be called. This analyzer reports calls to
 // functions like these when the result of the call is ignored.
 //
-// The set of functions may be controlled using flags.
+// In addition to a list of well-known functions, the analyzer infers
+// which functions are pure, such as strings.TrimSpace or a user-defined
+// function that only computes its result, and reports calls to them
+// whose results are ignored, even in other packages. A function is
+// inferred to be pure if its body assigns only to local variables and
+// calls only other pure functions, and it does not panic.
+//
+// The set of functions may be controlled using flags. The -funcs flag
+// accepts function names such as fmt.Sprintf or (*bytes.Buffer).String,
+// and regular expressions that match such names, for example
+// example.com/util\.Must.* or strings\.(Trim|To).*.
 package unusedresult
@@ -0,0 +1,189 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package unusedresult
+
+// This file infers which functions are pure, that is, have no effects
+// other than computing their results, so that it is always a mistake
+// to discard their results.
+//
+// Purity, unlike the inductive approach rejected at the top of
+// unusedresult.go, is a sound basis for inference: a function whose
+// only observable behavior is its result is useless when its result
+// is ignored.
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// A mustUseResult fact records that a function is pure, so its
+// results must be used.
+type mustUseResult struct{}
+
+func (*mustUseResult) AFact()         {}
+func (*mustUseResult) String() string { return "mustUseResult" }
+
+// inferPure returns the set of functions and methods declared in
+// the package that are pure, and exports a fact for each exported one.
+// A function is pure if it has results, and its body assigns only to
+// local variables and calls only pure functions, conversions, certain
+// built-ins, and function-valued parameters, which are assumed to be
+// pure, as for slices.IndexFunc.
+//
+// isPure reports whether a function of another package is pure.
+func inferPure(pass *analysis.Pass, isPure func(*types.Func) bool) map[*types.Func]bool {
+	var decls []*ast.FuncDecl
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			if decl, ok := decl.(*ast.FuncDecl); ok && decl.Body != nil {
+				if fn, ok := pass.TypesInfo.Defs[decl.Name].(*types.Func); ok && fn.Signature().Results().Len() > 0 {
+					decls = append(decls, decl)
+				}
+			}
+		}
+	}
+
+	// Iterate to a fixed point, as pure functions
+	// may call others declared later in the package.
+	pure := make(map[*types.Func]bool)
+	callee := func(fn *types.Func) bool {
+		return pure[fn.Origin()] || isPure(fn)
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, decl := range decls {
+			fn := pass.TypesInfo.Defs[decl.Name].(*types.Func)
+			if !pure[fn] && pureBody(pass.TypesInfo, decl, callee) {
+				pure[fn] = true
+				changed = true
+			}
+		}
+	}
+
+	for fn := range pure {
+		if fn.Exported() {
+			pass.ExportObjectFact(fn, new(mustUseResult))
+		}
+	}
+	return pure
+}
+
+// pureBody reports whether the body of the function declaration has
+// no effects, given a predicate for the purity of called functions.
+func pureBody(info *types.Info, decl *ast.FuncDecl, isPure func(*types.Func) bool) bool {
+	fn := info.Defs[decl.Name].(*types.Func)
+
+	// local reports whether e denotes a local variable
+	// (including a parameter or result) of the function.
+	local := func(e ast.Expr) bool {
+		id, ok := ast.Unparen(e).(*ast.Ident)
+		if !ok {
+			return false
+		}
+		if id.Name == "_" {
+			return true
+		}
+		v, ok := info.ObjectOf(id).(*types.Var)
+		return ok && !v.IsField() && v.Parent() != nil && v.Parent() != fn.Pkg().Scope()
+	}
+
+	// param reports whether e denotes a parameter of the function.
+	param := func(e ast.Expr) bool {
+		id, ok := ast.Unparen(e).(*ast.Ident)
+		if !ok {
+			return false
+		}
+		v, ok := info.Uses[id].(*types.Var)
+		if !ok {
+			return false
+		}
+		params := fn.Signature().Params()
+		for i := range params.Len() {
+			if params.At(i) == v {
+				return true
+			}
+		}
+		return false
+	}
+
+	pure := true
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		if !pure {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.GoStmt, *ast.DeferStmt, *ast.SendStmt, *ast.SelectStmt, *ast.FuncLit:
+			pure = false
+
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if !local(lhs) {
+					pure = false // e.g. global, field, element, or *ptr
+				}
+			}
+
+		case *ast.IncDecStmt:
+			pure = local(n.X)
+
+		case *ast.UnaryExpr:
+			if n.Op == token.ARROW {
+				pure = false // channel receive
+			}
+
+		case *ast.RangeStmt:
+			switch typeOf(info, n.X).(type) {
+			case *types.Chan, *types.Signature:
+				pure = false // channel receive, or call of iterator
+			}
+
+		case *ast.CallExpr:
+			tv := info.Types[n.Fun]
+			switch {
+			case tv.IsType():
+				// conversion
+			case tv.IsBuiltin():
+				id, _ := ast.Unparen(n.Fun).(*ast.Ident)
+				if id == nil || !pureBuiltins[id.Name] {
+					pure = false // e.g. panic, copy, clear
+				}
+			case param(n.Fun):
+				// callback, assumed pure
+			default:
+				callee, ok := typeutil.Callee(info, n).(*types.Func)
+				if !ok || !isPure(callee) {
+					pure = false // dynamic or impure call
+				}
+			}
+		}
+		return pure
+	})
+	return pure
+}
+
+// typeOf returns the underlying type of the expression, or nil.
+func typeOf(info *types.Info, e ast.Expr) types.Type {
+	t := info.TypeOf(e)
+	if t == nil {
+		return nil
+	}
+	return t.Underlying()
+}
+
+// pureBuiltins is the set of built-in functions that have no effects.
+var pureBuiltins = map[string]bool{
+	"cap":     true,
+	"complex": true,
+	"imag":    true,
+	"len":     true,
+	"make":    true,
+	"max":     true,
+	"min":     true,
+	"new":     true,
+	"real":    true,
+}
@@ -0,0 +1,66 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dep
+
+import "strings"
+
+var count int
+
+func Add(x, y int) int { return x + y } // want Add:"mustUseResult"
+
+// Clamp calls Add, which is declared before it,
+// and max, which is declared after it.
+func Clamp(x, lo, hi int) int { return min(maxInt(Add(x, 0), lo), hi) } // want Clamp:"mustUseResult"
+
+func maxInt(x, y int) int {
+	if x > y {
+		return x
+	}
+	return y
+}
+
+func Index(s []int, f func(int) bool) int { // want Index:"mustUseResult"
+	for i, x := range s {
+		if f(x) {
+			return i
+		}
+	}
+	return -1
+}
+
+func Upper(s string) string { return strings.ToUpper(s) } // want Upper:"mustUseResult"
+
+func Incr() int { // impure: updates a global
+	count++
+	return count
+}
+
+func Must(x int) int { // impure: may panic
+	if x < 0 {
+		panic("negative")
+	}
+	return x
+}
+
+func Print(x int) int { // impure: calls println
+	println(x)
+	return x
+}
+
+type Point struct{ X, Y int }
+
+func (p Point) Add(q Point) Point {
+	p.X += q.X // impure: assigns a field, even of a copy
+	return p
+}
+
+func (p Point) Scale(k int) Point { // want Scale:"mustUseResult"
+	return Point{p.X * k, p.Y * k}
+}
+
+func (p *Point) Move(dx int) int {
+	p.X += dx
+	return p.X
+}
@@ -0,0 +1,29 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package pure
+
+import (
+	"pure/dep"
+	"strings"
+)
+
+func _(p dep.Point) {
+	dep.Add(1, 2)                   // want "result of pure/dep.Add call not used"
+	dep.Clamp(1, 2, 3)              // want "result of pure/dep.Clamp call not used"
+	dep.Index(nil, isZero)          // want "result of pure/dep.Index call not used"
+	p.Scale(2)                      // want `result of \(pure/dep.Point\).Scale call not used`
+	dep.Incr()                      // ok: impure
+	dep.Must(1)                     // ok: may panic
+	dep.Print(1)                    // ok: impure
+	p.Move(1)                       // ok: impure
+	strings.TrimSpace(" x ")        // want "result of strings.TrimSpace call not used"
+	strings.ReplaceAll("x", "", "") // want "result of strings.ReplaceAll call not used"
+	local(1)                        // want "result of pure.local call not used"
+	_ = local(1)
+}
+
+func isZero(x int) bool { return x == 0 }
+
+func local(x int) int { return dep.Add(x, x) }
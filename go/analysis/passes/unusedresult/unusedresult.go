@@ -11,13 +11,15 @@ package unusedresult
 // functions too. However, just because you must use the result of
 // fmt.Sprintf doesn't mean you need to use the result of every
 // function that returns a formatted string: it may have other results
-// and effects.
+// and effects. Instead, we infer which functions are pure, and so
+// have no effects at all (see pure.go).
 
 import (
 	_ "embed"
 	"go/ast"
 	"go/token"
 	"go/types"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -33,15 +35,19 @@ import (
 var doc string
 
 var Analyzer = &analysis.Analyzer{
-	Name:     "unusedresult",
-	Doc:      analysisutil.MustExtractDoc(doc, "unusedresult"),
-	URL:      "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/unusedresult",
-	Requires: []*analysis.Analyzer{inspect.Analyzer},
-	Run:      run,
+	Name:      "unusedresult",
+	Doc:       analysisutil.MustExtractDoc(doc, "unusedresult"),
+	URL:       "https://pkg.go.dev/golang.org/x/tools/go/analysis/passes/unusedresult",
+	Requires:  []*analysis.Analyzer{inspect.Analyzer},
+	Run:       run,
+	FactTypes: []analysis.Fact{new(mustUseResult)},
 }
 
 // flags
-var funcs, stringMethods stringSetFlag
+var (
+	funcs         = new(funcsFlag)
+	stringMethods stringSetFlag
+)
 
 func init() {
 	// TODO(adonovan): provide a comment or declaration syntax to
@@ -59,7 +65,7 @@ func init() {
 	// List standard library functions here.
 	// The context.With{Cancel,Deadline,Timeout} entries are
 	// effectively redundant wrt the lostcancel analyzer.
-	funcs = stringSetFlag{
+	funcs.names = stringSetFlag{
 		"context.WithCancel":      true,
 		"context.WithDeadline":    true,
 		"context.WithTimeout":     true,
@@ -117,8 +123,8 @@ func init() {
 		"slices.Values":           true,
 		"sort.Reverse":            true,
 	}
-	Analyzer.Flags.Var(&funcs, "funcs",
-		"comma-separated list of functions whose results must be used")
+	Analyzer.Flags.Var(funcs, "funcs",
+		"comma-separated list of functions whose results must be used, or regular expressions matching their full names")
 
 	stringMethods.Set("Error,String")
 	Analyzer.Flags.Var(&stringMethods, "stringmethods",
@@ -128,13 +134,11 @@ func init() {
 func run(pass *analysis.Pass) (any, error) {
 	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
 
-	// Split functions into (pkg, name) pairs to save allocation later.
-	pkgFuncs := make(map[[2]string]bool, len(funcs))
-	for s := range funcs {
-		if i := strings.LastIndexByte(s, '.'); i > 0 {
-			pkgFuncs[[2]string{s[:i], s[i+1:]}] = true
-		}
+	// Infer which functions of this package are pure.
+	isPure := func(fn *types.Func) bool {
+		return funcs.match(fn) || pass.ImportObjectFact(fn.Origin(), new(mustUseResult))
 	}
+	pure := inferPure(pass, isPure)
 
 	nodeFilter := []ast.Node{
 		(*ast.ExprStmt)(nil),
@@ -150,18 +154,18 @@ func run(pass *analysis.Pass) (any, error) {
 		if !ok {
 			return // e.g. var or builtin
 		}
-		if sig := fn.Type().(*types.Signature); sig.Recv() != nil {
+		sig := fn.Type().(*types.Signature)
+		mustUse := pure[fn.Origin()] || isPure(fn)
+		if sig.Recv() != nil {
 			// method (e.g. foo.String())
-			if types.Identical(sig, sigNoArgsStringResult) {
-				if stringMethods[fn.Name()] {
-					pass.ReportRangef(analysisinternal.Range(call.Pos(), call.Lparen),
-						"result of (%s).%s call not used",
-						sig.Recv().Type(), fn.Name())
-				}
+			if mustUse || types.Identical(sig, sigNoArgsStringResult) && stringMethods[fn.Name()] {
+				pass.ReportRangef(analysisinternal.Range(call.Pos(), call.Lparen),
+					"result of (%s).%s call not used",
+					sig.Recv().Type(), fn.Name())
 			}
 		} else {
 			// package-level function (e.g. fmt.Errorf)
-			if pkgFuncs[[2]string{fn.Pkg().Path(), fn.Name()}] {
+			if mustUse {
 				pass.ReportRangef(analysisinternal.Range(call.Pos(), call.Lparen),
 					"result of %s.%s call not used",
 					fn.Pkg().Path(), fn.Name())
@@ -174,6 +178,64 @@ func run(pass *analysis.Pass) (any, error) {
 // func() string
 var sigNoArgsStringResult = types.NewSignatureType(nil, nil, nil, nil, types.NewTuple(types.NewParam(token.NoPos, nil, "", types.Typ[types.String])), false)
 
+// A funcsFlag is a flag whose value is a comma-separated list of
+// function names, such as fmt.Sprintf or (*bytes.Buffer).String, or
+// regular expressions matching them. An entry containing regular
+// expression metacharacters other than '.' is a regular expression
+// that must match the entire name; any other entry is a name.
+type funcsFlag struct {
+	names    stringSetFlag
+	patterns []*regexp.Regexp
+}
+
+func (f *funcsFlag) String() string {
+	var items []string
+	if len(f.names) > 0 {
+		items = append(items, f.names.String())
+	}
+	for _, re := range f.patterns {
+		items = append(items, strings.TrimSuffix(strings.TrimPrefix(re.String(), "^(?:"), ")$"))
+	}
+	return strings.Join(items, ",")
+}
+
+func (f *funcsFlag) Set(s string) error {
+	var (
+		names    = make(stringSetFlag) // clobber previous value
+		patterns []*regexp.Regexp
+	)
+	for item := range strings.SplitSeq(s, ",") {
+		if item == "" {
+			continue
+		}
+		if strings.ContainsAny(item, `\^$|?+[]{}`) || strings.Contains(item, ".*") {
+			re, err := regexp.Compile("^(?:" + item + ")$")
+			if err != nil {
+				return err
+			}
+			patterns = append(patterns, re)
+		} else {
+			names[item] = true
+		}
+	}
+	f.names, f.patterns = names, patterns
+	return nil
+}
+
+// match reports whether the flag value matches the function.
+func (f *funcsFlag) match(fn *types.Func) bool {
+	name := fn.Origin().FullName()
+	if f.names[name] {
+		return true
+	}
+	for _, re := range f.patterns {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
 type stringSetFlag map[string]bool
 
 func (ss *stringSetFlag) String() string {
@@ -17,3 +17,9 @@ func Test(t *testing.T) {
 	unusedresult.Analyzer.Flags.Set("funcs", funcs)
 	analysistest.Run(t, testdata, unusedresult.Analyzer, "a", "typeparams")
 }
+
+func TestPure(t *testing.T) {
+	testdata := analysistest.TestData()
+	unusedresult.Analyzer.Flags.Set("funcs", `strings\.(Replace.*|To(Upper|Lower))`)
+	analysistest.Run(t, testdata, unusedresult.Analyzer, "pure", "pure/dep")
+}
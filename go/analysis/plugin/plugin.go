@@ -0,0 +1,415 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package plugin defines a protocol by which an analysis driver may
+// apply analyzers that are provided by a separate executable, called
+// a plugin, without linking them into the driver.
+//
+// A plugin is a program whose main function calls [Main] with its
+// analyzers. A driver calls [Load] with the name of the plugin
+// executable to obtain a proxy [analysis.Analyzer] for each analyzer
+// of the plugin. The proxies may be used like any other analyzer,
+// for example with the checker package. Each time a proxy is applied
+// to a package, it invokes the plugin in a child process to analyze
+// that package and reports the resulting diagnostics, including
+// their suggested fixes, as its own.
+//
+// The protocol is an extension of the one used by 'go vet -vettool'
+// (see the unitchecker package):
+//
+//	plugin -describe       describe the plugin's analyzers in JSON
+//	plugin -json -NAME foo.cfg
+//	                       apply analyzer NAME to the compilation unit
+//	                       described by foo.cfg, printing JSON results
+//
+// The type information for the dependencies of the unit is provided
+// in files written by [gcexportdata.Write].
+//
+// The flags of each analyzer are described by -describe and appear
+// in the Flags of its proxy; any flag whose value differs from its
+// default is passed to the plugin as -NAME.FLAG=VALUE.
+//
+// Facts are not supported: they are not transmitted between the
+// driver and the plugin, so analyzers that depend on facts about
+// imported packages will observe none.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/internal"
+	"golang.org/x/tools/go/analysis/internal/analysisflags"
+	"golang.org/x/tools/go/analysis/unitchecker"
+	"golang.org/x/tools/go/gcexportdata"
+)
+
+// ProtocolVersion is the version of the plugin protocol. A driver
+// refuses to load a plugin that speaks a different version.
+const ProtocolVersion = 1
+
+// description is the JSON schema of the output of 'plugin -describe'.
+type description struct {
+	Version   int // = ProtocolVersion
+	Analyzers []analyzerDescription
+}
+
+type analyzerDescription struct {
+	Name  string
+	Doc   string
+	URL   string            `json:",omitempty"`
+	Flags []flagDescription `json:",omitempty"`
+}
+
+type flagDescription struct {
+	Name    string
+	Usage   string
+	Default string
+}
+
+// Main is the main function of a plugin executable that provides
+// the specified analyzers. It does not return.
+func Main(analyzers ...*analysis.Analyzer) {
+	if len(os.Args) == 2 && os.Args[1] == "-describe" {
+		desc := description{Version: ProtocolVersion}
+		for _, a := range analyzers {
+			adesc := analyzerDescription{
+				Name: a.Name,
+				Doc:  a.Doc,
+				URL:  a.URL,
+			}
+			a.Flags.VisitAll(func(f *flag.Flag) {
+				adesc.Flags = append(adesc.Flags, flagDescription{
+					Name:    f.Name,
+					Usage:   f.Usage,
+					Default: f.DefValue,
+				})
+			})
+			desc.Analyzers = append(desc.Analyzers, adesc)
+		}
+		data, err := json.Marshal(desc)
+		if err != nil {
+			panic(err) // can't happen
+		}
+		os.Stdout.Write(data)
+		os.Exit(0)
+	}
+
+	internal.UnitcheckerUseGCExportData()
+	unitchecker.Main(analyzers...)
+}
+
+// Load runs the plugin executable to discover its analyzers, and
+// returns a proxy for each of them.
+func Load(executable string) ([]*analysis.Analyzer, error) {
+	cmd := exec.Command(executable, "-describe")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("describing plugin %s: %v (stderr=%s)", executable, err, &stderr)
+	}
+	var desc description
+	if err := json.Unmarshal(out, &desc); err != nil {
+		return nil, fmt.Errorf("describing plugin %s: invalid JSON: %v", executable, err)
+	}
+	if desc.Version != ProtocolVersion {
+		return nil, fmt.Errorf("plugin %s speaks protocol version %d, want %d",
+			executable, desc.Version, ProtocolVersion)
+	}
+
+	var analyzers []*analysis.Analyzer
+	for _, d := range desc.Analyzers {
+		name := d.Name
+		a := &analysis.Analyzer{
+			Name: name,
+			Doc:  d.Doc,
+			URL:  d.URL,
+			Run: func(pass *analysis.Pass) (any, error) {
+				return nil, run(executable, name, pass)
+			},
+		}
+		// The plugin parses its own flags, so the proxy
+		// treats each value as an uninterpreted string.
+		for _, f := range d.Flags {
+			a.Flags.String(f.Name, f.Default, f.Usage)
+		}
+		analyzers = append(analyzers, a)
+	}
+	return analyzers, nil
+}
+
+// run applies the plugin's analyzer of the specified name to the
+// package of the pass, and reports its diagnostics.
+func run(executable, name string, pass *analysis.Pass) error {
+	tmpdir, err := os.MkdirTemp("", "analysisplugin")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpdir) // ignore error
+
+	// Describe the compilation unit.
+	cfg := &unitchecker.Config{
+		ID:           pass.Pkg.Path(),
+		Compiler:     "gc",
+		ImportPath:   pass.Pkg.Path(),
+		GoVersion:    pass.Pkg.GoVersion(),
+		NonGoFiles:   pass.OtherFiles,
+		IgnoredFiles: pass.IgnoredFiles,
+		ImportMap:    make(map[string]string),
+		PackageFile:  make(map[string]string),
+		VetxOutput:   filepath.Join(tmpdir, "vetx"),
+	}
+	if pass.Module != nil {
+		cfg.ModulePath = pass.Module.Path
+		cfg.ModuleVersion = pass.Module.Version
+	}
+	// The driver may provide file contents that differ from those on
+	// disk, such as unsaved editor buffers, so give the plugin a
+	// copy of each Go file obtained from pass.ReadFile.
+	// The names of the copies must be mapped back to the originals.
+	names := make(map[string]string) // maps name of copy to name of original
+	for i, f := range pass.Files {
+		filename := pass.Fset.File(f.FileStart).Name()
+		if pass.ReadFile != nil {
+			content, err := pass.ReadFile(filename)
+			if err != nil {
+				return err
+			}
+			dir := filepath.Join(tmpdir, "src", strconv.Itoa(i))
+			if err := os.MkdirAll(dir, 0777); err != nil {
+				return err
+			}
+			filecopy := filepath.Join(dir, filepath.Base(filename))
+			if err := os.WriteFile(filecopy, content, 0666); err != nil {
+				return err
+			}
+			names[filecopy] = filename
+			filename = filecopy
+		}
+		cfg.GoFiles = append(cfg.GoFiles, filename)
+		for _, spec := range f.Imports {
+			importPath, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue // bad syntax
+			}
+			imp := importedPackage(pass, spec, importPath)
+			if imp == nil {
+				continue // e.g. failed import
+			}
+			cfg.ImportMap[importPath] = imp.Path()
+			if _, ok := cfg.PackageFile[imp.Path()]; ok || imp == types.Unsafe {
+				continue
+			}
+
+			// Save the type information for the import.
+			file := filepath.Join(tmpdir, fmt.Sprintf("%d.export", len(cfg.PackageFile)))
+			var buf bytes.Buffer
+			if err := gcexportdata.Write(&buf, pass.Fset, imp); err != nil {
+				return fmt.Errorf("writing type information for %s: %v", imp.Path(), err)
+			}
+			if err := os.WriteFile(file, buf.Bytes(), 0666); err != nil {
+				return err
+			}
+			cfg.PackageFile[imp.Path()] = file
+		}
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	cfgFile := filepath.Join(tmpdir, "unit.cfg")
+	if err := os.WriteFile(cfgFile, data, 0666); err != nil {
+		return err
+	}
+
+	// Run the plugin.
+	ctx := pass.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	args := []string{"-json", "-" + name}
+	pass.Analyzer.Flags.VisitAll(func(f *flag.Flag) {
+		if value := f.Value.String(); value != f.DefValue {
+			args = append(args, fmt.Sprintf("-%s.%s=%s", name, f.Name, value))
+		}
+	})
+	args = append(args, cfgFile)
+	cmd := exec.CommandContext(ctx, executable, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("plugin %s: %v (stderr=%s)", executable, err, &stderr)
+	}
+
+	// Decode and report the results.
+	var tree map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(out, &tree); err != nil {
+		return fmt.Errorf("plugin %s: invalid JSON output: %v", executable, err)
+	}
+	result, ok := tree[cfg.ID][name]
+	if !ok {
+		return nil // no diagnostics
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(result), []byte("{")) {
+		var jsonErr struct {
+			Err string `json:"error"`
+		}
+		if err := json.Unmarshal(result, &jsonErr); err != nil {
+			return fmt.Errorf("plugin %s: invalid JSON output: %v", executable, err)
+		}
+		return fmt.Errorf("plugin %s: %s", executable, jsonErr.Err)
+	}
+	var diags []analysisflags.JSONDiagnostic
+	if err := json.Unmarshal(result, &diags); err != nil {
+		return fmt.Errorf("plugin %s: invalid JSON output: %v", executable, err)
+	}
+	for _, jdiag := range diags {
+		diag, err := fromJSON(pass, names, jdiag)
+		if err != nil {
+			return fmt.Errorf("plugin %s: %v", executable, err)
+		}
+		pass.Report(diag)
+	}
+	return nil
+}
+
+// importedPackage returns the package imported by the specified
+// import declaration.
+func importedPackage(pass *analysis.Pass, spec *ast.ImportSpec, importPath string) *types.Package {
+	if pkgname := pass.TypesInfo.PkgNameOf(spec); pkgname != nil {
+		return pkgname.Imported()
+	}
+	for _, imp := range pass.Pkg.Imports() {
+		if imp.Path() == importPath {
+			return imp
+		}
+	}
+	return nil
+}
+
+// fromJSON converts a diagnostic in the form printed by
+// unitchecker -json to an analysis.Diagnostic. The names map
+// maps the names of copies of files to those of the originals.
+func fromJSON(pass *analysis.Pass, names map[string]string, jdiag analysisflags.JSONDiagnostic) (analysis.Diagnostic, error) {
+	fileNamed := func(filename string) *token.File {
+		if orig, ok := names[filename]; ok {
+			filename = orig
+		}
+		return fileNamed(pass, filename)
+	}
+	parsePosn := func(posn string) (token.Pos, error) {
+		return parsePosn(posn, fileNamed)
+	}
+	parseEnd := func(posn string) (token.Pos, error) {
+		if posn == "" {
+			return token.NoPos, nil // optional
+		}
+		return parsePosn(posn)
+	}
+
+	pos, err := parsePosn(jdiag.Posn)
+	if err != nil {
+		return analysis.Diagnostic{}, err
+	}
+	end, err := parseEnd(jdiag.End)
+	if err != nil {
+		return analysis.Diagnostic{}, err
+	}
+	diag := analysis.Diagnostic{
+		Pos:      pos,
+		End:      end,
+		Category: jdiag.Category,
+		Message:  jdiag.Message,
+	}
+	for _, jrel := range jdiag.Related {
+		pos, err := parsePosn(jrel.Posn)
+		if err != nil {
+			return analysis.Diagnostic{}, err
+		}
+		end, err := parseEnd(jrel.End)
+		if err != nil {
+			return analysis.Diagnostic{}, err
+		}
+		diag.Related = append(diag.Related, analysis.RelatedInformation{
+			Pos:     pos,
+			End:     end,
+			Message: jrel.Message,
+		})
+	}
+	for _, jfix := range jdiag.SuggestedFixes {
+		fix := analysis.SuggestedFix{Message: jfix.Message}
+		for _, jedit := range jfix.Edits {
+			file := fileNamed(jedit.Filename)
+			if file == nil {
+				return analysis.Diagnostic{}, fmt.Errorf("edit of unknown file %s", jedit.Filename)
+			}
+			if jedit.Start < 0 || jedit.End < jedit.Start || jedit.End > file.Size() {
+				return analysis.Diagnostic{}, fmt.Errorf("invalid edit [%d:%d] of %s", jedit.Start, jedit.End, jedit.Filename)
+			}
+			fix.TextEdits = append(fix.TextEdits, analysis.TextEdit{
+				Pos:     file.Pos(jedit.Start),
+				End:     file.Pos(jedit.End),
+				NewText: []byte(jedit.New),
+			})
+		}
+		diag.SuggestedFixes = append(diag.SuggestedFixes, fix)
+	}
+	return diag, nil
+}
+
+// parsePosn converts a position of the form "file:line:col" to a
+// token.Pos within the file of that name, as returned by fileNamed.
+func parsePosn(posn string, fileNamed func(string) *token.File) (token.Pos, error) {
+	rest, colStr, ok1 := cutLast(posn, ":")
+	filename, lineStr, ok2 := cutLast(rest, ":")
+	line, err1 := strconv.Atoi(lineStr)
+	col, err2 := strconv.Atoi(colStr)
+	if !ok1 || !ok2 || err1 != nil || err2 != nil {
+		return token.NoPos, fmt.Errorf("invalid position %q", posn)
+	}
+	file := fileNamed(filename)
+	if file == nil {
+		return token.NoPos, fmt.Errorf("position %q in unknown file", posn)
+	}
+	if line < 1 || line > file.LineCount() {
+		return token.NoPos, fmt.Errorf("invalid line in position %q", posn)
+	}
+	offset := file.Offset(file.LineStart(line)) + col - 1
+	if offset < 0 || offset > file.Size() {
+		return token.NoPos, fmt.Errorf("invalid column in position %q", posn)
+	}
+	return file.Pos(offset), nil
+}
+
+// fileNamed returns the token.File of the named Go file of the pass,
+// or nil if not found.
+func fileNamed(pass *analysis.Pass, filename string) *token.File {
+	for _, f := range pass.Files {
+		if file := pass.Fset.File(f.FileStart); file.Name() == filename {
+			return file
+		}
+	}
+	return nil
+}
+
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package plugin_test
+
+import (
+	"fmt"
+	"go/ast"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/analysis/passes/findcall"
+	"golang.org/x/tools/go/analysis/plugin"
+	"golang.org/x/tools/internal/testenv"
+	"golang.org/x/tools/internal/testfiles"
+	"golang.org/x/tools/txtar"
+)
+
+func TestMain(m *testing.M) {
+	// child process?
+	if os.Getenv("ENTRYPOINT") == "plugin" {
+		plugin.Main(findcall.Analyzer, callsAnalyzer)
+		panic("unreachable")
+	}
+	os.Exit(m.Run())
+}
+
+// callsAnalyzer reports the extent of each call expression.
+var callsAnalyzer = &analysis.Analyzer{
+	Name: "calls",
+	Doc:  "report each call",
+	Run: func(pass *analysis.Pass) (any, error) {
+		for _, f := range pass.Files {
+			ast.Inspect(f, func(n ast.Node) bool {
+				if call, ok := n.(*ast.CallExpr); ok {
+					pass.ReportRangef(call, "call")
+				}
+				return true
+			})
+		}
+		return nil, nil
+	},
+}
+
+// TestPlugin applies the analyzers provided by this executable,
+// acting as a plugin, to a package that imports fmt.
+func TestPlugin(t *testing.T) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+		t.Skipf("skipping fork/exec test on this platform")
+	}
+	testenv.NeedsGoPackages(t)
+	t.Setenv("ENTRYPOINT", "plugin")
+
+	analyzers, err := plugin.Load(os.Args[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(analyzers) != 2 || analyzers[0].Name != "findcall" || analyzers[1].Name != "calls" {
+		t.Fatalf("Load returned %v, want [findcall calls]", analyzers)
+	}
+	if err := analysis.Validate(analyzers); err != nil {
+		t.Fatal(err)
+	}
+
+	// The flag is forwarded to the plugin. (Its default is "println".)
+	if err := analyzers[0].Flags.Set("name", "Println"); err != nil {
+		t.Fatal(err)
+	}
+
+	const src = `
+-- go.mod --
+module example.com
+
+-- p/p.go --
+package p
+
+import "fmt"
+
+func f() {
+	fmt.Println("hello")
+}
+`
+	pkgs := testfiles.LoadPackages(t, txtar.Parse([]byte(src)), "example.com/p")
+
+	graph, err := checker.Analyze(analyzers, pkgs, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, act := range graph.Roots {
+		if act.Err != nil {
+			t.Fatal(act.Err)
+		}
+		for _, diag := range act.Diagnostics {
+			posn := act.Package.Fset.Position(diag.Pos)
+			line := fmt.Sprintf("%s: %d:%d", act.Analyzer.Name, posn.Line, posn.Column)
+			if diag.End.IsValid() {
+				end := act.Package.Fset.Position(diag.End)
+				line += fmt.Sprintf("-%d:%d", end.Line, end.Column)
+			}
+			got = append(got, fmt.Sprintf("%s: %s (%d fixes)", line, diag.Message, len(diag.SuggestedFixes)))
+		}
+	}
+	want := []string{
+		"findcall: 6:13: call of Println(...) (1 fixes)",
+		"calls: 6:2-6:22: call (0 fixes)",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got diagnostics:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
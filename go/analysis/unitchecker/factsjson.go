@@ -0,0 +1,135 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package unitchecker
+
+// This file defines the documented JSON form of the facts produced
+// for a compilation unit. Unlike the .vetx file, whose encoding is
+// private to this module and may change at any time, the JSON form
+// is versioned and intended for consumption by build systems and
+// other external tools.
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/types"
+	"os"
+	"reflect"
+	"sort"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/types/objectpath"
+)
+
+// FactsJSONVersion is the version of the format of the file written
+// to [Config.FactsJSONOutput]. It is incremented whenever the format
+// changes incompatibly; compatible additions do not change it.
+const FactsJSONVersion = 1
+
+// FactsJSON is the schema of the file written to
+// [Config.FactsJSONOutput]. It describes the facts exported by
+// analysis of a single compilation unit about the package itself and
+// the objects it declares. Facts that the unit merely re-exports on
+// behalf of its dependencies are not included.
+type FactsJSON struct {
+	Version int        // = FactsJSONVersion
+	ID      string     // Config.ID of the compilation unit
+	Package string     // path of the analyzed package
+	Facts   []FactJSON // sorted by (Object, Analyzer, Type)
+}
+
+// A FactJSON describes a single fact.
+type FactJSON struct {
+	Analyzer string          // name of the analyzer that declares the fact type
+	Object   objectpath.Path `json:",omitempty"` // path of object within Package, or "" for a package fact
+	Type     string          // Go type of the fact, e.g. "*printf.isWrapper"
+	String   string          `json:",omitempty"` // result of the fact's String method, if any
+	Value    json.RawMessage `json:",omitempty"` // JSON encoding of the fact, if it has one
+}
+
+// factsJSON returns the JSON description of the facts about pkg
+// declared by the specified analyzers (and their prerequisites).
+func factsJSON(cfg *Config, pkg *types.Package, analyzers []*analysis.Analyzer,
+	objectFacts []analysis.ObjectFact, packageFacts []analysis.PackageFact) *FactsJSON {
+
+	// Map each fact type to the analyzer that declares it.
+	owner := make(map[reflect.Type]string)
+	seen := make(map[*analysis.Analyzer]bool)
+	var visit func(a *analysis.Analyzer)
+	visit = func(a *analysis.Analyzer) {
+		if seen[a] {
+			return
+		}
+		seen[a] = true
+		for _, f := range a.FactTypes {
+			owner[reflect.TypeOf(f)] = a.Name
+		}
+		for _, req := range a.Requires {
+			visit(req)
+		}
+	}
+	for _, a := range analyzers {
+		visit(a)
+	}
+
+	describe := func(path objectpath.Path, fact analysis.Fact) FactJSON {
+		t := reflect.TypeOf(fact)
+		f := FactJSON{
+			Analyzer: owner[t],
+			Object:   path,
+			Type:     t.String(),
+		}
+		if s, ok := fact.(fmt.Stringer); ok {
+			f.String = s.String()
+		}
+		if data, err := json.Marshal(fact); err == nil && string(data) != "{}" {
+			f.Value = data
+		}
+		return f
+	}
+
+	out := &FactsJSON{
+		Version: FactsJSONVersion,
+		ID:      cfg.ID,
+		Package: pkg.Path(),
+		Facts:   []FactJSON{},
+	}
+	for _, f := range packageFacts {
+		if f.Package == pkg {
+			out.Facts = append(out.Facts, describe("", f.Fact))
+		}
+	}
+	var enc objectpath.Encoder
+	for _, f := range objectFacts {
+		if f.Object.Pkg() != pkg {
+			continue // re-exported fact about a dependency
+		}
+		path, err := enc.For(f.Object)
+		if err != nil {
+			continue // object not accessible from package API
+		}
+		out.Facts = append(out.Facts, describe(path, f.Fact))
+	}
+	sort.Slice(out.Facts, func(i, j int) bool {
+		x, y := out.Facts[i], out.Facts[j]
+		if x.Object != y.Object {
+			return x.Object < y.Object
+		}
+		if x.Analyzer != y.Analyzer {
+			return x.Analyzer < y.Analyzer
+		}
+		return x.Type < y.Type
+	})
+	return out
+}
+
+// writeFactsJSON writes the JSON description of the facts to the
+// file named by cfg.FactsJSONOutput.
+func writeFactsJSON(cfg *Config, facts *FactsJSON) error {
+	data, err := json.MarshalIndent(facts, "", "\t")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(cfg.FactsJSONOutput, data, 0666)
+}
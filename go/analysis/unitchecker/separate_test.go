@@ -153,16 +153,17 @@ func MyPrintf(format string, args ...any) {
 			}
 		}
 		cfg := unitchecker.Config{
-			ID:           pkg.ID,
-			ImportPath:   pkg.PkgPath,
-			GoFiles:      pkg.CompiledGoFiles,
-			NonGoFiles:   pkg.OtherFiles,
-			IgnoredFiles: pkg.IgnoredFiles,
-			ImportMap:    importMap,
-			PackageFile:  packageFile,
-			PackageVetx:  packageVetx,
-			VetxOnly:     !roots[pkg],
-			VetxOutput:   prefix + ".facts",
+			ID:              pkg.ID,
+			ImportPath:      pkg.PkgPath,
+			GoFiles:         pkg.CompiledGoFiles,
+			NonGoFiles:      pkg.OtherFiles,
+			IgnoredFiles:    pkg.IgnoredFiles,
+			ImportMap:       importMap,
+			PackageFile:     packageFile,
+			PackageVetx:     packageVetx,
+			VetxOnly:        !roots[pkg],
+			VetxOutput:      prefix + ".facts",
+			FactsJSONOutput: prefix + ".facts.json",
 		}
 		if pkg.Module != nil {
 			if v := pkg.Module.GoVersion; v != "" {
@@ -227,6 +228,30 @@ func MyPrintf(format string, args ...any) {
 	if got := strings.Join(allDiagnostics, "\n"); got != want {
 		t.Errorf("Got: %s\nWant: %s", got, want)
 	}
+
+	// Observe that the facts of "lib" are also available
+	// in the documented JSON form.
+	lib := pkgs[0].Imports["separate/lib"]
+	data, err := os.ReadFile(lib.ExportFile + ".facts.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var facts unitchecker.FactsJSON
+	if err := json.Unmarshal(data, &facts); err != nil {
+		t.Fatal(err)
+	}
+	var gotFacts []string
+	for _, f := range facts.Facts {
+		gotFacts = append(gotFacts, fmt.Sprintf("%s: [%s] %s", f.Object, f.Analyzer, f.String))
+	}
+	const wantFacts = `MyPrintf: [printf] printfWrapper`
+	if facts.Version != unitchecker.FactsJSONVersion || facts.Package != "separate/lib" {
+		t.Errorf("facts header = (%d, %q), want (%d, %q)",
+			facts.Version, facts.Package, unitchecker.FactsJSONVersion, "separate/lib")
+	}
+	if got := strings.Join(gotFacts, "\n"); got != wantFacts {
+		t.Errorf("Got facts: %s\nWant: %s", got, wantFacts)
+	}
 }
 
 // -- worker process --
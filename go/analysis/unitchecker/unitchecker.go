@@ -14,6 +14,11 @@
 //	-flags          describe flags                    (to the build tool)
 //	foo.cfg         description of compilation unit (from the build tool)
 //
+// If the Config sets FactsJSONOutput, the facts produced for the unit
+// are additionally written to that file in the documented, versioned
+// JSON form described by [FactsJSON], for use by build systems and
+// other tools that cannot decode the private .vetx format.
+//
 // This package does not depend on go/packages.
 // If you need a standalone tool, use multichecker,
 // which supports this mode but can also load packages
@@ -27,6 +32,7 @@ package unitchecker
 //   printf checker.
 
 import (
+	"context"
 	"encoding/gob"
 	"encoding/json"
 	"flag"
@@ -48,7 +54,9 @@ import (
 	"time"
 
 	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/internal"
 	"golang.org/x/tools/go/analysis/internal/analysisflags"
+	"golang.org/x/tools/go/gcexportdata"
 	"golang.org/x/tools/internal/analysisinternal"
 	"golang.org/x/tools/internal/facts"
 )
@@ -73,6 +81,7 @@ type Config struct {
 	PackageVetx               map[string]string // maps package path to file of fact information
 	VetxOnly                  bool              // run analysis only for facts, not diagnostics
 	VetxOutput                string            // where to write file of fact information
+	FactsJSONOutput           string            // where to write documented JSON form of facts (optional; see FactsJSON)
 	Stdout                    string            // write stdout (e.g. JSON, unified diff) to this file
 	SucceedOnTypecheckFailure bool              // obsolete awful hack; see #18395 and below
 	WarnDiagnostics           bool              // printing diagnostics should not cause a non-zero exit
@@ -221,7 +230,7 @@ func processResults(fset *token.FileSet, id string, results []result, warnDiagno
 		// JSON output
 		tree := make(analysisflags.JSONTree)
 		for _, res := range results {
-			tree.Add(fset, id, res.a.Name, res.diagnostics, res.err)
+			tree.Add(fset, os.ReadFile, id, res.a.Name, res.diagnostics, res.err)
 		}
 		tree.Print(os.Stdout) // ignore error
 
@@ -296,6 +305,38 @@ var (
 	}
 )
 
+func init() {
+	internal.UnitcheckerUseGCExportData = func() {
+		makeTypesImporter = makeGCExportDataImporter
+	}
+}
+
+// makeGCExportDataImporter is an alternative to the default
+// makeTypesImporter that reads type information from files written by
+// [gcexportdata.Write], such as those provided by the plugin package.
+func makeGCExportDataImporter(cfg *Config, fset *token.FileSet) types.Importer {
+	imports := make(map[string]*types.Package)
+	return importerFunc(func(importPath string) (*types.Package, error) {
+		path, ok := cfg.ImportMap[importPath] // resolve vendoring, etc
+		if !ok {
+			return nil, fmt.Errorf("can't resolve import %q", importPath)
+		}
+		if path == "unsafe" {
+			return types.Unsafe, nil
+		}
+		file, ok := cfg.PackageFile[path]
+		if !ok {
+			return nil, fmt.Errorf("no package file for %q", path)
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close() // ignore error
+		return gcexportdata.Read(f, fset, imports, path)
+	})
+}
+
 func run(fset *token.FileSet, cfg *Config, analyzers []*analysis.Analyzer) ([]result, error) {
 	// Load, parse, typecheck.
 	var files []*ast.File
@@ -382,8 +423,17 @@ func run(fset *token.FileSet, cfg *Config, analyzers []*analysis.Analyzer) ([]re
 	}
 	analyzers = filtered
 
-	// Read facts from imported packages.
-	facts, err := facts.NewDecoder(pkg).Decode(makeFactImporter(cfg))
+	// Read facts from imported packages, discarding any
+	// produced by a different version of their analyzer.
+	versions := make(map[reflect.Type]string)
+	for a := range actions {
+		for _, f := range a.FactTypes {
+			versions[reflect.TypeOf(f)] = a.Version
+		}
+	}
+	decoder := facts.NewDecoder(pkg)
+	decoder.SetVersions(versions)
+	facts, err := decoder.Decode(makeFactImporter(cfg))
 	if err != nil {
 		return nil, err
 	}
@@ -455,6 +505,7 @@ func run(fset *token.FileSet, cfg *Config, analyzers []*analysis.Analyzer) ([]re
 				ExportPackageFact: facts.ExportPackageFact,
 				AllPackageFacts:   func() []analysis.PackageFact { return facts.AllPackageFacts(factFilter) },
 				Module:            module,
+				Context:           context.Background(),
 			}
 			pass.ReadFile = analysisinternal.CheckedReadFile(pass, os.ReadFile)
 
@@ -503,6 +554,18 @@ func run(fset *token.FileSet, cfg *Config, analyzers []*analysis.Analyzer) ([]re
 	if err := exportFacts(cfg, data); err != nil {
 		return nil, fmt.Errorf("failed to export analysis facts: %v", err)
 	}
+	if cfg.FactsJSONOutput != "" {
+		allFacts := make(map[reflect.Type]bool)
+		for a := range actions {
+			for _, f := range a.FactTypes {
+				allFacts[reflect.TypeOf(f)] = true
+			}
+		}
+		out := factsJSON(cfg, pkg, analyzers, facts.AllObjectFacts(allFacts), facts.AllPackageFacts(allFacts))
+		if err := writeFactsJSON(cfg, out); err != nil {
+			return nil, fmt.Errorf("failed to export analysis facts as JSON: %v", err)
+		}
+	}
 	if err := exportTypes(cfg, fset, pkg); err != nil {
 		return nil, fmt.Errorf("failed to export type information: %v", err)
 	}
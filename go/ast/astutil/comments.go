@@ -0,0 +1,143 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package astutil
+
+// This file defines RewriteFile, a comment-preserving form of Apply.
+
+import (
+	"go/ast"
+	"go/token"
+	"reflect"
+)
+
+// RewriteFile is like [Apply] applied to file, but it maintains the
+// association of the file's comments with its nodes, so that
+// [go/printer] prints the comments of the rewritten file in the right
+// places. The file must belong to fset. The root node, file, must not
+// be replaced.
+//
+// The printer places each comment by comparing its position with
+// those of the nodes being printed, so RewriteFile acts as follows on
+// the operations of the [Cursor]:
+//
+//   - Replace transfers the comments associated with the current node
+//     (see [ast.CommentMap]) to its replacement, and, if the
+//     replacement has no position, gives it that of the current node.
+//   - InsertBefore gives the inserted node, if it has no position,
+//     that of the current node or, if it has leading comments, the end
+//     of the line before them, so that those comments remain attached
+//     to the current node.
+//   - InsertAfter gives the inserted node, if it has no position,
+//     that of the end of the current node or its trailing comments,
+//     whichever is later.
+//   - Delete deletes the comments associated with the current node.
+//
+// A node without a position is given one by setting the positions of
+// all its tokens, and those of its subtrees without a position. After
+// Delete, the printer may retain a blank line in place of the deleted
+// node. Comments associated with nodes that are no longer in the file once
+// the traversal is complete are deleted from file.Comments.
+func RewriteFile(fset *token.FileSet, file *ast.File, pre, post ApplyFunc) {
+	t := &commentTracker{
+		fset: fset,
+		cmap: ast.NewCommentMap(fset, file, file.Comments),
+	}
+	apply(file, pre, post, t)
+	file.Comments = t.cmap.Filter(file).Comments()
+}
+
+// A commentTracker maintains the comment map of a file being
+// rewritten by RewriteFile.
+type commentTracker struct {
+	fset *token.FileSet
+	cmap ast.CommentMap
+}
+
+func (t *commentTracker) replace(old, new ast.Node) {
+	if old == nil || new == nil || old == new {
+		return
+	}
+	if !new.Pos().IsValid() {
+		setPos(new, old.Pos())
+	}
+	if groups, ok := t.cmap[old]; ok {
+		t.cmap[new] = append(t.cmap[new], groups...)
+		delete(t.cmap, old)
+	}
+}
+
+func (t *commentTracker) insertBefore(cur, new ast.Node) {
+	if cur == nil || new.Pos().IsValid() {
+		return
+	}
+	pos := cur.Pos()
+	for _, group := range t.cmap[cur] {
+		if group.Pos() < pos {
+			pos = group.Pos()
+		}
+	}
+	if pos != cur.Pos() {
+		// Place the node on the line before the leading comments,
+		// lest the printer treat them as trailing comments of it.
+		if f := t.fset.File(pos); f != nil {
+			if start := f.LineStart(f.Line(pos)); int(start) > f.Base() {
+				pos = start - 1
+			}
+		}
+	}
+	setPos(new, pos)
+}
+
+func (t *commentTracker) insertAfter(cur, new ast.Node) {
+	if cur == nil || new.Pos().IsValid() {
+		return
+	}
+	end := cur.End()
+	for _, group := range t.cmap[cur] {
+		if group.End() > end {
+			end = group.End()
+		}
+	}
+	setPos(new, end)
+}
+
+// setPos sets the positions of the tokens of n, and of its subtrees
+// that have no position, to pos. Because the printer emits a comment
+// before the first token that follows it, giving all of the tokens
+// of the new node the same position keeps the comments that follow
+// that position after the node, and those that precede it before.
+//
+// Positions whose validity affects how a node is printed, such as
+// that of the ellipsis of a call, are unchanged.
+func setPos(n ast.Node, pos token.Pos) {
+	ast.Inspect(n, func(n ast.Node) bool {
+		if n == nil || n.Pos().IsValid() {
+			return false // reused subtree, or end of children
+		}
+		if _, ok := n.(*ast.CommentGroup); ok {
+			return false
+		}
+		v := reflect.ValueOf(n).Elem()
+		for i := range v.NumField() {
+			f := v.Field(i)
+			if f.Type() == posType && f.Int() == 0 && !significantPos[v.Type().Field(i).Name+"."+v.Type().Name()] {
+				f.SetInt(int64(pos))
+			}
+		}
+		return true
+	})
+}
+
+var posType = reflect.TypeOf(token.NoPos)
+
+// significantPos holds the positions, as "Field.Type", whose validity
+// affects how a node is printed.
+var significantPos = map[string]bool{
+	"Ellipsis.CallExpr": true, // f(x...)
+	"Lparen.GenDecl":    true, // var (...)
+	"Rparen.GenDecl":    true,
+	"Assign.TypeSpec":   true, // type T = U
+	"EndPos.ImportSpec": true,
+}
@@ -0,0 +1,148 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package astutil_test
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+func TestRewriteFile(t *testing.T) {
+	const orig = `package p
+
+func f() {
+	// a is first.
+	a()
+	// b is second.
+	b() // (trailing b)
+	// c is third.
+	c()
+}
+`
+	// isCall reports whether n is a call statement of the named function.
+	isCall := func(n ast.Node, name string) bool {
+		if stmt, ok := n.(*ast.ExprStmt); ok {
+			if call, ok := stmt.X.(*ast.CallExpr); ok {
+				if id, ok := call.Fun.(*ast.Ident); ok {
+					return id.Name == name
+				}
+			}
+		}
+		return false
+	}
+	call := func(name string) ast.Stmt {
+		return &ast.ExprStmt{X: &ast.CallExpr{Fun: ast.NewIdent(name)}}
+	}
+
+	for _, test := range []struct {
+		name string
+		pre  astutil.ApplyFunc
+		want string
+	}{
+		{
+			name: "replace",
+			pre: func(c *astutil.Cursor) bool {
+				if isCall(c.Node(), "b") {
+					c.Replace(call("x"))
+				}
+				return true
+			},
+			want: `package p
+
+func f() {
+	// a is first.
+	a()
+	// b is second.
+	x() // (trailing b)
+	// c is third.
+	c()
+}
+`,
+		},
+		{
+			name: "insert before",
+			pre: func(c *astutil.Cursor) bool {
+				if isCall(c.Node(), "b") {
+					c.InsertBefore(call("x"))
+				}
+				return true
+			},
+			want: `package p
+
+func f() {
+	// a is first.
+	a()
+	x()
+	// b is second.
+	b() // (trailing b)
+	// c is third.
+	c()
+}
+`,
+		},
+		{
+			name: "insert after",
+			pre: func(c *astutil.Cursor) bool {
+				if isCall(c.Node(), "b") {
+					c.InsertAfter(call("x"))
+				}
+				return true
+			},
+			want: `package p
+
+func f() {
+	// a is first.
+	a()
+	// b is second.
+	b() // (trailing b)
+	x()
+	// c is third.
+	c()
+}
+`,
+		},
+		{
+			name: "delete",
+			pre: func(c *astutil.Cursor) bool {
+				if isCall(c.Node(), "b") {
+					c.Delete()
+				}
+				return true
+			},
+			want: `package p
+
+func f() {
+	// a is first.
+	a()
+
+	// c is third.
+	c()
+}
+`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "p.go", orig, parser.ParseComments)
+			if err != nil {
+				t.Fatal(err)
+			}
+			astutil.RewriteFile(fset, f, test.pre, nil)
+			var buf bytes.Buffer
+			if err := format.Node(&buf, fset, f); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != test.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, test.want)
+			}
+		})
+	}
+}
@@ -170,6 +170,106 @@ func PathEnclosingInterval(root *ast.File, start, end token.Pos) (path []ast.Nod
 	return
 }
 
+// PathEnclosingIntervalFiles is like [PathEnclosingInterval], but it
+// looks for the interval [start, end) in whichever of files contains
+// it, such as the files of a package loaded by go/packages, and it
+// tolerates an interval that lies within a comment or whitespace.
+//
+// If the interval lies within a doc or line comment, such as that of
+// a declaration or a struct field, the result is the path to the
+// node that the comment belongs to. If it lies wholly within another
+// comment, or within whitespace between the children of a node, the
+// result is the path to the nearest child of the innermost node that
+// encloses the interval, preferring the following child if two are
+// equally near. In both cases, exact is false. Comments never appear
+// in the result.
+//
+// If no file contains the interval, the result is (nil, false).
+func PathEnclosingIntervalFiles(files []*ast.File, start, end token.Pos) (path []ast.Node, exact bool) {
+	if start > end {
+		start, end = end, start
+	}
+	for _, f := range files {
+		if !(f.FileStart <= start && end <= f.FileEnd) {
+			continue
+		}
+		path, exact = PathEnclosingInterval(f, start, end)
+		if is[*ast.Comment](path[0]) || is[*ast.CommentGroup](path[0]) {
+			// A doc or line comment: use the node it documents.
+			for is[*ast.Comment](path[0]) || is[*ast.CommentGroup](path[0]) {
+				path = path[1:]
+			}
+			return path, false
+		}
+		if !exact {
+			if child := nearestChild(path[0], start, end); child != nil {
+				path, _ = PathEnclosingInterval(f, child.Pos(), child.End())
+			}
+		}
+		return path, exact
+	}
+	return nil, false
+}
+
+// nearestChild returns the child of n, other than a token or comment,
+// nearest to the interval [start, end), preferring the later of two
+// equally near children, or nil if a child overlaps the interval or n
+// has no children. A child whose doc comment contains the interval is
+// nearest.
+func nearestChild(n ast.Node, start, end token.Pos) ast.Node {
+	if start == end {
+		end = start + 1 // empty interval => interval of size 1
+	}
+	var (
+		nearest ast.Node
+		dist    token.Pos
+	)
+	for _, child := range childrenOf(n) {
+		switch child.(type) {
+		case tokenNode, *ast.Comment, *ast.CommentGroup:
+			continue
+		}
+		pos := child.Pos()
+		if doc := docComment(child); doc != nil {
+			pos = doc.Pos()
+		}
+		var d token.Pos
+		switch {
+		case child.End() <= start:
+			d = start - child.End()
+		case end <= pos:
+			d = pos - end
+		case end <= child.Pos():
+			d = 0 // within doc comment
+		default:
+			return nil // overlap
+		}
+		if nearest == nil || d <= dist {
+			nearest, dist = child, d
+		}
+	}
+	return nearest
+}
+
+// docComment returns the doc comment of n, or nil.
+func docComment(n ast.Node) *ast.CommentGroup {
+	switch n := n.(type) {
+	case *ast.FuncDecl:
+		return n.Doc
+	case *ast.GenDecl:
+		return n.Doc
+	case *ast.Field:
+		return n.Doc
+	case *ast.ImportSpec:
+		return n.Doc
+	case *ast.ValueSpec:
+		return n.Doc
+	case *ast.TypeSpec:
+		return n.Doc
+	}
+	return nil
+}
+
 // tokenNode is a dummy implementation of ast.Node for a single token.
 // They are used transiently by PathEnclosingInterval but never escape
 // this package.
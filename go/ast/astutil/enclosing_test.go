@@ -231,3 +231,58 @@ func TestPathEnclosingInterval_Paths(t *testing.T) {
 		}
 	}
 }
+
+func TestPathEnclosingIntervalFiles(t *testing.T) {
+	const a = `package p
+
+var x = 1
+`
+	const b = `package p
+
+// f is a function.
+func f() {
+	x := 1
+
+	// a comment
+	_ = x
+}
+`
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, src := range []string{a, b} {
+		f, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, f)
+	}
+	fileB := fset.File(files[1].FileStart)
+
+	for _, test := range []struct {
+		substr string // first occurrence in b of this string indicates interval
+		path   string
+		exact  bool
+	}{
+		{"x := 1", "[AssignStmt BlockStmt FuncDecl File]", true},
+		{"is a", "[FuncDecl File]", false},
+		{"a comment", "[AssignStmt BlockStmt FuncDecl File]", false},
+		{"\n\n\t//", "[AssignStmt BlockStmt FuncDecl File]", false},
+	} {
+		i := strings.Index(b, test.substr)
+		start, end := fileB.Pos(i), fileB.Pos(i+len(test.substr))
+		path, exact := astutil.PathEnclosingIntervalFiles(files, start, end)
+		if got := pathToString(path); got != test.path || exact != test.exact {
+			t.Errorf("PathEnclosingIntervalFiles(%q) = %s, %t; want %s, %t",
+				test.substr, got, exact, test.path, test.exact)
+		}
+		if path[len(path)-1] != files[1] {
+			t.Errorf("PathEnclosingIntervalFiles(%q) is not in the second file", test.substr)
+		}
+	}
+
+	// An interval within neither file.
+	beyond := token.Pos(fileB.Base() + fileB.Size() + 1)
+	if path, _ := astutil.PathEnclosingIntervalFiles(files, beyond, beyond); path != nil {
+		t.Errorf("PathEnclosingIntervalFiles(beyond files) = %s, want nil", pathToString(path))
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package astutil
+
+// This file defines InsertBefore and InsertAfter, which compute the
+// text edits that insert new syntax into a file.
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// InsertBefore returns the text edits that insert the formatted nodes
+// before the node at, and that add to file the imports needed by the
+// new nodes. The edits are suitable for use as the TextEdits of an
+// [analysis.SuggestedFix]; they do not modify file.
+//
+// The node at must be either a top-level declaration of file, in which
+// case each node must be an [ast.Decl], or a statement of a block or
+// case clause that begins its line, in which case each node must be an
+// [ast.Stmt]. The new statements are indented like at, assuming that
+// the file is indented by tabs, as by gofmt. The new declarations are
+// inserted before the doc comment of at, if any, and separated from
+// it by a blank line.
+//
+// The imports map gives the import path of each package name that the
+// new nodes may use to qualify an identifier, as in fmt.Println. An
+// import is added for each such name used by the nodes, unless file
+// already imports the package under that name. It is an error if file
+// imports the package under another name, or uses the name for
+// another package.
+func InsertBefore(fset *token.FileSet, file *ast.File, at ast.Node, nodes []ast.Node, imports map[string]string) ([]analysis.TextEdit, error) {
+	return insert(fset, file, at, nodes, imports, false)
+}
+
+// InsertAfter is like [InsertBefore], but it inserts the nodes after
+// the line on which at ends, following any comment on that line.
+func InsertAfter(fset *token.FileSet, file *ast.File, at ast.Node, nodes []ast.Node, imports map[string]string) ([]analysis.TextEdit, error) {
+	return insert(fset, file, at, nodes, imports, true)
+}
+
+func insert(fset *token.FileSet, file *ast.File, at ast.Node, nodes []ast.Node, imports map[string]string, after bool) ([]analysis.TextEdit, error) {
+	tokFile := fset.File(file.Pos())
+	if tokFile == nil || fset.File(at.Pos()) != tokFile {
+		return nil, fmt.Errorf("node is not in file %s", file.Name.Name)
+	}
+
+	// Check the kinds of nodes.
+	var (
+		isDecl bool
+		indent int
+	)
+	switch at := at.(type) {
+	case ast.Decl:
+		if !slices.Contains(file.Decls, at) {
+			return nil, fmt.Errorf("%T is not a top-level declaration", at)
+		}
+		isDecl = true
+	case ast.Stmt:
+		indent = tokFile.Position(at.Pos()).Column - 1
+	default:
+		return nil, fmt.Errorf("cannot insert at %T", at)
+	}
+	for _, n := range nodes {
+		if _, ok := n.(ast.Decl); isDecl && !ok {
+			return nil, fmt.Errorf("cannot insert %T among declarations", n)
+		}
+		if _, ok := n.(ast.Stmt); !isDecl && !ok {
+			return nil, fmt.Errorf("cannot insert %T among statements", n)
+		}
+	}
+
+	// Format the nodes.
+	sep := "\n"
+	if isDecl {
+		sep = "\n\n"
+	}
+	var buf bytes.Buffer
+	cfg := printer.Config{Mode: printer.UseSpaces | printer.TabIndent, Tabwidth: 8, Indent: indent}
+	for i, n := range nodes {
+		if i > 0 {
+			buf.WriteString(sep)
+		}
+		if err := cfg.Fprint(&buf, fset, n); err != nil {
+			return nil, err
+		}
+	}
+
+	edits, err := addImports(tokFile, file, nodes, imports)
+	if err != nil {
+		return nil, err
+	}
+
+	var edit analysis.TextEdit
+	if after {
+		// Insert at the end of the line.
+		line := tokFile.Line(at.End())
+		pos := token.Pos(tokFile.Base() + tokFile.Size())
+		if line < tokFile.LineCount() {
+			pos = tokFile.LineStart(line+1) - 1
+		}
+		edit = analysis.TextEdit{Pos: pos, End: pos, NewText: []byte(sep + buf.String())}
+	} else {
+		// Insert at the start of the line, before any doc comment.
+		start := at.Pos()
+		switch at := at.(type) {
+		case *ast.FuncDecl:
+			if at.Doc != nil {
+				start = at.Doc.Pos()
+			}
+		case *ast.GenDecl:
+			if at.Doc != nil {
+				start = at.Doc.Pos()
+			}
+		}
+		pos := tokFile.LineStart(tokFile.Line(start))
+		edit = analysis.TextEdit{Pos: pos, End: pos, NewText: []byte(buf.String() + sep)}
+	}
+	return append(edits, edit), nil
+}
+
+// addImports returns the edits that add to file the imports, among
+// those of the imports map, of the package names used by nodes.
+func addImports(tokFile *token.File, file *ast.File, nodes []ast.Node, imports map[string]string) ([]analysis.TextEdit, error) {
+	// Find the package names used by nodes.
+	used := make(map[string]bool)
+	for _, n := range nodes {
+		ast.Inspect(n, func(n ast.Node) bool {
+			if sel, ok := n.(*ast.SelectorExpr); ok {
+				if id, ok := sel.X.(*ast.Ident); ok {
+					if _, ok := imports[id.Name]; ok {
+						used[id.Name] = true
+					}
+				}
+			}
+			return true
+		})
+	}
+
+	// Determine the import specs to add, in order of path.
+	var specs []string
+	var paths []string
+	byPath := make(map[string]string)
+names:
+	for name := range used {
+		path := imports[name]
+		for _, s := range file.Imports {
+			switch specName := guessImportName(s); {
+			case importPath(s) == path && specName == name:
+				continue names // already imported
+			case importPath(s) == path:
+				return nil, fmt.Errorf("file imports %q as %s, not %s", path, specName, name)
+			case specName == name:
+				return nil, fmt.Errorf("file imports %q as %s, which conflicts with %q", importPath(s), name, path)
+			}
+		}
+		spec := strconv.Quote(path)
+		if name != guessName(path) {
+			spec = name + " " + spec
+		}
+		paths = append(paths, path)
+		byPath[path] = spec
+	}
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		specs = append(specs, byPath[path])
+	}
+
+	// Find the first import declaration, preferring a grouped one.
+	var decl *ast.GenDecl
+	for _, d := range file.Decls {
+		gen, ok := d.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			break
+		}
+		if decl == nil || !decl.Lparen.IsValid() && gen.Lparen.IsValid() {
+			decl = gen
+		}
+	}
+
+	insertion := func(pos token.Pos, text string) analysis.TextEdit {
+		return analysis.TextEdit{Pos: pos, End: pos, NewText: []byte(text)}
+	}
+	switch {
+	case decl == nil:
+		// Add a declaration after the package clause.
+		text := "\n\nimport " + specs[0]
+		if len(specs) > 1 {
+			text = "\n\nimport (\n\t" + strings.Join(specs, "\n\t") + "\n)"
+		}
+		return []analysis.TextEdit{insertion(file.Name.End(), text)}, nil
+
+	case !decl.Lparen.IsValid():
+		// Group the existing import with the new ones.
+		spec := decl.Specs[0]
+		return []analysis.TextEdit{
+			insertion(spec.Pos(), "(\n\t"),
+			insertion(spec.End(), "\n\t"+strings.Join(specs, "\n\t")+"\n)"),
+		}, nil
+
+	default:
+		// Add each spec to the group before the first spec with a
+		// greater path, or at the end.
+		var edits []analysis.TextEdit
+		for i, path := range paths {
+			pos := tokFile.LineStart(tokFile.Line(decl.Rparen))
+			for _, s := range decl.Specs {
+				if importPath(s.(*ast.ImportSpec)) > path {
+					pos = tokFile.LineStart(tokFile.Line(s.Pos()))
+					break
+				}
+			}
+			text := "\t" + specs[i] + "\n"
+			if n := len(edits); n > 0 && edits[n-1].Pos == pos {
+				edits[n-1].NewText = append(edits[n-1].NewText, text...)
+			} else {
+				edits = append(edits, insertion(pos, text))
+			}
+		}
+		return edits, nil
+	}
+}
+
+// guessImportName returns the name under which s imports its package,
+// guessing it from the path if the import is not named.
+func guessImportName(s *ast.ImportSpec) string {
+	if s.Name != nil {
+		return s.Name.Name
+	}
+	return guessName(importPath(s))
+}
+
+// guessName returns the last element of an import path, the usual
+// name of its package.
+func guessName(path string) string {
+	return path[strings.LastIndex(path, "/")+1:]
+}
@@ -0,0 +1,233 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package astutil_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+func TestInsert(t *testing.T) {
+	// println returns the statement pkg.Println("msg").
+	println := func(pkg, msg string) ast.Node {
+		return &ast.ExprStmt{X: &ast.CallExpr{
+			Fun:  &ast.SelectorExpr{X: ast.NewIdent(pkg), Sel: ast.NewIdent("Println")},
+			Args: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: `"` + msg + `"`}},
+		}}
+	}
+	// decl returns the declaration var name = pkg.Value.
+	decl := func(name, pkg string) ast.Node {
+		return &ast.GenDecl{Tok: token.VAR, Specs: []ast.Spec{&ast.ValueSpec{
+			Names:  []*ast.Ident{ast.NewIdent(name)},
+			Values: []ast.Expr{&ast.SelectorExpr{X: ast.NewIdent(pkg), Sel: ast.NewIdent("Value")}},
+		}}}
+	}
+	// stmt returns the first statement of the function body that
+	// begins with prefix.
+	stmt := func(prefix string) func(fset *token.FileSet, f *ast.File, src string) ast.Node {
+		return func(fset *token.FileSet, f *ast.File, src string) ast.Node {
+			var found ast.Node
+			ast.Inspect(f, func(n ast.Node) bool {
+				if s, ok := n.(ast.Stmt); ok && found == nil && strings.HasPrefix(src[fset.Position(s.Pos()).Offset:], prefix) {
+					if _, ok := s.(*ast.BlockStmt); !ok {
+						found = s
+					}
+				}
+				return found == nil
+			})
+			return found
+		}
+	}
+	// lastDecl returns the last declaration of the file.
+	lastDecl := func(fset *token.FileSet, f *ast.File, src string) ast.Node {
+		return f.Decls[len(f.Decls)-1]
+	}
+
+	for _, test := range []struct {
+		name    string
+		src     string
+		at      func(fset *token.FileSet, f *ast.File, src string) ast.Node
+		after   bool
+		nodes   []ast.Node
+		imports map[string]string
+		want    string // or error
+	}{
+		{
+			name: "before statement, grouped imports",
+			src: `package p
+
+import (
+	"fmt"
+	"os"
+)
+
+func f() {
+	if true {
+		os.Exit(1)
+	}
+}
+`,
+			at:      stmt("os.Exit"),
+			nodes:   []ast.Node{println("fmt", "a"), println("log", "b")},
+			imports: map[string]string{"fmt": "fmt", "log": "log", "strings": "strings"},
+			want: `package p
+
+import (
+	"fmt"
+	"log"
+	"os"
+)
+
+func f() {
+	if true {
+		fmt.Println("a")
+		log.Println("b")
+		os.Exit(1)
+	}
+}
+`,
+		},
+		{
+			name: "after statement, single import",
+			src: `package p
+
+import "os"
+
+func f() {
+	os.Exit(1) // exit
+	return
+}
+`,
+			at:      stmt("os.Exit"),
+			after:   true,
+			nodes:   []ast.Node{println("fmt", "a")},
+			imports: map[string]string{"fmt": "fmt"},
+			want: `package p
+
+import (
+	"os"
+	"fmt"
+)
+
+func f() {
+	os.Exit(1) // exit
+	fmt.Println("a")
+	return
+}
+`,
+		},
+		{
+			name: "before declaration, no imports",
+			src: `package p
+
+// F is a function.
+func F() {}
+`,
+			at:      lastDecl,
+			nodes:   []ast.Node{decl("x", "pathpkg"), decl("y", "errors")},
+			imports: map[string]string{"pathpkg": "path", "errors": "errors"},
+			want: `package p
+
+import (
+	"errors"
+	pathpkg "path"
+)
+
+var x = pathpkg.Value
+
+var y = errors.Value
+
+// F is a function.
+func F() {}
+`,
+		},
+		{
+			name: "after declaration",
+			src: `package p
+
+import "fmt"
+
+func F() {}`,
+			at:      lastDecl,
+			after:   true,
+			nodes:   []ast.Node{decl("x", "fmt")},
+			imports: map[string]string{"fmt": "fmt"},
+			want: `package p
+
+import "fmt"
+
+func F() {}
+
+var x = fmt.Value`,
+		},
+		{
+			name: "conflicting import",
+			src: `package p
+
+import fmt "example.com/fmt"
+
+func f() {
+	return
+}
+`,
+			at:      stmt("return"),
+			nodes:   []ast.Node{println("fmt", "a")},
+			imports: map[string]string{"fmt": "fmt"},
+			want:    `file imports "example.com/fmt" as fmt, which conflicts with "fmt"`,
+		},
+		{
+			name: "statement among declarations",
+			src: `package p
+
+func F() {}
+`,
+			at:    lastDecl,
+			nodes: []ast.Node{println("fmt", "a")},
+			want:  "cannot insert *ast.ExprStmt among declarations",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "p.go", test.src, parser.ParseComments)
+			if err != nil {
+				t.Fatal(err)
+			}
+			insert := astutil.InsertBefore
+			if test.after {
+				insert = astutil.InsertAfter
+			}
+			edits, err := insert(fset, f, test.at(fset, f, test.src), test.nodes, test.imports)
+			var got string
+			if err != nil {
+				got = err.Error()
+			} else {
+				got = applyEdits(fset, test.src, edits)
+			}
+			if got != test.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+// applyEdits returns the result of applying the edits to src.
+func applyEdits(fset *token.FileSet, src string, edits []analysis.TextEdit) string {
+	edits = slices.Clone(edits)
+	slices.SortStableFunc(edits, func(x, y analysis.TextEdit) int {
+		return int(y.Pos - x.Pos) // last first
+	})
+	for _, edit := range edits {
+		start, end := fset.Position(edit.Pos).Offset, fset.Position(edit.End).Offset
+		src = src[:start] + string(edit.NewText) + src[end:]
+	}
+	return src
+}
@@ -40,6 +40,12 @@ type ApplyFunc func(*Cursor) bool
 // respective node's struct definition. A package's files are
 // traversed in the filenames' alphabetical order.
 func Apply(root ast.Node, pre, post ApplyFunc) (result ast.Node) {
+	return apply(root, pre, post, nil)
+}
+
+// apply is like Apply, but it reports changes to the tree to the
+// comment tracker t, if non-nil (see RewriteFile).
+func apply(root ast.Node, pre, post ApplyFunc, t *commentTracker) (result ast.Node) {
 	parent := &struct{ ast.Node }{root}
 	defer func() {
 		if r := recover(); r != nil && r != abort {
@@ -48,6 +54,7 @@ func Apply(root ast.Node, pre, post ApplyFunc) (result ast.Node) {
 		result = parent.Node
 	}()
 	a := &application{pre: pre, post: post}
+	a.cursor.comments = t
 	a.apply(parent, "Node", nil, root)
 	return
 }
@@ -72,10 +79,11 @@ var abort = new(int) // singleton, to signal termination of Apply
 // package [golang.org/x/tools/go/ast/inspector], which provides
 // stateless navigation of immutable syntax trees.
 type Cursor struct {
-	parent ast.Node
-	name   string
-	iter   *iterator // valid if non-nil
-	node   ast.Node
+	parent   ast.Node
+	name     string
+	iter     *iterator // valid if non-nil
+	node     ast.Node
+	comments *commentTracker // non-nil within RewriteFile
 }
 
 // Node returns the current Node.
@@ -116,6 +124,9 @@ func (c *Cursor) Replace(n ast.Node) {
 		c.parent.(*ast.Package).Files[c.name] = file
 		return
 	}
+	if c.comments != nil {
+		c.comments.replace(c.node, n)
+	}
 
 	v := c.field()
 	if i := c.Index(); i >= 0 {
@@ -154,6 +165,9 @@ func (c *Cursor) InsertAfter(n ast.Node) {
 	if i < 0 {
 		panic("InsertAfter node not contained in slice")
 	}
+	if c.comments != nil {
+		c.comments.insertAfter(c.node, n)
+	}
 	v := c.field()
 	v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
 	l := v.Len()
@@ -170,6 +184,9 @@ func (c *Cursor) InsertBefore(n ast.Node) {
 	if i < 0 {
 		panic("InsertBefore node not contained in slice")
 	}
+	if c.comments != nil {
+		c.comments.insertBefore(c.node, n)
+	}
 	v := c.field()
 	v.Set(reflect.Append(v, reflect.Zero(v.Type().Elem())))
 	l := v.Len()
@@ -0,0 +1,141 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package edit computes the text edits that transform the source of
+// a Go file into the source of a modified syntax tree, so that an
+// analyzer may express a suggested fix as a transformation of the
+// tree and let this package derive the [analysis.TextEdit] values of
+// its [analysis.SuggestedFix].
+//
+// The typical use is to clone the syntax tree of a file, modify the
+// clone, and compute the edits:
+//
+//	edits, err := edit.Transform(pass.Fset, src, file, func(file *ast.File) {
+//		// ...modify file...
+//	})
+//
+// where src is the content of the file, as provided by
+// [analysis.Pass.ReadFile]. The original tree, which may be shared
+// with other analyzers, is not modified.
+//
+// The edits are computed by formatting the modified tree, so they
+// reflect the layout chosen by [go/format] for new or modified nodes,
+// and the positions of the comments of the file determine where they
+// are printed. Nodes that are moved or inserted without a position
+// may thus be printed apart from their comments; the function
+// [golang.org/x/tools/go/ast/astutil.RewriteFile] maintains the
+// association of comments and nodes during a rewrite.
+package edit
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"reflect"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/internal/diff"
+)
+
+// Transform returns the edits that transform src, the content of the
+// file whose syntax tree is file, by the function f. It applies f to
+// a clone of file (see [Clone]), leaving file unchanged, and returns
+// the result of [Edits] for the modified clone.
+func Transform(fset *token.FileSet, src []byte, file *ast.File, f func(*ast.File)) ([]analysis.TextEdit, error) {
+	clone := Clone(file)
+	f(clone)
+	return Edits(fset, src, clone)
+}
+
+// Edits returns the minimal edits that transform src, the content of
+// a file of fset, into the formatted source of file, a syntax tree
+// derived from that of src, typically by [Clone] and modification.
+// The edits are ordered by position and do not overlap.
+//
+// If src is not formatted as if by gofmt, the edits also format the
+// parts of it that were not modified.
+func Edits(fset *token.FileSet, src []byte, file *ast.File) ([]analysis.TextEdit, error) {
+	tokFile := fset.File(file.FileStart)
+	if tokFile == nil {
+		return nil, fmt.Errorf("file is not in the file set")
+	}
+	if tokFile.Size() != len(src) {
+		return nil, fmt.Errorf("source of %s has size %d, want %d", tokFile.Name(), len(src), tokFile.Size())
+	}
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, err
+	}
+	var edits []analysis.TextEdit
+	for _, e := range diff.Bytes(src, buf.Bytes()) {
+		edits = append(edits, analysis.TextEdit{
+			Pos:     tokFile.Pos(e.Start),
+			End:     tokFile.Pos(e.End),
+			NewText: []byte(e.New),
+		})
+	}
+	return edits, nil
+}
+
+// Clone returns a deep copy of file, whose nodes have the positions of
+// those of file. A node referred to from several places in file, such
+// as an *ast.ImportSpec, which appears in both the Imports and Decls
+// of file, or an *ast.CommentGroup, which appears in both Comments and
+// the Doc of a declaration, is copied once, so that the clone has the
+// same structure as file. The Scope field of the clone and the Obj
+// fields of its identifiers are nil.
+func Clone(file *ast.File) *ast.File {
+	clones := make(map[any]reflect.Value) // maps each pointer to its clone
+	var clone func(x reflect.Value) reflect.Value
+	clone = func(x reflect.Value) reflect.Value {
+		switch x.Kind() {
+		case reflect.Pointer:
+			if x.IsNil() {
+				return x
+			}
+			switch x.Interface().(type) {
+			case *ast.Object, *ast.Scope:
+				return reflect.Zero(x.Type())
+			}
+			if y, ok := clones[x.Interface()]; ok {
+				return y
+			}
+			y := reflect.New(x.Type().Elem())
+			clones[x.Interface()] = y
+			y.Elem().Set(clone(x.Elem()))
+			return y
+
+		case reflect.Struct:
+			y := reflect.New(x.Type()).Elem()
+			for i := range x.NumField() {
+				y.Field(i).Set(clone(x.Field(i)))
+			}
+			return y
+
+		case reflect.Slice:
+			if x.IsNil() {
+				return x
+			}
+			y := reflect.MakeSlice(x.Type(), x.Len(), x.Len())
+			for i := range x.Len() {
+				y.Index(i).Set(clone(x.Index(i)))
+			}
+			return y
+
+		case reflect.Interface:
+			if x.IsNil() {
+				return x
+			}
+			y := reflect.New(x.Type()).Elem()
+			y.Set(clone(x.Elem()))
+			return y
+
+		default:
+			return x // bool, string, number
+		}
+	}
+	return clone(reflect.ValueOf(file)).Interface().(*ast.File)
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edit_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ast/edit"
+	"golang.org/x/tools/internal/diff"
+)
+
+func TestTransform(t *testing.T) {
+	const src = `package p
+
+import "fmt"
+
+// F prints a greeting.
+func F() {
+	fmt.Println("hello") // greet
+}
+
+// G is unchanged.
+func G() {}
+`
+	const want = `package p
+
+import (
+	"fmt"
+	"os"
+)
+
+// F prints a greeting.
+func F() {
+	fmt.Fprintln(os.Stderr, "hello") // greet
+}
+
+// G is unchanged.
+func G() {}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edits, err := edit.Transform(fset, []byte(src), file, func(file *ast.File) {
+		ast.Inspect(file, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok {
+				if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Println" {
+					sel.Sel = ast.NewIdent("Fprintln")
+					stderr := &ast.SelectorExpr{X: ast.NewIdent("os"), Sel: ast.NewIdent("Stderr")}
+					call.Args = append([]ast.Expr{stderr}, call.Args...)
+				}
+			}
+			return true
+		})
+		astutil.AddImport(fset, file, "os")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The original tree is unchanged.
+	if len(file.Imports) != 1 {
+		t.Errorf("Transform modified the original file: %d imports", len(file.Imports))
+	}
+
+	// The edits are minimal: they do not touch G.
+	tokFile := fset.File(file.FileStart)
+	var diffEdits []diff.Edit
+	for _, e := range edits {
+		start, end := tokFile.Offset(e.Pos), tokFile.Offset(e.End)
+		if start > len(src)-len("// G is unchanged.\nfunc G() {}\n") {
+			t.Errorf("edit %v changes G", e)
+		}
+		diffEdits = append(diffEdits, diff.Edit{Start: start, End: end, New: string(e.NewText)})
+	}
+	got, err := diff.Apply(src, diffEdits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestClone(t *testing.T) {
+	const src = `package p
+
+// Doc.
+import "fmt"
+
+var _ = fmt.Println
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clone := edit.Clone(file)
+	decl := clone.Decls[0].(*ast.GenDecl)
+	if clone.Imports[0] == file.Imports[0] {
+		t.Errorf("Clone did not copy the import spec")
+	}
+	if clone.Imports[0] != decl.Specs[0] {
+		t.Errorf("Clone copied the import spec more than once")
+	}
+	if clone.Comments[0] != decl.Doc {
+		t.Errorf("Clone copied the doc comment more than once")
+	}
+	if clone.Decls[0].Pos() != file.Decls[0].Pos() {
+		t.Errorf("Clone changed positions")
+	}
+}
@@ -379,6 +379,32 @@ func TestCursor_Edge(t *testing.T) {
 	}
 }
 
+// This example uses the parent edge of a cursor to find the
+// assignments that appear as the Init statement of a for loop,
+// without a traversal that maintains a stack.
+func ExampleCursor_ParentEdge() {
+	const src = `package p
+
+func f() {
+	x := 0
+	for i := 0; i < 10; i++ {
+		x += i
+	}
+}`
+	fset := token.NewFileSet()
+	f, _ := parser.ParseFile(fset, "p.go", src, 0)
+	inspect := inspector.New([]*ast.File{f})
+
+	for cur := range inspect.Root().Preorder((*ast.AssignStmt)(nil)) {
+		if e, _ := cur.ParentEdge(); e == edge.ForStmt_Init {
+			fmt.Printf("%s: loop init\n", fset.Position(cur.Node().Pos()))
+		}
+	}
+
+	// Output:
+	// p.go:5:6: loop init
+}
+
 func is[T any](x any) bool {
 	_, ok := x.(T)
 	return ok
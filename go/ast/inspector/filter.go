@@ -0,0 +1,101 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inspector
+
+import (
+	"go/ast"
+	"math/bits"
+)
+
+// A Filter selects the nodes visited by [Inspector.PreorderFilter]:
+// nodes of particular types, each optionally subject to a predicate.
+// Filters are built by [Types], [Match], and [Or], and may be computed
+// once and reused across traversals.
+//
+// The node types of a Filter are used, as in [Inspector.Preorder], to
+// skip subtrees that contain no nodes of those types; its predicates
+// are evaluated only for nodes of the matching type, so that the
+// callback is not called for nodes that are irrelevant to it.
+type Filter struct {
+	mask  uint64                     // types of nodes that may match
+	preds *[64]func(n ast.Node) bool // for each type bit, nil or a predicate
+}
+
+// Types returns a Filter that matches all nodes whose type matches an
+// element of the types slice, like the types argument of
+// [Inspector.Preorder]. An empty slice matches all nodes.
+func Types(types ...ast.Node) Filter {
+	return Filter{mask: maskOf(types)}
+}
+
+// Match returns a Filter that matches the nodes n of type N for which
+// pred(n) returns true.
+//
+// Example:
+//
+//	defines := inspector.Match(func(assign *ast.AssignStmt) bool {
+//		return assign.Tok == token.DEFINE
+//	})
+func Match[N interface {
+	*S
+	ast.Node
+}, S any](pred func(N) bool) Filter {
+	mask := typeOf((N)(nil))
+	if mask == 0 {
+		panic("inspector.Match: unsupported node type")
+	}
+	var preds [64]func(ast.Node) bool
+	preds[bits.TrailingZeros64(mask)] = func(n ast.Node) bool { return pred(n.(N)) }
+	return Filter{mask: mask, preds: &preds}
+}
+
+// Or returns a Filter that matches the nodes matched by any of the
+// filters.
+func Or(filters ...Filter) Filter {
+	var (
+		res      Filter
+		preds    [64]func(ast.Node) bool
+		hasPreds bool
+	)
+	for _, f := range filters {
+		for m := f.mask; m != 0; m &= m - 1 {
+			bit := bits.TrailingZeros64(m)
+			var pred func(ast.Node) bool
+			if f.preds != nil {
+				pred = f.preds[bit]
+			}
+			switch {
+			case res.mask&(1<<bit) == 0:
+				preds[bit] = pred
+			case pred == nil || preds[bit] == nil:
+				preds[bit] = nil // unconditional
+			default:
+				p, q := preds[bit], pred
+				preds[bit] = func(n ast.Node) bool { return p(n) || q(n) }
+			}
+			hasPreds = hasPreds || preds[bit] != nil
+		}
+		res.mask |= f.mask
+	}
+	if hasPreds {
+		res.preds = &preds
+	}
+	return res
+}
+
+// PreorderFilter visits the nodes of the files supplied to New in
+// the same manner as Preorder, but it calls f only for the nodes
+// selected by filter.
+func (in *Inspector) PreorderFilter(filter Filter, f func(ast.Node)) {
+	if filter.preds != nil {
+		f0, preds := f, filter.preds
+		f = func(n ast.Node) {
+			if pred := preds[bits.TrailingZeros64(typeOf(n))]; pred == nil || pred(n) {
+				f0(n)
+			}
+		}
+	}
+	in.preorder(filter.mask, 0, int32(len(in.events))-1, f)
+}
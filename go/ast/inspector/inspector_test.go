@@ -14,6 +14,8 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"golang.org/x/tools/go/ast/inspector"
@@ -311,3 +313,153 @@ func BenchmarkASTInspect(b *testing.B) {
 		}
 	}
 }
+
+func TestParallelPreorder(t *testing.T) {
+	inspect := inspector.New(netFiles)
+	nodeFilter := []ast.Node{(*ast.FuncDecl)(nil), (*ast.CallExpr)(nil)}
+
+	// Preorder and ParallelPreorder visit the same nodes.
+	var want []ast.Node
+	inspect.Preorder(nodeFilter, func(n ast.Node) { want = append(want, n) })
+	var (
+		mu   sync.Mutex
+		seen = make(map[ast.Node]bool)
+	)
+	inspect.ParallelPreorder(nodeFilter, func(n ast.Node) {
+		mu.Lock()
+		seen[n] = true
+		mu.Unlock()
+	})
+	if len(seen) != len(want) {
+		t.Errorf("ParallelPreorder visited %d nodes, want %d", len(seen), len(want))
+	}
+	for _, n := range want {
+		if !seen[n] {
+			t.Errorf("ParallelPreorder did not visit %T at %v", n, netFset.Position(n.Pos()))
+			break
+		}
+	}
+
+	// PreorderFiles visits each file's nodes in order, and merges
+	// them in file order.
+	var got []ast.Node
+	var files []*ast.File
+	inspector.PreorderFiles(inspect, nodeFilter,
+		func(nodes []ast.Node, n ast.Node) []ast.Node { return append(nodes, n) },
+		func(file *ast.File, nodes []ast.Node) {
+			files = append(files, file)
+			got = append(got, nodes...)
+		})
+	compare(t, got, want)
+	compare(t, files, netFiles)
+}
+
+func BenchmarkInspectParallel(b *testing.B) {
+	inspect := inspector.New(netFiles)
+
+	var ndecls, nlits atomic.Int64
+	for b.Loop() {
+		inspect.ParallelPreorder(nil, func(n ast.Node) {
+			switch n.(type) {
+			case *ast.FuncDecl:
+				ndecls.Add(1)
+			case *ast.FuncLit:
+				nlits.Add(1)
+			}
+		})
+	}
+}
+
+func BenchmarkInspectPreorderFiles(b *testing.B) {
+	inspect := inspector.New(netFiles)
+
+	type counts struct{ ndecls, nlits int }
+	var total counts
+	for b.Loop() {
+		inspector.PreorderFiles(inspect, nil,
+			func(c counts, n ast.Node) counts {
+				switch n.(type) {
+				case *ast.FuncDecl:
+					c.ndecls++
+				case *ast.FuncLit:
+					c.nlits++
+				}
+				return c
+			},
+			func(_ *ast.File, c counts) {
+				total.ndecls += c.ndecls
+				total.nlits += c.nlits
+			})
+	}
+}
+
+func TestPreorderFilter(t *testing.T) {
+	inspect := inspector.New(netFiles)
+
+	isDefine := func(assign *ast.AssignStmt) bool { return assign.Tok == token.DEFINE }
+	isMethodCall := func(call *ast.CallExpr) bool {
+		_, ok := call.Fun.(*ast.SelectorExpr)
+		return ok
+	}
+	for _, test := range []struct {
+		name   string
+		filter inspector.Filter
+		match  func(ast.Node) bool
+	}{
+		{"types", inspector.Types((*ast.FuncDecl)(nil), (*ast.FuncLit)(nil)), func(n ast.Node) bool {
+			switch n.(type) {
+			case *ast.FuncDecl, *ast.FuncLit:
+				return true
+			}
+			return false
+		}},
+		{"match", inspector.Match(isDefine), func(n ast.Node) bool {
+			assign, ok := n.(*ast.AssignStmt)
+			return ok && isDefine(assign)
+		}},
+		{"or", inspector.Or(inspector.Match(isDefine), inspector.Match(isMethodCall), inspector.Types((*ast.GoStmt)(nil))), func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				return isDefine(n)
+			case *ast.CallExpr:
+				return isMethodCall(n)
+			case *ast.GoStmt:
+				return true
+			}
+			return false
+		}},
+		{"or unconditional", inspector.Or(inspector.Match(isDefine), inspector.Types((*ast.AssignStmt)(nil))), func(n ast.Node) bool {
+			_, ok := n.(*ast.AssignStmt)
+			return ok
+		}},
+	} {
+		var got, want []ast.Node
+		inspect.PreorderFilter(test.filter, func(n ast.Node) { got = append(got, n) })
+		inspect.Preorder(nil, func(n ast.Node) {
+			if test.match(n) {
+				want = append(want, n)
+			}
+		})
+		if len(want) == 0 {
+			t.Errorf("%s: no matching nodes", test.name)
+		}
+		if len(got) != len(want) {
+			t.Errorf("%s: PreorderFilter visited %d nodes, want %d", test.name, len(got), len(want))
+			continue
+		}
+		compare(t, got, want)
+	}
+}
+
+func BenchmarkInspectFilterPredicate(b *testing.B) {
+	inspect := inspector.New(netFiles)
+
+	// Measure the cost of visiting only short variable declarations.
+	filter := inspector.Match(func(assign *ast.AssignStmt) bool { return assign.Tok == token.DEFINE })
+	var ndefines int
+	for b.Loop() {
+		inspect.PreorderFilter(filter, func(n ast.Node) {
+			ndefines++
+		})
+	}
+}
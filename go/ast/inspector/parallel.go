@@ -0,0 +1,92 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inspector
+
+import (
+	"go/ast"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ParallelPreorder visits the nodes of the files supplied to New in
+// the same manner as Preorder, except that the files are visited
+// concurrently, using up to GOMAXPROCS goroutines. The nodes of each
+// file are visited in order by a single goroutine, so f may be called
+// concurrently for nodes of different files; the caller is
+// responsible for synchronizing any state shared by those calls.
+//
+// The [PreorderFiles] function provides an alternative that does not
+// require synchronization.
+func (in *Inspector) ParallelPreorder(types []ast.Node, f func(ast.Node)) {
+	mask := maskOf(types)
+	in.parallelFiles(func(_ int, start, end int32) {
+		in.preorder(mask, start, end, f)
+	})
+}
+
+// PreorderFiles visits the nodes of the files supplied to New in the
+// same manner as [Inspector.ParallelPreorder], calling visit(acc, n)
+// for each node n of a file, where acc is the result of the previous
+// call for that file, or the zero value of T for its first node. It
+// then calls merge(file, acc) with the final result for each file, in
+// the order of the files. The calls to merge are not concurrent, so
+// merge may update shared state without synchronization.
+//
+// Example:
+//
+//	// Count the calls in each file.
+//	inspector.PreorderFiles(in, []ast.Node{(*ast.CallExpr)(nil)},
+//		func(count int, n ast.Node) int { return count + 1 },
+//		func(file *ast.File, count int) { counts[file] = count })
+func PreorderFiles[T any](in *Inspector, types []ast.Node, visit func(acc T, n ast.Node) T, merge func(file *ast.File, acc T)) {
+	mask := maskOf(types)
+	files := in.files()
+	results := make([]T, len(files))
+	in.parallelFiles(func(i int, start, end int32) {
+		var acc T
+		in.preorder(mask, start, end, func(n ast.Node) {
+			acc = visit(acc, n)
+		})
+		results[i] = acc
+	})
+	for i, start := range files {
+		merge(in.events[start].node.(*ast.File), results[i])
+	}
+}
+
+// files returns the indices of the push events of the files.
+func (in *Inspector) files() []int32 {
+	var files []int32
+	for i := int32(0); i < int32(len(in.events)); i = in.events[i].index + 1 {
+		files = append(files, i)
+	}
+	return files
+}
+
+// parallelFiles calls f(i, start, end) for the events [start, end]
+// of each file i, using up to GOMAXPROCS goroutines.
+func (in *Inspector) parallelFiles(f func(i int, start, end int32)) {
+	files := in.files()
+	var (
+		next int64 // index of next file to visit
+		wg   sync.WaitGroup
+	)
+	for range max(1, min(len(files), runtime.GOMAXPROCS(0))) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(atomic.AddInt64(&next, 1) - 1)
+				if i >= len(files) {
+					return
+				}
+				start := files[i]
+				f(i, start, in.events[start].index)
+			}
+		}()
+	}
+	wg.Wait()
+}
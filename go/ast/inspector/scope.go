@@ -0,0 +1,84 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inspector
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// WithScopes visits nodes in a similar manner to WithStack, but it
+// supplies each call to f the current stack of lexical scopes, as
+// recorded in info.Scopes, which must be populated. The stack's first
+// element is the scope of the enclosing *ast.File; its last is the
+// innermost scope that encloses n, which is the scope introduced by n
+// itself, if any, such as that of an *ast.BlockStmt or *ast.IfStmt.
+// (The file scope's parent is the package scope.)
+//
+// The scope of a function, which holds its receiver, type parameters,
+// parameters, and results, is pushed on entry to its *ast.FuncDecl or
+// *ast.FuncLit, so that it encloses both the signature and the body.
+// Consequently the name of a FuncDecl, which is declared in the
+// package scope, is visited within the function's scope.
+//
+// To find the object that an identifier at some position refers to,
+// use [types.Scope.LookupParent] on the innermost scope.
+func (in *Inspector) WithScopes(info *types.Info, nodeTypes []ast.Node, f func(n ast.Node, push bool, scopes []*types.Scope) (proceed bool)) {
+	mask := maskOf(nodeTypes)
+	var (
+		scopes []*types.Scope
+		pushed []bool // whether each node on the traversal stack pushed a scope
+	)
+	pushScope := func(n ast.Node) {
+		scope := info.Scopes[n]
+		switch n := n.(type) {
+		case *ast.FuncDecl:
+			scope = info.Scopes[n.Type]
+		case *ast.FuncLit:
+			scope = info.Scopes[n.Type]
+		}
+		// The FuncType of a FuncDecl or FuncLit has the scope
+		// already pushed by its parent.
+		ok := scope != nil && (len(scopes) == 0 || scopes[len(scopes)-1] != scope)
+		if ok {
+			scopes = append(scopes, scope)
+		}
+		pushed = append(pushed, ok)
+	}
+	popScope := func() {
+		if pushed[len(pushed)-1] {
+			scopes = scopes[:len(scopes)-1]
+		}
+		pushed = pushed[:len(pushed)-1]
+	}
+	for i := int32(0); i < int32(len(in.events)); {
+		ev := in.events[i]
+		if ev.index > i {
+			// push
+			pop := ev.index
+			pushScope(ev.node)
+			if ev.typ&mask != 0 {
+				if !f(ev.node, true, scopes) {
+					i = pop + 1
+					popScope()
+					continue
+				}
+			}
+			if in.events[pop].typ&mask == 0 {
+				// Subtrees do not contain types: skip them.
+				i = pop
+				continue
+			}
+		} else {
+			// pop
+			push := ev.index
+			if in.events[push].typ&mask != 0 {
+				f(ev.node, false, scopes)
+			}
+			popScope()
+		}
+		i++
+	}
+}
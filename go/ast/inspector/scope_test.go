@@ -0,0 +1,114 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inspector_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+func TestWithScopes(t *testing.T) {
+	const src = `package p
+
+var x = 1
+
+type T[P any] struct{ f P }
+
+func (t T[P]) m(x P) (r P) {
+	if x := t.f; true {
+		return x
+	}
+	for i := range 3 {
+		_ = i
+	}
+	switch y := any(x).(type) {
+	case int:
+		_ = y
+	}
+	f := func(x int) int { return x }
+	_ = f
+	return x
+}
+
+func g() int { x := x; return x }
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := &types.Info{
+		Uses:   make(map[*ast.Ident]types.Object),
+		Scopes: make(map[ast.Node]*types.Scope),
+	}
+	if _, err := new(types.Config).Check("p", fset, []*ast.File{f}, info); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every use of an identifier is resolved by a lookup in the
+	// innermost scope at its position.
+	in := inspector.New([]*ast.File{f})
+	uses := 0
+	in.WithScopes(info, []ast.Node{(*ast.Ident)(nil)}, func(n ast.Node, push bool, scopes []*types.Scope) bool {
+		if !push {
+			return true
+		}
+		if scopes[0] != info.Scopes[f] {
+			t.Errorf("%s: outermost scope is not the file scope", fset.Position(n.Pos()))
+		}
+		id := n.(*ast.Ident)
+		if obj := info.Uses[id]; obj != nil && obj.Pkg() != nil && !isSelected(obj) {
+			uses++
+			if _, got := scopes[len(scopes)-1].LookupParent(id.Name, id.Pos()); got != obj {
+				t.Errorf("%s: lookup of %s in innermost scope = %v, want %v", fset.Position(id.Pos()), id.Name, got, obj)
+			}
+		}
+		return true
+	})
+	if uses == 0 {
+		t.Error("no uses visited")
+	}
+
+	// Pruning pops the scope of the pruned node.
+	depth := make(map[ast.Node]int)
+	in.WithScopes(info, []ast.Node{(*ast.FuncDecl)(nil), (*ast.IfStmt)(nil)}, func(n ast.Node, push bool, scopes []*types.Scope) bool {
+		if push {
+			depth[n] = len(scopes)
+			_, isIf := n.(*ast.IfStmt)
+			return !isIf
+		}
+		if len(scopes) != depth[n] {
+			t.Errorf("%s: %d scopes on pop, want %d", fset.Position(n.Pos()), len(scopes), depth[n])
+		}
+		return true
+	})
+	for n, d := range depth {
+		if want := map[bool]int{true: 3, false: 2}[isIf(n)]; d != want {
+			t.Errorf("%s: %d scopes, want %d", fset.Position(n.Pos()), d, want)
+		}
+	}
+}
+
+// isSelected reports whether obj is a field or method, which is not
+// found by lexical lookup.
+func isSelected(obj types.Object) bool {
+	switch obj := obj.(type) {
+	case *types.Var:
+		return obj.IsField()
+	case *types.Func:
+		return obj.Signature().Recv() != nil
+	}
+	return false
+}
+
+func isIf(n ast.Node) bool {
+	_, ok := n.(*ast.IfStmt)
+	return ok
+}
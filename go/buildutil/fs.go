@@ -0,0 +1,298 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package buildutil
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/build"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/modfile"
+)
+
+// OverlayFS returns a file system that presents fsys, the tree of
+// files beneath the directory root of the host file system (as
+// returned by os.DirFS(root), for example), overlaid with the files
+// of overlay.
+//
+// The overlay maps absolute file names to their contents, as do
+// go/packages.Config.Overlay and OverlayContext, so the same overlay,
+// such as a set of unsaved editor buffers, may be shared by tools
+// that use go/packages and those that use the file system. Overlay
+// files beneath root take precedence over files of fsys, and those
+// that do not exist in fsys are added to it, along with any missing
+// parent directories; other overlay files are ignored.
+//
+// Unlike OverlayContext, the overlay is respected by all operations
+// of the file system, including Stat and ReadDir. It does not
+// follow symbolic links when comparing file names.
+func OverlayFS(fsys fs.FS, root string, overlay map[string][]byte) fs.FS {
+	ofs := &overlayFS{
+		base:  fsys,
+		files: make(map[string][]byte),
+		dirs:  make(map[string][]string),
+	}
+	for filename, content := range overlay {
+		rel, ok := relPath(root, filename)
+		if !ok || rel == "." {
+			continue
+		}
+		ofs.files[rel] = content
+		// Record the entry in each parent directory.
+		for name := rel; name != "."; name = path.Dir(name) {
+			dir := path.Dir(name)
+			if slices.Contains(ofs.dirs[dir], path.Base(name)) {
+				break // already recorded
+			}
+			ofs.dirs[dir] = append(ofs.dirs[dir], path.Base(name))
+		}
+	}
+	return ofs
+}
+
+// relPath returns the slash-separated path of filename relative to
+// root, if it is within root.
+func relPath(root, filename string) (string, bool) {
+	if filepath.Clean(filename) == filepath.Clean(root) {
+		return ".", true
+	}
+	return hasSubdir(root, filename)
+}
+
+// An overlayFS is a file system with overlaid files.
+type overlayFS struct {
+	base  fs.FS
+	files map[string][]byte   // contents of overlay files, by path
+	dirs  map[string][]string // names of overlay entries (files or directories) of each directory
+}
+
+var (
+	_ fs.ReadFileFS = (*overlayFS)(nil)
+	_ fs.ReadDirFS  = (*overlayFS)(nil)
+	_ fs.StatFS     = (*overlayFS)(nil)
+)
+
+func (ofs *overlayFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	if content, ok := ofs.files[name]; ok {
+		return &overlayFile{overlayInfo{path.Base(name), int64(len(content)), 0o444}, bytes.NewReader(content)}, nil
+	}
+	if _, ok := ofs.dirs[name]; ok {
+		entries, err := ofs.ReadDir(name)
+		if err != nil {
+			return nil, err
+		}
+		info, err := ofs.Stat(name)
+		if err != nil {
+			return nil, err
+		}
+		return &overlayDir{info: info, entries: entries}, nil
+	}
+	return ofs.base.Open(name)
+}
+
+func (ofs *overlayFS) ReadFile(name string) ([]byte, error) {
+	if content, ok := ofs.files[name]; ok {
+		return slices.Clone(content), nil
+	}
+	return fs.ReadFile(ofs.base, name)
+}
+
+func (ofs *overlayFS) Stat(name string) (fs.FileInfo, error) {
+	if content, ok := ofs.files[name]; ok {
+		return overlayInfo{path.Base(name), int64(len(content)), 0o444}, nil
+	}
+	info, err := fs.Stat(ofs.base, name)
+	if _, ok := ofs.dirs[name]; ok && err != nil {
+		// A directory that exists only in the overlay.
+		return overlayInfo{path.Base(name), 0, fs.ModeDir | 0o555}, nil
+	}
+	return info, err
+}
+
+func (ofs *overlayFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	overlayNames, ok := ofs.dirs[name]
+	entries, err := fs.ReadDir(ofs.base, name)
+	if !ok {
+		return entries, err
+	}
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	// Replace or add the overlay entries.
+	entries = slices.DeleteFunc(entries, func(e fs.DirEntry) bool {
+		return slices.Contains(overlayNames, e.Name())
+	})
+	for _, base := range overlayNames {
+		info, err := ofs.Stat(path.Join(name, base))
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, fs.FileInfoToDirEntry(info))
+	}
+	slices.SortFunc(entries, func(x, y fs.DirEntry) int {
+		return strings.Compare(x.Name(), y.Name())
+	})
+	return entries, nil
+}
+
+// An overlayInfo describes an overlay file or directory.
+type overlayInfo struct {
+	name string
+	size int64
+	mode fs.FileMode
+}
+
+func (i overlayInfo) Name() string       { return i.name }
+func (i overlayInfo) Size() int64        { return i.size }
+func (i overlayInfo) Mode() fs.FileMode  { return i.mode }
+func (i overlayInfo) ModTime() time.Time { return time.Time{} }
+func (i overlayInfo) IsDir() bool        { return i.mode.IsDir() }
+func (i overlayInfo) Sys() any           { return nil }
+
+// An overlayFile is an overlay file open for reading.
+type overlayFile struct {
+	info overlayInfo
+	*bytes.Reader
+}
+
+func (f *overlayFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *overlayFile) Close() error               { return nil }
+
+// An overlayDir is a directory with overlay entries, open for reading.
+type overlayDir struct {
+	info    fs.FileInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *overlayDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *overlayDir) Close() error               { return nil }
+func (d *overlayDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.Name(), Err: fs.ErrInvalid}
+}
+
+func (d *overlayDir) ReadDir(count int) ([]fs.DirEntry, error) {
+	n := len(d.entries) - d.offset
+	if n == 0 && count > 0 {
+		return nil, io.EOF
+	}
+	if count > 0 && n > count {
+		n = count
+	}
+	list := slices.Clone(d.entries[d.offset : d.offset+n])
+	d.offset += n
+	return list, nil
+}
+
+// FSContext returns a copy of orig whose file system interface
+// accesses the files beneath the directory root of the host file
+// system through fsys, which may be an OverlayFS. Other files are
+// accessed through orig.
+//
+// Because its file system interface is customized, the build package
+// does not consult the go command to locate packages when using the
+// resulting Context, so it locates them only within GOROOT and GOPATH.
+// Tools that work in module mode may use FindModule to determine the
+// module, and thus the import path, of a directory.
+func FSContext(orig *build.Context, fsys fs.FS, root string) *build.Context {
+	copy := *orig // make a copy
+	ctxt := &copy
+	ctxt.OpenFile = func(filename string) (io.ReadCloser, error) {
+		if rel, ok := relPath(root, filename); ok {
+			return fsys.Open(rel)
+		}
+		return OpenFile(orig, filename)
+	}
+	ctxt.IsDir = func(dir string) bool {
+		if rel, ok := relPath(root, dir); ok {
+			info, err := fs.Stat(fsys, rel)
+			return err == nil && info.IsDir()
+		}
+		return IsDir(orig, dir)
+	}
+	ctxt.ReadDir = func(dir string) ([]os.FileInfo, error) {
+		rel, ok := relPath(root, dir)
+		if !ok {
+			return ReadDir(orig, dir)
+		}
+		entries, err := fs.ReadDir(fsys, rel)
+		if err != nil {
+			return nil, err
+		}
+		infos := make([]os.FileInfo, 0, len(entries))
+		for _, e := range entries {
+			info, err := e.Info()
+			if err != nil {
+				return nil, err
+			}
+			infos = append(infos, info)
+		}
+		return infos, nil
+	}
+	return ctxt
+}
+
+// A Module describes a module whose go.mod file was found by FindModule.
+type Module struct {
+	Dir  string // slash-separated path of the module's directory within the file system
+	Path string // module path declared by go.mod
+}
+
+// FindModule returns the module containing the directory dir of
+// fsys: that of the go.mod file in dir or its nearest ancestor
+// directory. The go.mod file is read through fsys, so it may be that
+// of an OverlayFS. FindModule returns an error wrapping fs.ErrNotExist
+// if there is no such file.
+func FindModule(fsys fs.FS, dir string) (*Module, error) {
+	if !fs.ValidPath(dir) {
+		return nil, &fs.PathError{Op: "findmodule", Path: dir, Err: fs.ErrInvalid}
+	}
+	for d := dir; ; d = path.Dir(d) {
+		gomod := path.Join(d, "go.mod")
+		content, err := fs.ReadFile(fsys, gomod)
+		if err == nil {
+			modPath := modfile.ModulePath(content)
+			if modPath == "" {
+				return nil, fmt.Errorf("%s: no module directive", gomod)
+			}
+			return &Module{Dir: d, Path: modPath}, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		if d == "." {
+			return nil, fmt.Errorf("no go.mod file in %s or its parents: %w", dir, fs.ErrNotExist)
+		}
+	}
+}
+
+// ImportPath returns the import path of the package in directory dir,
+// for which FindModule reported the module m. It reports false if dir
+// is not within the module's directory.
+func (m *Module) ImportPath(dir string) (string, bool) {
+	if dir == m.Dir {
+		return m.Path, true
+	}
+	rel, ok := strings.CutPrefix(dir, m.Dir+"/")
+	if m.Dir == "." {
+		rel, ok = dir, dir != "."
+	}
+	if !ok {
+		return "", false
+	}
+	return path.Join(m.Path, rel), true
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package buildutil_test
+
+import (
+	"errors"
+	"fmt"
+	"go/build"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"golang.org/x/tools/go/buildutil"
+)
+
+func TestOverlayFS(t *testing.T) {
+	root := filepath.FromSlash("/root")
+	base := fstest.MapFS{
+		"go.mod":        {Data: []byte("module example.com/m\n")},
+		"a/a.go":        {Data: []byte("package a\n")},
+		"a/b.go":        {Data: []byte("package a // old\n")},
+		"nested/go.mod": {Data: []byte("module example.com/nested\n")},
+	}
+	overlay := map[string][]byte{
+		filepath.Join(root, "a", "b.go"):          []byte("package a // new\n"),
+		filepath.Join(root, "a", "c.go"):          []byte("package a\n"),
+		filepath.Join(root, "new", "sub", "d.go"): []byte("package sub\n"),
+		filepath.FromSlash("/elsewhere/e.go"):     []byte("package e\n"),
+	}
+	fsys := buildutil.OverlayFS(base, root, overlay)
+	if err := fstest.TestFS(fsys, "go.mod", "a/a.go", "a/b.go", "a/c.go", "new/sub/d.go", "nested/go.mod"); err != nil {
+		t.Fatal(err)
+	}
+	content, err := fs.ReadFile(fsys, "a/b.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(content), "package a // new\n"; got != want {
+		t.Errorf("ReadFile(a/b.go) = %q, want %q", got, want)
+	}
+	if _, err := fs.Stat(fsys, "e.go"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Stat of overlay file outside root returned %v, want ErrNotExist", err)
+	}
+
+	// The build context respects the overlay.
+	ctxt := buildutil.FSContext(&build.Default, fsys, root)
+	if !buildutil.IsDir(ctxt, filepath.Join(root, "new", "sub")) {
+		t.Errorf("IsDir(new/sub) = false, want true")
+	}
+	infos, err := buildutil.ReadDir(ctxt, filepath.Join(root, "a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, info := range infos {
+		names = append(names, info.Name())
+	}
+	if got, want := fmt.Sprint(names), "[a.go b.go c.go]"; got != want {
+		t.Errorf("ReadDir(a) = %s, want %s", got, want)
+	}
+	rc, err := buildutil.OpenFile(ctxt, filepath.Join(root, "a", "c.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	if data, _ := io.ReadAll(rc); string(data) != "package a\n" {
+		t.Errorf("OpenFile(a/c.go) read %q", data)
+	}
+	if buildutil.FileExists(ctxt, filepath.Join(root, "a", "missing.go")) {
+		t.Errorf("FileExists(a/missing.go) = true")
+	}
+}
+
+func TestFindModule(t *testing.T) {
+	fsys := fstest.MapFS{
+		"go.mod":            {Data: []byte("module example.com/m\n")},
+		"a/a.go":            {Data: []byte("package a\n")},
+		"nested/go.mod":     {Data: []byte("module example.com/nested\n")},
+		"nested/b/b.go":     {Data: []byte("package b\n")},
+		"nomod/dir/file.go": {Data: []byte("package dir\n")},
+	}
+	for _, test := range []struct {
+		fsys            fs.FS
+		dir             string
+		modDir, pkgPath string
+	}{
+		{fsys, ".", ".", "example.com/m"},
+		{fsys, "a", ".", "example.com/m/a"},
+		{fsys, "nested", "nested", "example.com/nested"},
+		{fsys, "nested/b", "nested", "example.com/nested/b"},
+	} {
+		m, err := buildutil.FindModule(test.fsys, test.dir)
+		if err != nil {
+			t.Errorf("FindModule(%q): %v", test.dir, err)
+			continue
+		}
+		if m.Dir != test.modDir {
+			t.Errorf("FindModule(%q).Dir = %q, want %q", test.dir, m.Dir, test.modDir)
+		}
+		if got, ok := m.ImportPath(test.dir); !ok || got != test.pkgPath {
+			t.Errorf("ImportPath(%q) = %q, %t, want %q", test.dir, got, ok, test.pkgPath)
+		}
+	}
+
+	// An overlay may add a module.
+	root := filepath.FromSlash("/root")
+	overlay := map[string][]byte{
+		filepath.Join(root, "nomod", "go.mod"): []byte("module example.com/overlay\n"),
+	}
+	m, err := buildutil.FindModule(buildutil.OverlayFS(fsys, root, overlay), "nomod/dir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := m.ImportPath("nomod/dir"); got != "example.com/overlay/dir" {
+		t.Errorf("ImportPath(nomod/dir) in overlay = %q, want example.com/overlay/dir", got)
+	}
+
+	delete(fsys, "go.mod")
+	if _, err := buildutil.FindModule(fsys, "nomod/dir"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("FindModule without go.mod returned %v, want ErrNotExist", err)
+	}
+}
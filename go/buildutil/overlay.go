@@ -27,7 +27,10 @@ import (
 // a set of unsaved, modified files.
 //
 // Currently, only the Context.OpenFile function will respect the
-// overlay. This may change in the future.
+// overlay. This may change in the future. New code should instead use
+// FSContext with an OverlayFS, which respects the overlay for all
+// file system operations, and whose overlay may be shared with
+// go/packages.
 func OverlayContext(orig *build.Context, overlay map[string][]byte) *build.Context {
 	// TODO(dominikh): Implement IsDir, HasSubdir and ReadDir
 
@@ -4,6 +4,9 @@
 package callgraph_test
 
 import (
+	"fmt"
+	"runtime"
+	"strings"
 	"sync"
 	"testing"
 
@@ -12,8 +15,10 @@ import (
 	"golang.org/x/tools/go/callgraph/rta"
 	"golang.org/x/tools/go/callgraph/static"
 	"golang.org/x/tools/go/callgraph/vta"
+	"golang.org/x/tools/go/packages"
 	"golang.org/x/tools/go/ssa"
 	"golang.org/x/tools/go/ssa/ssautil"
+	"golang.org/x/tools/internal/testenv"
 	"golang.org/x/tools/internal/testfiles"
 	"golang.org/x/tools/txtar"
 )
@@ -84,6 +89,29 @@ func example(t testing.TB) (*ssa.Program, *ssa.Function) {
 	return main.Prog, main
 }
 
+var (
+	stdOnce sync.Once
+	stdProg *ssa.Program
+)
+
+// stdlib returns a program containing the standard library.
+func stdlib(b *testing.B) *ssa.Program {
+	stdOnce.Do(func() {
+		testenv.NeedsGoPackages(b)
+		cfg := &packages.Config{Mode: packages.LoadAllSyntax}
+		pkgs, err := packages.Load(cfg, "std")
+		if err != nil {
+			b.Fatal(err)
+		}
+		stdProg, _ = ssautil.AllPackages(pkgs, ssa.InstantiateGenerics)
+		stdProg.Build()
+	})
+	if stdProg == nil {
+		b.Skip("loading std failed")
+	}
+	return stdProg
+}
+
 var stats bool = false // print stats?
 
 func logStats(b *testing.B, cnd bool, name string, cg *callgraph.Graph, main *ssa.Function) {
@@ -117,6 +145,24 @@ func BenchmarkCHA(b *testing.B) {
 	}
 }
 
+// BenchmarkCHAStd and BenchmarkVTAStd construct the call graph of the
+// standard library, in parallel. Run them with, for example,
+// -cpu=1,2,4,8 to measure the speedup.
+func BenchmarkCHAStd(b *testing.B) {
+	prog := stdlib(b)
+	for b.Loop() {
+		cha.CallGraph(prog)
+	}
+}
+
+func BenchmarkVTAStd(b *testing.B) {
+	prog := stdlib(b)
+	funcs := ssautil.AllFunctions(prog)
+	for b.Loop() {
+		vta.CallGraph(funcs, nil)
+	}
+}
+
 func BenchmarkRTA(b *testing.B) {
 
 	_, main := example(b)
@@ -184,6 +230,44 @@ func BenchmarkVTAAlt2(b *testing.B) {
 	}
 }
 
+// TestParallel checks that the call graphs constructed in parallel do
+// not depend on the number of goroutines, including the numbering of
+// nodes and the order of edges.
+func TestParallel(t *testing.T) {
+	funcs := build(t)
+	prog := anyProg(funcs)
+	for _, algo := range []struct {
+		name string
+		cg   func() *callgraph.Graph
+	}{
+		{"cha", func() *callgraph.Graph { return cha.CallGraph(prog) }},
+		{"vta", func() *callgraph.Graph { return vta.CallGraph(funcs, nil) }},
+	} {
+		var want string
+		for _, procs := range []int{1, 4} {
+			defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(procs))
+			var buf strings.Builder
+			cg := algo.cg()
+			for i := range len(cg.Nodes) {
+				for _, n := range cg.Nodes {
+					if n.ID == i {
+						fmt.Fprintf(&buf, "%d %v:", n.ID, n.Func)
+						for _, e := range n.Out {
+							fmt.Fprintf(&buf, " %d", e.Callee.ID)
+						}
+						fmt.Fprintln(&buf)
+					}
+				}
+			}
+			if procs == 1 {
+				want = buf.String()
+			} else if got := buf.String(); got != want {
+				t.Errorf("%s: graph with GOMAXPROCS=%d:\n%s\nwant:\n%s", algo.name, procs, got, want)
+			}
+		}
+	}
+}
+
 // reaches computes the transitive closure of functions forward reachable
 // via calls in cg starting from `sources`. If refs is true, include
 // functions referred to in an instruction.
@@ -0,0 +1,603 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package cfa computes the call graph of a Go program using a
+// context-sensitive control-flow analysis with one level of call-site
+// context (1-CFA), as first described for higher-order languages in:
+//
+// Olin Shivers. 1991. Control-Flow Analysis of Higher-Order
+// Languages. PhD thesis, Carnegie Mellon University.
+//
+// The analysis computes, for each SSA value, an overapproximation of
+// the set of functions (including closures) and dynamic types of
+// interface values it may hold, and uses them to resolve dynamic calls.
+// Each function body is analyzed once for each call site from which it
+// is reachable, so a function that merely passes on or returns a
+// function value, such as an adapter or a getter, does not merge the
+// values passed to it by different callers. The resulting precision
+// lies between that of the context-insensitive algorithms, such as
+// go/callgraph/vta, and that of a full pointer analysis.
+//
+// Memory is modeled by type: a function or dynamic type stored in any
+// variable, field, element, map, or channel may be loaded from any
+// location of a compatible type. Values from outside the analyzed
+// code, such as the parameters of the roots or the results of
+// functions without bodies, are unknown; calls of unknown function or
+// interface values are resolved conservatively, as by the Class
+// Hierarchy Analysis of go/callgraph/cha.
+//
+// The analysis is sound, modulo the use of reflection and unsafe and
+// calls made by functions without bodies, for the program reachable
+// from the roots.
+//
+// Note: this package is in an experimental phase and its interface
+// is subject to change.
+package cfa // import "golang.org/x/tools/go/callgraph/cfa"
+
+import (
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/callgraph/internal/chautil"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// CallGraph computes the call graph of the functions reachable from
+// roots, typically the main and init functions of a program, or the
+// exported functions of a library. Their parameters are assumed to
+// hold unknown values.
+//
+// The resulting graph has no root node. Its nodes are functions; the
+// contexts in which they were analyzed are not represented.
+func CallGraph(roots []*ssa.Function) *callgraph.Graph {
+	cg := &callgraph.Graph{Nodes: make(map[*ssa.Function]*callgraph.Node)}
+	if len(roots) == 0 {
+		return cg
+	}
+	a := &analysis{
+		prog:      roots[0].Prog,
+		cg:        cg,
+		values:    make(map[valueKey]objset),
+		readers:   make(map[valueKey]map[cfunc]bool),
+		results:   make(map[cfunc]objset),
+		callers:   make(map[cfunc]map[cfunc]bool),
+		queued:    make(map[cfunc]bool),
+		edges:     make(map[edge]bool),
+		sigHeap:   make(map[int]objset),
+		ifaceHeap: make(objset),
+		unknownT:  make(map[int]bool),
+		loaders:   make(map[cfunc]bool),
+		loadCache: make(map[int]objset),
+	}
+	a.typeIDs.SetHasher(typeutil.MakeHasher())
+
+	for _, root := range roots {
+		c := cfunc{root, nil}
+		if a.reach(c) {
+			for _, p := range root.Params {
+				a.flow(valueKey{p, nil}, unknownSet)
+			}
+			for _, fv := range root.FreeVars {
+				a.flow(valueKey{fv, nil}, unknownSet)
+			}
+		}
+	}
+	for len(a.queue) > 0 {
+		c := a.queue[0]
+		a.queue = a.queue[1:]
+		a.queued[c] = false
+		a.visit(c)
+	}
+	return cg
+}
+
+// A context is the call site for which a function is analyzed,
+// or nil for a root.
+type context = ssa.CallInstruction
+
+// A cfunc is a function analyzed in a context.
+type cfunc struct {
+	fn  *ssa.Function
+	ctx context
+}
+
+// A valueKey identifies an SSA value in the context of its function.
+type valueKey struct {
+	v   ssa.Value
+	ctx context
+}
+
+type edge struct {
+	caller *ssa.Function
+	site   ssa.CallInstruction
+	callee *ssa.Function
+}
+
+// The abstract objects that a value may hold are of these types:
+type (
+	// A funcObj is a function value: a declared function, or a
+	// closure created by the MakeClosure env in context ctx.
+	funcObj struct {
+		fn  *ssa.Function
+		env *ssa.MakeClosure
+		ctx context
+	}
+
+	// A typeObj is the dynamic type of an interface value,
+	// identified by its index in analysis.types.
+	typeObj struct{ id int }
+
+	// An unknownObj is any value from outside the analyzed code.
+	unknownObj struct{}
+)
+
+// An objset is a set of abstract objects.
+type objset map[any]bool
+
+var unknownSet = objset{unknownObj{}: true}
+
+// addAll adds the elements of src to *dst, allocating it if
+// necessary, and reports whether it changed.
+func addAll(dst *objset, src objset) bool {
+	changed := false
+	for obj := range src {
+		if !(*dst)[obj] {
+			if *dst == nil {
+				*dst = make(objset)
+			}
+			(*dst)[obj] = true
+			changed = true
+		}
+	}
+	return changed
+}
+
+// analysis holds the state of the analysis.
+type analysis struct {
+	prog *ssa.Program
+	cg   *callgraph.Graph
+
+	values  map[valueKey]objset
+	readers map[valueKey]map[cfunc]bool // functions to revisit when a value (a closure binding) changes
+	results map[cfunc]objset            // union of the results of each function
+	callers map[cfunc]map[cfunc]bool    // functions to revisit when a function's results change
+
+	queue  []cfunc
+	queued map[cfunc]bool
+	edges  map[edge]bool
+
+	typeIDs typeutil.Map // maps a type to its index in types
+	types   []types.Type
+
+	// The heap, by type.
+	sigHeap   map[int]objset // funcObjs, by the index of their signature
+	ifaceHeap objset         // typeObjs
+	unknownT  map[int]bool   // func and interface types, by index, of which some stored value is unknown
+	loaders   map[cfunc]bool // functions to revisit when the heap changes
+	loadCache map[int]objset // cache of load results, by type index
+
+	calleesOf func(site ssa.CallInstruction) []*ssa.Function // CHA callees, for unknown values
+}
+
+// typeID returns the index of type t.
+func (a *analysis) typeID(t types.Type) int {
+	if id, ok := a.typeIDs.At(t).(int); ok {
+		return id
+	}
+	id := len(a.types)
+	a.types = append(a.types, t)
+	a.typeIDs.Set(t, id)
+	return id
+}
+
+// reach marks the function c.fn as reached in context c.ctx, and
+// reports whether this is new.
+func (a *analysis) reach(c cfunc) bool {
+	if _, ok := a.results[c]; ok {
+		return false
+	}
+	a.results[c] = nil
+	a.cg.CreateNode(c.fn)
+	a.enqueue(c)
+	return true
+}
+
+func (a *analysis) enqueue(c cfunc) {
+	if !a.queued[c] {
+		a.queued[c] = true
+		a.queue = append(a.queue, c)
+	}
+}
+
+// objects returns the set of objects that the value v may hold in
+// context ctx.
+func (a *analysis) objects(v ssa.Value, ctx context) objset {
+	switch v := v.(type) {
+	case *ssa.Function:
+		return objset{funcObj{fn: v}: true}
+	case *ssa.Const, *ssa.Global, *ssa.Builtin:
+		return nil
+	}
+	return a.values[valueKey{v, ctx}]
+}
+
+// flow adds objs to the set of objects of the value k, and reports
+// whether it changed, revisiting the functions that read it.
+func (a *analysis) flow(k valueKey, objs objset) bool {
+	set := a.values[k]
+	if !addAll(&set, objs) {
+		return false
+	}
+	a.values[k] = set
+	for c := range a.readers[k] {
+		a.enqueue(c)
+	}
+	return true
+}
+
+// visit analyzes the instructions of function c.fn in context c.ctx
+// until a local fixed point is reached.
+func (a *analysis) visit(c cfunc) {
+	ctx := c.ctx
+	var rands []*ssa.Value
+	for changed := true; changed; {
+		changed = false
+		flow := func(v ssa.Value, objs objset) {
+			if a.flow(valueKey{v, ctx}, objs) {
+				changed = true
+			}
+		}
+		for _, b := range c.fn.Blocks {
+			for _, instr := range b.Instrs {
+				switch instr := instr.(type) {
+				case ssa.CallInstruction:
+					if a.call(c, instr) {
+						changed = true
+					}
+
+				case *ssa.MakeClosure:
+					flow(instr, objset{funcObj{instr.Fn.(*ssa.Function), instr, ctx}: true})
+
+				case *ssa.MakeInterface:
+					objs := objset{typeObj{a.typeID(instr.X.Type())}: true}
+					addAll(&objs, a.objects(instr.X, ctx))
+					flow(instr, objs)
+
+				case *ssa.UnOp:
+					switch instr.Op {
+					case token.MUL, token.ARROW:
+						flow(instr, a.load(c, instr.Type(), a.objects(instr.X, ctx)))
+					default:
+						flow(instr, a.objects(instr.X, ctx))
+					}
+
+				case *ssa.Lookup:
+					if _, ok := instr.X.Type().Underlying().(*types.Map); ok {
+						flow(instr, a.load(c, instr.Type(), a.objects(instr.X, ctx)))
+					}
+
+				case *ssa.Next:
+					flow(instr, a.load(c, instr.Type(), a.objects(instr.Iter, ctx)))
+
+				case *ssa.Select:
+					for _, st := range instr.States {
+						if st.Send != nil {
+							a.store(a.objects(st.Send, ctx), st.Send.Type())
+						}
+					}
+					flow(instr, a.load(c, instr.Type(), nil))
+
+				case *ssa.Store:
+					a.store(a.objects(instr.Val, ctx), instr.Val.Type())
+
+				case *ssa.MapUpdate:
+					a.store(a.objects(instr.Key, ctx), instr.Key.Type())
+					a.store(a.objects(instr.Value, ctx), instr.Value.Type())
+
+				case *ssa.Send:
+					a.store(a.objects(instr.X, ctx), instr.X.Type())
+
+				case *ssa.Return:
+					set := a.results[c]
+					grew := false
+					for _, res := range instr.Results {
+						grew = addAll(&set, a.objects(res, ctx)) || grew
+					}
+					if grew {
+						a.results[c] = set
+						for caller := range a.callers[c] {
+							a.enqueue(caller)
+						}
+					}
+
+				default:
+					// Any other value holds the objects of its operands.
+					if v, ok := instr.(ssa.Value); ok {
+						var objs objset
+						rands = instr.Operands(rands[:0])
+						for _, rand := range rands {
+							if *rand != nil {
+								addAll(&objs, a.objects(*rand, ctx))
+							}
+						}
+						flow(v, objs)
+					}
+				}
+			}
+		}
+	}
+}
+
+// call analyzes the call instruction site in function c, and reports
+// whether the objects of its result changed.
+func (a *analysis) call(c cfunc, site ssa.CallInstruction) bool {
+	ctx := c.ctx
+	common := site.Common()
+	result := site.Value() // nil for go and defer
+
+	// argObjs holds the objects of each argument, including the
+	// receiver of an invoke-mode call.
+	args := common.Args
+	if common.IsInvoke() {
+		args = append([]ssa.Value{common.Value}, args...)
+	}
+	argObjs := make([]objset, len(args))
+	var allArgs objset
+	for i, arg := range args {
+		argObjs[i] = a.objects(arg, ctx)
+		addAll(&allArgs, argObjs[i])
+	}
+
+	changed := false
+	flowResult := func(objs objset) {
+		if result != nil && a.flow(valueKey{result, ctx}, objs) {
+			changed = true
+		}
+	}
+
+	if b, ok := common.Value.(*ssa.Builtin); ok {
+		// The result of a built-in holds the objects of its
+		// arguments; append and copy also store them.
+		switch b.Name() {
+		case "append", "copy":
+			a.store(allArgs, common.Args[len(common.Args)-1].Type())
+		case "recover":
+			allArgs = unknownSet
+		}
+		flowResult(allArgs)
+		return changed
+	}
+
+	// Determine the callees and the closures that provide their
+	// free variables.
+	type callee struct {
+		fn  *ssa.Function
+		env *funcObj // nil => not a closure, or unknown
+	}
+	var callees []callee
+	unknown := false
+	if common.IsInvoke() {
+		iface := common.Value.Type().Underlying().(*types.Interface)
+		for obj := range argObjs[0] {
+			switch obj := obj.(type) {
+			case typeObj:
+				T := a.types[obj.id]
+				if !types.Implements(T, iface) {
+					continue // flowed from an incompatible interface
+				}
+				sel := a.prog.MethodSets.MethodSet(T).Lookup(common.Method.Pkg(), common.Method.Name())
+				if sel != nil {
+					if fn := a.prog.MethodValue(sel); fn != nil {
+						callees = append(callees, callee{fn: fn})
+					}
+				}
+			case unknownObj:
+				unknown = true
+			}
+		}
+	} else if fn := common.StaticCallee(); fn != nil {
+		cl := callee{fn: fn}
+		if mc, ok := common.Value.(*ssa.MakeClosure); ok {
+			cl.env = &funcObj{fn, mc, ctx}
+		}
+		callees = append(callees, cl)
+	} else {
+		for obj := range a.objects(common.Value, ctx) {
+			switch obj := obj.(type) {
+			case funcObj:
+				cl := callee{fn: obj.fn}
+				if obj.env != nil {
+					cl.env = &obj
+				}
+				callees = append(callees, cl)
+			case unknownObj:
+				unknown = true
+			}
+		}
+	}
+	if unknown {
+		if a.calleesOf == nil {
+			a.calleesOf = chautil.LazyCallees(ssautil.AllFunctions(a.prog))
+		}
+		for _, fn := range a.calleesOf(site) {
+			callees = append(callees, callee{fn: fn})
+		}
+	}
+
+	for _, cl := range callees {
+		g := cl.fn
+		if e := (edge{c.fn, site, g}); !a.edges[e] {
+			a.edges[e] = true
+			callgraph.AddEdge(a.cg.CreateNode(c.fn), site, a.cg.CreateNode(g))
+		}
+		if g.Blocks == nil {
+			// The callee is external: its result is unknown,
+			// and its arguments escape.
+			for i, arg := range args {
+				a.store(argObjs[i], arg.Type())
+			}
+			flowResult(unknownSet)
+			continue
+		}
+
+		// Analyze the callee in the context of this call site.
+		gc := cfunc{g, site}
+		a.reach(gc)
+		if a.callers[gc] == nil {
+			a.callers[gc] = make(map[cfunc]bool)
+		}
+		a.callers[gc][c] = true
+		for i, p := range g.Params {
+			if i < len(argObjs) && a.flow(valueKey{p, site}, argObjs[i]) {
+				a.enqueue(gc)
+			}
+		}
+		for i, fv := range g.FreeVars {
+			var objs objset
+			if cl.env != nil {
+				binding := valueKey{cl.env.env.Bindings[i], cl.env.ctx}
+				if a.readers[binding] == nil {
+					a.readers[binding] = make(map[cfunc]bool)
+				}
+				a.readers[binding][c] = true
+				objs = a.objects(binding.v, binding.ctx)
+			} else {
+				objs = unknownSet
+			}
+			if a.flow(valueKey{fv, site}, objs) {
+				a.enqueue(gc)
+			}
+		}
+		flowResult(a.results[gc])
+	}
+	return changed
+}
+
+// store adds the objects objs, stored in a location of type t, to
+// the heap.
+func (a *analysis) store(objs objset, t types.Type) {
+	changed := false
+	for obj := range objs {
+		switch obj := obj.(type) {
+		case funcObj:
+			set := a.sigHeap[a.typeID(obj.fn.Signature)]
+			if addAll(&set, objset{obj: true}) {
+				a.sigHeap[a.typeID(obj.fn.Signature)] = set
+				changed = true
+			}
+		case typeObj:
+			if !a.ifaceHeap[obj] {
+				a.ifaceHeap[obj] = true
+				changed = true
+			}
+		case unknownObj:
+			// Any function or interface value reachable
+			// from the stored value may be unknown.
+			visited := make(map[int]bool)
+			var mark func(t types.Type)
+			mark = func(t types.Type) {
+				id := a.typeID(t)
+				if visited[id] {
+					return
+				}
+				visited[id] = true
+				switch u := t.Underlying().(type) {
+				case *types.Signature, *types.Interface:
+					if id := a.typeID(u); !a.unknownT[id] {
+						a.unknownT[id] = true
+						changed = true
+					}
+				case *types.Pointer:
+					mark(u.Elem())
+				case *types.Slice:
+					mark(u.Elem())
+				case *types.Array:
+					mark(u.Elem())
+				case *types.Chan:
+					mark(u.Elem())
+				case *types.Map:
+					mark(u.Key())
+					mark(u.Elem())
+				case *types.Struct:
+					for i := range u.NumFields() {
+						mark(u.Field(i).Type())
+					}
+				case *types.Tuple:
+					for i := range u.Len() {
+						mark(u.At(i).Type())
+					}
+				}
+			}
+			mark(t)
+		}
+	}
+	if changed {
+		clear(a.loadCache)
+		for c := range a.loaders {
+			a.enqueue(c)
+		}
+	}
+}
+
+// load returns the objects that a value of type t loaded by function c
+// from the heap may hold. addrObjs are the objects of the address or
+// container from which it is loaded.
+func (a *analysis) load(c cfunc, t types.Type, addrObjs objset) objset {
+	a.loaders[c] = true
+	id := a.typeID(t)
+	objs, ok := a.loadCache[id]
+	if !ok {
+		objs = make(objset)
+		visited := make(map[int]bool)
+		var walk func(t types.Type)
+		walk = func(t types.Type) {
+			id := a.typeID(t)
+			if visited[id] {
+				return
+			}
+			visited[id] = true
+			switch u := t.Underlying().(type) {
+			case *types.Signature:
+				id := a.typeID(u)
+				addAll(&objs, a.sigHeap[id])
+				if a.unknownT[id] {
+					objs[unknownObj{}] = true
+				}
+			case *types.Interface:
+				for obj := range a.ifaceHeap {
+					T := a.types[obj.(typeObj).id]
+					if types.Implements(T, u) {
+						objs[obj] = true
+						walk(T) // the contents of the dynamic value
+					}
+				}
+				if a.unknownT[a.typeID(u)] {
+					objs[unknownObj{}] = true
+				}
+			case *types.Array:
+				walk(u.Elem())
+			case *types.Struct:
+				for i := range u.NumFields() {
+					walk(u.Field(i).Type())
+				}
+			case *types.Tuple:
+				for i := range u.Len() {
+					walk(u.At(i).Type())
+				}
+			}
+		}
+		walk(t)
+		a.loadCache[id] = objs
+	}
+	if addrObjs[unknownObj{}] && !objs[unknownObj{}] {
+		// Loaded from an unknown location.
+		res := objset{unknownObj{}: true}
+		addAll(&res, objs)
+		return res
+	}
+	return objs
+}
@@ -0,0 +1,101 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// No testdata on Android.
+
+//go:build !android
+
+package cfa_test
+
+import (
+	"fmt"
+	"go/ast"
+	"sort"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/callgraph/cfa"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+	"golang.org/x/tools/internal/testfiles"
+	"golang.org/x/tools/txtar"
+)
+
+// TestCFA runs 1-CFA on each testdata/*.txtar file, and compares the
+// resulting call graph with the expectations expressed in the WANT
+// comment, whose lines have the form:
+//
+//	edge <func> --kind--> <func>
+//
+// A line preceded by "!" asserts that the edge is absent.
+func TestCFA(t *testing.T) {
+	for _, archive := range []string{
+		"testdata/func.txtar",
+		"testdata/iface.txtar",
+	} {
+		t.Run(archive, func(t *testing.T) {
+			ar, err := txtar.ParseFile(archive)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pkgs := testfiles.LoadPackages(t, ar, "./...")
+			prog, spkgs := ssautil.Packages(pkgs, ssa.SanityCheckFunctions|ssa.InstantiateGenerics)
+			prog.Build()
+			mainPkg := spkgs[0]
+
+			cg := cfa.CallGraph([]*ssa.Function{mainPkg.Func("main"), mainPkg.Func("init")})
+
+			got := make(map[string]bool)
+			callgraph.GraphVisitEdges(cg, func(e *callgraph.Edge) error {
+				got[fmt.Sprintf("%s --%s--> %s",
+					e.Caller.Func.RelString(mainPkg.Pkg),
+					e.Description(),
+					e.Callee.Func.RelString(mainPkg.Pkg))] = true
+				return nil
+			})
+
+			ok := true
+			for line := range strings.SplitSeq(expectation(t, pkgs[0].Syntax[0]), "\n") {
+				line = strings.TrimSpace(line)
+				if line == "" {
+					continue
+				}
+				line, negated := strings.CutPrefix(line, "!")
+				edge, found := strings.CutPrefix(strings.TrimSpace(line), "edge ")
+				if !found {
+					t.Fatalf("invalid assertion: %q", line)
+				}
+				if got[edge] == negated {
+					ok = false
+					if negated {
+						t.Errorf("unwanted edge %q", edge)
+					} else {
+						t.Errorf("missing edge %q", edge)
+					}
+				}
+			}
+			if !ok {
+				var edges []string
+				for edge := range got {
+					edges = append(edges, edge)
+				}
+				sort.Strings(edges)
+				t.Errorf("got:\n%s", strings.Join(edges, "\n"))
+			}
+		})
+	}
+}
+
+// expectation returns the text of the WANT comment of file f.
+func expectation(t *testing.T, f *ast.File) string {
+	for _, c := range f.Comments {
+		text := strings.TrimSpace(c.Text())
+		if want, ok := strings.CutPrefix(text, "WANT:\n"); ok {
+			return want
+		}
+	}
+	t.Fatal("no WANT comment")
+	return ""
+}
@@ -27,51 +27,62 @@ package cha // import "golang.org/x/tools/go/callgraph/cha"
 import (
 	"golang.org/x/tools/go/callgraph"
 	"golang.org/x/tools/go/callgraph/internal/chautil"
+	"golang.org/x/tools/go/callgraph/internal/parallel"
 	"golang.org/x/tools/go/ssa"
 	"golang.org/x/tools/go/ssa/ssautil"
 )
 
 // CallGraph computes the call graph of the specified program using the
 // Class Hierarchy Analysis algorithm.
+//
+// The callees of the functions are discovered in parallel, using up
+// to GOMAXPROCS goroutines; the resulting graph does not depend on
+// their scheduling.
 func CallGraph(prog *ssa.Program) *callgraph.Graph {
 	cg := callgraph.New(nil) // TODO(adonovan) eliminate concept of rooted callgraph
 
-	allFuncs := ssautil.AllFunctions(prog)
+	funcs := parallel.Funcs(ssautil.AllFunctions(prog))
 
-	calleesOf := lazyCallees(allFuncs)
+	calleesOf := lazyCallees(funcs)
 
-	addEdge := func(fnode *callgraph.Node, site ssa.CallInstruction, g *ssa.Function) {
-		gnode := cg.CreateNode(g)
-		callgraph.AddEdge(fnode, site, gnode)
+	// A siteCallees records the callees of a call site.
+	type siteCallees struct {
+		site    ssa.CallInstruction
+		callees []*ssa.Function
 	}
-
-	addEdges := func(fnode *callgraph.Node, site ssa.CallInstruction, callees []*ssa.Function) {
-		// Because every call to a highly polymorphic and
-		// frequently used abstract method such as
-		// (io.Writer).Write is assumed to call every concrete
-		// Write method in the program, the call graph can
-		// contain a lot of duplication.
-		for _, g := range callees {
-			addEdge(fnode, site, g)
-		}
-	}
-
-	for f := range allFuncs {
-		fnode := cg.CreateNode(f)
-		for _, b := range f.Blocks {
+	sites := make([][]siteCallees, len(funcs))
+	parallel.Do(len(funcs), func(_, i int) {
+		for _, b := range funcs[i].Blocks {
 			for _, instr := range b.Instrs {
 				if site, ok := instr.(ssa.CallInstruction); ok {
+					var callees []*ssa.Function
 					if g := site.Common().StaticCallee(); g != nil {
-						addEdge(fnode, site, g)
+						callees = []*ssa.Function{g}
 					} else {
-						addEdges(fnode, site, calleesOf(site))
+						callees = calleesOf(site)
 					}
+					sites[i] = append(sites[i], siteCallees{site, callees})
 				}
 			}
 		}
+	})
+
+	// Add the edges sequentially, in a deterministic order.
+	for i, f := range funcs {
+		fnode := cg.CreateNode(f)
+		for _, sc := range sites[i] {
+			// Because every call to a highly polymorphic and
+			// frequently used abstract method such as
+			// (io.Writer).Write is assumed to call every concrete
+			// Write method in the program, the call graph can
+			// contain a lot of duplication.
+			for _, g := range sc.callees {
+				callgraph.AddEdge(fnode, sc.site, cg.CreateNode(g))
+			}
+		}
 	}
 
 	return cg
 }
 
-var lazyCallees = chautil.LazyCallees
+var lazyCallees = chautil.LazyCalleesOf
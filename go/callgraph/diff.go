@@ -0,0 +1,175 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package callgraph
+
+// This file defines the comparison of encoded call graphs.
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+)
+
+// A GraphDiff describes the differences between two encoded call
+// graphs, such as those of a program before and after a dependency
+// upgrade or a refactoring, grouped by package.
+type GraphDiff struct {
+	Packages []*PackageDiff // packages with differences, sorted by path
+}
+
+// A PackageDiff describes the differences between two encoded call
+// graphs in the nodes of one package, and in the calls from them.
+//
+// Calls are compared by caller and callee alone, so the addition of
+// a call site that calls a function already called from elsewhere in
+// the caller is not a difference, nor is the renumbering of the call
+// sites of a function that changed.
+type PackageDiff struct {
+	Path         string        // package path; "" for nodes without a package
+	AddedNodes   []EncodedNode // sorted by ID
+	RemovedNodes []EncodedNode // sorted by ID
+	AddedCalls   []Call        // sorted by caller and callee ID
+	RemovedCalls []Call        // sorted by caller and callee ID
+}
+
+// A Call is a pair of nodes of an encoded call graph connected by at
+// least one edge.
+type Call struct {
+	Caller, Callee EncodedNode
+}
+
+// Diff compares the encoded call graphs before and after, and reports
+// the nodes and calls that were added or removed. A call belongs to
+// the package of its caller.
+func Diff(before, after *EncodedGraph) *GraphDiff {
+	oldNodes, newNodes := nodesByID(before), nodesByID(after)
+	oldCalls, newCalls := calls(before, oldNodes), calls(after, newNodes)
+
+	pkgs := make(map[string]*PackageDiff)
+	pkg := func(path string) *PackageDiff {
+		d := pkgs[path]
+		if d == nil {
+			d = &PackageDiff{Path: path}
+			pkgs[path] = d
+		}
+		return d
+	}
+	for id, node := range newNodes {
+		if _, ok := oldNodes[id]; !ok {
+			d := pkg(node.Package)
+			d.AddedNodes = append(d.AddedNodes, node)
+		}
+	}
+	for id, node := range oldNodes {
+		if _, ok := newNodes[id]; !ok {
+			d := pkg(node.Package)
+			d.RemovedNodes = append(d.RemovedNodes, node)
+		}
+	}
+	for key, call := range newCalls {
+		if _, ok := oldCalls[key]; !ok {
+			d := pkg(call.Caller.Package)
+			d.AddedCalls = append(d.AddedCalls, call)
+		}
+	}
+	for key, call := range oldCalls {
+		if _, ok := newCalls[key]; !ok {
+			d := pkg(call.Caller.Package)
+			d.RemovedCalls = append(d.RemovedCalls, call)
+		}
+	}
+
+	byID := func(x, y EncodedNode) int { return cmp.Compare(x.ID, y.ID) }
+	byCall := func(x, y Call) int {
+		return cmp.Or(
+			cmp.Compare(x.Caller.ID, y.Caller.ID),
+			cmp.Compare(x.Callee.ID, y.Callee.ID))
+	}
+	diff := new(GraphDiff)
+	for _, path := range slices.Sorted(maps.Keys(pkgs)) {
+		d := pkgs[path]
+		slices.SortFunc(d.AddedNodes, byID)
+		slices.SortFunc(d.RemovedNodes, byID)
+		slices.SortFunc(d.AddedCalls, byCall)
+		slices.SortFunc(d.RemovedCalls, byCall)
+		diff.Packages = append(diff.Packages, d)
+	}
+	return diff
+}
+
+func nodesByID(g *EncodedGraph) map[string]EncodedNode {
+	nodes := make(map[string]EncodedNode, len(g.Nodes))
+	for _, node := range g.Nodes {
+		nodes[node.ID] = node
+	}
+	return nodes
+}
+
+// calls returns the calls of g, keyed by caller and callee ID.
+func calls(g *EncodedGraph, nodes map[string]EncodedNode) map[[2]string]Call {
+	calls := make(map[[2]string]Call)
+	for _, e := range g.Edges {
+		calls[[2]string{e.Caller, e.Callee}] = Call{nodeOrID(nodes, e.Caller), nodeOrID(nodes, e.Callee)}
+	}
+	return calls
+}
+
+// nodeOrID returns the node of nodes with the specified ID, or a
+// node with only that ID if there is none.
+func nodeOrID(nodes map[string]EncodedNode, id string) EncodedNode {
+	if node, ok := nodes[id]; ok {
+		return node
+	}
+	return EncodedNode{ID: id, Name: id}
+}
+
+// Empty reports whether the diff has no differences.
+func (d *GraphDiff) Empty() bool {
+	return len(d.Packages) == 0
+}
+
+// Write writes a textual description of the diff to w, of the form:
+//
+//	package example.com/p
+//		+ example.com/p.f
+//		- example.com/p.g
+//		+ example.com/p.main --> example.com/p.f
+//		- example.com/p.main --> example.com/p.g
+//
+// in which nodes and calls are described by the names of functions.
+func (d *GraphDiff) Write(w io.Writer) error {
+	for _, pkg := range d.Packages {
+		path := pkg.Path
+		if path == "" {
+			path = "(none)"
+		}
+		if _, err := fmt.Fprintf(w, "package %s\n", path); err != nil {
+			return err
+		}
+		for _, lists := range []struct {
+			sign  byte
+			nodes []EncodedNode
+		}{{'+', pkg.AddedNodes}, {'-', pkg.RemovedNodes}} {
+			for _, node := range lists.nodes {
+				if _, err := fmt.Fprintf(w, "\t%c %s\n", lists.sign, node.Name); err != nil {
+					return err
+				}
+			}
+		}
+		for _, lists := range []struct {
+			sign  byte
+			calls []Call
+		}{{'+', pkg.AddedCalls}, {'-', pkg.RemovedCalls}} {
+			for _, call := range lists.calls {
+				if _, err := fmt.Fprintf(w, "\t%c %s --> %s\n", lists.sign, call.Caller.Name, call.Callee.Name); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package callgraph_test
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/callgraph"
+)
+
+func TestDiff(t *testing.T) {
+	// graph returns an encoded graph with the specified edges, each
+	// of the form "caller callee", between functions named "pkg.f".
+	graph := func(edges ...string) *callgraph.EncodedGraph {
+		g := new(callgraph.EncodedGraph)
+		seen := make(map[string]bool)
+		node := func(name string) string {
+			if !seen[name] {
+				seen[name] = true
+				pkg, _, _ := strings.Cut(name, ".")
+				g.Nodes = append(g.Nodes, callgraph.EncodedNode{ID: "id:" + name, Name: name, Package: pkg})
+			}
+			return "id:" + name
+		}
+		for i, edge := range edges {
+			caller, callee, _ := strings.Cut(edge, " ")
+			g.Edges = append(g.Edges, callgraph.EncodedEdge{Caller: node(caller), Site: i, Callee: node(callee)})
+		}
+		return g
+	}
+
+	before := graph("p.main q.Old", "p.main p.f", "p.f q.Sensitive", "q.Old q.New")
+	after := graph("p.main q.New", "p.main p.f", "p.main p.f", "q.Old q.New")
+
+	var buf strings.Builder
+	if err := callgraph.Diff(before, after).Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `package p
+	+ p.main --> q.New
+	- p.f --> q.Sensitive
+	- p.main --> q.Old
+package q
+	- q.Sensitive
+`
+	// (q.Old is still a node, as the caller of q.New.)
+	if got := buf.String(); got != want {
+		t.Errorf("Diff:\n%s\nwant:\n%s", got, want)
+	}
+
+	if d := callgraph.Diff(after, after); !d.Empty() {
+		t.Errorf("Diff of a graph with itself is not empty: %v", d.Packages)
+	}
+}
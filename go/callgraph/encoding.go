@@ -0,0 +1,430 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package callgraph
+
+// This file defines the serialization of call graphs.
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"go/types"
+	"io"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/types/objectpath"
+)
+
+// FuncID returns a stable identifier for the function fn, which
+// identifies the same function in different builds of a program, and
+// in different programs that include the same package.
+//
+// The identifier of a function declared at package level is
+// "path:name", where path is the path of its package; that of a
+// concrete method is "path:objpath", where objpath is its
+// [objectpath.Path], such as "T.M0". That of an anonymous function is
+// the identifier of its enclosing function followed by the suffix of
+// its name, e.g. "$1". That of an instance of a generic function is
+// the identifier of the generic function followed by its type
+// arguments, qualified by package path, e.g. "[int,p.T]". Other
+// functions, such as wrappers and package initializers, are
+// identified by their [ssa.Function.String].
+func FuncID(fn *ssa.Function) string {
+	if parent := fn.Parent(); parent != nil {
+		return FuncID(parent) + strings.TrimPrefix(fn.Name(), parent.Name())
+	}
+	if orig := fn.Origin(); orig != nil {
+		args := make([]string, len(fn.TypeArgs()))
+		for i, targ := range fn.TypeArgs() {
+			args[i] = types.TypeString(targ, (*types.Package).Path)
+		}
+		return FuncID(orig) + "[" + strings.Join(args, ",") + "]"
+	}
+	if obj, ok := fn.Object().(*types.Func); ok && obj.Pkg() != nil && isDeclared(fn, obj) {
+		if fn.Signature.Recv() == nil {
+			// objectpath encodes only the exported functions,
+			// by name; but not init functions, which are not
+			// in the package scope.
+			if obj.Pkg().Scope().Lookup(obj.Name()) == obj {
+				return obj.Pkg().Path() + ":" + obj.Name()
+			}
+		} else if path, err := objectpath.For(obj); err == nil {
+			return obj.Pkg().Path() + ":" + string(path)
+		}
+	}
+	return fn.String()
+}
+
+// isDeclared reports whether fn is the function declared by obj, as
+// opposed to a wrapper derived from it.
+func isDeclared(fn *ssa.Function, obj *types.Func) bool {
+	if fn.Name() != obj.Name() {
+		return false // e.g. M$bound, M$thunk
+	}
+	r1, r2 := fn.Signature.Recv(), obj.Type().(*types.Signature).Recv()
+	if r1 == nil || r2 == nil {
+		return r1 == r2
+	}
+	return types.Identical(r1.Type(), r2.Type()) // not a promotion wrapper
+}
+
+// pkgPath returns the path of the package of fn, or of the object
+// from which a synthetic function without a package is derived.
+func pkgPath(fn *ssa.Function) string {
+	if fn.Pkg != nil {
+		return fn.Pkg.Pkg.Path()
+	}
+	if obj := fn.Object(); obj != nil && obj.Pkg() != nil {
+		return obj.Pkg().Path()
+	}
+	return ""
+}
+
+// rootID is the identifier of a synthetic root node, which has no
+// function.
+const rootID = "<root>"
+
+// An EncodedGraph is the serializable form of a call graph, in
+// which functions are identified by [FuncID] and call sites by their
+// index among the call instructions of the caller. Its nodes and edges
+// are in a canonical order, so that the encodings of two call graphs
+// may be compared directly.
+//
+// The JSON form of an EncodedGraph, as written by [EncodedGraph.Write]
+// with format [JSON], is an object of this form:
+//
+//	{
+//		"root": "example.com/p:main",
+//		"nodes": [{"id": "example.com/p:main", "name": "example.com/p.main", "package": "example.com/p"}, ...],
+//		"edges": [{"caller": "example.com/p:main", "site": 0, "callee": "example.com/p:f"}, ...]
+//	}
+type EncodedGraph struct {
+	Root  string        `json:"root,omitempty"` // identifier of the root node, if any
+	Nodes []EncodedNode `json:"nodes"`          // nodes, sorted by ID
+	Edges []EncodedEdge `json:"edges"`          // edges, sorted by caller, site, and callee
+}
+
+// An EncodedNode is a node of an [EncodedGraph].
+type EncodedNode struct {
+	ID      string `json:"id"`                // see [FuncID]; "<root>" for a root node without a function
+	Name    string `json:"name"`              // the function's String, for display
+	Package string `json:"package,omitempty"` // path of the function's package, if any
+}
+
+// An EncodedEdge is an edge of an [EncodedGraph].
+type EncodedEdge struct {
+	Caller string `json:"caller"` // identifier of the caller node
+	Site   int    `json:"site"`   // index of the site among the calls of the caller, or -1 if none
+	Callee string `json:"callee"` // identifier of the callee node
+}
+
+// Encode returns the serializable form of the call graph g.
+func Encode(g *Graph) *EncodedGraph {
+	enc := &EncodedGraph{Nodes: []EncodedNode{}, Edges: []EncodedEdge{}}
+	ids := make(map[*Node]string, len(g.Nodes))
+	for fn, n := range g.Nodes {
+		node := EncodedNode{ID: rootID, Name: rootID}
+		if fn != nil {
+			node = EncodedNode{ID: FuncID(fn), Name: fn.String(), Package: pkgPath(fn)}
+		}
+		ids[n] = node.ID
+		enc.Nodes = append(enc.Nodes, node)
+	}
+	if g.Root != nil {
+		enc.Root = ids[g.Root]
+	}
+	for _, n := range g.Nodes {
+		var sites map[ssa.CallInstruction]int
+		if len(n.Out) > 0 && n.Func != nil {
+			sites = make(map[ssa.CallInstruction]int)
+			for i, call := range callInstrs(n.Func) {
+				sites[call] = i
+			}
+		}
+		for _, e := range n.Out {
+			site := -1
+			if e.Site != nil {
+				site = sites[e.Site]
+			}
+			enc.Edges = append(enc.Edges, EncodedEdge{ids[e.Caller], site, ids[e.Callee]})
+		}
+	}
+	slices.SortFunc(enc.Nodes, func(x, y EncodedNode) int {
+		return cmp.Compare(x.ID, y.ID)
+	})
+	slices.SortFunc(enc.Edges, func(x, y EncodedEdge) int {
+		return cmp.Or(
+			cmp.Compare(x.Caller, y.Caller),
+			cmp.Compare(x.Site, y.Site),
+			cmp.Compare(x.Callee, y.Callee))
+	})
+	return enc
+}
+
+// Decode returns the call graph encoded by enc, whose nodes are
+// functions of funcs, such as the result of
+// [golang.org/x/tools/go/ssa/ssautil.AllFunctions]. It reports an
+// error if a node does not identify a unique function of funcs, or
+// an edge refers to a missing node or call site.
+func Decode(enc *EncodedGraph, funcs map[*ssa.Function]bool) (*Graph, error) {
+	byID := make(map[string]*ssa.Function)
+	ambiguous := make(map[string]bool)
+	for fn, in := range funcs {
+		if in {
+			id := FuncID(fn)
+			if byID[id] != nil {
+				ambiguous[id] = true
+			}
+			byID[id] = fn
+		}
+	}
+
+	g := &Graph{Nodes: make(map[*ssa.Function]*Node)}
+	nodes := make(map[string]*Node, len(enc.Nodes))
+	for _, node := range enc.Nodes {
+		var fn *ssa.Function
+		if node.ID != rootID {
+			if ambiguous[node.ID] {
+				return nil, fmt.Errorf("ambiguous function %s", node.ID)
+			}
+			fn = byID[node.ID]
+			if fn == nil {
+				return nil, fmt.Errorf("unknown function %s", node.ID)
+			}
+		}
+		nodes[node.ID] = g.CreateNode(fn)
+	}
+	if enc.Root != "" {
+		g.Root = nodes[enc.Root]
+		if g.Root == nil {
+			return nil, fmt.Errorf("unknown root node %s", enc.Root)
+		}
+	}
+
+	callsOf := make(map[*Node][]ssa.CallInstruction)
+	for _, e := range enc.Edges {
+		caller, callee := nodes[e.Caller], nodes[e.Callee]
+		if caller == nil || callee == nil {
+			return nil, fmt.Errorf("edge %s --> %s refers to an unknown node", e.Caller, e.Callee)
+		}
+		var site ssa.CallInstruction
+		if e.Site >= 0 {
+			calls, ok := callsOf[caller]
+			if !ok && caller.Func != nil {
+				calls = callInstrs(caller.Func)
+				callsOf[caller] = calls
+			}
+			if e.Site >= len(calls) {
+				return nil, fmt.Errorf("edge %s --> %s refers to call #%d of %d", e.Caller, e.Callee, e.Site, len(calls))
+			}
+			site = calls[e.Site]
+		}
+		AddEdge(caller, site, callee)
+	}
+	return g, nil
+}
+
+// callInstrs returns the call instructions of fn, in order.
+func callInstrs(fn *ssa.Function) []ssa.CallInstruction {
+	var calls []ssa.CallInstruction
+	for _, b := range fn.Blocks {
+		for _, instr := range b.Instrs {
+			if call, ok := instr.(ssa.CallInstruction); ok {
+				calls = append(calls, call)
+			}
+		}
+	}
+	return calls
+}
+
+// A Format is an encoding of an [EncodedGraph].
+type Format int
+
+const (
+	JSON   Format = iota // indented JSON, for inspection and non-Go tools
+	Binary               // a compact binary encoding
+)
+
+// magic begins the binary encoding; it cannot begin a JSON value.
+const magic = "\x00callgraph\x01"
+
+// Write writes the encoding of enc in the specified format to w.
+func (enc *EncodedGraph) Write(w io.Writer, format Format) error {
+	switch format {
+	case JSON:
+		data, err := json.MarshalIndent(enc, "", "\t")
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(append(data, '\n'))
+		return err
+
+	case Binary:
+		// The nodes are numbered by their index; the root, if
+		// any, and edge endpoints refer to those numbers.
+		index := make(map[string]uint64, len(enc.Nodes))
+		buf := []byte(magic)
+		buf = binary.AppendUvarint(buf, uint64(len(enc.Nodes)))
+		for i, node := range enc.Nodes {
+			index[node.ID] = uint64(i)
+			buf = appendString(buf, node.ID)
+			buf = appendString(buf, node.Name)
+			buf = appendString(buf, node.Package)
+		}
+		if root, ok := index[enc.Root]; ok {
+			buf = binary.AppendUvarint(buf, root+1)
+		} else {
+			buf = binary.AppendUvarint(buf, 0)
+		}
+		buf = binary.AppendUvarint(buf, uint64(len(enc.Edges)))
+		for _, e := range enc.Edges {
+			caller, ok1 := index[e.Caller]
+			callee, ok2 := index[e.Callee]
+			if !ok1 || !ok2 {
+				return fmt.Errorf("edge %s --> %s refers to an unknown node", e.Caller, e.Callee)
+			}
+			buf = binary.AppendUvarint(buf, caller)
+			buf = binary.AppendVarint(buf, int64(e.Site))
+			buf = binary.AppendUvarint(buf, callee)
+		}
+		_, err := w.Write(buf)
+		return err
+	}
+	return fmt.Errorf("invalid format %d", format)
+}
+
+func appendString(buf []byte, s string) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(s)))
+	return append(buf, s...)
+}
+
+// Read reads an encoded graph in either format from r.
+func Read(r io.Reader) (*EncodedGraph, error) {
+	br := bufio.NewReader(r)
+	if prefix, _ := br.Peek(len(magic)); string(prefix) != magic {
+		enc := new(EncodedGraph)
+		if err := json.NewDecoder(br).Decode(enc); err != nil {
+			return nil, fmt.Errorf("decoding call graph: %v", err)
+		}
+		return enc, nil
+	}
+	br.Discard(len(magic))
+
+	d := &decoder{r: br}
+	enc := &EncodedGraph{Nodes: make([]EncodedNode, d.count())}
+	for i := range enc.Nodes {
+		enc.Nodes[i] = EncodedNode{ID: d.string(), Name: d.string(), Package: d.string()}
+	}
+	if root := d.index(len(enc.Nodes) + 1); root > 0 {
+		enc.Root = enc.Nodes[root-1].ID
+	}
+	enc.Edges = make([]EncodedEdge, d.count())
+	for i := range enc.Edges {
+		caller := d.index(len(enc.Nodes))
+		site := d.varint()
+		callee := d.index(len(enc.Nodes))
+		if d.err != nil {
+			break
+		}
+		enc.Edges[i] = EncodedEdge{enc.Nodes[caller].ID, int(site), enc.Nodes[callee].ID}
+	}
+	if d.err != nil {
+		return nil, fmt.Errorf("decoding call graph: %v", d.err)
+	}
+	return enc, nil
+}
+
+// A decoder reads the binary encoding. After an error, which is
+// saved in err, its methods return zero values.
+type decoder struct {
+	r   *bufio.Reader
+	err error
+}
+
+func (d *decoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	x, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		d.err = noEOF(err)
+	}
+	return x
+}
+
+func (d *decoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	x, err := binary.ReadVarint(d.r)
+	if err != nil {
+		d.err = noEOF(err)
+	}
+	return x
+}
+
+// count reads the length of a sequence, bounding it to avoid huge
+// allocations for corrupt input.
+func (d *decoder) count() int {
+	n := d.uvarint()
+	if n > 1<<30 {
+		d.err = fmt.Errorf("invalid length %d", n)
+		return 0
+	}
+	return int(n)
+}
+
+// index reads a number less than n.
+func (d *decoder) index(n int) int {
+	i := d.uvarint()
+	if d.err == nil && i >= uint64(n) {
+		d.err = fmt.Errorf("invalid index %d", i)
+	}
+	if d.err != nil {
+		return 0
+	}
+	return int(i)
+}
+
+func (d *decoder) string() string {
+	n := d.count()
+	if d.err != nil {
+		return ""
+	}
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, d.r, int64(n)); err != nil {
+		d.err = noEOF(err)
+		return ""
+	}
+	return buf.String()
+}
+
+func noEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// Save writes the encoding of the call graph g in the specified
+// format to w. It is equivalent to Encode(g).Write(w, format).
+func Save(w io.Writer, g *Graph, format Format) error {
+	return Encode(g).Write(w, format)
+}
+
+// Load reads a call graph in either format from r, and decodes it
+// using the functions of funcs as its nodes (see [Decode]).
+func Load(r io.Reader, funcs map[*ssa.Function]bool) (*Graph, error) {
+	enc, err := Read(r)
+	if err != nil {
+		return nil, err
+	}
+	return Decode(enc, funcs)
+}
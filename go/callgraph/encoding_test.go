@@ -0,0 +1,128 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package callgraph_test
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/callgraph/cha"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+const encodingSrc = `package p
+
+type I interface{ M() }
+
+type T struct{}
+
+func (T) M() {}
+
+type U struct{ T }
+
+func id[X any](x X) X { return x }
+
+func main() {
+	var i I = U{}
+	i.M()
+	f := T{}.M
+	f()
+	func() { id(1) }()
+	id("")
+}
+`
+
+// build returns the functions of a new program built from encodingSrc.
+func build(t *testing.T) map[*ssa.Function]bool {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", encodingSrc, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg := types.NewPackage("example.com/p", "")
+	ssapkg, _, err := ssautil.BuildPackage(&types.Config{}, fset, pkg, []*ast.File{f}, ssa.InstantiateGenerics)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ssautil.AllFunctions(ssapkg.Prog)
+}
+
+func TestFuncID(t *testing.T) {
+	var ids []string
+	for fn := range build(t) {
+		ids = append(ids, callgraph.FuncID(fn))
+	}
+	slices.Sort(ids)
+	got := strings.Join(ids, "\n")
+	want := strings.Join([]string{
+		"(*example.com/p.T).M",
+		"(*example.com/p.U).M",
+		"(example.com/p.T).M$bound",
+		"(example.com/p.U).M",
+		"example.com/p.init",
+		"example.com/p:T.M0",
+		"example.com/p:id",
+		"example.com/p:id[int]",
+		"example.com/p:id[string]",
+		"example.com/p:main",
+		"example.com/p:main$1",
+	}, "\n")
+	if got != want {
+		t.Errorf("got function IDs:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	funcs := build(t)
+	cg := cha.CallGraph(anyProg(funcs))
+	want := callgraph.Encode(cg)
+	if len(want.Edges) == 0 {
+		t.Fatal("empty call graph")
+	}
+
+	for _, format := range []callgraph.Format{callgraph.JSON, callgraph.Binary} {
+		var buf bytes.Buffer
+		if err := callgraph.Save(&buf, cg, format); err != nil {
+			t.Fatal(err)
+		}
+		// Load the graph into an independent build of the same program.
+		g, err := callgraph.Load(bytes.NewReader(buf.Bytes()), build(t))
+		if err != nil {
+			t.Fatalf("format %d: %v", format, err)
+		}
+		if got := callgraph.Encode(g); !reflect.DeepEqual(got, want) {
+			t.Errorf("format %d: loaded graph differs:\ngot  %v\nwant %v", format, got, want)
+		}
+		for _, n := range g.Nodes {
+			for _, e := range n.Out {
+				if e.Site != nil && e.Site.Parent() != n.Func {
+					t.Errorf("format %d: site %v of edge %v is not in the caller", format, e.Site, e)
+				}
+			}
+		}
+
+		// Corrupt input is rejected.
+		if _, err := callgraph.Read(bytes.NewReader(buf.Bytes()[:buf.Len()/2])); err == nil {
+			t.Errorf("format %d: reading truncated input succeeded", format)
+		}
+	}
+}
+
+// anyProg returns the program of the functions of funcs.
+func anyProg(funcs map[*ssa.Function]bool) *ssa.Program {
+	for fn := range funcs {
+		return fn.Prog
+	}
+	return nil
+}
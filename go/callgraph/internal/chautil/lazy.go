@@ -8,7 +8,9 @@ package chautil
 
 import (
 	"go/types"
+	"sync"
 
+	"golang.org/x/tools/go/callgraph/internal/parallel"
 	"golang.org/x/tools/go/ssa"
 	"golang.org/x/tools/go/types/typeutil"
 )
@@ -18,8 +20,15 @@ import (
 // i.e., on the entire implements relation between interfaces and concrete types
 // in fns. Please see golang.org/x/tools/go/callgraph/cha for more information.
 //
-// The resulting function is not concurrency safe.
+// The resulting function is safe for concurrent use.
 func LazyCallees(fns map[*ssa.Function]bool) func(site ssa.CallInstruction) []*ssa.Function {
+	return LazyCalleesOf(parallel.Funcs(fns))
+}
+
+// LazyCalleesOf is like [LazyCallees] for the functions fns, but the
+// callees of each call site are in the order of fns. (LazyCallees
+// orders them as [parallel.Funcs] does.)
+func LazyCalleesOf(fns []*ssa.Function) func(site ssa.CallInstruction) []*ssa.Function {
 	// funcsBySig contains all functions, keyed by signature.  It is
 	// the effective set of address-taken functions used to resolve
 	// a dynamic call of a particular signature.
@@ -49,23 +58,31 @@ func LazyCallees(fns map[*ssa.Function]bool) func(site ssa.CallInstruction) []*s
 	// hence we must pass I explicitly, not guess from m.
 	//
 	// methodsMemo is just a cache, so it needn't be a typeutil.Map.
+	// It is guarded by mu; the other maps are read-only once built.
+	var mu sync.Mutex
 	methodsMemo := make(map[imethod][]*ssa.Function)
 	lookupMethods := func(I *types.Interface, m *types.Func) []*ssa.Function {
 		id := m.Id()
+		mu.Lock()
 		methods, ok := methodsMemo[imethod{I, id}]
+		mu.Unlock()
 		if !ok {
+			// Concurrent callers may compute the same
+			// methods, in the same order; either may win.
 			for _, f := range methodsByID[id] {
 				C := f.Signature.Recv().Type() // named or *named
 				if types.Implements(C, I) {
 					methods = append(methods, f)
 				}
 			}
+			mu.Lock()
 			methodsMemo[imethod{I, id}] = methods
+			mu.Unlock()
 		}
 		return methods
 	}
 
-	for f := range fns {
+	for _, f := range fns {
 		if f.Signature.Recv() == nil {
 			// Package initializers can never be address-taken.
 			if f.Name() == "init" && f.Synthetic == "package initializer" {
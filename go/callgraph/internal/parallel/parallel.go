@@ -0,0 +1,79 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package parallel provides helper functions for constructing call
+// graphs in parallel, with results that do not depend on the
+// scheduling of goroutines, for use in x/tools.
+package parallel
+
+import (
+	"cmp"
+	"go/token"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// Funcs returns the functions f of fns for which fns[f] is true, in a
+// deterministic order: by name, then by position.
+func Funcs(fns map[*ssa.Function]bool) []*ssa.Function {
+	type keyed struct {
+		name string
+		pos  token.Pos
+		fn   *ssa.Function
+	}
+	var keys []keyed
+	for fn, in := range fns {
+		if in {
+			keys = append(keys, keyed{fn.String(), fn.Pos(), fn})
+		}
+	}
+	slices.SortFunc(keys, func(x, y keyed) int {
+		return cmp.Or(cmp.Compare(x.name, y.name), cmp.Compare(x.pos, y.pos))
+	})
+	res := make([]*ssa.Function, len(keys))
+	for i, k := range keys {
+		res[i] = k.fn
+	}
+	return res
+}
+
+// Workers returns the number of goroutines that [Do] uses for n items.
+func Workers(n int) int {
+	return max(1, min(n, runtime.GOMAXPROCS(0)))
+}
+
+// Do calls f(worker, i) for each i in [0, n), using [Workers](n)
+// goroutines. The worker argument, in [0, Workers(n)), identifies the
+// calling goroutine, so that f may use per-goroutine state.
+func Do(n int, f func(worker, i int)) {
+	workers := Workers(n)
+	if workers == 1 {
+		for i := range n {
+			f(0, i)
+		}
+		return
+	}
+	var (
+		next int64 // next item to process
+		wg   sync.WaitGroup
+	)
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(atomic.AddInt64(&next, 1) - 1)
+				if i >= n {
+					return
+				}
+				f(w, i)
+			}
+		}()
+	}
+	wg.Wait()
+}
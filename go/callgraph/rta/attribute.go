@@ -0,0 +1,274 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rta
+
+// This file defines the attribution of reachable functions to roots.
+
+import (
+	"go/types"
+
+	"golang.org/x/tools/container/intsets"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// An Attribution relates each reachable function to the roots from
+// which it is reachable.
+//
+// A function is attributed to a root if it is reachable by RTA from
+// that root alone: a dynamic call from a function reachable from root
+// R is attributed to R only if the callee's address is taken, or its
+// receiver type is made a runtime type, in a function that is also
+// reachable from R. So a method of a type that only tests convert to
+// an interface is attributed only to the tests, even if the interface
+// call is also reachable from main. The exception is that the
+// functions whose addresses are taken, and the runtime types made,
+// by package initializers are shared by all roots, since the
+// initializers run before any of them.
+type Attribution struct {
+	roots []*ssa.Function
+	sets  map[*ssa.Function]*intsets.Sparse // indices of roots
+}
+
+// Roots returns the roots from which f is reachable, in the order in
+// which they were passed to [AnalyzeAttributed].
+func (a *Attribution) Roots(f *ssa.Function) []*ssa.Function {
+	var roots []*ssa.Function
+	if set := a.sets[f]; set != nil {
+		for _, i := range set.AppendTo(nil) {
+			roots = append(roots, a.roots[i])
+		}
+	}
+	return roots
+}
+
+// ReachableFrom reports whether f is reachable from some root for
+// which include returns true. For example, a tool may ask whether a
+// function is reachable from a root that is not a test.
+func (a *Attribution) ReachableFrom(f *ssa.Function, include func(root *ssa.Function) bool) bool {
+	if set := a.sets[f]; set != nil {
+		for _, i := range set.AppendTo(nil) {
+			if include(a.roots[i]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// An attributor propagates sets of roots over the final state of the
+// RTA algorithm: to each reachable function, from the functions that
+// call it; to each runtime type, from the functions that make it a
+// runtime type; and to each address-taken function, from the
+// functions that take its address. Each set only grows, so the
+// process terminates.
+type attributor struct {
+	r     *rta
+	funcs map[*ssa.Function]*intsets.Sparse // roots reaching each function
+	types typeutil.Map                      // roots reaching each runtime type (*intsets.Sparse)
+	addrs map[*ssa.Function]*intsets.Sparse // roots reaching each address-taken function
+	inits intsets.Sparse                    // roots that are package initializers
+	all   intsets.Sparse                    // all roots
+
+	funcQueue []*ssa.Function
+	typeQueue []types.Type
+	addrQueue []*ssa.Function
+}
+
+// attribute computes the Attribution of the final state of r.
+func (r *rta) attribute(roots []*ssa.Function) *Attribution {
+	a := &attributor{
+		r:     r,
+		funcs: make(map[*ssa.Function]*intsets.Sparse),
+		addrs: make(map[*ssa.Function]*intsets.Sparse),
+	}
+	for i, root := range roots {
+		if root.Synthetic == "package initializer" {
+			a.inits.Insert(i)
+		}
+		a.all.Insert(i)
+	}
+	for i, root := range roots {
+		var set intsets.Sparse
+		set.Insert(i)
+		a.flowFunc(root, &set)
+	}
+	for len(a.funcQueue)+len(a.typeQueue)+len(a.addrQueue) > 0 {
+		for len(a.funcQueue) > 0 {
+			f := a.funcQueue[0]
+			a.funcQueue = a.funcQueue[1:]
+			a.visitFunc(f)
+		}
+		for len(a.typeQueue) > 0 {
+			T := a.typeQueue[0]
+			a.typeQueue = a.typeQueue[1:]
+			a.visitType(T)
+		}
+		for len(a.addrQueue) > 0 {
+			g := a.addrQueue[0]
+			a.addrQueue = a.addrQueue[1:]
+			a.visitAddr(g)
+		}
+	}
+	return &Attribution{roots: roots, sets: a.funcs}
+}
+
+// flow adds the roots in set to the set for key in m, and reports
+// whether it grew.
+func flow[K comparable](m map[K]*intsets.Sparse, key K, set *intsets.Sparse) bool {
+	if set.IsEmpty() {
+		return false
+	}
+	dst := m[key]
+	if dst == nil {
+		dst = new(intsets.Sparse)
+		m[key] = dst
+	}
+	return dst.UnionWith(set)
+}
+
+func (a *attributor) flowFunc(f *ssa.Function, set *intsets.Sparse) {
+	if flow(a.funcs, f, set) {
+		a.funcQueue = append(a.funcQueue, f)
+	}
+}
+
+func (a *attributor) flowAddr(g *ssa.Function, set *intsets.Sparse) {
+	if flow(a.addrs, g, a.shared(set)) {
+		a.addrQueue = append(a.addrQueue, g)
+	}
+}
+
+func (a *attributor) flowType(T types.Type, set *intsets.Sparse) {
+	T = types.Unalias(T)
+	if set.IsEmpty() {
+		return
+	}
+	dst, _ := a.types.At(T).(*intsets.Sparse)
+	if dst == nil {
+		dst = new(intsets.Sparse)
+		a.types.Set(T, dst)
+	}
+	if dst.UnionWith(a.shared(set)) {
+		a.typeQueue = append(a.typeQueue, T)
+	}
+}
+
+// shared returns set, or all roots if set includes a package
+// initializer, whose address-taken functions and runtime types are
+// shared by all roots.
+func (a *attributor) shared(set *intsets.Sparse) *intsets.Sparse {
+	if set.Intersects(&a.inits) {
+		return &a.all
+	}
+	return set
+}
+
+// both returns the intersection of x and y, either of which may be nil.
+func both(x, y *intsets.Sparse) *intsets.Sparse {
+	var z intsets.Sparse
+	if x != nil && y != nil {
+		z.Intersection(x, y)
+	}
+	return &z
+}
+
+// visitFunc propagates the roots of f, following visitFunc of rta.
+func (a *attributor) visitFunc(f *ssa.Function) {
+	set := a.funcs[f]
+	var space [32]*ssa.Value
+	for _, b := range f.Blocks {
+		for _, instr := range b.Instrs {
+			rands := instr.Operands(space[:0])
+
+			switch instr := instr.(type) {
+			case ssa.CallInstruction:
+				call := instr.Common()
+				if call.IsInvoke() {
+					I := call.Value.Type().Underlying().(*types.Interface)
+					if iinfo, ok := a.r.interfaceTypes.At(I).(*interfaceTypeInfo); ok {
+						for _, C := range iinfo.implementations {
+							a.flowInvoke(instr, C, set, a.typeSet(C))
+						}
+					}
+				} else if g := call.StaticCallee(); g != nil {
+					a.flowFunc(g, set)
+				} else if _, ok := call.Value.(*ssa.Builtin); !ok {
+					funcs, _ := a.r.addrTakenFuncsBySig.At(call.Signature()).(map[*ssa.Function]bool)
+					for g := range funcs {
+						a.flowFunc(g, both(set, a.addrs[g]))
+					}
+				}
+				rands = rands[1:] // see rta.visitFunc
+
+			case *ssa.MakeInterface:
+				a.flowType(instr.X.Type(), set)
+			}
+
+			for _, op := range rands {
+				if g, ok := (*op).(*ssa.Function); ok {
+					a.flowAddr(g, set)
+				}
+			}
+		}
+	}
+
+	// (*reflect.Value).Call may call any address-taken function.
+	if f == a.r.reflectValueCall {
+		for g, addr := range a.addrs {
+			a.flowFunc(g, both(set, addr))
+		}
+	}
+}
+
+// visitAddr propagates the roots of the address-taken function g to
+// g itself, through the dynamic calls that may call it.
+func (a *attributor) visitAddr(g *ssa.Function) {
+	set := a.addrs[g]
+	sites, _ := a.r.dynCallSites.At(g.Signature).([]ssa.CallInstruction)
+	for _, site := range sites {
+		a.flowFunc(g, both(set, a.funcs[site.Parent()]))
+	}
+	if rvc := a.r.reflectValueCall; rvc != nil {
+		a.flowFunc(g, both(set, a.funcs[rvc]))
+	}
+}
+
+// visitType propagates the roots of the runtime type T, following
+// rta.addRuntimeType.
+func (a *attributor) visitType(T types.Type) {
+	set := a.typeSet(T)
+	mset := a.r.prog.MethodSets.MethodSet(T)
+	if _, ok := T.Underlying().(*types.Interface); !ok {
+		for i, n := 0, mset.Len(); i < n; i++ {
+			if sel := mset.At(i); sel.Obj().Exported() {
+				a.flowFunc(a.r.prog.MethodValue(sel), set)
+			}
+		}
+		if cinfo, ok := a.r.concreteTypes.At(T).(*concreteTypeInfo); ok {
+			for _, I := range cinfo.implements {
+				sites, _ := a.r.invokeSites.At(I).([]ssa.CallInstruction)
+				for _, site := range sites {
+					a.flowInvoke(site, T, a.funcs[site.Parent()], set)
+				}
+			}
+		}
+	}
+	forEachRuntimeTypeDep(T, mset, func(dep types.Type, _ bool) {
+		a.flowType(dep, set)
+	})
+}
+
+// flowInvoke propagates to the method of C called by the invoke-mode
+// call site the roots that reach both the site and the runtime type C.
+func (a *attributor) flowInvoke(site ssa.CallInstruction, C types.Type, siteSet, typeSet *intsets.Sparse) {
+	imethod := site.Common().Method
+	a.flowFunc(a.r.prog.LookupMethod(C, imethod.Pkg(), imethod.Name()), both(siteSet, typeSet))
+}
+
+func (a *attributor) typeSet(T types.Type) *intsets.Sparse {
+	set, _ := a.types.At(T).(*intsets.Sparse)
+	return set
+}
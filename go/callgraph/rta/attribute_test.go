@@ -0,0 +1,185 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rta_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/callgraph/rta"
+	"golang.org/x/tools/go/ssa"
+)
+
+const attributionSrc = `package main
+
+type I interface{ M() }
+
+type a struct{}
+
+func (a) M() { fa() }
+
+type b struct{}
+
+func (b) M() { fb() }
+
+func fa() {}
+func fb() {}
+func fc() {}
+
+var fn = fc
+
+func main() { call(a{}) }
+
+func call(i I) { i.M() }
+
+func Exported() {}
+
+type Exp struct{}
+
+func (Exp) Do() {}
+
+func (*Exp) Undo() {}
+
+func (Exp) private() {}
+`
+
+const attributionTestSrc = `package main
+
+import "testing"
+
+func TestB(t *testing.T) { call(b{}); fn() }
+
+func Testlower(t *testing.T) {}
+
+func BenchmarkX(b *testing.B) {}
+
+func ExampleE() {}
+
+func TestBad() {}
+`
+
+const testingSrc = `package testing
+
+type T struct{}
+type B struct{}
+`
+
+// buildAttributionProgram returns the main package of a program
+// built from attributionSrc and attributionTestSrc.
+func buildAttributionProgram(t *testing.T) *ssa.Package {
+	fset := token.NewFileSet()
+	parse := func(name, src string) *ast.File {
+		f, err := parser.ParseFile(fset, name, src, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f
+	}
+	newInfo := func() *types.Info {
+		return &types.Info{
+			Types:      make(map[ast.Expr]types.TypeAndValue),
+			Defs:       make(map[*ast.Ident]types.Object),
+			Uses:       make(map[*ast.Ident]types.Object),
+			Implicits:  make(map[ast.Node]types.Object),
+			Instances:  make(map[*ast.Ident]types.Instance),
+			Scopes:     make(map[ast.Node]*types.Scope),
+			Selections: make(map[*ast.SelectorExpr]*types.Selection),
+		}
+	}
+	prog := ssa.NewProgram(fset, ssa.InstantiateGenerics)
+
+	testingFiles := []*ast.File{parse("testing.go", testingSrc)}
+	testingInfo := newInfo()
+	testingPkg, err := new(types.Config).Check("testing", fset, testingFiles, testingInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prog.CreatePackage(testingPkg, testingFiles, testingInfo, true)
+
+	files := []*ast.File{parse("main.go", attributionSrc), parse("main_test.go", attributionTestSrc)}
+	info := newInfo()
+	conf := types.Config{Importer: importerFunc(func(string) (*types.Package, error) { return testingPkg, nil })}
+	mainPkg, err := conf.Check("main", fset, files, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg := prog.CreatePackage(mainPkg, files, info, true)
+	prog.Build()
+	return pkg
+}
+
+func TestRoots(t *testing.T) {
+	pkg := buildAttributionProgram(t)
+	for _, test := range []struct {
+		kinds rta.RootKind
+		want  string
+	}{
+		{rta.MainRoots, "main.main main.init"},
+		{rta.TestRoots, "main.TestB main.BenchmarkX main.ExampleE"},
+		{rta.APIRoots, "main.Exported (main.Exp).Do (*main.Exp).Do (*main.Exp).Undo"},
+		{rta.MainRoots | rta.TestRoots, "main.main main.init main.TestB main.BenchmarkX main.ExampleE"},
+	} {
+		var got []string
+		for _, fn := range rta.Roots(pkg, test.kinds) {
+			got = append(got, fn.String())
+		}
+		if strings.Join(got, " ") != test.want {
+			t.Errorf("Roots(%d) = %s, want %s", test.kinds, strings.Join(got, " "), test.want)
+		}
+	}
+}
+
+func TestAttribution(t *testing.T) {
+	pkg := buildAttributionProgram(t)
+	roots := rta.Roots(pkg, rta.MainRoots|rta.TestRoots)
+	res := rta.AnalyzeAttributed(roots, false)
+
+	isTest := func(fn *ssa.Function) bool { return strings.HasPrefix(fn.Name(), "Test") }
+	for _, test := range []struct {
+		fn   string
+		want string // roots from which fn is reachable
+	}{
+		{"main.call", "main.main main.TestB"},
+		{"(main.a).M", "main.main"},
+		{"(main.b).M", "main.TestB"},
+		{"main.fa", "main.main"},
+		{"main.fb", "main.TestB"},
+		{"main.fc", "main.TestB"}, // address taken by main.init
+		{"main.init", "main.init"},
+		{"main.Exported", ""},
+	} {
+		var fn *ssa.Function
+		for f := range res.Reachable {
+			if f.String() == test.fn {
+				fn = f
+			}
+		}
+		if fn == nil {
+			if test.want != "" {
+				t.Errorf("%s is not reachable", test.fn)
+			}
+			continue
+		}
+		var got []string
+		for _, root := range res.Attribution.Roots(fn) {
+			got = append(got, root.String())
+		}
+		if strings.Join(got, " ") != test.want {
+			t.Errorf("roots of %s = %s, want %s", test.fn, strings.Join(got, " "), test.want)
+		}
+		testOnly := !res.Attribution.ReachableFrom(fn, func(root *ssa.Function) bool { return !isTest(root) })
+		if wantTestOnly := test.want == "main.TestB"; testOnly != wantTestOnly {
+			t.Errorf("%s: test-only = %t, want %t", test.fn, testOnly, wantTestOnly)
+		}
+	}
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) { return f(path) }
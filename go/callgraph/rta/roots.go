@@ -0,0 +1,171 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rta
+
+import (
+	"cmp"
+	"go/types"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// A RootKind is a set of kinds of root functions; see [Roots].
+type RootKind int
+
+const (
+	// MainRoots are the main function of a main package, and the
+	// package initializer of every package.
+	MainRoots RootKind = 1 << iota
+
+	// TestRoots are the Test, Benchmark, Fuzz, and Example
+	// functions, and TestMain, declared in the _test.go files of
+	// a package.
+	TestRoots
+
+	// APIRoots are the exported functions of a package, and the
+	// exported methods of its exported types, except generic ones.
+	APIRoots
+)
+
+// Roots returns the functions of pkg of the specified kinds, for use
+// as roots of [Analyze] or [AnalyzeAttributed]. The functions of each
+// kind appear in source order.
+func Roots(pkg *ssa.Package, kinds RootKind) []*ssa.Function {
+	var roots []*ssa.Function
+	if kinds&MainRoots != 0 {
+		if pkg.Pkg.Name() == "main" {
+			if main := pkg.Func("main"); main != nil {
+				roots = append(roots, main)
+			}
+		}
+		if init := pkg.Func("init"); init != nil {
+			roots = append(roots, init)
+		}
+	}
+
+	// Visit the package-level declarations in source order.
+	scope := pkg.Pkg.Scope()
+	var objs []types.Object
+	for _, name := range scope.Names() {
+		objs = append(objs, scope.Lookup(name))
+	}
+	sortByPos(objs)
+
+	prog := pkg.Prog
+	if kinds&TestRoots != 0 {
+		for _, obj := range objs {
+			if fn, ok := obj.(*types.Func); ok && isTestFunc(fn) && isTestFile(prog, fn) {
+				roots = append(roots, pkg.Func(fn.Name()))
+			}
+		}
+	}
+	if kinds&APIRoots != 0 {
+		for _, obj := range objs {
+			if !obj.Exported() || isTestFile(prog, obj) {
+				continue
+			}
+			switch obj := obj.(type) {
+			case *types.Func:
+				if obj.Type().(*types.Signature).TypeParams() == nil {
+					roots = append(roots, pkg.Func(obj.Name()))
+				}
+			case *types.TypeName:
+				named, ok := obj.Type().(*types.Named)
+				if !ok || obj.IsAlias() || named.TypeParams() != nil || types.IsInterface(named) {
+					continue
+				}
+				for _, T := range []types.Type{named, types.NewPointer(named)} {
+					mset := prog.MethodSets.MethodSet(T)
+					for i := range mset.Len() {
+						if sel := mset.At(i); sel.Obj().Exported() {
+							if fn := prog.MethodValue(sel); fn != nil {
+								roots = append(roots, fn)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+	return roots
+}
+
+// isTestFunc reports whether fn has the name and signature of a
+// function called by the testing package.
+func isTestFunc(fn *types.Func) bool {
+	sig := fn.Type().(*types.Signature)
+	if sig.TypeParams() != nil || sig.Results().Len() > 0 {
+		return false
+	}
+	name := fn.Name()
+	if name == "TestMain" {
+		return isTestingParam(sig, "M")
+	}
+	for _, kind := range []struct{ prefix, param string }{
+		{"Test", "T"},
+		{"Benchmark", "B"},
+		{"Fuzz", "F"},
+		{"Example", ""},
+	} {
+		if hasTestPrefix(name, kind.prefix) {
+			if kind.param == "" {
+				return sig.Params().Len() == 0
+			}
+			return isTestingParam(sig, kind.param)
+		}
+	}
+	return false
+}
+
+// hasTestPrefix reports whether name is prefix followed by nothing
+// or by a character that is not a lower-case letter, as required by
+// the go test command.
+func hasTestPrefix(name, prefix string) bool {
+	if !strings.HasPrefix(name, prefix) {
+		return false
+	}
+	if len(name) == len(prefix) {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(name[len(prefix):])
+	return !unicode.IsLower(r)
+}
+
+// isTestingParam reports whether sig has a single parameter of type
+// *testing.name.
+func isTestingParam(sig *types.Signature, name string) bool {
+	if sig.Params().Len() != 1 {
+		return false
+	}
+	ptr, ok := types.Unalias(sig.Params().At(0).Type()).(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := types.Unalias(ptr.Elem()).(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == "testing" && obj.Name() == name
+}
+
+// isTestFile reports whether obj is declared in a _test.go file.
+func isTestFile(prog *ssa.Program, obj types.Object) bool {
+	if file := prog.Fset.File(obj.Pos()); file != nil {
+		return strings.HasSuffix(file.Name(), "_test.go")
+	}
+	return false
+}
+
+// sortByPos sorts objs by position.
+func sortByPos(objs []types.Object) {
+	slices.SortFunc(objs, func(x, y types.Object) int {
+		return cmp.Compare(x.Pos(), y.Pos())
+	})
+}
@@ -73,6 +73,11 @@ type Result struct {
 	// Types *A, A and B are accessible to reflection, but the unnamed
 	// type struct{B} is not.
 	RuntimeTypes typeutil.Map
+
+	// Attribution relates each reachable function to the roots
+	// from which it is reachable. It is computed only by
+	// [AnalyzeAttributed].
+	Attribution *Attribution
 }
 
 // Working state of the RTA algorithm.
@@ -300,7 +305,8 @@ func (r *rta) visitFunc(f *ssa.Function) {
 // The root functions must be one or more entrypoints (main and init
 // functions) of a complete SSA program, with function bodies for all
 // dependencies, constructed with the [ssa.InstantiateGenerics] mode
-// flag.
+// flag. Other functions, such as tests or the exported functions of a
+// library (see [Roots]), may be roots too.
 //
 // If buildCallGraph is true, Result.CallGraph will contain a call
 // graph; otherwise, only the other fields (reachable functions) are
@@ -309,7 +315,24 @@ func Analyze(roots []*ssa.Function, buildCallGraph bool) *Result {
 	if len(roots) == 0 {
 		return nil
 	}
+	return analyze(roots, buildCallGraph).result
+}
 
+// AnalyzeAttributed is like [Analyze], but it also attributes each
+// reachable function to the roots from which it is reachable, in
+// Result.Attribution.
+func AnalyzeAttributed(roots []*ssa.Function, buildCallGraph bool) *Result {
+	if len(roots) == 0 {
+		return nil
+	}
+	r := analyze(roots, buildCallGraph)
+	r.result.Attribution = r.attribute(roots)
+	return r.result
+}
+
+// analyze performs Rapid Type Analysis from one or more roots, and
+// returns its final state.
+func analyze(roots []*ssa.Function, buildCallGraph bool) *rta {
 	r := &rta{
 		result: &Result{Reachable: make(map[*ssa.Function]struct{ AddrTaken bool })},
 		prog:   roots[0].Prog,
@@ -351,7 +374,7 @@ func Analyze(roots []*ssa.Function, buildCallGraph bool) *Result {
 			r.visitFunc(f)
 		}
 	}
-	return r.result
+	return r
 }
 
 // interfaces(C) returns all currently known interfaces implemented by C.
@@ -450,6 +473,13 @@ func (r *rta) addRuntimeType(T types.Type, skip bool) {
 		}
 	}
 
+	forEachRuntimeTypeDep(T, mset, r.addRuntimeType)
+}
+
+// forEachRuntimeTypeDep calls f for each type that can be derived
+// from the runtime type T, whose method set is mset, by reflection,
+// along with its 'skip' flag (see addRuntimeType).
+func forEachRuntimeTypeDep(T types.Type, mset *types.MethodSet, f func(T types.Type, skip bool)) {
 	// Precondition: T is not a method signature (*Signature with Recv()!=nil).
 	// Recursive case: skip => don't call makeMethods(T).
 	// Each package maintains its own set of types it has visited.
@@ -472,8 +502,8 @@ func (r *rta) addRuntimeType(T types.Type, skip bool) {
 	for i := 0; i < mset.Len(); i++ {
 		if mset.At(i).Obj().Exported() {
 			sig := mset.At(i).Type().(*types.Signature)
-			r.addRuntimeType(sig.Params(), true)  // skip the Tuple itself
-			r.addRuntimeType(sig.Results(), true) // skip the Tuple itself
+			f(sig.Params(), true)  // skip the Tuple itself
+			f(sig.Results(), true) // skip the Tuple itself
 		}
 	}
 
@@ -488,47 +518,47 @@ func (r *rta) addRuntimeType(T types.Type, skip bool) {
 		// nop---handled by recursion over method set.
 
 	case *types.Pointer:
-		r.addRuntimeType(t.Elem(), false)
+		f(t.Elem(), false)
 
 	case *types.Slice:
-		r.addRuntimeType(t.Elem(), false)
+		f(t.Elem(), false)
 
 	case *types.Chan:
-		r.addRuntimeType(t.Elem(), false)
+		f(t.Elem(), false)
 
 	case *types.Map:
-		r.addRuntimeType(t.Key(), false)
-		r.addRuntimeType(t.Elem(), false)
+		f(t.Key(), false)
+		f(t.Elem(), false)
 
 	case *types.Signature:
 		if t.Recv() != nil {
 			panic(fmt.Sprintf("Signature %s has Recv %s", t, t.Recv()))
 		}
-		r.addRuntimeType(t.Params(), true)  // skip the Tuple itself
-		r.addRuntimeType(t.Results(), true) // skip the Tuple itself
+		f(t.Params(), true)  // skip the Tuple itself
+		f(t.Results(), true) // skip the Tuple itself
 
 	case *types.Named:
 		// A pointer-to-named type can be derived from a named
 		// type via reflection.  It may have methods too.
-		r.addRuntimeType(types.NewPointer(T), false)
+		f(types.NewPointer(T), false)
 
 		// Consider 'type T struct{S}' where S has methods.
 		// Reflection provides no way to get from T to struct{S},
 		// only to S, so the method set of struct{S} is unwanted,
 		// so set 'skip' flag during recursion.
-		r.addRuntimeType(t.Underlying(), true)
+		f(t.Underlying(), true)
 
 	case *types.Array:
-		r.addRuntimeType(t.Elem(), false)
+		f(t.Elem(), false)
 
 	case *types.Struct:
 		for i, n := 0, t.NumFields(); i < n; i++ {
-			r.addRuntimeType(t.Field(i).Type(), false)
+			f(t.Field(i).Type(), false)
 		}
 
 	case *types.Tuple:
 		for i, n := 0, t.Len(); i < n; i++ {
-			r.addRuntimeType(t.At(i).Type(), false)
+			f(t.At(i).Type(), false)
 		}
 
 	default:
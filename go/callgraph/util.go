@@ -4,7 +4,11 @@
 
 package callgraph
 
-import "golang.org/x/tools/go/ssa"
+import (
+	"slices"
+
+	"golang.org/x/tools/go/ssa"
+)
 
 // This file provides various utilities over call graphs, such as
 // visitation and path search.
@@ -75,6 +79,62 @@ func PathSearch(start *Node, isEnd func(*Node) bool) []*Edge {
 	return search(start)
 }
 
+// ShortestPath finds a shortest path starting at one of the start
+// nodes and ending at some node for which isEnd() returns true,
+// following only the edges for which follow() returns true, or all
+// edges if follow is nil. Among paths of the same length, it prefers
+// those from earlier start nodes, then those through earlier edges of
+// each node's Out list. On success, ShortestPath returns the path as
+// an ordered list of edges, which is empty but non-nil if a start
+// node satisfies isEnd; on failure, it returns nil.
+func ShortestPath(start []*Node, isEnd func(*Node) bool, follow func(*Edge) bool) []*Edge {
+	// pred maps each encountered node to the edge by which it was
+	// first reached, or to nil for a start node.
+	pred := make(map[*Node]*Edge)
+	var queue []*Node
+	for _, n := range start {
+		if _, ok := pred[n]; !ok {
+			pred[n] = nil
+			queue = append(queue, n)
+		}
+	}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		if isEnd(n) {
+			path := []*Edge{}
+			for e := pred[n]; e != nil; e = pred[e.Caller] {
+				path = append(path, e)
+			}
+			slices.Reverse(path)
+			return path
+		}
+		for _, e := range n.Out {
+			if _, ok := pred[e.Callee]; !ok && (follow == nil || follow(e)) {
+				pred[e.Callee] = e
+				queue = append(queue, e.Callee)
+			}
+		}
+	}
+	return nil
+}
+
+// Reachable reports whether the function target is reachable in call
+// graph g from one of the functions roots, and if so, returns a
+// shortest call path from a root to target as evidence, as by
+// [ShortestPath]. The path is empty if target is itself a root.
+// Roots that have no node in g are ignored.
+func (g *Graph) Reachable(roots []*ssa.Function, target *ssa.Function) ([]*Edge, bool) {
+	var start []*Node
+	for _, fn := range roots {
+		if n := g.Nodes[fn]; n != nil {
+			start = append(start, n)
+		}
+	}
+	path := ShortestPath(start, func(n *Node) bool { return n.Func == target }, nil)
+	return path, path != nil
+}
+
 // DeleteSyntheticNodes removes from call graph g all nodes for
 // functions that do not correspond to source syntax. For historical
 // reasons, nodes for g.Root and package initializers are always
@@ -0,0 +1,93 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package callgraph_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/callgraph/cha"
+	"golang.org/x/tools/go/ssa"
+)
+
+func TestShortestPath(t *testing.T) {
+	// a -> b -> c -> d
+	// a -> e -> d
+	// f
+	nodes := make(map[string]*callgraph.Node)
+	for i, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		nodes[name] = &callgraph.Node{ID: i}
+	}
+	name := func(n *callgraph.Node) string { return string(rune('a' + n.ID)) }
+	for _, edge := range []string{"ab", "bc", "cd", "ae", "ed"} {
+		callgraph.AddEdge(nodes[edge[:1]], nil, nodes[edge[1:]])
+	}
+	str := func(path []*callgraph.Edge) string {
+		if path == nil {
+			return "none"
+		}
+		var buf strings.Builder
+		for _, e := range path {
+			fmt.Fprintf(&buf, "%s%s ", name(e.Caller), name(e.Callee))
+		}
+		return strings.TrimSpace(buf.String())
+	}
+	notED := func(e *callgraph.Edge) bool { return !(e.Caller == nodes["e"] && e.Callee == nodes["d"]) }
+
+	for _, test := range []struct {
+		start  string
+		end    string
+		follow func(*callgraph.Edge) bool
+		want   string
+	}{
+		{"a", "d", nil, "ae ed"},
+		{"a", "d", notED, "ab bc cd"},
+		{"cb", "d", nil, "cd"},
+		{"d", "d", nil, ""},
+		{"d", "a", nil, "none"},
+		{"f", "d", nil, "none"},
+		{"", "d", nil, "none"},
+	} {
+		var start []*callgraph.Node
+		for _, r := range test.start {
+			start = append(start, nodes[string(r)])
+		}
+		isEnd := func(n *callgraph.Node) bool { return n == nodes[test.end] }
+		if got := str(callgraph.ShortestPath(start, isEnd, test.follow)); got != test.want {
+			t.Errorf("ShortestPath(%s, %s) = %s, want %s", test.start, test.end, got, test.want)
+		}
+	}
+}
+
+func TestReachable(t *testing.T) {
+	funcs := build(t)
+	byID := make(map[string]*ssa.Function)
+	for fn := range funcs {
+		byID[callgraph.FuncID(fn)] = fn
+	}
+	cg := cha.CallGraph(anyProg(funcs))
+	roots := []*ssa.Function{byID["example.com/p:main"]}
+
+	path, ok := cg.Reachable(roots, byID["example.com/p:id[int]"])
+	if !ok {
+		t.Fatal("id[int] is not reachable from main")
+	}
+	var got []string
+	for _, e := range path {
+		got = append(got, e.Callee.Func.String())
+	}
+	if want := "example.com/p.main$1 example.com/p.id[int]"; strings.Join(got, " ") != want {
+		t.Errorf("path to id[int] = %v, want %s", got, want)
+	}
+
+	if path, ok := cg.Reachable(roots, roots[0]); !ok || len(path) != 0 {
+		t.Errorf("Reachable(main, main) = %v, %t, want empty path", path, ok)
+	}
+	if path, ok := cg.Reachable(roots, byID["example.com/p.init"]); ok {
+		t.Errorf("package initializer is reachable from main via %v", path)
+	}
+}
@@ -281,6 +281,9 @@ func (g *vtaGraph) successors(x idx) iter.Seq[idx] {
 	}
 }
 
+// An edge x->y of a vtaGraph.
+type edge struct{ x, y node }
+
 // addEdge adds an edge x->y to the graph.
 func (g *vtaGraph) addEdge(x, y node) {
 	if g.idx == nil {
@@ -322,6 +325,11 @@ type builder struct {
 	graph   vtaGraph
 	callees calleesFunc // initial call graph for creating flows at unresolved call sites.
 
+	// If edges is non-nil, the edges added while visiting each
+	// function are also recorded in edges, for use by [Incremental].
+	edges map[*ssa.Function][]edge
+	fn    *ssa.Function // function being visited
+
 	// Specialized type map for canonicalization of types.Type.
 	// Semantically equivalent types can have different implementations,
 	// i.e., they are different pointer values. The map allows us to
@@ -344,6 +352,7 @@ func (b *builder) visit(funcs map[*ssa.Function]bool) {
 }
 
 func (b *builder) fun(f *ssa.Function) {
+	b.fn = f
 	for _, bl := range f.Blocks {
 		for _, instr := range bl.Instrs {
 			b.instr(instr)
@@ -775,7 +784,11 @@ func (b *builder) multiconvert(c *ssa.MultiConvert) {
 // is no interesting type flow so the edge is omitted.
 func (b *builder) addInFlowEdge(s, d node) {
 	if hasInFlow(d) {
-		b.graph.addEdge(b.representative(s), b.representative(d))
+		x, y := b.representative(s), b.representative(d)
+		b.graph.addEdge(x, y)
+		if b.edges != nil {
+			b.edges[b.fn] = append(b.edges[b.fn], edge{x, y})
+		}
 	}
 }
 
@@ -0,0 +1,225 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vta
+
+import (
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/callgraph/internal/chautil"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// An Incremental maintains the VTA call graph of a changing set of
+// functions, such as those of a program being edited in an
+// interactive tool.
+//
+// The graph is the same as the one computed by [CallGraph] with a nil
+// initial call graph, but after a change to a few functions, [Incremental.Update]
+// recomputes only the type flows contributed by the changed functions and by
+// the functions whose call sites they may be called from, and only the call
+// edges of the functions whose callees may have changed.
+//
+// An Incremental is not safe for concurrent use.
+type Incremental struct {
+	funcs map[*ssa.Function]bool                  // the current set of functions
+	calls map[*ssa.Function][]ssa.CallInstruction // call sites of each function in funcs
+	b     builder                                 // retains the type flows (b.edges) of each function
+	ids   map[propType]uint64                     // unique ids of propTypes, shared by each propagation
+	types propTypeMap                             // the types reaching each node
+	cg    *callgraph.Graph
+	maxID int // highest ID of a node of cg
+}
+
+// NewIncremental computes the VTA call graph of the functions f:true
+// in funcs, and returns an Incremental that maintains it.
+func NewIncremental(funcs map[*ssa.Function]bool) *Incremental {
+	inc := &Incremental{
+		funcs: make(map[*ssa.Function]bool),
+		calls: make(map[*ssa.Function][]ssa.CallInstruction),
+		b:     builder{edges: make(map[*ssa.Function][]edge)},
+		ids:   make(map[propType]uint64),
+		cg:    &callgraph.Graph{Nodes: make(map[*ssa.Function]*callgraph.Node)},
+		maxID: -1,
+	}
+	for f, in := range funcs {
+		if in {
+			inc.funcs[f] = true
+		}
+	}
+	inc.update(inc.funcs)
+	return inc
+}
+
+// Graph returns the call graph, which has no root node.
+// It is updated in place by each call to [Incremental.Update].
+func (inc *Incremental) Graph() *callgraph.Graph {
+	return inc.cg
+}
+
+// Update updates the call graph after a change to the set of
+// functions. The changed map holds f:true for each function f that
+// was added to the set (or whose body was built anew), and f:false
+// for each function removed from it. For example, after a call to
+// [ssa.Program.UpdatePackages], the changed functions are the
+// functions of the replaced packages, which are removed, and those of
+// the new packages, which are added.
+//
+// The functions that remain in the set and are not changed must not
+// refer to the removed functions, although they may call them
+// dynamically.
+func (inc *Incremental) Update(changed map[*ssa.Function]bool) {
+	// The changed functions may change the callees given by CHA of
+	// unchanged call sites of the same method or signature (see
+	// chautil.LazyCallees): those sites must be visited again.
+	ids := make(map[string]bool)
+	var sigs typeutil.Map
+	for f, in := range changed {
+		if in {
+			inc.funcs[f] = true
+		} else {
+			delete(inc.funcs, f)
+			delete(inc.calls, f)
+			delete(inc.b.edges, f)
+			if n := inc.cg.Nodes[f]; n != nil {
+				inc.deleteOuts(n)
+				inc.cg.DeleteNode(n)
+			}
+		}
+		if f.Signature.Recv() == nil {
+			sigs.Set(f.Signature, true)
+		} else if obj := f.Object(); obj != nil {
+			ids[obj.(*types.Func).Id()] = true
+		}
+	}
+	rebuild := make(map[*ssa.Function]bool)
+	for f, in := range changed {
+		if in {
+			rebuild[f] = true
+		}
+	}
+	for f, calls := range inc.calls {
+		if rebuild[f] {
+			continue
+		}
+		for _, call := range calls {
+			if affected(call.Common(), changed, ids, &sigs) {
+				rebuild[f] = true
+				break
+			}
+		}
+	}
+	inc.update(rebuild)
+}
+
+// affected reports whether the callees given by CHA of the call c
+// may be changed by the changed functions, whose method ids and
+// signatures are in ids and sigs.
+func affected(c *ssa.CallCommon, changed map[*ssa.Function]bool, ids map[string]bool, sigs *typeutil.Map) bool {
+	if c.IsInvoke() {
+		return ids[c.Method.Id()]
+	}
+	if g := c.StaticCallee(); g != nil {
+		in, ok := changed[g]
+		return ok && !in
+	}
+	if _, ok := c.Value.(*ssa.Builtin); ok {
+		return false
+	}
+	return sigs.At(c.Signature()) != nil
+}
+
+// update recomputes the type flows of the functions in rebuild,
+// propagates the types through the flows of all functions, and
+// updates the call edges of the functions whose call sites may
+// resolve differently.
+func (inc *Incremental) update(rebuild map[*ssa.Function]bool) {
+	callees := chautil.LazyCallees(inc.funcs)
+
+	b := &inc.b
+	b.graph = vtaGraph{}
+	b.callees = callees
+	b.graph.addEdge(panicArg{}, recoverReturn{})
+	for f := range rebuild {
+		b.edges[f] = nil
+		b.fun(f)
+		inc.calls[f] = calls(f)
+	}
+	for f, edges := range b.edges {
+		if !rebuild[f] {
+			for _, e := range edges {
+				b.graph.addEdge(e.x, e.y)
+			}
+		}
+	}
+	b.callees, b.fn = nil, nil
+
+	prev := inc.types
+	inc.types = propagate(&b.graph, &b.canon, inc.ids)
+
+	c := &constructor{types: inc.types, callees: callees}
+	cache := make(methodCache)
+	for f := range inc.funcs {
+		if !rebuild[f] && !inc.typesChanged(f, prev) {
+			continue
+		}
+		caller := inc.node(f)
+		inc.deleteOuts(caller)
+		for _, call := range inc.calls[f] {
+			for _, g := range c.resolves(call, cache) {
+				callgraph.AddEdge(caller, call, inc.node(g))
+			}
+		}
+	}
+}
+
+// typesChanged reports whether the types reaching the function value
+// of a dynamic call site of f differ from those in prev.
+func (inc *Incremental) typesChanged(f *ssa.Function, prev propTypeMap) bool {
+	for _, call := range inc.calls[f] {
+		cc := call.Common()
+		if cc.StaticCallee() != nil {
+			continue
+		}
+		n := local{val: cc.Value}
+		x, y := prev[n], inc.types[n]
+		switch {
+		case x == nil && y == nil:
+		case x == nil || y == nil:
+			return true
+		case !x.M.DeepEqual(y.M):
+			return true
+		}
+	}
+	return false
+}
+
+// node returns the node of the call graph for f, creating it if
+// needed. Unlike [callgraph.Graph.CreateNode], it gives each new node
+// a unique ID even after other nodes have been deleted.
+func (inc *Incremental) node(f *ssa.Function) *callgraph.Node {
+	n := inc.cg.Nodes[f]
+	if n == nil {
+		inc.maxID++
+		n = &callgraph.Node{Func: f, ID: inc.maxID}
+		inc.cg.Nodes[f] = n
+	}
+	return n
+}
+
+// deleteOuts deletes the outgoing edges of n, and the nodes of
+// callees outside the set of functions that are no longer called.
+func (inc *Incremental) deleteOuts(n *callgraph.Node) {
+	for _, e := range n.Out {
+		callee := e.Callee
+		callee.In = slices.DeleteFunc(callee.In, func(in *callgraph.Edge) bool { return in == e })
+		if len(callee.In) == 0 && len(callee.Out) == 0 && !inc.funcs[callee.Func] {
+			delete(inc.cg.Nodes, callee.Func)
+		}
+	}
+	n.Out = nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vta
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+const incrementalQ = `package q
+
+type I interface{ F() int }
+
+func Call(i I) int { return i.F() }
+
+func Apply(f func(int) int) int { return f(1) }
+`
+
+const incrementalP1 = `package p
+
+import "q"
+
+type A struct{}
+
+func (A) F() int { return 1 }
+
+type B struct{}
+
+func (B) F() int { return 2 }
+
+func double(x int) int { return 2 * x }
+
+func Run() int {
+	return q.Call(A{}) + q.Apply(double)
+}
+
+func other(i q.I) int { return i.F() }
+`
+
+// incrementalP2 removes B and double, adds C and triple, and changes
+// the values passed by Run to the unchanged functions of q.
+const incrementalP2 = `package p
+
+import "q"
+
+type A struct{}
+
+func (A) F() int { return 1 }
+
+type C struct{ x int }
+
+func (c C) F() int { return c.x }
+
+func triple(x int) int { return 3 * x }
+
+func Run() int {
+	return q.Call(C{}) + q.Apply(triple) + q.Apply(func(x int) int { return x })
+}
+
+func other(i q.I) int { return i.F() }
+`
+
+func TestIncremental(t *testing.T) {
+	fset := token.NewFileSet()
+	check := func(path, src string, imp types.Importer) (*types.Package, []*ast.File, *types.Info) {
+		f, err := parser.ParseFile(fset, path+".go", src, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		info := &types.Info{
+			Types:        make(map[ast.Expr]types.TypeAndValue),
+			Defs:         make(map[*ast.Ident]types.Object),
+			Uses:         make(map[*ast.Ident]types.Object),
+			Implicits:    make(map[ast.Node]types.Object),
+			Instances:    make(map[*ast.Ident]types.Instance),
+			Scopes:       make(map[ast.Node]*types.Scope),
+			Selections:   make(map[*ast.SelectorExpr]*types.Selection),
+			FileVersions: make(map[*ast.File]string),
+		}
+		conf := types.Config{Importer: imp}
+		pkg, err := conf.Check(path, fset, []*ast.File{f}, info)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pkg, []*ast.File{f}, info
+	}
+
+	prog := ssa.NewProgram(fset, ssa.BuilderMode(0))
+	qpkg, files, info := check("q", incrementalQ, nil)
+	prog.CreatePackage(qpkg, files, info, true)
+	imp := importerFunc(func(path string) (*types.Package, error) { return qpkg, nil })
+	ppkg, files, info := check("p", incrementalP1, imp)
+	p := prog.CreatePackage(ppkg, files, info, true)
+	prog.Build()
+
+	// compare checks that the graph of inc is the one computed from scratch.
+	compare := func(inc *Incremental, funcs map[*ssa.Function]bool) {
+		t.Helper()
+		got := callGraphStr(inc.Graph())
+		want := callGraphStr(CallGraph(funcs, nil))
+		sort.Strings(got)
+		sort.Strings(want)
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("incremental call graph differs (-want +got):\n%s", diff)
+		}
+	}
+
+	before := ssautil.AllFunctions(prog)
+	inc := NewIncremental(before)
+	compare(inc, before)
+	if got, want := callees(inc, "Call"), []string{"A.F"}; !cmp.Equal(got, want) {
+		t.Errorf("callees of q.Call = %v, want %v", got, want)
+	}
+
+	ppkg, files, info = check("p", incrementalP2, imp)
+	pkgs, err := prog.UpdatePackages([]ssa.PackageUpdate{{Old: p, Pkg: ppkg, Files: files, Info: info}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkgs[0].Build()
+
+	after := ssautil.AllFunctions(prog)
+	changed := make(map[*ssa.Function]bool)
+	for f := range before {
+		if !after[f] {
+			changed[f] = false
+		}
+	}
+	for f := range after {
+		if !before[f] {
+			changed[f] = true
+		}
+	}
+	inc.Update(changed)
+	compare(inc, after)
+
+	// The unchanged call site of q.Call resolves to the new callee.
+	if got, want := callees(inc, "Call"), []string{"C.F"}; !cmp.Equal(got, want) {
+		t.Errorf("callees of q.Call = %v, want %v", got, want)
+	}
+
+	// Nodes have unique IDs.
+	ids := make(map[int]bool)
+	for _, n := range inc.Graph().Nodes {
+		if ids[n.ID] {
+			t.Errorf("duplicate node ID %d", n.ID)
+		}
+		ids[n.ID] = true
+	}
+}
+
+// callees returns the sorted names of the callees of the function
+// of inc named name.
+func callees(inc *Incremental, name string) []string {
+	var names []string
+	for f, n := range inc.Graph().Nodes {
+		if f.Name() == name {
+			for _, e := range n.Out {
+				names = append(names, funcName(e.Callee.Func))
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) { return f(path) }
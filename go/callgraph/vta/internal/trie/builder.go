@@ -4,6 +4,11 @@
 
 package trie
 
+import (
+	"hash/maphash"
+	"sync"
+)
+
 // Collision functions combine a left and right hand side (lhs and rhs) values
 // the two values are associated with the same key and produces the value that
 // will be stored for the key.
@@ -38,8 +43,24 @@ type Builder struct {
 	// It may be possible to support more types of patricia tries
 	// (e.g. non-hash-consed) by making Builder an interface and abstracting
 	// the mkLeaf and mkBranch functions.
+
+	// shards, if non-nil, replace leaves and branches in a concurrent
+	// Builder; seed selects the shard of a node.
+	shards []shard
+	seed   maphash.Seed
+}
+
+// A shard holds the hash-consing maps for a subset of the nodes of a
+// concurrent Builder.
+type shard struct {
+	mu       sync.Mutex
+	leaves   map[leaf]*leaf
+	branches map[branch]*branch
 }
 
+// numShards is the number of shards of a concurrent Builder.
+const numShards = 64
+
 // NewBuilder creates a new Builder with a unique Scope.
 func NewBuilder() *Builder {
 	s := newScope()
@@ -51,6 +72,29 @@ func NewBuilder() *Builder {
 	}
 }
 
+// NewConcurrentBuilder creates a new Builder with a unique Scope,
+// whose methods, including those of its MutMaps, may be called
+// concurrently, as long as each MutMap is used by one goroutine at a
+// time. Its hash-consing maps are sharded, each shard guarded by a
+// mutex, so it is slower than a Builder created by [NewBuilder] when
+// used by only one goroutine.
+func NewConcurrentBuilder() *Builder {
+	b := &Builder{seed: maphash.MakeSeed()}
+	b.rescopeConcurrent()
+	return b
+}
+
+func (b *Builder) rescopeConcurrent() {
+	s := newScope()
+	b.scope = s
+	b.empty = &empty{s}
+	b.shards = make([]shard, numShards)
+	for i := range b.shards {
+		b.shards[i].leaves = make(map[leaf]*leaf)
+		b.shards[i].branches = make(map[branch]*branch)
+	}
+}
+
 func (b *Builder) Scope() Scope { return b.scope }
 
 // Rescope changes the builder's scope to a new unique Scope.
@@ -60,6 +104,10 @@ func (b *Builder) Scope() Scope { return b.scope }
 //
 // This makes the old internals of the Builder eligible to be GC'ed.
 func (b *Builder) Rescope() {
+	if b.shards != nil {
+		b.rescopeConcurrent()
+		return
+	}
 	s := newScope()
 	b.scope = s
 	b.empty = &empty{s}
@@ -260,6 +308,17 @@ func (b *Builder) create(leaves []*leaf) node {
 
 // mkLeaf returns the hash-consed representative of (k, v) in the current scope.
 func (b *Builder) mkLeaf(k key, v any) *leaf {
+	if b.shards != nil {
+		sh := &b.shards[maphash.Comparable(b.seed, leaf{k, v})%numShards]
+		sh.mu.Lock()
+		defer sh.mu.Unlock()
+		rep, ok := sh.leaves[leaf{k, v}]
+		if !ok {
+			rep = &leaf{k, v}
+			sh.leaves[leaf{k, v}] = rep
+		}
+		return rep
+	}
 	rep, ok := b.leaves[leaf{k, v}]
 	if !ok {
 		rep = &leaf{k, v} // heap-allocated copy
@@ -281,6 +340,18 @@ func (b *Builder) mkBranch(p prefix, bp bitpos, left node, right node) *branch {
 		left:      left,
 		right:     right,
 	}
+	if b.shards != nil {
+		sh := &b.shards[maphash.Comparable(b.seed, br)%numShards]
+		sh.mu.Lock()
+		defer sh.mu.Unlock()
+		rep, ok := sh.branches[br]
+		if !ok {
+			rep = new(branch)
+			*rep = br
+			sh.branches[br] = rep
+		}
+		return rep
+	}
 	rep, ok := b.branches[br]
 	if !ok {
 		rep = new(branch) // heap-allocated copy
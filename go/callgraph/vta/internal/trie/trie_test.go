@@ -9,6 +9,7 @@ package trie
 import (
 	"reflect"
 	"strconv"
+	"sync"
 	"testing"
 )
 
@@ -540,3 +541,40 @@ func TestSharing(t *testing.T) {
 		t.Errorf("r.n.(*branch).left was modified by the Merge operation. was %v now %v", rleftold, rleftnow)
 	}
 }
+
+func TestConcurrentBuilder(t *testing.T) {
+	b := NewConcurrentBuilder()
+	seq := NewBuilder()
+
+	// Each goroutine builds the union of the same sets, in a different
+	// order; the results must be the same hash-consed map.
+	const n = 8
+	results := make([]Map, n)
+	var wg sync.WaitGroup
+	for g := 0; g < n; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			mm := b.MutEmpty()
+			for i := 0; i < 100; i++ {
+				j := (i*7 + g*13) % 100
+				mm.Merge(b.Create(map[uint64]any{uint64(j): j, uint64(j * 31): j * 31}))
+			}
+			results[g] = mm.M
+		}(g)
+	}
+	wg.Wait()
+
+	want := seq.MutEmpty()
+	for j := 0; j < 100; j++ {
+		want.Merge(seq.Create(map[uint64]any{uint64(j): j, uint64(j * 31): j * 31}))
+	}
+	for g, m := range results {
+		if m != results[0] {
+			t.Errorf("result %d = %v is not == result 0 = %v", g, m, results[0])
+		}
+		if !m.DeepEqual(want.M) {
+			t.Errorf("result %d = %v, want %v", g, m, want.M)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"iter"
 	"slices"
 
+	"golang.org/x/tools/go/callgraph/internal/parallel"
 	"golang.org/x/tools/go/callgraph/vta/internal/trie"
 	"golang.org/x/tools/go/ssa"
 
@@ -130,12 +131,17 @@ func (ptm propTypeMap) propTypes(n node) iter.Seq[propType] {
 // graph. The result is a map from nodes to a set of types
 // and functions, stemming from higher-order data flow,
 // reaching the node. `canon` is used for type uniqueness.
-func propagate(graph *vtaGraph, canon *typeutil.Map) propTypeMap {
+//
+// propTypeIds records the unique id of each propType, used as its
+// key in the trie-based type sets. If it is nil, a new map is used;
+// successive calls that share it produce type sets that can be
+// compared with [trie.Map.DeepEqual].
+func propagate(graph *vtaGraph, canon *typeutil.Map, propTypeIds map[propType]uint64) propTypeMap {
 	sccs, idxToSccID := scc(graph)
 
-	// propTypeIds are used to create unique ids for
-	// propType, to be used for trie-based type sets.
-	propTypeIds := make(map[propType]uint64)
+	if propTypeIds == nil {
+		propTypeIds = make(map[propType]uint64)
+	}
 	// Id creation is based on == equality, which works
 	// as types are canonicalized (see getPropType).
 	propTypeId := func(p propType) uint64 {
@@ -146,7 +152,14 @@ func propagate(graph *vtaGraph, canon *typeutil.Map) propTypeMap {
 		propTypeIds[p] = id
 		return id
 	}
+	// With more than one goroutine, the SCCs are processed in
+	// parallel (see propagateParallel), so they share a concurrent
+	// builder.
+	parallelize := parallel.Workers(len(sccs)) > 1
 	builder := trie.NewBuilder()
+	if parallelize {
+		builder = trie.NewConcurrentBuilder()
+	}
 	// Initialize sccToTypes to avoid repeated check
 	// for initialization later.
 	sccToTypes := make([]*trie.MutMap, len(sccs))
@@ -162,16 +175,20 @@ func propagate(graph *vtaGraph, canon *typeutil.Map) propTypeMap {
 		sccToTypes[sccID] = &typeSet
 	}
 
-	for i := len(sccs) - 1; i >= 0; i-- {
-		nextSccs := make(map[int]empty)
-		for _, n := range sccs[i] {
-			for succ := range graph.successors(n) {
-				nextSccs[idxToSccID[succ]] = empty{}
+	if parallelize {
+		propagateParallel(graph, sccs, idxToSccID, sccToTypes)
+	} else {
+		for i := len(sccs) - 1; i >= 0; i-- {
+			nextSccs := make(map[int]empty)
+			for _, n := range sccs[i] {
+				for succ := range graph.successors(n) {
+					nextSccs[idxToSccID[succ]] = empty{}
+				}
+			}
+			// Propagate types to all successor SCCs.
+			for nextScc := range nextSccs {
+				sccToTypes[nextScc].Merge(sccToTypes[i].M)
 			}
-		}
-		// Propagate types to all successor SCCs.
-		for nextScc := range nextSccs {
-			sccToTypes[nextScc].Merge(sccToTypes[i].M)
 		}
 	}
 	nodeToTypes := make(propTypeMap, graph.numNodes())
@@ -184,6 +201,47 @@ func propagate(graph *vtaGraph, canon *typeutil.Map) propTypeMap {
 	return nodeToTypes
 }
 
+// propagateParallel propagates the types of each SCC in sccToTypes,
+// whose builder must be concurrent, to its successors, like the
+// sequential loop of propagate. It partitions the SCCs into levels, by
+// the length of the longest path to each from an SCC without
+// predecessors. Each SCC then pulls the types of its predecessors,
+// which are at lower levels; the SCCs of a level are processed in
+// parallel. Since the type sets are hash-consed, the result does not
+// depend on the order in which the sets are merged.
+func propagateParallel(graph *vtaGraph, sccs [][]idx, idxToSccID []int, sccToTypes []*trie.MutMap) {
+	preds := make([][]int, len(sccs))
+	level := make([]int, len(sccs))
+	var levels [][]int
+	last := make([]int, len(sccs)) // last SCC to record itself as a predecessor, plus one
+	// SCCs are in reverse topological order, so the predecessors
+	// of an SCC are visited before it.
+	for i := len(sccs) - 1; i >= 0; i-- {
+		l := level[i]
+		if l == len(levels) {
+			levels = append(levels, nil)
+		}
+		levels[l] = append(levels[l], i)
+		for _, n := range sccs[i] {
+			for succ := range graph.successors(n) {
+				if s := idxToSccID[succ]; s != i && last[s] != i+1 {
+					last[s] = i + 1
+					preds[s] = append(preds[s], i)
+					level[s] = max(level[s], l+1)
+				}
+			}
+		}
+	}
+	for _, sccIDs := range levels[1:] {
+		parallel.Do(len(sccIDs), func(_, k int) {
+			types := sccToTypes[sccIDs[k]]
+			for _, pred := range preds[sccIDs[k]] {
+				types.Merge(sccToTypes[pred].M)
+			}
+		})
+	}
+}
+
 // hasInitialTypes check if a node can have initial types.
 // Returns true iff `n` is not a panic, recover, nestedPtr*
 // node, nor a node whose type is an interface.
@@ -359,7 +359,7 @@ func TestPropagation(t *testing.T) {
 			},
 		},
 	} {
-		if got := nodeToTypeString(propagate(test.graph, &canon)); !reflect.DeepEqual(got, test.want) {
+		if got := nodeToTypeString(propagate(test.graph, &canon, nil)); !reflect.DeepEqual(got, test.want) {
 			t.Errorf("want %v for graph %v; got %v", test.want, test.name, got)
 		}
 	}
@@ -60,6 +60,7 @@ import (
 	"go/types"
 
 	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/callgraph/internal/parallel"
 	"golang.org/x/tools/go/ssa"
 )
 
@@ -73,12 +74,15 @@ import (
 // CallGraph does not make any assumptions on initial types global variables
 // and function/method inputs can have. CallGraph is then sound, modulo use of
 // reflection and unsafe, if the initial call graph is sound.
+//
+// To maintain the call graph of a program as it is edited, use
+// [NewIncremental] instead.
 func CallGraph(funcs map[*ssa.Function]bool, initial *callgraph.Graph) *callgraph.Graph {
 	callees := makeCalleesFunc(funcs, initial)
 	vtaG, canon := typePropGraph(funcs, callees)
-	types := propagate(vtaG, canon)
+	types := propagate(vtaG, canon, nil)
 
-	c := &constructor{types: types, callees: callees, cache: make(methodCache)}
+	c := &constructor{types: types, callees: callees}
 	return c.construct(funcs)
 }
 
@@ -87,32 +91,44 @@ func CallGraph(funcs map[*ssa.Function]bool, initial *callgraph.Graph) *callgrap
 // VTA type propagation phase.
 type constructor struct {
 	types   propTypeMap
-	cache   methodCache
 	callees calleesFunc
 }
 
+// construct resolves the call sites of funcs in parallel, using a
+// method cache per goroutine, and then adds the edges to the call
+// graph in a deterministic order.
 func (c *constructor) construct(funcs map[*ssa.Function]bool) *callgraph.Graph {
-	cg := &callgraph.Graph{Nodes: make(map[*ssa.Function]*callgraph.Node)}
-	for f, in := range funcs {
-		if in {
-			c.constrct(cg, f)
-		}
+	type siteCallees struct {
+		site    ssa.CallInstruction
+		callees []*ssa.Function
 	}
-	return cg
-}
+	fns := parallel.Funcs(funcs)
+	caches := make([]methodCache, parallel.Workers(len(fns)))
+	for i := range caches {
+		caches[i] = make(methodCache)
+	}
+	sites := make([][]siteCallees, len(fns))
+	parallel.Do(len(fns), func(w, i int) {
+		for _, call := range calls(fns[i]) {
+			sites[i] = append(sites[i], siteCallees{call, c.resolves(call, caches[w])})
+		}
+	})
 
-func (c *constructor) constrct(g *callgraph.Graph, f *ssa.Function) {
-	caller := g.CreateNode(f)
-	for _, call := range calls(f) {
-		for _, c := range c.resolves(call) {
-			callgraph.AddEdge(caller, call, g.CreateNode(c))
+	cg := &callgraph.Graph{Nodes: make(map[*ssa.Function]*callgraph.Node)}
+	for i, f := range fns {
+		caller := cg.CreateNode(f)
+		for _, sc := range sites[i] {
+			for _, g := range sc.callees {
+				callgraph.AddEdge(caller, sc.site, cg.CreateNode(g))
+			}
 		}
 	}
+	return cg
 }
 
 // resolves computes the set of functions to which VTA resolves `c`. The resolved
 // functions are intersected with functions to which `c.initial` resolves `c`.
-func (c *constructor) resolves(call ssa.CallInstruction) []*ssa.Function {
+func (c *constructor) resolves(call ssa.CallInstruction, cache methodCache) []*ssa.Function {
 	cc := call.Common()
 	if cc.StaticCallee() != nil {
 		return []*ssa.Function{cc.StaticCallee()}
@@ -125,7 +141,7 @@ func (c *constructor) resolves(call ssa.CallInstruction) []*ssa.Function {
 
 	// Cover the case of dynamic higher-order and interface calls.
 	var res []*ssa.Function
-	resolved := resolve(call, c.types, c.cache)
+	resolved := resolve(call, c.types, cache)
 	for f := range siteCallees(call, c.callees) {
 		if _, ok := resolved[f]; ok {
 			res = append(res, f)
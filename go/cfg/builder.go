@@ -10,14 +10,25 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
+	"sort"
 )
 
 type builder struct {
 	cfg       *CFG
 	mayReturn func(*ast.CallExpr) bool
+	mayPanic  func(*ast.CallExpr) bool // non-nil => model deferred calls
 	current   *Block
 	lblocks   map[string]*lblock // labeled blocks
 	targets   *targets           // linked stack of branch targets
+	defers    []*ast.DeferStmt   // defer statements, in source order (if mayPanic != nil)
+	exits     []exit             // returning and panicking blocks (if mayPanic != nil)
+}
+
+// An exit records a block that exits the function by returning or
+// panicking, and the position of the ReturnStmt or call.
+type exit struct {
+	block *Block
+	pos   token.Pos
 }
 
 func (b *builder) stmt(_s ast.Stmt) {
@@ -32,16 +43,25 @@ start:
 		*ast.SendStmt,
 		*ast.IncDecStmt,
 		*ast.GoStmt,
-		*ast.DeferStmt,
 		*ast.EmptyStmt,
 		*ast.AssignStmt:
 		// No effect on control flow.
 		b.add(s)
 
+	case *ast.DeferStmt:
+		// No immediate effect on control flow.
+		b.add(s)
+		if b.mayPanic != nil {
+			b.defers = append(b.defers, s)
+		}
+
 	case *ast.ExprStmt:
 		b.add(s)
 		if call, ok := s.X.(*ast.CallExpr); ok && !b.mayReturn(call) {
 			// Calls to panic, os.Exit, etc, never return.
+			if b.mayPanic != nil && b.mayPanic(call) {
+				b.exits = append(b.exits, exit{b.current, call.Pos()})
+			}
 			b.current = b.newBlock(KindUnreachable, s)
 		}
 
@@ -65,6 +85,9 @@ start:
 
 	case *ast.ReturnStmt:
 		b.add(s)
+		if b.mayPanic != nil {
+			b.exits = append(b.exits, exit{b.current, s.Pos()})
+		}
 		b.current = b.newBlock(KindUnreachable, s)
 
 	case *ast.BranchStmt:
@@ -511,3 +534,36 @@ func (b *builder) ifelse(t, f *Block) {
 	b.current.Succs = append(b.current.Succs, t, f)
 	b.current = nil
 }
+
+// deferEdges adds the blocks for deferred calls and the exit of the
+// function, and the edges to them from the exits; see [NewWithDefers].
+func (b *builder) deferEdges() {
+	// Chain the deferred calls in reverse order, leading to the exit.
+	exitBlock := b.newBlock(KindExit, nil)
+	b.cfg.Exit = exitBlock
+	deferBlocks := make([]*Block, len(b.defers))
+	next := exitBlock
+	for i, s := range b.defers {
+		block := b.newBlock(KindDefer, s)
+		block.Nodes = append(block.Nodes, s.Call)
+		block.Succs = append(block.Succs, next)
+		deferBlocks[i] = block
+		next = block
+	}
+
+	// Add an edge from each exit to the deferred call of
+	// the last preceding DeferStmt, or to the exit.
+	var live []*Block
+	for _, x := range b.exits {
+		i := sort.Search(len(b.defers), func(i int) bool { return b.defers[i].Pos() >= x.pos })
+		target := exitBlock
+		if i > 0 {
+			target = deferBlocks[i-1]
+		}
+		x.block.Succs = append(x.block.Succs, target)
+		if x.block.Live {
+			live = append(live, target)
+		}
+	}
+	markLive(live...)
+}
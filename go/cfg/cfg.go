@@ -36,9 +36,11 @@
 // materialized (at the position of the function's closing brace).
 //
 // The CFG does not record conditions associated with conditional branch
-// edges, nor the short-circuit semantics of the && and || operators,
-// nor abnormal control flow caused by panic.  If you need this
-// information, use golang.org/x/tools/go/ssa instead.
+// edges, nor the short-circuit semantics of the && and || operators.
+// By default it does not record the execution of deferred calls, nor
+// abnormal control flow caused by panic; use [NewWithDefers] to
+// approximate them. If you need precise information, use
+// golang.org/x/tools/go/ssa instead.
 package cfg
 
 import (
@@ -54,6 +56,11 @@ import (
 // The entry point is Blocks[0]; there may be multiple return blocks.
 type CFG struct {
 	Blocks []*Block // block[0] is entry; order otherwise undefined
+
+	// Exit is the block that represents the exit of the function,
+	// after the execution of its deferred calls. It is non-nil
+	// only for a CFG built by [NewWithDefers].
+	Exit *Block
 }
 
 // A Block represents a basic block: a list of statements and
@@ -103,6 +110,8 @@ const (
 	KindSwitchCaseBody  // body of CaseClause
 	KindSwitchDone      // block after {Type.}SwitchStmt
 	KindSwitchNextCase  // secondary expression of a multi-expression CaseClause
+	KindDefer           // execution of the call of DeferStmt (NewWithDefers only)
+	KindExit            // exit of the function, Stmt=nil (NewWithDefers only)
 )
 
 func (kind BlockKind) String() string {
@@ -127,6 +136,8 @@ func (kind BlockKind) String() string {
 		KindSwitchCaseBody:  "SwitchCaseBody",
 		KindSwitchDone:      "SwitchDone",
 		KindSwitchNextCase:  "SwitchNextCase",
+		KindDefer:           "Defer",
+		KindExit:            "Exit",
 	}[kind]
 }
 
@@ -139,16 +150,80 @@ func (kind BlockKind) String() string {
 // following such calls.  The builder calls mayReturn only for a
 // CallExpr beneath an ExprStmt.
 func New(body *ast.BlockStmt, mayReturn func(*ast.CallExpr) bool) *CFG {
+	return newCFG(body, mayReturn, nil)
+}
+
+// NewWithDefers is like [New], but the resulting CFG also models the
+// execution of the function's deferred calls when it returns or panics.
+//
+// Each DeferStmt in the body gives rise to a block of kind KindDefer
+// whose sole node is the deferred call. These blocks are chained in
+// reverse order of their DeferStmts, the last one leading to
+// CFG.Exit, a block of kind KindExit that represents the exit of the
+// function. Each block that ends in a ReturnStmt, or in a call that
+// does not return but panics, has an edge to the KindDefer block of
+// the last DeferStmt that precedes it in the source, or to CFG.Exit
+// if there is none. A consumer can thus distinguish these edges from
+// ordinary ones by the Kind of their target.
+//
+// This is an approximation: a deferred call is modeled as executed
+// on an exit if its DeferStmt precedes the exit in the source, though
+// the DeferStmt may not have been executed (for example, if it is
+// within an if statement), or may have been executed repeatedly (in
+// a loop). Calls that may return are not assumed to panic.
+//
+// The builder calls mayPanic to determine whether a call that does
+// not return (according to mayReturn) panics, as do calls to panic,
+// and so executes the deferred calls, as opposed to terminating the
+// process, as do calls to os.Exit and log.Fatal. If mayPanic is nil,
+// only calls to a function named panic are assumed to panic.
+func NewWithDefers(body *ast.BlockStmt, mayReturn, mayPanic func(*ast.CallExpr) bool) *CFG {
+	if mayPanic == nil {
+		mayPanic = func(call *ast.CallExpr) bool {
+			id, ok := ast.Unparen(call.Fun).(*ast.Ident)
+			return ok && id.Name == "panic"
+		}
+	}
+	return newCFG(body, mayReturn, mayPanic)
+}
+
+// newCFG builds a CFG. If mayPanic is non-nil,
+// it also models the execution of deferred calls.
+func newCFG(body *ast.BlockStmt, mayReturn, mayPanic func(*ast.CallExpr) bool) *CFG {
 	b := builder{
 		mayReturn: mayReturn,
+		mayPanic:  mayPanic,
 		cfg:       new(CFG),
 	}
 	b.current = b.newBlock(KindBody, body)
 	b.stmt(body)
 
-	// Compute liveness (reachability from entry point), breadth-first.
-	q := make([]*Block, 0, len(b.cfg.Blocks))
-	q = append(q, b.cfg.Blocks[0]) // entry point
+	// Compute liveness (reachability from entry point).
+	markLive(b.cfg.Blocks[0]) // entry point
+
+	// Does control fall off the end of the function's body?
+	// Make implicit return explicit.
+	if b.current != nil && b.current.Live {
+		ret := &ast.ReturnStmt{
+			Return: body.End() - 1,
+		}
+		b.add(ret)
+		if mayPanic != nil {
+			b.exits = append(b.exits, exit{b.current, ret.Pos()})
+		}
+	}
+
+	if mayPanic != nil {
+		b.deferEdges()
+	}
+
+	return b.cfg
+}
+
+// markLive marks as live each block reachable from the specified
+// ones, breadth-first. It does not visit the successors of blocks
+// that are already live.
+func markLive(q ...*Block) {
 	for len(q) > 0 {
 		b := q[len(q)-1]
 		q = q[:len(q)-1]
@@ -158,16 +233,6 @@ func New(body *ast.BlockStmt, mayReturn func(*ast.CallExpr) bool) *CFG {
 			q = append(q, b.Succs...)
 		}
 	}
-
-	// Does control fall off the end of the function's body?
-	// Make implicit return explicit.
-	if b.current != nil && b.current.Live {
-		b.add(&ast.ReturnStmt{
-			Return: body.End() - 1,
-		})
-	}
-
-	return b.cfg
 }
 
 func (b *Block) String() string {
@@ -233,9 +298,14 @@ func (g *CFG) Dot(fset *token.FileSet) string {
 		}
 
 		// node and edges
+		// (Edges to deferred calls and the exit are dashed.)
 		fmt.Fprintf(&buf, "  n%d [label=%q];\n", b.Index, &text)
 		for _, succ := range b.Succs {
-			fmt.Fprintf(&buf, "  n%d -> n%d;\n", b.Index, succ.Index)
+			style := ""
+			if (succ.Kind == KindDefer || succ.Kind == KindExit) && b.Kind != KindDefer {
+				style = " [style=dashed]"
+			}
+			fmt.Fprintf(&buf, "  n%d -> n%d%s;\n", b.Index, succ.Index, style)
 		}
 	}
 	buf.WriteString("}\n")
@@ -11,6 +11,7 @@ import (
 	"go/format"
 	"go/parser"
 	"go/token"
+	"strings"
 	"testing"
 
 	"golang.org/x/tools/go/cfg"
@@ -164,6 +165,66 @@ func TestDeadCode(t *testing.T) {
 	}
 }
 
+func TestDefers(t *testing.T) {
+	const src = `package p
+
+func f(x, y bool) {
+	if x {
+		return
+	}
+	defer unlock()
+	if y {
+		return
+	}
+	defer cleanup()
+	if x {
+		panic("oops")
+	}
+	log.Fatal()
+}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, parser.Mode(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := cfg.NewWithDefers(f.Decls[0].(*ast.FuncDecl).Body, mayReturn, nil)
+	if g.Exit == nil || g.Exit.Kind != cfg.KindExit || !g.Exit.Live {
+		t.Fatalf("invalid exit block %v", g.Exit)
+	}
+
+	// describe returns the last node of a block, or its kind.
+	describe := func(b *cfg.Block) string {
+		if len(b.Nodes) == 0 {
+			return b.Kind.String()
+		}
+		return fmt.Sprintf("%s %s", b.Kind, formatNode(fset, b.Nodes[len(b.Nodes)-1]))
+	}
+	var got []string
+	for _, b := range g.Blocks {
+		for _, succ := range b.Succs {
+			if succ.Kind == cfg.KindDefer || succ.Kind == cfg.KindExit {
+				got = append(got, fmt.Sprintf("%s -> %s", describe(b), describe(succ)))
+			}
+		}
+	}
+	want := []string{
+		"IfThen return -> Exit",
+		"IfThen return -> Defer unlock()",
+		`IfThen panic("oops") -> Defer cleanup()`,
+		"Defer unlock() -> Exit",
+		"Defer cleanup() -> Defer unlock()",
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("got edges:\n%s\nwant:\n%s\ncontrol flow graph:\n%s",
+			strings.Join(got, "\n"), strings.Join(want, "\n"), g.Format(fset))
+	}
+
+	if dot := g.Dot(fset); !strings.Contains(dot, "[style=dashed]") {
+		t.Errorf("Dot output lacks dashed edges:\n%s", dot)
+	}
+}
+
 // TestSmoke runs the CFG builder on every FuncDecl in the standard
 // library and x/tools. (This is all well-typed code, but it gives
 // some coverage.)
@@ -185,29 +246,11 @@ func TestSmoke(t *testing.T) {
 		for _, file := range pkg.Syntax {
 			for _, decl := range file.Decls {
 				if decl, ok := decl.(*ast.FuncDecl); ok && decl.Body != nil {
-					g := cfg.New(decl.Body, mayReturn)
-
-					// Run a few quick sanity checks.
-					failed := false
-					for i, b := range g.Blocks {
-						errorf := func(format string, args ...any) {
-							if !failed {
-								t.Errorf("%s\n%s", pkg.Fset.Position(decl.Pos()), g.Format(pkg.Fset))
-								failed = true
-							}
-							msg := fmt.Sprintf(format, args...)
-							t.Errorf("block %d: %s", i, msg)
-						}
-
-						if b.Kind == cfg.KindInvalid {
-							errorf("invalid Block.Kind %v", b.Kind)
-						}
-						if b.Stmt == nil && b.Kind != cfg.KindLabel {
-							errorf("nil Block.Stmt (Kind=%v)", b.Kind)
-						}
-						if i != int(b.Index) {
-							errorf("invalid Block.Index")
-						}
+					for _, g := range []*cfg.CFG{
+						cfg.New(decl.Body, mayReturn),
+						cfg.NewWithDefers(decl.Body, mayReturn, nil),
+					} {
+						smokeCheck(t, pkg.Fset, decl, g)
 					}
 				}
 			}
@@ -215,6 +258,31 @@ func TestSmoke(t *testing.T) {
 	}
 }
 
+// smokeCheck runs a few quick sanity checks on a CFG.
+func smokeCheck(t *testing.T, fset *token.FileSet, decl *ast.FuncDecl, g *cfg.CFG) {
+	failed := false
+	for i, b := range g.Blocks {
+		errorf := func(format string, args ...any) {
+			if !failed {
+				t.Errorf("%s\n%s", fset.Position(decl.Pos()), g.Format(fset))
+				failed = true
+			}
+			msg := fmt.Sprintf(format, args...)
+			t.Errorf("block %d: %s", i, msg)
+		}
+
+		if b.Kind == cfg.KindInvalid {
+			errorf("invalid Block.Kind %v", b.Kind)
+		}
+		if b.Stmt == nil && b.Kind != cfg.KindLabel && b.Kind != cfg.KindExit {
+			errorf("nil Block.Stmt (Kind=%v)", b.Kind)
+		}
+		if i != int(b.Index) {
+			errorf("invalid Block.Index")
+		}
+	}
+}
+
 // A trivial mayReturn predicate that looks only at syntax, not types.
 func mayReturn(call *ast.CallExpr) bool {
 	switch fun := call.Fun.(type) {
@@ -7,7 +7,11 @@
 // The cfg command prints the control-flow graph of the first function
 // or method whose name matches 'funcname' in the specified package.
 //
-// Usage: cfg package funcname
+// Usage: cfg [-defers] package funcname
+//
+// The -defers flag causes the graph to model the execution of deferred
+// calls on return and panic (see [cfg.NewWithDefers]); the edges to
+// them are dashed.
 //
 // Example:
 //
@@ -26,6 +30,8 @@ import (
 	"golang.org/x/tools/go/packages"
 )
 
+var defers = flag.Bool("defers", false, "model the execution of deferred calls")
+
 func main() {
 	flag.Parse()
 	if len(flag.Args()) != 2 {
@@ -45,6 +51,9 @@ func main() {
 				if decl, ok := decl.(*ast.FuncDecl); ok {
 					if decl.Name.Name == funcname {
 						g := cfg.New(decl.Body, mayReturn)
+						if *defers {
+							g = cfg.NewWithDefers(decl.Body, mayReturn, nil)
+						}
 						fmt.Println(g.Dot(pkg.Fset))
 						os.Exit(0)
 					}
@@ -7,6 +7,7 @@
 package gcexportdata_test
 
 import (
+	"bytes"
 	"fmt"
 	"go/ast"
 	"go/parser"
@@ -126,6 +127,65 @@ const serverError rpc.ServerError = ""
 	// const serverError net/rpc.ServerError = "" // myrpc.go:6:7
 }
 
+// ExampleWrite demonstrates usage of Write to fabricate export data for
+// a stub dependency, constructed in memory, without invoking the
+// compiler, and then to type-check a package that imports it.
+func ExampleWrite() {
+	// Construct the stub package:
+	//
+	//	package stub
+	//	type Client struct{}
+	//	func (*Client) Do() error
+	//	func New() *Client
+	stub := types.NewPackage("example.com/stub", "stub")
+	client := types.NewNamed(types.NewTypeName(token.NoPos, stub, "Client", nil), types.NewStruct(nil, nil), nil)
+	stub.Scope().Insert(client.Obj())
+	errorResult := types.NewTuple(types.NewParam(token.NoPos, stub, "", types.Universe.Lookup("error").Type()))
+	recv := types.NewParam(token.NoPos, stub, "", types.NewPointer(client))
+	client.AddMethod(types.NewFunc(token.NoPos, stub, "Do", types.NewSignatureType(recv, nil, nil, nil, errorResult, false)))
+	clientResult := types.NewTuple(types.NewParam(token.NoPos, stub, "", types.NewPointer(client)))
+	stub.Scope().Insert(types.NewFunc(token.NoPos, stub, "New", types.NewSignatureType(nil, nil, nil, nil, clientResult, false)))
+	stub.MarkComplete()
+
+	// Encode it.
+	var buf bytes.Buffer
+	if err := gcexportdata.Write(&buf, nil, stub); err != nil {
+		log.Fatal(err)
+	}
+
+	// Type-check a package that imports it.
+	const src = `package main
+
+import "example.com/stub"
+
+var err = stub.New().Do()
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "main.go", src, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	packages := make(map[string]*types.Package)
+	conf := types.Config{
+		Importer: importerFunc(func(path string) (*types.Package, error) {
+			return gcexportdata.Read(bytes.NewReader(buf.Bytes()), fset, packages, path)
+		}),
+	}
+	pkg, err := conf.Check("main", fset, []*ast.File{f}, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(pkg.Scope().Lookup("err"))
+
+	// Output:
+	//
+	// var main.err error
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) { return f(path) }
+
 func slashify(posn token.Position) token.Position {
 	posn.Filename = filepath.ToSlash(posn.Filename) // for MS Windows portability
 	return posn
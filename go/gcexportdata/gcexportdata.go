@@ -202,6 +202,23 @@ func Read(in io.Reader, fset *token.FileSet, imports map[string]*types.Package,
 
 // Write writes encoded type information for the specified package to out.
 // The FileSet provides file position information for named objects.
+//
+// The package need not have been produced by the type checker or by
+// [Read]: it may be constructed, or modified, in memory using the
+// constructor functions of go/types such as [types.NewPackage],
+// [types.NewTypeName], [types.NewNamed], and [types.NewFunc], for
+// example to fabricate stub dependencies for analysis drivers or
+// tests without invoking the compiler. Objects of such a package
+// may lack positions (token.NoPos), and the FileSet may be nil.
+//
+// Only the exported package-level objects of pkg, and the objects and
+// types they refer to, are recorded. The packages of referenced
+// objects need not be listed in pkg.Imports; Read creates (incomplete)
+// packages for them as needed.
+//
+// Write reports an error if the package is malformed, for example if
+// it contains a constant with no value, or its scope contains an
+// object of a different package.
 func Write(out io.Writer, fset *token.FileSet, pkg *types.Package) error {
 	if _, err := io.WriteString(out, "i"); err != nil {
 		return err
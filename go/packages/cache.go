@@ -0,0 +1,291 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package packages
+
+// This file defines the on-disk cache of the responses of the go list
+// driver (see Config.CacheDir).
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"golang.org/x/tools/internal/gocommand"
+	"golang.org/x/tools/internal/packagesinternal"
+)
+
+// cacheVersion is the version of the cache format, which is part of
+// each cache key.
+const cacheVersion = 2
+
+// modTimeCutoff is the minimum age of the files of a cached response.
+// A file modified more recently may have changed during the query
+// without its modification time changing again, so the response is
+// not cached.
+const modTimeCutoff = 2 * time.Second
+
+// A cacheEntry is the content of a cache file.
+type cacheEntry struct {
+	Files    []cacheFile            // files and directories whose change invalidates the entry
+	Response *DriverResponse        // the cached response
+	Extra    map[string]*cacheExtra // fields omitted by Package's JSON form, by package ID
+}
+
+// A cacheFile records the state of a file or directory when its
+// response was cached.
+type cacheFile struct {
+	Path    string
+	Size    int64
+	ModTime time.Time
+}
+
+// cacheExtra holds the fields of a Package that are set by the go list
+// driver but not encoded by Package.MarshalJSON.
+type cacheExtra struct {
+	Dir        string                           `json:",omitempty"`
+	Target     string                           `json:",omitempty"`
+	ForTest    string                           `json:",omitempty"`
+	Module     *Module                          `json:",omitempty"`
+	DepsErrors []*packagesinternal.PackageError `json:",omitempty"`
+	Export     string                           `json:",omitempty"` // cached copy of ExportFile
+}
+
+// cacheKey returns the name of the cache file for a query of the go
+// list driver. It depends on the patterns, the configuration, the go
+// command and its effective environment, and the contents of the
+// go.mod, go.sum, go.work, and go.work.sum files of cfg.Dir and its
+// parent directories.
+func cacheKey(cfg *Config, runner *gocommand.Runner, patterns []string) (string, error) {
+	dir, err := filepath.Abs(cfg.Dir)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "version %d\n", cacheVersion)
+	fmt.Fprintf(h, "mode %d\n", cfg.Mode)
+	fmt.Fprintf(h, "tests %t\n", cfg.Tests)
+	fmt.Fprintf(h, "dir %q\n", dir)
+	for _, flag := range cfg.BuildFlags {
+		fmt.Fprintf(h, "flag %q\n", flag)
+	}
+	env := cfg.Env
+	if env == nil {
+		env = os.Environ()
+	}
+	for _, kv := range env {
+		fmt.Fprintf(h, "env %q\n", kv)
+	}
+	for _, pattern := range patterns {
+		fmt.Fprintf(h, "pattern %q\n", pattern)
+	}
+
+	// Ask the go command that would run the query for its effective
+	// configuration, which also reflects go.env and GOENV files,
+	// and for its GOROOT, as a different go command may report
+	// different packages.
+	inv := gocommand.Invocation{
+		Verb:       "env",
+		Args:       []string{"-json"},
+		CleanEnv:   cfg.Env != nil,
+		Env:        cfg.Env,
+		Logf:       cfg.Logf,
+		WorkingDir: cfg.Dir,
+	}
+	stdout, err := runner.Run(cfg.Context, inv)
+	if err != nil {
+		return "", err
+	}
+	var goenv map[string]string
+	if err := json.Unmarshal(stdout.Bytes(), &goenv); err != nil {
+		return "", fmt.Errorf("decoding go env: %v", err)
+	}
+	for _, name := range slices.Sorted(maps.Keys(goenv)) {
+		if name == "GOGCCFLAGS" {
+			continue // contains the name of a temporary directory
+		}
+		fmt.Fprintf(h, "goenv %s=%q\n", name, goenv[name])
+	}
+	gocmd := filepath.Join(goenv["GOROOT"], "bin", "go"+goenv["GOEXE"])
+	info, err := os.Stat(gocmd)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(h, "go %q %d %d\n", gocmd, info.Size(), info.ModTime().UnixNano())
+
+	for d := dir; ; d = filepath.Dir(d) {
+		for _, name := range []string{"go.mod", "go.sum", "go.work", "go.work.sum"} {
+			filename := filepath.Join(d, name)
+			data, err := os.ReadFile(filename)
+			if err == nil {
+				fmt.Fprintf(h, "file %q %x\n", filename, sha256.Sum256(data))
+			} else if !os.IsNotExist(err) {
+				return "", err
+			}
+		}
+		if filepath.Dir(d) == d {
+			break // root
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// readCache returns the cached response for the key, if any, whose
+// files and directories are unchanged.
+func readCache(cfg *Config, key string) (*DriverResponse, bool) {
+	data, err := os.ReadFile(filepath.Join(cfg.CacheDir, key))
+	if err != nil {
+		return nil, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Response == nil {
+		cfg.Logf("invalid packages cache entry %s: %v", key, err)
+		return nil, false
+	}
+	for _, f := range entry.Files {
+		info, err := os.Stat(f.Path)
+		if err != nil || info.Size() != f.Size || !info.ModTime().Equal(f.ModTime) {
+			cfg.Logf("packages cache entry %s is stale: %s changed", key, f.Path)
+			return nil, false
+		}
+	}
+	for _, pkg := range entry.Response.Packages {
+		if extra := entry.Extra[pkg.ID]; extra != nil {
+			pkg.Dir = extra.Dir
+			pkg.Target = extra.Target
+			pkg.ForTest = extra.ForTest
+			pkg.Module = extra.Module
+			pkg.depsErrors = extra.DepsErrors
+			if extra.Export != "" {
+				pkg.ExportFile = extra.Export
+			}
+		}
+	}
+	return entry.Response, true
+}
+
+// writeCache caches the response for the key, along with the state
+// of the files and directories of its packages and a copy of their
+// export data, unless some package has errors, which may be
+// transient, or some file was modified since shortly before the
+// query started.
+func writeCache(cfg *Config, key string, response *DriverResponse, start time.Time) error {
+	entry := cacheEntry{
+		Response: response,
+		Extra:    make(map[string]*cacheExtra),
+	}
+	seen := make(map[string]bool)
+	add := func(filename string) error {
+		if filename == "" || seen[filename] {
+			return nil
+		}
+		seen[filename] = true
+		info, err := os.Stat(filename)
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(start.Add(-modTimeCutoff)) {
+			return fmt.Errorf("%s was modified recently", filename)
+		}
+		entry.Files = append(entry.Files, cacheFile{filename, info.Size(), info.ModTime()})
+		return nil
+	}
+	for _, pkg := range response.Packages {
+		if len(pkg.Errors) > 0 {
+			return nil
+		}
+		// A change to the set of files of a directory
+		// changes the directory's modification time.
+		files := []string{pkg.Dir}
+		for _, list := range [][]string{pkg.GoFiles, pkg.CompiledGoFiles, pkg.OtherFiles, pkg.IgnoredFiles, pkg.EmbedFiles} {
+			for _, filename := range list {
+				files = append(files, filename, filepath.Dir(filename))
+			}
+		}
+		if pkg.Module != nil {
+			files = append(files, pkg.Module.GoMod)
+		}
+		for _, filename := range files {
+			if err := add(filename); err != nil {
+				return err
+			}
+		}
+		extra := &cacheExtra{
+			Dir:        pkg.Dir,
+			Target:     pkg.Target,
+			ForTest:    pkg.ForTest,
+			Module:     pkg.Module,
+			DepsErrors: pkg.depsErrors,
+		}
+		// Copy the export data out of the go build cache,
+		// which may be trimmed before the entry is used.
+		if pkg.ExportFile != "" {
+			export, err := copyExportFile(cfg.CacheDir, pkg.ExportFile)
+			if err != nil {
+				return err
+			}
+			info, err := os.Stat(export)
+			if err != nil {
+				return err
+			}
+			entry.Files = append(entry.Files, cacheFile{export, info.Size(), info.ModTime()})
+			extra.Export = export
+		}
+		entry.Extra[pkg.ID] = extra
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(cfg.CacheDir, key, data)
+}
+
+// copyExportFile copies the named export data file into the export
+// subdirectory of the cache directory, under a name derived from its
+// content, and returns the name of the copy.
+func copyExportFile(cacheDir, filename string) (string, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(cacheDir, "export")
+	name := fmt.Sprintf("%x", sha256.Sum256(data))
+	export := filepath.Join(dir, name)
+	if _, err := os.Stat(export); err == nil {
+		return export, nil // already cached
+	}
+	if err := writeFileAtomic(dir, name, data); err != nil {
+		return "", err
+	}
+	return export, nil
+}
+
+// writeFileAtomic writes the named file of dir atomically,
+// as other processes may read it.
+func writeFileAtomic(dir, name string, data []byte) error {
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, name+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), filepath.Join(dir, name))
+}
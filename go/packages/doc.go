@@ -36,6 +36,14 @@ to ensure that x is not interpreted as a query operator if it contains '='.
 All other query operators are reserved for future use and currently
 cause Load to report an error.
 
+A pattern of the form "path@version", such as
+"golang.org/x/text/language@v0.14.0", names the packages matching
+path in the specified version of their module, which is resolved as
+by 'go get', even if no go.mod file in the working directory requires
+that module. When using the go command, Load resolves such patterns in a
+temporary module; they may be combined with other import path patterns,
+but not with file system paths or "file=" queries.
+
 The Package struct provides basic information about the package, including
 
   - ID, a unique identifier for the package in the returned set;
@@ -100,6 +108,15 @@ is written to the driver's standard input. The driver must write a
 JSON-encoded [DriverResponse] message to its standard output. (This
 message differs from the JSON schema produced by 'go list'.)
 
+In version 2 of the protocol, the request states the version it
+supports, and the driver may report its own version and the optional
+features it supports in the first message of its response, which may
+be followed by further messages that supply more packages. A driver
+that advertises the "watch" capability may also be run by [Watch], to
+report changes to the build graph as [DriverInvalidation] messages,
+so that long-running clients need not reload all packages after each
+change.
+
 The value of the PWD environment variable seen by the driver process
 is the preferred name of its working directory. (The working directory
 may have other aliases due to symbolic links; see the comment on the
@@ -223,6 +240,8 @@ Questions & Tasks
     myapp -query_flag="--cpu=amd64" -query_flag="--os=darwin"
   However, this approach is low-level, unwieldy, and non-portable.
   GOOS and GOARCH seem important enough to warrant a dedicated option.
+  LoadPlatforms is a start: it sets them in the environment of each
+  query, which the go command, but not every driver, honors.
 
 - How should we handle partial failures such as a mixture of good and
   malformed patterns, existing and non-existent packages, successful and
@@ -0,0 +1,118 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package packages
+
+// This file defines the structured forms of common errors
+// (see Error.Detail).
+
+import (
+	"go/ast"
+	"go/types"
+	"regexp"
+	"strconv"
+
+	"golang.org/x/tools/internal/typesinternal"
+)
+
+// An ErrorDetail describes an [Error] in structured form.
+// It is one of [*ImportError], [*MissingModuleError], or
+// [*BuildConstraintError].
+type ErrorDetail interface {
+	errorDetail()
+}
+
+// An ImportError reports an import path that does not denote a
+// loadable package, for example because it does not exist, is not
+// importable by the importing package, or forms a cycle.
+type ImportError struct {
+	Path string // the import path
+}
+
+// A MissingModuleError reports a package that is not provided by any
+// module in the build list.
+type MissingModuleError struct {
+	Path string // the package path
+}
+
+// A BuildConstraintError reports a package directory whose Go files
+// are all excluded by build constraints.
+type BuildConstraintError struct {
+	Dir string // the package directory
+}
+
+func (*ImportError) errorDetail()          {}
+func (*MissingModuleError) errorDetail()   {}
+func (*BuildConstraintError) errorDetail() {}
+
+// listErrorPatterns match the messages of the go command's errors
+// whose details are known. The first subexpression of each is the
+// path or directory.
+var listErrorPatterns = []struct {
+	re     *regexp.Regexp
+	detail func(string) ErrorDetail
+}{
+	{
+		regexp.MustCompile(`no required module provides package (\S+?);`),
+		func(path string) ErrorDetail { return &MissingModuleError{Path: path} },
+	},
+	{
+		regexp.MustCompile(`cannot find module providing package (\S+?)(:|$)`),
+		func(path string) ErrorDetail { return &MissingModuleError{Path: path} },
+	},
+	{
+		regexp.MustCompile(`build constraints exclude all Go files in (.+)$`),
+		func(dir string) ErrorDetail { return &BuildConstraintError{Dir: dir} },
+	},
+	{
+		regexp.MustCompile(`package (\S+) is not in std`),
+		func(path string) ErrorDetail { return &ImportError{Path: path} },
+	},
+	{
+		regexp.MustCompile(`cannot find package "([^"]+)"`),
+		func(path string) ErrorDetail { return &ImportError{Path: path} },
+	},
+	{
+		regexp.MustCompile(`use of internal package (\S+) not allowed`),
+		func(path string) ErrorDetail { return &ImportError{Path: path} },
+	},
+	{
+		regexp.MustCompile(`import "([^"]+)" is a program, not an importable package`),
+		func(path string) ErrorDetail { return &ImportError{Path: path} },
+	},
+}
+
+// setListErrorDetails sets the Detail of each of the package's
+// ListErrors whose message is that of a known error of the go command.
+func setListErrorDetails(pkg *Package) {
+	for i := range pkg.Errors {
+		err := &pkg.Errors[i]
+		if err.Kind != ListError || err.Detail != nil {
+			continue
+		}
+		for _, p := range listErrorPatterns {
+			if m := p.re.FindStringSubmatch(err.Msg); m != nil {
+				err.Detail = p.detail(m[1])
+				break
+			}
+		}
+	}
+}
+
+// typeErrorDetail returns the detail of a type error in the files,
+// or nil if it is not known.
+func typeErrorDetail(files []*ast.File, err types.Error) ErrorDetail {
+	if code, _, _, ok := typesinternal.ErrorCodeStartEnd(err); ok && code == typesinternal.BrokenImport {
+		for _, f := range files {
+			for _, spec := range f.Imports {
+				if spec.Pos() <= err.Pos && err.Pos < spec.End() {
+					if path, err := strconv.Unquote(spec.Path.Value); err == nil {
+						return &ImportError{Path: path}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -10,13 +10,28 @@ package packages
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"slices"
 	"strings"
 )
 
+// driverProtocol is the latest version of the driver protocol
+// understood by this package.
+//
+// Version 2 adds DriverRequest.Protocol and Watch, and
+// DriverResponse.Protocol and Capabilities, and permits a driver to
+// stream its response as a sequence of messages, and to report
+// invalidations (see [Watch]).
+const driverProtocol = 2
+
+// WatchCapability is the capability advertised by a driver that
+// supports Watch requests.
+const WatchCapability = "watch"
+
 // DriverRequest defines the schema of a request for package metadata
 // from an external driver program. The JSON-encoded DriverRequest
 // message is provided to the driver program's standard input. The
@@ -38,6 +53,16 @@ type DriverRequest struct {
 	// Overlay maps file paths (relative to the driver's working directory)
 	// to the contents of overlay files (see Config.Overlay).
 	Overlay map[string][]byte `json:"overlay"`
+
+	// Protocol is the latest version of the driver protocol
+	// understood by the client. It is zero for version 1.
+	Protocol int `json:"protocol,omitempty"`
+
+	// Watch requests that, instead of reporting the packages that
+	// match the patterns, the driver keep running and report each
+	// change to the build graph that may affect them (see [Watch]).
+	// It is set only for drivers that advertise [WatchCapability].
+	Watch bool `json:"watch,omitempty"`
 }
 
 // DriverResponse defines the schema of a response from an external
@@ -76,17 +101,80 @@ type DriverResponse struct {
 	// (e.g. the go command on the PATH) when selecting .go files.
 	// Zero means unknown.
 	GoVersion int
+
+	// Protocol is the version of the driver protocol used by the
+	// driver, which must not exceed that of the request. Zero
+	// means version 1.
+	//
+	// A driver using version 2 or later may stream its response
+	// as a sequence of DriverResponse messages; the Roots and
+	// Packages of each message are appended to those of the first,
+	// whose other fields apply to the whole response.
+	Protocol int `json:",omitempty"`
+
+	// Capabilities lists the optional features of the protocol
+	// supported by the driver, such as [WatchCapability].
+	// It is reported by drivers using version 2 or later.
+	Capabilities []string `json:",omitempty"`
+}
+
+// DriverInvalidation defines the schema of a message from an external
+// driver program in response to a Watch request, reporting a change
+// to the build graph.
+//
+// See [Watch] for details.
+type DriverInvalidation struct {
+	// IDs lists the packages whose metadata may have changed.
+	IDs []string `json:",omitempty"`
+
+	// All reports that any package may have changed,
+	// for example because of a change to the build configuration.
+	All bool `json:",omitempty"`
 }
 
 // driver is the type for functions that query the build system for the
 // packages named by the patterns.
 type driver func(cfg *Config, patterns []string) (*DriverResponse, error)
 
-// findExternalDriver returns the file path of a tool that supplies
+// findExternalDriver returns the driver function that runs the tool
+// that supplies the build system package structure, or nil if not found.
+func findExternalDriver(cfg *Config) driver {
+	tool := findExternalTool(cfg)
+	if tool == "" {
+		return nil
+	}
+	return func(cfg *Config, patterns []string) (*DriverResponse, error) {
+		cmd, stderr, err := driverCommand(cfg, tool, patterns, DriverRequest{})
+		if err != nil {
+			return nil, err
+		}
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			return nil, err
+		}
+		if err := cmd.Start(); err != nil {
+			return nil, fmt.Errorf("%v: %v", tool, err)
+		}
+		response, decodeErr := decodeDriverResponse(stdout)
+		io.Copy(io.Discard, stdout) // drain the pipe before Wait
+		if err := cmd.Wait(); err != nil {
+			return nil, fmt.Errorf("%v: %v: %s", tool, err, stderr)
+		}
+		if stderr.Len() != 0 && os.Getenv("GOPACKAGESPRINTDRIVERERRORS") != "" {
+			fmt.Fprintf(os.Stderr, "%s stderr: <<%s>>\n", cmdDebugStr(cmd), stderr)
+		}
+		if decodeErr != nil {
+			return nil, decodeErr
+		}
+		return response, nil
+	}
+}
+
+// findExternalTool returns the file path of a tool that supplies
 // the build system package structure, or "" if not found.
 // If GOPACKAGESDRIVER is set in the environment findExternalTool returns its
 // value, otherwise it searches for a binary named gopackagesdriver on the PATH.
-func findExternalDriver(cfg *Config) driver {
+func findExternalTool(cfg *Config) string {
 	const toolPrefix = "GOPACKAGESDRIVER="
 	tool := ""
 	for _, env := range cfg.Env {
@@ -95,59 +183,151 @@ func findExternalDriver(cfg *Config) driver {
 		}
 	}
 	if tool != "" && tool == "off" {
-		return nil
+		return ""
 	}
 	if tool == "" {
 		var err error
 		tool, err = exec.LookPath("gopackagesdriver")
 		if err != nil {
-			return nil
+			return ""
 		}
 	}
-	return func(cfg *Config, patterns []string) (*DriverResponse, error) {
-		req, err := json.Marshal(DriverRequest{
-			Mode:       cfg.Mode,
-			Env:        cfg.Env,
-			BuildFlags: cfg.BuildFlags,
-			Tests:      cfg.Tests,
-			Overlay:    cfg.Overlay,
-		})
-		if err != nil {
-			return nil, fmt.Errorf("failed to encode message to driver tool: %v", err)
-		}
+	return tool
+}
+
+// driverCommand returns the command that runs the driver tool for the
+// patterns, and the buffer to which it writes its standard error.
+// The request is completed from the configuration.
+func driverCommand(cfg *Config, tool string, patterns []string, req DriverRequest) (*exec.Cmd, *bytes.Buffer, error) {
+	req.Mode = cfg.Mode
+	req.Env = cfg.Env
+	req.BuildFlags = cfg.BuildFlags
+	req.Tests = cfg.Tests
+	req.Overlay = cfg.Overlay
+	req.Protocol = driverProtocol
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode message to driver tool: %v", err)
+	}
 
-		buf := new(bytes.Buffer)
-		stderr := new(bytes.Buffer)
-		cmd := exec.CommandContext(cfg.Context, tool, patterns...)
-		cmd.Dir = cfg.Dir
-		// The cwd gets resolved to the real path. On Darwin, where
-		// /tmp is a symlink, this breaks anything that expects the
-		// working directory to keep the original path, including the
-		// go command when dealing with modules.
-		//
-		// os.Getwd stdlib has a special feature where if the
-		// cwd and the PWD are the same node then it trusts
-		// the PWD, so by setting it in the env for the child
-		// process we fix up all the paths returned by the go
-		// command.
-		//
-		// (See similar trick in Invocation.run in ../../internal/gocommand/invoke.go)
-		cmd.Env = append(slices.Clip(cfg.Env), "PWD="+cfg.Dir)
-		cmd.Stdin = bytes.NewReader(req)
-		cmd.Stdout = buf
-		cmd.Stderr = stderr
-
-		if err := cmd.Run(); err != nil {
-			return nil, fmt.Errorf("%v: %v: %s", tool, err, cmd.Stderr)
+	stderr := new(bytes.Buffer)
+	cmd := exec.CommandContext(cfg.Context, tool, patterns...)
+	cmd.Dir = cfg.Dir
+	// The cwd gets resolved to the real path. On Darwin, where
+	// /tmp is a symlink, this breaks anything that expects the
+	// working directory to keep the original path, including the
+	// go command when dealing with modules.
+	//
+	// os.Getwd stdlib has a special feature where if the
+	// cwd and the PWD are the same node then it trusts
+	// the PWD, so by setting it in the env for the child
+	// process we fix up all the paths returned by the go
+	// command.
+	//
+	// (See similar trick in Invocation.run in ../../internal/gocommand/invoke.go)
+	cmd.Env = append(slices.Clip(cfg.Env), "PWD="+cfg.Dir)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stderr = stderr
+	return cmd, stderr, nil
+}
+
+// decodeDriverResponse decodes the response of a driver, which for
+// protocol version 2 or later may be a sequence of messages.
+func decodeDriverResponse(r io.Reader) (*DriverResponse, error) {
+	dec := json.NewDecoder(r)
+	var response DriverResponse
+	if err := dec.Decode(&response); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
 		}
-		if len(stderr.Bytes()) != 0 && os.Getenv("GOPACKAGESPRINTDRIVERERRORS") != "" {
-			fmt.Fprintf(os.Stderr, "%s stderr: <<%s>>\n", cmdDebugStr(cmd), stderr)
+		return nil, err
+	}
+	if response.Protocol > driverProtocol {
+		return nil, fmt.Errorf("driver uses protocol version %d, want at most %d", response.Protocol, driverProtocol)
+	}
+	if response.Protocol < 2 || response.NotHandled {
+		return &response, nil
+	}
+	for {
+		var msg DriverResponse
+		if err := dec.Decode(&msg); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
 		}
+		response.Roots = append(response.Roots, msg.Roots...)
+		response.Packages = append(response.Packages, msg.Packages...)
+	}
+	return &response, nil
+}
 
+// Watch reports changes to the build graph that may affect the
+// packages named by the given patterns, calling f for each one until
+// the configuration's context is done or the driver exits.
+//
+// Watch requires an external driver (see [The driver protocol]) that
+// supports version 2 of the protocol and advertises [WatchCapability]
+// in its Capabilities; otherwise it returns an error satisfying
+// errors.Is(err, errors.ErrUnsupported). The driver is run with a
+// [DriverRequest] whose Watch field is set. It must write a
+// [DriverResponse] message, whose Packages may be empty, that advertises
+// the capability, and then a [DriverInvalidation] message each time the
+// build graph changes, for as long as it runs.
+//
+// A long-running client may use Watch to reload only the packages
+// that have changed, instead of all of them after every change;
+// [Watcher.Watch] does so for the packages maintained by a [Watcher].
+// If the context is done, Watch returns its error; if the driver
+// exits successfully, Watch returns nil.
+func Watch(cfg *Config, f func(*DriverInvalidation), patterns ...string) error {
+	ld := newLoader(cfg)
+	tool := findExternalTool(&ld.Config)
+	if tool == "" {
+		return fmt.Errorf("no external driver: %w", errors.ErrUnsupported)
+	}
+	cmd, stderr, err := driverCommand(&ld.Config, tool, patterns, DriverRequest{Watch: true})
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("%v: %v", tool, err)
+	}
+	decodeErr := func() error {
+		dec := json.NewDecoder(stdout)
 		var response DriverResponse
-		if err := json.Unmarshal(buf.Bytes(), &response); err != nil {
-			return nil, err
+		if err := dec.Decode(&response); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return err
 		}
-		return &response, nil
+		if response.NotHandled || response.Protocol < 2 || !slices.Contains(response.Capabilities, WatchCapability) {
+			cmd.Process.Kill()
+			return fmt.Errorf("driver %s does not support watching: %w", tool, errors.ErrUnsupported)
+		}
+		for {
+			var msg DriverInvalidation
+			if err := dec.Decode(&msg); err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			f(&msg)
+		}
+	}()
+	io.Copy(io.Discard, stdout) // drain the pipe before Wait
+	waitErr := cmd.Wait()
+	switch {
+	case ld.Context.Err() != nil:
+		return ld.Context.Err()
+	case errors.Is(decodeErr, errors.ErrUnsupported):
+		return decodeErr
+	case waitErr != nil:
+		return fmt.Errorf("%v: %v: %s", tool, waitErr, stderr)
 	}
+	return decodeErr
 }
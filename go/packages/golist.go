@@ -117,10 +117,46 @@ func (state *golistState) getEnv() (map[string]string, error) {
 		if state.goEnvError = decoder.Decode(&state.goEnv); state.goEnvError != nil {
 			return
 		}
+
+		// 'go env' has no -overlay flag, so GOMOD is the go.mod file
+		// on disk. In module mode, prefer an overlaid go.mod file in
+		// a nearer directory, as 'go list' does.
+		if state.goEnv["GOMOD"] != "" {
+			if gomod := state.overlayGoMod(); gomod != "" {
+				state.goEnv["GOMOD"] = gomod
+			}
+		}
 	})
 	return state.goEnv, state.goEnvError
 }
 
+// overlayGoMod returns the go.mod file of the overlay that the go
+// command would use in the configured directory, or "" if the file it
+// would use is on disk, or there is none.
+func (state *golistState) overlayGoMod() string {
+	if len(state.cfg.Overlay) == 0 {
+		return ""
+	}
+	dir, err := filepath.Abs(state.cfg.Dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		gomod := filepath.Join(dir, "go.mod")
+		if _, ok := state.cfg.Overlay[gomod]; ok {
+			return gomod
+		}
+		if _, err := os.Stat(gomod); err == nil {
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
 // mustGetEnv is a convenience function that can be used if getEnv has already succeeded.
 func (state *golistState) mustGetEnv() map[string]string {
 	env, err := state.getEnv()
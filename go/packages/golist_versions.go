@@ -0,0 +1,114 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package packages
+
+// This file supports patterns such as golang.org/x/text/language@v0.14.0,
+// which name packages at a specific version of their module.
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/internal/gocommand"
+)
+
+// versionedModulePath is the path of the temporary main module in
+// which versioned patterns are loaded.
+const versionedModulePath = "gopackages.invalid/versions"
+
+// splitVersion splits a pattern of the form path@version into its
+// package pattern and version. It reports false for other patterns,
+// including queries and file system paths.
+func splitVersion(pattern string) (pkgPattern, version string, ok bool) {
+	if strings.Contains(pattern, "=") || isFilePattern(pattern) {
+		return "", "", false
+	}
+	pkgPattern, version, ok = strings.Cut(pattern, "@")
+	return pkgPattern, version, ok && pkgPattern != "" && version != ""
+}
+
+// isFilePattern reports whether the pattern denotes a directory of
+// the file system, rather than an import path.
+func isFilePattern(pattern string) bool {
+	return filepath.IsAbs(pattern) || pattern == "." || pattern == ".." ||
+		strings.HasPrefix(pattern, "./") || strings.HasPrefix(pattern, "../") ||
+		strings.HasPrefix(pattern, `.\`) || strings.HasPrefix(pattern, `..\`)
+}
+
+// versionedModule prepares to load patterns, some of which name
+// packages at specific module versions, by creating a temporary main
+// module that requires those versions. It returns a configuration
+// whose Dir is that module, the patterns without their versions, and
+// a function to remove the module, which the caller must call.
+//
+// Patterns that name packages in the file system, and queries other
+// than pattern=, cannot be combined with versioned patterns, since the
+// temporary module does not contain them.
+func versionedModule(ctx context.Context, cfg *Config, runner *gocommand.Runner, patterns []string) (_ *Config, _ []string, cleanup func(), _ error) {
+	var (
+		gets     []string // arguments of 'go get'
+		stripped []string // patterns without versions
+		other    string   // a pattern that cannot be versioned
+	)
+	for _, pattern := range patterns {
+		if pkgPattern, _, ok := splitVersion(pattern); ok {
+			gets = append(gets, pattern)
+			stripped = append(stripped, pkgPattern)
+			continue
+		}
+		if isFilePattern(pattern) || strings.Contains(pattern, "=") && !strings.HasPrefix(pattern, "pattern=") {
+			other = pattern
+		}
+		stripped = append(stripped, pattern)
+	}
+	if other != "" {
+		return nil, nil, nil, fmt.Errorf("cannot load %q with versioned patterns such as %q", other, gets[0])
+	}
+
+	dir, err := os.MkdirTemp("", "gopackages-versions-")
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	cleanup = func() { os.RemoveAll(dir) }
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module "+versionedModulePath+"\n"), 0666); err != nil {
+		cleanup()
+		return nil, nil, nil, err
+	}
+
+	// Resolve the versions, adding the requirements of their
+	// packages (and of their tests, if needed) to the temporary
+	// module.
+	modCfg := *cfg
+	modCfg.Dir = dir
+	modCfg.CacheDir = "" // the module is temporary
+	modCfg.Env = append(slices.Clip(cfg.Env), "GOWORK=off")
+	args := gets
+	if cfg.Tests {
+		args = append([]string{"-t"}, args...)
+	}
+	inv := gocommand.Invocation{
+		Verb:       "get",
+		Args:       args,
+		BuildFlags: cfg.BuildFlags,
+		Env:        modCfg.Env,
+		Logf:       cfg.Logf,
+		WorkingDir: dir,
+		CleanEnv:   true,
+	}
+	if _, err := runner.Run(ctx, inv); err != nil {
+		cleanup()
+		return nil, nil, nil, err
+	}
+
+	// Permit 'go list' to add requirements that 'go get' did not,
+	// such as those of the test dependencies of packages that are
+	// not initial.
+	modCfg.BuildFlags = append(slices.Clip(cfg.BuildFlags), "-mod=mod")
+	return &modCfg, stripped, cleanup, nil
+}
@@ -1104,3 +1104,87 @@ replace (
 		t.Fatalf(`expected import "fmt", got none`)
 	}
 }
+
+// TestOverlayModuleFiles tests that go.mod and go.work files in the
+// overlay take the place of those on disk.
+func TestOverlayModuleFiles(t *testing.T) {
+	testenv.NeedsGoPackages(t)
+	t.Parallel()
+
+	// On disk, module a has no dependencies, and b is not a module.
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"a/go.mod": "module example.com/a\n\ngo 1.22\n",
+		"a/a.go":   "package a\n",
+		"b/b.go":   "package b\n",
+	} {
+		filename := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(filename), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, []byte(content), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, test := range []struct {
+		name    string
+		overlay map[string]string
+	}{
+		{
+			"replace",
+			map[string]string{
+				"a/go.mod": "module example.com/a\n\ngo 1.22\n\nrequire example.com/b v0.0.0\n\nreplace example.com/b => ../b\n",
+				"a/a.go":   "package a\n\nimport _ \"example.com/b\"\n",
+				"b/go.mod": "module example.com/b\n\ngo 1.22\n",
+			},
+		},
+		{
+			"workspace",
+			map[string]string{
+				"go.work":  "go 1.22\n\nuse (\n\t./a\n\t./b\n)\n",
+				"a/a.go":   "package a\n\nimport _ \"example.com/b\"\n",
+				"b/go.mod": "module example.com/b\n\ngo 1.22\n",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			overlay := make(map[string][]byte)
+			for name, content := range test.overlay {
+				overlay[filepath.Join(dir, filepath.FromSlash(name))] = []byte(content)
+			}
+			cfg := &packages.Config{
+				Dir:     filepath.Join(dir, "a"),
+				Mode:    packages.NeedName | packages.NeedImports | packages.NeedModule,
+				Env:     append(os.Environ(), "GOFLAGS=", "GOPROXY=off", "GOWORK="),
+				Overlay: overlay,
+			}
+			initial, err := packages.Load(cfg, "example.com/a")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(initial) != 1 {
+				t.Fatalf("got %d packages, want 1", len(initial))
+			}
+			if packages.PrintErrors(initial) > 0 {
+				t.Fatal("packages contain errors")
+			}
+			b := initial[0].Imports["example.com/b"]
+			if b == nil {
+				t.Fatalf("example.com/a does not import example.com/b; imports: %v", initial[0].Imports)
+			}
+			if want := filepath.Join(dir, "b", "go.mod"); b.Module == nil || b.Module.GoMod != want {
+				t.Errorf("module of example.com/b = %+v, want go.mod %s", b.Module, want)
+			}
+		})
+	}
+
+	// Check that the files on disk were not modified.
+	got, err := os.ReadFile(filepath.Join(dir, "a", "go.mod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "module example.com/a\n\ngo 1.22\n"; string(got) != want {
+		t.Errorf("a/go.mod = %q, want %q", got, want)
+	}
+}
@@ -17,9 +17,11 @@ import (
 	"go/token"
 	"go/types"
 	"log"
+	"maps"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -224,11 +226,96 @@ type Config struct {
 	// editor-integrated tools to correctly analyze the contents
 	// of modified but unsaved buffers, for example.
 	//
+	// The overlay may also replace or add go.mod, go.sum, and
+	// go.work files, so that a tool may preview the effect of an
+	// edit to the module requirements without writing it to disk.
+	// The go command reports an error if loading would require it
+	// to update an overlaid go.mod or go.sum file.
+	//
 	// The overlay mapping is passed to the build system's driver
 	// (see "The driver protocol") so that it too can report
 	// consistent package metadata about unsaved files. However,
 	// drivers may vary in their level of support for overlays.
 	Overlay map[string][]byte
+
+	// CacheDir, if non-empty, is a directory in which [Load] caches
+	// the package metadata reported by the go command, along with a
+	// copy of the export data of each package, so that a later Load
+	// with the same patterns and configuration, perhaps in another
+	// process, need not run the go command again.
+	//
+	// A cached result is used only if the go command and its
+	// effective environment (as reported by 'go env'), the go.mod,
+	// go.sum, go.work, and go.work.sum files of Dir and its parent
+	// directories, and the files and directories of its packages are
+	// unchanged. Results with package errors are not cached, nor are
+	// those of an external driver or of a Load with an Overlay.
+	// The cache does not detect a new package in a directory that
+	// did not contain one; a client that creates packages should not
+	// use the cache, or should remove its files.
+	CacheDir string
+
+	// SyntaxFiles, if non-empty, limits the syntax trees and type
+	// information loaded by the NeedSyntax and NeedTypesInfo mode
+	// bits to the named files, which are absolute paths as they
+	// appear in CompiledGoFiles.
+	//
+	// The Syntax field of each package then contains the trees of
+	// only those of its files that are in SyntaxFiles, and its
+	// TypesInfo records information about only those trees. Other
+	// files of the package are parsed if needed to type-check it,
+	// and then discarded. A package none of whose files are in
+	// SyntaxFiles is loaded as if neither NeedSyntax nor
+	// NeedTypesInfo were requested for it; its types come from
+	// export data when possible.
+	//
+	// This reduces the memory used by tools, such as formatters,
+	// that examine only some files of the packages they load.
+	SyntaxFiles []string
+
+	// Parallelism, if positive, is the maximum number of packages
+	// that Load parses and type-checks at once. Whatever its value,
+	// the number does not exceed GOMAXPROCS.
+	Parallelism int
+
+	// MemoryBudget, if positive, bounds the estimated memory, in
+	// bytes, of the packages that Load parses and type-checks at
+	// once, so that the largest packages are loaded alone. The
+	// estimate is proportional to the size of a package's source
+	// files; it is a heuristic, and does not account for the memory
+	// of packages that have been loaded.
+	MemoryBudget int64
+
+	// Stats, if non-nil, is populated by Load with statistics
+	// about the packages it parsed and type-checked.
+	Stats *LoadStats
+
+	// SourceFallback, if set, causes Load to type-check from source
+	// any package whose export data is absent, unreadable, or older
+	// than one of its source files, along with the packages that
+	// import it, instead of reporting an error for it. Build systems
+	// that produce export data separately from their metadata, such
+	// as Bazel, may report export data files that do not exist yet
+	// or are stale.
+	SourceFallback bool
+
+	// PartialResults, if set, causes Load to return the initial
+	// packages that were completely loaded, along with a
+	// [*PartialLoadError], if its context is done after the build
+	// system has reported the packages. Otherwise Load returns only
+	// the error of the context.
+	PartialResults bool
+
+	// LazyTypes, if set along with NeedTypes and NeedDeps, causes
+	// Load to use export data for the dependencies of the initial
+	// packages where possible, and to defer decoding the export data
+	// of each dependency that is not needed to type-check a package
+	// from source until the client calls [Package.LoadTypes]. The
+	// Types field of such a package holds an incomplete package
+	// containing only the objects mentioned by the export data of
+	// other packages. This reduces the memory used by tools that
+	// examine the types of only a fraction of the import graph.
+	LazyTypes bool
 }
 
 // Load loads and returns the Go packages named by the given patterns.
@@ -258,8 +345,113 @@ type Config struct {
 // return an error. Clients may need to handle such errors before
 // proceeding with further analysis. The [PrintErrors] function is
 // provided for convenient display of all errors.
+//
+// If the context of cfg is done, Load returns nil and the error of the
+// context, unless cfg.PartialResults is set and the build system has
+// already reported the packages, in which case Load returns a
+// [*PartialLoadError], whose Unwrap method returns the error of the
+// context, along with the initial packages that were completely
+// loaded. In either case, any go command started by Load has been
+// terminated, along with its subprocesses on Linux.
 func Load(cfg *Config, patterns ...string) ([]*Package, error) {
+	return newLoader(cfg).load(patterns...)
+}
+
+// LoadEach is like [Load], but instead of returning the initial
+// packages, it calls f for each of them as soon as the data requested
+// by cfg.Mode is ready, so that the caller may process a package, or
+// report progress, while others are still being loaded. When type
+// information or syntax is requested, a package is ready once it has
+// been type-checked or parsed; otherwise all packages are ready at
+// once, after the build system has been queried.
+//
+// Calls to f occur in an unspecified order, but never concurrently,
+// and all complete before LoadEach returns. The Imports of a package
+// passed to f are fully loaded too, but unlike those of Load, the
+// packages passed to f may have their Name, PkgPath, Imports, Types,
+// IllTyped, and Fset fields populated even if they were not requested,
+// as they are required to load the packages that import them.
+//
+// LoadEach returns an error in the same cases as Load. If the
+// context of cfg is cancelled, f may already have been called for
+// some of the packages, which are complete; with cfg.PartialResults,
+// the IDs of the others are those of the Missing field of the
+// [*PartialLoadError].
+func LoadEach(cfg *Config, f func(*Package), patterns ...string) error {
 	ld := newLoader(cfg)
+	ld.each = f
+	_, err := ld.load(patterns...)
+	return err
+}
+
+// A Platform is a target operating system and architecture, as
+// specified by the GOOS and GOARCH environment variables.
+type Platform struct {
+	GOOS, GOARCH string
+}
+
+func (p Platform) String() string { return p.GOOS + "/" + p.GOARCH }
+
+// LoadPlatforms is like [Load], but loads the packages once for each
+// of the platforms, so that a tool may analyze code whose inclusion
+// depends on build constraints. It returns the initial packages of
+// each platform; their files, imports, and types are those of that
+// platform.
+//
+// Packages are not shared across platforms: each platform has its
+// own Package for each package, even when its files are the same.
+// However, the platforms are loaded concurrently and share a
+// FileSet (cfg.Fset, if set) and the syntax trees of the files they
+// have in common, which must therefore not be modified.
+//
+// Each platform is loaded with GOOS and GOARCH added to cfg.Env. If
+// loading any platform fails, LoadPlatforms returns the error of the
+// first such platform in the list, prefixed by the platform, and the
+// packages of the platforms that were loaded.
+func LoadPlatforms(cfg *Config, platforms []Platform, patterns ...string) (map[Platform][]*Package, error) {
+	env := cfg.Env
+	if env == nil {
+		env = os.Environ()
+	}
+	fset := cfg.Fset
+	if fset == nil {
+		fset = token.NewFileSet()
+	}
+	cache := &parseCache{m: make(map[string]*parseValue)}
+
+	pkgs := make([][]*Package, len(platforms))
+	errs := make([]error, len(platforms))
+	var g errgroup.Group
+	for i, p := range platforms {
+		g.Go(func() error {
+			pcfg := *cfg
+			pcfg.Env = append(slices.Clip(env), "GOOS="+p.GOOS, "GOARCH="+p.GOARCH)
+			pcfg.Fset = fset
+			ld := newLoader(&pcfg)
+			ld.parseCache = cache
+			pkgs[i], errs[i] = ld.load(patterns...)
+			return nil
+		})
+	}
+	g.Wait()
+
+	result := make(map[Platform][]*Package, len(platforms))
+	var firstErr error
+	for i, p := range platforms {
+		if errs[i] != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("%s: %w", p, errs[i])
+			}
+			continue
+		}
+		result[p] = pkgs[i]
+	}
+	return result, firstErr
+}
+
+// load calls the driver for the patterns and returns the initial
+// packages refined according to the load mode.
+func (ld *loader) load(patterns ...string) ([]*Package, error) {
 	response, external, err := defaultDriver(&ld.Config, patterns...)
 	if err != nil {
 		return nil, err
@@ -324,6 +516,39 @@ func defaultDriver(cfg *Config, patterns ...string) (*DriverResponse, bool, erro
 
 	// go list fallback
 
+	var runner gocommand.Runner // (shared across many 'go list' calls)
+
+	// Load packages at specific module versions in a temporary module.
+	if slices.ContainsFunc(patterns, func(pattern string) bool {
+		_, _, ok := splitVersion(pattern)
+		return ok
+	}) {
+		modCfg, stripped, cleanup, err := versionedModule(cfg.Context, cfg, &runner, patterns)
+		if err != nil {
+			return nil, false, err
+		}
+		defer cleanup()
+		cfg, patterns = modCfg, stripped
+		chunks, err = splitIntoChunks(patterns, safeArgMax)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	// Use the on-disk cache, if any.
+	var key string // non-empty if the response is to be cached
+	if cfg.CacheDir != "" && len(cfg.Overlay) == 0 {
+		k, err := cacheKey(cfg, &runner, patterns)
+		if err != nil {
+			cfg.Logf("not using packages cache: %v", err)
+		} else if response, ok := readCache(cfg, k); ok {
+			return response, false, nil
+		} else {
+			key = k
+		}
+	}
+	start := time.Now()
+
 	// Write overlays once, as there are many calls
 	// to 'go list' (one per chunk plus others too).
 	overlayFile, cleanupOverlay, err := gocommand.WriteOverlays(cfg.Overlay)
@@ -332,7 +557,6 @@ func defaultDriver(cfg *Config, patterns ...string) (*DriverResponse, bool, erro
 	}
 	defer cleanupOverlay()
 
-	var runner gocommand.Runner // (shared across many 'go list' calls)
 	driver := func(cfg *Config, patterns []string) (*DriverResponse, error) {
 		return goListDriver(cfg, &runner, overlayFile, patterns)
 	}
@@ -340,6 +564,11 @@ func defaultDriver(cfg *Config, patterns ...string) (*DriverResponse, bool, erro
 	if err != nil {
 		return nil, false, err
 	}
+	if key != "" {
+		if err := writeCache(cfg, key, response, start); err != nil {
+			cfg.Logf("not caching packages: %v", err)
+		}
+	}
 	return response, false, err
 }
 
@@ -533,6 +762,25 @@ type Package struct {
 
 	// depsErrors is the DepsErrors field from the go list response, if any.
 	depsErrors []*packagesinternal.PackageError
+
+	// lazyTypes, if non-nil, decodes the deferred export data of
+	// the package (see Config.LazyTypes).
+	lazyTypes func() (*types.Package, error)
+}
+
+// LoadTypes returns the complete types of the package. For a package
+// whose export data was not decoded by [Load] because of
+// [Config.LazyTypes], it decodes the export data on the first call,
+// into a new package distinct from the incomplete one in the Types
+// field, which is left unchanged; the new package refers to the
+// packages of its dependencies only where their types are complete.
+// For other packages, it returns the Types field.
+// It is safe to call concurrently.
+func (p *Package) LoadTypes() (*types.Package, error) {
+	if p.lazyTypes == nil {
+		return p.Types, nil
+	}
+	return p.lazyTypes()
 }
 
 // Module provides module information for a package.
@@ -565,11 +813,34 @@ func init() {
 	packagesinternal.DepsErrors = int(needInternalDepsErrors)
 }
 
+// A PartialLoadError is returned by [Load], if [Config.PartialResults]
+// is set, when its context is done after the build system has reported
+// the packages, but perhaps before all of them have been loaded. Load
+// returns it along with the initial packages that were completely
+// loaded, including their dependencies.
+type PartialLoadError struct {
+	Err     error    // the error of the context
+	Missing []string // IDs of the initial packages that were not completely loaded
+}
+
+func (err *PartialLoadError) Error() string {
+	return fmt.Sprintf("packages partially loaded (%d not loaded): %v", len(err.Missing), err.Err)
+}
+
+func (err *PartialLoadError) Unwrap() error { return err.Err }
+
 // An Error describes a problem with a package's metadata, syntax, or types.
 type Error struct {
 	Pos  string // "file:line:col" or "file:line" or "" or "-"
 	Msg  string
 	Kind ErrorKind
+
+	// Detail, if non-nil, describes the error in structured form,
+	// so that clients need not interpret Msg. It is populated for
+	// some errors reported by go list, including those of external
+	// drivers that use the same messages, and by the type checker.
+	// It is not part of the JSON form of the Error.
+	Detail ErrorDetail `json:"-"`
 }
 
 // ErrorKind describes the source of the error, allowing the user to
@@ -684,7 +955,9 @@ type loaderPackage struct {
 	color           uint8            // for cycle detection
 	needsrc         bool             // load from source (Mode >= LoadTypes)
 	needtypes       bool             // type information is either requested or depended on
+	importedBySrc   bool             // imported by a package loaded from source
 	initial         bool             // package was matched by a pattern
+	cancelled       bool             // loading was abandoned as the context was done
 	goVersion       int              // minor version number of go command on PATH
 }
 
@@ -692,10 +965,9 @@ type loaderPackage struct {
 type loader struct {
 	pkgs map[string]*loaderPackage // keyed by Package.ID
 	Config
-	sizes        types.Sizes // non-nil if needed by mode
-	parseCache   map[string]*parseValue
-	parseCacheMu sync.Mutex
-	exportMu     sync.Mutex // enforces mutual exclusion of exportdata operations
+	sizes      types.Sizes // non-nil if needed by mode
+	parseCache *parseCache // may be shared by the loaders of LoadPlatforms
+	exportMu   sync.Mutex  // enforces mutual exclusion of exportdata operations
 
 	// Config.Mode contains the implied mode (see impliedLoadMode).
 	// Implied mode contains all the fields we need the data for.
@@ -704,6 +976,23 @@ type loader struct {
 	// This makes it easier for us to get the conditions where
 	// we need certain modes right.
 	requestedMode LoadMode
+
+	// each, if non-nil, is called for each initial package as soon
+	// as it is ready (see LoadEach), with eachMu held.
+	each   func(*Package)
+	eachMu sync.Mutex
+
+	// syntaxFiles is the set of Config.SyntaxFiles, or nil.
+	syntaxFiles map[string]bool
+
+	// sched bounds the packages loaded at once.
+	sched *scheduler
+}
+
+// A parseCache holds the syntax trees parsed by a loader, by file name.
+type parseCache struct {
+	mu sync.Mutex
+	m  map[string]*parseValue
 }
 
 type parseValue struct {
@@ -714,13 +1003,19 @@ type parseValue struct {
 
 func newLoader(cfg *Config) *loader {
 	ld := &loader{
-		parseCache: map[string]*parseValue{},
+		parseCache: &parseCache{m: make(map[string]*parseValue)},
 	}
 	if cfg != nil {
 		ld.Config = *cfg
 		// If the user has provided a logger, use it.
 		ld.Config.Logf = cfg.Logf
 	}
+	if len(ld.SyntaxFiles) > 0 {
+		ld.syntaxFiles = make(map[string]bool, len(ld.SyntaxFiles))
+		for _, filename := range ld.SyntaxFiles {
+			ld.syntaxFiles[filepath.Clean(filename)] = true
+		}
+	}
 	if ld.Config.Logf == nil {
 		// If the GOPACKAGESDEBUG environment variable is set to true,
 		// but the user has not provided a logger, default to log.Printf.
@@ -780,6 +1075,8 @@ func (ld *loader) refine(response *DriverResponse) ([]*Package, error) {
 	// first pass, fixup and build the map and roots
 	var initial = make([]*loaderPackage, len(roots))
 	for _, pkg := range response.Packages {
+		setListErrorDetails(pkg)
+
 		rootIndex := -1
 		if i, found := rootMap[pkg.ID]; found {
 			rootIndex = i
@@ -787,13 +1084,15 @@ func (ld *loader) refine(response *DriverResponse) ([]*Package, error) {
 
 		// Overlays can invalidate export data.
 		// TODO(matloob): make this check fine-grained based on dependencies on overlaid files
-		exportDataInvalid := len(ld.Overlay) > 0 || pkg.ExportFile == "" && pkg.PkgPath != "unsafe"
+		exportDataInvalid := len(ld.Overlay) > 0 || pkg.ExportFile == "" && pkg.PkgPath != "unsafe" ||
+			ld.SourceFallback && ld.Mode&(NeedTypes|NeedTypesInfo) != 0 && pkg.PkgPath != "unsafe" && !exportDataUsable(pkg)
 		// This package needs type information if the caller requested types and the package is
 		// either a root, or it's a non-root and the user requested dependencies ...
 		needtypes := (ld.Mode&(NeedTypes|NeedTypesInfo) != 0 && (rootIndex >= 0 || ld.Mode&NeedDeps != 0))
 		// This package needs source if the call requested source (or types info, which implies source)
 		// and the package is either a root, or itas a non- root and the user requested dependencies...
-		needsrc := ((ld.Mode&(NeedSyntax|NeedTypesInfo) != 0 && (rootIndex >= 0 || ld.Mode&NeedDeps != 0)) ||
+		needsrc := ((ld.Mode&(NeedSyntax|NeedTypesInfo) != 0 && (rootIndex >= 0 || ld.Mode&NeedDeps != 0) &&
+			len(ld.syntaxFilesOf(pkg.CompiledGoFiles)) > 0) ||
 			// ... or if we need types and the exportData is invalid. We fall back to (incompletely)
 			// typechecking packages from source if they fail to compile.
 			(ld.Mode&(NeedTypes|NeedTypesInfo) != 0 && exportDataInvalid)) && pkg.PkgPath != "unsafe"
@@ -877,6 +1176,7 @@ func (ld *loader) refine(response *DriverResponse) ([]*Package, error) {
 				if lpkg.needsrc && ld.Mode&NeedTypes != 0 {
 					for _, ipkg := range lpkg.Imports {
 						ld.pkgs[ipkg.ID].needtypes = true
+						ld.pkgs[ipkg.ID].importedBySrc = true
 					}
 				}
 
@@ -926,8 +1226,11 @@ func (ld *loader) refine(response *DriverResponse) ([]*Package, error) {
 		// workers from enqeuing, and thus finishing, and thus
 		// allowing the group to make progress: deadlock.
 		//
-		// Instead we use the ioLimit and cpuLimit semaphores.
+		// Instead we use the ioLimit and cpuLimit semaphores,
+		// and the scheduler's limits, which are not held by a
+		// worker when it enqueues.
 		g, _ := errgroup.WithContext(ld.Context)
+		ld.sched = newScheduler(&ld.Config)
 
 		// enqueues adds a package to the type-checking queue.
 		// It must have no unfinished successors.
@@ -935,7 +1238,21 @@ func (ld *loader) refine(response *DriverResponse) ([]*Package, error) {
 		enqueue = func(lpkg *loaderPackage) {
 			g.Go(func() error {
 				// Parse and type-check.
+				// (If the context is done, acquire fails,
+				// and loadPackage returns early.)
+				release, err := ld.sched.acquire(ld.Context, lpkg)
 				ld.loadPackage(lpkg)
+				if err == nil {
+					release()
+				}
+
+				// A streamed package, and its dependencies, may be
+				// visible to the client while others are loading,
+				// so clear its unrequested fields now, but for those
+				// needed to load the packages that import it.
+				if ld.each != nil {
+					clearFields(lpkg, ld.requestedMode|NeedName|NeedImports|NeedTypes)
+				}
 
 				// Notify each waiting predecessor,
 				// and enqueue it when it becomes a leaf.
@@ -945,6 +1262,12 @@ func (ld *loader) refine(response *DriverResponse) ([]*Package, error) {
 					}
 				}
 
+				if ld.each != nil && lpkg.initial && ld.Context.Err() == nil {
+					ld.eachMu.Lock()
+					ld.each(lpkg.Package)
+					ld.eachMu.Unlock()
+				}
+
 				return nil
 			})
 		}
@@ -960,67 +1283,106 @@ func (ld *loader) refine(response *DriverResponse) ([]*Package, error) {
 		}
 	}
 
-	// If the context is done, return its error and
-	// throw out [likely] incomplete packages.
+	// If the context is done, return its error and throw out
+	// [likely] incomplete packages, or, if partial results were
+	// requested, only those initial packages that were not completely
+	// loaded.
+	var partial *PartialLoadError
+	complete := func(*loaderPackage) bool { return true }
 	if err := ld.Context.Err(); err != nil {
-		return nil, err
-	}
-
-	result := make([]*Package, len(initial))
-	for i, lpkg := range initial {
-		result[i] = lpkg.Package
-	}
-	for i := range ld.pkgs {
-		// Clear all unrequested fields,
-		// to catch programs that use more than they request.
-		if ld.requestedMode&NeedName == 0 {
-			ld.pkgs[i].Name = ""
-			ld.pkgs[i].PkgPath = ""
-		}
-		if ld.requestedMode&NeedFiles == 0 {
-			ld.pkgs[i].GoFiles = nil
-			ld.pkgs[i].OtherFiles = nil
-			ld.pkgs[i].IgnoredFiles = nil
-		}
-		if ld.requestedMode&NeedEmbedFiles == 0 {
-			ld.pkgs[i].EmbedFiles = nil
-		}
-		if ld.requestedMode&NeedEmbedPatterns == 0 {
-			ld.pkgs[i].EmbedPatterns = nil
-		}
-		if ld.requestedMode&NeedCompiledGoFiles == 0 {
-			ld.pkgs[i].CompiledGoFiles = nil
-		}
-		if ld.requestedMode&NeedImports == 0 {
-			ld.pkgs[i].Imports = nil
-		}
-		if ld.requestedMode&NeedExportFile == 0 {
-			ld.pkgs[i].ExportFile = ""
-		}
-		if ld.requestedMode&NeedTypes == 0 {
-			ld.pkgs[i].Types = nil
-			ld.pkgs[i].IllTyped = false
+		if !ld.PartialResults {
+			return nil, err
 		}
-		if ld.requestedMode&NeedSyntax == 0 {
-			ld.pkgs[i].Syntax = nil
-		}
-		if ld.requestedMode&(NeedSyntax|NeedTypes|NeedTypesInfo) == 0 {
-			ld.pkgs[i].Fset = nil
+		partial = &PartialLoadError{Err: err}
+		memo := make(map[*loaderPackage]bool)
+		complete = func(lpkg *loaderPackage) bool {
+			c, ok := memo[lpkg]
+			if !ok {
+				c = !lpkg.cancelled
+				for _, imp := range lpkg.Imports {
+					c = complete(ld.pkgs[imp.ID]) && c
+				}
+				memo[lpkg] = c
+			}
+			return c
 		}
-		if ld.requestedMode&NeedTypesInfo == 0 {
-			ld.pkgs[i].TypesInfo = nil
+	}
+
+	result := make([]*Package, 0, len(initial))
+	for _, lpkg := range initial {
+		if complete(lpkg) {
+			result = append(result, lpkg.Package)
+		} else {
+			partial.Missing = append(partial.Missing, lpkg.ID)
 		}
-		if ld.requestedMode&NeedTypesSizes == 0 {
-			ld.pkgs[i].TypesSizes = nil
+	}
+	loaded := ld.Mode&(NeedSyntax|NeedTypes|NeedTypesInfo) != 0
+	if ld.each == nil || !loaded {
+		for _, lpkg := range ld.pkgs {
+			clearFields(lpkg, ld.requestedMode)
 		}
-		if ld.requestedMode&NeedModule == 0 {
-			ld.pkgs[i].Module = nil
+	}
+	if ld.each != nil && !loaded {
+		for _, pkg := range result {
+			ld.each(pkg)
 		}
 	}
 
+	if partial != nil {
+		return result, partial
+	}
 	return result, nil
 }
 
+// clearFields clears the fields of the package that are not in mode,
+// normally the requested one, to catch programs that use more than
+// they request.
+func clearFields(lpkg *loaderPackage, mode LoadMode) {
+	if mode&NeedName == 0 {
+		lpkg.Name = ""
+		lpkg.PkgPath = ""
+	}
+	if mode&NeedFiles == 0 {
+		lpkg.GoFiles = nil
+		lpkg.OtherFiles = nil
+		lpkg.IgnoredFiles = nil
+	}
+	if mode&NeedEmbedFiles == 0 {
+		lpkg.EmbedFiles = nil
+	}
+	if mode&NeedEmbedPatterns == 0 {
+		lpkg.EmbedPatterns = nil
+	}
+	if mode&NeedCompiledGoFiles == 0 {
+		lpkg.CompiledGoFiles = nil
+	}
+	if mode&NeedImports == 0 {
+		lpkg.Imports = nil
+	}
+	if mode&NeedExportFile == 0 {
+		lpkg.ExportFile = ""
+	}
+	if mode&NeedTypes == 0 {
+		lpkg.Types = nil
+		lpkg.IllTyped = false
+	}
+	if mode&NeedSyntax == 0 {
+		lpkg.Syntax = nil
+	}
+	if mode&(NeedSyntax|NeedTypes|NeedTypesInfo) == 0 {
+		lpkg.Fset = nil
+	}
+	if mode&NeedTypesInfo == 0 {
+		lpkg.TypesInfo = nil
+	}
+	if mode&NeedTypesSizes == 0 {
+		lpkg.TypesSizes = nil
+	}
+	if mode&NeedModule == 0 {
+		lpkg.Module = nil
+	}
+}
+
 // loadPackage loads/parses/typechecks the specified package.
 // It must be called only once per Package,
 // after immediate dependencies are loaded.
@@ -1047,6 +1409,7 @@ func (ld *loader) loadPackage(lpkg *loaderPackage) {
 	// Packages that import this one will have ld.Context.Err() != nil.
 	// ld.Context.Err() will be returned later by refine.
 	if ld.Context.Err() != nil {
+		lpkg.cancelled = true
 		return
 	}
 
@@ -1065,6 +1428,16 @@ func (ld *loader) loadPackage(lpkg *loaderPackage) {
 	// I think it should be lpkg.needtypes && !lpg.needsrc,
 	// so that NeedSyntax without NeedTypes can be satisfied by export data.
 	if !lpkg.needsrc {
+		if ld.LazyTypes && !lpkg.initial && !lpkg.importedBySrc {
+			// Defer decoding until the client asks for it.
+			// (The imports are loaded, as this is a postorder.)
+			imports := make([]*types.Package, 0, len(lpkg.Imports))
+			for _, imp := range lpkg.Imports {
+				imports = append(imports, imp.Types)
+			}
+			lpkg.lazyTypes = lazyTypes(ld.Fset, lpkg.ExportFile, lpkg.PkgPath, imports)
+			return
+		}
 		if err := ld.loadFromExportData(lpkg); err != nil {
 			lpkg.Errors = append(lpkg.Errors, Error{
 				Pos:  "-",
@@ -1105,9 +1478,10 @@ func (ld *loader) loadPackage(lpkg *loaderPackage) {
 			// from type checker
 			lpkg.TypeErrors = append(lpkg.TypeErrors, err)
 			errs = append(errs, Error{
-				Pos:  err.Fset.Position(err.Pos).String(),
-				Msg:  err.Msg,
-				Kind: TypeError,
+				Pos:    err.Fset.Position(err.Pos).String(),
+				Msg:    err.Msg,
+				Kind:   TypeError,
+				Detail: typeErrorDetail(lpkg.Syntax, err),
 			})
 
 		default:
@@ -1157,13 +1531,19 @@ func (ld *loader) loadPackage(lpkg *loaderPackage) {
 	if ld.Config.Mode&NeedTypes != 0 && len(lpkg.CompiledGoFiles) == 0 && lpkg.ExportFile != "" {
 		// The config requested loading sources and types, but sources are missing.
 		// Add an error to the package and fall back to loading from export data.
-		appendError(Error{"-", fmt.Sprintf("sources missing for package %s", lpkg.ID), ParseError})
+		appendError(Error{Pos: "-", Msg: fmt.Sprintf("sources missing for package %s", lpkg.ID), Kind: ParseError})
 		_ = ld.loadFromExportData(lpkg) // ignore any secondary errors
 
 		return // can't get syntax trees for this package
 	}
 
-	files, errs := ld.parseFiles(lpkg.CompiledGoFiles)
+	// Parse only the requested files unless the package
+	// is to be type-checked.
+	filenames := lpkg.CompiledGoFiles
+	if ld.Config.Mode&(NeedTypes|NeedTypesInfo) == 0 {
+		filenames = ld.syntaxFilesOf(filenames)
+	}
+	files, errs := ld.parseFiles(filenames)
 	for _, err := range errs {
 		appendError(err)
 	}
@@ -1172,11 +1552,13 @@ func (ld *loader) loadPackage(lpkg *loaderPackage) {
 	if ld.Config.Mode&(NeedTypes|NeedTypesInfo) == 0 {
 		return
 	}
+	defer ld.discardSyntax(lpkg)
 
 	// Start shutting down if the context is done and do not type check.
 	// Packages that import this one will have ld.Context.Err() != nil.
 	// ld.Context.Err() will be returned later by refine.
 	if ld.Context.Err() != nil {
+		lpkg.cancelled = true
 		return
 	}
 
@@ -1307,6 +1689,61 @@ func (ld *loader) loadPackage(lpkg *loaderPackage) {
 	lpkg.IllTyped = illTyped
 }
 
+// syntaxFilesOf returns the subset of filenames that are in
+// Config.SyntaxFiles, or all of them if it is empty.
+func (ld *loader) syntaxFilesOf(filenames []string) []string {
+	if ld.syntaxFiles == nil {
+		return filenames
+	}
+	var res []string
+	for _, filename := range filenames {
+		if ld.syntaxFiles[filepath.Clean(filename)] {
+			res = append(res, filename)
+		}
+	}
+	return res
+}
+
+// discardSyntax removes from the type-checked package the syntax
+// trees of the files that are not in Config.SyntaxFiles, and the
+// type information about them.
+func (ld *loader) discardSyntax(lpkg *loaderPackage) {
+	if ld.syntaxFiles == nil {
+		return
+	}
+	var kept []*ast.File
+	for _, f := range lpkg.Syntax {
+		if ld.syntaxFiles[filepath.Clean(ld.Fset.File(f.FileStart).Name())] {
+			kept = append(kept, f)
+		}
+	}
+	lpkg.Syntax = kept
+
+	info := lpkg.TypesInfo
+	if info == nil {
+		return
+	}
+	// discard reports whether the node is outside the kept files.
+	discard := func(n ast.Node) bool {
+		pos := n.Pos()
+		for _, f := range kept {
+			if f.FileStart <= pos && pos <= f.FileEnd {
+				return false
+			}
+		}
+		return true
+	}
+	maps.DeleteFunc(info.Types, func(e ast.Expr, _ types.TypeAndValue) bool { return discard(e) })
+	maps.DeleteFunc(info.Instances, func(id *ast.Ident, _ types.Instance) bool { return discard(id) })
+	maps.DeleteFunc(info.Defs, func(id *ast.Ident, _ types.Object) bool { return discard(id) })
+	maps.DeleteFunc(info.Uses, func(id *ast.Ident, _ types.Object) bool { return discard(id) })
+	maps.DeleteFunc(info.Implicits, func(n ast.Node, _ types.Object) bool { return discard(n) })
+	maps.DeleteFunc(info.Selections, func(sel *ast.SelectorExpr, _ *types.Selection) bool { return discard(sel) })
+	maps.DeleteFunc(info.Scopes, func(n ast.Node, _ *types.Scope) bool { return discard(n) })
+	maps.DeleteFunc(info.FileVersions, func(f *ast.File, _ string) bool { return discard(f) })
+	info.InitOrder = slices.DeleteFunc(info.InitOrder, func(init *types.Initializer) bool { return discard(init.Rhs) })
+}
+
 // An importFunc is an implementation of the single-method
 // types.Importer interface based on a function value.
 type importerFunc func(path string) (*types.Package, error)
@@ -1321,17 +1758,17 @@ var (
 )
 
 func (ld *loader) parseFile(filename string) (*ast.File, error) {
-	ld.parseCacheMu.Lock()
-	v, ok := ld.parseCache[filename]
+	ld.parseCache.mu.Lock()
+	v, ok := ld.parseCache.m[filename]
 	if ok {
 		// cache hit
-		ld.parseCacheMu.Unlock()
+		ld.parseCache.mu.Unlock()
 		<-v.ready
 	} else {
 		// cache miss
 		v = &parseValue{ready: make(chan struct{})}
-		ld.parseCache[filename] = v
-		ld.parseCacheMu.Unlock()
+		ld.parseCache.m[filename] = v
+		ld.parseCache.mu.Unlock()
 
 		var src []byte
 		for f, contents := range ld.Config.Overlay {
@@ -1464,22 +1901,6 @@ func (ld *loader) loadFromExportData(lpkg *loaderPackage) error {
 		// Errors while building export data will have been printed to stderr.
 		return fmt.Errorf("no export data file")
 	}
-	f, err := os.Open(lpkg.ExportFile)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
-	// Read gc export data.
-	//
-	// We don't currently support gccgo export data because all
-	// underlying workspaces use the gc toolchain. (Even build
-	// systems that support gccgo don't use it for workspace
-	// queries.)
-	r, err := gcexportdata.NewReader(f)
-	if err != nil {
-		return fmt.Errorf("reading %s: %v", lpkg.ExportFile, err)
-	}
 
 	// Build the view.
 	//
@@ -1518,11 +1939,9 @@ func (ld *loader) loadFromExportData(lpkg *loaderPackage) error {
 	visit(lpkg.Imports)
 
 	viewLen := len(view) + 1 // adding the self package
-	// Parse the export data.
-	// (May modify incomplete packages in view but not create new ones.)
-	tpkg, err := gcexportdata.Read(r, ld.Fset, view, lpkg.PkgPath)
+	tpkg, err := readExportData(ld.Fset, lpkg.ExportFile, lpkg.PkgPath, view)
 	if err != nil {
-		return fmt.Errorf("reading %s: %v", lpkg.ExportFile, err)
+		return err
 	}
 	if _, ok := view["go.shape"]; ok {
 		// Account for the pseudopackage "go.shape" that gets
@@ -1532,12 +1951,98 @@ func (ld *loader) loadFromExportData(lpkg *loaderPackage) error {
 	if viewLen != len(view) {
 		log.Panicf("golang.org/x/tools/go/packages: unexpected new packages during load of %s", lpkg.PkgPath)
 	}
-
 	lpkg.Types = tpkg
 	lpkg.IllTyped = false
 	return nil
 }
 
+// lazyTypes returns a function that decodes the types of a package
+// whose export data was deferred by Config.LazyTypes, on its first
+// call. The package in the Types field may be read concurrently, and
+// the export data of other packages refers to it, so the function
+// decodes into new packages instead of completing it, reusing only
+// those of the (transitive) imports whose types are complete and
+// thus immutable. It retains neither the loader nor its packages.
+func lazyTypes(fset *token.FileSet, exportFile, pkgPath string, imports []*types.Package) func() (*types.Package, error) {
+	return sync.OnceValues(func() (*types.Package, error) {
+		if exportFile == "" {
+			return nil, fmt.Errorf("no export data file")
+		}
+		view := make(map[string]*types.Package)
+		complete := make(map[*types.Package]bool)
+		var visit func(tpkg *types.Package) bool
+		visit = func(tpkg *types.Package) bool {
+			ok, seen := complete[tpkg]
+			if !seen {
+				ok = tpkg.Complete()
+				for _, imp := range tpkg.Imports() {
+					ok = visit(imp) && ok
+				}
+				complete[tpkg] = ok
+				if _, dup := view[tpkg.Path()]; ok && !dup {
+					view[tpkg.Path()] = tpkg
+				}
+			}
+			return ok
+		}
+		for _, imp := range imports {
+			visit(imp)
+		}
+		return readExportData(fset, exportFile, pkgPath, view)
+	})
+}
+
+// readExportData reads the types of the package from the export
+// data file, using and updating view, and returns them.
+func readExportData(fset *token.FileSet, exportFile, pkgPath string, view map[string]*types.Package) (*types.Package, error) {
+	f, err := os.Open(exportFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	// Read gc export data.
+	//
+	// We don't currently support gccgo export data because all
+	// underlying workspaces use the gc toolchain. (Even build
+	// systems that support gccgo don't use it for workspace
+	// queries.)
+	r, err := gcexportdata.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %v", exportFile, err)
+	}
+
+	// Parse the export data.
+	// (May modify incomplete packages in view.)
+	tpkg, err := gcexportdata.Read(r, fset, view, pkgPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %v", exportFile, err)
+	}
+	return tpkg, nil
+}
+
+// exportDataUsable reports whether the package's export data file
+// exists, has a valid header, and is no older than its source files.
+// It is used to apply Config.SourceFallback.
+func exportDataUsable(pkg *Package) bool {
+	f, err := os.Open(pkg.ExportFile)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	for _, filename := range pkg.CompiledGoFiles {
+		if src, err := os.Stat(filename); err == nil && src.ModTime().After(info.ModTime()) {
+			return false // stale
+		}
+	}
+	_, err = gcexportdata.NewReader(f)
+	return err == nil
+}
+
 // impliedLoadMode returns loadMode with its dependencies.
 func impliedLoadMode(loadMode LoadMode) LoadMode {
 	if loadMode&(NeedDeps|NeedTypes|NeedTypesInfo) != 0 {
@@ -1553,7 +2058,7 @@ func impliedLoadMode(loadMode LoadMode) LoadMode {
 }
 
 func usesExportData(cfg *Config) bool {
-	return cfg.Mode&NeedExportFile != 0 || cfg.Mode&NeedTypes != 0 && cfg.Mode&NeedDeps == 0
+	return cfg.Mode&NeedExportFile != 0 || cfg.Mode&NeedTypes != 0 && (cfg.Mode&NeedDeps == 0 || cfg.LazyTypes)
 }
 
 type unit struct{}
@@ -6,7 +6,9 @@ package packages_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"go/ast"
 	constantpkg "go/constant"
@@ -21,13 +23,17 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"testing/fstest"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"golang.org/x/tools/go/packages"
 	"golang.org/x/tools/internal/packagesinternal"
 	"golang.org/x/tools/internal/packagestest"
+	"golang.org/x/tools/internal/proxydir"
 	"golang.org/x/tools/internal/testenv"
 	"golang.org/x/tools/internal/testfiles"
 	"golang.org/x/tools/txtar"
@@ -824,6 +830,710 @@ func testLoadDiamondTypes(t *testing.T, exporter packagestest.Exporter) {
 	}
 }
 
+func TestLoadEach(t *testing.T) { testAllOrModulesParallel(t, testLoadEach) }
+func testLoadEach(t *testing.T, exporter packagestest.Exporter) {
+	exported := packagestest.Export(t, exporter, []packagestest.Module{{
+		Name: "golang.org/fake",
+		Files: map[string]any{
+			"a/a.go": `package a; import ("golang.org/fake/b"; "golang.org/fake/c"); var _ = b.B == c.C`,
+			"b/b.go": `package b; import "golang.org/fake/d"; var B d.D`,
+			"c/c.go": `package c; import "golang.org/fake/d"; var C d.D`,
+			"d/d.go": `package d; type D int`,
+		}}})
+	defer exported.Cleanup()
+
+	for _, test := range []struct {
+		mode  packages.LoadMode
+		check func(*packages.Package) error
+	}{
+		{packages.NeedName, func(pkg *packages.Package) error {
+			if pkg.Name == "" || pkg.Syntax != nil {
+				return fmt.Errorf("package %s: got Name %q, %d files, want Name only", pkg.ID, pkg.Name, len(pkg.Syntax))
+			}
+			return nil
+		}},
+		{packages.LoadAllSyntax, func(pkg *packages.Package) error {
+			if pkg.Types == nil || !pkg.Types.Complete() || len(pkg.Syntax) != 1 || pkg.TypesInfo == nil {
+				return fmt.Errorf("package %s was not loaded", pkg.ID)
+			}
+			for dep := range packages.Postorder([]*packages.Package{pkg}) {
+				if dep.Types == nil || !dep.Types.Complete() {
+					return fmt.Errorf("dependency %s of %s was not loaded", dep.ID, pkg.ID)
+				}
+			}
+			return nil
+		}},
+	} {
+		exported.Config.Mode = test.mode
+		var got []string
+		err := packages.LoadEach(exported.Config, func(pkg *packages.Package) {
+			got = append(got, pkg.ID)
+			if err := test.check(pkg); err != nil {
+				t.Errorf("mode %v: %v", test.mode, err)
+			}
+		}, "golang.org/fake/a", "golang.org/fake/c")
+		if err != nil {
+			t.Fatal(err)
+		}
+		sort.Strings(got)
+		if want := []string{"golang.org/fake/a", "golang.org/fake/c"}; !slices.Equal(got, want) {
+			t.Errorf("mode %v: LoadEach called f for %v, want %v", test.mode, got, want)
+		}
+	}
+}
+
+func TestReverseDeps(t *testing.T) { testAllOrModulesParallel(t, testReverseDeps) }
+func testReverseDeps(t *testing.T, exporter packagestest.Exporter) {
+	exported := packagestest.Export(t, exporter, []packagestest.Module{{
+		Name: "golang.org/fake",
+		Files: map[string]any{
+			"a/a.go": `package a; import ("golang.org/fake/b"; "golang.org/fake/c"); var _ = b.B == c.C`,
+			"b/b.go": `package b; import "golang.org/fake/d"; var B d.D`,
+			"c/c.go": `package c; var C = 1`,
+			"d/d.go": `package d; type D int`,
+			"e/e.go": `package e; import "golang.org/fake/c"; var _ = c.C`,
+		}}})
+	defer exported.Cleanup()
+
+	exported.Config.Mode = packages.NeedName | packages.NeedImports | packages.NeedDeps
+	initial, err := packages.Load(exported.Config, "golang.org/fake/a", "golang.org/fake/e")
+	if err != nil {
+		t.Fatal(err)
+	}
+	byPath := make(map[string]*packages.Package)
+	for pkg := range packages.Postorder(initial) {
+		byPath[strings.TrimPrefix(pkg.PkgPath, "golang.org/fake/")] = pkg
+	}
+
+	for _, test := range []struct {
+		targets []string
+		want    string
+	}{
+		{[]string{"d"}, "[golang.org/fake/b golang.org/fake/a]"},
+		{[]string{"c"}, "[golang.org/fake/a golang.org/fake/e]"},
+		{[]string{"b", "c"}, "[golang.org/fake/a golang.org/fake/e]"},
+		{[]string{"d", "b"}, "[golang.org/fake/b golang.org/fake/a]"},
+		{[]string{"a"}, "[]"},
+	} {
+		var targets []*packages.Package
+		for _, path := range test.targets {
+			targets = append(targets, byPath[path])
+		}
+		got := fmt.Sprint(slices.Collect(packages.ReverseDeps(initial, targets...)))
+		if got != test.want {
+			t.Errorf("ReverseDeps(%v) = %s, want %s", test.targets, got, test.want)
+		}
+	}
+}
+
+func TestSnapshot(t *testing.T) { testAllOrModulesParallel(t, testSnapshot) }
+func testSnapshot(t *testing.T, exporter packagestest.Exporter) {
+	exported := packagestest.Export(t, exporter, []packagestest.Module{{
+		Name: "golang.org/fake",
+		Files: map[string]any{
+			"a/a.go": `package a; import "golang.org/fake/b"; var A b.B`,
+			"b/b.go": `package b; import "golang.org/fake/c"; type B struct{ F c.C }`,
+			"c/c.go": `package c; type C int`,
+		}}})
+	defer exported.Cleanup()
+
+	exported.Config.Mode = packages.LoadAllSyntax
+	initial, err := packages.Load(exported.Config, "golang.org/fake/a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := packages.ExportSnapshot(&buf, initial); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	got, err := packages.ImportSnapshot(bytes.NewReader(data), token.NewFileSet())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ID != initial[0].ID {
+		t.Fatalf("ImportSnapshot returned %v, want %v", got, initial)
+	}
+	a := got[0]
+	if !slices.Equal(a.GoFiles, initial[0].GoFiles) {
+		t.Errorf("GoFiles = %v, want %v", a.GoFiles, initial[0].GoFiles)
+	}
+	b := a.Imports["golang.org/fake/b"]
+	if b == nil || b.Types == nil || a.Types == nil {
+		t.Fatalf("ImportSnapshot did not restore types of a and its import b")
+	}
+	// The type of a.A is the type b.B of package b.
+	if typ, obj := a.Types.Scope().Lookup("A").Type(), b.Types.Scope().Lookup("B"); typ != obj.Type() {
+		t.Errorf("type of a.A is %v, want %v", typ, obj.Type())
+	}
+	// The type of field F of a.A is the type c.C of the indirect dependency c.
+	c := b.Imports["golang.org/fake/c"]
+	if c == nil || c.Types == nil {
+		t.Fatalf("ImportSnapshot did not restore types of indirect dependency c")
+	}
+	field := a.Types.Scope().Lookup("A").Type().Underlying().(*types.Struct).Field(0)
+	if want := c.Types.Scope().Lookup("C").Type(); field.Type() != want {
+		t.Errorf("type of a.A.F is %v, want %v", field.Type(), want)
+	}
+
+	// Adding a file to a package directory invalidates the snapshot.
+	extra := filepath.Join(filepath.Dir(exported.File("golang.org/fake", "a/a.go")), "extra.go")
+	if err := os.WriteFile(extra, []byte(`package a`), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := packages.ImportSnapshot(bytes.NewReader(data), token.NewFileSet()); err == nil || !strings.Contains(err.Error(), "have changed") {
+		t.Errorf("ImportSnapshot after adding a file returned %v, want error", err)
+	}
+	if err := os.Remove(extra); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := packages.ImportSnapshot(bytes.NewReader(data), token.NewFileSet()); err != nil {
+		t.Errorf("ImportSnapshot after removing the added file: %v", err)
+	}
+
+	// Changing a file invalidates the snapshot.
+	if err := os.WriteFile(exported.File("golang.org/fake", "b/b.go"), []byte(`package b; type B int`), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := packages.ImportSnapshot(bytes.NewReader(data), token.NewFileSet()); err == nil || !strings.Contains(err.Error(), "has changed") {
+		t.Errorf("ImportSnapshot of stale snapshot returned %v, want error", err)
+	}
+}
+
+func TestParallelism(t *testing.T) { testAllOrModulesParallel(t, testParallelism) }
+func testParallelism(t *testing.T, exporter packagestest.Exporter) {
+	files := map[string]any{
+		"a/a.go": `package a; import (_ "golang.org/fake/b"; _ "golang.org/fake/c"; _ "golang.org/fake/d"; _ "golang.org/fake/e")`,
+	}
+	for _, name := range []string{"b", "c", "d", "e"} {
+		files[name+"/"+name+".go"] = "package " + name
+	}
+	exported := packagestest.Export(t, exporter, []packagestest.Module{{
+		Name:  "golang.org/fake",
+		Files: files,
+	}})
+	defer exported.Cleanup()
+
+	for _, test := range []struct {
+		name         string
+		parallelism  int
+		memoryBudget int64
+	}{
+		{"workers", 1, 0},
+		{"memory", 0, 1}, // every package exceeds the budget
+	} {
+		var stats packages.LoadStats
+		cfg := *exported.Config
+		cfg.Mode = packages.LoadAllSyntax
+		cfg.Parallelism = test.parallelism
+		cfg.MemoryBudget = test.memoryBudget
+		cfg.Stats = &stats
+		if _, err := packages.Load(&cfg, "golang.org/fake/a"); err != nil {
+			t.Fatal(err)
+		}
+		if stats.Packages < 5 || stats.PeakConcurrency != 1 || stats.PeakMemory <= 0 {
+			t.Errorf("%s: got stats %+v, want at least 5 packages loaded one at a time", test.name, stats)
+		}
+	}
+}
+
+func TestErrorDetail(t *testing.T) {
+	testenv.NeedsGoPackages(t)
+	t.Parallel()
+
+	dir := writeTree(t, `
+-- go.mod --
+module example.com/m
+
+go 1.22
+-- a/a.go --
+package a
+
+import (
+	_ "example.org/missing"
+	_ "example.com/m/c"
+	_ "nosuchstd/x"
+	_ "example.com/m/y"
+)
+-- c/c.go --
+//go:build ignore
+
+package c
+-- x/x.go --
+package x
+
+import _ "example.com/m/y"
+-- y/y.go --
+package y
+
+import _ "example.com/m/x"
+`)
+	cfg := &packages.Config{
+		Dir:  dir,
+		Mode: packages.LoadAllSyntax,
+		Env:  append(os.Environ(), "GOPACKAGESDRIVER=off", "GOFLAGS=", "GOPROXY=off"),
+	}
+	initial, err := packages.Load(cfg, "./a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for pkg := range packages.Postorder(initial) {
+		for _, err := range pkg.Errors {
+			if err.Detail != nil {
+				got = append(got, fmt.Sprintf("%s: %T%+v", pkg.PkgPath, err.Detail, err.Detail))
+			}
+		}
+	}
+	sort.Strings(got)
+	want := []string{
+		"example.com/m/a: *packages.ImportError&{Path:example.org/missing}", // from the type checker
+		"example.com/m/c: *packages.BuildConstraintError&{Dir:" + filepath.Join(dir, "c") + "}",
+		"example.com/m/x: *packages.ImportError&{Path:example.com/m/y}", // import cycle
+		"example.org/missing: *packages.MissingModuleError&{Path:example.org/missing}",
+		"nosuchstd/x: *packages.ImportError&{Path:nosuchstd/x}",
+	}
+	for _, w := range want {
+		if !slices.Contains(got, w) {
+			t.Errorf("missing error detail %s", w)
+		}
+	}
+	if t.Failed() {
+		t.Logf("got details:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestCacheDir(t *testing.T) { testAllOrModulesParallel(t, testCacheDir) }
+func testCacheDir(t *testing.T, exporter packagestest.Exporter) {
+	exported := packagestest.Export(t, exporter, []packagestest.Module{{
+		Name: "golang.org/fake",
+		Files: map[string]any{
+			"a/a.go": `package a`,
+		}}})
+	defer exported.Cleanup()
+
+	// Recently modified files are not cached.
+	past := time.Now().Add(-time.Hour)
+	err := filepath.Walk(exported.Temp(), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		return os.Chtimes(path, past, past)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// load loads package a, and reports its files and
+	// whether the go command was run.
+	var ran atomic.Bool
+	exported.Config.Mode = packages.NeedName | packages.NeedFiles | packages.NeedExportFile
+	exported.Config.CacheDir = t.TempDir()
+	exported.Config.Logf = func(format string, args ...any) {
+		if msg := fmt.Sprintf(format, args...); strings.Contains(msg, "go list") && strings.Contains(msg, "-json=") {
+			ran.Store(true)
+		}
+	}
+	var exportFile string
+	load := func() ([]string, bool) {
+		ran.Store(false)
+		pkgs, err := packages.Load(exported.Config, "golang.org/fake/a")
+		if err != nil {
+			t.Fatal(err)
+		}
+		var files []string
+		for _, filename := range pkgs[0].GoFiles {
+			files = append(files, filepath.Base(filename))
+		}
+		exportFile = pkgs[0].ExportFile
+		return files, ran.Load()
+	}
+
+	if files, ran := load(); !ran || !slices.Equal(files, []string{"a.go"}) {
+		t.Errorf("first Load: got files %v, ran go list %t; want [a.go], true", files, ran)
+	}
+	if files, ran := load(); ran || !slices.Equal(files, []string{"a.go"}) {
+		t.Errorf("second Load: got files %v, ran go list %t; want [a.go], false", files, ran)
+	}
+
+	// The cached export data is a copy in the cache directory.
+	if !strings.HasPrefix(exportFile, exported.Config.CacheDir) {
+		t.Errorf("cached ExportFile = %q, want a file in %s", exportFile, exported.Config.CacheDir)
+	} else if _, err := os.Stat(exportFile); err != nil {
+		t.Error(err)
+	}
+
+	// A change to the effective go environment invalidates the cached result.
+	exported.Config.Env = append(exported.Config.Env, "GOFLAGS=-trimpath")
+	if _, ran := load(); !ran {
+		t.Errorf("Load after GOFLAGS change did not run go list")
+	}
+
+	// A new file invalidates the cached result.
+	if err := os.WriteFile(filepath.Join(filepath.Dir(exported.File("golang.org/fake", "a/a.go")), "b.go"), []byte("package a"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if files, ran := load(); !ran || !slices.Equal(files, []string{"a.go", "b.go"}) {
+		t.Errorf("Load after change: got files %v, ran go list %t; want [a.go b.go], true", files, ran)
+	}
+}
+
+func TestPartialLoad(t *testing.T) { testAllOrModulesParallel(t, testPartialLoad) }
+func testPartialLoad(t *testing.T, exporter packagestest.Exporter) {
+	exported := packagestest.Export(t, exporter, []packagestest.Module{{
+		Name: "golang.org/fake",
+		Files: map[string]any{
+			"a/a.go": `package a; import "golang.org/fake/b"; var A = b.B`,
+			"b/b.go": `package b; var B int`,
+		}}})
+	defer exported.Cleanup()
+
+	// Cancel the load while parsing a, which is loaded after b.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	exported.Config.Context = ctx
+	exported.Config.Mode = packages.LoadAllSyntax
+	exported.Config.ParseFile = func(fset *token.FileSet, filename string, src []byte) (*ast.File, error) {
+		if filepath.Base(filename) == "a.go" {
+			cancel()
+		}
+		return parser.ParseFile(fset, filename, src, 0)
+	}
+
+	// By default, Load returns only the error of the context.
+	pkgs, err := packages.Load(exported.Config, "golang.org/fake/a", "golang.org/fake/b")
+	if pkgs != nil || err != context.Canceled {
+		t.Fatalf("Load returned (%v, %v), want (nil, context.Canceled)", pkgs, err)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	exported.Config.Context = ctx
+	exported.Config.PartialResults = true
+	pkgs, err = packages.Load(exported.Config, "golang.org/fake/a", "golang.org/fake/b")
+	partial, ok := err.(*packages.PartialLoadError)
+	if !ok {
+		t.Fatalf("Load returned error %v, want a PartialLoadError", err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Load returned error %v, want context.Canceled", err)
+	}
+	if want := []string{"golang.org/fake/a"}; !slices.Equal(partial.Missing, want) {
+		t.Errorf("Missing = %v, want %v", partial.Missing, want)
+	}
+	if len(pkgs) != 1 || pkgs[0].ID != "golang.org/fake/b" || pkgs[0].Types == nil || !pkgs[0].Types.Complete() {
+		t.Errorf("Load returned %v, want complete package golang.org/fake/b", pkgs)
+	}
+}
+
+func TestSyntaxFiles(t *testing.T) { testAllOrModulesParallel(t, testSyntaxFiles) }
+func testSyntaxFiles(t *testing.T, exporter packagestest.Exporter) {
+	exported := packagestest.Export(t, exporter, []packagestest.Module{{
+		Name: "golang.org/fake",
+		Files: map[string]any{
+			"a/a.go":  `package a; import "golang.org/fake/b"; var A = b.B + a2`,
+			"a/a2.go": `package a; var a2 = len("a2")`,
+			"b/b.go":  `package b; var B = 1`,
+		}}})
+	defer exported.Cleanup()
+	filename := exported.File("golang.org/fake", "a/a.go")
+	exported.Config.SyntaxFiles = []string{filename}
+
+	for _, mode := range []packages.LoadMode{
+		packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles | packages.NeedSyntax,
+		packages.LoadAllSyntax,
+	} {
+		exported.Config.Mode = mode
+		initial, err := packages.Load(exported.Config, "golang.org/fake/a")
+		if err != nil {
+			t.Fatal(err)
+		}
+		a := initial[0]
+		if len(a.Syntax) != 1 || a.Fset.File(a.Syntax[0].FileStart).Name() != filename {
+			t.Errorf("mode %v: got %d syntax trees for package a, want only a.go", mode, len(a.Syntax))
+		}
+		if mode&packages.NeedTypes == 0 {
+			continue
+		}
+		if a.Types.Scope().Lookup("a2") == nil {
+			t.Errorf("mode %v: package a was not type-checked from all its files", mode)
+		}
+		for id := range a.TypesInfo.Defs {
+			if posn := a.Fset.Position(id.Pos()); posn.Filename != filename {
+				t.Errorf("mode %v: TypesInfo.Defs contains %s at %s, outside a.go", mode, id.Name, posn)
+			}
+		}
+		b := a.Imports["golang.org/fake/b"]
+		if len(b.Syntax) != 0 || b.Types == nil || !b.Types.Complete() {
+			t.Errorf("mode %v: got %d syntax trees and types %v for package b, want only types", mode, len(b.Syntax), b.Types)
+		}
+	}
+}
+
+func TestLoadPlatforms(t *testing.T) { testAllOrModulesParallel(t, testLoadPlatforms) }
+func testLoadPlatforms(t *testing.T, exporter packagestest.Exporter) {
+	exported := packagestest.Export(t, exporter, []packagestest.Module{{
+		Name: "golang.org/fake",
+		Files: map[string]any{
+			"a/a.go":         `package a; var A = os`,
+			"a/a_linux.go":   `package a; const os = "linux"`,
+			"a/a_windows.go": `package a; const os = "windows"`,
+		}}})
+	defer exported.Cleanup()
+	exported.Config.Mode = packages.LoadAllSyntax
+
+	linux := packages.Platform{GOOS: "linux", GOARCH: "amd64"}
+	windows := packages.Platform{GOOS: "windows", GOARCH: "386"}
+	result, err := packages.LoadPlatforms(exported.Config, []packages.Platform{linux, windows}, "golang.org/fake/a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []packages.Platform{linux, windows} {
+		pkgs := result[p]
+		if len(pkgs) != 1 {
+			t.Fatalf("%s: got %d packages, want 1", p, len(pkgs))
+		}
+		a := pkgs[0]
+		if got, want := len(a.GoFiles), 2; got != want {
+			t.Errorf("%s: got %d files %v, want %d", p, got, a.GoFiles, want)
+		}
+		if a.Types == nil {
+			t.Fatalf("%s: package has no types", p)
+		}
+		c, _ := a.Types.Scope().Lookup("os").(*types.Const)
+		if c == nil || constantpkg.StringVal(c.Val()) != p.GOOS {
+			t.Errorf("%s: os = %v, want %q", p, c, p.GOOS)
+		}
+		if got, want := a.TypesSizes.Sizeof(types.Typ[types.Uintptr]), map[string]int64{"amd64": 8, "386": 4}[p.GOARCH]; got != want {
+			t.Errorf("%s: sizeof(uintptr) = %d, want %d", p, got, want)
+		}
+	}
+	la, wa := result[linux][0], result[windows][0]
+	if la == wa {
+		t.Errorf("platforms share a package")
+	}
+	if la.Fset != wa.Fset {
+		t.Errorf("platforms do not share a FileSet")
+	}
+	// The syntax of a.go, common to both platforms, is parsed once.
+	syntaxOf := func(pkg *packages.Package, name string) *ast.File {
+		for _, f := range pkg.Syntax {
+			if filepath.Base(pkg.Fset.File(f.FileStart).Name()) == name {
+				return f
+			}
+		}
+		return nil
+	}
+	if f := syntaxOf(la, "a.go"); f == nil || f != syntaxOf(wa, "a.go") {
+		t.Errorf("platforms do not share the syntax of a.go")
+	}
+}
+
+func TestLazyTypes(t *testing.T) { testAllOrModulesParallel(t, testLazyTypes) }
+func testLazyTypes(t *testing.T, exporter packagestest.Exporter) {
+	exported := packagestest.Export(t, exporter, []packagestest.Module{{
+		Name: "golang.org/fake",
+		Files: map[string]any{
+			"a/a.go": `package a; import "golang.org/fake/b"; var A = b.B`,
+			"b/b.go": `package b; import "golang.org/fake/c"; var B = c.C; var unused int`,
+			"c/c.go": `package c; var C = 1`,
+		}}})
+	defer exported.Cleanup()
+	exported.Config.Mode = packages.NeedName | packages.NeedImports | packages.NeedDeps | packages.NeedTypes
+	exported.Config.LazyTypes = true
+
+	initial, err := packages.Load(exported.Config, "golang.org/fake/a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := initial[0]
+	if !a.Types.Complete() {
+		t.Errorf("types of initial package a are incomplete")
+	}
+	b := a.Imports["golang.org/fake/b"]
+	if b.Types.Complete() || b.Types.Scope().Lookup("unused") != nil {
+		t.Errorf("types of package b were decoded eagerly")
+	}
+	// Concurrent calls decode the export data once, without
+	// modifying the package in the Types field.
+	var wg sync.WaitGroup
+	results := make([]*types.Package, 4)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tpkg, err := b.LoadTypes()
+			if err != nil {
+				t.Error(err)
+			}
+			results[i] = tpkg
+			_ = b.Types.Scope().Names() // a concurrent reader
+		}()
+	}
+	wg.Wait()
+	loaded := results[0]
+	for _, tpkg := range results[1:] {
+		if tpkg != loaded {
+			t.Errorf("LoadTypes returned distinct packages %p and %p", loaded, tpkg)
+		}
+	}
+	if loaded == nil || loaded == b.Types || !loaded.Complete() || loaded.Scope().Lookup("unused") == nil {
+		t.Errorf("LoadTypes did not return new complete types of package b")
+	}
+	if b.Types.Complete() || b.Types.Scope().Lookup("unused") != nil {
+		t.Errorf("LoadTypes modified the Types field of package b")
+	}
+	if tpkg, err := a.LoadTypes(); err != nil || tpkg != a.Types {
+		t.Errorf("LoadTypes of eagerly loaded package a = %v, %v; want its Types", tpkg, err)
+	}
+}
+
+func TestWatcher(t *testing.T) { testAllOrModulesParallel(t, testWatcher) }
+func testWatcher(t *testing.T, exporter packagestest.Exporter) {
+	exported := packagestest.Export(t, exporter, []packagestest.Module{{
+		Name: "golang.org/fake",
+		Files: map[string]any{
+			"a/a.go": `package a; import _ "golang.org/fake/b"`,
+			"b/b.go": `package b`,
+			"c/c.go": `package c`,
+		}}})
+	defer exported.Cleanup()
+	exported.Config.Mode = packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps
+
+	w, err := packages.NewWatcher(exported.Config, "golang.org/fake/...")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var deltas []string
+	cancel := w.Subscribe(func(delta *packages.WatchDelta) {
+		deltas = append(deltas, fmt.Sprintf("added %v changed %v removed %v", delta.Added, delta.Changed, delta.Removed))
+	})
+	defer cancel()
+
+	// A change to b reloads b and its importer a, but not c.
+	if err := w.Changed(exported.File("golang.org/fake", "b/b.go")); err != nil {
+		t.Fatal(err)
+	}
+	// A new package is found by reloading all the patterns.
+	dFile := filepath.Join(filepath.Dir(exported.File("golang.org/fake", "c/c.go")), "..", "d", "d.go")
+	if err := os.MkdirAll(filepath.Dir(dFile), 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dFile, []byte("package d"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Changed(dFile); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"added [] changed [golang.org/fake/a golang.org/fake/b] removed []",
+		"added [golang.org/fake/d] changed [golang.org/fake/a golang.org/fake/b golang.org/fake/c] removed []",
+	}
+	if !slices.Equal(deltas, want) {
+		t.Errorf("got deltas:\n%s\nwant:\n%s", strings.Join(deltas, "\n"), strings.Join(want, "\n"))
+	}
+	if got := w.Packages(); len(got) != 4 {
+		t.Errorf("got packages %v, want 4", got)
+	}
+}
+
+// TestWatcherTests checks that the Watcher reloads the test variants
+// of a changed package, which cannot be named by a package path.
+func TestWatcherTests(t *testing.T) { testAllOrModulesParallel(t, testWatcherTests) }
+func testWatcherTests(t *testing.T, exporter packagestest.Exporter) {
+	exported := packagestest.Export(t, exporter, []packagestest.Module{{
+		Name: "golang.org/fake",
+		Files: map[string]any{
+			"b/b.go":      `package b`,
+			"b/b_test.go": `package b`,
+			"c/c.go":      `package c`,
+		}}})
+	defer exported.Cleanup()
+	exported.Config.Mode = packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps
+	exported.Config.Tests = true
+
+	w, err := packages.NewWatcher(exported.Config, "golang.org/fake/...")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var deltas []string
+	cancel := w.Subscribe(func(delta *packages.WatchDelta) {
+		deltas = append(deltas, fmt.Sprintf("added %v changed %v removed %v", delta.Added, delta.Changed, delta.Removed))
+	})
+	defer cancel()
+
+	if err := w.Changed(exported.File("golang.org/fake", "b/b_test.go")); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"added [] changed [golang.org/fake/b golang.org/fake/b [golang.org/fake/b.test] golang.org/fake/b.test] removed []",
+	}
+	if !slices.Equal(deltas, want) {
+		t.Errorf("got deltas:\n%s\nwant:\n%s", strings.Join(deltas, "\n"), strings.Join(want, "\n"))
+	}
+	if got := w.Packages(); len(got) != 4 {
+		t.Errorf("got packages %v, want 4", got)
+	}
+}
+
+func TestLoadVersioned(t *testing.T) {
+	testenv.NeedsGoPackages(t)
+	t.Parallel()
+
+	// Serve two versions of a module from a file system proxy.
+	proxy := t.TempDir()
+	for _, version := range []string{"v1.0.0", "v1.1.0"} {
+		if err := proxydir.WriteModuleVersion(proxy, "example.com/text", version, map[string][]byte{
+			"go.mod":               []byte("module example.com/text\n\ngo 1.22\n"),
+			"language/language.go": fmt.Appendf(nil, "package language\n\nconst Version = %q\n", version),
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := &packages.Config{
+		Dir:  t.TempDir(), // no go.mod file
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedModule,
+		Env: append(os.Environ(),
+			"GOPACKAGESDRIVER=off",
+			"GOFLAGS=-modcacherw",
+			"GOPROXY="+proxydir.ToURL(proxy),
+			"GOMODCACHE="+t.TempDir(),
+			"GOSUMDB=off",
+			"GOWORK=off"),
+		Logf: t.Logf,
+	}
+	for _, version := range []string{"v1.0.0", "v1.1.0"} {
+		initial, err := packages.Load(cfg, "example.com/text/language@"+version, "fmt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := fmt.Sprint(initial); got != "[example.com/text/language fmt]" {
+			t.Fatalf("Load(%s) = %s", version, got)
+		}
+		language := initial[0]
+		if language.Module == nil || language.Module.Version != version {
+			t.Errorf("Load(%s): got module %+v", version, language.Module)
+		}
+		if len(language.GoFiles) != 1 {
+			t.Fatalf("Load(%s): got files %v", version, language.GoFiles)
+		}
+		content, err := os.ReadFile(language.GoFiles[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(content), version) {
+			t.Errorf("Load(%s): got file %s:\n%s", version, language.GoFiles[0], content)
+		}
+	}
+
+	if _, err := packages.Load(cfg, "example.com/text/language@v1.0.0", "./..."); err == nil {
+		t.Errorf("Load of versioned and relative patterns succeeded unexpectedly")
+	}
+}
+
 func TestLoadSyntaxError(t *testing.T) { testAllOrModulesParallel(t, testLoadSyntaxError) }
 func testLoadSyntaxError(t *testing.T, exporter packagestest.Exporter) {
 	// A type error in a lower-level package (e) prevents go list
@@ -2798,6 +3508,219 @@ func main() {
 	}
 }
 
+func TestExternal_Protocol2(t *testing.T) {
+	testAllOrModulesParallel(t, testExternal_Protocol2)
+}
+func testExternal_Protocol2(t *testing.T, exporter packagestest.Exporter) {
+	skipIfShort(t, "builds and links fake driver binaries")
+	testenv.NeedsGoBuild(t)
+
+	tempdir := t.TempDir()
+	exported := packagestest.Export(t, exporter, []packagestest.Module{{
+		Name: "golang.org/fake",
+		Files: map[string]any{
+			// The driver streams its response in three messages,
+			// and in watch mode reports two invalidations.
+			"driver/main.go": `package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+func main() {
+	var req struct {
+		Protocol int  ` + "`json:\"protocol\"`" + `
+		Watch    bool ` + "`json:\"watch\"`" + `
+	}
+	if err := json.NewDecoder(os.Stdin).Decode(&req); err != nil || req.Protocol < 2 {
+		os.Exit(1)
+	}
+	if req.Watch {
+		fmt.Println(` + "`" + `{"Protocol": 2, "Capabilities": ["watch"]}` + "`" + `)
+		fmt.Println(` + "`" + `{"IDs": ["a"]}` + "`" + `)
+		fmt.Println(` + "`" + `{"All": true}` + "`" + `)
+		return
+	}
+	fmt.Println(` + "`" + `{"Protocol": 2, "Capabilities": ["watch"], "Roots": ["a"]}` + "`" + `)
+	fmt.Println(` + "`" + `{"Packages": [{"ID": "a", "Name": "a", "PkgPath": "example.com/a"}]}` + "`" + `)
+	fmt.Println(` + "`" + `{"Roots": ["b"], "Packages": [{"ID": "b", "Name": "b", "PkgPath": "example.com/b"}]}` + "`" + `)
+}
+`,
+			"v1_driver/main.go": `package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	io.ReadAll(os.Stdin)
+	fmt.Println("{}")
+}
+`,
+		}}})
+	defer exported.Cleanup()
+	baseEnv := exported.Config.Env
+
+	build := func(name string) string {
+		exe := filepath.Join(tempdir, name+".exe") // Add .exe because Windows expects it.
+		cmd := exec.Command("go", "build", "-o", exe, "golang.org/fake/"+name)
+		cmd.Env = baseEnv
+		cmd.Dir = exported.Config.Dir
+		if b, err := cmd.CombinedOutput(); err != nil {
+			t.Log(string(b))
+			t.Fatal(err)
+		}
+		return exe
+	}
+	driver, v1Driver := build("driver"), build("v1_driver")
+
+	exported.Config.Mode = packages.NeedName
+	exported.Config.Env = append(slices.Clone(baseEnv), "GOPACKAGESDRIVER="+driver)
+	initial, err := packages.Load(exported.Config, "example.com/...")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fmt.Sprint(initial), "[a b]"; got != want {
+		t.Errorf("Load with streaming driver: got %s, want %s", got, want)
+	}
+
+	var got []string
+	err = packages.Watch(exported.Config, func(inv *packages.DriverInvalidation) {
+		got = append(got, fmt.Sprintf("%v %t", inv.IDs, inv.All))
+	}, "example.com/...")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"[a] false", "[] true"}; !slices.Equal(got, want) {
+		t.Errorf("Watch reported %q, want %q", got, want)
+	}
+
+	// A Watcher reloads the packages after each invalidation. The
+	// packages of this driver have no files, so each reloads all
+	// the patterns.
+	w, err := packages.NewWatcher(exported.Config, "example.com/...")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var deltas []string
+	cancel := w.Subscribe(func(delta *packages.WatchDelta) {
+		deltas = append(deltas, fmt.Sprintf("added %v changed %v removed %v", delta.Added, delta.Changed, delta.Removed))
+	})
+	defer cancel()
+	if err := w.Watch(); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{
+		"added [] changed [a b] removed []",
+		"added [] changed [a b] removed []",
+	}; !slices.Equal(deltas, want) {
+		t.Errorf("Watcher.Watch: got deltas:\n%s\nwant:\n%s", strings.Join(deltas, "\n"), strings.Join(want, "\n"))
+	}
+
+	exported.Config.Env = append(slices.Clone(baseEnv), "GOPACKAGESDRIVER="+v1Driver)
+	err = packages.Watch(exported.Config, func(*packages.DriverInvalidation) {
+		t.Error("Watch with version 1 driver reported an invalidation")
+	}, "example.com/...")
+	if !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("Watch with version 1 driver returned %v, want ErrUnsupported", err)
+	}
+	w, err = packages.NewWatcher(exported.Config, "example.com/...")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Watch(); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("Watcher.Watch with version 1 driver returned %v, want ErrUnsupported", err)
+	}
+}
+
+func TestSourceFallback(t *testing.T) {
+	skipIfShort(t, "builds and links a fake driver binary")
+	testenv.NeedsGoBuild(t)
+	t.Parallel()
+
+	// The driver reports export data files that do not exist,
+	// as a build system may before it has built them.
+	dir := writeTree(t, `
+-- go.mod --
+module example.com
+go 1.21
+-- driver/main.go --
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	io.ReadAll(os.Stdin)
+	src := os.Getenv("FAKE_SRC")
+	missing := filepath.Join(src, "missing.a")
+	file := func(name string) []string { return []string{filepath.Join(src, name)} }
+	json.NewEncoder(os.Stdout).Encode(map[string]any{
+		"Compiler": "gc",
+		"Arch":     "amd64",
+		"Roots":    []string{"a"},
+		"Packages": []map[string]any{
+			{"ID": "a", "Name": "a", "PkgPath": "example.com/a", "GoFiles": file("a.go"), "CompiledGoFiles": file("a.go"),
+				"ExportFile": missing, "Imports": map[string]string{"example.com/b": "b"}},
+			{"ID": "b", "Name": "b", "PkgPath": "example.com/b", "GoFiles": file("b.go"), "CompiledGoFiles": file("b.go"),
+				"ExportFile": missing},
+		},
+	})
+}
+-- src/a.go --
+package a
+
+import "example.com/b"
+
+var A = b.B
+-- src/b.go --
+package b
+
+const B = 1
+`)
+	driver := filepath.Join(t.TempDir(), "driver.exe") // Add .exe because Windows expects it.
+	cmd := exec.Command("go", "build", "-o", driver, "./driver")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOFLAGS=", "GOWORK=off")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("building driver: %v\n%s", err, out)
+	}
+
+	for _, fallback := range []bool{false, true} {
+		cfg := &packages.Config{
+			Mode:           packages.NeedName | packages.NeedTypes,
+			Dir:            dir,
+			Env:            append(os.Environ(), "GOPACKAGESDRIVER="+driver, "FAKE_SRC="+filepath.Join(dir, "src")),
+			SourceFallback: fallback,
+		}
+		initial, err := packages.Load(cfg, "example.com/a")
+		if err != nil {
+			t.Fatal(err)
+		}
+		a := initial[0]
+		if !fallback {
+			if !a.IllTyped || len(a.Errors) == 0 {
+				t.Errorf("without fallback: package a is well typed, want error for missing export data")
+			}
+			continue
+		}
+		if a.IllTyped || len(a.Errors) > 0 {
+			t.Errorf("with fallback: package a is ill typed: %v", a.Errors)
+		}
+		if a.Types == nil || a.Types.Scope().Lookup("A") == nil {
+			t.Errorf("with fallback: package a has no types")
+		}
+	}
+}
+
 func TestInvalidPackageName(t *testing.T) {
 	testAllOrModulesParallel(t, testInvalidPackageName)
 }
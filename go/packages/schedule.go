@@ -0,0 +1,121 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package packages
+
+// This file defines the scheduler that bounds the number and
+// estimated memory of the packages that a loader parses and
+// type-checks at once (see Config.Parallelism and Config.MemoryBudget).
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"golang.org/x/sync/semaphore"
+)
+
+// memoryPerSourceByte is the estimated number of bytes of memory
+// needed to parse and type-check each byte of a package's source
+// files, used to apply Config.MemoryBudget.
+const memoryPerSourceByte = 32
+
+// LoadStats holds statistics about the type-checking phase of a Load,
+// which are useful for choosing Config.Parallelism and
+// Config.MemoryBudget.
+type LoadStats struct {
+	// Packages is the number of packages parsed from source.
+	Packages int
+
+	// PeakConcurrency is the largest number of packages
+	// parsed and type-checked at once.
+	PeakConcurrency int
+
+	// PeakMemory is the largest estimated memory of the packages
+	// parsed and type-checked at once, in bytes.
+	PeakMemory int64
+}
+
+// A scheduler bounds the packages that a loader parses and
+// type-checks concurrently, and records statistics about them.
+type scheduler struct {
+	workers *semaphore.Weighted // nil if the number is unbounded
+	memory  *semaphore.Weighted // nil if the memory is unbounded
+	budget  int64               // capacity of memory
+
+	mu     sync.Mutex
+	active int   // packages being loaded
+	inUse  int64 // estimated memory of active packages
+	stats  *LoadStats
+}
+
+// newScheduler returns a scheduler for the configuration.
+func newScheduler(cfg *Config) *scheduler {
+	s := &scheduler{stats: cfg.Stats}
+	if s.stats == nil {
+		s.stats = new(LoadStats)
+	}
+	if cfg.Parallelism > 0 {
+		s.workers = semaphore.NewWeighted(int64(cfg.Parallelism))
+	}
+	if cfg.MemoryBudget > 0 {
+		s.budget = cfg.MemoryBudget
+		s.memory = semaphore.NewWeighted(s.budget)
+	}
+	return s
+}
+
+// acquire waits until the package may be parsed and type-checked,
+// and returns a function to call once it has been. A package whose
+// estimated memory exceeds the budget is loaded alone.
+func (s *scheduler) acquire(ctx context.Context, lpkg *loaderPackage) (release func(), err error) {
+	if !lpkg.needsrc {
+		return func() {}, nil // export data is cheap
+	}
+
+	var size int64
+	for _, filename := range lpkg.CompiledGoFiles {
+		if info, err := os.Stat(filename); err == nil {
+			size += info.Size()
+		}
+	}
+	estimate := size * memoryPerSourceByte
+	weight := min(estimate, s.budget)
+
+	if s.workers != nil {
+		if err := s.workers.Acquire(ctx, 1); err != nil {
+			return nil, err
+		}
+	}
+	if s.memory != nil {
+		if err := s.memory.Acquire(ctx, weight); err != nil {
+			if s.workers != nil {
+				s.workers.Release(1)
+			}
+			return nil, err
+		}
+	}
+
+	s.mu.Lock()
+	s.active++
+	s.inUse += estimate
+	s.stats.Packages++
+	s.stats.PeakConcurrency = max(s.stats.PeakConcurrency, s.active)
+	s.stats.PeakMemory = max(s.stats.PeakMemory, s.inUse)
+	s.mu.Unlock()
+
+	return func() {
+		s.mu.Lock()
+		s.active--
+		s.inUse -= estimate
+		s.mu.Unlock()
+
+		if s.memory != nil {
+			s.memory.Release(weight)
+		}
+		if s.workers != nil {
+			s.workers.Release(1)
+		}
+	}, nil
+}
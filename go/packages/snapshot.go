@@ -0,0 +1,246 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package packages
+
+// This file defines the serialized form of a load result
+// (see ExportSnapshot).
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"go/types"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/gcexportdata"
+)
+
+// snapshotVersion is the version of the snapshot format.
+const snapshotVersion = 2
+
+// A snapshot is the serialized form of a graph of packages.
+type snapshot struct {
+	Version  int
+	Roots    []string               // IDs of the initial packages
+	Packages []*Package             // all packages, in dependencies-first order
+	Extra    map[string]*cacheExtra // fields omitted by Package's JSON form, by package ID
+	Types    map[string][]byte      // export data of each package with types, by package ID
+	Files    map[string]string      // SHA-256 hash of each file of the packages, by name
+	Dirs     map[string]string      // SHA-256 hash of the listing of each directory of the packages, by name
+}
+
+// ExportSnapshot writes to w a snapshot of the import graph whose
+// roots are pkgs, so that a later process may read it using
+// [ImportSnapshot] instead of loading the packages again.
+//
+// The snapshot records the metadata of each package, including its
+// files and the name of its export data file, and a hash of each of
+// its files and of the listing of each of its directories. If a
+// package has complete type information, the snapshot also records
+// it, in export data form. The Syntax, TypesInfo, and TypesSizes
+// fields are not recorded; a client that needs them must parse and
+// type-check the package's files again.
+func ExportSnapshot(w io.Writer, pkgs []*Package) error {
+	snap := snapshot{
+		Version: snapshotVersion,
+		Extra:   make(map[string]*cacheExtra),
+		Types:   make(map[string][]byte),
+		Files:   make(map[string]string),
+		Dirs:    make(map[string]string),
+	}
+	addDir := func(dir string) error {
+		if _, ok := snap.Dirs[dir]; ok || dir == "" {
+			return nil
+		}
+		hash, err := hashDir(dir)
+		if err != nil {
+			return err
+		}
+		snap.Dirs[dir] = hash
+		return nil
+	}
+	for _, pkg := range pkgs {
+		snap.Roots = append(snap.Roots, pkg.ID)
+	}
+	for pkg := range Postorder(pkgs) {
+		snap.Packages = append(snap.Packages, pkg)
+		snap.Extra[pkg.ID] = &cacheExtra{
+			Dir:        pkg.Dir,
+			Target:     pkg.Target,
+			ForTest:    pkg.ForTest,
+			Module:     pkg.Module,
+			DepsErrors: pkg.depsErrors,
+		}
+		if pkg.Types != nil && pkg.Types.Complete() && pkg.Types != types.Unsafe {
+			fset := pkg.Fset
+			if fset == nil {
+				fset = token.NewFileSet()
+			}
+			var buf bytes.Buffer
+			if err := gcexportdata.Write(&buf, fset, pkg.Types); err != nil {
+				return fmt.Errorf("writing types of %s: %v", pkg.ID, err)
+			}
+			snap.Types[pkg.ID] = buf.Bytes()
+		}
+		if err := addDir(pkg.Dir); err != nil {
+			return err
+		}
+		for _, list := range [][]string{pkg.GoFiles, pkg.CompiledGoFiles, pkg.OtherFiles, pkg.EmbedFiles} {
+			for _, filename := range list {
+				if _, ok := snap.Files[filename]; ok {
+					continue
+				}
+				hash, err := hashFile(filename)
+				if err != nil {
+					return err
+				}
+				snap.Files[filename] = hash
+				if err := addDir(filepath.Dir(filename)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return json.NewEncoder(w).Encode(snap)
+}
+
+// ImportSnapshot reads a snapshot written by [ExportSnapshot], and
+// returns its initial packages, connected to their dependencies as
+// they were when it was written. The type information of the
+// packages, if any, uses positions in fset; it must not be nil if
+// the snapshot records types.
+//
+// ImportSnapshot reports an error if a file of some package has
+// changed or been removed, or a file has been added to or removed
+// from one of their directories, since the snapshot was written.
+func ImportSnapshot(r io.Reader, fset *token.FileSet) ([]*Package, error) {
+	var snap snapshot
+	if err := json.NewDecoder(r).Decode(&snap); err != nil {
+		return nil, fmt.Errorf("reading packages snapshot: %v", err)
+	}
+	if snap.Version != snapshotVersion {
+		return nil, fmt.Errorf("packages snapshot has version %d, want %d", snap.Version, snapshotVersion)
+	}
+	for filename, want := range snap.Files {
+		got, err := hashFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		if got != want {
+			return nil, fmt.Errorf("%s has changed since the packages snapshot was written", filename)
+		}
+	}
+	for dir, want := range snap.Dirs {
+		got, err := hashDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		if got != want {
+			return nil, fmt.Errorf("the files of %s have changed since the packages snapshot was written", dir)
+		}
+	}
+
+	// Connect the graph. Dependencies precede their importers.
+	// As in loadFromExportData, the view of the export data reader
+	// for each package contains its transitive dependencies; it is
+	// computed from the views of its direct imports.
+	byID := make(map[string]*Package, len(snap.Packages))
+	views := make(map[*Package]map[string]*types.Package)
+	for _, pkg := range snap.Packages {
+		if extra := snap.Extra[pkg.ID]; extra != nil {
+			pkg.Dir = extra.Dir
+			pkg.Target = extra.Target
+			pkg.ForTest = extra.ForTest
+			pkg.Module = extra.Module
+			pkg.depsErrors = extra.DepsErrors
+		}
+		setListErrorDetails(pkg)
+		for path, imp := range pkg.Imports {
+			dep, ok := byID[imp.ID]
+			if !ok {
+				return nil, fmt.Errorf("package %s imports %s, which is missing from the packages snapshot", pkg.ID, imp.ID)
+			}
+			pkg.Imports[path] = dep
+		}
+		byID[pkg.ID] = pkg
+
+		if len(snap.Types) > 0 {
+			view := make(map[string]*types.Package)
+			for _, imp := range pkg.Imports {
+				maps.Copy(view, views[imp])
+				if imp.Types != nil {
+					view[imp.PkgPath] = imp.Types
+				}
+			}
+			views[pkg] = view
+		}
+		if pkg.PkgPath == "unsafe" {
+			pkg.Types = types.Unsafe
+		} else if data, ok := snap.Types[pkg.ID]; ok {
+			if err := readSnapshotTypes(pkg, fset, views[pkg], data); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	initial := make([]*Package, len(snap.Roots))
+	for i, id := range snap.Roots {
+		initial[i] = byID[id]
+		if initial[i] == nil {
+			return nil, fmt.Errorf("root package %s is missing from the packages snapshot", id)
+		}
+	}
+	return initial, nil
+}
+
+// readSnapshotTypes sets the Types of the package from its export
+// data, whose dependencies have already been read into view.
+func readSnapshotTypes(pkg *Package, fset *token.FileSet, view map[string]*types.Package, data []byte) error {
+	tpkg, err := gcexportdata.Read(bytes.NewReader(data), fset, view, pkg.PkgPath)
+	if err != nil {
+		return fmt.Errorf("reading types of %s: %v", pkg.ID, err)
+	}
+	pkg.Types = tpkg
+	pkg.Fset = fset
+	pkg.IllTyped = len(pkg.Errors) > 0
+	for _, imp := range pkg.Imports {
+		if imp.IllTyped {
+			pkg.IllTyped = true
+		}
+	}
+	return nil
+}
+
+// hashFile returns the hex-encoded SHA-256 hash of the file's contents.
+func hashFile(filename string) (string, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// hashDir returns the hex-encoded SHA-256 hash of the names of the
+// directory's entries.
+func hashDir(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	names := make([]string, len(entries))
+	for i, e := range entries {
+		names[i] = e.Name()
+	}
+	sum := sha256.Sum256([]byte(strings.Join(names, "\n")))
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -107,6 +107,37 @@ func Postorder(pkgs []*Package) iter.Seq[*Package] {
 	}
 }
 
+// ReverseDeps returns an iterator over the packages in the import
+// graph whose roots are pkgs that import one of the targets, directly
+// or indirectly. A target is enumerated only if it imports another.
+// Packages are enumerated in dependencies-first order.
+//
+// The graph must have been loaded with [NeedImports], and with
+// [NeedDeps] for it to include indirect dependencies. Targets are
+// compared by identity, so they must belong to the same graph.
+func ReverseDeps(pkgs []*Package, targets ...*Package) iter.Seq[*Package] {
+	return func(yield func(*Package) bool) {
+		isTarget := make(map[*Package]bool, len(targets))
+		for _, pkg := range targets {
+			isTarget[pkg] = true
+		}
+		// Dependencies are visited first, so the imports
+		// of each package have already been classified.
+		imports := make(map[*Package]bool) // package imports a target
+		for pkg := range Postorder(pkgs) {
+			for _, imp := range pkg.Imports {
+				if isTarget[imp] || imports[imp] {
+					imports[pkg] = true
+					if !yield(pkg) {
+						return
+					}
+					break
+				}
+			}
+		}
+	}
+}
+
 // -- copied from golang.org.x/tools/gopls/internal/util/moremaps --
 
 // sorted returns an iterator over the entries of m in key order.
@@ -0,0 +1,316 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package packages
+
+// This file defines Watcher, which keeps a load result up to date
+// as files change.
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// A Watcher maintains the result of loading a set of patterns,
+// reloading the affected packages when it is told that files have
+// changed, and notifying its subscribers of the difference.
+//
+// A Watcher does not observe the file system itself; its client
+// forwards the events of a file system notification mechanism, such
+// as fsnotify or an editor's, to [Watcher.Changed]. If the external
+// driver reports changes to the build graph itself (see [Watch]), the
+// client may instead call [Watcher.Watch], which reloads the packages
+// that the driver invalidates.
+//
+// After a change to the files of some packages, the Watcher reloads
+// only those packages and the packages that import them, in a single
+// call to [Load] whose patterns are file= queries for one existing Go
+// file of each (or, for a test main package, its directory). The
+// reloaded packages are therefore consistent with each other, but not
+// with the packages that were not reloaded: if [NeedTypes] is set,
+// types from packages loaded by different calls to Load must not be
+// mixed. After a change to a go.mod, go.sum, go.work, or go.work.sum
+// file, to a Go file that belongs to no known package directory, or
+// to a package none of whose Go files remain, the Watcher reloads all
+// the patterns.
+type Watcher struct {
+	cfg      Config
+	patterns []string
+
+	reloadMu sync.Mutex // serializes reloads
+
+	mu   sync.Mutex // guards the fields below
+	pkgs []*Package // initial packages of the current result
+	subs map[int]func(*WatchDelta)
+	next int // key of next subscriber
+}
+
+// A WatchDelta describes how the initial packages of a [Watcher]
+// changed after a reload. Changed packages are new Package values
+// with the same IDs as the ones they replace. Each list is sorted
+// by ID.
+type WatchDelta struct {
+	Added, Changed, Removed []*Package
+}
+
+// NewWatcher loads the packages named by the patterns, as if by
+// [Load], and returns a Watcher that maintains the result.
+func NewWatcher(cfg *Config, patterns ...string) (*Watcher, error) {
+	w := &Watcher{patterns: slices.Clone(patterns)}
+	if cfg != nil {
+		w.cfg = *cfg
+	}
+	pkgs, err := Load(&w.cfg, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	w.pkgs = pkgs
+	return w, nil
+}
+
+// Packages returns the current initial packages.
+func (w *Watcher) Packages() []*Package {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return slices.Clone(w.pkgs)
+}
+
+// Subscribe arranges for f to be called with the delta of each
+// reload that changes the initial packages, and returns a function
+// that cancels the subscription. Calls to f do not occur concurrently.
+func (w *Watcher) Subscribe(f func(*WatchDelta)) (cancel func()) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.subs == nil {
+		w.subs = make(map[int]func(*WatchDelta))
+	}
+	key := w.next
+	w.next++
+	w.subs[key] = f
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.subs, key)
+	}
+}
+
+// Changed reports that the named files have been created, modified,
+// or removed, and reloads the packages they affect. It notifies the
+// subscribers before it returns. If the reload fails, Changed returns
+// its error and the current packages are unchanged.
+func (w *Watcher) Changed(files ...string) error {
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
+	old := w.Packages()
+	affected, all := affectedPackages(old, files)
+	return w.reload(old, affected, all)
+}
+
+// Watch follows the invalidations reported by the external driver,
+// as if by [Watch], reloading the packages that each one affects, and
+// notifying the subscribers, as [Watcher.Changed] does for changed
+// files. It returns when the configuration's context is done or the
+// driver exits.
+//
+// If the driver does not support watching, Watch returns an error
+// satisfying errors.Is(err, errors.ErrUnsupported), and the client
+// must forward file changes to Changed instead. If a reload fails, the
+// current packages are unchanged, and Watch returns the error of the
+// first failed reload once the driver exits.
+func (w *Watcher) Watch() error {
+	var reloadErr error
+	err := Watch(&w.cfg, func(inv *DriverInvalidation) {
+		w.reloadMu.Lock()
+		defer w.reloadMu.Unlock()
+
+		old := w.Packages()
+		var affected []*Package
+		if !inv.All {
+			ids := make(map[string]bool)
+			for _, id := range inv.IDs {
+				ids[id] = true
+			}
+			for pkg := range Postorder(old) {
+				if ids[pkg.ID] {
+					affected = append(affected, pkg)
+				}
+			}
+		}
+		if err := w.reload(old, affected, inv.All); err != nil && reloadErr == nil {
+			reloadErr = err
+		}
+	}, w.patterns...)
+	if err != nil {
+		return err
+	}
+	return reloadErr
+}
+
+// reload reloads the affected packages of the current result old, or
+// all the patterns, and notifies the subscribers. Its caller holds
+// reloadMu.
+func (w *Watcher) reload(old, affected []*Package, all bool) error {
+	if !all && len(affected) == 0 {
+		return nil
+	}
+
+	// Reload the affected initial packages and their importers,
+	// each named by one of its files, as a package path may not
+	// identify a package (for example, a test variant) or may not
+	// be a valid pattern (for example, command-line-arguments).
+	patterns := w.patterns
+	stale := make(map[string]bool) // IDs of reloaded initial packages
+	if !all {
+		affectedOrImporter := make(map[*Package]bool)
+		for _, pkg := range affected {
+			affectedOrImporter[pkg] = true
+		}
+		for pkg := range ReverseDeps(old, affected...) {
+			affectedOrImporter[pkg] = true
+		}
+		patterns = nil
+		for _, pkg := range old {
+			if !affectedOrImporter[pkg] {
+				continue
+			}
+			stale[pkg.ID] = true
+			var pattern string
+			if file := existingGoFile(pkg); file != "" {
+				pattern = "file=" + file
+			} else if pkg.Dir != "" && len(pkg.GoFiles) > 0 {
+				// A package whose files are not in its directory,
+				// such as a test main package, is named by the
+				// directory, whose query also yields its variants.
+				pattern = filepath.Clean(pkg.Dir)
+			} else {
+				all = true // the package may no longer exist
+				patterns = w.patterns
+				break
+			}
+			if !slices.Contains(patterns, pattern) {
+				patterns = append(patterns, pattern)
+			}
+		}
+		if len(patterns) == 0 {
+			return nil // no initial package is affected
+		}
+	}
+	reloaded, err := Load(&w.cfg, patterns...)
+	if err != nil {
+		return err
+	}
+
+	// Merge the reloaded packages into the result.
+	var (
+		delta   WatchDelta
+		newByID = make(map[string]*Package)
+		pkgs    []*Package
+	)
+	for _, pkg := range reloaded {
+		newByID[pkg.ID] = pkg
+	}
+	for _, pkg := range old {
+		switch {
+		case newByID[pkg.ID] != nil:
+			pkgs = append(pkgs, newByID[pkg.ID])
+			delta.Changed = append(delta.Changed, newByID[pkg.ID])
+			delete(newByID, pkg.ID)
+		case all || stale[pkg.ID]:
+			delta.Removed = append(delta.Removed, pkg)
+		default:
+			pkgs = append(pkgs, pkg)
+		}
+	}
+	for _, pkg := range reloaded {
+		if newByID[pkg.ID] != nil {
+			pkgs = append(pkgs, pkg)
+			delta.Added = append(delta.Added, pkg)
+		}
+	}
+
+	for _, list := range [][]*Package{delta.Added, delta.Changed, delta.Removed} {
+		slices.SortFunc(list, func(x, y *Package) int { return strings.Compare(x.ID, y.ID) })
+	}
+
+	w.mu.Lock()
+	w.pkgs = pkgs
+	subs := make([]func(*WatchDelta), 0, len(w.subs))
+	for _, key := range keySlice(w.subs) {
+		subs = append(subs, w.subs[key])
+	}
+	w.mu.Unlock()
+
+	for _, f := range subs {
+		f(&delta)
+	}
+	return nil
+}
+
+// existingGoFile returns the name of a Go file in the directory of
+// the package that still exists, or "" if there is none.
+func existingGoFile(pkg *Package) string {
+	for _, file := range pkg.GoFiles {
+		if filepath.Dir(file) == filepath.Clean(pkg.Dir) {
+			if _, err := os.Stat(file); err == nil {
+				return file
+			}
+		}
+	}
+	return ""
+}
+
+// affectedPackages returns the packages in the import graph whose
+// roots are pkgs that may be affected by changes to the files, or
+// reports that all of them may be.
+func affectedPackages(pkgs []*Package, files []string) (affected []*Package, all bool) {
+	changed := make(map[string]bool)
+	for _, file := range files {
+		switch filepath.Base(file) {
+		case "go.mod", "go.sum", "go.work", "go.work.sum":
+			return nil, true
+		}
+		changed[filepath.Clean(file)] = true
+	}
+
+	dirs := make(map[string]bool) // directories of known packages
+	known := make(map[string]bool)
+	for pkg := range Postorder(pkgs) {
+		dirs[filepath.Clean(pkg.Dir)] = true
+		hit := false
+		for _, list := range [][]string{pkg.GoFiles, pkg.CompiledGoFiles, pkg.OtherFiles, pkg.EmbedFiles, pkg.IgnoredFiles} {
+			for _, file := range list {
+				file = filepath.Clean(file)
+				known[file] = true
+				if changed[file] {
+					hit = true
+				}
+			}
+		}
+		if !hit && pkg.Dir != "" {
+			// A file may have been added to the package's directory.
+			for file := range changed {
+				if filepath.Dir(file) == filepath.Clean(pkg.Dir) {
+					hit = true
+					break
+				}
+			}
+		}
+		if hit {
+			affected = append(affected, pkg)
+		}
+	}
+
+	// A new Go file outside known directories may form a new
+	// package that matches a pattern.
+	for file := range changed {
+		if !known[file] && !dirs[filepath.Dir(file)] && strings.HasSuffix(file, ".go") {
+			return nil, true
+		}
+	}
+	return affected, false
+}
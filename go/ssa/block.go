@@ -28,6 +28,9 @@ func (b *BasicBlock) String() string {
 func (b *BasicBlock) emit(i Instruction) Value {
 	i.setBlock(b)
 	b.Instrs = append(b.Instrs, i)
+	if f := b.parent; len(f.syntaxStack) > 0 {
+		f.setSyntax(i, f.syntaxStack[len(f.syntaxStack)-1])
+	}
 	v, _ := i.(Value)
 	return v
 }
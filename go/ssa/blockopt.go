@@ -57,6 +57,35 @@ func deleteUnreachableBlocks(f *Function) {
 	f.removeNilBlocks()
 }
 
+// deleteUnreachableArtifacts eliminates the blocks of f that have no
+// predecessors and are empty or contain only a jump, which the builder
+// creates after an unconditional transfer of control, e.g. at the end
+// of an if-statement whose body returns. It is used in
+// NaiveControlFlow mode, in lieu of deleteUnreachableBlocks.
+func deleteUnreachableArtifacts(f *Function) {
+	for changed := true; changed; {
+		changed = false
+		for i, b := range f.Blocks {
+			if i == 0 || b == nil || b == f.Recover || len(b.Preds) > 0 {
+				continue
+			}
+			if len(b.Instrs) == 0 {
+				// empty
+			} else if _, ok := b.Instrs[0].(*Jump); ok && len(b.Instrs) == 1 {
+				b.Succs[0].removePred(b)
+			} else {
+				continue
+			}
+			if debugBlockOpt {
+				fmt.Fprintln(os.Stderr, "unreachable artifact", b)
+			}
+			f.Blocks[i] = nil
+			changed = true
+		}
+	}
+	f.removeNilBlocks()
+}
+
 // jumpThreading attempts to apply simple jump-threading to block b,
 // in which a->b->c become a->c if b is just a Jump.
 // The result is true if the optimization was applied.
@@ -148,6 +177,11 @@ func fuseBlocks(f *Function, a *BasicBlock) bool {
 // completed function: dead block elimination, block fusion, jump
 // threading.
 func optimizeBlocks(f *Function) {
+	if f.Prog.mode&NaiveControlFlow != 0 {
+		deleteUnreachableArtifacts(f)
+		return
+	}
+
 	deleteUnreachableBlocks(f)
 
 	// Loop until no further progress.
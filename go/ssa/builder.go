@@ -81,6 +81,7 @@ import (
 	"os"
 	"runtime"
 	"sync"
+	"sync/atomic"
 
 	"slices"
 
@@ -177,6 +178,8 @@ func (b *builder) waitForSharedFunction(fn *Function) {
 //
 // Postcondition: fn.currentBlock is nil.
 func (b *builder) cond(fn *Function, e ast.Expr, t, f *BasicBlock) {
+	defer fn.enterSyntax(e)()
+
 	switch e := e.(type) {
 	case *ast.ParenExpr:
 		b.cond(fn, e.X, t, f)
@@ -279,6 +282,8 @@ func (b *builder) logicalBinop(fn *Function, e *ast.BinaryExpr) Value {
 // TypeAssertExpr, IndexExpr (when X is a map), and UnaryExpr (when Op
 // is token.ARROW).
 func (b *builder) exprN(fn *Function, e ast.Expr) Value {
+	defer fn.enterSyntax(e)()
+
 	typ := fn.typeOf(e).(*types.Tuple)
 	switch e := e.(type) {
 	case *ast.ParenExpr:
@@ -435,6 +440,8 @@ func (b *builder) builtin(fn *Function, obj *types.Builtin, args []ast.Expr, typ
 // - a[:] iff a is an array (not *array)
 // - references to variables in lexically enclosing functions.
 func (b *builder) addr(fn *Function, e ast.Expr, escaping bool) lvalue {
+	defer fn.enterSyntax(e)()
+
 	switch e := e.(type) {
 	case *ast.Ident:
 		if isBlankIdent(e) {
@@ -442,7 +449,7 @@ func (b *builder) addr(fn *Function, e ast.Expr, escaping bool) lvalue {
 		}
 		obj := fn.objectOf(e).(*types.Var)
 		var v Value
-		if g := fn.Prog.packageLevelMember(obj); g != nil {
+		if g := fn.Prog.declaredMember(obj, b); g != nil {
 			v = g.(*Global) // var (address)
 		} else {
 			v = fn.lookup(obj, escaping)
@@ -623,6 +630,7 @@ func (b *builder) assign(fn *Function, loc lvalue, e ast.Expr, isZero bool, sb *
 // to fn and returning the Value defined by the expression.
 func (b *builder) expr(fn *Function, e ast.Expr) Value {
 	e = ast.Unparen(e)
+	defer fn.enterSyntax(e)()
 
 	tv := fn.info.Types[e]
 
@@ -816,8 +824,7 @@ func (b *builder) expr0(fn *Function, e ast.Expr, tv types.TypeAndValue) Value {
 		}
 
 		// Package-level func or var?
-		// (obj must belong to same package or a direct import.)
-		if v := fn.Prog.packageLevelMember(obj); v != nil {
+		if v := fn.Prog.declaredMember(obj, b); v != nil {
 			if g, ok := v.(*Global); ok {
 				return emitLoad(fn, g) // var (address)
 			}
@@ -1159,6 +1166,8 @@ func (b *builder) assignOp(fn *Function, loc lvalue, val Value, op token.Token,
 // localValueSpec emits to fn code to define all of the vars in the
 // function-local ValueSpec, spec.
 func (b *builder) localValueSpec(fn *Function, spec *ast.ValueSpec) {
+	defer fn.enterSyntax(spec)()
+
 	switch {
 	case len(spec.Values) == len(spec.Names):
 		// e.g. var x, y = 0, 1
@@ -2641,7 +2650,7 @@ func (b *builder) buildYieldResume(fn *Function, jump *types.Var, exits []*exit,
 
 		case e.block == nil && e.label == nil: // return from fn?
 			// case EXIT(id): { return ... }
-			fn.emit(new(RunDefers))
+			emitRunDefers(fn)
 			results := make([]Value, len(fn.results))
 			for i, r := range fn.results {
 				results[i] = emitLoad(fn, r)
@@ -2666,6 +2675,7 @@ func (b *builder) stmt(fn *Function, _s ast.Stmt) {
 	// within the body of switch/typeswitch/select/for/range.
 	// It is effectively an additional default-nil parameter of stmt().
 	var label *lblock
+	defer fn.enterSyntax(_s)()
 start:
 	switch s := _s.(type) {
 	case *ast.EmptyStmt:
@@ -2737,6 +2747,9 @@ start:
 		deferstack := emitLoad(fn, fn.lookup(fn.deferstack, false))
 		v := Defer{pos: s.Defer, DeferStack: deferstack}
 		b.setCall(fn, s.Call, &v.Call)
+		if fn.explicit {
+			v.Pending = emitExplicitDefer(fn, &v.Call)
+		}
 		fn.emit(&v)
 
 		// A deferred call can cause recovery from panic,
@@ -2873,7 +2886,7 @@ func (b *builder) returnStmt(fn *Function, s *ast.ReturnStmt) {
 
 	// Run function calls deferred in this
 	// function when explicitly returning from it.
-	fn.emit(new(RunDefers))
+	emitRunDefers(fn)
 	// Reload (potentially) named result variables to form the result tuple.
 	results = results[:0]
 	for _, nr := range fn.results {
@@ -2892,13 +2905,18 @@ type buildFunc = func(*builder, *Function)
 //
 // Waits for any dependencies to finish building.
 func (b *builder) iterate() {
+	b.buildEnqueued()
+	b.buildshared.markDone()
+	b.buildshared.wait()
+}
+
+// buildEnqueued builds the functions that have been enqueued but
+// not yet built, including those enqueued while doing so.
+func (b *builder) buildEnqueued() {
 	for ; b.finished < len(b.fns); b.finished++ {
 		fn := b.fns[b.finished]
 		b.buildFunction(fn)
 	}
-
-	b.buildshared.markDone()
-	b.buildshared.wait()
 }
 
 // buildFunction builds SSA code for the body of function fn.  Idempotent.
@@ -2959,7 +2977,13 @@ func (b *builder) buildFromSyntax(fn *Function) {
 	fn.startBody()
 	fn.createSyntacticParams(recvField, functype)
 	fn.createDeferStack()
+	fn.explicit = fn.Prog.mode&ExplicitDefers != 0 && explicitDefersOK(body)
 	b.stmt(fn, body)
+	if fn.Prog.mode&NaiveControlFlow != 0 {
+		// Don't mistake the artifacts of unreachable code
+		// for predecessors of the current block.
+		deleteUnreachableArtifacts(fn)
+	}
 	if cb := fn.currentBlock; cb != nil && (cb == fn.Blocks[0] || cb == fn.Recover || cb.Preds != nil) {
 		// Control fell off the end of the function's body block.
 		//
@@ -2968,7 +2992,7 @@ func (b *builder) buildFromSyntax(fn *Function) {
 		// if this no-arg return is ill-typed for
 		// fn.Signature.Results, this block must be
 		// unreachable.  The sanity checker checks this.
-		fn.emit(new(RunDefers))
+		emitRunDefers(fn)
 		fn.emit(new(Return))
 	}
 	fn.finishBody()
@@ -3168,6 +3192,9 @@ var cpuLimit = make(chan unit, runtime.GOMAXPROCS(0))
 // Functions will be created for all necessary methods in those
 // packages on demand.
 //
+// The functions of a large package are built in parallel unless the
+// BuildSerially mode flag was set; the result is the same either way.
+//
 // Build is idempotent and thread-safe.
 func (p *Package) Build() { p.buildOnce.Do(p.build) }
 
@@ -3175,12 +3202,13 @@ func (p *Package) build() {
 	if p.info == nil {
 		return // synthetic package, e.g. "testmain"
 	}
+	p.buildMu.Lock()
+	defer p.buildMu.Unlock()
 	if p.Prog.mode&LogSource != 0 {
 		defer logStack("build %s", p)()
 	}
 
-	b := builder{fns: p.created}
-	b.iterate()
+	p.buildCreated()
 
 	// We no longer need transient information: ASTs or go/types deductions.
 	p.info = nil
@@ -3193,6 +3221,94 @@ func (p *Package) build() {
 	}
 }
 
+// Build builds SSA code for fn, without building the other functions
+// of its package, for tools that examine only a single function.
+// An anonymous function is built along with the function that
+// encloses it, so for an anonymous function, Build builds its
+// outermost enclosing function. Functions that fn causes to be
+// created, such as instances of generic functions and wrappers, are
+// built too.
+//
+// Functions built by Build are not built again by [Package.Build].
+//
+// Build is idempotent and thread-safe.
+func (fn *Function) Build() {
+	for fn.parent != nil {
+		fn = fn.parent
+	}
+	if fn.buildshared != nil {
+		// fn is a shared function (e.g. an instance),
+		// which is built by the builder that created it.
+		fn.buildshared.wait()
+		return
+	}
+	if fn.Pkg == nil {
+		return // e.g. a wrapper, built when created
+	}
+	fn.Pkg.buildMu.Lock()
+	defer fn.Pkg.buildMu.Unlock()
+	if fn.build == nil {
+		return // already built
+	}
+	b := builder{fns: []*Function{fn}}
+	b.iterate()
+}
+
+// minParallelFunctions is the number of created functions above which
+// a package's functions are built by several goroutines.
+const minParallelFunctions = 64
+
+// buildCreated builds the created functions of p. The functions of a
+// large package are shared among several builders, one per available
+// CPU token (see cpuLimit), unless the BuildSerially mode flag was set.
+// Since the values and blocks of each function are numbered by its
+// own builder state, the result does not depend on the schedule.
+func (p *Package) buildCreated() {
+	created := p.created
+	if p.Prog.mode&BuildSerially != 0 || len(created) < minParallelFunctions {
+		b := builder{fns: created}
+		b.iterate()
+		return
+	}
+
+	// Each worker claims the next unbuilt function, and builds it
+	// along with any functions it causes to be enqueued.
+	var next atomic.Int64
+	work := func() {
+		var b builder
+		for {
+			i := int(next.Add(1)) - 1
+			if i >= len(created) {
+				break
+			}
+			b.enqueue(created[i])
+			b.buildEnqueued()
+		}
+		b.buildshared.markDone()
+		b.buildshared.wait()
+	}
+
+	// Start a worker for each available token, without blocking:
+	// the caller (e.g. Program.Build) may hold a token already.
+	var wg sync.WaitGroup
+workers:
+	for range runtime.GOMAXPROCS(0) - 1 {
+		select {
+		case cpuLimit <- unit{}: // acquire a token
+		default:
+			break workers // no token is available
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-cpuLimit }() // release the token
+			work()
+		}()
+	}
+	work()
+	wg.Wait()
+}
+
 // buildPackageInit builds fn.Body for the synthetic package initializer.
 func (b *builder) buildPackageInit(fn *Function) {
 	p := fn.Pkg
@@ -3210,10 +3326,12 @@ func (b *builder) buildPackageInit(fn *Function) {
 		emitStore(fn, initguard, vTrue, token.NoPos)
 
 		// Call the init() function of each package we import.
+		// Those of packages that were not created are external
+		// and their calls are omitted.
 		for _, pkg := range p.Pkg.Imports() {
 			prereq := p.Prog.packages[pkg]
 			if prereq == nil {
-				panic(fmt.Sprintf("Package(%q).Build(): unsatisfied import: Program.CreatePackage(%q) was not called", p.Pkg.Path(), pkg.Path()))
+				continue
 			}
 			var v Call
 			v.Call.Value = prereq.init
@@ -1484,3 +1484,403 @@ func TestBuildPackageGo120(t *testing.T) {
 		})
 	}
 }
+
+// TestParallelBuildDeterministic checks that building the functions of
+// a large package in parallel produces the same result as building
+// them serially.
+func TestParallelBuildDeterministic(t *testing.T) {
+	var src strings.Builder
+	src.WriteString("package p\n\nfunc Id[T any](x T) T { return x }\n\ntype S struct{ n int }\n\n")
+	for i := range 200 {
+		fmt.Fprintf(&src, "type T%d int\n\n", i)
+		fmt.Fprintf(&src, "func (s *S) M%d() any { return Id(T%d(s.n)) }\n\n", i, i)
+		fmt.Fprintf(&src, "func F%d(xs []int) (sum int) {\n\tfor _, x := range xs {\n\t\tfunc() { sum += Id(x) * %d }()\n\t}\n\treturn\n}\n\n", i, i)
+	}
+
+	build := func(mode ssa.BuilderMode) string {
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, "p.go", src.String(), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pkg := types.NewPackage("p", "")
+		ssapkg, _, err := ssautil.BuildPackage(&types.Config{}, fset, pkg, []*ast.File{f}, mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var fns []string
+		for fn := range ssautil.AllFunctions(ssapkg.Prog) {
+			var buf bytes.Buffer
+			ssa.WriteFunction(&buf, fn)
+			fns = append(fns, buf.String())
+		}
+		sort.Strings(fns)
+		return strings.Join(fns, "\n")
+	}
+
+	const mode = ssa.SanityCheckFunctions | ssa.InstantiateGenerics
+	if serial, parallel := build(mode|ssa.BuildSerially), build(mode); serial != parallel {
+		t.Errorf("parallel build differs from serial build")
+	}
+}
+
+// TestFunctionBuild checks that Function.Build builds a single
+// function, along with its anonymous functions, and that Package.Build
+// does not build it again.
+func TestFunctionBuild(t *testing.T) {
+	const src = `package p
+
+func F() func() int { return func() int { return G[int]() } }
+
+func G[T any]() T { var zero T; return zero }
+
+func H() {}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Implicits:  make(map[ast.Node]types.Object),
+		Instances:  make(map[*ast.Ident]types.Instance),
+		Scopes:     make(map[ast.Node]*types.Scope),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	pkg, err := new(types.Config).Check("p", fset, []*ast.File{f}, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prog := ssa.NewProgram(fset, ssa.SanityCheckFunctions|ssa.InstantiateGenerics)
+	ssapkg := prog.CreatePackage(pkg, []*ast.File{f}, info, true)
+
+	fn := ssapkg.Func("F")
+	fn.Build()
+	if isEmpty(fn) || len(fn.AnonFuncs) != 1 || isEmpty(fn.AnonFuncs[0]) {
+		t.Fatalf("F and its anonymous function were not built")
+	}
+	if h := ssapkg.Func("H"); !isEmpty(h) {
+		t.Errorf("H was built by F.Build")
+	}
+	fn.AnonFuncs[0].Build() // no-op
+
+	entry := fn.Blocks[0]
+	ssapkg.Build()
+	if fn.Blocks[0] != entry {
+		t.Errorf("Package.Build built F again")
+	}
+	if h := ssapkg.Func("H"); isEmpty(h) {
+		t.Errorf("Package.Build did not build H")
+	}
+}
+
+// TestSyntaxOf checks that in RecordSyntax mode each instruction
+// records the innermost syntax node whose lowering emitted it.
+func TestSyntaxOf(t *testing.T) {
+	const src = `package p
+
+type T struct{ f int }
+
+func g(int) int
+
+func F(t *T, n int) int {
+	x := 0
+	for i := 0; i < n; i++ {
+		x += g(t.f)
+	}
+	return x
+}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, mode := range []ssa.BuilderMode{0, ssa.RecordSyntax} {
+		pkg := types.NewPackage("p", "")
+		ssapkg, _, err := ssautil.BuildPackage(&types.Config{}, fset, pkg, []*ast.File{f}, mode|ssa.SanityCheckFunctions)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fn := ssapkg.Func("F")
+
+		// Record each kind of instruction with the printed form of its syntax.
+		got := make(map[string]bool)
+		for _, b := range fn.Blocks {
+			for _, instr := range b.Instrs {
+				if n := fn.SyntaxOf(instr); n != nil {
+					got[fmt.Sprintf("%T: %T %s", instr, n, types.ExprString(exprOf(n)))] = true
+				}
+			}
+		}
+		if mode == 0 {
+			if len(got) > 0 {
+				t.Errorf("SyntaxOf returned non-nil results without RecordSyntax: %v", got)
+			}
+			continue
+		}
+		for _, want := range []string{
+			"*ssa.Call: *ast.CallExpr g(t.f)",
+			"*ssa.FieldAddr: *ast.SelectorExpr t.f",
+			"*ssa.BinOp: *ast.BinaryExpr i < n",
+			"*ssa.If: *ast.BinaryExpr i < n",
+			"*ssa.Phi: *ast.AssignStmt x", // x := 0
+			"*ssa.Return: *ast.ReturnStmt x",
+		} {
+			if !got[want] {
+				t.Errorf("no instruction with syntax %q; got %v", want, got)
+			}
+		}
+	}
+}
+
+// exprOf returns the first expression of a statement, or the node
+// itself if it is an expression.
+func exprOf(n ast.Node) ast.Expr {
+	switch n := n.(type) {
+	case ast.Expr:
+		return n
+	case *ast.AssignStmt:
+		return n.Lhs[0]
+	case *ast.ReturnStmt:
+		return n.Results[0]
+	}
+	return &ast.BadExpr{}
+}
+
+// TestNaiveControlFlow checks that NaiveControlFlow mode retains
+// unreachable blocks and the dead code within them.
+func TestNaiveControlFlow(t *testing.T) {
+	const src = `package p
+
+func g(int)
+
+func F(n int) int {
+	x := 1
+	for i := 0; i < n; i++ {
+		return x
+	}
+	g(x)
+	return 0
+	x = 2
+	g(x)
+	for {
+		x++
+	}
+}
+
+func G(n int) (x int) {
+	defer func() { recover() }()
+	if n > 0 {
+		panic(n)
+	}
+	x = 1
+	return
+	x = 3
+	return
+}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, mode := range []ssa.BuilderMode{0, ssa.NaiveControlFlow} {
+		pkg := types.NewPackage("p", "")
+		ssapkg, _, err := ssautil.BuildPackage(&types.Config{}, fset, pkg, []*ast.File{f}, mode|ssa.SanityCheckFunctions)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, test := range []struct {
+			fn   string
+			dead string // an instruction of the unreachable code
+		}{
+			{"F", "*t0 = 2:int"},
+			{"G", "*t0 = 3:int"},
+		} {
+			fn := ssapkg.Func(test.fn)
+			var sb strings.Builder
+			fn.WriteTo(&sb)
+			found := strings.Contains(sb.String(), test.dead)
+			if want := mode != 0; found != want {
+				t.Errorf("%v mode: unreachable instruction %q present in %s = %t, want %t:\n%s",
+					mode, test.dead, test.fn, found, want, sb.String())
+			}
+			// Unreachable blocks have no Idom and are
+			// dominated only by themselves.
+			unreachable := 0
+			for _, b := range fn.Blocks {
+				if b.Index == 0 || b == fn.Recover || b.Idom() != nil {
+					continue
+				}
+				unreachable++
+				for _, c := range fn.Blocks {
+					if c != b && c.Dominates(b) {
+						t.Errorf("%v mode: block %s of %s dominates unreachable block %s", mode, c, test.fn, b)
+					}
+				}
+			}
+			if found := unreachable > 0; found != (mode != 0) {
+				t.Errorf("%v mode: %s has %d unreachable blocks", mode, test.fn, unreachable)
+			}
+		}
+	}
+}
+
+// TestExternalDependencies checks that a package may be built without
+// creating the packages it imports, whose functions and variables are
+// then declared on demand from type information.
+func TestExternalDependencies(t *testing.T) {
+	const (
+		a = `package a
+type T int
+func (T) M(x int) string
+func F(x, y int) (T, error)
+func Id[X any](x X) X { return x }
+var V []string`
+		b = `package b
+import "a"
+func G() string {
+	t, _ := a.F(1, 2)
+	a.V = append(a.V, a.Id("x"))
+	f := a.F
+	_ = f
+	return t.M(len(a.V))
+}`
+	)
+	fset := token.NewFileSet()
+	tpkgs := make(map[string]*types.Package)
+	var files []*ast.File
+	var info *types.Info
+	for _, src := range []string{a, b} {
+		f, err := parser.ParseFile(fset, "", src, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		info = &types.Info{
+			Types:      make(map[ast.Expr]types.TypeAndValue),
+			Defs:       make(map[*ast.Ident]types.Object),
+			Uses:       make(map[*ast.Ident]types.Object),
+			Implicits:  make(map[ast.Node]types.Object),
+			Instances:  make(map[*ast.Ident]types.Instance),
+			Scopes:     make(map[ast.Node]*types.Scope),
+			Selections: make(map[*ast.SelectorExpr]*types.Selection),
+		}
+		conf := types.Config{Importer: importerFunc(func(path string) (*types.Package, error) { return tpkgs[path], nil })}
+		tpkg, err := conf.Check(f.Name.Name, fset, []*ast.File{f}, info)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tpkgs[tpkg.Path()] = tpkg
+		files = []*ast.File{f}
+	}
+
+	for _, mode := range []ssa.BuilderMode{ssa.SanityCheckFunctions, ssa.SanityCheckFunctions | ssa.InstantiateGenerics} {
+		// Create only package b, as if a had only export data.
+		prog := ssa.NewProgram(fset, mode)
+		bpkg := prog.CreatePackage(tpkgs["b"], files, info, false)
+		bpkg.Build()
+
+		if prog.Package(tpkgs["a"]) != nil {
+			t.Errorf("package a was created")
+		}
+		var got []string
+		for _, instr := range bpkg.Func("G").Blocks[0].Instrs {
+			for _, rand := range instr.Operands(nil) {
+				switch v := (*rand).(type) {
+				case *ssa.Function:
+					if v.Blocks != nil && v.Origin() == nil {
+						t.Errorf("%v: %s has a body", mode, v)
+					}
+					nparams := v.Signature.Params().Len()
+					if v.Signature.Recv() != nil {
+						nparams++
+					}
+					if len(v.Params) != nparams {
+						t.Errorf("%v: %s has %d params", mode, v, len(v.Params))
+					}
+					got = append(got, v.String())
+				case *ssa.Global:
+					if v.Pkg != nil {
+						t.Errorf("%v: external global %s has a package", mode, v)
+					}
+					got = append(got, v.String())
+				}
+			}
+		}
+		const want = "a.F a.V a.Id[string] a.V a.V (a.T).M"
+		if strings.Join(got, " ") != want {
+			t.Errorf("%v: operands of b.G = %s, want %s", mode, strings.Join(got, " "), want)
+		}
+	}
+}
+
+// TestExplicitDefers checks that, in ExplicitDefers mode, deferred
+// calls are made explicitly at each normal exit.
+func TestExplicitDefers(t *testing.T) {
+	const input = `package p
+
+func f(int)
+
+func g(cond bool) int {
+	defer f(1)
+	if cond {
+		defer f(2)
+		return 3
+	}
+	return 4
+}
+
+func loop() {
+	for i := range 3 {
+		defer f(i)
+	}
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", input, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, mode := range []ssa.BuilderMode{ssa.ExplicitDefers, ssa.ExplicitDefers | ssa.NaiveForm} {
+		pkg, _, err := ssautil.BuildPackage(&types.Config{}, fset, types.NewPackage("p", ""), []*ast.File{file}, ssa.SanityCheckFunctions|mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// count returns the numbers of RunDefers instructions,
+		// Defer instructions with a Pending flag, and calls to f
+		// in the named function.
+		count := func(name string) (rundefers, pending int, calls []string) {
+			for _, b := range pkg.Func(name).Blocks {
+				for _, instr := range b.Instrs {
+					switch instr := instr.(type) {
+					case *ssa.RunDefers:
+						rundefers++
+					case *ssa.Defer:
+						if instr.Pending != nil {
+							pending++
+						}
+					case *ssa.Call:
+						if callee := instr.Call.StaticCallee(); callee != nil && callee.Name() == "f" {
+							calls = append(calls, b.Comment)
+						}
+					}
+				}
+			}
+			return
+		}
+
+		// Each return of g calls each preceding deferred call, if pending.
+		if rundefers, pending, calls := count("g"); rundefers != 0 || pending != 2 || len(calls) != 4 {
+			t.Errorf("%v: g has %d rundefers, %d pending defers, explicit calls in %v; want 0, 2, 4 calls", mode, rundefers, pending, calls)
+		}
+		// A defer in a loop is not explicit.
+		if rundefers, pending, calls := count("loop"); rundefers != 1 || pending != 0 || len(calls) != 0 {
+			t.Errorf("%v: loop has %d rundefers, %d pending defers, explicit calls in %v; want 1, 0, none", mode, rundefers, pending, calls)
+		}
+	}
+}
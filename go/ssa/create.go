@@ -193,6 +193,13 @@ func membersFromDecl(pkg *Package, decl ast.Decl, goversion string) {
 //
 // The real work of building SSA form for each function is not done
 // until a subsequent call to Package.Build.
+//
+// It is not necessary to create packages for the dependencies of the
+// packages whose code is built, such as those for which only export
+// data is available. A reference to a function or variable of a
+// package that was not created denotes an external [Function], with
+// no Blocks, or a [Global], with no Pkg, that is declared on demand
+// from its type information, as are the methods of its types.
 func (prog *Program) CreatePackage(pkg *types.Package, files []*ast.File, info *types.Info, importable bool) *Package {
 	if pkg == nil {
 		panic("nil pkg") // otherwise pkg.Scope below returns types.Universe!
@@ -0,0 +1,161 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssa
+
+// This file implements the ExplicitDefers builder mode, in which the
+// deferred calls of a function are made explicitly at each of its
+// normal exits, rather than implicitly by a RunDefers instruction.
+//
+// For each defer statement, the builder spills the function and
+// arguments of the deferred call to locals of the entry block, and
+// sets a local "pending" flag. At each normal exit, in reverse order
+// of the defer statements, it emits a check of each flag, which it
+// clears before making the call:
+//
+//	defer f(x)           t0 = local bool (defer$pending)
+//	...                  t1 = local int (defer$arg)
+//	return               ...
+//	                     *t1 = x
+//	                     *t0 = true:bool
+//	                     defer pending t0 f(x)
+//	                     ...
+//	                     t2 = *t0
+//	                     if t2 goto defer.call else defer.done
+//	                 defer.call:
+//	                     *t0 = false:bool
+//	                     t3 = *t1
+//	                     f(t3)
+//	                     jump defer.done
+//	                 defer.done:
+//	                     return
+//
+// The Defer instruction is retained, so that the calls that are
+// pending when the function panics are made as usual; control then
+// resumes at the Recover block if one of them recovers.
+//
+// A flag can only represent a deferred call that is made at most
+// once per activation of the function, so functions with a defer
+// statement within a loop, or with a goto statement, are built as
+// usual.
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// A deferredCall records the spilled operands of a deferred call
+// in ExplicitDefers mode.
+type deferredCall struct {
+	call    CallCommon // operands are spill locals, or immutable values
+	pending *Alloc     // local flag, true if the call is pending
+}
+
+// explicitDefersOK reports whether the function body may be built in
+// ExplicitDefers mode, that is, whether each defer statement within
+// it is executed at most once per call.
+func explicitDefersOK(body *ast.BlockStmt) bool {
+	ok := true
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false // defers belong to the literal's function
+		case *ast.BranchStmt:
+			if n.Tok == token.GOTO {
+				ok = false // may form a loop
+			}
+		case *ast.ForStmt, *ast.RangeStmt:
+			ast.Inspect(n, func(n ast.Node) bool {
+				switch n.(type) {
+				case *ast.FuncLit:
+					return false
+				case *ast.DeferStmt:
+					ok = false
+				}
+				return ok
+			})
+		}
+		return ok
+	})
+	return ok
+}
+
+// entryLocal creates a local variable of type t whose Alloc
+// instruction is at the start of the entry block of fn, so that it
+// dominates all uses.
+func entryLocal(fn *Function, t types.Type, comment string) *Alloc {
+	v := &Alloc{Comment: comment}
+	v.setType(types.NewPointer(t))
+	entry := fn.Blocks[0]
+	v.setBlock(entry)
+	entry.Instrs = append([]Instruction{v}, entry.Instrs...)
+	fn.Locals = append(fn.Locals, v)
+	return v
+}
+
+// emitExplicitDefer spills the operands of the deferred call c to
+// locals and sets its pending flag, which it returns.
+func emitExplicitDefer(fn *Function, c *CallCommon) *Alloc {
+	spill := func(v Value) Value {
+		switch v.(type) {
+		case nil, *Const, *Function, *Builtin, *Global:
+			return v // immutable
+		}
+		local := entryLocal(fn, v.Type(), "defer$arg")
+		emitStore(fn, local, v, token.NoPos)
+		return local
+	}
+	d := &deferredCall{call: *c}
+	d.call.Value = spill(c.Value)
+	d.call.Args = make([]Value, len(c.Args))
+	for i, arg := range c.Args {
+		d.call.Args[i] = spill(arg)
+	}
+	d.pending = entryLocal(fn, tBool, "defer$pending")
+	emitStore(fn, d.pending, vTrue, token.NoPos)
+	fn.deferred = append(fn.deferred, d)
+	return d.pending
+}
+
+// emitRunDefers emits to fn the code to make its deferred calls when
+// it returns normally: a RunDefers instruction or, in ExplicitDefers
+// mode, a call of each pending deferred call in LIFO order.
+func emitRunDefers(fn *Function) {
+	if !fn.explicit {
+		fn.emit(new(RunDefers))
+		return
+	}
+	reload := func(v Value) Value {
+		if local, ok := v.(*Alloc); ok {
+			return emitLoad(fn, local)
+		}
+		return v
+	}
+	for i := len(fn.deferred) - 1; i >= 0; i-- {
+		d := fn.deferred[i]
+		call := fn.newBasicBlock("defer.call")
+		done := fn.newBasicBlock("defer.done")
+		emitIf(fn, emitLoad(fn, d.pending), call, done)
+
+		fn.currentBlock = call
+		emitStore(fn, d.pending, vFalse, token.NoPos)
+		v := &Call{Call: d.call}
+		v.Call.Value = reload(d.call.Value)
+		v.Call.Args = make([]Value, len(d.call.Args))
+		for i, arg := range d.call.Args {
+			v.Call.Args[i] = reload(arg)
+		}
+		switch results := v.Call.Signature().Results(); results.Len() {
+		case 1:
+			v.setType(results.At(0).Type())
+		default:
+			v.setType(results)
+		}
+		fn.emit(v)
+		emitJump(fn, done)
+
+		fn.currentBlock = done
+	}
+}
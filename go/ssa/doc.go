@@ -37,6 +37,19 @@
 // subsequent analyses; this pass can be skipped by setting the
 // NaiveForm builder flag.
 //
+// The builder also deletes statically unreachable blocks and
+// simplifies the control-flow graph. Tools that inspect unreachable
+// code may set the NaiveControlFlow builder flag to retain it: the
+// statements of unreachable blocks are then preserved verbatim,
+// including their (dead) stores to local variables.
+//
+// Deferred calls are normally made by a RunDefers instruction at
+// each return. Analyses of the paths through deferred code may set
+// the ExplicitDefers builder flag to make them explicit instead: each
+// normal exit then calls each deferred call that is pending, guarded
+// by a local flag set by its Defer instruction. Control resumes at
+// the Recover block after a panic is recovered, as usual.
+//
 // The primary interfaces of this package are:
 //
 //   - [Member]: a named member of a Go package.
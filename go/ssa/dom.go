@@ -29,7 +29,9 @@ import (
 // Idom returns the block that immediately dominates b:
 // its parent in the dominator tree, if any.
 // Neither the entry node (b.Index==0) nor recover node
-// (b==b.Parent().Recover()) have a parent.
+// (b==b.Parent().Recover()) have a parent, nor do the unreachable
+// blocks retained in NaiveControlFlow mode, each of which dominates
+// only itself.
 func (b *BasicBlock) Idom() *BasicBlock { return b.dom.idom }
 
 // Dominees returns the list of blocks that b immediately dominates:
@@ -41,6 +43,13 @@ func (b *BasicBlock) Dominates(c *BasicBlock) bool {
 	return b.dom.pre <= c.dom.pre && c.dom.post <= b.dom.post
 }
 
+// unreachable reports whether b is unreachable from the entry and
+// recover blocks, as is possible only in NaiveControlFlow mode.
+// Precondition: the dominator tree is up-to-date.
+func (b *BasicBlock) unreachable() bool {
+	return b.dom.idom == nil && b.Index != 0 && b != b.parent.Recover
+}
+
 // DomPreorder returns a new slice containing the blocks of f
 // in a preorder traversal of the dominator tree.
 func (f *Function) DomPreorder() []*BasicBlock {
@@ -112,7 +121,10 @@ func (lt *ltState) link(v, w *BasicBlock) {
 }
 
 // buildDomTree computes the dominator tree of f using the LT algorithm.
-// Precondition: all blocks are reachable (e.g. optimizeBlocks has been run).
+//
+// Blocks unreachable from the entry and recover blocks, which exist
+// only in NaiveControlFlow mode, are excluded from the tree: they
+// have no idom, and are numbered after all the others.
 func buildDomTree(f *Function) {
 	// The step numbers refer to the original LT paper; the
 	// reordering is due to Georgiadis.
@@ -138,14 +150,19 @@ func buildDomTree(f *Function) {
 	prenum := lt.dfs(root, 0, preorder)
 	recover := f.Recover
 	if recover != nil {
-		lt.dfs(recover, prenum, preorder)
+		prenum = lt.dfs(recover, prenum, preorder)
+	}
+
+	reached := make([]bool, n)
+	for _, b := range preorder[:prenum] {
+		reached[b.Index] = true
 	}
 
 	buckets := space[4*n : 5*n]
 	copy(buckets, preorder)
 
 	// In reverse preorder...
-	for i := int32(n) - 1; i > 0; i-- {
+	for i := prenum - 1; i > 0; i-- {
 		w := preorder[i]
 
 		// Step 3. Implicitly define the immediate dominator of each node.
@@ -161,6 +178,9 @@ func buildDomTree(f *Function) {
 		// Step 2. Compute the semidominators of all nodes.
 		lt.sdom[w.Index] = lt.parent[w.Index]
 		for _, v := range w.Preds {
+			if !reached[v.Index] {
+				continue
+			}
 			u := lt.eval(v)
 			if lt.sdom[u.Index].dom.pre < lt.sdom[w.Index].dom.pre {
 				lt.sdom[w.Index] = lt.sdom[u.Index]
@@ -184,7 +204,7 @@ func buildDomTree(f *Function) {
 
 	// Step 4. Explicitly define the immediate dominator of each
 	// node, in preorder.
-	for _, w := range preorder[1:] {
+	for _, w := range preorder[1:prenum] {
 		if w == root || w == recover {
 			w.dom.idom = nil
 		} else {
@@ -198,7 +218,17 @@ func buildDomTree(f *Function) {
 
 	pre, post := numberDomTree(root, 0, 0)
 	if recover != nil {
-		numberDomTree(recover, pre, post)
+		pre, post = numberDomTree(recover, pre, post)
+	}
+
+	// Number the unreachable blocks, if any, as singleton trees.
+	for _, b := range f.Blocks {
+		if !reached[b.Index] {
+			b.dom.pre = pre
+			b.dom.post = post
+			pre++
+			post++
+		}
 	}
 
 	// printDomTreeDot(os.Stderr, f)        // debugging
@@ -242,11 +272,30 @@ func sanityCheckDomTree(f *Function) {
 	var all big.Int
 	all.Set(one).Lsh(&all, uint(n)).Sub(&all, one)
 
+	// reachable is the set of blocks reachable from the roots.
+	var reachable big.Int
+	var visit func(b *BasicBlock)
+	visit = func(b *BasicBlock) {
+		if reachable.Bit(b.Index) == 0 {
+			reachable.SetBit(&reachable, b.Index, 1)
+			for _, succ := range b.Succs {
+				visit(succ)
+			}
+		}
+	}
+	visit(f.Blocks[0])
+	if f.Recover != nil {
+		visit(f.Recover)
+	}
+
 	// Initialization.
 	for i, b := range f.Blocks {
 		if i == 0 || b == f.Recover {
 			// A root is dominated only by itself.
 			D[i].SetBit(&D[0], 0, 1)
+		} else if reachable.Bit(i) == 0 {
+			// So is an unreachable block (see NaiveControlFlow).
+			D[i].SetBit(&D[i], i, 1)
 		} else {
 			// All other blocks are (initially) dominated
 			// by every block.
@@ -258,14 +307,16 @@ func sanityCheckDomTree(f *Function) {
 	for changed := true; changed; {
 		changed = false
 		for i, b := range f.Blocks {
-			if i == 0 || b == f.Recover {
+			if i == 0 || b == f.Recover || reachable.Bit(i) == 0 {
 				continue
 			}
-			// Compute intersection across predecessors.
+			// Compute intersection across reachable predecessors.
 			var x big.Int
 			x.Set(&all)
 			for _, pred := range b.Preds {
-				x.And(&x, &D[pred.Index])
+				if reachable.Bit(pred.Index) == 1 {
+					x.And(&x, &D[pred.Index])
+				}
 			}
 			x.SetBit(&x, i, 1) // a block always dominates itself.
 			if D[i].Cmp(&x) != 0 {
@@ -354,6 +354,8 @@ func (f *Function) finishBody() {
 	f.jump = nil
 	f.source = nil
 	f.exits = nil
+	f.syntaxStack = nil
+	f.deferred = nil
 
 	// Remove from f.Locals any Allocs that escape to the heap.
 	j := 0
@@ -390,6 +392,19 @@ func (f *Function) finishBody() {
 	// clear out other function state (keep consistent with buildParamsOnly)
 	f.subst = nil
 
+	// Forget the syntax of the instructions eliminated by optimization and lifting.
+	if f.instrSyntax != nil {
+		live := make(map[Instruction]ast.Node, len(f.instrSyntax))
+		for _, b := range f.Blocks {
+			for _, instr := range b.Instrs {
+				if n, ok := f.instrSyntax[instr]; ok {
+					live[instr] = n
+				}
+			}
+		}
+		f.instrSyntax = live
+	}
+
 	numberRegisters(f) // uses f.namedRegisters
 }
 
@@ -499,6 +514,40 @@ func (f *Function) emit(instr Instruction) Value {
 	return f.currentBlock.emit(instr)
 }
 
+// enterSyntax records, in RecordSyntax mode, that the instructions
+// emitted until the call of the returned function belong to the
+// lowering of node n. Typical usage is:
+//
+//	defer fn.enterSyntax(e)()
+func (f *Function) enterSyntax(n ast.Node) (exit func()) {
+	if f.Prog.mode&RecordSyntax == 0 {
+		return func() {}
+	}
+	f.syntaxStack = append(f.syntaxStack, n)
+	return func() { f.syntaxStack = f.syntaxStack[:len(f.syntaxStack)-1] }
+}
+
+// setSyntax records that n is the syntax of instruction instr of f.
+func (f *Function) setSyntax(instr Instruction, n ast.Node) {
+	if f.instrSyntax == nil {
+		f.instrSyntax = make(map[Instruction]ast.Node)
+	}
+	f.instrSyntax[instr] = n
+}
+
+// SyntaxOf returns the innermost syntax node whose lowering emitted
+// the instruction instr of function f, such as the *ast.CallExpr of a
+// Call, the *ast.SelectorExpr of a FieldAddr, or the *ast.AssignStmt
+// of a Store. It returns nil if there is no such node, as for the
+// instructions of synthetic functions, or if the program was not
+// built in [RecordSyntax] mode.
+//
+// A φ-node placed by the lifting of a local variable has the syntax
+// of the variable's Alloc instruction.
+func (f *Function) SyntaxOf(instr Instruction) ast.Node {
+	return f.instrSyntax[instr]
+}
+
 // RelString returns the full name of this function, qualified by
 // package name, receiver type, etc.
 //
@@ -554,7 +603,11 @@ func (f *Function) RelString(from *types.Package) string {
 
 	// Package-level function?
 	// Prefix with package name for cross-package references only.
-	if p := f.relPkg(); p != nil && p != from {
+	p := f.relPkg()
+	if p == nil && f.object != nil {
+		p = f.object.Pkg() // external function of a package that was not created
+	}
+	if p != nil && p != from {
 		return fmt.Sprintf("%s.%s", p.Path(), f.name)
 	}
 
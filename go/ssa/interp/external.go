@@ -17,6 +17,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 	"unicode/utf8"
 )
@@ -34,6 +35,7 @@ func init() {
 	// That little dot ۰ is an Arabic zero numeral (U+06F0), categories [Nd].
 	maps.Copy(externals, map[string]externalFn{
 		"(reflect.Value).Bool":            ext۰reflect۰Value۰Bool,
+		"(reflect.Value).Cap":             ext۰reflect۰Value۰Cap,
 		"(reflect.Value).CanAddr":         ext۰reflect۰Value۰CanAddr,
 		"(reflect.Value).CanInterface":    ext۰reflect۰Value۰CanInterface,
 		"(reflect.Value).Elem":            ext۰reflect۰Value۰Elem,
@@ -60,7 +62,10 @@ func init() {
 		"(reflect.rtype).Elem":            ext۰reflect۰rtype۰Elem,
 		"(reflect.rtype).Field":           ext۰reflect۰rtype۰Field,
 		"(reflect.rtype).In":              ext۰reflect۰rtype۰In,
+		"(reflect.rtype).Key":             ext۰reflect۰rtype۰Key,
 		"(reflect.rtype).Kind":            ext۰reflect۰rtype۰Kind,
+		"(reflect.rtype).Len":             ext۰reflect۰rtype۰Len,
+		"(reflect.rtype).Name":            ext۰reflect۰rtype۰Name,
 		"(reflect.rtype).NumField":        ext۰reflect۰rtype۰NumField,
 		"(reflect.rtype).NumIn":           ext۰reflect۰rtype۰NumIn,
 		"(reflect.rtype).NumMethod":       ext۰reflect۰rtype۰NumMethod,
@@ -98,7 +103,10 @@ func init() {
 		"runtime.GOROOT":                  ext۰runtime۰GOROOT,
 		"runtime.Goexit":                  ext۰runtime۰Goexit,
 		"runtime.Gosched":                 ext۰runtime۰Gosched,
+		"runtime.KeepAlive":               ext۰runtime۰KeepAlive,
 		"runtime.NumCPU":                  ext۰runtime۰NumCPU,
+		"runtime.NumGoroutine":            ext۰runtime۰NumGoroutine,
+		"runtime.SetFinalizer":            ext۰runtime۰SetFinalizer,
 		"sort.Float64s":                   ext۰sort۰Float64s,
 		"sort.Ints":                       ext۰sort۰Ints,
 		"sort.Strings":                    ext۰sort۰Strings,
@@ -286,10 +294,22 @@ func ext۰runtime۰Gosched(fr *frame, args []value) value {
 	return nil
 }
 
+func ext۰runtime۰KeepAlive(fr *frame, args []value) value {
+	return nil
+}
+
 func ext۰runtime۰NumCPU(fr *frame, args []value) value {
 	return runtime.NumCPU()
 }
 
+func ext۰runtime۰NumGoroutine(fr *frame, args []value) value {
+	return int(atomic.LoadInt32(&fr.i.goroutines))
+}
+
+func ext۰runtime۰SetFinalizer(fr *frame, args []value) value {
+	return nil // finalizers are never run
+}
+
 func ext۰time۰Sleep(fr *frame, args []value) value {
 	time.Sleep(time.Duration(args[0].(int64)))
 	return nil
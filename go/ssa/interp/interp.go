@@ -42,6 +42,12 @@
 //
 // * os.Exit is implemented using panic, causing deferred functions to
 // run.
+//
+// When the target program calls a function that has no Go body and no
+// implementation in the interpreter (such as one written in assembly),
+// or otherwise depends on an unsupported feature, the interpreter
+// reports an "unsupported: ..." error, which the target program cannot
+// recover, and Interpret returns exit code 2.
 package interp // import "golang.org/x/tools/go/ssa/interp"
 
 import (
@@ -93,10 +99,11 @@ type interpreter struct {
 }
 
 type deferred struct {
-	fn    value
-	args  []value
-	instr *ssa.Defer
-	tail  *deferred
+	fn      value
+	args    []value
+	instr   *ssa.Defer
+	pending *value // if non-nil, the call is made only if *pending is true
+	tail    *deferred
 }
 
 type frame struct {
@@ -166,6 +173,9 @@ func (fr *frame) runDefer(d *deferred) {
 // runDefers returns normally.
 func (fr *frame) runDefers() {
 	for d := fr.defers; d != nil; d = d.tail {
+		if d.pending != nil && !(*d.pending).(bool) {
+			continue // already called explicitly
+		}
 		fr.runDefer(d)
 	}
 	fr.defers = nil
@@ -276,6 +286,9 @@ func visitInstr(fr *frame, instr ssa.Instruction) continuation {
 			instr: instr,
 			tail:  *defers,
 		}
+		if instr.Pending != nil {
+			(*defers).pending = fr.get(instr.Pending).(*value)
+		}
 
 	case *ssa.Go:
 		fn, args := prepareCall(fr, &instr.Call)
@@ -519,13 +532,13 @@ func callSSA(i *interpreter, caller *frame, callpos token.Pos, fn *ssa.Function,
 			return ext(fr, args)
 		}
 		if fn.Blocks == nil {
-			panic("no code for function: " + name)
+			unsupported("no code for function %s", name)
 		}
 	}
 
 	// generic function body?
 	if fn.TypeParams().Len() > 0 && len(fn.TypeArgs()) == 0 {
-		panic("interp requires ssa.BuilderMode to include InstantiateGenerics to execute generics")
+		unsupported("generic function body %s; ssa.BuilderMode must include InstantiateGenerics to execute generics", fn)
 	}
 
 	fr.env = make(map[ssa.Value]value)
@@ -655,6 +668,10 @@ func doRecover(caller *frame) value {
 
 		// TODO(adonovan): support runtime.Goexit.
 		switch p := p.(type) {
+		case unsupportedError:
+			// The target program cannot recover from a
+			// limitation of the interpreter.
+			panic(p)
 		case targetPanic:
 			// The target program explicitly called panic().
 			return p.v
@@ -725,6 +742,8 @@ func Interpret(mainpkg *ssa.Package, mode Mode, sizes types.Sizes, filename stri
 		case exitPanic:
 			exitCode = int(p)
 			return
+		case unsupportedError:
+			fmt.Fprintln(os.Stderr, "interp:", p.Error())
 		case targetPanic:
 			fmt.Fprintln(os.Stderr, "panic:", toString(p.v))
 		case runtime.Error:
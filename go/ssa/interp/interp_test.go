@@ -125,6 +125,7 @@ var testdataTests = []string{
 	"fixedbugs/issue69929.go",
 	"forvarlifetime_go122.go",
 	"forvarlifetime_old.go",
+	"generics.go",
 	"ifaceconv.go",
 	"ifaceprom.go",
 	"initorder.go",
@@ -155,6 +156,21 @@ func init() {
 
 // run runs a single test. On success it returns the captured std{out,err}.
 func run(t *testing.T, input string, goroot string) string {
+	capturedOutput, exitCode := interpret(t, input, goroot, 0)
+	if exitCode != 0 {
+		t.Fatalf("interpreting %s: exit code was %d", input, exitCode)
+	}
+	// $GOROOT/test tests use this convention:
+	if strings.Contains(capturedOutput, "BUG") {
+		t.Fatalf("interpreting %s: exited zero but output contained 'BUG'", input)
+	}
+	return capturedOutput
+}
+
+// interpret loads, builds (with the additional builder mode flags),
+// and interprets a single program, and returns its captured
+// std{out,err} and exit code.
+func interpret(t *testing.T, input string, goroot string, mode ssa.BuilderMode) (string, int) {
 	testenv.NeedsExec(t) // really we just need os.Pipe, but os/exec uses pipes
 
 	t.Logf("Input: %s\n", input)
@@ -208,7 +224,7 @@ func run(t *testing.T, input string, goroot string) string {
 		t.Fatalf("conf.Load(%s) failed: %s", input, err)
 	}
 
-	bmode := ssa.InstantiateGenerics | ssa.SanityCheckFunctions
+	bmode := ssa.InstantiateGenerics | ssa.SanityCheckFunctions | mode
 	// bmode |= ssa.PrintFunctions // enable for debugging
 	prog := ssautil.CreateProgram(iprog, bmode)
 	prog.Build()
@@ -268,17 +284,10 @@ func run(t *testing.T, input string, goroot string) string {
 	// imode |= interp.EnableTracing // enable for debugging
 	exitCode := interp.Interpret(mainPkg, imode, sizes, input, []string{})
 	capturedOutput := restore()
-	if exitCode != 0 {
-		t.Fatalf("interpreting %s: exit code was %d", input, exitCode)
-	}
-	// $GOROOT/test tests use this convention:
-	if strings.Contains(capturedOutput, "BUG") {
-		t.Fatalf("interpreting %s: exited zero but output contained 'BUG'", input)
-	}
 
 	hint = "" // call off the hounds
 
-	return capturedOutput
+	return capturedOutput, exitCode
 }
 
 // makeGoroot copies testdata/src into the "src" directory of a temporary
@@ -337,6 +346,37 @@ func TestTestdataFiles(t *testing.T) {
 	}
 }
 
+// TestExplicitDefers runs the interpreter on the tests of deferred
+// calls built in ExplicitDefers mode.
+func TestExplicitDefers(t *testing.T) {
+	goroot := makeGoroot(t)
+	for _, input := range []string{"defer.go", "recover.go", "rangefunc.go"} {
+		t.Run(input, func(t *testing.T) {
+			output, exitCode := interpret(t, filepath.Join("testdata", input), goroot, ssa.ExplicitDefers)
+			if exitCode != 0 || strings.Contains(output, "BUG") {
+				t.Fatalf("interpreting %s: exit code %d:\n%s", input, exitCode, output)
+			}
+		})
+	}
+}
+
+// TestUnsupported checks that the interpreter reports the use of an
+// unsupported feature as an error that the target cannot recover.
+func TestUnsupported(t *testing.T) {
+	goroot := makeGoroot(t)
+	output, exitCode := interpret(t, filepath.Join("testdata", "unsupported.go"), goroot, 0)
+	if exitCode != 2 {
+		t.Errorf("exit code was %d, want 2", exitCode)
+	}
+	const want = "unsupported: no code for function main.asm"
+	if !strings.Contains(output, want) {
+		t.Errorf("output does not contain %q:\n%s", want, output)
+	}
+	if strings.Contains(output, "recovered") {
+		t.Errorf("target program recovered from unsupported feature:\n%s", output)
+	}
+}
+
 // TestGorootTest runs the interpreter on $GOROOT/test/*.go.
 func TestGorootTest(t *testing.T) {
 	testenv.NeedsGOROOTDir(t, "test")
@@ -104,6 +104,14 @@ func (m *hashmap) insert(k hashable, v value) {
 	m.length++
 }
 
+// clear removes all associations from the map.
+func (m *hashmap) clear() {
+	if m != nil {
+		clear(m.table)
+		m.length = 0
+	}
+}
+
 // len returns the number of key/value associations in the map.
 func (m *hashmap) len() int {
 	if m != nil {
@@ -31,6 +31,20 @@ func (p targetPanic) String() string {
 // If the target program calls exit, the interpreter panics with this type.
 type exitPanic int
 
+// If the target program uses a feature that the interpreter does not
+// support, the interpreter panics with this type. Unlike a runtime
+// error, it cannot be recovered by the target program.
+type unsupportedError string
+
+func (e unsupportedError) Error() string {
+	return "unsupported: " + string(e)
+}
+
+// unsupported panics with an unsupportedError describing the feature.
+func unsupported(format string, args ...any) {
+	panic(unsupportedError(fmt.Sprintf(format, args...)))
+}
+
 // constValue returns the value of the constant with the
 // dynamic type tag appropriate for c.Type().
 func constValue(c *ssa.Const) value {
@@ -994,6 +1008,24 @@ func callBuiltin(caller *frame, callpos token.Pos, fn *ssa.Builtin, args []value
 		}
 		return nil
 
+	case "clear": // clear(map[K]V) or clear([]T)
+		switch x := args[0].(type) {
+		case map[value]value:
+			clear(x)
+		case *hashmap:
+			x.clear()
+		case []value:
+			elem := fn.Type().(*types.Signature).Params().At(0).Type().Underlying().(*types.Slice).Elem()
+			for i := range x {
+				x[i] = zero(elem)
+			}
+		case nil:
+			// nil map or slice
+		default:
+			panic(fmt.Sprintf("clear: illegal operand: %T", x))
+		}
+		return nil
+
 	case "print", "println": // print(any, ...)
 		ln := fn.Name() == "println"
 		var buf bytes.Buffer
@@ -1100,7 +1132,8 @@ func callBuiltin(caller *frame, callpos token.Pos, fn *ssa.Builtin, args []value
 		return &caller.defers
 	}
 
-	panic("unknown built-in: " + fn.Name())
+	unsupported("built-in %s", fn.Name())
+	return nil
 }
 
 func rangeIter(x value, t types.Type) iter {
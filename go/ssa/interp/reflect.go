@@ -15,6 +15,8 @@ import (
 	"go/token"
 	"go/types"
 	"reflect"
+	"strconv"
+	"strings"
 	"unsafe"
 
 	"golang.org/x/tools/go/ssa"
@@ -107,6 +109,31 @@ func ext۰reflect۰rtype۰In(fr *frame, args []value) value {
 	return makeReflectType(rtype{args[0].(rtype).t.(*types.Signature).Params().At(i).Type()})
 }
 
+func ext۰reflect۰rtype۰Key(fr *frame, args []value) value {
+	// Signature: func (t reflect.rtype) reflect.Type
+	return makeReflectType(rtype{args[0].(rtype).t.Underlying().(*types.Map).Key()})
+}
+
+func ext۰reflect۰rtype۰Len(fr *frame, args []value) value {
+	// Signature: func (t reflect.rtype) int
+	return int(args[0].(rtype).t.Underlying().(*types.Array).Len())
+}
+
+func ext۰reflect۰rtype۰Name(fr *frame, args []value) value {
+	// Signature: func (t reflect.rtype) string
+	switch t := types.Unalias(args[0].(rtype).t).(type) {
+	case *types.Basic:
+		return t.Name()
+	case *types.Named:
+		// The name includes the type arguments, if any.
+		var buf strings.Builder
+		buf.WriteString(t.Obj().Name())
+		writeReflectTypeArgs(&buf, t.TypeArgs())
+		return buf.String()
+	}
+	return ""
+}
+
 func ext۰reflect۰rtype۰Kind(fr *frame, args []value) value {
 	// Signature: func (t reflect.rtype) uint
 	return uint(reflectKind(args[0].(rtype).t))
@@ -145,7 +172,156 @@ func ext۰reflect۰rtype۰Size(fr *frame, args []value) value {
 
 func ext۰reflect۰rtype۰String(fr *frame, args []value) value {
 	// Signature: func (t reflect.rtype) string
-	return args[0].(rtype).t.String()
+	return reflectTypeString(args[0].(rtype).t)
+}
+
+// reflectTypeString returns the string form of t used by package
+// reflect, which qualifies names by package name, not path, and
+// omits the spaces between type arguments, e.g. "a.Pair[int,string]".
+func reflectTypeString(t types.Type) string {
+	var buf strings.Builder
+	writeReflectType(&buf, t)
+	return buf.String()
+}
+
+// writeReflectType writes the reflect string form of t to buf.
+func writeReflectType(buf *strings.Builder, t types.Type) {
+	switch t := t.(type) {
+	case *types.Alias:
+		writeReflectType(buf, types.Unalias(t))
+
+	case *types.Named:
+		if pkg := t.Obj().Pkg(); pkg != nil {
+			buf.WriteString(pkg.Name())
+			buf.WriteByte('.')
+		}
+		buf.WriteString(t.Obj().Name())
+		writeReflectTypeArgs(buf, t.TypeArgs())
+
+	case *types.TypeParam:
+		buf.WriteString(t.Obj().Name())
+
+	case *types.Pointer:
+		buf.WriteByte('*')
+		writeReflectType(buf, t.Elem())
+
+	case *types.Slice:
+		buf.WriteString("[]")
+		writeReflectType(buf, t.Elem())
+
+	case *types.Array:
+		fmt.Fprintf(buf, "[%d]", t.Len())
+		writeReflectType(buf, t.Elem())
+
+	case *types.Map:
+		buf.WriteString("map[")
+		writeReflectType(buf, t.Key())
+		buf.WriteByte(']')
+		writeReflectType(buf, t.Elem())
+
+	case *types.Chan:
+		switch t.Dir() {
+		case types.SendRecv:
+			buf.WriteString("chan ")
+		case types.SendOnly:
+			buf.WriteString("chan<- ")
+		case types.RecvOnly:
+			buf.WriteString("<-chan ")
+		}
+		// chan (<-chan T) requires parens.
+		elem, paren := t.Elem().(*types.Chan)
+		paren = paren && t.Dir() == types.SendRecv && elem.Dir() == types.RecvOnly
+		if paren {
+			buf.WriteByte('(')
+		}
+		writeReflectType(buf, t.Elem())
+		if paren {
+			buf.WriteByte(')')
+		}
+
+	case *types.Signature:
+		buf.WriteString("func")
+		writeReflectSignature(buf, t)
+
+	case *types.Struct:
+		buf.WriteString("struct{")
+		for i := range t.NumFields() {
+			if i > 0 {
+				buf.WriteString("; ")
+			}
+			f := t.Field(i)
+			if !f.Embedded() {
+				buf.WriteString(f.Name())
+				buf.WriteByte(' ')
+			}
+			writeReflectType(buf, f.Type())
+			if tag := t.Tag(i); tag != "" {
+				buf.WriteByte(' ')
+				buf.WriteString(strconv.Quote(tag))
+			}
+		}
+		buf.WriteByte('}')
+
+	case *types.Interface:
+		buf.WriteString("interface{")
+		for i := range t.NumMethods() {
+			if i > 0 {
+				buf.WriteString("; ")
+			}
+			m := t.Method(i)
+			buf.WriteString(m.Name())
+			writeReflectSignature(buf, m.Type().(*types.Signature))
+		}
+		buf.WriteByte('}')
+
+	default: // *types.Basic, and anything else
+		buf.WriteString(types.TypeString(t, (*types.Package).Name))
+	}
+}
+
+// writeReflectTypeArgs writes the type argument list, if any, in
+// reflect form, without spaces.
+func writeReflectTypeArgs(buf *strings.Builder, args *types.TypeList) {
+	if args.Len() == 0 {
+		return
+	}
+	buf.WriteByte('[')
+	for i := range args.Len() {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		writeReflectType(buf, args.At(i))
+	}
+	buf.WriteByte(']')
+}
+
+// writeReflectSignature writes the parameters and results of sig.
+func writeReflectSignature(buf *strings.Builder, sig *types.Signature) {
+	writeReflectTuple(buf, sig.Params(), sig.Variadic())
+	if res := sig.Results(); res.Len() == 1 {
+		buf.WriteByte(' ')
+		writeReflectType(buf, res.At(0).Type())
+	} else if res.Len() > 1 {
+		buf.WriteByte(' ')
+		writeReflectTuple(buf, res, false)
+	}
+}
+
+// writeReflectTuple writes a parenthesized parameter list.
+func writeReflectTuple(buf *strings.Builder, tuple *types.Tuple, variadic bool) {
+	buf.WriteByte('(')
+	for i := range tuple.Len() {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		T := tuple.At(i).Type()
+		if variadic && i == tuple.Len()-1 {
+			buf.WriteString("...")
+			T = T.(*types.Slice).Elem()
+		}
+		writeReflectType(buf, T)
+	}
+	buf.WriteByte(')')
 }
 
 func ext۰reflect۰New(fr *frame, args []value) value {
@@ -274,6 +450,20 @@ func ext۰reflect۰Value۰Uint(fr *frame, args []value) value {
 	panic("reflect.Value.Uint")
 }
 
+func ext۰reflect۰Value۰Cap(fr *frame, args []value) value {
+	// Signature: func (reflect.Value) int
+	switch v := rV2V(args[0]).(type) {
+	case array:
+		return len(v)
+	case chan value:
+		return cap(v)
+	case []value:
+		return cap(v)
+	default:
+		panic(fmt.Sprintf("reflect.(Value).Cap(%v)", v))
+	}
+}
+
 func ext۰reflect۰Value۰Len(fr *frame, args []value) value {
 	// Signature: func (reflect.Value) int
 	switch v := rV2V(args[0]).(type) {
@@ -558,7 +748,10 @@ func initReflect(i *interpreter) {
 		"Elem":      newMethod(i.reflectPackage, rtypeType, "Elem"),
 		"Field":     newMethod(i.reflectPackage, rtypeType, "Field"),
 		"In":        newMethod(i.reflectPackage, rtypeType, "In"),
+		"Key":       newMethod(i.reflectPackage, rtypeType, "Key"),
 		"Kind":      newMethod(i.reflectPackage, rtypeType, "Kind"),
+		"Len":       newMethod(i.reflectPackage, rtypeType, "Len"),
+		"Name":      newMethod(i.reflectPackage, rtypeType, "Name"),
 		"NumField":  newMethod(i.reflectPackage, rtypeType, "NumField"),
 		"NumIn":     newMethod(i.reflectPackage, rtypeType, "NumIn"),
 		"NumMethod": newMethod(i.reflectPackage, rtypeType, "NumMethod"),
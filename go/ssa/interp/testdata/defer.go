@@ -49,5 +49,75 @@ func init() {
 	}
 }
 
+// Deferred calls evaluate their operands at the defer statement.
+func deferOperands() (s string) {
+	x := "a"
+	f := func(y string) { s += y }
+	defer f(x)
+	x = "b"
+	f = func(string) { panic("wrong function") }
+	return "c"
+}
+
+// A conditional defer runs only if its statement was executed.
+func deferConditional(cond bool) (n int) {
+	if cond {
+		defer func() { n++ }()
+	}
+	defer func() { n *= 10 }()
+	return 1
+}
+
+// Deferred calls in a loop all run, in reverse order.
+func deferLoop() (s string) {
+	for _, x := range "abc" {
+		defer func() { s += string(x) }()
+	}
+	return ""
+}
+
+// If a deferred call panics, the earlier ones still run, once.
+func deferPanicking() (s string) {
+	defer func() {
+		s += fmt.Sprint(recover())
+	}()
+	defer func() { s += "1" }()
+	defer func() { panic("2") }()
+	defer func() { s += "3" }()
+	return ""
+}
+
+type counter struct{ n int }
+
+func (c *counter) inc() { c.n++ }
+
+// Deferred method calls bind their receiver at the defer statement.
+func deferMethod() int {
+	c, d := &counter{}, &counter{}
+	defer c.inc()
+	c = d
+	defer c.inc()
+	return 0
+}
+
+func init() {
+	if s := deferOperands(); s != "ca" {
+		panic(s)
+	}
+	if n := deferConditional(true); n != 11 {
+		panic(n)
+	}
+	if n := deferConditional(false); n != 10 {
+		panic(n)
+	}
+	if s := deferLoop(); s != "cba" {
+		panic(s)
+	}
+	if s := deferPanicking(); s != "312" {
+		panic(s)
+	}
+	deferMethod()
+}
+
 func main() {
 }
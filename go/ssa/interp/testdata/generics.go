@@ -0,0 +1,299 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+// Tests of the execution of instantiated generic code.
+
+import (
+	"fmt"
+	"reflect"
+)
+
+func main() {
+	testFuncs()
+	testTypes()
+	testConstraints()
+	testConversions()
+	testTypeSwitch()
+	testClosures()
+	testMethodValues()
+	testReflect()
+	testBuiltins()
+	testEmbedding()
+}
+
+func assert(cond bool, msg string) {
+	if !cond {
+		panic(msg)
+	}
+}
+
+type Number interface {
+	~int | ~int8 | ~int64 | ~uint8 | ~float64
+}
+
+func Sum[T Number](xs ...T) T {
+	var sum T
+	for _, x := range xs {
+		sum += x
+	}
+	return sum
+}
+
+func Map[T, U any](xs []T, f func(T) U) []U {
+	ys := make([]U, 0, len(xs))
+	for _, x := range xs {
+		ys = append(ys, f(x))
+	}
+	return ys
+}
+
+func Keys[M ~map[K]V, K comparable, V any](m M) int {
+	n := 0
+	for range m {
+		n++
+	}
+	return n
+}
+
+func testFuncs() {
+	assert(Sum(1, 2, 3) == 6, "Sum[int]")
+	assert(Sum(1.5, 2.5) == 4, "Sum[float64]")
+	assert(Sum[uint8](200, 100) == 44, "Sum[uint8] overflow")
+	strs := Map([]int{1, 2}, func(i int) string { return fmt.Sprint(i) })
+	assert(len(strs) == 2 && strs[0] == "1" && strs[1] == "2", "Map")
+	type M map[string]bool
+	assert(Keys(M{"a": true, "b": false}) == 2, "Keys")
+}
+
+type Pair[K comparable, V any] struct {
+	Key K
+	Val V
+}
+
+func (p Pair[K, V]) Swap() Pair[string, K] {
+	return Pair[string, K]{fmt.Sprint(p.Val), p.Key}
+}
+
+type List[T any] struct {
+	head *node[T]
+	n    int
+}
+
+type node[T any] struct {
+	val  T
+	next *node[T]
+}
+
+func (l *List[T]) Push(v T) {
+	l.head = &node[T]{v, l.head}
+	l.n++
+}
+
+func (l *List[T]) All() []T {
+	var out []T
+	for n := l.head; n != nil; n = n.next {
+		out = append(out, n.val)
+	}
+	return out
+}
+
+type Stringer interface{ String() string }
+
+type ID int
+
+func (id ID) String() string { return fmt.Sprint("#", int(id)) }
+
+func Join[T Stringer](xs []T) string {
+	s := ""
+	for _, x := range xs {
+		s += x.String()
+	}
+	return s
+}
+
+func testTypes() {
+	p := Pair[int, bool]{1, true}
+	q := p.Swap()
+	assert(q.Key == "true" && q.Val == 1, "Pair.Swap")
+
+	var l List[ID]
+	l.Push(1)
+	l.Push(2)
+	all := l.All()
+	assert(l.n == 2 && all[0] == 2 && all[1] == 1, "List")
+	assert(Join(all) == "#2#1", "Join")
+
+	m := map[Pair[string, int]]int{{"a", 1}: 10}
+	assert(m[Pair[string, int]{"a", 1}] == 10, "generic map key")
+}
+
+type Ordered interface {
+	~int | ~string | ~float64
+}
+
+func Max[T Ordered](x, y T) T {
+	if x > y {
+		return x
+	}
+	return y
+}
+
+func Index[T comparable](xs []T, x T) int {
+	for i, y := range xs {
+		if x == y {
+			return i
+		}
+	}
+	return -1
+}
+
+func testConstraints() {
+	assert(Max(1, 2) == 2, "Max[int]")
+	assert(Max("b", "a") == "b", "Max[string]")
+	type Name string
+	assert(Max(Name("x"), Name("y")) == "y", "Max[Name]")
+	assert(Index([]any{1, "a", nil}, any("a")) == 1, "Index[any]")
+	assert(Index([]ID{3, 4}, 4) == 1, "Index[ID]")
+}
+
+func Convert[From, To Number](x From) To { return To(x) }
+
+func Bytes[S ~string | ~[]byte](s S) []byte { return []byte(s) }
+
+func Zero[T any]() T {
+	var zero T
+	return zero
+}
+
+func testConversions() {
+	assert(Convert[float64, int](2.9) == 2, "float64 -> int")
+	assert(Convert[int, uint8](257) == 1, "int -> uint8")
+	assert(Convert[int8, float64](-3) == -3, "int8 -> float64")
+	assert(string(Bytes("hi")) == "hi", "Bytes[string]")
+	assert(string(Bytes([]byte("yo"))) == "yo", "Bytes[[]byte]")
+	assert(Zero[*int]() == nil && Zero[string]() == "" && Zero[Pair[int, int]]().Key == 0, "Zero")
+}
+
+func Describe[T any](x T) string {
+	switch v := any(x).(type) {
+	case int:
+		return "int"
+	case string:
+		return "string " + v
+	case Stringer:
+		return "Stringer " + v.String()
+	}
+	return "other"
+}
+
+func testTypeSwitch() {
+	assert(Describe(1) == "int", "Describe[int]")
+	assert(Describe("s") == "string s", "Describe[string]")
+	assert(Describe(ID(5)) == "Stringer #5", "Describe[ID]")
+	assert(Describe(1.5) == "other", "Describe[float64]")
+}
+
+func Counter[T Number]() func(T) T {
+	var total T
+	return func(x T) T {
+		total += x
+		return total
+	}
+}
+
+func testClosures() {
+	c := Counter[int]()
+	c(1)
+	assert(c(2) == 3, "Counter[int]")
+	f := Counter[float64]()
+	assert(f(0.5) == 0.5, "Counter[float64]")
+}
+
+func Apply[T any](x T, f func(T) string) string { return f(x) }
+
+func testMethodValues() {
+	id := ID(7)
+	assert(Apply(id, ID.String) == "#7", "method expression")
+	assert(Apply(id, func(ID) string { return id.String() }) == "#7", "closure")
+	p := Pair[int, int]{1, 2}
+	swap := p.Swap
+	assert(swap().Key == "2", "generic method value")
+}
+
+func TypeName[T any]() string {
+	var zero T
+	return reflect.TypeOf(zero).String()
+}
+
+func testReflect() {
+	assert(TypeName[int]() == "int", "TypeName[int]")
+	assert(TypeName[Pair[int, string]]() == "main.Pair[int,string]", "TypeName[Pair]: "+TypeName[Pair[int, string]]())
+	assert(TypeName[[]*Pair[ID, map[string]int]]() == "[]*main.Pair[main.ID,map[string]int]", "TypeName[[]*Pair]: "+TypeName[[]*Pair[ID, map[string]int]]())
+	assert(TypeName[func(...Pair[int, int]) (ID, error)]() == "func(...main.Pair[int,int]) (main.ID, error)", "TypeName[func]: "+TypeName[func(...Pair[int, int]) (ID, error)]())
+
+	t := reflect.TypeOf(Pair[ID, bool]{})
+	assert(t.Name() == "Pair[main.ID,bool]", "Name: "+t.Name())
+	assert(reflect.TypeOf(map[ID]int(nil)).Key().Name() == "ID", "Key")
+	assert(reflect.TypeOf([3]ID{}).Len() == 3, "Len")
+	assert(reflect.ValueOf(make([]int, 1, 5)).Cap() == 5, "Cap")
+}
+
+func Len[S ~[]E, E any](s S) int { return len(s) + cap(s[:0]) - cap(s[:0]) }
+
+func Clear[M ~map[K]V, K comparable, V any](m M) { clear(m) }
+
+func Smallest[T Ordered](x T, ys ...T) T {
+	for _, y := range ys {
+		x = min(x, y)
+	}
+	return x
+}
+
+func Send[T any](x T) T {
+	ch := make(chan T, 1)
+	ch <- x
+	return <-ch
+}
+
+func Recover[T any](f func() T) (result T, panicked bool) {
+	defer func() {
+		if recover() != nil {
+			panicked = true
+		}
+	}()
+	return f(), false
+}
+
+func testBuiltins() {
+	assert(Len([]ID{1, 2, 3}) == 3, "Len")
+	m := map[string]int{"a": 1}
+	Clear(m)
+	assert(len(m) == 0, "Clear")
+	pm := map[Pair[int, int]]bool{{1, 2}: true}
+	Clear(pm)
+	assert(len(pm) == 0, "Clear (hashmap)")
+	s := []ID{1, 2}
+	clear(s)
+	assert(len(s) == 2 && s[0] == 0 && s[1] == 0, "clear slice")
+	assert(Smallest(3, 1, 2) == 1 && Smallest("b", "a") == "a", "Smallest")
+	assert(Send(ID(9)) == 9, "Send")
+	_, panicked := Recover(func() int { var m map[string]int; m["x"] = 1; return 0 })
+	assert(panicked, "Recover")
+}
+
+type Stack[T any] struct {
+	List[T]
+}
+
+func testEmbedding() {
+	var s Stack[string]
+	s.Push("a")
+	s.Push("b")
+	assert(len(s.All()) == 2 && s.n == 2, "promoted generic methods")
+	var i interface{ Push(string) } = &s
+	i.Push("c")
+	assert(s.n == 3, "promoted generic method via interface")
+}
@@ -4,6 +4,9 @@ type Type interface {
 	String() string
 	Kind() Kind
 	Elem() Type
+	Key() Type
+	Len() int
+	Name() string
 }
 
 type Value struct {
@@ -17,6 +20,7 @@ func (Value) Int() int64
 func (Value) IsValid() bool
 func (Value) IsNil() bool
 func (Value) Len() int
+func (Value) Cap() int
 func (Value) Pointer() uintptr
 func (Value) Index(i int) Value
 func (Value) Type() Type
@@ -17,3 +17,9 @@ type Error interface {
 }
 
 func GC()
+
+func KeepAlive(any)
+
+func NumGoroutine() int
+
+func SetFinalizer(obj any, finalizer any)
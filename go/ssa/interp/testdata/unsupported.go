@@ -0,0 +1,15 @@
+package main
+
+// Tests that the use of an unsupported feature cannot be recovered.
+// This program is run by TestUnsupported, not TestTestdataFiles.
+
+func asm() int // implemented in assembly
+
+func main() {
+	defer func() {
+		if recover() != nil {
+			println("recovered")
+		}
+	}()
+	println(asm())
+}
@@ -113,8 +113,12 @@ func removeInstr(refs []Instruction, instr Instruction) []Instruction {
 // load/store by SSA registers, inserting φ-nodes where necessary.
 // The result is a program in classical pruned SSA form.
 //
+// Allocs used in unreachable blocks, which exist only in
+// NaiveControlFlow mode, are not lifted, so that the loads and stores
+// of unreachable code are preserved.
+//
 // Preconditions:
-// - fn has no dead blocks (blockopt has run).
+// - blockopt has run.
 // - Def/use info (Operands and Referrers) is up-to-date.
 // - The dominator tree is up-to-date.
 func lift(fn *Function) {
@@ -218,6 +222,14 @@ func lift(fn *Function) {
 	// Renaming.
 	rename(fn.Blocks[0], renaming, newPhis)
 
+	// The φ-node edges from unreachable blocks, if any, receive
+	// zero values. (Such blocks contain no lifted loads and stores.)
+	for _, b := range fn.Blocks {
+		if b.unreachable() {
+			rename(b, make([]Value, numAllocs), newPhis)
+		}
+	}
+
 	// Eliminate dead φ-nodes.
 	removeDeadPhis(fn.Blocks, newPhis)
 
@@ -408,10 +420,18 @@ func liftAlloc(df domFrontier, alloc *Alloc, newPhis newPhiMap, fresh *int) bool
 		}
 	}
 
+	// Don't lift allocs used in unreachable blocks.
+	if alloc.Block().unreachable() {
+		return false
+	}
+
 	// Compute defblocks, the set of blocks containing a
 	// definition of the alloc cell.
 	var defblocks blockSet
 	for _, instr := range *alloc.Referrers() {
+		if instr.Block().unreachable() {
+			return false
+		}
 		// Bail out if we discover the alloc is not liftable;
 		// the only operations permitted to use the alloc are
 		// loads/stores into the cell, and DebugRef.
@@ -478,6 +498,9 @@ func liftAlloc(df domFrontier, alloc *Alloc, newPhis newPhiMap, fresh *int) bool
 				*fresh++
 
 				phi.pos = alloc.Pos()
+				if n := fn.instrSyntax[alloc]; n != nil {
+					fn.setSyntax(phi, n)
+				}
 				phi.setType(typeparams.MustDeref(alloc.Type()))
 				phi.block = v
 				if debugLifting {
@@ -25,10 +25,13 @@ const (
 	LogSource                                    // Log source locations as SSA builder progresses
 	SanityCheckFunctions                         // Perform sanity checking of function bodies
 	NaiveForm                                    // Build naïve SSA form: don't replace local loads/stores with registers
-	BuildSerially                                // Build packages serially, not in parallel.
+	BuildSerially                                // Build packages and functions serially, not in parallel.
 	GlobalDebug                                  // Enable debug info for all packages
 	BareInits                                    // Build init functions without guards or calls to dependent inits
 	InstantiateGenerics                          // Instantiate generics functions (monomorphize) while building
+	RecordSyntax                                 // Record the syntax of each instruction; see Function.SyntaxOf
+	NaiveControlFlow                             // Build naïve control flow: retain unreachable blocks; don't simplify the CFG
+	ExplicitDefers                               // Make deferred calls explicitly at normal exits, not by RunDefers
 )
 
 const BuilderModeDoc = `Options controlling the SSA builder.
@@ -42,6 +45,9 @@ L	build distinct packages seria[L]ly instead of in parallel.
 N	build [N]aive SSA form: don't replace local loads/stores with registers.
 I	build bare [I]nit functions: no init guards or calls to dependent inits.
 G   instantiate [G]eneric function bodies via monomorphization
+A	record the syntax ([A]ST) of each instruction.
+U	build naive control flow: retain [U]nreachable blocks; don't simplify the CFG.
+E	make deferred calls [E]xplicitly at normal exits, not by rundefers.
 `
 
 func (m BuilderMode) String() string {
@@ -73,6 +79,15 @@ func (m BuilderMode) String() string {
 	if m&InstantiateGenerics != 0 {
 		buf.WriteByte('G')
 	}
+	if m&RecordSyntax != 0 {
+		buf.WriteByte('A')
+	}
+	if m&NaiveControlFlow != 0 {
+		buf.WriteByte('U')
+	}
+	if m&ExplicitDefers != 0 {
+		buf.WriteByte('E')
+	}
 	return buf.String()
 }
 
@@ -99,6 +114,12 @@ func (m *BuilderMode) Set(s string) error {
 			mode |= BareInits
 		case 'G':
 			mode |= InstantiateGenerics
+		case 'A':
+			mode |= RecordSyntax
+		case 'U':
+			mode |= NaiveControlFlow
+		case 'E':
+			mode |= ExplicitDefers
 		default:
 			return fmt.Errorf("unknown BuilderMode option: %q", c)
 		}
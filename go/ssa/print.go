@@ -53,8 +53,15 @@ func relTerm(term *types.Term, from *types.Package) string {
 
 func relString(m Member, from *types.Package) string {
 	// NB: not all globals have an Object (e.g. init$guard),
-	// so use Package().Object not Object.Package().
-	if pkg := m.Package().Pkg; pkg != nil && pkg != from {
+	// so use Package().Object not Object.Package(),
+	// unless the global is external and has no Package.
+	var pkg *types.Package
+	if p := m.Package(); p != nil {
+		pkg = p.Pkg
+	} else if obj := m.Object(); obj != nil {
+		pkg = obj.Pkg()
+	}
+	if pkg != nil && pkg != from {
 		return fmt.Sprintf("%s.%s", pkg.Path(), m.Name())
 	}
 	return m.Name()
@@ -351,6 +358,9 @@ func (s *Defer) String() string {
 	if s.DeferStack != nil {
 		prefix += "[" + relName(s.DeferStack, s) + "] "
 	}
+	if s.Pending != nil {
+		prefix += "pending " + relName(s.Pending, s) + " "
+	}
 	c := printCall(&s.Call, prefix, s)
 	return c
 }
@@ -265,7 +265,7 @@ func (s *sanity) checkBlock(b *BasicBlock, index int) {
 	// Check all blocks are reachable.
 	// (The entry block is always implicitly reachable,
 	// as is the Recover block, if any.)
-	if (index > 0 && b != b.parent.Recover) && len(b.Preds) == 0 {
+	if (index > 0 && b != b.parent.Recover) && len(b.Preds) == 0 && s.fn.Prog.mode&NaiveControlFlow == 0 {
 		s.warnf("unreachable block")
 		if b.Instrs == nil {
 			// Since this block is about to be pruned,
@@ -190,6 +190,56 @@ func (prog *Program) packageLevelMember(obj types.Object) Member {
 	return nil
 }
 
+// declaredMember is like packageLevelMember, but if obj is a
+// package-level func or var of a package that has not been created,
+// such as a dependency for which only export data is available, it
+// returns an external *Function or *Global declared from its type
+// information on demand. It returns nil for other objects.
+//
+// Acquires prog.externalsMu.
+func (prog *Program) declaredMember(obj types.Object, b *builder) Member {
+	if m := prog.packageLevelMember(obj); m != nil {
+		return m
+	}
+	pkg := obj.Pkg()
+	if pkg == nil || obj.Parent() != pkg.Scope() || prog.packages[pkg] != nil {
+		return nil // not package-level, or a const or type
+	}
+
+	// Consult/update cache of members created from types.Objects.
+	prog.externalsMu.Lock()
+	defer prog.externalsMu.Unlock()
+	if m, ok := prog.externals[obj]; ok {
+		if fn, ok := m.(*Function); ok {
+			b.waitForSharedFunction(fn)
+		}
+		return m
+	}
+	var m Member
+	switch obj := obj.(type) {
+	case *types.Var:
+		m = &Global{
+			name:   obj.Name(),
+			object: obj,
+			typ:    types.NewPointer(obj.Type()), // address
+			pos:    obj.Pos(),
+		}
+	case *types.Func:
+		fn := createFunction(prog, obj, obj.Name(), nil, nil, "")
+		fn.Synthetic = "from type information (on demand)"
+		fn.buildshared = b.shared()
+		b.enqueue(fn)
+		m = fn
+	default:
+		return nil
+	}
+	if prog.externals == nil {
+		prog.externals = make(map[types.Object]Member)
+	}
+	prog.externals[obj] = m
+	return m
+}
+
 // FuncValue returns the SSA function or (non-interface) method
 // denoted by the specified func symbol. It returns nil if the symbol
 // denotes an interface method, or belongs to a package that was not
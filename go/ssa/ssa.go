@@ -45,6 +45,11 @@ type Program struct {
 	// to avoid creation of duplicate methods from type information.
 	objectMethodsMu sync.Mutex
 	objectMethods   map[*types.Func]*Function
+
+	// externals is a memoization of declaredMember for the
+	// members of packages that were not created.
+	externalsMu sync.Mutex
+	externals   map[types.Object]Member
 }
 
 // A Package is a single analyzed Go package containing Members for
@@ -67,6 +72,7 @@ type Package struct {
 	// The following fields are set transiently, then cleared
 	// after building.
 	buildOnce   sync.Once           // ensures package building occurs once
+	buildMu     sync.Mutex          // serializes building of the package and Function.Build
 	ninit       int32               // number of init functions
 	info        *types.Info         // package type information
 	files       []*ast.File         // package ASTs
@@ -362,10 +368,11 @@ type Function struct {
 	referrers []Instruction // referring instructions (iff Parent() != nil)
 	anonIdx   int32         // position of a nested function in parent's AnonFuncs. fn.Parent()!=nil => fn.Parent().AnonFunc[fn.anonIdx] == fn.
 
-	typeparams     *types.TypeParamList // type parameters of this function. typeparams.Len() > 0 => generic or instance of generic function
-	typeargs       []types.Type         // type arguments that instantiated typeparams. len(typeargs) > 0 => instance of generic function
-	topLevelOrigin *Function            // the origin function if this is an instance of a source function. nil if Parent()!=nil.
-	generic        *generic             // instances of this function, if generic
+	typeparams     *types.TypeParamList     // type parameters of this function. typeparams.Len() > 0 => generic or instance of generic function
+	typeargs       []types.Type             // type arguments that instantiated typeparams. len(typeargs) > 0 => instance of generic function
+	topLevelOrigin *Function                // the origin function if this is an instance of a source function. nil if Parent()!=nil.
+	generic        *generic                 // instances of this function, if generic
+	instrSyntax    map[Instruction]ast.Node // syntax of each instruction, in RecordSyntax mode
 
 	// The following fields are cleared after building.
 	build        buildFunc                // algorithm to build function body (nil => built)
@@ -381,6 +388,9 @@ type Function struct {
 	source       *Function                // nearest enclosing source function
 	exits        []*exit                  // exits of the function that need to be resolved
 	uniq         int64                    // source of unique ints within the source tree while building
+	syntaxStack  []ast.Node               // nodes being lowered, innermost last (RecordSyntax mode)
+	explicit     bool                     // deferred calls are made explicitly at normal exits (ExplicitDefers mode)
+	deferred     []*deferredCall          // deferred calls, in order of defer statements (ExplicitDefers mode)
 }
 
 // BasicBlock represents an SSA basic block.
@@ -483,13 +493,16 @@ type Const struct {
 //
 // Pos() returns the position of the ast.ValueSpec.Names[*]
 // identifier.
+//
+// A Global of a package that was not created by
+// [Program.CreatePackage] is external: it has no Pkg.
 type Global struct {
 	name   string
 	object types.Object // a *types.Var; may be nil for synthetics e.g. init$guard
 	typ    types.Type
 	pos    token.Pos
 
-	Pkg *Package
+	Pkg *Package // nil => external
 }
 
 // A Builtin represents a specific use of a built-in function, e.g. len.
@@ -1203,6 +1216,9 @@ type Return struct {
 // The RunDefers instruction pops and invokes the entire stack of
 // procedure calls pushed by Defer instructions in this function.
 //
+// In ExplicitDefers mode, functions whose deferred calls are made
+// explicitly contain no RunDefers instructions.
+//
 // It is legal to encounter multiple 'rundefers' instructions in a
 // single control-flow path through a function; this is useful in
 // the combined init() function, for example.
@@ -1263,6 +1279,11 @@ type Go struct {
 // of the current function frame. DeferStack allows for deferring into an
 // alternative function stack than the current function.
 //
+// If Pending != nil, the function was built in ExplicitDefers mode,
+// and its normal exits make the deferred call explicitly. Pending is
+// then the address of a local bool that is true until the explicit
+// call is made; a panic makes the deferred call only if it is true.
+//
 // See CallCommon for generic function call documentation.
 //
 // Pos() returns the ast.DeferStmt.Defer.
@@ -1276,6 +1297,7 @@ type Defer struct {
 	anInstruction
 	Call       CallCommon
 	DeferStack Value // stack of deferred functions (from ssa:deferstack() intrinsic) onto which this function is pushed
+	Pending    Value // *bool flag, true while the call is pending; nil unless ExplicitDefers
 	pos        token.Pos
 }
 
@@ -1718,7 +1740,7 @@ func (s *Call) Operands(rands []*Value) []*Value {
 }
 
 func (s *Defer) Operands(rands []*Value) []*Value {
-	return append(s.Call.Operands(rands), &s.DeferStack)
+	return append(s.Call.Operands(rands), &s.DeferStack, &s.Pending)
 }
 
 func (v *ChangeInterface) Operands(rands []*Value) []*Value {
@@ -0,0 +1,456 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssautil
+
+// This file defines a worklist-based solver for dataflow problems
+// over the control-flow graph of a function, and several canned
+// analyses built upon it.
+
+import (
+	"go/token"
+	"maps"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// A Lattice defines the domain of the facts of a dataflow analysis.
+//
+// Facts are values: the methods of a Lattice and the transfer
+// functions of a [Dataflow] must not modify their arguments.
+type Lattice[F any] interface {
+	// Bottom returns the fact initially assumed at every program
+	// point other than the boundary. It is the identity of Join.
+	Bottom() F
+
+	// Join returns the least upper bound of two facts, such as the
+	// union of two sets, for a "may" analysis, or their
+	// intersection, for a "must" analysis.
+	Join(x, y F) F
+
+	// Equal reports whether two facts are equal.
+	Equal(x, y F) bool
+}
+
+// A Direction is the direction in which facts flow in a dataflow analysis.
+type Direction int
+
+const (
+	Forward  Direction = iota // from entry to exits, in program order
+	Backward                  // from exits to entry, against program order
+)
+
+// A Dataflow describes a dataflow problem over the control-flow
+// graph of a function, which [Solve] solves.
+type Dataflow[F any] struct {
+	Lattice   Lattice[F]
+	Direction Direction
+
+	// Boundary is the fact at the entry and recover blocks, for a
+	// forward analysis, or at the end of each block without
+	// successors, for a backward one.
+	Boundary F
+
+	// Transfer returns the fact that holds after instr (for a
+	// forward analysis) or before it (for a backward analysis),
+	// given the fact that holds on the other side.
+	Transfer func(instr ssa.Instruction, fact F) F
+
+	// Edge, if non-nil, returns the fact that flows along the
+	// control-flow edge from block from to block to, given the
+	// fact at the end of from (for a forward analysis) or at the
+	// start of to (for a backward analysis). It allows an analysis
+	// to account for the edge-specific operands of φ-nodes.
+	Edge func(from, to *ssa.BasicBlock, fact F) F
+}
+
+// A DataflowResult holds the solution of a [Dataflow] problem.
+type DataflowResult[F any] struct {
+	// In and Out hold the facts at the start and end of each block,
+	// in program order, indexed by [ssa.BasicBlock.Index].
+	In, Out []F
+
+	d *Dataflow[F]
+}
+
+// Solve computes the least fixed point of the dataflow problem d over
+// the blocks of the function fn, which must have been built, using a
+// worklist algorithm.
+func Solve[F any](fn *ssa.Function, d *Dataflow[F]) *DataflowResult[F] {
+	n := len(fn.Blocks)
+	res := &DataflowResult[F]{
+		In:  make([]F, n),
+		Out: make([]F, n),
+		d:   d,
+	}
+	for i := range n {
+		res.In[i] = d.Lattice.Bottom()
+		res.Out[i] = d.Lattice.Bottom()
+	}
+
+	// The worklist is a FIFO queue of blocks, initially all of
+	// them, in the order of the analysis.
+	queue := make([]*ssa.BasicBlock, 0, n)
+	queued := make([]bool, n)
+	for i := range n {
+		if d.Direction == Backward {
+			i = n - 1 - i
+		}
+		queue = append(queue, fn.Blocks[i])
+		queued[i] = true
+	}
+
+	edge := func(from, to *ssa.BasicBlock, fact F) F {
+		if d.Edge != nil {
+			fact = d.Edge(from, to, fact)
+		}
+		return fact
+	}
+	for len(queue) > 0 {
+		b := queue[0]
+		queue = queue[1:]
+		queued[b.Index] = false
+
+		if d.Direction == Forward {
+			in := d.Lattice.Bottom()
+			if b.Index == 0 || b == fn.Recover {
+				in = d.Boundary
+			}
+			for _, pred := range b.Preds {
+				in = d.Lattice.Join(in, edge(pred, b, res.Out[pred.Index]))
+			}
+			res.In[b.Index] = in
+			out := res.transferBlock(b, in)
+			if !d.Lattice.Equal(out, res.Out[b.Index]) {
+				res.Out[b.Index] = out
+				for _, succ := range b.Succs {
+					if !queued[succ.Index] {
+						queue = append(queue, succ)
+						queued[succ.Index] = true
+					}
+				}
+			}
+		} else {
+			out := d.Lattice.Bottom()
+			if len(b.Succs) == 0 {
+				out = d.Boundary
+			}
+			for _, succ := range b.Succs {
+				out = d.Lattice.Join(out, edge(b, succ, res.In[succ.Index]))
+			}
+			res.Out[b.Index] = out
+			in := res.transferBlock(b, out)
+			if !d.Lattice.Equal(in, res.In[b.Index]) {
+				res.In[b.Index] = in
+				for _, pred := range b.Preds {
+					if !queued[pred.Index] {
+						queue = append(queue, pred)
+						queued[pred.Index] = true
+					}
+				}
+			}
+		}
+	}
+	return res
+}
+
+// transferBlock applies the transfer function to the instructions of
+// block b, in the direction of the analysis.
+func (res *DataflowResult[F]) transferBlock(b *ssa.BasicBlock, fact F) F {
+	return res.transferRange(b.Instrs, fact)
+}
+
+// transferRange applies the transfer function to the instructions,
+// in the direction of the analysis.
+func (res *DataflowResult[F]) transferRange(instrs []ssa.Instruction, fact F) F {
+	if res.d.Direction == Forward {
+		for _, instr := range instrs {
+			fact = res.d.Transfer(instr, fact)
+		}
+	} else {
+		for i := len(instrs) - 1; i >= 0; i-- {
+			fact = res.d.Transfer(instrs[i], fact)
+		}
+	}
+	return fact
+}
+
+// Before returns the fact that holds immediately before instr.
+func (res *DataflowResult[F]) Before(instr ssa.Instruction) F {
+	b := instr.Block()
+	i := indexOf(b, instr)
+	if res.d.Direction == Forward {
+		return res.transferRange(b.Instrs[:i], res.In[b.Index])
+	}
+	return res.transferRange(b.Instrs[i:], res.Out[b.Index])
+}
+
+// After returns the fact that holds immediately after instr.
+func (res *DataflowResult[F]) After(instr ssa.Instruction) F {
+	b := instr.Block()
+	i := indexOf(b, instr)
+	if res.d.Direction == Forward {
+		return res.transferRange(b.Instrs[:i+1], res.In[b.Index])
+	}
+	return res.transferRange(b.Instrs[i+1:], res.Out[b.Index])
+}
+
+// indexOf returns the index of instr within block b.
+func indexOf(b *ssa.BasicBlock, instr ssa.Instruction) int {
+	for i, x := range b.Instrs {
+		if x == instr {
+			return i
+		}
+	}
+	panic("instruction not found in its block")
+}
+
+// -- canned analyses --
+
+// unionLattice is the lattice of sets of T ordered by inclusion, for
+// "may" analyses. Its facts are maps whose values are true.
+type unionLattice[T comparable] struct{}
+
+func (unionLattice[T]) Bottom() map[T]bool { return nil }
+
+func (unionLattice[T]) Join(x, y map[T]bool) map[T]bool {
+	if len(x) == 0 {
+		return y
+	}
+	if len(y) == 0 {
+		return x
+	}
+	z := maps.Clone(x)
+	maps.Copy(z, y)
+	return z
+}
+
+func (unionLattice[T]) Equal(x, y map[T]bool) bool { return maps.Equal(x, y) }
+
+// ReachingDefinitions computes, for each point of the function fn,
+// the set of definitions of memory locations that may reach it
+// without an intervening redefinition of the same location.
+//
+// The definitions are the Alloc instructions, which store a zero
+// value, and the Store instructions. A definition of a local variable
+// (an Alloc) or of a package-level variable (a Global) kills the prior
+// definitions of the same variable; stores through other addresses
+// kill nothing, as they may or may not alias. Local variables are
+// most numerous in functions built in [ssa.NaiveForm].
+func ReachingDefinitions(fn *ssa.Function) *DataflowResult[map[ssa.Instruction]bool] {
+	return Solve(fn, &Dataflow[map[ssa.Instruction]bool]{
+		Lattice:   unionLattice[ssa.Instruction]{},
+		Direction: Forward,
+		Transfer: func(instr ssa.Instruction, defs map[ssa.Instruction]bool) map[ssa.Instruction]bool {
+			var addr ssa.Value
+			switch instr := instr.(type) {
+			case *ssa.Alloc:
+				addr = instr
+			case *ssa.Store:
+				addr = instr.Addr
+			default:
+				return defs
+			}
+			out := make(map[ssa.Instruction]bool, len(defs)+1)
+			for def := range defs {
+				if !killsDef(addr, def) {
+					out[def] = true
+				}
+			}
+			out[instr] = true
+			return out
+		},
+	})
+}
+
+// killsDef reports whether a definition of the location addr kills
+// the earlier definition def.
+func killsDef(addr ssa.Value, def ssa.Instruction) bool {
+	switch addr.(type) {
+	case *ssa.Alloc, *ssa.Global:
+		switch def := def.(type) {
+		case *ssa.Alloc:
+			return def == addr
+		case *ssa.Store:
+			return def.Addr == addr
+		}
+	}
+	return false
+}
+
+// Liveness computes, for each point of the function fn, the set of
+// values that are live: those defined by instructions or parameters
+// (including free variables) that may be used later.
+//
+// The operands of a φ-node are live at the end of the corresponding
+// predecessor block, not at the start of the φ-node's block.
+func Liveness(fn *ssa.Function) *DataflowResult[map[ssa.Value]bool] {
+	return Solve(fn, &Dataflow[map[ssa.Value]bool]{
+		Lattice:   unionLattice[ssa.Value]{},
+		Direction: Backward,
+		Transfer: func(instr ssa.Instruction, live map[ssa.Value]bool) map[ssa.Value]bool {
+			out := maps.Clone(live)
+			if out == nil {
+				out = make(map[ssa.Value]bool)
+			}
+			if v, ok := instr.(ssa.Value); ok {
+				delete(out, v)
+			}
+			if _, ok := instr.(*ssa.Phi); !ok {
+				for _, rand := range instr.Operands(nil) {
+					if isVariable(*rand) {
+						out[*rand] = true
+					}
+				}
+			}
+			return out
+		},
+		Edge: func(from, to *ssa.BasicBlock, live map[ssa.Value]bool) map[ssa.Value]bool {
+			var out map[ssa.Value]bool
+			for _, instr := range to.Instrs {
+				phi, ok := instr.(*ssa.Phi)
+				if !ok {
+					break
+				}
+				for i, pred := range to.Preds {
+					if pred == from && isVariable(phi.Edges[i]) {
+						if out == nil {
+							out = maps.Clone(live)
+							if out == nil {
+								out = make(map[ssa.Value]bool)
+							}
+						}
+						out[phi.Edges[i]] = true
+					}
+				}
+			}
+			if out == nil {
+				return live
+			}
+			return out
+		},
+	})
+}
+
+// isVariable reports whether v is a value whose liveness is of
+// interest: a parameter, free variable, or instruction.
+func isVariable(v ssa.Value) bool {
+	switch v.(type) {
+	case *ssa.Parameter, *ssa.FreeVar, ssa.Instruction:
+		return true
+	}
+	return false
+}
+
+// intersectionLattice is the lattice of sets of expressions ordered by
+// reverse inclusion, for "must" analyses. Its facts map the key of
+// each expression to a value that computes it. The nil map stands for
+// the set of all expressions.
+type intersectionLattice struct{}
+
+func (intersectionLattice) Bottom() map[string]ssa.Value { return nil }
+
+func (intersectionLattice) Join(x, y map[string]ssa.Value) map[string]ssa.Value {
+	if x == nil {
+		return y
+	}
+	if y == nil {
+		return x
+	}
+	z := make(map[string]ssa.Value)
+	for k, v := range x {
+		if _, ok := y[k]; ok {
+			z[k] = v
+		}
+	}
+	return z
+}
+
+func (intersectionLattice) Equal(x, y map[string]ssa.Value) bool {
+	if (x == nil) != (y == nil) || len(x) != len(y) {
+		return false
+	}
+	for k := range x {
+		if _, ok := y[k]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// AvailableExpressions computes, for each point of the function fn,
+// the set of expressions that have been computed on every path
+// leading to it, and not invalidated since, as a mapping from the key
+// of each expression to one of the values that computed it.
+//
+// The expressions are the values of the instructions that compute a
+// function of their operands, such as arithmetic, conversions, and
+// field and element selection, plus loads (*p). The key of an
+// expression is formed from its type and text, so instructions with
+// identical operands have the same key. A load is invalidated by any
+// instruction that may store to memory, such as a Store or a Call.
+//
+// In the facts of unreachable blocks, the nil map stands for the set
+// of all expressions.
+func AvailableExpressions(fn *ssa.Function) *DataflowResult[map[string]ssa.Value] {
+	return Solve(fn, &Dataflow[map[string]ssa.Value]{
+		Lattice:   intersectionLattice{},
+		Direction: Forward,
+		Boundary:  map[string]ssa.Value{},
+		Transfer: func(instr ssa.Instruction, avail map[string]ssa.Value) map[string]ssa.Value {
+			kills := mayStore(instr)
+			key, isExpr := expressionKey(instr)
+			if !kills && !isExpr {
+				return avail
+			}
+			out := make(map[string]ssa.Value, len(avail)+1)
+			for k, v := range avail {
+				if kills && isLoad(v) {
+					continue
+				}
+				out[k] = v
+			}
+			if isExpr {
+				if _, ok := out[key]; !ok {
+					out[key] = instr.(ssa.Value)
+				}
+			}
+			return out
+		},
+	})
+}
+
+// expressionKey returns the key of the expression computed by instr,
+// if instr computes a function of its operands.
+func expressionKey(instr ssa.Instruction) (string, bool) {
+	switch instr := instr.(type) {
+	case *ssa.UnOp:
+		if instr.Op == token.ARROW {
+			return "", false // receive
+		}
+	case *ssa.BinOp, *ssa.Convert, *ssa.ChangeType, *ssa.ChangeInterface,
+		*ssa.SliceToArrayPointer, *ssa.MultiConvert,
+		*ssa.Field, *ssa.FieldAddr, *ssa.Index, *ssa.IndexAddr, *ssa.Extract:
+	default:
+		return "", false
+	}
+	v := instr.(ssa.Value)
+	return v.Type().String() + " " + v.String(), true
+}
+
+// isLoad reports whether v is a load (*p).
+func isLoad(v ssa.Value) bool {
+	u, ok := v.(*ssa.UnOp)
+	return ok && u.Op == token.MUL
+}
+
+// mayStore reports whether instr may store to memory.
+func mayStore(instr ssa.Instruction) bool {
+	switch instr.(type) {
+	case *ssa.Store, *ssa.Call, *ssa.Go, *ssa.Defer, *ssa.RunDefers,
+		*ssa.MapUpdate, *ssa.Send, *ssa.Select, *ssa.Panic:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssautil_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"slices"
+	"sort"
+	"testing"
+
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+const dataflowSrc = `package p
+
+func g()
+
+func F(x, y int, p *int) int {
+	a := x + y
+	if x > 0 {
+		b := x + y
+		*p = b
+	}
+	c := *p
+	g()
+	d := *p
+	return a + c + d
+}
+
+func H(c bool) int {
+	v := 1
+	if c {
+		v = 2
+	}
+	return v
+}
+`
+
+// buildDataflowPackage builds the package of dataflowSrc in the specified mode.
+func buildDataflowPackage(t *testing.T, mode ssa.BuilderMode) *ssa.Package {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", dataflowSrc, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg := types.NewPackage("p", "")
+	ssapkg, _, err := ssautil.BuildPackage(&types.Config{}, fset, pkg, []*ast.File{f}, mode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ssapkg
+}
+
+// instrs returns the instructions of fn that satisfy pred.
+func instrs(fn *ssa.Function, pred func(ssa.Instruction) bool) []ssa.Instruction {
+	var res []ssa.Instruction
+	for _, b := range fn.Blocks {
+		for _, instr := range b.Instrs {
+			if pred(instr) {
+				res = append(res, instr)
+			}
+		}
+	}
+	return res
+}
+
+func TestAvailableExpressions(t *testing.T) {
+	fn := buildDataflowPackage(t, 0).Func("F")
+	res := ssautil.AvailableExpressions(fn)
+
+	// The second x + y is redundant with the first.
+	adds := instrs(fn, func(instr ssa.Instruction) bool {
+		binop, ok := instr.(*ssa.BinOp)
+		return ok && binop.Op == token.ADD && binop.X == fn.Params[0] && binop.Y == fn.Params[1]
+	})
+	if len(adds) != 2 {
+		t.Fatalf("got %d additions x + y, want 2", len(adds))
+	}
+	if !slices.Contains(values(res.Before(adds[1])), adds[0].(ssa.Value)) {
+		t.Errorf("x + y is not available before the second addition: %v", res.Before(adds[1]))
+	}
+	if slices.Contains(values(res.Before(adds[0])), adds[0].(ssa.Value)) {
+		t.Errorf("x + y is available before the first addition")
+	}
+
+	// A load is invalidated by a call.
+	loads := instrs(fn, func(instr ssa.Instruction) bool {
+		unop, ok := instr.(*ssa.UnOp)
+		return ok && unop.Op == token.MUL
+	})
+	if len(loads) != 2 {
+		t.Fatalf("got %d loads, want 2", len(loads))
+	}
+	if !slices.Contains(values(res.After(loads[0])), loads[0].(ssa.Value)) {
+		t.Errorf("*p is not available after the first load")
+	}
+	if slices.Contains(values(res.Before(loads[1])), loads[0].(ssa.Value)) {
+		t.Errorf("*p is available after the call g()")
+	}
+}
+
+func TestLiveness(t *testing.T) {
+	fn := buildDataflowPackage(t, 0).Func("F")
+	res := ssautil.Liveness(fn)
+
+	x, y, p := fn.Params[0], fn.Params[1], fn.Params[2]
+	entry := res.In[0]
+	if !entry[x] || !entry[y] || !entry[p] {
+		t.Errorf("parameters are not live on entry: %v", entry)
+	}
+	ret := instrs(fn, func(instr ssa.Instruction) bool {
+		_, ok := instr.(*ssa.Return)
+		return ok
+	})[0]
+	if live := res.After(ret); len(live) != 0 {
+		t.Errorf("values are live after return: %v", live)
+	}
+	// After the last load of p, only a, c, and d
+	// (and their partial sums) are live.
+	if live := res.Before(ret); live[x] || live[y] || live[p] {
+		t.Errorf("parameters are live before return: %v", live)
+	}
+}
+
+func TestReachingDefinitions(t *testing.T) {
+	fn := buildDataflowPackage(t, ssa.NaiveForm).Func("H")
+	res := ssautil.ReachingDefinitions(fn)
+
+	ret := instrs(fn, func(instr ssa.Instruction) bool {
+		_, ok := instr.(*ssa.Return)
+		return ok
+	})[0]
+	var got []string
+	for def := range res.Before(ret) {
+		if store, ok := def.(*ssa.Store); ok {
+			if alloc, ok := store.Addr.(*ssa.Alloc); ok && alloc.Comment == "v" {
+				got = append(got, store.Val.String())
+			}
+		}
+	}
+	sort.Strings(got)
+	if want := []string{"1:int", "2:int"}; !slices.Equal(got, want) {
+		t.Errorf("definitions of v reaching return = %v, want %v", got, want)
+	}
+}
+
+// values returns the values of the map.
+func values(m map[string]ssa.Value) []ssa.Value {
+	var res []ssa.Value
+	for _, v := range m {
+		res = append(res, v)
+	}
+	return res
+}
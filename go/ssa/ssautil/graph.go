@@ -0,0 +1,243 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssautil
+
+// This file implements the display of a function's control-flow
+// graph, dominator tree, and φ-nodes, as a GraphViz graph or an
+// interactive HTML page, as an aid to debugging SSA-based analyses.
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+// WriteDOT writes the control-flow graph of the function fn to w in
+// the AT&T GraphViz (.dot) format, e.g. for rendering by 'dot -Tsvg'.
+//
+// Each node is a basic block, listing its instructions, with
+// φ-nodes highlighted. Solid edges are control-flow edges; those
+// leaving an If block are green for the true branch and red for the
+// false one. Dashed blue edges relate each block to its immediate
+// dominator. The recover block, if any, has a double border.
+//
+// The function must have been built.
+func WriteDOT(w io.Writer, fn *ssa.Function) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "digraph %q {\n", fn.String())
+	fmt.Fprintf(bw, "\tlabel=%q;\n", fn.String())
+	fmt.Fprintln(bw, "\tnode [shape=plaintext,fontname=\"monospace\"];")
+	for _, b := range fn.Blocks {
+		border := 1
+		if b == fn.Recover {
+			border = 3
+		}
+		fmt.Fprintf(bw, "\tb%d [label=<<table border=\"%d\" cellborder=\"0\" cellspacing=\"0\">", b.Index, border)
+		fmt.Fprintf(bw, "<tr><td align=\"left\" bgcolor=\"lightgray\"><b>%s</b></td></tr>", html.EscapeString(blockTitle(b)))
+		for _, instr := range b.Instrs {
+			bgcolor := ""
+			if _, ok := instr.(*ssa.Phi); ok {
+				bgcolor = ` bgcolor="lightyellow"`
+			}
+			fmt.Fprintf(bw, "<tr><td align=\"left\"%s>%s</td></tr>", bgcolor, html.EscapeString(instrString(instr)))
+		}
+		fmt.Fprintln(bw, "</table>>];")
+	}
+	for _, b := range fn.Blocks {
+		_, isIf := lastInstr(b).(*ssa.If)
+		for i, succ := range b.Succs {
+			attrs := ""
+			if isIf {
+				attrs = [2]string{` [color="darkgreen",label="T"]`, ` [color="red",label="F"]`}[i]
+			}
+			fmt.Fprintf(bw, "\tb%d -> b%d%s;\n", b.Index, succ.Index, attrs)
+		}
+		if idom := b.Idom(); idom != nil {
+			fmt.Fprintf(bw, "\tb%d -> b%d [style=\"dashed\",color=\"blue\",constraint=false];\n", idom.Index, b.Index)
+		}
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// WriteHTML writes to w a self-contained HTML page that displays the
+// basic blocks of the function fn, their control-flow edges and
+// immediate dominators, with φ-nodes highlighted.
+//
+// The page is interactive: clicking a value highlights its
+// definition and all its uses, and pointing at a block highlights
+// the blocks it dominates.
+//
+// The function must have been built.
+func WriteHTML(w io.Writer, fn *ssa.Function) error {
+	// Gather the names of the function's values, so that their
+	// occurrences in the text of each instruction can be marked.
+	names := make(map[string]bool)
+	for _, p := range fn.Params {
+		names[p.Name()] = true
+	}
+	for _, fv := range fn.FreeVars {
+		names[fv.Name()] = true
+	}
+	for _, b := range fn.Blocks {
+		for _, instr := range b.Instrs {
+			if v, ok := instr.(ssa.Value); ok {
+				names[v.Name()] = true
+			}
+		}
+	}
+	markValues := func(s string) string {
+		var out strings.Builder
+		last := 0
+		for _, loc := range identRegexp.FindAllStringIndex(s, -1) {
+			word := s[loc[0]:loc[1]]
+			if !names[word] {
+				continue
+			}
+			out.WriteString(html.EscapeString(s[last:loc[0]]))
+			fmt.Fprintf(&out, "<span class=\"value\" data-value=\"%s\">%s</span>", html.EscapeString(word), html.EscapeString(word))
+			last = loc[1]
+		}
+		out.WriteString(html.EscapeString(s[last:]))
+		return out.String()
+	}
+	blockLinks := func(blocks []*ssa.BasicBlock) string {
+		if len(blocks) == 0 {
+			return "-"
+		}
+		var links []string
+		for _, b := range blocks {
+			links = append(links, fmt.Sprintf("<a href=\"#b%d\">%d</a>", b.Index, b.Index))
+		}
+		return strings.Join(links, " ")
+	}
+
+	bw := bufio.NewWriter(w)
+	title := html.EscapeString(fn.String())
+	fmt.Fprintf(bw, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n", title)
+	fmt.Fprint(bw, htmlStyle)
+	fmt.Fprintf(bw, "</head>\n<body>\n<h1>%s</h1>\n", title)
+	if len(fn.Params) > 0 || len(fn.FreeVars) > 0 {
+		fmt.Fprint(bw, "<p>")
+		for _, p := range fn.Params {
+			fmt.Fprintf(bw, "param %s %s<br>\n", markValues(p.Name()), html.EscapeString(p.Type().String()))
+		}
+		for _, fv := range fn.FreeVars {
+			fmt.Fprintf(bw, "freevar %s %s<br>\n", markValues(fv.Name()), html.EscapeString(fv.Type().String()))
+		}
+		fmt.Fprint(bw, "</p>\n")
+	}
+	if fn.Blocks == nil {
+		fmt.Fprint(bw, "<p>(external function: no body)</p>\n")
+	}
+	for _, b := range fn.Blocks {
+		// The ids of the blocks that b dominates, including b.
+		var dominated []string
+		var visit func(b *ssa.BasicBlock)
+		visit = func(b *ssa.BasicBlock) {
+			dominated = append(dominated, fmt.Sprintf("b%d", b.Index))
+			for _, c := range b.Dominees() {
+				visit(c)
+			}
+		}
+		visit(b)
+
+		class := "block"
+		if b == fn.Recover {
+			class += " recover"
+		}
+		fmt.Fprintf(bw, "<div class=%q id=\"b%d\" data-dominates=%q>\n", class, b.Index, strings.Join(dominated, " "))
+		fmt.Fprintf(bw, "<div class=\"header\">%s</div>\n", html.EscapeString(blockTitle(b)))
+		var idom []*ssa.BasicBlock
+		if b.Idom() != nil {
+			idom = append(idom, b.Idom())
+		}
+		fmt.Fprintf(bw, "<div class=\"edges\">preds: %s; succs: %s; idom: %s</div>\n",
+			blockLinks(b.Preds), blockLinks(b.Succs), blockLinks(idom))
+		fmt.Fprint(bw, "<pre>")
+		for _, instr := range b.Instrs {
+			class, text, comment := "instr", instrString(instr), ""
+			if phi, ok := instr.(*ssa.Phi); ok {
+				class += " phi"
+				// Don't mistake the comment for a value.
+				if phi.Comment != "" {
+					text, comment = strings.TrimSuffix(text, " #"+phi.Comment), " #"+phi.Comment
+				}
+			}
+			fmt.Fprintf(bw, "<span class=%q>%s%s</span>\n", class, markValues(text), html.EscapeString(comment))
+		}
+		fmt.Fprint(bw, "</pre>\n</div>\n")
+	}
+	fmt.Fprint(bw, htmlScript)
+	fmt.Fprint(bw, "</body>\n</html>\n")
+	return bw.Flush()
+}
+
+var identRegexp = regexp.MustCompile(`[\pL_][\pL\pN_]*`)
+
+// blockTitle returns the heading of block b, e.g. "2: for.body".
+func blockTitle(b *ssa.BasicBlock) string {
+	if b.Comment != "" {
+		return fmt.Sprintf("%d: %s", b.Index, b.Comment)
+	}
+	return fmt.Sprint(b.Index)
+}
+
+// instrString returns the text of an instruction as printed by
+// [ssa.Function.WriteTo], including the name of the value it defines.
+func instrString(instr ssa.Instruction) string {
+	if v, ok := instr.(ssa.Value); ok {
+		return fmt.Sprintf("%s = %s", v.Name(), instr)
+	}
+	return instr.String()
+}
+
+// lastInstr returns the final instruction of block b, or nil.
+func lastInstr(b *ssa.BasicBlock) ssa.Instruction {
+	if len(b.Instrs) == 0 {
+		return nil
+	}
+	return b.Instrs[len(b.Instrs)-1]
+}
+
+const htmlStyle = `<style>
+body { font-family: sans-serif; }
+.block { border: 1px solid gray; margin: 0.5em 0; padding: 0.25em 0.5em; }
+.block.recover { border: 3px double gray; }
+.block.dominated { background: #eef3ff; }
+.header { font-weight: bold; }
+.edges { font-size: smaller; color: #555; }
+.phi { background: lightyellow; }
+.value { cursor: pointer; }
+.value.selected { background: #fc6; }
+pre { margin: 0.25em 0; }
+</style>
+`
+
+const htmlScript = `<script>
+document.querySelectorAll(".value").forEach(function(v) {
+	v.addEventListener("click", function() {
+		var name = v.dataset.value;
+		document.querySelectorAll(".value").forEach(function(u) {
+			u.classList.toggle("selected", u.dataset.value === name && !u.classList.contains("selected"));
+		});
+	});
+});
+document.querySelectorAll(".block").forEach(function(b) {
+	var ids = b.dataset.dominates.split(" ");
+	b.addEventListener("mouseenter", function() {
+		ids.forEach(function(id) { document.getElementById(id).classList.add("dominated"); });
+	});
+	b.addEventListener("mouseleave", function() {
+		ids.forEach(function(id) { document.getElementById(id).classList.remove("dominated"); });
+	});
+});
+</script>
+`
@@ -0,0 +1,74 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssautil_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+func TestWriteGraph(t *testing.T) {
+	const src = `package p
+
+func f(x int) int {
+	y := 0
+	for x > 0 {
+		y += x
+		x--
+	}
+	return y
+}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg := types.NewPackage("p", "")
+	ssapkg, _, err := ssautil.BuildPackage(&types.Config{}, fset, pkg, []*ast.File{f}, ssa.BuilderMode(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fn := ssapkg.Func("f")
+
+	var dot strings.Builder
+	if err := ssautil.WriteDOT(&dot, fn); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`digraph "p.f" {`,
+		`b0 -> b3;`, // entry -> for.loop
+		`b3 -> b1 [color="darkgreen",label="T"];`,                  // for.loop -> for.body
+		`b3 -> b2 [color="red",label="F"];`,                        // for.loop -> for.done
+		`b0 -> b3 [style="dashed",color="blue",constraint=false];`, // idom
+		`<td align="left" bgcolor="lightyellow">t3 = phi [0: 0:int, 1: t0] #y</td>`,
+	} {
+		if !strings.Contains(dot.String(), want) {
+			t.Errorf("WriteDOT output does not contain %q:\n%s", want, dot.String())
+		}
+	}
+
+	var page strings.Builder
+	if err := ssautil.WriteHTML(&page, fn); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`<title>p.f</title>`,
+		`<div class="block" id="b3" data-dominates="b3 b1 b2">`,
+		`<span class="instr phi"><span class="value" data-value="t2">t2</span> = phi [0: <span class="value" data-value="x">x</span>, 1: <span class="value" data-value="t1">t1</span>] #x</span>`,
+		`preds: <a href="#b0">0</a> <a href="#b1">1</a>; succs: <a href="#b1">1</a> <a href="#b2">2</a>; idom: <a href="#b0">0</a>`,
+	} {
+		if !strings.Contains(page.String(), want) {
+			t.Errorf("WriteHTML output does not contain %q:\n%s", want, page.String())
+		}
+	}
+}
@@ -31,6 +31,11 @@ import (
 // called on the resulting Program. SSA code is constructed only for
 // the initial packages with well-typed syntax trees.
 //
+// The dependencies need not have been loaded (see [packages.NeedDeps]):
+// the functions and variables of those that were not are declared as
+// externals from their type information, as described at
+// [ssa.Program.CreatePackage].
+//
 // The mode parameter controls diagnostics and checking during SSA construction.
 func Packages(initial []*packages.Package, mode ssa.BuilderMode) (*ssa.Program, []*ssa.Package) {
 	// TODO(adonovan): opt: this calls CreatePackage far more than
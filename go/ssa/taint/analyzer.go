@@ -0,0 +1,84 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package taint
+
+import (
+	"go/types"
+	"reflect"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/buildssa"
+	"golang.org/x/tools/go/callgraph/static"
+)
+
+// Diagnostic returns the finding as a diagnostic at the call to the
+// sink, with the kind of the sink as its category.
+func (f *Finding) Diagnostic() analysis.Diagnostic {
+	diag := analysis.Diagnostic{
+		Pos:      f.Sink.Pos(),
+		Category: f.Kind,
+		Message:  f.Message(),
+	}
+	if pos := f.Source.Pos(); pos.IsValid() {
+		diag.Related = []analysis.RelatedInformation{{Pos: pos, Message: "source of tainted data"}}
+	}
+	return diag
+}
+
+// NewAnalyzer returns an analyzer with the given name and
+// documentation that reports the findings of the taint analysis
+// specified by cfg as diagnostics.
+//
+// The analysis of each package is bounded by the static call graph of
+// the package's SSA program. Calls to functions of other packages are
+// analyzed using the summaries of those functions, which the analyzer
+// exports as facts; calls to functions without summaries are treated
+// as calls to functions without bodies. The analyzer's result is the
+// slice of findings, of type []*Finding.
+func NewAnalyzer(name, doc string, cfg *Config) *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name:       name,
+		Doc:        doc,
+		Requires:   []*analysis.Analyzer{buildssa.Analyzer},
+		ResultType: reflect.TypeOf([]*Finding(nil)),
+		FactTypes:  []analysis.Fact{new(summary)},
+		Run: func(pass *analysis.Pass) (any, error) {
+			ssainput := pass.ResultOf[buildssa.Analyzer].(*buildssa.SSA)
+			cg := static.CallGraph(ssainput.Pkg.Prog)
+			summaries := func(obj *types.Func) *summary {
+				if obj.Pkg() == pass.Pkg {
+					return nil // analyzed from its body
+				}
+				var sum summary
+				if !pass.ImportObjectFact(obj, &sum) {
+					return nil
+				}
+				return &sum
+			}
+
+			s := newState(cfg, cg, summaries, nil)
+			s.run()
+			var result []*Finding
+			for _, f := range s.sortedFindings() {
+				if f.Sink.Parent().Package() == ssainput.Pkg {
+					pass.Report(f.Diagnostic())
+					result = append(result, f)
+				}
+			}
+
+			// Summarize the functions of the package for its importers.
+			for _, fn := range ssainput.SrcFuncs {
+				obj, ok := fn.Object().(*types.Func)
+				if !ok || fn.Blocks == nil {
+					continue
+				}
+				// (An empty summary tells importers that no taint
+				// flows through the function.)
+				pass.ExportObjectFact(obj, summarize(cfg, cg, summaries, fn, s.returns[fn] != nil))
+			}
+			return result, nil
+		},
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package taint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/types"
+)
+
+// A Config specifies the sources, sinks, and sanitizers of a taint
+// analysis.
+//
+// A Config is typically decoded from JSON by [ParseConfig], e.g.:
+//
+//	{
+//	  "sources": [{"package": "net/http", "receiver": "Request", "name": "FormValue"}],
+//	  "sinks": [{"package": "database/sql", "receiver": "DB", "name": "Query", "args": [0], "kind": "sql-injection"}],
+//	  "sanitizers": [{"package": "strconv", "name": "Quote"}]
+//	}
+type Config struct {
+	Sources    []Func `json:"sources"`    // functions whose results are tainted
+	Sinks      []Sink `json:"sinks"`      // functions whose arguments must not be tainted
+	Sanitizers []Func `json:"sanitizers"` // functions whose results are never tainted
+}
+
+// A Func identifies a function or method.
+type Func struct {
+	Package  string `json:"package"`            // path of the declaring package, e.g. "net/http"
+	Receiver string `json:"receiver,omitempty"` // name of the receiver's named type, if a method, e.g. "Request"
+	Name     string `json:"name"`               // name of the function or method
+}
+
+// A Sink identifies a function or method whose arguments must not be
+// tainted.
+type Sink struct {
+	Func
+	Args []int  `json:"args,omitempty"` // indices of the checked arguments, not counting any receiver; all if empty
+	Kind string `json:"kind,omitempty"` // category of the findings, e.g. "sql-injection"; default "taint"
+}
+
+// ParseConfig decodes and validates a JSON configuration.
+func ParseConfig(data []byte) (*Config, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var cfg Config
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("invalid taint configuration: %v", err)
+	}
+	check := func(kind string, fn Func) error {
+		if fn.Package == "" || fn.Name == "" {
+			return fmt.Errorf("invalid taint configuration: %s %v lacks a package or name", kind, fn)
+		}
+		return nil
+	}
+	for _, fn := range cfg.Sources {
+		if err := check("source", fn); err != nil {
+			return nil, err
+		}
+	}
+	for _, fn := range cfg.Sanitizers {
+		if err := check("sanitizer", fn); err != nil {
+			return nil, err
+		}
+	}
+	for _, sink := range cfg.Sinks {
+		if err := check("sink", sink.Func); err != nil {
+			return nil, err
+		}
+		for _, i := range sink.Args {
+			if i < 0 {
+				return nil, fmt.Errorf("invalid taint configuration: sink %v has negative argument index %d", sink.Func, i)
+			}
+		}
+	}
+	return &cfg, nil
+}
+
+// String returns the qualified name of the function,
+// e.g. "(net/http.Request).FormValue".
+func (fn Func) String() string {
+	if fn.Receiver != "" {
+		return fmt.Sprintf("(%s.%s).%s", fn.Package, fn.Receiver, fn.Name)
+	}
+	return fn.Package + "." + fn.Name
+}
+
+// matches reports whether fn identifies the function or method obj.
+func (fn Func) matches(obj *types.Func) bool {
+	if obj == nil || obj.Pkg() == nil || obj.Name() != fn.Name || obj.Pkg().Path() != fn.Package {
+		return false
+	}
+	recv := obj.Signature().Recv()
+	if recv == nil {
+		return fn.Receiver == ""
+	}
+	t := types.Unalias(recv.Type())
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = types.Unalias(ptr.Elem())
+	}
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Name() == fn.Receiver
+}
@@ -0,0 +1,504 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package taint defines an interprocedural taint analysis over the SSA
+// form of a program.
+//
+// The analysis reports each flow of data from a call to a source
+// function, such as one that returns untrusted input, to an argument
+// of a call to a sink function, such as one that executes an SQL
+// query, unless the data passes through a sanitizer function along
+// the way. The sources, sinks, and sanitizers are specified by a
+// [Config].
+//
+// Taint propagates from the operands of each instruction to its
+// result, from stored values to the variables, fields, and elements
+// in which they are stored, and from the arguments of each call to
+// the parameters of its callees, and back through their results. The
+// propagation between functions follows the edges of a call graph,
+// such as one computed by [golang.org/x/tools/go/callgraph/static] or
+// [golang.org/x/tools/go/callgraph/vta], and is context-insensitive.
+// The result of a call to a function without a body, or with no
+// known callee, is tainted if any of its arguments is, as is its
+// receiver.
+//
+// [NewAnalyzer] adapts the analysis to the [analysis.Analyzer]
+// interface, reporting each finding as a diagnostic. The analyzer
+// summarizes the flows through the functions of each package as
+// facts, which it applies at the calls of the functions from other
+// packages, whose bodies are not available: a summary records whether
+// the results of a function are tainted by a source, and which of its
+// parameters taint its results or reach a sink.
+//
+// The configuration is read from JSON only: this module depends on
+// no YAML decoder, so a YAML configuration must be converted to JSON
+// before it is parsed.
+//
+// Note: this package is in an experimental phase and its interface
+// is subject to change.
+package taint // import "golang.org/x/tools/go/ssa/taint"
+
+import (
+	"cmp"
+	"fmt"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/ssa"
+)
+
+// A Finding reports a flow of tainted data from a source to a sink.
+type Finding struct {
+	Source ssa.CallInstruction // a call to a source function
+	Sink   ssa.CallInstruction // the call to a sink function
+	Arg    int                 // index of the tainted argument of Sink, not counting any receiver
+	Kind   string              // category of the sink, e.g. "sql-injection"
+}
+
+// Message returns a description of the finding.
+func (f *Finding) Message() string {
+	return fmt.Sprintf("%s: argument %d of call to %s is tainted by call to %s",
+		f.Kind, f.Arg, calleeName(f.Sink), calleeName(f.Source))
+}
+
+// calleeName returns the name of the function called by call.
+func calleeName(call ssa.CallInstruction) string {
+	c := call.Common()
+	if c.IsInvoke() {
+		return c.Method.FullName()
+	}
+	if fn := c.StaticCallee(); fn != nil {
+		return fn.String()
+	}
+	return c.Value.Name()
+}
+
+// Analyze runs the taint analysis specified by cfg over the functions
+// of the call graph cg, and returns its findings in order of the
+// position of the sink.
+func Analyze(cfg *Config, cg *callgraph.Graph) []*Finding {
+	s := newState(cfg, cg, nil, nil)
+	s.run()
+	return s.sortedFindings()
+}
+
+// newState returns the state of an analysis of the functions of cg,
+// or of those reachable from root, if non-nil.
+func newState(cfg *Config, cg *callgraph.Graph, summaries func(*types.Func) *summary, root *ssa.Function) *state {
+	s := &state{
+		cfg:       cfg,
+		cg:        cg,
+		summaries: summaries,
+		taint:     make(map[ssa.Value]ssa.CallInstruction),
+		returns:   make(map[*ssa.Function]ssa.CallInstruction),
+		queued:    make(map[*ssa.Function]bool),
+		findings:  make(map[findingKey]*Finding),
+	}
+	if root != nil {
+		s.reach = make(map[*ssa.Function]bool)
+		var visit func(fn *ssa.Function)
+		visit = func(fn *ssa.Function) {
+			if !s.reach[fn] {
+				s.reach[fn] = true
+				if node := cg.Nodes[fn]; node != nil {
+					for _, out := range node.Out {
+						visit(out.Callee.Func)
+					}
+				}
+			}
+		}
+		visit(root)
+	}
+	for fn := range cg.Nodes {
+		if fn != nil && fn.Blocks != nil && (s.reach == nil || s.reach[fn]) {
+			s.all = append(s.all, fn)
+		}
+	}
+	// Visit the functions in a deterministic order.
+	slices.SortFunc(s.all, func(x, y *ssa.Function) int {
+		return cmp.Or(cmp.Compare(x.Pos(), y.Pos()), cmp.Compare(x.String(), y.String()))
+	})
+	return s
+}
+
+// run propagates taint through all the functions until a fixed point
+// is reached.
+func (s *state) run() {
+	for _, fn := range s.all {
+		s.enqueue(fn)
+	}
+	for len(s.queue) > 0 {
+		fn := s.queue[0]
+		s.queue = s.queue[1:]
+		s.queued[fn] = false
+		s.visit(fn)
+	}
+}
+
+// sortedFindings returns the findings in order of the position of
+// the sink.
+func (s *state) sortedFindings() []*Finding {
+	findings := make([]*Finding, 0, len(s.findings))
+	for _, f := range s.findings {
+		findings = append(findings, f)
+	}
+	slices.SortFunc(findings, func(x, y *Finding) int {
+		return cmp.Or(cmp.Compare(x.Sink.Pos(), y.Sink.Pos()), cmp.Compare(x.Arg, y.Arg))
+	})
+	return findings
+}
+
+type findingKey struct {
+	sink ssa.CallInstruction
+	arg  int
+}
+
+// state holds the state of the analysis.
+type state struct {
+	cfg       *Config
+	cg        *callgraph.Graph
+	summaries func(*types.Func) *summary // summary of a function of another package, if any
+	reach     map[*ssa.Function]bool     // if non-nil, functions reachable from the summarized one
+	seed      ssa.CallInstruction        // if non-nil, the sole source of taint (see summarize)
+	all       []*ssa.Function            // functions of cg with bodies (within reach)
+
+	// taint maps each tainted value to a source of its taint.
+	taint map[ssa.Value]ssa.CallInstruction
+	// returns maps each function that returns tainted results
+	// to a source of their taint.
+	returns map[*ssa.Function]ssa.CallInstruction
+
+	queue  []*ssa.Function
+	queued map[*ssa.Function]bool
+
+	findings map[findingKey]*Finding
+}
+
+func (s *state) enqueue(fn *ssa.Function) {
+	if fn.Blocks != nil && !s.queued[fn] && (s.reach == nil || s.reach[fn]) {
+		s.queued[fn] = true
+		s.queue = append(s.queue, fn)
+	}
+}
+
+// setTaint records that v is tainted by source, and reports whether
+// this is new. Taint stored in a global variable may be loaded by any
+// function, so all of them are visited again.
+func (s *state) setTaint(v ssa.Value, source ssa.CallInstruction) bool {
+	if v == nil || s.taint[v] != nil {
+		return false
+	}
+	switch v.(type) {
+	case *ssa.Const, *ssa.Function, *ssa.Builtin:
+		return false // immutable
+	case *ssa.Global:
+		for _, fn := range s.all {
+			s.enqueue(fn)
+		}
+	}
+	s.taint[v] = source
+	return true
+}
+
+// taintAddr records that the location addr, and the aggregates that
+// contain it, hold tainted data.
+func (s *state) taintAddr(addr ssa.Value, source ssa.CallInstruction) bool {
+	changed := false
+	for addr != nil {
+		changed = s.setTaint(addr, source) || changed
+		switch a := addr.(type) {
+		case *ssa.FieldAddr:
+			addr = a.X
+		case *ssa.IndexAddr:
+			addr = a.X
+		case *ssa.Slice:
+			addr = a.X
+		default:
+			addr = nil
+		}
+	}
+	return changed
+}
+
+// visit propagates taint through the instructions of fn until a fixed
+// point is reached.
+func (s *state) visit(fn *ssa.Function) {
+	var rands []*ssa.Value
+	for changed := true; changed; {
+		changed = false
+		for _, b := range fn.Blocks {
+			for _, instr := range b.Instrs {
+				switch instr := instr.(type) {
+				case ssa.CallInstruction:
+					changed = s.call(instr) || changed
+
+				case *ssa.Store:
+					if src := s.taint[instr.Val]; src != nil {
+						changed = s.taintAddr(instr.Addr, src) || changed
+					}
+
+				case *ssa.MapUpdate:
+					if src := cmp.Or(s.taint[instr.Key], s.taint[instr.Value]); src != nil {
+						changed = s.taintAddr(instr.Map, src) || changed
+					}
+
+				case *ssa.Send:
+					if src := s.taint[instr.X]; src != nil {
+						changed = s.setTaint(instr.Chan, src) || changed
+					}
+
+				case *ssa.Return:
+					for _, res := range instr.Results {
+						if src := s.taint[res]; src != nil && s.returns[fn] == nil {
+							s.returns[fn] = src
+							if node := s.cg.Nodes[fn]; node != nil {
+								for _, in := range node.In {
+									s.enqueue(in.Caller.Func)
+								}
+							}
+						}
+					}
+
+				case *ssa.MakeClosure:
+					callee := instr.Fn.(*ssa.Function)
+					for i, binding := range instr.Bindings {
+						if src := s.taint[binding]; src != nil && s.setTaint(callee.FreeVars[i], src) {
+							s.enqueue(callee)
+						}
+					}
+
+				default:
+					// The result of any other instruction
+					// is tainted if one of its operands is.
+					if v, ok := instr.(ssa.Value); ok {
+						rands = instr.Operands(rands[:0])
+						for _, rand := range rands {
+							if src := s.taint[*rand]; src != nil {
+								changed = s.setTaint(v, src) || changed
+								break
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// call propagates taint through a call instruction, and records a
+// finding if it passes tainted data to a sink.
+func (s *state) call(call ssa.CallInstruction) bool {
+	c := call.Common()
+	result := call.Value() // nil for go and defer
+
+	// args holds the arguments of the call, including the receiver
+	// or interface value; recv is the number of receivers (0 or 1).
+	args := c.Args
+	recv := 0
+	if c.IsInvoke() {
+		args = append([]ssa.Value{c.Value}, c.Args...)
+		recv = 1
+	} else if c.Signature().Recv() != nil {
+		recv = 1
+	}
+
+	if obj := callee(c); obj != nil {
+		for _, sink := range s.cfg.Sinks {
+			if sink.matches(obj) {
+				s.checkSink(call, sink, args[recv:])
+			}
+		}
+		for _, fn := range s.cfg.Sanitizers {
+			if fn.matches(obj) {
+				return false
+			}
+		}
+		for _, fn := range s.cfg.Sources {
+			if fn.matches(obj) {
+				return s.seed == nil && s.setTaint(result, call)
+			}
+		}
+		if s.summaries != nil {
+			if sum := s.summaries(obj); sum != nil {
+				return s.applySummary(call, sum, args, recv)
+			}
+		}
+	}
+
+	// Propagate taint to and from the callees with bodies.
+	changed := false
+	known := false
+	if node := s.cg.Nodes[call.Parent()]; node != nil {
+		for _, out := range node.Out {
+			g := out.Callee.Func
+			if out.Site != call || g.Blocks == nil || len(g.Params) != len(args) {
+				continue
+			}
+			known = true
+			for i, arg := range args {
+				if src := s.taint[arg]; src != nil && s.setTaint(g.Params[i], src) {
+					s.enqueue(g)
+				}
+			}
+			if src := s.returns[g]; src != nil {
+				changed = s.setTaint(result, src) || changed
+			}
+		}
+	}
+	if known {
+		return changed
+	}
+
+	// The callee is unknown or has no body: assume that the result
+	// and receiver depend on every argument.
+	for _, arg := range args {
+		src := s.taint[arg]
+		if src == nil {
+			continue
+		}
+		changed = s.setTaint(result, src) || changed
+		if recv > 0 {
+			changed = s.taintAddr(args[0], src) || changed
+		}
+		if b, ok := c.Value.(*ssa.Builtin); ok && b.Name() == "copy" {
+			changed = s.taintAddr(args[0], src) || changed
+		}
+	}
+	return changed
+}
+
+// applySummary propagates taint through a call to a function of
+// another package, according to its summary, and records a finding if
+// it passes tainted data to a parameter that reaches a sink.
+func (s *state) applySummary(call ssa.CallInstruction, sum *summary, args []ssa.Value, recv int) bool {
+	result := call.Value()
+	changed := false
+	if sum.Returns && s.seed == nil {
+		changed = s.setTaint(result, call) || changed
+	}
+	for _, i := range sum.ToResults {
+		if i < len(args) {
+			if src := s.taint[args[i]]; src != nil {
+				changed = s.setTaint(result, src) || changed
+			}
+		}
+	}
+	for _, flow := range sum.ToSinks {
+		if recv <= flow.Param && flow.Param < len(args) {
+			if src := s.taint[args[flow.Param]]; src != nil {
+				s.report(call, flow.Param-recv, flow.Kind, src)
+			}
+		}
+	}
+	return changed
+}
+
+// checkSink records a finding for each checked argument of the call
+// to the sink that is tainted.
+func (s *state) checkSink(call ssa.CallInstruction, sink Sink, args []ssa.Value) {
+	for i, arg := range args {
+		if len(sink.Args) > 0 && !slices.Contains(sink.Args, i) {
+			continue
+		}
+		if src := s.taint[arg]; src != nil {
+			s.report(call, i, cmp.Or(sink.Kind, "taint"), src)
+		}
+	}
+}
+
+// report records a finding, unless there is already one for the
+// argument of the call.
+func (s *state) report(call ssa.CallInstruction, arg int, kind string, src ssa.CallInstruction) {
+	key := findingKey{call, arg}
+	if s.findings[key] == nil {
+		s.findings[key] = &Finding{Source: src, Sink: call, Arg: arg, Kind: kind}
+	}
+}
+
+// callee returns the function or method called by c, if known statically.
+func callee(c *ssa.CallCommon) *types.Func {
+	if c.IsInvoke() {
+		return c.Method
+	}
+	if fn := c.StaticCallee(); fn != nil {
+		if fn.Origin() != nil {
+			fn = fn.Origin()
+		}
+		obj, _ := fn.Object().(*types.Func)
+		return obj
+	}
+	return nil
+}
+
+// A summary is a fact that records the flows of tainted data through
+// a function, for the analysis of calls to it from other packages.
+// Parameters are indexed as in [ssa.Function.Params], so the
+// receiver of a method is parameter 0.
+type summary struct {
+	Returns   bool       // results are tainted by a source, whatever the arguments
+	ToResults []int      // parameters whose taint reaches the results
+	ToSinks   []sinkFlow // parameters whose taint reaches a sink
+}
+
+// A sinkFlow records that the taint of a parameter reaches a sink of
+// the specified kind.
+type sinkFlow struct {
+	Param int
+	Kind  string
+}
+
+func (*summary) AFact() {}
+
+func (sum *summary) String() string {
+	var buf strings.Builder
+	buf.WriteString("taint")
+	if sum.Returns {
+		buf.WriteString(" returns")
+	}
+	if len(sum.ToResults) > 0 {
+		fmt.Fprintf(&buf, " results%v", sum.ToResults)
+	}
+	for _, flow := range sum.ToSinks {
+		fmt.Fprintf(&buf, " sink%d:%s", flow.Param, flow.Kind)
+	}
+	return buf.String()
+}
+
+// summarize returns the summary of fn, given whether its results are
+// tainted by a source.
+//
+// It analyzes the functions reachable from fn once for each of its
+// parameters, with the parameter as the sole source of taint.
+func summarize(cfg *Config, cg *callgraph.Graph, summaries func(*types.Func) *summary, fn *ssa.Function, returns bool) *summary {
+	recv := 0
+	if fn.Signature.Recv() != nil {
+		recv = 1
+	}
+	sum := &summary{Returns: returns}
+	for i, param := range fn.Params {
+		s := newState(cfg, cg, summaries, fn)
+		s.seed = new(ssa.Call) // stands for the caller's source of taint
+		s.taint[param] = s.seed
+		s.run()
+		if s.returns[fn] == s.seed {
+			sum.ToResults = append(sum.ToResults, i)
+		}
+		if i < recv {
+			continue // the receiver is not an argument of a sink
+		}
+		var kinds []string
+		for _, f := range s.findings {
+			if f.Source == s.seed && !slices.Contains(kinds, f.Kind) {
+				kinds = append(kinds, f.Kind)
+			}
+		}
+		slices.Sort(kinds)
+		for _, kind := range kinds {
+			sum.ToSinks = append(sum.ToSinks, sinkFlow{Param: i, Kind: kind})
+		}
+	}
+	return sum
+}
@@ -0,0 +1,181 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package taint_test
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/callgraph/static"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+	"golang.org/x/tools/go/ssa/taint"
+)
+
+const config = `{
+	"sources": [{"package": "p", "name": "Input"}, {"package": "p", "receiver": "Request", "name": "Header"}],
+	"sinks": [
+		{"package": "p", "name": "Exec", "args": [1], "kind": "injection"},
+		{"package": "p", "receiver": "DB", "name": "Query"}
+	],
+	"sanitizers": [{"package": "p", "name": "Escape"}]
+}`
+
+const input = `package p
+
+func Input() string
+func Escape(s string) string
+func Exec(db string, q string)
+
+type Request struct{}
+
+func (*Request) Header(name string) string
+
+type DB struct{}
+
+func (*DB) Query(q string, args ...any)
+
+func direct() {
+	Exec(Input(), Input()) // want "injection: argument 1"
+}
+
+func sanitized() {
+	Exec("", Escape(Input()))
+}
+
+func concat(cond bool) {
+	s := "x"
+	if cond {
+		s += Input()
+	}
+	Exec("", "select "+s) // want "injection: argument 1"
+}
+
+func id(s string) string { return s }
+
+func constant(s string) string { return "const" }
+
+func helper() {
+	Exec("", id(Input())) // want "injection: argument 1"
+	Exec("", constant(Input()))
+}
+
+func sink(s string) {
+	Exec("", s) // want "injection: argument 1"
+}
+
+func callsSink(r *Request) {
+	sink(r.Header("X"))
+}
+
+type T struct{ a, b string }
+
+func field(db *DB) {
+	var t T
+	t.a = Input()
+	db.Query(t.a) // want "taint: argument 0"
+}
+
+func closure(db *DB) {
+	s := Input()
+	func() {
+		db.Query("", s) // want "taint: argument 1"
+	}()
+}
+
+var global string
+
+func setGlobal() { global = Input() }
+
+func useGlobal() {
+	Exec("", global) // want "injection: argument 1"
+}
+`
+
+func TestAnalyze(t *testing.T) {
+	cfg, err := taint.ParseConfig([]byte(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", input, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg, _, err := ssautil.BuildPackage(&types.Config{}, fset, types.NewPackage("p", ""), []*ast.File{f}, ssa.SanityCheckFunctions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Gather the expectations from the "want" comments.
+	want := make(map[int]string) // line -> message prefix
+	for _, cg := range f.Comments {
+		for _, c := range cg.List {
+			if text, ok := strings.CutPrefix(c.Text, `// want "`); ok {
+				want[fset.Position(c.Pos()).Line] = strings.TrimSuffix(text, `"`)
+			}
+		}
+	}
+
+	for _, finding := range taint.Analyze(cfg, static.CallGraph(pkg.Prog)) {
+		posn := fset.Position(finding.Sink.Pos())
+		msg := finding.Message()
+		prefix, ok := want[posn.Line]
+		if !ok {
+			t.Errorf("%v: unexpected finding: %s", posn, msg)
+			continue
+		}
+		delete(want, posn.Line)
+		if !strings.HasPrefix(msg, prefix) {
+			t.Errorf("%v: got finding %q, want %q", posn, msg, prefix)
+		}
+		if diag := finding.Diagnostic(); diag.Category != finding.Kind || diag.Message != msg || len(diag.Related) != 1 {
+			t.Errorf("%v: bad diagnostic %+v", posn, diag)
+		}
+	}
+	for line, prefix := range want {
+		t.Errorf("p.go:%d: missing finding %q", line, prefix)
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	for _, test := range []struct {
+		config, wantErr string
+	}{
+		{config, ""},
+		{`{}`, ""},
+		{`{"sources": [{"package": "p"}]}`, "lacks a package or name"},
+		{`{"sinks": [{"package": "p", "name": "F", "args": [-1]}]}`, "negative argument index"},
+		{`{"sinks": [{"package": "p", "name": "F", "arg": [0]}]}`, "unknown field"},
+		{`[]`, "cannot unmarshal"},
+	} {
+		_, err := taint.ParseConfig([]byte(test.config))
+		if got := fmt.Sprint(err); test.wantErr == "" && err != nil || !strings.Contains(got, test.wantErr) {
+			t.Errorf("ParseConfig(%s) = %v, want error containing %q", test.config, err, test.wantErr)
+		}
+	}
+}
+
+// TestAnalyzer checks that the analyzer propagates taint through the
+// functions of other packages using their summaries.
+func TestAnalyzer(t *testing.T) {
+	cfg, err := taint.ParseConfig([]byte(`{
+		"sources": [{"package": "a", "name": "Input"}],
+		"sinks": [{"package": "a", "name": "Exec", "kind": "injection"}],
+		"sanitizers": [{"package": "a", "name": "Escape"}]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	analyzer := taint.NewAnalyzer("taint", "report tainted data", cfg)
+	analysistest.Run(t, analysistest.TestData(), analyzer, "a", "b")
+}
@@ -0,0 +1,19 @@
+package a
+
+func Input() string { return "" } // want Input:"taint"
+
+func Escape(s string) string { return "" } // want Escape:"taint"
+
+func Exec(q string) {} // want Exec:"taint"
+
+func Fetch() string { return Input() } // want Fetch:"taint returns"
+
+func Wrap(s string) string { return "(" + s + ")" } // want Wrap:`taint results\[0\]`
+
+func Run(prefix, q string) { Exec(q) } // want Run:"taint sink1:injection"
+
+func Clean(s string) string { return Escape(s) } // want Clean:"taint"
+
+type Conn struct{ q string }
+
+func (c *Conn) Query() string { return c.q } // want Query:`taint results\[0\]`
@@ -0,0 +1,16 @@
+package b
+
+import "a"
+
+func f(c *a.Conn) { // want f:"taint sink0:injection"
+	a.Exec(a.Fetch())         // want "injection: argument 0 of call to a.Exec is tainted by call to a.Fetch"
+	a.Exec(a.Wrap(a.Input())) // want "injection: argument 0 of call to a.Exec is tainted by call to a.Input"
+	a.Run("", a.Input())      // want "injection: argument 1 of call to a.Run is tainted by call to a.Input"
+	a.Run(a.Input(), "")
+	a.Exec(a.Clean(a.Input()))
+	a.Exec(c.Query())
+}
+
+func g() string { // want g:"taint returns"
+	return a.Wrap(a.Fetch())
+}
@@ -0,0 +1,387 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssa
+
+// This file implements the replacement of changed packages and
+// functions in an existing Program.
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/internal/typeparams"
+)
+
+// A PackageUpdate describes the new type information and syntax of
+// a package whose SSA form is to be replaced by
+// [Program.UpdatePackages].
+type PackageUpdate struct {
+	Old   *Package       // the package to replace
+	Pkg   *types.Package // the new type information of the package
+	Files []*ast.File    // the new syntax of the package
+	Info  *types.Info    // the new type information of the syntax
+}
+
+// UpdatePackages replaces the SSA form of changed packages, as if each
+// package were created anew by [Program.CreatePackage], while reusing
+// the functions of all other packages. It returns the new packages,
+// in the order of the updates; each is importable if the package it
+// replaces was. Like those of CreatePackage, they are not built until
+// a call to [Package.Build].
+//
+// Since the functions of a package refer to the members of the
+// packages it imports, a package that imports a replaced package must
+// be replaced too. UpdatePackages reports an error, and makes no
+// change, if some other package of the program imports one of the
+// replaced packages, or if the new type information of a package
+// refers to one of them. An interactive tool typically updates the
+// packages it type-checked again after an edit: those whose files
+// changed, and those that import them, directly or indirectly, up to
+// the packages for which it chose to reuse the previous types. An edit
+// confined to the body of a function is better handled by
+// [Program.UpdateFunctions], which rebuilds only that function.
+//
+// The entries derived from the replaced packages are removed from the
+// program's caches, so that [Program.RuntimeTypes] and the instances
+// of generic functions no longer mention them. (Entries of the
+// exported MethodSets cache are not removed.)
+//
+// UpdatePackages must not be called concurrently with the building
+// of any package of the program.
+func (prog *Program) UpdatePackages(updates []PackageUpdate) ([]*Package, error) {
+	replaced := make(map[*types.Package]bool, len(updates))
+	for _, u := range updates {
+		if prog.packages[u.Old.Pkg] != u.Old {
+			return nil, fmt.Errorf("package %s is not in the program", u.Old.Pkg.Path())
+		}
+		replaced[u.Old.Pkg] = true
+	}
+	for tpkg, p := range prog.packages {
+		if replaced[tpkg] {
+			continue
+		}
+		for _, imp := range tpkg.Imports() {
+			if replaced[imp] {
+				return nil, fmt.Errorf("package %s imports %s, so it must be updated too", p.Pkg.Path(), imp.Path())
+			}
+		}
+	}
+	for _, u := range updates {
+		for _, imp := range u.Pkg.Imports() {
+			if replaced[imp] {
+				return nil, fmt.Errorf("new types of package %s refer to the replaced package %s", u.Pkg.Path(), imp.Path())
+			}
+		}
+	}
+
+	// Remove the replaced packages and the entries that refer to them.
+	importable := make([]bool, len(updates))
+	for i, u := range updates {
+		path := u.Old.Pkg.Path()
+		importable[i] = prog.imported[path] == u.Old
+		if importable[i] {
+			delete(prog.imported, path)
+		}
+		delete(prog.packages, u.Old.Pkg)
+	}
+	prog.forgetPackages(replaced)
+
+	pkgs := make([]*Package, len(updates))
+	for i, u := range updates {
+		pkgs[i] = prog.CreatePackage(u.Pkg, u.Files, u.Info, importable[i])
+	}
+	return pkgs, nil
+}
+
+// forgetPackages removes from the program's caches the entries that
+// refer to the specified packages.
+func (prog *Program) forgetPackages(pkgs map[*types.Package]bool) {
+	prog.objectMethodsMu.Lock()
+	for obj := range prog.objectMethods {
+		if pkgs[obj.Pkg()] {
+			delete(prog.objectMethods, obj)
+		}
+	}
+	prog.objectMethodsMu.Unlock()
+
+	prog.externalsMu.Lock()
+	for obj := range prog.externals {
+		if pkgs[obj.Pkg()] {
+			delete(prog.externals, obj)
+		}
+	}
+	prog.externalsMu.Unlock()
+
+	prog.methodsMu.Lock()
+	for _, T := range prog.methodSets.Keys() {
+		if refersTo(pkgs, T) {
+			prog.methodSets.Delete(T)
+		}
+	}
+	prog.methodsMu.Unlock()
+
+	prog.makeInterfaceTypesMu.Lock()
+	for T := range prog.makeInterfaceTypes {
+		if refersTo(pkgs, T) {
+			delete(prog.makeInterfaceTypes, T)
+		}
+	}
+	prog.makeInterfaceTypesMu.Unlock()
+
+	prog.canon.mu.Lock()
+	for _, T := range prog.canon.types.Keys() {
+		if refersTo(pkgs, T) {
+			prog.canon.types.Delete(T)
+		}
+	}
+	for h, bucket := range prog.canon.lists.buckets {
+		bucket = slices.DeleteFunc(bucket, func(l *typeList) bool { return refersTo(pkgs, *l...) })
+		if len(bucket) > 0 {
+			prog.canon.lists.buckets[h] = bucket
+		} else {
+			delete(prog.canon.lists.buckets, h)
+		}
+	}
+	prog.canon.mu.Unlock()
+
+	// The memo is cheap to recompute.
+	prog.hasParamsMu.Lock()
+	prog.hasParams = typeparams.Free{}
+	prog.hasParamsMu.Unlock()
+
+	// Remove the instances of the remaining generic functions
+	// whose type arguments refer to the packages.
+	for _, p := range prog.packages {
+		for _, mem := range p.objects {
+			fn, ok := mem.(*Function)
+			if !ok || fn.generic == nil {
+				continue
+			}
+			fn.generic.instancesMu.Lock()
+			for key := range fn.generic.instances {
+				if refersTo(pkgs, *key...) {
+					delete(fn.generic.instances, key)
+				}
+			}
+			fn.generic.instancesMu.Unlock()
+		}
+	}
+}
+
+// A FunctionUpdate describes the new syntax of a function whose SSA
+// form is to be rebuilt by [Program.UpdateFunctions].
+type FunctionUpdate struct {
+	Fn     *Function     // the function to rebuild
+	Syntax *ast.FuncDecl // the new declaration of the function
+	Info   *types.Info   // the type information of the new declaration
+}
+
+// UpdateFunctions rebuilds the SSA form of functions whose bodies
+// changed, in place, without changing the packages that declare them
+// or the other functions of the program, which continue to refer to
+// the same Function values. The instances of a generic function are
+// rebuilt too.
+//
+// Each function must be a package-level function or method declared
+// in syntax, and its new declaration must have the same signature, so
+// that the types of its package are unchanged. Its type information
+// must use the objects of that package: a tool may obtain it by
+// type-checking a function literal whose parameters are the receiver
+// and parameters of the new declaration, and whose results and body
+// are its own, using [types.CheckExpr] at the position of the body of
+// the declaration that was type-checked with the package, where any
+// type parameters are in scope. An
+// edit that changes the types of a package requires
+// [Program.UpdatePackages] instead. UpdateFunctions reports an error,
+// and makes no change, if an update does not satisfy these conditions.
+//
+// UpdateFunctions must not be called concurrently with the building
+// of any package of the program.
+func (prog *Program) UpdateFunctions(updates []FunctionUpdate) error {
+	for _, u := range updates {
+		fn := u.Fn
+		if fn.Prog != prog || fn.Pkg == nil || fn.object == nil || fn.parent != nil || fn.topLevelOrigin != nil {
+			return fmt.Errorf("%s is not a function declared in a package of the program", fn)
+		}
+		if _, ok := fn.syntax.(*ast.FuncDecl); !ok {
+			return fmt.Errorf("%s is not declared in syntax", fn)
+		}
+		if err := checkSameSignature(fn, u.Syntax, u.Info); err != nil {
+			return err
+		}
+	}
+
+	for _, u := range updates {
+		fn := u.Fn
+		fn.Pkg.buildMu.Lock()
+		fn.syntax = u.Syntax
+		fn.info = u.Info
+		fn.pos = u.Syntax.Name.Pos()
+		if fn.build != nil {
+			// Not yet built: its package builds the new syntax.
+			fn.Pkg.buildMu.Unlock()
+			continue
+		}
+		b := builder{}
+		fn.reset()
+		b.enqueue(fn)
+		if fn.generic != nil {
+			fn.generic.instancesMu.Lock()
+			for _, inst := range fn.generic.instances {
+				inst.syntax, inst.info, inst.pos = fn.syntax, fn.info, fn.pos
+				if prog.mode&InstantiateGenerics != 0 && !prog.isParameterized(inst.typeargs...) {
+					// An instance, not an instantiation wrapper.
+					inst.reset()
+					inst.subst = makeSubster(prog.ctxt, inst.object, fn.typeparams, inst.typeargs, false)
+					b.enqueue(inst)
+				}
+			}
+			fn.generic.instancesMu.Unlock()
+		}
+		b.iterate()
+		fn.Pkg.buildMu.Unlock()
+	}
+	return nil
+}
+
+// reset discards the built body of fn, so that it is built again
+// from its syntax.
+func (fn *Function) reset() {
+	fn.Params = nil
+	fn.Locals = nil
+	fn.Blocks = nil
+	fn.Recover = nil
+	fn.AnonFuncs = nil
+	fn.instrSyntax = nil
+	fn.build = (*builder).buildFromSyntax
+}
+
+// checkSameSignature reports an error if the receiver, parameters,
+// and results declared by decl, according to info, do not have the
+// types of the signature of fn.
+func checkSameSignature(fn *Function, decl *ast.FuncDecl, info *types.Info) error {
+	if decl == nil || decl.Body == nil || info == nil {
+		return fmt.Errorf("no new syntax or type information for %s", fn)
+	}
+	if decl.Name.Name != fn.object.Name() || (decl.Recv != nil) != (fn.Signature.Recv() != nil) {
+		return fmt.Errorf("new declaration of %s declares %s", fn, decl.Name.Name)
+	}
+	var want []*types.Var
+	if recv := fn.Signature.Recv(); recv != nil {
+		want = append(want, recv)
+	}
+	want = slices.AppendSeq(want, fn.Signature.Params().Variables())
+	want = slices.AppendSeq(want, fn.Signature.Results().Variables())
+
+	var got []types.Type
+	for _, list := range []*ast.FieldList{decl.Recv, decl.Type.Params, decl.Type.Results} {
+		if list == nil {
+			continue
+		}
+		for _, field := range list.List {
+			T := info.TypeOf(field.Type)
+			for range max(1, len(field.Names)) {
+				got = append(got, T)
+			}
+		}
+	}
+	if len(got) != len(want) {
+		return fmt.Errorf("signature of %s changed", fn)
+	}
+	for i, v := range want {
+		if got[i] == nil || !types.Identical(got[i], v.Type()) {
+			return fmt.Errorf("signature of %s changed", fn)
+		}
+	}
+	return nil
+}
+
+// refersTo reports whether any of the types refers to a named
+// type, alias, or type parameter declared in one of the packages.
+func refersTo(pkgs map[*types.Package]bool, ts ...types.Type) bool {
+	seen := make(map[types.Type]bool)
+	var visit func(T types.Type) bool
+	visit = func(T types.Type) bool {
+		if T == nil || seen[T] {
+			return false
+		}
+		seen[T] = true
+		switch T := T.(type) {
+		case *types.Basic:
+			return false
+		case *types.Alias:
+			return pkgs[T.Obj().Pkg()] || visitList(T.TypeArgs(), visit) || visit(types.Unalias(T))
+		case *types.Named:
+			return pkgs[T.Obj().Pkg()] || visitList(T.TypeArgs(), visit)
+		case *types.TypeParam:
+			return pkgs[T.Obj().Pkg()]
+		case *types.Pointer:
+			return visit(T.Elem())
+		case *types.Slice:
+			return visit(T.Elem())
+		case *types.Array:
+			return visit(T.Elem())
+		case *types.Chan:
+			return visit(T.Elem())
+		case *types.Map:
+			return visit(T.Key()) || visit(T.Elem())
+		case *types.Struct:
+			for i := range T.NumFields() {
+				if visit(T.Field(i).Type()) {
+					return true
+				}
+			}
+			return false
+		case *types.Tuple:
+			for i := range T.Len() {
+				if visit(T.At(i).Type()) {
+					return true
+				}
+			}
+			return false
+		case *types.Signature:
+			return visit(T.Params()) || visit(T.Results())
+		case *types.Interface:
+			for i := range T.NumEmbeddeds() {
+				if visit(T.EmbeddedType(i)) {
+					return true
+				}
+			}
+			for i := range T.NumExplicitMethods() {
+				if visit(T.ExplicitMethod(i).Type()) {
+					return true
+				}
+			}
+			return false
+		case *types.Union:
+			for i := range T.Len() {
+				if visit(T.Term(i).Type()) {
+					return true
+				}
+			}
+			return false
+		default:
+			panic(fmt.Sprintf("unexpected type: %T", T))
+		}
+	}
+	for _, T := range ts {
+		if visit(T) {
+			return true
+		}
+	}
+	return false
+}
+
+// visitList reports whether f holds for any type in the list.
+func visitList(list *types.TypeList, f func(types.Type) bool) bool {
+	for i := range list.Len() {
+		if f(list.At(i)) {
+			return true
+		}
+	}
+	return false
+}
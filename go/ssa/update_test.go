@@ -0,0 +1,245 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package ssa_test
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/ssa"
+)
+
+func TestUpdatePackages(t *testing.T) {
+	fset := token.NewFileSet()
+	tpkgs := make(map[string]*types.Package)
+
+	// check type-checks a package whose imports are those of tpkgs.
+	check := func(path, src string) ssa.PackageUpdate {
+		f, err := parser.ParseFile(fset, path+".go", src, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		info := newInfo()
+		conf := types.Config{Importer: importerFunc(func(path string) (*types.Package, error) {
+			if tpkg := tpkgs[path]; tpkg != nil {
+				return tpkg, nil
+			}
+			return nil, fmt.Errorf("no package %q", path)
+		})}
+		tpkg, err := conf.Check(path, fset, []*ast.File{f}, info)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tpkgs[path] = tpkg
+		return ssa.PackageUpdate{Pkg: tpkg, Files: []*ast.File{f}, Info: info}
+	}
+
+	const (
+		c = `package c; func Id[X any](x X) X { return x }`
+		a = `package a; type T int; func F() T { return 1 }`
+		b = `package b; import ("a"; "c"); func G() any { return c.Id(a.F()) }`
+	)
+	prog := ssa.NewProgram(fset, ssa.SanityCheckFunctions|ssa.InstantiateGenerics)
+	var pkgs []*ssa.Package
+	for _, u := range []ssa.PackageUpdate{check("c", c), check("a", a), check("b", b)} {
+		pkgs = append(pkgs, prog.CreatePackage(u.Pkg, u.Files, u.Info, true))
+	}
+	prog.Build()
+	oldC, oldA, oldB := pkgs[0], pkgs[1], pkgs[2]
+
+	newA := check("a", strings.Replace(a, "return 1", "return 2", 1))
+	newA.Old = oldA
+	if _, err := prog.UpdatePackages([]ssa.PackageUpdate{newA}); err == nil || !strings.Contains(err.Error(), "package b imports a") {
+		t.Fatalf("UpdatePackages without importer returned %v, want error", err)
+	}
+
+	// Change the body of a.F, and type-check a and its importer b
+	// again, repeatedly.
+	for i := 2; i <= 4; i++ {
+		newA := check("a", strings.Replace(a, "return 1", fmt.Sprintf("return %d", i), 1))
+		newA.Old = oldA
+		newB := check("b", b)
+		newB.Old = oldB
+		updated, err := prog.UpdatePackages([]ssa.PackageUpdate{newA, newB})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, p := range updated {
+			p.Build()
+		}
+
+		if prog.ImportedPackage("a") != updated[0] || prog.ImportedPackage("b") != updated[1] || prog.ImportedPackage("c") != oldC {
+			t.Errorf("ImportedPackage returned old packages after update %d", i)
+		}
+		if got := len(prog.AllPackages()); got != 3 {
+			t.Errorf("program has %d packages after update %d, want 3", got, i)
+		}
+		var sb strings.Builder
+		updated[0].Func("F").WriteTo(&sb)
+		if want := fmt.Sprintf("return %d:T", i); !strings.Contains(sb.String(), want) {
+			t.Errorf("a.F was not rebuilt after update %d:\n%s", i, sb.String())
+		}
+		for _, T := range prog.RuntimeTypes() {
+			if n, ok := T.(*types.Named); ok && n.Obj().Pkg().Path() == "a" && n.Obj().Pkg() != updated[0].Pkg {
+				t.Errorf("RuntimeTypes mentions replaced type %v after update %d", T, i)
+			}
+		}
+		// The instance c.Id[a.T] called by b.G is that of the new a.T.
+		if callee := calleeOf(t, updated[1].Func("G"), "Id"); callee == nil {
+			t.Errorf("no call of c.Id in b.G after update %d", i)
+		} else if param := callee.Signature.Params().At(0).Type().(*types.Named); param.Obj().Pkg() != updated[0].Pkg {
+			t.Errorf("b.G calls an instance of c.Id for the replaced type %v after update %d", param, i)
+		}
+		oldA, oldB = updated[0], updated[1]
+	}
+}
+
+func TestUpdateFunctions(t *testing.T) {
+	const src = `package p
+
+type T struct{ x int }
+
+func (t *T) Get() int { return t.x }
+
+func F(x int) int { return x + 1 }
+
+func Id[X any](x X) X { return x }
+
+func G() int { return F(Id(2)) + new(T).Get() }
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := newInfo()
+	tpkg, err := new(types.Config).Check("p", fset, []*ast.File{f}, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prog := ssa.NewProgram(fset, ssa.SanityCheckFunctions|ssa.InstantiateGenerics)
+	p := prog.CreatePackage(tpkg, []*ast.File{f}, info, true)
+	p.Build()
+
+	G := p.Func("G")
+	get := prog.FuncValue(tpkg.Scope().Lookup("T").Type().(*types.Named).Method(0))
+	id := calleeOf(t, G, "Id")
+
+	// update type-checks the new declaration of fn, as a function
+	// literal in the scope of the declaration checked with tpkg.
+	lbrace := make(map[string]token.Pos)
+	for _, decl := range f.Decls {
+		if decl, ok := decl.(*ast.FuncDecl); ok {
+			lbrace[decl.Name.Name] = decl.Body.Lbrace
+		}
+	}
+	update := func(fn *ssa.Function, decl string) ssa.FunctionUpdate {
+		t.Helper()
+		f, err := parser.ParseFile(fset, "new.go", "package p\n"+decl, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		newDecl := f.Decls[0].(*ast.FuncDecl)
+		params := &ast.FieldList{}
+		if newDecl.Recv != nil {
+			params.List = append(params.List, newDecl.Recv.List...)
+		}
+		params.List = append(params.List, newDecl.Type.Params.List...)
+		lit := &ast.FuncLit{
+			Type: &ast.FuncType{Func: newDecl.Type.Func, Params: params, Results: newDecl.Type.Results},
+			Body: newDecl.Body,
+		}
+		info := newInfo()
+		if err := types.CheckExpr(fset, tpkg, lbrace[newDecl.Name.Name], lit, info); err != nil {
+			t.Fatal(err)
+		}
+		return ssa.FunctionUpdate{Fn: fn, Syntax: newDecl, Info: info}
+	}
+	body := func(fn *ssa.Function) string {
+		var sb strings.Builder
+		fn.WriteTo(&sb)
+		return sb.String()
+	}
+
+	// Change the body of F, repeatedly.
+	for i := 2; i <= 3; i++ {
+		F := p.Func("F")
+		u := update(F, fmt.Sprintf("func F(x int) int { return x + %d }", i*10))
+		if err := prog.UpdateFunctions([]ssa.FunctionUpdate{u}); err != nil {
+			t.Fatal(err)
+		}
+		if want := fmt.Sprintf("%d:int", i*10); !strings.Contains(body(F), want) {
+			t.Errorf("F was not rebuilt after update %d:\n%s", i, body(F))
+		}
+		if calleeOf(t, G, "F") != F {
+			t.Errorf("G no longer calls F after update %d", i)
+		}
+	}
+
+	// Change the bodies of a generic function and a method.
+	err = prog.UpdateFunctions([]ssa.FunctionUpdate{
+		update(p.Func("Id"), `func Id[X any](x X) X { println("id"); return x }`),
+		update(get, `func (t *T) Get() int { return t.x * 2 }`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calleeOf(t, G, "Id") != id || !strings.Contains(body(id), `println("id":string)`) {
+		t.Errorf("instance Id[int] was not rebuilt:\n%s", body(id))
+	}
+	if !strings.Contains(body(get), "2:int") {
+		t.Errorf("method Get was not rebuilt:\n%s", body(get))
+	}
+
+	// A change of signature requires UpdatePackages.
+	u := update(p.Func("F"), `func F(x string) int { return len(x) }`)
+	if err := prog.UpdateFunctions([]ssa.FunctionUpdate{u}); err == nil || !strings.Contains(err.Error(), "signature of p.F changed") {
+		t.Errorf("UpdateFunctions with a new signature returned %v, want error", err)
+	}
+}
+
+// newInfo returns a types.Info that records everything the SSA
+// builder uses.
+func newInfo() *types.Info {
+	return &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Implicits:  make(map[ast.Node]types.Object),
+		Instances:  make(map[*ast.Ident]types.Instance),
+		Scopes:     make(map[ast.Node]*types.Scope),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+}
+
+// calleeOf returns the static callee of the first call in fn of a
+// function of the specified name.
+func calleeOf(t *testing.T, fn *ssa.Function, name string) *ssa.Function {
+	t.Helper()
+	for _, b := range fn.Blocks {
+		for _, instr := range b.Instrs {
+			if call, ok := instr.(ssa.CallInstruction); ok {
+				callee := call.Common().StaticCallee()
+				if callee != nil && callee.Origin() != nil {
+					if callee.Origin().Name() == name {
+						return callee
+					}
+				} else if callee != nil && callee.Name() == name {
+					return callee
+				}
+			}
+		}
+	}
+	return nil
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) { return f(path) }
@@ -0,0 +1,203 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package freevars reports the free variables of a piece of syntax,
+// such as a function literal or a block: the local variables declared
+// outside it that it refers to, and how it uses them.
+//
+// This information is needed by analyses and refactorings that reason
+// about closures, such as a check for loop variables captured by a
+// goroutine, or the extraction of a block into a new function, whose
+// parameters are the variables that the block reads and whose results
+// are those that it writes.
+package freevars
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// A Var describes the uses of a free variable by a node.
+type Var struct {
+	Obj *types.Var
+
+	// Read reports whether the node may read the value of the
+	// variable, for example by using it in an expression or by
+	// updating it with an assignment such as x += 1.
+	Read bool
+
+	// Written reports whether the node assigns to the variable or
+	// to a part of it, such as a field of a struct variable or an
+	// element of an array variable, or increments or decrements it.
+	Written bool
+
+	// AddrTaken reports whether the node takes the address of the
+	// variable or of a part of it, explicitly by &x or implicitly
+	// by a call of a method with a pointer receiver, so that it
+	// may be read or written indirectly.
+	AddrTaken bool
+
+	// Captured reports whether the variable is referred to by a
+	// function literal: the node itself, or one nested within it.
+	// A function literal captures its free variables by reference,
+	// so it shares them with the enclosing function.
+	Captured bool
+}
+
+// Of returns the free variables of the node n, in the order of their
+// first reference within it. A free variable of n is a local variable
+// or parameter that is declared outside n and referred to within it.
+// Package-level variables are not free variables, nor are struct
+// fields.
+//
+// The information must include Defs, Uses, and Selections.
+func Of(info *types.Info, n ast.Node) []*Var {
+	// Find the identifiers that are assigned, assigned and read,
+	// or whose address is taken.
+	const (
+		written = 1 << iota
+		read
+		addrTaken
+	)
+	roles := make(map[*ast.Ident]int)
+	markRoot := func(e ast.Expr, role int) {
+		if id := root(info, e); id != nil {
+			roles[id] |= role
+		}
+	}
+	ast.Inspect(n, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			role := written
+			if n.Tok != token.ASSIGN && n.Tok != token.DEFINE {
+				role |= read // x op= y
+			}
+			for _, lhs := range n.Lhs {
+				markRoot(lhs, role)
+			}
+
+		case *ast.IncDecStmt:
+			markRoot(n.X, written|read)
+
+		case *ast.RangeStmt:
+			if n.Tok == token.ASSIGN {
+				if n.Key != nil {
+					markRoot(n.Key, written)
+				}
+				if n.Value != nil {
+					markRoot(n.Value, written)
+				}
+			}
+
+		case *ast.UnaryExpr:
+			if n.Op == token.AND {
+				markRoot(n.X, addrTaken)
+			}
+
+		case *ast.SelectorExpr:
+			// A call of a method with a pointer receiver on an
+			// addressable value implicitly takes its address.
+			if sel, ok := info.Selections[n]; ok && sel.Kind() == types.MethodVal {
+				recv := sel.Obj().(*types.Func).Signature().Recv()
+				if _, ok := recv.Type().Underlying().(*types.Pointer); ok && !isPointer(info, n.X) {
+					markRoot(n.X, addrTaken)
+				}
+			}
+		}
+		return true
+	})
+
+	// Record the uses of free variables.
+	var (
+		vars    []*Var
+		byObj   = make(map[*types.Var]*Var)
+		funcLit = 0 // depth of nested function literals
+	)
+	var visit func(node ast.Node) bool
+	visit = func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.FuncLit:
+			funcLit++
+			ast.Inspect(node.Type, visit)
+			ast.Inspect(node.Body, visit)
+			funcLit--
+			return false
+
+		case *ast.Ident:
+			v, ok := info.Uses[node].(*types.Var)
+			if !ok || v.IsField() || !isLocal(v) || n.Pos() <= v.Pos() && v.Pos() < n.End() {
+				return true
+			}
+			fv := byObj[v]
+			if fv == nil {
+				fv = &Var{Obj: v}
+				byObj[v] = fv
+				vars = append(vars, fv)
+			}
+			role, ok := roles[node]
+			if !ok {
+				role = read
+			}
+			fv.Read = fv.Read || role&read != 0
+			fv.Written = fv.Written || role&written != 0
+			fv.AddrTaken = fv.AddrTaken || role&addrTaken != 0
+			fv.Captured = fv.Captured || funcLit > 0
+		}
+		return true
+	}
+	ast.Inspect(n, visit)
+	return vars
+}
+
+// root returns the identifier of the variable that holds the storage
+// denoted by the addressable expression e, such as x in x.f[i] where x
+// is a struct whose field f is an array, or nil if there is none,
+// for example because the storage is reached through a pointer.
+func root(info *types.Info, e ast.Expr) *ast.Ident {
+	for {
+		switch x := e.(type) {
+		case *ast.ParenExpr:
+			e = x.X
+
+		case *ast.Ident:
+			return x
+
+		case *ast.SelectorExpr:
+			sel, ok := info.Selections[x]
+			if !ok || sel.Kind() != types.FieldVal || sel.Indirect() || isPointer(info, x.X) {
+				return nil // qualified identifier, method, or indirect field
+			}
+			e = x.X
+
+		case *ast.IndexExpr:
+			tv, ok := info.Types[x.X]
+			if !ok {
+				return nil
+			}
+			if _, ok := tv.Type.Underlying().(*types.Array); !ok {
+				return nil // slice, map, string, or pointer to array
+			}
+			e = x.X
+
+		default:
+			return nil
+		}
+	}
+}
+
+// isPointer reports whether the type of e is a pointer.
+func isPointer(info *types.Info, e ast.Expr) bool {
+	tv, ok := info.Types[e]
+	if !ok {
+		return false
+	}
+	_, ok = tv.Type.Underlying().(*types.Pointer)
+	return ok
+}
+
+// isLocal reports whether v is a local variable or parameter.
+func isLocal(v *types.Var) bool {
+	return v.Pkg() != nil && v.Parent() != v.Pkg().Scope()
+}
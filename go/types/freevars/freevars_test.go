@@ -0,0 +1,106 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package freevars_test
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/types/freevars"
+)
+
+func TestOf(t *testing.T) {
+	const src = `package p
+
+var global int
+
+type S struct{ f [2]int }
+
+func (*S) m() {}
+
+func f(param int, ptr *S) {
+	var (
+		r, w, rw, inc, s, arr, addr, slice, closure int
+		st, st2 S
+		arr2 [2]int
+		sl []int
+	)
+	_, _, _ = slice, arr, st2
+	defer func() { _, _, _, _, _, _ = w, rw, inc, closure, arr2, sl }()
+
+	// block
+	{
+		local := r + global
+		w = local
+		rw += 1
+		inc++
+		st.f[0] = 1
+		arr2[1] = 2
+		sl[0] = param
+		ptr.f[0] = 3
+		_ = &addr
+		st2.m()
+		func() { closure = 1 }()
+	}
+
+	// funclit
+	_ = func() int { return s + param }
+}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := &types.Info{
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	var conf types.Config
+	if _, err := conf.Check("p", fset, []*ast.File{f}, info); err != nil {
+		t.Fatal(err)
+	}
+
+	// format returns a description of each variable.
+	format := func(vars []*freevars.Var) string {
+		var descs []string
+		for _, v := range vars {
+			desc := v.Obj.Name() + ":"
+			for _, flag := range []struct {
+				set  bool
+				name string
+			}{{v.Read, "r"}, {v.Written, "w"}, {v.AddrTaken, "a"}, {v.Captured, "c"}} {
+				if flag.set {
+					desc += flag.name
+				}
+			}
+			descs = append(descs, desc)
+		}
+		return strings.Join(descs, " ")
+	}
+
+	body := f.Decls[len(f.Decls)-1].(*ast.FuncDecl).Body
+	block := body.List[3].(*ast.BlockStmt)
+	funcLit := body.List[4].(*ast.AssignStmt).Rhs[0].(*ast.FuncLit)
+
+	for _, test := range []struct {
+		node ast.Node
+		want string
+	}{
+		{block, "r:r w:w rw:rw inc:rw st:w arr2:w sl:r param:r ptr:r addr:a st2:a closure:wc"},
+		{funcLit, "s:rc param:rc"},
+	} {
+		if got := format(freevars.Of(info, test.node)); got != test.want {
+			t.Errorf("Of(%s) =\n%s, want\n%s", fmt.Sprintf("%T", test.node), got, test.want)
+		}
+	}
+}
@@ -0,0 +1,529 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package objectpath
+
+import (
+	"fmt"
+	"go/types"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/internal/typesinternal"
+)
+
+// Extended encoding
+//
+// The paths returned by For name only the objects of a package's API,
+// which suffice for export data. Tools that record facts about, or
+// build indexes of, the objects of a package's syntax must also name
+// function-local objects and the methods of instantiated types, for
+// which ForExtended returns paths in an extended encoding.
+//
+// An extended path starts with a prefix that identifies the version of
+// its encoding, currently "ext1:". No path in the basic encoding
+// contains a colon, so a decoder that predates a version rejects its
+// paths rather than misinterpreting them. As a compatibility fallback,
+// ForExtended returns a path in the basic encoding for every object
+// that has one, so most of its paths may be decoded by any version of
+// Object.
+//
+// Version 1 of the extended encoding defines two forms of path.
+//
+// A local path names an object declared within the body of a function F
+// (an exported package-level function, or a method), or a field,
+// parameter, or method reachable from the type of such an object:
+//
+//	ext1:F/B/N S
+//
+// F is the basic path of the function; B is a possibly empty sequence
+// of dot-separated indices of child scopes that, starting from the
+// function's scope, identifies the block in which the object named N
+// is declared; and S is an optional suffix of operators of the basic
+// encoding, applied to that object. In this example,
+//
+//	func F() {
+//		for {
+//			type T struct{ X int }
+//		}
+//	}
+//
+// T has the path "ext1:F/0.0/T" and X has the path "ext1:F/0.0/T.UF0".
+// Local paths may be decoded only for packages type-checked from
+// syntax, as export data does not record the bodies of functions.
+//
+// An instance path names a method of an instantiation of a generic
+// package-level type G:
+//
+//	ext1:G[A1,...,An]S
+//
+// Each type argument Ai is a predeclared type such as int or any; a
+// quoted package path followed by a dot and the name of a
+// package-level type of that package, followed by its own type
+// arguments if it is generic; or a pointer ("*") to or slice ("[]") of
+// another such type argument. S is the suffix that follows "G." in the
+// basic path of the method of G itself. In this example,
+//
+//	type S[T any] struct{}
+//
+//	func (S[T]) M() {}
+//
+// the method M of S[*bytes.Buffer] has the path `ext1:S[*"bytes".Buffer]M0`.
+// The decoder instantiates G anew, so the method it returns is
+// equivalent, but not identical, to that of the type checker's
+// instance. Instantiated fields and parameters have no paths, as they
+// do not record the type to which they belong.
+const extendedPrefix = "ext1:"
+
+// ForExtended is equivalent to new(Encoder).ForExtended(obj).
+func ForExtended(obj types.Object) (Path, error) {
+	return new(Encoder).ForExtended(obj)
+}
+
+// ForExtended returns the path to an object relative to its package,
+// like For, but in addition to the objects named by For, it returns a
+// path in the extended encoding for the following objects:
+// - objects declared within the body of an exported package-level
+// function or of a method, and the fields, parameters and methods
+// reachable from their types
+// - methods of instantiated package-level generic types, provided
+// their type arguments may be encoded
+//
+// For objects that For can name, ForExtended returns the same path,
+// which any version of Object can decode.
+func (enc *Encoder) ForExtended(obj types.Object) (Path, error) {
+	path, err := enc.For(obj)
+	if err == nil {
+		return path, nil
+	}
+	if fn, ok := obj.(*types.Func); ok && fn.Origin() != fn {
+		if path, ok := enc.instanceMethod(fn); ok {
+			return path, nil
+		}
+	} else if path, ok := enc.local(obj); ok {
+		return path, nil
+	}
+	return "", err
+}
+
+// local returns the local path of obj, if it has one.
+func (enc *Encoder) local(obj types.Object) (Path, bool) {
+	pkg := obj.Pkg()
+	if pkg == nil {
+		return "", false
+	}
+	funcs := enc.funcScopes(pkg)
+
+	// Object declared in a block?
+	if scope := obj.Parent(); scope != nil {
+		if prefix, ok := funcs.blockPath(scope); ok && scope.Lookup(obj.Name()) == obj {
+			return Path(append(prefix, obj.Name()...)), true
+		}
+		return "", false
+	}
+
+	// Otherwise obj may be a field, parameter or method reachable from
+	// the type of a local object: search them, block by block.
+	var search func(scope *types.Scope, prefix []byte) []byte
+	search = func(scope *types.Scope, prefix []byte) []byte {
+		for _, o := range enc.scopeObjects(scope) {
+			path := append(prefix, o.Name()...)
+			path = append(path, opType)
+			T := o.Type()
+			if named, ok := T.(*types.Named); ok && named.Obj() == o {
+				T = named.Underlying()
+				path = append(path, opUnderlying)
+			}
+			if r := find(obj, T, path); r != nil {
+				return r
+			}
+		}
+		for i := 0; i < scope.NumChildren(); i++ {
+			child := scope.Child(i)
+			if prefix, ok := funcs.blockPath(child); ok {
+				if r := search(child, prefix); r != nil {
+					return r
+				}
+			}
+		}
+		return nil
+	}
+	for _, scope := range funcs.scopes {
+		prefix, _ := funcs.blockPath(scope)
+		if r := search(scope, prefix); r != nil {
+			return Path(r), true
+		}
+	}
+	return "", false
+}
+
+// funcScopes records the functions of a package whose local objects
+// may have paths. It is empty for packages loaded from export data.
+type funcScopes struct {
+	scopes []*types.Scope          // function scopes, in a deterministic order
+	paths  map[*types.Scope]string // basic path of each function, by scope
+}
+
+// funcScopes returns the functions of pkg whose local objects may have
+// paths.
+func (enc *Encoder) funcScopes(pkg *types.Package) *funcScopes {
+	if funcs, ok := enc.funcScopeMemo[pkg]; ok {
+		return funcs
+	}
+	funcs := &funcScopes{paths: make(map[*types.Scope]string)}
+	add := func(scope *types.Scope, path string) {
+		if scope != nil {
+			funcs.scopes = append(funcs.scopes, scope)
+			funcs.paths[scope] = path
+		}
+	}
+	for _, o := range enc.scopeObjects(pkg.Scope()) {
+		switch o := o.(type) {
+		case *types.Func:
+			if o.Exported() {
+				add(o.Scope(), o.Name())
+			}
+		case *types.TypeName:
+			if named, ok := o.Type().(*types.Named); ok && named.Obj() == o {
+				for i := 0; i < named.NumMethods(); i++ {
+					path := appendOpArg([]byte(o.Name()+string(opType)), opMethod, i)
+					add(named.Method(i).Scope(), string(path))
+				}
+			}
+		}
+	}
+	if enc.funcScopeMemo == nil {
+		enc.funcScopeMemo = make(map[*types.Package]*funcScopes)
+	}
+	enc.funcScopeMemo[pkg] = funcs
+	return funcs
+}
+
+// blockPath returns the prefix "ext1:F/B/" of the local paths of the
+// objects declared in the specified block, if it is within one of the
+// functions.
+func (funcs *funcScopes) blockPath(scope *types.Scope) ([]byte, bool) {
+	var indices []int // in reverse
+	for {
+		if fpath, ok := funcs.paths[scope]; ok {
+			path := []byte(extendedPrefix + fpath + "/")
+			for i := len(indices) - 1; i >= 0; i-- {
+				path = strconv.AppendInt(path, int64(indices[i]), 10)
+				if i > 0 {
+					path = append(path, '.')
+				}
+			}
+			return append(path, '/'), true
+		}
+		parent := scope.Parent()
+		if parent == nil {
+			return nil, false // not within a function
+		}
+		for i := 0; i < parent.NumChildren(); i++ {
+			if parent.Child(i) == scope {
+				indices = append(indices, i)
+				break
+			}
+		}
+		scope = parent
+	}
+}
+
+// instanceMethod returns the instance path of meth, a method of an
+// instantiated type, if it has one.
+func (enc *Encoder) instanceMethod(meth *types.Func) (Path, bool) {
+	recv := meth.Type().(*types.Signature).Recv()
+	if recv == nil {
+		return "", false
+	}
+	_, named := typesinternal.ReceiverNamed(recv)
+	if named == nil || named.TypeArgs().Len() == 0 {
+		return "", false
+	}
+	tname := named.Obj()
+	if tname.Pkg() != meth.Pkg() || tname.Pkg().Scope().Lookup(tname.Name()) != tname {
+		return "", false
+	}
+	originPath, err := enc.For(meth.Origin())
+	if err != nil {
+		return "", false
+	}
+	suffix, ok := strings.CutPrefix(string(originPath), tname.Name()+".")
+	if !ok {
+		return "", false
+	}
+	path := []byte(extendedPrefix + tname.Name())
+	path, ok = appendTypeArgs(path, named.TypeArgs())
+	if !ok {
+		return "", false
+	}
+	return Path(append(path, suffix...)), true
+}
+
+// appendTypeArgs appends the encoding of a list of type arguments to
+// path, if each may be encoded.
+func appendTypeArgs(path []byte, targs *types.TypeList) ([]byte, bool) {
+	path = append(path, '[')
+	for i := 0; i < targs.Len(); i++ {
+		if i > 0 {
+			path = append(path, ',')
+		}
+		var ok bool
+		path, ok = appendTypeArg(path, targs.At(i))
+		if !ok {
+			return nil, false
+		}
+	}
+	return append(path, ']'), true
+}
+
+// appendTypeArg appends the encoding of a type argument to path, if it
+// may be encoded.
+func appendTypeArg(path []byte, T types.Type) ([]byte, bool) {
+	switch T := types.Unalias(T).(type) {
+	case *types.Basic:
+		if T.Kind() == types.UnsafePointer || T.Info()&types.IsUntyped != 0 {
+			return nil, false
+		}
+		return append(path, T.Name()...), true
+	case *types.Pointer:
+		return appendTypeArg(append(path, '*'), T.Elem())
+	case *types.Slice:
+		return appendTypeArg(append(path, "[]"...), T.Elem())
+	case *types.Interface:
+		if !T.Empty() {
+			return nil, false
+		}
+		return append(path, "any"...), true
+	case *types.Named:
+		obj := T.Obj()
+		if obj.Pkg() == nil {
+			return append(path, obj.Name()...), true // error, comparable
+		}
+		if obj.Pkg().Scope().Lookup(obj.Name()) != obj {
+			return nil, false // local type
+		}
+		path = strconv.AppendQuote(path, obj.Pkg().Path())
+		path = append(path, '.')
+		path = append(path, obj.Name()...)
+		if T.TypeArgs().Len() > 0 {
+			return appendTypeArgs(path, T.TypeArgs())
+		}
+		return path, true
+	}
+	return nil, false
+}
+
+// extendedObject returns the object denoted by the path p, in the
+// extended encoding without its prefix, within the package pkg.
+func extendedObject(pkg *types.Package, p string) (types.Object, error) {
+	// The package paths of type arguments may contain slashes.
+	switch i := strings.IndexAny(p, "/["); {
+	case i < 0:
+		return nil, fmt.Errorf("invalid extended path %q", p)
+	case p[i] == '/':
+		return localObject(pkg, p[:i], p[i+1:])
+	default:
+		return instanceObject(pkg, p[:i], p[i:])
+	}
+}
+
+// localObject returns the object denoted by the local path "F/rest".
+func localObject(pkg *types.Package, fpath, rest string) (types.Object, error) {
+	blocks, rest, ok := strings.Cut(rest, "/")
+	if !ok {
+		return nil, fmt.Errorf("invalid local path: no name")
+	}
+	if strings.IndexByte(fpath, ':') >= 0 {
+		return nil, fmt.Errorf("invalid local path: bad function path %q", fpath)
+	}
+	obj, err := Object(pkg, Path(fpath))
+	if err != nil {
+		return nil, err
+	}
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return nil, fmt.Errorf("invalid local path: %s is not a function", obj)
+	}
+	scope := fn.Scope()
+	if scope == nil {
+		return nil, fmt.Errorf("no local objects for %s: package was not type-checked from syntax", fn)
+	}
+	if blocks != "" {
+		for _, numerals := range strings.Split(blocks, ".") {
+			i, err := strconv.Atoi(numerals)
+			if err != nil {
+				return nil, fmt.Errorf("invalid local path: bad block index %q", numerals)
+			}
+			if n := scope.NumChildren(); i < 0 || i >= n {
+				return nil, fmt.Errorf("block index %d out of range [0-%d)", i, n)
+			}
+			scope = scope.Child(i)
+		}
+	}
+
+	name, suffix := rest, ""
+	if dot := strings.IndexByte(rest, opType); dot >= 0 {
+		name, suffix = rest[:dot], rest[dot:]
+	}
+	obj = scope.Lookup(name)
+	if obj == nil {
+		return nil, fmt.Errorf("block of %s does not contain %q", fn, name)
+	}
+	return decode(pkg, obj, nil, suffix)
+}
+
+// instanceObject returns the object denoted by the instance path
+// "G[A1,...,An]S", split before the left bracket.
+func instanceObject(pkg *types.Package, name, rest string) (types.Object, error) {
+	tname, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("package %s does not contain type %q", pkg.Path(), name)
+	}
+	named, ok := tname.Type().(*types.Named)
+	if !ok || named.TypeParams().Len() == 0 {
+		return nil, fmt.Errorf("invalid instance path: %s is not a generic type", tname)
+	}
+	p := &typeArgParser{pkg: pkg, s: rest}
+	targs, err := p.typeArgs()
+	if err != nil {
+		return nil, err
+	}
+	inst, err := types.Instantiate(nil, named, targs, true)
+	if err != nil {
+		return nil, fmt.Errorf("invalid instance path: %v", err)
+	}
+	if p.s == "" {
+		return nil, fmt.Errorf("invalid instance path: ends with type arguments")
+	}
+	return decode(pkg, nil, inst, p.s)
+}
+
+// A typeArgParser parses the type arguments of an instance path.
+type typeArgParser struct {
+	pkg *types.Package // package of the path
+	s   string         // unparsed remainder of the path
+}
+
+// typeArgs parses a bracketed list of type arguments.
+func (p *typeArgParser) typeArgs() ([]types.Type, error) {
+	if !strings.HasPrefix(p.s, "[") {
+		return nil, fmt.Errorf("invalid instance path: want '[' at %q", p.s)
+	}
+	p.s = p.s[1:]
+	var targs []types.Type
+	for {
+		T, err := p.typeArg()
+		if err != nil {
+			return nil, err
+		}
+		targs = append(targs, T)
+		switch {
+		case strings.HasPrefix(p.s, ","):
+			p.s = p.s[1:]
+		case strings.HasPrefix(p.s, "]"):
+			p.s = p.s[1:]
+			return targs, nil
+		default:
+			return nil, fmt.Errorf("invalid instance path: want ',' or ']' at %q", p.s)
+		}
+	}
+}
+
+// typeArg parses a single type argument.
+func (p *typeArgParser) typeArg() (types.Type, error) {
+	switch {
+	case strings.HasPrefix(p.s, "*"):
+		p.s = p.s[1:]
+		elem, err := p.typeArg()
+		if err != nil {
+			return nil, err
+		}
+		return types.NewPointer(elem), nil
+
+	case strings.HasPrefix(p.s, "[]"):
+		p.s = p.s[2:]
+		elem, err := p.typeArg()
+		if err != nil {
+			return nil, err
+		}
+		return types.NewSlice(elem), nil
+
+	case strings.HasPrefix(p.s, `"`):
+		quoted, err := strconv.QuotedPrefix(p.s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid instance path: bad package path at %q", p.s)
+		}
+		pkgpath, _ := strconv.Unquote(quoted)
+		p.s = p.s[len(quoted):]
+		if !strings.HasPrefix(p.s, ".") {
+			return nil, fmt.Errorf("invalid instance path: want '.' at %q", p.s)
+		}
+		p.s = p.s[1:]
+		name := p.ident()
+		tpkg := importedPackage(p.pkg, pkgpath)
+		if tpkg == nil {
+			return nil, fmt.Errorf("package %s does not depend on %q", p.pkg.Path(), pkgpath)
+		}
+		tname, ok := tpkg.Scope().Lookup(name).(*types.TypeName)
+		if !ok {
+			return nil, fmt.Errorf("package %s does not contain type %q", pkgpath, name)
+		}
+		T := tname.Type()
+		if strings.HasPrefix(p.s, "[") {
+			targs, err := p.typeArgs()
+			if err != nil {
+				return nil, err
+			}
+			T, err = types.Instantiate(nil, T, targs, true)
+			if err != nil {
+				return nil, fmt.Errorf("invalid instance path: %v", err)
+			}
+		}
+		return T, nil
+
+	default:
+		name := p.ident()
+		tname, ok := types.Universe.Lookup(name).(*types.TypeName)
+		if !ok {
+			return nil, fmt.Errorf("invalid instance path: %q is not a predeclared type", name)
+		}
+		return tname.Type(), nil
+	}
+}
+
+// ident parses an identifier, which may be empty.
+func (p *typeArgParser) ident() string {
+	end := strings.IndexFunc(p.s, func(r rune) bool {
+		return !(r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r))
+	})
+	if end < 0 {
+		end = len(p.s)
+	}
+	name := p.s[:end]
+	p.s = p.s[end:]
+	return name
+}
+
+// importedPackage returns the package with the specified path among
+// pkg and its transitive imports, or nil if there is none.
+func importedPackage(pkg *types.Package, path string) *types.Package {
+	seen := make(map[*types.Package]bool)
+	queue := []*types.Package{pkg}
+	for len(queue) > 0 {
+		pkg := queue[0]
+		queue = queue[1:]
+		if pkg.Path() == path {
+			return pkg
+		}
+		for _, imp := range pkg.Imports() {
+			if !seen[imp] {
+				seen[imp] = true
+				queue = append(queue, imp)
+			}
+		}
+	}
+	return nil
+}
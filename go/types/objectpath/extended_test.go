@@ -0,0 +1,181 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package objectpath_test
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/gcexportdata"
+	"golang.org/x/tools/go/types/objectpath"
+)
+
+func TestExtendedPaths(t *testing.T) {
+	const srcA = `package a
+
+type Buf struct{}
+`
+	const srcB = `package b
+
+import "x.io/a"
+
+type S[T any] struct{}
+
+func (S[T]) M() {}
+
+func (S[T]) Local() {
+	var z int
+	_ = z
+}
+
+type I[T any] interface{ N() T }
+
+func F(x int) {
+	const k = 1
+	for {
+		type T struct{ X int }
+		var v struct{ Y int }
+		_, _ = T{}, v
+	}
+	_ = func(p string) {}
+
+	var s S[*a.Buf]
+	s.M()
+	var i I[[]int]
+	i.N()
+	var m S[map[int]int]
+	m.M()
+}
+
+func unexported() {
+	var u int
+	_ = u
+}
+`
+	fset := token.NewFileSet()
+	pkgs := make(map[string]*types.Package)
+	conf := types.Config{Importer: importerFunc(func(path string) (*types.Package, error) {
+		return pkgs[path], nil
+	})}
+	info := &types.Info{
+		Defs: make(map[*ast.Ident]types.Object),
+		Uses: make(map[*ast.Ident]types.Object),
+	}
+	for _, src := range []struct{ path, content string }{{"x.io/a", srcA}, {"x.io/b", srcB}} {
+		f, err := parser.ParseFile(fset, src.path+".go", src.content, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pkg, err := conf.Check(src.path, fset, []*ast.File{f}, info)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pkgs[src.path] = pkg
+	}
+	pkg := pkgs["x.io/b"]
+
+	// objects returns the objects of package b defined or used by
+	// identifiers with the specified name.
+	objects := func(name string) []types.Object {
+		var objs []types.Object
+		for _, m := range []map[*ast.Ident]types.Object{info.Defs, info.Uses} {
+			for id, obj := range m {
+				if id.Name == name && obj != nil && obj.Pkg() == pkg {
+					objs = append(objs, obj)
+				}
+			}
+		}
+		return objs
+	}
+
+	enc := new(objectpath.Encoder)
+	for _, test := range []struct {
+		name, objstr string // name of identifier, and substring of object's string
+		want         string // path, or "" for error
+	}{
+		{"x", "var x", "F.PA0"}, // basic path
+		{"M", "S[T]", "S.M0"},
+		{"k", "const k", "ext1:F//k"},
+		{"T", "type T struct", "ext1:F/0.0/T"},
+		{"X", "field X", "ext1:F/0.0/T.UF0"},
+		{"v", "var v", "ext1:F/0.0/v"},
+		{"Y", "field Y", "ext1:F/0.0/v.F0"},
+		{"p", "var p", "ext1:F/1/p"},
+		{"z", "var z", "ext1:S.M1//z"},
+		{"M", "S[*x.io/a.Buf]", `ext1:S[*"x.io/a".Buf]M0`},
+		{"N", "I[[]int]", "ext1:I[[]int]UM0"},
+		{"M", "S[map[int]int]", ""}, // type argument cannot be encoded
+		{"u", "var u", ""},          // local to unexported function
+	} {
+		var obj types.Object
+		for _, o := range objects(test.name) {
+			if strings.Contains(o.String(), test.objstr) {
+				obj = o
+			}
+		}
+		if obj == nil {
+			t.Errorf("no object %s (%s)", test.name, test.objstr)
+			continue
+		}
+		path, err := enc.ForExtended(obj)
+		if test.want == "" {
+			if err == nil {
+				t.Errorf("ForExtended(%v) = %q, want error", obj, path)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ForExtended(%v): %v", obj, err)
+			continue
+		}
+		if string(path) != test.want {
+			t.Errorf("ForExtended(%v) = %q, want %q", obj, path, test.want)
+			continue
+		}
+		got, err := objectpath.Object(pkg, path)
+		if err != nil {
+			t.Errorf("Object(%q): %v", path, err)
+			continue
+		}
+		if fn, ok := obj.(*types.Func); ok && fn.Origin() != fn {
+			// Instance methods are equivalent, not identical.
+			if got.(*types.Func).Origin() != fn.Origin() || got.String() != obj.String() {
+				t.Errorf("Object(%q) = %v, want %v", path, got, obj)
+			}
+		} else if got != obj {
+			t.Errorf("Object(%q) = %v, want %v", path, got, obj)
+		}
+	}
+
+	// Local paths cannot be decoded in export data.
+	var buf bytes.Buffer
+	if err := gcexportdata.Write(&buf, fset, pkg); err != nil {
+		t.Fatal(err)
+	}
+	binpkg, err := gcexportdata.Read(&buf, fset, make(map[string]*types.Package), pkg.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if obj, err := objectpath.Object(binpkg, "ext1:F//k"); err == nil {
+		t.Errorf("Object(export data, local path) = %v, want error", obj)
+	}
+	if _, err := objectpath.Object(binpkg, "ext1:I[[]int]UM0"); err != nil {
+		t.Errorf("Object(export data, instance path): %v", err)
+	}
+
+	// Unknown versions are rejected.
+	if obj, err := objectpath.Object(pkg, "ext2:F//k"); err == nil {
+		t.Errorf("Object(ext2 path) = %v, want error", obj)
+	}
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) { return f(path) }
@@ -21,6 +21,10 @@
 // the field X has two paths due to its membership of both A and B.
 // The For(obj) function always returns one of these paths, arbitrarily
 // but consistently.
+//
+// The ForExtended function additionally names function-local objects
+// and the methods of instantiated types, using a versioned extension
+// of the encoding (see Extended encoding).
 package objectpath
 
 import (
@@ -125,7 +129,8 @@ func For(obj types.Object) (Path, error) {
 // An Encoder amortizes the cost of encoding the paths of multiple objects.
 // The zero value of an Encoder is ready to use.
 type Encoder struct {
-	scopeMemo map[*types.Scope][]types.Object // memoization of scopeObjects
+	scopeMemo     map[*types.Scope][]types.Object // memoization of scopeObjects
+	funcScopeMemo map[*types.Package]*funcScopes  // memoization of funcScopes
 }
 
 // For returns the path to an object relative to its package,
@@ -153,7 +158,8 @@ type Encoder struct {
 //
 // For does not return a path for predeclared names, imported package
 // names, local names, and unexported package-level names (except
-// types).
+// types). ForExtended additionally returns paths for some local names
+// and for methods of instantiated types.
 //
 // Example: given this definition,
 //
@@ -576,11 +582,19 @@ func (f *finder) findTypeParam(list *types.TypeParamList, path []byte, op byte)
 }
 
 // Object returns the object denoted by path p within the package pkg.
+// The path may be in the basic encoding, as returned by For, or in the
+// extended encoding, as returned by ForExtended.
 func Object(pkg *types.Package, p Path) (types.Object, error) {
 	pathstr := string(p)
 	if pathstr == "" {
 		return nil, fmt.Errorf("empty path")
 	}
+	if rest, ok := strings.CutPrefix(pathstr, extendedPrefix); ok {
+		return extendedObject(pkg, rest)
+	}
+	if colon := strings.IndexByte(pathstr, ':'); colon >= 0 {
+		return nil, fmt.Errorf("unsupported path encoding %q", pathstr[:colon+1])
+	}
 
 	var pkgobj, suffix string
 	if dot := strings.IndexByte(pathstr, opType); dot < 0 {
@@ -594,7 +608,13 @@ func Object(pkg *types.Package, p Path) (types.Object, error) {
 	if obj == nil {
 		return nil, fmt.Errorf("package %s does not contain %q", pkg.Path(), pkgobj)
 	}
+	return decode(pkg, obj, nil, suffix)
+}
 
+// decode applies the operators of a path suffix to the object obj or
+// the type t, exactly one of which is non-nil, and returns the
+// resulting object, which must belong to pkg.
+func decode(pkg *types.Package, obj types.Object, t types.Type, suffix string) (types.Object, error) {
 	// abstraction of *types.{Pointer,Slice,Array,Chan,Map}
 	type hasElem interface {
 		Elem() types.Type
@@ -609,12 +629,12 @@ func Object(pkg *types.Package, p Path) (types.Object, error) {
 	}
 
 	// The loop state is the pair (t, obj),
-	// exactly one of which is non-nil, initially obj.
+	// exactly one of which is non-nil, initially obj
+	// (except in instance paths of the extended encoding).
 	// All suffixes start with '.' (the only object->type operation),
 	// followed by optional type->type operations,
 	// then a type->object operation.
 	// The cycle then repeats.
-	var t types.Type
 	for suffix != "" {
 		code := suffix[0]
 		suffix = suffix[1:]
@@ -789,7 +809,7 @@ func Object(pkg *types.Package, p Path) (types.Object, error) {
 	}
 
 	if obj == nil {
-		panic(p) // path does not end in an object-valued operator
+		return nil, fmt.Errorf("invalid path: does not end in an object")
 	}
 
 	if obj.Pkg() != pkg {
@@ -0,0 +1,310 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeutil
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/internal/typeparams"
+)
+
+// CanonicalString returns a string that identifies the type t
+// independent of the process that created it, and is thus suitable as
+// a key for on-disk indexes, caches, and serialized facts keyed by
+// type. Unlike [Hasher.Hash], which depends on the addresses of
+// type-checker objects, it depends only on the structure of t and on
+// the package paths and names of the named types it mentions, so the
+// types of two type-checkings of the same source produce the same
+// string. Types with the same string are [types.Identical] (up to the
+// identity of their packages); conversely, identical types have the
+// same string, except that the type parameters of generic function
+// signatures are distinguished by index, not name.
+//
+// The string resembles the result of [types.TypeString] with fully
+// qualified package paths, but it is not Go syntax and its format may
+// change; it should not be parsed.
+//
+// CanonicalString returns an error if t mentions a type that cannot
+// be named independent of the process: a named type declared within a
+// function, or a type parameter other than one of a generic function
+// signature within t.
+func CanonicalString(t types.Type) (string, error) {
+	var c canonicalizer
+	c.writeType(t)
+	if c.err != nil {
+		return "", c.err
+	}
+	return c.buf.String(), nil
+}
+
+// CanonicalHash returns a 64-bit hash of [CanonicalString](t). Like
+// the string, it is independent of the process that created t.
+func CanonicalHash(t types.Type) (uint64, error) {
+	s, err := CanonicalString(t)
+	if err != nil {
+		return 0, err
+	}
+	// Fowler–Noll–Vo, as for hashString.
+	var h uint64 = 14695981039346656037
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= 1099511628211
+	}
+	return h, nil
+}
+
+// A canonicalizer holds the state of a single CanonicalString
+// traversal.
+type canonicalizer struct {
+	buf        strings.Builder
+	err        error
+	tparams    []*types.TypeParam // type parameters of enclosing generic signatures
+	interfaces []*types.Interface // enclosing interfaces, to break cycles
+}
+
+func (c *canonicalizer) writeType(t types.Type) {
+	// See Identical for rationale.
+	switch t := t.(type) {
+	case *types.Basic:
+		if t.Kind() == types.UnsafePointer {
+			c.buf.WriteString("unsafe.")
+		}
+		c.buf.WriteString(t.Name())
+
+	case *types.Alias:
+		c.writeType(types.Unalias(t))
+
+	case *types.Array:
+		fmt.Fprintf(&c.buf, "[%d]", t.Len())
+		c.writeType(t.Elem())
+
+	case *types.Slice:
+		c.buf.WriteString("[]")
+		c.writeType(t.Elem())
+
+	case *types.Struct:
+		c.buf.WriteString("struct{")
+		for i := range t.NumFields() {
+			if i > 0 {
+				c.buf.WriteString("; ")
+			}
+			f := t.Field(i)
+			if f.Anonymous() {
+				c.buf.WriteString("embedded ")
+			}
+			c.writeName(f.Pkg(), f.Name())
+			c.buf.WriteByte(' ')
+			c.writeType(f.Type())
+			if tag := t.Tag(i); tag != "" {
+				c.buf.WriteByte(' ')
+				c.buf.WriteString(strconv.Quote(tag))
+			}
+		}
+		c.buf.WriteByte('}')
+
+	case *types.Pointer:
+		c.buf.WriteByte('*')
+		c.writeType(t.Elem())
+
+	case *types.Signature:
+		c.buf.WriteString("func")
+		c.writeSignature(t)
+
+	case *types.Union:
+		terms, err := typeparams.UnionTermSet(t)
+		if err != nil {
+			c.fail(err)
+			return
+		}
+		c.writeTerms(terms)
+
+	case *types.Interface:
+		// Interfaces are identical if they have the same set of
+		// methods and the same type set.
+		for i, iface := range c.interfaces {
+			if iface == t {
+				// An anonymous cycle: refer to the enclosing
+				// interface by its depth.
+				fmt.Fprintf(&c.buf, "interface^%d", i)
+				return
+			}
+		}
+		c.interfaces = append(c.interfaces, t)
+		defer func() { c.interfaces = c.interfaces[:len(c.interfaces)-1] }()
+
+		c.buf.WriteString("interface{")
+		// The methods are sorted by Id.
+		for i := range t.NumMethods() {
+			if i > 0 {
+				c.buf.WriteString("; ")
+			}
+			m := t.Method(i)
+			c.writeName(m.Pkg(), m.Name())
+			c.writeSignature(m.Type().(*types.Signature))
+		}
+		terms, err := typeparams.InterfaceTermSet(t)
+		if err != nil {
+			c.fail(err)
+			return
+		}
+		if len(terms) > 0 {
+			if t.NumMethods() > 0 {
+				c.buf.WriteString("; ")
+			}
+			c.writeTerms(terms)
+		}
+		if t.IsComparable() && len(terms) == 0 {
+			c.buf.WriteString("comparable")
+		}
+		c.buf.WriteByte('}')
+
+	case *types.Map:
+		c.buf.WriteString("map[")
+		c.writeType(t.Key())
+		c.buf.WriteByte(']')
+		c.writeType(t.Elem())
+
+	case *types.Chan:
+		switch t.Dir() {
+		case types.SendRecv:
+			c.buf.WriteString("chan ")
+		case types.SendOnly:
+			c.buf.WriteString("chan<- ")
+		case types.RecvOnly:
+			c.buf.WriteString("<-chan ")
+		}
+		c.buf.WriteByte('(')
+		c.writeType(t.Elem())
+		c.buf.WriteByte(')')
+
+	case *types.Named:
+		obj := t.Origin().Obj()
+		switch {
+		case obj.Pkg() == nil:
+			c.buf.WriteString(obj.Name()) // error
+		case obj.Pkg().Scope().Lookup(obj.Name()) == obj:
+			c.buf.WriteString(obj.Pkg().Path())
+			c.buf.WriteByte('.')
+			c.buf.WriteString(obj.Name())
+		default:
+			c.fail(fmt.Errorf("local type %s.%s has no canonical name", obj.Pkg().Path(), obj.Name()))
+			return
+		}
+		if targs := t.TypeArgs(); targs.Len() > 0 {
+			c.buf.WriteByte('[')
+			for i := range targs.Len() {
+				if i > 0 {
+					c.buf.WriteString(", ")
+				}
+				c.writeType(targs.At(i))
+			}
+			c.buf.WriteByte(']')
+		}
+
+	case *types.TypeParam:
+		// Within the signature of a generic function, type
+		// parameters are identical if they have the same index.
+		i := slices.Index(c.tparams, t)
+		if i < 0 {
+			c.fail(fmt.Errorf("free type parameter %s has no canonical name", t))
+			return
+		}
+		fmt.Fprintf(&c.buf, "$%d", i)
+
+	case *types.Tuple:
+		c.writeTuple(t, false)
+
+	default:
+		panic(fmt.Sprintf("%T: %v", t, t))
+	}
+}
+
+// writeName writes the name of a field or method, qualified by its
+// package path if it is not exported.
+func (c *canonicalizer) writeName(pkg *types.Package, name string) {
+	if !token.IsExported(name) && pkg != nil {
+		c.buf.WriteString(pkg.Path())
+		c.buf.WriteByte('.')
+	}
+	c.buf.WriteString(name)
+}
+
+// writeSignature writes the type parameters, parameters, and results
+// of sig. The receiver is not part of the type.
+func (c *canonicalizer) writeSignature(sig *types.Signature) {
+	if tparams := sig.TypeParams(); tparams.Len() > 0 {
+		// Number the type parameters, including those of
+		// enclosing signatures, in order.
+		base := len(c.tparams)
+		for i := range tparams.Len() {
+			c.tparams = append(c.tparams, tparams.At(i))
+		}
+		defer func() { c.tparams = c.tparams[:base] }()
+
+		c.buf.WriteByte('[')
+		for i := range tparams.Len() {
+			if i > 0 {
+				c.buf.WriteString(", ")
+			}
+			fmt.Fprintf(&c.buf, "$%d ", base+i)
+			c.writeType(tparams.At(i).Constraint())
+		}
+		c.buf.WriteByte(']')
+	}
+	c.writeTuple(sig.Params(), sig.Variadic())
+	if sig.Results().Len() > 0 {
+		c.buf.WriteByte(' ')
+		c.writeTuple(sig.Results(), false)
+	}
+}
+
+// writeTuple writes the types of the elements of tuple; their names
+// are not significant.
+func (c *canonicalizer) writeTuple(tuple *types.Tuple, variadic bool) {
+	c.buf.WriteByte('(')
+	for i := range tuple.Len() {
+		if i > 0 {
+			c.buf.WriteString(", ")
+		}
+		t := tuple.At(i).Type()
+		if variadic && i == tuple.Len()-1 {
+			c.buf.WriteString("...")
+		}
+		c.writeType(t)
+	}
+	c.buf.WriteByte(')')
+}
+
+// writeTerms writes a type set's terms, whose order is not
+// significant.
+func (c *canonicalizer) writeTerms(terms []*types.Term) {
+	strs := make([]string, len(terms))
+	for i, term := range terms {
+		sub := canonicalizer{tparams: c.tparams, interfaces: c.interfaces}
+		if term.Tilde() {
+			sub.buf.WriteByte('~')
+		}
+		sub.writeType(term.Type())
+		if sub.err != nil {
+			c.fail(sub.err)
+			return
+		}
+		strs[i] = sub.buf.String()
+	}
+	slices.Sort(strs)
+	c.buf.WriteString(strings.Join(strs, " | "))
+}
+
+// fail records the first error.
+func (c *canonicalizer) fail(err error) {
+	if c.err == nil {
+		c.err = err
+	}
+}
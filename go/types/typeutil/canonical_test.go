@@ -0,0 +1,127 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeutil_test
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+func TestCanonicalString(t *testing.T) {
+	const src = `package p
+
+import "unsafe"
+
+type T struct {
+	x int
+	Y string ` + "`json:\"y\"`" + `
+	*T
+}
+
+type G[E any] []E
+
+type Alias = map[string]*T
+
+type I interface {
+	m(...int) (bool, error)
+	N() chan<- <-chan int
+}
+
+type Num interface{ ~int | ~float64 }
+
+type Cycle interface{ M() []*interface{ Cycle } }
+
+var (
+	vT     T
+	vG     G[unsafe.Pointer]
+	vAlias Alias
+	vI     I
+	vCycle Cycle
+	vArr   [3]struct{ Cycle }
+)
+
+func F[A, B any](A) B { panic(0) }
+func F2[X, Y any](X) Y { panic(0) }
+func H[K comparable, V Num](map[K]V) {}
+
+func local() {
+	type L int
+	var vL L
+	_ = vL
+}
+`
+	// check type-checks src afresh.
+	check := func() *types.Package {
+		fset := token.NewFileSet()
+		f, err := parser.ParseFile(fset, "p.go", src, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		conf := types.Config{Importer: importer.Default()}
+		pkg, err := conf.Check("p", fset, []*ast.File{f}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pkg
+	}
+	pkg1, pkg2 := check(), check()
+
+	for _, test := range []struct {
+		name string
+		want string
+	}{
+		{"vT", "p.T"},
+		{"T", "struct{p.x int; Y string \"json:\\\"y\\\"\"; embedded T *p.T}"},
+		{"vG", "p.G[unsafe.Pointer]"},
+		{"vAlias", "map[string]*p.T"},
+		{"I", "interface{N() (chan<- (<-chan (int))); p.m(...[]int) (bool, error)}"},
+		{"Num", "interface{~float64 | ~int}"},
+		{"Cycle", "interface{M() ([]*interface{M() ([]*interface^1)})}"},
+		{"vArr", "[3]struct{embedded Cycle p.Cycle}"},
+		{"F", "func[$0 interface{}, $1 interface{}]($0) ($1)"},
+		{"F2", "func[$0 interface{}, $1 interface{}]($0) ($1)"},
+		{"H", "func[$0 comparable, $1 p.Num](map[$0]$1)"},
+	} {
+		obj1, obj2 := pkg1.Scope().Lookup(test.name), pkg2.Scope().Lookup(test.name)
+		t1, t2 := obj1.Type(), obj2.Type()
+		if _, ok := obj1.(*types.TypeName); ok {
+			t1, t2 = t1.Underlying(), t2.Underlying()
+		}
+		got1, err := typeutil.CanonicalString(t1)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if got1 != test.want {
+			t.Errorf("%s: got %s, want %s", test.name, got1, test.want)
+		}
+		got2, _ := typeutil.CanonicalString(t2)
+		if got1 != got2 {
+			t.Errorf("%s: strings of different type-checkings differ: %s, %s", test.name, got1, got2)
+		}
+		hash1, _ := typeutil.CanonicalHash(t1)
+		hash2, _ := typeutil.CanonicalHash(t2)
+		if hash1 != hash2 {
+			t.Errorf("%s: hashes of different type-checkings differ: %d, %d", test.name, hash1, hash2)
+		}
+	}
+
+	// Local types and free type parameters have no canonical string.
+	local := pkg1.Scope().Lookup("local").(*types.Func)
+	vL := local.Scope().Lookup("vL")
+	if _, err := typeutil.CanonicalString(vL.Type()); err == nil {
+		t.Errorf("CanonicalString(%v) succeeded for local type", vL.Type())
+	}
+	tparam := pkg1.Scope().Lookup("G").Type().(*types.Named).TypeParams().At(0)
+	if _, err := typeutil.CanonicalString(tparam); err == nil {
+		t.Errorf("CanonicalString(%v) succeeded for free type parameter", tparam)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file defines an index of the interfaces implemented by types.
+
+package typeutil
+
+import (
+	"go/types"
+)
+
+// An ImplementsIndex records the package-level named types of a set
+// of packages, and answers the queries "which types implement
+// interface I?" and "which interfaces does type T implement?".
+//
+// Each type is summarized by a fingerprint of the names of its
+// methods, so that most candidates are rejected without an
+// examination of their method sets; the remainder are confirmed by
+// [types.Implements].
+//
+// Generic types that have not been instantiated, and types declared
+// within functions, are not indexed. An ImplementsIndex is safe for
+// concurrent use.
+type ImplementsIndex struct {
+	msets      MethodSetCache
+	concrete   []indexedType // non-interface types N
+	interfaces []indexedType // interface types
+}
+
+// An indexedType is a type with the fingerprint of its method set; for
+// a concrete type N, the fingerprint is that of the method set of *N,
+// which includes that of N.
+type indexedType struct {
+	t           types.Type
+	fingerprint uint64
+}
+
+// NewImplementsIndex returns an index of the package-level named types
+// of pkgs, which typically consist of a set of packages and their
+// dependencies.
+func NewImplementsIndex(pkgs []*types.Package) *ImplementsIndex {
+	index := new(ImplementsIndex)
+	for _, pkg := range pkgs {
+		scope := pkg.Scope()
+		for _, name := range scope.Names() {
+			tname, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || tname.IsAlias() {
+				continue
+			}
+			named, ok := tname.Type().(*types.Named)
+			if !ok || named.TypeParams().Len() > 0 {
+				continue
+			}
+			if types.IsInterface(named) {
+				index.interfaces = append(index.interfaces, indexedType{named, index.fingerprint(named)})
+			} else {
+				ptr := types.NewPointer(named)
+				index.concrete = append(index.concrete, indexedType{named, index.fingerprint(ptr)})
+			}
+		}
+	}
+	return index
+}
+
+// Implementations returns the indexed types that implement the
+// interface iface. For each concrete type N, the result contains N if
+// it implements iface, or else *N if that type implements iface. It
+// also contains each indexed interface whose method set includes that
+// of iface, other than iface itself. The concrete types precede the
+// interfaces, and each are in the order of the packages provided to
+// [NewImplementsIndex], and then by name.
+func (index *ImplementsIndex) Implementations(iface *types.Interface) []types.Type {
+	fp := index.fingerprint(iface)
+	var res []types.Type
+	for _, c := range index.concrete {
+		if fp&^c.fingerprint != 0 {
+			continue // c lacks a method
+		}
+		if types.Implements(c.t, iface) {
+			res = append(res, c.t)
+		} else if ptr := types.NewPointer(c.t); types.Implements(ptr, iface) {
+			res = append(res, ptr)
+		}
+	}
+	for _, i := range index.interfaces {
+		if fp&^i.fingerprint == 0 && i.t.Underlying() != iface && types.Implements(i.t, iface) {
+			res = append(res, i.t)
+		}
+	}
+	return res
+}
+
+// Interfaces returns the indexed interfaces implemented by the type
+// t, other than t itself. The result is in the order of the packages
+// provided to [NewImplementsIndex], and then by name.
+func (index *ImplementsIndex) Interfaces(t types.Type) []types.Type {
+	fp := index.fingerprint(t)
+	var res []types.Type
+	for _, i := range index.interfaces {
+		if i.fingerprint&^fp != 0 {
+			continue // t lacks a method
+		}
+		if !types.Identical(i.t, t) && types.Implements(t, i.t.Underlying().(*types.Interface)) {
+			res = append(res, i.t)
+		}
+	}
+	return res
+}
+
+// fingerprint returns a set of bits derived from the names of the
+// methods of t's method set, such that if the method set of t includes
+// that of u, the fingerprint of t includes that of u.
+func (index *ImplementsIndex) fingerprint(t types.Type) uint64 {
+	var fp uint64
+	mset := index.msets.MethodSet(t)
+	for i := range mset.Len() {
+		fp |= 1 << (hashString(mset.At(i).Obj().Id()) % 64)
+	}
+	return fp
+}
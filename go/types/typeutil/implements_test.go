@@ -0,0 +1,92 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package typeutil_test
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+func TestImplementsIndex(t *testing.T) {
+	const src = `package p
+
+import "fmt"
+
+type Reader interface{ Read([]byte) (int, error) }
+type ReadCloser interface {
+	Reader
+	Close() error
+}
+type Stringer interface{ String() string }
+
+type File struct{}
+func (*File) Read([]byte) (int, error) { return 0, nil }
+func (*File) Close() error { return nil }
+
+type Buf []byte
+func (Buf) Read([]byte) (int, error) { return 0, nil }
+func (Buf) String() string { return "" }
+
+type Int int
+
+type G[T any] struct{}
+func (G[T]) String() string { return "" }
+
+var _ fmt.Stringer
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := types.Config{Importer: importer.Default()}
+	pkg, err := conf.Check("p", fset, []*ast.File{f}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmtPkg := pkg.Imports()[0]
+	index := typeutil.NewImplementsIndex([]*types.Package{fmtPkg, pkg})
+
+	str := func(ts []types.Type) string {
+		return fmt.Sprint(ts)
+	}
+	lookup := func(name string) types.Type { return pkg.Scope().Lookup(name).Type() }
+
+	for _, test := range []struct {
+		iface string
+		want  string
+	}{
+		{"Reader", "[*fmt.stringReader p.Buf *p.File fmt.ScanState p.ReadCloser]"},
+		{"ReadCloser", "[*p.File]"},
+		{"Stringer", "[p.Buf fmt.Stringer]"},
+	} {
+		iface := lookup(test.iface).Underlying().(*types.Interface)
+		if got := str(index.Implementations(iface)); got != test.want {
+			t.Errorf("Implementations(%s) = %s, want %s", test.iface, got, test.want)
+		}
+	}
+
+	for _, test := range []struct {
+		typ  types.Type
+		want string
+	}{
+		{lookup("Buf"), "[fmt.Stringer p.Reader p.Stringer]"},
+		{lookup("File"), "[]"},
+		{types.NewPointer(lookup("File")), "[p.ReadCloser p.Reader]"},
+		{lookup("ReadCloser"), "[p.Reader]"},
+		{lookup("Int"), "[]"},
+	} {
+		if got := str(index.Interfaces(test.typ)); got != test.want {
+			t.Errorf("Interfaces(%s) = %s, want %s", test.typ, got, test.want)
+		}
+	}
+}
@@ -131,7 +131,9 @@ func (s *Snapshot) Analyze(ctx context.Context, pkgs map[PackageID]*metadata.Pac
 		toSrc            = make(map[*analysis.Analyzer]*settings.Analyzer)
 		enabledAnalyzers []*analysis.Analyzer // enabled subset + transitive requirements
 	)
-	for _, a := range settings.AllAnalyzers {
+	// Errors loading plugins are reported when the options are set.
+	plugins, _ := settings.LoadAnalyzerPlugins(s.Options().AnalyzerPlugins)
+	for _, a := range slices.Concat(settings.AllAnalyzers, plugins) {
 		if a.Enabled(s.Options()) {
 			toSrc[a.Analyzer()] = a
 			enabledAnalyzers = append(enabledAnalyzers, a.Analyzer())
@@ -423,7 +425,13 @@ func (s *Snapshot) Analyze(ctx context.Context, pkgs map[PackageID]*metadata.Pac
 				continue // action failed
 			}
 			for _, gobDiag := range summary.Diagnostics {
-				results = append(results, toSourceDiagnostic(srcAnalyzer, &gobDiag))
+				severity, ok := srcAnalyzer.CategorySeverity(s.Options(), gobDiag.Code)
+				if !ok {
+					continue // category disabled
+				}
+				diag := toSourceDiagnostic(srcAnalyzer, &gobDiag)
+				diag.Severity = severity
+				results = append(results, diag)
 			}
 		}
 	}
@@ -643,7 +651,7 @@ func (an *analysisNode) cacheKey() file.Hash {
 	// analyzers
 	fmt.Fprintf(hasher, "analyzers: %d\n", len(an.analyzers))
 	for _, a := range an.analyzers {
-		fmt.Fprintln(hasher, a.Name)
+		fmt.Fprintln(hasher, a.Name, a.Version)
 	}
 
 	// type checked package
@@ -1056,6 +1064,7 @@ func (act *action) exec(ctx context.Context) (any, *actionSummary, error) {
 		ExportPackageFact: factset.ExportPackageFact,
 		AllObjectFacts:    func() []analysis.ObjectFact { return factset.AllObjectFacts(factFilter) },
 		AllPackageFacts:   func() []analysis.PackageFact { return factset.AllPackageFacts(factFilter) },
+		Context:           ctx,
 	}
 
 	pass.ReadFile = func(filename string) ([]byte, error) {
@@ -56,12 +56,13 @@ import (
 	"golang.org/x/tools/gopls/internal/util/fingerprint"
 	"golang.org/x/tools/gopls/internal/util/frob"
 	"golang.org/x/tools/gopls/internal/util/safetoken"
+	"golang.org/x/tools/internal/typeparams"
 	"golang.org/x/tools/internal/typesinternal"
 )
 
-// An Index records the non-empty method sets of all package-level
-// types in a package in a form that permits assignability queries
-// without the type checker.
+// An Index records the method sets of all package-level types in a
+// package in a form that permits assignability queries without the
+// type checker. Interface types with empty method sets are omitted.
 type Index struct {
 	pkg     gobPackage
 	PkgPath metadata.PackagePath
@@ -97,17 +98,63 @@ type Location struct {
 // A Key represents the method set of a given type in a form suitable
 // to pass to the (*Index).Search method of many different Indexes.
 type Key struct {
-	mset *gobMethodSet // note: lacks position information
+	mset  *gobMethodSet // note: lacks position information
+	terms []term        // type-set terms of a constraint interface, if any
+}
+
+// A term records a term of the type set of a constraint interface.
+type term struct {
+	tilde       bool
+	fingerprint string // of the term's type, or the origin of a non-tilde named type
+	tricky      bool
 }
 
 // KeyOf returns the search key for the method sets of a given type.
-// It returns false if the type has no methods.
+// If the type is a constraint interface whose type set is restricted
+// by type terms, such as interface{ ~int | ~string }, the key also
+// records the terms, so that a Subtype search reports only the
+// non-interface types that may belong to the type set.
+// It returns false if the type has neither methods nor type terms.
 func KeyOf(t types.Type) (Key, bool) {
 	mset := methodSetInfo(t, nil)
-	if mset.Mask == 0 {
-		return Key{}, false // no methods
+	terms := typeSetTerms(t)
+	if mset.Mask == 0 && len(terms) == 0 {
+		return Key{}, false // no methods or terms (or empty type set)
+	}
+	return Key{mset, terms}, true
+}
+
+// typeSetTerms returns the normalized terms of the type set of a
+// constraint interface, or nil if its type set is not restricted by
+// terms (or cannot be computed).
+func typeSetTerms(t types.Type) []term {
+	iface, ok := t.Underlying().(*types.Interface)
+	if !ok || iface.IsMethodSet() {
+		return nil
+	}
+	tterms, err := typeparams.InterfaceTermSet(iface)
+	if err != nil {
+		return nil
+	}
+	var terms []term
+	for _, tt := range tterms {
+		typ := tt.Type()
+		if !tt.Tilde() {
+			// A non-tilde term matches only a named type,
+			// or any instantiation of a generic one.
+			named, ok := types.Unalias(typ).(*types.Named)
+			if !ok {
+				continue
+			}
+			typ = named.Origin()
+		}
+		fp, tricky := fingerprint.Encode(typ)
+		terms = append(terms, term{tt.Tilde(), fp, tricky})
 	}
-	return Key{mset}, true
+	if terms == nil {
+		terms = []term{} // no package-level type belongs to the type set
+	}
+	return terms
 }
 
 // A Result reports a matching type or method in a method-set search.
@@ -150,7 +197,7 @@ func (index *Index) Search(key Key, want TypeRelation, method *types.Func) []Res
 		// both tests succeed when comparing identical
 		// interface types.
 		var got TypeRelation
-		if want&Subtype != 0 && implements(candidate, key.mset) {
+		if want&Subtype != 0 && implements(candidate, key.mset) && satisfies(candidate, key.terms) {
 			got |= Subtype
 		}
 		if want&Supertype != 0 && implements(key.mset, candidate) {
@@ -239,6 +286,27 @@ func implements(x, y *gobMethodSet) bool {
 	return !slices.ContainsFunc(y.Methods, nonmatching)
 }
 
+// satisfies reports whether type x may belong to the type set
+// described by a constraint's terms, under some instantiation.
+// A nil terms slice denotes an unrestricted type set.
+func satisfies(x *gobMethodSet, terms []term) bool {
+	if terms == nil {
+		return true
+	}
+	if x.IsInterface {
+		return false // interfaces are not in the type set of a constraint with terms
+	}
+	return slices.ContainsFunc(terms, func(t term) bool {
+		if !t.tilde {
+			return t.fingerprint == x.Type
+		}
+		if !t.tricky && !x.TrickyUnderlying {
+			return t.fingerprint == x.Underlying
+		}
+		return fingerprint.Matches(fingerprint.Parse(x.Underlying), fingerprint.Parse(t.fingerprint))
+	})
+}
+
 func (index *Index) location(posn gobPosition) Location {
 	return Location{
 		Filename: index.pkg.Strings[posn.File],
@@ -313,12 +381,22 @@ func (b *indexBuilder) build(fset *token.FileSet, pkg *types.Package) *Index {
 	scope := pkg.Scope()
 	for _, name := range scope.Names() {
 		if tname, ok := scope.Lookup(name).(*types.TypeName); ok && !tname.IsAlias() {
-			if mset := methodSetInfo(tname.Type(), setIndexInfo); mset.Mask != 0 {
-				mset.TypeName = b.string(name)
-				mset.Posn = objectPos(tname)
-				// Only record types with non-trivial method sets.
-				b.MethodSets = append(b.MethodSets, mset)
+			mset := methodSetInfo(tname.Type(), setIndexInfo)
+			if mset.IsInterface && mset.Mask == 0 {
+				continue // no point recording the interfaces implemented by every type
 			}
+			mset.TypeName = b.string(name)
+			mset.Posn = objectPos(tname)
+			if !mset.IsInterface {
+				// Record the type, and its underlying type, for
+				// queries of constraints with type terms.
+				// Non-interface types are recorded even if their
+				// method sets are empty, as such constraints
+				// may have no methods.
+				mset.Type, _ = fingerprint.Encode(tname.Type())
+				mset.Underlying, mset.TrickyUnderlying = fingerprint.Encode(tname.Type().Underlying())
+			}
+			b.MethodSets = append(b.MethodSets, mset)
 		}
 	}
 
@@ -409,6 +487,11 @@ type gobMethodSet struct {
 	Tricky      bool   // at least one method is tricky; fingerprint must be parsed + unified
 	Mask        uint64 // mask with 1 bit from each of methods[*].sum
 	Methods     []*gobMethod
+
+	// index records of non-interface types only:
+	Type             string // fingerprint of the (uninstantiated) type
+	Underlying       string // fingerprint of its underlying type
+	TrickyUnderlying bool   // underlying type contains tricky features
 }
 
 // A gobMethod records the name, type, and position of a single method.
@@ -562,19 +562,22 @@ func (s *Snapshot) PackageDiagnostics(ctx context.Context, ids ...PackageID) (ma
 	return perFile, s.forEachPackage(ctx, ids, pre, post)
 }
 
-// References returns cross-reference indexes for the specified packages.
+// References returns the locations of references, within the specified
+// packages, to the target objects of other packages.
 //
-// If these indexes cannot be loaded from cache, the requested packages may
-// be type-checked.
-func (s *Snapshot) References(ctx context.Context, ids ...PackageID) ([]xrefIndex, error) {
+// If the cross-reference indexes of the packages cannot be loaded from
+// cache, the requested packages may be type-checked. Indexes loaded from
+// cache are queried in place, without reading them into the heap.
+func (s *Snapshot) References(ctx context.Context, targets map[PackagePath]map[objectpath.Path]struct{}, ids ...PackageID) ([]protocol.Location, error) {
 	ctx, done := event.Start(ctx, "cache.snapshot.References")
 	defer done()
 
-	indexes := make([]xrefIndex, len(ids))
+	locs := make([][]protocol.Location, len(ids))
 	pre := func(i int, ph *packageHandle) bool {
-		data, err := filecache.Get(xrefsKind, ph.key)
+		err := filecache.GetFunc(xrefsKind, ph.key, func(data []byte) {
+			locs[i] = xrefs.Lookup(ph.mp, data, targets)
+		})
 		if err == nil { // hit
-			indexes[i] = xrefIndex{mp: ph.mp, data: data}
 			return false
 		} else if err != filecache.ErrNotFound {
 			event.Error(ctx, "reading xrefs from filecache", err)
@@ -582,19 +585,12 @@ func (s *Snapshot) References(ctx context.Context, ids ...PackageID) ([]xrefInde
 		return true
 	}
 	post := func(i int, pkg *Package) {
-		indexes[i] = xrefIndex{mp: pkg.metadata, data: pkg.pkg.xrefs()}
+		locs[i] = xrefs.Lookup(pkg.metadata, pkg.pkg.xrefs(), targets)
 	}
-	return indexes, s.forEachPackage(ctx, ids, pre, post)
-}
-
-// An xrefIndex is a helper for looking up references in a given package.
-type xrefIndex struct {
-	mp   *metadata.Package
-	data []byte
-}
-
-func (index xrefIndex) Lookup(targets map[PackagePath]map[objectpath.Path]struct{}) []protocol.Location {
-	return xrefs.Lookup(index.mp, index.data, targets)
+	if err := s.forEachPackage(ctx, ids, pre, post); err != nil {
+		return nil, err
+	}
+	return slices.Concat(locs...), nil
 }
 
 // MethodSets returns method-set indexes for the specified packages.
@@ -607,9 +603,10 @@ func (s *Snapshot) MethodSets(ctx context.Context, ids ...PackageID) ([]*methods
 
 	indexes := make([]*methodsets.Index, len(ids))
 	pre := func(i int, ph *packageHandle) bool {
-		data, err := filecache.Get(methodSetsKind, ph.key)
-		if err == nil { // hit
+		err := filecache.GetFunc(methodSetsKind, ph.key, func(data []byte) {
 			indexes[i] = methodsets.Decode(ph.mp.PkgPath, data)
+		})
+		if err == nil { // hit
 			return false
 		} else if err != filecache.ErrNotFound {
 			event.Error(ctx, "reading methodsets from filecache", err)
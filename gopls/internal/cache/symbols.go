@@ -46,8 +46,10 @@ func (s *Snapshot) Symbols(ctx context.Context, ids ...PackageID) ([]*symbols.Pa
 				return err
 			}
 
-			if data, err := filecache.Get(symbolsKind, key); err == nil {
+			err = filecache.GetFunc(symbolsKind, key, func(data []byte) {
 				res[i] = symbols.Decode(data)
+			})
+			if err == nil {
 				return nil
 			} else if err != filecache.ErrNotFound {
 				bug.Reportf("internal error reading symbol data: %v", err)
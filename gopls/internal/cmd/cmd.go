@@ -34,8 +34,8 @@ import (
 	bugpkg "golang.org/x/tools/gopls/internal/util/bug"
 	"golang.org/x/tools/gopls/internal/util/moreslices"
 	"golang.org/x/tools/internal/diff"
-	"golang.org/x/tools/internal/jsonrpc2"
 	"golang.org/x/tools/internal/tool"
+	"golang.org/x/tools/jsonrpc2"
 )
 
 // Application is the main application as passed to tool.Main
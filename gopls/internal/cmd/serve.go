@@ -21,8 +21,8 @@ import (
 	"golang.org/x/tools/gopls/internal/mcp"
 	"golang.org/x/tools/gopls/internal/protocol"
 	"golang.org/x/tools/gopls/internal/util/fakenet"
-	"golang.org/x/tools/internal/jsonrpc2"
 	"golang.org/x/tools/internal/tool"
+	"golang.org/x/tools/jsonrpc2"
 )
 
 // Serve is a struct that exposes the configurable parts of the LSP and MCP
@@ -7,7 +7,7 @@ package debug
 import (
 	"golang.org/x/tools/internal/event/export/metric"
 	"golang.org/x/tools/internal/event/label"
-	"golang.org/x/tools/internal/jsonrpc2"
+	"golang.org/x/tools/internal/jsonrpc2/rpclabel"
 )
 
 var (
@@ -18,41 +18,41 @@ var (
 	receivedBytes = metric.HistogramInt64{
 		Name:        "received_bytes",
 		Description: "Distribution of received bytes, by method.",
-		Keys:        []label.Key{jsonrpc2.RPCDirection, jsonrpc2.Method},
+		Keys:        []label.Key{rpclabel.RPCDirection, rpclabel.Method},
 		Buckets:     bytesDistribution,
 	}
 
 	sentBytes = metric.HistogramInt64{
 		Name:        "sent_bytes",
 		Description: "Distribution of sent bytes, by method.",
-		Keys:        []label.Key{jsonrpc2.RPCDirection, jsonrpc2.Method},
+		Keys:        []label.Key{rpclabel.RPCDirection, rpclabel.Method},
 		Buckets:     bytesDistribution,
 	}
 
 	latency = metric.HistogramFloat64{
 		Name:        "latency",
 		Description: "Distribution of latency in milliseconds, by method.",
-		Keys:        []label.Key{jsonrpc2.RPCDirection, jsonrpc2.Method},
+		Keys:        []label.Key{rpclabel.RPCDirection, rpclabel.Method},
 		Buckets:     millisecondsDistribution,
 	}
 
 	started = metric.Scalar{
 		Name:        "started",
 		Description: "Count of RPCs started by method.",
-		Keys:        []label.Key{jsonrpc2.RPCDirection, jsonrpc2.Method},
+		Keys:        []label.Key{rpclabel.RPCDirection, rpclabel.Method},
 	}
 
 	completed = metric.Scalar{
 		Name:        "completed",
 		Description: "Count of RPCs completed by method and status.",
-		Keys:        []label.Key{jsonrpc2.RPCDirection, jsonrpc2.Method, jsonrpc2.StatusCode},
+		Keys:        []label.Key{rpclabel.RPCDirection, rpclabel.Method, rpclabel.StatusCode},
 	}
 )
 
 func registerMetrics(m *metric.Config) {
-	receivedBytes.Record(m, jsonrpc2.ReceivedBytes)
-	sentBytes.Record(m, jsonrpc2.SentBytes)
-	latency.Record(m, jsonrpc2.Latency)
-	started.Count(m, jsonrpc2.Started)
-	completed.Count(m, jsonrpc2.Latency)
+	receivedBytes.Record(m, rpclabel.ReceivedBytes)
+	sentBytes.Record(m, rpclabel.SentBytes)
+	latency.Record(m, rpclabel.Latency)
+	started.Count(m, rpclabel.Started)
+	completed.Count(m, rpclabel.Latency)
 }
@@ -81,63 +81,74 @@ func Get(kind string, key [32]byte) ([]byte, error) {
 	iolimit <- struct{}{}        // acquire a token
 	defer func() { <-iolimit }() // release a token
 
+	indexName, casName, valueHash, err := lookup(kind, key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read the CAS file and check its contents match.
+	//
+	// This ensures integrity in all cases (corrupt or truncated
+	// file, short read, I/O error, wrong length, etc) except an
+	// engineered hash collision, which is infeasible.
+	value, _ := os.ReadFile(casName) // ignore error
+	if sha256.Sum256(value) != valueHash {
+		return nil, ErrNotFound // CAS file is missing or has wrong contents
+	}
+
+	touch(indexName, casName)
+
+	memCache.Set(memKey{kind, key}, value, len(value))
+
+	return value, nil
+}
+
+// lookup returns the names of the index file and CAS file of the
+// value for (kind, key), and the hash of the value.
+func lookup(kind string, key [32]byte) (indexName, casName string, valueHash [32]byte, err error) {
 	// Read the index file, which provides the name of the CAS file.
-	indexName, err := filename(kind, key)
+	indexName, err = filename(kind, key)
 	if err != nil {
 		// e.g. ENOSPC, deletion of executable (first time only);
 		// deletion of cache (at any time).
-		return nil, err
+		return "", "", valueHash, err
 	}
 	indexData, err := os.ReadFile(indexName)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
-			return nil, ErrNotFound
+			return "", "", valueHash, ErrNotFound
 		}
-		return nil, err
+		return "", "", valueHash, err
 	}
-	var valueHash [32]byte
 	if copy(valueHash[:], indexData) != len(valueHash) {
-		return nil, ErrNotFound // index entry has wrong length
+		return "", "", valueHash, ErrNotFound // index entry has wrong length
 	}
-
-	// Read the CAS file and check its contents match.
-	//
-	// This ensures integrity in all cases (corrupt or truncated
-	// file, short read, I/O error, wrong length, etc) except an
-	// engineered hash collision, which is infeasible.
-	casName, err := filename(casKind, valueHash)
+	casName, err = filename(casKind, valueHash)
 	if err != nil {
-		return nil, err // see above for possible causes
-	}
-	value, _ := os.ReadFile(casName) // ignore error
-	if sha256.Sum256(value) != valueHash {
-		return nil, ErrNotFound // CAS file is missing or has wrong contents
+		return "", "", valueHash, err // see above for possible causes
 	}
+	return indexName, casName, valueHash, nil
+}
 
-	// Update file times used by LRU eviction.
-	//
-	// Because this turns a read into a write operation,
-	// we follow the approach used in the go command's
-	// cache and update the access time only if the
-	// existing timestamp is older than one hour.
-	//
-	// (Traditionally the access time would be updated
-	// automatically, but for efficiency most POSIX systems have
-	// for many years set the noatime mount option to avoid every
-	// open or read operation entailing a metadata write.)
+// touch updates the file times used by LRU eviction.
+//
+// Because this turns a read into a write operation,
+// we follow the approach used in the go command's
+// cache and update the access time only if the
+// existing timestamp is older than one hour.
+//
+// (Traditionally the access time would be updated
+// automatically, but for efficiency most POSIX systems have
+// for many years set the noatime mount option to avoid every
+// open or read operation entailing a metadata write.)
+func touch(filenames ...string) {
 	now := time.Now()
-	touch := func(filename string) {
+	for _, filename := range filenames {
 		st, err := os.Stat(filename)
 		if err == nil && now.Sub(st.ModTime()) > time.Hour {
 			os.Chtimes(filename, now, now) // ignore error
 		}
 	}
-	touch(indexName)
-	touch(casName)
-
-	memCache.Set(memKey{kind, key}, value, len(value))
-
-	return value, nil
 }
 
 // ErrNotFound is the distinguished error
@@ -121,6 +121,8 @@ const (
 	testIPCKind   = "TestIPC"
 	testIPCValueA = "hello"
 	testIPCValueB = "world"
+
+	testGetFuncKind = "TestGetFunc"
 )
 
 // TestIPC exercises interprocess communication through the cache.
@@ -166,17 +168,94 @@ func TestIPC(t *testing.T) {
 	}
 }
 
+// TestGetFunc exercises GetFunc of small and large values. It calls
+// Set in the parent and GetFunc in a child process, so that values are
+// read from the file-based cache, not the memory cache.
+func TestGetFunc(t *testing.T) {
+	testenv.NeedsExec(t)
+
+	small, large := uniqueKey(), uniqueKey()
+	for _, kv := range []struct {
+		key   [32]byte
+		value []byte
+	}{
+		{small, getFuncValue(100)},
+		{large, getFuncValue(1 << 20)}, // large enough to map
+	} {
+		if err := filecache.Set(testGetFuncKind, kv.key, kv.value); err != nil {
+			if strings.Contains(err.Error(), "operation not supported") {
+				t.Skipf("skipping: %v", err)
+			}
+			t.Fatalf("Set: %v", err)
+		}
+	}
+
+	cmd := exec.Command(os.Args[0], os.Args[1:]...)
+	cmd.Env = append(os.Environ(),
+		"ENTRYPOINT=getFuncChild",
+		fmt.Sprintf("KEYA=%q", small),
+		fmt.Sprintf("KEYB=%q", large))
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// getFuncValue returns a value of the specified size for TestGetFunc.
+func getFuncValue(size int) []byte {
+	return bytes.Repeat([]byte("0123456789abcdef"), size/16)
+}
+
 // We define our own main function so that portions of
 // some tests can run in a separate (child) process.
 func TestMain(m *testing.M) {
 	switch os.Getenv("ENTRYPOINT") {
 	case "ipcChild":
 		ipcChild()
+	case "getFuncChild":
+		getFuncChild()
 	default:
 		os.Exit(m.Run())
 	}
 }
 
+// getFuncChild is the portion of TestGetFunc that runs in a child process.
+func getFuncChild() {
+	getenv := func(name string) (key [32]byte) {
+		s, _ := strconv.Unquote(os.Getenv(name))
+		copy(key[:], []byte(s))
+		return
+	}
+
+	for _, kv := range []struct {
+		key  [32]byte
+		size int
+	}{
+		{getenv("KEYA"), 100},
+		{getenv("KEYB"), 1 << 20},
+	} {
+		// Call GetFunc twice, to exercise reuse of mappings.
+		for range 2 {
+			var ok bool
+			err := filecache.GetFunc(testGetFuncKind, kv.key, func(value []byte) {
+				ok = bytes.Equal(value, getFuncValue(kv.size))
+			})
+			if err != nil || !ok {
+				log.Fatalf("child: GetFunc(key) of %d-byte value: ok=%t, err=%v", kv.size, ok, err)
+			}
+		}
+	}
+
+	// GetFunc of a never-seen key returns not found without calling f.
+	err := filecache.GetFunc(testGetFuncKind, uniqueKey(), func([]byte) {
+		log.Fatalf("child: GetFunc of random key called f")
+	})
+	if err != filecache.ErrNotFound {
+		log.Fatalf("child: GetFunc of random key returned err=%v, want not found", err)
+	}
+}
+
 // ipcChild is the portion of TestIPC that runs in a child process.
 func ipcChild() {
 	getenv := func(name string) (key [32]byte) {
@@ -0,0 +1,199 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package filecache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"sync"
+)
+
+// GetFunc calls f with the value most recently supplied to
+// Set(kind, key), possibly by another process, like [Get].
+//
+// Unlike Get, it does not read large values into the heap: where the
+// platform permits, it maps them into memory from the cache file, so
+// that their pages are managed by the operating system, and their
+// memory may be reclaimed under pressure without any garbage
+// collection. The mapped memory is valid only during the call to f,
+// which must not retain any reference to it or modify it. It is thus
+// suitable for large values, such as indexes, that are decoded into
+// a smaller form, or queried, immediately after retrieval.
+//
+// GetFunc returns ErrNotFound if the value was not found, in which
+// case f is not called.
+func GetFunc(kind string, key [32]byte, f func(value []byte)) error {
+	// First consult the read-through memory cache.
+	if value, ok := memCache.Get(memKey{kind, key}); ok {
+		f(value)
+		return nil
+	}
+
+	m, err := acquire(kind, key)
+	if err == errSmall || err == errors.ErrUnsupported {
+		// Read small values into the heap, as mapping
+		// them would not be worthwhile.
+		value, err := Get(kind, key)
+		if err != nil {
+			return err
+		}
+		f(value)
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer release(m)
+	return callMapped(m.data, f)
+}
+
+// callMapped calls f with mapped data, converting a fault due to
+// truncation of the mapped file (by external meddling) into an error.
+func callMapped(data []byte, f func([]byte)) (err error) {
+	defer debug.SetPanicOnFault(debug.SetPanicOnFault(true))
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(interface{ Addr() uintptr }); !ok {
+				panic(r) // not a fault
+			}
+			err = fmt.Errorf("reading mapped cache file: %v", r)
+		}
+	}()
+	f(data)
+	return nil
+}
+
+// mapThreshold is the size of the smallest value that GetFunc maps
+// into memory.
+const mapThreshold = 64 << 10
+
+// maxMapped is the total size of the mappings, not in use, that are
+// retained for reuse by later calls to GetFunc.
+const maxMapped = 512 << 20
+
+// errSmall indicates that a value is too small to be mapped.
+var errSmall = errors.New("value too small to map")
+
+// A mapping is a CAS file mapped into memory.
+type mapping struct {
+	hash    [32]byte
+	data    []byte
+	refs    int           // number of calls to GetFunc using it
+	elem    *list.Element // element of mappings.lru, or nil if evicted
+	evicted bool
+}
+
+// mappings is an LRU cache of mapped CAS files, to avoid repeatedly
+// mapping the same file, such as the index of a frequently queried
+// package. Mappings are evicted once the total size of mappings
+// exceeds maxMapped, and unmapped once no longer in use.
+var mappings struct {
+	mu     sync.Mutex
+	byHash map[[32]byte]*mapping
+	lru    list.List // of *mapping, most recently used first
+	size   int       // total size of mappings in lru
+}
+
+// acquire returns the mapping of the CAS file of the value for
+// (kind, key), which the caller must release.
+// It returns errSmall if the value is too small to map,
+// and errors.ErrUnsupported if the platform cannot map files.
+func acquire(kind string, key [32]byte) (*mapping, error) {
+	if !canMap {
+		return nil, errors.ErrUnsupported
+	}
+
+	iolimit <- struct{}{}        // acquire a token
+	defer func() { <-iolimit }() // release a token
+
+	indexName, casName, valueHash, err := lookup(kind, key)
+	if err != nil {
+		return nil, err
+	}
+
+	mappings.mu.Lock()
+	if m, ok := mappings.byHash[valueHash]; ok {
+		m.refs++
+		mappings.lru.MoveToFront(m.elem)
+		mappings.mu.Unlock()
+		touch(indexName, casName)
+		return m, nil
+	}
+	mappings.mu.Unlock()
+
+	// Map the CAS file and check its contents match (see Get).
+	f, err := os.Open(casName)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, ErrNotFound // CAS file is missing
+		}
+		return nil, err
+	}
+	defer f.Close() // the mapping outlives the file
+	st, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if st.Size() < mapThreshold {
+		return nil, errSmall
+	}
+	data, err := mapFile(f, int(st.Size()))
+	if err != nil {
+		return nil, err
+	}
+	if sha256.Sum256(data) != valueHash {
+		unmapFile(data)         // ignore error
+		return nil, ErrNotFound // CAS file has wrong contents
+	}
+	touch(indexName, casName)
+
+	mappings.mu.Lock()
+	defer mappings.mu.Unlock()
+	if m, ok := mappings.byHash[valueHash]; ok {
+		// Another call to acquire mapped the same file.
+		unmapFile(data) // ignore error
+		m.refs++
+		mappings.lru.MoveToFront(m.elem)
+		return m, nil
+	}
+	if mappings.byHash == nil {
+		mappings.byHash = make(map[[32]byte]*mapping)
+	}
+	m := &mapping{hash: valueHash, data: data, refs: 1}
+	m.elem = mappings.lru.PushFront(m)
+	mappings.byHash[valueHash] = m
+	mappings.size += len(data)
+
+	// Evict the least recently used mappings.
+	for mappings.size > maxMapped {
+		oldest := mappings.lru.Back().Value.(*mapping)
+		if oldest == m {
+			break
+		}
+		mappings.lru.Remove(oldest.elem)
+		delete(mappings.byHash, oldest.hash)
+		mappings.size -= len(oldest.data)
+		oldest.elem = nil
+		oldest.evicted = true
+		if oldest.refs == 0 {
+			unmapFile(oldest.data) // ignore error
+		}
+	}
+	return m, nil
+}
+
+// release releases a mapping returned by acquire, unmapping it if it
+// has been evicted and is no longer in use.
+func release(m *mapping) {
+	mappings.mu.Lock()
+	defer mappings.mu.Unlock()
+	m.refs--
+	if m.evicted && m.refs == 0 {
+		unmapFile(m.data) // ignore error
+	}
+}
@@ -0,0 +1,19 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !unix
+
+package filecache
+
+import (
+	"errors"
+	"os"
+)
+
+// TODO: use CreateFileMapping on Windows.
+const canMap = false
+
+func mapFile(f *os.File, size int) ([]byte, error) { return nil, errors.ErrUnsupported }
+
+func unmapFile(data []byte) error { return errors.ErrUnsupported }
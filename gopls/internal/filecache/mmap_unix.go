@@ -0,0 +1,24 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unix
+
+package filecache
+
+import (
+	"os"
+	"syscall"
+)
+
+const canMap = true
+
+// mapFile maps the first size bytes of the file into memory, read-only.
+func mapFile(f *os.File, size int) ([]byte, error) {
+	return syscall.Mmap(int(f.Fd()), 0, size, syscall.PROT_READ, syscall.MAP_SHARED)
+}
+
+// unmapFile unmaps data returned by mapFile.
+func unmapFile(data []byte) error {
+	return syscall.Munmap(data)
+}
@@ -20,7 +20,8 @@ func AddImport(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle, im
 	if err != nil {
 		return nil, err
 	}
-	return ComputeImportFixEdits(snapshot.Options().Local, pgf.Src, &imports.ImportFix{
+	mp, _ := snapshot.NarrowestMetadataForFile(ctx, fh.URI()) // nil => no module
+	return ComputeImportFixEdits(snapshot, mp, pgf.Src, &imports.ImportFix{
 		StmtInfo: imports.ImportInfo{
 			ImportPath: importPath,
 		},
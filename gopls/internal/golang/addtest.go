@@ -26,6 +26,7 @@ import (
 	"golang.org/x/tools/gopls/internal/cache/metadata"
 	"golang.org/x/tools/gopls/internal/cache/parsego"
 	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/util/bug"
 	"golang.org/x/tools/gopls/internal/util/moremaps"
 	"golang.org/x/tools/internal/analysisinternal"
 	internalastutil "golang.org/x/tools/internal/astutil"
@@ -156,6 +157,10 @@ func {{.TestFuncName}}(t *{{.TestingPackageName}}.T) {
 }
 `
 
+// todoTestCases is the placeholder for the test cases of a generated
+// test, which is selected after the test is added.
+const todoTestCases = "// TODO: Add test cases."
+
 // Name is the name of the field this input parameter should reference.
 // Value is the expression this input parameter should accept.
 //
@@ -707,7 +712,7 @@ func AddTestForFunc(ctx context.Context, snapshot *cache.Snapshot, loc protocol.
 				FixType: imports.AddImport,
 			})
 		}
-		importEdits, err := ComputeImportFixEdits(snapshot.Options().Local, testPGF.Src, importFixes...)
+		importEdits, err := ComputeImportFixEdits(snapshot, pkg.Metadata(), testPGF.Src, importFixes...)
 		if err != nil {
 			return nil, nil, fmt.Errorf("could not compute the import fix edits: %w", err)
 		}
@@ -757,7 +762,8 @@ func AddTestForFunc(ctx context.Context, snapshot *cache.Snapshot, loc protocol.
 		},
 	)
 
-	// Show the line of generated test function.
+	// Select the placeholder for the first test case of the generated
+	// test function, so that the user can start writing it.
 	{
 		line := eofRange.Start.Line
 		for i := range len(edits) - 1 { // last edits is the func decl
@@ -766,12 +772,17 @@ func AddTestForFunc(ctx context.Context, snapshot *cache.Snapshot, loc protocol.
 			newLines := uint32(strings.Count(e.NewText, "\n"))
 			line += (newLines - oldLines)
 		}
+		before, _, ok := bytes.Cut(formatted, []byte(todoTestCases))
+		if !ok {
+			return nil, nil, bug.Errorf("generated test has no %q placeholder", todoTestCases)
+		}
+		line += uint32(bytes.Count(before, []byte("\n")))
+		col := uint32(len(before) - (bytes.LastIndexByte(before, '\n') + 1)) // indentation is ASCII
 		show = &protocol.Location{
 			URI: testFH.URI(),
 			Range: protocol.Range{
-				// Test function template have a new line at beginning.
-				Start: protocol.Position{Line: line + 1},
-				End:   protocol.Position{Line: line + 1},
+				Start: protocol.Position{Line: line, Character: col},
+				End:   protocol.Position{Line: line, Character: col + uint32(len(todoTestCases))},
 			},
 		}
 	}
@@ -0,0 +1,183 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/protocol/command"
+	"golang.org/x/tools/internal/event"
+)
+
+// CallGraph computes the graph of calls to and/or from the function
+// at the specified location, to the specified depth, by repeated
+// application of the IncomingCalls and OutgoingCalls operations.
+func CallGraph(ctx context.Context, snapshot *cache.Snapshot, args command.CallGraphArgs) (command.CallGraphResult, error) {
+	ctx, done := event.Start(ctx, "golang.CallGraph")
+	defer done()
+
+	var result command.CallGraphResult
+
+	incoming, outgoing := true, true
+	switch args.Direction {
+	case "", command.CallGraphBoth:
+	case command.CallGraphIncoming:
+		outgoing = false
+	case command.CallGraphOutgoing:
+		incoming = false
+	default:
+		return result, fmt.Errorf("invalid call graph direction %q", args.Direction)
+	}
+	depth := args.Depth
+	if depth <= 0 {
+		depth = 1
+	}
+
+	fh, err := snapshot.ReadFile(ctx, args.Location.URI)
+	if err != nil {
+		return result, err
+	}
+	roots, err := PrepareCallHierarchy(ctx, snapshot, fh, args.Location.Range.Start)
+	if err != nil {
+		return result, err
+	}
+	if len(roots) == 0 {
+		return result, fmt.Errorf("no function at %v", args.Location)
+	}
+
+	// Nodes are identified by the location of their declaration.
+	type edgeKey struct{ from, to int }
+	var (
+		nodes = make(map[protocol.Location]int)
+		edges = make(map[edgeKey]*command.CallGraphEdge)
+	)
+	node := func(item protocol.CallHierarchyItem) (int, bool) {
+		loc := item.URI.Location(item.Range)
+		if i, ok := nodes[loc]; ok {
+			return i, false
+		}
+		i := len(result.Nodes)
+		nodes[loc] = i
+		result.Nodes = append(result.Nodes, command.CallGraphNode{
+			Name:     item.Name,
+			Detail:   item.Detail,
+			Location: loc,
+		})
+		return i, true
+	}
+	addEdge := func(from, to int, uri protocol.DocumentURI, ranges []protocol.Range) {
+		k := edgeKey{from, to}
+		e, ok := edges[k]
+		if !ok {
+			e = &command.CallGraphEdge{From: from, To: to}
+			edges[k] = e
+		}
+		for _, rng := range ranges {
+			if loc := uri.Location(rng); !slices.Contains(e.Calls, loc) {
+				e.Calls = append(e.Calls, loc)
+			}
+		}
+	}
+
+	// Explore breadth first. Callers are explored only for their
+	// callers, and callees only for their callees.
+	type job struct {
+		item     protocol.CallHierarchyItem
+		index    int
+		incoming bool
+	}
+	root, _ := node(roots[0])
+	var queue []job
+	if incoming {
+		queue = append(queue, job{roots[0], root, true})
+	}
+	if outgoing {
+		queue = append(queue, job{roots[0], root, false})
+	}
+	for d := 0; d < depth && len(queue) > 0; d++ {
+		var next []job
+		for _, j := range queue {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+			// Package and variable initializers have no callers,
+			// and their callees are attributed to the package.
+			if j.item.Kind != protocol.Function {
+				continue
+			}
+			fh, err := snapshot.ReadFile(ctx, j.item.URI)
+			if err != nil {
+				return result, err
+			}
+			if j.incoming {
+				calls, err := IncomingCalls(ctx, snapshot, fh, j.item.SelectionRange.Start)
+				if err != nil {
+					return result, err
+				}
+				for _, call := range calls {
+					i, isNew := node(call.From)
+					addEdge(i, j.index, call.From.URI, call.FromRanges)
+					if isNew {
+						next = append(next, job{call.From, i, true})
+					}
+				}
+			} else {
+				calls, err := OutgoingCalls(ctx, snapshot, fh, j.item.SelectionRange.Start)
+				if err != nil {
+					return result, err
+				}
+				for _, call := range calls {
+					i, isNew := node(call.To)
+					addEdge(j.index, i, j.item.URI, call.FromRanges)
+					if isNew {
+						next = append(next, job{call.To, i, false})
+					}
+				}
+			}
+		}
+		queue = next
+	}
+
+	for _, e := range edges {
+		result.Edges = append(result.Edges, *e)
+	}
+	slices.SortFunc(result.Edges, func(x, y command.CallGraphEdge) int {
+		return cmp.Or(cmp.Compare(x.From, y.From), cmp.Compare(x.To, y.To))
+	})
+
+	switch args.Format {
+	case "", "json":
+	case "dot":
+		result.DOT = callGraphDOT(result)
+	default:
+		return result, fmt.Errorf("invalid call graph format %q", args.Format)
+	}
+	return result, nil
+}
+
+// callGraphDOT renders a call graph in the Graphviz DOT language.
+func callGraphDOT(graph command.CallGraphResult) string {
+	var buf bytes.Buffer
+	buf.WriteString("digraph calls {\n")
+	buf.WriteString("\tnode [shape=box];\n")
+	for i, n := range graph.Nodes {
+		attrs := ""
+		if i == 0 {
+			attrs = ", style=bold"
+		}
+		fmt.Fprintf(&buf, "\tn%d [label=%q, tooltip=%q%s];\n", i, n.Name, n.Detail, attrs)
+	}
+	for _, e := range graph.Edges {
+		fmt.Fprintf(&buf, "\tn%d -> n%d;\n", e.From, e.To)
+	}
+	buf.WriteString("}\n")
+	return buf.String()
+}
@@ -248,6 +248,7 @@ var codeActionProducers = [...]codeActionProducer{
 	{kind: settings.RefactorExtractFunction, fn: refactorExtractFunction},
 	{kind: settings.RefactorExtractMethod, fn: refactorExtractMethod},
 	{kind: settings.RefactorExtractToNewFile, fn: refactorExtractToNewFile},
+	{kind: settings.RefactorExtractInterface, fn: refactorExtractInterface, needPkg: true},
 	{kind: settings.RefactorExtractConstant, fn: refactorExtractVariable, needPkg: true},
 	{kind: settings.RefactorExtractVariable, fn: refactorExtractVariable, needPkg: true},
 	{kind: settings.RefactorExtractConstantAll, fn: refactorExtractVariableAll, needPkg: true},
@@ -560,6 +561,26 @@ func refactorExtractToNewFile(ctx context.Context, req *codeActionsRequest) erro
 	return nil
 }
 
+// refactorExtractInterface produces "Extract interface from T" code actions.
+// See [server.commandHandler.ExtractInterface] for command implementation.
+func refactorExtractInterface(ctx context.Context, req *codeActionsRequest) error {
+	obj, methods := selectedMethods(req.pkg.TypesInfo(), req.pgf, req.start, req.end)
+	if obj == nil {
+		return nil
+	}
+	title := "Extract interface from " + obj.Name()
+	if mset := types.NewMethodSet(types.NewPointer(obj.Type())); len(methods) < mset.Len() {
+		var names []string
+		for _, m := range methods {
+			names = append(names, m.Name())
+		}
+		title = fmt.Sprintf("Extract interface from %s (%s)", obj.Name(), strings.Join(names, ", "))
+	}
+	cmd := command.NewExtractInterfaceCommand(title, command.ExtractInterfaceArgs{Location: req.loc})
+	req.addCommandAction(cmd, false)
+	return nil
+}
+
 // addTest produces "Add test for FUNC" code actions.
 // See [server.commandHandler.AddTest] for command implementation.
 func addTest(ctx context.Context, req *codeActionsRequest) error {
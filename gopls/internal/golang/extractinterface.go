@@ -0,0 +1,516 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package golang
+
+// This file defines the code action "Extract interface".
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"maps"
+	pathpkg "path"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/cache/metadata"
+	"golang.org/x/tools/gopls/internal/cache/parsego"
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/protocol/command"
+	"golang.org/x/tools/gopls/internal/util/safetoken"
+	"golang.org/x/tools/internal/diff"
+	"golang.org/x/tools/internal/typesinternal"
+)
+
+// selectedMethods returns the named type, and the methods of it, from
+// which to extract an interface given the selection [start, end) of
+// pgf, or nil if there are none.
+//
+// If the selection is within the name of the declaration of a
+// (non-generic, non-interface) named type, the methods are all the
+// exported methods of the type (including promoted ones); if the
+// selection intersects one or more method declarations of the same
+// type, or is within the name of one, the methods are those.
+func selectedMethods(info *types.Info, pgf *parsego.File, start, end token.Pos) (*types.TypeName, []*types.Func) {
+	for _, decl := range pgf.File.Decls {
+		decl, ok := decl.(*ast.GenDecl)
+		if !ok || decl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range decl.Specs {
+			spec := spec.(*ast.TypeSpec)
+			if !posRangeContains(spec.Name.Pos(), spec.Name.End(), start, end) {
+				continue
+			}
+			obj, ok := info.Defs[spec.Name].(*types.TypeName)
+			if !ok || !extractableType(obj) {
+				return nil, nil
+			}
+			var methods []*types.Func
+			mset := types.NewMethodSet(types.NewPointer(obj.Type()))
+			for sel := range mset.Methods() {
+				if sel.Obj().Exported() {
+					methods = append(methods, sel.Obj().(*types.Func))
+				}
+			}
+			if len(methods) == 0 {
+				return nil, nil
+			}
+			return obj, methods
+		}
+	}
+
+	var (
+		recv    *types.TypeName
+		methods []*types.Func
+	)
+	for _, decl := range pgf.File.Decls {
+		if !posRangeIntersects(start, end, decl.Pos(), decl.End()) &&
+			!(start == end && posRangeContains(decl.Pos(), decl.End(), start, end)) {
+			continue
+		}
+		decl, ok := decl.(*ast.FuncDecl)
+		if !ok || decl.Recv == nil {
+			return nil, nil // selection includes a non-method
+		}
+		if start == end && !posRangeContains(decl.Name.Pos(), decl.Name.End(), start, end) {
+			return nil, nil // cursor is not within the method name
+		}
+		fn, ok := info.Defs[decl.Name].(*types.Func)
+		if !ok {
+			return nil, nil
+		}
+		_, named := typesinternal.ReceiverNamed(fn.Signature().Recv())
+		if named == nil || recv != nil && named.Obj() != recv || !extractableType(named.Obj()) {
+			return nil, nil
+		}
+		recv = named.Obj()
+		methods = append(methods, fn)
+	}
+	return recv, methods
+}
+
+// extractableType reports whether an interface may be extracted from
+// the named type: it must be neither generic nor an interface.
+func extractableType(obj *types.TypeName) bool {
+	named, ok := obj.Type().(*types.Named)
+	return ok && !obj.IsAlias() && named.TypeParams() == nil && !types.IsInterface(named)
+}
+
+// ExtractInterface declares an interface type whose methods are those
+// of a named type, as specified by args, and replaces the type by the
+// interface in the type expressions of args.Rewrites.
+func ExtractInterface(ctx context.Context, snapshot *cache.Snapshot, args command.ExtractInterfaceArgs) ([]protocol.DocumentChange, error) {
+	pkg, pgf, err := NarrowestPackageForFile(ctx, snapshot, args.Location.URI)
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := pgf.RangePos(args.Location.Range)
+	if err != nil {
+		return nil, err
+	}
+	obj, methods := selectedMethods(pkg.TypesInfo(), pgf, start, end)
+	if obj == nil {
+		return nil, fmt.Errorf("selection is not a named type or methods of one")
+	}
+	if len(args.Methods) > 0 {
+		mset := types.NewMethodSet(types.NewPointer(obj.Type()))
+		methods = methods[:0]
+		for _, name := range args.Methods {
+			sel := mset.Lookup(obj.Pkg(), name)
+			if sel == nil {
+				return nil, fmt.Errorf("%s has no method %s", obj.Name(), name)
+			}
+			methods = append(methods, sel.Obj().(*types.Func))
+		}
+	}
+
+	// Find the package in which to declare the interface.
+	targetPkg := pkg
+	if args.Dir != "" && args.Dir.Path() != pgf.URI.DirPath() {
+		mp, err := packageInDir(ctx, snapshot, args.Dir)
+		if err != nil {
+			return nil, err
+		}
+		pkgs, err := snapshot.TypeCheck(ctx, mp.ID)
+		if err != nil {
+			return nil, err
+		}
+		targetPkg = pkgs[0]
+	}
+	target := targetPkg.Types()
+	sameTarget := func(p *types.Package) bool { return p != nil && p.Path() == target.Path() }
+
+	// Outside the declaring package, the interface can't refer
+	// to its unexported methods and types.
+	if !sameTarget(obj.Pkg()) {
+		for _, m := range methods {
+			if !m.Exported() {
+				return nil, fmt.Errorf("method %s is unexported, so cannot be declared in package %s", m.Name(), target.Name())
+			}
+			if name := unexportedTypeOf(m.Signature(), obj.Pkg()); name != "" {
+				return nil, fmt.Errorf("method %s refers to unexported type %s, so cannot be declared in package %s", m.Name(), name, target.Name())
+			}
+		}
+	}
+
+	name := args.Name
+	if name == "" {
+		name = obj.Name() + "Interface"
+		for i := 1; target.Scope().Lookup(name) != nil; i++ {
+			name = fmt.Sprintf("%sInterface%d", obj.Name(), i)
+		}
+	} else if !token.IsIdentifier(name) {
+		return nil, fmt.Errorf("invalid interface name %q", name)
+	} else if target.Scope().Lookup(name) != nil {
+		return nil, fmt.Errorf("%s is already declared in package %s", name, target.Name())
+	}
+
+	// Accumulate the edits to each file.
+	files := make(map[protocol.DocumentURI]*fileEdits)
+	editsTo := func(pgf *parsego.File, pkg *cache.Package) *fileEdits {
+		fe, ok := files[pgf.URI]
+		if !ok {
+			fe = newFileEdits(pgf, pkg.TypesInfo())
+			files[pgf.URI] = fe
+		}
+		return fe
+	}
+
+	// Declare the interface.
+	importsSource := false // whether the target package must import the declaring package
+	declareIn := func(fe *fileEdits) []byte {
+		var buf bytes.Buffer
+		typeName := obj.Name()
+		if !sameTarget(obj.Pkg()) {
+			typeName = obj.Pkg().Name() + "." + typeName
+		}
+		fmt.Fprintf(&buf, "// %s is the interface of %s.\n", name, typeName)
+		fmt.Fprintf(&buf, "type %s interface {\n", name)
+		for _, m := range methods {
+			if doc := methodDoc(pkg, m); doc != nil {
+				for _, c := range doc.List {
+					fmt.Fprintf(&buf, "%s\n", c.Text)
+				}
+			}
+			sig := m.Signature()
+			buf.WriteString(m.Name())
+			types.WriteSignature(&buf, types.NewSignatureType(nil, nil, nil, sig.Params(), sig.Results(), sig.Variadic()), fe.qualifier(target))
+			buf.WriteString("\n")
+		}
+		buf.WriteString("}\n")
+		return buf.Bytes()
+	}
+	if sameTarget(obj.Pkg()) {
+		// Insert the interface after the declaration of the type.
+		declPGF, err := pkg.FileEnclosing(obj.Pos())
+		if err != nil {
+			return nil, err
+		}
+		fe := editsTo(declPGF, pkg)
+		offset := len(declPGF.Src)
+		for _, decl := range declPGF.File.Decls {
+			if decl.End() > obj.Pos() {
+				offset, err = safetoken.Offset(declPGF.Tok, decl.End())
+				if err != nil {
+					return nil, err
+				}
+				break
+			}
+		}
+		fe.edits = append(fe.edits, diff.Edit{Start: offset, End: offset, New: "\n\n" + string(declareIn(fe))})
+	} else {
+		// Append the interface to the first file of the target package.
+		targetPGF := targetPkg.CompiledGoFiles()[0]
+		fe := editsTo(targetPGF, targetPkg)
+		decl := declareIn(fe)
+		fe.edits = append(fe.edits, diff.Edit{Start: len(targetPGF.Src), End: len(targetPGF.Src), New: "\n" + string(decl)})
+		if slices.ContainsFunc(fe.imports, func(imp newImport) bool { return imp.path == obj.Pkg().Path() }) {
+			importsSource = true
+		}
+	}
+	if importsSource {
+		// The target package must import the declaring package,
+		// so the declaring package must not depend on it.
+		if deps, err := snapshot.ReverseDependencies(ctx, targetPkg.Metadata().ID, true); err != nil {
+			return nil, err
+		} else if deps[pkg.Metadata().ID] != nil {
+			return nil, fmt.Errorf("declaring %s in package %s would create an import cycle", name, target.Name())
+		}
+	}
+
+	// Replace the type by the interface at each rewrite site.
+	for _, loc := range args.Rewrites {
+		rpkg, rpgf, err := NarrowestPackageForFile(ctx, snapshot, loc.URI)
+		if err != nil {
+			return nil, err
+		}
+		start, end, err := rpgf.RangePos(loc.Range)
+		if err != nil {
+			return nil, err
+		}
+		expr := typeExprDenoting(rpkg.TypesInfo(), rpgf, start, end, obj)
+		if expr == nil {
+			return nil, fmt.Errorf("%s: not a type expression denoting %s or *%s", loc, obj.Name(), obj.Name())
+		}
+		mset := types.NewMethodSet(rpkg.TypesInfo().TypeOf(expr))
+		for _, m := range methods {
+			if mset.Lookup(m.Pkg(), m.Name()) == nil {
+				return nil, fmt.Errorf("%s: method %s of %s has a pointer receiver, so the type must be *%s",
+					loc, m.Name(), obj.Name(), obj.Name())
+			}
+		}
+		if rpath := rpkg.Types().Path(); rpath != target.Path() {
+			deps, err := snapshot.ReverseDependencies(ctx, rpkg.Metadata().ID, true)
+			if err != nil {
+				return nil, err
+			}
+			if deps[targetPkg.Metadata().ID] != nil || importsSource && (rpath == obj.Pkg().Path() || deps[pkg.Metadata().ID] != nil) {
+				return nil, fmt.Errorf("%s: using %s in package %s would create an import cycle", loc, name, rpkg.Types().Name())
+			}
+		}
+		fe := editsTo(rpgf, rpkg)
+		exprStart, exprEnd, err := safetoken.Offsets(rpgf.Tok, expr.Pos(), expr.End())
+		if err != nil {
+			return nil, err
+		}
+		text := types.TypeString(types.NewNamed(types.NewTypeName(token.NoPos, target, name, nil), nil, nil), fe.qualifier(rpkg.Types()))
+		fe.edits = append(fe.edits, diff.Edit{Start: exprStart, End: exprEnd, New: text})
+	}
+
+	var changes []protocol.DocumentChange
+	for _, uri := range slices.Sorted(maps.Keys(files)) {
+		fe := files[uri]
+		edits, err := fe.textEdits()
+		if err != nil {
+			return nil, err
+		}
+		fh, err := snapshot.ReadFile(ctx, fe.pgf.URI)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, protocol.DocumentChangeEdit(fh, edits))
+	}
+	return changes, nil
+}
+
+// packageInDir returns the metadata of the (non-test) workspace
+// package in the directory dir.
+func packageInDir(ctx context.Context, snapshot *cache.Snapshot, dir protocol.DocumentURI) (*metadata.Package, error) {
+	mps, err := snapshot.WorkspaceMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, mp := range mps {
+		if mp.IsIntermediateTestVariant() || mp.ForTest != "" || strings.HasSuffix(string(mp.Name), "_test") {
+			continue
+		}
+		if len(mp.CompiledGoFiles) > 0 && mp.CompiledGoFiles[0].DirPath() == dir.Path() {
+			return mp, nil
+		}
+	}
+	return nil, fmt.Errorf("no package in directory %s", dir.Path())
+}
+
+// methodDoc returns the doc comment of the method m, if it is
+// declared in pkg.
+func methodDoc(pkg *cache.Package, m *types.Func) *ast.CommentGroup {
+	if m.Pkg() != pkg.Types() {
+		return nil
+	}
+	pgf, err := pkg.FileEnclosing(m.Pos())
+	if err != nil {
+		return nil
+	}
+	for _, decl := range pgf.File.Decls {
+		if decl, ok := decl.(*ast.FuncDecl); ok && decl.Name.Pos() == m.Pos() {
+			return decl.Doc
+		}
+	}
+	return nil
+}
+
+// unexportedTypeOf returns the name of an unexported named type of pkg
+// to which the signature refers, or "" if there is none.
+func unexportedTypeOf(sig *types.Signature, pkg *types.Package) string {
+	var (
+		found string
+		seen  = make(map[types.Type]bool)
+		visit func(t types.Type)
+	)
+	visit = func(t types.Type) {
+		if found != "" || seen[t] {
+			return
+		}
+		seen[t] = true
+		switch t := t.(type) {
+		case *types.Named:
+			if obj := t.Obj(); obj.Pkg() == pkg && !obj.Exported() {
+				found = obj.Name()
+			}
+			for targ := range t.TypeArgs().Types() {
+				visit(targ)
+			}
+		case *types.Alias:
+			visit(types.Unalias(t))
+		case *types.Pointer:
+			visit(t.Elem())
+		case *types.Slice:
+			visit(t.Elem())
+		case *types.Array:
+			visit(t.Elem())
+		case *types.Chan:
+			visit(t.Elem())
+		case *types.Map:
+			visit(t.Key())
+			visit(t.Elem())
+		case *types.Signature:
+			for v := range t.Params().Variables() {
+				visit(v.Type())
+			}
+			for v := range t.Results().Variables() {
+				visit(v.Type())
+			}
+		case *types.Struct:
+			for f := range t.Fields() {
+				visit(f.Type())
+			}
+		case *types.Interface:
+			for m := range t.Methods() {
+				visit(m.Type())
+			}
+		}
+	}
+	visit(sig)
+	return found
+}
+
+// typeExprDenoting returns the innermost type expression enclosing
+// [start, end) that denotes the named type of obj, or a pointer to it,
+// or nil if there is none.
+func typeExprDenoting(info *types.Info, pgf *parsego.File, start, end token.Pos, obj *types.TypeName) ast.Expr {
+	path, _ := astutil.PathEnclosingInterval(pgf.File, start, end)
+	for _, n := range path {
+		expr, ok := n.(ast.Expr)
+		if !ok {
+			continue
+		}
+		tv, ok := info.Types[expr]
+		if !ok || !tv.IsType() {
+			continue
+		}
+		t := tv.Type
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		if named, ok := types.Unalias(t).(*types.Named); ok &&
+			named.Obj().Name() == obj.Name() && named.Obj().Pkg() != nil &&
+			named.Obj().Pkg().Path() == obj.Pkg().Path() {
+			return expr
+		}
+	}
+	return nil
+}
+
+// fileEdits accumulates the edits to a file, and the imports they need.
+type fileEdits struct {
+	pgf     *parsego.File
+	origEnv map[string]string // package names of the file's imports, by path
+	env     map[string]string // origEnv plus new imports
+	edits   []diff.Edit
+	imports []newImport
+}
+
+type newImport struct{ name, path string }
+
+func newFileEdits(pgf *parsego.File, info *types.Info) *fileEdits {
+	fe := &fileEdits{pgf: pgf, origEnv: make(map[string]string), env: make(map[string]string)}
+	for _, imp := range pgf.File.Imports {
+		if pkgname := info.PkgNameOf(imp); pkgname != nil && pkgname.Name() != "_" && pkgname.Name() != "." {
+			fe.origEnv[pkgname.Imported().Path()] = pkgname.Name()
+			fe.env[pkgname.Imported().Path()] = pkgname.Name()
+		}
+	}
+	return fe
+}
+
+// usesPkgName reports whether the file refers to the imported package
+// of the specified name.
+func usesPkgName(f *ast.File, name string) bool {
+	found := false
+	ast.Inspect(f, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok && id.Name == name {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// qualifier returns a qualifier for types in the file, which belongs to
+// package pkg, that records the imports it needs.
+func (fe *fileEdits) qualifier(pkg *types.Package) types.Qualifier {
+	return func(p *types.Package) string {
+		if p.Path() == pkg.Path() {
+			return ""
+		}
+		name, ok := fe.env[p.Path()]
+		if !ok {
+			name = p.Name()
+			fe.env[p.Path()] = name
+			imp := newImport{path: p.Path()}
+			if name != pathpkg.Base(trimVersionSuffix(imp.path)) {
+				imp.name = name
+			}
+			fe.imports = append(fe.imports, imp)
+		}
+		return name
+	}
+}
+
+// textEdits returns the edits to the file in protocol form, after
+// adding the imports they need.
+func (fe *fileEdits) textEdits() ([]protocol.TextEdit, error) {
+	slices.SortFunc(fe.edits, func(x, y diff.Edit) int { return x.Start - y.Start })
+	src, err := diff.Apply(string(fe.pgf.Src), fe.edits)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, fe.pgf.URI.Path(), src, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("could not reparse file: %w", err)
+	}
+	// Delete the imports that the edits made unused.
+	for _, spec := range fe.pgf.File.Imports {
+		path := string(metadata.UnquoteImportPath(spec))
+		name, ok := fe.origEnv[path]
+		if ok && usesPkgName(fe.pgf.File, name) && !usesPkgName(f, name) {
+			var specName string
+			if spec.Name != nil {
+				specName = spec.Name.Name
+			}
+			astutil.DeleteNamedImport(fset, f, specName, path)
+		}
+	}
+	for _, imp := range fe.imports {
+		astutil.AddNamedImport(fset, f, imp.name, imp.path)
+	}
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, f); err != nil {
+		return nil, err
+	}
+	src = buf.String()
+	return protocol.EditsFromDiffEdits(fe.pgf.Mapper, diff.Strings(string(fe.pgf.Src), src))
+}
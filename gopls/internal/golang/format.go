@@ -18,6 +18,7 @@ import (
 	"text/scanner"
 
 	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/cache/metadata"
 	"golang.org/x/tools/gopls/internal/cache/parsego"
 	"golang.org/x/tools/gopls/internal/file"
 	"golang.org/x/tools/gopls/internal/protocol"
@@ -117,6 +118,8 @@ func allImportsFixes(ctx context.Context, snapshot *cache.Snapshot, pgf *parsego
 	defer done()
 
 	if err := snapshot.RunProcessEnvFunc(ctx, func(ctx context.Context, opts *imports.Options) error {
+		mp, _ := snapshot.NarrowestMetadataForFile(ctx, pgf.URI) // nil => no module
+		opts.Groups = importGroups(snapshot, mp)
 		allFixEdits, editsPerFix, err = computeImportEdits(ctx, pgf, snapshot, opts)
 		return err
 	}); err != nil {
@@ -176,10 +179,12 @@ func computeImportEdits(ctx context.Context, pgf *parsego.File, snapshot *cache.
 	return allFixEdits, editsPerFix, nil
 }
 
-// ComputeImportFixEdits returns text edits for a single import fix.
-func ComputeImportFixEdits(localPrefix string, src []byte, fixes ...*imports.ImportFix) ([]protocol.TextEdit, error) {
+// ComputeImportFixEdits returns text edits for a single import fix to
+// the source of a file of package mp, which may be nil if unknown.
+func ComputeImportFixEdits(snapshot *cache.Snapshot, mp *metadata.Package, src []byte, fixes ...*imports.ImportFix) ([]protocol.TextEdit, error) {
 	options := &imports.Options{
-		LocalPrefix: localPrefix,
+		LocalPrefix: snapshot.Options().Local,
+		Groups:      importGroups(snapshot, mp),
 		// Defaults.
 		AllErrors:  true,
 		Comments:   true,
@@ -191,6 +196,34 @@ func ComputeImportFixEdits(localPrefix string, src []byte, fixes ...*imports.Imp
 	return computeFixEdits(src, options, fixes)
 }
 
+// importGroups returns the import groups of the ImportGroups option
+// for the files of package mp, with the pattern "module" replaced by
+// the path of the package's module, or removed if it has none.
+func importGroups(snapshot *cache.Snapshot, mp *metadata.Package) []string {
+	groups := snapshot.Options().ImportGroups
+	if len(groups) == 0 {
+		return nil
+	}
+	var modulePath string
+	if mp != nil && mp.Module != nil {
+		modulePath = mp.Module.Path
+	}
+	result := make([]string, len(groups))
+	for i, group := range groups {
+		var patterns []string
+		for _, pattern := range strings.Fields(group) {
+			if pattern == "module" {
+				pattern = modulePath
+			}
+			if pattern != "" {
+				patterns = append(patterns, pattern)
+			}
+		}
+		result[i] = strings.Join(patterns, " ")
+	}
+	return result
+}
+
 func computeFixEdits(src []byte, options *imports.Options, fixes []*imports.ImportFix) ([]protocol.TextEdit, error) {
 	// trim the original data to match fixedData
 	left, err := importPrefix(src)
@@ -121,6 +121,7 @@ func highlightPath(info *types.Info, path []ast.Node, pos token.Pos) (map[posRan
 		// Check if ident is inside return or func decl.
 		highlightFuncControlFlow(path, result)
 		highlightIdentifier(node, file, info, result)
+		highlightShadowing(info.ObjectOf(node), file, info, result)
 	case *ast.ForStmt, *ast.RangeStmt:
 		highlightLoopControlFlow(path, info, result)
 	case *ast.SwitchStmt, *ast.TypeSwitchStmt:
@@ -742,3 +743,37 @@ func highlightIdentifier(id *ast.Ident, file *ast.File, info *types.Info, result
 		return true
 	})
 }
+
+// highlightShadowing highlights, as Text, the declarations in file of
+// the variable that the variable obj shadows, and of the variables
+// that shadow obj, to distinguish them from the Read and Write
+// highlights of obj itself.
+func highlightShadowing(obj types.Object, file *ast.File, info *types.Info, result map[posRange]protocol.DocumentHighlightKind) {
+	v, ok := obj.(*types.Var)
+	if !ok {
+		return
+	}
+	if outer := shadowedVar(v); outer != nil && file.FileStart <= outer.Pos() && outer.Pos() < file.FileEnd {
+		highlightRange(result, outer.Pos(), outer.Pos()+token.Pos(len(outer.Name())), protocol.Text)
+	}
+	ast.Inspect(file, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && id.Name == v.Name() {
+			if inner, ok := info.Defs[id].(*types.Var); ok && shadowedVar(inner) == v {
+				highlightNode(result, id, protocol.Text)
+			}
+		}
+		return true
+	})
+}
+
+// shadowedVar returns the variable, if any, that the local variable v
+// shadows, that is, the variable of the same name that would be in
+// scope at the declaration of v if v were not declared.
+func shadowedVar(v *types.Var) *types.Var {
+	if v.IsField() || v.Parent() == nil || v.Parent().Parent() == nil {
+		return nil
+	}
+	_, obj := v.Parent().Parent().LookupParent(v.Name(), v.Pos())
+	outer, _ := obj.(*types.Var)
+	return outer
+}
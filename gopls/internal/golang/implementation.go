@@ -31,6 +31,7 @@ import (
 	"golang.org/x/tools/gopls/internal/util/safetoken"
 	"golang.org/x/tools/internal/event"
 	"golang.org/x/tools/internal/moreiters"
+	"golang.org/x/tools/internal/typeparams"
 	"golang.org/x/tools/internal/typesinternal"
 )
 
@@ -169,10 +170,19 @@ func implementationsMsets(ctx context.Context, snapshot *cache.Snapshot, pkg *ca
 		return bug.Errorf("%s is not a type or method", obj.Name()) // should have been handled by implementsObj
 	}
 
+	// If the query is a type parameter whose constraint is a
+	// named interface, don't report the constraint itself.
+	var constraint *types.TypeName
+	if _, ok := obj.Type().(*types.TypeParam); ok {
+		if named, ok := types.Unalias(queryType).(*types.Named); ok {
+			constraint = named.Obj()
+		}
+	}
+
 	// Compute the method-set fingerprint used as a key to the global search.
-	key, hasMethods := methodsets.KeyOf(queryType)
-	if !hasMethods {
-		// A type with no methods yields an empty result.
+	key, ok := methodsets.KeyOf(queryType)
+	if !ok {
+		// A type with no methods (or type terms) yields an empty result.
 		// (No point reporting that every type satisfies 'any'.)
 		return nil
 	}
@@ -257,6 +267,11 @@ func implementationsMsets(ctx context.Context, snapshot *cache.Snapshot, pkg *ca
 	for _, index := range indexes {
 		group.Go(func() error {
 			for _, res := range index.Search(key, rel, queryMethod) {
+				if constraint != nil && constraint.Pkg() != nil &&
+					index.PkgPath == PackagePath(constraint.Pkg().Path()) &&
+					res.TypeName == constraint.Name() {
+					continue
+				}
 				loc := res.Location
 				// Map offsets to protocol.Locations in parallel (may involve I/O).
 				group.Go(func() error {
@@ -278,11 +293,17 @@ func implementationsMsets(ctx context.Context, snapshot *cache.Snapshot, pkg *ca
 // Implementations operation on the specified symbol.
 // It returns a nil type to indicate that the query should not proceed.
 //
+// For a type parameter, it returns the constraint interface, so that
+// the operation reports the types that satisfy the constraint.
+//
 // (It is factored out to allow it to be used both in the query package
 // then (in [localImplementations]) again in the declaring package.)
 func typeOrMethod(obj types.Object) (types.Type, *types.Func) {
 	switch obj := obj.(type) {
 	case *types.TypeName:
+		if tparam, ok := obj.Type().(*types.TypeParam); ok {
+			return tparam.Constraint(), nil
+		}
 		return obj.Type(), nil
 	case *types.Func:
 		// For methods, use the receiver type, which may be anonymous.
@@ -379,9 +400,15 @@ func localImplementations(ctx context.Context, snapshot *cache.Snapshot, pkg *ca
 	}
 	queryType = methodsets.EnsurePointer(queryType)
 
+	// If the query type is a constraint interface with
+	// type terms, its subtypes must belong to its type set.
+	terms := typeSetTerms(queryType)
+
 	var msets typeutil.MethodSetCache
 
 	matches := func(candidateType types.Type) bool {
+		ptr := methodsets.EnsurePointer(candidateType)
+
 		// Test the direction of the relation.
 		// The client may request either direction or both
 		// (e.g. when the client is References),
@@ -389,10 +416,10 @@ func localImplementations(ctx context.Context, snapshot *cache.Snapshot, pkg *ca
 		// both tests succeed when comparing identical
 		// interface types.
 		var got methodsets.TypeRelation
-		if rel&methodsets.Supertype != 0 && implements(&msets, queryType, candidateType) {
+		if rel&methodsets.Supertype != 0 && implements(&msets, queryType, ptr) {
 			got |= methodsets.Supertype
 		}
-		if rel&methodsets.Subtype != 0 && implements(&msets, candidateType, queryType) {
+		if rel&methodsets.Subtype != 0 && implements(&msets, ptr, queryType) && inTypeSet(candidateType, terms) {
 			got |= methodsets.Subtype
 		}
 		return got != 0
@@ -409,18 +436,21 @@ func localImplementations(ctx context.Context, snapshot *cache.Snapshot, pkg *ca
 			if def == nil {
 				continue // "can't happen" for types
 			}
+			if types.Identical(methodsets.EnsurePointer(def.Type()), queryType) {
+				continue // e.g. constraint of query type parameter
+			}
 			if def.(*types.TypeName).IsAlias() {
 				continue // skip type aliases to avoid duplicate reporting
 			}
-			candidateType := methodsets.EnsurePointer(def.Type())
-			if !matches(candidateType) {
+			if !matches(def.Type()) {
 				continue
 			}
 
-			// Ignore types with empty method sets.
+			// Ignore types with empty method sets,
+			// unless they satisfy a constraint's type terms.
 			// (No point reporting that every type satisfies 'any'.)
-			mset := msets.MethodSet(candidateType)
-			if mset.Len() == 0 {
+			mset := msets.MethodSet(methodsets.EnsurePointer(def.Type()))
+			if mset.Len() == 0 && terms == nil {
 				continue
 			}
 
@@ -493,6 +523,47 @@ func errorLocation(ctx context.Context, snapshot *cache.Snapshot) (protocol.Loca
 	return protocol.Location{}, fmt.Errorf("built-in error type not found")
 }
 
+// typeSetTerms returns the normalized terms of the type set of a
+// constraint interface, or nil if its type set is not restricted by
+// terms (or cannot be computed).
+func typeSetTerms(t types.Type) []*types.Term {
+	iface, ok := t.Underlying().(*types.Interface)
+	if !ok || iface.IsMethodSet() {
+		return nil
+	}
+	terms, err := typeparams.InterfaceTermSet(iface)
+	if err != nil {
+		return nil
+	}
+	return terms
+}
+
+// inTypeSet reports whether type t may belong to the type set
+// described by the normalized terms of a constraint interface, under
+// some instantiation of t or the constraint. A nil terms slice denotes
+// an unrestricted type set.
+//
+// See also satisfies in cache/methodsets, which implements the same
+// check for the global index.
+func inTypeSet(t types.Type, terms []*types.Term) bool {
+	if terms == nil {
+		return true
+	}
+	if types.IsInterface(t) {
+		return false // interfaces are not in the type set of a constraint with terms
+	}
+	return slices.ContainsFunc(terms, func(term *types.Term) bool {
+		if term.Tilde() {
+			return unify(t.Underlying(), term.Type(), nil)
+		}
+		// A non-tilde term matches only a named type,
+		// or any instantiation of a generic one.
+		x, ok1 := types.Unalias(t).(*types.Named)
+		y, ok2 := types.Unalias(term.Type()).(*types.Named)
+		return ok1 && ok2 && x.Origin() == y.Origin()
+	})
+}
+
 // implements reports whether x implements y.
 // If one or both types are generic, the result indicates whether the
 // interface may be implemented under some instantiation.
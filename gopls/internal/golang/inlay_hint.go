@@ -27,6 +27,7 @@ import (
 	"golang.org/x/tools/internal/event"
 	"golang.org/x/tools/internal/typeparams"
 	"golang.org/x/tools/internal/typesinternal"
+	"golang.org/x/tools/internal/versions"
 )
 
 func InlayHint(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle, pRng protocol.Range) ([]protocol.InlayHint, error) {
@@ -90,6 +91,7 @@ var allInlayHints = map[settings.InlayHint]inlayHintFunc{
 	settings.CompositeLiteralFieldNames: compositeLiteralFields,
 	settings.FunctionTypeParameters:     funcTypeParams,
 	settings.IgnoredError:               ignoredError,
+	settings.CapturedVariables:          capturedVariables,
 }
 
 func parameterNames(info *types.Info, pgf *parsego.File, qual types.Qualifier, cur inspector.Cursor, add func(protocol.InlayHint)) {
@@ -197,6 +199,87 @@ outer:
 	}
 }
 
+func capturedVariables(info *types.Info, pgf *parsego.File, qual types.Qualifier, cur inspector.Cursor, add func(protocol.InlayHint)) {
+	// Before go1.22, each loop variable is shared by all iterations.
+	sharedLoopVars := versions.Before(versions.FileVersion(info, pgf.File), versions.Go1_22)
+
+	for curLit := range cur.Preorder((*ast.FuncLit)(nil)) {
+		lit := curLit.Node().(*ast.FuncLit)
+
+		// Find the local variables declared outside the literal
+		// to which it refers, in order of first reference.
+		var (
+			captured []*types.Var
+			seen     = make(map[*types.Var]bool)
+		)
+		for curId := range curLit.Preorder((*ast.Ident)(nil)) {
+			v, ok := info.Uses[curId.Node().(*ast.Ident)].(*types.Var)
+			if !ok || v.IsField() || seen[v] ||
+				v.Pkg() == nil || v.Parent() == v.Pkg().Scope() || // package-level
+				lit.Pos() <= v.Pos() && v.Pos() < lit.End() { // declared within literal
+				continue
+			}
+			seen[v] = true
+			captured = append(captured, v)
+		}
+		if len(captured) == 0 {
+			continue
+		}
+
+		// Find the variables of the enclosing loops.
+		loopVars := make(map[*types.Var]bool)
+		if sharedLoopVars {
+			addDefs := func(ids ...ast.Expr) {
+				for _, id := range ids {
+					if id, ok := id.(*ast.Ident); ok {
+						if v, ok := info.Defs[id].(*types.Var); ok {
+							loopVars[v] = true
+						}
+					}
+				}
+			}
+			for curLoop := range curLit.Enclosing((*ast.ForStmt)(nil), (*ast.RangeStmt)(nil)) {
+				switch loop := curLoop.Node().(type) {
+				case *ast.ForStmt:
+					if init, ok := loop.Init.(*ast.AssignStmt); ok && init.Tok == token.DEFINE {
+						addDefs(init.Lhs...)
+					}
+				case *ast.RangeStmt:
+					if loop.Tok == token.DEFINE {
+						addDefs(loop.Key, loop.Value)
+					}
+				}
+			}
+		}
+
+		const maxCaptured = 5
+		var buf strings.Builder
+		buf.WriteString("captures ")
+		for i, v := range captured {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			if i == maxCaptured {
+				buf.WriteString("...")
+				break
+			}
+			fmt.Fprintf(&buf, "&%s", v.Name())
+			if loopVars[v] {
+				buf.WriteString(" (shared loop var)")
+			}
+		}
+		pos, err := pgf.PosPosition(lit.Body.Lbrace)
+		if err != nil {
+			continue
+		}
+		add(protocol.InlayHint{
+			Position:     pos,
+			Label:        []protocol.InlayHintLabelPart{{Value: buf.String()}},
+			PaddingRight: true,
+		})
+	}
+}
+
 func funcTypeParams(info *types.Info, pgf *parsego.File, qual types.Qualifier, cur inspector.Cursor, add func(protocol.InlayHint)) {
 	for curCall := range cur.Preorder((*ast.CallExpr)(nil)) {
 		call := curCall.Node().(*ast.CallExpr)
@@ -0,0 +1,234 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package golang
+
+// This file defines the "Move package" operation.
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/cache/metadata"
+	"golang.org/x/tools/gopls/internal/cache/parsego"
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/protocol/command"
+	"golang.org/x/tools/internal/diff"
+)
+
+// MovePackage computes the changes that move the directory of the
+// package containing the file uri, along with its subpackages, to
+// newDir, which must be within a workspace module, and update the
+// import declarations of all packages that import them.
+//
+// It also returns the imports that violate the visibility rules of
+// internal packages after the move.
+func MovePackage(ctx context.Context, snapshot *cache.Snapshot, uri, newDir protocol.DocumentURI) ([]protocol.DocumentChange, []command.MovePackageViolation, error) {
+	meta, err := snapshot.NarrowestMetadataForFile(ctx, uri)
+	if err != nil {
+		return nil, nil, err
+	}
+	if meta.Module == nil {
+		return nil, nil, fmt.Errorf("cannot move package: missing module information for package %q", meta.PkgPath)
+	}
+	oldPkgPath := meta.PkgPath
+	oldModPath := meta.Module.Path
+	if PackagePath(oldModPath) == oldPkgPath {
+		return nil, nil, fmt.Errorf("cannot move package %q: it is the root of its module", oldPkgPath)
+	}
+
+	oldDir := uri.DirPath()
+	newDirPath := filepath.Clean(newDir.Path())
+	if newDirPath == oldDir {
+		return nil, nil, fmt.Errorf("package %q is already in %s", oldPkgPath, newDirPath)
+	}
+	if within(newDirPath, oldDir) {
+		return nil, nil, fmt.Errorf("cannot move package %q into its own directory", oldPkgPath)
+	}
+	if _, err := os.Stat(newDirPath); err == nil {
+		return nil, nil, fmt.Errorf("cannot move package %q: %s already exists", oldPkgPath, newDirPath)
+	}
+
+	// Find the module of the new directory,
+	// which determines the new import path.
+	var newModDir, newModPath string
+	for _, modURI := range snapshot.View().ModFiles() {
+		modDir := modURI.DirPath()
+		if within(newDirPath, modDir) && len(modDir) > len(newModDir) {
+			fh, err := snapshot.ReadFile(ctx, modURI)
+			if err != nil {
+				return nil, nil, err
+			}
+			pm, err := snapshot.ParseMod(ctx, fh)
+			if err != nil {
+				return nil, nil, err
+			}
+			if pm.File == nil || pm.File.Module == nil {
+				return nil, nil, fmt.Errorf("%s has no module directive", modURI.Path())
+			}
+			newModDir, newModPath = modDir, pm.File.Module.Mod.Path
+		}
+	}
+	if newModDir == "" {
+		return nil, nil, fmt.Errorf("cannot move package %q: %s is not within a workspace module", oldPkgPath, newDirPath)
+	}
+	rel, err := filepath.Rel(newModDir, newDirPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	newPkgPath := PackagePath(path.Join(newModPath, filepath.ToSlash(rel)))
+
+	// moved returns the path after the move of a package
+	// (including an x_test package) in the moved directories,
+	// and reports whether it is one.
+	moved := func(mp *metadata.Package) (PackagePath, bool) {
+		if mp.Module == nil || mp.Module.Path != oldModPath {
+			return "", false // e.g. a nested module
+		}
+		p := string(mp.PkgPath)
+		if p == string(oldPkgPath) || p == string(oldPkgPath)+"_test" || strings.HasPrefix(p, string(oldPkgPath)+"/") {
+			return newPkgPath + PackagePath(strings.TrimPrefix(p, string(oldPkgPath))), true
+		}
+		return "", false
+	}
+	newPathOf := func(mp *metadata.Package) PackagePath {
+		if p, ok := moved(mp); ok {
+			return p
+		}
+		return mp.PkgPath
+	}
+
+	allMetadata, err := snapshot.AllMetadata(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Update the imports of the moved packages.
+	edits := make(map[protocol.DocumentURI][]diff.Edit)
+	type importEdge struct {
+		from *metadata.Package
+		to   PackageID
+	}
+	var violations []importEdge
+	for _, mp := range allMetadata {
+		if mp.IsIntermediateTestVariant() {
+			continue // for moving, these variants are redundant
+		}
+		newPath, ok := moved(mp)
+		if !ok {
+			continue
+		}
+		if !strings.HasSuffix(string(mp.Name), "_test") {
+			if err := renameImports(ctx, snapshot, mp, ImportPath(newPath), mp.Name, edits); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		// Check the visibility of the package after the move
+		// to its importers, and of its imports to it.
+		rdeps, err := snapshot.ReverseDependencies(ctx, mp.ID, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, rdep := range rdeps {
+			if !rdep.IsIntermediateTestVariant() && !metadata.IsValidImport(newPathOf(rdep), newPath, true) {
+				violations = append(violations, importEdge{rdep, mp.ID})
+			}
+		}
+		for _, id := range mp.DepsByImpPath {
+			if dep := snapshot.Metadata(id); dep != nil && !metadata.IsValidImport(newPath, newPathOf(dep), true) {
+				violations = append(violations, importEdge{mp, id})
+			}
+		}
+	}
+
+	// Update the replace directives that refer to the moved directories.
+	if err := updateReplaceDirectives(ctx, snapshot, oldDir, newDirPath, edits); err != nil {
+		return nil, nil, err
+	}
+
+	var changes []protocol.DocumentChange
+	for _, uri := range slices.Sorted(maps.Keys(edits)) {
+		fileEdits := edits[uri]
+		diff.SortEdits(fileEdits)
+		fileEdits = slices.Compact(fileEdits)
+		fh, err := snapshot.ReadFile(ctx, uri)
+		if err != nil {
+			return nil, nil, err
+		}
+		content, err := fh.Content()
+		if err != nil {
+			return nil, nil, err
+		}
+		textedits, err := protocol.EditsFromDiffEdits(protocol.NewMapper(uri, content), fileEdits)
+		if err != nil {
+			return nil, nil, err
+		}
+		changes = append(changes, protocol.DocumentChangeEdit(fh, textedits))
+	}
+	changes = append(changes, protocol.DocumentChangeRename(
+		protocol.URIFromPath(oldDir),
+		protocol.URIFromPath(newDirPath)))
+
+	// Locate the imports that violate visibility, in the moved files.
+	seen := make(map[protocol.Location]bool)
+	var result []command.MovePackageViolation
+	for _, v := range violations {
+		to := snapshot.Metadata(v.to)
+		if to == nil {
+			continue
+		}
+		toPath, toMoved := moved(to)
+		for _, uri := range v.from.CompiledGoFiles {
+			fh, err := snapshot.ReadFile(ctx, uri)
+			if err != nil {
+				return nil, nil, err
+			}
+			pgf, err := snapshot.ParseGo(ctx, fh, parsego.Header)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, imp := range pgf.File.Imports {
+				if v.from.DepsByImpPath[metadata.UnquoteImportPath(imp)] != v.to {
+					continue
+				}
+				rng, err := pgf.NodeRange(imp.Path)
+				if err != nil {
+					return nil, nil, err
+				}
+				if toMoved {
+					// The import path is updated in place.
+					rng.End.Character = rng.Start.Character + uint32(len(strconv.Quote(string(toPath))))
+				}
+				loc := protocol.Location{URI: uri, Range: rng}
+				if within(uri.Path(), oldDir) {
+					loc.URI = protocol.URIFromPath(newDirPath + strings.TrimPrefix(uri.Path(), oldDir))
+				}
+				if seen[loc] {
+					continue
+				}
+				seen[loc] = true
+				result = append(result, command.MovePackageViolation{
+					Location: loc,
+					Message:  fmt.Sprintf("use of internal package %s not allowed in %s", newPathOf(to), newPathOf(v.from)),
+				})
+			}
+		}
+	}
+	return changes, result, nil
+}
+
+// within reports whether the file or directory name is dir or is
+// within it.
+func within(name, dir string) bool {
+	return name == dir || strings.HasPrefix(name, dir+string(filepath.Separator))
+}
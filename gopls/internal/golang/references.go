@@ -474,14 +474,12 @@ func ordinaryReferences(ctx context.Context, snapshot *cache.Snapshot, uri proto
 		for id := range globalScope {
 			globalIDs = append(globalIDs, id)
 		}
-		indexes, err := snapshot.References(ctx, globalIDs...)
+		locs, err := snapshot.References(ctx, globalTargets, globalIDs...)
 		if err != nil {
 			return err
 		}
-		for _, index := range indexes {
-			for _, loc := range index.Lookup(globalTargets) {
-				report(loc, false)
-			}
+		for _, loc := range locs {
+			report(loc, false)
 		}
 		return nil
 	})
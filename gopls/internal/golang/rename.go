@@ -888,20 +888,30 @@ func renamePackageName(ctx context.Context, s *cache.Snapshot, f file.Handle, ne
 	newPkgDir := filepath.Join(filepath.Dir(oldBase), string(newName))
 
 	// Update any affected replace directives in go.mod files.
-	// TODO(adonovan): extract into its own function.
-	//
-	// Get all workspace modules.
+	if err := updateReplaceDirectives(ctx, s, oldBase, newPkgDir, renamingEdits); err != nil {
+		return nil, err
+	}
+
+	return renamingEdits, nil
+}
+
+// updateReplaceDirectives computes the edits to the go.mod files of the
+// workspace modules whose replace directives refer to oldDir, or a
+// directory within it, required to move oldDir to newDir.
+//
+// Edits are written into the edits map.
+func updateReplaceDirectives(ctx context.Context, s *cache.Snapshot, oldDir, newDir string, edits map[protocol.DocumentURI][]diff.Edit) error {
 	// TODO(adonovan): should this operate on all go.mod files,
 	// irrespective of whether they are included in the workspace?
 	modFiles := s.View().ModFiles()
 	for _, m := range modFiles {
 		fh, err := s.ReadFile(ctx, m)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		pm, err := s.ParseMod(ctx, fh)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		modFileDir := pm.URI.DirPath()
@@ -919,8 +929,8 @@ func renamePackageName(ctx context.Context, s *cache.Snapshot, f file.Handle, ne
 			}
 
 			// TODO: Is there a risk of converting a '\' delimited replacement to a '/' delimited replacement?
-			if !strings.HasPrefix(filepath.ToSlash(replacedPath)+"/", filepath.ToSlash(oldBase)+"/") {
-				continue // not affected by the package renaming
+			if !strings.HasPrefix(filepath.ToSlash(replacedPath)+"/", filepath.ToSlash(oldDir)+"/") {
+				continue // not affected by the move
 			}
 
 			affectedReplaces = append(affectedReplaces, r)
@@ -931,7 +941,7 @@ func renamePackageName(ctx context.Context, s *cache.Snapshot, f file.Handle, ne
 		}
 		copied, err := modfile.Parse("", pm.Mapper.Content, nil)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		for _, r := range affectedReplaces {
@@ -940,11 +950,11 @@ func renamePackageName(ctx context.Context, s *cache.Snapshot, f file.Handle, ne
 				replacedPath = filepath.Join(modFileDir, r.New.Path)
 			}
 
-			suffix := strings.TrimPrefix(replacedPath, oldBase)
+			suffix := strings.TrimPrefix(replacedPath, oldDir)
 
-			newReplacedPath, err := filepath.Rel(modFileDir, newPkgDir+suffix)
+			newReplacedPath, err := filepath.Rel(modFileDir, newDir+suffix)
 			if err != nil {
-				return nil, err
+				return err
 			}
 
 			newReplacedPath = filepath.ToSlash(newReplacedPath)
@@ -954,22 +964,20 @@ func renamePackageName(ctx context.Context, s *cache.Snapshot, f file.Handle, ne
 			}
 
 			if err := copied.AddReplace(r.Old.Path, "", newReplacedPath, ""); err != nil {
-				return nil, err
+				return err
 			}
 		}
 
 		copied.Cleanup()
 		newContent, err := copied.Format()
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		// Calculate the edits to be made due to the change.
-		edits := diff.Bytes(pm.Mapper.Content, newContent)
-		renamingEdits[pm.URI] = append(renamingEdits[pm.URI], edits...)
+		edits[pm.URI] = append(edits[pm.URI], diff.Bytes(pm.Mapper.Content, newContent)...)
 	}
-
-	return renamingEdits, nil
+	return nil
 }
 
 // renamePackage computes all workspace edits required to rename the package
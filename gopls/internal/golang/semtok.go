@@ -549,6 +549,9 @@ func (tv *tokenVisitor) appendObjectModifiers(mods []semtok.Modifier, obj types.
 		return semtok.TokVariable, mods
 
 	case *types.Var:
+		if shadowedVar(obj) != nil {
+			mods = append(mods, semtok.ModShadowing)
+		}
 		if tv.isParam(obj.Pos()) {
 			return semtok.TokParameter, mods
 		} else {
@@ -0,0 +1,123 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package golang
+
+import (
+	"context"
+	"go/build/constraint"
+	"strings"
+
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/cache/parsego"
+	"golang.org/x/tools/gopls/internal/cache/testfuncs"
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/protocol/command"
+)
+
+// TestTree arranges the tests of a package, as reported by its test
+// index, into a tree of subtests beneath each top-level test, grouped
+// by the file that declares the top-level test.
+func TestTree(ctx context.Context, snapshot *cache.Snapshot, tests []testfuncs.Result) ([]command.TestSourceFile, error) {
+	type node struct {
+		test     command.TestNode
+		subtests []*node
+	}
+	var (
+		files  []protocol.DocumentURI
+		roots  = make(map[protocol.DocumentURI][]*node)
+		byName = make(map[string]*node)
+	)
+	for _, test := range tests {
+		n := &node{test: command.TestNode{Name: test.Name, Loc: test.Location}}
+		byName[test.Name] = n
+
+		// Attach a subtest to its nearest known ancestor.
+		// (Tests are ordered so that each precedes its subtests.)
+		parent := test.Name
+		for {
+			i := strings.LastIndexByte(parent, '/')
+			if i < 0 {
+				parent = ""
+				break
+			}
+			parent = parent[:i]
+			if byName[parent] != nil {
+				break
+			}
+		}
+		if parent != "" {
+			p := byName[parent]
+			n.test.Kind = p.test.Kind
+			p.subtests = append(p.subtests, n)
+			continue
+		}
+
+		n.test.Kind = testKind(test.Name)
+		uri := test.Location.URI
+		if _, ok := roots[uri]; !ok {
+			files = append(files, uri)
+		}
+		roots[uri] = append(roots[uri], n)
+	}
+
+	var convert func(n *node) command.TestNode
+	convert = func(n *node) command.TestNode {
+		test := n.test
+		for _, sub := range n.subtests {
+			test.Subtests = append(test.Subtests, convert(sub))
+		}
+		return test
+	}
+	var result []command.TestSourceFile
+	for _, uri := range files {
+		file := command.TestSourceFile{URI: uri}
+		fh, err := snapshot.ReadFile(ctx, uri)
+		if err != nil {
+			return nil, err
+		}
+		pgf, err := snapshot.ParseGo(ctx, fh, parsego.Header)
+		if err != nil {
+			return nil, err
+		}
+		file.BuildConstraint = buildConstraint(pgf)
+		for _, n := range roots[uri] {
+			file.Tests = append(file.Tests, convert(n))
+		}
+		result = append(result, file)
+	}
+	return result, nil
+}
+
+// testKind returns the kind of the top-level test function of the
+// specified name.
+func testKind(name string) command.TestKind {
+	switch {
+	case strings.HasPrefix(name, "Benchmark"):
+		return command.KindBenchmark
+	case strings.HasPrefix(name, "Fuzz"):
+		return command.KindFuzz
+	case strings.HasPrefix(name, "Example"):
+		return command.KindExample
+	}
+	return command.KindTest
+}
+
+// buildConstraint returns the expression of the //go:build
+// constraint of the file, or "" if it has none.
+func buildConstraint(pgf *parsego.File) string {
+	for _, cg := range pgf.File.Comments {
+		if cg.Pos() >= pgf.File.Package {
+			break
+		}
+		for _, c := range cg.List {
+			if constraint.IsGoBuild(c.Text) {
+				if expr, err := constraint.Parse(c.Text); err == nil {
+					return expr.String()
+				}
+			}
+		}
+	}
+	return ""
+}
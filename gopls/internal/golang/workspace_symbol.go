@@ -13,6 +13,7 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"time"
 	"unicode"
 
 	"golang.org/x/tools/gopls/internal/cache"
@@ -317,6 +318,8 @@ func collectSymbols(ctx context.Context, snapshots []*cache.Snapshot, matcherTyp
 		}
 		metadata.RemoveIntermediateTestVariants(&mps)
 
+		ranking := newSymbolRanking(snapshot, query)
+
 		// We'll process packages in order to consider candidate symbols.
 		//
 		// The order here doesn't matter for correctness, but can affect
@@ -379,7 +382,7 @@ func collectSymbols(ctx context.Context, snapshots []*cache.Snapshot, matcherTyp
 				}
 				// seen[uri] = true
 				_, workspace := workspacePackages.Value(mp.ID)
-				work = append(work, symbolFile{mp, uri, syms, workspace})
+				work = append(work, symbolFile{mp, uri, syms, workspace, ranking.factor(mp, uri)})
 			}
 		}
 	}
@@ -420,6 +423,67 @@ type symbolFile struct {
 	uri       protocol.DocumentURI
 	syms      []symbols.Symbol
 	workspace bool
+	factor    float64 // user-configured ranking factor; see symbolRanking
+}
+
+// recentEditWindow is the period within which a file must have been
+// opened or edited for the SymbolRecentlyEdited ranking factor to apply.
+const recentEditWindow = 10 * time.Minute
+
+// symbolRanking computes the user-configured ranking factors
+// (the SymbolRanking option) of the files of a snapshot.
+type symbolRanking struct {
+	factors   map[settings.SymbolRankingFactor]float64
+	qualifier string                        // package qualifier of the query, or ""
+	recent    map[protocol.DocumentURI]bool // recently opened or edited files
+}
+
+func newSymbolRanking(snapshot *cache.Snapshot, query string) *symbolRanking {
+	r := &symbolRanking{
+		factors:   snapshot.Options().SymbolRanking,
+		qualifier: queryPackage(query),
+	}
+	if _, ok := r.factors[settings.SymbolRecentlyEdited]; ok {
+		r.recent = make(map[protocol.DocumentURI]bool)
+		for _, o := range snapshot.Overlays() {
+			if modTime, _ := o.ModTime(); time.Since(modTime) < recentEditWindow {
+				r.recent[o.URI()] = true
+			}
+		}
+	}
+	return r
+}
+
+// factor returns the product of the ranking factors that apply to
+// the symbols of the specified file of package mp.
+func (r *symbolRanking) factor(mp *metadata.Package, uri protocol.DocumentURI) float64 {
+	factor := 1.0
+	apply := func(k settings.SymbolRankingFactor, cond bool) {
+		if f, ok := r.factors[k]; ok && cond {
+			factor *= f
+		}
+	}
+	pkgPath := string(mp.PkgPath)
+	apply(settings.SymbolPackageMatch, r.qualifier != "" &&
+		(string(mp.Name) == r.qualifier || pkgPath == r.qualifier || strings.HasSuffix(pkgPath, "/"+r.qualifier)))
+	apply(settings.SymbolVendor, strings.Contains(filepath.ToSlash(uri.Path()), "/vendor/"))
+	apply(settings.SymbolInternal, slices.Contains(strings.Split(pkgPath, "/"), "internal"))
+	apply(settings.SymbolRecentlyEdited, r.recent[uri])
+	return factor
+}
+
+// queryPackage returns the package qualifier of the first field of
+// a workspace/symbol query that has one, such as "http" in
+// "http.Server" or "net/http" in "^net/http.Serve", or "" if none does.
+func queryPackage(query string) string {
+	for _, field := range strings.Fields(query) {
+		field = strings.TrimLeft(field, "^'")
+		slash := strings.LastIndexByte(field, '/')
+		if dot := strings.IndexByte(field[slash+1:], '.'); dot > 0 {
+			return field[:slash+1+dot]
+		}
+	}
+	return ""
 }
 
 // matchFile scans a symbol file and adds matching symbols to the store.
@@ -429,7 +493,8 @@ func matchFile(store *symbolStore, symbolizer symbolizer, matcher matcherFunc, f
 		symbolParts, score := symbolizer(space, sym.Name, f.mp, matcher)
 
 		// Check if the score is too low before applying any downranking.
-		if store.tooLow(score) {
+		// (The user-configured ranking factor may promote the symbol.)
+		if store.tooLow(score * max(f.factor, 1)) {
 			continue
 		}
 
@@ -486,6 +551,9 @@ func matchFile(store *symbolStore, symbolizer symbolizer, matcher matcherFunc, f
 			}
 		}
 
+		// Apply the user-configured ranking.
+		score *= f.factor
+
 		// Apply downranking based on symbol depth.
 		if depth > 3 {
 			depth = 3
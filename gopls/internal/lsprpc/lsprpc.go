@@ -29,7 +29,7 @@ import (
 	"golang.org/x/tools/gopls/internal/server"
 	"golang.org/x/tools/gopls/internal/settings"
 	"golang.org/x/tools/internal/event"
-	"golang.org/x/tools/internal/jsonrpc2"
+	"golang.org/x/tools/jsonrpc2"
 )
 
 // Unique identifiers for client/server.
@@ -51,7 +51,7 @@ func (h *handler) vulncheckHandler(ctx context.Context, req *mcp.CallToolRequest
 	}
 
 	var logBuf bytes.Buffer
-	result, err := scan.RunGovulncheck(ctx, pattern, snapshot, dir, &logBuf)
+	result, err := scan.RunGovulncheck(ctx, pattern, snapshot, dir, &logBuf, nil)
 	if err != nil {
 		return nil, nil, fmt.Errorf("running govulncheck failed: %v\nLogs:\n%s", err, logBuf.String())
 	}
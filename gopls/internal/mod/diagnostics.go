@@ -234,8 +234,10 @@ func vulnerabilityDiagnostics(ctx context.Context, snapshot *cache.Snapshot, fh
 		// Map affecting vulns to 'warning' level diagnostics,
 		// others to 'info' level diagnostics.
 		// Fixes will include only the upgrades for warning level diagnostics.
-		var warningFixes, infoFixes []cache.SuggestedFix
 		var warningSet, infoSet = map[string]bool{}, map[string]bool{}
+		// The minimal versions that fix all the warning and info
+		// level vulnerabilities that have fixes.
+		var warningVersion, infoVersion string
 		for _, finding := range findings {
 			// It is possible that the source code was changed since the last
 			// govulncheck run and information in the `vulns` info is stale.
@@ -256,22 +258,17 @@ func vulnerabilityDiagnostics(ctx context.Context, snapshot *cache.Snapshot, fh
 			if finding.FixedVersion != "" && semver.IsValid(req.Mod.Version) && semver.Compare(finding.FixedVersion, req.Mod.Version) <= 0 {
 				continue
 			}
+			fixedVersion := finding.FixedVersion
+			if !semver.IsValid(fixedVersion) || semver.Compare(req.Mod.Version, fixedVersion) >= 0 {
+				fixedVersion = ""
+			}
 			switch _, typ := foundVuln(finding); typ {
 			case vulnImported:
 				infoSet[finding.OSV] = true
+				infoVersion = maxVersion(infoVersion, fixedVersion)
 			case vulnCalled:
 				warningSet[finding.OSV] = true
-			}
-			// Upgrade to the exact version we offer the user, not the most recent.
-			if fixedVersion := finding.FixedVersion; semver.IsValid(fixedVersion) && semver.Compare(req.Mod.Version, fixedVersion) < 0 {
-				cmd := getUpgradeCodeAction(fh, req, fixedVersion)
-				sf := cache.SuggestedFixFromCommand(cmd, protocol.QuickFix)
-				switch _, typ := foundVuln(finding); typ {
-				case vulnImported:
-					infoFixes = append(infoFixes, sf)
-				case vulnCalled:
-					warningFixes = append(warningFixes, sf)
-				}
+				warningVersion = maxVersion(warningVersion, fixedVersion)
 			}
 		}
 
@@ -286,15 +283,24 @@ func vulnerabilityDiagnostics(ctx context.Context, snapshot *cache.Snapshot, fh
 				}
 			}
 		}
-		// Add an upgrade for module@latest.
+		// Offer to upgrade to the exact version that fixes the
+		// vulnerabilities, not the most recent, and to module@latest.
 		// TODO(suzmue): verify if latest is the same as fixedVersion.
-		latest := getUpgradeCodeAction(fh, req, "latest")
-		sf := cache.SuggestedFixFromCommand(latest, protocol.QuickFix)
-		if len(warningFixes) > 0 {
-			warningFixes = append(warningFixes, sf)
+		var warningFixes, infoFixes []cache.SuggestedFix
+		latest := cache.SuggestedFixFromCommand(getUpgradeCodeAction(fh, req, "latest"), protocol.QuickFix)
+		if warningVersion != "" {
+			fix, err := upgradeFix(pm, req, warningVersion)
+			if err != nil {
+				return nil, err
+			}
+			warningFixes = append(warningFixes, fix, latest)
 		}
-		if len(infoFixes) > 0 {
-			infoFixes = append(infoFixes, sf)
+		if infoVersion != "" {
+			fix, err := upgradeFix(pm, req, infoVersion)
+			if err != nil {
+				return nil, err
+			}
+			infoFixes = append(infoFixes, fix, latest)
 		}
 		if len(warningSet) > 0 {
 			warning := sortedKeys(warningSet)
@@ -493,6 +499,44 @@ func getUpgradeCodeAction(fh file.Handle, req *modfile.Require, version string)
 	})
 }
 
+// upgradeFix returns a quick fix that edits the require directive req
+// of the go.mod file pm to require the specified version of the
+// module, and then runs go mod tidy to update the go.sum file and the
+// module's other requirements accordingly.
+func upgradeFix(pm *cache.ParsedModule, req *modfile.Require, version string) (cache.SuggestedFix, error) {
+	// The version is the last token of the directive.
+	offsets := tokenOffsets(pm.Mapper.Content, req.Syntax)
+	if len(offsets) == 0 || len(offsets) != len(req.Syntax.Token) {
+		return cache.SuggestedFix{}, fmt.Errorf("invalid require directive for %s", req.Mod.Path)
+	}
+	start := offsets[len(offsets)-1]
+	end := start + len(req.Syntax.Token[len(req.Syntax.Token)-1])
+	rng, err := pm.Mapper.OffsetRange(start, end)
+	if err != nil {
+		return cache.SuggestedFix{}, err
+	}
+	title := upgradeTitle(version)
+	return cache.SuggestedFix{
+		Title: title,
+		Edits: map[protocol.DocumentURI][]protocol.TextEdit{
+			pm.URI: {{Range: rng, NewText: modfile.AutoQuote(version)}},
+		},
+		Command: command.NewTidyCommand(title, command.URIArgs{
+			URIs: []protocol.DocumentURI{pm.URI},
+		}),
+		ActionKind: protocol.QuickFix,
+	}, nil
+}
+
+// maxVersion returns the greater of two versions,
+// either of which may be empty.
+func maxVersion(x, y string) string {
+	if x == "" || y != "" && semver.Compare(x, y) < 0 {
+		return y
+	}
+	return x
+}
+
 func upgradeTitle(fixedVersion string) string {
 	title := fmt.Sprintf("%s%v", upgradeCodeActionPrefix, fixedVersion)
 	return title
@@ -0,0 +1,209 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mod
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/tools/gopls/internal/cache"
+	"golang.org/x/tools/gopls/internal/cache/metadata"
+	"golang.org/x/tools/gopls/internal/cache/parsego"
+	"golang.org/x/tools/gopls/internal/file"
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/internal/diff"
+	"golang.org/x/tools/internal/event"
+)
+
+// PrepareRename returns the range and text of the module path of the
+// module directive of a go.mod file, if the position is within it.
+func PrepareRename(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle, position protocol.Position) (protocol.Range, string, error) {
+	pm, err := snapshot.ParseMod(ctx, fh)
+	if err != nil {
+		return protocol.Range{}, "", err
+	}
+	offset, err := pm.Mapper.PositionOffset(position)
+	if err != nil {
+		return protocol.Range{}, "", err
+	}
+	mod := pm.File.Module
+	if mod == nil || offset < mod.Syntax.Start.Byte || offset > mod.Syntax.End.Byte {
+		return protocol.Range{}, "", fmt.Errorf("only the module path of a go.mod file can be renamed")
+	}
+	edits := pathEdits(pm.Mapper.Content, mod.Syntax, mod.Mod.Path, mod.Mod.Path)
+	if len(edits) != 1 {
+		return protocol.Range{}, "", fmt.Errorf("module directive has no path")
+	}
+	rng, err := pm.Mapper.OffsetRange(edits[0].Start, edits[0].End)
+	if err != nil {
+		return protocol.Range{}, "", err
+	}
+	return rng, mod.Mod.Path, nil
+}
+
+// Rename returns the edits that change the path of the module whose
+// go.mod file is fh to newPath, updating the module directive, the
+// import declarations of all workspace packages that import packages
+// of the module, and the directives of the view's other go.mod files
+// that refer to the module.
+func Rename(ctx context.Context, snapshot *cache.Snapshot, fh file.Handle, position protocol.Position, newPath string) (map[protocol.DocumentURI][]protocol.TextEdit, error) {
+	ctx, done := event.Start(ctx, "mod.Rename")
+	defer done()
+
+	if !slices.Contains(snapshot.View().ModFiles(), fh.URI()) {
+		return nil, fmt.Errorf("%s is not a go.mod file of the workspace", fh.URI().Path())
+	}
+	if _, _, err := PrepareRename(ctx, snapshot, fh, position); err != nil {
+		return nil, err
+	}
+	pm, err := snapshot.ParseMod(ctx, fh)
+	if err != nil {
+		return nil, err
+	}
+	oldPath := pm.File.Module.Mod.Path
+	if newPath == oldPath {
+		return nil, fmt.Errorf("module is already named %s", newPath)
+	}
+	if err := module.CheckImportPath(newPath); err != nil {
+		return nil, fmt.Errorf("invalid module path: %v", err)
+	}
+
+	edits := make(map[protocol.DocumentURI][]diff.Edit)
+
+	// Update the go.mod files: the module directive of the renamed
+	// module, and the directives of the others that refer to it.
+	for _, uri := range snapshot.View().ModFiles() {
+		modFH, err := snapshot.ReadFile(ctx, uri)
+		if err != nil {
+			return nil, err
+		}
+		pm, err := snapshot.ParseMod(ctx, modFH)
+		if err != nil {
+			return nil, err
+		}
+		var lines []*modfile.Line
+		if uri == fh.URI() {
+			lines = append(lines, pm.File.Module.Syntax)
+		}
+		for _, r := range pm.File.Require {
+			lines = append(lines, r.Syntax)
+		}
+		for _, r := range pm.File.Replace {
+			lines = append(lines, r.Syntax)
+		}
+		for _, x := range pm.File.Exclude {
+			lines = append(lines, x.Syntax)
+		}
+		for _, line := range lines {
+			edits[uri] = append(edits[uri], pathEdits(pm.Mapper.Content, line, oldPath, newPath)...)
+		}
+	}
+
+	// Update the import declarations of the workspace packages
+	// that import packages of the module.
+	mps, err := snapshot.WorkspaceMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, mp := range mps {
+		if mp.IsIntermediateTestVariant() {
+			continue // for renaming, these variants are redundant
+		}
+		for _, uri := range mp.CompiledGoFiles {
+			fh, err := snapshot.ReadFile(ctx, uri)
+			if err != nil {
+				return nil, err
+			}
+			pgf, err := snapshot.ParseGo(ctx, fh, parsego.Header)
+			if err != nil {
+				return nil, err
+			}
+			for _, imp := range pgf.File.Imports {
+				importPath := metadata.UnquoteImportPath(imp)
+				dep := snapshot.Metadata(mp.DepsByImpPath[importPath])
+				if dep == nil || dep.Module == nil || dep.Module.Path != oldPath {
+					continue // not a package of the module
+				}
+				rest, ok := strings.CutPrefix(string(importPath), oldPath)
+				if !ok || rest != "" && rest[0] != '/' {
+					continue // e.g. vendored
+				}
+				start, end, err := pgf.NodeOffsets(imp.Path)
+				if err != nil {
+					return nil, err
+				}
+				edits[uri] = append(edits[uri], diff.Edit{Start: start, End: end, New: strconv.Quote(newPath + rest)})
+			}
+		}
+	}
+
+	result := make(map[protocol.DocumentURI][]protocol.TextEdit)
+	for uri, fileEdits := range edits {
+		if len(fileEdits) == 0 {
+			continue
+		}
+		diff.SortEdits(fileEdits)
+		fileEdits = slices.Compact(fileEdits) // a file may belong to several packages
+		fh, err := snapshot.ReadFile(ctx, uri)
+		if err != nil {
+			return nil, err
+		}
+		content, err := fh.Content()
+		if err != nil {
+			return nil, err
+		}
+		textEdits, err := protocol.EditsFromDiffEdits(protocol.NewMapper(uri, content), fileEdits)
+		if err != nil {
+			return nil, err
+		}
+		result[uri] = textEdits
+	}
+	return result, nil
+}
+
+// pathEdits returns the edits that replace each token of the go.mod
+// line that denotes module path old by new.
+func pathEdits(content []byte, line *modfile.Line, old, new string) []diff.Edit {
+	var edits []diff.Edit
+	for i, offset := range tokenOffsets(content, line) {
+		tok := line.Token[i]
+		if path, err := parseToken(tok); err == nil && path == old {
+			edits = append(edits, diff.Edit{Start: offset, End: offset + len(tok), New: modfile.AutoQuote(new)})
+		}
+	}
+	return edits
+}
+
+// tokenOffsets returns the offsets of the tokens of a go.mod line.
+// (The tokens of a line in a block exclude the block's verb.)
+func tokenOffsets(content []byte, line *modfile.Line) []int {
+	var offsets []int
+	offset := line.Start.Byte
+	for _, tok := range line.Token {
+		i := bytes.Index(content[offset:line.End.Byte], []byte(tok))
+		if i < 0 {
+			break // can't happen
+		}
+		offset += i
+		offsets = append(offsets, offset)
+		offset += len(tok)
+	}
+	return offsets
+}
+
+// parseToken returns the string denoted by a (possibly quoted) token
+// of a go.mod file.
+func parseToken(tok string) (string, error) {
+	if strings.HasPrefix(tok, `"`) || strings.HasPrefix(tok, "`") {
+		return strconv.Unquote(tok)
+	}
+	return tok, nil
+}
@@ -30,12 +30,14 @@ const (
 	AddTest                 Command = "gopls.add_test"
 	ApplyFix                Command = "gopls.apply_fix"
 	Assembly                Command = "gopls.assembly"
+	CallGraph               Command = "gopls.call_graph"
 	ChangeSignature         Command = "gopls.change_signature"
 	CheckUpgrades           Command = "gopls.check_upgrades"
 	ClientOpenURL           Command = "gopls.client_open_url"
 	DiagnoseFiles           Command = "gopls.diagnose_files"
 	Doc                     Command = "gopls.doc"
 	EditGoDirective         Command = "gopls.edit_go_directive"
+	ExtractInterface        Command = "gopls.extract_interface"
 	ExtractToNewFile        Command = "gopls.extract_to_new_file"
 	FetchVulncheckResult    Command = "gopls.fetch_vulncheck_result"
 	FreeSymbols             Command = "gopls.free_symbols"
@@ -45,10 +47,12 @@ const (
 	LSP                     Command = "gopls.lsp"
 	ListImports             Command = "gopls.list_imports"
 	ListKnownPackages       Command = "gopls.list_known_packages"
+	ListTests               Command = "gopls.list_tests"
 	MaybePromptForTelemetry Command = "gopls.maybe_prompt_for_telemetry"
 	MemStats                Command = "gopls.mem_stats"
 	ModifyTags              Command = "gopls.modify_tags"
 	Modules                 Command = "gopls.modules"
+	MovePackage             Command = "gopls.move_package"
 	PackageSymbols          Command = "gopls.package_symbols"
 	Packages                Command = "gopls.packages"
 	RegenerateCgo           Command = "gopls.regenerate_cgo"
@@ -78,12 +82,14 @@ var Commands = []Command{
 	AddTest,
 	ApplyFix,
 	Assembly,
+	CallGraph,
 	ChangeSignature,
 	CheckUpgrades,
 	ClientOpenURL,
 	DiagnoseFiles,
 	Doc,
 	EditGoDirective,
+	ExtractInterface,
 	ExtractToNewFile,
 	FetchVulncheckResult,
 	FreeSymbols,
@@ -93,10 +99,12 @@ var Commands = []Command{
 	LSP,
 	ListImports,
 	ListKnownPackages,
+	ListTests,
 	MaybePromptForTelemetry,
 	MemStats,
 	ModifyTags,
 	Modules,
+	MovePackage,
 	PackageSymbols,
 	Packages,
 	RegenerateCgo,
@@ -159,6 +167,12 @@ func Dispatch(ctx context.Context, params *protocol.ExecuteCommandParams, s Inte
 			return nil, err
 		}
 		return nil, s.Assembly(ctx, a0, a1, a2)
+	case CallGraph:
+		var a0 CallGraphArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return s.CallGraph(ctx, a0)
 	case ChangeSignature:
 		var a0 ChangeSignatureArgs
 		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
@@ -195,6 +209,12 @@ func Dispatch(ctx context.Context, params *protocol.ExecuteCommandParams, s Inte
 			return nil, err
 		}
 		return nil, s.EditGoDirective(ctx, a0)
+	case ExtractInterface:
+		var a0 ExtractInterfaceArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return nil, s.ExtractInterface(ctx, a0)
 	case ExtractToNewFile:
 		var a0 protocol.Location
 		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
@@ -250,6 +270,12 @@ func Dispatch(ctx context.Context, params *protocol.ExecuteCommandParams, s Inte
 			return nil, err
 		}
 		return s.ListKnownPackages(ctx, a0)
+	case ListTests:
+		var a0 ListTestsArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return s.ListTests(ctx, a0)
 	case MaybePromptForTelemetry:
 		return nil, s.MaybePromptForTelemetry(ctx)
 	case MemStats:
@@ -266,6 +292,12 @@ func Dispatch(ctx context.Context, params *protocol.ExecuteCommandParams, s Inte
 			return nil, err
 		}
 		return s.Modules(ctx, a0)
+	case MovePackage:
+		var a0 MovePackageArgs
+		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
+			return nil, err
+		}
+		return s.MovePackage(ctx, a0)
 	case PackageSymbols:
 		var a0 PackageSymbolsArgs
 		if err := UnmarshalArgs(params.Arguments, &a0); err != nil {
@@ -427,6 +459,14 @@ func NewAssemblyCommand(title string, a0 string, a1 string, a2 string) *protocol
 	}
 }
 
+func NewCallGraphCommand(title string, a0 CallGraphArgs) *protocol.Command {
+	return &protocol.Command{
+		Title:     title,
+		Command:   CallGraph.String(),
+		Arguments: MustMarshalArgs(a0),
+	}
+}
+
 func NewChangeSignatureCommand(title string, a0 ChangeSignatureArgs) *protocol.Command {
 	return &protocol.Command{
 		Title:     title,
@@ -475,6 +515,14 @@ func NewEditGoDirectiveCommand(title string, a0 EditGoDirectiveArgs) *protocol.C
 	}
 }
 
+func NewExtractInterfaceCommand(title string, a0 ExtractInterfaceArgs) *protocol.Command {
+	return &protocol.Command{
+		Title:     title,
+		Command:   ExtractInterface.String(),
+		Arguments: MustMarshalArgs(a0),
+	}
+}
+
 func NewExtractToNewFileCommand(title string, a0 protocol.Location) *protocol.Command {
 	return &protocol.Command{
 		Title:     title,
@@ -547,6 +595,14 @@ func NewListKnownPackagesCommand(title string, a0 URIArg) *protocol.Command {
 	}
 }
 
+func NewListTestsCommand(title string, a0 ListTestsArgs) *protocol.Command {
+	return &protocol.Command{
+		Title:     title,
+		Command:   ListTests.String(),
+		Arguments: MustMarshalArgs(a0),
+	}
+}
+
 func NewMaybePromptForTelemetryCommand(title string) *protocol.Command {
 	return &protocol.Command{
 		Title:     title,
@@ -579,6 +635,14 @@ func NewModulesCommand(title string, a0 ModulesArgs) *protocol.Command {
 	}
 }
 
+func NewMovePackageCommand(title string, a0 MovePackageArgs) *protocol.Command {
+	return &protocol.Command{
+		Title:     title,
+		Command:   MovePackage.String(),
+		Arguments: MustMarshalArgs(a0),
+	}
+}
+
 func NewPackageSymbolsCommand(title string, a0 PackageSymbolsArgs) *protocol.Command {
 	return &protocol.Command{
 		Title:     title,
@@ -175,6 +175,28 @@ type Interface interface {
 	// Used by the code action of the same name.
 	ExtractToNewFile(context.Context, protocol.Location) error
 
+	// ExtractInterface: Extract an interface from a type
+	//
+	// Declares an interface type whose methods are those of a
+	// concrete type, or a subset of them, and optionally replaces
+	// the concrete type by the interface at selected declarations.
+	//
+	// Used by the code action of the same name.
+	ExtractInterface(context.Context, ExtractInterfaceArgs) error
+
+	// MovePackage: Move a package to another directory
+	//
+	// Moves the directory of a package, and of its subpackages, to
+	// another directory within a workspace module, updating the
+	// import declarations of all workspace packages that import
+	// them and the replace directives of workspace go.mod files that
+	// refer to them.
+	//
+	// If the move would cause imports to violate the visibility
+	// rules of internal packages, the package is not moved unless
+	// Force is set; the result describes the violations either way.
+	MovePackage(context.Context, MovePackageArgs) (MovePackageResult, error)
+
 	// StartDebugging: Start the gopls debug server
 	//
 	// Start the gopls debug server if it isn't running, and return the debug
@@ -307,6 +329,29 @@ type Interface interface {
 	// server yet.
 	Packages(context.Context, PackagesArgs) (PackagesResult, error)
 
+	// ListTests: Return the tests of packages
+	//
+	// Returns, for each workspace package associated with the
+	// specified files or directories, the tree of its Test,
+	// Benchmark, Fuzz, and Example functions and their statically
+	// known subtests, along with the build constraints of the files
+	// that declare them, so that clients can present them without
+	// inspecting document symbols.
+	//
+	// Like Packages, it returns an empty result if the specified
+	// files or directories are not associated with any Views.
+	ListTests(context.Context, ListTestsArgs) (ListTestsResult, error)
+
+	// CallGraph: Export the call hierarchy of a function as a graph
+	//
+	// Computes the graph of the incoming and/or outgoing calls of
+	// the function at the specified location, transitively, to the
+	// specified depth, using the same indexes as the LSP call
+	// hierarchy requests. The graph is returned as a list of nodes
+	// and edges and, if requested, rendered in the Graphviz DOT
+	// language, for use by documentation and code review tools.
+	CallGraph(context.Context, CallGraphArgs) (CallGraphResult, error)
+
 	// Modules: Return information about modules within a directory
 	//
 	// This command returns an empty result if there is no module, or if module
@@ -740,6 +785,77 @@ type Package struct {
 	TestFiles []TestFile
 }
 
+// ListTestsArgs holds arguments for the ListTests command.
+type ListTestsArgs struct {
+	// Files is a list of files and directories whose associated
+	// packages should be described by the result, as for Packages.
+	Files []protocol.DocumentURI
+
+	// Enumerate all packages under the directory loadable with
+	// the ... pattern, as for Packages.
+	Recursive bool `json:"Recursive,omitempty"`
+}
+
+// ListTestsResult is the result of the ListTests command.
+type ListTestsResult struct {
+	// Packages lists the packages that have tests, in an
+	// unspecified order.
+	Packages []TestPackage
+}
+
+// TestPackage describes the tests of a package.
+type TestPackage struct {
+	// Package path.
+	Path string
+	// Module path. Empty if the package doesn't
+	// belong to any module.
+	ModulePath string
+	// q in a "p [q.test]" package.
+	ForTest string
+
+	// Files contains the *_test.go files of the package
+	// that declare tests.
+	Files []TestSourceFile
+}
+
+// TestSourceFile describes the tests declared in a *_test.go file.
+type TestSourceFile struct {
+	URI protocol.DocumentURI
+
+	// BuildConstraint is the expression of the file's //go:build
+	// constraint, if any, such as "linux && !race".
+	BuildConstraint string `json:",omitempty"`
+
+	// Tests are the top-level test functions of the file, in
+	// order of declaration.
+	Tests []TestNode
+}
+
+// TestNode is a node in the tree of tests: a top-level test
+// function or a subtest of one.
+type TestNode struct {
+	// Name is the complete name of the test or subtest,
+	// as for [TestCase.Name].
+	Name string
+	// Kind is the kind of the top-level test function.
+	Kind TestKind
+	// Loc is the location of the test function or subtest,
+	// as for [TestCase.Loc].
+	Loc protocol.Location
+	// Subtests are the statically known subtests of the test.
+	Subtests []TestNode `json:",omitempty"`
+}
+
+// TestKind is the kind of a test function.
+type TestKind string
+
+const (
+	KindTest      TestKind = "test"
+	KindBenchmark TestKind = "benchmark"
+	KindFuzz      TestKind = "fuzz"
+	KindExample   TestKind = "example"
+)
+
 type Module struct {
 	Path    string               // module path
 	Version string               // module version if any.
@@ -872,6 +988,106 @@ type ModifyTagsArgs struct {
 	ValueFormat          string               // format for the tag's value, after transformation; for example "column:{field}"
 }
 
+// ExtractInterfaceArgs holds the arguments to the ExtractInterface command.
+type ExtractInterfaceArgs struct {
+	// The location of the name of the type, or of the selected
+	// methods of it.
+	Location protocol.Location
+	// The name of the interface. By default, it is the name of the
+	// type followed by "Interface".
+	Name string
+	// The names of the methods of the interface. By default, they
+	// are the selected methods, or, if the type is selected, all its
+	// exported methods.
+	Methods []string
+	// The directory of the package in which to declare the
+	// interface. By default, it is the package of the type.
+	Dir protocol.DocumentURI
+	// The locations of type expressions denoting the type, or a
+	// pointer to it, to replace by the interface.
+	Rewrites []protocol.Location
+}
+
+// CallGraphArgs holds the arguments to the CallGraph command.
+type CallGraphArgs struct {
+	// The location of a function or method, or of a reference to one.
+	Location protocol.Location
+	// The direction of the calls to follow. By default, both
+	// incoming and outgoing calls are followed.
+	Direction CallGraphDirection `json:"Direction,omitempty"`
+	// The maximum length of a path of calls from the function.
+	// By default, it is 1.
+	Depth int `json:"Depth,omitempty"`
+	// Format, if "dot", causes the graph to be rendered in the
+	// Graphviz DOT language too.
+	Format string `json:"Format,omitempty"`
+}
+
+// A CallGraphDirection selects the calls followed by the CallGraph command.
+type CallGraphDirection string
+
+const (
+	CallGraphIncoming CallGraphDirection = "incoming"
+	CallGraphOutgoing CallGraphDirection = "outgoing"
+	CallGraphBoth     CallGraphDirection = "both"
+)
+
+// CallGraphResult holds the result of the CallGraph command.
+type CallGraphResult struct {
+	// The functions of the graph. The first is the root.
+	Nodes []CallGraphNode
+	// The calls of the graph, ordered by caller and callee.
+	Edges []CallGraphEdge
+	// The graph in the DOT language, if requested.
+	DOT string `json:",omitempty"`
+}
+
+// A CallGraphNode is a function in the graph computed by the
+// CallGraph command, identified by its index in CallGraphResult.Nodes.
+type CallGraphNode struct {
+	Name string
+	// Detail describes the package and file of the function.
+	Detail   string
+	Location protocol.Location
+}
+
+// A CallGraphEdge records the calls from one function to another in
+// the graph computed by the CallGraph command.
+type CallGraphEdge struct {
+	// The indices of the caller and callee in CallGraphResult.Nodes.
+	From, To int
+	// The ranges of the calls within the caller.
+	Calls []protocol.Location
+}
+
+// MovePackageArgs holds the arguments to the MovePackage command.
+type MovePackageArgs struct {
+	// A file of the package to move.
+	URI protocol.DocumentURI
+	// The new directory of the package, which must not exist.
+	NewDir protocol.DocumentURI
+	// Force causes the package to be moved even if the move would
+	// violate the visibility rules of internal packages.
+	Force bool
+}
+
+// MovePackageResult holds the result of the MovePackage command.
+type MovePackageResult struct {
+	// Violations describes the imports that violate the
+	// visibility rules of internal packages after the move.
+	Violations []MovePackageViolation
+	// Moved reports whether the package was moved.
+	Moved bool
+}
+
+// A MovePackageViolation is an import that violates the visibility
+// rules of internal packages after a package move.
+type MovePackageViolation struct {
+	// The location of the import path, after the move.
+	Location protocol.Location
+	Message  string
+}
+
 type LSPArgs struct {
 	Method string          `json:"method"`
 	Param  json.RawMessage `json:"param"`
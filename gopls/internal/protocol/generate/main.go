@@ -98,7 +98,7 @@ func writeclient() {
 	"encoding/json"
 	"fmt"
 
-	"golang.org/x/tools/internal/jsonrpc2"
+	"golang.org/x/tools/jsonrpc2"
 )
 `)
 	out.WriteString("type Client interface {\n")
@@ -142,7 +142,7 @@ func writeserver() {
 	"encoding/json"
 	"fmt"
 
-	"golang.org/x/tools/internal/jsonrpc2"
+	"golang.org/x/tools/jsonrpc2"
 )
 `)
 	out.WriteString("type Server interface {\n")
@@ -12,9 +12,9 @@ import (
 	"io"
 
 	"golang.org/x/tools/internal/event"
-	"golang.org/x/tools/internal/jsonrpc2"
 	jsonrpc2_v2 "golang.org/x/tools/internal/jsonrpc2_v2"
 	"golang.org/x/tools/internal/xcontext"
+	"golang.org/x/tools/jsonrpc2"
 )
 
 var (
@@ -107,6 +107,7 @@ const (
 	ModMap       Modifier = "map"
 	ModNumber    Modifier = "number"
 	ModPointer   Modifier = "pointer"
+	ModShadowing Modifier = "shadowing" // for a variable that shadows another
 	ModSignature Modifier = "signature" // for function types
 	ModSlice     Modifier = "slice"
 	ModString    Modifier = "string"
@@ -129,6 +130,7 @@ var TokenModifiers = []Modifier{
 	ModMap,
 	ModNumber,
 	ModPointer,
+	ModShadowing,
 	ModSignature,
 	ModSlice,
 	ModString,
@@ -44,8 +44,8 @@ import (
 	"golang.org/x/tools/internal/diff"
 	"golang.org/x/tools/internal/event"
 	"golang.org/x/tools/internal/gocommand"
-	"golang.org/x/tools/internal/jsonrpc2"
 	"golang.org/x/tools/internal/xcontext"
+	"golang.org/x/tools/jsonrpc2"
 )
 
 func (s *server) ExecuteCommand(ctx context.Context, params *protocol.ExecuteCommandParams) (any, error) {
@@ -149,10 +149,13 @@ func (h *commandHandler) Modules(ctx context.Context, args command.ModulesArgs)
 	return result, nil
 }
 
-func (h *commandHandler) Packages(ctx context.Context, args command.PackagesArgs) (command.PackagesResult, error) {
+// packageFilter returns a predicate that reports whether a package
+// is associated with the specified files and directories, or, if
+// recursive, is beneath one of the directories.
+func packageFilter(files []protocol.DocumentURI, recursive bool) func(*metadata.Package) bool {
 	// Convert file arguments into directories
-	dirs := make([]protocol.DocumentURI, len(args.Files))
-	for i, file := range args.Files {
+	dirs := make([]protocol.DocumentURI, len(files))
+	for i, file := range files {
 		if filepath.Ext(file.Path()) == ".go" {
 			dirs[i] = file.Dir()
 		} else {
@@ -160,16 +163,20 @@ func (h *commandHandler) Packages(ctx context.Context, args command.PackagesArgs
 		}
 	}
 
-	keepPackage := func(pkg *metadata.Package) bool {
+	return func(pkg *metadata.Package) bool {
 		for _, file := range pkg.GoFiles {
 			for _, dir := range dirs {
-				if file.Dir() == dir || args.Recursive && dir.Encloses(file) {
+				if file.Dir() == dir || recursive && dir.Encloses(file) {
 					return true
 				}
 			}
 		}
 		return false
 	}
+}
+
+func (h *commandHandler) Packages(ctx context.Context, args command.PackagesArgs) (command.PackagesResult, error) {
+	keepPackage := packageFilter(args.Files, args.Recursive)
 
 	result := command.PackagesResult{
 		Module: make(map[string]command.Module),
@@ -260,6 +267,62 @@ func (h *commandHandler) Packages(ctx context.Context, args command.PackagesArgs
 	return result, err
 }
 
+func (h *commandHandler) ListTests(ctx context.Context, args command.ListTestsArgs) (command.ListTestsResult, error) {
+	keepPackage := packageFilter(args.Files, args.Recursive)
+
+	var result command.ListTestsResult
+	err := h.run(ctx, commandConfig{
+		progress: "List tests",
+	}, func(ctx context.Context, _ commandDeps) error {
+		for _, view := range h.s.session.Views() {
+			snapshot, release, err := view.Snapshot()
+			if err != nil {
+				return err
+			}
+			defer release()
+
+			metas, err := snapshot.WorkspaceMetadata(ctx)
+			if err != nil {
+				return err
+			}
+			metas = slices.DeleteFunc(metas, func(meta *metadata.Package) bool {
+				return meta.IsIntermediateTestVariant() ||
+					!keepPackage(meta)
+			})
+			var ids []cache.PackageID
+			for _, meta := range metas {
+				ids = append(ids, meta.ID)
+			}
+			allTests, err := snapshot.Tests(ctx, ids...)
+			if err != nil {
+				return err
+			}
+
+			for i, tests := range allTests {
+				files, err := golang.TestTree(ctx, snapshot, tests.All())
+				if err != nil {
+					return err
+				}
+				if len(files) == 0 {
+					continue
+				}
+				meta := metas[i]
+				pkg := command.TestPackage{
+					Path:    string(meta.PkgPath),
+					ForTest: string(meta.ForTest),
+					Files:   files,
+				}
+				if meta.Module != nil {
+					pkg.ModulePath = meta.Module.Path
+				}
+				result.Packages = append(result.Packages, pkg)
+			}
+		}
+		return nil
+	})
+	return result, err
+}
+
 func (h *commandHandler) MaybePromptForTelemetry(ctx context.Context) error {
 	// if the server's TelemetryPrompt is true, it's likely the server already
 	// handled prompting for it. Don't try to prompt again.
@@ -1137,6 +1200,64 @@ func (c *commandHandler) ExtractToNewFile(ctx context.Context, args protocol.Loc
 	})
 }
 
+func (c *commandHandler) ExtractInterface(ctx context.Context, args command.ExtractInterfaceArgs) error {
+	return c.run(ctx, commandConfig{
+		progress: "Extract interface",
+		forURI:   args.Location.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		changes, err := golang.ExtractInterface(ctx, deps.snapshot, args)
+		if err != nil {
+			return err
+		}
+		return applyChanges(ctx, c.s.client, changes)
+	})
+}
+
+func (c *commandHandler) MovePackage(ctx context.Context, args command.MovePackageArgs) (command.MovePackageResult, error) {
+	var result command.MovePackageResult
+	err := c.run(ctx, commandConfig{
+		progress: "Move package",
+		forURI:   args.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		changes, violations, err := golang.MovePackage(ctx, deps.snapshot, args.URI, args.NewDir)
+		if err != nil {
+			return err
+		}
+		result.Violations = violations
+		if len(violations) > 0 {
+			v := violations[0]
+			msg := fmt.Sprintf("%s: %s", v.Location.URI.Path(), v.Message)
+			if len(violations) > 1 {
+				msg += fmt.Sprintf(" (and %d more)", len(violations)-1)
+			}
+			if !args.Force {
+				showMessage(ctx, c.s.client, protocol.Warning, "Package not moved, as the move would violate the visibility of internal packages: "+msg)
+				return nil
+			}
+			showMessage(ctx, c.s.client, protocol.Warning, "The moved package violates the visibility of internal packages: "+msg)
+		}
+		if err := applyChanges(ctx, c.s.client, changes); err != nil {
+			return err
+		}
+		result.Moved = true
+		return nil
+	})
+	return result, err
+}
+
+func (c *commandHandler) CallGraph(ctx context.Context, args command.CallGraphArgs) (command.CallGraphResult, error) {
+	var result command.CallGraphResult
+	err := c.run(ctx, commandConfig{
+		progress: "Computing call graph",
+		forURI:   args.Location.URI,
+	}, func(ctx context.Context, deps commandDeps) error {
+		var err error
+		result, err = golang.CallGraph(ctx, deps.snapshot, args)
+		return err
+	})
+	return result, err
+}
+
 func (c *commandHandler) StartDebugging(ctx context.Context, args command.DebuggingArgs) (result command.DebuggingResult, _ error) {
 	addr := args.Addr
 	if addr == "" {
@@ -1236,24 +1357,23 @@ func (c *commandHandler) Vulncheck(ctx context.Context, args command.VulncheckAr
 		dir := args.URI.DirPath()
 		pattern := args.Pattern
 
-		result, err := scan.RunGovulncheck(ctx, pattern, deps.snapshot, dir, workDoneWriter)
+		// Report findings as diagnostics as the scan progresses.
+		view := deps.snapshot.View()
+		partial := func(result *vulncheck.Result) {
+			if err := c.updateVulns(ctx, view, args.URI, result); err != nil {
+				event.Error(ctx, "reporting partial govulncheck result", err)
+			}
+		}
+		result, err := scan.RunGovulncheck(ctx, pattern, deps.snapshot, dir, workDoneWriter, partial)
 		if err != nil {
 			return err
 		}
 		commandResult.Result = result
 		commandResult.Token = deps.work.Token()
 
-		snapshot, release, err := c.s.session.InvalidateView(ctx, deps.snapshot.View(), cache.StateChange{
-			Vulns: map[protocol.DocumentURI]*vulncheck.Result{args.URI: result},
-		})
-		if err != nil {
+		if err := c.updateVulns(ctx, view, args.URI, result); err != nil {
 			return err
 		}
-		defer release()
-
-		// Diagnosing with the background context ensures new snapshots are fully
-		// diagnosed.
-		c.s.diagnoseSnapshot(snapshot.BackgroundContext(), snapshot, nil, 0)
 
 		affecting := make(map[string]bool, len(result.Entries))
 		for _, finding := range result.Findings {
@@ -1281,6 +1401,24 @@ func (c *commandHandler) Vulncheck(ctx context.Context, args command.VulncheckAr
 	return commandResult, nil
 }
 
+// updateVulns records the (possibly partial) govulncheck result for
+// the specified go.mod file in the view, and diagnoses the resulting
+// snapshot, so that the findings appear as diagnostics.
+func (c *commandHandler) updateVulns(ctx context.Context, view *cache.View, modURI protocol.DocumentURI, result *vulncheck.Result) error {
+	snapshot, release, err := c.s.session.InvalidateView(ctx, view, cache.StateChange{
+		Vulns: map[protocol.DocumentURI]*vulncheck.Result{modURI: result},
+	})
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	// Diagnosing with the background context ensures new snapshots are fully
+	// diagnosed.
+	c.s.diagnoseSnapshot(snapshot.BackgroundContext(), snapshot, nil, 0)
+	return nil
+}
+
 // RunGovulncheck is like Vulncheck (in fact, a copy), but is tweaked slightly
 // to run asynchronously rather than return a result.
 //
@@ -1311,22 +1449,21 @@ func (c *commandHandler) RunGovulncheck(ctx context.Context, args command.Vulnch
 		dir := filepath.Dir(args.URI.Path())
 		pattern := args.Pattern
 
-		result, err := scan.RunGovulncheck(ctx, pattern, deps.snapshot, dir, workDoneWriter)
+		// Report findings as diagnostics as the scan progresses.
+		view := deps.snapshot.View()
+		partial := func(result *vulncheck.Result) {
+			if err := c.updateVulns(ctx, view, args.URI, result); err != nil {
+				event.Error(ctx, "reporting partial govulncheck result", err)
+			}
+		}
+		result, err := scan.RunGovulncheck(ctx, pattern, deps.snapshot, dir, workDoneWriter, partial)
 		if err != nil {
 			return err
 		}
 
-		snapshot, release, err := c.s.session.InvalidateView(ctx, deps.snapshot.View(), cache.StateChange{
-			Vulns: map[protocol.DocumentURI]*vulncheck.Result{args.URI: result},
-		})
-		if err != nil {
+		if err := c.updateVulns(ctx, view, args.URI, result); err != nil {
 			return err
 		}
-		defer release()
-
-		// Diagnosing with the background context ensures new snapshots are fully
-		// diagnosed.
-		c.s.diagnoseSnapshot(snapshot.BackgroundContext(), snapshot, nil, 0)
 
 		affecting := make(map[string]bool, len(result.Entries))
 		for _, finding := range result.Findings {
@@ -33,7 +33,7 @@ import (
 	"golang.org/x/tools/gopls/internal/util/moremaps"
 	"golang.org/x/tools/gopls/internal/util/moreslices"
 	"golang.org/x/tools/internal/event"
-	"golang.org/x/tools/internal/jsonrpc2"
+	"golang.org/x/tools/jsonrpc2"
 )
 
 func (s *server) Initialize(ctx context.Context, params *protocol.ParamInitialize) (*protocol.InitializeResult, error) {
@@ -63,7 +63,8 @@ func (s *server) Initialize(ctx context.Context, params *protocol.ParamInitializ
 	// Process initialization options.
 	{
 		res, errs := options.Set(params.InitializationOptions)
-		s.handleOptionResult(ctx, res, errs)
+		_, pluginErrs := settings.LoadAnalyzerPlugins(options.AnalyzerPlugins)
+		s.handleOptionResult(ctx, res, append(errs, pluginErrs...))
 	}
 	options.ForClientCapabilities(params.ClientInfo, params.Capabilities)
 
@@ -551,6 +552,9 @@ func (s *server) fetchFolderOptions(ctx context.Context, folder protocol.Documen
 		res, errs := opts.Set(config)
 		s.handleOptionResult(ctx, res, errs)
 	}
+	if _, errs := settings.LoadAnalyzerPlugins(opts.AnalyzerPlugins); len(errs) > 0 {
+		s.handleOptionResult(ctx, nil, errs)
+	}
 	return opts, nil
 }
 
@@ -12,6 +12,7 @@ import (
 	"golang.org/x/tools/gopls/internal/file"
 	"golang.org/x/tools/gopls/internal/golang"
 	"golang.org/x/tools/gopls/internal/label"
+	"golang.org/x/tools/gopls/internal/mod"
 	"golang.org/x/tools/gopls/internal/protocol"
 	"golang.org/x/tools/internal/event"
 )
@@ -27,14 +28,23 @@ func (s *server) Rename(ctx context.Context, params *protocol.RenameParams) (*pr
 	}
 	defer release()
 
-	if kind := snapshot.FileKind(fh); kind != file.Go {
+	var (
+		edits         map[protocol.DocumentURI][]protocol.TextEdit
+		isPkgRenaming bool
+	)
+	switch kind := snapshot.FileKind(fh); kind {
+	case file.Go:
+		// Because we don't handle directory renaming within golang.Rename, golang.Rename returns
+		// boolean value isPkgRenaming to determine whether any DocumentChanges of type RenameFile should
+		// be added to the return protocol.WorkspaceEdit value.
+		edits, isPkgRenaming, err = golang.Rename(ctx, snapshot, fh, params.Position, params.NewName)
+	case file.Mod:
+		// Renaming the module path of a go.mod file
+		// updates the import paths of the workspace.
+		edits, err = mod.Rename(ctx, snapshot, fh, params.Position, params.NewName)
+	default:
 		return nil, fmt.Errorf("cannot rename in file of type %s", kind)
 	}
-
-	// Because we don't handle directory renaming within golang.Rename, golang.Rename returns
-	// boolean value isPkgRenaming to determine whether any DocumentChanges of type RenameFile should
-	// be added to the return protocol.WorkspaceEdit value.
-	edits, isPkgRenaming, err := golang.Rename(ctx, snapshot, fh, params.Position, params.NewName)
 	if err != nil {
 		return nil, err
 	}
@@ -78,7 +88,15 @@ func (s *server) PrepareRename(ctx context.Context, params *protocol.PrepareRena
 	}
 	defer release()
 
-	if kind := snapshot.FileKind(fh); kind != file.Go {
+	switch kind := snapshot.FileKind(fh); kind {
+	case file.Go:
+	case file.Mod:
+		rng, path, err := mod.PrepareRename(ctx, snapshot, fh, params.Position)
+		if err != nil {
+			return nil, err
+		}
+		return &protocol.PrepareRenamePlaceholder{Range: rng, Placeholder: path}, nil
+	default:
 		return nil, fmt.Errorf("cannot rename in file of type %s", kind)
 	}
 
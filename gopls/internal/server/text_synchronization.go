@@ -19,8 +19,8 @@ import (
 	"golang.org/x/tools/gopls/internal/label"
 	"golang.org/x/tools/gopls/internal/protocol"
 	"golang.org/x/tools/internal/event"
-	"golang.org/x/tools/internal/jsonrpc2"
 	"golang.org/x/tools/internal/xcontext"
+	"golang.org/x/tools/jsonrpc2"
 )
 
 // ModificationSource identifies the origin of a change.
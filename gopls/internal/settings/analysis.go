@@ -20,6 +20,7 @@ import (
 	"golang.org/x/tools/go/analysis/passes/copylock"
 	"golang.org/x/tools/go/analysis/passes/deepequalerrors"
 	"golang.org/x/tools/go/analysis/passes/defers"
+	"golang.org/x/tools/go/analysis/passes/deprecated"
 	"golang.org/x/tools/go/analysis/passes/directive"
 	"golang.org/x/tools/go/analysis/passes/errorsas"
 	"golang.org/x/tools/go/analysis/passes/framepointer"
@@ -45,13 +46,14 @@ import (
 	"golang.org/x/tools/go/analysis/passes/testinggoroutine"
 	"golang.org/x/tools/go/analysis/passes/tests"
 	"golang.org/x/tools/go/analysis/passes/timeformat"
+	"golang.org/x/tools/go/analysis/passes/uncheckederror"
 	"golang.org/x/tools/go/analysis/passes/unmarshal"
 	"golang.org/x/tools/go/analysis/passes/unreachable"
 	"golang.org/x/tools/go/analysis/passes/unsafeptr"
+	"golang.org/x/tools/go/analysis/passes/unusedparams"
 	"golang.org/x/tools/go/analysis/passes/unusedresult"
 	"golang.org/x/tools/go/analysis/passes/unusedwrite"
 	"golang.org/x/tools/go/analysis/passes/waitgroup"
-	"golang.org/x/tools/gopls/internal/analysis/deprecated"
 	"golang.org/x/tools/gopls/internal/analysis/embeddirective"
 	"golang.org/x/tools/gopls/internal/analysis/fillreturns"
 	"golang.org/x/tools/gopls/internal/analysis/infertypeargs"
@@ -63,7 +65,6 @@ import (
 	"golang.org/x/tools/gopls/internal/analysis/simplifyrange"
 	"golang.org/x/tools/gopls/internal/analysis/simplifyslice"
 	"golang.org/x/tools/gopls/internal/analysis/unusedfunc"
-	"golang.org/x/tools/gopls/internal/analysis/unusedparams"
 	"golang.org/x/tools/gopls/internal/analysis/unusedvariable"
 	"golang.org/x/tools/gopls/internal/analysis/yield"
 	"golang.org/x/tools/gopls/internal/protocol"
@@ -146,6 +147,36 @@ func (a *Analyzer) Severity() protocol.DiagnosticSeverity {
 	return a.severity
 }
 
+// CategorySeverity returns the severity of the diagnostics of this
+// analyzer in the specified category, as configured by the options,
+// and reports whether they should be reported at all.
+//
+// Only the categories of the shadow analyzer are configurable.
+func (a *Analyzer) CategorySeverity(o *Options, category string) (protocol.DiagnosticSeverity, bool) {
+	if a.analyzer != shadow.Analyzer {
+		return a.Severity(), true
+	}
+	c := ShadowCategory(category)
+	switch c {
+	case ShadowNamedReturn, ShadowContext, ShadowImport:
+	default:
+		c = ShadowOther // uncategorized
+	}
+	switch o.ShadowSeverity[c] {
+	case "error":
+		return protocol.SeverityError, true
+	case "warning":
+		return protocol.SeverityWarning, true
+	case "info":
+		return protocol.SeverityInformation, true
+	case "hint":
+		return protocol.SeverityHint, true
+	case "off":
+		return 0, false
+	}
+	return a.Severity(), true
+}
+
 // Tags is extra tags (unnecessary, deprecated, etc) for diagnostics
 // reported by this analyzer.
 func (a *Analyzer) Tags() []protocol.DiagnosticTag { return a.tags }
@@ -214,7 +245,13 @@ var DefaultAnalyzers = []*Analyzer{
 	{analyzer: recursiveiter.Analyzer}, // under evaluation
 
 	// disabled due to high false positives
-	{analyzer: shadow.Analyzer, severity: protocol.SeverityHint, nonDefault: true}, // very noisy
+	{
+		analyzer:    shadow.Analyzer,
+		actionKinds: []protocol.CodeActionKind{protocol.QuickFix},
+		severity:    protocol.SeverityHint,
+		nonDefault:  true, // very noisy
+	},
+	{analyzer: uncheckederror.Analyzer, nonDefault: true}, // noisy on existing code
 	// fieldalignment is not even off-by-default; see #67762.
 
 	// simplifiers and modernizers
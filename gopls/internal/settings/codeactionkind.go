@@ -109,6 +109,7 @@ const (
 	RefactorExtractConstant    protocol.CodeActionKind = "refactor.extract.constant"
 	RefactorExtractConstantAll protocol.CodeActionKind = "refactor.extract.constant-all"
 	RefactorExtractFunction    protocol.CodeActionKind = "refactor.extract.function"
+	RefactorExtractInterface   protocol.CodeActionKind = "refactor.extract.interface"
 	RefactorExtractMethod      protocol.CodeActionKind = "refactor.extract.method"
 	RefactorExtractVariable    protocol.CodeActionKind = "refactor.extract.variable"
 	RefactorExtractVariableAll protocol.CodeActionKind = "refactor.extract.variable-all"
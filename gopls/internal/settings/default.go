@@ -67,6 +67,7 @@ func DefaultOptions(overrides ...func(*Options)) *Options {
 						RefactorExtractConstant:          true,
 						RefactorExtractConstantAll:       true,
 						RefactorExtractFunction:          true,
+						RefactorExtractInterface:         true,
 						RefactorExtractMethod:            true,
 						RefactorExtractVariable:          true,
 						RefactorExtractVariableAll:       true,
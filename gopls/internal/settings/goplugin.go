@@ -0,0 +1,36 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build (linux || darwin || freebsd) && cgo
+
+package settings
+
+import (
+	"fmt"
+	goplugin "plugin"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// openGoPlugin opens the Go plugin at the specified path, and returns
+// the analyzers of its exported Analyzers variable, of type
+// []*analysis.Analyzer.
+//
+// The plugin must have been built with the same version of Go and of
+// all packages that it has in common with gopls.
+func openGoPlugin(path string) ([]*analysis.Analyzer, error) {
+	p, err := goplugin.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	sym, err := p.Lookup("Analyzers")
+	if err != nil {
+		return nil, err
+	}
+	analyzers, ok := sym.(*[]*analysis.Analyzer)
+	if !ok {
+		return nil, fmt.Errorf("Analyzers has type %T, want []*analysis.Analyzer", sym)
+	}
+	return *analyzers, nil
+}
@@ -0,0 +1,17 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !((linux || darwin || freebsd) && cgo)
+
+package settings
+
+import (
+	"errors"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+func openGoPlugin(path string) ([]*analysis.Analyzer, error) {
+	return nil, errors.New("Go plugins are not supported by this build of gopls")
+}
@@ -0,0 +1,103 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package settings
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/plugin"
+)
+
+// LoadAnalyzerPlugins returns the analyzers provided by the specified
+// analyzer plugins (see the AnalyzerPlugins option), in order, along
+// with an error for each plugin that could not be loaded.
+//
+// Each plugin is loaded at most once for each version of its file,
+// so it is cheap to call LoadAnalyzerPlugins repeatedly.
+func LoadAnalyzerPlugins(paths []string) ([]*Analyzer, []error) {
+	var (
+		analyzers []*Analyzer
+		errs      []error
+		names     = make(map[string]bool) // analyzers are identified by name
+	)
+	for _, a := range AllAnalyzers {
+		names[a.analyzer.Name] = true
+	}
+	for _, path := range paths {
+		as, err := loadAnalyzerPlugin(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("loading analyzer plugin %s: %v", path, err))
+			continue
+		}
+		for _, a := range as {
+			if names[a.analyzer.Name] {
+				errs = append(errs, fmt.Errorf("analyzer plugin %s: duplicate analyzer name %q", path, a.analyzer.Name))
+				continue
+			}
+			names[a.analyzer.Name] = true
+			analyzers = append(analyzers, a)
+		}
+	}
+	return analyzers, errs
+}
+
+// A pluginKey identifies a version of a plugin file.
+type pluginKey struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+type pluginResult struct {
+	analyzers []*Analyzer
+	err       error
+}
+
+var (
+	pluginsMu sync.Mutex
+	plugins   = make(map[pluginKey]*pluginResult)
+)
+
+func loadAnalyzerPlugin(path string) ([]*Analyzer, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	key := pluginKey{path, info.Size(), info.ModTime()}
+
+	pluginsMu.Lock()
+	defer pluginsMu.Unlock()
+	res, ok := plugins[key]
+	if !ok {
+		res = new(pluginResult)
+		var as []*analysis.Analyzer
+		if strings.HasSuffix(path, ".so") {
+			as, res.err = openGoPlugin(path)
+		} else {
+			as, res.err = plugin.Load(path)
+			// The executable may be replaced at any time,
+			// so record its version in its analyzers for the
+			// benefit of the analysis cache.
+			for _, a := range as {
+				a.Version = fmt.Sprintf("%s@%d.%d", path, key.size, key.modTime.UnixNano())
+			}
+		}
+		if res.err == nil {
+			res.err = analysis.Validate(as)
+		}
+		if res.err == nil {
+			for _, a := range as {
+				res.analyzers = append(res.analyzers, &Analyzer{analyzer: a})
+			}
+		}
+		plugins[key] = res
+	}
+	return res.analyzers, res.err
+}
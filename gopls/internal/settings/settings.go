@@ -36,6 +36,27 @@ const (
 	Bounds Annotation = "bounds"
 )
 
+// A ShadowCategory is a category of the diagnostics of the shadow
+// analyzer.
+type ShadowCategory string
+
+const (
+	// ShadowNamedReturn is the category of variables that shadow a
+	// named result of the function.
+	ShadowNamedReturn ShadowCategory = "named-return"
+
+	// ShadowContext is the category of variables that shadow a
+	// context.Context.
+	ShadowContext ShadowCategory = "context"
+
+	// ShadowImport is the category of variables that shadow an
+	// imported package.
+	ShadowImport ShadowCategory = "import"
+
+	// ShadowOther is the category of all other shadowing variables.
+	ShadowOther ShadowCategory = "other"
+)
+
 // Options holds various configuration that affects Gopls execution, organized
 // by the nature or origin of the settings.
 //
@@ -456,6 +477,23 @@ type FormattingOptions struct {
 	// existing imports.
 	Local string
 
+	// ImportGroups is the equivalent of the `goimports -groups` flag.
+	// If non-empty, it specifies the order of the groups into which
+	// imports are sorted, overriding Local. Each group is a
+	// space-separated list of patterns: "std", which matches standard
+	// library packages; "external", which matches packages that no
+	// other pattern matches; "module", which matches the packages of
+	// the file's own module; or an import path prefix, which matches
+	// the packages beneath it. An import belongs to the group of its
+	// longest matching prefix, if any.
+	//
+	// For example, `["std", "external", "github.com/myorg", "module"]`
+	// places imports of packages of the organization after third-party
+	// imports, and those of the current module last.
+	//
+	// Like Local, it is used when tidying or inserting imports.
+	ImportGroups []string `status:"experimental"`
+
 	// Gofumpt indicates if we should run gofumpt formatting.
 	Gofumpt bool
 }
@@ -496,6 +534,28 @@ type DiagnosticOptions struct {
 	Staticcheck         bool `status:"experimental"`
 	StaticcheckProvided bool `status:"experimental"` // = "staticcheck" was explicitly provided
 
+	// AnalyzerPlugins specifies additional analyzers, provided by
+	// plugins, to run alongside those of gopls. Each element is the
+	// absolute path of either an executable that speaks the protocol
+	// of the [golang.org/x/tools/go/analysis/plugin] package, or, if
+	// it ends in ".so", a Go plugin whose exported Analyzers variable
+	// is a []*analysis.Analyzer. (A Go plugin must be built with
+	// exactly the same version of Go and of its dependencies as gopls.)
+	//
+	// Plugin analyzers are enabled by default, and can be disabled by
+	// name using the `analyses` setting. Their diagnostics and
+	// suggested fixes are presented like those of gopls' analyzers.
+	// Analysis facts are not available to executable plugins.
+	//
+	// Example Usage:
+	//
+	// ```json5
+	// ...
+	// "analyzerPlugins": ["/usr/local/bin/myvet"]
+	// ...
+	// ```
+	AnalyzerPlugins []string `status:"experimental"`
+
 	// Annotations specifies the various kinds of compiler
 	// optimization details that should be reported as diagnostics
 	// when enabled for a package by the "Toggle compiler
@@ -509,6 +569,25 @@ type DiagnosticOptions struct {
 	// TODO(adonovan): rename this field to CompilerOptDetail.
 	Annotations map[Annotation]bool
 
+	// ShadowSeverity configures the severity of each category of
+	// diagnostics of the shadow analyzer, when it is enabled by the
+	// "analyses" setting. The severity is one of "error", "warning",
+	// "info", "hint", or "off", which suppresses the diagnostics of the
+	// category. Categories that are not mentioned have the severity of
+	// the analyzer, "hint".
+	//
+	// Example Usage:
+	//
+	// ```json5
+	// ...
+	// "shadowSeverity": {
+	//   "named-return": "warning", // Shadowing of results is likely a bug.
+	//   "other": "off"             // Report only the categories above.
+	// }
+	// ...
+	// ```
+	ShadowSeverity map[ShadowCategory]string `status:"experimental"`
+
 	// Vulncheck enables vulnerability scanning.
 	Vulncheck VulncheckMode `status:"experimental"`
 
@@ -615,6 +694,26 @@ const (
 	// functions such as `fmt.Println` are excluded from the
 	// check.
 	IgnoredError InlayHint = "ignoredError"
+
+	// CapturedVariables inlay hints for the variables captured by function literals:
+	// ```go
+	// 	for i := 0; i < n; i++ {
+	// 		go func() /* captures &wg, &i (shared loop var)*/ {
+	// 			defer wg.Done()
+	// 			use(i)
+	// 		}()
+	// 	}
+	// ```
+	// This check annotates each function literal with the local
+	// variables declared outside it that it refers to. A closure
+	// captures variables by reference, so it observes, and may
+	// make, later changes to them, which is a common source of
+	// bugs when the closure runs concurrently, as in a goroutine.
+	//
+	// In files whose Go version is before go1.22, in which each
+	// loop variable is shared by all iterations of the loop, the
+	// captured loop variables are additionally marked.
+	CapturedVariables InlayHint = "capturedVariables"
 )
 
 type NavigationOptions struct {
@@ -643,6 +742,26 @@ type NavigationOptions struct {
 	// packages. When the scope is "all", gopls searches all loaded packages,
 	// including dependencies and the standard library.
 	SymbolScope SymbolScope
+
+	// SymbolRanking adjusts the ranking of workspace/symbol results.
+	// Each entry is a positive factor by which the score of a
+	// matching symbol is multiplied when the symbol has the specified
+	// property; factors greater than 1 promote symbols, and factors
+	// less than 1 demote them. Properties that are not mentioned have
+	// a factor of 1.
+	//
+	// Example Usage:
+	//
+	// ```json5
+	// ...
+	// "symbolRanking": {
+	//   "packageMatch": 2,   // Prefer symbols of the package named in the query.
+	//   "vendor": 0.5,       // Demote vendored packages.
+	//   "recentlyEdited": 1.5
+	// }
+	// ...
+	// ```
+	SymbolRanking map[SymbolRankingFactor]float64 `status:"advanced"`
 }
 
 // UserOptions holds custom Gopls configuration (not part of the LSP) that is
@@ -882,6 +1001,27 @@ const (
 	AllSymbolScope SymbolScope = "all"
 )
 
+// A SymbolRankingFactor is a property of a symbol that affects its
+// ranking in workspace/symbol results.
+type SymbolRankingFactor string
+
+const (
+	// SymbolPackageMatch is the property of symbols whose package name
+	// or path is exactly the package qualifier of the query, such as
+	// "http" in "http.Server".
+	SymbolPackageMatch SymbolRankingFactor = "packageMatch"
+
+	// SymbolVendor is the property of symbols in vendored packages.
+	SymbolVendor SymbolRankingFactor = "vendor"
+
+	// SymbolInternal is the property of symbols in internal packages.
+	SymbolInternal SymbolRankingFactor = "internal"
+
+	// SymbolRecentlyEdited is the property of symbols in files that
+	// were opened or edited in the editor within the last ten minutes.
+	SymbolRecentlyEdited SymbolRankingFactor = "recentlyEdited"
+)
+
 type HoverKind string
 
 const (
@@ -1169,6 +1309,9 @@ func (o *Options) setOne(name string, value any) (applied []CounterPath, _ error
 			WorkspaceSymbolScope,
 			AllSymbolScope)
 
+	case "symbolRanking":
+		return setSymbolRankingMap(&o.SymbolRanking, value)
+
 	case "hoverKind":
 		// TODO(rfindley): reinstate the deprecation of Structured hover by making
 		// it a warning in gopls v0.N+1, and removing it in gopls v0.N+2.
@@ -1218,6 +1361,22 @@ func (o *Options) setOne(name string, value any) (applied []CounterPath, _ error
 	case "annotations":
 		return setAnnotationMap(&o.Annotations, value)
 
+	case "shadowSeverity":
+		return setShadowSeverityMap(&o.ShadowSeverity, value)
+
+	case "analyzerPlugins":
+		paths, err := asStringSlice(value)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range paths {
+			if !filepath.IsAbs(path) {
+				return nil, fmt.Errorf("analyzer plugin path %q is not absolute", path)
+			}
+		}
+		o.AnalyzerPlugins = paths
+		return nil, nil
+
 	case "vulncheck":
 		return setEnum(&o.Vulncheck, value,
 			ModeVulncheckOff,
@@ -1251,6 +1410,19 @@ func (o *Options) setOne(name string, value any) (applied []CounterPath, _ error
 	case "local":
 		return nil, setString(&o.Local, value)
 
+	case "importGroups":
+		groups, err := asStringSlice(value)
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range groups {
+			if len(strings.Fields(group)) == 0 {
+				return nil, fmt.Errorf("empty import group")
+			}
+		}
+		o.ImportGroups = groups
+		return nil, nil
+
 	case "verboseOutput":
 		return setBool(&o.VerboseOutput, value)
 
@@ -1564,6 +1736,60 @@ func setAnnotationMap(dest *map[Annotation]bool, value any) ([]CounterPath, erro
 	return counters, nil
 }
 
+func setShadowSeverityMap(dest *map[ShadowCategory]string, value any) ([]CounterPath, error) {
+	all, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid type %T (want JSON object)", value)
+	}
+	m := make(map[ShadowCategory]string)
+	var counts []CounterPath
+	for k, v := range all {
+		category, err := asEnum(k,
+			ShadowNamedReturn,
+			ShadowContext,
+			ShadowImport,
+			ShadowOther)
+		if err != nil {
+			return nil, err
+		}
+		severity, err := asEnum(v, "error", "warning", "info", "hint", "off")
+		if err != nil {
+			return nil, fmt.Errorf("object field %q: %v", k, err)
+		}
+		m[category] = severity
+		counts = append(counts, CounterPath{string(category), severity})
+	}
+	*dest = m
+	return counts, nil
+}
+
+func setSymbolRankingMap(dest *map[SymbolRankingFactor]float64, value any) ([]CounterPath, error) {
+	all, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid type %T (want JSON object)", value)
+	}
+	m := make(map[SymbolRankingFactor]float64)
+	var counts []CounterPath
+	for k, v := range all {
+		factor, err := asEnum(k,
+			SymbolPackageMatch,
+			SymbolVendor,
+			SymbolInternal,
+			SymbolRecentlyEdited)
+		if err != nil {
+			return nil, err
+		}
+		f, ok := v.(float64)
+		if !ok || f <= 0 {
+			return nil, fmt.Errorf("invalid value %v for object field %q (want positive number)", v, k)
+		}
+		m[factor] = f
+		counts = append(counts, CounterPath{string(factor)})
+	}
+	*dest = m
+	return counts, nil
+}
+
 func setBoolMap[K ~string](dest *map[K]bool, value any) ([]CounterPath, error) {
 	m, err := asBoolMap[K](value)
 	if err != nil {
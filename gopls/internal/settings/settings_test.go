@@ -191,6 +191,76 @@ func TestOptions_Set(t *testing.T) {
 				return !o.Annotations[Nil] && !o.Annotations[Bounds]
 			},
 		},
+		{
+			name: "shadowSeverity",
+			value: map[string]any{
+				"named-return": "Warning",
+				"other":        "off",
+			},
+			check: func(o Options) bool {
+				return o.ShadowSeverity[ShadowNamedReturn] == "warning" && o.ShadowSeverity[ShadowOther] == "off"
+			},
+		},
+		{
+			name: "shadowSeverity",
+			value: map[string]any{
+				"context": "loud",
+			},
+			wantError: true,
+			check: func(o Options) bool {
+				return len(o.ShadowSeverity) == 0
+			},
+		},
+		{
+			name:  "analyzerPlugins",
+			value: []any{"/usr/local/bin/myvet"},
+			check: func(o Options) bool {
+				return len(o.AnalyzerPlugins) == 1 && o.AnalyzerPlugins[0] == "/usr/local/bin/myvet"
+			},
+		},
+		{
+			name:      "analyzerPlugins",
+			value:     []any{"myvet"},
+			wantError: true,
+			check: func(o Options) bool {
+				return len(o.AnalyzerPlugins) == 0
+			},
+		},
+		{
+			name: "symbolRanking",
+			value: map[string]any{
+				"packageMatch": 2.0,
+				"vendor":       0.5,
+			},
+			check: func(o Options) bool {
+				return o.SymbolRanking[SymbolPackageMatch] == 2 && o.SymbolRanking[SymbolVendor] == 0.5
+			},
+		},
+		{
+			name: "symbolRanking",
+			value: map[string]any{
+				"internal": -1.0,
+			},
+			wantError: true,
+			check: func(o Options) bool {
+				return len(o.SymbolRanking) == 0
+			},
+		},
+		{
+			name:  "importGroups",
+			value: []any{"std", "external", "example.com/org module"},
+			check: func(o Options) bool {
+				return len(o.ImportGroups) == 3 && o.ImportGroups[2] == "example.com/org module"
+			},
+		},
+		{
+			name:      "importGroups",
+			value:     []any{"std", " "},
+			wantError: true,
+			check: func(o Options) bool {
+				return len(o.ImportGroups) == 0
+			},
+		},
 		{
 			name:      "vulncheck",
 			value:     []any{"invalid"},
@@ -27,10 +27,10 @@ import (
 	"golang.org/x/tools/gopls/internal/util/bug"
 	"golang.org/x/tools/gopls/internal/util/fakenet"
 	"golang.org/x/tools/internal/event"
-	"golang.org/x/tools/internal/jsonrpc2"
 	"golang.org/x/tools/internal/jsonrpc2/servertest"
 	"golang.org/x/tools/internal/pprof"
 	"golang.org/x/tools/internal/tool"
+	"golang.org/x/tools/jsonrpc2"
 )
 
 var (
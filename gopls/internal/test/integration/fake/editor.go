@@ -26,9 +26,9 @@ import (
 	"golang.org/x/tools/gopls/internal/test/integration/fake/glob"
 	"golang.org/x/tools/gopls/internal/util/bug"
 	"golang.org/x/tools/gopls/internal/util/pathutil"
-	"golang.org/x/tools/internal/jsonrpc2"
 	"golang.org/x/tools/internal/jsonrpc2/servertest"
 	"golang.org/x/tools/internal/xcontext"
+	"golang.org/x/tools/jsonrpc2"
 )
 
 // Editor is a fake client editor.  It keeps track of client state and can be
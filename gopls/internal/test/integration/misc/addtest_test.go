@@ -104,13 +104,15 @@ func TestFoo(t *testing.T) {
 				t.Errorf("gopls.add_test: got showDocument requests for %v, want %v", got[0].URI, want)
 			}
 
-			// Pointing to the line of test function declaration.
+			// Selecting the placeholder for the first test case.
 			if want := (protocol.Range{
 				Start: protocol.Position{
-					Line: 11,
+					Line:      18,
+					Character: 2,
 				},
 				End: protocol.Position{
-					Line: 11,
+					Line:      18,
+					Character: 26,
 				},
 			}); *got[0].Selection != want {
 				t.Errorf("gopls.add_test: got showDocument requests selection for %v, want %v", *got[0].Selection, want)
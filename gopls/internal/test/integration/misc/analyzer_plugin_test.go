@@ -0,0 +1,99 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/passes/findcall"
+	"golang.org/x/tools/go/analysis/plugin"
+	"golang.org/x/tools/gopls/internal/protocol"
+	. "golang.org/x/tools/gopls/internal/test/integration"
+)
+
+// analyzerPluginEnvvar, if set to "true", causes the test executable
+// to act as the analyzer plugin used by TestAnalyzerPlugin.
+const analyzerPluginEnvvar = "_GOPLS_TEST_ANALYZER_PLUGIN"
+
+func runAnalyzerPlugin() {
+	findcall.Analyzer.Flags.Set("name", "Println")
+	plugin.Main(findcall.Analyzer)
+	panic("unreachable")
+}
+
+func TestAnalyzerPlugin(t *testing.T) {
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" {
+		t.Skipf("skipping test that needs a shell script on %s", runtime.GOOS)
+	}
+
+	// The plugin is this test executable, run by a script
+	// that sets the environment variable that makes it a plugin
+	// (but not a gopls process forwarded to by the test).
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	script := filepath.Join(t.TempDir(), "findcall")
+	content := fmt.Sprintf("#!/bin/sh\n%s=true exec %q \"$@\"\n", analyzerPluginEnvvar, exe)
+	if err := os.WriteFile(script, []byte(content), 0777); err != nil {
+		t.Fatal(err)
+	}
+
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.21
+-- a.go --
+package a
+
+import "fmt"
+
+func _() {
+	fmt.Println()
+}
+`
+	WithOptions(
+		Settings{"analyzerPlugins": []any{script}},
+	).Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a.go")
+		var d protocol.PublishDiagnosticsParams
+		env.AfterChange(
+			Diagnostics(env.AtRegexp("a.go", `Println(\()`), WithMessage("call of Println(...)")),
+			ReadDiagnostics("a.go", &d),
+		)
+		if got := d.Diagnostics[0].Source; got != "findcall" {
+			t.Errorf("diagnostic source is %q, want findcall", got)
+		}
+
+		// The plugin analyzes the unsaved contents of the buffer,
+		// and its suggested fix is offered as a quick fix.
+		env.RegexpReplace("a.go", `fmt.Println\(\)`, "\n\tfmt.Println()")
+		env.AfterChange(
+			Diagnostics(env.AtRegexp("a.go", `Println(\()`), WithMessage("call of Println(...)")),
+			ReadDiagnostics("a.go", &d),
+		)
+		env.ApplyQuickFixes("a.go", d.Diagnostics)
+		if got, want := env.BufferText("a.go"), "fmt.Println_TEST_()"; !strings.Contains(got, want) {
+			t.Errorf("buffer does not contain %q after applying fix:\n%s", want, got)
+		}
+	})
+
+	// A plugin analyzer may be disabled by name.
+	WithOptions(
+		Settings{
+			"analyzerPlugins": []any{script},
+			"analyses":        map[string]any{"findcall": false},
+		},
+	).Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("a.go")
+		env.AfterChange(NoDiagnostics(ForFile("a.go")))
+	})
+}
@@ -5,9 +5,13 @@
 package misc
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/protocol/command"
 	. "golang.org/x/tools/gopls/internal/test/integration"
 )
 
@@ -34,3 +38,70 @@ package pkg
 		env.Editor.Server.PrepareCallHierarchy(env.Ctx, &params)
 	})
 }
+
+func TestCallGraph(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.21
+-- p.go --
+package p
+
+func A() { B() }
+
+func B() { C(); C() }
+
+func C() { D() }
+
+func D() {}
+
+func E() { B() }
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("p.go")
+		callGraph := func(dir command.CallGraphDirection, depth int) command.CallGraphResult {
+			cmd := command.NewCallGraphCommand("", command.CallGraphArgs{
+				Location:  env.RegexpSearch("p.go", "func (B)"),
+				Direction: dir,
+				Depth:     depth,
+				Format:    "dot",
+			})
+			var result command.CallGraphResult
+			env.ExecuteCommand(&protocol.ExecuteCommandParams{
+				Command:   cmd.Command,
+				Arguments: cmd.Arguments,
+			}, &result)
+			return result
+		}
+		// graph summarizes a call graph as a list of "caller -> callee (calls)".
+		graph := func(result command.CallGraphResult) []string {
+			var edges []string
+			for _, e := range result.Edges {
+				edges = append(edges, fmt.Sprintf("%s -> %s (%d)", result.Nodes[e.From].Name, result.Nodes[e.To].Name, len(e.Calls)))
+			}
+			return edges
+		}
+
+		for _, test := range []struct {
+			dir   command.CallGraphDirection
+			depth int
+			want  []string
+		}{
+			{"", 0, []string{"B -> C (2)", "A -> B (1)", "E -> B (1)"}},
+			{command.CallGraphOutgoing, 2, []string{"B -> C (2)", "C -> D (1)"}},
+			{command.CallGraphIncoming, 5, []string{"A -> B (1)", "E -> B (1)"}},
+		} {
+			result := callGraph(test.dir, test.depth)
+			if result.Nodes[0].Name != "B" {
+				t.Errorf("CallGraph(%q, %d): root is %s, want B", test.dir, test.depth, result.Nodes[0].Name)
+			}
+			if diff := cmp.Diff(test.want, graph(result)); diff != "" {
+				t.Errorf("CallGraph(%q, %d): unexpected edges (-want +got):\n%s", test.dir, test.depth, diff)
+			}
+			if !strings.Contains(result.DOT, "n0 [label=\"B\"") {
+				t.Errorf("CallGraph(%q, %d): unexpected DOT:\n%s", test.dir, test.depth, result.DOT)
+			}
+		}
+	})
+}
@@ -0,0 +1,133 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"testing"
+
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/protocol/command"
+	"golang.org/x/tools/gopls/internal/settings"
+	"golang.org/x/tools/gopls/internal/test/compare"
+	. "golang.org/x/tools/gopls/internal/test/integration"
+)
+
+const extractInterfaceFiles = `
+-- go.mod --
+module mod.com
+
+go 1.21
+-- store/store.go --
+package store
+
+import "io"
+
+type Store struct{}
+
+// Get returns the value of key.
+func (s *Store) Get(key string) ([]byte, error) { return nil, nil }
+
+func (s *Store) Put(key string, value io.Reader) error { return nil }
+
+func (s *Store) reset() {}
+-- use/use.go --
+package use
+
+import "mod.com/store"
+
+func Use(s *store.Store) {}
+-- api/api.go --
+package api
+`
+
+func TestExtractInterface(t *testing.T) {
+	Run(t, extractInterfaceFiles, func(t *testing.T, env *Env) {
+		env.OpenFile("store/store.go")
+		loc := env.RegexpSearch("store/store.go", `type (Store)`)
+		actions, err := env.Editor.CodeAction(env.Ctx, loc, nil, protocol.CodeActionUnknownTrigger)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var extract *protocol.CodeAction
+		for _, action := range actions {
+			if action.Kind == settings.RefactorExtractInterface {
+				extract = &action
+				break
+			}
+		}
+		if extract == nil {
+			t.Fatal("could not find extract interface action")
+		}
+		if want := "Extract interface from Store (Get, Put)"; extract.Title != want {
+			t.Errorf("title = %q, want %q", extract.Title, want)
+		}
+		env.ApplyCodeAction(*extract)
+		want := `package store
+
+import "io"
+
+type Store struct{}
+
+// StoreInterface is the interface of Store.
+type StoreInterface interface {
+	// Get returns the value of key.
+	Get(key string) ([]byte, error)
+	Put(key string, value io.Reader) error
+}
+
+// Get returns the value of key.
+func (s *Store) Get(key string) ([]byte, error) { return nil, nil }
+
+func (s *Store) Put(key string, value io.Reader) error { return nil }
+
+func (s *Store) reset() {}
+`
+		if got := env.BufferText("store/store.go"); got != want {
+			t.Errorf("extract interface: unexpected result (-want +got):\n%s", compare.Text(want, got))
+		}
+	})
+}
+
+func TestExtractInterfaceToPackage(t *testing.T) {
+	Run(t, extractInterfaceFiles, func(t *testing.T, env *Env) {
+		env.OpenFile("store/store.go")
+		env.OpenFile("use/use.go")
+		env.OpenFile("api/api.go")
+		args := command.ExtractInterfaceArgs{
+			Location: env.RegexpSearch("store/store.go", `type (Store)`),
+			Name:     "Getter",
+			Methods:  []string{"Get"},
+			Dir:      env.Sandbox.Workdir.URI("api"),
+			Rewrites: []protocol.Location{env.RegexpSearch("use/use.go", `\*store.Store`)},
+		}
+		cmd := command.NewExtractInterfaceCommand("", args)
+		env.ExecuteCommand(&protocol.ExecuteCommandParams{
+			Command:   cmd.Command,
+			Arguments: cmd.Arguments,
+		}, nil)
+		env.AfterChange()
+
+		wantAPI := `package api
+
+// Getter is the interface of store.Store.
+type Getter interface {
+	// Get returns the value of key.
+	Get(key string) ([]byte, error)
+}
+`
+		if got := env.BufferText("api/api.go"); got != wantAPI {
+			t.Errorf("api/api.go: unexpected content (-want +got):\n%s", compare.Text(wantAPI, got))
+		}
+		wantUse := `package use
+
+import "mod.com/api"
+
+func Use(s api.Getter) {}
+`
+		if got := env.BufferText("use/use.go"); got != wantUse {
+			t.Errorf("use/use.go: unexpected content (-want +got):\n%s", compare.Text(wantUse, got))
+		}
+	})
+}
@@ -760,3 +760,80 @@ func Bar() {}
 		}
 	})
 }
+
+func TestImportGroups(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.19
+
+require (
+	example.com/org/b v0.0.0
+	other.com/c v0.0.0
+)
+
+replace (
+	example.com/org/b => ./b
+	other.com/c => ./c
+)
+-- b/go.mod --
+module example.com/org/b
+
+go 1.19
+-- b/b.go --
+package b
+
+const B = 1
+-- c/go.mod --
+module other.com/c
+
+go 1.19
+-- c/c.go --
+package c
+
+const C = 1
+-- a/a.go --
+package a
+
+const A = 1
+-- main.go --
+package main
+
+import (
+	"example.com/org/b"
+	"fmt"
+	"mod.com/a"
+	"other.com/c"
+)
+
+func main() {
+	fmt.Println(a.A, b.B, c.C)
+}
+`
+	const want = `package main
+
+import (
+	"fmt"
+
+	"other.com/c"
+
+	"example.com/org/b"
+
+	"mod.com/a"
+)
+
+func main() {
+	fmt.Println(a.A, b.B, c.C)
+}
+`
+	WithOptions(
+		Settings{"importGroups": []string{"std", "external", "example.com/org", "module"}},
+	).Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("main.go")
+		env.OrganizeImports("main.go")
+		if got := env.BufferText("main.go"); got != want {
+			t.Errorf("OrganizeImports: got\n%s\nwant\n%s", got, want)
+		}
+	})
+}
@@ -16,6 +16,11 @@ import (
 )
 
 func TestMain(m *testing.M) {
+	// Provide an entrypoint for the analyzer plugin of TestAnalyzerPlugin.
+	if os.Getenv(analyzerPluginEnvvar) == "true" {
+		runAnalyzerPlugin()
+	}
+
 	bug.PanicOnBugs = true
 	tmp, err := os.MkdirTemp("", "gopls-misc-test-counters")
 	if err != nil {
@@ -0,0 +1,152 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package misc
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/gopls/internal/protocol"
+	"golang.org/x/tools/gopls/internal/protocol/command"
+	"golang.org/x/tools/gopls/internal/test/compare"
+	. "golang.org/x/tools/gopls/internal/test/integration"
+)
+
+func movePackage(env *Env, file, newDir string, force bool) command.MovePackageResult {
+	cmd := command.NewMovePackageCommand("", command.MovePackageArgs{
+		URI:    env.Sandbox.Workdir.URI(file),
+		NewDir: env.Sandbox.Workdir.URI(newDir),
+		Force:  force,
+	})
+	var result command.MovePackageResult
+	env.ExecuteCommand(&protocol.ExecuteCommandParams{
+		Command:   cmd.Command,
+		Arguments: cmd.Arguments,
+	}, &result)
+	env.AfterChange()
+	return result
+}
+
+func TestMovePackage(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.21
+
+replace example.com/nested => ./a/lib/nested
+-- main.go --
+package main
+
+import (
+	"mod.com/a/lib"
+	"mod.com/a/lib/sub"
+)
+
+func main() {
+	lib.F()
+	sub.G()
+}
+-- a/lib/lib.go --
+package lib
+
+import "mod.com/a/lib/sub"
+
+func F() { sub.G() }
+-- a/lib/sub/sub.go --
+package sub
+
+func G() {}
+-- a/lib/nested/go.mod --
+module example.com/nested
+
+go 1.21
+-- a/lib/nested/nested.go --
+package nested
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("main.go")
+		env.OpenFile("a/lib/lib.go")
+		result := movePackage(env, "a/lib/lib.go", "a/util", false)
+		if !result.Moved || len(result.Violations) > 0 {
+			t.Fatalf("MovePackage returned %+v, want Moved and no violations", result)
+		}
+
+		for _, test := range []struct{ file, want string }{
+			{"main.go", `package main
+
+import (
+	"mod.com/a/util"
+	"mod.com/a/util/sub"
+)
+
+func main() {
+	lib.F()
+	sub.G()
+}
+`},
+			{"a/util/lib.go", `package lib
+
+import "mod.com/a/util/sub"
+
+func F() { sub.G() }
+`},
+		} {
+			if got := env.BufferText(test.file); got != test.want {
+				t.Errorf("%s: unexpected content (-want +got):\n%s", test.file, compare.Text(test.want, got))
+			}
+		}
+		if got := env.BufferText("go.mod"); !strings.Contains(got, "replace example.com/nested => ./a/util/nested") {
+			t.Errorf("go.mod replace directive not updated:\n%s", got)
+		}
+		env.AfterChange(NoDiagnostics())
+	})
+}
+
+func TestMovePackageInternal(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.21
+-- main.go --
+package main
+
+import "mod.com/b/sub/x"
+
+func main() { x.F() }
+-- b/sub/sub.go --
+package sub
+-- b/sub/x/x.go --
+package x
+
+func F() {}
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("main.go")
+		result := movePackage(env, "b/sub/sub.go", "b/internal", false)
+		if result.Moved {
+			t.Fatalf("MovePackage moved a package despite violations")
+		}
+		if len(result.Violations) != 1 {
+			t.Fatalf("got violations %+v, want 1", result.Violations)
+		}
+		v := result.Violations[0]
+		if v.Location.URI != env.Sandbox.Workdir.URI("main.go") || !strings.Contains(v.Message, "mod.com/b/internal/x") {
+			t.Errorf("unexpected violation %+v", v)
+		}
+		if got := env.BufferText("main.go"); !strings.Contains(got, `"mod.com/b/sub/x"`) {
+			t.Errorf("main.go was changed:\n%s", got)
+		}
+
+		result = movePackage(env, "b/sub/sub.go", "b/internal", true)
+		if !result.Moved || len(result.Violations) != 1 {
+			t.Fatalf("MovePackage with Force returned %+v, want Moved and 1 violation", result)
+		}
+		if got := env.BufferText("main.go"); !strings.Contains(got, `"mod.com/b/internal/x"`) {
+			t.Errorf("main.go was not updated:\n%s", got)
+		}
+	})
+}
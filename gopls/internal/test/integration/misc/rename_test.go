@@ -919,3 +919,87 @@ func checkTestdata(t *testing.T, env *Env) {
 		}
 	}
 }
+
+func TestRenameModule(t *testing.T) {
+	const files = `
+-- go.work --
+go 1.18
+use (
+	.
+	./foo/bar
+)
+
+-- go.mod --
+module mod.com
+
+go 1.18
+
+require mod.com/foo/bar v0.0.0
+
+replace mod.com/foo/bar => ./foo/bar
+-- foo/bar/go.mod --
+module mod.com/foo/bar
+
+go 1.18
+-- foo/bar/bar.go --
+package bar
+
+const Msg = "Hi from package bar"
+
+-- foo/bar/sub/sub.go --
+package sub
+
+import "mod.com/foo/bar"
+
+const Msg = bar.Msg
+
+-- main.go --
+package main
+
+import (
+	"fmt"
+
+	"mod.com/foo/bar"
+	"mod.com/foo/bar/sub"
+)
+
+func main() {
+	fmt.Println(bar.Msg, sub.Msg)
+}
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("foo/bar/go.mod")
+		env.Rename(env.RegexpSearch("foo/bar/go.mod", "mod.com/foo/bar"), "example.com/bar")
+
+		env.RegexpSearch("foo/bar/go.mod", "module example.com/bar")
+		env.RegexpSearch("foo/bar/sub/sub.go", `import "example.com/bar"`)
+		env.RegexpSearch("main.go", `"example.com/bar"`)
+		env.RegexpSearch("main.go", `"example.com/bar/sub"`)
+		env.RegexpSearch("go.mod", "require example.com/bar v0.0.0")
+		env.RegexpSearch("go.mod", "replace example.com/bar => ./foo/bar")
+
+		// The workspace builds once the changes are saved.
+		for _, name := range []string{"go.mod", "foo/bar/go.mod", "foo/bar/sub/sub.go", "main.go"} {
+			env.SaveBuffer(name)
+		}
+		env.AfterChange(NoDiagnostics())
+	})
+}
+
+func TestRenameModule_InvalidPath(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.18
+-- a/a.go --
+package a
+`
+	Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("go.mod")
+		loc := env.RegexpSearch("go.mod", "mod.com")
+		if err := env.Editor.Rename(env.Ctx, loc, "not a path"); err == nil || !strings.Contains(err.Error(), "invalid module path") {
+			t.Errorf("Rename returned error %v, want invalid module path", err)
+		}
+	})
+}
@@ -767,6 +767,10 @@ func TestRunVulncheckWarning(t *testing.T) {
 			}
 			for _, action := range gotActions {
 				if action.Title == want.applyAction {
+					// The fix edits the require directive, then tidies.
+					if action.Edit == nil || action.Command == nil || action.Command.Command != command.Tidy.String() {
+						t.Errorf("action %q: got edit %v, command %v, want go.mod edit followed by tidy", action.Title, action.Edit, action.Command)
+					}
 					env.ApplyCodeAction(action)
 					break
 				}
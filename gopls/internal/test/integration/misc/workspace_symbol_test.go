@@ -102,6 +102,45 @@ const (
 	})
 }
 
+func TestWorkspaceSymbolRanking(t *testing.T) {
+	const files = `
+-- go.mod --
+module mod.com
+
+go 1.17
+-- a/a.go --
+package a
+
+func Server() {}
+-- b/internal/c/c.go --
+package c
+
+func Serve() {}
+`
+
+	Run(t, files, func(t *testing.T, env *Env) {
+		checkSymbols(env, "Serve", "Serve", "Server")
+	})
+	WithOptions(
+		Settings{"symbolRanking": map[string]any{"internal": 0.1}},
+	).Run(t, files, func(t *testing.T, env *Env) {
+		checkSymbols(env, "Serve", "Server", "Serve")
+		checkSymbols(env, "c.Serve", "mod.com/a.Server", "c.Serve")
+	})
+	WithOptions(
+		Settings{"symbolRanking": map[string]any{"internal": 0.1, "packageMatch": 100}},
+	).Run(t, files, func(t *testing.T, env *Env) {
+		checkSymbols(env, "Serve", "Server", "Serve")
+		checkSymbols(env, "c.Serve", "c.Serve", "mod.com/a.Server")
+	})
+	WithOptions(
+		Settings{"symbolRanking": map[string]any{"internal": 0.1, "recentlyEdited": 100}},
+	).Run(t, files, func(t *testing.T, env *Env) {
+		env.OpenFile("b/internal/c/c.go")
+		checkSymbols(env, "Serve", "Serve", "Server")
+	})
+}
+
 func checkSymbols(env *Env, query string, want ...string) {
 	env.TB.Helper()
 	var got []string
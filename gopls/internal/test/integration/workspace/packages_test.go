@@ -493,6 +493,104 @@ func TestBaz(t *testing.T) {
 	})
 }
 
+func TestListTests(t *testing.T) {
+	const files = `
+-- go.mod --
+module foo
+
+-- foo.go --
+package foo
+
+-- foo_test.go --
+package foo
+
+import "testing"
+
+func TestFoo(t *testing.T) {
+	t.Run("Bar", func(t *testing.T) {
+		t.Run("Baz", func(t *testing.T) {})
+	})
+	t.Run("Quux", func(t *testing.T) {})
+}
+
+func BenchmarkFoo(b *testing.B) {}
+
+-- linux_test.go --
+//go:build linux && !race
+
+package foo
+
+import "testing"
+
+func FuzzFoo(f *testing.F) {}
+
+func ExampleFoo() {}
+`
+
+	Run(t, files, func(t *testing.T, env *Env) {
+		cmd := command.NewListTestsCommand("", command.ListTestsArgs{
+			Files: []protocol.DocumentURI{env.Editor.DocumentURI("")},
+		})
+		var result command.ListTestsResult
+		env.ExecuteCommand(&protocol.ExecuteCommandParams{
+			Command:   cmd.Command,
+			Arguments: cmd.Arguments,
+		}, &result)
+
+		// Clear the locations, after checking that of a subtest.
+		var clearLocs func(tests []command.TestNode)
+		clearLocs = func(tests []command.TestNode) {
+			for i := range tests {
+				test := &tests[i]
+				if test.Name == "TestFoo/Bar/Baz" {
+					env.OpenFile(test.Loc.URI.Path())
+					if got, want := env.FileContentAt(test.Loc), `t.Run("Baz", func(t *testing.T) {})`; got != want {
+						t.Errorf("location of %s: got %q, want %q", test.Name, got, want)
+					}
+				}
+				test.Loc = protocol.Location{}
+				clearLocs(test.Subtests)
+			}
+		}
+		for _, pkg := range result.Packages {
+			for _, file := range pkg.Files {
+				clearLocs(file.Tests)
+			}
+		}
+
+		want := []command.TestPackage{{
+			Path:       "foo",
+			ForTest:    "foo",
+			ModulePath: "foo",
+			Files: []command.TestSourceFile{
+				{
+					URI: env.Editor.DocumentURI("foo_test.go"),
+					Tests: []command.TestNode{
+						{Name: "TestFoo", Kind: command.KindTest, Subtests: []command.TestNode{
+							{Name: "TestFoo/Bar", Kind: command.KindTest, Subtests: []command.TestNode{
+								{Name: "TestFoo/Bar/Baz", Kind: command.KindTest},
+							}},
+							{Name: "TestFoo/Quux", Kind: command.KindTest},
+						}},
+						{Name: "BenchmarkFoo", Kind: command.KindBenchmark},
+					},
+				},
+				{
+					URI:             env.Editor.DocumentURI("linux_test.go"),
+					BuildConstraint: "linux && !race",
+					Tests: []command.TestNode{
+						{Name: "FuzzFoo", Kind: command.KindFuzz},
+						{Name: "ExampleFoo", Kind: command.KindExample},
+					},
+				},
+			},
+		}}
+		if diff := cmp.Diff(want, result.Packages); diff != "" {
+			t.Errorf("ListTests returned unexpected packages (-want +got):\n%s", diff)
+		}
+	})
+}
+
 func checkPackages(t testing.TB, env *Env, files []protocol.DocumentURI, recursive bool, mode command.PackagesMode, wantPkg []command.Package, wantModule map[string]command.Module, wantSource []string) {
 	t.Helper()
 
@@ -45,10 +45,10 @@ import (
 	"golang.org/x/tools/internal/diff"
 	"golang.org/x/tools/internal/diff/myers"
 	"golang.org/x/tools/internal/expect"
-	"golang.org/x/tools/internal/jsonrpc2"
 	"golang.org/x/tools/internal/jsonrpc2/servertest"
 	"golang.org/x/tools/internal/mcp"
 	"golang.org/x/tools/internal/testenv"
+	"golang.org/x/tools/jsonrpc2"
 	"golang.org/x/tools/txtar"
 )
 
@@ -9,9 +9,12 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"os/exec"
+	"slices"
 	"sort"
+	"sync"
 	"time"
 
 	"golang.org/x/sync/errgroup"
@@ -36,9 +39,15 @@ func Main(ctx context.Context, args ...string) error {
 // that runs 'gopls vulncheck' and converts the output to gopls's internal data
 // used for diagnostics and hover message construction.
 //
+// If report is non-nil, it is called with the partial result of the
+// scan each time the scan reports new findings, so that the findings
+// may be shown as the scan progresses. Findings tend to arrive in
+// bursts, so report is called once the findings have stopped
+// arriving for a short while, and never after RunGovulncheck returns.
+//
 // TODO(rfindley): this should accept a *View (which exposes) Options, rather
 // than a snapshot.
-func RunGovulncheck(ctx context.Context, pattern string, snapshot *cache.Snapshot, dir string, log io.Writer) (*vulncheck.Result, error) {
+func RunGovulncheck(ctx context.Context, pattern string, snapshot *cache.Snapshot, dir string, log io.Writer, report func(*vulncheck.Result)) (*vulncheck.Result, error) {
 	vulncheckargs := []string{
 		"vulncheck", "--",
 		"-json",
@@ -56,7 +65,8 @@ func RunGovulncheck(ctx context.Context, pattern string, snapshot *cache.Snapsho
 	// TODO: support -test.
 
 	ir, iw := io.Pipe()
-	handler := &govulncheckHandler{logger: log, osvs: map[string]*osv.Entry{}}
+	handler := &govulncheckHandler{logger: log, report: report, osvs: map[string]*osv.Entry{}}
+	defer handler.stop()
 
 	stderr := new(bytes.Buffer)
 	var g errgroup.Group
@@ -94,7 +104,33 @@ func RunGovulncheck(ctx context.Context, pattern string, snapshot *cache.Snapsho
 		return nil, fmt.Errorf("failed to read govulncheck output: %v: stderr:\n%s", err, stderr)
 	}
 
-	findings := handler.findings // sort so the findings in the result is deterministic.
+	handler.stop()
+	return handler.result(), nil
+}
+
+// reportDelay is the period without new findings after which
+// RunGovulncheck reports its partial result.
+const reportDelay = 200 * time.Millisecond
+
+type govulncheckHandler struct {
+	logger io.Writer               // forward progress reports to logger.
+	report func(*vulncheck.Result) // if non-nil, report partial results
+
+	reportMu sync.Mutex // held during calls to report
+
+	mu       sync.Mutex // guards the fields below
+	osvs     map[string]*osv.Entry
+	findings []*govulncheck.Finding
+	timer    *time.Timer // pending report of partial result, if any
+	stopped  bool        // no further reports
+}
+
+// result returns the result of the scan so far.
+func (h *govulncheckHandler) result() *vulncheck.Result {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	findings := slices.Clone(h.findings) // sort so the findings in the result is deterministic.
 	sort.Slice(findings, func(i, j int) bool {
 		x, y := findings[i], findings[j]
 		if x.OSV != y.OSV {
@@ -102,20 +138,38 @@ func RunGovulncheck(ctx context.Context, pattern string, snapshot *cache.Snapsho
 		}
 		return x.Trace[0].Package < y.Trace[0].Package
 	})
-	result := &vulncheck.Result{
+	return &vulncheck.Result{
 		Mode:     vulncheck.ModeGovulncheck,
 		AsOf:     time.Now(),
-		Entries:  handler.osvs,
+		Entries:  maps.Clone(h.osvs),
 		Findings: findings,
 	}
-	return result, nil
 }
 
-type govulncheckHandler struct {
-	logger io.Writer // forward progress reports to logger.
+// reportPartial reports the partial result of the scan, unless the
+// handler has been stopped.
+func (h *govulncheckHandler) reportPartial() {
+	h.reportMu.Lock()
+	defer h.reportMu.Unlock()
+	h.mu.Lock()
+	stopped := h.stopped
+	h.mu.Unlock()
+	if !stopped {
+		h.report(h.result())
+	}
+}
 
-	osvs     map[string]*osv.Entry
-	findings []*govulncheck.Finding
+// stop cancels any pending report of a partial result, and waits for
+// any report in progress.
+func (h *govulncheckHandler) stop() {
+	h.reportMu.Lock()
+	defer h.reportMu.Unlock()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.stopped = true
+	if h.timer != nil {
+		h.timer.Stop()
+	}
 }
 
 // Config implements vulncheck.Handler.
@@ -142,12 +196,24 @@ func (h *govulncheckHandler) Config(config *govulncheck.Config) error {
 
 // Finding implements vulncheck.Handler.
 func (h *govulncheckHandler) Finding(finding *govulncheck.Finding) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
 	h.findings = append(h.findings, finding)
+	if h.report != nil && !h.stopped {
+		// Report once the current burst of findings is over.
+		if h.timer == nil {
+			h.timer = time.AfterFunc(reportDelay, h.reportPartial)
+		} else {
+			h.timer.Reset(reportDelay)
+		}
+	}
 	return nil
 }
 
 // OSV implements vulncheck.Handler.
 func (h *govulncheckHandler) OSV(entry *osv.Entry) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
 	h.osvs[entry.ID] = entry
 	return nil
 }
@@ -0,0 +1,61 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package imports
+
+import (
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/internal/diff"
+	intimp "golang.org/x/tools/internal/imports"
+)
+
+// ProcessEdits is like Process, but instead of the whole formatted
+// file, it returns the changes that Process makes to the file as a
+// list of minimal, non-overlapping edits, in order, which callers such
+// as editors may apply to their own copy of the source.
+//
+// ProcessEdits adds the file to fset, and the positions of the edits
+// refer to it: the offset of an edit's Pos is that of the start of the
+// replaced text within src.
+func ProcessEdits(fset *token.FileSet, filename string, src []byte, opt *Options) ([]analysis.TextEdit, error) {
+	src, intopt, err := prepare(filename, src, opt)
+	if err != nil {
+		return nil, err
+	}
+	edits, err := intimp.ProcessEdits(filename, src, intopt)
+	return textEdits(fset, filename, src, edits), err
+}
+
+// ImportEdits is like ProcessEdits, but it returns only the changes to
+// the file's import declarations, and leaves the formatting of the
+// rest of the file unchanged. The Fragment option is not supported.
+func ImportEdits(fset *token.FileSet, filename string, src []byte, opt *Options) ([]analysis.TextEdit, error) {
+	src, intopt, err := prepare(filename, src, opt)
+	if err != nil {
+		return nil, err
+	}
+	edits, err := intimp.ImportEdits(filename, src, intopt)
+	return textEdits(fset, filename, src, edits), err
+}
+
+// textEdits converts the edits of src to ones whose positions refer
+// to a new file of fset.
+func textEdits(fset *token.FileSet, filename string, src []byte, edits []diff.Edit) []analysis.TextEdit {
+	if edits == nil {
+		return nil
+	}
+	tokFile := fset.AddFile(filename, -1, len(src))
+	tokFile.SetLinesForContent(src)
+	res := make([]analysis.TextEdit, len(edits))
+	for i, edit := range edits {
+		res[i] = analysis.TextEdit{
+			Pos:     tokFile.Pos(edit.Start),
+			End:     tokFile.Pos(edit.End),
+			NewText: []byte(edit.New),
+		}
+	}
+	return res
+}
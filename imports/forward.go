@@ -24,6 +24,11 @@ type Options struct {
 	TabWidth  int  // Tab width (8 if nil *Options provided)
 
 	FormatOnly bool // Disable the insertion and deletion of imports
+
+	// Index, if non-nil, resolves missing imports that the standard
+	// library does not satisfy, in place of a search of GOPATH and the
+	// module cache using the go command.
+	Index Index
 }
 
 // Debug controls verbose logging.
@@ -42,11 +47,21 @@ var LocalPrefix string
 // so it is important that filename be accurate.
 // To process data “as if” it were in filename, pass the data as a non-nil src.
 func Process(filename string, src []byte, opt *Options) ([]byte, error) {
+	src, intopt, err := prepare(filename, src, opt)
+	if err != nil {
+		return nil, err
+	}
+	return intimp.Process(filename, src, intopt)
+}
+
+// prepare returns the source of the file, reading it if src is nil, and
+// the internal options corresponding to opt.
+func prepare(filename string, src []byte, opt *Options) ([]byte, *intimp.Options, error) {
 	var err error
 	if src == nil {
 		src, err = os.ReadFile(filename)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 	if opt == nil {
@@ -64,8 +79,11 @@ func Process(filename string, src []byte, opt *Options) ([]byte, error) {
 		TabIndent:   opt.TabIndent,
 		TabWidth:    opt.TabWidth,
 	}
+	if opt.Index != nil {
+		intopt.Source = &indexSource{index: opt.Index, filename: filename}
+	}
 	if Debug {
 		intopt.Env.Logf = log.Printf
 	}
-	return intimp.Process(filename, src, intopt)
+	return src, intopt, nil
 }
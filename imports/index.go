@@ -0,0 +1,82 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package imports
+
+import (
+	"context"
+	"slices"
+
+	intimp "golang.org/x/tools/internal/imports"
+)
+
+// An Index is a caller-provided index of the packages that may satisfy
+// the missing imports of a file, such as one maintained by an editor or
+// a code-modification tool. See [Options.Index].
+type Index interface {
+	// Lookup returns the packages with the specified name that the file
+	// filename may import, in order of preference. Process imports the
+	// first of them that provides all the symbols the file refers to.
+	Lookup(ctx context.Context, filename, name string) ([]Package, error)
+
+	// PackageName returns the name of the package with the specified
+	// import path, as imported by the file filename, or "" if it is
+	// unknown, in which case the name is assumed from the path.
+	PackageName(ctx context.Context, filename, importPath string) (string, error)
+}
+
+// A Package describes a package of an Index.
+type Package struct {
+	ImportPath string   // import path, e.g. "golang.org/x/mod/modfile"
+	Name       string   // package name, e.g. "modfile"
+	Exports    []string // names of the package's exported package-level symbols
+}
+
+// indexSource adapts an Index to the internal Source interface.
+type indexSource struct {
+	index    Index
+	filename string // file whose imports are resolved
+}
+
+func (s *indexSource) LoadPackageNames(ctx context.Context, srcDir string, paths []intimp.ImportPath) (map[intimp.ImportPath]intimp.PackageName, error) {
+	names := make(map[intimp.ImportPath]intimp.PackageName)
+	for _, path := range paths {
+		name, err := s.index.PackageName(ctx, s.filename, path)
+		if err != nil {
+			return nil, err
+		}
+		if name != "" {
+			names[path] = name
+		}
+	}
+	return names, nil
+}
+
+func (s *indexSource) ResolveReferences(ctx context.Context, filename string, missing intimp.References) ([]*intimp.Result, error) {
+	var results []*intimp.Result
+	for name, symbols := range missing {
+		pkgs, err := s.index.Lookup(ctx, filename, name)
+		if err != nil {
+			return nil, err
+		}
+	pkgs:
+		for _, pkg := range pkgs {
+			for symbol := range symbols {
+				if !slices.Contains(pkg.Exports, symbol) {
+					continue pkgs
+				}
+			}
+			exports := make(map[string]bool, len(pkg.Exports))
+			for _, symbol := range pkg.Exports {
+				exports[symbol] = true
+			}
+			results = append(results, &intimp.Result{
+				Import:  &intimp.ImportInfo{ImportPath: pkg.ImportPath},
+				Package: &intimp.PackageInfo{Name: pkg.Name, Exports: exports},
+			})
+			break
+		}
+	}
+	return results, nil
+}
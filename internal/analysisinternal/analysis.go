@@ -333,6 +333,32 @@ func Imports(pkg *types.Package, path string) bool {
 	return false
 }
 
+// InspectFunc calls f for each node within n, not descending into
+// function literals, whose statements belong to another function.
+func InspectFunc(n ast.Node, f func(ast.Node)) {
+	ast.Inspect(n, func(n ast.Node) bool {
+		if _, ok := n.(*ast.FuncLit); ok {
+			return false
+		}
+		if n != nil {
+			f(n)
+		}
+		return true
+	})
+}
+
+var errorType = types.Universe.Lookup("error").Type()
+
+// IsErrorType reports whether t is the predeclared error type.
+func IsErrorType(t types.Type) bool {
+	return types.Identical(t, errorType)
+}
+
+// ImplementsError reports whether t implements the error interface.
+func ImplementsError(t types.Type) bool {
+	return types.Implements(t, errorType.Underlying().(*types.Interface))
+}
+
 // IsTypeNamed reports whether t is (or is an alias for) a
 // package-level defined type with the given package path and one of
 // the given names. It returns false if t is nil.
@@ -52,6 +52,18 @@ true, false or nil. All the literals match the standard go conventions, with
 all bases of integers, and both quote and backtick strings.
 There is one extra literal type, which is a string literal preceded by the
 identifier "re" which is compiled to a regular expression.
+
+A pattern (a string or regular expression literal) preceded by a string
+literal and a colon, such as "b.go":"x := 1" or "b.go":re`x (\w+)`,
+denotes a match of the pattern anywhere in the named file, which is
+interpreted relative to the directory of the file containing the note.
+Its value is a FileMatch. This allows a note in one file to refer to
+positions in another, such as the related information of a diagnostic.
+
+The named capture groups of a regular expression pattern, such as
+re`var (?P<v>\w+)`, may be referred to by later notes as $v. Its
+value is a Capture, which the application may resolve to the text or
+extent of the group in an earlier match (see Find).
 */
 package expect
 
@@ -59,6 +71,7 @@ import (
 	"bytes"
 	"fmt"
 	"go/token"
+	"path/filepath"
 	"regexp"
 )
 
@@ -95,29 +108,121 @@ func MatchBefore(fset *token.FileSet, readFile ReadFile, end token.Pos, pattern
 	position := f.Position(end)
 	startOffset := f.Offset(f.LineStart(position.Line))
 	endOffset := f.Offset(end)
-	line := content[startOffset:endOffset]
-	matchStart, matchEnd := -1, -1
+	match := matchPattern(content[startOffset:endOffset], pattern)
+	if match == nil {
+		return token.NoPos, token.NoPos, nil
+	}
+	return f.Pos(startOffset + match[0]), f.Pos(startOffset + match[1]), nil
+}
+
+// FileMatch is the value of a note argument of the form "file":pattern.
+// It denotes the first match of the pattern in the named file.
+type FileMatch struct {
+	File    string // file name, relative to the directory of the note's file
+	Pattern any    // string or *regexp.Regexp
+}
+
+// Capture is the value of a note argument of the form $name.
+// It refers to the capture group of that name of an earlier
+// regular expression pattern.
+type Capture string
+
+// A Match is the result of a successful call to Find.
+type Match struct {
+	Start, End token.Pos
+	Text       string           // the matched text
+	Groups     map[string]Match // named capture groups of a regular expression (or nil)
+}
+
+// Find is like MatchBefore, but it also reports the text and named
+// capture groups of the match, and it additionally accepts a
+// FileMatch pattern, which it matches against the entire content of
+// the named file instead of the line of pos. If that file is not
+// already in the FileSet, Find adds it.
+//
+// Find returns nil if there was no match.
+func Find(fset *token.FileSet, readFile ReadFile, pos token.Pos, pattern any) (*Match, error) {
+	f := fset.File(pos)
+	var (
+		content                []byte
+		startOffset, endOffset int
+	)
+	if fm, ok := pattern.(FileMatch); ok {
+		filename := fm.File
+		if !filepath.IsAbs(filename) {
+			filename = filepath.Join(filepath.Dir(f.Name()), filename)
+		}
+		data, err := readFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("invalid file: %v", err)
+		}
+		f = fileNamed(fset, filename, data)
+		content, startOffset, endOffset = data, 0, len(data)
+		pattern = fm.Pattern
+	} else {
+		data, err := readFile(f.Name())
+		if err != nil {
+			return nil, fmt.Errorf("invalid file: %v", err)
+		}
+		position := f.Position(pos)
+		content = data
+		startOffset = f.Offset(f.LineStart(position.Line))
+		endOffset = f.Offset(pos)
+	}
+
+	match := matchPattern(content[startOffset:endOffset], pattern)
+	if match == nil {
+		return nil, nil
+	}
+	newMatch := func(start, end int) Match {
+		start += startOffset
+		end += startOffset
+		return Match{Start: f.Pos(start), End: f.Pos(end), Text: string(content[start:end])}
+	}
+	m := newMatch(match[0], match[1])
+	if re, ok := pattern.(*regexp.Regexp); ok {
+		for i, name := range re.SubexpNames() {
+			if name != "" && match[2*i] >= 0 {
+				if m.Groups == nil {
+					m.Groups = make(map[string]Match)
+				}
+				m.Groups[name] = newMatch(match[2*i], match[2*i+1])
+			}
+		}
+	}
+	return &m, nil
+}
+
+// matchPattern returns the submatch indices (as with
+// regexp.FindSubmatchIndex) of the first match of the pattern in data,
+// or nil if there is none.
+func matchPattern(data []byte, pattern any) []int {
 	switch pattern := pattern.(type) {
 	case string:
-		bytePattern := []byte(pattern)
-		matchStart = bytes.Index(line, bytePattern)
-		if matchStart >= 0 {
-			matchEnd = matchStart + len(bytePattern)
-		}
+		return matchPattern(data, []byte(pattern))
 	case []byte:
-		matchStart = bytes.Index(line, pattern)
-		if matchStart >= 0 {
-			matchEnd = matchStart + len(pattern)
+		if i := bytes.Index(data, pattern); i >= 0 {
+			return []int{i, i + len(pattern)}
 		}
 	case *regexp.Regexp:
-		match := pattern.FindIndex(line)
-		if len(match) > 0 {
-			matchStart = match[0]
-			matchEnd = match[1]
-		}
+		return pattern.FindSubmatchIndex(data)
 	}
-	if matchStart < 0 {
-		return token.NoPos, token.NoPos, nil
+	return nil
+}
+
+// fileNamed returns the file of the FileSet with the specified name,
+// adding it if necessary.
+func fileNamed(fset *token.FileSet, filename string, content []byte) *token.File {
+	var file *token.File
+	fset.Iterate(func(f *token.File) bool {
+		if f.Name() == filename {
+			file = f
+		}
+		return file == nil
+	})
+	if file == nil {
+		file = fset.AddFile(filename, -1, len(content))
+		file.SetLinesForContent(content)
 	}
-	return f.Pos(startOffset + matchStart), f.Pos(startOffset + matchEnd), nil
+	return file
 }
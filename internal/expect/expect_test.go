@@ -9,6 +9,7 @@ import (
 	"go/token"
 	"os"
 	"reflect"
+	"regexp"
 	"slices"
 	"testing"
 
@@ -26,7 +27,7 @@ func TestMarker(t *testing.T) {
 	}{
 		{
 			filename:    "testdata/test.go",
-			expectNotes: 14,
+			expectNotes: 15,
 			expectMarkers: map[string]string{
 				"αSimpleMarker": "α",
 				"OffsetMarker":  "β",
@@ -49,6 +50,10 @@ func TestMarker(t *testing.T) {
 					"c": "3",
 					"d": true,
 				}},
+				"FileMatch": {
+					expect.FileMatch{File: "go.fake.mod", Pattern: regexp.MustCompile(`require (?P<mod>\S+)`)},
+					expect.Capture("mod"),
+				},
 			},
 		},
 		{
@@ -155,6 +160,63 @@ func TestMarker(t *testing.T) {
 	}
 }
 
+func TestFind(t *testing.T) {
+	const filename = "testdata/test.go"
+	fset := token.NewFileSet()
+	notes, err := expect.Parse(fset, filename, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var note *expect.Note
+	for _, n := range notes {
+		if n.Name == "check" && n.Args[0] == expect.Identifier("FileMatch") {
+			note = n
+		}
+	}
+	if note == nil {
+		t.Fatal("no check(FileMatch) note")
+	}
+
+	// Find the pattern in the other file, and its capture group.
+	m, err := expect.Find(fset, os.ReadFile, note.Pos, note.Args[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m == nil {
+		t.Fatalf("Find(%v) found no match", note.Args[1])
+	}
+	if got, want := m.Text, "require golang.org/modfile"; got != want {
+		t.Errorf("Find matched %q, want %q", got, want)
+	}
+	if got, want := fset.Position(m.Start).String(), "testdata/go.fake.mod:9:1"; got != want {
+		t.Errorf("Find matched at %s, want %s", got, want)
+	}
+	group, ok := m.Groups[string(note.Args[2].(expect.Capture))]
+	if !ok {
+		t.Fatalf("Find returned groups %v, want mod", m.Groups)
+	}
+	if got, want := group.Text, "golang.org/modfile"; got != want {
+		t.Errorf("capture group matched %q, want %q", got, want)
+	}
+	if got, want := fset.Position(group.Start).String(), "testdata/go.fake.mod:9:9"; got != want {
+		t.Errorf("capture group matched at %s, want %s", got, want)
+	}
+
+	// A pattern without a file is matched in the note's line.
+	for _, n := range notes {
+		if n.Name == "αSimpleMarker" {
+			note = n
+		}
+	}
+	m, err = expect.Find(fset, os.ReadFile, note.Pos, regexp.MustCompile(`α(?P<x>\w+)Marker`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m == nil || m.Groups["x"].Text != "Simple" {
+		t.Errorf("Find(αSimpleMarker) = %+v, want capture group x = Simple", m)
+	}
+}
+
 func checkMarker(t *testing.T, fset *token.FileSet, readFile expect.ReadFile, markers map[string]token.Pos, pos token.Pos, name string, pattern any) {
 	start, end, err := expect.MatchBefore(fset, readFile, pos, pattern)
 	if err != nil {
@@ -391,7 +391,31 @@ again:
 		}
 
 	case scanner.String, scanner.RawString:
-		value, _ = strconv.Unquote(t.Consume()) // can't fail
+		s, _ := strconv.Unquote(t.Consume()) // can't fail
+		value = s
+		if t.Token() == ':' {
+			// "file":pattern
+			t.Consume()
+			if tok := t.Token(); !(tok == scanner.String || tok == scanner.RawString ||
+				tok == scanner.Ident && t.scanner.TokenText() == "re") {
+				t.Errorf("file %q must be followed by pattern, got %s", s, t.TokenString())
+				return
+			}
+			_, pattern := parseArgument(t)
+			if _, ok := pattern.(FileMatch); ok {
+				t.Errorf("file %q must be followed by pattern, got file", s)
+				return
+			}
+			value = FileMatch{File: s, Pattern: pattern}
+		}
+
+	case '$':
+		t.Consume()
+		if t.Token() != scanner.Ident {
+			t.Errorf("$ must be followed by capture group name, got %s", t.TokenString())
+			return
+		}
+		value = Capture(t.Consume())
 
 	case scanner.Int:
 		s := t.Consume()
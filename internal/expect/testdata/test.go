@@ -39,4 +39,6 @@ check(StringAndInt,
 check(Bool, true)
 
 check(NamedArgs, 1, true, a, b=1, c="3", d=true)
+
+check(FileMatch, "go.fake.mod":re`require (?P<mod>\S+)`, $mod)
 */
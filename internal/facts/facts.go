@@ -60,9 +60,10 @@ const debug = false
 //
 // All of Set's methods except String are safe to call concurrently.
 type Set struct {
-	pkg *types.Package
-	mu  sync.Mutex
-	m   map[key]analysis.Fact
+	pkg      *types.Package
+	versions map[reflect.Type]string // see Decoder.SetVersions
+	mu       sync.Mutex
+	m        map[key]analysis.Fact
 }
 
 type key struct {
@@ -145,6 +146,16 @@ func (s *Set) AllPackageFacts(filter map[reflect.Type]bool) []analysis.PackageFa
 	return facts
 }
 
+// gobFactGroup is the Gob declaration of the serialized facts of one
+// type. The facts are encoded separately, so that they need not be
+// decoded (which may fail, if the encoding of the type has changed)
+// unless they were produced by the current version of the analyzer.
+type gobFactGroup struct {
+	Type    string // name of the fact type; see typeName
+	Version string // version of the analyzer that declares the fact type
+	Facts   []byte // Gob encoding of []gobFact
+}
+
 // gobFact is the Gob declaration of a serialized fact.
 type gobFact struct {
 	PkgPath string          // path of package
@@ -152,6 +163,12 @@ type gobFact struct {
 	Fact    analysis.Fact   // type and value of user-defined Fact
 }
 
+// typeName returns the name of a fact type,
+// a pointer to a named type, for use in an encoding.
+func typeName(t reflect.Type) string {
+	return "*" + t.Elem().PkgPath() + "." + t.Elem().Name()
+}
+
 // A Decoder decodes the facts from the direct imports of the package
 // provided to NewEncoder. A single decoder may be used to decode
 // multiple fact sets (e.g. each for a different set of fact types)
@@ -160,6 +177,7 @@ type gobFact struct {
 type Decoder struct {
 	pkg        *types.Package
 	getPackage GetPackageFunc
+	versions   map[reflect.Type]string
 }
 
 // NewDecoder returns a fact decoder for the specified package.
@@ -191,6 +209,18 @@ func NewDecoderFunc(pkg *types.Package, getPackage GetPackageFunc) *Decoder {
 	}
 }
 
+// SetVersions records the version (see [analysis.Analyzer.Version])
+// of the analyzer that declares each fact type. Decode discards any
+// fact whose encoding was produced by a different version, without
+// decoding it, so that stale facts are recomputed rather than silently
+// reused, even if their encoding has changed. The versions are recorded
+// with the facts by [Set.Encode].
+//
+// It must be called before Decode.
+func (d *Decoder) SetVersions(versions map[reflect.Type]string) {
+	d.versions = versions
+}
+
 // A GetPackageFunc function returns the package denoted by a package path.
 type GetPackageFunc = func(pkgPath string) *types.Package
 
@@ -226,10 +256,28 @@ func (d *Decoder) Decode(read func(pkgPath string) ([]byte, error)) (*Set, error
 		if len(data) == 0 {
 			continue // no facts
 		}
-		var gobFacts []gobFact
-		if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&gobFacts); err != nil {
+		var groups []gobFactGroup
+		if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&groups); err != nil {
 			return nil, fmt.Errorf("decoding facts for %q: %v", imp.Path(), err)
 		}
+		versions := make(map[string]string) // by type name
+		for t, v := range d.versions {
+			versions[typeName(t)] = v
+		}
+		var gobFacts []gobFact
+		for _, g := range groups {
+			if want := versions[g.Type]; g.Version != want {
+				// Facts were produced by a different
+				// version of their analyzer. Skip.
+				logf("%s facts have version %q, want %q; discarding", g.Type, g.Version, want)
+				continue
+			}
+			var facts []gobFact
+			if err := gob.NewDecoder(bytes.NewReader(g.Facts)).Decode(&facts); err != nil {
+				return nil, fmt.Errorf("decoding %s facts for %q: %v", g.Type, imp.Path(), err)
+			}
+			gobFacts = append(gobFacts, facts...)
+		}
 		logf("decoded %d facts: %v", len(gobFacts), gobFacts)
 
 		// Parse each one into a key and a Fact.
@@ -261,7 +309,7 @@ func (d *Decoder) Decode(read func(pkgPath string) ([]byte, error)) (*Set, error
 		}
 	}
 
-	return &Set{pkg: d.pkg, m: m}, nil
+	return &Set{pkg: d.pkg, versions: d.versions, m: m}, nil
 }
 
 // Encode encodes a set of facts to a memory buffer.
@@ -328,28 +376,32 @@ func (s *Set) Encode() []byte {
 	}
 	s.mu.Unlock()
 
-	// Sort facts by (package, object, type) for determinism.
+	// Sort facts by (type, package, object) for determinism.
 	sort.Slice(gobFacts, func(i, j int) bool {
 		x, y := gobFacts[i], gobFacts[j]
+		tx := typeName(reflect.TypeOf(x.Fact))
+		ty := typeName(reflect.TypeOf(y.Fact))
+		if tx != ty {
+			return tx < ty
+		}
 		if x.PkgPath != y.PkgPath {
 			return x.PkgPath < y.PkgPath
 		}
-		if x.Object != y.Object {
-			return x.Object < y.Object
-		}
-		tx := reflect.TypeOf(x.Fact)
-		ty := reflect.TypeOf(y.Fact)
-		if tx != ty {
-			return tx.String() < ty.String()
-		}
-		return false // equal
+		return x.Object < y.Object
 	})
 
-	var buf bytes.Buffer
-	if len(gobFacts) > 0 {
-		if err := gob.NewEncoder(&buf).Encode(gobFacts); err != nil {
+	// Encode the facts of each type separately.
+	var groups []gobFactGroup
+	for i := 0; i < len(gobFacts); {
+		t := reflect.TypeOf(gobFacts[i].Fact)
+		j := i + 1
+		for j < len(gobFacts) && reflect.TypeOf(gobFacts[j].Fact) == t {
+			j++
+		}
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(gobFacts[i:j]); err != nil {
 			// Fact encoding should never fail. Identify the culprit.
-			for _, gf := range gobFacts {
+			for _, gf := range gobFacts[i:j] {
 				if err := gob.NewEncoder(io.Discard).Encode(gf); err != nil {
 					fact := gf.Fact
 					pkgpath := reflect.TypeOf(fact).Elem().PkgPath()
@@ -358,6 +410,19 @@ func (s *Set) Encode() []byte {
 				}
 			}
 		}
+		groups = append(groups, gobFactGroup{
+			Type:    typeName(t),
+			Version: s.versions[t],
+			Facts:   buf.Bytes(),
+		})
+		i = j
+	}
+
+	var buf bytes.Buffer
+	if len(groups) > 0 {
+		if err := gob.NewEncoder(&buf).Encode(groups); err != nil {
+			log.Panicf("internal error: gob encoding of analysis facts failed: %v", err)
+		}
 	}
 
 	if debug {
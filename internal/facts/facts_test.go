@@ -477,6 +477,67 @@ func TestFactFilter(t *testing.T) {
 	}
 }
 
+// TestFactVersions checks that facts encoded by one version of an
+// analyzer are discarded when decoded by a different version.
+func TestFactVersions(t *testing.T) {
+	// Type-check a, and b which imports it.
+	fset := token.NewFileSet()
+	pkgs := make(map[string]*types.Package)
+	check := func(path, src string) *types.Package {
+		f, err := parser.ParseFile(fset, path+".go", src, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		conf := types.Config{Importer: importerFunc(func(path string) (*types.Package, error) {
+			return pkgs[path], nil
+		})}
+		pkg, err := conf.Check(path, fset, []*ast.File{f}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pkgs[path] = pkg
+		return pkg
+	}
+	a := check("a", `package a; type A int`)
+	b := check("b", `package b; import "a"; var _ a.A`)
+
+	versions := func(v string) map[reflect.Type]string {
+		return map[reflect.Type]string{reflect.TypeOf(new(myFact)): v}
+	}
+
+	dec := facts.NewDecoder(a)
+	dec.SetVersions(versions("v1"))
+	s, err := dec.Decode(func(string) ([]byte, error) { return nil, nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.ExportObjectFact(a.Scope().Lookup("A"), &myFact{"A"})
+	data := s.Encode()
+
+	for _, test := range []struct {
+		version string
+		want    string
+	}{
+		{"v1", "myFact(A)"},
+		{"v2", ""},
+		{"", ""},
+	} {
+		dec := facts.NewDecoder(b)
+		dec.SetVersions(versions(test.version))
+		s, err := dec.Decode(func(string) ([]byte, error) { return data, nil })
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got string
+		if fact := new(myFact); s.ImportObjectFact(a.Scope().Lookup("A"), fact) {
+			got = fact.String()
+		}
+		if got != test.want {
+			t.Errorf("version %q: got fact %q, want %q", test.version, got, test.want)
+		}
+	}
+}
+
 // TestMalformed checks that facts can be encoded and decoded *despite*
 // types.Config.Check returning an error. Importing facts is expected to
 // happen when Analyzers have RunDespiteErrors set to true. So this
@@ -599,3 +660,7 @@ func TestMalformed(t *testing.T) {
 type closure map[string]*types.Package
 
 func (c closure) Import(path string) (*types.Package, error) { return c[path], nil }
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) { return f(path) }
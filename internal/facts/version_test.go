@@ -0,0 +1,57 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package facts
+
+import (
+	"bytes"
+	"encoding/gob"
+	"go/types"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type staleFact struct{ N int }
+
+func (*staleFact) AFact() {}
+
+// TestStaleEncoding checks that facts produced by another version of
+// their analyzer are discarded without being decoded, so that a change
+// to their encoding does not cause an error.
+func TestStaleEncoding(t *testing.T) {
+	a := types.NewPackage("a", "a")
+	b := types.NewPackage("b", "b")
+	b.SetImports([]*types.Package{a})
+
+	// Facts of version v1 in an encoding that is no longer valid.
+	var data bytes.Buffer
+	if err := gob.NewEncoder(&data).Encode([]gobFactGroup{{
+		Type:    typeName(reflect.TypeOf(new(staleFact))),
+		Version: "v1",
+		Facts:   []byte("not a valid encoding"),
+	}}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		version, wantErr string
+	}{
+		{"v2", ""},
+		{"v1", "decoding"},
+	} {
+		dec := NewDecoderFunc(b, func(path string) *types.Package { return a })
+		dec.SetVersions(map[reflect.Type]string{reflect.TypeOf(new(staleFact)): test.version})
+		s, err := dec.Decode(func(string) ([]byte, error) { return data.Bytes(), nil })
+		if test.wantErr == "" {
+			if err != nil {
+				t.Errorf("version %s: Decode failed: %v", test.version, err)
+			} else if len(s.m) != 0 {
+				t.Errorf("version %s: Decode returned facts %v, want none", test.version, s)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+			t.Errorf("version %s: Decode returned error %v, want error containing %q", test.version, err, test.wantErr)
+		}
+	}
+}
@@ -377,10 +377,19 @@ func iexportCommon(out io.Writer, fset *token.FileSet, bundle, shallow bool, ver
 
 	// Initialize work queue with exported declarations.
 	for _, pkg := range pkgs {
+		// A package constructed in memory, rather than by
+		// the type checker, may violate its invariants.
+		if pkg.Name() == "" {
+			panic(internalErrorf("package %q has no name", pkg.Path()))
+		}
 		scope := pkg.Scope()
 		for _, name := range scope.Names() {
 			if token.IsExported(name) {
-				p.pushDecl(scope.Lookup(name))
+				obj := scope.Lookup(name)
+				if obj.Pkg() != pkg {
+					panic(internalErrorf("object %s in scope of package %q belongs to package %v", name, pkg.Path(), obj.Pkg()))
+				}
+				p.pushDecl(obj)
 			}
 		}
 
@@ -713,7 +722,10 @@ func (p *iexporter) doDecl(obj types.Object) {
 		w.typ(obj.Type(), obj.Pkg())
 
 	case *types.Func:
-		sig, _ := obj.Type().(*types.Signature)
+		sig, ok := obj.Type().(*types.Signature)
+		if !ok {
+			panic(internalErrorf("function %s has type %v, not a signature", obj.Name(), obj.Type()))
+		}
 		if sig.Recv() != nil {
 			// We shouldn't see methods in the package scope,
 			// but the type checker may repair "func () F() {}"
@@ -744,6 +756,9 @@ func (p *iexporter) doDecl(obj types.Object) {
 		w.signature(sig)
 
 	case *types.Const:
+		if obj.Val() == nil {
+			panic(internalErrorf("constant %s has no value", obj.Name()))
+		}
 		w.tag(constTag)
 		w.pos(obj.Pos())
 		w.value(obj.Type(), obj.Val())
@@ -823,7 +838,10 @@ func (p *iexporter) doDecl(obj types.Object) {
 			m := named.Method(i)
 			w.pos(m.Pos())
 			w.string(m.Name())
-			sig, _ := m.Type().(*types.Signature)
+			sig, ok := m.Type().(*types.Signature)
+			if !ok {
+				panic(internalErrorf("method %s.%s has type %v, not a signature", obj.Name(), m.Name(), m.Type()))
+			}
 
 			// Receiver type parameters are type arguments of the receiver type, so
 			// their name must be qualified before exporting recv.
@@ -1137,7 +1155,10 @@ func (w *exportWriter) doTyp(t types.Type, pkg *types.Package) {
 			}
 			w.pos(m.Pos())
 			w.string(m.Name())
-			sig, _ := m.Type().(*types.Signature)
+			sig, ok := m.Type().(*types.Signature)
+			if !ok {
+				panic(internalErrorf("interface method %s has type %v, not a signature", m.Name(), m.Type()))
+			}
 			w.signature(sig)
 		}
 
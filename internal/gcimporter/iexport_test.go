@@ -494,3 +494,73 @@ type Chained = C[Named] // B[Named, A[Named]] = B[Named, *Named] = []*Named
 		}
 	}
 }
+
+// TestIExportData_synthesized exercises the export of packages
+// constructed in memory, rather than by the type checker.
+func TestIExportData_synthesized(t *testing.T) {
+	// newPkg returns a package with a valid exported type T,
+	// and the specified additional exported object.
+	newPkg := func(extra func(pkg *types.Package) types.Object) *types.Package {
+		pkg := types.NewPackage("example.com/p", "p")
+		dep := types.NewPackage("example.com/dep", "dep")
+		d := types.NewNamed(types.NewTypeName(token.NoPos, dep, "D", nil), types.Typ[types.Int], nil)
+		tname := types.NewTypeName(token.Pos(123), pkg, "T", nil) // position not in file set
+		types.NewNamed(tname, types.NewStruct([]*types.Var{types.NewField(token.NoPos, pkg, "X", d, false)}, nil), nil)
+		pkg.Scope().Insert(tname)
+		if extra != nil {
+			pkg.Scope().Insert(extra(pkg))
+		}
+		return pkg
+	}
+
+	// A valid package round-trips.
+	var buf bytes.Buffer
+	if err := gcexportdata.Write(&buf, token.NewFileSet(), newPkg(nil)); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	imports := make(map[string]*types.Package)
+	pkg, err := gcexportdata.Read(&buf, token.NewFileSet(), imports, "example.com/p")
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if got, want := pkg.Scope().Lookup("T").Type().Underlying().String(), "struct{X example.com/dep.D}"; got != want {
+		t.Errorf("T = %s, want %s", got, want)
+	}
+
+	// Malformed packages are reported as errors, not panics.
+	for _, test := range []struct {
+		name  string
+		extra func(pkg *types.Package) types.Object
+		want  string
+	}{
+		{
+			"constant without value",
+			func(pkg *types.Package) types.Object {
+				return types.NewConst(token.NoPos, pkg, "C", types.Typ[types.Int], nil)
+			},
+			"constant C has no value",
+		},
+		{
+			"function without signature",
+			func(pkg *types.Package) types.Object {
+				return types.NewFunc(token.NoPos, pkg, "F", nil)
+			},
+			"function F has type <nil>, not a signature",
+		},
+		{
+			"object of another package",
+			func(pkg *types.Package) types.Object {
+				other := types.NewPackage("example.com/other", "other")
+				return types.NewVar(token.NoPos, other, "V", types.Typ[types.Int])
+			},
+			`object V in scope of package "example.com/p" belongs to package package other ("example.com/other")`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := gcexportdata.Write(new(bytes.Buffer), nil, newPkg(test.extra))
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("Write returned error %v, want %q", err, test.want)
+			}
+		})
+	}
+}
@@ -169,8 +169,10 @@ type Invocation struct {
 	ModFile string
 
 	// Overlay is the name of the JSON overlay file that describes
-	// unsaved editor buffers; see [WriteOverlays].
-	// If set, the go command is invoked with -overlay=Overlay.
+	// unsaved editor buffers, including go.mod, go.sum, and go.work
+	// files; see [WriteOverlays].
+	// If set, the go command is invoked with -overlay=Overlay,
+	// except for the env and version verbs, which do not accept it.
 	// TODO(rfindley): remove, in favor of Args.
 	Overlay string
 
@@ -232,10 +234,12 @@ func (i *Invocation) run(ctx context.Context, stdout, stderr io.Writer) error {
 		// mod needs the sub-verb before flags.
 		goArgs = append(goArgs, i.Args[0])
 		appendModFile()
+		appendOverlayFlag()
 		goArgs = append(goArgs, i.Args[1:]...)
 	case "get":
 		goArgs = append(goArgs, i.BuildFlags...)
 		appendModFile()
+		appendOverlayFlag()
 		goArgs = append(goArgs, i.Args...)
 
 	default: // notably list and build.
@@ -292,7 +296,8 @@ func (i *Invocation) run(ctx context.Context, stdout, stderr io.Writer) error {
 var DebugHangingGoCommands = false
 
 // runCmdContext is like exec.CommandContext except it sends os.Interrupt
-// before os.Kill.
+// before os.Kill. On Linux, it also signals the subprocesses of the
+// command upon cancellation, so that none outlives it.
 func runCmdContext(ctx context.Context, cmd *exec.Cmd) (err error) {
 	// If cmd.Stdout is not an *os.File, the exec package will create a pipe and
 	// copy it to the Writer in a goroutine until the process has finished and
@@ -401,14 +406,25 @@ func runCmdContext(ctx context.Context, cmd *exec.Cmd) (err error) {
 		}
 	}
 
-	// Cancelled. Interrupt and see if it ends voluntarily.
+	// Cancelled. Find the subprocesses of the go command, such as
+	// the compiler processes of go list -export, before they are
+	// orphaned by its exit, as they may hold its output pipes open.
+	// They are held by pidfds, so those that outlive it can still
+	// be signalled without risk of signalling an unrelated process.
+	subprocs := subprocesses(cmd.Process.Pid)
+	defer releaseProcesses(subprocs)
+
+	// Interrupt and see if they end voluntarily.
 	if err := cmd.Process.Signal(os.Interrupt); err == nil {
+		signalProcesses(subprocs, os.Interrupt)
+
 		// (We used to wait only 1s but this proved
 		// fragile on loaded builder machines.)
 		timer := time.NewTimer(5 * time.Second)
 		defer timer.Stop()
 		select {
 		case err := <-resChan:
+			signalProcesses(subprocs, os.Kill) // in case any outlived the go command
 			return err
 		case <-timer.C:
 		}
@@ -418,6 +434,7 @@ func runCmdContext(ctx context.Context, cmd *exec.Cmd) (err error) {
 	if err := cmd.Process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) && debug {
 		log.Printf("error killing the Go command: %v", err)
 	}
+	signalProcesses(subprocs, os.Kill)
 
 	return <-resChan
 }
@@ -0,0 +1,119 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gocommand
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// Linux system calls, whose numbers are the same on all architectures.
+const (
+	sysPidfdSendSignal = 424
+	sysPidfdOpen       = 434
+)
+
+// A subprocess is a descendant of the go command, such as a compiler
+// process of go list -export, held by a pidfd. Unlike its PID, which
+// may be reused once it has exited and been reaped, the pidfd always
+// refers to the same process, so it may be signalled even after the go
+// command has exited and the subprocess has been orphaned.
+type subprocess struct {
+	pid, fd int
+}
+
+// subprocesses returns the descendants of the go command pid, which
+// must not yet have been waited for. The caller must release them.
+//
+// The go command runs in the process group of its caller, so that
+// terminal signals reach it, so its subprocesses must be found
+// individually. Each is opened, then its parent is checked again, so
+// that a process that took the PID of an exited one is not mistaken
+// for it.
+func subprocesses(pid int) []subprocess {
+	children := make(map[int][]int)
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil
+	}
+	for _, e := range entries {
+		child, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+		if parent, ok := parentPID(child); ok {
+			children[parent] = append(children[parent], child)
+		}
+	}
+
+	// The go command must still be a child of this process.
+	if parent, ok := parentPID(pid); !ok || parent != os.Getpid() {
+		return nil
+	}
+
+	var procs []subprocess
+	stack := []int{pid}
+	for len(stack) > 0 {
+		parent := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, child := range children[parent] {
+			if proc, ok := openProcess(child, parent); ok {
+				procs = append(procs, proc)
+				stack = append(stack, child)
+			}
+		}
+	}
+	return procs
+}
+
+// openProcess opens a pidfd for process pid,
+// if it is a child of the specified parent.
+func openProcess(pid, parent int) (subprocess, bool) {
+	fd, _, errno := syscall.Syscall(sysPidfdOpen, uintptr(pid), 0, 0)
+	if errno != 0 {
+		return subprocess{}, false // exited, or pidfds are not supported
+	}
+	if p, ok := parentPID(pid); !ok || p != parent {
+		syscall.Close(int(fd))
+		return subprocess{}, false
+	}
+	return subprocess{pid, int(fd)}, true
+}
+
+// parentPID returns the ID of the parent of process pid.
+func parentPID(pid int) (int, bool) {
+	stat, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/stat")
+	if err != nil {
+		return 0, false
+	}
+	// The state and parent follow the parenthesized command name.
+	i := strings.LastIndexByte(string(stat), ')')
+	if i < 0 {
+		return 0, false
+	}
+	fields := strings.Fields(string(stat[i+1:]))
+	if len(fields) < 2 {
+		return 0, false
+	}
+	parent, err := strconv.Atoi(fields[1])
+	return parent, err == nil
+}
+
+// signalProcesses sends the signal to the subprocesses,
+// ignoring errors, as they may have exited already.
+func signalProcesses(procs []subprocess, sig os.Signal) {
+	for _, proc := range procs {
+		syscall.Syscall6(sysPidfdSendSignal, uintptr(proc.fd), uintptr(sig.(syscall.Signal)), 0, 0, 0, 0) // ignore error
+	}
+}
+
+// releaseProcesses releases the subprocesses.
+func releaseProcesses(procs []subprocess) {
+	for _, proc := range procs {
+		syscall.Close(proc.fd)
+	}
+}
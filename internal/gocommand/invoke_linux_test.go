@@ -0,0 +1,79 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gocommand_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"golang.org/x/tools/internal/gocommand"
+	"golang.org/x/tools/internal/testenv"
+)
+
+// TestCancelKillsSubprocesses checks that cancelling a go command
+// terminates its subprocesses, even those that ignore interrupts.
+func TestCancelKillsSubprocesses(t *testing.T) {
+	testenv.NeedsTool(t, "sh")
+
+	// Replace the go command by a script whose subprocess ignores
+	// interrupts, as does a background job of a shell.
+	dir := t.TempDir()
+	pidfile := filepath.Join(dir, "pid")
+	script := "#!/bin/sh\nsleep 60 &\necho $! > " + pidfile + "\nwait\n"
+	if err := os.WriteFile(filepath.Join(dir, "go"), []byte(script), 0777); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(filepath.ListSeparator)+os.Getenv("PATH"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var runner gocommand.Runner
+		runner.RunRaw(ctx, gocommand.Invocation{Verb: "list", WorkingDir: dir})
+	}()
+
+	// Wait for the subprocess to start, then cancel.
+	var pid int
+	for deadline := time.Now().Add(10 * time.Second); pid == 0; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("subprocess did not start")
+		}
+		data, err := os.ReadFile(pidfile)
+		if err == nil && strings.HasSuffix(string(data), "\n") {
+			pid, _ = strconv.Atoi(strings.TrimSpace(string(data)))
+		}
+	}
+	cancel()
+	<-done
+
+	// The subprocess is killed (or is an unreaped zombie).
+	for deadline := time.Now().Add(10 * time.Second); alive(pid); time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			syscall.Kill(pid, syscall.SIGKILL)
+			t.Fatalf("subprocess %d is still running after cancellation", pid)
+		}
+	}
+}
+
+// alive reports whether the process is running.
+func alive(pid int) bool {
+	if syscall.Kill(pid, 0) != nil {
+		return false
+	}
+	stat, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/stat")
+	if err != nil {
+		return true // not Linux
+	}
+	// The state follows the parenthesized command name.
+	fields := strings.Fields(string(stat[strings.LastIndexByte(string(stat), ')')+1:]))
+	return len(fields) == 0 || fields[0] != "Z"
+}
@@ -0,0 +1,24 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !linux
+
+package gocommand
+
+import "os"
+
+// A subprocess is a descendant of the go command.
+// Subprocesses are tracked only on Linux, where they can be
+// held by a pidfd, so that a reused PID is never signalled.
+type subprocess struct{}
+
+// subprocesses returns nil: the subprocesses of the go command are
+// not tracked.
+func subprocesses(pid int) []subprocess { return nil }
+
+// signalProcesses is a no-op, as subprocesses returns nil.
+func signalProcesses(procs []subprocess, sig os.Signal) {}
+
+// releaseProcesses is a no-op, as subprocesses returns nil.
+func releaseProcesses(procs []subprocess) {}
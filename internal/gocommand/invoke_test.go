@@ -137,3 +137,37 @@ func testRmdirAfterGoList(t *testing.T, f func(ctx context.Context, dir string))
 		}) // ignore error
 	}
 }
+
+// TestModOverlay checks that 'go mod' commands read an overlaid
+// go.mod file.
+func TestModOverlay(t *testing.T) {
+	testenv.NeedsTool(t, "go")
+
+	dir := t.TempDir()
+	gomod := filepath.Join(dir, "go.mod")
+	if err := os.WriteFile(gomod, []byte("module example.com/disk\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	overlay, cleanup, err := gocommand.WriteOverlays(map[string][]byte{
+		gomod: []byte("module example.com/overlay\n\ngo 1.22\n"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cleanup()
+
+	var runner gocommand.Runner
+	stdout, err := runner.Run(context.Background(), gocommand.Invocation{
+		Verb:       "mod",
+		Args:       []string{"graph"},
+		Overlay:    overlay,
+		Env:        []string{"GOFLAGS=", "GOWORK=off"},
+		WorkingDir: dir,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := stdout.String(); !strings.Contains(got, "example.com/overlay") {
+		t.Errorf("go mod graph = %q, want module example.com/overlay", got)
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package imports
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+
+	"golang.org/x/tools/internal/diff"
+)
+
+// ProcessEdits is like Process, but returns the changes it makes to
+// src as a list of minimal, non-overlapping edits, in order, rather
+// than the whole formatted file.
+func ProcessEdits(filename string, src []byte, opt *Options) ([]diff.Edit, error) {
+	formatted, err := Process(filename, src, opt)
+	if err != nil {
+		return nil, err
+	}
+	return diff.Bytes(src, formatted), nil
+}
+
+// ImportEdits is like ProcessEdits, but returns only the changes to
+// the file's import declarations (and to the remainder of the line of
+// its package clause), leaving the rest of the file as it is, for
+// example while it is being edited. It does not accept fragments.
+func ImportEdits(filename string, src []byte, opt *Options) ([]diff.Edit, error) {
+	if opt.Fragment {
+		return nil, fmt.Errorf("ImportEdits: fragments are not supported")
+	}
+	formatted, err := Process(filename, src, opt)
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := importRegion(filename, src)
+	if err != nil {
+		return nil, err
+	}
+	fstart, fend, err := importRegion(filename, formatted)
+	if err != nil {
+		return nil, err
+	}
+	edits := diff.Bytes(src[start:end], formatted[fstart:fend])
+	for i := range edits {
+		edits[i].Start += start
+		edits[i].End += start
+	}
+	return edits, nil
+}
+
+// importRegion returns the offsets of the region of src that holds
+// its import declarations: from the end of its package name to the
+// end of its last import declaration, or of the line of the package
+// clause if that is later.
+func importRegion(filename string, src []byte) (start, end int, err error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.ImportsOnly|parser.ParseComments)
+	if err != nil {
+		return 0, 0, err
+	}
+	tok := fset.File(f.FileStart)
+	start = tok.Offset(f.Name.End())
+	end = len(src)
+	if nl := bytes.IndexByte(src[start:], '\n'); nl >= 0 {
+		end = start + nl
+	}
+	for _, decl := range f.Decls {
+		if decl, ok := decl.(*ast.GenDecl); ok && decl.Tok == token.IMPORT {
+			end = max(end, tok.Offset(decl.End()))
+		}
+	}
+	return start, end, nil
+}
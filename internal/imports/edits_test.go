@@ -0,0 +1,112 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package imports_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/tools/internal/diff"
+	"golang.org/x/tools/internal/imports"
+)
+
+// mapSource is a Source that resolves references from a fixed set of
+// packages, indexed by name.
+type mapSource map[imports.PackageName]*imports.Result
+
+func (s mapSource) LoadPackageNames(ctx context.Context, srcDir string, paths []imports.ImportPath) (map[imports.ImportPath]imports.PackageName, error) {
+	return nil, nil
+}
+
+func (s mapSource) ResolveReferences(ctx context.Context, filename string, missing imports.References) ([]*imports.Result, error) {
+	var results []*imports.Result
+	for name := range missing {
+		if result, ok := s[name]; ok {
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+func TestEdits(t *testing.T) {
+	const src = `package p // comment
+
+func f() {   fmt.Println(foo.X, bar.Y)  }
+`
+	source := mapSource{
+		"foo": {
+			Import:  &imports.ImportInfo{ImportPath: "example.com/foo"},
+			Package: &imports.PackageInfo{Name: "foo", Exports: map[string]bool{"X": true}},
+		},
+		"bar": {
+			Import:  &imports.ImportInfo{ImportPath: "example.com/barimpl"},
+			Package: &imports.PackageInfo{Name: "bar", Exports: map[string]bool{"Y": true}},
+		},
+	}
+	opt := &imports.Options{
+		Source:    source,
+		Comments:  true,
+		TabIndent: true,
+		TabWidth:  8,
+	}
+	filename := filepath.Join(t.TempDir(), "p.go") // (no other files)
+
+	apply := func(edits []diff.Edit) string {
+		t.Helper()
+		got, err := diff.Apply(src, edits)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	// ProcessEdits reproduces the result of Process.
+	formatted, err := imports.Process(filename, []byte(src), opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edits, err := imports.ProcessEdits(filename, []byte(src), opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := apply(edits); got != string(formatted) {
+		t.Errorf("ProcessEdits: got\n%s\nwant\n%s", got, formatted)
+	}
+
+	// ImportEdits changes only the imports.
+	edits, err = imports.ImportEdits(filename, []byte(src), opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = `package p // comment
+
+import (
+	"fmt"
+
+	bar "example.com/barimpl"
+	"example.com/foo"
+)
+
+func f() {   fmt.Println(foo.X, bar.Y)  }
+`
+	if got := apply(edits); got != want {
+		t.Errorf("ImportEdits: got\n%s\nwant\n%s", got, want)
+	}
+	for _, edit := range edits {
+		if edit.End > len("package p // comment") {
+			t.Errorf("ImportEdits returned edit %v beyond the import declarations", edit)
+		}
+	}
+
+	// No changes, no edits.
+	edits, err = imports.ImportEdits(filename, []byte(want), opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(edits) > 0 {
+		t.Errorf("ImportEdits of fixed file returned %v, want none", edits)
+	}
+}
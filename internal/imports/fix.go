@@ -73,6 +73,52 @@ func importGroup(localPrefix, importPath string) int {
 	return 0
 }
 
+// importGrouper returns the function that maps an import path to
+// its group number under the options opt.
+func importGrouper(opt *Options) func(importPath string) int {
+	if len(opt.Groups) == 0 {
+		return func(importPath string) int { return importGroup(opt.LocalPrefix, importPath) }
+	}
+	std, external := -1, -1
+	prefixes := make(map[string]int) // maps a prefix to its group
+	for i, group := range opt.Groups {
+		for _, pattern := range strings.Fields(group) {
+			switch pattern {
+			case "std":
+				std = i
+			case "external":
+				external = i
+			default:
+				prefixes[strings.TrimSuffix(pattern, "/")] = i
+			}
+		}
+	}
+	if external < 0 {
+		external = len(opt.Groups)
+	}
+	if std < 0 {
+		std = external
+	}
+	return func(importPath string) int {
+		// Find the longest matching prefix.
+		for p := importPath; ; {
+			if n, ok := prefixes[p]; ok {
+				return n
+			}
+			i := strings.LastIndex(p, "/")
+			if i < 0 {
+				break
+			}
+			p = p[:i]
+		}
+		firstComponent, _, _ := strings.Cut(importPath, "/")
+		if !strings.Contains(firstComponent, ".") {
+			return std
+		}
+		return external
+	}
+}
+
 type ImportFixType int
 
 const (
@@ -2058,6 +2058,83 @@ const _ = runtime.GOOS
 	}
 }
 
+// Tests that the Groups option orders the groups of imports,
+// and separates them by exactly one blank line.
+func TestGroups(t *testing.T) {
+	const src = `package main
+
+import (
+	"example.com/acme/util"
+	"fmt"
+
+	"github.com/x/y"
+
+	"example.com/acme/tool" // comment
+
+	// Standalone comment.
+	"os"
+	"example.com/other"
+)
+`
+	for _, test := range []struct {
+		groups []string
+		want   string
+	}{
+		{
+			groups: []string{"std", "external", "example.com/acme"},
+			want: `package main
+
+import (
+	"fmt"
+
+	"github.com/x/y"
+
+	"example.com/acme/tool" // comment
+	"example.com/acme/util"
+
+	// Standalone comment.
+	"os"
+
+	"example.com/other"
+)
+`,
+		},
+		{
+			groups: []string{"example.com/acme github.com/x", "std"},
+			want: `package main
+
+import (
+	"example.com/acme/tool" // comment
+	"example.com/acme/util"
+	"github.com/x/y"
+
+	"fmt"
+
+	// Standalone comment.
+	"os"
+
+	"example.com/other"
+)
+`,
+		},
+	} {
+		options := &Options{
+			Groups:     test.groups,
+			TabWidth:   8,
+			TabIndent:  true,
+			Comments:   true,
+			FormatOnly: true,
+		}
+		got, err := Process("main.go", []byte(src), options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != test.want {
+			t.Errorf("Groups=%q: got:\n%s\nwant:\n%s", test.groups, got, test.want)
+		}
+	}
+}
+
 // Tests that "package documentation" files are ignored.
 func TestIgnoreDocumentationPackage(t *testing.T) {
 	const input = `package x
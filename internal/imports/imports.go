@@ -29,11 +29,30 @@ import (
 type Options struct {
 	Env *ProcessEnv // The environment to use. Note: this contains the cached module and filesystem state.
 
+	// Source, if non-nil, resolves missing imports in place of Env,
+	// which is then used only for logging and may be nil.
+	Source Source
+
 	// LocalPrefix is a comma-separated string of import path prefixes, which, if
 	// set, instructs Process to sort the import paths with the given prefixes
 	// into another group after 3rd-party packages.
 	LocalPrefix string
 
+	// Groups, if non-empty, specifies the order of the groups into which
+	// Process sorts import paths, overriding LocalPrefix. Each group is
+	// a space-separated list of patterns: "std", which matches standard
+	// library packages; "external", which matches packages that no other
+	// pattern matches; or an import path prefix, which matches the
+	// packages beneath it. A path matches the group of its longest
+	// matching prefix, if any. Paths that match no group are placed in
+	// a final group.
+	//
+	// Unlike the default grouping, which only separates the groups
+	// within each run of consecutive import lines, Groups causes the
+	// blank lines between imports to be replaced by exactly one blank
+	// line between groups, except where they are separated by comments.
+	Groups []string
+
 	Fragment  bool // Accept fragment of a source file (no package statement)
 	AllErrors bool // Report all errors (not just the first 10 on different lines)
 
@@ -60,7 +79,17 @@ func Process(filename string, src []byte, opt *Options) (formatted []byte, err e
 	}
 
 	if !opt.FormatOnly {
-		if err := fixImports(fileSet, file, filename, opt.Env); err != nil {
+		if opt.Source != nil {
+			var logf func(string, ...any)
+			if opt.Env != nil {
+				logf = opt.Env.Logf
+			}
+			fixes, err := getFixesWithSource(context.Background(), fileSet, file, filename, "", logf, opt.Source)
+			if err != nil {
+				return nil, err
+			}
+			apply(fileSet, file, fixes)
+		} else if err := fixImports(fileSet, file, filename, opt.Env); err != nil {
 			return nil, err
 		}
 	}
@@ -122,8 +151,9 @@ func ApplyFixes(fixes []*ImportFix, filename string, src []byte, opt *Options, e
 // with the original source (formatFile's src parameter) and the
 // formatted file, and returns the postpocessed result.
 func formatFile(fset *token.FileSet, file *ast.File, src []byte, adjust func(orig []byte, src []byte) []byte, opt *Options) ([]byte, error) {
+	group := importGrouper(opt)
 	mergeImports(file)
-	sortImports(opt.LocalPrefix, fset.File(file.FileStart), file)
+	sortImports(group, len(opt.Groups) > 0, fset.File(file.FileStart), file)
 	var spacesBefore []string // import paths we need spaces before
 	for _, impSection := range astutil.Imports(fset, file) {
 		// Within each block of contiguous imports, see if any
@@ -133,7 +163,7 @@ func formatFile(fset *token.FileSet, file *ast.File, src []byte, adjust func(ori
 		lastGroup := -1
 		for _, importSpec := range impSection {
 			importPath, _ := strconv.Unquote(importSpec.Path.Value)
-			groupNum := importGroup(opt.LocalPrefix, importPath)
+			groupNum := group(importPath)
 			if groupNum != lastGroup && lastGroup != -1 {
 				spacesBefore = append(spacesBefore, importPath)
 			}
@@ -16,11 +16,13 @@ import (
 	"strconv"
 )
 
-// sortImports sorts runs of consecutive import lines in import blocks in f.
+// sortImports sorts runs of consecutive import lines in import blocks in f,
+// by the group numbers given by group and then by path. If regroup is set,
+// runs separated only by blank lines are merged before sorting.
 // It also removes duplicate imports when it is possible to do so without data loss.
 //
 // It may mutate the token.File and the ast.File.
-func sortImports(localPrefix string, tokFile *token.File, f *ast.File) {
+func sortImports(group func(importPath string) int, regroup bool, tokFile *token.File, f *ast.File) {
 	for i, d := range f.Decls {
 		d, ok := d.(*ast.GenDecl)
 		if !ok || d.Tok != token.IMPORT {
@@ -43,13 +45,14 @@ func sortImports(localPrefix string, tokFile *token.File, f *ast.File) {
 		i := 0
 		specs := d.Specs[:0]
 		for j, s := range d.Specs {
-			if j > i && tokFile.Line(s.Pos()) > 1+tokFile.Line(d.Specs[j-1].End()) {
+			if j > i && tokFile.Line(s.Pos()) > 1+tokFile.Line(d.Specs[j-1].End()) &&
+				!(regroup && !hasComment(f, d.Specs[j-1].End(), s.Pos())) {
 				// j begins a new run.  End this one.
-				specs = append(specs, sortSpecs(localPrefix, tokFile, f, d.Specs[i:j])...)
+				specs = append(specs, sortSpecs(group, tokFile, f, d.Specs[i:j])...)
 				i = j
 			}
 		}
-		specs = append(specs, sortSpecs(localPrefix, tokFile, f, d.Specs[i:])...)
+		specs = append(specs, sortSpecs(group, tokFile, f, d.Specs[i:])...)
 		d.Specs = specs
 
 		// Deduping can leave a blank line before the rparen; clean that up.
@@ -64,6 +67,16 @@ func sortImports(localPrefix string, tokFile *token.File, f *ast.File) {
 	}
 }
 
+// hasComment reports whether f has a comment between start and end.
+func hasComment(f *ast.File, start, end token.Pos) bool {
+	for _, g := range f.Comments {
+		if start < g.Pos() && g.End() < end {
+			return true
+		}
+	}
+	return false
+}
+
 // mergeImports merges all the import declarations into the first one.
 // Taken from golang.org/x/tools/ast/astutil.
 // This does not adjust line numbers properly
@@ -151,7 +164,7 @@ type posSpan struct {
 
 // sortSpecs sorts the import specs within each import decl.
 // It may mutate the token.File.
-func sortSpecs(localPrefix string, tokFile *token.File, f *ast.File, specs []ast.Spec) []ast.Spec {
+func sortSpecs(group func(importPath string) int, tokFile *token.File, f *ast.File, specs []ast.Spec) []ast.Spec {
 	// Can't short-circuit here even if specs are already sorted,
 	// since they might yet need deduplication.
 	// A lone import, however, may be safely ignored.
@@ -200,7 +213,7 @@ func sortSpecs(localPrefix string, tokFile *token.File, f *ast.File, specs []ast
 	// Reassign the import paths to have the same position sequence.
 	// Reassign each comment to abut the end of its spec.
 	// Sort the comments by new position.
-	sort.Sort(byImportSpec{localPrefix, specs})
+	sort.Sort(byImportSpec{group, specs})
 
 	// Dedup. Thanks to our sorting, we can just consider
 	// adjacent pairs of imports.
@@ -263,8 +276,8 @@ func sortSpecs(localPrefix string, tokFile *token.File, f *ast.File, specs []ast
 }
 
 type byImportSpec struct {
-	localPrefix string
-	specs       []ast.Spec // slice of *ast.ImportSpec
+	group func(importPath string) int
+	specs []ast.Spec // slice of *ast.ImportSpec
 }
 
 func (x byImportSpec) Len() int      { return len(x.specs) }
@@ -273,8 +286,8 @@ func (x byImportSpec) Less(i, j int) bool {
 	ipath := importPath(x.specs[i])
 	jpath := importPath(x.specs[j])
 
-	igroup := importGroup(x.localPrefix, ipath)
-	jgroup := importGroup(x.localPrefix, jpath)
+	igroup := x.group(ipath)
+	jgroup := x.group(jpath)
 	if igroup != jgroup {
 		return igroup < jgroup
 	}
@@ -2,7 +2,13 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-package jsonrpc2
+// Package rpclabel defines the keys of the labels with which the
+// golang.org/x/tools/jsonrpc2 package instruments its events.
+//
+// The keys are internal so that the jsonrpc2 package's API does not
+// depend on the internal event packages; gopls uses them to record
+// its RPC metrics.
+package rpclabel
 
 import "golang.org/x/tools/internal/event/keys"
 
@@ -205,7 +205,7 @@ func isDeprecated(doc *ast.CommentGroup) bool {
 	}
 	// go.dev/wiki/Deprecated Paragraph starting 'Deprecated:'
 	// This code fails for /* Deprecated: */, but it's the code from
-	// gopls/internal/analysis/deprecated
+	// go/analysis/passes/deprecated
 	for line := range strings.SplitSeq(doc.Text(), "\n\n") {
 		if strings.HasPrefix(line, "Deprecated:") {
 			return true
@@ -51,7 +51,7 @@ const (
 // There are a very limited set of types the arguments are allowed to be.
 //
 //	expect.Note : passed the Note instance being evaluated.
-//	string : can be supplied either a string literal or an identifier.
+//	string : can be supplied either a string literal, an identifier, or a capture.
 //	int : can only be supplied an integer literal.
 //	*regexp.Regexp : can only be supplied a regular expression literal
 //	token.Pos : has a file position calculated as described below.
@@ -68,7 +68,15 @@ const (
 // marker to look up (as if markers were global variables).
 //
 // If it is a string or regular expression, then it will be passed to
-// expect.MatchBefore to look up a match in the line at which it was declared.
+// expect.Find to look up a match in the line at which it was declared.
+// If it is a file match ("file":pattern), the pattern is matched
+// anywhere in the named file, so that a note may refer to a position
+// in another file.
+//
+// The named capture groups of each regular expression matched in this
+// way are recorded, so that a capture ($name) argument of a later note
+// denotes the extent, or as a string the text, of the group in the most
+// recent match.
 //
 // It is safe to call this repeatedly with different method sets, but it is
 // not safe to call it concurrently.
@@ -334,6 +342,12 @@ func (e *Exported) buildConverter(pt reflect.Type) (converter, error) {
 				return reflect.ValueOf(string(arg)), args, nil
 			case string:
 				return reflect.ValueOf(arg), args, nil
+			case expect.Capture:
+				m, ok := e.captures[string(arg)]
+				if !ok {
+					return reflect.Value{}, nil, fmt.Errorf("cannot find capture group %v", arg)
+				}
+				return reflect.ValueOf(m.Text), args, nil
 			default:
 				return reflect.Value{}, nil, fmt.Errorf("cannot convert %v to string", arg)
 			}
@@ -418,24 +432,27 @@ func (e *Exported) rangeConverter(n *expect.Note, args []any) (Range, []any, err
 			}
 			return mark, args, nil
 		}
-	case string:
-		start, end, err := expect.MatchBefore(e.ExpectFileSet, e.FileContents, n.Pos, arg)
-		if err != nil {
-			return Range{}, nil, err
-		}
-		if !start.IsValid() {
-			return Range{}, nil, fmt.Errorf("%v: pattern %s did not match", e.ExpectFileSet.Position(n.Pos), arg)
+	case expect.Capture:
+		m, ok := e.captures[string(arg)]
+		if !ok {
+			return Range{}, nil, fmt.Errorf("cannot find capture group %v", arg)
 		}
-		return newRange(tokFile, start, end), args, nil
-	case *regexp.Regexp:
-		start, end, err := expect.MatchBefore(e.ExpectFileSet, e.FileContents, n.Pos, arg)
+		return newRange(e.ExpectFileSet.File(m.Start), m.Start, m.End), args, nil
+	case string, *regexp.Regexp, expect.FileMatch:
+		m, err := expect.Find(e.ExpectFileSet, e.FileContents, n.Pos, arg)
 		if err != nil {
 			return Range{}, nil, err
 		}
-		if !start.IsValid() {
-			return Range{}, nil, fmt.Errorf("%v: pattern %s did not match", e.ExpectFileSet.Position(n.Pos), arg)
+		if m == nil {
+			return Range{}, nil, fmt.Errorf("%v: pattern %v did not match", e.ExpectFileSet.Position(n.Pos), arg)
+		}
+		for name, group := range m.Groups {
+			if e.captures == nil {
+				e.captures = make(map[string]expect.Match)
+			}
+			e.captures[name] = group
 		}
-		return newRange(tokFile, start, end), args, nil
+		return newRange(e.ExpectFileSet.File(m.Start), m.Start, m.End), args, nil
 	default:
 		return Range{}, nil, fmt.Errorf("cannot convert %v to pos", arg)
 	}
@@ -6,6 +6,7 @@ package packagestest_test
 
 import (
 	"go/token"
+	"path/filepath"
 	"testing"
 
 	"golang.org/x/tools/internal/expect"
@@ -19,6 +20,7 @@ func TestExpect(t *testing.T) {
 	}})
 	defer exported.Cleanup()
 	checkCount := 0
+	crossFileCount := 0
 	if err := exported.Expect(map[string]any{
 		"check": func(src, target token.Position) {
 			checkCount++
@@ -52,6 +54,18 @@ func TestExpect(t *testing.T) {
 				t.Errorf("Range ending was not greater than start")
 			}
 		},
+		"crossFile": func(r packagestest.Range, name string, group packagestest.Range) {
+			crossFileCount++
+			if got := filepath.Base(r.TokFile.Name()); got != "test_test.go" {
+				t.Errorf("crossFile range is in %s, want test_test.go", got)
+			}
+			if name != "ATestType" {
+				t.Errorf("crossFile capture text is %q, want ATestType", name)
+			}
+			if group.TokFile != r.TokFile || group.Start <= r.Start || group.End != r.End {
+				t.Errorf("crossFile capture range %v-%v is not the end of match %v-%v", group.Start, group.End, r.Start, r.End)
+			}
+		},
 		"checkEOF": func(n *expect.Note, p token.Pos) {
 			if p <= n.Pos {
 				t.Errorf("EOF was before the checkEOF note")
@@ -68,4 +82,7 @@ func TestExpect(t *testing.T) {
 	if wantCheck != checkCount {
 		t.Fatalf("Expected @check count of %v; got %v", wantCheck, checkCount)
 	}
+	if crossFileCount != 1 {
+		t.Fatalf("Expected @crossFile count of 1; got %v", crossFileCount)
+	}
 }
@@ -128,6 +128,7 @@ type Exported struct {
 	written  map[string]map[string]string // the full set of exported files
 	notes    []*expect.Note               // The list of expectations extracted from go source files
 	markers  map[string]Range             // The set of markers extracted from go source files
+	captures map[string]expect.Match      // The capture groups of the patterns matched so far
 }
 
 // Exporter implementations are responsible for converting from the generic description of some
@@ -19,6 +19,7 @@ type Match string //@check("Match",re`[[:upper:]]`)
 //@stringArg(IdentAsString,IdentAsString)
 //@directNote()
 //@range(AThing)
+//@crossFile("test_test.go":re`type (?P<testType>\w+)`, $testType, $testType)
 
 // The following test should remain at the bottom of the file
 //@checkEOF(EOF)
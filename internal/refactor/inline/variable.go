@@ -0,0 +1,416 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inline
+
+// This file defines the inlining of local variables and constants.
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/internal/typesinternal"
+)
+
+// InlineVariable returns the edits that replace each use of the local
+// variable or constant declared by the identifier id with the
+// expression that initializes it, and that delete its declaration.
+// content is the source of file, and info its type information.
+//
+// The variable must be declared alone, by a short variable declaration
+// or a var declaration with an initializer, and must not be updated
+// after its declaration, nor have its address taken. If its initializer
+// may have effects, or depends on variables that may be updated, the
+// variable must be used once, in the statement that follows its
+// declaration, and the use must be the first evaluation with effects
+// of that statement, so that inlining changes neither the number nor
+// the order of evaluations. The free identifiers of the initializer
+// must not be shadowed at any use.
+//
+// Where the type of the initializer differs from that of the variable
+// or constant, the replacement is converted to that type.
+func InlineVariable(fset *token.FileSet, pkg *types.Package, info *types.Info, file *ast.File, content []byte, id *ast.Ident) ([]analysis.TextEdit, error) {
+	obj := info.Defs[id]
+	switch obj.(type) {
+	case *types.Var, *types.Const:
+	default:
+		return nil, fmt.Errorf("%s is not a variable or constant", id.Name)
+	}
+	if obj.Parent() == nil || obj.Parent() == pkg.Scope() || obj.Parent() == types.Universe {
+		return nil, fmt.Errorf("%s is not a local %s", id.Name, objectKind(obj))
+	}
+
+	// Find the declaration, its initializer, and the enclosing
+	// statement list and function.
+	path, _ := astutil.PathEnclosingInterval(file, id.Pos(), id.End())
+	var (
+		rhs      ast.Expr
+		explicit bool     // the declaration specifies the type
+		del      ast.Node // the node to delete: a statement or spec
+		stmt     ast.Stmt // the declaring statement
+		fn       ast.Node // the outermost enclosing function
+	)
+	switch decl := path[1].(type) {
+	case *ast.AssignStmt:
+		if decl.Tok != token.DEFINE || len(decl.Lhs) != len(decl.Rhs) {
+			return nil, fmt.Errorf("cannot inline %s: not declared with an initializer", id.Name)
+		}
+		if len(decl.Lhs) > 1 {
+			return nil, fmt.Errorf("cannot inline %s: declared with other variables", id.Name)
+		}
+		rhs, del, stmt = decl.Rhs[0], decl, decl
+
+	case *ast.ValueSpec:
+		if len(decl.Values) == 0 {
+			return nil, fmt.Errorf("cannot inline %s: declared without an initializer", id.Name)
+		}
+		if len(decl.Names) > 1 {
+			return nil, fmt.Errorf("cannot inline %s: declared with other %ss", id.Name, objectKind(obj))
+		}
+		rhs, explicit, del = decl.Values[0], decl.Type != nil, decl
+		gen := path[2].(*ast.GenDecl)
+		stmt = path[3].(*ast.DeclStmt)
+		if len(gen.Specs) == 1 {
+			del = stmt
+		}
+
+	default:
+		return nil, fmt.Errorf("cannot inline %s: unsupported declaration", id.Name)
+	}
+	for _, n := range path {
+		switch n := n.(type) {
+		case *ast.FuncDecl, *ast.FuncLit:
+			fn = n
+		}
+	}
+	if fn == nil {
+		return nil, fmt.Errorf("%s is not declared in a function", id.Name) // unreachable?
+	}
+	if _, ok := obj.(*types.Const); ok {
+		usesIota := false
+		ast.Inspect(rhs, func(n ast.Node) bool {
+			if id, ok := n.(*ast.Ident); ok && info.Uses[id] == types.Universe.Lookup("iota") {
+				usesIota = true
+			}
+			return !usesIota
+		})
+		if usesIota {
+			return nil, fmt.Errorf("cannot inline %s: its value depends on iota", id.Name)
+		}
+	}
+
+	// Find the uses.
+	var uses []*ast.Ident
+	for use, o := range info.Uses {
+		if o == obj {
+			uses = append(uses, use)
+		}
+	}
+	slices.SortFunc(uses, func(x, y *ast.Ident) int { return int(x.Pos() - y.Pos()) })
+
+	// Check that the variable is not updated, and whether the
+	// initializer is pure.
+	updated := make(map[*types.Var]bool)
+	escape(info, fn, func(v *types.Var, _ bool) { updated[v] = true })
+	if v, ok := obj.(*types.Var); ok && updated[v] {
+		return nil, fmt.Errorf("cannot inline %s: it is updated or its address is taken", id.Name)
+	}
+	if _, ok := obj.(*types.Var); ok && !pure(info, func(v *types.Var) bool { return !updated[v] }, rhs) {
+		if err := checkSoleUse(info, file, path, stmt, uses); err != nil {
+			return nil, fmt.Errorf("cannot inline %s: its initializer may have effects or depend on updated variables, and %v", id.Name, err)
+		}
+	}
+
+	// Check that the free identifiers of the initializer are
+	// not shadowed at any use.
+	for _, use := range uses {
+		scope := info.Scopes[file].Innermost(use.Pos())
+		var err error
+		ast.Inspect(rhs, func(n ast.Node) bool {
+			if id, ok := n.(*ast.Ident); ok && err == nil {
+				obj1 := info.Uses[id]
+				if obj1 == nil || isField(obj1) || within(obj1.Pos(), rhs) {
+					return true
+				}
+				if sel, ok := obj1.(*types.Func); ok && sel.Signature().Recv() != nil {
+					return true // method
+				}
+				if _, obj2 := scope.LookupParent(id.Name, use.Pos()); obj1 != obj2 {
+					err = fmt.Errorf("cannot inline %s: its initializer refers to %s, which is shadowed at line %d",
+						obj.Name(), id.Name, fset.Position(obj2.Pos()).Line)
+				}
+			}
+			return err == nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Compute the replacement of each use.
+	tokFile := fset.File(file.FileStart)
+	text := string(content[tokFile.Offset(rhs.Pos()):tokFile.Offset(rhs.End())])
+	//
+	// The type checker records the type to which an untyped constant
+	// initializer is converted, so the initializer must be inspected
+	// to determine whether it is untyped.
+	var (
+		untyped    = info.Types[rhs].Value != nil && untypedConst(info, rhs)
+		conversion string // the type to which the initializer must be converted, if any
+	)
+	if untyped && !isUntyped(obj.Type()) || !untyped && !types.Identical(info.TypeOf(rhs), obj.Type()) {
+		var err error
+		conversion = types.TypeString(obj.Type(), func(p *types.Package) string {
+			if p != pkg && !slices.ContainsFunc(file.Imports, func(imp *ast.ImportSpec) bool {
+				return imp.Path.Value == fmt.Sprintf("%q", p.Path())
+			}) {
+				err = fmt.Errorf("cannot inline %s: its type refers to package %s, which is not imported", id.Name, p.Path())
+			}
+			return typesinternal.FileQualifier(file, pkg)(p)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasPrefix(conversion, "*") || strings.HasPrefix(conversion, "<-") || strings.HasPrefix(conversion, "func") {
+			conversion = "(" + conversion + ")"
+		}
+	}
+	var edits []analysis.TextEdit
+	for _, use := range uses {
+		usePath, _ := astutil.PathEnclosingInterval(file, use.Pos(), use.End())
+		newText := text
+		switch {
+		case conversion != "" && !(untyped && !explicit && assignedOperand(info, usePath)):
+			// An untyped initializer of a variable declared
+			// without a type takes its default type, that of
+			// the variable, when assigned, so it need not be
+			// converted.
+			newText = conversion + "(" + text + ")"
+		case needsOperandParens(rhs, usePath):
+			newText = "(" + text + ")"
+		}
+		edits = append(edits, analysis.TextEdit{Pos: use.Pos(), End: use.End(), NewText: []byte(newText)})
+	}
+
+	// Delete the declaration, its semicolon, if any, and the line
+	// it occupies if it is alone on that line.
+	start, end := del.Pos(), del.End()
+	rest := string(content[tokFile.Offset(end):])
+	if after, ok := strings.CutPrefix(strings.TrimLeft(rest, " \t"), ";"); ok {
+		end += token.Pos(len(rest) - len(strings.TrimLeft(after, " \t")))
+	}
+	if lineStart := tokFile.LineStart(tokFile.Line(start)); strings.TrimSpace(string(content[tokFile.Offset(lineStart):tokFile.Offset(start)])) == "" {
+		if line := tokFile.Line(end); line < tokFile.LineCount() {
+			nextLine := tokFile.LineStart(line + 1)
+			if strings.TrimSpace(string(content[tokFile.Offset(end):tokFile.Offset(nextLine)])) == "" {
+				start, end = lineStart, nextLine
+			}
+		}
+	}
+	edits = append(edits, analysis.TextEdit{Pos: start, End: end})
+	slices.SortFunc(edits, func(x, y analysis.TextEdit) int { return int(x.Pos - y.Pos) })
+	return edits, nil
+}
+
+// checkSoleUse returns an error unless uses consists of a single use
+// in the statement that follows stmt, the declaration at the end of
+// path, that is evaluated once, unconditionally, and before any
+// operation with effects in that statement.
+func checkSoleUse(info *types.Info, file *ast.File, path []ast.Node, stmt ast.Stmt, uses []*ast.Ident) error {
+	if len(uses) != 1 {
+		return fmt.Errorf("it has %d uses", len(uses))
+	}
+	use := uses[0]
+
+	// Find the statement that follows stmt.
+	var next ast.Stmt
+	for _, n := range path {
+		var list []ast.Stmt
+		switch n := n.(type) {
+		case *ast.BlockStmt:
+			list = n.List
+		case *ast.CaseClause:
+			list = n.Body
+		case *ast.CommClause:
+			list = n.Body
+		default:
+			continue
+		}
+		if i := slices.Index(list, stmt); i >= 0 && i+1 < len(list) {
+			next = list[i+1]
+		}
+		break
+	}
+	if next == nil || !within(use.Pos(), next) {
+		return fmt.Errorf("it is not used by the next statement")
+	}
+
+	// Check that the use is evaluated once and unconditionally.
+	usePath, _ := astutil.PathEnclosingInterval(file, use.Pos(), use.End())
+	usePath = usePath[:slices.Index(usePath, ast.Node(next))]
+	for i := 1; i < len(usePath); i++ {
+		child := usePath[i-1]
+		ok := true
+		switch n := usePath[i].(type) {
+		case *ast.FuncLit, *ast.BlockStmt, *ast.CaseClause, *ast.CommClause, *ast.SelectStmt:
+			ok = false
+		case *ast.BinaryExpr:
+			ok = child != n.Y || n.Op != token.LAND && n.Op != token.LOR
+		case *ast.IfStmt:
+			ok = child == n.Init || child == n.Cond
+		case *ast.SwitchStmt:
+			ok = child == n.Init || child == n.Tag
+		case *ast.TypeSwitchStmt:
+			ok = child == n.Init || child == n.Assign
+		case *ast.ForStmt:
+			ok = child == n.Init
+		case *ast.RangeStmt:
+			ok = child == n.X
+		}
+		if !ok {
+			return fmt.Errorf("it is not evaluated once and unconditionally")
+		}
+	}
+
+	// Check that no operation with effects precedes it.
+	var err error
+	ast.Inspect(next, func(n ast.Node) bool {
+		if err != nil || n == nil || n.End() > use.Pos() {
+			return err == nil && n != nil && n.Pos() < use.Pos()
+		}
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			if !info.Types[n.Fun].IsType() && !callsPureBuiltin(info, n) {
+				err = fmt.Errorf("a call precedes its use")
+			}
+		case *ast.UnaryExpr:
+			if n.Op == token.ARROW {
+				err = fmt.Errorf("a receive precedes its use")
+			}
+		}
+		return err == nil
+	})
+	return err
+}
+
+// assignedOperand reports whether the expression at the start of path
+// is assigned to a variable, parameter, or result, or is an element of
+// a composite literal, so that an untyped constant in its place takes
+// the type of its destination.
+func assignedOperand(info *types.Info, path []ast.Node) bool {
+	child := path[0]
+	for _, n := range path[1:] {
+		switch n := n.(type) {
+		case *ast.ParenExpr:
+			child = n
+			continue
+		case *ast.AssignStmt:
+			return slices.Contains(n.Rhs, child.(ast.Expr))
+		case *ast.ValueSpec, *ast.ReturnStmt, *ast.CompositeLit:
+			return true
+		case *ast.KeyValueExpr:
+			return child == n.Value
+		case *ast.SendStmt:
+			return child == n.Value
+		case *ast.CallExpr:
+			if info.Types[n.Fun].IsType() {
+				return false // conversion
+			}
+			if id, ok := ast.Unparen(n.Fun).(*ast.Ident); ok {
+				if _, ok := info.Uses[id].(*types.Builtin); ok {
+					return false
+				}
+			}
+			return child != n.Fun
+		}
+		return false
+	}
+	return false
+}
+
+// needsOperandParens reports whether the expression e must be
+// parenthesized when it replaces the operand at the start of path.
+func needsOperandParens(e ast.Expr, path []ast.Node) bool {
+	// A composite literal T{...} in the header of a control
+	// statement would be parsed as its body.
+	compositeLit := false
+	ast.Inspect(e, func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.CompositeLit:
+			compositeLit = true
+		case *ast.FuncLit, *ast.ParenExpr:
+			return false
+		}
+		return !compositeLit
+	})
+	if compositeLit {
+		for _, n := range path {
+			switch n.(type) {
+			case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt:
+				return true
+			}
+		}
+	}
+
+	switch e.(type) {
+	case *ast.BinaryExpr, *ast.UnaryExpr, *ast.StarExpr:
+	default:
+		return false // an operand or primary expression
+	}
+	child := path[0]
+	switch n := path[1].(type) {
+	case *ast.BinaryExpr, *ast.UnaryExpr, *ast.StarExpr:
+		return true
+	case *ast.SelectorExpr:
+		return child == n.X
+	case *ast.IndexExpr:
+		return child == n.X
+	case *ast.IndexListExpr:
+		return child == n.X
+	case *ast.SliceExpr:
+		return child == n.X
+	case *ast.TypeAssertExpr:
+		return child == n.X
+	case *ast.CallExpr:
+		return child == n.Fun
+	}
+	return false
+}
+
+// untypedConst reports whether the constant expression e is untyped.
+func untypedConst(info *types.Info, e ast.Expr) bool {
+	switch e := e.(type) {
+	case *ast.BasicLit:
+		return true
+	case *ast.Ident:
+		return isUntyped(info.Uses[e].Type())
+	case *ast.SelectorExpr:
+		return isUntyped(info.Uses[e.Sel].Type()) // qualified identifier
+	case *ast.ParenExpr:
+		return untypedConst(info, e.X)
+	case *ast.UnaryExpr:
+		return untypedConst(info, e.X)
+	case *ast.BinaryExpr:
+		switch e.Op {
+		case token.SHL, token.SHR:
+			return untypedConst(info, e.X)
+		case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+			return true // untyped boolean
+		}
+		return untypedConst(info, e.X) && untypedConst(info, e.Y)
+	}
+	return false // conversion or call of a built-in function
+}
+
+// isUntyped reports whether t is the type of an untyped constant.
+func isUntyped(t types.Type) bool {
+	b, ok := t.(*types.Basic)
+	return ok && b.Info()&types.IsUntyped != 0
+}
@@ -0,0 +1,157 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inline_test
+
+import (
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/internal/refactor/inline"
+)
+
+func TestInlineVariable(t *testing.T) {
+	// Each test inlines the variable or constant x.
+	for _, test := range []struct {
+		descr, src, want string
+	}{
+		{
+			"Pure initializer, several uses.",
+			`func _(a, b int) int { x := a + b; return x * x }`,
+			`func _(a, b int) int { return (a + b) * (a + b) }`,
+		},
+		{
+			"Var declaration.",
+			`func _(s []int) { var x = len(s); print(x) }`,
+			`func _(s []int) { print(len(s)) }`,
+		},
+		{
+			"Conversion to the declared type.",
+			`func _() { var x float64 = 1; print(x / 2) }`,
+			`func _() { print(float64(1) / 2) }`,
+		},
+		{
+			"Untyped initializer of a variable of its default type.",
+			`func _() { x := 1; var y int = x; print(y, x/2*4.0) }`,
+			`func _() { var y int = 1; print(y, int(1)/2*4.0) }`,
+		},
+		{
+			"Typed constant.",
+			`func _() { const x time.Duration = 5; fmt.Println(x) }`,
+			`func _() { fmt.Println(time.Duration(5)) }`,
+		},
+		{
+			"Untyped constant.",
+			`func _() { const x = 1 << 3; var f float64 = x; print(f) }`,
+			`func _() { var f float64 = 1 << 3; print(f) }`,
+		},
+		{
+			"Composite literal in an if header.",
+			`type T struct{}; func _(t T) { x := T{}; if t == x { } }`,
+			`type T struct{}; func _(t T) { if t == (T{}) { } }`,
+		},
+		{
+			"Call used once by the next statement.",
+			`func _() { x := f(); print(x, f()) }; func f() int`,
+			`func _() { print(f(), f()) }; func f() int`,
+		},
+		{
+			"Call preceded by another.",
+			`func _() { x := f(); print(f(), x) }; func f() int`,
+			`error: cannot inline x: its initializer may have effects or depend on updated variables, and a call precedes its use`,
+		},
+		{
+			"Call used conditionally.",
+			`func _(b bool) { x := f(); if b { print(x) } }; func f() int`,
+			`error: cannot inline x: its initializer may have effects or depend on updated variables, and it is not evaluated once and unconditionally`,
+		},
+		{
+			"Call used twice.",
+			`func _() { x := f(); print(x, x) }; func f() int`,
+			`error: cannot inline x: its initializer may have effects or depend on updated variables, and it has 2 uses`,
+		},
+		{
+			"Updated variable.",
+			`func _() { x := 1; x++; print(x) }`,
+			`error: cannot inline x: it is updated or its address is taken`,
+		},
+		{
+			"Dependency on an updated variable.",
+			`func _(y int) { x := y; y++; print(x) }`,
+			`error: cannot inline x: its initializer may have effects or depend on updated variables, and it is not used by the next statement`,
+		},
+		{
+			"Shadowed free identifier.",
+			`func _(y int) { x := y; { y := 2; print(x, y) } }`,
+			`error: cannot inline x: its initializer refers to y, which is shadowed at line 4`,
+		},
+		{
+			"Declared with others.",
+			`func _() { x, y := 1, 2; print(x, y) }`,
+			`error: cannot inline x: declared with other variables`,
+		},
+	} {
+		t.Run(test.descr, func(t *testing.T) {
+			const header = "package p\nimport (\"fmt\"; \"time\")\nvar _ = fmt.Print; var _ time.Time\n"
+			src := header + test.src
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "p.go", src, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			info := &types.Info{
+				Types:      make(map[ast.Expr]types.TypeAndValue),
+				Defs:       make(map[*ast.Ident]types.Object),
+				Uses:       make(map[*ast.Ident]types.Object),
+				Selections: make(map[*ast.SelectorExpr]*types.Selection),
+				Scopes:     make(map[ast.Node]*types.Scope),
+			}
+			conf := types.Config{Importer: importer.Default()}
+			pkg, err := conf.Check("p", fset, []*ast.File{f}, info)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var x *ast.Ident
+			ast.Inspect(f, func(n ast.Node) bool {
+				if id, ok := n.(*ast.Ident); ok && id.Name == "x" && info.Defs[id] != nil && x == nil {
+					x = id
+				}
+				return x == nil
+			})
+
+			var got string
+			edits, err := inline.InlineVariable(fset, pkg, info, f, []byte(src), x)
+			if err != nil {
+				got = "error: " + err.Error()
+			} else {
+				for i := len(edits) - 1; i >= 0; i-- {
+					edit := edits[i]
+					start, end := fset.Position(edit.Pos).Offset, fset.Position(edit.End).Offset
+					src = src[:start] + string(edit.NewText) + src[end:]
+				}
+				got = strings.TrimPrefix(src, header)
+
+				// Compare the formatted results.
+				want, err := format.Source([]byte(header + test.want))
+				if err != nil {
+					t.Fatal(err)
+				}
+				gotFormatted, err := format.Source([]byte(header + got))
+				if err != nil {
+					t.Fatalf("invalid result: %v\n%s", err, got)
+				}
+				got, test.want = string(gotFormatted), string(want)
+			}
+			if got != test.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, test.want)
+			}
+		})
+	}
+}
@@ -100,7 +100,8 @@ func LoadPackages(t testing.TB, ar *txtar.Archive, patterns ...string) []*packag
 			packages.NeedFiles |
 			packages.NeedImports |
 			packages.NeedCompiledGoFiles |
-			packages.NeedTypes,
+			packages.NeedTypes |
+			packages.NeedTypesSizes,
 		Dir: dir,
 		Env: append(os.Environ(),
 			"GO111MODULES=on",
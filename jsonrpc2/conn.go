@@ -13,6 +13,7 @@ import (
 
 	"golang.org/x/tools/internal/event"
 	"golang.org/x/tools/internal/event/label"
+	"golang.org/x/tools/internal/jsonrpc2/rpclabel"
 )
 
 // Conn is the common interface to jsonrpc clients and servers.
@@ -32,12 +33,13 @@ type Conn interface {
 	// be handed to the method invoked.
 	Notify(ctx context.Context, method string, params any) error
 
-	// Go starts a goroutine to handle the connection.
-	// It must be called exactly once for each Conn.
+	// Go starts a goroutine to handle the connection, passing each
+	// incoming request to the handler and delivering the responses to
+	// calls made by Call.
+	// It must be called exactly once for each Conn. It is separate from
+	// NewConn so that the caller may prepare the handler's state first.
 	// It returns immediately.
 	// You must block on Done() to wait for the connection to shut down.
-	// This is a temporary measure, this should be started automatically in the
-	// future.
 	Go(ctx context.Context, handler Handler)
 
 	// Close closes the connection and it's underlying stream.
@@ -82,17 +84,17 @@ func (c *conn) Notify(ctx context.Context, method string, params any) (err error
 		return fmt.Errorf("marshaling notify parameters: %v", err)
 	}
 	ctx, done := event.Start(ctx, method,
-		Method.Of(method),
-		RPCDirection.Of(Outbound),
+		rpclabel.Method.Of(method),
+		rpclabel.RPCDirection.Of(rpclabel.Outbound),
 	)
 	defer func() {
 		recordStatus(ctx, err)
 		done()
 	}()
 
-	event.Metric(ctx, Started.Of(1))
+	event.Metric(ctx, rpclabel.Started.Of(1))
 	n, err := c.write(ctx, notify)
-	event.Metric(ctx, SentBytes.Of(n))
+	event.Metric(ctx, rpclabel.SentBytes.Of(n))
 	return err
 }
 
@@ -104,15 +106,15 @@ func (c *conn) Call(ctx context.Context, method string, params, result any) (_ I
 		return id, fmt.Errorf("marshaling call parameters: %v", err)
 	}
 	ctx, done := event.Start(ctx, method,
-		Method.Of(method),
-		RPCDirection.Of(Outbound),
-		RPCID.Of(fmt.Sprintf("%q", id)),
+		rpclabel.Method.Of(method),
+		rpclabel.RPCDirection.Of(rpclabel.Outbound),
+		rpclabel.RPCID.Of(fmt.Sprintf("%q", id)),
 	)
 	defer func() {
 		recordStatus(ctx, err)
 		done()
 	}()
-	event.Metric(ctx, Started.Of(1))
+	event.Metric(ctx, rpclabel.Started.Of(1))
 	// We have to add ourselves to the pending map before we send, otherwise we
 	// are racing the response. Also add a buffer to rchan, so that if we get a
 	// wire response between the time this call is cancelled and id is deleted
@@ -128,7 +130,7 @@ func (c *conn) Call(ctx context.Context, method string, params, result any) (_ I
 	}()
 	// now we are ready to send
 	n, err := c.write(ctx, call)
-	event.Metric(ctx, SentBytes.Of(n))
+	event.Metric(ctx, rpclabel.SentBytes.Of(n))
 	if err != nil {
 		// sending failed, we will never get a response, so don't leave it pending
 		return id, err
@@ -168,7 +170,7 @@ func (c *conn) replier(req Request, spanDone func()) Replier {
 			return err
 		}
 		n, err := c.write(ctx, response)
-		event.Metric(ctx, SentBytes.Of(n))
+		event.Metric(ctx, rpclabel.SentBytes.Of(n))
 		if err != nil {
 			// TODO(iancottrell): if a stream write fails, we really need to shut down
 			// the whole stream
@@ -201,19 +203,19 @@ func (c *conn) run(ctx context.Context, handler Handler) {
 		switch msg := msg.(type) {
 		case Request:
 			labels := []label.Label{
-				Method.Of(msg.Method()),
-				RPCDirection.Of(Inbound),
+				rpclabel.Method.Of(msg.Method()),
+				rpclabel.RPCDirection.Of(rpclabel.Inbound),
 				{}, // reserved for ID if present
 			}
 			if call, ok := msg.(*Call); ok {
-				labels[len(labels)-1] = RPCID.Of(fmt.Sprintf("%q", call.ID()))
+				labels[len(labels)-1] = rpclabel.RPCID.Of(fmt.Sprintf("%q", call.ID()))
 			} else {
 				labels = labels[:len(labels)-1]
 			}
 			reqCtx, spanDone := event.Start(ctx, msg.Method(), labels...)
 			event.Metric(reqCtx,
-				Started.Of(1),
-				ReceivedBytes.Of(n))
+				rpclabel.Started.Of(1),
+				rpclabel.ReceivedBytes.Of(n))
 			if err := handler(reqCtx, c.replier(msg, spanDone), msg); err != nil {
 				// delivery failed, not much we can do
 				event.Error(reqCtx, "jsonrpc2 message delivery failed", err)
@@ -254,8 +256,8 @@ func (c *conn) fail(err error) {
 
 func recordStatus(ctx context.Context, err error) {
 	if err != nil {
-		event.Label(ctx, StatusCode.Of("ERROR"))
+		event.Label(ctx, rpclabel.StatusCode.Of("ERROR"))
 	} else {
-		event.Label(ctx, StatusCode.Of("OK"))
+		event.Label(ctx, rpclabel.StatusCode.Of("OK"))
 	}
 }
@@ -0,0 +1,35 @@
+// Copyright 2018 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package jsonrpc2 is a minimal implementation of the JSON RPC 2 spec.
+// https://www.jsonrpc.org/specification
+// It is intended to be compatible with other implementations at the wire level.
+//
+// It is the implementation used by gopls, the Go language server, and is
+// suitable for other servers of the Language Server Protocol and
+// similar protocols. Its principal components are:
+//
+//   - A [Stream] reads and writes messages on a connection. Streams
+//     created by [NewHeaderStream] frame each message with a
+//     Content-Length header, as does the Language Server Protocol;
+//     those created by [NewRawStream] do not.
+//   - A [Conn] manages the protocol over a Stream, in both directions:
+//     it sends calls and notifications, matches responses to calls, and
+//     dispatches incoming requests to a [Handler].
+//   - Handlers may be composed with middleware such as [AsyncHandler],
+//     [MustReplyHandler], and [CancelHandler], which cancels the
+//     context of the request with a given ID, for use by
+//     protocol-specific cancellation messages.
+//   - [Serve] and [ListenAndServe] accept connections and serve each
+//     with a [StreamServer], such as one created by [HandlerServer].
+package jsonrpc2
+
+const (
+	// ErrIdleTimeout is returned when serving timed out waiting for new connections.
+	ErrIdleTimeout = constError("timed out waiting for new connections")
+)
+
+type constError string
+
+func (e constError) Error() string { return string(e) }
@@ -15,8 +15,8 @@ import (
 	"testing"
 
 	"golang.org/x/tools/internal/event/export/eventtest"
-	"golang.org/x/tools/internal/jsonrpc2"
 	"golang.org/x/tools/internal/jsonrpc2/stack/stacktest"
+	"golang.org/x/tools/jsonrpc2"
 )
 
 var logRPC = flag.Bool("logrpc", false, "Enable jsonrpc2 communication logging")
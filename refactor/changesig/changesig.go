@@ -0,0 +1,791 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package changesig implements the "change signature" refactoring:
+// the addition, removal, reordering, and retyping of the parameters of
+// a function or method, and the corresponding update of its calls.
+//
+// The refactoring operates on a set of packages loaded by
+// [packages.Load] with syntax and type information, which should
+// include all packages that refer to the function, and their tests.
+// A reference that cannot be updated safely, such as the use of the
+// function as a value, or a call whose removed argument has effects,
+// is reported as a [Problem] and left unchanged.
+package changesig // import "golang.org/x/tools/refactor/changesig"
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"os"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/types/objectpath"
+	"golang.org/x/tools/internal/typesinternal"
+)
+
+// A Param describes a parameter of the new signature of a function.
+type Param struct {
+	// Old is the index of the existing parameter that the parameter
+	// replaces, or -1 for a new parameter.
+	Old int
+
+	// Name is the name of a new parameter. An existing parameter
+	// keeps its name.
+	Name string
+
+	// Type is the type of the parameter, as a type expression in the
+	// file that declares the function. If empty, an existing parameter
+	// keeps its type; a new parameter must specify one.
+	Type string
+
+	// Value is the argument expression of a new parameter at each
+	// existing call. If empty, the zero value of its type is used,
+	// or, where that cannot be expressed, the name of the parameter,
+	// as a placeholder that the user must replace.
+	Value string
+}
+
+// A Problem describes a reference to the function that could not be
+// updated safely, or another reason that the change may break the
+// program.
+type Problem struct {
+	Posn    token.Position
+	Message string
+}
+
+func (p Problem) String() string { return fmt.Sprintf("%s: %s", p.Posn, p.Message) }
+
+// Change returns the edits that change the parameters of the function
+// or concrete method fn to params, and update its calls in pkgs, which
+// must contain its declaration. The packages must have been loaded by
+// a single call to [packages.Load], so that they share a file set, to
+// which the positions of the edits refer.
+//
+// Each existing parameter may appear at most once in params; those that
+// do not appear are removed. The body of the function must not refer
+// to a removed parameter, and its type checking is not repeated for a
+// retyped one. A variadic parameter that is kept must remain the last
+// parameter and variadic. Type expressions and argument values are
+// inserted as given, so they must refer only to packages imported by
+// the files in question.
+//
+// Change returns an error if the declaration cannot be changed, and
+// reports the references that it could not update as problems. The
+// edits are sorted by position.
+func Change(pkgs []*packages.Package, fn *types.Func, params []Param) ([]analysis.TextEdit, []Problem, error) {
+	fn = fn.Origin()
+	sig := fn.Signature()
+	if recv := sig.Recv(); recv != nil && types.IsInterface(recv.Type()) {
+		return nil, nil, fmt.Errorf("cannot change the signature of interface method %s", fn.Name())
+	}
+	if fn.Pkg() == nil {
+		return nil, nil, fmt.Errorf("cannot change the signature of %s", fn.Name())
+	}
+	path, err := objectpath.For(fn)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot change the signature of %s: %v", fn.Name(), err)
+	}
+	c := &changer{
+		fn:      fn,
+		path:    path,
+		params:  params,
+		generic: sig.TypeParams().Len() > 0 || sig.RecvTypeParams().Len() > 0,
+		content: make(map[string][]byte),
+		edits:   make(map[edit]bool),
+		reports: make(map[string]bool),
+		types:   make(map[typeKey]types.Type),
+	}
+	if err := c.checkParams(); err != nil {
+		return nil, nil, err
+	}
+
+	// Update the declarations (of each package variant) and the calls.
+	ndecls := 0
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			for _, decl := range file.Decls {
+				if decl, ok := decl.(*ast.FuncDecl); ok && c.matches(pkg.TypesInfo.Defs[decl.Name]) {
+					if err := c.decl(pkg, decl); err != nil {
+						return nil, nil, err
+					}
+					ndecls++
+				}
+			}
+		}
+	}
+	if ndecls == 0 {
+		return nil, nil, fmt.Errorf("declaration of %s not found", fn.Name())
+	}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			if err := c.calls(pkg, file); err != nil {
+				return nil, nil, err
+			}
+		}
+		c.interfaces(pkg)
+	}
+
+	edits := make([]analysis.TextEdit, 0, len(c.edits))
+	for e := range c.edits {
+		edits = append(edits, analysis.TextEdit{Pos: e.pos, End: e.end, NewText: []byte(e.text)})
+	}
+	sort.Slice(edits, func(i, j int) bool { return edits[i].Pos < edits[j].Pos })
+	sort.Slice(c.problems, func(i, j int) bool {
+		x, y := c.problems[i].Posn, c.problems[j].Posn
+		if x.Filename != y.Filename {
+			return x.Filename < y.Filename
+		}
+		return x.Offset < y.Offset
+	})
+	return edits, c.problems, nil
+}
+
+type changer struct {
+	fn       *types.Func
+	path     objectpath.Path // of fn within its package
+	params   []Param
+	generic  bool // fn or its receiver type has type parameters
+	content  map[string][]byte
+	edits    map[edit]bool // (a set, as files may belong to several package variants)
+	problems []Problem
+	reports  map[string]bool
+	types    map[typeKey]types.Type // new parameter types, per package variant
+}
+
+// An edit replaces the text [pos, end) by text.
+type edit struct {
+	pos, end token.Pos
+	text     string
+}
+
+type typeKey struct {
+	pkg   *types.Package
+	param int
+}
+
+// checkParams checks the new parameters against the signature of fn.
+func (c *changer) checkParams() error {
+	old := c.fn.Signature().Params()
+	seen := make(map[int]bool)
+	for i, p := range c.params {
+		last := i == len(c.params)-1
+		variadic := strings.HasPrefix(p.Type, "...")
+		if p.Old < 0 {
+			if p.Type == "" {
+				return fmt.Errorf("new parameter %s has no type", p.Name)
+			}
+			if p.Name != "" && p.Name != "_" && !token.IsIdentifier(p.Name) {
+				return fmt.Errorf("invalid parameter name %q", p.Name)
+			}
+		} else {
+			if p.Old >= old.Len() {
+				return fmt.Errorf("%s has no parameter %d", c.fn.Name(), p.Old)
+			}
+			if seen[p.Old] {
+				return fmt.Errorf("parameter %d appears more than once", p.Old)
+			}
+			seen[p.Old] = true
+			if c.isVariadic(p.Old) {
+				if !last {
+					return fmt.Errorf("variadic parameter %s must remain the last parameter", old.At(p.Old).Name())
+				}
+				if p.Type != "" && !variadic {
+					return fmt.Errorf("variadic parameter %s must remain variadic", old.At(p.Old).Name())
+				}
+			} else if variadic {
+				return fmt.Errorf("parameter %s cannot become variadic", old.At(p.Old).Name())
+			}
+		}
+		if variadic && !last {
+			return fmt.Errorf("only the last parameter may be variadic")
+		}
+	}
+	return nil
+}
+
+// isVariadic reports whether the old parameter i is variadic.
+func (c *changer) isVariadic(i int) bool {
+	sig := c.fn.Signature()
+	return sig.Variadic() && i == sig.Params().Len()-1
+}
+
+// matches reports whether obj, which may belong to any variant of the
+// package that declares fn, denotes fn.
+func (c *changer) matches(obj types.Object) bool {
+	f, ok := obj.(*types.Func)
+	if !ok || f.Name() != c.fn.Name() || f.Pkg() == nil || f.Pkg().Path() != c.fn.Pkg().Path() {
+		return false
+	}
+	f = f.Origin()
+	if f == c.fn {
+		return true
+	}
+	path, err := objectpath.For(f)
+	return err == nil && path == c.path
+}
+
+// decl updates the declaration of fn.
+func (c *changer) decl(pkg *packages.Package, decl *ast.FuncDecl) error {
+	info := pkg.TypesInfo
+	src, err := c.source(pkg.Fset, decl)
+	if err != nil {
+		return err
+	}
+	declFn := info.Defs[decl.Name].(*types.Func)
+	scope := info.Scopes[decl.Type]
+
+	// Flatten the old parameter list.
+	type oldParam struct {
+		name, typ string
+		obj       *types.Var
+	}
+	var olds []oldParam
+	named := false
+	for _, field := range decl.Type.Params.List {
+		typ := c.text(pkg.Fset, src, field.Type)
+		if len(field.Names) == 0 {
+			olds = append(olds, oldParam{"", typ, nil})
+		}
+		for _, id := range field.Names {
+			named = true
+			olds = append(olds, oldParam{id.Name, typ, info.Defs[id].(*types.Var)})
+		}
+	}
+
+	// A removed parameter must not be used by the body.
+	used := make(map[*types.Var]bool)
+	if decl.Body != nil {
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			if id, ok := n.(*ast.Ident); ok {
+				if v, ok := info.Uses[id].(*types.Var); ok {
+					used[v] = true
+				}
+			}
+			return true
+		})
+	}
+	kept := make(map[int]bool)
+	for _, p := range c.params {
+		if p.Old >= 0 {
+			kept[p.Old] = true
+		}
+	}
+	for i, old := range olds {
+		if !kept[i] && old.obj != nil && used[old.obj] {
+			return fmt.Errorf("cannot remove parameter %s: it is used by the body of %s", old.name, c.fn.Name())
+		}
+	}
+
+	// Check the types and names of the new parameters.
+	for i, p := range c.params {
+		if p.Type != "" {
+			expr := strings.TrimPrefix(p.Type, "...")
+			if _, err := types.Eval(pkg.Fset, pkg.Types, decl.Type.Params.Opening, expr); err != nil {
+				return fmt.Errorf("invalid type for parameter %d: %v", i, err)
+			}
+		}
+		if p.Old >= 0 || p.Name == "" || p.Name == "_" {
+			continue
+		}
+		if obj := scope.Lookup(p.Name); obj != nil && !(isParam(declFn, obj) && !kept[indexOf(declFn, obj)]) {
+			return fmt.Errorf("new parameter %s conflicts with the declaration of %s in %s", p.Name, p.Name, c.fn.Name())
+		}
+		if decl.Recv != nil {
+			for _, field := range decl.Recv.List {
+				for _, id := range field.Names {
+					if id.Name == p.Name {
+						return fmt.Errorf("new parameter %s conflicts with the receiver", p.Name)
+					}
+				}
+			}
+		}
+		for j, q := range c.params {
+			if j != i && (q.Old < 0 && q.Name == p.Name || q.Old >= 0 && olds[q.Old].name == p.Name) {
+				return fmt.Errorf("duplicate parameter %s", p.Name)
+			}
+		}
+		if decl.Body != nil {
+			var err error
+			ast.Inspect(decl.Body, func(n ast.Node) bool {
+				if id, ok := n.(*ast.Ident); ok && id.Name == p.Name && err == nil {
+					if obj := info.Uses[id]; obj != nil && !(decl.Pos() <= obj.Pos() && obj.Pos() < decl.End()) {
+						err = fmt.Errorf("new parameter %s would shadow the reference to %s at %s",
+							p.Name, p.Name, pkg.Fset.Position(id.Pos()))
+					}
+				}
+				return err == nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Format the new parameter list. Parameters are named if
+	// the old ones were, or if a new one has a name.
+	for _, p := range c.params {
+		if p.Old < 0 && p.Name != "" {
+			named = true
+		}
+	}
+	var names, typs []string
+	for _, p := range c.params {
+		name, typ := p.Name, p.Type
+		if p.Old >= 0 {
+			name = olds[p.Old].name
+			if typ == "" {
+				typ = olds[p.Old].typ
+			}
+		}
+		if name == "" {
+			name = "_"
+		}
+		names = append(names, name)
+		typs = append(typs, typ)
+	}
+	var buf strings.Builder
+	for i := range typs {
+		if named {
+			buf.WriteString(names[i])
+			// Group consecutive parameters of the same type.
+			if i+1 < len(typs) && typs[i+1] == typs[i] {
+				buf.WriteString(", ")
+				continue
+			}
+			buf.WriteString(" ")
+		}
+		buf.WriteString(typs[i])
+		if i+1 < len(typs) {
+			buf.WriteString(", ")
+		}
+	}
+	c.edit(pkg.Fset, decl.Type.Params.Opening+1, decl.Type.Params.Closing, buf.String())
+	return nil
+}
+
+// calls updates the calls of fn in file, and reports its other
+// references.
+func (c *changer) calls(pkg *packages.Package, file *ast.File) error {
+	info := pkg.TypesInfo
+	src, err := c.source(pkg.Fset, file)
+	if err != nil {
+		return err
+	}
+	// Find the calls and the other references.
+	var calls []*ast.CallExpr
+	called := make(map[*ast.Ident]bool)
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			if id, _ := calleeIdent(info, n); id != nil && c.matches(info.Uses[id]) {
+				called[id] = true
+				calls = append(calls, n)
+			}
+		case *ast.Ident:
+			if !called[n] && c.matches(info.Uses[n]) {
+				c.report(pkg.Fset, n.Pos(), "reference to %s is not a call, so it cannot be updated", c.fn.Name())
+			}
+		}
+		return true
+	})
+
+	// Update the calls, inner ones first, so that the
+	// arguments of a call include the updates of the calls
+	// within them.
+	var reps []replacement
+	text := func(n ast.Node) string {
+		tokFile := pkg.Fset.File(n.Pos())
+		var buf strings.Builder
+		pos := n.Pos()
+		for i := len(reps) - 1; i >= 0; i-- { // (in order)
+			rep := reps[i]
+			if pos <= rep.start && rep.end <= n.End() {
+				buf.Write(src[tokFile.Offset(pos):tokFile.Offset(rep.start)])
+				buf.WriteString(rep.text)
+				pos = rep.end
+			}
+		}
+		buf.Write(src[tokFile.Offset(pos):tokFile.Offset(n.End())])
+		return buf.String()
+	}
+	for i := len(calls) - 1; i >= 0; i-- {
+		call := calls[i]
+		id, recv := calleeIdent(info, call)
+		if args, ok := c.call(pkg, file, text, call, info.Uses[id].(*types.Func), recv); ok {
+			// Discard the replacements within this one.
+			for len(reps) > 0 && call.Lparen < reps[len(reps)-1].start && reps[len(reps)-1].end <= call.Rparen {
+				reps = reps[:len(reps)-1]
+			}
+			reps = append(reps, replacement{call.Lparen + 1, call.Rparen, args})
+		}
+	}
+	for _, rep := range reps {
+		c.edit(pkg.Fset, rep.start, rep.end, rep.text)
+	}
+	return nil
+}
+
+// A replacement is a pending update of the arguments of a call.
+type replacement struct {
+	start, end token.Pos
+	text       string
+}
+
+// calleeIdent returns the identifier that denotes the function or
+// method called by call, and the number of arguments of a method
+// expression call that precede the parameters.
+func calleeIdent(info *types.Info, call *ast.CallExpr) (*ast.Ident, int) {
+	fun := ast.Unparen(call.Fun)
+	switch x := fun.(type) {
+	case *ast.IndexExpr:
+		fun = x.X
+	case *ast.IndexListExpr:
+		fun = x.X
+	}
+	switch fun := fun.(type) {
+	case *ast.Ident:
+		return fun, 0
+	case *ast.SelectorExpr:
+		if sel, ok := info.Selections[fun]; ok && sel.Kind() == types.MethodExpr {
+			return fun.Sel, 1
+		}
+		return fun.Sel, 0
+	}
+	return nil, 0
+}
+
+// call returns the new arguments of a call of fn, whose variant is
+// callee, in file, or reports why the call cannot be updated.
+// The text function returns the updated text of a node.
+func (c *changer) call(pkg *packages.Package, file *ast.File, text func(ast.Node) string, call *ast.CallExpr, callee *types.Func, recv int) (string, bool) {
+	info := pkg.TypesInfo
+	fset := pkg.Fset
+	if len(call.Args) < recv {
+		return "", false // ill-typed
+	}
+	args := call.Args[recv:]
+	nparams := c.fn.Signature().Params().Len()
+	if len(args) == 1 && nparams != 1 {
+		if tuple, ok := info.TypeOf(args[0]).(*types.Tuple); ok && tuple.Len() > 1 {
+			c.report(fset, call.Lparen, "call passes the results of a call, so it cannot be updated")
+			return "", false
+		}
+	}
+
+	// Group the arguments by parameter.
+	groups := make([][]ast.Expr, nparams)
+	for i, arg := range args {
+		if i >= nparams-1 && c.fn.Signature().Variadic() {
+			groups[nparams-1] = append(groups[nparams-1], arg)
+		} else if i < nparams {
+			groups[i] = []ast.Expr{arg}
+		}
+	}
+	groupText := func(group []ast.Expr) string {
+		var texts []string
+		for _, arg := range group {
+			texts = append(texts, text(arg))
+		}
+		s := strings.Join(texts, ", ")
+		if call.Ellipsis.IsValid() && len(group) > 0 && group[len(group)-1] == args[len(args)-1] {
+			s += "..."
+		}
+		return s
+	}
+
+	// An argument with effects may be neither removed nor, as its
+	// effects may be observed by the other arguments, evaluated out
+	// of order with respect to those that are not constant.
+	kept := make(map[int]bool)
+	effects := false
+	for _, p := range c.params {
+		if p.Old >= 0 {
+			kept[p.Old] = true
+			for _, arg := range groups[p.Old] {
+				effects = effects || !pure(info, arg)
+			}
+		}
+	}
+	if effects {
+		last := -1
+		for _, p := range c.params {
+			if p.Old < 0 {
+				continue
+			}
+			for _, arg := range groups[p.Old] {
+				if tv := info.Types[arg]; tv.Value == nil {
+					if p.Old < last {
+						c.report(fset, arg.Pos(), "reordering the arguments would change the order of their effects")
+						return "", false
+					}
+					last = p.Old
+				}
+			}
+		}
+	}
+	for i, group := range groups {
+		if kept[i] {
+			continue
+		}
+		if c.isVariadic(i) && call.Ellipsis.IsValid() {
+			c.report(fset, call.Ellipsis, "cannot remove the variadic argument of a call with ...")
+			return "", false
+		}
+		for _, arg := range group {
+			if !pure(info, arg) {
+				c.report(fset, arg.Pos(), "cannot remove argument %s, which may have effects", text(arg))
+				return "", false
+			}
+		}
+	}
+
+	// Compute the new arguments.
+	var texts []string
+	for _, arg := range call.Args[:recv] {
+		texts = append(texts, text(arg))
+	}
+	var placeholders []string
+	for i, p := range c.params {
+		switch {
+		case p.Old >= 0:
+			if p.Type != "" && !c.isVariadic(p.Old) && !c.generic {
+				if t := c.newType(fset, callee, i); t != nil {
+					for _, arg := range groups[p.Old] {
+						at := info.TypeOf(arg)
+						if tv := info.Types[arg]; tv.Value != nil {
+							// Use the type of the constant before its
+							// implicit conversion, which may be untyped.
+							if tv, err := types.Eval(fset, pkg.Types, arg.Pos(), text(arg)); err == nil {
+								at = tv.Type
+							}
+						}
+						if at != nil && !types.AssignableTo(at, t) {
+							c.report(fset, arg.Pos(), "argument %s of type %s is not assignable to new parameter type %s",
+								text(arg), at, p.Type)
+							return "", false
+						}
+					}
+				}
+			}
+			if s := groupText(groups[p.Old]); s != "" {
+				texts = append(texts, s)
+			}
+
+		case strings.HasPrefix(p.Type, "..."):
+			// A new variadic parameter needs no argument.
+
+		case p.Value != "":
+			texts = append(texts, p.Value)
+
+		default:
+			if zero, ok := c.zero(fset, pkg, file, callee, i); ok {
+				texts = append(texts, zero)
+			} else {
+				name := p.Name
+				if name == "" || name == "_" {
+					name = fmt.Sprintf("param%d", i)
+				}
+				texts = append(texts, name)
+				placeholders = append(placeholders, name)
+			}
+		}
+	}
+	for _, name := range placeholders {
+		c.report(fset, call.Lparen, "placeholder %s must be replaced by a value", name)
+	}
+	return strings.Join(texts, ", "), true
+}
+
+// newType returns the new type of parameter i as seen by a caller of
+// callee, a variant of fn, or nil if it cannot be determined.
+func (c *changer) newType(fset *token.FileSet, callee *types.Func, i int) types.Type {
+	key := typeKey{callee.Pkg(), i}
+	t, ok := c.types[key]
+	if !ok {
+		callee = callee.Origin()
+		expr := strings.TrimPrefix(c.params[i].Type, "...")
+		if tv, err := types.Eval(fset, callee.Pkg(), callee.Pos(), expr); err == nil && tv.IsType() {
+			t = tv.Type
+		}
+		c.types[key] = t
+	}
+	return t
+}
+
+// zero returns the zero value of the type of new parameter i, for a
+// call of callee in file, if it can be expressed there.
+func (c *changer) zero(fset *token.FileSet, pkg *packages.Package, file *ast.File, callee *types.Func, i int) (string, bool) {
+	if c.generic {
+		return "", false
+	}
+	t := c.newType(fset, callee, i)
+	if t == nil {
+		return "", false
+	}
+	qual := typesinternal.FileQualifier(file, pkg.Types)
+	valid := true
+	zero, ok := typesinternal.ZeroString(t, func(p *types.Package) string {
+		if p != pkg.Types && !imports(file, p) {
+			valid = false
+		}
+		return qual(p)
+	})
+	return zero, ok && valid
+}
+
+// interfaces reports the interfaces declared by pkg that the receiver
+// type of the method fn may no longer implement.
+func (c *changer) interfaces(pkg *packages.Package) {
+	recv := c.fn.Signature().Recv()
+	if recv == nil {
+		return
+	}
+	// Find the variant of the method seen by pkg.
+	var method *types.Func
+	for _, p := range append([]*types.Package{pkg.Types}, pkg.Types.Imports()...) {
+		if p.Path() == c.fn.Pkg().Path() {
+			if obj, err := objectpath.Object(p, c.path); err == nil {
+				method, _ = obj.(*types.Func)
+			}
+			break
+		}
+	}
+	if method == nil {
+		return
+	}
+	t := typesinternal.Unpointer(method.Signature().Recv().Type())
+	scope := pkg.Types.Scope()
+	for _, name := range scope.Names() {
+		tname, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || tname.IsAlias() {
+			continue
+		}
+		iface, ok := tname.Type().Underlying().(*types.Interface)
+		if !ok || !types.Implements(types.NewPointer(t), iface) {
+			continue
+		}
+		for m := range iface.Methods() {
+			if m.Name() == c.fn.Name() {
+				c.report(pkg.Fset, c.fn.Pos(), "%s implements %s.%s, which it may no longer do",
+					types.TypeString(recv.Type(), (*types.Package).Name), pkg.Types.Name(), tname.Name())
+				break
+			}
+		}
+	}
+}
+
+// edit records the replacement of [start, end) by text.
+func (c *changer) edit(fset *token.FileSet, start, end token.Pos, text string) {
+	tokFile := fset.File(start)
+	src := c.content[tokFile.Name()]
+	startOff, endOff := tokFile.Offset(start), tokFile.Offset(end)
+	if string(src[startOff:endOff]) != text {
+		c.edits[edit{start, end, text}] = true
+	}
+}
+
+// report records a problem at pos.
+func (c *changer) report(fset *token.FileSet, pos token.Pos, format string, args ...any) {
+	p := Problem{fset.Position(pos), fmt.Sprintf(format, args...)}
+	if !c.reports[p.String()] {
+		c.reports[p.String()] = true
+		c.problems = append(c.problems, p)
+	}
+}
+
+// source returns the content of the file containing n.
+func (c *changer) source(fset *token.FileSet, n ast.Node) ([]byte, error) {
+	tokFile := fset.File(n.Pos())
+	name := tokFile.Name()
+	src, ok := c.content[name]
+	if !ok {
+		var err error
+		src, err = os.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		if len(src) != tokFile.Size() {
+			return nil, fmt.Errorf("file %s has changed since it was loaded", name)
+		}
+		c.content[name] = src
+	}
+	return src, nil
+}
+
+// text returns the source text of n.
+func (c *changer) text(fset *token.FileSet, src []byte, n ast.Node) string {
+	tokFile := fset.File(n.Pos())
+	return string(src[tokFile.Offset(n.Pos()):tokFile.Offset(n.End())])
+}
+
+// pure reports whether the evaluation of e has no effects, including
+// panics and the receipt of channel values, on which the program
+// might depend. It is conservative.
+func pure(info *types.Info, e ast.Expr) bool {
+	if tv, ok := info.Types[e]; ok && tv.Value != nil {
+		return true // constant
+	}
+	switch e := e.(type) {
+	case *ast.Ident, *ast.BasicLit, *ast.FuncLit:
+		return true
+	case *ast.ParenExpr:
+		return pure(info, e.X)
+	case *ast.SelectorExpr:
+		if sel, ok := info.Selections[e]; ok {
+			return sel.Kind() == types.FieldVal && !sel.Indirect() && pure(info, e.X)
+		}
+		return true // qualified identifier
+	case *ast.UnaryExpr:
+		return e.Op != token.ARROW && pure(info, e.X)
+	case *ast.BinaryExpr:
+		return e.Op != token.QUO && e.Op != token.REM && pure(info, e.X) && pure(info, e.Y)
+	case *ast.CompositeLit:
+		for _, elt := range e.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				elt = kv.Value
+			}
+			if !pure(info, elt) {
+				return false
+			}
+		}
+		return true
+	case *ast.CallExpr:
+		// A conversion of a pure operand to a non-interface type.
+		if tv, ok := info.Types[e.Fun]; ok && tv.IsType() && len(e.Args) == 1 {
+			return !types.IsInterface(tv.Type) && pure(info, e.Args[0])
+		}
+	}
+	return false
+}
+
+// isParam reports whether obj is a parameter of fn.
+func isParam(fn *types.Func, obj types.Object) bool {
+	return indexOf(fn, obj) >= 0
+}
+
+// indexOf returns the index of the parameter obj of fn, or -1.
+func indexOf(fn *types.Func, obj types.Object) int {
+	params := fn.Signature().Params()
+	for i := range params.Len() {
+		if params.At(i) == obj {
+			return i
+		}
+	}
+	return -1
+}
+
+// imports reports whether file imports the package p.
+func imports(file *ast.File, p *types.Package) bool {
+	for _, imp := range file.Imports {
+		if imp.Path.Value == fmt.Sprintf("%q", p.Path()) {
+			return true
+		}
+	}
+	return false
+}
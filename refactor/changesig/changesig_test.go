@@ -0,0 +1,172 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package changesig_test
+
+import (
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/internal/testfiles"
+	"golang.org/x/tools/refactor/changesig"
+	"golang.org/x/tools/txtar"
+)
+
+const src = `
+-- go.mod --
+module example.com
+
+go 1.22
+
+-- p/p.go --
+package p
+
+import "context"
+
+var _ context.Context
+
+type I interface{ M(x int) }
+
+type T struct{}
+
+func (T) M(x int) {}
+
+func F(a, b int, s string) int { return a + len(s) }
+
+func g() int { return 0 }
+
+func _() {
+	F(1, 2, "x")
+	F(1, g(), "y")
+	_ = F
+	F(F(1, 2, "a"), 0, "b")
+	T{}.M(1)
+	T.M(T{}, 2)
+}
+
+-- q/q.go --
+package q
+
+import "example.com/p"
+
+func H(x int) { p.F(x, x, "z") }
+`
+
+func TestChange(t *testing.T) {
+	pkgs := testfiles.LoadPackages(t, txtar.Parse([]byte(src)), "./...")
+
+	for _, test := range []struct {
+		name     string
+		params   []changesig.Param
+		want     map[string]string // maps file suffix to expected content
+		problems []string
+	}{
+		{
+			name: "F",
+			params: []changesig.Param{
+				{Old: 2},
+				{Old: 0},
+				{Old: -1, Name: "ctx", Type: "context.Context"},
+			},
+			want: map[string]string{
+				"p/p.go": `func F(s string, a int, ctx context.Context) int { return a + len(s) }`,
+				"p/p.go#1": `	F("x", 1, nil)
+	F(1, g(), "y")
+	_ = F
+	F("b", F("a", 1, nil), nil)`,
+				"q/q.go": `func H(x int) { p.F("z", x, nil) }`,
+			},
+			problems: []string{
+				"p/p.go:19:7: cannot remove argument g(), which may have effects",
+				"p/p.go:20:6: reference to F is not a call, so it cannot be updated",
+			},
+		},
+		{
+			name: "M",
+			params: []changesig.Param{
+				{Old: -1, Name: "y", Type: "string", Value: `"y"`},
+				{Old: 0, Type: "int64"},
+			},
+			want: map[string]string{
+				"p/p.go": `func (T) M(y string, x int64) {}`,
+				"p/p.go#1": `	T{}.M("y", 1)
+	T.M(T{}, "y", 2)`,
+			},
+			problems: []string{
+				"p/p.go:11:10: p.T implements p.I, which it may no longer do",
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var pkg *packages.Package
+			for _, p := range pkgs {
+				if p.PkgPath == "example.com/p" {
+					pkg = p
+				}
+			}
+			var fn *types.Func
+			switch test.name {
+			case "F":
+				fn = pkg.Types.Scope().Lookup("F").(*types.Func)
+			case "M":
+				obj, _, _ := types.LookupFieldOrMethod(pkg.Types.Scope().Lookup("T").Type(), false, pkg.Types, "M")
+				fn = obj.(*types.Func)
+			}
+			edits, problems, err := changesig.Change(pkgs, fn, test.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Apply the edits.
+			got := make(map[string]string)
+			byFile := make(map[string][]analysis.TextEdit)
+			for _, edit := range edits {
+				file := pkg.Fset.File(edit.Pos).Name()
+				byFile[file] = append(byFile[file], edit)
+			}
+			for file, edits := range byFile {
+				content, err := os.ReadFile(file)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for i := len(edits) - 1; i >= 0; i-- {
+					edit := edits[i]
+					start, end := pkg.Fset.Position(edit.Pos).Offset, pkg.Fset.Position(edit.End).Offset
+					content = append(content[:start:start], append(edit.NewText, content[end:]...)...)
+				}
+				got[filepath.ToSlash(file)] = string(content)
+			}
+			for key, want := range test.want {
+				suffix, _, _ := strings.Cut(key, "#")
+				found := false
+				for file, content := range got {
+					if strings.HasSuffix(file, suffix) {
+						found = true
+						if !strings.Contains(content, want) {
+							t.Errorf("%s does not contain:\n%s\ngot:\n%s", suffix, want, content)
+						}
+					}
+				}
+				if !found {
+					t.Errorf("%s was not edited", suffix)
+				}
+			}
+
+			var gotProblems []string
+			for _, p := range problems {
+				s := filepath.ToSlash(p.String())
+				gotProblems = append(gotProblems, s[strings.Index(s, "p/p.go"):])
+			}
+			if !reflect.DeepEqual(gotProblems, test.problems) {
+				t.Errorf("problems = %q, want %q", gotProblems, test.problems)
+			}
+		})
+	}
+}
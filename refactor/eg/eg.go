@@ -20,7 +20,8 @@ import (
 )
 
 const Help = `
-This tool implements example-based refactoring of expressions.
+This tool implements example-based refactoring of expressions and
+statements.
 
 The transformation is specified as a Go file defining two functions,
 'before' and 'after', of identical types.  Each function body consists
@@ -65,6 +66,53 @@ Matching of type syntax is semantic, not syntactic: type syntax in the
 pattern matches type syntax in the input if the types are identical.
 Thus, func(x int) matches func(y int).
 
+A pattern may call a method of a wildcard of interface type.  Such a
+pattern matches a call of the method of that name on any expression
+assignable to the interface type, regardless of the method's concrete
+receiver type.  For example, this template:
+
+ 	func before(w io.Writer, s string) (int, error) { return w.Write([]byte(s)) }
+ 	func after(w io.Writer, s string) (int, error)  { return io.WriteString(w, s) }
+
+replaces both buf.Write([]byte(s)) and os.Stdout.Write([]byte(s)),
+where buf is a *bytes.Buffer.
+
+STATEMENT TEMPLATES
+
+If the body of 'before' is not a single return or expression
+statement, the template replaces sequences of statements: each
+occurrence of the statements of 'before', consecutive within a block
+or case clause, is replaced by the statements of 'after', which may
+be of any number.  Local variables declared by 'before' are
+placeholders: each matches any identifier of identical type, and
+subsequent references to it must refer to the same variable.  The
+occurrences of a placeholder's name in 'after' are replaced by the
+matched identifier.  For example, this template:
+
+ 	func before(name string) ([]byte, error) {
+ 		data, err := ioutil.ReadFile(name)
+ 		os.Remove(name)
+ 		return data, err
+ 	}
+ 	func after(name string) ([]byte, error) {
+ 		defer os.Remove(name)
+ 		data, err := os.ReadFile(name)
+ 		return data, err
+ 	}
+
+would change this input:
+	b, err := ioutil.ReadFile(tmp)
+	os.Remove(tmp)
+	return b, err
+to this output:
+	defer os.Remove(tmp)
+	b, err := os.ReadFile(tmp)
+	return b, err
+
+Only simple statements, return, block, and if statements are matched.
+Other local variables declared by 'after' are not renamed, so they may
+conflict with those of the input.
+
 This tool was inspired by other example-based refactoring tools,
 'gofmt -r' for Go and Refaster for Java.
 
@@ -74,10 +122,11 @@ LIMITATIONS
 
 EXPRESSIVENESS
 
-Only refactorings that replace one expression with another, regardless
-of the expression's context, may be expressed.  Refactoring arbitrary
-statements (or sequences of statements) is a less well-defined problem
-and is less amenable to this approach.
+Only refactorings that replace one expression with another, or one
+sequence of statements with another, regardless of their context, may
+be expressed.  Refactoring statements that declare labels, or control
+flow statements such as for, switch, and select, is a less
+well-defined problem and is less amenable to this approach.
 
 A pattern that contains a function literal (and hence statements)
 never matches.
@@ -146,7 +195,9 @@ type Transformer struct {
 	wildcards      map[*types.Var]bool                // set of parameters in func before()
 	env            map[string]ast.Expr                // maps parameter name to wildcard binding
 	importedObjs   map[types.Object]*ast.SelectorExpr // objects imported by after().
+	locals         map[*types.Var]bool                // set of local variables of func before()
 	before, after  ast.Expr
+	beforeStmts    []ast.Stmt // statements of before(), for a statement template
 	afterStmts     []ast.Stmt
 	allowWildcards bool
 
@@ -200,45 +251,11 @@ func NewTransformer(fset *token.FileSet, tmplPkg *types.Package, tmplFile *ast.F
 		}
 	}
 
-	before, err := soleExpr(beforeDecl)
-	if err != nil {
-		return nil, fmt.Errorf("before: %s", err)
-	}
-	afterStmts, after, err := stmtAndExpr(afterDecl)
-	if err != nil {
-		return nil, fmt.Errorf("after: %s", err)
-	}
-
 	wildcards := make(map[*types.Var]bool)
 	for i := 0; i < beforeSig.Params().Len(); i++ {
 		wildcards[beforeSig.Params().At(i)] = true
 	}
 
-	// checkExprTypes returns an error if Tb (type of before()) is not
-	// safe to replace with Ta (type of after()).
-	//
-	// Only superficial checks are performed, and they may result in both
-	// false positives and negatives.
-	//
-	// Ideally, we would only require that the replacement be assignable
-	// to the context of a specific pattern occurrence, but the type
-	// checker doesn't record that information and it's complex to deduce.
-	// A Go type cannot capture all the constraints of a given expression
-	// context, which may include the size, constness, signedness,
-	// namedness or constructor of its type, and even the specific value
-	// of the replacement.  (Consider the rule that array literal keys
-	// must be unique.)  So we cannot hope to prove the safety of a
-	// transformation in general.
-	Tb := tmplInfo.TypeOf(before)
-	Ta := tmplInfo.TypeOf(after)
-	if types.AssignableTo(Tb, Ta) {
-		// safe: replacement is assignable to pattern.
-	} else if tuple, ok := Tb.(*types.Tuple); ok && tuple.Len() == 0 {
-		// safe: pattern has void type (must appear in an ExprStmt).
-	} else {
-		return nil, fmt.Errorf("%s is not a safe replacement for %s", Ta, Tb)
-	}
-
 	tr := &Transformer{
 		fset:           fset,
 		verbose:        verbose,
@@ -246,9 +263,43 @@ func NewTransformer(fset *token.FileSet, tmplPkg *types.Package, tmplFile *ast.F
 		allowWildcards: true,
 		seenInfos:      make(map[*types.Info]bool),
 		importedObjs:   make(map[types.Object]*ast.SelectorExpr),
-		before:         before,
-		after:          after,
-		afterStmts:     afterStmts,
+	}
+
+	if isStmtTemplate(beforeDecl) {
+		if len(beforeDecl.Body.List) == 0 {
+			return nil, fmt.Errorf("before: must contain at least one statement")
+		}
+		if afterDecl.Body == nil {
+			return nil, fmt.Errorf("after: no body")
+		}
+		tr.beforeStmts = beforeDecl.Body.List
+		tr.afterStmts = afterDecl.Body.List
+
+		// The local variables of before() are placeholders.
+		tr.locals = make(map[*types.Var]bool)
+		ast.Inspect(beforeDecl.Body, func(n ast.Node) bool {
+			if id, ok := n.(*ast.Ident); ok && id.Name != "_" {
+				if v, ok := tmplInfo.Defs[id].(*types.Var); ok {
+					tr.locals[v] = true
+				}
+			}
+			return true
+		})
+	} else {
+		before, err := soleExpr(beforeDecl)
+		if err != nil {
+			return nil, fmt.Errorf("before: %s", err)
+		}
+		afterStmts, after, err := stmtAndExpr(afterDecl)
+		if err != nil {
+			return nil, fmt.Errorf("after: %s", err)
+		}
+		if err := checkExprTypes(tmplInfo, before, after); err != nil {
+			return nil, err
+		}
+		tr.before = before
+		tr.after = after
+		tr.afterStmts = afterStmts
 	}
 
 	// Combine type info from the template and input packages, and
@@ -265,7 +316,7 @@ func NewTransformer(fset *token.FileSet, tmplPkg *types.Package, tmplFile *ast.F
 
 	// Compute set of imported objects required by after().
 	// TODO(adonovan): reject dot-imports in pattern
-	ast.Inspect(after, func(n ast.Node) bool {
+	ast.Inspect(afterDecl.Body, func(n ast.Node) bool {
 		if n, ok := n.(*ast.SelectorExpr); ok {
 			if _, ok := tr.info.Selections[n]; !ok {
 				// qualified ident
@@ -280,6 +331,34 @@ func NewTransformer(fset *token.FileSet, tmplPkg *types.Package, tmplFile *ast.F
 	return tr, nil
 }
 
+// checkExprTypes returns an error if Tb (type of before()) is not
+// safe to replace with Ta (type of after()).
+//
+// Only superficial checks are performed, and they may result in both
+// false positives and negatives.
+//
+// Ideally, we would only require that the replacement be assignable
+// to the context of a specific pattern occurrence, but the type
+// checker doesn't record that information and it's complex to deduce.
+// A Go type cannot capture all the constraints of a given expression
+// context, which may include the size, constness, signedness,
+// namedness or constructor of its type, and even the specific value
+// of the replacement.  (Consider the rule that array literal keys
+// must be unique.)  So we cannot hope to prove the safety of a
+// transformation in general.
+func checkExprTypes(info *types.Info, before, after ast.Expr) error {
+	Tb := info.TypeOf(before)
+	Ta := info.TypeOf(after)
+	if types.AssignableTo(Tb, Ta) {
+		// safe: replacement is assignable to pattern.
+	} else if tuple, ok := Tb.(*types.Tuple); ok && tuple.Len() == 0 {
+		// safe: pattern has void type (must appear in an ExprStmt).
+	} else {
+		return fmt.Errorf("%s is not a safe replacement for %s", Ta, Tb)
+	}
+	return nil
+}
+
 // WriteAST is a convenience function that writes AST f to the specified file.
 func WriteAST(fset *token.FileSet, filename string, f *ast.File) (err error) {
 	fh, err := os.Create(filename)
@@ -305,6 +384,22 @@ func funcSig(pkg *types.Package, name string) *types.Signature {
 	return nil
 }
 
+// isStmtTemplate reports whether the before template function fn
+// defines a statement template: one whose body is not a single return
+// or expression statement.
+func isStmtTemplate(fn *ast.FuncDecl) bool {
+	if fn.Body == nil {
+		return false // (reported by soleExpr)
+	}
+	if len(fn.Body.List) == 1 {
+		switch fn.Body.List[0].(type) {
+		case *ast.ReturnStmt, *ast.ExprStmt:
+			return false
+		}
+	}
+	return true
+}
+
 // soleExpr returns the sole expression in the before/after template function.
 func soleExpr(fn *ast.FuncDecl) (ast.Expr, error) {
 	if fn.Body == nil {
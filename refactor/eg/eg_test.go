@@ -63,6 +63,8 @@ func Test(t *testing.T) {
 		"testdata/h.txtar",
 		"testdata/i.txtar",
 		"testdata/j.txtar",
+		"testdata/k.txtar",
+		"testdata/l.txtar",
 		"testdata/bad_type.txtar",
 		"testdata/no_before.txtar",
 		"testdata/no_after_return.txtar",
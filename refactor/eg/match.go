@@ -40,6 +40,11 @@ func (tr *Transformer) matchExpr(x, y ast.Expr) bool {
 		return tr.matchWildcard(xobj, y)
 	}
 
+	// Is x a placeholder?  (a 'before' local variable)
+	if xobj, ok := tr.localObj(x); ok {
+		return tr.matchLocal(xobj, y)
+	}
+
 	// Object identifiers (including pkg-qualified ones)
 	// are handled semantically, not syntactically.
 	xobj := isRef(x, tr.info)
@@ -60,6 +65,9 @@ func (tr *Transformer) matchExpr(x, y ast.Expr) bool {
 	}
 	switch x := x.(type) {
 	case *ast.Ident:
+		if x.Name == "_" {
+			return y.(*ast.Ident).Name == "_"
+		}
 		log.Fatalf("unexpected Ident: %s", astString(tr.fset, x))
 
 	case *ast.BasicLit:
@@ -80,6 +88,15 @@ func (tr *Transformer) matchExpr(x, y ast.Expr) bool {
 
 	case *ast.SelectorExpr:
 		y := y.(*ast.SelectorExpr)
+		if xobj, ok := tr.interfaceMethodWildcard(x); ok {
+			// A method of a wildcard of interface type
+			// matches the method of the same name of any
+			// type assignable to it.
+			ysel, ok := tr.info.Selections[y]
+			return ok && ysel.Kind() == types.MethodVal &&
+				ysel.Obj().Id() == tr.info.Selections[x].Obj().Id() &&
+				tr.matchWildcard(xobj, y.X)
+		}
 		return tr.matchSelectorExpr(x, y) &&
 			tr.info.Selections[x].Obj() == tr.info.Selections[y].Obj()
 
@@ -147,6 +164,83 @@ func (tr *Transformer) matchExprs(xx, yy []ast.Expr) bool {
 	return true
 }
 
+// matchStmts reports whether the statements of pattern xx match yy.
+func (tr *Transformer) matchStmts(xx, yy []ast.Stmt) bool {
+	if len(xx) != len(yy) {
+		return false
+	}
+	for i := range xx {
+		if !tr.matchStmt(xx[i], yy[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchStmt reports whether pattern statement x matches y.
+// Only simple statements, return, block, and if statements match.
+func (tr *Transformer) matchStmt(x, y ast.Stmt) bool {
+	if x == nil && y == nil {
+		return true
+	}
+	if x == nil || y == nil {
+		return false
+	}
+	if reflect.TypeOf(x) != reflect.TypeOf(y) {
+		return false
+	}
+	switch x := x.(type) {
+	case *ast.EmptyStmt:
+		return true
+
+	case *ast.ExprStmt:
+		y := y.(*ast.ExprStmt)
+		return tr.matchExpr(x.X, y.X)
+
+	case *ast.SendStmt:
+		y := y.(*ast.SendStmt)
+		return tr.matchExpr(x.Chan, y.Chan) &&
+			tr.matchExpr(x.Value, y.Value)
+
+	case *ast.IncDecStmt:
+		y := y.(*ast.IncDecStmt)
+		return x.Tok == y.Tok &&
+			tr.matchExpr(x.X, y.X)
+
+	case *ast.AssignStmt:
+		y := y.(*ast.AssignStmt)
+		return x.Tok == y.Tok &&
+			tr.matchExprs(x.Lhs, y.Lhs) &&
+			tr.matchExprs(x.Rhs, y.Rhs)
+
+	case *ast.GoStmt:
+		y := y.(*ast.GoStmt)
+		return tr.matchExpr(x.Call, y.Call)
+
+	case *ast.DeferStmt:
+		y := y.(*ast.DeferStmt)
+		return tr.matchExpr(x.Call, y.Call)
+
+	case *ast.ReturnStmt:
+		y := y.(*ast.ReturnStmt)
+		return tr.matchExprs(x.Results, y.Results)
+
+	case *ast.BlockStmt:
+		y := y.(*ast.BlockStmt)
+		return tr.matchStmts(x.List, y.List)
+
+	case *ast.IfStmt:
+		y := y.(*ast.IfStmt)
+		return tr.matchStmt(x.Init, y.Init) &&
+			tr.matchExpr(x.Cond, y.Cond) &&
+			tr.matchStmt(x.Body, y.Body) &&
+			tr.matchStmt(x.Else, y.Else)
+	}
+
+	// Declarations, labels, and other control flow never match.
+	return false
+}
+
 // matchType reports whether the two type ASTs denote identical types.
 func (tr *Transformer) matchType(x, y ast.Expr) bool {
 	tx := tr.info.Types[x].Type
@@ -163,6 +257,28 @@ func (tr *Transformer) wildcardObj(x ast.Expr) (*types.Var, bool) {
 	return nil, false
 }
 
+// interfaceMethodWildcard returns the wildcard x.X if x selects a
+// method of it and its type is an interface.
+func (tr *Transformer) interfaceMethodWildcard(x *ast.SelectorExpr) (*types.Var, bool) {
+	xobj, ok := tr.wildcardObj(x.X)
+	if !ok || !types.IsInterface(xobj.Type()) {
+		return nil, false
+	}
+	sel, ok := tr.info.Selections[x]
+	return xobj, ok && sel.Kind() == types.MethodVal
+}
+
+// localObj returns the local variable of before() to which x refers,
+// if x is a placeholder of a statement template.
+func (tr *Transformer) localObj(x ast.Expr) (*types.Var, bool) {
+	if x, ok := x.(*ast.Ident); ok && tr.allowWildcards {
+		if xobj, ok := tr.info.ObjectOf(x).(*types.Var); ok && tr.locals[xobj] {
+			return xobj, true
+		}
+	}
+	return nil, false
+}
+
 func (tr *Transformer) matchSelectorExpr(x, y *ast.SelectorExpr) bool {
 	if xobj, ok := tr.wildcardObj(x.X); ok {
 		field := x.Sel.Name
@@ -225,6 +341,27 @@ func (tr *Transformer) matchWildcard(xobj *types.Var, y ast.Expr) bool {
 	return true
 }
 
+// matchLocal reports whether the placeholder xobj matches y, an
+// identifier that refers to a variable of identical type. Like a
+// wildcard, a placeholder that appears more than once in the pattern
+// must consistently match the same variable.
+func (tr *Transformer) matchLocal(xobj *types.Var, y ast.Expr) bool {
+	id, ok := y.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	yobj, ok := tr.info.ObjectOf(id).(*types.Var)
+	if !ok || !types.Identical(yobj.Type(), xobj.Type()) {
+		return false
+	}
+	if old, ok := tr.env[xobj.Name()]; ok {
+		old, ok := old.(*ast.Ident)
+		return ok && tr.info.ObjectOf(old) == yobj
+	}
+	tr.env[xobj.Name()] = id // record binding
+	return true
+}
+
 // -- utilities --------------------------------------------------------
 
 // isRef returns the object referred to by this (possibly qualified)
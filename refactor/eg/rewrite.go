@@ -34,7 +34,7 @@ func (tr *Transformer) transformItem(rv reflect.Value) (reflect.Value, bool, map
 	rv, changed, newEnv := tr.apply(tr.transformItem, rv)
 
 	e := rvToExpr(rv)
-	if e == nil {
+	if e == nil || tr.before == nil {
 		return rv, changed, newEnv
 	}
 
@@ -68,6 +68,49 @@ func (tr *Transformer) transformItem(rv reflect.Value) (reflect.Value, bool, map
 	return rv, changed, newEnv
 }
 
+// transformStmts replaces each occurrence of the statements of a
+// statement template in list, whose elements have been transformed.
+func (tr *Transformer) transformStmts(list []ast.Stmt) []ast.Stmt {
+	n := len(tr.beforeStmts)
+	var out []ast.Stmt
+	for i := 0; i < len(list); {
+		if i+n > len(list) {
+			out = append(out, list[i:]...)
+			break
+		}
+
+		savedEnv := tr.env
+		tr.env = make(map[string]ast.Expr)
+
+		if tr.matchStmts(tr.beforeStmts, list[i:i+n]) {
+			if tr.verbose {
+				fmt.Fprintf(os.Stderr, "%s: statements match\n", tr.fset.Position(list[i].Pos()))
+			}
+			tr.nsubsts++
+
+			// Clone the replacement statements, performing
+			// parameter substitution. Each takes the position
+			// of the corresponding matched statement, and the
+			// last that of the last, to aid comment placement.
+			for j, s := range tr.afterStmts {
+				k := min(j, n-1)
+				if j == len(tr.afterStmts)-1 {
+					k = n - 1
+				}
+				pos := reflect.ValueOf(list[i+k].Pos())
+				t := tr.subst(tr.env, reflect.ValueOf(s), pos).Interface()
+				out = append(out, t.(ast.Stmt))
+			}
+			i += n
+		} else {
+			out = append(out, list[i])
+			i++
+		}
+		tr.env = savedEnv
+	}
+	return out
+}
+
 // Transform applies the transformation to the specified parsed file,
 // whose type information is supplied in info, and returns the number
 // of replacements that were made.
@@ -86,9 +129,14 @@ func (tr *Transformer) Transform(info *types.Info, pkg *types.Package, file *ast
 	tr.nsubsts = 0
 
 	if tr.verbose {
-		fmt.Fprintf(os.Stderr, "before: %s\n", astString(tr.fset, tr.before))
-		fmt.Fprintf(os.Stderr, "after: %s\n", astString(tr.fset, tr.after))
-		fmt.Fprintf(os.Stderr, "afterStmts: %s\n", tr.afterStmts)
+		if tr.beforeStmts != nil {
+			fmt.Fprintf(os.Stderr, "before: %s\n", astString(tr.fset, &ast.BlockStmt{List: tr.beforeStmts}))
+			fmt.Fprintf(os.Stderr, "after: %s\n", astString(tr.fset, &ast.BlockStmt{List: tr.afterStmts}))
+		} else {
+			fmt.Fprintf(os.Stderr, "before: %s\n", astString(tr.fset, tr.before))
+			fmt.Fprintf(os.Stderr, "after: %s\n", astString(tr.fset, tr.after))
+			fmt.Fprintf(os.Stderr, "afterStmts: %s\n", tr.afterStmts)
+		}
 	}
 
 	o, changed, _ := tr.apply(tr.transformItem, reflect.ValueOf(file))
@@ -225,6 +273,9 @@ func (tr *Transformer) apply(f func(reflect.Value) (reflect.Value, bool, map[str
 			setValue(e, o)
 			out = append(out, e.Interface().(ast.Stmt))
 		}
+		if tr.beforeStmts != nil {
+			out = tr.transformStmts(out)
+		}
 		return reflect.ValueOf(out), false, nil
 	case reflect.Struct:
 		changed := false
@@ -273,6 +324,13 @@ func (tr *Transformer) subst(env map[string]ast.Expr, pattern, pos reflect.Value
 	if env != nil && pattern.Type() == identType {
 		id := pattern.Interface().(*ast.Ident)
 		if old, ok := env[id.Name]; ok {
+			if _, ok := old.(*ast.Ident); ok {
+				// Identifiers, such as those bound to the
+				// placeholders of statement templates, take
+				// the new position, as they may be bound at
+				// a distance.
+				return tr.subst(nil, reflect.ValueOf(old), pos)
+			}
 			return tr.subst(nil, reflect.ValueOf(old), reflect.Value{})
 		}
 	}
@@ -0,0 +1,461 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package extract implements the "extract function" refactoring: the
+// replacement of a sequence of statements by a call of a new function
+// whose body is those statements.
+//
+// The parameters of the new function are the local variables declared
+// outside the statements that they refer to, as reported by
+// [freevars.Of]. Its results are the variables that the statements
+// assign and that are referred to after them, either because they
+// were declared by the statements or because the statements update
+// them. The call assigns the results to those variables.
+//
+// The package computes text edits, so that it may be used by
+// interactive tools, command-line tools, and the suggested fixes of
+// analyzers alike.
+package extract // import "golang.org/x/tools/refactor/extract"
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/types/freevars"
+	"golang.org/x/tools/internal/typesinternal"
+)
+
+// Function returns the edits that extract the statements of file
+// within the interval [start, end) into a new package-level function
+// named name, declared after the declaration that encloses them, and
+// replace them by a call of it. The interval must consist of one or
+// more complete statements of the same block or case clause, which
+// must not contain a return, defer, goto, or labeled statement, nor a
+// break or continue statement whose target is outside the interval.
+//
+// src is the content of file, and info is its type information, which
+// must include Types, Defs, Uses, Selections, and Scopes.
+//
+// The variables that the statements refer to are passed to the
+// function by value, and those that they update are returned, so the
+// function acts on copies of them. Function therefore rejects
+// statements that take the address of such a variable, that refer to
+// one from a function literal while it may be updated elsewhere, or
+// that refer to one that is shared with a function literal or pointer
+// outside them.
+func Function(fset *token.FileSet, pkg *types.Package, info *types.Info, file *ast.File, src []byte, start, end token.Pos, name string) ([]analysis.TextEdit, error) {
+	if !token.IsIdentifier(name) {
+		return nil, fmt.Errorf("invalid function name %q", name)
+	}
+	if pkg.Scope().Lookup(name) != nil {
+		return nil, fmt.Errorf("%s is already declared in package %s", name, pkg.Name())
+	}
+	tokFile := fset.File(file.FileStart)
+	if tokFile == nil || tokFile.Size() != len(src) {
+		return nil, fmt.Errorf("source does not match file")
+	}
+
+	// Find the selected statements.
+	path, _ := astutil.PathEnclosingInterval(file, start, end)
+	var (
+		list  []ast.Stmt // statements of the enclosing block or clause
+		scope *types.Scope
+	)
+	for i, n := range path {
+		switch n := n.(type) {
+		case *ast.BlockStmt:
+			list = n.List
+		case *ast.CaseClause:
+			list = n.Body
+		case *ast.CommClause:
+			list = n.Body
+		default:
+			continue
+		}
+		scope = info.Scopes[n]
+		if scope == nil && i+1 < len(path) {
+			// The scope of a function body is that of its type.
+			switch fn := path[i+1].(type) {
+			case *ast.FuncDecl:
+				scope = info.Scopes[fn.Type]
+			case *ast.FuncLit:
+				scope = info.Scopes[fn.Type]
+			}
+		}
+		break
+	}
+	var stmts []ast.Stmt
+	for _, stmt := range list {
+		if stmt.End() <= start || end <= stmt.Pos() {
+			continue // outside
+		}
+		if stmt.Pos() < start || end < stmt.End() {
+			return nil, fmt.Errorf("selection does not consist of complete statements")
+		}
+		stmts = append(stmts, stmt)
+	}
+	if len(stmts) == 0 || scope == nil {
+		return nil, fmt.Errorf("selection does not contain statements")
+	}
+	first, last := stmts[0], stmts[len(stmts)-1]
+	if err := checkControl(stmts); err != nil {
+		return nil, err
+	}
+
+	// Find the top-level declaration that encloses the statements,
+	// and the body of the function that does.
+	var (
+		decl  ast.Decl
+		ftype *ast.FuncType
+		body  *ast.BlockStmt
+	)
+	for _, n := range path {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			if body == nil {
+				ftype, body = n.Type, n.Body
+			}
+		case *ast.FuncDecl:
+			if body == nil {
+				ftype, body = n.Type, n.Body
+			}
+			decl = n
+		case *ast.GenDecl:
+			decl = n
+		}
+	}
+	if decl == nil || body == nil {
+		return nil, fmt.Errorf("selection is not within a function")
+	}
+
+	// The parameters are the free variables of the statements.
+	// The results are those that the statements update, and
+	// those that they declare, that are referred to outside them.
+	// (A variable that is referred to before the statements may be
+	// referred to after them in a loop.)
+	block := &ast.BlockStmt{Lbrace: first.Pos(), List: stmts, Rbrace: last.End() - 1}
+	usedOutside := make(map[*types.Var]bool)
+	if ftype.Results != nil {
+		for _, field := range ftype.Results.List {
+			for _, id := range field.Names {
+				if v, ok := info.Defs[id].(*types.Var); ok {
+					usedOutside[v] = true // (by a return statement)
+				}
+			}
+		}
+	}
+	for id, obj := range info.Uses {
+		if v, ok := obj.(*types.Var); ok && body.Pos() < id.Pos() && id.Pos() < body.End() &&
+			(id.Pos() < first.Pos() || last.End() <= id.Pos()) {
+			usedOutside[v] = true
+		}
+	}
+	var params, updated, declared []*types.Var
+	shared := sharedVars(info, body, first.Pos(), last.End())
+	for _, fv := range freevars.Of(info, block) {
+		if err := checkCopy(fv, shared[fv.Obj]); err != nil {
+			return nil, err
+		}
+		params = append(params, fv.Obj)
+		if fv.Written && usedOutside[fv.Obj] {
+			updated = append(updated, fv.Obj)
+		}
+	}
+	ast.Inspect(block, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			if v, ok := info.Defs[id].(*types.Var); ok && v.Parent() == scope && usedOutside[v] {
+				declared = append(declared, v)
+			}
+		}
+		return true
+	})
+	results := append(updated, declared...)
+
+	// Format the declaration of the new function, using the
+	// original text of the statements.
+	qual := typesinternal.FileQualifier(file, pkg)
+	typeString := func(v *types.Var) (string, error) {
+		var err error
+		t := types.TypeString(v.Type(), func(p *types.Package) string {
+			if p != pkg && !imports(file, p) {
+				err = fmt.Errorf("type of %s refers to package %s, which %s does not import",
+					v.Name(), p.Path(), tokFile.Name())
+			}
+			return qual(p)
+		})
+		if typeparams(v.Type()) {
+			err = fmt.Errorf("type of %s refers to a type parameter", v.Name())
+		}
+		return t, err
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "func %s(", name)
+	for i, v := range params {
+		t, err := typeString(v)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(&buf, "%s %s", v.Name(), t)
+	}
+	buf.WriteString(") ")
+	if len(results) > 0 {
+		buf.WriteString("(")
+		for i, v := range results {
+			t, err := typeString(v)
+			if err != nil {
+				return nil, err
+			}
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(t)
+		}
+		buf.WriteString(") ")
+	}
+	buf.WriteString("{\n")
+	buf.Write(src[tokFile.Offset(first.Pos()):tokFile.Offset(last.End())])
+	if len(results) > 0 {
+		fmt.Fprintf(&buf, "\nreturn %s", names(results))
+	}
+	buf.WriteString("\n}\n")
+	funcText, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting new function: %v", err)
+	}
+
+	// Format the call. A variable declared by the statements is
+	// declared by the call, or, if the call also updates others,
+	// by a preceding var declaration.
+	var call strings.Builder
+	args := names(params)
+	switch {
+	case len(results) == 0:
+		fmt.Fprintf(&call, "%s(%s)", name, args)
+	case len(updated) == 0:
+		fmt.Fprintf(&call, "%s := %s(%s)", names(results), name, args)
+	default:
+		line := src[tokFile.Offset(tokFile.LineStart(tokFile.Line(first.Pos()))):tokFile.Offset(first.Pos())]
+		indent := line[:len(line)-len(bytes.TrimLeft(line, " \t"))]
+		for _, v := range declared {
+			t, _ := typeString(v) // error already reported
+			fmt.Fprintf(&call, "var %s %s\n%s", v.Name(), t, indent)
+		}
+		fmt.Fprintf(&call, "%s = %s(%s)", names(results), name, args)
+	}
+
+	return []analysis.TextEdit{
+		{
+			Pos:     first.Pos(),
+			End:     last.End(),
+			NewText: []byte(call.String()),
+		},
+		{
+			Pos:     decl.End(),
+			End:     decl.End(),
+			NewText: append([]byte("\n\n"), bytes.TrimSuffix(funcText, []byte("\n"))...),
+		},
+	}, nil
+}
+
+// checkControl returns an error if the statements contain a statement
+// whose effect on control flow would differ in another function.
+func checkControl(stmts []ast.Stmt) error {
+	var err error
+	var check func(n ast.Node, loop, breakable bool)
+	check = func(n ast.Node, loop, breakable bool) {
+		ast.Inspect(n, func(n ast.Node) bool {
+			if err != nil {
+				return false
+			}
+			switch n := n.(type) {
+			case *ast.FuncLit:
+				return false
+
+			case *ast.ReturnStmt:
+				err = fmt.Errorf("cannot extract a return statement")
+
+			case *ast.DeferStmt:
+				err = fmt.Errorf("cannot extract a defer statement")
+
+			case *ast.LabeledStmt:
+				err = fmt.Errorf("cannot extract a labeled statement")
+
+			case *ast.BranchStmt:
+				if n.Label != nil || n.Tok == token.GOTO || n.Tok == token.FALLTHROUGH ||
+					n.Tok == token.BREAK && !breakable ||
+					n.Tok == token.CONTINUE && !loop {
+					err = fmt.Errorf("cannot extract a %s statement whose target is outside the selection", n.Tok)
+				}
+
+			case *ast.ForStmt:
+				check(n.Body, true, true)
+				return false
+
+			case *ast.RangeStmt:
+				check(n.Body, true, true)
+				return false
+
+			case *ast.SwitchStmt:
+				check(n.Body, loop, true)
+				return false
+
+			case *ast.TypeSwitchStmt:
+				check(n.Body, loop, true)
+				return false
+
+			case *ast.SelectStmt:
+				check(n.Body, loop, true)
+				return false
+			}
+			return true
+		})
+	}
+	for _, stmt := range stmts {
+		check(stmt, false, false)
+	}
+	return err
+}
+
+// A sharing records how the rest of a function body uses a free
+// variable of the extracted statements: directly, or from the function
+// literals outside them.
+type sharing struct {
+	direct, closure freevars.Var
+}
+
+// sharedVars returns the uses of the variables of the function body
+// outside the interval [start, end), by the variables they refer to.
+func sharedVars(info *types.Info, body *ast.BlockStmt, start, end token.Pos) map[*types.Var]*sharing {
+	shared := make(map[*types.Var]*sharing)
+	record := func(fv *freevars.Var, closure bool) {
+		sh := shared[fv.Obj]
+		if sh == nil {
+			sh = new(sharing)
+			shared[fv.Obj] = sh
+		}
+		u := &sh.direct
+		if closure {
+			u = &sh.closure
+		}
+		u.Read = u.Read || fv.Read
+		u.Written = u.Written || fv.Written
+		u.AddrTaken = u.AddrTaken || fv.AddrTaken
+		u.Captured = u.Captured || fv.Captured
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		if n == nil || start <= n.Pos() && n.End() <= end {
+			return false // within the statements
+		}
+		if n.End() <= start || end <= n.Pos() {
+			// The variables declared outside n that it refers
+			// to include those of the statements.
+			for _, fv := range freevars.Of(info, n) {
+				record(fv, false)
+			}
+			ast.Inspect(n, func(n ast.Node) bool {
+				if lit, ok := n.(*ast.FuncLit); ok {
+					for _, fv := range freevars.Of(info, lit) {
+						record(fv, true)
+					}
+				}
+				return true
+			})
+			return false
+		}
+		return true // n encloses the statements
+	})
+	return shared
+}
+
+// checkCopy returns an error if the statements would behave
+// differently if the free variable fv were replaced by a copy of it,
+// given how the rest of the function uses it, if at all.
+func checkCopy(fv *freevars.Var, sh *sharing) error {
+	name := fv.Obj.Name()
+	if fv.AddrTaken {
+		return fmt.Errorf("cannot extract statements that take the address of %s, as the new function would take that of a copy", name)
+	}
+	if sh == nil {
+		return nil
+	}
+	if sh.direct.AddrTaken || sh.closure.AddrTaken {
+		return fmt.Errorf("cannot extract statements that refer to %s, whose address is taken outside them", name)
+	}
+	if sh.closure.Read && fv.Written || sh.closure.Written {
+		return fmt.Errorf("cannot extract statements that refer to %s, which is shared with a function literal outside them", name)
+	}
+	if fv.Captured && sh.direct.Written {
+		return fmt.Errorf("cannot extract statements whose function literals refer to %s, which is updated outside them", name)
+	}
+	return nil
+}
+
+// imports reports whether file imports the package p.
+func imports(file *ast.File, p *types.Package) bool {
+	for _, imp := range file.Imports {
+		if imp.Path.Value == fmt.Sprintf("%q", p.Path()) {
+			return true
+		}
+	}
+	return false
+}
+
+// typeparams reports whether t refers to a type parameter.
+func typeparams(t types.Type) bool {
+	found := false
+	var visit func(t types.Type)
+	visit = func(t types.Type) {
+		switch t := t.(type) {
+		case *types.TypeParam:
+			found = true
+		case *types.Pointer:
+			visit(t.Elem())
+		case *types.Slice:
+			visit(t.Elem())
+		case *types.Array:
+			visit(t.Elem())
+		case *types.Chan:
+			visit(t.Elem())
+		case *types.Map:
+			visit(t.Key())
+			visit(t.Elem())
+		case *types.Named:
+			for t := range t.TypeArgs().Types() {
+				visit(t)
+			}
+		case *types.Signature:
+			for v := range t.Params().Variables() {
+				visit(v.Type())
+			}
+			for v := range t.Results().Variables() {
+				visit(v.Type())
+			}
+		case *types.Struct:
+			for f := range t.Fields() {
+				visit(f.Type())
+			}
+		}
+	}
+	visit(t)
+	return found
+}
+
+// names returns the comma-separated names of vars.
+func names(vars []*types.Var) string {
+	var names []string
+	for _, v := range vars {
+		names = append(names, v.Name())
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,339 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package extract_test
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/refactor/extract"
+)
+
+func TestFunction(t *testing.T) {
+	// The statements to extract lie between /*<*/ and /*>*/.
+	for _, test := range []struct {
+		name, src, want string
+	}{
+		{
+			name: "params",
+			src: `package p
+
+import "fmt"
+
+func f(x, y int) {
+	/*<*/z := x + y
+	fmt.Println(z) /*>*/
+}
+`,
+			want: `package p
+
+import "fmt"
+
+func f(x, y int) {
+	/*<*/g(x, y) /*>*/
+}
+
+func g(x int, y int) {
+	z := x + y
+	fmt.Println(z)
+}
+`,
+		},
+		{
+			name: "declared",
+			src: `package p
+
+func f(s []string) int {
+	/*<*/n := len(s)
+	m := n * 2 /*>*/
+	return m
+}
+`,
+			want: `package p
+
+func f(s []string) int {
+	/*<*/m := g(s) /*>*/
+	return m
+}
+
+func g(s []string) int {
+	n := len(s)
+	m := n * 2
+	return m
+}
+`,
+		},
+		{
+			name: "updated",
+			src: `package p
+
+func f(s []int) (sum int) {
+	for _, x := range s {
+		/*<*/if x > 0 {
+			// count positives
+			sum += x
+		}/*>*/
+	}
+	return
+}
+`,
+			want: `package p
+
+func f(s []int) (sum int) {
+	for _, x := range s {
+		/*<*/sum = g(x, sum)/*>*/
+	}
+	return
+}
+
+func g(x int, sum int) int {
+	if x > 0 {
+		// count positives
+		sum += x
+	}
+	return sum
+}
+`,
+		},
+		{
+			name: "mixed",
+			src: `package p
+
+func f(i int) int {
+	/*<*/i++
+	j := i * i/*>*/
+	return i + j
+}
+`,
+			want: `package p
+
+func f(i int) int {
+	/*<*/var j int
+	i, j = g(i)/*>*/
+	return i + j
+}
+
+func g(i int) (int, int) {
+	i++
+	j := i * i
+	return i, j
+}
+`,
+		},
+		{
+			name: "loop",
+			src: `package p
+
+func f(s []int) {
+	/*<*/for _, x := range s {
+		if x < 0 {
+			break
+		}
+	}/*>*/
+}
+`,
+			want: `package p
+
+func f(s []int) {
+	/*<*/g(s)/*>*/
+}
+
+func g(s []int) {
+	for _, x := range s {
+		if x < 0 {
+			break
+		}
+	}
+}
+`,
+		},
+		{
+			name: "return",
+			src: `package p
+
+func f(x int) int {
+	/*<*/if x > 0 {
+		return x
+	}/*>*/
+	return 0
+}
+`,
+			want: "error: cannot extract a return statement",
+		},
+		{
+			name: "break",
+			src: `package p
+
+func f(s []int) {
+	for _, x := range s {
+		/*<*/if x < 0 {
+			break
+		}/*>*/
+	}
+}
+`,
+			want: "error: cannot extract a break statement whose target is outside the selection",
+		},
+		{
+			name: "closure",
+			src: `package p
+
+import "sort"
+
+func f(s []int) []int {
+	/*<*/sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })/*>*/
+	return s
+}
+`,
+			want: `package p
+
+import "sort"
+
+func f(s []int) []int {
+	/*<*/g(s)/*>*/
+	return s
+}
+
+func g(s []int) {
+	sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })
+}
+`,
+		},
+		{
+			name: "addr",
+			src: `package p
+
+func f() int {
+	x := 0
+	/*<*/p := &x
+	*p = 1/*>*/
+	return x
+}
+`,
+			want: "error: cannot extract statements that take the address of x, as the new function would take that of a copy",
+		},
+		{
+			name: "addr-method",
+			src: `package p
+
+type T struct{ n int }
+
+func (t *T) inc() { t.n++ }
+
+func f() int {
+	var t T
+	/*<*/t.inc()/*>*/
+	return t.n
+}
+`,
+			want: "error: cannot extract statements that take the address of t, as the new function would take that of a copy",
+		},
+		{
+			name: "addr-outside",
+			src: `package p
+
+func f() int {
+	x := 0
+	p := &x
+	/*<*/x++/*>*/
+	return *p
+}
+`,
+			want: "error: cannot extract statements that refer to x, whose address is taken outside them",
+		},
+		{
+			name: "captured-outside",
+			src: `package p
+
+func f() int {
+	x := 0
+	inc := func() { x++ }
+	/*<*/inc()
+	y := x/*>*/
+	return y
+}
+`,
+			want: "error: cannot extract statements that refer to x, which is shared with a function literal outside them",
+		},
+		{
+			name: "captured-inside",
+			src: `package p
+
+func f() int {
+	x := 0
+	var get func() int
+	/*<*/get = func() int { return x }/*>*/
+	x = 1
+	return get()
+}
+`,
+			want: "error: cannot extract statements whose function literals refer to x, which is updated outside them",
+		},
+		{
+			name: "partial",
+			src: `package p
+
+func f(x int) int {
+	y := /*<*/x + 1
+	return y/*>*/
+}
+`,
+			want: "error: selection does not consist of complete statements",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "p.go", test.src, parser.ParseComments)
+			if err != nil {
+				t.Fatal(err)
+			}
+			info := &types.Info{
+				Types:      make(map[ast.Expr]types.TypeAndValue),
+				Defs:       make(map[*ast.Ident]types.Object),
+				Uses:       make(map[*ast.Ident]types.Object),
+				Selections: make(map[*ast.SelectorExpr]*types.Selection),
+				Scopes:     make(map[ast.Node]*types.Scope),
+			}
+			conf := types.Config{Importer: importer.Default()}
+			pkg, err := conf.Check("p", fset, []*ast.File{f}, info)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tokFile := fset.File(f.FileStart)
+			start := tokFile.Pos(strings.Index(test.src, "/*<*/") + len("/*<*/"))
+			end := tokFile.Pos(strings.Index(test.src, "/*>*/"))
+
+			edits, err := extract.Function(fset, pkg, info, f, []byte(test.src), start, end, "g")
+			var got string
+			if err != nil {
+				got = "error: " + err.Error()
+			} else {
+				got = applyEdits(fset, test.src, edits)
+			}
+			if got != test.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+func applyEdits(fset *token.FileSet, src string, edits []analysis.TextEdit) string {
+	edits = slices.Clone(edits)
+	slices.SortStableFunc(edits, func(x, y analysis.TextEdit) int {
+		return int(y.Pos - x.Pos) // last first
+	})
+	for _, edit := range edits {
+		start, end := fset.Position(edit.Pos).Offset, fset.Position(edit.End).Offset
+		src = src[:start] + string(edit.NewText) + src[end:]
+	}
+	return src
+}
@@ -0,0 +1,46 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package inline implements the "inline variable" refactoring: the
+// replacement of each use of a local variable or constant by the
+// expression that initializes it, and the deletion of its
+// declaration.
+//
+// The package computes text edits, so that it may be used by
+// interactive tools, command-line tools, and the suggested fixes of
+// analyzers alike, such as a fix that removes a variable that merely
+// shadows another.
+package inline // import "golang.org/x/tools/refactor/inline"
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/internal/refactor/inline"
+)
+
+// Variable returns the edits that replace each use of the local
+// variable or constant declared by the identifier id with the
+// expression that initializes it, and that delete its declaration.
+//
+// src is the content of file, and info is its type information, which
+// must include Types, Defs, Uses, Selections, and Scopes.
+//
+// The variable must be declared alone, by a short variable declaration
+// or a var declaration with an initializer, and must not be updated
+// after its declaration, nor have its address taken. If its initializer
+// may have effects, or depends on variables that may be updated, the
+// variable must be used once, in the statement that follows its
+// declaration, and the use must be the first evaluation with effects
+// of that statement, so that inlining changes neither the number nor
+// the order of evaluations. The free identifiers of the initializer
+// must not be shadowed at any use.
+//
+// Where the type of the initializer differs from that of the variable
+// or constant, the replacement is converted to that type.
+func Variable(fset *token.FileSet, pkg *types.Package, info *types.Info, file *ast.File, src []byte, id *ast.Ident) ([]analysis.TextEdit, error) {
+	return inline.InlineVariable(fset, pkg, info, file, src, id)
+}
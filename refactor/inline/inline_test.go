@@ -0,0 +1,119 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package inline_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"slices"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/refactor/inline"
+)
+
+func TestVariable(t *testing.T) {
+	// Each test inlines the variable or constant x.
+	for _, test := range []struct {
+		name, src, want string
+	}{
+		{
+			name: "pure",
+			src: `package p
+
+func f(a, b int) int {
+	x := a + b
+	return x * x
+}
+`,
+			want: `package p
+
+func f(a, b int) int {
+	return (a + b) * (a + b)
+}
+`,
+		},
+		{
+			name: "const",
+			src: `package p
+
+func f() float64 {
+	const x = 1 << 3
+	var y float64 = x
+	return y
+}
+`,
+			want: `package p
+
+func f() float64 {
+	var y float64 = 1 << 3
+	return y
+}
+`,
+		},
+		{
+			name: "updated",
+			src: `package p
+
+func f(a int) int {
+	x := a
+	x++
+	return x
+}
+`,
+			want: "error: cannot inline x: it is updated or its address is taken",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			fset := token.NewFileSet()
+			f, err := parser.ParseFile(fset, "p.go", test.src, parser.ParseComments)
+			if err != nil {
+				t.Fatal(err)
+			}
+			info := &types.Info{
+				Types:      make(map[ast.Expr]types.TypeAndValue),
+				Defs:       make(map[*ast.Ident]types.Object),
+				Uses:       make(map[*ast.Ident]types.Object),
+				Selections: make(map[*ast.SelectorExpr]*types.Selection),
+				Scopes:     make(map[ast.Node]*types.Scope),
+			}
+			pkg, err := new(types.Config).Check("p", fset, []*ast.File{f}, info)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var id *ast.Ident
+			for def, obj := range info.Defs {
+				if obj != nil && obj.Name() == "x" {
+					id = def
+				}
+			}
+
+			edits, err := inline.Variable(fset, pkg, info, f, []byte(test.src), id)
+			var got string
+			if err != nil {
+				got = "error: " + err.Error()
+			} else {
+				got = applyEdits(fset, test.src, edits)
+			}
+			if got != test.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+func applyEdits(fset *token.FileSet, src string, edits []analysis.TextEdit) string {
+	edits = slices.Clone(edits)
+	slices.SortStableFunc(edits, func(x, y analysis.TextEdit) int {
+		return int(y.Pos - x.Pos) // last first
+	})
+	for _, edit := range edits {
+		start, end := fset.Position(edit.Pos).Offset, fset.Position(edit.End).Offset
+		src = src[:start] + string(edit.NewText) + src[end:]
+	}
+	return src
+}
@@ -12,6 +12,7 @@ package rename
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"go/ast"
@@ -30,8 +31,10 @@ import (
 	"strconv"
 	"strings"
 
+	"golang.org/x/tools/go/buildutil"
 	"golang.org/x/tools/go/loader"
 	"golang.org/x/tools/go/types/typeutil"
+	textdiff "golang.org/x/tools/internal/diff"
 	"golang.org/x/tools/refactor/importgraph"
 	"golang.org/x/tools/refactor/satisfy"
 )
@@ -91,6 +94,10 @@ affected.  For a local renaming, this is just the package specified by
 -from or -offset, but for a potentially exported name, gorename scans
 the workspace ($GOROOT and $GOPATH).
 
+In a multi-module workspace defined by a go.work file, gorename
+scans the packages of all the workspace's modules instead, and
+updates the references in each of them.
+
 gorename rejects renamings of concrete methods that would change the
 assignability relation between types and interfaces. If the interface
 change was intentional, initiate the renaming at the interface method.
@@ -135,7 +142,6 @@ $ gorename -from '"bytes".Buffer.Len' -to Size
 //   all receiver vars of a given type,
 //   all local variables of a given type,
 //   all PkgNames for a given package.
-// - emit JSON output for other editors and tools.
 
 var (
 	// Force enables patching of the source files even if conflicts were reported.
@@ -146,6 +152,13 @@ var (
 	// Diff causes the tool to display diffs instead of rewriting files.
 	Diff bool
 
+	// JSON causes the tool to print the edits to standard output as
+	// a JSON array instead of rewriting files. Each element is an
+	// object with the fields "file", "start", "end", and "new", which
+	// specify the replacement of the bytes [start, end) of the file
+	// by the new text. Diff and JSON are mutually exclusive.
+	JSON bool
+
 	// DiffCmd specifies the diff command used by the -d feature.
 	// (The command must accept a -u flag and two filename arguments.)
 	DiffCmd = "diff"
@@ -156,10 +169,29 @@ var (
 
 	// Verbose enables extra logging.
 	Verbose bool
+
+	// UpdateTags causes the renaming of a struct field to update the
+	// names in its json and yaml struct tags that are derived from
+	// its old name, such as json:"Name" for a field Name.
+	//
+	// Whether or not UpdateTags is set, Main reports each call that
+	// decodes a value of the struct type, such as by json.Unmarshal,
+	// if the renaming changes the name under which an encoding
+	// identifies the field, since data encoded under the old name
+	// would no longer be decoded into it.
+	UpdateTags bool
 )
 
 var stdout io.Writer = os.Stdout
 
+// A jsonEdit is an element of the output of the JSON option.
+type jsonEdit struct {
+	File  string `json:"file"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	New   string `json:"new"`
+}
+
 type renamer struct {
 	iprog              *loader.Program
 	objsToUpdate       map[types.Object]bool
@@ -169,6 +201,7 @@ type renamer struct {
 	packages           map[*types.Package]*loader.PackageInfo // subset of iprog.AllPackages to inspect
 	msets              typeutil.MethodSetCache
 	changeMethods      bool
+	wireRenames        []wireRename // changes to the encoded names of fields
 }
 
 var reportError = func(posn token.Position, message string) {
@@ -228,17 +261,34 @@ func Main(ctxt *build.Context, offsetFlag, fromFlag, to string) error {
 		return fmt.Errorf("-to %q: not a valid identifier", to)
 	}
 
+	var gowork string
+	if ctxt.OpenFile == nil { // not a virtual file system
+		gowork = workFile()
+	}
+
+	if Diff && JSON {
+		return fmt.Errorf("the Diff and JSON options are mutually exclusive")
+	}
 	if Diff {
 		defer func(saved func(string, []byte) error) { writeFile = saved }(writeFile)
 		writeFile = diff
 	}
+	edits := []jsonEdit{}
+	if JSON {
+		defer func(saved func(string, []byte) error) { writeFile = saved }(writeFile)
+		writeFile = func(filename string, content []byte) error {
+			e, err := fileEdits(ctxt, filename, content)
+			edits = append(edits, e...)
+			return err
+		}
+	}
 
 	var spec *spec
 	var err error
 	if fromFlag != "" {
-		spec, err = parseFromFlag(ctxt, fromFlag)
+		spec, err = parseFromFlag(ctxt, gowork, fromFlag)
 	} else {
-		spec, err = parseOffsetFlag(ctxt, offsetFlag)
+		spec, err = parseOffsetFlag(ctxt, gowork, offsetFlag)
 	}
 	if err != nil {
 		return err
@@ -273,7 +323,20 @@ func Main(ctxt *build.Context, offsetFlag, fromFlag, to string) error {
 		}
 
 		// Scan the workspace and build the import graph.
-		_, rev, errors := importgraph.Build(ctxt)
+		// The packages of a go.work workspace are not beneath
+		// $GOPATH, so they are found by the go command instead.
+		var (
+			rev    importgraph.Graph
+			errors map[string]error
+		)
+		if gowork != "" {
+			rev, errors, err = workspaceGraph(gowork)
+			if err != nil {
+				return err
+			}
+		} else {
+			_, rev, errors = importgraph.Build(ctxt)
+		}
 		if len(errors) > 0 {
 			// With a large GOPATH tree, errors are inevitable.
 			// Report them but proceed.
@@ -351,7 +414,17 @@ func Main(ctxt *build.Context, offsetFlag, fromFlag, to string) error {
 	if r.hadConflicts && !Force {
 		return ConflictError
 	}
-	return r.update()
+	if err := r.update(); err != nil {
+		return err
+	}
+	if JSON {
+		data, err := json.MarshalIndent(edits, "", "\t")
+		if err != nil {
+			return err
+		}
+		stdout.Write(append(data, '\n'))
+	}
+	return nil
 }
 
 // loadProgram loads the specified set of packages (plus their tests)
@@ -468,6 +541,9 @@ func (r *renamer) update() error {
 				nidents++
 				id.Name = r.to
 				filesToUpdate[r.iprog.Fset.File(id.Pos())] = true
+				if v, ok := obj.(*types.Var); ok && v.IsField() {
+					r.updateTags(info, id)
+				}
 				// Perform the rename in doc comments too.
 				if doc := r.docComment(id); doc != nil {
 					for _, comment := range doc.List {
@@ -486,6 +562,8 @@ func (r *renamer) update() error {
 		}
 	}
 
+	r.reportUnmarshals()
+
 	// Renaming not supported if cgo files are affected.
 	var generatedFileNames []string
 	for _, info := range r.packages {
@@ -500,8 +578,17 @@ func (r *renamer) update() error {
 		return fmt.Errorf("refusing to modify generated file%s containing DO NOT EDIT marker: %v", plural(len(generatedFileNames)), generatedFileNames)
 	}
 
-	// Write affected files.
-	var nerrs, npkgs int
+	// Format all the affected files before writing any of them,
+	// so that the files of a workspace's modules are either all
+	// updated or, if one cannot be printed, all left unchanged.
+	type output struct {
+		filename string
+		content  []byte
+	}
+	var (
+		outputs      []output
+		nerrs, npkgs int
+	)
 	for _, info := range r.packages {
 		first := true
 		for _, f := range info.Files {
@@ -515,21 +602,28 @@ func (r *renamer) update() error {
 					}
 				}
 
-				filename := tokenFile.Name()
 				var buf bytes.Buffer
 				if err := format.Node(&buf, r.iprog.Fset, f); err != nil {
 					log.Printf("failed to pretty-print syntax tree: %v", err)
 					nerrs++
 					continue
 				}
-				if err := writeFile(filename, buf.Bytes()); err != nil {
-					log.Print(err)
-					nerrs++
-				}
+				outputs = append(outputs, output{tokenFile.Name(), buf.Bytes()})
 			}
 		}
 	}
-	if !Diff {
+	if nerrs > 0 {
+		return fmt.Errorf("failed to format %d file%s; no files were changed", nerrs, plural(nerrs))
+	}
+
+	// Write affected files.
+	for _, out := range outputs {
+		if err := writeFile(out.filename, out.content); err != nil {
+			log.Print(err)
+			nerrs++
+		}
+	}
+	if !Diff && !JSON {
 		fmt.Printf("Renamed %d occurrence%s in %d file%s in %d package%s.\n",
 			nidents, plural(nidents),
 			len(filesToUpdate), plural(len(filesToUpdate)),
@@ -577,13 +671,32 @@ func plural(n int) string {
 	return ""
 }
 
-// writeFile is a seam for testing and for the -d flag.
+// writeFile is a seam for testing and for the Diff and JSON options.
 var writeFile = reallyWriteFile
 
 func reallyWriteFile(filename string, content []byte) error {
 	return os.WriteFile(filename, content, 0644)
 }
 
+// fileEdits returns the edits that transform the file, read through
+// ctxt, into content.
+func fileEdits(ctxt *build.Context, filename string, content []byte) ([]jsonEdit, error) {
+	rc, err := buildutil.OpenFile(ctxt, filename)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	old, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+	var edits []jsonEdit
+	for _, e := range textdiff.Bytes(old, content) {
+		edits = append(edits, jsonEdit{File: filename, Start: e.Start, End: e.End, New: e.New})
+	}
+	return edits, nil
+}
+
 func diff(filename string, content []byte) error {
 	renamed := fmt.Sprintf("%s.%d.renamed", filename, os.Getpid())
 	if err := os.WriteFile(renamed, content, 0644); err != nil {
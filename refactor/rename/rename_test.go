@@ -6,12 +6,14 @@ package rename
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"go/build"
 	"go/token"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"runtime"
 	"strings"
@@ -1440,6 +1442,187 @@ func justHereForTestingDiff() {
 	}
 }
 
+func TestFieldTags(t *testing.T) {
+	defer func(saved func(string, []byte) error) { writeFile = saved }(writeFile)
+	defer func(saved func(token.Position, string)) { reportError = saved }(reportError)
+	defer func(saved bool) { UpdateTags = saved }(UpdateTags)
+
+	ctxt := fakeContext(map[string][]string{
+		"encoding/json": {`package json
+
+func Unmarshal(data []byte, v any) error { return nil }
+`},
+		"main": {`package main
+
+import "encoding/json"
+
+type T struct {
+	Name  string ` + "`json:\"Name,omitempty\" yaml:\"name\"`" + `
+	Other string ` + "`json:\"Other\"`" + `
+}
+
+func main() {
+	var ts []T
+	json.Unmarshal(nil, &ts)
+	_ = T{Name: "x"}
+}
+`},
+	})
+
+	for _, test := range []struct {
+		updateTags bool
+		tag        string
+		reports    []string
+	}{
+		{false, "`json:\"Name,omitempty\" yaml:\"name\"`", nil},
+		{true, "`json:\"Title,omitempty\" yaml:\"title\"`", []string{
+			`encoding/json.Unmarshal decodes field Name, whose json name changes from "Name" to "Title"`,
+		}},
+	} {
+		UpdateTags = test.updateTags
+		var got string
+		writeFile = func(filename string, content []byte) error {
+			got = string(content)
+			return nil
+		}
+		var reports []string
+		reportError = func(posn token.Position, message string) {
+			reports = append(reports, message)
+		}
+		if err := Main(ctxt, "", `"main".T.Name`, "Title"); err != nil {
+			t.Fatal(err)
+		}
+		want := "\tTitle string " + test.tag + "\n"
+		if !strings.Contains(got, want) {
+			t.Errorf("UpdateTags=%t: output does not contain %q:\n%s", test.updateTags, want, got)
+		}
+		if !strings.Contains(got, `T{Title: "x"}`) {
+			t.Errorf("UpdateTags=%t: composite literal not updated:\n%s", test.updateTags, got)
+		}
+		if !reflect.DeepEqual(reports, test.reports) {
+			t.Errorf("UpdateTags=%t: reports = %q, want %q", test.updateTags, reports, test.reports)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	defer func(saved func(string, []byte) error) { writeFile = saved }(writeFile)
+	defer func() {
+		JSON = false
+		stdout = os.Stdout
+	}()
+	JSON = true
+	var buf bytes.Buffer
+	stdout = &buf
+	writeFile = func(filename string, content []byte) error {
+		t.Errorf("unexpected write of %s", filename)
+		return nil
+	}
+
+	const src = `package main
+
+func f() { f() }
+`
+	ctxt := fakeContext(map[string][]string{"main": {src}})
+	if err := Main(ctxt, "", `"main".f`, "gg"); err != nil {
+		t.Fatal(err)
+	}
+	var edits []jsonEdit
+	if err := json.Unmarshal(buf.Bytes(), &edits); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, buf.String())
+	}
+	got := src
+	for i := len(edits) - 1; i >= 0; i-- {
+		e := edits[i]
+		if filepath.ToSlash(e.File) != "/go/src/main/0.go" {
+			t.Errorf("edit of unexpected file %s", e.File)
+		}
+		got = got[:e.Start] + e.New + got[e.End:]
+	}
+	const want = `package main
+
+func gg() { gg() }
+`
+	if got != want {
+		t.Errorf("applying edits %+v gives <<%s>>, want <<%s>>", edits, got, want)
+	}
+}
+
+// TestWorkspace checks that a renaming updates the references in all
+// the modules of a go.work workspace.
+func TestWorkspace(t *testing.T) {
+	testenv.NeedsTool(t, "go")
+	defer func(saved func(string, []byte) error) { writeFile = saved }(writeFile)
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.work":  "go 1.22\n\nuse (\n\t./a\n\t./b\n)\n",
+		"a/go.mod": "module example.com/a\n\ngo 1.22\n",
+		"a/a.go": `package a
+
+func F() int { return 1 }
+`,
+		"a/a_test.go": `package a
+
+func g() int { return F() }
+`,
+		"b/go.mod": "module example.com/b\n\ngo 1.22\n\nrequire example.com/a v0.0.0\n",
+		"b/b.go": `package b
+
+import "example.com/a"
+
+var X = a.F()
+`,
+		"b/b_test.go": `package b_test
+
+import "example.com/a"
+
+var Y = a.F()
+`,
+	}
+	for name, content := range files {
+		name = filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("GOWORK", filepath.Join(dir, "go.work"))
+	t.Chdir(filepath.Join(dir, "a"))
+
+	bgo := filepath.Join(dir, "b", "b.go")
+	for _, test := range []struct {
+		offset, from string
+	}{
+		{from: `"example.com/a".F`},
+		{offset: fmt.Sprintf("%s:#%d", bgo, strings.Index(files["b/b.go"], "F()"))},
+	} {
+		got := make(map[string]string)
+		writeFile = func(filename string, content []byte) error {
+			rel, err := filepath.Rel(dir, filename)
+			if err != nil {
+				return err
+			}
+			got[filepath.ToSlash(rel)] = string(content)
+			return nil
+		}
+		buildCtx := build.Default
+		if err := Main(&buildCtx, test.offset, test.from, "G"); err != nil {
+			t.Errorf("Main(-offset=%q, -from=%q): %v", test.offset, test.from, err)
+			continue
+		}
+		want := make(map[string]string)
+		for _, name := range []string{"a/a.go", "a/a_test.go", "b/b.go", "b/b_test.go"} {
+			want[name] = strings.ReplaceAll(files[name], "F()", "G()")
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Main(-offset=%q, -from=%q) wrote %q, want %q", test.offset, test.from, got, want)
+		}
+	}
+}
+
 // ---------------------------------------------------------------------
 
 // Simplifying wrapper around buildutil.FakeContext for packages whose
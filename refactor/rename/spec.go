@@ -68,7 +68,10 @@ type spec struct {
 
 // parseFromFlag interprets the "-from" flag value as a renaming specification.
 // See Usage in rename.go for valid formats.
-func parseFromFlag(ctxt *build.Context, fromFlag string) (*spec, error) {
+//
+// If gowork is not empty, it names the go.work file of the workspace
+// that contains the files of the query.
+func parseFromFlag(ctxt *build.Context, gowork, fromFlag string) (*spec, error) {
 	var spec spec
 	var main string // sans "::x" suffix
 	switch parts := strings.Split(fromFlag, "::"); len(parts) {
@@ -94,11 +97,11 @@ func parseFromFlag(ctxt *build.Context, fromFlag string) (*spec, error) {
 			return nil, fmt.Errorf("no such file: %s", spec.filename)
 		}
 
-		bp, err := buildutil.ContainingPackage(ctxt, wd, spec.filename)
+		pkg, err := containingPackage(ctxt, gowork, spec.filename)
 		if err != nil {
 			return nil, err
 		}
-		spec.pkg = bp.ImportPath
+		spec.pkg = pkg
 
 	} else {
 		// main is one of:
@@ -204,7 +207,8 @@ func parseImportPath(e ast.Expr) string {
 }
 
 // parseOffsetFlag interprets the "-offset" flag value as a renaming specification.
-func parseOffsetFlag(ctxt *build.Context, offsetFlag string) (*spec, error) {
+// The meaning of gowork is as for parseFromFlag.
+func parseOffsetFlag(ctxt *build.Context, gowork, offsetFlag string) (*spec, error) {
 	var spec spec
 	// Validate -offset, e.g. file.go:#123
 	parts := strings.Split(offsetFlag, ":#")
@@ -217,11 +221,11 @@ func parseOffsetFlag(ctxt *build.Context, offsetFlag string) (*spec, error) {
 		return nil, fmt.Errorf("no such file: %s", spec.filename)
 	}
 
-	bp, err := buildutil.ContainingPackage(ctxt, wd, spec.filename)
+	var err error
+	spec.pkg, err = containingPackage(ctxt, gowork, spec.filename)
 	if err != nil {
 		return nil, err
 	}
-	spec.pkg = bp.ImportPath
 
 	for _, r := range parts[1] {
 		if !isDigit(r) {
@@ -250,6 +254,20 @@ func parseOffsetFlag(ctxt *build.Context, offsetFlag string) (*spec, error) {
 	return &spec, nil
 }
 
+// containingPackage returns the import path of the package that
+// contains the named file, which belongs to the workspace defined by
+// the go.work file, if any, or else to $GOROOT or $GOPATH.
+func containingPackage(ctxt *build.Context, gowork, filename string) (string, error) {
+	if gowork != "" {
+		return workspacePackage(gowork, filename)
+	}
+	bp, err := buildutil.ContainingPackage(ctxt, wd, filename)
+	if err != nil {
+		return "", err
+	}
+	return bp.ImportPath, nil
+}
+
 var wd = func() string {
 	wd, err := os.Getwd()
 	if err != nil {
@@ -0,0 +1,177 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rename
+
+// This file defines the treatment of the struct tags of renamed fields.
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/loader"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// tagKeys holds each struct tag key whose name may be derived from the
+// name of a field, and the function that derives it.
+var tagKeys = []struct {
+	key    string
+	derive func(field string) string
+}{
+	{"json", func(field string) string { return field }},
+	{"yaml", strings.ToLower},
+}
+
+// A wireRename records that the renaming of a field changes the name
+// under which an encoding identifies it.
+type wireRename struct {
+	field    *types.Var
+	st       *types.Struct // the struct type of the field
+	key      string        // the tag key, such as "json"
+	old, new string        // the old and new encoded names
+}
+
+// updateTags updates the struct tag of the field whose declaring
+// identifier is id, if UpdateTags is set, and records the changes to
+// the names under which encodings identify the field.
+func (r *renamer) updateTags(info *loader.PackageInfo, id *ast.Ident) {
+	_, path, _ := r.iprog.PathEnclosingInterval(id.Pos(), id.End())
+	var (
+		field *ast.Field
+		st    *types.Struct
+	)
+	for _, n := range path {
+		switch n := n.(type) {
+		case *ast.Field:
+			if field == nil {
+				field = n
+			}
+		case *ast.StructType:
+			st, _ = info.TypeOf(n).(*types.Struct)
+		}
+		if st != nil {
+			break
+		}
+	}
+	if field == nil || st == nil {
+		return
+	}
+	v := info.Defs[id].(*types.Var)
+
+	var tag string
+	if field.Tag != nil {
+		tag, _ = strconv.Unquote(field.Tag.Value)
+	}
+	newTag := tag
+	for _, k := range tagKeys {
+		key := k.key
+		oldName, newName := k.derive(r.from), k.derive(r.to)
+		name, ok := reflect.StructTag(tag).Lookup(key)
+		if ok {
+			name, _, _ = strings.Cut(name, ",")
+		}
+		switch {
+		case name == "-":
+			continue // not encoded
+
+		case name == "":
+			// The name is implicitly derived from that of the field.
+
+		case name == oldName && UpdateTags:
+			re := regexp.MustCompile(`(^|\s)` + key + `:"` + regexp.QuoteMeta(oldName) + `([,"])`)
+			newTag = re.ReplaceAllString(newTag, `${1}`+key+`:"`+newName+`${2}`)
+
+		default:
+			continue // the name is unchanged
+		}
+		r.wireRenames = append(r.wireRenames, wireRename{v, st, key, oldName, newName})
+	}
+	if newTag != tag {
+		if strings.Contains(newTag, "`") {
+			field.Tag.Value = strconv.Quote(newTag)
+		} else {
+			field.Tag.Value = "`" + newTag + "`"
+		}
+	}
+}
+
+// reportUnmarshals reports the calls that decode values whose types
+// include a struct with a field whose encoded name was changed by the
+// renaming, so that the user can decide whether the change is safe.
+func (r *renamer) reportUnmarshals() {
+	if len(r.wireRenames) == 0 {
+		return
+	}
+	for _, info := range r.packages {
+		for _, f := range info.Files {
+			ast.Inspect(f, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok || len(call.Args) == 0 {
+					return true
+				}
+				fn, ok := typeutil.Callee(&info.Info, call).(*types.Func)
+				if !ok {
+					return true
+				}
+				key := decoderKey(fn)
+				if key == "" {
+					return true
+				}
+				t := info.TypeOf(call.Args[len(call.Args)-1])
+				for _, w := range r.wireRenames {
+					if w.key == key && containsStruct(t, w.st) {
+						reportError(r.iprog.Fset.Position(call.Lparen),
+							fmt.Sprintf("%s decodes field %s, whose %s name changes from %q to %q",
+								fn.FullName(), w.field.Name(), w.key, w.old, w.new))
+					}
+				}
+				return true
+			})
+		}
+	}
+}
+
+// decoderKey returns the struct tag key of the encoding decoded by
+// fn, if it is a function or method such as json.Unmarshal, or "".
+func decoderKey(fn *types.Func) string {
+	if fn.Pkg() == nil || fn.Name() != "Unmarshal" && fn.Name() != "Decode" {
+		return ""
+	}
+	switch path := fn.Pkg().Path(); {
+	case path == "encoding/json", path == "sigs.k8s.io/yaml": // (uses json tags)
+		return "json"
+	case strings.HasPrefix(path, "gopkg.in/yaml."):
+		return "yaml"
+	}
+	return ""
+}
+
+// containsStruct reports whether t, after indirection through
+// pointers, slices, arrays, and map values, has the underlying
+// struct type st.
+func containsStruct(t types.Type, st *types.Struct) bool {
+	for t != nil {
+		switch u := t.Underlying().(type) {
+		case *types.Pointer:
+			t = u.Elem()
+		case *types.Slice:
+			t = u.Elem()
+		case *types.Array:
+			t = u.Elem()
+		case *types.Map:
+			t = u.Elem()
+		case *types.Struct:
+			return types.Identical(u, st)
+		default:
+			return false
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rename
+
+// This file contains the support for multi-module workspaces defined
+// by a go.work file, whose packages are found by the go command
+// rather than by scanning $GOPATH.
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/refactor/importgraph"
+)
+
+// workFile returns the name of the go.work file that defines the
+// workspace of the current directory, or "" if there is none.
+// Like the go command, it consults the GOWORK environment variable,
+// and otherwise looks for go.work in the current directory and its
+// parents.
+func workFile() string {
+	switch gowork := os.Getenv("GOWORK"); gowork {
+	case "off":
+		return ""
+	case "":
+		dir, err := os.Getwd()
+		if err != nil {
+			return ""
+		}
+		for {
+			name := filepath.Join(dir, "go.work")
+			if _, err := os.Stat(name); err == nil {
+				return name
+			}
+			parent := filepath.Dir(dir)
+			if parent == dir {
+				return ""
+			}
+			dir = parent
+		}
+	default:
+		return gowork
+	}
+}
+
+// workspaceGraph returns the reverse import graph of the packages of
+// all the modules of the workspace defined by the go.work file, and a
+// mapping from import paths to errors for packages that could not be
+// loaded. Like [importgraph.Build], it records the imports of the
+// tests of each package as imports of the package itself.
+func workspaceGraph(gowork string) (reverse importgraph.Graph, errors map[string]error, err error) {
+	data, err := os.ReadFile(gowork)
+	if err != nil {
+		return nil, nil, err
+	}
+	work, err := modfile.ParseWork(gowork, data, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	dir := filepath.Dir(gowork)
+	var patterns []string
+	for _, use := range work.Use {
+		moddir := use.Path
+		if !filepath.IsAbs(moddir) {
+			moddir = filepath.Join(dir, moddir)
+		}
+		patterns = append(patterns, filepath.Join(moddir, "...")) // a directory pattern
+	}
+	if len(patterns) == 0 {
+		return nil, nil, fmt.Errorf("workspace %s has no modules", gowork)
+	}
+
+	cfg := &packages.Config{
+		Mode:  packages.NeedName | packages.NeedImports | packages.NeedForTest,
+		Dir:   dir,
+		Tests: true,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, nil, err
+	}
+	reverse = make(importgraph.Graph)
+	errors = make(map[string]error)
+	for _, pkg := range pkgs {
+		if pkg.Name == "main" && strings.HasSuffix(pkg.PkgPath, ".test") {
+			continue // a generated test main package
+		}
+		// A test variant or external test package stands
+		// for the package under test.
+		path := pkg.PkgPath
+		if pkg.ForTest != "" {
+			path = pkg.ForTest
+		}
+		if len(pkg.Errors) > 0 && errors[path] == nil {
+			errors[path] = pkg.Errors[0]
+		}
+		for _, imp := range pkg.Imports {
+			if reverse[imp.PkgPath] == nil {
+				reverse[imp.PkgPath] = make(map[string]bool)
+			}
+			reverse[imp.PkgPath][path] = true
+		}
+	}
+	return reverse, errors, nil
+}
+
+// workspacePackage returns the import path of the package of the
+// workspace defined by the go.work file that contains the named file.
+func workspacePackage(gowork, filename string) (string, error) {
+	filename, err := filepath.Abs(filename)
+	if err != nil {
+		return "", err
+	}
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles, // files are needed to match the query
+		Dir:  filepath.Dir(gowork),
+	}
+	pkgs, err := packages.Load(cfg, "file="+filename)
+	if err != nil {
+		return "", err
+	}
+	if len(pkgs) == 0 || pkgs[0].PkgPath == "" {
+		return "", fmt.Errorf("can't find package containing %s", filename)
+	}
+	return pkgs[0].PkgPath, nil
+}
@@ -8,9 +8,15 @@
 // interface, and this fact is necessary for the package to be
 // well-typed.
 //
+// Clients such as refactoring tools, which must preserve these
+// constraints, and API-evolution tools may query the constraints that
+// involve a given type or method, and the positions of the syntax
+// that imposes them, using [Finder.ConstraintsOf] and
+// [Finder.Positions].
+//
 // THIS PACKAGE IS EXPERIMENTAL AND MAY CHANGE AT ANY TIME.
 //
-// It is provided only for the gopls tool. It requires well-typed inputs.
+// It requires well-typed inputs.
 package satisfy // import "golang.org/x/tools/refactor/satisfy"
 
 // NOTES:
@@ -42,6 +48,7 @@ import (
 	"go/ast"
 	"go/token"
 	"go/types"
+	"sort"
 
 	"golang.org/x/tools/go/types/typeutil"
 	"golang.org/x/tools/internal/typeparams"
@@ -65,13 +72,23 @@ type Constraint struct {
 // that is checked during compilation of a package.  Refactoring tools
 // will need to preserve at least this part of the relation to ensure
 // continued compilation.
+//
+// Each call to [Finder.Find] adds the constraints of one package, so a
+// Finder may accumulate the constraints of a set of packages, which
+// may then be queried by [Finder.ConstraintsOf].
 type Finder struct {
 	Result    map[Constraint]bool
 	msetcache typeutil.MethodSetCache
 
+	// Positions records, for each constraint in Result, the
+	// positions of the expressions, statements, and declarations
+	// that impose it, in the order in which they were found.
+	Positions map[Constraint][]token.Pos
+
 	// per-Find state
 	info *types.Info
 	sig  *types.Signature
+	pos  token.Pos // position of the current node
 }
 
 // Find inspects a single package, populating Result with its pairs of
@@ -90,6 +107,9 @@ func (f *Finder) Find(info *types.Info, files []*ast.File) {
 	if f.Result == nil {
 		f.Result = make(map[Constraint]bool)
 	}
+	if f.Positions == nil {
+		f.Positions = make(map[Constraint][]token.Pos)
+	}
 
 	f.info = info
 	for _, file := range files {
@@ -243,6 +263,8 @@ func (f *Finder) extract(tuple types.Type, i int) types.Type {
 }
 
 func (f *Finder) valueSpec(spec *ast.ValueSpec) {
+	defer f.enter(spec)()
+
 	var T types.Type
 	if spec.Type != nil {
 		T = f.info.Types[spec.Type].Type
@@ -289,7 +311,17 @@ func (f *Finder) assign(lhs, rhs types.Type) {
 		return
 	}
 	// record the pair
-	f.Result[Constraint{lhs, rhs}] = true
+	c := Constraint{lhs, rhs}
+	f.Result[c] = true
+	f.Positions[c] = append(f.Positions[c], f.pos)
+}
+
+// enter makes n the current node, and returns a function that
+// restores the previous one.
+func (f *Finder) enter(n ast.Node) func() {
+	saved := f.pos
+	f.pos = n.Pos()
+	return func() { f.pos = saved }
 }
 
 // typeAssert must be called for each type assertion x.(T) where x has
@@ -330,6 +362,7 @@ func (f *Finder) expr(e ast.Expr) types.Type {
 	if tv.Value != nil {
 		return tv.Type // prune the descent for constants
 	}
+	defer f.enter(e)()
 
 	// tv.Type may be nil for an ast.Ident.
 
@@ -500,6 +533,8 @@ func (f *Finder) expr(e ast.Expr) types.Type {
 }
 
 func (f *Finder) stmt(s ast.Stmt) {
+	defer f.enter(s)()
+
 	switch s := s.(type) {
 	case *ast.BadStmt,
 		*ast.EmptyStmt,
@@ -710,6 +745,62 @@ func (f *Finder) stmt(s ast.Stmt) {
 	}
 }
 
+// ConstraintsOf returns the constraints in Result that involve obj,
+// ordered by the first of their Positions.
+//
+// If obj is a type name, a constraint involves it if either of its
+// types is the type, or a pointer to it; for a generic type, this
+// includes its instantiations. If obj is a method, concrete or
+// abstract, a constraint involves it if the method set of either type
+// includes it, and those of both types include a method of the same
+// name, so that renaming the method would break the constraint.
+// Constraints involve no other kinds of object.
+func (f *Finder) ConstraintsOf(obj types.Object) []Constraint {
+	var involves func(c Constraint) bool
+	switch obj := obj.(type) {
+	case *types.TypeName:
+		is := func(t types.Type) bool {
+			if ptr, ok := types.Unalias(t).(*types.Pointer); ok {
+				t = ptr.Elem()
+			}
+			named, ok := types.Unalias(t).(*types.Named)
+			return ok && named.Origin().Obj() == obj
+		}
+		involves = func(c Constraint) bool { return is(c.LHS) || is(c.RHS) }
+
+	case *types.Func:
+		if obj.Signature().Recv() == nil {
+			return nil // not a method
+		}
+		// lookup returns the method of t with the name of obj, if any.
+		lookup := func(t types.Type) *types.Func {
+			sel := f.msetcache.MethodSet(t).Lookup(obj.Pkg(), obj.Name())
+			if sel == nil {
+				return nil
+			}
+			return sel.Obj().(*types.Func).Origin()
+		}
+		involves = func(c Constraint) bool {
+			l, r := lookup(c.LHS), lookup(c.RHS)
+			return l != nil && r != nil && (l == obj.Origin() || r == obj.Origin())
+		}
+
+	default:
+		return nil
+	}
+
+	var res []Constraint
+	for c := range f.Result {
+		if involves(c) {
+			res = append(res, c)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return f.Positions[res[i]][0] < f.Positions[res[j]][0]
+	})
+	return res
+}
+
 // -- Plundered from golang.org/x/tools/go/ssa -----------------
 
 func instance(info *types.Info, expr ast.Expr) bool {
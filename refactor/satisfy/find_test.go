@@ -13,6 +13,7 @@ import (
 	"go/types"
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
 
 	"golang.org/x/tools/internal/testenv"
@@ -261,3 +262,83 @@ func constraints(t *testing.T, src string) []string {
 	sort.Strings(constraints)
 	return constraints
 }
+
+func TestConstraintsOf(t *testing.T) {
+	const src = `package p
+
+type I interface{ f() }
+type J interface{ f(); g() }
+
+type A int
+func (A) f() {}
+func (A) g() {}
+
+type B int
+func (*B) f() {}
+
+var (
+	_ I = A(0)
+	_ J = A(0)
+	_ I = new(B)
+)
+
+func _(j J) I {
+	if j == A(1) {
+		return nil
+	}
+	return j
+}
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	pkg, err := new(types.Config).Check("p", fset, []*ast.File{f}, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var finder satisfy.Finder
+	finder.Find(info, []*ast.File{f})
+
+	lookup := func(name string) types.Object {
+		obj := pkg.Scope().Lookup(name)
+		if obj == nil {
+			typ, method, _ := strings.Cut(name, ".")
+			T := pkg.Scope().Lookup(typ).Type()
+			obj, _, _ = types.LookupFieldOrMethod(T, true, pkg, method)
+		}
+		return obj
+	}
+	for _, test := range []struct {
+		obj  string
+		want []string
+	}{
+		{"A", []string{"p.I <- p.A at 14:2", "p.J <- p.A at 15:2, 20:5"}},
+		{"B", []string{"p.I <- *p.B at 16:2"}},
+		{"I", []string{"p.I <- p.A at 14:2", "p.I <- *p.B at 16:2", "p.I <- p.J at 23:2"}},
+		{"A.f", []string{"p.I <- p.A at 14:2", "p.J <- p.A at 15:2, 20:5"}},
+		{"A.g", []string{"p.J <- p.A at 15:2, 20:5"}},
+		{"I.f", []string{"p.I <- p.A at 14:2", "p.I <- *p.B at 16:2", "p.I <- p.J at 23:2"}},
+		{"J.g", []string{"p.J <- p.A at 15:2, 20:5"}},
+	} {
+		var got []string
+		for _, c := range finder.ConstraintsOf(lookup(test.obj)) {
+			var posns []string
+			for _, pos := range finder.Positions[c] {
+				posn := fset.Position(pos)
+				posns = append(posns, fmt.Sprintf("%d:%d", posn.Line, posn.Column))
+			}
+			got = append(got, fmt.Sprintf("%v <- %v at %s", c.LHS, c.RHS, strings.Join(posns, ", ")))
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("ConstraintsOf(%s) = %q, want %q", test.obj, got, test.want)
+		}
+	}
+}
@@ -22,6 +22,11 @@ import (
 // If the file system detects that it has been modified, calls to the
 // file system return an ErrModified error.
 func FS(a *Archive) (fs.FS, error) {
+	return newFilesystem(a)
+}
+
+// newFilesystem returns the filesystem of an Archive.
+func newFilesystem(a *Archive) (*filesystem, error) {
 	// Create a filesystem with a root directory.
 	root := &node{fileinfo: fileinfo{path: ".", mode: readOnlyDir}}
 	fsys := &filesystem{a, map[string]*node{root.path: root}}
@@ -32,6 +37,117 @@ func FS(a *Archive) (fs.FS, error) {
 	return fsys, nil
 }
 
+// A WriteFS is a file system form of an Archive, like that returned
+// by FS, that also permits files to be created, modified, and
+// removed. Each such change is applied to the underlying archive, so
+// that the archive may be encoded by Format (or a Writer) once the
+// changes are complete, for example to update a golden file.
+//
+// The archive must not be modified other than through the WriteFS
+// while it is in use. A WriteFS is not safe for concurrent use.
+type WriteFS struct {
+	fsys *filesystem
+}
+
+var _ fs.ReadFileFS = (*WriteFS)(nil)
+
+// NewWriteFS returns a writable file system form of an Archive.
+// It returns an error if any of the file names in the archive
+// are not valid file system names.
+func NewWriteFS(a *Archive) (*WriteFS, error) {
+	fsys, err := newFilesystem(a)
+	if err != nil {
+		return nil, err
+	}
+	return &WriteFS{fsys}, nil
+}
+
+// Open opens the named file or directory for reading.
+func (w *WriteFS) Open(name string) (fs.File, error) { return w.fsys.Open(name) }
+
+// ReadFile returns the content of the named file.
+func (w *WriteFS) ReadFile(name string) ([]byte, error) { return w.fsys.ReadFile(name) }
+
+// WriteFile sets the content of the named file to a copy of data,
+// replacing the content of the archive file of that name, if any,
+// or else adding a new file at the end of the archive.
+// The parent directories of a new file are created as needed.
+//
+// It is an error if name is not a valid file system name
+// (see [fs.ValidPath]), or if it or one of its parent
+// directories is an existing file or directory, respectively.
+func (w *WriteFS) WriteFile(name string, data []byte) error {
+	fsys := w.fsys
+	if !fs.ValidPath(name) || name == "." {
+		return &fs.PathError{Op: "write", Path: name, Err: fs.ErrInvalid}
+	}
+
+	if n := fsys.nodes[name]; n != nil {
+		if n.IsDir() {
+			return &fs.PathError{Op: "write", Path: name, Err: fs.ErrInvalid}
+		}
+		if _, err := dataOf(fsys, n); err != nil {
+			return err
+		}
+		fsys.ar.Files[n.idx].Data = slices.Clone(data)
+		n.size = len(data)
+		return nil
+	}
+
+	// Check that no parent is a file before inserting,
+	// so that a failed insertion leaves fsys unchanged.
+	for dir := path.Dir(name); dir != "."; dir = path.Dir(dir) {
+		if n := fsys.nodes[dir]; n != nil && !n.IsDir() {
+			return &fs.PathError{Op: "write", Path: name, Err: fs.ErrExist}
+		}
+	}
+	n := &node{idx: len(fsys.ar.Files), fileinfo: fileinfo{path: name, size: len(data), mode: readOnly}}
+	if err := insert(fsys, n); err != nil {
+		return err // can't happen
+	}
+	fsys.ar.Files = append(fsys.ar.Files, File{Name: name, Data: slices.Clone(data)})
+	return nil
+}
+
+// Remove removes the named file or empty directory. Removing a file
+// removes it from the archive; the directories of the file system
+// are implied by the names of the archive files, so removing the last
+// file of a directory leaves an empty directory that is not
+// represented in the archive.
+func (w *WriteFS) Remove(name string) error {
+	fsys := w.fsys
+	if !fs.ValidPath(name) || name == "." {
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrInvalid}
+	}
+
+	n := fsys.nodes[name]
+	switch {
+	case n == nil:
+		return &fs.PathError{Op: "remove", Path: name, Err: fs.ErrNotExist}
+	case n.IsDir():
+		if len(n.entries) > 0 {
+			return &fs.PathError{Op: "remove", Path: name, Err: errors.New("directory not empty")}
+		}
+	default:
+		if _, err := dataOf(fsys, n); err != nil {
+			return err
+		}
+		fsys.ar.Files = slices.Delete(fsys.ar.Files, n.idx, n.idx+1)
+		for _, m := range fsys.nodes {
+			if !m.IsDir() && m.idx > n.idx {
+				m.idx--
+			}
+		}
+	}
+
+	delete(fsys.nodes, name)
+	parent := fsys.nodes[path.Dir(name)]
+	i := slices.Index(parent.entries, fs.DirEntry(n))
+	// Don't modify entries in place: it may be in use by an openDir.
+	parent.entries = slices.Concat(parent.entries[:i], parent.entries[i+1:])
+	return nil
+}
+
 const (
 	readOnly    fs.FileMode = 0o444 // read only mode
 	readOnlyDir             = readOnly | fs.ModeDir
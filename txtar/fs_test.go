@@ -181,3 +181,68 @@ one
 		t.Errorf("ReadFile(%q) = %q; want %q", "1/one.txt", got, want)
 	}
 }
+
+func TestWriteFS(t *testing.T) {
+	const input = `comment
+-- a/one.txt --
+one
+-- a/b/two.txt --
+two
+-- three.txt --
+three
+`
+	a := txtar.Parse([]byte(input))
+	fsys, err := txtar.NewWriteFS(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Modify, add, and remove files.
+	if err := fsys.WriteFile("a/one.txt", []byte("ONE\n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := fsys.WriteFile("c/d/four.txt", []byte("four")); err != nil {
+		t.Fatal(err)
+	}
+	if err := fsys.Remove("a/b/two.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if err := fsys.Remove("a/b"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Invalid changes.
+	for _, name := range []string{".", "/x", "a", "three.txt/x"} {
+		if err := fsys.WriteFile(name, nil); err == nil {
+			t.Errorf("WriteFile(%q) succeeded, want error", name)
+		}
+	}
+	for _, name := range []string{".", "a", "missing.txt"} {
+		if err := fsys.Remove(name); err == nil {
+			t.Errorf("Remove(%q) succeeded, want error", name)
+		}
+	}
+
+	if err := fstest.TestFS(fsys, "a/one.txt", "c/d/four.txt", "three.txt"); err != nil {
+		t.Fatal(err)
+	}
+	content, err := fsys.ReadFile("three.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(content), "three\n"; got != want {
+		t.Errorf("ReadFile(%q) = %q; want %q", "three.txt", got, want)
+	}
+
+	const want = `comment
+-- a/one.txt --
+ONE
+-- three.txt --
+three
+-- c/d/four.txt --
+four
+`
+	if got := string(txtar.Format(a)); got != want {
+		t.Errorf("Format after changes = %q; want %q", got, want)
+	}
+}
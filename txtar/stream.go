@@ -0,0 +1,199 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package txtar
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A Reader provides sequential access to the contents of an archive,
+// without holding the entire archive in memory, for example to
+// process a large golden file. It interprets its input just as
+// [Parse] does.
+//
+// The Reader initially reads the archive comment. The Next method
+// advances to the next file entry of the archive, and the Read method
+// then reads the data of that file.
+type Reader struct {
+	r         *bufio.Reader
+	lineStart bool   // the next byte of r begins a line
+	pending   []byte // data read from r but not yet returned by Read
+	done      bool   // the current entry has been completely read
+	next      string // name of the next file, or "" at end of archive
+	err       error  // sticky error from r
+}
+
+// NewReader returns a Reader that reads the archive from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: bufio.NewReader(r), lineStart: true}
+}
+
+// Next advances to the next file of the archive, skipping any unread
+// data of the current entry, and returns its name. It returns io.EOF
+// at the end of the archive.
+func (r *Reader) Next() (string, error) {
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		return "", err
+	}
+	if r.next == "" {
+		return "", io.EOF
+	}
+	name := r.next
+	r.next, r.done = "", false
+	return name, nil
+}
+
+// Read reads from the current entry of the archive: before the first
+// call to Next, the archive comment; thereafter, the data of the file
+// most recently returned by Next. It returns 0, io.EOF at the end of
+// the entry. As with [Parse], the final line of the archive is
+// terminated by a newline even if the input lacks one.
+func (r *Reader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.fill(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// fill reads the next piece of the current entry into r.pending, or
+// marks the entry done at a file marker line or the end of the input.
+func (r *Reader) fill() error {
+	if r.err != nil {
+		return r.err
+	}
+
+	if r.lineStart {
+		// Only a line beginning with a marker can be a file
+		// marker line, so read such lines whole.
+		prefix, err := r.r.Peek(len(marker))
+		if err != nil && err != io.EOF {
+			r.err = err
+			return err
+		}
+		if bytes.Equal(prefix, marker) {
+			line, err := r.r.ReadBytes('\n')
+			if err != nil && err != io.EOF {
+				r.err = err
+				return err
+			}
+			if name, _ := isMarker(line); name != "" {
+				r.next, r.done = name, true
+				return nil
+			}
+			r.pending = fixNL(line)
+			return nil
+		}
+	}
+
+	// Read the rest of the line, or as much of it as fits in the buffer.
+	// Slices returned by ReadSlice are valid only until the next
+	// read from r.r, which follows the consumption of r.pending.
+	chunk, err := r.r.ReadSlice('\n')
+	switch err {
+	case nil, bufio.ErrBufferFull:
+	case io.EOF:
+		if len(chunk) == 0 {
+			// End of archive: terminate the last line if necessary.
+			if !r.lineStart {
+				r.pending = []byte{'\n'}
+				r.lineStart = true
+			}
+			r.done = true
+			return nil
+		}
+	default:
+		r.err = err
+		return err
+	}
+	r.pending = chunk
+	r.lineStart = chunk[len(chunk)-1] == '\n'
+	return nil
+}
+
+// A Writer writes an archive to an io.Writer one entry at a time, in
+// the form produced by [Format], without holding the entire archive
+// in memory.
+//
+// Data written before the first call to Create forms the archive
+// comment. Create begins a new file entry, and subsequent writes
+// form its data. As with Format, the caller must ensure that the
+// data contain no file marker lines. Close terminates the final entry.
+type Writer struct {
+	w       io.Writer
+	midLine bool  // the current entry ends with an unterminated line
+	err     error // sticky error
+}
+
+// NewWriter returns a Writer that writes an archive to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w}
+}
+
+// Write writes data to the current entry of the archive.
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	n, err := w.w.Write(p)
+	if n > 0 {
+		w.midLine = p[n-1] != '\n'
+	}
+	if err != nil {
+		w.err = err
+	}
+	return n, err
+}
+
+// Create terminates the current entry and begins a new file entry
+// with the specified name, whose data is written by subsequent calls
+// to Write.
+func (w *Writer) Create(name string) error {
+	if name == "" || strings.ContainsAny(name, "\r\n") || strings.TrimSpace(name) != name {
+		return fmt.Errorf("txtar: invalid file name %q", name)
+	}
+	if err := w.endEntry(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "-- %s --\n", name)
+	return err
+}
+
+// Close terminates the final entry of the archive, adding a newline
+// if its data does not end with one. It does not close the
+// underlying writer. Subsequent calls to Write or Create fail.
+func (w *Writer) Close() error {
+	if err := w.endEntry(); err != nil {
+		return err
+	}
+	w.err = errClosed
+	return nil
+}
+
+var errClosed = errors.New("txtar: write to closed Writer")
+
+// endEntry terminates the current entry with a newline, if needed.
+func (w *Writer) endEntry() error {
+	if w.err != nil {
+		return w.err
+	}
+	if w.midLine {
+		if _, err := w.Write([]byte{'\n'}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
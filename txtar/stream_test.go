@@ -0,0 +1,151 @@
+// Copyright 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package txtar_test
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"golang.org/x/tools/txtar"
+)
+
+var streamTests = []string{
+	"",
+	"comment",
+	"comment\n",
+	"-- a --",
+	"-- a --\nA",
+	"-- a --\nA\n-- b --\n",
+	"c1\nc2\n-- file1 --\nFile 1 text.\n-- foo ---\nMore file 1 text.\n-- file 2 --\nFile 2 text.\n-- empty --\n-- noNL --\nhello world\n-- empty filename line --\nsome content\n-- --",
+	"--\n-- -\n--  --\n-- x --\n-- y\n--",
+	"-- long --\n" + strings.Repeat("x", 10000) + "\n-- " + strings.Repeat("y", 10000) + "\n-- z --\n" + strings.Repeat("z", 10000),
+}
+
+func TestReader(t *testing.T) {
+	for _, input := range streamTests {
+		want := txtar.Parse([]byte(input))
+		// Read one byte at a time to exercise the buffering.
+		r := txtar.NewReader(iotest.OneByteReader(strings.NewReader(input)))
+		got, err := readArchive(r)
+		if err != nil {
+			t.Errorf("reading %q: %v", input, err)
+			continue
+		}
+		if !equalArchives(got, want) {
+			t.Errorf("Reader(%q) = %q, want %q (as Parse)", input, got, want)
+		}
+	}
+}
+
+// readArchive reads the entire archive from r.
+func readArchive(r *txtar.Reader) (*txtar.Archive, error) {
+	var a txtar.Archive
+	comment, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	a.Comment = comment
+	for {
+		name, err := r.Next()
+		if err == io.EOF {
+			return &a, nil
+		} else if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		a.Files = append(a.Files, txtar.File{Name: name, Data: data})
+	}
+}
+
+func TestReaderSkip(t *testing.T) {
+	const input = "comment\n-- a --\nA\n-- b --\nB\n-- c --\nC\n"
+	r := txtar.NewReader(strings.NewReader(input))
+	var names []string
+	for {
+		name, err := r.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, name)
+		if name == "b" {
+			data, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != "B\n" {
+				t.Errorf("data of b = %q, want %q", data, "B\n")
+			}
+		}
+	}
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Next returned %q, want %q", names, want)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	for _, input := range streamTests {
+		a := txtar.Parse([]byte(input))
+		// Strip final newlines, which the Writer must restore.
+		for i := range a.Files {
+			a.Files[i].Data = bytes.TrimSuffix(a.Files[i].Data, []byte("\n"))
+		}
+		want := txtar.Format(a)
+
+		var buf bytes.Buffer
+		w := txtar.NewWriter(&buf)
+		if _, err := w.Write(a.Comment); err != nil {
+			t.Fatal(err)
+		}
+		for _, f := range a.Files {
+			if err := w.Create(f.Name); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := w.Write(f.Data); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); got != string(want) {
+			t.Errorf("Writer wrote %q, want %q (as Format)", got, want)
+		}
+		if _, err := w.Write([]byte("x")); err == nil {
+			t.Errorf("Write after Close succeeded")
+		}
+	}
+}
+
+func TestWriterInvalidName(t *testing.T) {
+	w := txtar.NewWriter(io.Discard)
+	for _, name := range []string{"", "a\nb", " a"} {
+		if err := w.Create(name); err == nil {
+			t.Errorf("Create(%q) succeeded, want error", name)
+		}
+	}
+}
+
+// equalArchives reports whether x and y have the same content,
+// ignoring the distinction between nil and empty data.
+func equalArchives(x, y *txtar.Archive) bool {
+	if !bytes.Equal(x.Comment, y.Comment) || len(x.Files) != len(y.Files) {
+		return false
+	}
+	for i := range x.Files {
+		if x.Files[i].Name != y.Files[i].Name || !bytes.Equal(x.Files[i].Data, y.Files[i].Data) {
+			return false
+		}
+	}
+	return true
+}